@@ -7,17 +7,21 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"runtime"
 	"runtime/debug"
 	"slices"
 	"strings"
 	"sync"
 
+	"github.com/EvilBit-Labs/opnDossier/internal/analysis"
 	"github.com/EvilBit-Labs/opnDossier/internal/audit"
 	"github.com/EvilBit-Labs/opnDossier/internal/cfgparser"
 	"github.com/EvilBit-Labs/opnDossier/internal/config"
 	"github.com/EvilBit-Labs/opnDossier/internal/constants"
 	"github.com/EvilBit-Labs/opnDossier/internal/logging"
+	"github.com/EvilBit-Labs/opnDossier/internal/progress"
+	"github.com/EvilBit-Labs/opnDossier/internal/runstats"
 	"github.com/EvilBit-Labs/opnDossier/pkg/parser"
 	"github.com/spf13/cobra"
 )
@@ -29,6 +33,15 @@ var (
 	auditPluginDir    string   //nolint:gochecknoglobals // Cobra flag variable — dynamic plugin directory
 	auditFailuresOnly bool     //nolint:gochecknoglobals // Cobra flag variable — show only failing controls
 	auditBlackhat     bool     //nolint:gochecknoglobals // Cobra flag variable — red-mode sharper-tone ExploitNotes
+	auditNoDedupe     bool     //nolint:gochecknoglobals // Cobra flag variable — keep overlapping findings separate
+	auditDescrPattern string   //nolint:gochecknoglobals // Cobra flag variable — required rule description regex
+	auditMinDescrLen  int      //nolint:gochecknoglobals // Cobra flag variable — shortest acceptable rule description
+	auditLogCoverage  int      //nolint:gochecknoglobals // Cobra flag variable — expected WAN pass rule logging percentage
+	auditMinScore     float64  //nolint:gochecknoglobals // Cobra flag variable — lowest passing benchmark score
+	auditStatsOut     string   //nolint:gochecknoglobals // Cobra flag variable — NDJSON file to append usage statistics to
+	auditStrictChecks bool     //nolint:gochecknoglobals // Cobra flag variable — fail when a compliance check errored
+
+	auditEffectiveRules []string //nolint:gochecknoglobals // Cobra flag variable — interfaces to print the effective ruleset of
 )
 
 // init registers the audit command with the root command and configures its command-line flags.
@@ -37,7 +50,7 @@ func init() {
 
 	// Audit-specific flags (shorter names since this is the dedicated audit command)
 	auditCmd.Flags().
-		StringVar(&auditMode, "mode", auditModeBlue, "Audit mode (blue|red)")
+		StringVar(&auditMode, "mode", auditModeBlue, "Audit mode (blue|red|executive)")
 	setFlagAnnotation(auditCmd.Flags(), "mode", []flagCategory{categoryAudit})
 
 	auditCmd.Flags().
@@ -56,9 +69,37 @@ func init() {
 		BoolVar(&auditBlackhat, "audit-blackhat", false, "Sharpen the tone of red mode ExploitNotes (red mode only; impact/context only, no attack instructions)")
 	setFlagAnnotation(auditCmd.Flags(), "audit-blackhat", []flagCategory{categoryAudit})
 
+	auditCmd.Flags().
+		BoolVar(&auditNoDedupe, "no-dedupe", false, "Keep findings that several checks raise against the same config element separate (blue mode only)")
+	setFlagAnnotation(auditCmd.Flags(), "no-dedupe", []flagCategory{categoryAudit})
+
+	auditCmd.Flags().
+		StringVar(&auditDescrPattern, "require-descr-pattern", "", "Regular expression every enabled firewall and NAT rule description must match, e.g. '(CHG|TKT)-\\d+' (blue mode only)")
+	setFlagAnnotation(auditCmd.Flags(), "require-descr-pattern", []flagCategory{categoryAudit})
+
+	auditCmd.Flags().
+		IntVar(&auditMinDescrLen, "min-descr-length", analysis.DefaultMinDescriptionLength, "Shortest acceptable firewall and NAT rule description in characters (blue mode only)")
+	setFlagAnnotation(auditCmd.Flags(), "min-descr-length", []flagCategory{categoryAudit})
+
+	auditCmd.Flags().
+		IntVar(&auditLogCoverage, "log-coverage-threshold", analysis.DefaultLogCoverageThreshold, "Percentage of pass rules on a WAN interface expected to have logging enabled (blue mode only)")
+	setFlagAnnotation(auditCmd.Flags(), "log-coverage-threshold", []flagCategory{categoryAudit})
+
+	auditCmd.Flags().
+		Float64Var(&auditMinScore, "min-score", 0, "Fail with a non-zero exit code when the benchmark score is below this value from 0 to 1, e.g. 0.8 (blue mode only)")
+	setFlagAnnotation(auditCmd.Flags(), "min-score", []flagCategory{categoryAudit})
+
+	auditCmd.Flags().
+		BoolVar(&auditStrictChecks, "strict-checks", false, "Fail with a non-zero exit code when a compliance check errored instead of reaching a result (blue mode only)")
+	setFlagAnnotation(auditCmd.Flags(), "strict-checks", []flagCategory{categoryAudit})
+
+	auditCmd.Flags().
+		StringSliceVar(&auditEffectiveRules, "effective-rules", []string{}, "Print the effective firewall ruleset of these interfaces in evaluation order instead of auditing, e.g. wan,lan")
+	setFlagAnnotation(auditCmd.Flags(), "effective-rules", []flagCategory{categoryAudit})
+
 	// Output and format flags (reuse existing package-level variables)
 	auditCmd.Flags().
-		StringVarP(&format, flagFormat, "f", defaultFormat, "Output format for audit report (markdown, json, yaml, text, html)")
+		StringVarP(&format, flagFormat, "f", defaultFormat, "Output format for audit report (markdown, json, yaml, text, html, pdf)")
 	setFlagAnnotation(auditCmd.Flags(), flagFormat, []flagCategory{categoryOutput})
 
 	auditCmd.Flags().
@@ -69,12 +110,19 @@ func init() {
 		BoolVar(&force, "force", false, "Force overwrite existing files without prompting for confirmation")
 	setFlagAnnotation(auditCmd.Flags(), "force", []flagCategory{categoryOutput})
 
+	auditCmd.Flags().
+		StringVar(&auditStatsOut, "stats-out", "", "Append one line of usage statistics per audited file to this NDJSON file (written locally, never sent anywhere)")
+	setFlagAnnotation(auditCmd.Flags(), "stats-out", []flagCategory{categoryOutput})
+
 	// Add shared styling and content flags
 	addSharedContentFlags(auditCmd)
 
 	// Add shared redact flag
 	addSharedRedactFlag(auditCmd)
 
+	// Add port risk table flag for the port forward exposure check
+	addPortRiskFlag(auditCmd)
+
 	// Register flag completion functions for better tab completion
 	registerAuditFlagCompletions(auditCmd)
 
@@ -99,6 +147,71 @@ func registerAuditFlagCompletions(cmd *cobra.Command) {
 	if err := cmd.RegisterFlagCompletionFunc("section", ValidSections); err != nil {
 		logger.Debug("failed to register section completion", "error", err)
 	}
+	if err := cmd.RegisterFlagCompletionFunc("section-order", ValidSections); err != nil {
+		logger.Debug("failed to register section-order completion", "error", err)
+	}
+}
+
+// validateDescriptionPolicyFlags rejects --require-descr-pattern and
+// --min-descr-length outside blue mode, a pattern that does not compile, and
+// a minimum length below one.
+func validateDescriptionPolicyFlags(cmd *cobra.Command) error {
+	customized := cmd.Flags().Changed("require-descr-pattern") || cmd.Flags().Changed("min-descr-length")
+	if customized && !strings.EqualFold(auditMode, auditModeBlue) {
+		return fmt.Errorf(
+			"--require-descr-pattern and --min-descr-length are only supported with --mode blue; %q mode does not check rule descriptions",
+			auditMode,
+		)
+	}
+
+	if auditDescrPattern != "" {
+		if _, err := regexp.Compile(auditDescrPattern); err != nil {
+			return fmt.Errorf("invalid --require-descr-pattern %q: %w", auditDescrPattern, err)
+		}
+	}
+
+	if auditMinDescrLen < 1 {
+		return fmt.Errorf("--min-descr-length must be at least 1, got %d", auditMinDescrLen)
+	}
+
+	return nil
+}
+
+// maxLogCoverageThreshold is the largest accepted --log-coverage-threshold.
+const maxLogCoverageThreshold = 100
+
+// validateLogCoverageFlag rejects --log-coverage-threshold outside blue mode
+// and values outside 1-100.
+func validateLogCoverageFlag(cmd *cobra.Command) error {
+	if cmd.Flags().Changed("log-coverage-threshold") && !strings.EqualFold(auditMode, auditModeBlue) {
+		return fmt.Errorf(
+			"--log-coverage-threshold is only supported with --mode blue; %q mode does not check rule logging",
+			auditMode,
+		)
+	}
+
+	if auditLogCoverage < 1 || auditLogCoverage > maxLogCoverageThreshold {
+		return fmt.Errorf("--log-coverage-threshold must be between 1 and 100, got %d", auditLogCoverage)
+	}
+
+	return nil
+}
+
+// validateMinScoreFlag rejects --min-score outside blue mode and values
+// outside 0-1.
+func validateMinScoreFlag(cmd *cobra.Command) error {
+	if cmd.Flags().Changed("min-score") && !strings.EqualFold(auditMode, auditModeBlue) {
+		return fmt.Errorf(
+			"--min-score is only supported with --mode blue; %q mode does not compute a benchmark score",
+			auditMode,
+		)
+	}
+
+	if auditMinScore < 0 || auditMinScore > 1 {
+		return fmt.Errorf("--min-score must be between 0 and 1, got %g", auditMinScore)
+	}
+
+	return nil
 }
 
 // auditCmd is the cobra.Command for the audit subcommand.
@@ -122,7 +235,7 @@ var auditCmd = &cobra.Command{
 		normalizeConvertFlags()
 
 		// Validate audit mode
-		validModes := []string{auditModeBlue, auditModeRed}
+		validModes := []string{auditModeBlue, auditModeRed, auditModeExecutive}
 		if !slices.Contains(validModes, strings.ToLower(auditMode)) {
 			return fmt.Errorf("invalid audit mode %q, must be one of: %s",
 				auditMode, strings.Join(validModes, ", "))
@@ -163,6 +276,45 @@ var auditCmd = &cobra.Command{
 			)
 		}
 
+		// Reject --no-dedupe outside blue mode — only blue hygiene findings are
+		// merged; red mode already de-dupes observations against its findings.
+		if auditNoDedupe && !strings.EqualFold(auditMode, auditModeBlue) {
+			return fmt.Errorf(
+				"--no-dedupe is only supported with --mode blue; %q mode does not merge findings",
+				auditMode,
+			)
+		}
+
+		// Validate the rule description policy. Only blue mode checks rule
+		// descriptions, so a custom policy elsewhere is a user error.
+		if err := validateDescriptionPolicyFlags(cmd); err != nil {
+			return err
+		}
+
+		// Validate the logging coverage threshold, which blue mode alone uses.
+		if err := validateLogCoverageFlag(cmd); err != nil {
+			return err
+		}
+
+		// Validate the benchmark score gate, which blue mode alone computes.
+		if err := validateMinScoreFlag(cmd); err != nil {
+			return err
+		}
+
+		// Reject --strict-checks outside blue mode — only blue mode runs
+		// compliance checks.
+		if auditStrictChecks && !strings.EqualFold(auditMode, auditModeBlue) {
+			return fmt.Errorf(
+				"--strict-checks is only supported with --mode blue; %q mode does not run compliance checks",
+				auditMode,
+			)
+		}
+
+		// The effective ruleset view is markdown and replaces the audit report.
+		if err := validateEffectiveRulesFlag(); err != nil {
+			return err
+		}
+
 		// Reject --failures-only with non-markdown formats — the flag only affects
 		// the markdown plugin controls table. JSON/YAML consumers should filter
 		// client-side to avoid information loss.
@@ -197,9 +349,11 @@ mode and compliance plugins.
 AUDIT MODES:
   Select the audit perspective using the --mode flag:
 
-    blue  - Defensive audit with security findings and recommendations (default)
-    red   - Attacker-focused recon report highlighting attack surfaces,
-            weak NAT rules, admin portals, and enumeration data
+    blue      - Defensive audit with security findings and recommendations (default)
+    red       - Attacker-focused recon report highlighting attack surfaces,
+                weak NAT rules, admin portals, and enumeration data
+    executive - One-page summary: system identity, key metrics, the top five
+                findings, and a Red/Amber/Green posture rating
 
 COMPLIANCE PLUGINS (blue mode only):
   Select compliance checks with --plugins (requires --mode blue):
@@ -208,7 +362,28 @@ COMPLIANCE PLUGINS (blue mode only):
     sans      - SANS Firewall Baseline
     firewall  - Firewall Configuration Analysis
 
-  Omit --plugins to run every available plugin. The flag is rejected with red mode.
+  Omit --plugins to run every available plugin. The flag is rejected with red and executive modes.
+
+BENCHMARK SCORE (blue mode only):
+  Blue mode scores the compliance controls per section (network, access
+  control, logging, services, crypto) and overall from 0 to 1, graded A-F.
+  Controls that do not apply to the device are left out of the score. Use
+  --min-score as a CI gate: the report is still written, but the command
+  exits non-zero when the overall score is below the minimum.
+
+CHECKS EXECUTED (blue mode only):
+  The report closes with an appendix listing every compliance check as
+  passed, findings, skipped (with the reason, e.g. no OpenVPN servers
+  configured), or errored (with the error or panic message). A check that
+  errors or panics does not stop the audit. Use --strict-checks to exit
+  non-zero when any check errored; the report is still written.
+
+EFFECTIVE RULES:
+  Use --effective-rules to print, instead of the audit report, the rules pf
+  evaluates for each listed interface: floating rules first, then rules on
+  interface groups containing the interface, then the interface's own rules.
+  Each rule shows its origin (floating, group:NAME, or direct). The
+  comprehensive report's interface cross-reference lists the same order.
 
 CONTROL FILTERING (blue mode only):
   Use --failures-only to hide PASS rows in plugin result tables. Applies only to
@@ -222,6 +397,9 @@ OUTPUT FORMATS:
     yaml      - YAML format for configuration management
     text      - Plain text output (markdown without formatting)
     html      - Self-contained HTML report for web viewing
+    pdf       - PDF document of the comprehensive report with the audit
+                appended (blue and red modes; requires --output or
+                redirected stdout)
 
 MULTI-FILE RUNS:
   Pass multiple input files to audit them concurrently. --output is rejected in
@@ -240,6 +418,9 @@ RELATED:
   # Red team attack surface analysis
   opnDossier audit config.xml --mode red
 
+  # One-page executive summary with a Red/Amber/Green posture rating
+  opnDossier audit config.xml --mode executive
+
   # Export audit report as JSON
   opnDossier audit config.xml --format json -o audit-report.json
 
@@ -249,6 +430,15 @@ RELATED:
   # Comprehensive blue team audit with all compliance checks
   opnDossier audit config.xml --mode blue --comprehensive --plugins stig,sans,firewall
 
+  # Fail a CI pipeline when the benchmark score is below 0.8
+  opnDossier audit config.xml --min-score 0.8 -o audit-report.md
+
+  # Fail a CI pipeline when any compliance check errored
+  opnDossier audit config.xml --strict-checks -o audit-report.md
+
+  # Show every rule that applies to WAN traffic, in evaluation order
+  opnDossier audit config.xml --effective-rules wan
+
   # Show only failing controls in blue mode markdown output
   opnDossier audit config.xml --mode blue --failures-only
 
@@ -297,6 +487,16 @@ func runAudit(cmd *cobra.Command, args []string) error {
 	timeoutCtx, cancel := context.WithTimeout(ctx, constants.DefaultProcessingTimeout)
 	defer cancel()
 
+	// Progress is only rendered for interactive single-file runs; see newProgressTracker.
+	tracker := newProgressTracker(cmdConfig, len(args))
+	timeoutCtx = progress.NewContext(timeoutCtx, tracker)
+
+	// Every worker appends its statistics line to the same file.
+	var stats *runstats.Appender
+	if auditStatsOut != "" {
+		stats = runstats.NewAppender(auditStatsOut)
+	}
+
 	// Use a semaphore to limit concurrent file operations
 	maxConcurrent := max(runtime.NumCPU(), 1)
 	sem := make(chan struct{}, maxConcurrent)
@@ -314,12 +514,20 @@ func runAudit(cmd *cobra.Command, args []string) error {
 		go func(idx int, fp string) {
 			defer wg.Done()
 
-			results[idx] = processAuditFile(timeoutCtx, fp, sem, cmdLogger, cmdConfig)
+			results[idx] = processAuditFile(timeoutCtx, fp, sem, stats, cmdLogger, cmdConfig)
 		}(i, filePath)
 	}
 
 	wg.Wait()
 
+	// Release the progress line before any report reaches stdout. Progress is
+	// only ever active for a single input, so results[0] is the whole run.
+	if len(results) == 1 && results[0].err == nil {
+		tracker.Done("Audit report generated")
+	} else {
+		tracker.Done("")
+	}
+
 	// Serialize emission: write results in input order after all processing completes.
 	// This prevents interleaved stdout writes and file clobbering.
 	var allErrors []error
@@ -334,6 +542,10 @@ func runAudit(cmd *cobra.Command, args []string) error {
 		if err := emitAuditResult(ctx, cmd, r.result, cmdLogger, cmdConfig, multiFile); err != nil {
 			allErrors = append(allErrors, err)
 		}
+
+		if r.gateErr != nil {
+			allErrors = append(allErrors, r.gateErr)
+		}
 	}
 
 	return errors.Join(allErrors...)
@@ -345,6 +557,10 @@ func runAudit(cmd *cobra.Command, args []string) error {
 type auditResultOrError struct {
 	result auditResult
 	err    error
+	// gateErr records a report that was generated but scored below
+	// --min-score or has errored checks under --strict-checks. The report is
+	// still emitted; gateErr then fails the run.
+	gateErr error
 }
 
 // processAuditFile runs the audit pipeline for a single input file under the
@@ -362,6 +578,7 @@ func processAuditFile(
 	ctx context.Context,
 	fp string,
 	sem chan struct{},
+	stats *runstats.Appender,
 	cmdLogger *logging.Logger,
 	cmdConfig *config.Config,
 ) (res auditResultOrError) {
@@ -393,20 +610,23 @@ func processAuditFile(
 		return auditResultOrError{err: ctx.Err()}
 	}
 
-	output, err := generateAuditOutput(ctx, fp, cmdLogger, cmdConfig)
-	if err != nil {
+	output, err := generateAuditOutput(ctx, fp, stats, cmdLogger, cmdConfig)
+	if err != nil && !isAuditGateError(err) {
 		return auditResultOrError{err: err}
 	}
 
-	return auditResultOrError{result: auditResult{inputFile: fp, output: output}}
+	return auditResultOrError{result: auditResult{inputFile: fp, output: output}, gateErr: err}
 }
 
 // generateAuditOutput handles parsing and audit generation for a single configuration
 // file, returning the rendered report string. It does NOT perform any I/O emission
-// (stdout or file writes) so that it is safe to call concurrently.
+// (stdout or file writes) so that it is safe to call concurrently. The one
+// exception is the --stats-out line, which a non-nil stats appends atomically
+// once the report is generated.
 func generateAuditOutput(
 	ctx context.Context,
 	fp string,
+	stats *runstats.Appender,
 	cmdLogger *logging.Logger,
 	cmdConfig *config.Config,
 ) (string, error) {
@@ -439,7 +659,7 @@ func generateAuditOutput(
 	ctxLogger.Debug("Parsing configuration file")
 
 	device, warnings, parseErr := parser.NewFactory(cfgparser.NewXMLParser()).
-		CreateDevice(ctx, file, resolveDeviceType(), false)
+		CreateDevice(ctx, trackParseInput(file, progress.FromContext(ctx)), resolveDeviceType(), false)
 	if parseErr != nil {
 		ctxLogger.Error("Failed to parse configuration", "error", parseErr)
 
@@ -453,7 +673,7 @@ func generateAuditOutput(
 			ctxLogger.Error("Configuration validation failed")
 		}
 
-		return "", fmt.Errorf("failed to parse configuration from %s: %w", fp, parseErr)
+		return "", explainParseError(fmt.Errorf("failed to parse configuration from %s: %w", fp, parseErr))
 	}
 
 	ctxLogger.Debug("Configuration parsed successfully")
@@ -463,21 +683,44 @@ func generateAuditOutput(
 			ctxLogger.Warn("conversion warning",
 				"field", w.Field,
 				"message", w.Message,
+				"value", w.Value,
+				"action", w.Action,
 				"severity", w.Severity,
 			)
 		}
 	}
 
+	if err := checkConfigCompatibility(device, ctxLogger, cmdConfig != nil && cmdConfig.IsQuiet()); err != nil {
+		return "", fmt.Errorf("failed to parse configuration from %s: %w", fp, err)
+	}
+
 	// Build conversion options with precedence: CLI flags > env vars > config > defaults
 	eff := buildEffectiveFormat(format, cmdConfig)
 	opt := buildConversionOptions(eff, cmdConfig)
 
+	// --effective-rules replaces the audit report with the ruleset view.
+	if len(auditEffectiveRules) > 0 {
+		output, err := renderEffectiveRules(device, auditEffectiveRules, opt)
+		if err != nil {
+			return "", fmt.Errorf("failed to render effective rules for %s: %w", fp, err)
+		}
+
+		return output, nil
+	}
+
 	// Build audit options from audit-specific flag variables (not shared globals)
 	auditOpts := audit.Options{
 		AuditMode:       auditMode,
 		SelectedPlugins: auditPlugins,
 		FailuresOnly:    auditFailuresOnly,
 		Blackhat:        auditBlackhat,
+		DisableDedupe:   auditNoDedupe,
+
+		DescriptionPattern:   auditDescrPattern,
+		MinDescriptionLength: auditMinDescrLen,
+		LogCoverageThreshold: auditLogCoverage,
+		MinScore:             auditMinScore,
+		StrictChecks:         auditStrictChecks,
 	}
 
 	if auditPluginDir != "" {
@@ -492,12 +735,30 @@ func generateAuditOutput(
 		"failuresOnly", auditOpts.FailuresOnly,
 	)
 
-	output, err := handleAuditMode(ctx, device, auditOpts, opt, ctxLogger)
-	if err != nil {
+	outcome, err := runAuditMode(ctx, device, auditOpts, opt, ctxLogger)
+	if err != nil && !isAuditGateError(err) {
 		ctxLogger.Error("Failed to generate audit report", "error", err)
 
 		return "", fmt.Errorf("failed to generate audit report for %s: %w", fp, err)
 	}
 
-	return output, nil
+	if stats != nil {
+		rec := runstats.NewRecord(fp, device)
+		rec.Findings = outcome.findings
+		rec.SetScore(outcome.score)
+
+		if serr := stats.Append(rec); serr != nil {
+			ctxLogger.Error("Failed to write usage statistics", "stats_file", stats.Path(), "error", serr)
+
+			return "", fmt.Errorf("failed to write usage statistics for %s: %w", fp, serr)
+		}
+	}
+
+	if err != nil {
+		ctxLogger.Warn("Audit report failed --min-score or --strict-checks", "error", err)
+
+		return outcome.output, fmt.Errorf("audit of %s failed: %w", fp, err)
+	}
+
+	return outcome.output, nil
 }
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/converter"
+	"github.com/EvilBit-Labs/opnDossier/internal/converter/builder"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+)
+
+// ErrUnknownInterface is returned when --effective-rules names an interface
+// the configuration does not define.
+var ErrUnknownInterface = errors.New("unknown interface")
+
+// validateEffectiveRulesFlag rejects --effective-rules with output formats
+// other than markdown and with --stats-out, since the view replaces the audit
+// report and produces no statistics.
+func validateEffectiveRulesFlag() error {
+	if len(auditEffectiveRules) == 0 {
+		return nil
+	}
+
+	if !strings.EqualFold(format, "markdown") {
+		return fmt.Errorf(
+			"--effective-rules is only supported with --format markdown; got %q",
+			format,
+		)
+	}
+
+	if auditStatsOut != "" {
+		return errors.New("--effective-rules cannot be combined with --stats-out; it does not run the audit")
+	}
+
+	return nil
+}
+
+// renderEffectiveRules renders the effective ruleset of each interface in
+// ifaces, in the order given. Interface names are matched case-insensitively
+// against the configured interfaces; an unknown name is an error listing the
+// configured ones.
+func renderEffectiveRules(device *common.CommonDevice, ifaces []string, opt converter.Options) (string, error) {
+	configured := make([]string, 0, len(device.Interfaces))
+	for _, iface := range device.Interfaces {
+		configured = append(configured, iface.Name)
+	}
+
+	reportBuilder := builder.NewMarkdownBuilder()
+	reportBuilder.SetPortNames(!opt.NoPortNames)
+
+	sections := make([]string, 0, len(ifaces))
+	for _, name := range ifaces {
+		idx := slices.IndexFunc(configured, func(c string) bool { return strings.EqualFold(c, name) })
+		if idx < 0 {
+			return "", fmt.Errorf("%w %q, configured interfaces: %s",
+				ErrUnknownInterface, name, strings.Join(configured, ", "))
+		}
+
+		sections = append(sections, reportBuilder.BuildEffectiveRulesSection(device, configured[idx]))
+	}
+
+	return strings.Join(sections, "\n"), nil
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"maps"
+	"regexp"
 	"slices"
 	"strings"
 
@@ -14,13 +15,30 @@ import (
 	"github.com/EvilBit-Labs/opnDossier/internal/compliance"
 	"github.com/EvilBit-Labs/opnDossier/internal/converter"
 	"github.com/EvilBit-Labs/opnDossier/internal/logging"
+	"github.com/EvilBit-Labs/opnDossier/internal/processor"
+	"github.com/EvilBit-Labs/opnDossier/internal/progress"
+	"github.com/EvilBit-Labs/opnDossier/internal/runstats"
 	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
 )
 
+// auditOutcome is the rendered report of one audit together with the
+// figures --stats-out records for it.
+type auditOutcome struct {
+	output   string
+	findings runstats.SeverityCounts
+	// score is the benchmark score; nil outside blue mode or when no plugin
+	// ran.
+	score *common.BenchmarkScore
+}
+
 // handleAuditMode generates a report with audit findings.
 // It runs compliance checks, maps results onto a shallow copy of the device's
 // ComplianceResults field, and delegates report generation to
-// generateWithProgrammaticGenerator. The input device is not mutated.
+// generateWithProgrammaticGenerator. The input device is not mutated. When
+// auditOpts.MinScore is set and the benchmark score is below it, the rendered
+// report is returned together with an error wrapping
+// audit.ErrScoreBelowMinimum; likewise, when auditOpts.StrictChecks is set
+// and a compliance check errored, with one wrapping audit.ErrChecksErrored.
 func handleAuditMode(
 	ctx context.Context,
 	device *common.CommonDevice,
@@ -28,18 +46,47 @@ func handleAuditMode(
 	opt converter.Options,
 	logger *logging.Logger,
 ) (string, error) {
+	outcome, err := runAuditMode(ctx, device, auditOpts, opt, logger)
+
+	return outcome.output, err
+}
+
+// runAuditMode is handleAuditMode, additionally returning the finding counts
+// and benchmark score of the report.
+func runAuditMode(
+	ctx context.Context,
+	device *common.CommonDevice,
+	auditOpts audit.Options,
+	opt converter.Options,
+	logger *logging.Logger,
+) (auditOutcome, error) {
+	if strings.EqualFold(auditOpts.AuditMode, auditModeExecutive) {
+		return handleExecutiveMode(ctx, device, opt, logger)
+	}
+
 	// Parse audit mode
 	mode, err := audit.ParseReportMode(auditOpts.AuditMode)
 	if err != nil {
-		return "", fmt.Errorf("invalid audit mode: %w", err)
+		return auditOutcome{}, fmt.Errorf("invalid audit mode: %w", err)
+	}
+
+	descriptionPolicy := analysis.DescriptionPolicy{MinLength: auditOpts.MinDescriptionLength}
+	if auditOpts.DescriptionPattern != "" {
+		descriptionPolicy.Pattern, err = regexp.Compile(auditOpts.DescriptionPattern)
+		if err != nil {
+			return auditOutcome{}, fmt.Errorf("invalid description pattern: %w", err)
+		}
 	}
 
 	// Create mode config
 	modeConfig := &audit.ModeConfig{
-		Mode:            mode,
-		Comprehensive:   opt.Comprehensive,
-		SelectedPlugins: auditOpts.SelectedPlugins,
-		Blackhat:        auditOpts.Blackhat,
+		Mode:                 mode,
+		Comprehensive:        opt.Comprehensive,
+		SelectedPlugins:      auditOpts.SelectedPlugins,
+		Blackhat:             auditOpts.Blackhat,
+		DisableDedupe:        auditOpts.DisableDedupe,
+		DescriptionPolicy:    descriptionPolicy,
+		LogCoverageThreshold: float64(auditOpts.LogCoverageThreshold),
 	}
 
 	pm := audit.NewPluginManager(logger, nil)
@@ -51,7 +98,7 @@ func handleAuditMode(
 	}
 
 	if err := pm.InitializePlugins(ctx); err != nil {
-		return "", fmt.Errorf("initialize plugins: %w", err)
+		return auditOutcome{}, fmt.Errorf("initialize plugins: %w", err)
 	}
 
 	// Surface any dynamic plugin load failures to the CLI user, including
@@ -78,7 +125,7 @@ func handleAuditMode(
 			failedNames[i] = f.Name
 		}
 
-		return "", fmt.Errorf(
+		return auditOutcome{}, fmt.Errorf(
 			"%w (note: %d dynamic plugin(s) failed to load: %s)",
 			err,
 			loadResult.Failed(),
@@ -87,7 +134,7 @@ func handleAuditMode(
 	}
 
 	if err != nil {
-		return "", fmt.Errorf("generate audit report: %w", err)
+		return auditOutcome{}, fmt.Errorf("generate audit report: %w", err)
 	}
 
 	// Create a shallow copy so the caller's device is not mutated.
@@ -98,7 +145,98 @@ func handleAuditMode(
 	opt.FailuresOnly = auditOpts.FailuresOnly
 
 	// Delegate to the shared generator pipeline (handles markdown, JSON, YAML, etc.)
-	return generateWithProgrammaticGenerator(ctx, &enrichedDevice, opt, logger)
+	output, err := generateWithProgrammaticGenerator(ctx, &enrichedDevice, opt, logger)
+	if err != nil {
+		return auditOutcome{}, err
+	}
+
+	outcome := auditOutcome{output: output, score: auditReport.BenchmarkScore}
+	if summary := enrichedDevice.ComplianceResults.Summary; summary != nil {
+		outcome.findings = runstats.SeverityCounts{
+			Critical: summary.CriticalFindings,
+			High:     summary.HighFindings,
+			Medium:   summary.MediumFindings,
+			Low:      summary.LowFindings,
+			Info:     summary.InfoFindings,
+		}
+	}
+
+	// The report is returned even when it misses --min-score or has errored
+	// checks under --strict-checks so CI runs keep it as an artifact; the
+	// caller emits it before failing.
+	gateErr := audit.CheckMinimumScore(auditReport.BenchmarkScore, auditOpts.MinScore)
+	if auditOpts.StrictChecks {
+		gateErr = errors.Join(gateErr, audit.CheckErroredChecks(auditReport))
+	}
+
+	return outcome, gateErr
+}
+
+// isAuditGateError reports whether err only fails a generated report's
+// quality gates (--min-score, --strict-checks), in which case the report is
+// still emitted.
+func isAuditGateError(err error) bool {
+	return errors.Is(err, audit.ErrScoreBelowMinimum) || errors.Is(err, audit.ErrChecksErrored)
+}
+
+// handleExecutiveMode generates the one-page executive summary. It runs every
+// processor analysis so the posture rating reflects all findings, reporting
+// each pass to the context's progress tracker, then renders the report with
+// Report.ToExecutiveMarkdown. JSON and YAML carry the full
+// processor report, including its rating and the thresholds that produced it.
+func handleExecutiveMode(
+	ctx context.Context,
+	device *common.CommonDevice,
+	opt converter.Options,
+	logger *logging.Logger,
+) (auditOutcome, error) {
+	p, err := processor.NewCoreProcessor(logger)
+	if err != nil {
+		return auditOutcome{}, fmt.Errorf("create processor: %w", err)
+	}
+
+	report, err := p.Process(ctx, device,
+		processor.WithAllFeatures(),
+		processor.WithProgress(progress.FromContext(ctx)),
+	)
+	if err != nil {
+		return auditOutcome{}, fmt.Errorf("process configuration: %w", err)
+	}
+
+	output, err := renderExecutiveReport(report, opt)
+	if err != nil {
+		return auditOutcome{}, err
+	}
+
+	return auditOutcome{
+		output: output,
+		findings: runstats.SeverityCounts{
+			Critical: len(report.Findings.Critical),
+			High:     len(report.Findings.High),
+			Medium:   len(report.Findings.Medium),
+			Low:      len(report.Findings.Low),
+			Info:     len(report.Findings.Info),
+		},
+	}, nil
+}
+
+// renderExecutiveReport renders the processor report in the requested format.
+func renderExecutiveReport(report *processor.Report, opt converter.Options) (string, error) {
+	canonical, _ := converter.DefaultRegistry.Canonical(strings.ToLower(string(opt.Format)))
+	switch converter.Format(canonical) {
+	case converter.FormatJSON:
+		return report.ToJSON()
+	case converter.FormatYAML:
+		return report.ToYAML()
+	case converter.FormatText:
+		return converter.StripMarkdownFormatting(report.ToExecutiveMarkdown())
+	case converter.FormatHTML:
+		return converter.RenderMarkdownToHTML(report.ToExecutiveMarkdown())
+	case converter.FormatPDF:
+		return "", fmt.Errorf("%w: %q is not available for the executive summary", ErrUnsupportedOutputFormat, opt.Format)
+	default:
+		return report.ToExecutiveMarkdown(), nil
+	}
 }
 
 // mapAuditReportToComplianceResults converts an audit.Report into a common.ComplianceResults
@@ -170,6 +308,32 @@ func mapAuditReportToComplianceResults(report *audit.Report) *common.ComplianceR
 		NonCompliant:     totalNonCompliant,
 	}
 
+	if report.DescriptionQuality != nil {
+		quality := *report.DescriptionQuality
+		result.Summary.DescriptionQuality = &quality
+	}
+
+	if report.LoggingCoverage != nil {
+		coverage := *report.LoggingCoverage
+		coverage.Interfaces = slices.Clone(coverage.Interfaces)
+		result.Summary.LoggingCoverage = &coverage
+	}
+
+	if report.ConfigFreshness != nil {
+		freshness := *report.ConfigFreshness
+		result.Summary.ConfigFreshness = &freshness
+	}
+
+	if report.BenchmarkScore != nil {
+		score := *report.BenchmarkScore
+		score.Sections = slices.Clone(score.Sections)
+		result.Summary.BenchmarkScore = &score
+	}
+
+	if report.Configuration != nil && report.Configuration.PF != nil {
+		result.Summary.StateTableMax = report.Configuration.PF.MaxStates
+	}
+
 	return result
 }
 
@@ -268,9 +432,32 @@ func mapPluginComplianceResult(pluginName string, cr *audit.ComplianceResult) co
 		pluginResult.Compliance = maps.Clone(controlCompliance)
 	}
 
+	pluginResult.Checks = mapCheckExecutions(cr.Checks[pluginName])
+
 	return pluginResult
 }
 
+// mapCheckExecutions converts audit.CheckExecution slices to
+// common.ComplianceCheck slices.
+func mapCheckExecutions(checks []audit.CheckExecution) []common.ComplianceCheck {
+	if len(checks) == 0 {
+		return nil
+	}
+
+	mapped := make([]common.ComplianceCheck, len(checks))
+	for i, c := range checks {
+		mapped[i] = common.ComplianceCheck{
+			ID:       c.ID,
+			Name:     c.Name,
+			Status:   string(c.Status),
+			Findings: c.Findings,
+			Reason:   c.Reason,
+		}
+	}
+
+	return mapped
+}
+
 // mapComplianceFindings converts compliance.Finding (analysis.Finding) slices
 // to common.ComplianceFinding slices.
 func mapComplianceFindings(findings []compliance.Finding) []common.ComplianceFinding {
@@ -10,6 +10,7 @@ import (
 	"github.com/EvilBit-Labs/opnDossier/internal/compliance"
 	"github.com/EvilBit-Labs/opnDossier/internal/converter"
 	"github.com/EvilBit-Labs/opnDossier/internal/logging"
+	"github.com/EvilBit-Labs/opnDossier/internal/plugins/firewall"
 	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
@@ -22,13 +23,14 @@ func TestValidAuditModes(t *testing.T) {
 	// See GOTCHAS §1.1.
 	completions, directive := ValidAuditModes(nil, nil, "")
 
-	assert.Len(t, completions, 2)
+	assert.Len(t, completions, 3)
 	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
 
 	// Check that all modes are present
 	completionStr := strings.Join(completions, " ")
 	assert.Contains(t, completionStr, "blue")
 	assert.Contains(t, completionStr, "red")
+	assert.Contains(t, completionStr, "executive")
 }
 
 func TestValidAuditPlugins(t *testing.T) {
@@ -89,6 +91,114 @@ func TestMapAuditReportToComplianceResults(t *testing.T) {
 				assert.Equal(t, 0, result.Summary.TotalFindings)
 			},
 		},
+		{
+			name: "description quality is copied into the summary",
+			report: &audit.Report{
+				Mode:       audit.ModeBlue,
+				Findings:   []audit.Finding{},
+				Compliance: make(map[string]audit.ComplianceResult),
+				Metadata:   make(map[string]any),
+				DescriptionQuality: &common.DescriptionQuality{
+					TotalRules:       4,
+					CompliantRules:   3,
+					CompliantPercent: 75,
+				},
+			},
+			verify: func(t *testing.T, result *common.ComplianceResults) {
+				t.Helper()
+				require.NotNil(t, result.Summary)
+				require.NotNil(t, result.Summary.DescriptionQuality)
+				assert.Equal(t, common.DescriptionQuality{
+					TotalRules:       4,
+					CompliantRules:   3,
+					CompliantPercent: 75,
+				}, *result.Summary.DescriptionQuality)
+			},
+		},
+		{
+			name: "configured state table maximum is copied into the summary",
+			report: &audit.Report{
+				Mode:          audit.ModeBlue,
+				Configuration: &common.CommonDevice{PF: &common.PFSettings{MaxStates: 500000}},
+				Findings:      []audit.Finding{},
+				Compliance:    make(map[string]audit.ComplianceResult),
+				Metadata:      make(map[string]any),
+			},
+			verify: func(t *testing.T, result *common.ComplianceResults) {
+				t.Helper()
+				require.NotNil(t, result.Summary)
+				assert.Equal(t, 500000, result.Summary.StateTableMax)
+			},
+		},
+		{
+			name: "logging coverage is copied into the summary",
+			report: &audit.Report{
+				Mode:       audit.ModeBlue,
+				Findings:   []audit.Finding{},
+				Compliance: make(map[string]audit.ComplianceResult),
+				Metadata:   make(map[string]any),
+				LoggingCoverage: &common.LoggingCoverage{
+					Interfaces: []common.LoggingCoverageEntry{
+						{Interface: "wan", PassRules: 4, LoggedPassRules: 1, PassLoggedPercent: 25},
+					},
+					Total: common.LoggingCoverageEntry{PassRules: 4, LoggedPassRules: 1, PassLoggedPercent: 25},
+				},
+			},
+			verify: func(t *testing.T, result *common.ComplianceResults) {
+				t.Helper()
+				require.NotNil(t, result.Summary)
+				require.NotNil(t, result.Summary.LoggingCoverage)
+				require.Len(t, result.Summary.LoggingCoverage.Interfaces, 1)
+				assert.Equal(t, "wan", result.Summary.LoggingCoverage.Interfaces[0].Interface)
+				assert.InDelta(t, 25.0, result.Summary.LoggingCoverage.Total.PassLoggedPercent, 0.001)
+			},
+		},
+		{
+			name: "config freshness is copied into the summary",
+			report: &audit.Report{
+				Mode:       audit.ModeBlue,
+				Findings:   []audit.Finding{},
+				Compliance: make(map[string]audit.ComplianceResult),
+				Metadata:   make(map[string]any),
+				ConfigFreshness: &common.ConfigFreshness{
+					ReferenceTime: "2026-01-01T00:00:00Z",
+					Rules:         common.FreshnessBuckets{Recent: 2, Stale: 1},
+					Users:         common.FreshnessBuckets{Unknown: 3},
+				},
+			},
+			verify: func(t *testing.T, result *common.ComplianceResults) {
+				t.Helper()
+				require.NotNil(t, result.Summary)
+				require.NotNil(t, result.Summary.ConfigFreshness)
+				assert.Equal(t, common.FreshnessBuckets{Recent: 2, Stale: 1}, result.Summary.ConfigFreshness.Rules)
+				assert.Equal(t, 3, result.Summary.ConfigFreshness.Users.Unknown)
+			},
+		},
+		{
+			name: "benchmark score is copied into the summary",
+			report: &audit.Report{
+				Mode:       audit.ModeBlue,
+				Findings:   []audit.Finding{},
+				Compliance: make(map[string]audit.ComplianceResult),
+				Metadata:   make(map[string]any),
+				BenchmarkScore: &common.BenchmarkScore{
+					Score:            0.75,
+					Grade:            "C",
+					ApplicableChecks: 4,
+					Sections: []common.SectionScore{
+						{Section: "network", Score: 0.75, Grade: "C", PassedChecks: 3, ApplicableChecks: 4},
+					},
+				},
+			},
+			verify: func(t *testing.T, result *common.ComplianceResults) {
+				t.Helper()
+				require.NotNil(t, result.Summary)
+				require.NotNil(t, result.Summary.BenchmarkScore)
+				assert.Equal(t, "C", result.Summary.BenchmarkScore.Grade)
+				require.Len(t, result.Summary.BenchmarkScore.Sections, 1)
+				assert.Equal(t, 3, result.Summary.BenchmarkScore.Sections[0].PassedChecks)
+			},
+		},
 		{
 			name: "report with findings maps correctly",
 			report: &audit.Report{
@@ -793,6 +903,130 @@ func TestHandleAuditMode_StructuredFormats(t *testing.T) {
 	}
 }
 
+// TestHandleAuditMode_Executive verifies that executive mode renders the
+// one-page summary in markdown, carries the posture rating in JSON, and
+// rejects PDF output.
+func TestHandleAuditMode_Executive(t *testing.T) {
+	// Do NOT use t.Parallel() — cmd package uses package-level flag globals.
+	// See GOTCHAS §1.1.
+	logger, err := logging.New(logging.Config{Level: "warn"})
+	require.NoError(t, err)
+
+	device := &common.CommonDevice{
+		System: common.System{
+			Hostname: "test-fw",
+			Domain:   "example.com",
+			WebGUI:   common.WebGUI{Protocol: "http"},
+		},
+	}
+	auditOpts := audit.Options{AuditMode: "executive"}
+
+	result, err := handleAuditMode(context.Background(), device, auditOpts,
+		converter.Options{Format: converter.FormatMarkdown}, logger)
+	require.NoError(t, err)
+	assert.Contains(t, result, "# Executive Security Summary")
+	assert.Contains(t, result, "**Hostname**: test-fw.example.com")
+	assert.Contains(t, result, "**Red**")
+	assert.Contains(t, result, "**Critical** Insecure Web GUI Protocol")
+	assert.NotContains(t, result, "## Compliance Audit Results")
+
+	result, err = handleAuditMode(context.Background(), device, auditOpts,
+		converter.Options{Format: converter.FormatJSON}, logger)
+	require.NoError(t, err)
+
+	var parsed map[string]any
+	require.NoError(t, json.Unmarshal([]byte(result), &parsed))
+	assert.Equal(t, "Red", parsed["rating"])
+	assert.Contains(t, parsed["ratingReason"], "1 critical finding")
+
+	_, err = handleAuditMode(context.Background(), device, auditOpts,
+		converter.Options{Format: converter.FormatPDF}, logger)
+	require.ErrorIs(t, err, ErrUnsupportedOutputFormat)
+}
+
+// TestHandleAuditMode_MinScore verifies that a blue report scoring below
+// --min-score is still returned, together with ErrScoreBelowMinimum, and that
+// the benchmark score reaches the JSON export.
+func TestHandleAuditMode_MinScore(t *testing.T) {
+	// Do NOT use t.Parallel() — cmd package uses package-level flag globals.
+	// See GOTCHAS §1.1.
+	logger, err := logging.New(logging.Config{Level: "warn"})
+	require.NoError(t, err)
+
+	device := &common.CommonDevice{
+		System: common.System{Hostname: "test-fw", Domain: "example.com"},
+	}
+	opt := converter.Options{Format: converter.FormatJSON}
+
+	result, err := handleAuditMode(context.Background(), device,
+		audit.Options{AuditMode: "blue", SelectedPlugins: []string{"firewall"}}, opt, logger)
+	require.NoError(t, err)
+
+	var parsed struct {
+		ComplianceResults struct {
+			Summary struct {
+				BenchmarkScore common.BenchmarkScore `json:"benchmarkScore"`
+			} `json:"summary"`
+		} `json:"complianceResults"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(result), &parsed))
+	score := parsed.ComplianceResults.Summary.BenchmarkScore
+	require.Len(t, score.Sections, len(compliance.ScoreSections()))
+	require.Positive(t, score.ApplicableChecks)
+	require.Less(t, score.Score, 1.0, "an unconfigured device should not score perfectly")
+
+	result, err = handleAuditMode(context.Background(), device,
+		audit.Options{AuditMode: "blue", SelectedPlugins: []string{"firewall"}, MinScore: 1}, opt, logger)
+	require.ErrorIs(t, err, audit.ErrScoreBelowMinimum)
+	assert.Contains(t, result, "benchmarkScore", "the report must still be returned when the gate fails")
+
+	_, err = handleAuditMode(context.Background(), device,
+		audit.Options{AuditMode: "blue", SelectedPlugins: []string{"firewall"}, MinScore: score.Score}, opt, logger)
+	require.NoError(t, err)
+}
+
+// TestHandleAuditMode_ChecksExecuted verifies that the execution status of
+// every check reaches the JSON export and the markdown appendix, and that
+// --strict-checks passes a run in which no check errored.
+func TestHandleAuditMode_ChecksExecuted(t *testing.T) {
+	// Do NOT use t.Parallel() — cmd package uses package-level flag globals.
+	// See GOTCHAS §1.1.
+	logger, err := logging.New(logging.Config{Level: "warn"})
+	require.NoError(t, err)
+
+	device := &common.CommonDevice{
+		System: common.System{Hostname: "test-fw", Domain: "example.com"},
+	}
+	auditOpts := audit.Options{AuditMode: "blue", SelectedPlugins: []string{"firewall"}, StrictChecks: true}
+
+	result, err := handleAuditMode(context.Background(), device, auditOpts,
+		converter.Options{Format: converter.FormatJSON}, logger)
+	require.NoError(t, err)
+
+	var parsed struct {
+		ComplianceResults struct {
+			PluginResults map[string]struct {
+				Checks []common.ComplianceCheck `json:"checks"`
+			} `json:"pluginResults"`
+		} `json:"complianceResults"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(result), &parsed))
+	checks := parsed.ComplianceResults.PluginResults["firewall"].Checks
+	require.Len(t, checks, len(firewall.NewPlugin().GetControls()))
+	assert.Contains(t, checks, common.ComplianceCheck{
+		ID:     "FIREWALL-066",
+		Name:   "OpenVPN CRL Validity",
+		Status: common.CheckStatusSkipped,
+		Reason: "no OpenVPN servers configured",
+	})
+
+	result, err = handleAuditMode(context.Background(), device, auditOpts,
+		converter.Options{Format: converter.FormatMarkdown}, logger)
+	require.NoError(t, err)
+	assert.Contains(t, result, "## Appendix: Checks Executed")
+	assert.Contains(t, result, "no OpenVPN servers configured")
+}
+
 // TestHandleAuditMode_UnknownPluginRejectedPostInit verifies that handleAuditMode
 // rejects an unknown plugin name after plugin initialization, because the registry
 // does not contain the requested plugin. This tests the post-init validation phase
@@ -6,12 +6,17 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
 
+	"github.com/EvilBit-Labs/opnDossier/internal/audit"
 	"github.com/EvilBit-Labs/opnDossier/internal/config"
 	"github.com/EvilBit-Labs/opnDossier/internal/logging"
+	"github.com/EvilBit-Labs/opnDossier/internal/runstats"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -182,6 +187,51 @@ func TestRunAuditFileOutput(t *testing.T) {
 	assert.NotEmpty(t, data, "output file should have content")
 }
 
+// TestRunAuditMinScoreWritesReportBeforeFailing verifies that a report
+// scoring below --min-score is still written and that runAudit then fails
+// with ErrScoreBelowMinimum.
+func TestRunAuditMinScoreWritesReportBeforeFailing(t *testing.T) {
+	testdataPath := filepath.Join("..", "testdata", "sample.config.1.xml")
+	if _, err := os.Stat(testdataPath); os.IsNotExist(err) {
+		t.Fatal("required testdata not available — ensure testdata/ is checked out")
+	}
+
+	auditSnap := captureAuditFlags()
+	sharedSnap := captureSharedFlags()
+	t.Cleanup(func() {
+		auditSnap.restore()
+		sharedSnap.restore()
+	})
+
+	outFile := filepath.Join(t.TempDir(), "audit-output.md")
+
+	auditMode = testAuditModeBlue
+	auditPlugins = []string{}
+	auditMinScore = 1
+	format = testFormatMarkdown
+	outputFile = outFile
+	force = true
+
+	testLogger, err := logging.New(logging.Config{Level: "error"})
+	require.NoError(t, err)
+
+	cmd := &cobra.Command{Use: "test"}
+	cmd.SetContext(context.Background())
+	SetCommandContext(cmd, &CommandContext{
+		Config: &config.Config{Format: testFormatMarkdown},
+		Logger: testLogger,
+	})
+	cmd.Flags().StringVar(&format, "format", testFormatMarkdown, "")
+	cmd.Flags().StringVar(&outputFile, "output", outFile, "")
+
+	err = runAudit(cmd, []string{testdataPath})
+	require.ErrorIs(t, err, audit.ErrScoreBelowMinimum)
+
+	data, err := os.ReadFile(outFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "Benchmark Score")
+}
+
 // TestRunAuditRedMode verifies that runAudit works with red (recon) mode.
 // Red mode with markdown format renders through glamour to os.Stdout, so
 // we use file output to capture the result.
@@ -296,6 +346,77 @@ func TestRunAuditMultiFile(t *testing.T) {
 	assert.Equal(t, 2, auditFiles, "should produce 2 auto-named output files for 2 inputs")
 }
 
+// TestRunAuditStatsOut audits three fixtures in one run with --stats-out and
+// verifies that the statistics file holds one complete record per input with
+// every documented key.
+func TestRunAuditStatsOut(t *testing.T) {
+	var inputs []string
+	for _, name := range []string{"sample.config.1.xml", "sample.config.2.xml", "sample.config.3.xml"} {
+		abs, err := filepath.Abs(filepath.Join("..", "testdata", name))
+		require.NoError(t, err)
+		inputs = append(inputs, abs)
+	}
+
+	auditSnap := captureAuditFlags()
+	sharedSnap := captureSharedFlags()
+	t.Cleanup(func() {
+		auditSnap.restore()
+		sharedSnap.restore()
+	})
+
+	tmpDir := t.TempDir()
+	t.Chdir(tmpDir)
+
+	auditMode = testAuditModeBlue
+	format = testFormatJSON
+	outputFile = ""
+	force = true
+	auditStatsOut = filepath.Join(tmpDir, "stats.ndjson")
+
+	testLogger, err := logging.New(logging.Config{Level: "error"})
+	require.NoError(t, err)
+
+	cmd := &cobra.Command{Use: "test"}
+	cmd.SetContext(context.Background())
+	SetCommandContext(cmd, &CommandContext{
+		Config: &config.Config{Format: testFormatJSON},
+		Logger: testLogger,
+	})
+	cmd.Flags().StringVar(&format, "format", testFormatJSON, "")
+	cmd.Flags().StringVar(&outputFile, "output", "", "")
+
+	require.NoError(t, runAudit(cmd, inputs))
+
+	data, err := os.ReadFile(auditStatsOut)
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	require.Len(t, lines, len(inputs))
+
+	documentedKeys := []string{
+		"deviceType", "domain", "file", "findings", "firmwareVersion", "grade", "hostname",
+		"interfaces", "natRules", "rules", "schemaVersion", "score", "services", "users", "vpnInstances",
+	}
+
+	var files []string
+	for _, line := range lines {
+		var fields map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &fields))
+		assert.ElementsMatch(t, documentedKeys, slices.Collect(maps.Keys(fields)))
+
+		var rec runstats.Record
+		require.NoError(t, json.Unmarshal([]byte(line), &rec))
+		assert.Equal(t, runstats.SchemaVersion, rec.SchemaVersion)
+		assert.NotEmpty(t, rec.Hostname)
+		assert.Positive(t, rec.Interfaces)
+		require.NotNil(t, rec.Score)
+		files = append(files, rec.File)
+	}
+
+	// Workers finish in any order, so lines are not in input order.
+	assert.ElementsMatch(t, inputs, files)
+}
+
 // TestGenerateAuditOutput verifies that generateAuditOutput produces
 // output without performing any I/O emission.
 func TestGenerateAuditOutput(t *testing.T) {
@@ -322,11 +443,53 @@ func TestGenerateAuditOutput(t *testing.T) {
 	}
 
 	ctx := context.Background()
-	output, err := generateAuditOutput(ctx, testdataPath, testLogger, cfg)
+	output, err := generateAuditOutput(ctx, testdataPath, nil, testLogger, cfg)
 	require.NoError(t, err)
 	assert.NotEmpty(t, output, "generateAuditOutput should return report content")
 }
 
+// TestGenerateAuditOutputEffectiveRules verifies that --effective-rules
+// replaces the audit report with the interface's rules in evaluation order,
+// and that an unknown interface is rejected.
+func TestGenerateAuditOutputEffectiveRules(t *testing.T) {
+	testdataPath := filepath.Join("..", "testdata", "effective_rules_test.xml")
+
+	auditSnap := captureAuditFlags()
+	sharedSnap := captureSharedFlags()
+	t.Cleanup(func() {
+		auditSnap.restore()
+		sharedSnap.restore()
+	})
+
+	auditMode = testAuditModeBlue
+	format = testFormatMarkdown
+	auditEffectiveRules = []string{"WAN"}
+
+	testLogger, err := logging.New(logging.Config{Level: "error"})
+	require.NoError(t, err)
+
+	cfg := &config.Config{
+		Format: testFormatMarkdown,
+	}
+
+	output, err := generateAuditOutput(context.Background(), testdataPath, nil, testLogger, cfg)
+	require.NoError(t, err)
+	assert.Contains(t, output, "## Effective Rules: Wan")
+	assert.NotContains(t, output, "Compliance", "the ruleset view replaces the audit report")
+
+	floating := strings.Index(output, "| 1 | 3 | floating |")
+	group := strings.Index(output, "| 2 | 2 | group:uplinks |")
+	direct := strings.Index(output, "| 3 | 1 | direct |")
+	require.NotEqual(t, -1, floating, output)
+	assert.Less(t, floating, group)
+	assert.Less(t, group, direct)
+
+	auditEffectiveRules = []string{"dmz"}
+	_, err = generateAuditOutput(context.Background(), testdataPath, nil, testLogger, cfg)
+	require.ErrorIs(t, err, ErrUnknownInterface)
+	assert.Contains(t, err.Error(), "configured interfaces: lan, wan")
+}
+
 // TestGenerateAuditOutputInvalidFile verifies that generateAuditOutput
 // returns an error for non-existent files.
 func TestGenerateAuditOutputInvalidFile(t *testing.T) {
@@ -348,7 +511,7 @@ func TestGenerateAuditOutputInvalidFile(t *testing.T) {
 	}
 
 	ctx := context.Background()
-	_, err = generateAuditOutput(ctx, "/nonexistent/file.xml", testLogger, cfg)
+	_, err = generateAuditOutput(ctx, "/nonexistent/file.xml", nil, testLogger, cfg)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to open file")
 }
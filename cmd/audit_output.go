@@ -80,7 +80,7 @@ func emitAuditResult(
 	// Export to file when requested.
 	if actualOutputFile != "" {
 		ctxLogger.Debug("Exporting audit report to file", "output_file", actualOutputFile)
-		e := export.NewFileExporter(ctxLogger)
+		e := export.NewFileExporter(ctxLogger, export.WithBinaryContent(opt.Format.IsBinary()))
 
 		if err := e.Export(ctx, result.output, actualOutputFile); err != nil {
 			return fmt.Errorf("failed to export audit report to %s: %w", actualOutputFile, err)
@@ -96,8 +96,9 @@ func emitAuditResult(
 
 // emitAuditReportToStdout writes an audit report to the command's stdout.
 // Markdown is rendered through glamour for styled terminal output; other
-// formats (JSON, YAML, text, HTML) are written raw. Extracted from
-// emitAuditResult to keep the if/else branching flat enough to read.
+// formats (JSON, YAML, text, HTML, PDF) are written raw, except that PDF is
+// refused when stdout is a terminal. Extracted from emitAuditResult to keep
+// the if/else branching flat enough to read.
 func emitAuditReportToStdout(ctx context.Context, cmd *cobra.Command, output string, opt converter.Options) error {
 	if opt.Format == converter.FormatMarkdown {
 		displayer := display.NewTerminalDisplayWithMarkdownOptions(opt)
@@ -108,6 +109,10 @@ func emitAuditReportToStdout(ctx context.Context, cmd *cobra.Command, output str
 		return nil
 	}
 
+	if err := checkStdoutAcceptsOutput(cmd.OutOrStdout(), opt.Format.IsBinary()); err != nil {
+		return err
+	}
+
 	if _, err := fmt.Fprint(cmd.OutOrStdout(), output); err != nil {
 		return fmt.Errorf("failed to write audit report to stdout: %w", err)
 	}
@@ -19,6 +19,14 @@ type auditFlagSnapshot struct {
 	pluginDir    string
 	failuresOnly bool
 	blackhat     bool
+	noDedupe     bool
+	descrPattern string
+	minDescrLen  int
+	logCoverage  int
+	minScore     float64
+	strictChecks bool
+	statsOut     string
+	effective    []string
 	formatFlag   string
 	outputFile   string
 	forceFlag    bool
@@ -33,6 +41,14 @@ func captureAuditFlags() auditFlagSnapshot {
 		pluginDir:    auditPluginDir,
 		failuresOnly: auditFailuresOnly,
 		blackhat:     auditBlackhat,
+		noDedupe:     auditNoDedupe,
+		descrPattern: auditDescrPattern,
+		minDescrLen:  auditMinDescrLen,
+		logCoverage:  auditLogCoverage,
+		minScore:     auditMinScore,
+		strictChecks: auditStrictChecks,
+		statsOut:     auditStatsOut,
+		effective:    auditEffectiveRules,
 		formatFlag:   format,
 		outputFile:   outputFile,
 		forceFlag:    force,
@@ -47,6 +63,14 @@ func (s auditFlagSnapshot) restore() {
 	auditPluginDir = s.pluginDir
 	auditFailuresOnly = s.failuresOnly
 	auditBlackhat = s.blackhat
+	auditNoDedupe = s.noDedupe
+	auditDescrPattern = s.descrPattern
+	auditMinDescrLen = s.minDescrLen
+	auditLogCoverage = s.logCoverage
+	auditMinScore = s.minScore
+	auditStrictChecks = s.strictChecks
+	auditStatsOut = s.statsOut
+	auditEffectiveRules = s.effective
 	format = s.formatFlag
 	outputFile = s.outputFile
 	force = s.forceFlag
@@ -92,6 +116,12 @@ func TestAuditCmdFlagDefaults(t *testing.T) {
 		{"plugins", "[]"},
 		{"plugin-dir", ""},
 		{"failures-only", "false"},
+		{"require-descr-pattern", ""},
+		{"min-descr-length", "10"},
+		{"log-coverage-threshold", "50"},
+		{"min-score", "0"},
+		{"strict-checks", "false"},
+		{"effective-rules", "[]"},
 		{"format", "markdown"},
 		{"output", ""},
 		{"force", "false"},
@@ -171,6 +201,7 @@ func TestAuditCmdPreRunEModeValidation(t *testing.T) {
 	}{
 		{"blue is accepted", "blue", false, ""},
 		{"red is accepted", "red", false, ""},
+		{"executive is accepted", "executive", false, ""},
 		{"standard is rejected", "standard", true, "invalid audit mode"},
 		{"invalid is rejected", "invalid", true, "invalid audit mode"},
 		{"empty is rejected", "", true, "invalid audit mode"},
@@ -382,12 +413,13 @@ func TestAuditCmdPreRunEMultiFileOutput(t *testing.T) {
 func TestAuditCmdCompletions(t *testing.T) {
 	t.Run("audit modes", func(t *testing.T) {
 		completions, directive := ValidAuditModes(nil, nil, "")
-		assert.Len(t, completions, 2)
+		assert.Len(t, completions, 3)
 		assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
 
 		joined := strings.Join(completions, " ")
 		assert.Contains(t, joined, "blue")
 		assert.Contains(t, joined, "red")
+		assert.Contains(t, joined, "executive")
 	})
 
 	t.Run("audit plugins", func(t *testing.T) {
@@ -416,7 +448,7 @@ func TestAuditCmdCompletions(t *testing.T) {
 
 	t.Run("formats", func(t *testing.T) {
 		completions, directive := ValidFormats(nil, nil, "")
-		assert.Len(t, completions, 5)
+		assert.Len(t, completions, 6)
 		assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
 	})
 }
@@ -637,3 +669,312 @@ func TestAuditCmdPreRunEBlackhatRequiresRedMode(t *testing.T) {
 		})
 	}
 }
+
+// TestAuditCmdPreRunENoDedupeRequiresBlueMode verifies --no-dedupe is accepted
+// in blue mode and rejected in red mode, where hygiene findings are not merged.
+func TestAuditCmdPreRunENoDedupeRequiresBlueMode(t *testing.T) {
+	tests := []struct {
+		name    string
+		mode    string
+		wantErr bool
+	}{
+		{"no-dedupe with blue mode is accepted", "blue", false},
+		{"no-dedupe with red mode is rejected", "red", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auditSnap := captureAuditFlags()
+			sharedSnap := captureSharedFlags()
+			t.Cleanup(func() {
+				auditSnap.restore()
+				sharedSnap.restore()
+			})
+
+			tempCmd := &cobra.Command{}
+			tempCmd.Flags().StringVar(&auditMode, "mode", "blue", "")
+			tempCmd.Flags().StringSliceVar(&auditPlugins, "plugins", []string{}, "")
+			tempCmd.Flags().StringVar(&auditPluginDir, "plugin-dir", "", "")
+			tempCmd.Flags().BoolVar(&auditNoDedupe, "no-dedupe", false, "")
+			tempCmd.Flags().StringVar(&outputFile, "output", "", "")
+			tempCmd.Flags().StringVar(&format, "format", "markdown", "")
+			tempCmd.Flags().Bool("no-wrap", false, "")
+			tempCmd.Flags().Int("wrap", -1, "")
+
+			require.NoError(t, tempCmd.Flags().Set("mode", tt.mode))
+			require.NoError(t, tempCmd.Flags().Set("no-dedupe", "true"))
+
+			err := auditCmd.PreRunE(tempCmd, []string{"dummy.xml"})
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "--no-dedupe is only supported with --mode blue")
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+// TestAuditCmdPreRunEDescriptionPolicyFlags verifies the rule description
+// flags are accepted in blue mode and rejected in other modes, and that an
+// uncompilable pattern or a non-positive minimum length fails fast.
+func TestAuditCmdPreRunEDescriptionPolicyFlags(t *testing.T) {
+	tests := []struct {
+		name    string
+		mode    string
+		flag    string
+		value   string
+		wantErr string
+	}{
+		{"pattern with blue mode is accepted", "blue", "require-descr-pattern", `(CHG|TKT)-\d+`, ""},
+		{"min length with blue mode is accepted", "blue", "min-descr-length", "20", ""},
+		{
+			"pattern with red mode is rejected", "red", "require-descr-pattern", `CHG-\d+`,
+			"--require-descr-pattern and --min-descr-length are only supported with --mode blue",
+		},
+		{
+			"min length with red mode is rejected", "red", "min-descr-length", "20",
+			"--require-descr-pattern and --min-descr-length are only supported with --mode blue",
+		},
+		{"invalid pattern is rejected", "blue", "require-descr-pattern", "CHG-(", "invalid --require-descr-pattern"},
+		{"zero min length is rejected", "blue", "min-descr-length", "0", "--min-descr-length must be at least 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auditSnap := captureAuditFlags()
+			sharedSnap := captureSharedFlags()
+			t.Cleanup(func() {
+				auditSnap.restore()
+				sharedSnap.restore()
+			})
+
+			tempCmd := &cobra.Command{}
+			tempCmd.Flags().StringVar(&auditMode, "mode", "blue", "")
+			tempCmd.Flags().StringSliceVar(&auditPlugins, "plugins", []string{}, "")
+			tempCmd.Flags().StringVar(&auditPluginDir, "plugin-dir", "", "")
+			tempCmd.Flags().StringVar(&auditDescrPattern, "require-descr-pattern", "", "")
+			tempCmd.Flags().IntVar(&auditMinDescrLen, "min-descr-length", 10, "")
+			tempCmd.Flags().StringVar(&outputFile, "output", "", "")
+			tempCmd.Flags().StringVar(&format, "format", "markdown", "")
+			tempCmd.Flags().Bool("no-wrap", false, "")
+			tempCmd.Flags().Int("wrap", -1, "")
+
+			require.NoError(t, tempCmd.Flags().Set("mode", tt.mode))
+			require.NoError(t, tempCmd.Flags().Set(tt.flag, tt.value))
+
+			err := auditCmd.PreRunE(tempCmd, []string{"dummy.xml"})
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+// TestAuditCmdPreRunELogCoverageThreshold verifies --log-coverage-threshold is
+// accepted in blue mode, rejected in other modes, and limited to 1-100.
+func TestAuditCmdPreRunELogCoverageThreshold(t *testing.T) {
+	tests := []struct {
+		name    string
+		mode    string
+		value   string
+		wantErr string
+	}{
+		{"blue mode is accepted", "blue", "75", ""},
+		{"100 percent is accepted", "blue", "100", ""},
+		{
+			"red mode is rejected", "red", "75",
+			"--log-coverage-threshold is only supported with --mode blue",
+		},
+		{"zero is rejected", "blue", "0", "--log-coverage-threshold must be between 1 and 100"},
+		{"above 100 is rejected", "blue", "101", "--log-coverage-threshold must be between 1 and 100"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auditSnap := captureAuditFlags()
+			sharedSnap := captureSharedFlags()
+			t.Cleanup(func() {
+				auditSnap.restore()
+				sharedSnap.restore()
+			})
+
+			tempCmd := &cobra.Command{}
+			tempCmd.Flags().StringVar(&auditMode, "mode", "blue", "")
+			tempCmd.Flags().StringSliceVar(&auditPlugins, "plugins", []string{}, "")
+			tempCmd.Flags().StringVar(&auditPluginDir, "plugin-dir", "", "")
+			tempCmd.Flags().IntVar(&auditMinDescrLen, "min-descr-length", 10, "")
+			tempCmd.Flags().IntVar(&auditLogCoverage, "log-coverage-threshold", 50, "")
+			tempCmd.Flags().StringVar(&outputFile, "output", "", "")
+			tempCmd.Flags().StringVar(&format, "format", "markdown", "")
+			tempCmd.Flags().Bool("no-wrap", false, "")
+			tempCmd.Flags().Int("wrap", -1, "")
+
+			require.NoError(t, tempCmd.Flags().Set("mode", tt.mode))
+			require.NoError(t, tempCmd.Flags().Set("log-coverage-threshold", tt.value))
+
+			err := auditCmd.PreRunE(tempCmd, []string{"dummy.xml"})
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+// TestAuditCmdPreRunEMinScore verifies --min-score is accepted in blue mode,
+// rejected in other modes, and limited to 0-1.
+func TestAuditCmdPreRunEMinScore(t *testing.T) {
+	tests := []struct {
+		name    string
+		mode    string
+		value   string
+		wantErr string
+	}{
+		{"blue mode is accepted", "blue", "0.8", ""},
+		{"one is accepted", "blue", "1", ""},
+		{"red mode is rejected", "red", "0.8", "--min-score is only supported with --mode blue"},
+		{"negative is rejected", "blue", "-0.1", "--min-score must be between 0 and 1"},
+		{"percentages are rejected", "blue", "80", "--min-score must be between 0 and 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auditSnap := captureAuditFlags()
+			sharedSnap := captureSharedFlags()
+			t.Cleanup(func() {
+				auditSnap.restore()
+				sharedSnap.restore()
+			})
+
+			tempCmd := &cobra.Command{}
+			tempCmd.Flags().StringVar(&auditMode, "mode", "blue", "")
+			tempCmd.Flags().StringSliceVar(&auditPlugins, "plugins", []string{}, "")
+			tempCmd.Flags().StringVar(&auditPluginDir, "plugin-dir", "", "")
+			tempCmd.Flags().IntVar(&auditMinDescrLen, "min-descr-length", 10, "")
+			tempCmd.Flags().IntVar(&auditLogCoverage, "log-coverage-threshold", 50, "")
+			tempCmd.Flags().Float64Var(&auditMinScore, "min-score", 0, "")
+			tempCmd.Flags().StringVar(&outputFile, "output", "", "")
+			tempCmd.Flags().StringVar(&format, "format", "markdown", "")
+			tempCmd.Flags().Bool("no-wrap", false, "")
+			tempCmd.Flags().Int("wrap", -1, "")
+
+			require.NoError(t, tempCmd.Flags().Set("mode", tt.mode))
+			require.NoError(t, tempCmd.Flags().Set("min-score", tt.value))
+
+			err := auditCmd.PreRunE(tempCmd, []string{"dummy.xml"})
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+// TestAuditCmdPreRunEStrictChecks verifies --strict-checks is accepted in
+// blue mode and rejected in the modes that run no compliance checks.
+func TestAuditCmdPreRunEStrictChecks(t *testing.T) {
+	tests := []struct {
+		name    string
+		mode    string
+		wantErr string
+	}{
+		{"blue mode is accepted", "blue", ""},
+		{"red mode is rejected", "red", "--strict-checks is only supported with --mode blue"},
+		{"executive mode is rejected", "executive", "--strict-checks is only supported with --mode blue"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auditSnap := captureAuditFlags()
+			sharedSnap := captureSharedFlags()
+			t.Cleanup(func() {
+				auditSnap.restore()
+				sharedSnap.restore()
+			})
+
+			tempCmd := &cobra.Command{}
+			tempCmd.Flags().StringVar(&auditMode, "mode", "blue", "")
+			tempCmd.Flags().StringSliceVar(&auditPlugins, "plugins", []string{}, "")
+			tempCmd.Flags().StringVar(&auditPluginDir, "plugin-dir", "", "")
+			tempCmd.Flags().IntVar(&auditMinDescrLen, "min-descr-length", 10, "")
+			tempCmd.Flags().IntVar(&auditLogCoverage, "log-coverage-threshold", 50, "")
+			tempCmd.Flags().Float64Var(&auditMinScore, "min-score", 0, "")
+			tempCmd.Flags().BoolVar(&auditStrictChecks, "strict-checks", false, "")
+			tempCmd.Flags().StringVar(&outputFile, "output", "", "")
+			tempCmd.Flags().StringVar(&format, "format", "markdown", "")
+			tempCmd.Flags().Bool("no-wrap", false, "")
+			tempCmd.Flags().Int("wrap", -1, "")
+
+			require.NoError(t, tempCmd.Flags().Set("mode", tt.mode))
+			require.NoError(t, tempCmd.Flags().Set("strict-checks", "true"))
+
+			err := auditCmd.PreRunE(tempCmd, []string{"dummy.xml"})
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+// TestAuditCmdPreRunEEffectiveRules verifies --effective-rules is accepted
+// with markdown output and rejected with other formats and with --stats-out.
+func TestAuditCmdPreRunEEffectiveRules(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   string
+		statsOut string
+		wantErr  string
+	}{
+		{"markdown is accepted", "markdown", "", ""},
+		{"json is rejected", "json", "", "--effective-rules is only supported with --format markdown"},
+		{"stats-out is rejected", "markdown", "stats.ndjson", "--effective-rules cannot be combined with --stats-out"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auditSnap := captureAuditFlags()
+			sharedSnap := captureSharedFlags()
+			t.Cleanup(func() {
+				auditSnap.restore()
+				sharedSnap.restore()
+			})
+
+			tempCmd := &cobra.Command{}
+			tempCmd.Flags().StringVar(&auditMode, "mode", "blue", "")
+			tempCmd.Flags().StringSliceVar(&auditPlugins, "plugins", []string{}, "")
+			tempCmd.Flags().StringVar(&auditPluginDir, "plugin-dir", "", "")
+			tempCmd.Flags().IntVar(&auditMinDescrLen, "min-descr-length", 10, "")
+			tempCmd.Flags().IntVar(&auditLogCoverage, "log-coverage-threshold", 50, "")
+			tempCmd.Flags().Float64Var(&auditMinScore, "min-score", 0, "")
+			tempCmd.Flags().StringSliceVar(&auditEffectiveRules, "effective-rules", []string{}, "")
+			tempCmd.Flags().StringVar(&auditStatsOut, "stats-out", "", "")
+			tempCmd.Flags().StringVar(&outputFile, "output", "", "")
+			tempCmd.Flags().StringVar(&format, "format", "markdown", "")
+			tempCmd.Flags().Bool("no-wrap", false, "")
+			tempCmd.Flags().Int("wrap", -1, "")
+
+			require.NoError(t, tempCmd.Flags().Set("effective-rules", "wan"))
+			require.NoError(t, tempCmd.Flags().Set("format", tt.format))
+			require.NoError(t, tempCmd.Flags().Set("stats-out", tt.statsOut))
+
+			err := auditCmd.PreRunE(tempCmd, []string{"dummy.xml"})
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
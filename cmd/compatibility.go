@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/logging"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	schema "github.com/EvilBit-Labs/opnDossier/pkg/schema/opnsense"
+)
+
+// ErrConfigFormatTooNew is returned when a configuration declares a format
+// more than one major version newer than the parser was tested against.
+var ErrConfigFormatTooNew = errors.New("configuration format too new")
+
+// sharedAllowNewer is the --allow-newer flag value.
+var sharedAllowNewer bool //nolint:gochecknoglobals // Parse configurations newer than the tested format
+
+// checkConfigCompatibility warns when device declares a configuration format
+// newer than the parser was tested against, since sections added in that
+// format go unreported. A format more than one major version newer is
+// refused unless --allow-newer is set. When quiet is true, the warning is
+// suppressed but the refusal still applies.
+func checkConfigCompatibility(device *common.CommonDevice, logger *logging.Logger, quiet bool) error {
+	if device == nil || device.Compatibility == "" {
+		return nil
+	}
+
+	if device.DeviceType == common.DeviceTypeOPNsense && !sharedAllowNewer &&
+		schema.CheckConfigVersion(device.Version) == schema.ConfigVersionUnsupported {
+		return fmt.Errorf("%w: %s; use --allow-newer to parse it anyway", ErrConfigFormatTooNew, device.Compatibility)
+	}
+
+	if !quiet {
+		logger.Warn("configuration format is newer than tested",
+			"version", device.Version,
+			"tested", schema.MaxTestedConfigVersion,
+			"message", device.Compatibility,
+		)
+	}
+
+	return nil
+}
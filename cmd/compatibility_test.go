@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/logging"
+	schema "github.com/EvilBit-Labs/opnDossier/pkg/schema/opnsense"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeVersionedConfig writes a minimal OPNsense configuration declaring
+// version and returns its path.
+func writeVersionedConfig(t *testing.T, version string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "config.xml")
+	content := `<?xml version="1.0"?>
+<opnsense>
+  <version>` + version + `</version>
+  <system>
+    <hostname>fw01</hostname>
+    <domain>example.com</domain>
+  </system>
+</opnsense>
+`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	return path
+}
+
+func TestParseConfigFile_ConfigVersionCompatibility(t *testing.T) {
+	sharedSnap := captureSharedFlags()
+	t.Cleanup(sharedSnap.restore)
+
+	tests := []struct {
+		name        string
+		version     string
+		allowNewer  bool
+		wantWarning bool
+		wantErr     bool
+	}{
+		{name: "tested version", version: schema.MaxTestedConfigVersion},
+		{name: "slightly newer version", version: "25.1", wantWarning: true},
+		{name: "much newer version", version: "27.1", wantErr: true},
+		{name: "much newer version with --allow-newer", version: "27.1", allowNewer: true, wantWarning: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sharedAllowNewer = tt.allowNewer
+
+			var logs bytes.Buffer
+			logger, err := logging.New(logging.Config{Level: "warn", Output: &logs})
+			require.NoError(t, err)
+
+			device, err := parseConfigFile(context.Background(), writeVersionedConfig(t, tt.version), logger, false)
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrConfigFormatTooNew)
+				assert.Contains(t, err.Error(), "--allow-newer")
+				assert.Nil(t, device)
+				return
+			}
+			require.NoError(t, err)
+
+			if tt.wantWarning {
+				assert.Contains(t, logs.String(), "configuration format is newer than tested")
+				assert.Contains(t, device.Compatibility, "config format "+tt.version+" newer than tested")
+			} else {
+				assert.NotContains(t, logs.String(), "newer than tested")
+				assert.Empty(t, device.Compatibility)
+			}
+		})
+	}
+}
@@ -59,6 +59,10 @@ const (
 	configKeyWidth   = "width"
 )
 
+// deviceTypeAuto is the --device-type value that selects detection from the
+// XML root element.
+const deviceTypeAuto = "auto"
+
 // Flag names that recur 3+ times across subcommand init() functions.
 const (
 	flagVerbose = "verbose"
@@ -108,10 +112,13 @@ const displayTrue = "true"
 
 // Audit modes mirroring internal/audit.ModeBlue / ModeRed but kept as raw
 // strings here since cmd flag parsing accepts plain strings (the typed
-// ReportMode constants are consumed inside internal/audit).
+// ReportMode constants are consumed inside internal/audit). Executive mode
+// has no ReportMode: it is rendered from the processor report instead (see
+// handleExecutiveMode).
 const (
-	auditModeBlue = "blue"
-	auditModeRed  = "red"
+	auditModeBlue      = "blue"
+	auditModeRed       = "red"
+	auditModeExecutive = "executive"
 )
 
 // Severity strings used in human-facing CLI output (audit findings table,
@@ -145,4 +152,5 @@ const (
 	outputFormatYAML     = "yaml"
 	outputFormatText     = "text"
 	outputFormatHTML     = "html"
+	outputFormatPDF      = "pdf"
 )
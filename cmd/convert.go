@@ -2,16 +2,21 @@
 package cmd
 
 import (
-	"bufio"
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"strings"
 	"sync"
+	"time"
 
+	"github.com/EvilBit-Labs/opnDossier/internal/analysis"
 	"github.com/EvilBit-Labs/opnDossier/internal/cfgparser"
 	"github.com/EvilBit-Labs/opnDossier/internal/config"
 	"github.com/EvilBit-Labs/opnDossier/internal/constants"
@@ -19,8 +24,12 @@ import (
 	"github.com/EvilBit-Labs/opnDossier/internal/converter/builder"
 	"github.com/EvilBit-Labs/opnDossier/internal/export"
 	"github.com/EvilBit-Labs/opnDossier/internal/logging"
+	"github.com/EvilBit-Labs/opnDossier/internal/progress"
+	"github.com/EvilBit-Labs/opnDossier/internal/watch"
 	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
 	"github.com/EvilBit-Labs/opnDossier/pkg/parser"
+	opnsenseparser "github.com/EvilBit-Labs/opnDossier/pkg/parser/opnsense"
+	schema "github.com/EvilBit-Labs/opnDossier/pkg/schema/opnsense"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 )
@@ -28,10 +37,37 @@ import (
 // Package-level flag variables for the convert command, required by cobra's flag binding mechanism.
 var (
 	outputFile string //nolint:gochecknoglobals // Cobra flag variable
-	format     string //nolint:gochecknoglobals // Output format (markdown, json, yaml, text, html)
+	format     string //nolint:gochecknoglobals // Output format (markdown, json, yaml, text, html, pdf)
 	force      bool   //nolint:gochecknoglobals // Force overwrite without prompt
+	statsOnly  bool   //nolint:gochecknoglobals // Print configuration statistics and exit
+	tmplFile   string //nolint:gochecknoglobals // User-supplied Go template rendered instead of a built-in format
+
+	embedSource      bool  //nolint:gochecknoglobals // Embed the original config file in JSON/YAML exports
+	embedSourceLimit int64 //nolint:gochecknoglobals // Maximum size in bytes of a source embedded with --embed-source
+
+	dataQuality bool //nolint:gochecknoglobals // Append the Data Quality appendix to markdown, text, HTML, and PDF reports
+
+	filterInterface string //nolint:gochecknoglobals // List only rules on this interface
+	filterAction    string //nolint:gochecknoglobals // List only firewall rules with this action
+	filterSearch    string //nolint:gochecknoglobals // List only rules containing this text
+
+	outputDir   string //nolint:gochecknoglobals // Directory receiving <hostname>-report.<ext> files in batch mode
+	parallelism int    //nolint:gochecknoglobals // Files converted at once in batch mode
+
+	strictRender bool //nolint:gochecknoglobals // Fail instead of writing a placeholder for a section that cannot be rendered
+
+	watchMode     bool          //nolint:gochecknoglobals // Regenerate the output file whenever the input changes
+	watchDebounce time.Duration //nolint:gochecknoglobals // Quiet period after the last write before regenerating
+	watchPoll     bool          //nolint:gochecknoglobals // Poll the input instead of relying on filesystem notifications
 )
 
+// convertExit ends the process after a convert run whose reports contain
+// placeholder sections.
+var convertExit = ExitWithCode //nolint:gochecknoglobals // test override hook
+
+// stdoutIsTerminal reports whether report output is going to a terminal.
+var stdoutIsTerminal = progress.IsTerminal //nolint:gochecknoglobals // test override hook
+
 // ErrOperationCancelled is returned when the user cancels an operation.
 var ErrOperationCancelled = errors.New("operation cancelled by user")
 
@@ -41,6 +77,19 @@ var (
 	ErrFailedToEnrichConfig = errors.New("failed to enrich configuration")
 	// ErrUnsupportedOutputFormat is returned when an unsupported output format is specified.
 	ErrUnsupportedOutputFormat = errors.New("unsupported output format")
+	// ErrUnsupportedStatsDevice is returned when --stats is used with a non-OPNsense device type.
+	ErrUnsupportedStatsDevice = errors.New("unsupported device type for statistics")
+	// ErrEmbedSourceFormat is returned when --embed-source is used with a format other than JSON or YAML.
+	ErrEmbedSourceFormat = errors.New("embedding the source requires json or yaml output")
+	// ErrInvalidEmbedSourceLimit is returned when --embed-source-limit is not positive.
+	ErrInvalidEmbedSourceLimit = errors.New("embed source limit must be positive")
+	// ErrNoBatchInputs is returned when a --output-dir directory or glob argument matches no files.
+	ErrNoBatchInputs = errors.New("no configuration files to convert")
+	// ErrBinaryOutputToTerminal is returned when binary output such as a PDF would be written to a terminal.
+	ErrBinaryOutputToTerminal = errors.New("refusing to write binary output to a terminal")
+	// ErrInvalidWatch is returned when --watch is not given exactly one input, an output file distinct from
+	// the input, and a positive --watch-debounce.
+	ErrInvalidWatch = errors.New("invalid --watch usage")
 )
 
 // init registers the `convert` command with the root command and configures its command-line flags.
@@ -49,6 +98,19 @@ var (
 //   - `--output, -o` : file path to write the converted output (omitted to print to stdout).
 //   - `--format, -f` : output format to produce; supported values are `markdown`, `json`, and `yaml` (default: `markdown`).
 //   - `--force`      : overwrite existing output files without prompting.
+//   - `--stats`      : print a JSON count summary of each configuration and exit.
+//   - `--template`   : render each configuration with a user-supplied Go template instead of a built-in format.
+//   - `--embed-source`: embed the original file under _meta.source of JSON/YAML exports, up to
+//     `--embed-source-limit` bytes.
+//   - `--data-quality`: append a Data Quality appendix listing values skipped or defaulted during
+//     normalization to markdown, text, HTML, and PDF reports.
+//   - `--output-dir`: batch mode; write each input's report to `<hostname>-report.<ext>` in the
+//     directory, converting up to `--parallel` files at once.
+//   - `--strict-render`: fail when a report section cannot be rendered instead of writing a
+//     placeholder for it.
+//   - `--watch`: keep running and regenerate the `--output` file whenever the input changes,
+//     once it has been left alone for `--watch-debounce`; `--watch-poll` polls the input instead
+//     of relying on filesystem notifications.
 //
 // It also adds shared styling and content flags (sections, theme, wrap width, etc.) via addSharedContentFlags and
 // disables automatic flag sorting to preserve logical flag grouping in help output.
@@ -68,11 +130,19 @@ func init() {
 		StringVarP(&outputFile, "output", "o", "", "Output file path for saving converted configuration (default: print to console)")
 	setFlagAnnotation(convertCmd.Flags(), "output", []flagCategory{categoryOutput})
 	convertCmd.Flags().
-		StringVarP(&format, "format", "f", "markdown", "Output format for conversion (markdown, json, yaml, text, html)")
+		StringVarP(&format, "format", "f", "markdown", "Output format for conversion (markdown, json, yaml, text, html, pdf)")
 	setFlagAnnotation(convertCmd.Flags(), "format", []flagCategory{categoryOutput})
 	convertCmd.Flags().
 		BoolVar(&force, "force", false, "Force overwrite existing files without prompting for confirmation")
 	setFlagAnnotation(convertCmd.Flags(), "force", []flagCategory{categoryOutput})
+	convertCmd.Flags().
+		BoolVar(&statsOnly, "stats", false, "Print configuration statistics as JSON and exit without converting")
+	setFlagAnnotation(convertCmd.Flags(), "stats", []flagCategory{categoryOutput})
+	convertCmd.Flags().
+		StringVar(&tmplFile, "template", "", "Render output with a Go template file instead of a built-in format")
+	setFlagAnnotation(convertCmd.Flags(), "template", []flagCategory{categoryOutput})
+	convertCmd.MarkFlagsMutuallyExclusive("template", "format")
+	convertCmd.MarkFlagsMutuallyExclusive("template", "stats")
 
 	// Add shared styling and content flags
 	addSharedContentFlags(convertCmd)
@@ -80,6 +150,79 @@ func init() {
 	// Add shared redact flag
 	addSharedRedactFlag(convertCmd)
 
+	// Add port risk table flag for the port forward exposure check
+	addPortRiskFlag(convertCmd)
+
+	// Source embedding is registered after --redact so the exclusion below can
+	// reference it: a redacted export must not carry the unredacted source.
+	convertCmd.Flags().
+		BoolVar(&embedSource, "embed-source", false,
+			"Embed the original config file (zlib-compressed, base64) under _meta.source of JSON/YAML output")
+	setFlagAnnotation(convertCmd.Flags(), "embed-source", []flagCategory{categoryOutput})
+	convertCmd.Flags().
+		Int64Var(&embedSourceLimit, "embed-source-limit", converter.DefaultEmbedSourceLimit,
+			"Maximum size in bytes of a config file embedded with --embed-source")
+	setFlagAnnotation(convertCmd.Flags(), "embed-source-limit", []flagCategory{categoryOutput})
+	convertCmd.MarkFlagsMutuallyExclusive("embed-source", "redact")
+	convertCmd.MarkFlagsMutuallyExclusive("embed-source", "template")
+	convertCmd.MarkFlagsMutuallyExclusive("embed-source", "stats")
+
+	convertCmd.Flags().
+		BoolVar(&dataQuality, "data-quality", false,
+			"Append a Data Quality appendix listing values skipped or defaulted during normalization (markdown, text, html, pdf)")
+	setFlagAnnotation(convertCmd.Flags(), "data-quality", []flagCategory{categoryOutput})
+	convertCmd.MarkFlagsMutuallyExclusive("data-quality", "template")
+	convertCmd.MarkFlagsMutuallyExclusive("data-quality", "stats")
+
+	convertCmd.Flags().
+		StringVar(&filterInterface, "filter-interface", "", "List only firewall and NAT rules on this interface (e.g., wan)")
+	setFlagAnnotation(convertCmd.Flags(), "filter-interface", []flagCategory{categoryContent})
+	convertCmd.Flags().
+		StringVar(&filterAction, "filter-action", "", "List only firewall rules with this action (pass, block, reject)")
+	setFlagAnnotation(convertCmd.Flags(), "filter-action", []flagCategory{categoryContent})
+	convertCmd.Flags().
+		StringVar(&filterSearch, "filter-search", "",
+			"List only firewall and NAT rules whose description, source, destination, or ports contain this text")
+	setFlagAnnotation(convertCmd.Flags(), "filter-search", []flagCategory{categoryContent})
+	for _, name := range []string{"filter-interface", "filter-action", "filter-search"} {
+		convertCmd.MarkFlagsMutuallyExclusive(name, "template")
+		convertCmd.MarkFlagsMutuallyExclusive(name, "stats")
+	}
+
+	convertCmd.Flags().
+		StringVar(&outputDir, "output-dir", "",
+			"Batch mode: write each report to <hostname>-report.<ext> in this directory (inputs may be directories or globs)")
+	setFlagAnnotation(convertCmd.Flags(), "output-dir", []flagCategory{categoryOutput})
+	convertCmd.Flags().
+		IntVar(&parallelism, "parallel", 0, "Number of files converted at once with --output-dir (default: number of CPUs)")
+	setFlagAnnotation(convertCmd.Flags(), "parallel", []flagCategory{categoryOutput})
+	for _, other := range []string{"output", "stats", "template", "embed-source", "data-quality"} {
+		convertCmd.MarkFlagsMutuallyExclusive("output-dir", other)
+	}
+
+	convertCmd.Flags().
+		BoolVar(&strictRender, "strict-render", false,
+			"Fail when a report section cannot be rendered instead of writing a placeholder and exiting with code 6")
+	setFlagAnnotation(convertCmd.Flags(), "strict-render", []flagCategory{categoryOutput})
+	convertCmd.MarkFlagsMutuallyExclusive("strict-render", "template")
+	convertCmd.MarkFlagsMutuallyExclusive("strict-render", "stats")
+
+	convertCmd.Flags().
+		BoolVar(&watchMode, "watch", false,
+			"Keep running and regenerate the --output file whenever the input file changes")
+	setFlagAnnotation(convertCmd.Flags(), "watch", []flagCategory{categoryOutput})
+	convertCmd.Flags().
+		DurationVar(&watchDebounce, "watch-debounce", watch.DefaultDebounce,
+			"How long the input must stay unchanged after a write before --watch regenerates the output")
+	setFlagAnnotation(convertCmd.Flags(), "watch-debounce", []flagCategory{categoryOutput})
+	convertCmd.Flags().
+		BoolVar(&watchPoll, "watch-poll", false,
+			"Poll the input for --watch instead of relying on filesystem notifications (for network filesystems)")
+	setFlagAnnotation(convertCmd.Flags(), "watch-poll", []flagCategory{categoryOutput})
+	for _, other := range []string{"output-dir", "stats"} {
+		convertCmd.MarkFlagsMutuallyExclusive("watch", other)
+	}
+
 	// Register flag completion functions for better tab completion
 	registerConvertFlagCompletions(convertCmd)
 
@@ -99,6 +242,23 @@ func registerConvertFlagCompletions(cmd *cobra.Command) {
 	if err := cmd.RegisterFlagCompletionFunc("section", ValidSections); err != nil {
 		logger.Debug("failed to register section completion", "error", err)
 	}
+	if err := cmd.RegisterFlagCompletionFunc("section-order", ValidSections); err != nil {
+		logger.Debug("failed to register section-order completion", "error", err)
+	}
+
+	// Rule filter action completion
+	if err := cmd.RegisterFlagCompletionFunc("filter-action", ValidRuleFilterActions); err != nil {
+		logger.Debug("failed to register filter-action completion", "error", err)
+	}
+}
+
+// ValidRuleFilterActions provides completion for the filter-action flag.
+func ValidRuleFilterActions(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	return []string{
+		"pass\tRules that allow traffic",
+		"block\tRules that silently drop traffic",
+		"reject\tRules that drop traffic and notify the sender",
+	}, cobra.ShellCompDirectiveNoFileComp
 }
 
 // convertCmd is the cobra.Command for the convert subcommand.
@@ -138,25 +298,86 @@ OUTPUT FORMATS:
     yaml      - YAML export for configuration management
     text      - Plain text (markdown without ANSI formatting)
     html      - Self-contained HTML report
+    pdf       - PDF document of the comprehensive report (requires --output
+                or redirected stdout)
 
 CONTENT OPTIONS:
   --comprehensive    - Emit every section, including rarely used ones
   --include-tunables - Include all system tunables (default suppresses defaults)
   --section          - Restrict output to specific sections (e.g. system,firewall)
+  --section-order    - Render the listed sections first (e.g. security,system)
   --wrap / --no-wrap - Control text wrapping for terminal rendering
   --redact           - Redact passwords, SNMP community strings, private keys
 
+RULE FILTERS:
+  --filter-interface, --filter-action, and --filter-search narrow the
+  firewall rule and NAT tables to the rules matching every filter given.
+  Matching is case-insensitive; the search text is matched against each
+  rule's description, source, destination, and ports. NAT rules have no
+  action and are narrowed by interface and search only. Each filtered table
+  is preceded by the active filters and a "showing 37 of 412 rules" count.
+  JSON and YAML exports list only the matching rules and record the filters
+  under _meta.ruleFilter. Rules keep their original numbers.
+
 OUTPUT DESTINATION:
   By default, output is printed to stdout. Use --output/-o to save to a file.
   When processing multiple input files, --output is ignored and each output
   file is auto-named after the input (config.xml -> config.md, config.json, ...).
   Use --force to overwrite existing files without prompting.
 
+BATCH MODE:
+  --output-dir converts every input into <hostname>-report.<ext> inside the
+  given directory, creating it if needed. Inputs may be files, directories
+  (every *.xml file directly inside), or quoted glob patterns. Up to
+  --parallel files are converted at once (default: number of CPUs). A device
+  without a hostname is named after its input file, and a repeated hostname
+  gets a numeric suffix (fw-2-report.md). Existing reports are only replaced
+  with --force. One failing input does not stop the others.
+
+SECTION FAILURES:
+  A report section that fails to render, for example on configuration data
+  the renderer does not expect, is replaced by a warning placeholder and the
+  other sections are still written. The affected sections are listed on
+  stderr and convert exits with code 6. --strict-render makes such a failure
+  an error instead: the report is not written and convert exits non-zero.
+
+WATCH MODE:
+  --watch writes the report, then keeps running and regenerates the --output
+  file whenever the single input file changes, until interrupted with Ctrl+C.
+  A burst of writes triggers one regeneration once the input has been left
+  alone for --watch-debounce (default 500ms). Reports are written to a
+  temporary file and renamed into place, and each regeneration prints a
+  timestamped line with its duration on stderr. An input that fails to parse,
+  such as a half-written export, is reported and the previous report is kept.
+  --watch-poll checks the input every second instead of relying on filesystem
+  notifications, for network filesystems that deliver none.
+
+CUSTOM TEMPLATES:
+  --template renders each configuration with a Go text/template file instead
+  of a built-in format. The parsed device model is the template's data root
+  ({{.System.Hostname}}, {{range .FirewallRules}}, ...), and the helper
+  functions countRules, enabledInterfaces, formatIP, join, and default are
+  available. Files named *.html or *.html.tmpl use html/template escaping.
+  Auto-named outputs take the template's extension with .tmpl removed.
+
+SOURCE ARCHIVING:
+  --embed-source stores the exact input file, zlib-compressed and base64-
+  encoded, under _meta.source of JSON and YAML output together with its file
+  name, size, and SHA-256. Recover it with 'opnDossier extract-source'. Files
+  larger than --embed-source-limit bytes (default 64 MiB) are refused.
+  --embed-source cannot be combined with --redact, --template, or --stats.
+
+STATISTICS:
+  --stats prints a JSON count summary (rules, interfaces, DHCP scopes, users,
+  aliases, certificates, ...) for each OPNsense input and exits without
+  converting. One object is written per input file, in argument order.
+
 RELATED:
   audit      - Convert plus compliance checks (STIG/SANS/firewall)
   display    - Convert then render to the terminal in one step
   validate   - Validate config.xml before conversion
-  sanitize   - Redact a config.xml before distribution`,
+  sanitize   - Redact a config.xml before distribution
+  extract-source - Recover the config.xml embedded with --embed-source`,
 	Example: `  # Convert configuration to markdown (default)
   opnDossier convert my_config.xml
 
@@ -169,18 +390,42 @@ RELATED:
   # Convert to self-contained HTML
   opnDossier convert my_config.xml --format html -o report.html
 
+  # Export the comprehensive report as a PDF document
+  opnDossier convert my_config.xml --format pdf -o report.pdf
+
   # Generate a comprehensive report
   opnDossier convert my_config.xml --comprehensive
 
   # Convert only specific sections
   opnDossier convert my_config.xml --section system,network
 
+  # Put firewall and NAT rules first in the report
+  opnDossier convert my_config.xml --section-order security
+
+  # List only the block rules on the WAN interface
+  opnDossier convert my_config.xml --filter-interface wan --filter-action block
+
   # Convert multiple files to JSON (each output auto-named)
   opnDossier convert config1.xml config2.xml --format json
 
+  # Convert a directory of configs into per-device reports
+  opnDossier convert configs/ --output-dir reports/ --format html
+
   # Redact sensitive fields (passwords, SNMP community strings, private keys)
   opnDossier convert config.xml --format json --redact
 
+  # Regenerate the report whenever the config changes
+  opnDossier convert config.xml -f html -o report.html --watch
+
+  # Render a custom report from a Go template
+  opnDossier convert config.xml --template summary.md.tmpl -o summary.md
+
+  # Archive the export together with the exact source file
+  opnDossier convert config.xml -f json --embed-source -o archive.json
+
+  # Print a quick count summary of the configuration
+  opnDossier convert config.xml --stats
+
   # Validate then convert (recommended workflow)
   opnDossier validate config.xml && opnDossier convert config.xml -f json -o output.json`,
 	Args: cobra.MinimumNArgs(1),
@@ -192,6 +437,9 @@ RELATED:
 // deterministic error aggregation after wg.Wait().
 type convertResult struct {
 	err error
+	// partial records the sections replaced by placeholders in a report that
+	// was otherwise written.
+	partial *builder.PartialReportError
 }
 
 // runConvert processes one or more configuration files through the convert
@@ -219,10 +467,41 @@ func runConvert(cmd *cobra.Command, args []string) error {
 	cmdLogger := cmdCtx.Logger
 	cmdConfig := cmdCtx.Config
 
+	if statsOnly {
+		return runConvertStats(ctx, cmd.OutOrStdout(), args)
+	}
+
+	if outputDir != "" {
+		return runConvertBatch(ctx, cmd.ErrOrStderr(), args, cmdConfig, cmdLogger)
+	}
+
+	if embedSource {
+		if err := validateEmbedSource(buildEffectiveFormat(format, cmdConfig), embedSourceLimit); err != nil {
+			return err
+		}
+	}
+
+	// Compile the template once up front so syntax errors fail fast, before
+	// any input is parsed. The compiled template is shared by all workers.
+	var tmpl *converter.TemplateConverter
+	if tmplFile != "" {
+		var err error
+		if tmpl, err = converter.NewTemplateConverter(tmplFile); err != nil {
+			return err
+		}
+	}
+
+	if watchMode {
+		return runConvertWatch(ctx, cmd.ErrOrStderr(), args, tmpl, cmdConfig, cmdLogger)
+	}
+
 	// Create a timeout context for file processing
 	timeoutCtx, cancel := context.WithTimeout(ctx, constants.DefaultProcessingTimeout)
 	defer cancel()
 
+	// Progress is only rendered for interactive single-file runs; see newProgressTracker.
+	timeoutCtx = progress.NewContext(timeoutCtx, newProgressTracker(cmdConfig, len(args)))
+
 	// Use a semaphore to limit concurrent file operations.
 	// This prevents resource exhaustion when processing many files.
 	maxConcurrent := max(runtime.NumCPU(), 1)
@@ -243,7 +522,7 @@ func runConvert(cmd *cobra.Command, args []string) error {
 		go func(idx int, fp string) {
 			defer wg.Done()
 
-			results[idx] = processConvertFile(timeoutCtx, fp, sem, cmd, cmdLogger, cmdConfig)
+			results[idx] = processConvertFile(timeoutCtx, fp, sem, tmpl, cmd, cmdLogger, cmdConfig)
 		}(i, filePath)
 	}
 
@@ -251,14 +530,225 @@ func runConvert(cmd *cobra.Command, args []string) error {
 
 	// Aggregate errors in input order via errors.Join for proper Unwrap() support.
 	var allErrors []error
+	partials := make(map[string]*builder.PartialReportError)
 
-	for _, r := range results {
+	for i, r := range results {
 		if r.err != nil {
 			allErrors = append(allErrors, r.err)
 		}
+		if r.partial != nil {
+			partials[args[i]] = r.partial
+		}
 	}
 
-	return errors.Join(allErrors...)
+	return finishConvert(cmd.ErrOrStderr(), args, partials, errors.Join(allErrors...))
+}
+
+// finishConvert returns err after listing on w the report sections of each
+// input that were replaced by placeholders, in input order. When sections
+// were replaced and no input failed, it exits with ExitRenderWarning so
+// automation can tell a degraded report from a complete one.
+func finishConvert(w io.Writer, inputs []string, partials map[string]*builder.PartialReportError, err error) error {
+	if len(partials) == 0 {
+		return err
+	}
+
+	count := 0
+	for _, partial := range partials {
+		count += len(partial.Sections)
+	}
+
+	fmt.Fprintf(w, "⚠️  %d report section(s) could not be rendered and were replaced by placeholders:\n", count)
+	for _, input := range inputs {
+		if partial, ok := partials[input]; ok {
+			for _, section := range partial.Sections {
+				fmt.Fprintf(w, "  %s: %v\n", input, section)
+			}
+		}
+	}
+	fmt.Fprintln(w, "Please report this with your config; use --strict-render to treat it as an error.")
+
+	if err != nil {
+		return err
+	}
+
+	convertExit(ExitRenderWarning)
+	return nil
+}
+
+// partialReport returns err as a *builder.PartialReportError, or nil when
+// err is not one.
+func partialReport(err error) *builder.PartialReportError {
+	var partial *builder.PartialReportError
+	if errors.As(err, &partial) {
+		return partial
+	}
+	return nil
+}
+
+// runConvertBatch expands args into configuration files and converts them
+// with converter.BatchConvert into --output-dir, which is created if needed.
+// Each written report is listed on w; per-file errors are joined in input
+// order.
+func runConvertBatch(
+	ctx context.Context,
+	w io.Writer,
+	args []string,
+	cmdConfig *config.Config,
+	cmdLogger *logging.Logger,
+) error {
+	inputs, err := expandBatchInputs(args)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(outputDir, 0o750); err != nil {
+		return fmt.Errorf("failed to create output directory %s: %w", outputDir, err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, constants.DefaultProcessingTimeout)
+	defer cancel()
+
+	conversion := buildConversionOptions(buildEffectiveFormat(format, cmdConfig), cmdConfig)
+	opts := converter.ConvertOptions{
+		Options:     conversion,
+		DeviceType:  resolveDeviceType(),
+		Parallelism: parallelism,
+		Force:       force,
+		Logger:      cmdLogger,
+		Export:      export.NewFileExporter(cmdLogger, export.WithBinaryContent(conversion.Format.IsBinary())).Export,
+	}
+
+	var allErrors []error
+	partials := make(map[string]*builder.PartialReportError)
+	for _, r := range converter.BatchConvert(ctx, inputs, outputDir, opts) {
+		if partial := partialReport(r.Err); partial != nil {
+			logRenderFailures(cmdLogger.WithFields("input_file", r.Input), partial)
+			partials[r.Input] = partial
+		} else if r.Err != nil {
+			cmdLogger.Error("Failed to convert", "input_file", r.Input, "error", r.Err)
+			allErrors = append(allErrors, r.Err)
+			continue
+		}
+
+		fmt.Fprintf(w, "Converted %s → %s\n", r.Input, r.Output)
+	}
+
+	return finishConvert(w, inputs, partials, errors.Join(allErrors...))
+}
+
+// logRenderFailures logs a warning for each section of partial that was
+// replaced by a placeholder.
+func logRenderFailures(ctxLogger *logging.Logger, partial *builder.PartialReportError) {
+	for _, section := range partial.Sections {
+		ctxLogger.Warn("Report section could not be rendered", "section", section.Section, "error", section.Err)
+	}
+}
+
+// expandBatchInputs resolves batch mode arguments into configuration file
+// paths, in argument order. A directory contributes the *.xml files directly
+// inside it, sorted by name; an argument containing glob metacharacters
+// contributes its matches; any other argument is taken as a file path.
+func expandBatchInputs(args []string) ([]string, error) {
+	var inputs []string
+
+	for _, arg := range args {
+		if info, err := os.Stat(arg); err == nil && info.IsDir() {
+			matches, err := filepath.Glob(filepath.Join(arg, "*.xml"))
+			if err != nil {
+				return nil, fmt.Errorf("failed to list %s: %w", arg, err)
+			}
+			if len(matches) == 0 {
+				return nil, fmt.Errorf("%w: no .xml files in directory %s", ErrNoBatchInputs, arg)
+			}
+			inputs = append(inputs, matches...)
+			continue
+		}
+
+		if strings.ContainsAny(arg, "*?[") {
+			matches, err := filepath.Glob(arg)
+			if err != nil {
+				return nil, fmt.Errorf("invalid glob pattern %q: %w", arg, err)
+			}
+			if len(matches) == 0 {
+				return nil, fmt.Errorf("%w: pattern %q matched no files", ErrNoBatchInputs, arg)
+			}
+			inputs = append(inputs, matches...)
+			continue
+		}
+
+		inputs = append(inputs, arg)
+	}
+
+	return inputs, nil
+}
+
+// convertStatsReport is the --stats JSON object written for one input: its
+// configuration statistics followed by the normalization issues reported
+// while converting it.
+type convertStatsReport struct {
+	schema.ConfigStatistics
+
+	Issues []common.ConversionWarning `json:"issues,omitempty"`
+}
+
+// runConvertStats parses each input as an OPNsense document and writes its
+// [schema.ConfigStatistics], together with the conversion warnings of the
+// document, to w as an indented JSON object. Files are handled sequentially
+// so objects appear in argument order; the first failure stops processing
+// and is returned wrapped with the offending path.
+func runConvertStats(ctx context.Context, w io.Writer, args []string) error {
+	if dt := resolveDeviceType(); dt != common.DeviceTypeUnknown && dt != common.DeviceTypeOPNsense {
+		return fmt.Errorf("%w: --stats is only supported for %s configurations, got %s",
+			ErrUnsupportedStatsDevice, common.DeviceTypeOPNsense, dt)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, constants.DefaultProcessingTimeout)
+	defer cancel()
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	for _, fp := range args {
+		doc, err := parseStatsInput(ctx, fp)
+		if err != nil {
+			return err
+		}
+
+		_, warnings, err := opnsenseparser.ConvertDocument(doc)
+		if err != nil {
+			return fmt.Errorf("failed to convert configuration from %s: %w", fp, err)
+		}
+
+		report := convertStatsReport{ConfigStatistics: doc.Statistics(), Issues: warnings}
+		if err := enc.Encode(report); err != nil {
+			return fmt.Errorf("failed to write statistics for %s: %w", fp, err)
+		}
+	}
+
+	return nil
+}
+
+// parseStatsInput opens fp and parses it into the raw OPNsense schema document.
+// Unlike parseConvertInput it skips conversion to CommonDevice, since
+// statistics are counted directly from the XML structure.
+func parseStatsInput(ctx context.Context, fp string) (doc *schema.OpnSenseDocument, err error) {
+	file, err := os.Open(filepath.Clean(fp))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file %s: %w", fp, err)
+	}
+	defer func() {
+		if cerr := file.Close(); cerr != nil && err == nil {
+			err = fmt.Errorf("failed to close config file %s: %w", fp, cerr)
+		}
+	}()
+
+	doc, err = cfgparser.NewXMLParser().Parse(ctx, file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse configuration from %s: %w", fp, err)
+	}
+
+	return doc, nil
 }
 
 // processConvertFile runs the full convert pipeline for a single input file
@@ -267,6 +757,9 @@ func runConvert(cmd *cobra.Command, args []string) error {
 // stdout — preserving the pre-refactor behavior where emission happens
 // inside the worker (unlike audit, which defers emission to the parent).
 //
+// When tmpl is non-nil the device is rendered with the user-supplied template
+// instead of the format selected by --format.
+//
 // A context timeout or cancellation before the semaphore is acquired returns
 // the ctx error wrapped with the input file path. All subsequent failures are
 // wrapped with the input file path so aggregated errors identify the offending
@@ -275,6 +768,7 @@ func processConvertFile(
 	ctx context.Context,
 	fp string,
 	sem chan struct{},
+	tmpl *converter.TemplateConverter,
 	cmd *cobra.Command,
 	cmdLogger *logging.Logger,
 	cmdConfig *config.Config,
@@ -289,36 +783,85 @@ func processConvertFile(
 
 	ctxLogger := cmdLogger.WithFields("input_file", fp)
 
-	device, err := parseConvertInput(ctx, fp, ctxLogger, cmdConfig)
+	// Clear the progress line on every failure path; the success path below
+	// reports completion before any output reaches stdout.
+	tracker := progress.FromContext(ctx)
+	defer tracker.Done("")
+
+	device, source, issues, err := parseConvertInput(ctx, fp, ctxLogger, cmdConfig)
 	if err != nil {
 		return convertResult{err: err}
 	}
 
-	eff := buildEffectiveFormat(format, cmdConfig)
-	opt := buildConversionOptions(eff, cmdConfig)
-	ctxLogger.Debug("Converting with options", "format", opt.Format, "theme", opt.Theme, "sections", opt.Sections)
-
-	output, handler, err := generateOutputByFormat(ctx, device, opt, ctxLogger)
-	if err != nil {
+	output, fileExt, err := renderConvertOutput(ctx, device, source, issues, tmpl, cmdConfig, ctxLogger)
+	partial := partialReport(err)
+	if partial != nil {
+		logRenderFailures(ctxLogger, partial)
+	} else if err != nil {
 		ctxLogger.Error("Failed to convert", "error", err)
 		return convertResult{err: fmt.Errorf("failed to convert from %s: %w", fp, err)}
 	}
 
 	ctxLogger.Debug("Conversion completed successfully")
+	tracker.Done("Report generated")
 
-	actualOutputFile, err := determineOutputPath(fp, outputFile, handler.FileExtension(), cmdConfig, force)
+	actualOutputFile, err := determineOutputPath(fp, outputFile, fileExt, cmdConfig, force)
 	if err != nil {
 		ctxLogger.Error("Failed to determine output path", "error", err)
 		return convertResult{err: fmt.Errorf("failed to determine output path for %s: %w", fp, err)}
 	}
 
-	if err := emitConvertOutput(ctx, cmd, ctxLogger, output, actualOutputFile); err != nil {
+	binary := convertOutputIsBinary(tmpl, cmdConfig)
+	if err := emitConvertOutput(ctx, cmd, ctxLogger, output, actualOutputFile, binary); err != nil {
 		return convertResult{err: err}
 	}
-	return convertResult{}
+	return convertResult{partial: partial}
+}
+
+// renderConvertOutput renders device with tmpl when it is non-nil, otherwise
+// in the effective --format with source, when non-nil, embedded in the
+// export. With --data-quality, issues are listed in the report's Data Quality
+// appendix. It returns the output and the file extension used when the output
+// path is derived from the input file name.
+// A report with sections replaced by placeholders is returned together with
+// its *builder.PartialReportError.
+func renderConvertOutput(
+	ctx context.Context,
+	device *common.CommonDevice,
+	source *common.EmbeddedSource,
+	issues []common.ConversionWarning,
+	tmpl *converter.TemplateConverter,
+	cmdConfig *config.Config,
+	ctxLogger *logging.Logger,
+) (string, string, error) {
+	if tmpl != nil {
+		ctxLogger.Debug("Converting with template", "template", tmplFile)
+		output, err := tmpl.Render(ctx, device, sharedRedact)
+		if err != nil {
+			return "", "", err
+		}
+		return output, tmpl.FileExtension(), nil
+	}
+
+	eff := buildEffectiveFormat(format, cmdConfig)
+	opt := buildConversionOptions(eff, cmdConfig).WithEmbeddedSource(source)
+	if dataQuality {
+		opt = opt.WithDataQuality(issues)
+	}
+	ctxLogger.Debug("Converting with options", "format", opt.Format, "theme", opt.Theme, "sections", opt.Sections)
+
+	output, handler, err := generateOutputByFormat(ctx, device, opt, ctxLogger)
+	if handler == nil {
+		return "", "", err
+	}
+	return output, handler.FileExtension(), err
 }
 
-// parseConvertInput cleans fp, opens the file, and parses it into a CommonDevice.
+// parseConvertInput cleans fp, opens the file, and parses it into a CommonDevice,
+// also returning the issues found while normalizing it.
+// With --embed-source the file is read into memory first and also returned
+// as an EmbeddedSource, so the embedded bytes are exactly the parsed bytes;
+// otherwise the source is nil and the file is streamed to the parser.
 // All parse-side logging (Debug success, Warn per conversion warning, Error
 // with detailed parse/validation context) stays here so processConvertFile
 // remains a straightforward orchestrator. Distinct from diff.go's
@@ -328,19 +871,19 @@ func parseConvertInput(
 	fp string,
 	ctxLogger *logging.Logger,
 	cmdConfig *config.Config,
-) (*common.CommonDevice, error) {
+) (*common.CommonDevice, *common.EmbeddedSource, []common.ConversionWarning, error) {
 	cleanPath := filepath.Clean(fp)
 	if !filepath.IsAbs(cleanPath) {
 		abs, err := filepath.Abs(cleanPath)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get absolute path for %s: %w", fp, err)
+			return nil, nil, nil, fmt.Errorf("failed to get absolute path for %s: %w", fp, err)
 		}
 		cleanPath = abs
 	}
 
 	file, err := os.Open(cleanPath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open file %s: %w", fp, err)
+		return nil, nil, nil, fmt.Errorf("failed to open file %s: %w", fp, err)
 	}
 	defer func() {
 		if cerr := file.Close(); cerr != nil {
@@ -348,9 +891,20 @@ func parseConvertInput(
 		}
 	}()
 
+	input := trackParseInput(file, progress.FromContext(ctx))
+
+	var source *common.EmbeddedSource
+	if embedSource {
+		var content []byte
+		if source, content, err = loadEmbeddedSource(file, input, fp, embedSourceLimit); err != nil {
+			return nil, nil, nil, err
+		}
+		input = bytes.NewReader(content)
+	}
+
 	ctxLogger.Debug("Parsing configuration file")
 	device, warnings, err := parser.NewFactory(cfgparser.NewXMLParser()).
-		CreateDevice(ctx, file, resolveDeviceType(), false)
+		CreateDevice(ctx, input, resolveDeviceType(), false)
 	if err != nil {
 		ctxLogger.Error("Failed to parse configuration", "error", err)
 		if cfgparser.IsParseError(err) {
@@ -362,33 +916,83 @@ func parseConvertInput(
 		if cfgparser.IsValidationError(err) {
 			ctxLogger.Error("Configuration validation failed")
 		}
-		return nil, fmt.Errorf("failed to parse configuration from %s: %w", fp, err)
+		return nil, nil, nil, explainParseError(fmt.Errorf("failed to parse configuration from %s: %w", fp, err))
 	}
 
 	ctxLogger.Debug("Configuration parsed successfully")
 	if cmdConfig == nil || !cmdConfig.IsQuiet() {
 		for _, w := range warnings {
 			ctxLogger.Warn("conversion warning",
-				"field", w.Field, "message", w.Message, "severity", w.Severity)
+				"field", w.Field, "value", w.Value, "message", w.Message, "action", w.Action, "severity", w.Severity)
 		}
 	}
-	return device, nil
+	if err := checkConfigCompatibility(device, ctxLogger, cmdConfig != nil && cmdConfig.IsQuiet()); err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to parse configuration from %s: %w", fp, err)
+	}
+	return device, source, warnings, nil
+}
+
+// loadEmbeddedSource reads input, the tracked parse stream of file, in full
+// and encodes it for --embed-source. Regular files larger than limit are
+// refused before any bytes are read; other inputs are cut off after limit+1
+// bytes so an oversized stream is never buffered completely.
+func loadEmbeddedSource(
+	file *os.File,
+	input io.Reader,
+	fp string,
+	limit int64,
+) (*common.EmbeddedSource, []byte, error) {
+	if info, err := file.Stat(); err == nil && info.Mode().IsRegular() && info.Size() > limit {
+		return nil, nil, fmt.Errorf("%w: %s is %d bytes, limit is %d bytes (see --embed-source-limit)",
+			converter.ErrSourceTooLarge, fp, info.Size(), limit)
+	}
+
+	content, err := io.ReadAll(io.LimitReader(input, limit+1))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read %s: %w", fp, err)
+	}
+
+	source, err := converter.NewEmbeddedSource(fp, content, limit)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return source, content, nil
+}
+
+// validateEmbedSource checks that --embed-source is paired with a JSON or
+// YAML output format and a positive --embed-source-limit.
+func validateEmbedSource(effectiveFormat string, limit int64) error {
+	switch normalizeFormat(effectiveFormat) {
+	case converter.FormatJSON, converter.FormatYAML:
+	default:
+		return fmt.Errorf("%w (--embed-source), got %q", ErrEmbedSourceFormat, effectiveFormat)
+	}
+
+	if limit <= 0 {
+		return fmt.Errorf("%w (--embed-source-limit), got %d", ErrInvalidEmbedSourceLimit, limit)
+	}
+
+	return nil
 }
 
 // emitConvertOutput writes the converted report to actualOutputFile when
-// non-empty, otherwise to cmd's stdout. The enhanced logger tags every log
-// line with either output_file or output_mode=stdout so CLI logs stay
-// attributable when multiple inputs run concurrently.
+// non-empty, otherwise to cmd's stdout. binary marks a binary report, such as
+// a PDF, which is written byte for byte and refused by a terminal. The
+// enhanced logger tags every log line with either output_file or
+// output_mode=stdout so CLI logs stay attributable when multiple inputs run
+// concurrently.
 func emitConvertOutput(
 	ctx context.Context,
 	cmd *cobra.Command,
 	ctxLogger *logging.Logger,
 	output, actualOutputFile string,
+	binary bool,
 ) error {
 	if actualOutputFile != "" {
 		enhancedLogger := ctxLogger.WithFields("output_file", actualOutputFile)
 		enhancedLogger.Debug("Exporting to file")
-		e := export.NewFileExporter(ctxLogger)
+		e := export.NewFileExporter(ctxLogger, export.WithBinaryContent(binary))
 		if err := e.Export(ctx, output, actualOutputFile); err != nil {
 			enhancedLogger.Error("Failed to export output", "error", err)
 			return fmt.Errorf("failed to export output to %s: %w", actualOutputFile, err)
@@ -396,6 +1000,10 @@ func emitConvertOutput(
 		return nil
 	}
 
+	if err := checkStdoutAcceptsOutput(cmd.OutOrStdout(), binary); err != nil {
+		return err
+	}
+
 	enhancedLogger := ctxLogger.WithFields("output_mode", "stdout")
 	enhancedLogger.Debug("Outputting to stdout")
 	if _, err := fmt.Fprint(cmd.OutOrStdout(), output); err != nil {
@@ -405,6 +1013,24 @@ func emitConvertOutput(
 	return nil
 }
 
+// convertOutputIsBinary reports whether convert writes a binary report: the
+// effective --format is binary, such as PDF, and no --template
+// replaces it.
+func convertOutputIsBinary(tmpl *converter.TemplateConverter, cmdConfig *config.Config) bool {
+	return tmpl == nil && normalizeFormat(buildEffectiveFormat(format, cmdConfig)).IsBinary()
+}
+
+// checkStdoutAcceptsOutput rejects binary output bound for an interactive
+// terminal, where it would be unreadable and could leave the terminal in a
+// broken state. Redirected or piped stdout is accepted.
+func checkStdoutAcceptsOutput(w io.Writer, binary bool) error {
+	if binary && stdoutIsTerminal(w) {
+		return fmt.Errorf("%w; use --output to write the report to a file or redirect stdout", ErrBinaryOutputToTerminal)
+	}
+
+	return nil
+}
+
 // buildEffectiveFormat determines the output format to use, giving precedence to the CLI flag, then the configuration file, and defaulting to "markdown" if neither is set.
 func buildEffectiveFormat(flagFormat string, cfg *config.Config) string {
 	// CLI flag takes precedence
@@ -441,8 +1067,13 @@ func normalizeFormat(format string) converter.Format {
 //   - Theme: uses the theme from cfg when set.
 //   - WrapWidth: CLI wrap width if specified (>=0), otherwise cfg wrap width if >=0,
 //     otherwise -1 to indicate automatic behavior; 0 disables wrapping.
+//   - MaxWidth: set from the CLI-only max-width flag; 0 leaves prose unwrapped.
 //   - Comprehensive: controlled by the CLI-only comprehensive flag.
 //   - IncludeTunables: set from the CLI-only include-tunables flag.
+//   - NoPortNames: set from the CLI-only no-port-names flag.
+//   - SectionOrder: set from the CLI-only section-order flag.
+//   - RuleFilter: set from the CLI-only filter-interface, filter-action, and
+//     filter-search flags.
 //
 // The function returns a fully populated converter.Options ready for use by the
 // programmatic generator.
@@ -485,15 +1116,35 @@ func buildConversionOptions(
 		opt.WrapWidth = -1
 	}
 
+	// Max width: CLI flag only, so existing `wrap:` settings never start
+	// re-wrapping markdown prose
+	opt.MaxWidth = sharedMaxWidth
+
 	// Comprehensive: CLI flag only
 	opt.Comprehensive = sharedComprehensive
 
 	// Include tunables: CLI flag only
 	opt.IncludeTunables = sharedIncludeTunables
 
+	// Port names: CLI flag only
+	opt.NoPortNames = sharedNoPortNames
+
+	// Section order: CLI flag only
+	opt.SectionOrder = sharedSectionOrder
+
+	// Strict render: CLI flag only
+	opt.StrictRender = strictRender
+
 	// Redact: CLI flag only
 	opt.Redact = sharedRedact
 
+	// Rule filter: CLI flags only
+	opt.RuleFilter = analysis.RuleFilter{
+		Interface: strings.TrimSpace(filterInterface),
+		Action:    strings.ToLower(strings.TrimSpace(filterAction)),
+		Search:    strings.TrimSpace(filterSearch),
+	}
+
 	return opt
 }
 
@@ -530,42 +1181,15 @@ func determineOutputPath(inputFile, outputFile, fileExt string, cfg *config.Conf
 	}
 
 	// Check if file already exists and handle overwrite protection
-
-	if _, err := os.Stat(actualOutputFile); err == nil {
-		// File exists, check if we should overwrite
-		if !force {
-			// Prompt user for confirmation (using stderr to avoid interfering with piped output)
-
-			fmt.Fprintf(os.Stderr, "File '%s' already exists. Overwrite? (y/N): ", actualOutputFile)
-
-			// Use bufio.NewReader to correctly capture entire input line including spaces
-			reader := bufio.NewReader(os.Stdin)
-
-			response, err := reader.ReadString('\n')
-			if err != nil {
-				return "", fmt.Errorf("failed to read user input: %w", err)
-			}
-
-			// Trim whitespace and newline characters
-			response = strings.TrimSpace(response)
-
-			// Empty input defaults to "N" (no)
-			if response == "" {
-				response = "N"
-			}
-
-			// Only proceed if user explicitly confirms with 'y' or 'Y'
-			if response != "y" && response != "Y" {
-				return "", ErrOperationCancelled
-			}
-		}
+	if err := confirmOverwrite(actualOutputFile, force); err != nil {
+		return "", err
 	}
 
 	return actualOutputFile, nil
 }
 
 // generateOutputByFormat generates the document output in the requested format using the programmatic generator.
-// Supported formats are "markdown" (or "md"), "json", "yaml" (or "yml"), "text" (or "txt"), and "html" (or "htm").
+// Supported formats are "markdown" (or "md"), "json", "yaml" (or "yml"), "text" (or "txt"), "html" (or "htm"), and "pdf".
 // It returns the rendered output, the resolved FormatHandler (for file-extension lookups), or an error
 // if the format is unsupported or generation fails.
 //
@@ -589,12 +1213,13 @@ func generateOutputByFormat(
 	}
 
 	// Use programmatic generator for all formats.
-	// The HybridGenerator handles markdown (via builder), JSON, YAML, text, and HTML natively.
+	// The HybridGenerator handles markdown (via builder), JSON, YAML, text, HTML, and PDF natively.
+	// A partial report is returned with its *builder.PartialReportError.
 	output, err := generateWithProgrammaticGenerator(ctx, device, opt, logger)
-	if err != nil {
+	if err != nil && partialReport(err) == nil {
 		return "", nil, err
 	}
-	return output, handler, nil
+	return output, handler, err
 }
 
 // generateWithProgrammaticGenerator creates and uses a generator that produces output using the programmatic Markdown builder.
@@ -610,7 +1235,10 @@ func generateWithProgrammaticGenerator(
 	logger *logging.Logger,
 ) (string, error) {
 	// Create the programmatic builder
-	reportBuilder := builder.NewMarkdownBuilder()
+	reportBuilder := builder.NewMarkdownBuilder(
+		builder.WithProgress(progress.FromContext(ctx)),
+		builder.WithLogger(logger),
+	)
 
 	// Create hybrid generator (configured for programmatic mode)
 	hybridGen, err := converter.NewHybridGenerator(reportBuilder, logger)
@@ -631,8 +1259,29 @@ func normalizeConvertFlags() {
 }
 
 // validateConvertFlags validates flag combinations and CLI options for the convert command.
-// It delegates format, wrap, and section validation to validateOutputFlags.
+// It checks --filter-action and delegates format, wrap, and section validation to validateOutputFlags.
 // The cmdLogger parameter is used for structured warnings; if nil, warnings fall back to stderr.
 func validateConvertFlags(flags *pflag.FlagSet, cmdLogger *logging.Logger) error {
+	if err := validateRuleFilterAction(filterAction); err != nil {
+		return err
+	}
+
 	return validateOutputFlags(flags, cmdLogger)
 }
+
+// validateRuleFilterAction checks that action is empty or a firewall rule
+// action accepted by --filter-action.
+func validateRuleFilterAction(action string) error {
+	actions := []string{
+		string(common.RuleTypePass),
+		string(common.RuleTypeBlock),
+		string(common.RuleTypeReject),
+	}
+
+	action = strings.ToLower(strings.TrimSpace(action))
+	if action != "" && !slices.Contains(actions, action) {
+		return fmt.Errorf("invalid filter action %q, must be one of: %s", action, strings.Join(actions, ", "))
+	}
+
+	return nil
+}
@@ -2,14 +2,21 @@ package cmd
 
 import (
 	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/EvilBit-Labs/opnDossier/internal/analysis"
 	"github.com/EvilBit-Labs/opnDossier/internal/config"
 	"github.com/EvilBit-Labs/opnDossier/internal/converter"
+	"github.com/EvilBit-Labs/opnDossier/internal/converter/builder"
 	"github.com/EvilBit-Labs/opnDossier/internal/logging"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	schema "github.com/EvilBit-Labs/opnDossier/pkg/schema/opnsense"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"github.com/stretchr/testify/assert"
@@ -269,6 +276,40 @@ func TestBuildConversionOptionsIncludeTunables(t *testing.T) {
 	}
 }
 
+func TestBuildConversionOptionsNoPortNames(t *testing.T) {
+	origNoPortNames := sharedNoPortNames
+	t.Cleanup(func() { sharedNoPortNames = origNoPortNames })
+
+	sharedNoPortNames = false
+	assert.False(t, buildConversionOptions("markdown", nil).NoPortNames)
+
+	sharedNoPortNames = true
+	assert.True(t, buildConversionOptions("markdown", nil).NoPortNames)
+}
+
+func TestBuildConversionOptionsRuleFilter(t *testing.T) {
+	origInterface, origAction, origSearch := filterInterface, filterAction, filterSearch
+	t.Cleanup(func() { filterInterface, filterAction, filterSearch = origInterface, origAction, origSearch })
+
+	filterInterface, filterAction, filterSearch = "", "", ""
+	assert.False(t, buildConversionOptions("markdown", nil).RuleFilter.IsActive())
+
+	filterInterface, filterAction, filterSearch = " wan ", "Block", "web server"
+	assert.Equal(t,
+		analysis.RuleFilter{Interface: "wan", Action: "block", Search: "web server"},
+		buildConversionOptions("json", nil).RuleFilter)
+}
+
+func TestValidateRuleFilterAction(t *testing.T) {
+	for _, action := range []string{"", "pass", "Block", " reject "} {
+		assert.NoError(t, validateRuleFilterAction(action), action)
+	}
+
+	err := validateRuleFilterAction("allow")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid filter action "allow"`)
+}
+
 func TestBuildConversionOptionsWrapWidthPrecedence(t *testing.T) {
 	originalWrap := sharedWrapWidth
 	originalNoWrap := sharedNoWrap
@@ -353,6 +394,29 @@ func TestBuildConversionOptionsWrapWidthPrecedence(t *testing.T) {
 	}
 }
 
+func TestBuildConversionOptionsMaxWidth(t *testing.T) {
+	originalWrap := sharedWrapWidth
+	originalMaxWidth := sharedMaxWidth
+	t.Cleanup(func() {
+		sharedWrapWidth = originalWrap
+		sharedMaxWidth = originalMaxWidth
+	})
+
+	// A configured wrap width alone must not opt in to prose wrapping.
+	sharedWrapWidth = -1
+	sharedMaxWidth = 0
+	result := buildConversionOptions("markdown", &config.Config{WrapWidth: 80})
+	assert.Equal(t, 80, result.WrapWidth)
+	assert.Zero(t, result.MaxWidth)
+
+	// --max-width is independent of --wrap.
+	sharedWrapWidth = 100
+	sharedMaxWidth = 60
+	result = buildConversionOptions("markdown", nil)
+	assert.Equal(t, 100, result.WrapWidth)
+	assert.Equal(t, 60, result.MaxWidth)
+}
+
 func TestValidateConvertFlagsNoWrapMutualExclusivity(t *testing.T) {
 	originalWrap := sharedWrapWidth
 	originalNoWrap := sharedNoWrap
@@ -725,3 +789,409 @@ func findCommand(root *cobra.Command) *cobra.Command {
 
 	return nil
 }
+
+func TestRunConvertStats(t *testing.T) {
+	var stdout bytes.Buffer
+	err := runConvertStats(t.Context(), &stdout, []string{
+		filepath.Join("..", "testdata", "sample.config.1.xml"),
+		filepath.Join("..", "testdata", "opnsense-aliases.xml"),
+	})
+	require.NoError(t, err)
+
+	dec := json.NewDecoder(&stdout)
+
+	var first, second schema.ConfigStatistics
+	require.NoError(t, dec.Decode(&first))
+	require.NoError(t, dec.Decode(&second))
+	assert.False(t, dec.More(), "one object per input file")
+
+	assert.Equal(t, schema.ConfigStatistics{
+		FirewallRules: 2, EnabledFirewallRules: 2,
+		Interfaces: 2, EnabledInterfaces: 2,
+		DHCPScopes: 1, Users: 1, Groups: 1,
+	}, first)
+	assert.Equal(t, 6, second.Aliases)
+}
+
+func TestRunConvertStats_Issues(t *testing.T) {
+	var stdout bytes.Buffer
+	err := runConvertStats(t.Context(), &stdout, []string{filepath.Join("..", "testdata", "data_quality_test.xml")})
+	require.NoError(t, err)
+
+	var report convertStatsReport
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &report))
+	assert.Equal(t, 1, report.DHCPScopes)
+	assert.Len(t, report.Issues, 3)
+}
+
+func TestRunConvertStats_Errors(t *testing.T) {
+	t.Run("missing file", func(t *testing.T) {
+		var stdout bytes.Buffer
+		err := runConvertStats(t.Context(), &stdout, []string{filepath.Join(t.TempDir(), "missing.xml")})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to open file")
+		assert.Empty(t, stdout.String())
+	})
+
+	t.Run("pfsense device type", func(t *testing.T) {
+		orig := sharedDeviceType
+		sharedDeviceType = "pfsense"
+		t.Cleanup(func() { sharedDeviceType = orig })
+
+		var stdout bytes.Buffer
+		err := runConvertStats(t.Context(), &stdout, []string{filepath.Join("..", "testdata", "sample.config.1.xml")})
+		require.ErrorIs(t, err, ErrUnsupportedStatsDevice)
+	})
+}
+
+func TestConvertCmdStatsFlag(t *testing.T) {
+	convertCmd := findCommand(GetRootCmd())
+	require.NotNil(t, convertCmd)
+
+	statsFlag := convertCmd.Flags().Lookup("stats")
+	require.NotNil(t, statsFlag)
+	assert.Equal(t, "false", statsFlag.DefValue)
+}
+
+func TestConvertCmdTemplateFlag(t *testing.T) {
+	convertCmd := findCommand(GetRootCmd())
+	require.NotNil(t, convertCmd)
+
+	templateFlag := convertCmd.Flags().Lookup("template")
+	require.NotNil(t, templateFlag)
+	assert.Empty(t, templateFlag.DefValue)
+}
+
+func TestRenderConvertOutput_Template(t *testing.T) {
+	tmplPath := filepath.Join(t.TempDir(), "summary.md.tmpl")
+	require.NoError(t, os.WriteFile(tmplPath, []byte("# {{.System.Hostname}}\n"), 0o600))
+
+	tmpl, err := converter.NewTemplateConverter(tmplPath)
+	require.NoError(t, err)
+
+	logger, err := logging.New(logging.Config{Level: "warn"})
+	require.NoError(t, err)
+
+	device := &common.CommonDevice{System: common.System{Hostname: "fw01"}}
+
+	output, ext, err := renderConvertOutput(t.Context(), device, nil, nil, tmpl, nil, logger)
+	require.NoError(t, err)
+	assert.Equal(t, "# fw01\n", output)
+	assert.Equal(t, ".md", ext)
+}
+
+func TestConvertCmdEmbedSourceFlags(t *testing.T) {
+	convertCmd := findCommand(GetRootCmd())
+	require.NotNil(t, convertCmd)
+
+	embedFlag := convertCmd.Flags().Lookup("embed-source")
+	require.NotNil(t, embedFlag)
+	assert.Equal(t, "false", embedFlag.DefValue)
+
+	limitFlag := convertCmd.Flags().Lookup("embed-source-limit")
+	require.NotNil(t, limitFlag)
+	assert.Equal(t, "67108864", limitFlag.DefValue)
+}
+
+func TestValidateEmbedSource(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  string
+		limit   int64
+		wantErr error
+	}{
+		{name: "json", format: "json", limit: 1},
+		{name: "yaml alias", format: "yml", limit: 1},
+		{name: "markdown", format: "markdown", limit: 1, wantErr: ErrEmbedSourceFormat},
+		{name: "html", format: "html", limit: 1, wantErr: ErrEmbedSourceFormat},
+		{name: "zero limit", format: "json", limit: 0, wantErr: ErrInvalidEmbedSourceLimit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateEmbedSource(tt.format, tt.limit)
+			if tt.wantErr == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestCheckStdoutAcceptsOutput(t *testing.T) {
+	origIsTerminal := stdoutIsTerminal
+	t.Cleanup(func() { stdoutIsTerminal = origIsTerminal })
+
+	stdoutIsTerminal = func(io.Writer) bool { return false }
+	require.NoError(t, checkStdoutAcceptsOutput(io.Discard, true), "redirected stdout accepts binary output")
+
+	stdoutIsTerminal = func(io.Writer) bool { return true }
+	require.NoError(t, checkStdoutAcceptsOutput(io.Discard, false))
+	err := checkStdoutAcceptsOutput(io.Discard, true)
+	require.ErrorIs(t, err, ErrBinaryOutputToTerminal)
+	assert.Contains(t, err.Error(), "--output")
+}
+
+// setEmbedSourceFlags sets the --embed-source globals for one test and
+// restores them afterwards.
+func setEmbedSourceFlags(t *testing.T, limit int64, outputFormat string) {
+	t.Helper()
+
+	origEmbed, origLimit, origFormat := embedSource, embedSourceLimit, format
+	t.Cleanup(func() {
+		embedSource, embedSourceLimit, format = origEmbed, origLimit, origFormat
+	})
+	embedSource, embedSourceLimit, format = true, limit, outputFormat
+}
+
+func TestConvertEmbedSource_RoundTrip(t *testing.T) {
+	setEmbedSourceFlags(t, converter.DefaultEmbedSourceLimit, "json")
+
+	origOutput, origForce := extractSourceOutputFile, extractSourceForce
+	t.Cleanup(func() { extractSourceOutputFile, extractSourceForce = origOutput, origForce })
+
+	inputPath := filepath.Join("..", "testdata", "sample.config.1.xml")
+	want, err := os.ReadFile(inputPath)
+	require.NoError(t, err)
+
+	logger, err := logging.New(logging.Config{Level: "warn"})
+	require.NoError(t, err)
+
+	device, source, _, err := parseConvertInput(t.Context(), inputPath, logger, nil)
+	require.NoError(t, err)
+	require.NotNil(t, source)
+
+	output, ext, err := renderConvertOutput(t.Context(), device, source, nil, nil, nil, logger)
+	require.NoError(t, err)
+	assert.Equal(t, ".json", ext)
+
+	tmpDir := t.TempDir()
+	exportPath := filepath.Join(tmpDir, "archive.json")
+	recoveredPath := filepath.Join(tmpDir, "config.xml")
+	require.NoError(t, os.WriteFile(exportPath, []byte(output), 0o600))
+
+	rootCmd := GetRootCmd()
+	rootCmd.SetArgs([]string{"extract-source", exportPath, "-o", recoveredPath})
+	require.NoError(t, rootCmd.Execute())
+
+	got, err := os.ReadFile(recoveredPath)
+	require.NoError(t, err)
+	assert.Equal(t, want, got, "extracted config must be byte-identical to the converted input")
+}
+
+func TestExtractSource_WritesToCommandOutput(t *testing.T) {
+	setEmbedSourceFlags(t, converter.DefaultEmbedSourceLimit, "yaml")
+
+	origOutput, origForce := extractSourceOutputFile, extractSourceForce
+	t.Cleanup(func() { extractSourceOutputFile, extractSourceForce = origOutput, origForce })
+	extractSourceOutputFile = ""
+
+	inputPath := filepath.Join("..", "testdata", "sample.config.1.xml")
+	want, err := os.ReadFile(inputPath)
+	require.NoError(t, err)
+
+	logger, err := logging.New(logging.Config{Level: "warn"})
+	require.NoError(t, err)
+
+	device, source, _, err := parseConvertInput(t.Context(), inputPath, logger, nil)
+	require.NoError(t, err)
+
+	output, _, err := renderConvertOutput(t.Context(), device, source, nil, nil, nil, logger)
+	require.NoError(t, err)
+
+	exportPath := filepath.Join(t.TempDir(), "archive.yaml")
+	require.NoError(t, os.WriteFile(exportPath, []byte(output), 0o600))
+
+	var stdout bytes.Buffer
+	rootCmd := GetRootCmd()
+	rootCmd.SetOut(&stdout)
+	t.Cleanup(func() { rootCmd.SetOut(nil) })
+	rootCmd.SetArgs([]string{"extract-source", exportPath})
+	require.NoError(t, rootCmd.Execute())
+
+	assert.Equal(t, want, stdout.Bytes(), "extracted config must be written to the command's output")
+}
+
+func TestConvertEmbedSource_SizeLimit(t *testing.T) {
+	setEmbedSourceFlags(t, 16, "json")
+
+	logger, err := logging.New(logging.Config{Level: "warn"})
+	require.NoError(t, err)
+
+	_, _, _, err = parseConvertInput(t.Context(), filepath.Join("..", "testdata", "sample.config.1.xml"), logger, nil)
+	require.ErrorIs(t, err, converter.ErrSourceTooLarge)
+	assert.Contains(t, err.Error(), "--embed-source-limit")
+}
+
+// TestConvertDataQuality parses a fixture with three distinct bad values and
+// checks that every one is reported, and that the report still generates
+// with all three listed in its Data Quality appendix.
+func TestConvertDataQuality(t *testing.T) {
+	origFormat, origDataQuality := format, dataQuality
+	t.Cleanup(func() { format, dataQuality = origFormat, origDataQuality })
+	format, dataQuality = "markdown", true
+
+	logger, err := logging.New(logging.Config{Level: "error"})
+	require.NoError(t, err)
+
+	device, _, issues, err := parseConvertInput(
+		t.Context(), filepath.Join("..", "testdata", "data_quality_test.xml"), logger, nil)
+	require.NoError(t, err)
+
+	fields := make([]string, 0, len(issues))
+	actions := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		fields = append(fields, issue.Field)
+		actions = append(actions, issue.Action)
+	}
+	assert.ElementsMatch(t, []string{
+		"Schedules[0].TimeRanges[0].Month",
+		"NAT.InboundRules[0].Priority",
+		"DHCP[lan].StaticLeases[1].MAC",
+	}, fields)
+	assert.ElementsMatch(t, []string{"value ignored", "defaulted to 0", "lease skipped"}, actions)
+
+	require.Len(t, device.DHCP, 1)
+	assert.Len(t, device.DHCP[0].StaticLeases, 1, "the lease with the bad MAC is skipped")
+	require.Len(t, device.NAT.InboundRules, 1)
+	assert.Zero(t, device.NAT.InboundRules[0].Priority)
+
+	output, _, err := renderConvertOutput(t.Context(), device, nil, issues, nil, nil, logger)
+	require.NoError(t, err)
+	assert.Contains(t, output, "quality-firewall")
+	assert.Contains(t, output, "## Data Quality")
+	for _, issue := range issues {
+		assert.Contains(t, output, issue.Message)
+	}
+}
+
+func TestConvertCmdDataQualityFlag(t *testing.T) {
+	convertCmd := findCommand(GetRootCmd())
+	require.NotNil(t, convertCmd)
+
+	flag := convertCmd.Flags().Lookup("data-quality")
+	require.NotNil(t, flag)
+	assert.Equal(t, "false", flag.DefValue)
+}
+
+func TestExpandBatchInputs(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"b.xml", "a.xml", "notes.txt"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("<opnsense/>"), 0o600))
+	}
+
+	got, err := expandBatchInputs([]string{dir, filepath.Join(dir, "a*.xml"), "single.xml"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "a.xml"),
+		filepath.Join(dir, "b.xml"),
+		filepath.Join(dir, "a.xml"),
+		"single.xml",
+	}, got)
+
+	_, err = expandBatchInputs([]string{filepath.Join(dir, "*.conf")})
+	require.ErrorIs(t, err, ErrNoBatchInputs)
+
+	_, err = expandBatchInputs([]string{t.TempDir()})
+	require.ErrorIs(t, err, ErrNoBatchInputs)
+}
+
+func TestRunConvertBatch(t *testing.T) {
+	origOutputDir, origFormat, origForce := outputDir, format, force
+	t.Cleanup(func() { outputDir, format, force = origOutputDir, origFormat, origForce })
+	outputDir, format, force = filepath.Join(t.TempDir(), "reports"), "json", false
+
+	logger, err := logging.New(logging.Config{Level: "error"})
+	require.NoError(t, err)
+
+	var stderr bytes.Buffer
+	err = runConvertBatch(t.Context(), &stderr, []string{
+		filepath.Join("..", "testdata", "sample.config.1.xml"),
+		filepath.Join("..", "testdata", "sample.config.2.xml"),
+		filepath.Join("..", "testdata", "opnsense-aliases.xml"),
+	}, nil, logger)
+	require.NoError(t, err)
+
+	for _, name := range []string{"OPNsense-report.json", "firewall-report.json", "OPNsense-2-report.json"} {
+		assert.FileExists(t, filepath.Join(outputDir, name))
+		assert.Contains(t, stderr.String(), filepath.Join(outputDir, name))
+	}
+
+	err = runConvertBatch(t.Context(), &stderr, []string{filepath.Join("..", "testdata", "sample.config.2.xml")}, nil, logger)
+	require.ErrorIs(t, err, converter.ErrOutputExists)
+}
+
+func TestConvertCmdOutputDirFlags(t *testing.T) {
+	convertCmd := findCommand(GetRootCmd())
+	require.NotNil(t, convertCmd)
+
+	outputDirFlag := convertCmd.Flags().Lookup("output-dir")
+	require.NotNil(t, outputDirFlag)
+	assert.Empty(t, outputDirFlag.DefValue)
+
+	parallelFlag := convertCmd.Flags().Lookup("parallel")
+	require.NotNil(t, parallelFlag)
+	assert.Equal(t, "0", parallelFlag.DefValue)
+}
+
+func TestConvertCmdStrictRenderFlag(t *testing.T) {
+	convertCmd := findCommand(GetRootCmd())
+	require.NotNil(t, convertCmd)
+
+	strictFlag := convertCmd.Flags().Lookup("strict-render")
+	require.NotNil(t, strictFlag)
+	assert.Equal(t, "false", strictFlag.DefValue)
+
+	original := strictRender
+	t.Cleanup(func() { strictRender = original })
+
+	strictRender = true
+	assert.True(t, buildConversionOptions("markdown", nil).StrictRender)
+}
+
+func TestFinishConvert(t *testing.T) {
+	originalExit := convertExit
+	t.Cleanup(func() { convertExit = originalExit })
+
+	exitCode := -1
+	convertExit = func(code int) { exitCode = code }
+
+	partial := &builder.PartialReportError{Sections: []*builder.SectionRenderError{{
+		Section: "OpenVPN Configuration",
+		Err:     errors.New("panic: index out of range"),
+	}}}
+	inputs := []string{"a.xml", "b.xml"}
+
+	t.Run("no placeholders", func(t *testing.T) {
+		exitCode = -1
+		var stderr bytes.Buffer
+
+		require.NoError(t, finishConvert(&stderr, inputs, nil, nil))
+		assert.Equal(t, -1, exitCode)
+		assert.Empty(t, stderr.String())
+	})
+
+	t.Run("placeholders exit with the render warning code", func(t *testing.T) {
+		exitCode = -1
+		var stderr bytes.Buffer
+
+		err := finishConvert(&stderr, inputs, map[string]*builder.PartialReportError{"b.xml": partial}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, ExitRenderWarning, exitCode)
+		assert.Contains(t, stderr.String(), "1 report section(s) could not be rendered")
+		assert.Contains(t, stderr.String(),
+			"b.xml: section 'OpenVPN Configuration' could not be rendered: panic: index out of range")
+	})
+
+	t.Run("a failed input takes precedence", func(t *testing.T) {
+		exitCode = -1
+		var stderr bytes.Buffer
+		failure := errors.New("failed to convert from a.xml")
+
+		err := finishConvert(&stderr, inputs, map[string]*builder.PartialReportError{"b.xml": partial}, failure)
+		require.ErrorIs(t, err, failure)
+		assert.Equal(t, -1, exitCode)
+		assert.Contains(t, stderr.String(), "b.xml: section 'OpenVPN Configuration'")
+	})
+}
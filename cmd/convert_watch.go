@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/config"
+	"github.com/EvilBit-Labs/opnDossier/internal/constants"
+	"github.com/EvilBit-Labs/opnDossier/internal/converter"
+	"github.com/EvilBit-Labs/opnDossier/internal/export"
+	"github.com/EvilBit-Labs/opnDossier/internal/logging"
+	"github.com/EvilBit-Labs/opnDossier/internal/watch"
+)
+
+// runConvertWatch converts the single input in args to the output file, then
+// watches the input and regenerates the output after every change until ctx
+// is cancelled or the process is interrupted. Each regeneration is reported
+// on w. A failed regeneration, such as one reading a half-written export,
+// leaves the previous output in place and watching continues.
+func runConvertWatch(
+	ctx context.Context,
+	w io.Writer,
+	args []string,
+	tmpl *converter.TemplateConverter,
+	cmdConfig *config.Config,
+	cmdLogger *logging.Logger,
+) error {
+	if err := validateWatchArgs(args, watchDebounce); err != nil {
+		return err
+	}
+	input := args[0]
+
+	output, err := determineOutputPath(input, outputFile, "", cmdConfig, force)
+	if err != nil {
+		return fmt.Errorf("failed to determine output path for %s: %w", input, err)
+	}
+	if output == "" {
+		return fmt.Errorf("%w: --watch writes to a file, set one with --output", ErrInvalidWatch)
+	}
+	if samePath(input, output) {
+		return fmt.Errorf("%w: the output file %s is the watched input", ErrInvalidWatch, output)
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	regenerate := func() {
+		regenerateConvertOutput(ctx, w, input, output, tmpl, cmdConfig, cmdLogger)
+	}
+
+	regenerate()
+	fmt.Fprintf(w, "Watching %s for changes, press Ctrl+C to stop\n", input)
+
+	return watch.File(ctx, input, watch.Options{
+		Debounce: watchDebounce,
+		Poll:     watchPoll,
+		Logger:   cmdLogger,
+	}, regenerate)
+}
+
+// validateWatchArgs checks that --watch has exactly one input file to watch
+// and a positive debounce period.
+func validateWatchArgs(args []string, debounce time.Duration) error {
+	if len(args) != 1 {
+		return fmt.Errorf("%w: exactly one input file can be watched, got %d", ErrInvalidWatch, len(args))
+	}
+	if debounce <= 0 {
+		return fmt.Errorf("%w: --watch-debounce must be positive, got %s", ErrInvalidWatch, debounce)
+	}
+
+	return nil
+}
+
+// samePath reports whether a and b name the same file.
+func samePath(a, b string) bool {
+	absA, errA := filepath.Abs(a)
+	absB, errB := filepath.Abs(b)
+
+	return errA == nil && errB == nil && absA == absB
+}
+
+// regenerateConvertOutput converts input and atomically replaces output with
+// the result, then writes a one-line notice to w with the time and how long
+// generation took. On failure the notice carries the error and output is
+// left untouched.
+func regenerateConvertOutput(
+	ctx context.Context,
+	w io.Writer,
+	input, output string,
+	tmpl *converter.TemplateConverter,
+	cmdConfig *config.Config,
+	cmdLogger *logging.Logger,
+) {
+	start := time.Now()
+	stamp := start.Format(time.DateTime)
+
+	replaced, err := writeConvertOutput(ctx, input, output, tmpl, cmdConfig, cmdLogger)
+	if err != nil {
+		fmt.Fprintf(w, "[%s] Failed to regenerate %s, keeping the previous output: %v\n", stamp, output, err)
+		return
+	}
+
+	elapsed := time.Since(start).Round(time.Millisecond)
+	if replaced > 0 {
+		fmt.Fprintf(w, "[%s] Regenerated %s in %s (%d section(s) replaced by placeholders)\n",
+			stamp, output, elapsed, replaced)
+		return
+	}
+	fmt.Fprintf(w, "[%s] Regenerated %s in %s\n", stamp, output, elapsed)
+}
+
+// writeConvertOutput runs the convert pipeline for input and exports the
+// result to output through a temporary file renamed into place, so readers
+// of output never see a partial report. It returns the number of report
+// sections replaced by placeholders.
+func writeConvertOutput(
+	ctx context.Context,
+	input, output string,
+	tmpl *converter.TemplateConverter,
+	cmdConfig *config.Config,
+	cmdLogger *logging.Logger,
+) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.DefaultProcessingTimeout)
+	defer cancel()
+
+	ctxLogger := cmdLogger.WithFields("input_file", input)
+
+	device, source, issues, err := parseConvertInput(ctx, input, ctxLogger, cmdConfig)
+	if err != nil {
+		return 0, err
+	}
+
+	content, _, err := renderConvertOutput(ctx, device, source, issues, tmpl, cmdConfig, ctxLogger)
+	partial := partialReport(err)
+	if partial != nil {
+		logRenderFailures(ctxLogger, partial)
+	} else if err != nil {
+		return 0, fmt.Errorf("failed to convert from %s: %w", input, err)
+	}
+
+	exporter := export.NewFileExporter(ctxLogger, export.WithBinaryContent(convertOutputIsBinary(tmpl, cmdConfig)))
+	if err := exporter.Export(ctx, content, output); err != nil {
+		return 0, fmt.Errorf("failed to export output to %s: %w", output, err)
+	}
+
+	if partial != nil {
+		return len(partial.Sections), nil
+	}
+	return 0, nil
+}
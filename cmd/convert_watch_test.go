@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// syncBuffer is a bytes.Buffer safe for a watch goroutine writing while the
+// test reads.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// setWatchFlags sets the convert globals used by --watch for one test and
+// restores them afterwards.
+func setWatchFlags(t *testing.T, output string, debounce time.Duration) {
+	t.Helper()
+
+	origOutput, origFormat, origForce := outputFile, format, force
+	origDebounce, origPoll := watchDebounce, watchPoll
+	t.Cleanup(func() {
+		outputFile, format, force = origOutput, origFormat, origForce
+		watchDebounce, watchPoll = origDebounce, origPoll
+	})
+	outputFile, format, force = output, "markdown", true
+	watchDebounce, watchPoll = debounce, false
+}
+
+func TestValidateWatchArgs(t *testing.T) {
+	require.NoError(t, validateWatchArgs([]string{"config.xml"}, 500*time.Millisecond))
+
+	err := validateWatchArgs([]string{"a.xml", "b.xml"}, 500*time.Millisecond)
+	require.ErrorIs(t, err, ErrInvalidWatch)
+	assert.Contains(t, err.Error(), "exactly one input")
+
+	err = validateWatchArgs([]string{"config.xml"}, 0)
+	require.ErrorIs(t, err, ErrInvalidWatch)
+	assert.Contains(t, err.Error(), "--watch-debounce")
+}
+
+func TestRunConvertWatch_RequiresOutputFile(t *testing.T) {
+	input := filepath.Join("..", "testdata", "sample.config.1.xml")
+	logger, err := logging.New(logging.Config{Level: "error"})
+	require.NoError(t, err)
+
+	setWatchFlags(t, "", 50*time.Millisecond)
+	err = runConvertWatch(t.Context(), &bytes.Buffer{}, []string{input}, nil, nil, logger)
+	require.ErrorIs(t, err, ErrInvalidWatch)
+	assert.Contains(t, err.Error(), "--output")
+
+	setWatchFlags(t, input, 50*time.Millisecond)
+	err = runConvertWatch(t.Context(), &bytes.Buffer{}, []string{input}, nil, nil, logger)
+	require.ErrorIs(t, err, ErrInvalidWatch)
+	assert.Contains(t, err.Error(), "watched input")
+}
+
+// TestRunConvertWatch rewrites a watched config and checks that the report
+// follows it: one regeneration per burst of writes, and a broken write
+// reported without touching the last good report.
+func TestRunConvertWatch(t *testing.T) {
+	sample, err := os.ReadFile(filepath.Join("..", "testdata", "sample.config.1.xml"))
+	require.NoError(t, err)
+	require.Contains(t, string(sample), "<hostname>OPNsense</hostname>")
+
+	withHostname := func(hostname string) []byte {
+		return bytes.Replace(sample, []byte("<hostname>OPNsense</hostname>"),
+			[]byte("<hostname>"+hostname+"</hostname>"), 1)
+	}
+
+	dir := t.TempDir()
+	input := filepath.Join(dir, "config.xml")
+	output := filepath.Join(dir, "report.md")
+	require.NoError(t, os.WriteFile(input, sample, 0o600))
+
+	setWatchFlags(t, output, 150*time.Millisecond)
+
+	logger, err := logging.New(logging.Config{Level: "error", Output: &bytes.Buffer{}})
+	require.NoError(t, err)
+
+	var stderr syncBuffer
+	ctx, cancel := context.WithCancel(t.Context())
+	done := make(chan error, 1)
+	go func() {
+		done <- runConvertWatch(ctx, &stderr, []string{input}, nil, nil, logger)
+	}()
+
+	regenerations := func() int { return strings.Count(stderr.String(), "] Regenerated "+output) }
+	readOutput := func() string {
+		content, err := os.ReadFile(output)
+		require.NoError(t, err)
+		return string(content)
+	}
+
+	// The report is generated once at startup.
+	require.Eventually(t, func() bool {
+		return strings.Contains(stderr.String(), "Watching "+input)
+	}, 10*time.Second, 10*time.Millisecond)
+	require.Equal(t, 1, regenerations())
+	assert.Contains(t, readOutput(), "OPNsense")
+
+	// Rapid successive writes produce a single regeneration from the last one.
+	for i := range 5 {
+		require.NoError(t, os.WriteFile(input, withHostname("watch-fw-"+strconv.Itoa(i)), 0o600))
+		time.Sleep(20 * time.Millisecond)
+	}
+	require.Eventually(t, func() bool { return regenerations() == 2 }, 10*time.Second, 10*time.Millisecond)
+	time.Sleep(400 * time.Millisecond)
+	assert.Equal(t, 2, regenerations(), "rapid writes must be coalesced into one regeneration")
+	good := readOutput()
+	assert.Contains(t, good, "watch-fw-4")
+
+	// A broken intermediate write is reported and leaves the report alone.
+	require.NoError(t, os.WriteFile(input, sample[:len(sample)/2], 0o600))
+	require.Eventually(t, func() bool {
+		return strings.Contains(stderr.String(), "Failed to regenerate "+output+", keeping the previous output")
+	}, 10*time.Second, 10*time.Millisecond)
+	assert.Equal(t, good, readOutput())
+
+	// Watching continues, and the next good write is picked up.
+	require.NoError(t, os.WriteFile(input, withHostname("watch-fw-fixed"), 0o600))
+	require.Eventually(t, func() bool { return regenerations() == 3 }, 10*time.Second, 10*time.Millisecond)
+	assert.Contains(t, readOutput(), "watch-fw-fixed")
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 2, "no temporary files may be left next to the report")
+
+	cancel()
+	require.NoError(t, <-done)
+}
@@ -3,8 +3,10 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"slices"
@@ -41,6 +43,12 @@ const (
 	DiffFormatJSON = "json"
 	// DiffFormatHTML specifies self-contained HTML output for diffs.
 	DiffFormatHTML = "html"
+	// DiffFormatJSONPatch specifies an RFC 6902 JSON Patch from the old
+	// model's JSON export to the new one.
+	DiffFormatJSONPatch = "jsonpatch"
+	// DiffFormatMergePatch specifies an RFC 7386 JSON Merge Patch from the
+	// old model's JSON export to the new one.
+	DiffFormatMergePatch = "mergepatch"
 )
 
 // Diff display mode constants.
@@ -62,7 +70,7 @@ func init() {
 	diffCmd.Flags().
 		StringVarP(&diffOutputFile, "output", "o", "", "Output file path (default: print to console)")
 	diffCmd.Flags().
-		StringVarP(&diffFormat, "format", "f", DiffFormatTerminal, "Output format (terminal, markdown, json, html)")
+		StringVarP(&diffFormat, "format", "f", DiffFormatTerminal, "Output format (terminal, markdown, json, html, jsonpatch, mergepatch)")
 	diffCmd.Flags().
 		StringVarP(&diffMode, "mode", "m", DiffModeUnified, "Display mode (unified, side-by-side)")
 
@@ -105,6 +113,8 @@ func ValidDiffFormats(_ *cobra.Command, _ []string, _ string) ([]string, cobra.S
 		DiffFormatMarkdown + "\tMarkdown formatted output",
 		DiffFormatJSON + "\tJSON structured output",
 		DiffFormatHTML + "\tSelf-contained HTML report",
+		DiffFormatJSONPatch + "\tRFC 6902 JSON Patch for automation",
+		DiffFormatMergePatch + "\tRFC 7386 JSON Merge Patch for automation",
 	}, cobra.ShellCompDirectiveNoFileComp
 }
 
@@ -152,10 +162,17 @@ Unlike a raw XML text diff, this command understands OPNsense semantics:
   - Changes are scored by a pattern-based engine (high/medium/low)
 
 OUTPUT FORMATS (--format/-f):
-  terminal  - Color-coded terminal output with +/-/~ markers (default)
-  markdown  - Markdown formatted output for documentation
-  json      - JSON structured output for automation
-  html      - Self-contained HTML report
+  terminal    - Color-coded terminal output with +/-/~ markers (default)
+  markdown    - Markdown formatted output for documentation
+  json        - JSON structured output for automation
+  html        - Self-contained HTML report
+  jsonpatch   - RFC 6902 JSON Patch turning the old JSON export into the new
+  mergepatch  - RFC 7386 JSON Merge Patch turning the old JSON export into the new
+
+  Patch paths use the JSON export field names (/firewallRules/12/destination/port).
+  Rules and other entries with a uuid or tracker are matched by identity, so a
+  reordering becomes move operations. Patch formats honor --section but not
+  --security.
 
 DISPLAY MODES (--mode/-m):
   unified       - Standard diff view (default)
@@ -193,6 +210,9 @@ RELATED:
   # Generate JSON for automation
   opnDossier diff old-config.xml new-config.xml -f json | jq '.changes[]'
 
+  # Generate an RFC 6902 JSON Patch between the JSON exports
+  opnDossier diff old-config.xml new-config.xml -f jsonpatch -o changes.patch.json
+
   # Generate a self-contained HTML report
   opnDossier diff old-config.xml new-config.xml -f html -o report.html
 
@@ -251,6 +271,11 @@ RELATED:
 			DetectOrder:  diffDetectOrder,
 		}
 
+		// Patch formats describe the JSON exports, not the engine's changes
+		if isDiffPatchFormat(opts.Format) {
+			return outputDiffPatch(cmd, oldConfig, newConfig, opts)
+		}
+
 		// Create diff engine and compare
 		engine := diff.NewEngine(oldConfig, newConfig, opts, cmdLogger)
 		result, err := engine.Compare(timeoutCtx)
@@ -298,7 +323,7 @@ func parseConfigFile(
 	device, warnings, err := parser.NewFactory(cfgparser.NewXMLParser()).
 		CreateDevice(ctx, file, resolveDeviceType(), false)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse config: %w", err)
+		return nil, explainParseError(fmt.Errorf("failed to parse config: %w", err))
 	}
 
 	if !quiet {
@@ -306,23 +331,77 @@ func parseConfigFile(
 			cmdLogger.Warn("conversion warning",
 				"field", w.Field,
 				"message", w.Message,
+				"value", w.Value,
+				"action", w.Action,
 				"severity", w.Severity,
 			)
 		}
 	}
 
+	if err := checkConfigCompatibility(device, cmdLogger, quiet); err != nil {
+		return nil, err
+	}
+
 	return device, nil
 }
 
 // outputDiffResult formats and outputs the diff result.
 func outputDiffResult(cmd *cobra.Command, result *diff.Result, opts diff.Options) error {
+	return writeDiffOutput(cmd, diffOutputFile, func(output io.Writer) error {
+		// Create formatter via factory
+		formatter, err := formatters.NewWithMode(opts.Format, opts.Mode, output)
+		if err != nil {
+			return fmt.Errorf("unsupported diff format: %w", err)
+		}
+
+		return formatter.Format(result)
+	})
+}
+
+// outputDiffPatch writes the JSON Patch or JSON Merge Patch that turns the
+// old configuration's JSON export into the new one.
+func outputDiffPatch(cmd *cobra.Command, oldConfig, newConfig *common.CommonDevice, opts diff.Options) error {
+	var (
+		patch any
+		err   error
+	)
+
+	if strings.EqualFold(opts.Format, DiffFormatMergePatch) {
+		patch, err = diff.BuildMergePatch(oldConfig, newConfig, opts)
+	} else {
+		patch, err = diff.BuildJSONPatch(oldConfig, newConfig, opts)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to build patch: %w", err)
+	}
+
+	return writeDiffOutput(cmd, diffOutputFile, func(output io.Writer) error {
+		encoder := json.NewEncoder(output)
+		encoder.SetIndent("", "  ")
+
+		if err := encoder.Encode(patch); err != nil {
+			return fmt.Errorf("failed to encode patch: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// isDiffPatchFormat reports whether format names one of the patch formats.
+func isDiffPatchFormat(format string) bool {
+	return strings.EqualFold(format, DiffFormatJSONPatch) || strings.EqualFold(format, DiffFormatMergePatch)
+}
+
+// writeDiffOutput runs write against the file at outputPath, or the command's
+// output when outputPath is empty, and syncs the file afterwards.
+func writeDiffOutput(cmd *cobra.Command, outputPath string, write func(io.Writer) error) error {
 	// Determine output destination
 	output := cmd.OutOrStdout()
 	var outputFile *os.File
 
-	if diffOutputFile != "" {
+	if outputPath != "" {
 		var err error
-		outputFile, err = os.Create(diffOutputFile)
+		outputFile, err = os.Create(outputPath)
 		if err != nil {
 			return fmt.Errorf("failed to create output file: %w", err)
 		}
@@ -336,14 +415,8 @@ func outputDiffResult(cmd *cobra.Command, result *diff.Result, opts diff.Options
 		output = outputFile
 	}
 
-	// Create formatter via factory
-	formatter, err := formatters.NewWithMode(opts.Format, opts.Mode, output)
-	if err != nil {
-		return fmt.Errorf("unsupported diff format: %w", err)
-	}
-
-	if formatErr := formatter.Format(result); formatErr != nil {
-		return formatErr
+	if err := write(output); err != nil {
+		return err
 	}
 
 	// Sync to ensure all data is written to disk before returning success
@@ -359,15 +432,24 @@ func outputDiffResult(cmd *cobra.Command, result *diff.Result, opts diff.Options
 // validateDiffFlags validates the diff command flags.
 func validateDiffFlags() error {
 	// Validate format
-	validFormats := []string{DiffFormatTerminal, DiffFormatMarkdown, DiffFormatJSON, DiffFormatHTML, ""}
-	if !slices.Contains(validFormats, strings.ToLower(diffFormat)) {
+	formats := []string{
+		DiffFormatTerminal, DiffFormatMarkdown, DiffFormatJSON, DiffFormatHTML,
+		DiffFormatJSONPatch, DiffFormatMergePatch,
+	}
+	if diffFormat != "" && !slices.Contains(formats, strings.ToLower(diffFormat)) {
 		return fmt.Errorf(
 			"invalid format %q, must be one of: %s",
 			diffFormat,
-			strings.Join([]string{DiffFormatTerminal, DiffFormatMarkdown, DiffFormatJSON, DiffFormatHTML}, ", "),
+			strings.Join(formats, ", "),
 		)
 	}
 
+	// Patch formats describe every difference between the exports, so a
+	// security filter has nothing to act on
+	if isDiffPatchFormat(diffFormat) && diffSecurityOnly {
+		return fmt.Errorf("--security is not supported with --format %s", strings.ToLower(diffFormat))
+	}
+
 	// Validate mode
 	validModes := []string{DiffModeUnified, DiffModeSideBySide, ""}
 	if !slices.Contains(validModes, strings.ToLower(diffMode)) {
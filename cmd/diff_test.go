@@ -3,6 +3,7 @@ package cmd
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"os"
 	"path/filepath"
 	"strings"
@@ -174,6 +175,17 @@ func TestValidateDiffFlags(t *testing.T) {
 			format: DiffFormatHTML,
 			mode:   DiffModeUnified,
 		},
+		{
+			name:   "jsonpatch format accepted",
+			format: DiffFormatJSONPatch,
+			mode:   DiffModeUnified,
+		},
+		{
+			name:     "mergepatch format with sections accepted",
+			format:   DiffFormatMergePatch,
+			mode:     DiffModeUnified,
+			sections: []string{"firewall"},
+		},
 		{
 			name:   "empty format accepted",
 			format: "",
@@ -261,6 +273,32 @@ func TestValidateDiffFlags(t *testing.T) {
 			errSubstr: "side-by-side is only supported with --format terminal",
 		},
 
+		{
+			name:      "side-by-side with jsonpatch rejected",
+			format:    DiffFormatJSONPatch,
+			mode:      DiffModeSideBySide,
+			wantErr:   true,
+			errSubstr: "side-by-side is only supported with --format terminal",
+		},
+
+		// Patch formats cannot filter by security relevance
+		{
+			name:         "security with jsonpatch rejected",
+			format:       DiffFormatJSONPatch,
+			mode:         DiffModeUnified,
+			securityOnly: true,
+			wantErr:      true,
+			errSubstr:    "--security is not supported",
+		},
+		{
+			name:         "security with mergepatch rejected",
+			format:       DiffFormatMergePatch,
+			mode:         DiffModeUnified,
+			securityOnly: true,
+			wantErr:      true,
+			errSubstr:    "--security is not supported",
+		},
+
 		// Invalid sections
 		{
 			name:      "invalid section rejected",
@@ -684,6 +722,53 @@ func TestOutputDiffResult_InvalidOutputPath(t *testing.T) {
 	assert.Contains(t, err.Error(), "failed to create output file")
 }
 
+// TestOutputDiffPatch writes both patch formats for two sample configs and
+// verifies the output decodes as the expected JSON shape.
+func TestOutputDiffPatch(t *testing.T) {
+	snap := captureDiffFlags()
+	t.Cleanup(snap.restore)
+
+	diffOutputFile = ""
+
+	oldConfig, err := parseConfigFile(context.Background(),
+		filepath.Join("..", "testdata", "sample.config.1.xml"), newTestLogger(t), true)
+	require.NoError(t, err)
+	newConfig, err := parseConfigFile(context.Background(),
+		filepath.Join("..", "testdata", "sample.config.2.xml"), newTestLogger(t), true)
+	require.NoError(t, err)
+
+	t.Run("jsonpatch", func(t *testing.T) {
+		cmd := &cobra.Command{}
+		var buf bytes.Buffer
+		cmd.SetOut(&buf)
+
+		require.NoError(t, outputDiffPatch(cmd, oldConfig, newConfig, diff.Options{Format: DiffFormatJSONPatch}))
+
+		var ops []map[string]any
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &ops))
+		require.NotEmpty(t, ops)
+		for _, op := range ops {
+			assert.Contains(t, op, "op")
+			assert.Contains(t, op, "path")
+		}
+	})
+
+	t.Run("mergepatch", func(t *testing.T) {
+		cmd := &cobra.Command{}
+		var buf bytes.Buffer
+		cmd.SetOut(&buf)
+
+		require.NoError(t, outputDiffPatch(cmd, oldConfig, newConfig,
+			diff.Options{Format: DiffFormatMergePatch, Sections: []string{"system"}}))
+
+		var patch map[string]any
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &patch))
+		for key := range patch {
+			assert.Equal(t, "system", key, "section filter should limit the patch to system")
+		}
+	})
+}
+
 // TestOutputDiffResult_EmptyResult verifies that outputDiffResult handles an empty
 // diff result without error.
 func TestOutputDiffResult_EmptyResult(t *testing.T) {
@@ -740,7 +825,7 @@ func TestDiffCmdHelpOutput(t *testing.T) {
 // TestValidDiffFormats verifies the completion function returns all valid formats.
 func TestValidDiffFormats(t *testing.T) {
 	completions, directive := ValidDiffFormats(nil, nil, "")
-	assert.Len(t, completions, 4)
+	assert.Len(t, completions, 6)
 	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
 
 	// Verify all format names appear
@@ -754,6 +839,8 @@ func TestValidDiffFormats(t *testing.T) {
 	assert.Contains(t, joined, DiffFormatMarkdown)
 	assert.Contains(t, joined, DiffFormatJSON)
 	assert.Contains(t, joined, DiffFormatHTML)
+	assert.Contains(t, joined, DiffFormatJSONPatch)
+	assert.Contains(t, joined, DiffFormatMergePatch)
 }
 
 // TestValidDiffModes verifies the completion function returns all valid modes.
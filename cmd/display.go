@@ -48,6 +48,9 @@ func registerDisplayFlagCompletions(cmd *cobra.Command) {
 	if err := cmd.RegisterFlagCompletionFunc("section", ValidSections); err != nil {
 		logger.Debug("failed to register section completion", "error", err)
 	}
+	if err := cmd.RegisterFlagCompletionFunc("section-order", ValidSections); err != nil {
+		logger.Debug("failed to register section-order completion", "error", err)
+	}
 }
 
 // displayCmd is the cobra.Command for the display subcommand.
@@ -80,6 +83,7 @@ RENDERING:
 CONTENT CONTROL:
   --theme       Force theme (light|dark|auto|none)
   --section     Restrict output to specific sections (e.g. system,firewall)
+  --section-order    Render the listed sections first (e.g. security,system)
   --wrap N      Wrap text at N columns (auto-detected if omitted)
   --no-wrap     Disable text wrapping (equivalent to --wrap 0)
   --redact      Redact passwords, SNMP community strings, private keys
@@ -172,7 +176,7 @@ RELATED:
 			if cfgparser.IsValidationError(err) {
 				ctxLogger.Error("Configuration validation failed")
 			}
-			return fmt.Errorf("failed to parse configuration from %s: %w", filePath, err)
+			return explainParseError(fmt.Errorf("failed to parse configuration from %s: %w", filePath, err))
 		}
 
 		if cmdConfig == nil || !cmdConfig.IsQuiet() {
@@ -180,11 +184,17 @@ RELATED:
 				ctxLogger.Warn("conversion warning",
 					"field", w.Field,
 					"message", w.Message,
+					"value", w.Value,
+					"action", w.Action,
 					"severity", w.Severity,
 				)
 			}
 		}
 
+		if err := checkConfigCompatibility(device, ctxLogger, cmdConfig != nil && cmdConfig.IsQuiet()); err != nil {
+			return fmt.Errorf("failed to parse configuration from %s: %w", filePath, err)
+		}
+
 		mdOpts := buildDisplayOptions(cmdConfig)
 		g, err := converter.NewMarkdownGenerator(ctxLogger, mdOpts)
 		if err != nil {
@@ -223,7 +233,9 @@ RELATED:
 //   - WrapWidth uses CLI flag if >= 0, otherwise cfg value if >= 0, otherwise -1
 //     (auto-detect). A WrapWidth of 0 disables wrapping; positive values set a
 //     specific column width.
+//   - MaxWidth is taken from the CLI flag and caps the rendered width.
 //   - Comprehensive is taken from the corresponding CLI flag.
+//   - SectionOrder is taken from the corresponding CLI flag.
 func buildDisplayOptions(cfg *config.Config) converter.Options {
 	// Start with defaults
 	opt := converter.DefaultOptions()
@@ -258,11 +270,20 @@ func buildDisplayOptions(cfg *config.Config) converter.Options {
 		opt.WrapWidth = -1
 	}
 
+	// Max width: CLI flag only
+	opt.MaxWidth = sharedMaxWidth
+
 	opt.Comprehensive = sharedComprehensive
 
 	// Include tunables: CLI flag only
 	opt.IncludeTunables = sharedIncludeTunables
 
+	// Port names: CLI flag only
+	opt.NoPortNames = sharedNoPortNames
+
+	// Section order: CLI flag only
+	opt.SectionOrder = sharedSectionOrder
+
 	// Redact: CLI flag only
 	opt.Redact = sharedRedact
 
@@ -272,28 +293,28 @@ func buildDisplayOptions(cfg *config.Config) converter.Options {
 // validateDisplayFlags checks display command flag combinations and value constraints.
 //
 // It inspects the command's flags and enforces:
-// - Mutual exclusivity of `--no-wrap` and `--wrap` (returns an error if both were set).
+// - Mutual exclusivity of `--no-wrap` with `--wrap` or `--max-width`.
 // - Normalizes `sharedWrapWidth` to 0 when `sharedNoWrap` is true.
+// - Validates `sharedSectionOrder` against the report section registry.
 // - Validates that `sharedTheme`, if provided, is one of: "light", "dark", "auto", or "none" (returns an error otherwise).
 // - Emits a warning to stderr when a positive `sharedWrapWidth` is outside the recommended [MinWrapWidth, MaxWrapWidth] range.
-// - Returns an error if `sharedWrapWidth` is less than -1.
+// - Returns an error if `sharedWrapWidth` is less than -1 or `sharedMaxWidth` is negative.
 //
 // Returns an error when flag combinations or values are invalid; nil otherwise.
 func validateDisplayFlags(cmd *cobra.Command) error {
 	// Validate mutual exclusivity for wrap flags before other checks
-	flags := cmd.Flags()
-	if flags != nil {
-		noWrapFlag := flags.Lookup("no-wrap")
-		wrapFlag := flags.Lookup("wrap")
-		if noWrapFlag != nil && wrapFlag != nil && noWrapFlag.Changed && wrapFlag.Changed {
-			return errors.New("--no-wrap and --wrap flags are mutually exclusive")
-		}
+	if err := validateWrapFlagExclusivity(cmd.Flags()); err != nil {
+		return err
 	}
 
 	if sharedNoWrap {
 		sharedWrapWidth = 0
 	}
 
+	if err := validateSectionOrder(); err != nil {
+		return err
+	}
+
 	// Validate theme values
 	if sharedTheme != "" {
 		validThemes := []string{"light", "dark", "auto", "none"}
@@ -312,5 +333,9 @@ func validateDisplayFlags(cmd *cobra.Command) error {
 			sharedWrapWidth)
 	}
 
+	if sharedMaxWidth < 0 {
+		return fmt.Errorf("invalid max width %d: must be 0 (off) or positive", sharedMaxWidth)
+	}
+
 	return nil
 }
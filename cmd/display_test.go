@@ -39,6 +39,7 @@ func testDisplayCmd(localFlags *pflag.FlagSet) *cobra.Command {
 //   - wrapWidth: Text wrapping width for display
 //   - noWrap: Disable text wrapping
 //   - sections: Which sections to include in output
+//   - sectionOrder: Order of the report sections
 //   - comprehensive: Whether to generate comprehensive reports
 //
 // Rationale: The snapshot focuses on flags that directly affect display output
@@ -49,10 +50,13 @@ type sharedFlagSnapshot struct {
 	wrapWidth       int
 	noWrap          bool
 	sections        []string
+	sectionOrder    []string
 	comprehensive   bool
 	deviceType      string
 	redact          bool
 	includeTunables bool
+	noPortNames     bool
+	allowNewer      bool
 }
 
 func captureSharedFlags() sharedFlagSnapshot {
@@ -61,10 +65,13 @@ func captureSharedFlags() sharedFlagSnapshot {
 		wrapWidth:       sharedWrapWidth,
 		noWrap:          sharedNoWrap,
 		sections:        sharedSections,
+		sectionOrder:    sharedSectionOrder,
 		comprehensive:   sharedComprehensive,
 		deviceType:      sharedDeviceType,
 		redact:          sharedRedact,
 		includeTunables: sharedIncludeTunables,
+		noPortNames:     sharedNoPortNames,
+		allowNewer:      sharedAllowNewer,
 	}
 }
 
@@ -73,10 +80,13 @@ func (s sharedFlagSnapshot) restore() {
 	sharedWrapWidth = s.wrapWidth
 	sharedNoWrap = s.noWrap
 	sharedSections = s.sections
+	sharedSectionOrder = s.sectionOrder
 	sharedComprehensive = s.comprehensive
 	sharedDeviceType = s.deviceType
 	sharedRedact = s.redact
 	sharedIncludeTunables = s.includeTunables
+	sharedNoPortNames = s.noPortNames
+	sharedAllowNewer = s.allowNewer
 }
 
 func captureStderr(t *testing.T, fn func()) string {
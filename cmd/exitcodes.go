@@ -3,10 +3,13 @@ package cmd
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/EvilBit-Labs/opnDossier/internal/cfgparser"
+	"github.com/EvilBit-Labs/opnDossier/pkg/parser"
 )
 
 // Exit codes for structured error handling in CI/CD pipelines.
@@ -26,6 +29,11 @@ const (
 
 	// ExitFileError indicates a file I/O error (file not found, permission denied, etc.).
 	ExitFileError = 4
+
+	// ExitRenderWarning indicates that convert wrote its reports but replaced
+	// one or more sections that failed to render with placeholders.
+	// ExitConfigValidationError (5) is defined in config_validate.go.
+	ExitRenderWarning = 6
 )
 
 // JSONError represents a machine-readable error output.
@@ -57,6 +65,14 @@ func OutputJSONError(err error, file string, exitCode int) {
 		}
 	}
 
+	if limitErr, ok := errors.AsType[*parser.LimitError](err); ok {
+		jsonErr.Details = map[string]any{
+			"offset":    limitErr.Offset,
+			"message":   limitErr.Err.Error(),
+			"rationale": limitErr.Rationale(),
+		}
+	}
+
 	output, marshalErr := json.Marshal(jsonErr)
 	if marshalErr != nil {
 		// Fallback to plain error if JSON marshaling fails
@@ -80,6 +96,7 @@ const (
 	errorTypeParseError      = "parse_error"
 	errorTypeValidationError = "validation_error"
 	errorTypeFileError       = "file_error"
+	errorTypeRenderWarning   = "render_warning"
 	errorTypeUnknownError    = "unknown_error"
 	jsonFieldSuccess         = "success"
 )
@@ -97,6 +114,8 @@ func getErrorType(code int) string {
 		return errorTypeValidationError
 	case ExitFileError:
 		return errorTypeFileError
+	case ExitRenderWarning:
+		return errorTypeRenderWarning
 	default:
 		return errorTypeUnknownError
 	}
@@ -108,7 +127,7 @@ func DetermineExitCode(err error) int {
 		return ExitSuccess
 	}
 
-	if cfgparser.IsParseError(err) {
+	if cfgparser.IsParseError(err) || isLimitError(err) {
 		return ExitParseError
 	}
 
@@ -123,6 +142,26 @@ func DetermineExitCode(err error) int {
 	return ExitGeneralError
 }
 
+// isLimitError reports whether err is input the parser refused because it
+// contains a DTD or breaks one of the parser's size limits.
+func isLimitError(err error) bool {
+	_, ok := errors.AsType[*parser.LimitError](err)
+	return ok
+}
+
+// explainParseError appends the security rationale to err when the parser
+// refused the input on principle, so operators learn why a file that may
+// look like a valid config.xml was rejected. Other errors are returned
+// unchanged. The final period is dropped, as error strings end without one.
+func explainParseError(err error) error {
+	limitErr, ok := errors.AsType[*parser.LimitError](err)
+	if !ok {
+		return err
+	}
+
+	return fmt.Errorf("%w\n\n%s", err, strings.TrimSuffix(limitErr.Rationale(), "."))
+}
+
 // ExitWithCode exits the program with the specified exit code.
 // This function should be used instead of os.Exit to ensure proper cleanup.
 func ExitWithCode(code int) {
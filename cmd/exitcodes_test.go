@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/EvilBit-Labs/opnDossier/internal/cfgparser"
+	"github.com/EvilBit-Labs/opnDossier/pkg/parser"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -26,6 +27,7 @@ func TestGetErrorType(t *testing.T) {
 		{"parse error", ExitParseError, "parse_error"},
 		{"validation error", ExitValidationError, "validation_error"},
 		{"file error", ExitFileError, "file_error"},
+		{"render warning", ExitRenderWarning, "render_warning"},
 		{"unknown code 99", 99, "unknown_error"},
 		{"unknown negative", -1, "unknown_error"},
 	}
@@ -82,6 +84,11 @@ func TestDetermineExitCode(t *testing.T) {
 			fmt.Errorf("validation failed: %w", cfgparser.NewValidationError("dns", "invalid")),
 			ExitValidationError,
 		},
+		{
+			"rejected DTD returns ExitParseError",
+			fmt.Errorf("opnsense parser: %w", &parser.LimitError{Err: parser.ErrDTDNotSupported, Offset: 22}),
+			ExitParseError,
+		},
 	}
 
 	for _, tt := range tests {
@@ -92,6 +99,22 @@ func TestDetermineExitCode(t *testing.T) {
 	}
 }
 
+// TestExplainParseError verifies that limit errors gain their security
+// rationale and stay matchable, while other errors pass through unchanged.
+func TestExplainParseError(t *testing.T) {
+	limitErr := &parser.LimitError{Err: parser.ErrElementTooDeep, Limit: 256, Offset: 775}
+	err := explainParseError(fmt.Errorf("failed to parse configuration from config.xml: %w", limitErr))
+
+	require.ErrorIs(t, err, parser.ErrElementTooDeep)
+	assert.Equal(t, "failed to parse configuration from config.xml: "+
+		"element nesting exceeds the maximum depth of 256 (at byte offset 775)\n\n"+
+		"Element nesting is capped so a crafted file cannot exhaust memory or the stack while it is decoded. "+
+		"Real configurations nest only a dozen or so levels deep", err.Error())
+
+	other := errors.New("something went wrong")
+	assert.Same(t, other, explainParseError(other))
+}
+
 // TestOutputJSONError verifies that OutputJSONError writes valid JSON
 // to stderr with the correct structure and error type mapping.
 func TestOutputJSONError(t *testing.T) {
@@ -0,0 +1,244 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/netip"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/analysis"
+	"github.com/EvilBit-Labs/opnDossier/internal/constants"
+	"github.com/spf13/cobra"
+)
+
+// Expose command flags.
+var (
+	exposeOutputFile string //nolint:gochecknoglobals // Cobra flag variable
+	exposeFormat     string //nolint:gochecknoglobals // Output format (markdown, json)
+)
+
+// exposeRequiredArgs is the number of positional arguments expose takes: the
+// config file and the address or network to look up.
+const exposeRequiredArgs = 2
+
+// exposeNotEvaluated lists the exposure sources expose cannot check because
+// opnDossier does not parse them. It is printed with every result so an empty
+// result is not mistaken for proof the host is unreachable.
+var exposeNotEvaluated = []string{ //nolint:gochecknoglobals // Read-only list
+	"UPnP/NAT-PMP port mappings",
+}
+
+// init registers the expose command and its flags with the root command.
+func init() {
+	rootCmd.AddCommand(exposeCmd)
+
+	exposeCmd.Flags().
+		StringVarP(&exposeOutputFile, "output", "o", "", "Output file path (default: print to console)")
+	exposeCmd.Flags().
+		StringVarP(&exposeFormat, "format", "f", DiffFormatMarkdown, "Output format (markdown, json)")
+
+	if err := exposeCmd.RegisterFlagCompletionFunc("format", ValidExposeFormats); err != nil {
+		logger.Warn("failed to register format completion", "error", err)
+	}
+
+	exposeCmd.Flags().SortFlags = false
+}
+
+// ValidExposeFormats provides completion for the expose format flag.
+func ValidExposeFormats(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	return []string{
+		DiffFormatMarkdown + "\tMarkdown report (default)",
+		DiffFormatJSON + "\tJSON structured output",
+	}, cobra.ShellCompDirectiveNoFileComp
+}
+
+// exposeCmd is the cobra.Command for the expose subcommand.
+var exposeCmd = &cobra.Command{ //nolint:gochecknoglobals // Cobra command
+	Use:               "expose <config.xml> <ip|cidr>",
+	Short:             "List the rules through which a host or subnet can be reached.",
+	GroupID:           groupCore,
+	ValidArgsFunction: ValidXMLFiles,
+	PreRunE: func(_ *cobra.Command, _ []string) error {
+		return validateExposeFlags()
+	},
+	Long: `The 'expose' command answers "what exposes this host?": given an IP address
+or CIDR network, it lists every rule through which traffic can reach it.
+
+PATHS CHECKED:
+  port-forward    - Enabled inbound NAT port forwards whose internal IP, or an
+                    alias resolving to it, lies in the query
+  one-to-one-nat  - Enabled 1:1 NAT (BINAT) mappings whose internal address or
+                    subnet overlaps the query; every port is forwarded
+  pass-rule       - Enabled inbound pass rules whose destination overlaps the
+                    query through a literal address or network, an alias
+                    member, an interface network ("lan"), or an interface
+                    address ("lanip")
+
+  Pass rules with an unrestricted or negated destination match nearly every
+  host, so they are listed only when reachable from the WAN. Each path shows
+  whether it is reachable from the WAN or only from internal networks, and the
+  port traffic is sent to: a forward's external port or a rule's destination
+  port.
+
+NOT EVALUATED:
+  ` + strings.Join(exposeNotEvaluated, "\n  ") + `
+
+  opnDossier does not parse these, so check them separately.
+
+OUTPUT FORMATS (--format/-f):
+  markdown  - Markdown report (default)
+  json      - JSON structured output for automation
+
+RELATED:
+  convert    - Render the full firewall and NAT rule tables
+  audit      - Run compliance checks on a config`,
+	Example: `  # Which rules reach the web server?
+  opnDossier expose config.xml 192.168.1.50
+
+  # Everything reaching the DMZ subnet, as JSON
+  opnDossier expose config.xml 10.0.20.0/24 -f json -o dmz-exposure.json`,
+	Args: cobra.ExactArgs(exposeRequiredArgs),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+
+		// Validate device type flag early before any file processing
+		if err := validateDeviceType(); err != nil {
+			return err
+		}
+
+		query, err := analysis.ParseHostExposureQuery(args[1])
+		if err != nil {
+			return err
+		}
+
+		cmdCtx := GetCommandContext(cmd)
+		if cmdCtx == nil {
+			return errors.New("command context not initialized")
+		}
+		cmdLogger := cmdCtx.Logger
+		quiet := cmdCtx.Config != nil && cmdCtx.Config.IsQuiet()
+
+		timeoutCtx, cancel := context.WithTimeout(ctx, constants.DefaultProcessingTimeout)
+		defer cancel()
+
+		path := filepath.Clean(args[0])
+
+		device, err := parseConfigFile(timeoutCtx, path, cmdLogger, quiet)
+		if err != nil {
+			return fmt.Errorf("failed to parse config %s: %w", path, err)
+		}
+
+		result := exposeResult{
+			Query:        query.String(),
+			Hostname:     device.System.Hostname,
+			Paths:        analysis.HostExposure(device, query),
+			NotEvaluated: exposeNotEvaluated,
+		}
+
+		return writeDiffOutput(cmd, exposeOutputFile, func(output io.Writer) error {
+			if strings.EqualFold(exposeFormat, DiffFormatJSON) {
+				return writeExposeJSON(output, result)
+			}
+
+			return writeExposeMarkdown(output, query, result)
+		})
+	},
+}
+
+// exposeResult is the JSON output of the expose command.
+type exposeResult struct {
+	// Query is the looked-up network; a single address has a /32 or /128 mask.
+	Query string `json:"query"`
+	// Hostname is the firewall's hostname.
+	Hostname string `json:"hostname,omitempty"`
+	// Paths are the rules through which the query can be reached.
+	Paths []analysis.HostExposurePath `json:"paths"`
+	// NotEvaluated lists exposure sources that were not checked.
+	NotEvaluated []string `json:"notEvaluated"`
+}
+
+// writeExposeJSON writes result as indented JSON.
+func writeExposeJSON(w io.Writer, result exposeResult) error {
+	if result.Paths == nil {
+		result.Paths = []analysis.HostExposurePath{}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(result); err != nil {
+		return fmt.Errorf("failed to encode exposure: %w", err)
+	}
+
+	return nil
+}
+
+// writeExposeMarkdown writes result as a markdown report: a table of the
+// exposure paths, or a "no exposure found" line, followed by the sources that
+// were not evaluated.
+func writeExposeMarkdown(w io.Writer, query netip.Prefix, result exposeResult) error {
+	var b strings.Builder
+
+	target := result.Query
+	if query.IsSingleIP() {
+		target = query.Addr().String()
+	}
+
+	fmt.Fprintf(&b, "# Exposure of %s\n\n", target)
+	if result.Hostname != "" {
+		fmt.Fprintf(&b, "**Firewall:** %s\n\n", result.Hostname)
+	}
+
+	if len(result.Paths) == 0 {
+		fmt.Fprintf(&b, "No exposure found for %s.\n\n", target)
+	} else {
+		b.WriteString("| Kind | Rule | Interfaces | Reachability | Protocol | Port | Target | Match |\n")
+		b.WriteString("|------|------|------------|--------------|----------|------|--------|-------|\n")
+		for _, p := range result.Paths {
+			interfaces := "floating"
+			if len(p.Interfaces) > 0 {
+				interfaces = strings.Join(p.Interfaces, ", ")
+			}
+
+			fmt.Fprintf(&b, "| %s | %s | %s | %s | %s | %s | %s | %s |\n",
+				p.Kind,
+				exposeCell(p.Rule),
+				exposeCell(interfaces),
+				p.Reachability,
+				exposeCell(p.Protocol),
+				exposeCell(p.Port),
+				exposeCell(p.Target),
+				exposeCell(p.Match),
+			)
+		}
+		b.WriteString("\n")
+	}
+
+	fmt.Fprintf(&b, "**Not evaluated:** %s (not parsed by opnDossier).\n", strings.Join(result.NotEvaluated, ", "))
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// exposeCell escapes pipes so value can be placed in a markdown table cell.
+func exposeCell(value string) string {
+	return strings.ReplaceAll(value, "|", `\|`)
+}
+
+// validateExposeFlags validates the expose command flags.
+func validateExposeFlags() error {
+	formats := []string{DiffFormatMarkdown, DiffFormatJSON}
+	if exposeFormat != "" && !slices.Contains(formats, strings.ToLower(exposeFormat)) {
+		return fmt.Errorf("invalid format %q, must be one of: %s", exposeFormat, strings.Join(formats, ", "))
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/analysis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runExpose executes the expose command against the expose fixture.
+func runExpose(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+
+	resetRootFlagsForTest(t)
+	saved := exposeFormat
+	t.Cleanup(func() { exposeFormat = saved })
+	exposeFormat = DiffFormatMarkdown
+
+	rootCmd := GetRootCmd()
+
+	var buf bytes.Buffer
+	rootCmd.SetOut(&buf)
+	rootCmd.SetErr(&buf)
+	rootCmd.SetArgs(append([]string{"expose", filepath.Join("..", "testdata", "expose_test.xml")}, args...))
+
+	err := rootCmd.Execute()
+
+	return buf.String(), err
+}
+
+// TestExposeCmd_JSON checks the web server is reached through exactly the
+// fixture's port forward and pass rule.
+func TestExposeCmd_JSON(t *testing.T) {
+	out, err := runExpose(t, "192.168.1.50", "--format", "json")
+	require.NoError(t, err)
+
+	var result exposeResult
+	require.NoError(t, json.Unmarshal([]byte(out), &result), out)
+	assert.Equal(t, "192.168.1.50/32", result.Query)
+	assert.Equal(t, "expose-firewall", result.Hostname)
+	assert.NotEmpty(t, result.NotEvaluated)
+
+	require.Len(t, result.Paths, 2)
+	assert.Equal(t, analysis.HostExposurePassRule, result.Paths[0].Kind)
+	assert.Equal(t, "443", result.Paths[0].Port)
+	assert.Equal(t, analysis.HostExposurePortForward, result.Paths[1].Kind)
+	assert.Equal(t, "8443", result.Paths[1].Port)
+	assert.Equal(t, "192.168.1.50:443", result.Paths[1].Target)
+	assert.Equal(t, analysis.WANReachable, result.Paths[1].Reachability)
+}
+
+func TestExposeCmd_Markdown(t *testing.T) {
+	out, err := runExpose(t, "192.168.1.0/24")
+	require.NoError(t, err)
+
+	assert.Contains(t, out, "# Exposure of 192.168.1.0/24")
+	assert.Contains(t, out, "| port-forward | nat.inbound[0]: Forward 8443 to web server |")
+	assert.Contains(t, out, "Allow SMTP to mail relay")
+	assert.Contains(t, out, "**Not evaluated:**")
+}
+
+func TestExposeCmd_NoExposure(t *testing.T) {
+	out, err := runExpose(t, "192.168.1.99")
+	require.NoError(t, err)
+
+	assert.Contains(t, out, "No exposure found for 192.168.1.99.")
+}
+
+func TestExposeCmd_InvalidQuery(t *testing.T) {
+	_, err := runExpose(t, "webserver")
+	require.ErrorContains(t, err, "not an IP address or CIDR network")
+}
+
+func TestValidateExposeFlags(t *testing.T) {
+	saved := exposeFormat
+	t.Cleanup(func() { exposeFormat = saved })
+
+	exposeFormat = "JSON"
+	require.NoError(t, validateExposeFlags())
+
+	exposeFormat = "yaml"
+	require.ErrorContains(t, validateExposeFlags(), "invalid format")
+}
@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/converter"
+	"github.com/EvilBit-Labs/opnDossier/internal/export"
+	"github.com/spf13/cobra"
+)
+
+// Extract-source command flag variables.
+var (
+	extractSourceOutputFile string //nolint:gochecknoglobals // Output file path
+	extractSourceForce      bool   //nolint:gochecknoglobals // Force overwrite without prompt
+	extractSourceLimit      int64  //nolint:gochecknoglobals // Maximum size of the extracted source
+)
+
+// opndossier extract-source export.json --output config.xml --force.
+func init() {
+	rootCmd.AddCommand(extractSourceCmd)
+
+	extractSourceCmd.Flags().
+		StringVarP(&extractSourceOutputFile, "output", "o", "",
+			"Output file path for the recovered configuration (default: print to console)")
+	setFlagAnnotation(extractSourceCmd.Flags(), "output", []flagCategory{categoryOutput})
+
+	extractSourceCmd.Flags().
+		BoolVar(&extractSourceForce, "force", false,
+			"Force overwrite existing files without prompting for confirmation")
+	setFlagAnnotation(extractSourceCmd.Flags(), "force", []flagCategory{categoryOutput})
+
+	extractSourceCmd.Flags().
+		Int64Var(&extractSourceLimit, "limit", converter.DefaultEmbedSourceLimit,
+			"Maximum size in bytes of the embedded configuration to recover")
+	setFlagAnnotation(extractSourceCmd.Flags(), "limit", []flagCategory{categoryOutput})
+
+	extractSourceCmd.Flags().SortFlags = false
+}
+
+// extractSourceCmd is the cobra.Command for the extract-source subcommand.
+var extractSourceCmd = &cobra.Command{ //nolint:gochecknoglobals // Cobra command
+	Use:     "extract-source [export]",
+	Short:   "Recover the config.xml embedded in a JSON or YAML export.",
+	GroupID: groupUtility,
+	Long: `The 'extract-source' command recovers the original configuration file from a
+JSON or YAML export produced with 'convert --embed-source'. The embedded bytes
+are decompressed and checked against the SHA-256 digest and size recorded in
+_meta.source, so the output is byte-for-byte identical to the converted file.
+
+OUTPUT:
+  By default, the recovered file is printed to stdout. Use --output/-o to save
+  it to a file, and --force to overwrite an existing file.
+
+LIMITS:
+  Exports declaring an embedded file larger than --limit bytes (default
+  64 MiB) are refused before decompression.
+
+RELATED:
+  convert    - Use --embed-source to archive the source with a JSON/YAML export`,
+	Example: `  # Recover the configuration from an archived export
+  opnDossier extract-source archive.json -o config.xml
+
+  # Recover from a YAML export and print to stdout
+  opnDossier extract-source archive.yaml
+
+  # Force overwrite of an existing file
+  opnDossier extract-source archive.json -o config.xml --force`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmdCtx := GetCommandContext(cmd)
+		if cmdCtx == nil {
+			return errors.New("command context not initialized")
+		}
+		ctxLogger := cmdCtx.Logger.WithFields("input_file", args[0])
+
+		data, err := os.ReadFile(filepath.Clean(args[0]))
+		if err != nil {
+			return fmt.Errorf("failed to read export %s: %w", args[0], err)
+		}
+
+		source, content, err := converter.ExtractEmbeddedSource(data, extractSourceLimit)
+		if err != nil {
+			return fmt.Errorf("failed to extract source from %s: %w", args[0], err)
+		}
+		ctxLogger.Debug("Embedded source verified",
+			"filename", source.Filename, "size", source.Size, "sha256", source.SHA256)
+
+		if extractSourceOutputFile == "" {
+			if _, err := cmd.OutOrStdout().Write(content); err != nil {
+				return fmt.Errorf("failed to write source to stdout: %w", err)
+			}
+			return nil
+		}
+
+		outputPath := extractSourceOutputFile
+		if err := confirmOverwrite(outputPath, extractSourceForce); err != nil {
+			if errors.Is(err, ErrOperationCancelled) {
+				ctxLogger.Info("Operation cancelled by user")
+				return nil
+			}
+			return err
+		}
+
+		if err := os.WriteFile(outputPath, content, export.DefaultFilePermissions); err != nil {
+			return fmt.Errorf("failed to write output file %s: %w", outputPath, err)
+		}
+
+		// Summary goes to stderr so it never mixes with piped output.
+		fmt.Fprintf(cmd.ErrOrStderr(), "Extracted %s → %s (%d bytes, SHA-256 verified)\n",
+			source.Filename, outputPath, source.Size)
+
+		return nil
+	},
+}
@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/constants"
+	"github.com/EvilBit-Labs/opnDossier/internal/diff"
+	"github.com/EvilBit-Labs/opnDossier/internal/diff/formatters"
+	"github.com/spf13/cobra"
+)
+
+// HA compare command flags.
+var (
+	haCompareOutputFile string   //nolint:gochecknoglobals // Cobra flag variable
+	haCompareFormat     string   //nolint:gochecknoglobals // Output format (markdown, json)
+	haCompareAllow      []string //nolint:gochecknoglobals // Extra paths expected to differ
+)
+
+// init registers the ha-compare command and its flags with the root command.
+func init() {
+	rootCmd.AddCommand(haCompareCmd)
+
+	haCompareCmd.Flags().
+		StringVarP(&haCompareOutputFile, "output", "o", "", "Output file path (default: print to console)")
+	haCompareCmd.Flags().
+		StringVarP(&haCompareFormat, "format", "f", DiffFormatMarkdown, "Output format (markdown, json)")
+	haCompareCmd.Flags().
+		StringSliceVar(&haCompareAllow, "allow", nil,
+			"Additional JSON export paths expected to differ between the nodes (* matches one path segment)")
+
+	if err := haCompareCmd.RegisterFlagCompletionFunc("format", ValidHACompareFormats); err != nil {
+		logger.Warn("failed to register format completion", "error", err)
+	}
+
+	haCompareCmd.Flags().SortFlags = false
+}
+
+// ValidHACompareFormats provides completion for the ha-compare format flag.
+func ValidHACompareFormats(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	return []string{
+		DiffFormatMarkdown + "\tMarkdown report (default)",
+		DiffFormatJSON + "\tJSON structured output",
+	}, cobra.ShellCompDirectiveNoFileComp
+}
+
+// haCompareCmd is the cobra.Command for the ha-compare subcommand.
+var haCompareCmd = &cobra.Command{ //nolint:gochecknoglobals // Cobra command
+	Use:               "ha-compare <primary.xml> <secondary.xml>",
+	Short:             "Compare the two nodes of an HA pair for unexpected divergence.",
+	GroupID:           groupCore,
+	ValidArgsFunction: ValidXMLFiles,
+	PreRunE: func(_ *cobra.Command, _ []string) error {
+		return validateHACompareFlags()
+	},
+	Long: `The 'ha-compare' command compares the configurations of the primary and
+secondary nodes of a high-availability (CARP) pair. The nodes of a healthy pair
+differ only in node-specific settings, so every other difference is reported
+as a finding.
+
+The comparison walks the normalized model that 'convert --format json' exports.
+Rules, users, interfaces, and tunables are matched by uuid, name, or tunable
+rather than by position, so a rule present on only one node is reported once.
+
+EXPECTED DIFFERENCES:
+  These JSON export paths are allowed to differ by default:
+    ` + strings.Join(diff.DefaultHAAllowlist(), "\n    ") + `
+
+  Add paths with --allow; "*" matches any single path segment and a path
+  also allows everything beneath it.
+
+FINDING SEVERITY:
+  HIGH    - Firewall rules, NAT, aliases, and schedules
+  MEDIUM  - Users, groups, system settings, tunables, VPN, and certificates
+  LOW     - Other sections
+  INFO    - Description-only differences
+
+OUTPUT FORMATS (--format/-f):
+  markdown  - Markdown report (default)
+  json      - JSON structured output for automation
+
+RELATED:
+  diff       - Compare two revisions of the same config`,
+	Example: `  # Compare the nodes of an HA pair
+  opnDossier ha-compare primary.xml secondary.xml
+
+  # Write a JSON report
+  opnDossier ha-compare primary.xml secondary.xml -f json -o ha-report.json
+
+  # Also expect the interface descriptions and the web GUI port to differ
+  opnDossier ha-compare primary.xml secondary.xml \
+    --allow '/interfaces/*/description' --allow /system/webGui/port`,
+	Args: cobra.ExactArgs(diffRequiredArgs),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+
+		// Validate device type flag early before any file processing
+		if err := validateDeviceType(); err != nil {
+			return err
+		}
+
+		cmdCtx := GetCommandContext(cmd)
+		if cmdCtx == nil {
+			return errors.New("command context not initialized")
+		}
+		cmdLogger := cmdCtx.Logger
+		quiet := cmdCtx.Config != nil && cmdCtx.Config.IsQuiet()
+
+		timeoutCtx, cancel := context.WithTimeout(ctx, constants.DefaultProcessingTimeout)
+		defer cancel()
+
+		primaryPath := filepath.Clean(args[0])
+		secondaryPath := filepath.Clean(args[1])
+
+		cmdLogger.Debug("Parsing HA node configurations", "primary", primaryPath, "secondary", secondaryPath)
+
+		primary, err := parseConfigFile(timeoutCtx, primaryPath, cmdLogger, quiet)
+		if err != nil {
+			return fmt.Errorf("failed to parse primary config %s: %w", primaryPath, err)
+		}
+
+		secondary, err := parseConfigFile(timeoutCtx, secondaryPath, cmdLogger, quiet)
+		if err != nil {
+			return fmt.Errorf("failed to parse secondary config %s: %w", secondaryPath, err)
+		}
+
+		allowlist := append(diff.DefaultHAAllowlist(), haCompareAllow...)
+
+		result, err := diff.CompareHAPair(primary, secondary, allowlist)
+		if err != nil {
+			return fmt.Errorf("failed to compare HA nodes: %w", err)
+		}
+
+		result.Metadata.PrimaryFile = primaryPath
+		result.Metadata.SecondaryFile = secondaryPath
+
+		return writeDiffOutput(cmd, haCompareOutputFile, func(output io.Writer) error {
+			formatter, err := formatters.NewHA(haCompareFormat, output)
+			if err != nil {
+				return fmt.Errorf("unsupported ha-compare format: %w", err)
+			}
+
+			return formatter.Format(result)
+		})
+	},
+}
+
+// validateHACompareFlags validates the ha-compare command flags.
+func validateHACompareFlags() error {
+	formats := []string{DiffFormatMarkdown, DiffFormatJSON}
+	if haCompareFormat != "" && !slices.Contains(formats, strings.ToLower(haCompareFormat)) {
+		return fmt.Errorf("invalid format %q, must be one of: %s", haCompareFormat, strings.Join(formats, ", "))
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/diff"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestHACompareCmd_JSON runs ha-compare against the HA pair fixtures and
+// checks the rule present only on the secondary is the single finding.
+func TestHACompareCmd_JSON(t *testing.T) {
+	saved := haCompareFormat
+	t.Cleanup(func() { haCompareFormat = saved })
+
+	rootCmd := GetRootCmd()
+
+	var buf bytes.Buffer
+	rootCmd.SetOut(&buf)
+	rootCmd.SetErr(&buf)
+	rootCmd.SetArgs([]string{
+		"ha-compare",
+		filepath.Join("..", "testdata", "ha", "primary.xml"),
+		filepath.Join("..", "testdata", "ha", "secondary_rule_drift.xml"),
+		"--format", "json",
+	})
+
+	require.NoError(t, rootCmd.Execute())
+
+	var result diff.HAResult
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &result), buf.String())
+	require.Len(t, result.Findings, 1)
+	assert.Equal(t, "/firewallRules/2", result.Findings[0].Path)
+	assert.Equal(t, "fw-a", result.Metadata.PrimaryHostname)
+}
+
+func TestValidateHACompareFlags(t *testing.T) {
+	saved := haCompareFormat
+	t.Cleanup(func() { haCompareFormat = saved })
+
+	haCompareFormat = "JSON"
+	require.NoError(t, validateHACompareFlags())
+
+	haCompareFormat = "html"
+	require.ErrorContains(t, validateHACompareFlags(), "invalid format")
+}
@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// confirmOverwrite reports whether outputPath may be written. When the file
+// already exists and force is false, the user is prompted on stderr to confirm
+// overwriting; an empty response is treated as "No" and only `y` or `Y` are
+// accepted to proceed. Returns ErrOperationCancelled when the user declines,
+// or a wrapped error if reading user input fails.
+func confirmOverwrite(outputPath string, force bool) error {
+	if _, err := os.Stat(outputPath); err != nil || force {
+		return nil
+	}
+
+	// Prompt on stderr to avoid interfering with piped output.
+	fmt.Fprintf(os.Stderr, "File '%s' already exists. Overwrite? (y/N): ", outputPath)
+
+	// Use bufio.NewReader to correctly capture entire input line including spaces
+	reader := bufio.NewReader(os.Stdin)
+
+	response, err := reader.ReadString('\n')
+	if err != nil {
+		return fmt.Errorf("failed to read user input: %w", err)
+	}
+
+	// Only proceed if user explicitly confirms with 'y' or 'Y'; empty input
+	// defaults to "N".
+	response = strings.TrimSpace(response)
+	if response != "y" && response != "Y" {
+		return ErrOperationCancelled
+	}
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"io"
+	"os"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/config"
+	"github.com/EvilBit-Labs/opnDossier/internal/progress"
+)
+
+// newProgressTracker returns the progress tracker for a convert or audit run.
+//
+// Progress is rendered on stderr only when it cannot interfere with anything:
+// quiet mode is off, exactly one input file is being processed (concurrent
+// workers would clobber a single status line), stdout is an interactive
+// terminal (piped report output signals a non-interactive run), and stderr
+// is itself a terminal. In every other case a no-op tracker is returned.
+func newProgressTracker(cmdConfig *config.Config, inputCount int) progress.Tracker {
+	if (cmdConfig != nil && cmdConfig.IsQuiet()) || inputCount != 1 || !progress.IsTerminal(os.Stdout) {
+		return progress.NewNoOp()
+	}
+
+	opts := progress.DefaultOptions()
+	opts.Output = os.Stderr
+
+	return progress.NewTracker(opts)
+}
+
+// trackParseInput wraps file so that bytes consumed by the parser are
+// reported to tracker. The file size is used as the phase total when it can
+// be determined; otherwise the phase runs with an unknown total.
+func trackParseInput(file *os.File, tracker progress.Tracker) io.Reader {
+	var size int64
+	if info, err := file.Stat(); err == nil && info.Mode().IsRegular() {
+		size = info.Size()
+	}
+
+	tracker.SetTotal(size)
+
+	return progress.NewReader(file, tracker, "Parsing configuration")
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/config"
+	"github.com/EvilBit-Labs/opnDossier/internal/progress"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNewProgressTracker_NoOp verifies that progress is suppressed for quiet
+// runs, multi-file runs, and runs whose stdout is not a terminal (as under
+// go test, where stdout is captured).
+func TestNewProgressTracker_NoOp(t *testing.T) {
+	tests := []struct {
+		name       string
+		cfg        *config.Config
+		inputCount int
+	}{
+		{"nil config non-tty", nil, 1},
+		{"quiet", &config.Config{Quiet: true}, 1},
+		{"multi-file", &config.Config{}, 3},
+		{"no inputs", &config.Config{}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracker := newProgressTracker(tt.cfg, tt.inputCount)
+			assert.IsType(t, &progress.NoOpProgress{}, tracker)
+		})
+	}
+}
+
+// TestTrackParseInput verifies that the wrapped reader passes the file
+// through unchanged, reports the bytes consumed against the file size, and
+// writes nothing to stdout.
+func TestTrackParseInput(t *testing.T) {
+	content := "<opnsense>" + strings.Repeat("<x/>", 256) + "</opnsense>"
+	path := filepath.Join(t.TempDir(), "config.xml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = file.Close() })
+
+	origStdout := os.Stdout
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	os.Stdout = w
+	t.Cleanup(func() { os.Stdout = origStdout })
+
+	var progressOut bytes.Buffer
+	tracker := progress.NewLineTracker(&progressOut)
+
+	data, err := io.ReadAll(trackParseInput(file, tracker))
+	require.NoError(t, err)
+	tracker.Done("Report generated")
+
+	require.NoError(t, w.Close())
+	os.Stdout = origStdout
+
+	stdout, err := io.ReadAll(r)
+	require.NoError(t, err)
+
+	assert.Equal(t, content, string(data))
+	assert.Contains(t, progressOut.String(), "100% Parsing configuration")
+	assert.True(t, strings.HasSuffix(progressOut.String(), "✓ Report generated\n"))
+	assert.Empty(t, stdout, "progress must never write to stdout")
+}
@@ -2,7 +2,9 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"slices"
 	"strings"
@@ -11,6 +13,7 @@ import (
 	"github.com/EvilBit-Labs/opnDossier/internal/constants"
 	"github.com/EvilBit-Labs/opnDossier/internal/logging"
 	"github.com/EvilBit-Labs/opnDossier/internal/validator"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
 	"github.com/EvilBit-Labs/opnDossier/pkg/parser"
 	_ "github.com/EvilBit-Labs/opnDossier/pkg/parser/opnsense"       // self-registers OPNsense parser via init()
 	pfparser "github.com/EvilBit-Labs/opnDossier/pkg/parser/pfsense" // self-registers pfSense parser via init()
@@ -227,10 +230,14 @@ func init() {
 	// removed. SupportedDevices() is the same source used by
 	// ValidateDeviceType error messages (see shared_flags.go).
 	rootCmd.PersistentFlags().
-		StringVar(&sharedDeviceType, "device-type", "",
-			fmt.Sprintf("Force device type (supported: %s). Bypasses auto-detection.",
+		StringVar(&sharedDeviceType, "device-type", deviceTypeAuto,
+			fmt.Sprintf("Device type: auto detects from the XML root element, or force one of: %s",
 				parser.DefaultRegistry().SupportedDevices()))
 	setFlagAnnotation(rootCmd.PersistentFlags(), "device-type", []flagCategory{categoryParsing})
+	rootCmd.PersistentFlags().
+		BoolVar(&sharedAllowNewer, "allow-newer", false,
+			"Parse configurations more than one major format version newer than tested")
+	setFlagAnnotation(rootCmd.PersistentFlags(), "allow-newer", []flagCategory{categoryParsing})
 
 	// Flag groups for better organization
 	rootCmd.PersistentFlags().SortFlags = false
@@ -248,12 +255,16 @@ func init() {
 		Annotations: map[string]string{
 			annotationLightweight: annotationValueOn, // Skip heavy initialization for fast startup
 		},
-		Run: func(_ *cobra.Command, _ []string) {
-			fmt.Printf("opnDossier version %s\n", constants.Version)
-			fmt.Printf("Build date: %s\n", getBuildDate())
-			fmt.Printf("Git commit: %s\n", getGitCommit())
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			asJSON, err := cmd.Flags().GetBool("json")
+			if err != nil {
+				return err
+			}
+
+			return writeVersionInfo(cmd.OutOrStdout(), asJSON)
 		},
 	}
+	versionCmd.Flags().Bool("json", false, "Output version information as JSON")
 	rootCmd.AddCommand(versionCmd)
 
 	// Add command aliases for common workflows
@@ -419,6 +430,37 @@ func getGitCommit() string {
 	return gitCommit
 }
 
+// versionInfo is the machine-readable form of the version command output.
+type versionInfo struct {
+	Version      string `json:"version"`
+	ModelVersion string `json:"modelVersion"`
+	BuildDate    string `json:"buildDate"`
+	GitCommit    string `json:"gitCommit"`
+}
+
+// writeVersionInfo writes the tool, model, and build versions to w, either as
+// human-readable lines or as an indented JSON object.
+func writeVersionInfo(w io.Writer, asJSON bool) error {
+	info := versionInfo{
+		Version:      constants.Version,
+		ModelVersion: common.ModelVersion,
+		BuildDate:    getBuildDate(),
+		GitCommit:    getGitCommit(),
+	}
+
+	if asJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+
+		return enc.Encode(info)
+	}
+
+	_, err := fmt.Fprintf(w, "opnDossier version %s\nModel version: %s\nBuild date: %s\nGit commit: %s\n",
+		info.Version, info.ModelVersion, info.BuildDate, info.GitCommit)
+
+	return err
+}
+
 // validateGlobalFlags validates global flag combinations for consistency.
 func validateGlobalFlags(flags *pflag.FlagSet) error {
 	// Check color values
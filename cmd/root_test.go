@@ -3,11 +3,14 @@ package cmd
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"os"
 	"strings"
 	"testing"
 
+	"github.com/EvilBit-Labs/opnDossier/internal/constants"
 	"github.com/EvilBit-Labs/opnDossier/internal/logging"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -371,3 +374,39 @@ func TestRootCmdPersistentPreRunERecoversFromFallback(t *testing.T) {
 	require.NotNil(t, cmdCtx, "CommandContext should be set after PersistentPreRunE")
 	assert.NotNil(t, cmdCtx.Logger, "Logger should be reinitialized after fallback")
 }
+
+func TestWriteVersionInfo(t *testing.T) {
+	t.Run("human readable", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, writeVersionInfo(&buf, false))
+
+		out := buf.String()
+		assert.Contains(t, out, "opnDossier version "+constants.Version)
+		assert.Contains(t, out, "Model version: "+common.ModelVersion)
+		assert.Contains(t, out, "Build date: ")
+		assert.Contains(t, out, "Git commit: ")
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, writeVersionInfo(&buf, true))
+
+		var info map[string]string
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &info))
+		assert.Equal(t, constants.Version, info["version"])
+		assert.Equal(t, common.ModelVersion, info["modelVersion"])
+		assert.Equal(t, getBuildDate(), info["buildDate"])
+		assert.Equal(t, getGitCommit(), info["gitCommit"])
+	})
+}
+
+func TestVersionCmdJSONFlag(t *testing.T) {
+	rootCmd := GetRootCmd()
+
+	versionCmd, _, err := rootCmd.Find([]string{cmdNameVersion})
+	require.NoError(t, err)
+
+	flag := versionCmd.Flags().Lookup("json")
+	require.NotNil(t, flag)
+	assert.Equal(t, "false", flag.DefValue)
+}
@@ -2,7 +2,6 @@
 package cmd
 
 import (
-	"bufio"
 	"context"
 	"errors"
 	"fmt"
@@ -21,6 +20,7 @@ var (
 	sanitizeOutputFile  string //nolint:gochecknoglobals // Output file path
 	sanitizeMappingFile string //nolint:gochecknoglobals // Mapping file output path
 	sanitizeForce       bool   //nolint:gochecknoglobals // Force overwrite without prompt
+	sanitizeCanonical   bool   //nolint:gochecknoglobals // Re-encode every token instead of preserving bytes
 )
 
 // Sanitize mode constants matching the sanitizer package.
@@ -67,6 +67,12 @@ func init() {
 			"Force overwrite existing files without prompting for confirmation")
 	setFlagAnnotation(sanitizeCmd.Flags(), "force", []flagCategory{categoryOutput})
 
+	// Canonical XML flag
+	sanitizeCmd.Flags().
+		BoolVar(&sanitizeCanonical, "canonical-xml", false,
+			"Re-encode every XML token (trimmed text, normalized comments, expanded empty tags) instead of changing only redacted values")
+	setFlagAnnotation(sanitizeCmd.Flags(), "canonical-xml", []flagCategory{categoryOutput})
+
 	// Register flag completion functions
 	registerSanitizeFlagCompletions(sanitizeCmd)
 
@@ -143,6 +149,11 @@ OUTPUT:
   file, and --force to overwrite an existing file. Sanitize never modifies the
   input in place.
 
+  Only redacted values change: comments, processing instructions, attribute
+  order and quoting, empty-element tags, and indentation are kept byte for
+  byte, so the output diffs cleanly against the original. Use --canonical-xml
+  to re-encode every token instead.
+
 RELATED:
   convert    - Use --redact for single-pass redaction of the rendered report
   audit      - Use --redact to keep audit output safe to share`,
@@ -158,6 +169,9 @@ RELATED:
   # Minimal redaction (credentials and authserver values only)
   opnDossier sanitize config.xml --mode minimal
 
+  # Re-encode every token instead of preserving the original layout
+  opnDossier sanitize config.xml --canonical-xml -o sanitized.xml
+
   # Force overwrite of an existing file
   opnDossier sanitize config.xml -o output.xml --force
 
@@ -210,6 +224,7 @@ RELATED:
 		// Create sanitizer with specified mode
 		ctxLogger.Debug("Creating sanitizer", "mode", sanitizeMode)
 		s := sanitizer.NewSanitizer(sanitizer.Mode(sanitizeMode))
+		s.SetCanonicalXML(sanitizeCanonical)
 
 		// Determine output destination
 		var outputWriter *os.File
@@ -321,33 +336,11 @@ RELATED:
 }
 
 // determineSanitizeOutputPath determines whether the provided outputPath may be used.
-// If the file already exists and force is false, it prompts the user on stderr to
-// confirm overwriting; an empty response is treated as "No" and only `y` or `Y`
-// are accepted to proceed. It returns the original outputPath on approval.
-// Returns ErrOperationCancelled when the user declines, or a wrapped error if
-// reading user input fails.
+// It returns the original outputPath when confirmOverwrite approves it, and
+// ErrOperationCancelled or a wrapped input error otherwise.
 func determineSanitizeOutputPath(outputPath string, force bool) (string, error) {
-	// Check if file already exists
-	if _, err := os.Stat(outputPath); err == nil {
-		// File exists, check if we should overwrite
-		if !force {
-			fmt.Fprintf(os.Stderr, "File '%s' already exists. Overwrite? (y/N): ", outputPath)
-
-			reader := bufio.NewReader(os.Stdin)
-			response, err := reader.ReadString('\n')
-			if err != nil {
-				return "", fmt.Errorf("failed to read user input: %w", err)
-			}
-
-			response = strings.TrimSpace(response)
-			if response == "" {
-				response = "N"
-			}
-
-			if response != "y" && response != "Y" {
-				return "", ErrOperationCancelled
-			}
-		}
+	if err := confirmOverwrite(outputPath, force); err != nil {
+		return "", err
 	}
 
 	return outputPath, nil
@@ -74,6 +74,14 @@ func TestSanitizeCommandFlags(t *testing.T) {
 	if forceFlag == nil {
 		t.Error("expected --force flag to exist")
 	}
+
+	// Canonical XML flag
+	canonicalFlag := flags.Lookup("canonical-xml")
+	if canonicalFlag == nil {
+		t.Error("expected --canonical-xml flag to exist")
+	} else if canonicalFlag.DefValue != "false" {
+		t.Errorf("canonical-xml flag default = %q, want %q", canonicalFlag.DefValue, "false")
+	}
 }
 
 func TestSanitizeCommandGroupID(t *testing.T) {
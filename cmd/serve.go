@@ -0,0 +1,221 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"syscall"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/constants"
+	"github.com/EvilBit-Labs/opnDossier/internal/server"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/spf13/cobra"
+)
+
+// Serve command flags.
+var (
+	serveHost          string //nolint:gochecknoglobals // Cobra flag variable
+	servePort          int    //nolint:gochecknoglobals // Cobra flag variable
+	serveAPIKey        string //nolint:gochecknoglobals // Cobra flag variable
+	serveWebhookSecret string //nolint:gochecknoglobals // Cobra flag variable
+	servePrometheus    bool   //nolint:gochecknoglobals // Cobra flag variable
+	serveMaxWebhook    int64  //nolint:gochecknoglobals // Cobra flag variable
+)
+
+// Serve command defaults.
+const (
+	// defaultServeHost binds the API to loopback so it is not exposed by accident.
+	defaultServeHost = "127.0.0.1"
+	// defaultServePort is the default TCP port of the API.
+	defaultServePort = 8080
+	// maxServePort is the highest valid TCP port.
+	maxServePort = 65535
+	// serveAPIKeyEnvVar supplies the API key when --api-key is not set, which
+	// keeps the key out of the process list.
+	serveAPIKeyEnvVar = "OPNDOSSIER_API_KEY"
+	// serveWebhookSecretEnvVar supplies the webhook secret when
+	// --webhook-secret is not set.
+	serveWebhookSecretEnvVar = "OPNDOSSIER_WEBHOOK_SECRET"
+)
+
+// init registers the serve command and its flags with the root command.
+func init() {
+	rootCmd.AddCommand(serveCmd)
+
+	serveCmd.Flags().
+		StringVar(&serveHost, "host", defaultServeHost, "Address to listen on (use 0.0.0.0 for all interfaces)")
+	serveCmd.Flags().
+		IntVarP(&servePort, "port", "p", defaultServePort, "TCP port to listen on")
+	serveCmd.Flags().
+		StringVar(&serveAPIKey, "api-key", "",
+			"API key required in the X-API-Key header or as a bearer token (default: $"+serveAPIKeyEnvVar+")")
+	serveCmd.Flags().
+		StringVar(&serveWebhookSecret, "webhook-secret", "",
+			"HMAC-SHA256 secret pushes to /api/v1/webhook/config must be signed with (default: $"+serveWebhookSecretEnvVar+")")
+	serveCmd.Flags().
+		Int64Var(&serveMaxWebhook, "max-webhook-size", 0,
+			"Largest webhook push body in bytes (default: the 10 MiB upload limit of /api/v1/analyze)")
+	serveCmd.Flags().
+		BoolVar(&servePrometheus, "prometheus", false, "Serve Prometheus metrics for the config at /metrics")
+
+	serveCmd.Flags().SortFlags = false
+}
+
+// serveCmd is the cobra.Command for the serve subcommand.
+var serveCmd = &cobra.Command{ //nolint:gochecknoglobals // Cobra command
+	Use:               "serve [config.xml]",
+	Short:             "Serve reports, findings, and statistics over a REST API.",
+	GroupID:           groupCore,
+	ValidArgsFunction: ValidXMLFiles,
+	PreRunE: func(_ *cobra.Command, _ []string) error {
+		return validateServeFlags()
+	},
+	Long: `The 'serve' command starts an HTTP server exposing opnDossier as a REST API,
+so dashboards and other tools can integrate without shelling out to the CLI.
+
+When a config.xml is given, it is parsed once at startup and served by the GET
+endpoints. A firewall can push its config.xml to the webhook to replace it, so
+the GET endpoints follow the live configuration. Without either, only
+POST /api/v1/analyze is available.
+
+ENDPOINTS:
+  GET  /api/v1/report?format=markdown|json  Report for the served config
+  GET  /api/v1/findings                     Processor findings and posture rating
+  GET  /api/v1/statistics                   Configuration statistics
+  POST /api/v1/analyze                      Findings and statistics for a config.xml
+                                            uploaded in the multipart "config" field
+  POST /api/v1/webhook/config               Replace the served config with a pushed
+                                            multipart config.xml and re-analyze it
+  GET  /metrics                             Prometheus metrics for the served config
+                                            (with --prometheus)
+
+  Responses are always redacted. Errors are JSON objects with an "error" field.
+
+AUTHENTICATION:
+  With --api-key (or $` + serveAPIKeyEnvVar + `) set, every request must send the key in
+  the X-API-Key header or as "Authorization: Bearer <key>"; other requests get
+  401. Without a key the API is unauthenticated, so keep the default loopback
+  --host unless the network is trusted.
+
+WEBHOOK:
+  With --webhook-secret (or $` + serveWebhookSecretEnvVar + `) set, pushes to the webhook
+  must carry "X-Opndossier-Signature-256: sha256=<hex>", the HMAC-SHA256 of
+  the request body keyed with the secret, and need no API key; other pushes
+  get 401. Without a secret the webhook is protected by the API key like any
+  other endpoint. A push that fails to parse leaves the served config as is.
+  Pushes share the 10 MiB upload limit of /api/v1/analyze; --max-webhook-size
+  lowers it, since signed pushes are read before the signature is checked.
+
+The server logs one line per request and shuts down gracefully on Ctrl+C or
+SIGTERM.
+
+RELATED:
+  convert    - Render a config to markdown/JSON/YAML once
+  audit      - Run compliance checks on a config`,
+	Example: `  # Serve a config on http://127.0.0.1:8080
+  opnDossier serve config.xml
+
+  # Fetch its report and findings
+  curl http://127.0.0.1:8080/api/v1/report?format=json
+  curl http://127.0.0.1:8080/api/v1/findings
+
+  # Let Prometheus scrape findings and rule counts
+  opnDossier serve config.xml --prometheus
+  curl http://127.0.0.1:8080/metrics
+
+  # Follow a firewall's configuration pushed to the signed webhook
+  OPNDOSSIER_WEBHOOK_SECRET=s3cret opnDossier serve --host 0.0.0.0
+
+  # Analyze uploaded configs only, on all interfaces with an API key
+  OPNDOSSIER_API_KEY=s3cret opnDossier serve --host 0.0.0.0 --port 9000
+  curl -H 'X-API-Key: s3cret' -F config=@config.xml http://firewall-docs:9000/api/v1/analyze`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+
+		// Validate device type flag early before any file processing
+		if err := validateDeviceType(); err != nil {
+			return err
+		}
+
+		cmdCtx := GetCommandContext(cmd)
+		if cmdCtx == nil {
+			return errors.New("command context not initialized")
+		}
+		cmdLogger := cmdCtx.Logger
+		quiet := cmdCtx.Config != nil && cmdCtx.Config.IsQuiet()
+
+		var device *common.CommonDevice
+		if len(args) == 1 {
+			path := filepath.Clean(args[0])
+
+			parseCtx, cancel := context.WithTimeout(ctx, constants.DefaultProcessingTimeout)
+			defer cancel()
+
+			var err error
+			device, err = parseConfigFile(parseCtx, path, cmdLogger, quiet)
+			if err != nil {
+				return fmt.Errorf("failed to parse config %s: %w", path, err)
+			}
+		}
+
+		apiKey := serveAPIKey
+		if apiKey == "" {
+			apiKey = os.Getenv(serveAPIKeyEnvVar)
+		}
+		webhookSecret := serveWebhookSecret
+		if webhookSecret == "" {
+			webhookSecret = os.Getenv(serveWebhookSecretEnvVar)
+		}
+
+		srv, err := server.New(server.Options{
+			Device:         device,
+			DeviceType:     resolveDeviceType(),
+			APIKey:         apiKey,
+			WebhookSecret:  webhookSecret,
+			MaxWebhookSize: serveMaxWebhook,
+			Prometheus:     servePrometheus,
+			Logger:         cmdLogger,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create server: %w", err)
+		}
+
+		serveCtx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		addr := net.JoinHostPort(serveHost, strconv.Itoa(servePort))
+
+		return srv.ListenAndServe(serveCtx, addr, func(bound net.Addr) {
+			cmdLogger.Info("Serving REST API",
+				"url", "http://"+bound.String()+"/api/v1/",
+				"config", len(args) == 1,
+				"auth", apiKey != "",
+				"webhook_signed", webhookSecret != "",
+				"prometheus", servePrometheus)
+		})
+	},
+}
+
+// validateServeFlags checks the serve flags before any file is read.
+func validateServeFlags() error {
+	if servePort < 0 || servePort > maxServePort {
+		return fmt.Errorf("invalid port %d: must be between 0 and %d", servePort, maxServePort)
+	}
+	if serveHost == "" {
+		return errors.New("--host must not be empty")
+	}
+	if serveMaxWebhook < 0 {
+		return fmt.Errorf("invalid --max-webhook-size %d: must not be negative", serveMaxWebhook)
+	}
+
+	return nil
+}
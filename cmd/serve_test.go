@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateServeFlags(t *testing.T) {
+	savedHost, savedPort, savedMaxWebhook := serveHost, servePort, serveMaxWebhook
+	t.Cleanup(func() { serveHost, servePort, serveMaxWebhook = savedHost, savedPort, savedMaxWebhook })
+
+	serveHost, servePort = defaultServeHost, defaultServePort
+	require.NoError(t, validateServeFlags())
+
+	servePort = 0
+	require.NoError(t, validateServeFlags())
+
+	servePort = maxServePort + 1
+	require.ErrorContains(t, validateServeFlags(), "invalid port")
+
+	servePort, serveHost = defaultServePort, ""
+	require.ErrorContains(t, validateServeFlags(), "--host")
+
+	serveHost, serveMaxWebhook = defaultServeHost, -1
+	require.ErrorContains(t, validateServeFlags(), "--max-webhook-size")
+}
+
+// TestServeCmd_InvalidConfig checks serve fails before listening when the
+// config.xml cannot be parsed.
+func TestServeCmd_InvalidConfig(t *testing.T) {
+	resetRootFlagsForTest(t)
+
+	rootCmd := GetRootCmd()
+
+	var buf bytes.Buffer
+	rootCmd.SetOut(&buf)
+	rootCmd.SetErr(&buf)
+	rootCmd.SetArgs([]string{"serve", filepath.Join(t.TempDir(), "missing.xml")})
+
+	require.ErrorContains(t, rootCmd.Execute(), "failed to parse config")
+}
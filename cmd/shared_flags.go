@@ -13,8 +13,10 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/EvilBit-Labs/opnDossier/internal/analysis"
 	"github.com/EvilBit-Labs/opnDossier/internal/audit"
 	"github.com/EvilBit-Labs/opnDossier/internal/converter"
+	"github.com/EvilBit-Labs/opnDossier/internal/converter/builder"
 	"github.com/EvilBit-Labs/opnDossier/internal/logging"
 	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
 	"github.com/EvilBit-Labs/opnDossier/pkg/parser"
@@ -25,16 +27,22 @@ import (
 // Shared flag variables for convert and display commands.
 var (
 	// Parsing flags.
-	sharedDeviceType string //nolint:gochecknoglobals // Force device type (bypasses auto-detection)
+	sharedDeviceType string //nolint:gochecknoglobals // Device type, or "auto" to detect from the XML root
 
 	// Styling flags.
 	sharedSections        []string //nolint:gochecknoglobals // Sections to include
+	sharedSectionOrder    []string //nolint:gochecknoglobals // Order of the report sections
 	sharedTheme           string   //nolint:gochecknoglobals // Theme for rendering
 	sharedWrapWidth       = -1     //nolint:gochecknoglobals // Text wrap width
+	sharedMaxWidth        int      //nolint:gochecknoglobals // Prose soft-wrap and terminal width cap
 	sharedNoWrap          bool     //nolint:gochecknoglobals // Disable text wrapping
 	sharedIncludeTunables bool     //nolint:gochecknoglobals // Include system tunables in output
 	sharedComprehensive   bool     //nolint:gochecknoglobals // Generate comprehensive report
 	sharedRedact          bool     //nolint:gochecknoglobals // Redact sensitive fields in output
+	sharedNoPortNames     bool     //nolint:gochecknoglobals // Show raw port values in rule tables
+
+	// Analysis flags.
+	sharedPortRiskFile string //nolint:gochecknoglobals // YAML table of ports that should not be forwarded from the internet
 )
 
 // addSharedContentFlags adds shared CLI flags for content, formatting, and audit-related
@@ -44,9 +52,12 @@ var (
 //
 //	--include-tunables    Include all system tunables in report output (markdown, text, HTML only; JSON/YAML always include all tunables).
 //	--section             Comma-separated list of specific sections to include (e.g., system,network,firewall).
+//	--section-order       Comma-separated order of the report sections; unlisted sections follow in default order.
 //	--wrap                Text wrap width in characters (-1 = auto-detect terminal width, 0 = no wrapping).
+//	--max-width           Soft-wrap markdown prose and cap terminal rendering at this width (0 = off).
 //	--no-wrap             Disable text wrapping (alias for --wrap 0).
 //	--comprehensive       Generate comprehensive detailed reports with full configuration analysis.
+//	--no-port-names       Show raw port values instead of annotating well-known ports with service names.
 //
 // Example:
 //
@@ -62,10 +73,18 @@ func addSharedContentFlags(cmd *cobra.Command) {
 		StringSliceVar(&sharedSections, "section", []string{}, "Specific sections to include in output (comma-separated, e.g., system,network,firewall)")
 	setFlagAnnotation(cmd.Flags(), "section", []flagCategory{categoryContent})
 
+	cmd.Flags().
+		StringSliceVar(&sharedSectionOrder, "section-order", []string{}, "Order of the report sections and table of contents (comma-separated, e.g., security,system,network); unlisted sections follow in default order")
+	setFlagAnnotation(cmd.Flags(), "section-order", []flagCategory{categoryContent})
+
 	cmd.Flags().
 		IntVar(&sharedWrapWidth, "wrap", -1, "Text wrap width in characters (-1 = auto-detect terminal width, 0 = no wrapping, recommended: 80-120)")
 	setFlagAnnotation(cmd.Flags(), "wrap", []flagCategory{categoryFormatting})
 
+	cmd.Flags().
+		IntVar(&sharedMaxWidth, "max-width", 0, "Soft-wrap markdown prose and cap terminal rendering at this width (0 = off; tables and code never wrap)")
+	setFlagAnnotation(cmd.Flags(), "max-width", []flagCategory{categoryFormatting})
+
 	cmd.Flags().
 		BoolVar(&sharedNoWrap, "no-wrap", false, "Disable text wrapping (alias for --wrap 0)")
 	setFlagAnnotation(cmd.Flags(), "no-wrap", []flagCategory{categoryFormatting})
@@ -73,6 +92,11 @@ func addSharedContentFlags(cmd *cobra.Command) {
 	cmd.Flags().
 		BoolVar(&sharedComprehensive, "comprehensive", false, "Generate comprehensive detailed reports with full configuration analysis")
 	setFlagAnnotation(cmd.Flags(), "comprehensive", []flagCategory{categoryAudit})
+
+	cmd.Flags().
+		BoolVar(&sharedNoPortNames, "no-port-names", false, "Show raw port values in firewall and NAT rule tables instead of annotating well-known TCP/UDP ports with service names (e.g., 443 (https))")
+	setFlagAnnotation(cmd.Flags(), "no-port-names", []flagCategory{categoryContent})
+
 }
 
 // addDisplayFlags adds display-related CLI flags to cmd.
@@ -84,6 +108,16 @@ func addDisplayFlags(cmd *cobra.Command) {
 	setFlagAnnotation(cmd.Flags(), "theme", []flagCategory{categoryDisplay})
 }
 
+// addPortRiskFlag adds the --port-risk-file flag to cmd. The table it names
+// is merged over the built-in table of ports that should not be forwarded
+// from the internet, which the security analysis checks port forwards
+// against.
+func addPortRiskFlag(cmd *cobra.Command) {
+	cmd.Flags().
+		StringVar(&sharedPortRiskFile, "port-risk-file", "", "YAML table of ports that should not be forwarded from the internet (port: {service, severity, rationale}); entries replace or extend the built-in telnet/FTP/HTTP/RDP/VNC table")
+	setFlagAnnotation(cmd.Flags(), "port-risk-file", []flagCategory{categoryAudit})
+}
+
 // addSharedRedactFlag adds the --redact flag to cmd for redacting sensitive fields
 // (passwords, keys, community strings) in output.
 func addSharedRedactFlag(cmd *cobra.Command) {
@@ -211,6 +245,7 @@ var formatDescriptions = map[string]string{ //nolint:gochecknoglobals // static
 	outputFormatYAML:     "YAML format for configuration management",
 	outputFormatText:     "Plain text format (markdown without formatting)",
 	outputFormatHTML:     "Self-contained HTML report for web viewing",
+	outputFormatPDF:      "PDF document of the comprehensive report",
 }
 
 // deviceTypeDescriptions maps registered device types to their shell completion descriptions.
@@ -247,15 +282,43 @@ func ValidThemes(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellC
 	}, cobra.ShellCompDirectiveNoFileComp
 }
 
-// ValidSections provides shell completion for section filter values.
+// ValidSections provides shell completion for section filter and order
+// values, taken from the report section registry.
 func ValidSections(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
-	return []string{
-		"system\tSystem configuration and settings",
-		"network\tNetwork interfaces and routing",
-		"firewall\tFirewall rules and policies",
-		"services\tConfigured services and daemons",
-		"security\tSecurity settings and certificates",
-	}, cobra.ShellCompDirectiveNoFileComp
+	var completions []string
+	for _, section := range builder.ReportSections() {
+		completions = append(completions, section.ID+"\t"+section.Description)
+		for _, alias := range section.Aliases {
+			completions = append(completions, alias+"\tAlias for "+section.ID)
+		}
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// validateSectionOrder checks the --section-order values against the report
+// section registry.
+func validateSectionOrder() error {
+	if _, err := builder.ResolveSectionOrder(sharedSectionOrder); err != nil {
+		return fmt.Errorf("invalid --section-order: %w", err)
+	}
+	return nil
+}
+
+// applyPortRiskFile loads the --port-risk-file table and installs it for the
+// security analysis, or restores the built-in table when the flag is unset.
+func applyPortRiskFile() error {
+	if sharedPortRiskFile == "" {
+		analysis.SetPortRiskTable(nil)
+		return nil
+	}
+
+	table, err := analysis.LoadPortRiskTable(sharedPortRiskFile)
+	if err != nil {
+		return fmt.Errorf("invalid --port-risk-file: %w", err)
+	}
+
+	analysis.SetPortRiskTable(table)
+	return nil
 }
 
 // ValidColorModes provides shell completion for color mode values.
@@ -272,6 +335,7 @@ func ValidAuditModes(_ *cobra.Command, _ []string, _ string) ([]string, cobra.Sh
 	return []string{
 		"blue\tDefensive audit with security findings",
 		"red\tAttacker-focused recon report",
+		"executive\tOne-page summary with a posture rating",
 	}, cobra.ShellCompDirectiveNoFileComp
 }
 
@@ -345,7 +409,8 @@ func ValidAuditPlugins(_ *cobra.Command, _ []string, _ string) ([]string, cobra.
 // Canonical device type names are sourced from the parser.DefaultRegistry.
 func ValidDeviceTypes(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
 	devices := parser.DefaultRegistry().List()
-	completions := make([]string, 0, len(devices))
+	completions := make([]string, 0, len(devices)+1)
+	completions = append(completions, deviceTypeAuto+"\tDetect from the XML root element")
 
 	for _, d := range devices {
 		desc, ok := deviceTypeDescriptions[d]
@@ -360,11 +425,13 @@ func ValidDeviceTypes(_ *cobra.Command, _ []string, _ string) ([]string, cobra.S
 }
 
 // validateDeviceType validates the --device-type flag against the parser registry.
+// The "auto" value (and an empty string) always passes and selects detection
+// from the XML root element.
 // It checks the raw flag value directly against registered device types, so
 // third-party parsers registered in the registry are accepted even if they are
 // not built into the common.DeviceType enum.
 func validateDeviceType() error {
-	if sharedDeviceType == "" {
+	if isAutoDeviceType() {
 		return nil
 	}
 
@@ -379,6 +446,13 @@ func validateDeviceType() error {
 	)
 }
 
+// isAutoDeviceType reports whether the --device-type flag requests root-element
+// auto-detection: either the explicit "auto" value or an empty string.
+func isAutoDeviceType() bool {
+	v := strings.TrimSpace(sharedDeviceType)
+	return v == "" || strings.EqualFold(v, deviceTypeAuto)
+}
+
 // resolveDeviceType converts the raw --device-type flag value into a
 // common.DeviceType suitable for Factory.CreateDevice. For built-in types
 // (opnsense, pfsense) it returns the canonical enum constant. For non-empty
@@ -386,7 +460,7 @@ func validateDeviceType() error {
 // it falls back to casting the normalized registry key, allowing third-party
 // registered parsers to work via the CLI.
 func resolveDeviceType() common.DeviceType {
-	if sharedDeviceType == "" {
+	if isAutoDeviceType() {
 		return common.DeviceTypeUnknown
 	}
 
@@ -401,10 +475,33 @@ func resolveDeviceType() common.DeviceType {
 	return common.DeviceType(strings.ToLower(strings.TrimSpace(sharedDeviceType)))
 }
 
+// validateWrapFlagExclusivity returns an error when --no-wrap is combined with
+// --wrap or --max-width. A nil flags set is accepted.
+func validateWrapFlagExclusivity(flags *pflag.FlagSet) error {
+	if flags == nil {
+		return nil
+	}
+
+	changed := func(name string) bool {
+		f := flags.Lookup(name)
+		return f != nil && f.Changed
+	}
+
+	switch {
+	case changed("no-wrap") && changed("wrap"):
+		return errors.New("--no-wrap and --wrap flags are mutually exclusive")
+	case changed("no-wrap") && changed("max-width"):
+		return errors.New("--no-wrap and --max-width flags are mutually exclusive")
+	}
+
+	return nil
+}
+
 // validateOutputFlags validates format, wrap, and section flag combinations that are
 // shared across multiple commands (convert, audit). It checks mutual exclusivity of
 // wrap flags, validates the output format against the converter registry, warns when
-// section filtering is used with JSON or YAML, and validates wrap width range.
+// section filtering is used with JSON or YAML, and validates wrap width range. It also
+// loads the --port-risk-file table for the security analysis.
 //
 // Command-specific validation (e.g., audit mode, plugin names) should be performed
 // in the calling command's PreRunE, not here.
@@ -412,12 +509,16 @@ func resolveDeviceType() common.DeviceType {
 // The cmdLogger parameter is used for structured warnings; if nil, warnings fall back to stderr.
 func validateOutputFlags(flags *pflag.FlagSet, cmdLogger *logging.Logger) error {
 	// Validate mutual exclusivity for wrap flags before other checks
-	if flags != nil {
-		noWrapFlag := flags.Lookup("no-wrap")
-		wrapFlag := flags.Lookup("wrap")
-		if noWrapFlag != nil && wrapFlag != nil && noWrapFlag.Changed && wrapFlag.Changed {
-			return errors.New("--no-wrap and --wrap flags are mutually exclusive")
-		}
+	if err := validateWrapFlagExclusivity(flags); err != nil {
+		return err
+	}
+
+	if err := validateSectionOrder(); err != nil {
+		return err
+	}
+
+	if err := applyPortRiskFile(); err != nil {
+		return err
 	}
 
 	// Validate format values via the converter registry
@@ -469,5 +570,9 @@ func validateOutputFlags(flags *pflag.FlagSet, cmdLogger *logging.Logger) error
 			sharedWrapWidth)
 	}
 
+	if sharedMaxWidth < 0 {
+		return fmt.Errorf("invalid max width %d: must be 0 (off) or positive", sharedMaxWidth)
+	}
+
 	return nil
 }
@@ -1,9 +1,13 @@
 package cmd
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/EvilBit-Labs/opnDossier/internal/analysis"
+	"github.com/EvilBit-Labs/opnDossier/internal/converter/builder"
 	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
 	"github.com/EvilBit-Labs/opnDossier/pkg/parser"
 	"github.com/spf13/cobra"
@@ -18,10 +22,13 @@ func TestAddSharedContentFlagsRegistersFlags(t *testing.T) {
 	flags := cmd.Flags()
 	// These flags should exist
 	require.NotNil(t, flags.Lookup("section"))
+	require.NotNil(t, flags.Lookup("section-order"))
 	require.NotNil(t, flags.Lookup("wrap"))
 	require.NotNil(t, flags.Lookup("no-wrap"))
+	require.NotNil(t, flags.Lookup("max-width"))
 	require.NotNil(t, flags.Lookup("include-tunables"))
 	require.NotNil(t, flags.Lookup("comprehensive"))
+	require.NotNil(t, flags.Lookup("no-port-names"))
 
 	// These legacy flags (removed in NATS-6) should NOT exist
 	assert.Nil(t, flags.Lookup("legacy"))
@@ -34,6 +41,39 @@ func TestAddSharedContentFlagsRegistersFlags(t *testing.T) {
 	assert.Nil(t, flags.Lookup("redact"))
 }
 
+func TestValidateWrapFlagExclusivity(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{name: "max-width alone", args: []string{"--max-width", "60"}},
+		{name: "wrap alone", args: []string{"--wrap", "80"}},
+		{name: "no-wrap with max-width", args: []string{"--no-wrap", "--max-width", "60"}, wantErr: "--no-wrap and --max-width"},
+		{name: "wrap with max-width", args: []string{"--wrap", "80", "--max-width", "60"}},
+		{name: "no-wrap with wrap", args: []string{"--no-wrap", "--wrap", "80"}, wantErr: "--no-wrap and --wrap"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cobra.Command{Use: "test"}
+			addSharedContentFlags(cmd)
+			t.Cleanup(func() { sharedWrapWidth, sharedMaxWidth, sharedNoWrap = -1, 0, false })
+
+			require.NoError(t, cmd.Flags().Parse(tt.args))
+
+			err := validateWrapFlagExclusivity(cmd.Flags())
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
 func TestAddSharedRedactFlagRegistersFlag(t *testing.T) {
 	// Do NOT use t.Parallel() — cmd package uses package-level flag globals.
 	// See GOTCHAS §1.1.
@@ -59,13 +99,14 @@ func TestValidFormats(t *testing.T) {
 	// See GOTCHAS §1.1.
 	completions, directive := ValidFormats(nil, nil, "")
 	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
-	require.Len(t, completions, 5)
+	require.Len(t, completions, 6)
 	// Verify all formats are present (sorted alphabetically by the registry)
 	assert.Contains(t, completions[0], "html")
 	assert.Contains(t, completions[1], "json")
 	assert.Contains(t, completions[2], "markdown")
-	assert.Contains(t, completions[3], "text")
-	assert.Contains(t, completions[4], "yaml")
+	assert.Contains(t, completions[3], "pdf")
+	assert.Contains(t, completions[4], "text")
+	assert.Contains(t, completions[5], "yaml")
 }
 
 func TestValidThemes(t *testing.T) {
@@ -81,7 +122,76 @@ func TestValidSections(t *testing.T) {
 	// See GOTCHAS §1.1.
 	completions, directive := ValidSections(nil, nil, "")
 	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
-	require.Len(t, completions, 5)
+	require.Len(t, completions, 8)
+	assert.Contains(t, completions, "firewall\tAlias for security")
+
+	for _, section := range builder.ReportSections() {
+		assert.Contains(t, completions, section.ID+"\t"+section.Description)
+	}
+}
+
+func TestValidateSectionOrder(t *testing.T) {
+	// Do NOT use t.Parallel() — cmd package uses package-level flag globals.
+	// See GOTCHAS §1.1.
+	orig := sharedSectionOrder
+	t.Cleanup(func() { sharedSectionOrder = orig })
+
+	sharedSectionOrder = []string{"security", "system"}
+	require.NoError(t, validateSectionOrder())
+	assert.Equal(t, []string{"security", "system"}, buildConversionOptions("markdown", nil).SectionOrder)
+
+	sharedSectionOrder = []string{"system", "network", "system"}
+	err := validateSectionOrder()
+	require.ErrorIs(t, err, builder.ErrDuplicateSection)
+	assert.ErrorContains(t, err, "--section-order")
+
+	sharedSectionOrder = []string{"routing"}
+	require.ErrorIs(t, validateSectionOrder(), builder.ErrUnknownSection)
+}
+
+func TestApplyPortRiskFile(t *testing.T) {
+	// Do NOT use t.Parallel() — cmd package uses package-level flag globals.
+	// See GOTCHAS §1.1.
+	orig := sharedPortRiskFile
+	t.Cleanup(func() {
+		sharedPortRiskFile = orig
+		analysis.SetPortRiskTable(nil)
+	})
+
+	require.NotNil(t, convertCmd.Flags().Lookup("port-risk-file"))
+	require.NotNil(t, auditCmd.Flags().Lookup("port-risk-file"))
+
+	device := &common.CommonDevice{
+		FirewallRules: []common.FirewallRule{{Type: common.RuleTypePass, Interfaces: []string{"wan"}}},
+		NAT: common.NATConfig{InboundRules: []common.InboundNATRule{
+			{Interfaces: []string{"wan"}, ExternalPort: "8443", InternalIP: "192.168.1.50"},
+		}},
+	}
+	exposed := func() bool {
+		for _, f := range analysis.DetectSecurityIssues(device) {
+			if strings.HasPrefix(f.Component, "nat.inbound[0]") {
+				return true
+			}
+		}
+		return false
+	}
+
+	path := filepath.Join(t.TempDir(), "ports.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("8443: {service: HTTPS admin panel, severity: medium}\n"), 0o600))
+
+	sharedPortRiskFile = path
+	require.NoError(t, applyPortRiskFile())
+	assert.True(t, exposed(), "custom table adds 8443")
+
+	sharedPortRiskFile = ""
+	require.NoError(t, applyPortRiskFile())
+	assert.False(t, exposed(), "unset flag restores the built-in table")
+
+	require.NoError(t, os.WriteFile(path, []byte("8443: {service: HTTPS admin panel, severity: severe}\n"), 0o600))
+	sharedPortRiskFile = path
+	err := applyPortRiskFile()
+	require.ErrorIs(t, err, analysis.ErrInvalidPortRisk)
+	assert.ErrorContains(t, err, "--port-risk-file")
 }
 
 func TestValidColorModes(t *testing.T) {
@@ -110,6 +220,7 @@ func TestValidDeviceTypes(t *testing.T) {
 		}
 	}
 	assert.True(t, found, "opnsense should be in completions")
+	assert.True(t, strings.HasPrefix(completions[0], "auto\t"), "auto should be the first completion")
 }
 
 func TestValidateDeviceType(t *testing.T) {
@@ -124,6 +235,16 @@ func TestValidateDeviceType(t *testing.T) {
 			value:   "",
 			wantErr: false,
 		},
+		{
+			name:    "auto auto-detects",
+			value:   "auto",
+			wantErr: false,
+		},
+		{
+			name:    "AUTO uppercase auto-detects",
+			value:   "AUTO",
+			wantErr: false,
+		},
 		{
 			name:    "opnsense lowercase",
 			value:   "opnsense",
@@ -194,6 +315,11 @@ func TestResolveDeviceType(t *testing.T) {
 			value:    "",
 			expected: common.DeviceTypeUnknown,
 		},
+		{
+			name:     "auto returns DeviceTypeUnknown",
+			value:    "auto",
+			expected: common.DeviceTypeUnknown,
+		},
 		{
 			name:     "opnsense returns built-in constant",
 			value:    "opnsense",
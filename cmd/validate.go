@@ -157,7 +157,7 @@ Examples:
 							ctxLogger.Error("Configuration validation failed")
 							fmt.Fprintf(os.Stderr, "❌ %s:\n%s\n", fp, err)
 						} else {
-							fmt.Fprintf(os.Stderr, "❌ %s: %v\n", fp, err)
+							fmt.Fprintf(os.Stderr, "❌ %s: %v\n", fp, explainParseError(err))
 						}
 					}
 					return
@@ -168,6 +168,8 @@ Examples:
 						ctxLogger.Warn("conversion warning",
 							"field", w.Field,
 							"message", w.Message,
+							"value", w.Value,
+							"action", w.Action,
 							"severity", w.Severity,
 						)
 					}
@@ -0,0 +1,316 @@
+package analysis
+
+import (
+	"fmt"
+	"net/netip"
+	"strconv"
+
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+)
+
+// interfacePrefix is one static address of an enabled interface together
+// with its prefix length, e.g. 192.168.1.1/24.
+type interfacePrefix struct {
+	name   string
+	prefix netip.Prefix
+}
+
+// DetectAddressPlanIssues checks the device's IP address plan for collisions
+// that cause outages and usually indicate copy-paste configuration errors:
+//
+//   - two interfaces with the same address are Critical;
+//   - two interfaces whose subnets overlap are High;
+//   - a CARP virtual IP outside every subnet of its interface is Medium;
+//   - a gateway outside every subnet of its interface is Medium, unless it is
+//     marked as a far gateway;
+//   - a static DHCP lease whose address is an interface address is High;
+//   - an inbound NAT rule whose internal IP is an interface address is Info.
+//
+// Only enabled interfaces with static IPv4 or IPv6 addresses are considered;
+// disabled gateways, DHCP scopes and NAT rules are skipped. Each finding names both
+// colliding objects. Returns nil when no issues are found.
+func DetectAddressPlanIssues(cfg *common.CommonDevice) []common.ConsistencyFinding {
+	if cfg == nil {
+		return nil
+	}
+
+	prefixes := staticInterfacePrefixes(cfg.Interfaces)
+
+	var findings []common.ConsistencyFinding
+	findings = append(findings, detectInterfaceCollisions(prefixes)...)
+	findings = append(findings, detectCARPOutsideSubnet(cfg.VirtualIPs, prefixes)...)
+	findings = append(findings, detectGatewayOutsideSubnet(cfg.Routing.Gateways, prefixes)...)
+	findings = append(findings, detectStaticLeaseCollisions(cfg.DHCP, prefixes)...)
+	findings = append(findings, detectNATTargetsInterface(cfg.NAT.InboundRules, prefixes)...)
+
+	return findings
+}
+
+// staticInterfacePrefixes returns the parseable IPv4 and IPv6 addresses of
+// the enabled interfaces, in configuration order. Dynamic address keywords
+// such as "dhcp" or "track6" are skipped.
+func staticInterfacePrefixes(ifaces []common.Interface) []interfacePrefix {
+	var prefixes []interfacePrefix
+
+	for _, iface := range ifaces {
+		if !iface.Enabled {
+			continue
+		}
+
+		if p, ok := parseHostPrefix(iface.IPAddress, iface.Subnet); ok {
+			prefixes = append(prefixes, interfacePrefix{name: iface.Name, prefix: p})
+		}
+		if p, ok := parseHostPrefix(iface.IPv6Address, iface.SubnetV6); ok {
+			prefixes = append(prefixes, interfacePrefix{name: iface.Name, prefix: p})
+		}
+	}
+
+	return prefixes
+}
+
+// parseHostPrefix combines an address and a prefix length into a prefix that
+// keeps the host bits, e.g. ("10.0.0.1", "24") yields 10.0.0.1/24.
+func parseHostPrefix(addr, bits string) (netip.Prefix, bool) {
+	ip, err := netip.ParseAddr(addr)
+	if err != nil {
+		return netip.Prefix{}, false
+	}
+
+	n, err := strconv.Atoi(bits)
+	if err != nil {
+		return netip.Prefix{}, false
+	}
+
+	p := netip.PrefixFrom(ip.Unmap(), n)
+
+	return p, p.IsValid()
+}
+
+// detectInterfaceCollisions reports each pair of interfaces that share an
+// address (Critical) or whose subnets overlap (High). A pair with the same
+// address is reported once, as a duplicate.
+func detectInterfaceCollisions(prefixes []interfacePrefix) []common.ConsistencyFinding {
+	var findings []common.ConsistencyFinding
+
+	for i, a := range prefixes {
+		for _, b := range prefixes[i+1:] {
+			if a.name == b.name {
+				continue
+			}
+
+			switch {
+			case a.prefix.Addr() == b.prefix.Addr():
+				findings = append(findings, common.ConsistencyFinding{
+					Component: "interfaces." + a.name,
+					Issue:     "Duplicate Interface IP Address",
+					Severity:  common.SeverityCritical,
+					Description: fmt.Sprintf(
+						"Interfaces %s and %s are both assigned %s",
+						a.name, b.name, a.prefix.Addr(),
+					),
+					Recommendation: "Assign a unique address to each interface",
+				})
+			case a.prefix.Overlaps(b.prefix):
+				findings = append(findings, common.ConsistencyFinding{
+					Component: "interfaces." + a.name,
+					Issue:     "Overlapping Interface Subnets",
+					Severity:  common.SeverityHigh,
+					Description: fmt.Sprintf(
+						"Interface %s subnet %s overlaps interface %s subnet %s",
+						a.name, a.prefix.Masked(), b.name, b.prefix.Masked(),
+					),
+					Recommendation: "Renumber one of the interfaces so their subnets do not overlap",
+				})
+			}
+		}
+	}
+
+	return findings
+}
+
+// detectCARPOutsideSubnet reports CARP virtual IPs that fall outside every
+// subnet of the same address family on their interface. VIPs on interfaces
+// without a static address of that family are skipped.
+func detectCARPOutsideSubnet(vips []common.VirtualIP, prefixes []interfacePrefix) []common.ConsistencyFinding {
+	var findings []common.ConsistencyFinding
+
+	for i, vip := range vips {
+		if vip.Mode != common.VIPModeCarp {
+			continue
+		}
+
+		addr, err := netip.ParseAddr(vip.Subnet)
+		if err != nil {
+			continue
+		}
+
+		subnets, contained := interfaceContains(prefixes, vip.Interface, addr.Unmap())
+		if len(subnets) == 0 || contained {
+			continue
+		}
+
+		label := "CARP virtual IP " + vip.Subnet
+		if vip.VHID != "" {
+			label += " (VHID " + vip.VHID + ")"
+		}
+
+		findings = append(findings, common.ConsistencyFinding{
+			Component: fmt.Sprintf("virtualip.vip[%d]", i),
+			Issue:     "CARP Virtual IP Outside Interface Subnet",
+			Severity:  common.SeverityMedium,
+			Description: fmt.Sprintf(
+				"%s is not within interface %s subnet %s",
+				label, vip.Interface, subnets[0].Masked(),
+			),
+			Recommendation: "Move the virtual IP into the interface subnet or bind it to the correct interface",
+		})
+	}
+
+	return findings
+}
+
+// detectGatewayOutsideSubnet reports enabled gateways that fall outside every
+// subnet of the same address family on their interface, unless the gateway
+// is marked as a far gateway.
+func detectGatewayOutsideSubnet(gateways []common.Gateway, prefixes []interfacePrefix) []common.ConsistencyFinding {
+	var findings []common.ConsistencyFinding
+
+	for i, gw := range gateways {
+		if gw.Disabled || gw.FarGW {
+			continue
+		}
+
+		addr, err := netip.ParseAddr(gw.Address)
+		if err != nil {
+			continue
+		}
+
+		subnets, contained := interfaceContains(prefixes, gw.Interface, addr.Unmap())
+		if len(subnets) == 0 || contained {
+			continue
+		}
+
+		findings = append(findings, common.ConsistencyFinding{
+			Component: fmt.Sprintf("gateways.gateway_item[%d]", i),
+			Issue:     "Gateway Outside Interface Subnet",
+			Severity:  common.SeverityMedium,
+			Description: fmt.Sprintf(
+				"Gateway %s address %s is not within interface %s subnet %s",
+				gw.Name, gw.Address, gw.Interface, subnets[0].Masked(),
+			),
+			Recommendation: "Correct the gateway address, or mark it as a far gateway if it is intentionally off-link",
+		})
+	}
+
+	return findings
+}
+
+// detectStaticLeaseCollisions reports static DHCP leases in enabled scopes
+// whose address is an interface address of the firewall itself. The client
+// and the interface would answer ARP for the same address.
+func detectStaticLeaseCollisions(
+	scopes []common.DHCPScope,
+	prefixes []interfacePrefix,
+) []common.ConsistencyFinding {
+	var findings []common.ConsistencyFinding
+
+	for _, scope := range scopes {
+		if !scope.Enabled {
+			continue
+		}
+
+		for i, lease := range scope.StaticLeases {
+			addr, err := netip.ParseAddr(lease.IPAddress)
+			if err != nil {
+				continue
+			}
+
+			for _, p := range prefixes {
+				if p.prefix.Addr() != addr.Unmap() {
+					continue
+				}
+
+				client := lease.MAC
+				if lease.Hostname != "" {
+					client = lease.Hostname
+				}
+
+				findings = append(findings, common.ConsistencyFinding{
+					Component: fmt.Sprintf("dhcpd.%s.staticmap[%d]", scope.Interface, i),
+					Issue:     "Static DHCP Lease Collides With Interface Address",
+					Severity:  common.SeverityHigh,
+					Description: fmt.Sprintf(
+						"Static lease for %s in DHCP scope %s reserves %s, which is the address of interface %s",
+						client, scope.Interface, lease.IPAddress, p.name,
+					),
+					Recommendation: "Assign the client an unused address in the scope",
+				})
+
+				break
+			}
+		}
+	}
+
+	return findings
+}
+
+// detectNATTargetsInterface reports enabled inbound NAT rules whose internal
+// IP is an interface address of the firewall itself.
+func detectNATTargetsInterface(
+	rules []common.InboundNATRule,
+	prefixes []interfacePrefix,
+) []common.ConsistencyFinding {
+	var findings []common.ConsistencyFinding
+
+	for i, rule := range rules {
+		if rule.Disabled {
+			continue
+		}
+
+		addr, err := netip.ParseAddr(rule.InternalIP)
+		if err != nil {
+			continue
+		}
+
+		for _, p := range prefixes {
+			if p.prefix.Addr() != addr.Unmap() {
+				continue
+			}
+
+			findings = append(findings, common.ConsistencyFinding{
+				Component: fmt.Sprintf("nat.inbound[%d]", i),
+				Issue:     "Port Forward Targets Firewall Interface",
+				Severity:  common.SeverityInfo,
+				Description: fmt.Sprintf(
+					"Inbound NAT rule %d forwards to %s, which is the address of interface %s",
+					i+1, rule.InternalIP, p.name,
+				),
+				Recommendation: "Confirm the firewall itself is the intended target, or point the rule at the internal host",
+			})
+
+			break
+		}
+	}
+
+	return findings
+}
+
+// interfaceContains returns the subnets of interface name in addr's address
+// family and whether any of them contains addr.
+func interfaceContains(prefixes []interfacePrefix, name string, addr netip.Addr) ([]netip.Prefix, bool) {
+	var subnets []netip.Prefix
+
+	for _, p := range prefixes {
+		if p.name != name || p.prefix.Addr().Is4() != addr.Is4() {
+			continue
+		}
+
+		if p.prefix.Contains(addr) {
+			return append(subnets, p.prefix), true
+		}
+
+		subnets = append(subnets, p.prefix)
+	}
+
+	return subnets, false
+}
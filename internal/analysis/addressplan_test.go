@@ -0,0 +1,170 @@
+package analysis_test
+
+import (
+	"testing"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/analysis"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+//nolint:funlen // test table or data declaration; length is in data not logic
+func TestDetectAddressPlanIssues(t *testing.T) {
+	t.Parallel()
+
+	lan := common.Interface{Name: "lan", Enabled: true, IPAddress: "192.168.1.1", Subnet: "24"}
+	wan := common.Interface{Name: "wan", Enabled: true, IPAddress: "203.0.113.2", Subnet: "29"}
+
+	tests := []struct {
+		name string
+		cfg  *common.CommonDevice
+		want []common.ConsistencyFinding
+	}{
+		{
+			name: "nil device",
+			cfg:  nil,
+		},
+		{
+			name: "disjoint interfaces",
+			cfg:  &common.CommonDevice{Interfaces: []common.Interface{lan, wan}},
+		},
+		{
+			name: "overlapping subnets",
+			cfg: &common.CommonDevice{Interfaces: []common.Interface{
+				lan,
+				{Name: "opt1", Enabled: true, IPAddress: "192.168.1.129", Subnet: "25"},
+			}},
+			want: []common.ConsistencyFinding{{
+				Component:      "interfaces.lan",
+				Issue:          "Overlapping Interface Subnets",
+				Severity:       common.SeverityHigh,
+				Description:    "Interface lan subnet 192.168.1.0/24 overlaps interface opt1 subnet 192.168.1.128/25",
+				Recommendation: "Renumber one of the interfaces so their subnets do not overlap",
+			}},
+		},
+		{
+			name: "identical addresses are reported once as duplicates",
+			cfg: &common.CommonDevice{Interfaces: []common.Interface{
+				lan,
+				{Name: "opt1", Enabled: true, IPAddress: "192.168.1.1", Subnet: "24"},
+			}},
+			want: []common.ConsistencyFinding{{
+				Component:      "interfaces.lan",
+				Issue:          "Duplicate Interface IP Address",
+				Severity:       common.SeverityCritical,
+				Description:    "Interfaces lan and opt1 are both assigned 192.168.1.1",
+				Recommendation: "Assign a unique address to each interface",
+			}},
+		},
+		{
+			name: "IPv6 overlap",
+			cfg: &common.CommonDevice{Interfaces: []common.Interface{
+				{Name: "lan", Enabled: true, IPv6Address: "2001:db8::1", SubnetV6: "64"},
+				{Name: "opt1", Enabled: true, IPv6Address: "2001:db8::2", SubnetV6: "120"},
+			}},
+			want: []common.ConsistencyFinding{{
+				Component:      "interfaces.lan",
+				Issue:          "Overlapping Interface Subnets",
+				Severity:       common.SeverityHigh,
+				Description:    "Interface lan subnet 2001:db8::/64 overlaps interface opt1 subnet 2001:db8::/120",
+				Recommendation: "Renumber one of the interfaces so their subnets do not overlap",
+			}},
+		},
+		{
+			name: "disabled and dynamic interfaces are ignored",
+			cfg: &common.CommonDevice{Interfaces: []common.Interface{
+				lan,
+				{Name: "opt1", Enabled: false, IPAddress: "192.168.1.1", Subnet: "24"},
+				{Name: "opt2", Enabled: true, IPAddress: "dhcp"},
+			}},
+		},
+		{
+			name: "CARP VIP outside its interface subnet",
+			cfg: &common.CommonDevice{
+				Interfaces: []common.Interface{lan, wan},
+				VirtualIPs: []common.VirtualIP{
+					{Mode: common.VIPModeCarp, Interface: "lan", Subnet: "192.168.1.254", VHID: "1"},
+					{Mode: common.VIPModeCarp, Interface: "lan", Subnet: "10.9.9.1", VHID: "2"},
+					{Mode: common.VIPModeIPAlias, Interface: "lan", Subnet: "10.9.9.2"},
+					{Mode: common.VIPModeCarp, Interface: "lan", Subnet: "2001:db8::1", VHID: "3"},
+				},
+			},
+			want: []common.ConsistencyFinding{{
+				Component:      "virtualip.vip[1]",
+				Issue:          "CARP Virtual IP Outside Interface Subnet",
+				Severity:       common.SeverityMedium,
+				Description:    "CARP virtual IP 10.9.9.1 (VHID 2) is not within interface lan subnet 192.168.1.0/24",
+				Recommendation: "Move the virtual IP into the interface subnet or bind it to the correct interface",
+			}},
+		},
+		{
+			name: "gateway outside its interface subnet unless far gateway",
+			cfg: &common.CommonDevice{
+				Interfaces: []common.Interface{lan, wan},
+				Routing: common.Routing{Gateways: []common.Gateway{
+					{Name: "WAN_GW", Interface: "wan", Address: "203.0.113.1"},
+					{Name: "BAD_GW", Interface: "wan", Address: "198.51.100.1"},
+					{Name: "FAR_GW", Interface: "wan", Address: "198.51.100.2", FarGW: true},
+					{Name: "OFF_GW", Interface: "wan", Address: "198.51.100.3", Disabled: true},
+					{Name: "DYN_GW", Interface: "wan", Address: "dynamic"},
+				}},
+			},
+			want: []common.ConsistencyFinding{{
+				Component:      "gateways.gateway_item[1]",
+				Issue:          "Gateway Outside Interface Subnet",
+				Severity:       common.SeverityMedium,
+				Description:    "Gateway BAD_GW address 198.51.100.1 is not within interface wan subnet 203.0.113.0/29",
+				Recommendation: "Correct the gateway address, or mark it as a far gateway if it is intentionally off-link",
+			}},
+		},
+		{
+			name: "static lease on an interface address",
+			cfg: &common.CommonDevice{
+				Interfaces: []common.Interface{lan, wan},
+				DHCP: []common.DHCPScope{
+					{Interface: "lan", Enabled: true, StaticLeases: []common.DHCPStaticLease{
+						{MAC: "00:11:22:33:44:55", IPAddress: "192.168.1.20"},
+						{MAC: "00:11:22:33:44:66", Hostname: "printer", IPAddress: "192.168.1.1"},
+					}},
+					{Interface: "opt1", Enabled: false, StaticLeases: []common.DHCPStaticLease{
+						{MAC: "00:11:22:33:44:77", IPAddress: "192.168.1.1"},
+					}},
+				},
+			},
+			want: []common.ConsistencyFinding{{
+				Component: "dhcpd.lan.staticmap[1]",
+				Issue:     "Static DHCP Lease Collides With Interface Address",
+				Severity:  common.SeverityHigh,
+				Description: "Static lease for printer in DHCP scope lan reserves 192.168.1.1, " +
+					"which is the address of interface lan",
+				Recommendation: "Assign the client an unused address in the scope",
+			}},
+		},
+		{
+			name: "port forward to an interface address",
+			cfg: &common.CommonDevice{
+				Interfaces: []common.Interface{lan, wan},
+				NAT: common.NATConfig{InboundRules: []common.InboundNATRule{
+					{InternalIP: "192.168.1.10"},
+					{InternalIP: "192.168.1.1"},
+					{InternalIP: "192.168.1.1", Disabled: true},
+				}},
+			},
+			want: []common.ConsistencyFinding{{
+				Component:   "nat.inbound[1]",
+				Issue:       "Port Forward Targets Firewall Interface",
+				Severity:    common.SeverityInfo,
+				Description: "Inbound NAT rule 2 forwards to 192.168.1.1, which is the address of interface lan",
+				Recommendation: "Confirm the firewall itself is the intended target, " +
+					"or point the rule at the internal host",
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, analysis.DetectAddressPlanIssues(tt.cfg))
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package analysis
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+)
+
+// minRADIUSSecretLength is the shortest RADIUS shared secret not reported as
+// weak. RFC 2865 recommends at least 16 octets.
+const minRADIUSSecretLength = 16
+
+// detectAuthServerIssues checks the external authentication servers:
+//
+//   - an LDAP server reached over plain TCP on a non-loopback host is High,
+//     since bind credentials and user passwords cross the network in clear;
+//   - a RADIUS server whose shared secret is shorter than 16 characters is
+//     Medium. Only the length is reported, never the secret;
+//   - a server that neither the web GUI, an OpenVPN server, nor a captive
+//     portal zone authenticates against is Info.
+func detectAuthServerIssues(cfg *common.CommonDevice) []common.SecurityFinding {
+	if len(cfg.AuthServers) == 0 {
+		return nil
+	}
+
+	used := referencedAuthServers(cfg)
+
+	var findings []common.SecurityFinding
+	for i, server := range cfg.AuthServers {
+		component := fmt.Sprintf("system.authserver[%d]", i)
+
+		if server.Type == common.AuthServerLDAP && server.Transport == common.LDAPTransportTCP &&
+			!isLoopbackHost(server.Host) {
+			findings = append(findings, common.SecurityFinding{
+				Component: component,
+				Issue:     "LDAP Authentication Over Plain TCP",
+				Severity:  common.SeverityHigh,
+				Description: fmt.Sprintf(
+					"Authentication server %q connects to %s over unencrypted LDAP; bind credentials and user passwords are sent in clear text",
+					server.Name, server.Host,
+				),
+				Recommendation: "Switch the server transport to SSL/TLS (LDAPS) or StartTLS",
+			})
+		}
+
+		if server.Type == common.AuthServerRADIUS && server.SecretLength < minRADIUSSecretLength {
+			findings = append(findings, common.SecurityFinding{
+				Component: component,
+				Issue:     "Weak RADIUS Shared Secret",
+				Severity:  common.SeverityMedium,
+				Description: fmt.Sprintf(
+					"Authentication server %q uses a RADIUS shared secret shorter than %d characters",
+					server.Name, minRADIUSSecretLength,
+				),
+				Recommendation: fmt.Sprintf(
+					"Use a random shared secret of at least %d characters on the firewall and the RADIUS server",
+					minRADIUSSecretLength,
+				),
+			})
+		}
+
+		if !used[server.Name] {
+			findings = append(findings, common.SecurityFinding{
+				Component: component,
+				Issue:     "Unused Authentication Server",
+				Severity:  common.SeverityInfo,
+				Description: fmt.Sprintf(
+					"Authentication server %q is not used by the web GUI, any OpenVPN server, or any captive portal zone",
+					server.Name,
+				),
+				Recommendation: "Remove the server if it is no longer needed, or assign it where intended",
+			})
+		}
+	}
+
+	return findings
+}
+
+// referencedAuthServers returns the set of authentication server names the
+// web GUI, OpenVPN servers, and captive portal zones authenticate against.
+func referencedAuthServers(cfg *common.CommonDevice) map[string]bool {
+	used := make(map[string]bool)
+	addAuthModes := func(authMode string) {
+		for name := range strings.SplitSeq(authMode, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				used[name] = true
+			}
+		}
+	}
+
+	addAuthModes(cfg.System.WebGUI.AuthMode)
+	for _, server := range cfg.VPN.OpenVPN.Servers {
+		addAuthModes(server.AuthMode)
+	}
+	if cfg.CaptivePortal != nil {
+		for _, name := range cfg.CaptivePortal.AuthServers {
+			used[name] = true
+		}
+	}
+
+	return used
+}
+
+// isLoopbackHost reports whether host is "localhost" or a loopback address.
+func isLoopbackHost(host string) bool {
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+
+	ip := net.ParseIP(host)
+
+	return ip != nil && ip.IsLoopback()
+}
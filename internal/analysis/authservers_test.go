@@ -0,0 +1,93 @@
+package analysis_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/analysis"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+// authServerFindings returns the findings DetectSecurityIssues reports for
+// cfg's authentication servers, as "component: issue" strings.
+func authServerFindings(cfg *common.CommonDevice) []string {
+	var got []string
+	for _, f := range analysis.DetectSecurityIssues(cfg) {
+		if strings.HasPrefix(f.Component, "system.authserver[") {
+			got = append(got, f.Component+": "+f.Issue)
+		}
+	}
+	return got
+}
+
+//nolint:funlen // test table or data declaration; length is in data not logic
+func TestDetectSecurityIssues_AuthServers(t *testing.T) {
+	t.Parallel()
+
+	ldap := func(name, host string, transport common.LDAPTransport) common.AuthServer {
+		return common.AuthServer{Name: name, Type: common.AuthServerLDAP, Host: host, Transport: transport}
+	}
+	radius := func(name string, secretLength int) common.AuthServer {
+		return common.AuthServer{Name: name, Type: common.AuthServerRADIUS, Host: "10.0.0.5", SecretLength: secretLength}
+	}
+
+	tests := []struct {
+		name string
+		cfg  *common.CommonDevice
+		want []string
+	}{
+		{
+			name: "no servers",
+			cfg:  &common.CommonDevice{},
+		},
+		{
+			name: "plain LDAP to remote host",
+			cfg: &common.CommonDevice{
+				System:      common.System{WebGUI: common.WebGUI{AuthMode: "corp-ldap"}},
+				AuthServers: []common.AuthServer{ldap("corp-ldap", "ldap.example.com", common.LDAPTransportTCP)},
+			},
+			want: []string{"system.authserver[0]: LDAP Authentication Over Plain TCP"},
+		},
+		{
+			name: "plain LDAP to loopback and encrypted LDAP are not flagged",
+			cfg: &common.CommonDevice{
+				System: common.System{WebGUI: common.WebGUI{AuthMode: "Local Database, local,localhost6,ldaps,starttls"}},
+				AuthServers: []common.AuthServer{
+					ldap("local", "127.0.0.1", common.LDAPTransportTCP),
+					ldap("localhost6", "::1", common.LDAPTransportTCP),
+					ldap("ldaps", "dc1.example.com", common.LDAPTransportSSL),
+					ldap("starttls", "dc2.example.com", common.LDAPTransportStartTLS),
+				},
+			},
+		},
+		{
+			name: "short RADIUS secret",
+			cfg: &common.CommonDevice{
+				VPN: common.VPN{OpenVPN: common.OpenVPNConfig{
+					Servers: []common.OpenVPNServer{{AuthMode: "short,long"}},
+				}},
+				AuthServers: []common.AuthServer{radius("short", 15), radius("long", 16)},
+			},
+			want: []string{"system.authserver[0]: Weak RADIUS Shared Secret"},
+		},
+		{
+			name: "unused servers",
+			cfg: &common.CommonDevice{
+				CaptivePortal: &common.CaptivePortalConfig{AuthServers: []string{"guest-radius"}},
+				AuthServers: []common.AuthServer{
+					radius("guest-radius", 32),
+					ldap("old-ldap", "dc1.example.com", common.LDAPTransportSSL),
+				},
+			},
+			want: []string{"system.authserver[1]: Unused Authentication Server"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, authServerFindings(tt.cfg))
+		})
+	}
+}
@@ -0,0 +1,264 @@
+package analysis
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+)
+
+// DefaultMinDescriptionLength is the shortest rule description, in
+// characters, that DetectDescriptionHygiene accepts when the policy leaves
+// MinLength unset.
+const DefaultMinDescriptionLength = 10
+
+// percentScale converts a ratio into a percentage.
+const percentScale = 100
+
+// DescriptionPolicy configures the change-management checks that
+// DetectDescriptionHygiene applies to rule descriptions.
+type DescriptionPolicy struct {
+	// MinLength is the shortest acceptable description in characters. Zero
+	// or negative selects DefaultMinDescriptionLength.
+	MinLength int
+	// Pattern, when non-nil, must match every description (e.g. a ticket
+	// reference such as `(CHG|TKT)-\d+`).
+	Pattern *regexp.Regexp
+}
+
+// minLength returns the effective minimum description length.
+func (p DescriptionPolicy) minLength() int {
+	if p.MinLength <= 0 {
+		return DefaultMinDescriptionLength
+	}
+
+	return p.MinLength
+}
+
+// describedRule is one enabled rule whose description is under review.
+type describedRule struct {
+	component   string
+	label       string
+	description string
+}
+
+// ruleFamily groups rules that can be compared with each other for
+// functional equivalence.
+type ruleFamily struct {
+	rules []describedRule
+	// equivalent reports whether rules i and j differ only in metadata.
+	equivalent func(i, j int) bool
+}
+
+// DetectDescriptionHygiene checks the descriptions of every enabled firewall,
+// inbound NAT, and outbound NAT rule against policy and returns one
+// Observation per problem together with the overall compliance stats:
+//
+//   - an empty description is Low;
+//   - a description shorter than policy's minimum length is Info;
+//   - a description not matching policy.Pattern, when set, is Medium;
+//   - a description already used by a functionally different rule of the
+//     same kind is Info (equivalent rules are reported as duplicate rules
+//     elsewhere).
+//
+// A rule is compliant when it triggers none of the first three checks; the
+// duplicate check is advisory and does not affect the stats. Returns nil
+// observations and zero stats for a nil cfg.
+func DetectDescriptionHygiene(
+	cfg *common.CommonDevice,
+	policy DescriptionPolicy,
+) ([]Observation, common.DescriptionQuality) {
+	if cfg == nil {
+		return nil, common.DescriptionQuality{}
+	}
+
+	var (
+		observations []Observation
+		stats        common.DescriptionQuality
+	)
+
+	for _, family := range describedRuleFamilies(cfg) {
+		for _, rule := range family.rules {
+			stats.TotalRules++
+
+			problems := checkDescription(rule, policy)
+			if len(problems) == 0 {
+				stats.CompliantRules++
+			}
+
+			observations = append(observations, problems...)
+		}
+
+		observations = append(observations, duplicateDescriptions(family)...)
+	}
+
+	stats.CompliantPercent = percentScale
+	if stats.TotalRules > 0 {
+		stats.CompliantPercent = float64(stats.CompliantRules) * percentScale / float64(stats.TotalRules)
+	}
+
+	return observations, stats
+}
+
+// describedRuleFamilies collects the enabled firewall, inbound NAT, and
+// outbound NAT rules of cfg, each family with its own equivalence test.
+func describedRuleFamilies(cfg *common.CommonDevice) []ruleFamily {
+	return []ruleFamily{
+		newRuleFamily(
+			cfg.FirewallRules, "filter.rule", "Firewall rule",
+			func(r common.FirewallRule) (string, bool) { return r.Description, r.Disabled },
+			RulesEquivalent,
+		),
+		newRuleFamily(
+			cfg.NAT.InboundRules, "nat.inbound", "Inbound NAT rule",
+			func(r common.InboundNATRule) (string, bool) { return r.Description, r.Disabled },
+			func(a, b common.InboundNATRule) bool {
+				a.UUID, a.Description = "", ""
+				b.UUID, b.Description = "", ""
+
+				return reflect.DeepEqual(a, b)
+			},
+		),
+		newRuleFamily(
+			cfg.NAT.OutboundRules, "nat.outbound", "Outbound NAT rule",
+			func(r common.NATRule) (string, bool) { return r.Description, r.Disabled },
+			func(a, b common.NATRule) bool {
+				a.UUID, a.Description = "", ""
+				b.UUID, b.Description = "", ""
+
+				return reflect.DeepEqual(a, b)
+			},
+		),
+	}
+}
+
+// newRuleFamily builds a ruleFamily from the enabled entries of rules.
+// describe returns a rule's description and whether it is disabled;
+// equivalent compares two rules ignoring their identity and description.
+func newRuleFamily[R any](
+	rules []R,
+	componentPrefix, labelPrefix string,
+	describe func(R) (string, bool),
+	equivalent func(a, b R) bool,
+) ruleFamily {
+	enabled := make([]R, 0, len(rules))
+	family := ruleFamily{rules: make([]describedRule, 0, len(rules))}
+
+	for i, rule := range rules {
+		description, disabled := describe(rule)
+		if disabled {
+			continue
+		}
+
+		enabled = append(enabled, rule)
+		family.rules = append(family.rules, describedRule{
+			component:   fmt.Sprintf("%s[%d]", componentPrefix, i),
+			label:       fmt.Sprintf("%s %d", labelPrefix, i+1),
+			description: strings.TrimSpace(description),
+		})
+	}
+
+	family.equivalent = func(i, j int) bool {
+		return equivalent(enabled[i], enabled[j])
+	}
+
+	return family
+}
+
+// checkDescription returns the empty, too-short, and pattern-mismatch
+// observations for a single rule.
+func checkDescription(rule describedRule, policy DescriptionPolicy) []Observation {
+	if rule.description == "" {
+		return []Observation{descriptionObservation(
+			rule, SeverityLow,
+			"Rule Missing Description",
+			rule.label+" has no description.",
+			"Describe the rule's purpose and reference the change ticket that introduced it.",
+		)}
+	}
+
+	var observations []Observation
+
+	if minLen := policy.minLength(); utf8.RuneCountInString(rule.description) < minLen {
+		observations = append(observations, descriptionObservation(
+			rule, SeverityInfo,
+			"Rule Description Too Short",
+			fmt.Sprintf("%s description %q is shorter than %d characters.", rule.label, rule.description, minLen),
+			"Expand the description so reviewers can tell why the rule exists.",
+		))
+	}
+
+	if policy.Pattern != nil && !policy.Pattern.MatchString(rule.description) {
+		observations = append(observations, descriptionObservation(
+			rule, SeverityMedium,
+			"Rule Description Missing Required Reference",
+			fmt.Sprintf(
+				"%s description %q does not match the required pattern %q.",
+				rule.label, rule.description, policy.Pattern.String(),
+			),
+			"Add the change-management reference required by policy to the rule description.",
+		))
+	}
+
+	return observations
+}
+
+// duplicateDescriptions flags each rule whose non-empty description was
+// already used by an earlier, functionally different rule in the family.
+func duplicateDescriptions(family ruleFamily) []Observation {
+	var observations []Observation
+
+	firstSeen := make(map[string][]int)
+
+	for i, rule := range family.rules {
+		if rule.description == "" {
+			continue
+		}
+
+		key := strings.ToLower(rule.description)
+		for _, j := range firstSeen[key] {
+			if family.equivalent(i, j) {
+				continue
+			}
+
+			observations = append(observations, descriptionObservation(
+				rule, SeverityInfo,
+				"Duplicate Rule Description",
+				fmt.Sprintf(
+					"%s reuses the description %q of %s, which matches different traffic.",
+					rule.label, rule.description, strings.ToLower(family.rules[j].label),
+				),
+				"Give each rule a description that distinguishes it from the others.",
+			))
+
+			break
+		}
+
+		firstSeen[key] = append(firstSeen[key], i)
+	}
+
+	return observations
+}
+
+// descriptionObservation builds a description-hygiene Observation. These are
+// deterministic checks against local configuration text, so confidence is
+// High and reachability is Local.
+func descriptionObservation(
+	rule describedRule,
+	severity Severity,
+	title, description, recommendation string,
+) Observation {
+	return Observation{
+		Severity:       severity,
+		Confidence:     ConfidenceHigh,
+		Reachability:   Local,
+		Component:      rule.component,
+		Evidence:       fmt.Sprintf("description=%q", rule.description),
+		Title:          title,
+		Description:    description,
+		Recommendation: recommendation,
+	}
+}
@@ -0,0 +1,175 @@
+package analysis_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/analysis"
+	"github.com/EvilBit-Labs/opnDossier/internal/cfgparser"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/EvilBit-Labs/opnDossier/pkg/parser"
+	_ "github.com/EvilBit-Labs/opnDossier/pkg/parser/opnsense" // self-registers OPNsense parser via init()
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// descriptionFindings flattens observations into "component: title" pairs.
+func descriptionFindings(observations []analysis.Observation) []string {
+	got := make([]string, 0, len(observations))
+	for _, o := range observations {
+		got = append(got, o.Component+": "+o.Title)
+	}
+
+	return got
+}
+
+// TestDetectDescriptionHygiene_Fixture parses testdata/rule_descriptions_test.xml,
+// which holds one empty, one short, and one compliant description plus a
+// disabled rule, and checks the exact findings and compliance percentage.
+func TestDetectDescriptionHygiene_Fixture(t *testing.T) {
+	t.Parallel()
+
+	f, err := os.Open(filepath.Join("..", "..", "testdata", "rule_descriptions_test.xml"))
+	require.NoError(t, err)
+	defer f.Close()
+
+	factory := parser.NewFactory(cfgparser.NewXMLParser())
+	device, _, err := factory.CreateDevice(context.Background(), f, common.DeviceTypeUnknown, false)
+	require.NoError(t, err)
+
+	t.Run("default policy", func(t *testing.T) {
+		t.Parallel()
+
+		observations, quality := analysis.DetectDescriptionHygiene(device, analysis.DescriptionPolicy{})
+
+		assert.Equal(t, []string{
+			"filter.rule[0]: Rule Missing Description",
+			"filter.rule[1]: Rule Description Too Short",
+		}, descriptionFindings(observations))
+		assert.Equal(t, analysis.SeverityLow, observations[0].Severity)
+		assert.Equal(t, analysis.SeverityInfo, observations[1].Severity)
+
+		assert.Equal(t, 3, quality.TotalRules)
+		assert.Equal(t, 1, quality.CompliantRules)
+		assert.InDelta(t, 100.0/3, quality.CompliantPercent, 0.001)
+	})
+
+	t.Run("required pattern", func(t *testing.T) {
+		t.Parallel()
+
+		observations, quality := analysis.DetectDescriptionHygiene(device, analysis.DescriptionPolicy{
+			Pattern: regexp.MustCompile(`(CHG|TKT)-\d+`),
+		})
+
+		assert.Equal(t, []string{
+			"filter.rule[0]: Rule Missing Description",
+			"filter.rule[1]: Rule Description Too Short",
+			"filter.rule[1]: Rule Description Missing Required Reference",
+		}, descriptionFindings(observations))
+		assert.Equal(t, analysis.SeverityMedium, observations[2].Severity)
+		assert.Equal(t, 1, quality.CompliantRules)
+	})
+}
+
+func TestDetectDescriptionHygiene(t *testing.T) {
+	t.Parallel()
+
+	tcpRule := func(port, description string) common.FirewallRule {
+		return common.FirewallRule{
+			Type:        common.RuleTypePass,
+			Interfaces:  []string{"lan"},
+			Protocol:    "tcp",
+			Source:      common.RuleEndpoint{Address: "lan"},
+			Destination: common.RuleEndpoint{Address: "any", Port: port},
+			Description: description,
+		}
+	}
+
+	tests := []struct {
+		name        string
+		cfg         *common.CommonDevice
+		policy      analysis.DescriptionPolicy
+		want        []string
+		wantQuality common.DescriptionQuality
+	}{
+		{
+			name:        "nil device",
+			cfg:         nil,
+			want:        []string{},
+			wantQuality: common.DescriptionQuality{},
+		},
+		{
+			name:        "no rules is fully compliant",
+			cfg:         &common.CommonDevice{},
+			want:        []string{},
+			wantQuality: common.DescriptionQuality{CompliantPercent: 100},
+		},
+		{
+			name: "custom minimum length",
+			cfg: &common.CommonDevice{
+				FirewallRules: []common.FirewallRule{tcpRule("443", "Allow HTTPS")},
+			},
+			policy:      analysis.DescriptionPolicy{MinLength: 20},
+			want:        []string{"filter.rule[0]: Rule Description Too Short"},
+			wantQuality: common.DescriptionQuality{TotalRules: 1},
+		},
+		{
+			name: "duplicate description on different rules",
+			cfg: &common.CommonDevice{
+				FirewallRules: []common.FirewallRule{
+					tcpRule("443", "Allow web traffic"),
+					tcpRule("80", "allow web traffic"),
+				},
+			},
+			want:        []string{"filter.rule[1]: Duplicate Rule Description"},
+			wantQuality: common.DescriptionQuality{TotalRules: 2, CompliantRules: 2, CompliantPercent: 100},
+		},
+		{
+			name: "duplicate description on equivalent rules is not flagged",
+			cfg: &common.CommonDevice{
+				FirewallRules: []common.FirewallRule{
+					tcpRule("443", "Allow web traffic"),
+					tcpRule("443", "Allow web traffic"),
+				},
+			},
+			want:        []string{},
+			wantQuality: common.DescriptionQuality{TotalRules: 2, CompliantRules: 2, CompliantPercent: 100},
+		},
+		{
+			name: "NAT rules are checked and disabled rules skipped",
+			cfg: &common.CommonDevice{
+				NAT: common.NATConfig{
+					InboundRules: []common.InboundNATRule{
+						{InternalIP: "10.0.1.10", InternalPort: "443", Description: "TKT-77 web server"},
+						{InternalIP: "10.0.1.11", InternalPort: "22", Disabled: true},
+					},
+					OutboundRules: []common.NATRule{
+						{Interfaces: []string{"wan"}, Target: "192.0.2.1"},
+					},
+				},
+			},
+			policy:      analysis.DescriptionPolicy{Pattern: regexp.MustCompile(`(CHG|TKT)-\d+`)},
+			want:        []string{"nat.outbound[0]: Rule Missing Description"},
+			wantQuality: common.DescriptionQuality{TotalRules: 2, CompliantRules: 1, CompliantPercent: 50},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			observations, quality := analysis.DetectDescriptionHygiene(tt.cfg, tt.policy)
+
+			assert.Equal(t, tt.want, descriptionFindings(observations))
+			assert.Equal(t, tt.wantQuality, quality)
+
+			for _, o := range observations {
+				assert.Equal(t, analysis.Local, o.Reachability)
+				assert.Equal(t, analysis.ConfidenceHigh, o.Confidence)
+			}
+		})
+	}
+}
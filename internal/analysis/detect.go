@@ -6,6 +6,7 @@ import (
 	"net"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/EvilBit-Labs/opnDossier/internal/constants"
 	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
@@ -14,6 +15,8 @@ import (
 // ComputeAnalysis performs lightweight analysis of the device configuration and returns
 // an Analysis suitable for serialization in JSON/YAML exports. The returned Analysis is
 // derived purely from cfg with no side effects. A nil cfg returns an empty Analysis.
+// Address-plan collisions and schedule issues are reported alongside the
+// consistency issues; schedule expiry is judged against the current time.
 func ComputeAnalysis(cfg *common.CommonDevice) *common.Analysis {
 	if cfg == nil {
 		return &common.Analysis{}
@@ -24,11 +27,19 @@ func ComputeAnalysis(cfg *common.CommonDevice) *common.Analysis {
 		UnusedInterfaces:  DetectUnusedInterfaces(cfg),
 		SecurityIssues:    DetectSecurityIssues(cfg),
 		PerformanceIssues: DetectPerformanceIssues(cfg),
-		ConsistencyIssues: DetectConsistency(cfg),
+		ConsistencyIssues: detectConsistencyIssues(cfg),
 		ShadowedRules:     DetectShadowedRules(cfg),
 	}
 }
 
+// detectConsistencyIssues combines the consistency, address-plan, tunnel and
+// schedule findings reported as Analysis.ConsistencyIssues.
+func detectConsistencyIssues(cfg *common.CommonDevice) []common.ConsistencyFinding {
+	findings := append(DetectConsistency(cfg), DetectAddressPlanIssues(cfg)...)
+	findings = append(findings, DetectTunnelIssues(cfg)...)
+	return append(findings, DetectScheduleIssues(cfg, time.Now())...)
+}
+
 // deadRuleOwnerKey identifies one (interface, owner rule index) pair in the
 // legacy per-interface, raw-position bucketing DetectDeadRules re-projects
 // onto (see normalizeForDeadRuleView and DetectDeadRules doc comment). The
@@ -212,66 +223,72 @@ func normalizeForDeadRuleView(cfg *common.CommonDevice) *common.CommonDevice {
 }
 
 // DetectUnusedInterfaces detects enabled interfaces not referenced by firewall rules,
-// DHCP scopes, DNS resolvers (Unbound/DNSMasq), OpenVPN instances, WireGuard, or the
-// load balancer. DNS, WireGuard, and load balancer currently assume "lan" binding when
-// enabled — this is a known limitation when these services are bound to non-LAN interfaces.
+// DHCP scopes, DNS resolvers (Unbound/DNSMasq), VPN instances, load balancer
+// virtual servers, or GIF/GRE tunnels, using the references collected by
+// [BuildInterfaceIndex]. DNS and WireGuard currently assume "lan" binding when
+// enabled — this is a known limitation when these services are bound to non-LAN
+// interfaces.
 // Returns nil when no unused interfaces are found.
 func DetectUnusedInterfaces(cfg *common.CommonDevice) []common.UnusedInterfaceFinding {
 	if cfg == nil {
 		return nil
 	}
 
-	used := make(map[string]bool)
+	return BuildInterfaceIndex(cfg).UnusedInterfaces(cfg.Interfaces)
+}
 
-	for _, rule := range cfg.FirewallRules {
-		for _, iface := range rule.Interfaces {
-			used[iface] = true
+// markLoadBalancerInterfaces marks every interface that carries a load balancer
+// virtual server address as used. An interface carries the address when the
+// address is its own IP, falls inside its configured subnet, or is a virtual
+// IP bound to it. Virtual servers with unparseable addresses are skipped.
+func markLoadBalancerInterfaces(cfg *common.CommonDevice, used map[string]bool) {
+	for _, vs := range cfg.LoadBalancer.VirtualServers {
+		addr := net.ParseIP(strings.TrimSpace(vs.Address))
+		if addr == nil {
+			continue
 		}
-	}
-	for _, scope := range cfg.DHCP {
-		if scope.Enabled {
-			used[scope.Interface] = true
+
+		for _, iface := range cfg.Interfaces {
+			if interfaceContainsAddress(iface, addr) {
+				used[iface.Name] = true
+			}
 		}
-	}
-	if cfg.DNS.Unbound.Enabled || cfg.DNS.DNSMasq.Enabled {
-		used["lan"] = true
-	}
-	for _, srv := range cfg.VPN.OpenVPN.Servers {
-		if srv.Interface != "" {
-			used[srv.Interface] = true
+
+		for _, vip := range cfg.VirtualIPs {
+			if vip.Interface != "" && addr.Equal(net.ParseIP(vip.Subnet)) {
+				used[vip.Interface] = true
+			}
 		}
 	}
-	for _, cli := range cfg.VPN.OpenVPN.Clients {
-		if cli.Interface != "" {
-			used[cli.Interface] = true
+}
+
+// interfaceContainsAddress reports whether addr is iface's own address or lies
+// within its configured subnet. IPAddress may be a bare address with a separate
+// prefix length in Subnet, or already in CIDR notation.
+func interfaceContainsAddress(iface common.Interface, addr net.IP) bool {
+	cidr := iface.IPAddress
+	if !strings.Contains(cidr, "/") {
+		if ip := net.ParseIP(cidr); ip != nil && ip.Equal(addr) {
+			return true
 		}
-	}
-	if cfg.VPN.WireGuard.Enabled {
-		used["lan"] = true
-	}
-	if len(cfg.LoadBalancer.MonitorTypes) > 0 {
-		used["lan"] = true
+		if iface.Subnet == "" {
+			return false
+		}
+		cidr += "/" + iface.Subnet
 	}
 
-	var findings []common.UnusedInterfaceFinding
-	for _, iface := range cfg.Interfaces {
-		if iface.Enabled && !used[iface.Name] {
-			findings = append(findings, common.UnusedInterfaceFinding{
-				InterfaceName: iface.Name,
-				Description: fmt.Sprintf(
-					"Interface %s is enabled but not used in any rules or services",
-					strings.ToUpper(iface.Name),
-				),
-				Recommendation: "Consider disabling unused interface or add appropriate rules",
-			})
-		}
+	ip, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return false
 	}
 
-	return findings
+	return ip.Equal(addr) || network.Contains(addr)
 }
 
-// DetectSecurityIssues detects security configuration issues.
-// Returns nil when no security issues are found.
+// DetectSecurityIssues detects security configuration issues. Port forwards
+// are matched against the table set with SetPortRiskTable, or the built-in
+// table (see DetectRiskyPortForwards). Returns nil when no security issues
+// are found.
 func DetectSecurityIssues(cfg *common.CommonDevice) []common.SecurityFinding {
 	if cfg == nil {
 		return nil
@@ -312,9 +329,69 @@ func DetectSecurityIssues(cfg *common.CommonDevice) []common.SecurityFinding {
 		}
 	}
 
+	findings = append(findings, detectLegacyRemoteAccessVPN(cfg.VPN)...)
+	findings = append(findings, detectAuthServerIssues(cfg)...)
+	findings = append(findings, detectUPnPIssues(cfg.UPnP)...)
+	findings = append(findings, DetectRiskyPortForwards(cfg, portRiskTable())...)
+	findings = append(findings, detectOneToOneNATIssues(cfg.NAT)...)
+
 	return findings
 }
 
+// detectLegacyRemoteAccessVPN reports legacy PPTP and L2TP servers. An enabled
+// PPTP server is critical because MS-CHAPv2 is broken; an enabled L2TP server
+// is high when IPsec is not enabled to encrypt it. A section that is present
+// but disabled is reported as informational configuration residue.
+func detectLegacyRemoteAccessVPN(vpn common.VPN) []common.SecurityFinding {
+	var findings []common.SecurityFinding
+
+	if vpn.PPTP != nil {
+		if vpn.PPTP.Enabled {
+			findings = append(findings, common.SecurityFinding{
+				Component:      "vpn.pptp",
+				Issue:          "PPTP VPN Server Enabled",
+				Severity:       common.SeverityCritical,
+				Description:    "A legacy PPTP remote access server is enabled; PPTP relies on MS-CHAPv2, which is broken",
+				Recommendation: "Disable PPTP and migrate remote access users to OpenVPN, WireGuard, or IPsec",
+			})
+		} else {
+			findings = append(findings, legacyVPNRemnant("vpn.pptp", "PPTP"))
+		}
+	}
+
+	if vpn.L2TP != nil {
+		switch {
+		case !vpn.L2TP.Enabled:
+			findings = append(findings, legacyVPNRemnant("vpn.l2tp", "L2TP"))
+		case !vpn.IPsec.Enabled:
+			findings = append(findings, common.SecurityFinding{
+				Component:      "vpn.l2tp",
+				Issue:          "L2TP VPN Server Without IPsec",
+				Severity:       common.SeverityHigh,
+				Description:    "A legacy L2TP remote access server is enabled but IPsec is not, so tunnel traffic is unencrypted",
+				Recommendation: "Disable L2TP or carry it inside IPsec; prefer migrating to OpenVPN, WireGuard, or IPsec",
+			})
+		}
+	}
+
+	return findings
+}
+
+// legacyVPNRemnant returns the informational finding for a disabled legacy
+// PPTP or L2TP section that is still present in the configuration.
+func legacyVPNRemnant(component, protocol string) common.SecurityFinding {
+	return common.SecurityFinding{
+		Component: component,
+		Issue:     fmt.Sprintf("Legacy %s Configuration Present", protocol),
+		Severity:  common.SeverityInfo,
+		Description: fmt.Sprintf(
+			"A disabled %s remote access section remains in the configuration (legacy configuration remnants)",
+			protocol,
+		),
+		Recommendation: fmt.Sprintf("Consider removing the unused %s section", protocol),
+	}
+}
+
 // DetectPerformanceIssues detects performance configuration issues.
 // Returns nil when no performance issues are found.
 func DetectPerformanceIssues(cfg *common.CommonDevice) []common.PerformanceFinding {
@@ -422,5 +499,67 @@ func DetectConsistency(cfg *common.CommonDevice) []common.ConsistencyFinding {
 		}
 	}
 
+	findings = append(findings, detectLoadBalancerConsistency(cfg.LoadBalancer)...)
+	findings = append(findings, detectUnrecognizedTunables(cfg.Sysctl)...)
+
+	return findings
+}
+
+// detectLoadBalancerConsistency flags virtual servers whose primary pool cannot
+// serve traffic (missing, or no enabled members) and pools whose health monitor
+// references a monitor type that does not exist.
+func detectLoadBalancerConsistency(lb common.LoadBalancerConfig) []common.ConsistencyFinding {
+	var findings []common.ConsistencyFinding
+
+	pools := make(map[string]common.LBPool, len(lb.Pools))
+	for _, pool := range lb.Pools {
+		pools[pool.Name] = pool
+	}
+
+	for i, vs := range lb.VirtualServers {
+		pool, exists := pools[vs.Pool]
+		if exists && len(pool.Servers) > 0 {
+			continue
+		}
+
+		reason := fmt.Sprintf("pool %s has no enabled members", vs.Pool)
+		if !exists {
+			reason = fmt.Sprintf("pool %s does not exist", vs.Pool)
+		}
+
+		findings = append(findings, common.ConsistencyFinding{
+			Component: fmt.Sprintf("load_balancer.virtual_server[%d].poolname", i),
+			Issue:     "Load Balancer Virtual Server Has No Backends",
+			Severity:  common.SeverityMedium,
+			Description: fmt.Sprintf(
+				"Virtual server %s (%s:%s) has no primary backends: %s",
+				vs.Name, vs.Address, vs.Port, reason,
+			),
+			Recommendation: "Add enabled members to the pool, point the virtual server at a populated pool, or remove the virtual server",
+		})
+	}
+
+	monitors := make(map[string]bool, len(lb.MonitorTypes))
+	for _, m := range lb.MonitorTypes {
+		monitors[m.Name] = true
+	}
+
+	for i, pool := range lb.Pools {
+		if pool.Monitor == "" || monitors[pool.Monitor] {
+			continue
+		}
+
+		findings = append(findings, common.ConsistencyFinding{
+			Component: fmt.Sprintf("load_balancer.lbpool[%d].monitor", i),
+			Issue:     "Load Balancer Pool References Non-existent Monitor",
+			Severity:  common.SeverityMedium,
+			Description: fmt.Sprintf(
+				"Pool %s references monitor %s which does not exist",
+				pool.Name, pool.Monitor,
+			),
+			Recommendation: "Create the referenced monitor type or update the pool's health monitor",
+		})
+	}
+
 	return findings
 }
@@ -646,7 +646,34 @@ func TestDetectUnusedInterfaces(t *testing.T) {
 			wantCount: 0,
 		},
 		{
-			name: "used by load balancer not flagged",
+			name: "carrying load balancer virtual server not flagged",
+			cfg: &common.CommonDevice{
+				Interfaces: []common.Interface{
+					{Name: "lan", Enabled: true, IPAddress: "10.0.1.1", Subnet: "24"},
+					{Name: "opt1", Enabled: true, IPAddress: "172.16.0.1", Subnet: "24"},
+				},
+				LoadBalancer: common.LoadBalancerConfig{
+					VirtualServers: []common.LBVirtualServer{{Name: "web", Address: "172.16.0.10"}},
+				},
+			},
+			wantCount: 1,
+			wantNames: []string{"lan"},
+		},
+		{
+			name: "virtual server on bound virtual IP not flagged",
+			cfg: &common.CommonDevice{
+				Interfaces: []common.Interface{
+					{Name: "wan", Enabled: true, IPAddress: "dhcp"},
+				},
+				VirtualIPs: []common.VirtualIP{{Interface: "wan", Subnet: "203.0.113.10", SubnetBits: "32"}},
+				LoadBalancer: common.LoadBalancerConfig{
+					VirtualServers: []common.LBVirtualServer{{Name: "web", Address: "203.0.113.10"}},
+				},
+			},
+			wantCount: 0,
+		},
+		{
+			name: "monitor types alone do not mark lan as used",
 			cfg: &common.CommonDevice{
 				Interfaces: []common.Interface{
 					{Name: "lan", Enabled: true},
@@ -655,7 +682,8 @@ func TestDetectUnusedInterfaces(t *testing.T) {
 					MonitorTypes: []common.MonitorType{{Name: "http"}},
 				},
 			},
-			wantCount: 0,
+			wantCount: 1,
+			wantNames: []string{"lan"},
 		},
 	}
 
@@ -750,6 +778,40 @@ func TestDetectSecurityIssues(t *testing.T) {
 			wantIssues:     []string{"Overly Permissive WAN Rule"},
 			wantSeverities: []common.Severity{common.SeverityHigh},
 		},
+		{
+			name: "enabled PPTP and L2TP without IPsec",
+			cfg: &common.CommonDevice{
+				VPN: common.VPN{
+					PPTP: &common.LegacyRemoteAccessVPN{Enabled: true, Mode: "server"},
+					L2TP: &common.LegacyRemoteAccessVPN{Enabled: true, Mode: "server"},
+				},
+			},
+			wantCount:      2,
+			wantIssues:     []string{"PPTP VPN Server Enabled", "L2TP VPN Server Without IPsec"},
+			wantSeverities: []common.Severity{common.SeverityCritical, common.SeverityHigh},
+		},
+		{
+			name: "L2TP carried over IPsec is not flagged",
+			cfg: &common.CommonDevice{
+				VPN: common.VPN{
+					IPsec: common.IPsecConfig{Enabled: true},
+					L2TP:  &common.LegacyRemoteAccessVPN{Enabled: true, Mode: "server"},
+				},
+			},
+			wantCount: 0,
+		},
+		{
+			name: "disabled legacy VPN sections are informational",
+			cfg: &common.CommonDevice{
+				VPN: common.VPN{
+					PPTP: &common.LegacyRemoteAccessVPN{Mode: "off"},
+					L2TP: &common.LegacyRemoteAccessVPN{Mode: "off"},
+				},
+			},
+			wantCount:      2,
+			wantIssues:     []string{"Legacy PPTP Configuration Present", "Legacy L2TP Configuration Present"},
+			wantSeverities: []common.Severity{common.SeverityInfo, common.SeverityInfo},
+		},
 	}
 
 	for _, tt := range tests {
@@ -860,6 +922,29 @@ func TestDetectConsistency(t *testing.T) {
 			},
 			wantCount: 0,
 		},
+		{
+			name: "load balancer dead virtual servers and dangling monitor",
+			cfg: &common.CommonDevice{
+				LoadBalancer: common.LoadBalancerConfig{
+					MonitorTypes: []common.MonitorType{{Name: "HTTP"}},
+					Pools: []common.LBPool{
+						{Name: "web", Monitor: "HTTP", Servers: []string{"10.0.1.10", "10.0.1.11"}},
+						{Name: "drained", Monitor: "TCP-custom", DisabledServers: []string{"10.0.1.20"}},
+					},
+					VirtualServers: []common.LBVirtualServer{
+						{Name: "vip-web", Address: "203.0.113.10", Port: "443", Pool: "web"},
+						{Name: "vip-drained", Address: "203.0.113.11", Port: "443", Pool: "drained"},
+						{Name: "vip-missing", Address: "203.0.113.12", Port: "80", Pool: "gone"},
+					},
+				},
+			},
+			wantCount: 3,
+			wantIssues: []string{
+				"Load Balancer Virtual Server Has No Backends",
+				"Load Balancer Virtual Server Has No Backends",
+				"Load Balancer Pool References Non-existent Monitor",
+			},
+		},
 		{
 			name: "invalid gateway format",
 			cfg: &common.CommonDevice{
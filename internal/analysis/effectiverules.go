@@ -0,0 +1,104 @@
+package analysis
+
+import (
+	"slices"
+
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+)
+
+// RuleOrigin records why a firewall rule applies to an interface.
+type RuleOrigin string
+
+// Rule origins, in the order their tiers are evaluated.
+const (
+	// RuleOriginFloating is a floating rule naming the interface, a group
+	// containing it, or no interface at all.
+	RuleOriginFloating RuleOrigin = "floating"
+	// RuleOriginGroup is a rule bound to an interface group containing the
+	// interface.
+	RuleOriginGroup RuleOrigin = "group"
+	// RuleOriginDirect is a rule bound to the interface itself.
+	RuleOriginDirect RuleOrigin = "direct"
+)
+
+// EffectiveRule is one rule of an interface's effective ruleset.
+type EffectiveRule struct {
+	IndexedRule
+
+	// Origin is why the rule applies to the interface.
+	Origin RuleOrigin
+	// Group is the interface group the rule is bound to when Origin is
+	// RuleOriginGroup.
+	Group string
+}
+
+// OriginLabel returns the origin as shown in reports: "floating",
+// "group:NAME", or "direct".
+func (r EffectiveRule) OriginLabel() string {
+	if r.Origin == RuleOriginGroup {
+		return string(RuleOriginGroup) + ":" + r.Group
+	}
+	return string(r.Origin)
+}
+
+// EffectiveRules returns the enabled firewall rules that apply to traffic on
+// the interface named iface, in the order pf evaluates them. OPNsense
+// generates the ruleset in three tiers:
+//  1. floating rules that name iface, name a group containing iface, or name
+//     no interface and so apply to every interface;
+//  2. rules bound to an interface group containing iface, group by group in
+//     the order the groups are defined;
+//  3. rules bound to iface itself.
+//
+// Within a tier rules keep their configuration order. A rule is listed once,
+// in the first tier it qualifies for. Disabled rules never reach pf and are
+// omitted. A nil cfg returns nil.
+func EffectiveRules(cfg *common.CommonDevice, iface string) []EffectiveRule {
+	if cfg == nil {
+		return nil
+	}
+
+	var groups []string
+	for _, group := range cfg.InterfaceGroups {
+		if slices.Contains(group.Members, iface) {
+			groups = append(groups, group.Name)
+		}
+	}
+
+	var effective []EffectiveRule
+	listed := make(map[int]bool)
+	add := func(i int, origin RuleOrigin, group string) {
+		listed[i] = true
+		effective = append(effective, EffectiveRule{
+			IndexedRule: IndexedRule{Index: i, Rule: cfg.FirewallRules[i]},
+			Origin:      origin,
+			Group:       group,
+		})
+	}
+
+	for i, rule := range cfg.FirewallRules {
+		if rule.Disabled || !rule.Floating {
+			continue
+		}
+		if isUnscopedFloating(rule) || slices.Contains(rule.Interfaces, iface) ||
+			slices.ContainsFunc(groups, func(g string) bool { return slices.Contains(rule.Interfaces, g) }) {
+			add(i, RuleOriginFloating, "")
+		}
+	}
+
+	for _, group := range groups {
+		for i, rule := range cfg.FirewallRules {
+			if !rule.Disabled && !rule.Floating && !listed[i] && slices.Contains(rule.Interfaces, group) {
+				add(i, RuleOriginGroup, group)
+			}
+		}
+	}
+
+	for i, rule := range cfg.FirewallRules {
+		if !rule.Disabled && !rule.Floating && !listed[i] && slices.Contains(rule.Interfaces, iface) {
+			add(i, RuleOriginDirect, "")
+		}
+	}
+
+	return effective
+}
@@ -0,0 +1,146 @@
+package analysis_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/analysis"
+	"github.com/EvilBit-Labs/opnDossier/internal/cfgparser"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/EvilBit-Labs/opnDossier/pkg/parser"
+	_ "github.com/EvilBit-Labs/opnDossier/pkg/parser/opnsense" // self-registers OPNsense parser via init()
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// effectiveOrder flattens an effective ruleset into "description origin"
+// strings.
+func effectiveOrder(rules []analysis.EffectiveRule) []string {
+	got := make([]string, 0, len(rules))
+	for _, r := range rules {
+		got = append(got, r.Rule.Description+" "+r.OriginLabel())
+	}
+
+	return got
+}
+
+// TestEffectiveRules_Fixture parses testdata/effective_rules_test.xml, where
+// WAN has a direct, a group and a floating rule defined in that order, and
+// checks they are listed in pf evaluation order instead.
+func TestEffectiveRules_Fixture(t *testing.T) {
+	t.Parallel()
+
+	f, err := os.Open(filepath.Join("..", "..", "testdata", "effective_rules_test.xml"))
+	require.NoError(t, err)
+	defer f.Close()
+
+	factory := parser.NewFactory(cfgparser.NewXMLParser())
+	device, _, err := factory.CreateDevice(context.Background(), f, common.DeviceTypeUnknown, false)
+	require.NoError(t, err)
+
+	wan := analysis.EffectiveRules(device, "wan")
+	assert.Equal(t, []string{
+		"Block known bad hosts floating",
+		"Block SMB on uplinks group:uplinks",
+		"Allow HTTPS to web server direct",
+	}, effectiveOrder(wan))
+
+	indexes := make([]int, 0, len(wan))
+	for _, r := range wan {
+		indexes = append(indexes, r.Index)
+	}
+	assert.Equal(t, []int{2, 1, 0}, indexes)
+
+	assert.Equal(t, []string{"Default allow LAN to any direct"}, effectiveOrder(analysis.EffectiveRules(device, "lan")))
+}
+
+func TestEffectiveRules(t *testing.T) {
+	t.Parallel()
+
+	rule := func(description string, floating bool, interfaces ...string) common.FirewallRule {
+		return common.FirewallRule{
+			Type:        common.RuleTypePass,
+			Description: description,
+			Floating:    floating,
+			Interfaces:  interfaces,
+		}
+	}
+
+	tests := []struct {
+		name  string
+		cfg   *common.CommonDevice
+		iface string
+		want  []string
+	}{
+		{
+			name:  "nil config",
+			iface: "wan",
+			want:  []string{},
+		},
+		{
+			name: "unscoped floating rule applies to every interface",
+			cfg: &common.CommonDevice{
+				FirewallRules: []common.FirewallRule{rule("any", true)},
+			},
+			iface: "opt1",
+			want:  []string{"any floating"},
+		},
+		{
+			name: "floating rule naming a group containing the interface",
+			cfg: &common.CommonDevice{
+				InterfaceGroups: []common.InterfaceGroup{{Name: "inside", Members: []string{"lan", "opt1"}}},
+				FirewallRules:   []common.FirewallRule{rule("inside", true, "inside"), rule("wan only", true, "wan")},
+			},
+			iface: "opt1",
+			want:  []string{"inside floating"},
+		},
+		{
+			name: "group rules follow group definition order",
+			cfg: &common.CommonDevice{
+				InterfaceGroups: []common.InterfaceGroup{
+					{Name: "second", Members: []string{"lan"}},
+					{Name: "first", Members: []string{"lan"}},
+					{Name: "other", Members: []string{"wan"}},
+				},
+				FirewallRules: []common.FirewallRule{
+					rule("first", false, "first"),
+					rule("other", false, "other"),
+					rule("second", false, "second"),
+				},
+			},
+			iface: "lan",
+			want:  []string{"second group:second", "first group:first"},
+		},
+		{
+			name: "rule naming both a group and the interface is listed once",
+			cfg: &common.CommonDevice{
+				InterfaceGroups: []common.InterfaceGroup{{Name: "inside", Members: []string{"lan"}}},
+				FirewallRules:   []common.FirewallRule{rule("both", false, "lan", "inside")},
+			},
+			iface: "lan",
+			want:  []string{"both group:inside"},
+		},
+		{
+			name: "disabled rules are omitted",
+			cfg: &common.CommonDevice{
+				FirewallRules: []common.FirewallRule{
+					{Description: "off", Interfaces: []string{"lan"}, Disabled: true},
+					{Description: "off floating", Floating: true, Disabled: true},
+					rule("on", false, "lan"),
+				},
+			},
+			iface: "lan",
+			want:  []string{"on direct"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, effectiveOrder(analysis.EffectiveRules(tt.cfg, tt.iface)))
+		})
+	}
+}
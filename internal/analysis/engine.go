@@ -23,7 +23,8 @@ var weakTLSProtocols = []string{"SSLv3", "SSLv2", "TLSv1", "TLSv1.1"}
 // detections wrapped with reachability and confidence, plus additive
 // framework-free hygiene detectors for categories no compliance plugin owns
 // at per-instance granularity (insecure management protocols, weak crypto
-// defaults, any-to-any rules, disabled logging).
+// defaults, any-to-any rules, disabled logging, floating rule ordering, state
+// table exhaustion).
 //
 // ScanObservations does not modify DetectSecurityIssues or ComputeAnalysis;
 // both remain unchanged for their existing callers in internal/converter and
@@ -39,6 +40,8 @@ func ScanObservations(cfg *common.CommonDevice) []Observation {
 	observations = append(observations, detectAnyToAnyRules(cfg)...)
 	observations = append(observations, detectDisabledLogging(cfg)...)
 	observations = append(observations, detectShadowedRules(cfg)...)
+	observations = append(observations, detectFloatingRuleIssues(cfg)...)
+	observations = append(observations, detectStateTableExhaustion(cfg)...)
 
 	return observations
 }
@@ -81,13 +84,16 @@ func adaptSecurityFindings(findings []common.SecurityFinding) []Observation {
 // the permissive WAN pass rule (Component "filter.rule[N]"), and that
 // detector already requires the rule to be bound to a WAN interface (via
 // RuleReachability as of the U1 consolidation), so it is deterministically
+// WAN-reachable. The same holds for the risky port forward findings
+// (Component "nat.inbound[N] ..."), which DetectRiskyPortForwards only
+// emits for forwards InboundNATRuleReachability classifies as
 // WAN-reachable. System-wide findings (insecure WebGUI protocol, default
 // SNMP community) are not bound to a specific interface, and correlating
 // them against exposing firewall/NAT rules is red mode's WAN-exposed-service
 // enumeration (R17), out of scope for this slice — so they are tagged Local
 // here rather than guessed.
 func securityFindingReachability(f common.SecurityFinding) Reachability {
-	if strings.HasPrefix(f.Component, "filter.rule[") {
+	if strings.HasPrefix(f.Component, "filter.rule[") || strings.HasPrefix(f.Component, "nat.inbound[") {
 		return WANReachable
 	}
 
@@ -0,0 +1,160 @@
+package analysis
+
+import (
+	"cmp"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/constants"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+)
+
+// PortExposureEntry is a port that can be reached from the WAN, together with
+// the rule that opens it.
+type PortExposureEntry struct {
+	// Protocol is the layer-4 protocol (e.g. "tcp", "tcp/udp"), or "any".
+	Protocol string
+	// Port is the destination port or range as configured, or "any".
+	Port string
+	// Target is where the traffic is delivered: a pass rule's destination
+	// address, a port forward's internal IP and port, or a 1:1 NAT rule's
+	// internal address.
+	Target string
+	// SourceRule identifies the rule that opens the port, e.g.
+	// "filter.rule[2]: Allow HTTPS", "nat.inbound[0]", or "nat.onetoone[1]".
+	SourceRule string
+}
+
+// WANPortExposure lists the ports reachable from the WAN. It combines enabled
+// WAN-reachable pass rules (see RuleReachability) with enabled inbound NAT
+// port forwards that InboundNATRuleReachability classifies as WAN-reachable,
+// and with enabled 1:1 NAT rules that OneToOneNATRuleReachability classifies
+// as WAN-reachable. A 1:1 mapping forwards every port, so its entry has the
+// protocol and port "any" unless the rule restricts the destination port.
+// Outbound-only pass rules and forwards with redirection disabled (NoRDR) open
+// nothing and are skipped.
+//
+// A port opened by both a pass rule and a port forward is listed once per
+// rule, so every rule that opens it can be reviewed. Entries are sorted by
+// port number, with non-numeric ports (such as "any" or an alias) last, then
+// by protocol; entries for the same port keep the configuration order, pass
+// rules first.
+func WANPortExposure(device *common.CommonDevice) []PortExposureEntry {
+	if device == nil {
+		return nil
+	}
+
+	var entries []PortExposureEntry
+
+	for i, rule := range device.FirewallRules {
+		if rule.Disabled || rule.Type != common.RuleTypePass || rule.Direction == common.DirectionOut {
+			continue
+		}
+
+		if RuleReachability(rule, device.Interfaces) != WANReachable {
+			continue
+		}
+
+		entries = append(entries, PortExposureEntry{
+			Protocol:   cmp.Or(rule.Protocol, constants.NetworkAny),
+			Port:       cmp.Or(rule.Destination.Port, constants.NetworkAny),
+			Target:     exposureEndpointAddress(rule.Destination),
+			SourceRule: exposureRuleLabel(fmt.Sprintf("filter.rule[%d]", i), rule.Description),
+		})
+	}
+
+	for i, nat := range device.NAT.InboundRules {
+		if nat.NoRDR || InboundNATRuleReachability(nat, device.Interfaces, device.FirewallRules) != WANReachable {
+			continue
+		}
+
+		target := cmp.Or(nat.InternalIP, constants.NetworkAny)
+		if nat.InternalIP != "" && nat.InternalPort != "" {
+			target = net.JoinHostPort(nat.InternalIP, nat.InternalPort)
+		}
+
+		entries = append(entries, PortExposureEntry{
+			Protocol:   cmp.Or(nat.Protocol, constants.NetworkAny),
+			Port:       cmp.Or(nat.ExternalPort, nat.Destination.Port, constants.NetworkAny),
+			Target:     target,
+			SourceRule: exposureRuleLabel(fmt.Sprintf("nat.inbound[%d]", i), nat.Description),
+		})
+	}
+
+	for i, nat := range device.NAT.OneToOneRules {
+		if OneToOneNATRuleReachability(nat, device.Interfaces, device.FirewallRules) != WANReachable {
+			continue
+		}
+
+		entries = append(entries, PortExposureEntry{
+			Protocol:   constants.NetworkAny,
+			Port:       cmp.Or(nat.Destination.Port, constants.NetworkAny),
+			Target:     cmp.Or(nat.Internal, constants.NetworkAny),
+			SourceRule: exposureRuleLabel(fmt.Sprintf("nat.onetoone[%d]", i), nat.Description),
+		})
+	}
+
+	slices.SortStableFunc(entries, func(a, b PortExposureEntry) int {
+		return cmp.Or(
+			compareExposurePorts(a.Port, b.Port),
+			cmp.Compare(a.Protocol, b.Protocol),
+		)
+	})
+
+	return entries
+}
+
+// exposureEndpointAddress returns the address of ep, prefixed with "!" when
+// the match is negated, or "any" when no address is set.
+func exposureEndpointAddress(ep common.RuleEndpoint) string {
+	address := cmp.Or(ep.Address, constants.NetworkAny)
+	if ep.Negated {
+		return "!" + address
+	}
+
+	return address
+}
+
+// exposureRuleLabel returns the rule reference ref followed by the rule's
+// description, when it has one.
+func exposureRuleLabel(ref, description string) string {
+	if description == "" {
+		return ref
+	}
+
+	return ref + ": " + description
+}
+
+// compareExposurePorts orders ports by the number they start with, e.g. 80
+// for "80-90" or "80,443", and places ports without a leading number after
+// numeric ones, in lexical order.
+func compareExposurePorts(a, b string) int {
+	an, aErr := strconv.Atoi(leadingPort(a))
+	bn, bErr := strconv.Atoi(leadingPort(b))
+
+	switch {
+	case aErr == nil && bErr == nil:
+		return cmp.Or(cmp.Compare(an, bn), cmp.Compare(a, b))
+	case aErr == nil:
+		return -1
+	case bErr == nil:
+		return 1
+	default:
+		return cmp.Compare(a, b)
+	}
+}
+
+// leadingPort returns the number a port specification starts with, e.g. "80"
+// for the range "80-90" or the list "80,443", or "" when it starts with none.
+func leadingPort(port string) string {
+	port = strings.TrimSpace(port)
+	if end := strings.IndexFunc(port, func(r rune) bool { return !unicode.IsDigit(r) }); end >= 0 {
+		return port[:end]
+	}
+
+	return port
+}
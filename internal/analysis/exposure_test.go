@@ -0,0 +1,132 @@
+package analysis_test
+
+import (
+	"testing"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/analysis"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWANPortExposure(t *testing.T) {
+	t.Parallel()
+
+	wanHTTPS := common.FirewallRule{
+		Type:        common.RuleTypePass,
+		Interfaces:  []string{"wan"},
+		Protocol:    "tcp",
+		Destination: common.RuleEndpoint{Address: "192.168.1.10", Port: "443"},
+		Description: "Allow HTTPS",
+	}
+	webForward := common.InboundNATRule{
+		Interfaces:   []string{"wan"},
+		Protocol:     "tcp",
+		ExternalPort: "443",
+		InternalIP:   "192.168.1.10",
+		InternalPort: "8443",
+	}
+
+	tests := []struct {
+		name   string
+		device *common.CommonDevice
+		want   []analysis.PortExposureEntry
+	}{
+		{
+			name:   "nil device",
+			device: nil,
+			want:   nil,
+		},
+		{
+			name: "pass rule and port forward opening the same port are both listed",
+			device: &common.CommonDevice{
+				FirewallRules: []common.FirewallRule{wanHTTPS},
+				NAT:           common.NATConfig{InboundRules: []common.InboundNATRule{webForward}},
+			},
+			want: []analysis.PortExposureEntry{
+				{Protocol: "tcp", Port: "443", Target: "192.168.1.10", SourceRule: "filter.rule[0]: Allow HTTPS"},
+				{Protocol: "tcp", Port: "443", Target: "192.168.1.10:8443", SourceRule: "nat.inbound[0]"},
+			},
+		},
+		{
+			name: "LAN, disabled, block, and outbound rules expose nothing",
+			device: &common.CommonDevice{
+				FirewallRules: []common.FirewallRule{
+					{Type: common.RuleTypePass, Interfaces: []string{"lan"}, Destination: common.RuleEndpoint{Port: "22"}},
+					{Type: common.RuleTypePass, Interfaces: []string{"wan"}, Disabled: true},
+					{Type: common.RuleTypeBlock, Interfaces: []string{"wan"}},
+					{Type: common.RuleTypePass, Interfaces: []string{"wan"}, Direction: common.DirectionOut},
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "port forward without a WAN pass rule is inert",
+			device: &common.CommonDevice{
+				NAT: common.NATConfig{InboundRules: []common.InboundNATRule{webForward}},
+			},
+			want: nil,
+		},
+		{
+			name: "disabled and no-redirect port forwards are skipped",
+			device: &common.CommonDevice{
+				FirewallRules: []common.FirewallRule{wanHTTPS},
+				NAT: common.NATConfig{InboundRules: []common.InboundNATRule{
+					{Interfaces: []string{"wan"}, ExternalPort: "25", Disabled: true},
+					{Interfaces: []string{"wan"}, ExternalPort: "110", NoRDR: true},
+				}},
+			},
+			want: []analysis.PortExposureEntry{
+				{Protocol: "tcp", Port: "443", Target: "192.168.1.10", SourceRule: "filter.rule[0]: Allow HTTPS"},
+			},
+		},
+		{
+			name: "1:1 NAT forwards every port to its internal address",
+			device: &common.CommonDevice{
+				FirewallRules: []common.FirewallRule{wanHTTPS},
+				NAT: common.NATConfig{OneToOneRules: []common.OneToOneNATRule{
+					{Interfaces: []string{"wan"}, External: "203.0.113.10", Internal: "192.168.1.25", Description: "Mail"},
+					{Interfaces: []string{"wan"}, External: "203.0.113.11", Internal: "192.168.1.26", Disabled: true},
+					{Interfaces: []string{"lan"}, External: "203.0.113.12", Internal: "192.168.1.27"},
+				}},
+			},
+			want: []analysis.PortExposureEntry{
+				{Protocol: "tcp", Port: "443", Target: "192.168.1.10", SourceRule: "filter.rule[0]: Allow HTTPS"},
+				{Protocol: "any", Port: "any", Target: "192.168.1.25", SourceRule: "nat.onetoone[0]: Mail"},
+			},
+		},
+		{
+			name: "sorted by port number with unrestricted ports last",
+			device: &common.CommonDevice{
+				FirewallRules: []common.FirewallRule{
+					{Type: common.RuleTypePass, Interfaces: []string{"wan"}},
+					wanHTTPS,
+					{
+						Type:        common.RuleTypePass,
+						Interfaces:  []string{"wan"},
+						Protocol:    "udp",
+						Destination: common.RuleEndpoint{Address: "lan", Port: "1000-2000", Negated: true},
+					},
+					{
+						Type:        common.RuleTypePass,
+						Interfaces:  []string{"wan"},
+						Protocol:    "tcp",
+						Destination: common.RuleEndpoint{Port: "80"},
+					},
+				},
+			},
+			want: []analysis.PortExposureEntry{
+				{Protocol: "tcp", Port: "80", Target: "any", SourceRule: "filter.rule[3]"},
+				{Protocol: "tcp", Port: "443", Target: "192.168.1.10", SourceRule: "filter.rule[1]: Allow HTTPS"},
+				{Protocol: "udp", Port: "1000-2000", Target: "!lan", SourceRule: "filter.rule[2]"},
+				{Protocol: "any", Port: "any", Target: "any", SourceRule: "filter.rule[0]"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, analysis.WANPortExposure(tt.device))
+		})
+	}
+}
@@ -70,4 +70,11 @@ type Finding struct {
 	Tags []string `json:"tags,omitempty"`
 	// Metadata contains arbitrary key-value pairs for additional context.
 	Metadata map[string]string `json:"metadata,omitempty"`
+
+	// Count is the number of identical findings this finding stands for once
+	// repeated findings are merged; zero when the finding was not merged.
+	Count int `json:"count,omitempty"`
+	// Trackers is the comma-separated list of the firewall rule trackers the
+	// merged findings concern.
+	Trackers string `json:"trackers,omitempty"`
 }
@@ -0,0 +1,148 @@
+package analysis
+
+import (
+	"fmt"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/constants"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+)
+
+// floatingEvaluationNote explains where floating rules sit in pf evaluation
+// order. Every floating-rule recommendation ends with it, since each of the
+// checks below is only a problem because of that ordering.
+const floatingEvaluationNote = "Floating rules are evaluated before every interface rule. " +
+	"A floating rule with quick set decides matching traffic at its first match, so interface rules never see it; " +
+	"a floating rule without quick lets evaluation continue, and the last matching rule wins."
+
+// detectFloatingRuleIssues flags floating rules whose placement ahead of
+// every interface rule makes them riskier than the same rule bound to an
+// interface:
+//   - an enabled quick pass with source or destination any, which admits
+//     traffic before any interface policy is consulted (High);
+//   - a rule with no direction, which pf applies to both inbound and
+//     outbound traffic — rarely what the operator intended (Info);
+//   - a block or reject placed after a floating pass that already wins the
+//     same traffic, taken from ResolvePrecedence so overlap and pf quick /
+//     last-match semantics match the shadow detector (Medium).
+func detectFloatingRuleIssues(cfg *common.CommonDevice) []Observation {
+	var observations []Observation
+
+	for i, rule := range cfg.FirewallRules {
+		if !rule.Floating || rule.Disabled {
+			continue
+		}
+
+		component := fmt.Sprintf("filter.rule[%d]", i)
+		reachability := RuleReachability(rule, cfg.Interfaces)
+
+		if rule.Type == common.RuleTypePass && rule.Quick &&
+			(rule.Source.Address == constants.NetworkAny || rule.Destination.Address == constants.NetworkAny) {
+			observations = append(observations, Observation{
+				Severity:     SeverityHigh,
+				Confidence:   ConfidenceHigh,
+				Reachability: reachability,
+				Component:    component,
+				Evidence: fmt.Sprintf(
+					"rule %d: floating quick pass source=%s destination=%s",
+					i+1, rule.Source.Address, rule.Destination.Address,
+				),
+				Title: "Floating Quick Pass Bypasses Interface Policy",
+				Description: fmt.Sprintf(
+					"Floating rule %d is a quick pass rule with an any source or destination; matching traffic is passed before any interface rule can block it.",
+					i+1,
+				),
+				Recommendation: "Restrict the source and destination of the floating pass rule, or move it to the interface it is meant for. " +
+					floatingEvaluationNote,
+			})
+		}
+
+		if rule.Direction == "" {
+			observations = append(observations, Observation{
+				Severity:     SeverityInfo,
+				Confidence:   ConfidenceHigh,
+				Reachability: reachability,
+				Component:    component,
+				Evidence:     fmt.Sprintf("rule %d: floating rule without direction", i+1),
+				Title:        "Floating Rule Without Direction",
+				Description: fmt.Sprintf(
+					"Floating rule %d has no direction, so it matches both inbound and outbound traffic.",
+					i+1,
+				),
+				Recommendation: "Set the direction the floating rule is meant for, or choose any explicitly if both directions are intended. " +
+					floatingEvaluationNote,
+			})
+		}
+	}
+
+	return append(observations, detectFloatingBlockAfterPass(cfg)...)
+}
+
+// detectFloatingBlockAfterPass reports enabled floating block and reject
+// rules that sit below a floating pass rule which wins their traffic.
+// Precedence is resolved over the enabled floating rules alone: interface
+// rules never change which of two floating rules wins, and resolving the
+// whole rule set a second time would double the cost of ScanObservations on
+// large configurations. A pair can appear in several (interface, direction)
+// groups, so each is reported once. The terminal default-deny guard applied
+// by DetectShadowedRules applies here too, judged within the floating rules.
+func detectFloatingBlockAfterPass(cfg *common.CommonDevice) []Observation {
+	floating := *cfg
+	floating.FirewallRules = nil
+
+	var originalIndex []int
+
+	for i, rule := range cfg.FirewallRules {
+		if rule.Floating && !rule.Disabled {
+			floating.FirewallRules = append(floating.FirewallRules, rule)
+			originalIndex = append(originalIndex, i)
+		}
+	}
+
+	type rulePair struct{ winner, loser int }
+
+	seen := make(map[rulePair]bool)
+
+	var observations []Observation
+
+	for _, pair := range ResolvePrecedence(&floating) {
+		winner, loser := pair.Winner, pair.Loser
+		if winner.Rule.Type != common.RuleTypePass ||
+			(loser.Rule.Type != common.RuleTypeBlock && loser.Rule.Type != common.RuleTypeReject) ||
+			loser.Index < winner.Index {
+			continue
+		}
+
+		key := rulePair{winner: winner.Index, loser: loser.Index}
+		if seen[key] || isTerminalDefaultDeny(pair, floating.FirewallRules) {
+			continue
+		}
+		seen[key] = true
+
+		winnerIndex, loserIndex := originalIndex[winner.Index], originalIndex[loser.Index]
+
+		extentWord := "all"
+		if pair.Extent == CoverPartial {
+			extentWord = "part"
+		}
+
+		observations = append(observations, Observation{
+			Severity:     SeverityMedium,
+			Confidence:   ConfidenceHigh,
+			Reachability: RuleReachability(loser.Rule, cfg.Interfaces),
+			Component:    fmt.Sprintf("filter.rule[%d]", loserIndex),
+			Evidence: fmt.Sprintf(
+				"rule %d (floating %s) follows rule %d (floating pass) on %s (%s)",
+				loserIndex+1, loser.Rule.Type, winnerIndex+1, pair.Interface, pair.Direction,
+			),
+			Title: "Floating Block Rule After Matching Pass Rule",
+			Description: fmt.Sprintf(
+				"Floating rule %d (%s) is placed after floating pass rule %d, which already passes %s of the traffic it matches; the block never takes effect for that traffic.",
+				loserIndex+1, loser.Rule.Type, winnerIndex+1, extentWord,
+			),
+			Recommendation: "Move the floating block rule above the pass rule, or narrow the pass rule to exclude this traffic. " +
+				floatingEvaluationNote,
+		})
+	}
+
+	return observations
+}
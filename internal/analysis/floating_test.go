@@ -0,0 +1,171 @@
+package analysis
+
+import (
+	"testing"
+
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// baseFloatingRule returns an unscoped floating rule with every dimension
+// wildcarded. Test cases narrow only the dimensions under test.
+func baseFloatingRule(dir common.FirewallDirection, ruleType common.FirewallRuleType, quick bool) common.FirewallRule {
+	return common.FirewallRule{
+		Type:        ruleType,
+		Floating:    true,
+		Direction:   dir,
+		Quick:       quick,
+		Source:      common.RuleEndpoint{Address: "any"},
+		Destination: common.RuleEndpoint{Address: "any"},
+	}
+}
+
+func floatingDevice(rules ...common.FirewallRule) *common.CommonDevice {
+	return &common.CommonDevice{
+		Interfaces:    []common.Interface{{Name: "wan", Enabled: true}, {Name: "lan", Enabled: true}},
+		FirewallRules: rules,
+	}
+}
+
+func observationsTitled(observations []Observation, title string) []Observation {
+	var matched []Observation
+	for _, o := range observations {
+		if o.Title == title {
+			matched = append(matched, o)
+		}
+	}
+	return matched
+}
+
+func TestDetectFloatingRuleIssues_QuickPassAny_High(t *testing.T) {
+	rule := baseFloatingRule(common.DirectionIn, common.RuleTypePass, true)
+
+	got := observationsTitled(detectFloatingRuleIssues(floatingDevice(rule)), "Floating Quick Pass Bypasses Interface Policy")
+	require.Len(t, got, 1)
+	assert.Equal(t, SeverityHigh, got[0].Severity)
+	assert.Equal(t, "filter.rule[0]", got[0].Component)
+	assert.Contains(t, got[0].Recommendation, "evaluated before every interface rule")
+}
+
+func TestDetectFloatingRuleIssues_QuickPassAny_NotReported(t *testing.T) {
+	nonQuick := baseFloatingRule(common.DirectionIn, common.RuleTypePass, false)
+
+	disabled := baseFloatingRule(common.DirectionIn, common.RuleTypePass, true)
+	disabled.Disabled = true
+
+	specific := baseFloatingRule(common.DirectionIn, common.RuleTypePass, true)
+	specific.Source.Address = "10.0.0.0/8"
+	specific.Destination.Address = "192.168.1.10"
+
+	interfaceRule := baseShadowRule("wan", common.DirectionIn, common.RuleTypePass)
+
+	for name, rule := range map[string]common.FirewallRule{
+		"non-quick":      nonQuick,
+		"disabled":       disabled,
+		"specific":       specific,
+		"interface rule": interfaceRule,
+	} {
+		t.Run(name, func(t *testing.T) {
+			got := observationsTitled(detectFloatingRuleIssues(floatingDevice(rule)), "Floating Quick Pass Bypasses Interface Policy")
+			assert.Empty(t, got)
+		})
+	}
+}
+
+func TestDetectFloatingRuleIssues_NoDirection_Info(t *testing.T) {
+	rule := baseFloatingRule("", common.RuleTypeBlock, false)
+
+	got := observationsTitled(detectFloatingRuleIssues(floatingDevice(rule)), "Floating Rule Without Direction")
+	require.Len(t, got, 1)
+	assert.Equal(t, SeverityInfo, got[0].Severity)
+	assert.Contains(t, got[0].Description, "both inbound and outbound")
+
+	withDirection := baseFloatingRule(common.DirectionAny, common.RuleTypeBlock, false)
+	assert.Empty(t, observationsTitled(
+		detectFloatingRuleIssues(floatingDevice(withDirection)), "Floating Rule Without Direction",
+	))
+}
+
+func TestDetectFloatingRuleIssues_BlockAfterPass_Medium(t *testing.T) {
+	pass := baseFloatingRule(common.DirectionIn, common.RuleTypePass, true)
+	pass.Destination.Port = "22"
+
+	block := baseFloatingRule(common.DirectionIn, common.RuleTypeBlock, true)
+	block.Source.Address = "10.0.0.0/8"
+	block.Destination.Port = "22"
+
+	// An interface rule between the pair must not shift the reported index.
+	between := baseShadowRule("lan", common.DirectionIn, common.RuleTypePass)
+
+	got := observationsTitled(
+		detectFloatingRuleIssues(floatingDevice(pass, between, block)), "Floating Block Rule After Matching Pass Rule",
+	)
+	require.Len(t, got, 1, "the pair must be reported once, not once per interface group")
+	assert.Equal(t, SeverityMedium, got[0].Severity)
+	assert.Equal(t, "filter.rule[2]", got[0].Component)
+	assert.Contains(t, got[0].Description, "after floating pass rule 1")
+	assert.Contains(t, got[0].Description, "passes all of the traffic")
+}
+
+func TestDetectFloatingRuleIssues_BlockAfterPass_NotReported(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules func() []common.FirewallRule
+	}{
+		{
+			name: "block before pass",
+			rules: func() []common.FirewallRule {
+				block := baseFloatingRule(common.DirectionIn, common.RuleTypeBlock, true)
+				block.Destination.Port = "22"
+				pass := baseFloatingRule(common.DirectionIn, common.RuleTypePass, true)
+				pass.Destination.Port = "22"
+				return []common.FirewallRule{block, pass}
+			},
+		},
+		{
+			name: "non-quick pass loses to the later block",
+			rules: func() []common.FirewallRule {
+				pass := baseFloatingRule(common.DirectionIn, common.RuleTypePass, false)
+				pass.Destination.Port = "22"
+				block := baseFloatingRule(common.DirectionIn, common.RuleTypeBlock, false)
+				block.Destination.Port = "22"
+				return []common.FirewallRule{pass, block}
+			},
+		},
+		{
+			name: "terminal default deny",
+			rules: func() []common.FirewallRule {
+				pass := baseFloatingRule(common.DirectionIn, common.RuleTypePass, true)
+				pass.Destination.Port = "443"
+				return []common.FirewallRule{pass, baseFloatingRule(common.DirectionIn, common.RuleTypeBlock, true)}
+			},
+		},
+		{
+			name: "interface block after floating pass",
+			rules: func() []common.FirewallRule {
+				pass := baseFloatingRule(common.DirectionIn, common.RuleTypePass, true)
+				pass.Destination.Port = "22"
+				block := baseShadowRule("wan", common.DirectionIn, common.RuleTypeBlock)
+				block.Destination.Port = "22"
+				return []common.FirewallRule{pass, block}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := observationsTitled(
+				detectFloatingRuleIssues(floatingDevice(tt.rules()...)), "Floating Block Rule After Matching Pass Rule",
+			)
+			assert.Empty(t, got)
+		})
+	}
+}
+
+func TestScanObservations_IncludesFloatingRuleIssues(t *testing.T) {
+	rule := baseFloatingRule(common.DirectionIn, common.RuleTypePass, true)
+
+	got := observationsTitled(ScanObservations(floatingDevice(rule)), "Floating Quick Pass Bypasses Interface Policy")
+	assert.Len(t, got, 1)
+}
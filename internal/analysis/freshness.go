@@ -0,0 +1,140 @@
+package analysis
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/EvilBit-Labs/opnDossier/pkg/schema/shared"
+)
+
+// freshnessDay is the unit of the freshness bucket bounds.
+const freshnessDay = 24 * time.Hour
+
+// Freshness bucket bounds: objects changed less than freshnessRecentAge ago
+// are recent, and objects changed more than freshnessStaleAge ago are stale.
+const (
+	freshnessRecentAge = 90 * freshnessDay
+	freshnessStaleAge  = 365 * freshnessDay
+)
+
+// oldestRulesLimit is the number of oldest enabled firewall rules
+// DetectConfigFreshness reports.
+const oldestRulesLimit = 10
+
+// DetectConfigFreshness counts the firewall rules, NAT rules, and users of
+// cfg by the age of their last change, measured against now, and returns one
+// Info Observation for each of the ten oldest enabled firewall rules, oldest
+// first. An object's last change is the later of its created and updated
+// times; an object with neither counts as unknown. The model records no
+// change times for users, so every user is unknown until a parser supplies
+// them. now is the reference time; callers outside tests pass time.Now().
+// Returns nil observations and zero stats for a nil cfg.
+func DetectConfigFreshness(cfg *common.CommonDevice, now time.Time) ([]Observation, common.ConfigFreshness) {
+	if cfg == nil {
+		return nil, common.ConfigFreshness{}
+	}
+
+	freshness := common.ConfigFreshness{ReferenceTime: now.UTC().Format(time.RFC3339)}
+	if changed, ok := shared.ParseTimestamp(cfg.Revision.Time); ok {
+		freshness.LastChanged = changed.Format(time.RFC3339)
+	}
+
+	type datedRule struct {
+		index   int
+		changed time.Time
+	}
+
+	var dated []datedRule
+
+	for i, rule := range cfg.FirewallRules {
+		changed, ok := lastChange(rule.Created, rule.Updated)
+		countFreshness(&freshness.Rules, changed, ok, now)
+
+		if ok && !rule.Disabled {
+			dated = append(dated, datedRule{index: i, changed: changed})
+		}
+	}
+
+	for _, rule := range cfg.NAT.InboundRules {
+		changed, ok := lastChange(rule.Created, rule.Updated)
+		countFreshness(&freshness.NATRules, changed, ok, now)
+	}
+
+	for _, rule := range cfg.NAT.OutboundRules {
+		changed, ok := lastChange(rule.Created, rule.Updated)
+		countFreshness(&freshness.NATRules, changed, ok, now)
+	}
+
+	freshness.Users.Unknown = len(cfg.Users)
+
+	slices.SortStableFunc(dated, func(a, b datedRule) int {
+		return a.changed.Compare(b.changed)
+	})
+
+	observations := make([]Observation, 0, min(len(dated), oldestRulesLimit))
+	for _, rule := range dated[:min(len(dated), oldestRulesLimit)] {
+		observations = append(observations, oldestRuleObservation(rule.index, rule.changed, now))
+	}
+
+	return observations, freshness
+}
+
+// lastChange returns the later of the created and updated times, reporting
+// false when neither is recognizable.
+func lastChange(created, updated *common.ChangeRecord) (time.Time, bool) {
+	var (
+		latest time.Time
+		found  bool
+	)
+
+	for _, record := range []*common.ChangeRecord{created, updated} {
+		if record == nil {
+			continue
+		}
+
+		if t, ok := shared.ParseTimestamp(record.Time); ok && (!found || t.After(latest)) {
+			latest, found = t, true
+		}
+	}
+
+	return latest, found
+}
+
+// countFreshness adds an object last changed at changed to the matching
+// bucket of buckets, or to Unknown when ok is false. Objects changed after
+// now count as recent.
+func countFreshness(buckets *common.FreshnessBuckets, changed time.Time, ok bool, now time.Time) {
+	if !ok {
+		buckets.Unknown++
+		return
+	}
+
+	switch age := now.Sub(changed); {
+	case age < freshnessRecentAge:
+		buckets.Recent++
+	case age > freshnessStaleAge:
+		buckets.Stale++
+	default:
+		buckets.Aging++
+	}
+}
+
+// oldestRuleObservation reports firewall rule i, one of the oldest enabled
+// rules, last changed at changed.
+func oldestRuleObservation(i int, changed, now time.Time) Observation {
+	return Observation{
+		Severity:     SeverityInfo,
+		Confidence:   ConfidenceHigh,
+		Reachability: Local,
+		Component:    fmt.Sprintf("filter.rule[%d]", i),
+		Evidence:     "lastChanged=" + changed.Format(time.RFC3339),
+		Title:        "Long-Unchanged Firewall Rule",
+		Description: fmt.Sprintf(
+			"Firewall rule %d was last changed on %s, %d days ago, and is among the %d oldest enabled rules.",
+			i+1, changed.Format(time.DateOnly), int(now.Sub(changed)/freshnessDay), oldestRulesLimit,
+		),
+		Recommendation: "Confirm the rule is still needed and that its purpose and owner are documented.",
+	}
+}
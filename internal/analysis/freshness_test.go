@@ -0,0 +1,120 @@
+package analysis_test
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/analysis"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// changedAt returns a change record stamped with the epoch seconds of t, the
+// format OPNsense stores.
+func changedAt(t time.Time) *common.ChangeRecord {
+	return &common.ChangeRecord{Username: "root@10.0.0.1", Time: strconv.FormatInt(t.Unix(), 10)}
+}
+
+func TestDetectConfigFreshness_Buckets(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	rule := func(age time.Duration) common.FirewallRule {
+		return common.FirewallRule{Type: common.RuleTypePass, Created: changedAt(now.Add(-age))}
+	}
+
+	tests := []struct {
+		name string
+		rule common.FirewallRule
+		want common.FreshnessBuckets
+	}{
+		{name: "changed in the future", rule: rule(-day), want: common.FreshnessBuckets{Recent: 1}},
+		{name: "just under 90 days", rule: rule(90*day - time.Second), want: common.FreshnessBuckets{Recent: 1}},
+		{name: "exactly 90 days", rule: rule(90 * day), want: common.FreshnessBuckets{Aging: 1}},
+		{name: "exactly 365 days", rule: rule(365 * day), want: common.FreshnessBuckets{Aging: 1}},
+		{name: "just over 365 days", rule: rule(365*day + time.Second), want: common.FreshnessBuckets{Stale: 1}},
+		{name: "no change record", rule: common.FirewallRule{}, want: common.FreshnessBuckets{Unknown: 1}},
+		{
+			name: "unrecognized time",
+			rule: common.FirewallRule{Created: &common.ChangeRecord{Time: "yesterday"}},
+			want: common.FreshnessBuckets{Unknown: 1},
+		},
+		{
+			name: "update newer than creation",
+			rule: common.FirewallRule{Created: changedAt(now.Add(-400 * day)), Updated: changedAt(now.Add(-10 * day))},
+			want: common.FreshnessBuckets{Recent: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := &common.CommonDevice{FirewallRules: []common.FirewallRule{tt.rule}}
+			_, freshness := analysis.DetectConfigFreshness(cfg, now)
+
+			assert.Equal(t, tt.want, freshness.Rules)
+			assert.Equal(t, "2026-01-01T00:00:00Z", freshness.ReferenceTime)
+		})
+	}
+}
+
+func TestDetectConfigFreshness_NATAndUsers(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	cfg := &common.CommonDevice{
+		Revision: common.Revision{Time: strconv.FormatInt(now.Add(-time.Hour).Unix(), 10)},
+		NAT: common.NATConfig{
+			InboundRules:  []common.InboundNATRule{{Created: changedAt(now.AddDate(-2, 0, 0))}, {}},
+			OutboundRules: []common.NATRule{{Updated: changedAt(now.AddDate(0, -6, 0))}},
+		},
+		Users: []common.User{{Name: "root"}, {Name: "backup"}},
+	}
+
+	observations, freshness := analysis.DetectConfigFreshness(cfg, now)
+
+	assert.Empty(t, observations, "only firewall rules are listed as oldest")
+	assert.Equal(t, "2025-12-31T23:00:00Z", freshness.LastChanged)
+	assert.Equal(t, common.FreshnessBuckets{Aging: 1, Stale: 1, Unknown: 1}, freshness.NATRules)
+	assert.Equal(t, common.FreshnessBuckets{Unknown: 2}, freshness.Users)
+}
+
+func TestDetectConfigFreshness_OldestRules(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	var rules []common.FirewallRule
+	for i := range 14 {
+		rules = append(rules, common.FirewallRule{
+			Type:    common.RuleTypePass,
+			Created: changedAt(now.AddDate(0, 0, -100*(i+1))),
+		})
+	}
+	// The oldest rule is disabled and the next oldest has no change time, so
+	// neither is listed.
+	rules[13].Disabled = true
+	rules[12].Created = nil
+
+	observations, freshness := analysis.DetectConfigFreshness(&common.CommonDevice{FirewallRules: rules}, now)
+
+	assert.Equal(t, common.FreshnessBuckets{Aging: 3, Stale: 10, Unknown: 1}, freshness.Rules)
+	require.Len(t, observations, 10)
+	for i, obs := range observations {
+		assert.Equal(t, "filter.rule["+strconv.Itoa(11-i)+"]", obs.Component, "oldest first")
+		assert.Equal(t, analysis.SeverityInfo, obs.Severity)
+	}
+	assert.Equal(t, "Long-Unchanged Firewall Rule", observations[0].Title)
+	assert.Contains(t, observations[0].Description, "1200 days ago")
+}
+
+func TestDetectConfigFreshness_Nil(t *testing.T) {
+	t.Parallel()
+
+	observations, freshness := analysis.DetectConfigFreshness(nil, time.Now())
+	assert.Nil(t, observations)
+	assert.Equal(t, common.ConfigFreshness{}, freshness)
+}
@@ -0,0 +1,47 @@
+package analysis
+
+import (
+	"net/netip"
+	"strings"
+
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+)
+
+// InHomeNetwork reports whether addr belongs to the device's own networks:
+// loopback, link-local, and private addresses (RFC 1918 and IPv6 unique local),
+// the subnet of an enabled interface that is not WAN-facing, or a network the
+// IDS lists as its home network. An address that is none of these is
+// external, e.g. a collector or server on the internet.
+func InHomeNetwork(device *common.CommonDevice, addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if addr.IsLoopback() || addr.IsLinkLocalUnicast() || addr.IsPrivate() {
+		return true
+	}
+
+	if device == nil {
+		return false
+	}
+
+	for _, iface := range device.Interfaces {
+		if InterfaceReachability(iface) == WANReachable {
+			continue
+		}
+
+		for _, p := range staticInterfacePrefixes([]common.Interface{iface}) {
+			if p.prefix.Masked().Contains(addr) {
+				return true
+			}
+		}
+	}
+
+	if device.IDS != nil {
+		for _, network := range device.IDS.HomeNetworks {
+			p, err := netip.ParsePrefix(strings.TrimSpace(network))
+			if err == nil && p.Masked().Contains(addr) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
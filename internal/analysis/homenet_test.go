@@ -0,0 +1,48 @@
+package analysis
+
+import (
+	"net/netip"
+	"testing"
+
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInHomeNetwork(t *testing.T) {
+	t.Parallel()
+
+	device := &common.CommonDevice{
+		Interfaces: []common.Interface{
+			{Name: "wan", Enabled: true, IPAddress: "198.51.100.2", Subnet: "24"},
+			{Name: "dmz", Enabled: true, IPAddress: "203.0.113.1", Subnet: "28"},
+			{Name: "opt2", Enabled: false, IPAddress: "192.0.2.1", Subnet: "24"},
+		},
+		IDS: &common.IDSConfig{HomeNetworks: []string{"100.64.0.0/10", "not-a-network"}},
+	}
+
+	tests := []struct {
+		name   string
+		device *common.CommonDevice
+		addr   string
+		want   bool
+	}{
+		{"private IPv4", device, "10.0.0.5", true},
+		{"unique local IPv6", device, "fd00::5", true},
+		{"loopback", device, "127.0.0.1", true},
+		{"internal interface subnet", device, "203.0.113.5", true},
+		{"IDS home network", device, "100.64.1.1", true},
+		{"WAN subnet", device, "198.51.100.9", false},
+		{"disabled interface subnet", device, "192.0.2.7", false},
+		{"internet address", device, "8.8.8.8", false},
+		{"nil device private", nil, "192.168.1.1", true},
+		{"nil device public", nil, "203.0.113.5", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, InHomeNetwork(tt.device, netip.MustParseAddr(tt.addr)))
+		})
+	}
+}
@@ -0,0 +1,303 @@
+package analysis
+
+import (
+	"cmp"
+	"fmt"
+	"net"
+	"net/netip"
+	"slices"
+	"strings"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/constants"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+)
+
+// HostExposurePathKind identifies the kind of rule a HostExposurePath comes from.
+type HostExposurePathKind string
+
+const (
+	// HostExposurePortForward is an inbound NAT port forward to the host.
+	HostExposurePortForward HostExposurePathKind = "port-forward"
+	// HostExposurePassRule is a firewall pass rule whose destination contains the host.
+	HostExposurePassRule HostExposurePathKind = "pass-rule"
+	// HostExposureOneToOneNAT is a 1:1 (binat) NAT mapping whose internal side contains the host.
+	HostExposureOneToOneNAT HostExposurePathKind = "one-to-one-nat"
+)
+
+// interfaceAddressSuffix marks a rule address macro naming an interface's own
+// address, e.g. "lanip", rather than its network.
+const interfaceAddressSuffix = "ip"
+
+// HostExposurePath is one rule through which traffic can reach a queried host
+// or subnet.
+type HostExposurePath struct {
+	// Kind is the kind of rule that opens the path.
+	Kind HostExposurePathKind `json:"kind"`
+	// Rule identifies the rule, e.g. "nat.inbound[0]: Web server".
+	Rule string `json:"rule"`
+	// Interfaces are the interfaces the rule applies to; empty for a
+	// floating rule that applies to all of them.
+	Interfaces []string `json:"interfaces,omitempty"`
+	// Reachability is where the path can be used from.
+	Reachability Reachability `json:"reachability"`
+	// Protocol is the layer-4 protocol, or "any".
+	Protocol string `json:"protocol"`
+	// Port is the port traffic is sent to: a port forward's external port,
+	// or a pass rule's destination port. "any" when unrestricted, as it is
+	// for a 1:1 NAT mapping.
+	Port string `json:"port"`
+	// Target is where the traffic is delivered: a port forward's internal IP
+	// and port, a 1:1 NAT rule's internal address, or a pass rule's
+	// destination address.
+	Target string `json:"target"`
+	// Match explains how the rule's target was matched to the query, e.g.
+	// "alias WebServers (192.168.1.50)" or "interface network lan
+	// (192.168.1.0/24)".
+	Match string `json:"match"`
+}
+
+// ParseHostExposureQuery parses an IP address or CIDR network for
+// HostExposure. A network is masked to its base address.
+func ParseHostExposureQuery(s string) (netip.Prefix, error) {
+	p, err := parsePrefixOrAddr(strings.TrimSpace(s))
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("%q is not an IP address or CIDR network", s)
+	}
+
+	return p.Masked(), nil
+}
+
+// HostExposure lists the paths through which traffic can reach query, an IP
+// address or a whole subnet:
+//
+//   - enabled inbound NAT port forwards whose internal IP, or an alias
+//     resolving to it, lies in query;
+//   - enabled 1:1 NAT mappings whose internal address or subnet overlaps
+//     query;
+//   - enabled inbound pass rules whose destination overlaps query, through a
+//     literal address or CIDR network, an alias member, an interface network
+//     macro such as "lan", or an interface address macro such as "lanip".
+//
+// A negated destination matches when its excluded addresses do not cover the
+// whole query. Pass rules with an unrestricted or negated destination match
+// nearly every host; they are listed only when WAN-reachable (see
+// RuleReachability), since on an internal interface they are ordinary egress
+// rules. Forwards with redirection disabled and outbound rules are skipped.
+// Each path carries the reachability of its rule, so LAN-only paths can be
+// told apart from WAN exposure. Paths are sorted like WANPortExposure.
+func HostExposure(device *common.CommonDevice, query netip.Prefix) []HostExposurePath {
+	if device == nil || !query.IsValid() {
+		return nil
+	}
+
+	query = query.Masked()
+	prefixes := staticInterfacePrefixes(device.Interfaces)
+
+	var paths []HostExposurePath
+
+	for i, nat := range device.NAT.InboundRules {
+		if nat.Disabled || nat.NoRDR {
+			continue
+		}
+
+		match, ok := matchAddressValue(nat.InternalIP, device.NamedObjects.Ref(nat.InternalIP), device, prefixes, query)
+		if !ok {
+			continue
+		}
+
+		target := nat.InternalIP
+		if nat.InternalPort != "" {
+			target = net.JoinHostPort(nat.InternalIP, nat.InternalPort)
+		}
+
+		paths = append(paths, HostExposurePath{
+			Kind:         HostExposurePortForward,
+			Rule:         exposureRuleLabel(fmt.Sprintf("nat.inbound[%d]", i), nat.Description),
+			Interfaces:   nat.Interfaces,
+			Reachability: InboundNATRuleReachability(nat, device.Interfaces, device.FirewallRules),
+			Protocol:     cmp.Or(nat.Protocol, constants.NetworkAny),
+			Port:         cmp.Or(nat.ExternalPort, nat.Destination.Port, constants.NetworkAny),
+			Target:       target,
+			Match:        match,
+		})
+	}
+
+	for i, nat := range device.NAT.OneToOneRules {
+		if nat.Disabled {
+			continue
+		}
+
+		match, ok := matchAddressValue(nat.Internal, device.NamedObjects.Ref(nat.Internal), device, prefixes, query)
+		if !ok {
+			continue
+		}
+
+		paths = append(paths, HostExposurePath{
+			Kind:         HostExposureOneToOneNAT,
+			Rule:         exposureRuleLabel(fmt.Sprintf("nat.onetoone[%d]", i), nat.Description),
+			Interfaces:   nat.Interfaces,
+			Reachability: OneToOneNATRuleReachability(nat, device.Interfaces, device.FirewallRules),
+			Protocol:     constants.NetworkAny,
+			Port:         cmp.Or(nat.Destination.Port, constants.NetworkAny),
+			Target:       nat.Internal,
+			Match:        match,
+		})
+	}
+
+	for i, rule := range device.FirewallRules {
+		if rule.Disabled || rule.Type != common.RuleTypePass || rule.Direction == common.DirectionOut {
+			continue
+		}
+
+		reachability := RuleReachability(rule, device.Interfaces)
+
+		match, ok := matchDestination(rule.Destination, device, prefixes, query)
+		broad := match == constants.NetworkAny || rule.Destination.Negated
+		if !ok || (broad && reachability != WANReachable) {
+			continue
+		}
+
+		paths = append(paths, HostExposurePath{
+			Kind:         HostExposurePassRule,
+			Rule:         exposureRuleLabel(fmt.Sprintf("filter.rule[%d]", i), rule.Description),
+			Interfaces:   rule.Interfaces,
+			Reachability: reachability,
+			Protocol:     cmp.Or(rule.Protocol, constants.NetworkAny),
+			Port:         cmp.Or(rule.Destination.Port, constants.NetworkAny),
+			Target:       exposureEndpointAddress(rule.Destination),
+			Match:        match,
+		})
+	}
+
+	slices.SortStableFunc(paths, func(a, b HostExposurePath) int {
+		return cmp.Or(
+			compareExposurePorts(a.Port, b.Port),
+			cmp.Compare(a.Protocol, b.Protocol),
+		)
+	})
+
+	return paths
+}
+
+// matchDestination reports whether a pass rule destination can match an
+// address in query, and how. A negated destination matches unless every
+// excluded value parses and one of them covers the whole query.
+func matchDestination(
+	ep common.RuleEndpoint,
+	device *common.CommonDevice,
+	prefixes []interfacePrefix,
+	query netip.Prefix,
+) (string, bool) {
+	if isAnyAddressSet([]string{ep.Address}) {
+		return constants.NetworkAny, true
+	}
+
+	if !ep.Negated {
+		return matchAddressValue(ep.Address, ep.AddressRef, device, prefixes, query)
+	}
+
+	excluded, ok := addressValuePrefixes(ep.Address, ep.AddressRef, device, prefixes)
+	if !ok {
+		return "", false
+	}
+
+	for _, p := range excluded {
+		if p.Bits() <= query.Bits() && p.Contains(query.Addr()) {
+			return "", false
+		}
+	}
+
+	return "not " + ep.Address, true
+}
+
+// matchAddressValue reports whether value, a rule address or NAT target,
+// overlaps query, and describes the match.
+func matchAddressValue(
+	value string,
+	ref *common.ObjectRef,
+	device *common.CommonDevice,
+	prefixes []interfacePrefix,
+	query netip.Prefix,
+) (string, bool) {
+	candidates, ok := addressValuePrefixes(value, ref, device, prefixes)
+	if !ok {
+		return "", false
+	}
+
+	for _, p := range candidates {
+		if !p.Overlaps(query) {
+			continue
+		}
+
+		member := p.String()
+		if p.IsSingleIP() {
+			member = p.Addr().String()
+		}
+
+		switch {
+		case ref != nil:
+			return fmt.Sprintf("alias %s (%s)", ref.Name, member), true
+		case FindInterface(device.Interfaces, value) != nil:
+			return fmt.Sprintf("interface network %s (%s)", value, member), true
+		case isInterfaceAddressMacro(device, value):
+			return fmt.Sprintf("interface address %s (%s)", value, member), true
+		case p.IsSingleIP():
+			return "address " + member, true
+		default:
+			return "network " + member, true
+		}
+	}
+
+	return "", false
+}
+
+// addressValuePrefixes expands value into the prefixes it stands for: the
+// members of the alias ref, the subnets of an interface network macro ("lan"),
+// the address of an interface address macro ("lanip"), or the literal address
+// or network itself. The second return is false when nothing parseable
+// remains, e.g. for an unresolvable alias or a hostname.
+func addressValuePrefixes(
+	value string,
+	ref *common.ObjectRef,
+	device *common.CommonDevice,
+	prefixes []interfacePrefix,
+) ([]netip.Prefix, bool) {
+	var out []netip.Prefix
+
+	switch {
+	case ref != nil:
+		members, _ := resolveAddressValues(common.RuleEndpoint{Address: value, AddressRef: ref}, device.NamedObjects)
+		for _, member := range members {
+			if p, err := parsePrefixOrAddr(member); err == nil {
+				out = append(out, p.Masked())
+			}
+		}
+	case FindInterface(device.Interfaces, value) != nil:
+		for _, p := range prefixes {
+			if p.name == value {
+				out = append(out, p.prefix.Masked())
+			}
+		}
+	case isInterfaceAddressMacro(device, value):
+		name := strings.TrimSuffix(value, interfaceAddressSuffix)
+		for _, p := range prefixes {
+			if p.name == name {
+				out = append(out, netip.PrefixFrom(p.prefix.Addr(), p.prefix.Addr().BitLen()))
+			}
+		}
+	default:
+		if p, err := parsePrefixOrAddr(value); err == nil {
+			out = append(out, p.Masked())
+		}
+	}
+
+	return out, len(out) > 0
+}
+
+// isInterfaceAddressMacro reports whether value names an interface's own
+// address, such as "lanip" for interface lan.
+func isInterfaceAddressMacro(device *common.CommonDevice, value string) bool {
+	name, ok := strings.CutSuffix(value, interfaceAddressSuffix)
+
+	return ok && FindInterface(device.Interfaces, name) != nil
+}
@@ -0,0 +1,184 @@
+package analysis_test
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/analysis"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseHostExposureQuery(t *testing.T) {
+	t.Parallel()
+
+	got, err := analysis.ParseHostExposureQuery(" 192.168.1.50 ")
+	require.NoError(t, err)
+	assert.Equal(t, netip.MustParsePrefix("192.168.1.50/32"), got)
+
+	got, err = analysis.ParseHostExposureQuery("192.168.1.77/24")
+	require.NoError(t, err)
+	assert.Equal(t, netip.MustParsePrefix("192.168.1.0/24"), got)
+
+	_, err = analysis.ParseHostExposureQuery("webserver")
+	require.ErrorContains(t, err, "not an IP address or CIDR network")
+}
+
+func TestHostExposure(t *testing.T) {
+	t.Parallel()
+
+	pass := func(iface, address string) common.FirewallRule {
+		return common.FirewallRule{
+			Type:        common.RuleTypePass,
+			Interfaces:  []string{iface},
+			Protocol:    "tcp",
+			Destination: common.RuleEndpoint{Address: address, Port: "443"},
+		}
+	}
+	aliased := pass("wan", "WebServers")
+	aliased.Destination.AddressRef = &common.ObjectRef{Name: "WebServers"}
+	negated := pass("wan", "192.168.1.128/25")
+	negated.Destination.Negated = true
+
+	device := &common.CommonDevice{
+		Interfaces: []common.Interface{
+			{Name: "wan", Enabled: true, IPAddress: "203.0.113.2", Subnet: "24"},
+			{Name: "lan", Enabled: true, IPAddress: "192.168.1.1", Subnet: "24"},
+			{Name: "opt1", Enabled: true, IPAddress: "10.0.0.1", Subnet: "24"},
+		},
+		NamedObjects: common.NamedObjects{
+			"WebServers": {Name: "WebServers", Type: common.NamedObjectTypeHost, Members: []string{"192.168.1.50", "192.168.1.51"}},
+		},
+		NAT: common.NATConfig{InboundRules: []common.InboundNATRule{
+			{Interfaces: []string{"wan"}, Protocol: "tcp", ExternalPort: "8443", InternalIP: "192.168.1.50", InternalPort: "443", Description: "Web"},
+			{Interfaces: []string{"wan"}, Protocol: "tcp", ExternalPort: "22", InternalIP: "192.168.1.50", Disabled: true},
+			{Interfaces: []string{"wan"}, Protocol: "tcp", ExternalPort: "25", InternalIP: "192.168.1.60"},
+		}},
+		FirewallRules: []common.FirewallRule{
+			pass("wan", "192.168.1.50"),
+			aliased,
+			pass("lan", "lan"),
+			pass("opt1", "lanip"),
+			negated,
+			pass("lan", "any"),
+			pass("wan", ""),
+			{Type: common.RuleTypeBlock, Interfaces: []string{"wan"}, Destination: common.RuleEndpoint{Address: "192.168.1.50"}},
+			{Type: common.RuleTypePass, Interfaces: []string{"wan"}, Direction: common.DirectionOut, Destination: common.RuleEndpoint{Address: "192.168.1.50"}},
+			pass("wan", "192.168.2.0/24"),
+		},
+	}
+
+	t.Run("single host", func(t *testing.T) {
+		t.Parallel()
+
+		paths := analysis.HostExposure(device, netip.MustParsePrefix("192.168.1.50/32"))
+
+		matches := make(map[string]string, len(paths))
+		for _, p := range paths {
+			matches[p.Rule] = p.Match
+		}
+		assert.Equal(t, map[string]string{
+			"filter.rule[0]":      "address 192.168.1.50",
+			"filter.rule[1]":      "alias WebServers (192.168.1.50)",
+			"filter.rule[2]":      "interface network lan (192.168.1.0/24)",
+			"filter.rule[4]":      "not 192.168.1.128/25",
+			"filter.rule[6]":      "any",
+			"nat.inbound[0]: Web": "address 192.168.1.50",
+		}, matches)
+
+		// Sorted by port: the forward's external port 8443 follows the pass
+		// rules on 443.
+		require.Len(t, paths, 6)
+		last := paths[len(paths)-1]
+		assert.Equal(t, analysis.HostExposurePortForward, last.Kind)
+		assert.Equal(t, "8443", last.Port)
+		assert.Equal(t, "192.168.1.50:443", last.Target)
+		assert.Equal(t, analysis.WANReachable, last.Reachability)
+		assert.Equal(t, analysis.LANOnly, paths[2].Reachability)
+	})
+
+	t.Run("interface address macro and negated exclusion", func(t *testing.T) {
+		t.Parallel()
+
+		rules := func(query string) []string {
+			var out []string
+			for _, p := range analysis.HostExposure(device, netip.MustParsePrefix(query)) {
+				out = append(out, p.Rule)
+			}
+			return out
+		}
+
+		assert.Contains(t, rules("192.168.1.1/32"), "filter.rule[3]")
+		assert.NotContains(t, rules("192.168.1.200/32"), "filter.rule[4]", "excluded by the negated network")
+		assert.Contains(t, rules("192.168.1.0/24"), "filter.rule[4]", "query only partly excluded")
+	})
+
+	t.Run("subnet", func(t *testing.T) {
+		t.Parallel()
+
+		paths := analysis.HostExposure(device, netip.MustParsePrefix("192.168.1.0/24"))
+
+		var forwards int
+		for _, p := range paths {
+			if p.Kind == analysis.HostExposurePortForward {
+				forwards++
+			}
+		}
+		assert.Equal(t, 2, forwards)
+	})
+
+	t.Run("unreferenced host", func(t *testing.T) {
+		t.Parallel()
+
+		paths := analysis.HostExposure(device, netip.MustParsePrefix("172.16.0.9/32"))
+		require.Len(t, paths, 2, "only the WAN rules with an unrestricted or negated destination")
+		assert.Equal(t, "not 192.168.1.128/25", paths[0].Match)
+		assert.Equal(t, "any", paths[1].Match)
+	})
+
+	assert.Nil(t, analysis.HostExposure(nil, netip.MustParsePrefix("192.168.1.50/32")))
+}
+
+func TestHostExposure_OneToOneNAT(t *testing.T) {
+	t.Parallel()
+
+	device := &common.CommonDevice{
+		Interfaces: []common.Interface{
+			{Name: "wan", Enabled: true, IPAddress: "203.0.113.2", Subnet: "24"},
+			{Name: "opt1", Enabled: true, IPAddress: "10.20.0.1", Subnet: "24"},
+		},
+		NAT: common.NATConfig{OneToOneRules: []common.OneToOneNATRule{
+			{Interfaces: []string{"wan"}, External: "203.0.113.10", Internal: "192.168.1.25", Description: "Mail"},
+			{Interfaces: []string{"wan"}, External: "203.0.113.64", Internal: "10.20.0.0/27"},
+			{Interfaces: []string{"wan"}, External: "203.0.113.99", Internal: "10.20.0.5", Disabled: true},
+		}},
+		FirewallRules: []common.FirewallRule{
+			{
+				Type:        common.RuleTypePass,
+				Interfaces:  []string{"wan"},
+				Protocol:    "tcp",
+				Destination: common.RuleEndpoint{Address: "192.168.1.25", Port: "25"},
+			},
+		},
+	}
+
+	paths := analysis.HostExposure(device, netip.MustParsePrefix("10.20.0.5/32"))
+	require.Len(t, paths, 1, "the disabled mapping is skipped")
+	assert.Equal(t, analysis.HostExposurePath{
+		Kind:         analysis.HostExposureOneToOneNAT,
+		Rule:         "nat.onetoone[1]",
+		Interfaces:   []string{"wan"},
+		Reachability: analysis.WANReachable,
+		Protocol:     "any",
+		Port:         "any",
+		Target:       "10.20.0.0/27",
+		Match:        "network 10.20.0.0/27",
+	}, paths[0])
+
+	paths = analysis.HostExposure(device, netip.MustParsePrefix("192.168.1.25/32"))
+	require.Len(t, paths, 2)
+	assert.Equal(t, "filter.rule[0]", paths[0].Rule, "the pass rule on port 25 sorts before the unrestricted mapping")
+	assert.Equal(t, "nat.onetoone[0]: Mail", paths[1].Rule)
+	assert.Equal(t, "address 192.168.1.25", paths[1].Match)
+}
@@ -0,0 +1,190 @@
+package analysis
+
+import (
+	"fmt"
+	"slices"
+
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+)
+
+// DefaultLogCoverageThreshold is the percentage of pass rules on an
+// internet-facing interface that DetectLoggingCoverage expects to log when
+// the caller leaves the threshold unset.
+const DefaultLogCoverageThreshold = 50
+
+// DetectLoggingCoverage counts, per interface and across the rule set, the
+// enabled pass and block/reject rules and how many of them log their
+// matches, and returns one Observation per logging gap:
+//
+//   - a WAN interface on which fewer than threshold percent of the pass
+//     rules log is Info;
+//   - a block or reject rule on a WAN interface that does not log is Low,
+//     since dropped probes are invisible to the monitoring team.
+//
+// Disabled rules are ignored. A floating rule bound to several interfaces
+// counts towards each of them; an unscoped floating rule counts towards the
+// total only. A non-positive threshold selects DefaultLogCoverageThreshold.
+// Returns nil observations and zero stats for a nil cfg.
+func DetectLoggingCoverage(cfg *common.CommonDevice, threshold float64) ([]Observation, common.LoggingCoverage) {
+	if cfg == nil {
+		return nil, common.LoggingCoverage{}
+	}
+
+	if threshold <= 0 {
+		threshold = DefaultLogCoverageThreshold
+	}
+
+	var (
+		observations []Observation
+		total        common.LoggingCoverageEntry
+		order        []string
+	)
+
+	for _, iface := range cfg.Interfaces {
+		order = append(order, iface.Name)
+	}
+
+	perInterface := make(map[string]*common.LoggingCoverageEntry)
+
+	for i, rule := range cfg.FirewallRules {
+		if rule.Disabled || !isPassOrBlock(rule.Type) {
+			continue
+		}
+
+		countLoggedRule(&total, rule)
+
+		for _, name := range rule.Interfaces {
+			entry, ok := perInterface[name]
+			if !ok {
+				entry = &common.LoggingCoverageEntry{Interface: name}
+				perInterface[name] = entry
+				order = appendMissing(order, name)
+			}
+
+			countLoggedRule(entry, rule)
+		}
+
+		if rule.Type != common.RuleTypePass && !rule.Log {
+			if wan := firstWANInterface(rule.Interfaces); wan != "" {
+				observations = append(observations, unloggedWANBlockObservation(i, rule.Type, wan))
+			}
+		}
+	}
+
+	finishLoggingCoverage(&total)
+
+	coverage := common.LoggingCoverage{Total: total}
+
+	for _, name := range order {
+		entry, ok := perInterface[name]
+		if !ok {
+			continue
+		}
+
+		finishLoggingCoverage(entry)
+		coverage.Interfaces = append(coverage.Interfaces, *entry)
+
+		if !IsWANInterfaceName(name) || entry.PassRules == 0 || entry.PassLoggedPercent >= threshold {
+			continue
+		}
+
+		observations = append(observations, passLogCoverageObservation(*entry, threshold))
+	}
+
+	return observations, coverage
+}
+
+// unloggedWANBlockObservation reports firewall rule i, a block or reject
+// rule on WAN interface wan, that does not log its matches.
+func unloggedWANBlockObservation(i int, ruleType common.FirewallRuleType, wan string) Observation {
+	return Observation{
+		Severity:     SeverityLow,
+		Confidence:   ConfidenceHigh,
+		Reachability: WANReachable,
+		Component:    fmt.Sprintf("filter.rule[%d]", i),
+		Evidence:     "log=false",
+		Title:        "WAN Block Rule Without Logging",
+		Description: fmt.Sprintf(
+			"Firewall rule %d %ss traffic on %s without logging, so dropped connection attempts are not recorded.",
+			i+1, ruleType, wan,
+		),
+		Recommendation: "Enable logging on WAN block rules so scans and intrusion attempts reach the log pipeline.",
+	}
+}
+
+// passLogCoverageObservation reports a WAN interface whose pass rule logging
+// coverage is below threshold percent.
+func passLogCoverageObservation(entry common.LoggingCoverageEntry, threshold float64) Observation {
+	return Observation{
+		Severity:     SeverityInfo,
+		Confidence:   ConfidenceHigh,
+		Reachability: WANReachable,
+		Component:    "interfaces." + entry.Interface,
+		Evidence:     fmt.Sprintf("loggedPassRules=%d passRules=%d", entry.LoggedPassRules, entry.PassRules),
+		Title:        "Low Pass Rule Logging Coverage",
+		Description: fmt.Sprintf(
+			"Only %d of %d enabled pass rules on internet-facing interface %s log their matches (%.1f%%, below %.0f%%).",
+			entry.LoggedPassRules, entry.PassRules, entry.Interface, entry.PassLoggedPercent, threshold,
+		),
+		Recommendation: "Enable logging on pass rules for internet-facing services so allowed traffic can be investigated.",
+	}
+}
+
+// isPassOrBlock reports whether t is a pass, block, or reject rule type.
+func isPassOrBlock(t common.FirewallRuleType) bool {
+	return t == common.RuleTypePass || t == common.RuleTypeBlock || t == common.RuleTypeReject
+}
+
+// countLoggedRule adds an enabled pass, block, or reject rule to entry.
+func countLoggedRule(entry *common.LoggingCoverageEntry, rule common.FirewallRule) {
+	if rule.Type == common.RuleTypePass {
+		entry.PassRules++
+		if rule.Log {
+			entry.LoggedPassRules++
+		}
+
+		return
+	}
+
+	entry.BlockRules++
+	if rule.Log {
+		entry.LoggedBlockRules++
+	}
+}
+
+// finishLoggingCoverage fills in the percentages of entry, using 100 when
+// there are no rules of a kind.
+func finishLoggingCoverage(entry *common.LoggingCoverageEntry) {
+	entry.PassLoggedPercent = loggedPercent(entry.LoggedPassRules, entry.PassRules)
+	entry.BlockLoggedPercent = loggedPercent(entry.LoggedBlockRules, entry.BlockRules)
+}
+
+// loggedPercent returns logged as a percentage of total, or 100 when total
+// is zero.
+func loggedPercent(logged, total int) float64 {
+	if total == 0 {
+		return percentScale
+	}
+
+	return float64(logged) * percentScale / float64(total)
+}
+
+// firstWANInterface returns the first WAN-style name in names, or "".
+func firstWANInterface(names []string) string {
+	for _, name := range names {
+		if IsWANInterfaceName(name) {
+			return name
+		}
+	}
+
+	return ""
+}
+
+// appendMissing appends name to names unless it is already present.
+func appendMissing(names []string, name string) []string {
+	if slices.Contains(names, name) {
+		return names
+	}
+
+	return append(names, name)
+}
@@ -0,0 +1,157 @@
+package analysis_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/analysis"
+	"github.com/EvilBit-Labs/opnDossier/internal/cfgparser"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/EvilBit-Labs/opnDossier/pkg/parser"
+	_ "github.com/EvilBit-Labs/opnDossier/pkg/parser/opnsense" // self-registers OPNsense parser via init()
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDetectLoggingCoverage_Fixture parses testdata/logging_coverage_test.xml,
+// where one of four enabled WAN pass rules logs, and checks the per-interface
+// percentages and the resulting findings. The disabled WAN pass rule must not
+// be counted.
+func TestDetectLoggingCoverage_Fixture(t *testing.T) {
+	t.Parallel()
+
+	f, err := os.Open(filepath.Join("..", "..", "testdata", "logging_coverage_test.xml"))
+	require.NoError(t, err)
+	defer f.Close()
+
+	factory := parser.NewFactory(cfgparser.NewXMLParser())
+	device, _, err := factory.CreateDevice(context.Background(), f, common.DeviceTypeUnknown, false)
+	require.NoError(t, err)
+
+	observations, coverage := analysis.DetectLoggingCoverage(device, 0)
+
+	require.Len(t, coverage.Interfaces, 2)
+
+	byName := make(map[string]common.LoggingCoverageEntry, len(coverage.Interfaces))
+	for _, entry := range coverage.Interfaces {
+		byName[entry.Interface] = entry
+	}
+
+	wan := byName["wan"]
+	assert.Equal(t, 4, wan.PassRules)
+	assert.Equal(t, 1, wan.LoggedPassRules)
+	assert.InDelta(t, 25.0, wan.PassLoggedPercent, 0.001)
+	assert.Equal(t, 2, wan.BlockRules)
+	assert.Equal(t, 1, wan.LoggedBlockRules)
+	assert.InDelta(t, 50.0, wan.BlockLoggedPercent, 0.001)
+
+	lan := byName["lan"]
+	assert.Equal(t, 1, lan.PassRules)
+	assert.InDelta(t, 100.0, lan.PassLoggedPercent, 0.001)
+	assert.InDelta(t, 100.0, lan.BlockLoggedPercent, 0.001, "no block rules counts as fully logged")
+
+	assert.Equal(t, 5, coverage.Total.PassRules)
+	assert.Equal(t, 2, coverage.Total.LoggedPassRules)
+	assert.InDelta(t, 40.0, coverage.Total.PassLoggedPercent, 0.001)
+
+	assert.Equal(t, []string{
+		"filter.rule[5]: WAN Block Rule Without Logging",
+		"interfaces.wan: Low Pass Rule Logging Coverage",
+	}, descriptionFindings(observations))
+	assert.Equal(t, analysis.SeverityLow, observations[0].Severity)
+	assert.Equal(t, analysis.SeverityInfo, observations[1].Severity)
+	assert.Contains(t, observations[1].Description, "Only 1 of 4 enabled pass rules")
+	assert.Contains(t, observations[1].Description, "25.0%")
+}
+
+func TestDetectLoggingCoverage(t *testing.T) {
+	t.Parallel()
+
+	rule := func(ruleType common.FirewallRuleType, log bool, ifaces ...string) common.FirewallRule {
+		return common.FirewallRule{Type: ruleType, Log: log, Interfaces: ifaces}
+	}
+
+	tests := []struct {
+		name      string
+		cfg       *common.CommonDevice
+		threshold float64
+		wantTotal common.LoggingCoverageEntry
+		wantIface []string
+		want      []string
+	}{
+		{
+			name: "nil device",
+		},
+		{
+			name: "threshold below the coverage suppresses the finding",
+			cfg: &common.CommonDevice{FirewallRules: []common.FirewallRule{
+				rule(common.RuleTypePass, true, "wan"),
+				rule(common.RuleTypePass, false, "wan"),
+				rule(common.RuleTypePass, false, "wan"),
+			}},
+			threshold: 30,
+			wantTotal: common.LoggingCoverageEntry{
+				PassRules: 3, LoggedPassRules: 1, PassLoggedPercent: 100.0 / 3, BlockLoggedPercent: 100,
+			},
+			wantIface: []string{"wan"},
+		},
+		{
+			name: "unscoped floating rules count towards the total only",
+			cfg: &common.CommonDevice{FirewallRules: []common.FirewallRule{
+				{Type: common.RuleTypeBlock, Floating: true},
+				rule(common.RuleTypePass, true, "lan", "opt1"),
+				rule(common.FirewallRuleType("match"), false, "wan"),
+			}},
+			wantTotal: common.LoggingCoverageEntry{
+				PassRules: 1, LoggedPassRules: 1, PassLoggedPercent: 100, BlockRules: 1, BlockLoggedPercent: 0,
+			},
+			wantIface: []string{"lan", "opt1"},
+		},
+		{
+			name: "unlogged block rules are reported on WAN only",
+			cfg: &common.CommonDevice{FirewallRules: []common.FirewallRule{
+				rule(common.RuleTypeBlock, false, "lan"),
+				rule(common.RuleTypeReject, false, "lan", "wan2"),
+				rule(common.RuleTypeBlock, true, "wan"),
+			}},
+			wantTotal: common.LoggingCoverageEntry{
+				PassLoggedPercent: 100, BlockRules: 3, LoggedBlockRules: 1, BlockLoggedPercent: 100.0 / 3,
+			},
+			wantIface: []string{"lan", "wan2", "wan"},
+			want:      []string{"filter.rule[1]: WAN Block Rule Without Logging"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			observations, coverage := analysis.DetectLoggingCoverage(tt.cfg, tt.threshold)
+
+			assert.Equal(t, tt.want, nilIfEmpty(descriptionFindings(observations)))
+			assert.InDelta(t, tt.wantTotal.PassLoggedPercent, coverage.Total.PassLoggedPercent, 0.001)
+			assert.InDelta(t, tt.wantTotal.BlockLoggedPercent, coverage.Total.BlockLoggedPercent, 0.001)
+
+			coverage.Total.PassLoggedPercent, tt.wantTotal.PassLoggedPercent = 0, 0
+			coverage.Total.BlockLoggedPercent, tt.wantTotal.BlockLoggedPercent = 0, 0
+			assert.Equal(t, tt.wantTotal, coverage.Total)
+
+			var ifaces []string
+			for _, entry := range coverage.Interfaces {
+				ifaces = append(ifaces, entry.Interface)
+			}
+			assert.Equal(t, tt.wantIface, ifaces)
+		})
+	}
+}
+
+// nilIfEmpty returns nil for an empty slice so table expectations can omit it.
+func nilIfEmpty(s []string) []string {
+	if len(s) == 0 {
+		return nil
+	}
+
+	return s
+}
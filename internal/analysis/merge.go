@@ -0,0 +1,169 @@
+package analysis
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// MergeFindings collapses findings that describe the same problem from
+// different checks. Findings sharing a Type (category) and a non-empty
+// Component (the affected config element, e.g. "filter.rule[3]") are merged
+// into a single finding that:
+//
+//   - takes its Title, Severity, Reference, and Metadata from the most severe
+//     member (the first one on ties), so the merged severity is the maximum;
+//   - lists every contributing check's title in its Description;
+//   - carries the sorted, de-duplicated check IDs of all members in
+//     References, so compliance and attestation exports keep every ID;
+//   - joins the members' distinct recommendations and unions their Tags.
+//
+// Findings with an empty Component are never merged. The output preserves the
+// order in which each group first appears in the input, and the input slice
+// is not modified, so the result is deterministic for a deterministic input.
+func MergeFindings(findings []Finding) []Finding {
+	type group struct {
+		members []Finding
+	}
+
+	groups := make(map[string]*group, len(findings))
+	order := make([]*group, 0, len(findings))
+
+	for _, f := range findings {
+		if f.Component == "" {
+			order = append(order, &group{members: []Finding{f}})
+			continue
+		}
+
+		key := f.Type + "\x00" + f.Component
+		if g, ok := groups[key]; ok {
+			g.members = append(g.members, f)
+			continue
+		}
+
+		g := &group{members: []Finding{f}}
+		groups[key] = g
+		order = append(order, g)
+	}
+
+	merged := make([]Finding, 0, len(order))
+	for _, g := range order {
+		if len(g.members) == 1 {
+			merged = append(merged, g.members[0])
+			continue
+		}
+
+		merged = append(merged, mergeGroup(g.members))
+	}
+
+	return merged
+}
+
+// FindingCheckID returns the identifier of the check that produced f: its
+// Reference when set, otherwise its first References entry, otherwise a
+// slug of its Title (e.g. "any-to-any-pass-rule") for engine observations
+// that carry no control ID.
+func FindingCheckID(f Finding) string {
+	if f.Reference != "" {
+		return f.Reference
+	}
+
+	if len(f.References) > 0 {
+		return f.References[0]
+	}
+
+	return titleSlug(f.Title)
+}
+
+// mergeGroup folds two or more findings for the same category and component
+// into one, as described on MergeFindings.
+func mergeGroup(members []Finding) Finding {
+	primary := members[0]
+	for _, f := range members[1:] {
+		if severityIndex(f.Severity) < severityIndex(primary.Severity) {
+			primary = f
+		}
+	}
+
+	checkIDs := make(map[string]struct{}, len(members))
+	tags := make(map[string]struct{})
+	titles := make([]string, 0, len(members))
+	recommendations := make([]string, 0, len(members))
+
+	for _, f := range members {
+		checkIDs[FindingCheckID(f)] = struct{}{}
+
+		for _, ref := range f.References {
+			checkIDs[ref] = struct{}{}
+		}
+
+		for _, tag := range f.Tags {
+			tags[tag] = struct{}{}
+		}
+
+		if !slices.Contains(titles, f.Title) {
+			titles = append(titles, f.Title)
+		}
+
+		if f.Recommendation != "" && !slices.Contains(recommendations, f.Recommendation) {
+			recommendations = append(recommendations, f.Recommendation)
+		}
+	}
+
+	merged := primary
+	merged.Description = fmt.Sprintf(
+		"%s (Merged from %d checks: %s.)",
+		primary.Description, len(members), strings.Join(titles, "; "),
+	)
+	merged.Recommendation = strings.Join(recommendations, "; ")
+	merged.References = slices.Sorted(maps.Keys(checkIDs))
+	merged.Tags = nil
+
+	if len(tags) > 0 {
+		merged.Tags = slices.Sorted(maps.Keys(tags))
+	}
+
+	if primary.Metadata != nil {
+		merged.Metadata = maps.Clone(primary.Metadata)
+	}
+
+	return merged
+}
+
+// severityIndex ranks a severity string from most (0) to least urgent.
+// Unrecognized values rank after SeverityInfo so they never win a merge.
+func severityIndex(s string) int {
+	severities := ValidSeverities()
+	if i := slices.Index(severities, Severity(s)); i >= 0 {
+		return i
+	}
+
+	return len(severities)
+}
+
+// titleSlug lowercases title and replaces every run of non-alphanumeric
+// characters with a single hyphen.
+func titleSlug(title string) string {
+	var b strings.Builder
+
+	pendingHyphen := false
+
+	for _, r := range strings.ToLower(title) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if pendingHyphen && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+
+			pendingHyphen = false
+
+			b.WriteRune(r)
+
+			continue
+		}
+
+		pendingHyphen = true
+	}
+
+	return b.String()
+}
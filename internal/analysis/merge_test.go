@@ -0,0 +1,137 @@
+package analysis_test
+
+import (
+	"testing"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/analysis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeFindings(t *testing.T) {
+	t.Parallel()
+
+	findings := []analysis.Finding{
+		{
+			Type:           "hygiene",
+			Severity:       "medium",
+			Title:          "Shadowed Firewall Rule: Redundant Coverage",
+			Description:    "Rule 2 is shadowed by rule 1",
+			Recommendation: "Remove the redundant rule",
+			Component:      "filter.rule[1]",
+			Metadata:       map[string]string{"reachability": "lan_only"},
+		},
+		{
+			Type:        "hygiene",
+			Severity:    "low",
+			Title:       "Unrelated",
+			Description: "Different component",
+			Component:   "filter.rule[2]",
+		},
+		{
+			Type:           "hygiene",
+			Severity:       "high",
+			Title:          "Any-to-Any Pass Rule",
+			Description:    "Rule 2 passes any traffic",
+			Recommendation: "Restrict the rule",
+			Component:      "filter.rule[1]",
+			Tags:           []string{"rules"},
+			Metadata:       map[string]string{"reachability": "wan_reachable"},
+		},
+		{
+			Type:           "hygiene",
+			Severity:       "high",
+			Title:          "Overly Permissive WAN Rule",
+			Description:    "Rule 2 allows any source on WAN",
+			Recommendation: "Restrict the rule",
+			Component:      "filter.rule[1]",
+			Tags:           []string{"wan", "rules"},
+		},
+		{
+			Type:      "compliance",
+			Severity:  "critical",
+			Title:     "Different category",
+			Component: "filter.rule[1]",
+			Reference: "FIREWALL-022",
+		},
+	}
+
+	merged := analysis.MergeFindings(findings)
+	require.Len(t, merged, 3)
+
+	// Groups keep the position of their first member.
+	got := merged[0]
+	assert.Equal(t, "filter.rule[1]", got.Component)
+	assert.Equal(t, "high", got.Severity, "merged severity is the maximum")
+	assert.Equal(t, "Any-to-Any Pass Rule", got.Title, "first most severe member leads")
+	assert.Equal(t, []string{
+		"any-to-any-pass-rule",
+		"overly-permissive-wan-rule",
+		"shadowed-firewall-rule-redundant-coverage",
+	}, got.References)
+	assert.Equal(t, []string{"rules", "wan"}, got.Tags)
+	assert.Equal(t, map[string]string{"reachability": "wan_reachable"}, got.Metadata)
+	assert.Equal(t, "Remove the redundant rule; Restrict the rule", got.Recommendation)
+	assert.Equal(t,
+		"Rule 2 passes any traffic (Merged from 3 checks: Shadowed Firewall Rule: Redundant Coverage; "+
+			"Any-to-Any Pass Rule; Overly Permissive WAN Rule.)",
+		got.Description)
+
+	assert.Equal(t, findings[1], merged[1])
+	assert.Equal(t, findings[4], merged[2], "different Type is a different category")
+
+	// The input is left untouched.
+	assert.Equal(t, "Rule 2 passes any traffic", findings[2].Description)
+	assert.Equal(t, map[string]string{"reachability": "wan_reachable"}, findings[2].Metadata)
+}
+
+func TestMergeFindings_EmptyComponentNeverMerged(t *testing.T) {
+	t.Parallel()
+
+	findings := []analysis.Finding{
+		{Type: "hygiene", Severity: "high", Title: "A"},
+		{Type: "hygiene", Severity: "low", Title: "B"},
+	}
+
+	assert.Equal(t, findings, analysis.MergeFindings(findings))
+	assert.Empty(t, analysis.MergeFindings(nil))
+}
+
+func TestMergeFindings_ControlReferences(t *testing.T) {
+	t.Parallel()
+
+	merged := analysis.MergeFindings([]analysis.Finding{
+		{Type: "compliance", Severity: "low", Component: "management-access", Reference: "FIREWALL-008"},
+		{
+			Type: "compliance", Severity: "bogus", Component: "management-access",
+			References: []string{"FIREWALL-003", "FIREWALL-004"},
+		},
+	})
+
+	require.Len(t, merged, 1)
+	assert.Equal(t, "low", merged[0].Severity, "unrecognized severity never wins")
+	assert.Equal(t, "FIREWALL-008", merged[0].Reference)
+	assert.Equal(t, []string{"FIREWALL-003", "FIREWALL-004", "FIREWALL-008"}, merged[0].References)
+}
+
+func TestFindingCheckID(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		finding analysis.Finding
+		want    string
+	}{
+		{"reference wins", analysis.Finding{Reference: "STIG-V-1", References: []string{"x"}, Title: "t"}, "STIG-V-1"},
+		{"first references entry", analysis.Finding{References: []string{"SANS-2", "SANS-3"}}, "SANS-2"},
+		{"title slug", analysis.Finding{Title: "Weak Crypto Default: Legacy TLS Cipher"}, "weak-crypto-default-legacy-tls-cipher"},
+		{"empty", analysis.Finding{}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, analysis.FindingCheckID(tt.finding))
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package analysis
+
+import (
+	"fmt"
+	"strings"
+
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+)
+
+// detectOneToOneNATIssues reports enabled 1:1 NAT rules that map a whole
+// subnet rather than a single host (see [common.OneToOneNATRule.MapsSubnet]).
+// Each address in the external range forwards every port to its internal
+// counterpart, so a subnet mapping exposes every host in it, including ones
+// added later: Medium.
+func detectOneToOneNATIssues(nat common.NATConfig) []common.SecurityFinding {
+	var findings []common.SecurityFinding
+
+	for i, rule := range nat.OneToOneRules {
+		if rule.Disabled || !rule.MapsSubnet() {
+			continue
+		}
+
+		external, _ := rule.ExternalPrefix()
+		interfaces := strings.Join(rule.Interfaces, ", ")
+		if interfaces == "" {
+			interfaces = "no interface"
+		}
+
+		findings = append(findings, common.SecurityFinding{
+			Component: fmt.Sprintf("nat.onetoone[%d]", i),
+			Issue:     "1:1 NAT of an Entire Subnet",
+			Severity:  common.SeverityMedium,
+			Description: fmt.Sprintf(
+				"1:1 NAT rule %d maps the external range %s to %s on %s, exposing every host in the subnet on all ports",
+				i+1, external, rule.Internal, interfaces,
+			),
+			Recommendation: "Map only the individual hosts that need a public address, or use port forwards " +
+				"for the services that must be reachable",
+		})
+	}
+
+	return findings
+}
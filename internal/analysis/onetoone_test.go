@@ -0,0 +1,76 @@
+package analysis_test
+
+import (
+	"testing"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/analysis"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectSecurityIssues_OneToOneNATSubnet(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name              string
+		rule              common.OneToOneNATRule
+		wantFinding       bool
+		wantInDescription string
+	}{
+		{
+			name: "single host",
+			rule: common.OneToOneNATRule{External: "203.0.113.10", Internal: "192.168.1.25"},
+		},
+		{
+			name: "explicit /32 internal",
+			rule: common.OneToOneNATRule{External: "203.0.113.10", Internal: "192.168.1.25/32"},
+		},
+		{
+			name:              "subnet inherits the internal mask",
+			rule:              common.OneToOneNATRule{External: "203.0.113.64", Internal: "10.20.0.0/27"},
+			wantFinding:       true,
+			wantInDescription: "203.0.113.64/27",
+		},
+		{
+			name:              "external range with its own mask",
+			rule:              common.OneToOneNATRule{External: "203.0.113.0/28", Internal: "DMZHosts"},
+			wantFinding:       true,
+			wantInDescription: "203.0.113.0/28",
+		},
+		{
+			name: "disabled subnet mapping",
+			rule: common.OneToOneNATRule{External: "203.0.113.64", Internal: "10.20.0.0/27", Disabled: true},
+		},
+		{
+			name: "unparseable external",
+			rule: common.OneToOneNATRule{External: "wanip", Internal: "10.20.0.0/27"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.rule.Interfaces = []string{"wan"}
+			device := &common.CommonDevice{NAT: common.NATConfig{OneToOneRules: []common.OneToOneNATRule{tt.rule}}}
+
+			var findings []common.SecurityFinding
+			for _, f := range analysis.DetectSecurityIssues(device) {
+				if f.Component == "nat.onetoone[0]" {
+					findings = append(findings, f)
+				}
+			}
+
+			if !tt.wantFinding {
+				assert.Empty(t, findings)
+				return
+			}
+
+			require.Len(t, findings, 1)
+			assert.Equal(t, common.SeverityMedium, findings[0].Severity)
+			assert.Equal(t, "1:1 NAT of an Entire Subnet", findings[0].Issue)
+			assert.Contains(t, findings[0].Description, tt.wantInDescription)
+		})
+	}
+}
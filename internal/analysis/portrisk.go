@@ -0,0 +1,197 @@
+package analysis
+
+import (
+	"cmp"
+	_ "embed"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/constants"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed portrisk.yaml
+var portRiskYAML []byte
+
+// ErrInvalidPortRisk is returned when a port risk table entry has an
+// out-of-range port, no service name, or an unknown severity.
+var ErrInvalidPortRisk = errors.New("invalid port risk entry")
+
+// PortRisk describes a service that should not be forwarded from the
+// internet.
+type PortRisk struct {
+	// Service names the protocol, e.g. "Telnet".
+	Service string `yaml:"service"`
+	// Severity is the severity of a finding for a forward that exposes the
+	// port.
+	Severity common.Severity `yaml:"severity"`
+	// Rationale explains why exposing the service is risky.
+	Rationale string `yaml:"rationale"`
+}
+
+// PortRiskTable maps a TCP/UDP port to the risk of forwarding it from the
+// internet.
+type PortRiskTable map[int]PortRisk
+
+// defaultPortRisks returns the embedded table, decoding it on first use. The
+// table is compiled into the binary, so a decoding failure is a build defect
+// and panics.
+var defaultPortRisks = sync.OnceValue(func() PortRiskTable {
+	table, err := ParsePortRiskTable(portRiskYAML)
+	if err != nil {
+		panic(fmt.Sprintf("analysis: decode embedded port risk table: %v", err))
+	}
+
+	return table
+})
+
+// activePortRisks is the table DetectSecurityIssues uses; nil selects the
+// built-in table.
+var activePortRisks atomic.Pointer[PortRiskTable]
+
+// DefaultPortRiskTable returns a copy of the built-in port risk table.
+func DefaultPortRiskTable() PortRiskTable {
+	return maps.Clone(defaultPortRisks())
+}
+
+// ParsePortRiskTable decodes a YAML port risk table keyed by port number.
+// Severities are case-insensitive.
+func ParsePortRiskTable(data []byte) (PortRiskTable, error) {
+	var table PortRiskTable
+	if err := yaml.Unmarshal(data, &table); err != nil {
+		return nil, fmt.Errorf("decode port risk table: %w", err)
+	}
+
+	for port, risk := range table {
+		risk.Severity = common.Severity(strings.ToLower(strings.TrimSpace(string(risk.Severity))))
+
+		switch {
+		case port < 1 || port > maxPort:
+			return nil, fmt.Errorf("%w: port %d is out of range", ErrInvalidPortRisk, port)
+		case strings.TrimSpace(risk.Service) == "":
+			return nil, fmt.Errorf("%w: port %d has no service", ErrInvalidPortRisk, port)
+		case !common.IsValidSeverity(risk.Severity):
+			return nil, fmt.Errorf("%w: port %d has unknown severity %q", ErrInvalidPortRisk, port, risk.Severity)
+		}
+
+		table[port] = risk
+	}
+
+	return table, nil
+}
+
+// LoadPortRiskTable reads a YAML port risk table from path and merges it
+// over the built-in table: its entries replace the built-in entry for the
+// same port and add new ports.
+func LoadPortRiskTable(path string) (PortRiskTable, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read port risk table: %w", err)
+	}
+
+	custom, err := ParsePortRiskTable(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	table := DefaultPortRiskTable()
+	maps.Copy(table, custom)
+
+	return table, nil
+}
+
+// SetPortRiskTable sets the table DetectSecurityIssues matches port forwards
+// against. A nil table restores the built-in table.
+func SetPortRiskTable(table PortRiskTable) {
+	if table == nil {
+		activePortRisks.Store(nil)
+		return
+	}
+
+	table = maps.Clone(table)
+	activePortRisks.Store(&table)
+}
+
+// portRiskTable returns the table set with SetPortRiskTable, or the built-in
+// table.
+func portRiskTable() PortRiskTable {
+	if table := activePortRisks.Load(); table != nil {
+		return *table
+	}
+
+	return defaultPortRisks()
+}
+
+// DetectRiskyPortForwards reports enabled, WAN-reachable port forwards (see
+// InboundNATRuleReachability) whose external or internal port matches an
+// entry of table, one finding per forward and matched port. Ports given as a
+// single port, a "lo-hi" range, or a comma-separated list are matched; a
+// forward of any port or of an alias is not.
+func DetectRiskyPortForwards(cfg *common.CommonDevice, table PortRiskTable) []common.SecurityFinding {
+	if cfg == nil || len(table) == 0 {
+		return nil
+	}
+
+	ports := slices.Sorted(maps.Keys(table))
+
+	var findings []common.SecurityFinding
+
+	for i, nat := range cfg.NAT.InboundRules {
+		if nat.NoRDR || InboundNATRuleReachability(nat, cfg.Interfaces, cfg.FirewallRules) != WANReachable {
+			continue
+		}
+
+		external := cmp.Or(nat.ExternalPort, nat.Destination.Port)
+		target := cmp.Or(nat.InternalIP, constants.NetworkAny)
+
+		for _, port := range ports {
+			if !portSpecContains(external, port) && !portSpecContains(nat.InternalPort, port) {
+				continue
+			}
+
+			risk := table[port]
+			findings = append(findings, common.SecurityFinding{
+				Component: riskyForwardComponent(i, target, nat.AssociatedRuleID),
+				Issue:     risk.Service + " Exposed to the Internet",
+				Severity:  risk.Severity,
+				Description: fmt.Sprintf(
+					"Port forward %d sends external port %s to %s port %s, exposing %s (port %d): %s",
+					i+1, cmp.Or(external, constants.NetworkAny), target,
+					cmp.Or(nat.InternalPort, external, constants.NetworkAny), risk.Service, port, risk.Rationale,
+				),
+				Recommendation: "Remove the port forward or restrict its source addresses, and reach the service over a VPN instead",
+			})
+		}
+	}
+
+	return findings
+}
+
+// portSpecContains reports whether spec, a single port, a "lo-hi" range, or
+// a comma-separated list of those, includes port. The wildcard and aliases
+// include nothing.
+func portSpecContains(spec string, port int) bool {
+	ranges, wildcard, ok := parsePortSpec(spec)
+	if !ok || wildcard {
+		return false
+	}
+
+	return slices.ContainsFunc(ranges, func(r portRange) bool { return r.lo <= port && port <= r.hi })
+}
+
+// riskyForwardComponent identifies port forward i, its internal target, and
+// the filter rule associated with it, when there is one.
+func riskyForwardComponent(i int, target, associatedRule string) string {
+	if associatedRule == "" {
+		return fmt.Sprintf("nat.inbound[%d] (%s)", i, target)
+	}
+
+	return fmt.Sprintf("nat.inbound[%d] (%s, filter rule %s)", i, target, associatedRule)
+}
@@ -0,0 +1,191 @@
+package analysis_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/analysis"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// riskyForwardDevice returns a device with a WAN pass rule and a port forward
+// for each of ports.
+func riskyForwardDevice(ports ...string) *common.CommonDevice {
+	device := &common.CommonDevice{
+		FirewallRules: []common.FirewallRule{{
+			Type:        common.RuleTypePass,
+			Interfaces:  []string{"wan"},
+			Protocol:    "tcp",
+			Destination: common.RuleEndpoint{Address: "192.168.1.50"},
+		}},
+	}
+
+	for _, port := range ports {
+		device.NAT.InboundRules = append(device.NAT.InboundRules, common.InboundNATRule{
+			Interfaces:       []string{"wan"},
+			Protocol:         "tcp",
+			ExternalPort:     port,
+			InternalIP:       "192.168.1.50",
+			InternalPort:     port,
+			AssociatedRuleID: "nat_" + port,
+		})
+	}
+
+	return device
+}
+
+func TestDetectRiskyPortForwards(t *testing.T) {
+	t.Parallel()
+
+	device := riskyForwardDevice("3389", "8443")
+
+	findings := analysis.DetectRiskyPortForwards(device, analysis.DefaultPortRiskTable())
+	require.Len(t, findings, 1, "8443 is not in the built-in table")
+	assert.Equal(t, common.SeverityHigh, findings[0].Severity)
+	assert.Equal(t, "RDP Exposed to the Internet", findings[0].Issue)
+	assert.Equal(t, "nat.inbound[0] (192.168.1.50, filter rule nat_3389)", findings[0].Component)
+
+	custom, err := analysis.ParsePortRiskTable([]byte(`
+8443:
+  service: HTTPS admin panel
+  severity: Medium
+  rationale: Device admin panels should not be reachable from the internet
+`))
+	require.NoError(t, err)
+
+	findings = analysis.DetectRiskyPortForwards(device, custom)
+	require.Len(t, findings, 1)
+	assert.Equal(t, common.SeverityMedium, findings[0].Severity)
+	assert.Equal(t, "nat.inbound[1] (192.168.1.50, filter rule nat_8443)", findings[0].Component)
+}
+
+func TestDetectRiskyPortForwards_Matching(t *testing.T) {
+	t.Parallel()
+
+	table := analysis.DefaultPortRiskTable()
+
+	tests := []struct {
+		name   string
+		mutate func(*common.CommonDevice)
+		want   []string
+	}{
+		{
+			name: "internal port matches behind a different external port",
+			mutate: func(d *common.CommonDevice) {
+				d.NAT.InboundRules[0].ExternalPort = "2323"
+				d.NAT.InboundRules[0].InternalPort = "23"
+			},
+			want: []string{"high: Telnet Exposed to the Internet"},
+		},
+		{
+			name:   "range covering two table ports",
+			mutate: func(d *common.CommonDevice) { d.NAT.InboundRules[0].ExternalPort = "20-23" },
+			want:   []string{"high: FTP Exposed to the Internet", "high: Telnet Exposed to the Internet"},
+		},
+		{
+			name:   "any port and aliases are not matched",
+			mutate: func(d *common.CommonDevice) { d.NAT.InboundRules[0].ExternalPort = "any" },
+		},
+		{
+			name:   "disabled forward",
+			mutate: func(d *common.CommonDevice) { d.NAT.InboundRules[0].Disabled = true },
+		},
+		{
+			name:   "LAN forward",
+			mutate: func(d *common.CommonDevice) { d.NAT.InboundRules[0].Interfaces = []string{"lan"} },
+		},
+		{
+			name:   "no WAN pass rule",
+			mutate: func(d *common.CommonDevice) { d.FirewallRules = nil },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			device := riskyForwardDevice("8443")
+			tt.mutate(device)
+
+			var got []string
+			for _, f := range analysis.DetectRiskyPortForwards(device, table) {
+				got = append(got, string(f.Severity)+": "+f.Issue)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParsePortRiskTable_Invalid(t *testing.T) {
+	t.Parallel()
+
+	for name, data := range map[string]string{
+		"port out of range": "70000: {service: X, severity: high}",
+		"missing service":   "23: {severity: high}",
+		"unknown severity":  "23: {service: Telnet, severity: severe}",
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := analysis.ParsePortRiskTable([]byte(data))
+			require.ErrorIs(t, err, analysis.ErrInvalidPortRisk)
+		})
+	}
+
+	_, err := analysis.ParsePortRiskTable([]byte("not: [a table"))
+	require.Error(t, err)
+}
+
+func TestLoadPortRiskTable(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "ports.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+80:
+  service: HTTP
+  severity: low
+  rationale: Only serves a redirect to HTTPS
+8443:
+  service: HTTPS admin panel
+  severity: medium
+`), 0o600))
+
+	table, err := analysis.LoadPortRiskTable(path)
+	require.NoError(t, err)
+	assert.Equal(t, common.SeverityLow, table[80].Severity, "file entries replace built-in ones")
+	assert.Equal(t, common.SeverityMedium, table[8443].Severity, "file entries add ports")
+	assert.Equal(t, "RDP", table[3389].Service, "built-in entries are kept")
+
+	_, err = analysis.LoadPortRiskTable(filepath.Join(t.TempDir(), "missing.yaml"))
+	require.Error(t, err)
+}
+
+// TestSetPortRiskTable changes the process-wide table, so it does not run in
+// parallel.
+func TestSetPortRiskTable(t *testing.T) {
+	t.Cleanup(func() { analysis.SetPortRiskTable(nil) })
+
+	device := riskyForwardDevice("8443")
+	component := "nat.inbound[0] (192.168.1.50, filter rule nat_8443)"
+
+	components := func() []string {
+		var got []string
+		for _, f := range analysis.DetectSecurityIssues(device) {
+			got = append(got, f.Component)
+		}
+		return got
+	}
+
+	assert.NotContains(t, components(), component)
+
+	table := analysis.DefaultPortRiskTable()
+	table[8443] = analysis.PortRisk{Service: "HTTPS admin panel", Severity: common.SeverityMedium}
+	analysis.SetPortRiskTable(table)
+	assert.Contains(t, components(), component)
+
+	analysis.SetPortRiskTable(nil)
+	assert.NotContains(t, components(), component)
+}
@@ -244,6 +244,18 @@ func resolveGroup(key precedenceGroupKey, ordered []IndexedRule, no common.Named
 func resolvePair(key precedenceGroupKey, earlier, later IndexedRule, no common.NamedObjects) (PrecedencePair, bool) {
 	wl, cov, aliasBlocked := derivePairWinnerLoser(earlier, later, no)
 
+	return precedencePairFor(key, wl, cov, aliasBlocked)
+}
+
+// precedencePairFor builds the PrecedencePair for an already-derived
+// winner/loser and coverage, or reports ok=false when cov is CoverNone or
+// CoverIndeterminate.
+func precedencePairFor(
+	key precedenceGroupKey,
+	wl pairWinnerLoser,
+	cov Coverage,
+	aliasBlocked bool,
+) (PrecedencePair, bool) {
 	if cov != CoverFull && cov != CoverPartial {
 		return PrecedencePair{}, false
 	}
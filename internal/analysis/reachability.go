@@ -139,11 +139,34 @@ func InboundNATRuleReachability(
 	ifaces []common.Interface,
 	passRules []common.FirewallRule,
 ) Reachability {
-	if nat.Disabled {
+	return natRuleReachability(nat.Disabled, nat.Interfaces, ifaces, passRules)
+}
+
+// OneToOneNATRuleReachability reports whether a 1:1 (binat) NAT rule is
+// WAN-reachable. It applies the same test as InboundNATRuleReachability: the
+// mapping must be enabled, apply to a WAN interface, and be matched by at
+// least one enabled WAN-reachable pass rule.
+func OneToOneNATRuleReachability(
+	nat common.OneToOneNATRule,
+	ifaces []common.Interface,
+	passRules []common.FirewallRule,
+) Reachability {
+	return natRuleReachability(nat.Disabled, nat.Interfaces, ifaces, passRules)
+}
+
+// natRuleReachability classifies a NAT rule by its disabled state, its bound
+// interfaces, and whether any enabled pass rule is WAN-reachable.
+func natRuleReachability(
+	disabled bool,
+	interfaces []string,
+	ifaces []common.Interface,
+	passRules []common.FirewallRule,
+) Reachability {
+	if disabled {
 		return Local
 	}
 
-	if !slices.ContainsFunc(nat.Interfaces, IsWANInterfaceName) {
+	if !slices.ContainsFunc(interfaces, IsWANInterfaceName) {
 		return LANOnly
 	}
 
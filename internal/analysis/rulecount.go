@@ -0,0 +1,47 @@
+package analysis
+
+import common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+
+// RuleCount tallies the firewall rules attached to one interface.
+type RuleCount struct {
+	// Pass is the number of pass rules.
+	Pass int `json:"pass"`
+	// Block is the number of block and reject rules.
+	Block int `json:"block"`
+	// Total is the number of rules of any type, including match rules.
+	Total int `json:"total"`
+}
+
+// InterfaceRuleCounts returns the firewall rule counts for every interface,
+// keyed by interface name. Every configured interface is present, even with
+// no rules, and a rule listing several interfaces (a floating rule) counts
+// towards each of them. Disabled rules are counted, matching
+// Statistics.RulesByInterface. A nil cfg returns an empty map.
+func InterfaceRuleCounts(cfg *common.CommonDevice) map[string]RuleCount {
+	counts := make(map[string]RuleCount)
+	if cfg == nil {
+		return counts
+	}
+
+	for _, iface := range cfg.Interfaces {
+		counts[iface.Name] = RuleCount{}
+	}
+
+	for _, rule := range cfg.FirewallRules {
+		for _, iface := range rule.Interfaces {
+			c := counts[iface]
+			c.Total++
+
+			switch rule.Type {
+			case common.RuleTypePass:
+				c.Pass++
+			case common.RuleTypeBlock, common.RuleTypeReject:
+				c.Block++
+			}
+
+			counts[iface] = c
+		}
+	}
+
+	return counts
+}
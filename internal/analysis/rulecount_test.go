@@ -0,0 +1,77 @@
+package analysis_test
+
+import (
+	"testing"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/analysis"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInterfaceRuleCounts(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		cfg  *common.CommonDevice
+		want map[string]analysis.RuleCount
+	}{
+		{
+			name: "nil config",
+			want: map[string]analysis.RuleCount{},
+		},
+		{
+			name: "interfaces without rules are zero",
+			cfg: &common.CommonDevice{
+				Interfaces: []common.Interface{{Name: "wan"}, {Name: "lan"}},
+			},
+			want: map[string]analysis.RuleCount{"wan": {}, "lan": {}},
+		},
+		{
+			name: "pass, block and reject are tallied per interface",
+			cfg: &common.CommonDevice{
+				Interfaces: []common.Interface{{Name: "wan"}, {Name: "lan"}, {Name: "opt1"}},
+				FirewallRules: []common.FirewallRule{
+					{Type: common.RuleTypePass, Interfaces: []string{"lan"}},
+					{Type: common.RuleTypePass, Interfaces: []string{"lan"}, Disabled: true},
+					{Type: common.RuleTypeBlock, Interfaces: []string{"wan"}},
+					{Type: common.RuleTypeReject, Interfaces: []string{"wan"}},
+					{Type: "match", Interfaces: []string{"wan"}},
+				},
+			},
+			want: map[string]analysis.RuleCount{
+				"wan":  {Block: 2, Total: 3},
+				"lan":  {Pass: 2, Total: 2},
+				"opt1": {},
+			},
+		},
+		{
+			name: "floating rule counts towards each interface",
+			cfg: &common.CommonDevice{
+				Interfaces: []common.Interface{{Name: "wan"}, {Name: "lan"}},
+				FirewallRules: []common.FirewallRule{
+					{Type: common.RuleTypeBlock, Interfaces: []string{"wan", "lan"}, Floating: true},
+				},
+			},
+			want: map[string]analysis.RuleCount{
+				"wan": {Block: 1, Total: 1},
+				"lan": {Block: 1, Total: 1},
+			},
+		},
+		{
+			name: "rule on unknown interface is still counted",
+			cfg: &common.CommonDevice{
+				FirewallRules: []common.FirewallRule{{Type: common.RuleTypePass, Interfaces: []string{"openvpn"}}},
+			},
+			want: map[string]analysis.RuleCount{"openvpn": {Pass: 1, Total: 1}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, analysis.InterfaceRuleCounts(tt.cfg))
+		})
+	}
+}
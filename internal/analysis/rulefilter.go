@@ -0,0 +1,159 @@
+package analysis
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+)
+
+// RuleFilter narrows rule listings to the rules matching every set
+// criterion. Empty criteria match everything, so the zero value keeps every
+// rule. All comparisons are case-insensitive.
+type RuleFilter struct {
+	// Interface keeps rules that apply to this interface name.
+	Interface string
+	// Action keeps firewall rules of this type (pass, block, or reject).
+	// NAT rules carry no action and are not narrowed by it.
+	Action string
+	// Search keeps rules whose description, source, destination, or ports
+	// contain this text.
+	Search string
+}
+
+// IsActive reports whether any criterion is set.
+func (f RuleFilter) IsActive() bool {
+	return f.Interface != "" || f.Action != "" || f.Search != ""
+}
+
+// String describes the set criteria, e.g. `interface=wan, action=block`.
+func (f RuleFilter) String() string {
+	var parts []string
+	if f.Interface != "" {
+		parts = append(parts, "interface="+f.Interface)
+	}
+	if f.Action != "" {
+		parts = append(parts, "action="+f.Action)
+	}
+	if f.Search != "" {
+		parts = append(parts, fmt.Sprintf("search=%q", f.Search))
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// MatchFirewallRule reports whether rule satisfies every set criterion.
+func (f RuleFilter) MatchFirewallRule(rule common.FirewallRule) bool {
+	if f.Action != "" && !strings.EqualFold(string(rule.Type), f.Action) {
+		return false
+	}
+
+	return f.matchInterfaces(rule.Interfaces) && f.matchText(
+		rule.Description,
+		rule.Source.Address, rule.Source.Port,
+		rule.Destination.Address, rule.Destination.Port,
+	)
+}
+
+// MatchOutboundNATRule reports whether rule satisfies the interface and
+// search criteria.
+func (f RuleFilter) MatchOutboundNATRule(rule common.NATRule) bool {
+	return f.matchInterfaces(rule.Interfaces) && f.matchText(
+		rule.Description,
+		rule.Source.Address, rule.Source.Port,
+		rule.Destination.Address, rule.Destination.Port,
+		rule.Target, rule.NatPort,
+	)
+}
+
+// MatchInboundNATRule reports whether rule satisfies the interface and
+// search criteria.
+func (f RuleFilter) MatchInboundNATRule(rule common.InboundNATRule) bool {
+	return f.matchInterfaces(rule.Interfaces) && f.matchText(
+		rule.Description,
+		rule.Source.Address, rule.Source.Port,
+		rule.Destination.Address, rule.Destination.Port,
+		rule.ExternalPort, rule.InternalIP, rule.InternalPort,
+	)
+}
+
+// MatchOneToOneNATRule reports whether rule satisfies the interface and
+// search criteria.
+func (f RuleFilter) MatchOneToOneNATRule(rule common.OneToOneNATRule) bool {
+	return f.matchInterfaces(rule.Interfaces) && f.matchText(
+		rule.Description,
+		rule.External, rule.Internal,
+		rule.Destination.Address, rule.Destination.Port,
+	)
+}
+
+// FilterRules returns the rules accepted by match and their 1-based
+// positions in rules, so a filtered table can keep the original rule numbers.
+func FilterRules[T any](rules []T, match func(T) bool) ([]T, []int) {
+	var (
+		kept      []T
+		positions []int
+	)
+
+	for i, rule := range rules {
+		if match(rule) {
+			kept = append(kept, rule)
+			positions = append(positions, i+1)
+		}
+	}
+
+	return kept, positions
+}
+
+// ApplyRuleFilter narrows cfg's firewall, outbound NAT, inbound NAT, and 1:1
+// NAT rules to those matching f, in place. cfg must be a copy the caller owns; the
+// rule slices are replaced, never modified. It returns the number of rules
+// kept and the number there were before filtering.
+func ApplyRuleFilter(cfg *common.CommonDevice, f RuleFilter) (shown, total int) {
+	if cfg == nil || !f.IsActive() {
+		return 0, 0
+	}
+
+	total = len(cfg.FirewallRules) + len(cfg.NAT.OutboundRules) + len(cfg.NAT.InboundRules) +
+		len(cfg.NAT.OneToOneRules)
+
+	cfg.FirewallRules, _ = FilterRules(cfg.FirewallRules, f.MatchFirewallRule)
+	cfg.NAT.OutboundRules, _ = FilterRules(cfg.NAT.OutboundRules, f.MatchOutboundNATRule)
+	cfg.NAT.InboundRules, _ = FilterRules(cfg.NAT.InboundRules, f.MatchInboundNATRule)
+	cfg.NAT.OneToOneRules, _ = FilterRules(cfg.NAT.OneToOneRules, f.MatchOneToOneNATRule)
+
+	shown = len(cfg.FirewallRules) + len(cfg.NAT.OutboundRules) + len(cfg.NAT.InboundRules) +
+		len(cfg.NAT.OneToOneRules)
+
+	return shown, total
+}
+
+// matchInterfaces reports whether the interface criterion is unset or names
+// one of interfaces.
+func (f RuleFilter) matchInterfaces(interfaces []string) bool {
+	if f.Interface == "" {
+		return true
+	}
+
+	return slices.ContainsFunc(interfaces, func(iface string) bool {
+		return strings.EqualFold(iface, f.Interface)
+	})
+}
+
+// matchText reports whether the search criterion is unset or contained in
+// one of fields.
+func (f RuleFilter) matchText(fields ...string) bool {
+	if f.Search == "" {
+		return true
+	}
+
+	needle := strings.ToLower(f.Search)
+	for _, field := range fields {
+		if strings.Contains(strings.ToLower(field), needle) {
+			return true
+		}
+	}
+
+	return false
+}
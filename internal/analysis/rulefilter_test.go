@@ -0,0 +1,106 @@
+package analysis_test
+
+import (
+	"testing"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/analysis"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRuleFilter_MatchFirewallRule(t *testing.T) {
+	t.Parallel()
+
+	rule := common.FirewallRule{
+		Type:        common.RuleTypeBlock,
+		Interfaces:  []string{"lan", "WAN"},
+		Description: "Block Telnet",
+		Source:      common.RuleEndpoint{Address: "any"},
+		Destination: common.RuleEndpoint{Address: "10.0.0.1", Port: "23"},
+	}
+
+	tests := []struct {
+		name   string
+		filter analysis.RuleFilter
+		want   bool
+	}{
+		{"zero filter", analysis.RuleFilter{}, true},
+		{"interface is case-insensitive", analysis.RuleFilter{Interface: "wan"}, true},
+		{"other interface", analysis.RuleFilter{Interface: "opt1"}, false},
+		{"action", analysis.RuleFilter{Action: "block"}, true},
+		{"other action", analysis.RuleFilter{Action: "pass"}, false},
+		{"search description", analysis.RuleFilter{Search: "telnet"}, true},
+		{"search destination", analysis.RuleFilter{Search: "10.0.0"}, true},
+		{"search port", analysis.RuleFilter{Search: "23"}, true},
+		{"search miss", analysis.RuleFilter{Search: "ssh"}, false},
+		{"all criteria", analysis.RuleFilter{Interface: "lan", Action: "BLOCK", Search: "telnet"}, true},
+		{"one criterion fails", analysis.RuleFilter{Interface: "lan", Action: "reject", Search: "telnet"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, tt.filter.MatchFirewallRule(rule))
+		})
+	}
+}
+
+func TestRuleFilter_NATRulesIgnoreAction(t *testing.T) {
+	t.Parallel()
+
+	filter := analysis.RuleFilter{Interface: "wan", Action: "block", Search: "8443"}
+
+	assert.True(t, filter.MatchInboundNATRule(common.InboundNATRule{
+		Interfaces: []string{"wan"}, ExternalPort: "8443",
+	}))
+	assert.False(t, filter.MatchInboundNATRule(common.InboundNATRule{
+		Interfaces: []string{"lan"}, ExternalPort: "8443",
+	}))
+	assert.True(t, filter.MatchOutboundNATRule(common.NATRule{
+		Interfaces: []string{"wan"}, NatPort: "8443",
+	}))
+}
+
+func TestRuleFilter_String(t *testing.T) {
+	t.Parallel()
+
+	assert.Empty(t, analysis.RuleFilter{}.String())
+	assert.False(t, analysis.RuleFilter{}.IsActive())
+
+	filter := analysis.RuleFilter{Interface: "wan", Action: "pass", Search: "web server"}
+	assert.True(t, filter.IsActive())
+	assert.Equal(t, `interface=wan, action=pass, search="web server"`, filter.String())
+}
+
+func TestApplyRuleFilter(t *testing.T) {
+	t.Parallel()
+
+	rules := []common.FirewallRule{
+		{Type: common.RuleTypePass, Interfaces: []string{"wan"}},
+		{Type: common.RuleTypeBlock, Interfaces: []string{"wan"}},
+		{Type: common.RuleTypeBlock, Interfaces: []string{"lan"}},
+	}
+	cfg := &common.CommonDevice{
+		FirewallRules: rules,
+		NAT: common.NATConfig{
+			OutboundRules: []common.NATRule{{Interfaces: []string{"wan"}}},
+		},
+	}
+
+	kept, positions := analysis.FilterRules(rules, analysis.RuleFilter{Action: "block"}.MatchFirewallRule)
+	assert.Equal(t, rules[1:], kept)
+	assert.Equal(t, []int{2, 3}, positions)
+
+	shown, total := analysis.ApplyRuleFilter(cfg, analysis.RuleFilter{Interface: "wan", Action: "block"})
+	assert.Equal(t, 2, shown)
+	assert.Equal(t, 4, total)
+	assert.Equal(t, rules[1:2], cfg.FirewallRules)
+	assert.Len(t, cfg.NAT.OutboundRules, 1, "NAT rules are not narrowed by action")
+	assert.Len(t, rules, 3, "the original slice is not modified")
+	assert.Equal(t, common.RuleTypePass, rules[0].Type)
+
+	shown, total = analysis.ApplyRuleFilter(cfg, analysis.RuleFilter{})
+	assert.Zero(t, shown)
+	assert.Zero(t, total)
+}
@@ -0,0 +1,167 @@
+package analysis
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/EvilBit-Labs/opnDossier/pkg/schema/shared"
+)
+
+// DetectScheduleIssues checks firewall rule schedules against the schedules
+// the device defines:
+//
+//   - an enabled rule referencing a schedule that does not exist is High;
+//   - an enabled rule whose schedule can never match again after now is
+//     Medium, since the rule is effectively inactive;
+//   - a schedule that no rule references is Info.
+//
+// A schedule can never match again when every one of its time ranges is a
+// list of dates and the last of those days has passed. Configurations record
+// only the month and day of a date, so dates are taken in the year of the
+// configuration's last revision, the year the schedule was written for, or
+// in now's year when the revision time is unknown. Weekly ranges never
+// expire. now is the reference time; callers outside tests pass time.Now().
+// Returns nil when no issues are found.
+func DetectScheduleIssues(cfg *common.CommonDevice, now time.Time) []common.ConsistencyFinding {
+	if cfg == nil {
+		return nil
+	}
+
+	schedules := make(map[string]common.Schedule, len(cfg.Schedules))
+	for _, sched := range cfg.Schedules {
+		schedules[sched.Name] = sched
+	}
+
+	year := scheduleYear(cfg, now)
+
+	var findings []common.ConsistencyFinding
+	used := make(map[string]bool)
+
+	for i, rule := range cfg.FirewallRules {
+		if rule.Schedule == "" {
+			continue
+		}
+
+		used[rule.Schedule] = true
+		if rule.Disabled {
+			continue
+		}
+
+		sched, ok := schedules[rule.Schedule]
+		switch {
+		case !ok:
+			findings = append(findings, common.ConsistencyFinding{
+				Component: fmt.Sprintf("filter.rule[%d]", i),
+				Issue:     "Rule References Missing Schedule",
+				Severity:  common.SeverityHigh,
+				Description: fmt.Sprintf(
+					"Rule %d references schedule %q, which is not defined",
+					i+1, rule.Schedule,
+				),
+				Recommendation: "Recreate the schedule or remove it from the rule so the rule's active times are known",
+			})
+		case scheduleExpired(sched, year, now):
+			findings = append(findings, common.ConsistencyFinding{
+				Component: fmt.Sprintf("filter.rule[%d]", i),
+				Issue:     "Rule Effectively Inactive",
+				Severity:  common.SeverityMedium,
+				Description: fmt.Sprintf(
+					"Rule %d uses schedule %q, whose date ranges have all elapsed; the rule will never match again",
+					i+1, sched.Name,
+				),
+				Recommendation: "Remove the rule or update the schedule with current dates",
+			})
+		}
+	}
+
+	for i, sched := range cfg.Schedules {
+		if used[sched.Name] {
+			continue
+		}
+
+		findings = append(findings, common.ConsistencyFinding{
+			Component:      fmt.Sprintf("schedules.schedule[%d]", i),
+			Issue:          "Unused Schedule",
+			Severity:       common.SeverityInfo,
+			Description:    fmt.Sprintf("Schedule %q is not referenced by any firewall rule", sched.Name),
+			Recommendation: "Remove the schedule if it is no longer needed",
+		})
+	}
+
+	return findings
+}
+
+// scheduleYear returns the year schedule dates are taken in: the year of
+// cfg's last revision, or now's year when the revision time is missing or
+// cannot be parsed.
+func scheduleYear(cfg *common.CommonDevice, now time.Time) int {
+	if revised, ok := shared.ParseTimestamp(cfg.Revision.Time); ok {
+		return revised.Year()
+	}
+
+	return now.Year()
+}
+
+// scheduleExpired reports whether sched has at least one time range and
+// every range, with its dates taken in year, has elapsed before now.
+func scheduleExpired(sched common.Schedule, year int, now time.Time) bool {
+	if len(sched.TimeRanges) == 0 {
+		return false
+	}
+
+	for _, tr := range sched.TimeRanges {
+		if !timeRangeElapsed(tr, year, now) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// timeRangeElapsed reports whether tr selects only dates whose last active
+// moment in year is before now. A range's last active moment is the end of
+// its latest day, or the end of the following day when its hours window wraps
+// past midnight. Weekly ranges and ranges without a day selection never
+// elapse.
+func timeRangeElapsed(tr common.ScheduleTimeRange, year int, now time.Time) bool {
+	if len(tr.Weekdays) > 0 || len(tr.Dates) == 0 {
+		return false
+	}
+
+	extraDays := 1
+	if hoursWrapMidnight(tr.Hours) {
+		extraDays = 2
+	}
+
+	for _, d := range tr.Dates {
+		end := time.Date(year, time.Month(d.Month), d.Day+extraDays, 0, 0, 0, 0, now.Location())
+		if end.After(now) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// hoursWrapMidnight reports whether an "HH:MM-HH:MM" window ends before it
+// starts, i.e. runs past midnight into the next day.
+func hoursWrapMidnight(hours string) bool {
+	from, to, ok := strings.Cut(hours, "-")
+	if !ok {
+		return false
+	}
+
+	start, err := time.Parse("15:04", strings.TrimSpace(from))
+	if err != nil {
+		return false
+	}
+
+	end, err := time.Parse("15:04", strings.TrimSpace(to))
+	if err != nil {
+		return false
+	}
+
+	return end.Before(start)
+}
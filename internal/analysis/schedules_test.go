@@ -0,0 +1,189 @@
+package analysis_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/analysis"
+	"github.com/EvilBit-Labs/opnDossier/internal/cfgparser"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/EvilBit-Labs/opnDossier/pkg/parser"
+	_ "github.com/EvilBit-Labs/opnDossier/pkg/parser/opnsense" // self-registers OPNsense parser via init()
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+//nolint:funlen // test table or data declaration; length is in data not logic
+func TestDetectScheduleIssues(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, time.June, 1, 12, 0, 0, 0, time.UTC)
+
+	rule := func(sched string) common.FirewallRule {
+		return common.FirewallRule{Type: common.RuleTypePass, Interfaces: []string{"lan"}, Schedule: sched}
+	}
+
+	// revised2021 is 2021-04-01T00:00:00Z, the last revision of a
+	// configuration whose schedule dates are taken in 2021.
+	revised2021 := common.Revision{Time: "1617235200"}
+
+	expired := common.Schedule{
+		Name: "Maintenance2021",
+		TimeRanges: []common.ScheduleTimeRange{{
+			Dates: []common.ScheduleDate{{Month: 3, Day: 14}, {Month: 3, Day: 15}},
+			Hours: "0:00-23:59",
+		}},
+	}
+	weekly := common.Schedule{
+		Name: "OfficeHours",
+		TimeRanges: []common.ScheduleTimeRange{
+			{Weekdays: []int{1, 2, 3, 4, 5}, Hours: "8:00-17:00"},
+		},
+	}
+
+	tests := []struct {
+		name string
+		cfg  *common.CommonDevice
+		want []common.ConsistencyFinding
+	}{
+		{
+			name: "nil device",
+			cfg:  nil,
+		},
+		{
+			name: "weekly repeating schedule is never inactive",
+			cfg: &common.CommonDevice{
+				FirewallRules: []common.FirewallRule{rule("OfficeHours")},
+				Schedules:     []common.Schedule{weekly},
+			},
+		},
+		{
+			name: "dates elapsed in the revision year make the rule inactive",
+			cfg: &common.CommonDevice{
+				Revision:      revised2021,
+				FirewallRules: []common.FirewallRule{rule(""), rule("Maintenance2021")},
+				Schedules:     []common.Schedule{expired},
+			},
+			want: []common.ConsistencyFinding{{
+				Component: "filter.rule[1]",
+				Issue:     "Rule Effectively Inactive",
+				Severity:  common.SeverityMedium,
+				Description: `Rule 2 uses schedule "Maintenance2021", whose date ranges have all elapsed; ` +
+					"the rule will never match again",
+				Recommendation: "Remove the rule or update the schedule with current dates",
+			}},
+		},
+		{
+			name: "a remaining date in the reference year keeps the schedule active",
+			cfg: &common.CommonDevice{
+				FirewallRules: []common.FirewallRule{rule("Mixed")},
+				Schedules: []common.Schedule{
+					{Name: "Mixed", TimeRanges: []common.ScheduleTimeRange{
+						expired.TimeRanges[0],
+						{Dates: []common.ScheduleDate{{Month: 6, Day: 1}}},
+					}},
+				},
+			},
+		},
+		{
+			name: "dates are taken in the current year without a revision time",
+			cfg: &common.CommonDevice{
+				FirewallRules: []common.FirewallRule{rule("Maintenance2021")},
+				Schedules:     []common.Schedule{expired},
+			},
+			want: []common.ConsistencyFinding{{
+				Component: "filter.rule[0]",
+				Issue:     "Rule Effectively Inactive",
+				Severity:  common.SeverityMedium,
+				Description: `Rule 1 uses schedule "Maintenance2021", whose date ranges have all elapsed; ` +
+					"the rule will never match again",
+				Recommendation: "Remove the rule or update the schedule with current dates",
+			}},
+		},
+		{
+			name: "overnight hours extend the last day",
+			cfg: &common.CommonDevice{
+				FirewallRules: []common.FirewallRule{rule("Overnight")},
+				Schedules: []common.Schedule{{Name: "Overnight", TimeRanges: []common.ScheduleTimeRange{
+					{Dates: []common.ScheduleDate{{Month: 5, Day: 31}}, Hours: "22:00-2:00"},
+				}}},
+			},
+		},
+		{
+			name: "missing schedule",
+			cfg: &common.CommonDevice{
+				FirewallRules: []common.FirewallRule{rule("Gone")},
+			},
+			want: []common.ConsistencyFinding{{
+				Component:      "filter.rule[0]",
+				Issue:          "Rule References Missing Schedule",
+				Severity:       common.SeverityHigh,
+				Description:    `Rule 1 references schedule "Gone", which is not defined`,
+				Recommendation: "Recreate the schedule or remove it from the rule so the rule's active times are known",
+			}},
+		},
+		{
+			name: "disabled rules are skipped but still use their schedule",
+			cfg: &common.CommonDevice{
+				FirewallRules: []common.FirewallRule{
+					{Disabled: true, Schedule: "Maintenance2021"},
+					{Disabled: true, Schedule: "Gone"},
+				},
+				Schedules: []common.Schedule{expired},
+			},
+		},
+		{
+			name: "unused schedule",
+			cfg: &common.CommonDevice{
+				Schedules: []common.Schedule{weekly},
+			},
+			want: []common.ConsistencyFinding{{
+				Component:      "schedules.schedule[0]",
+				Issue:          "Unused Schedule",
+				Severity:       common.SeverityInfo,
+				Description:    `Schedule "OfficeHours" is not referenced by any firewall rule`,
+				Recommendation: "Remove the schedule if it is no longer needed",
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, analysis.DetectScheduleIssues(tt.cfg, now))
+		})
+	}
+}
+
+// TestDetectScheduleIssues_Fixture parses testdata/schedules_test.xml, whose
+// time ranges carry only month and day as real configurations do, and checks
+// that the March 14-15 window, taken in the 2021 revision year, makes its rule
+// inactive only once March 15 has ended. The weekly schedule never expires.
+func TestDetectScheduleIssues_Fixture(t *testing.T) {
+	t.Parallel()
+
+	f, err := os.Open(filepath.Join("..", "..", "testdata", "schedules_test.xml"))
+	require.NoError(t, err)
+	defer f.Close()
+
+	factory := parser.NewFactory(cfgparser.NewXMLParser())
+	device, _, err := factory.CreateDevice(context.Background(), f, common.DeviceTypeUnknown, false)
+	require.NoError(t, err)
+
+	require.Len(t, device.Schedules, 2)
+	assert.Equal(t, []common.ScheduleDate{{Month: 3, Day: 14}, {Month: 3, Day: 15}},
+		device.Schedules[0].TimeRanges[0].Dates)
+
+	lastMinute := time.Date(2021, time.March, 15, 23, 59, 0, 0, time.UTC)
+	assert.Empty(t, analysis.DetectScheduleIssues(device, lastMinute))
+
+	afterWindow := time.Date(2021, time.March, 16, 0, 0, 0, 0, time.UTC)
+	findings := analysis.DetectScheduleIssues(device, afterWindow)
+	require.Len(t, findings, 1)
+	assert.Equal(t, "filter.rule[0]", findings[0].Component)
+	assert.Equal(t, "Rule Effectively Inactive", findings[0].Issue)
+}
@@ -21,18 +21,24 @@ func DetectShadowedRules(cfg *common.CommonDevice) []common.ShadowedRuleFinding
 		return nil
 	}
 
-	pairs := ResolvePrecedence(cfg)
-	pairs = append(pairs, detectAliasBlockedSecurityAdvisories(cfg.FirewallRules, cfg.Interfaces, cfg.NamedObjects)...)
+	groups := buildPrecedenceGroups(cfg.FirewallRules, cfg.Interfaces)
 
 	var findings []common.ShadowedRuleFinding
 
-	for _, pair := range pairs {
-		if isTerminalDefaultDeny(pair, cfg.FirewallRules) {
-			continue
-		}
+	for _, key := range sortedGroupKeys(groups) {
+		ordered := groups[key]
+
+		for i := range ordered {
+			for j := i + 1; j < len(ordered); j++ {
+				pair, ok := resolveShadowPair(key, ordered[i], ordered[j], cfg.NamedObjects)
+				if !ok || isTerminalDefaultDeny(pair, cfg.FirewallRules) {
+					continue
+				}
 
-		if finding, ok := buildShadowFinding(pair, cfg.Interfaces); ok {
-			findings = append(findings, finding)
+				if finding, ok := buildShadowFinding(pair, cfg.Interfaces); ok {
+					findings = append(findings, finding)
+				}
+			}
 		}
 	}
 
@@ -93,7 +99,7 @@ func impactClassFor(winnerType, loserType common.FirewallRuleType) common.Impact
 // full/partial Kind. Extent is never CoverNone or CoverIndeterminate for a
 // pair reaching this point (ResolvePrecedence guarantees Full/Partial; the
 // R8 advisory scan's CoverNone pairs are given an explicit conservative
-// Extent by resolveAliasBlockedAdvisoryPair).
+// Extent by aliasBlockedAdvisoryPair).
 func shadowKindFor(extent Coverage) common.ShadowKind {
 	if extent == CoverFull {
 		return common.ShadowKindFull
@@ -234,61 +240,57 @@ func isTerminalDefaultDeny(pair PrecedencePair, rules []common.FirewallRule) boo
 	return ordered[len(ordered)-1].Index == pair.Loser.Index
 }
 
-// detectAliasBlockedSecurityAdvisories scans every pf evaluation group for
-// candidate pairs whose coverage could not be resolved because of an
-// unresolvable named-object reference (aliasBlocked) and which — had the
-// alias resolved — might have been a Security-class shadow (a pass rule
-// possibly defeating a block/reject rule). ResolvePrecedence (U5) only
-// returns pairs whose coverage() call resolved to CoverFull or CoverPartial,
-// so a pair whose coverage folded back to CoverNone via U4's alias-blocked
-// exact-singleton fallback is invisible to it — this scan is the only place
-// such a pair surfaces, implementing R8's advisory carve-out (the same
-// over-report bias GOTCHAS §8.4 documents for NAT-to-WAN correlation).
+// resolveShadowPair resolves one candidate (earlier, later) pair into the
+// PrecedencePair a shadow finding is built from: the pair ResolvePrecedence
+// would return, or failing that the R8 advisory pair. The winner/loser and
+// coverage are derived once and offered to both classifications, so the
+// pairwise coverage work — which dominates on large rulesets — is not
+// repeated.
+//
+// The advisory pair covers a candidate pair whose coverage could not be
+// resolved because of an unresolvable named-object reference (aliasBlocked)
+// and which — had the alias resolved — might have been a Security-class
+// shadow (a pass rule possibly defeating a block/reject rule).
+// ResolvePrecedence (U5) only returns pairs whose coverage() call resolved
+// to CoverFull or CoverPartial, so a pair whose coverage folded back to
+// CoverNone via U4's alias-blocked exact-singleton fallback is invisible to
+// it — this is the only place such a pair surfaces, implementing R8's
+// advisory carve-out (the same over-report bias GOTCHAS §8.4 documents for
+// NAT-to-WAN correlation).
 //
 // Pairs whose coverage() call did resolve to CoverFull or CoverPartial with
-// AliasBlocked=true are NOT re-derived here — ResolvePrecedence already
-// returns those, and buildShadowFinding routes them through the same
-// advisory branch via pair.AliasBlocked.
-func detectAliasBlockedSecurityAdvisories(
-	rules []common.FirewallRule,
-	deviceIfaces []common.Interface,
+// AliasBlocked=true are returned as precedence pairs, and
+// buildShadowFinding routes them through the same advisory branch via
+// pair.AliasBlocked.
+func resolveShadowPair(
+	key precedenceGroupKey,
+	earlier, later IndexedRule,
 	no common.NamedObjects,
-) []PrecedencePair {
-	groups := buildPrecedenceGroups(rules, deviceIfaces)
-
-	var pairs []PrecedencePair
-
-	for _, key := range sortedGroupKeys(groups) {
-		ordered := groups[key]
+) (PrecedencePair, bool) {
+	wl, cov, aliasBlocked := derivePairWinnerLoser(earlier, later, no)
 
-		for i := range ordered {
-			for j := i + 1; j < len(ordered); j++ {
-				if pair, ok := resolveAliasBlockedAdvisoryPair(key, ordered[i], ordered[j], no); ok {
-					pairs = append(pairs, pair)
-				}
-			}
-		}
+	if pair, ok := precedencePairFor(key, wl, cov, aliasBlocked); ok {
+		return pair, true
 	}
 
-	return pairs
+	return aliasBlockedAdvisoryPair(key, wl, cov, aliasBlocked)
 }
 
-// resolveAliasBlockedAdvisoryPair mirrors resolvePair's winner/loser
-// derivation (precedence.go, derivePairWinnerLoser) for one candidate
-// (earlier, later) pair, but — unlike resolvePair — surfaces the pair even
-// when coverage() returns CoverNone, provided the CoverNone came from an
-// unresolvable alias (aliasBlocked) and the resulting winner/loser action
+// aliasBlockedAdvisoryPair builds the R8 advisory pair for an
+// already-derived winner/loser and coverage (see derivePairWinnerLoser in
+// precedence.go). Unlike precedencePairFor it surfaces the pair only when
+// coverage() returned CoverNone, provided the CoverNone came from an
+// unresolvable alias (aliasBlocked) and the winner/loser action
 // relationship would be Security. The pair's Extent is set to CoverFull: the
 // true extent is unknown (that is precisely what "unresolved" means), and
 // R8's over-report bias for security correlation prefers the conservative
 // (full) framing over silently under-reporting a partial one.
-func resolveAliasBlockedAdvisoryPair(
+func aliasBlockedAdvisoryPair(
 	key precedenceGroupKey,
-	earlier, later IndexedRule,
-	no common.NamedObjects,
+	wl pairWinnerLoser,
+	cov Coverage,
+	aliasBlocked bool,
 ) (PrecedencePair, bool) {
-	wl, cov, aliasBlocked := derivePairWinnerLoser(earlier, later, no)
-
 	if !aliasBlocked || cov != CoverNone {
 		return PrecedencePair{}, false
 	}
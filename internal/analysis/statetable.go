@@ -0,0 +1,117 @@
+package analysis
+
+import (
+	"cmp"
+	"fmt"
+	"strings"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/constants"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+)
+
+// pf state-tracking options as stored in a rule's StateType.
+const (
+	stateTypeNone     = "none"
+	stateTypeSloppy   = "sloppy state"
+	stateTypeSynproxy = "synproxy state"
+)
+
+// detectStateTableExhaustion flags enabled pass rules that leave the pf state
+// table open to exhaustion or weaken state tracking:
+//
+//   - a WAN-reachable rule matching TCP, including an any-protocol rule, with
+//     neither max-src-conn, max-src-conn-rate, nor synproxy state is Low,
+//     since a single source can fill the table;
+//   - a rule with state type "none" is Medium on any interface, since
+//     stateless rules bypass return-traffic tracking;
+//   - a WAN-reachable rule with sloppy state is Info.
+//
+// Block and reject rules never create state and are skipped.
+func detectStateTableExhaustion(cfg *common.CommonDevice) []Observation {
+	var observations []Observation
+
+	for i, rule := range cfg.FirewallRules {
+		if rule.Disabled || rule.Type != common.RuleTypePass {
+			continue
+		}
+
+		reachability := RuleReachability(rule, cfg.Interfaces)
+		component := fmt.Sprintf("filter.rule[%d]", i)
+
+		if reachability == WANReachable && isTCPRule(rule) && !hasConnectionLimits(rule) {
+			observations = append(observations, Observation{
+				Severity:     SeverityLow,
+				Confidence:   ConfidenceHigh,
+				Reachability: reachability,
+				Component:    component,
+				Evidence: fmt.Sprintf(
+					"rule %d: protocol=%s max-src-conn=unset max-src-conn-rate=unset statetype=%q",
+					i+1, cmp.Or(rule.Protocol, constants.NetworkAny), rule.StateType,
+				),
+				Title: "Internet-Facing TCP Rule Without Rate Limiting",
+				Description: fmt.Sprintf(
+					"Rule %d passes TCP traffic from the internet without per-source connection limits "+
+						"or synproxy state, so a single source can exhaust the state table.",
+					i+1,
+				),
+				Recommendation: "Set max-src-conn and max-src-conn-rate on the rule, or use synproxy state " +
+					"for services that must accept arbitrary sources.",
+			})
+		}
+
+		switch strings.ToLower(rule.StateType) {
+		case stateTypeNone:
+			observations = append(observations, Observation{
+				Severity:     SeverityMedium,
+				Confidence:   ConfidenceHigh,
+				Reachability: reachability,
+				Component:    component,
+				Evidence:     fmt.Sprintf("rule %d: statetype=none", i+1),
+				Title:        "Stateless Pass Rule",
+				Description: fmt.Sprintf(
+					"Rule %d passes traffic without keeping state, so return traffic is not tracked "+
+						"and must be allowed by separate rules.",
+					i+1,
+				),
+				Recommendation: "Use keep state unless the rule deliberately handles asymmetric routing.",
+			})
+		case stateTypeSloppy:
+			if reachability != WANReachable {
+				continue
+			}
+
+			observations = append(observations, Observation{
+				Severity:     SeverityInfo,
+				Confidence:   ConfidenceHigh,
+				Reachability: reachability,
+				Component:    component,
+				Evidence:     fmt.Sprintf("rule %d: statetype=%q", i+1, rule.StateType),
+				Title:        "Sloppy State on WAN Rule",
+				Description: fmt.Sprintf(
+					"Rule %d uses sloppy state on a WAN interface, which skips TCP sequence number checks.",
+					i+1,
+				),
+				Recommendation: "Use keep state on internet-facing rules; reserve sloppy state for asymmetric paths.",
+			})
+		}
+	}
+
+	return observations
+}
+
+// isTCPRule reports whether rule matches TCP traffic, either explicitly (e.g.
+// "tcp" or "tcp/udp") or because its protocol is empty or "any".
+func isTCPRule(rule common.FirewallRule) bool {
+	if rule.Protocol == "" || strings.EqualFold(rule.Protocol, constants.NetworkAny) {
+		return true
+	}
+
+	return strings.Contains(strings.ToLower(rule.Protocol), "tcp")
+}
+
+// hasConnectionLimits reports whether rule bounds what a single source can
+// add to the state table.
+func hasConnectionLimits(rule common.FirewallRule) bool {
+	return rule.MaxSrcConn != "" || rule.MaxSrcConnRate != "" ||
+		strings.EqualFold(rule.StateType, stateTypeSynproxy)
+}
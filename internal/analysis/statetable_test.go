@@ -0,0 +1,178 @@
+package analysis_test
+
+import (
+	"testing"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/analysis"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+// stateTableTitles are the observation titles produced by the state table
+// exhaustion detector.
+var stateTableTitles = map[string]bool{
+	"Internet-Facing TCP Rule Without Rate Limiting": true,
+	"Stateless Pass Rule":                            true,
+	"Sloppy State on WAN Rule":                       true,
+}
+
+func TestDetectStateTableExhaustion(t *testing.T) {
+	t.Parallel()
+
+	wanHTTPS := func() common.FirewallRule {
+		return common.FirewallRule{
+			Type:        common.RuleTypePass,
+			Interfaces:  []string{"wan"},
+			Protocol:    "tcp",
+			Source:      common.RuleEndpoint{Address: "any"},
+			Destination: common.RuleEndpoint{Address: "192.168.1.10", Port: "443"},
+			StateType:   "keep state",
+		}
+	}
+
+	tests := []struct {
+		name         string
+		rule         func() common.FirewallRule
+		wantTitle    string
+		wantSeverity analysis.Severity
+	}{
+		{
+			name:         "WAN HTTPS pass without limits is Low",
+			rule:         wanHTTPS,
+			wantTitle:    "Internet-Facing TCP Rule Without Rate Limiting",
+			wantSeverity: analysis.SeverityLow,
+		},
+		{
+			name: "max-src-conn set stays silent",
+			rule: func() common.FirewallRule {
+				r := wanHTTPS()
+				r.MaxSrcConn = "100"
+				return r
+			},
+		},
+		{
+			name: "max-src-conn-rate set stays silent",
+			rule: func() common.FirewallRule {
+				r := wanHTTPS()
+				r.MaxSrcConnRate = "15/5"
+				return r
+			},
+		},
+		{
+			name: "synproxy state stays silent",
+			rule: func() common.FirewallRule {
+				r := wanHTTPS()
+				r.StateType = "synproxy state"
+				return r
+			},
+		},
+		{
+			name: "LAN TCP rule without limits stays silent",
+			rule: func() common.FirewallRule {
+				r := wanHTTPS()
+				r.Interfaces = []string{"lan"}
+				return r
+			},
+		},
+		{
+			name: "WAN any-protocol pass without limits is Low",
+			rule: func() common.FirewallRule {
+				r := wanHTTPS()
+				r.Protocol = "any"
+				r.Destination.Port = ""
+				return r
+			},
+			wantTitle:    "Internet-Facing TCP Rule Without Rate Limiting",
+			wantSeverity: analysis.SeverityLow,
+		},
+		{
+			name: "WAN pass with unset protocol without limits is Low",
+			rule: func() common.FirewallRule {
+				r := wanHTTPS()
+				r.Protocol = ""
+				r.Destination.Port = ""
+				return r
+			},
+			wantTitle:    "Internet-Facing TCP Rule Without Rate Limiting",
+			wantSeverity: analysis.SeverityLow,
+		},
+		{
+			name: "WAN UDP rule without limits stays silent",
+			rule: func() common.FirewallRule {
+				r := wanHTTPS()
+				r.Protocol = "udp"
+				return r
+			},
+		},
+		{
+			name: "disabled rule stays silent",
+			rule: func() common.FirewallRule {
+				r := wanHTTPS()
+				r.Disabled = true
+				return r
+			},
+		},
+		{
+			name: "stateless LAN rule is Medium",
+			rule: func() common.FirewallRule {
+				return common.FirewallRule{
+					Type:       common.RuleTypePass,
+					Interfaces: []string{"lan"},
+					Protocol:   "udp",
+					StateType:  "none",
+				}
+			},
+			wantTitle:    "Stateless Pass Rule",
+			wantSeverity: analysis.SeverityMedium,
+		},
+		{
+			name: "sloppy state on WAN rule is Info",
+			rule: func() common.FirewallRule {
+				r := wanHTTPS()
+				r.MaxSrcConn = "100"
+				r.StateType = "sloppy state"
+				return r
+			},
+			wantTitle:    "Sloppy State on WAN Rule",
+			wantSeverity: analysis.SeverityInfo,
+		},
+		{
+			name: "sloppy state on LAN rule stays silent",
+			rule: func() common.FirewallRule {
+				r := wanHTTPS()
+				r.Interfaces = []string{"lan"}
+				r.StateType = "sloppy state"
+				return r
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := &common.CommonDevice{
+				Interfaces:    []common.Interface{{Name: "wan", Enabled: true}, {Name: "lan", Enabled: true}},
+				FirewallRules: []common.FirewallRule{tt.rule()},
+			}
+
+			var got []analysis.Observation
+			for _, o := range analysis.ScanObservations(cfg) {
+				if stateTableTitles[o.Title] {
+					got = append(got, o)
+				}
+			}
+
+			if tt.wantTitle == "" {
+				assert.Empty(t, got)
+				return
+			}
+
+			if assert.Len(t, got, 1) {
+				assert.Equal(t, tt.wantTitle, got[0].Title)
+				assert.Equal(t, tt.wantSeverity, got[0].Severity)
+				assert.Equal(t, "filter.rule[0]", got[0].Component)
+			}
+		})
+	}
+}
@@ -96,7 +96,7 @@ func populateFirewallStats(stats *common.Statistics, cfg *common.CommonDevice) {
 
 func populateNATAndRoutingStats(stats *common.Statistics, cfg *common.CommonDevice) {
 	stats.NATMode = cfg.NAT.OutboundMode
-	stats.NATEntries = len(cfg.NAT.OutboundRules) + len(cfg.NAT.InboundRules)
+	stats.NATEntries = len(cfg.NAT.OutboundRules) + len(cfg.NAT.InboundRules) + len(cfg.NAT.OneToOneRules)
 	stats.TotalGateways = len(cfg.Routing.Gateways)
 	stats.TotalGatewayGroups = len(cfg.Routing.GatewayGroups)
 }
@@ -211,7 +211,7 @@ func populateSecurityFeatures(stats *common.Statistics, cfg *common.CommonDevice
 	if cfg.System.WebGUI.Protocol == constants.ProtocolHTTPS {
 		stats.SecurityFeatures = append(stats.SecurityFeatures, "HTTPS Web GUI")
 	}
-	if cfg.System.DisableNATReflection {
+	if cfg.NATSummary().ReflectionDisabled {
 		stats.SecurityFeatures = append(stats.SecurityFeatures, "NAT Reflection Disabled")
 	}
 }
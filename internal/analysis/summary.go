@@ -0,0 +1,124 @@
+package analysis
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+)
+
+// SummaryTopIssues is the number of finding titles named in an executive
+// summary.
+const SummaryTopIssues = 3
+
+// SummaryInput holds the figures rendered by [ExecutiveSummary].
+type SummaryInput struct {
+	// DeviceType names the platform; an invalid type reads "This device".
+	DeviceType common.DeviceType
+	// Rules and Interfaces size the configuration.
+	Rules, Interfaces int
+	// Critical, High, Medium and Low count findings per severity.
+	Critical, High, Medium, Low int
+	// TopIssues lists finding titles, most severe first. Only the first
+	// SummaryTopIssues are named.
+	TopIssues []string
+}
+
+// ExecutiveSummary renders a one-paragraph plain-English summary for
+// executive stakeholders: the size of the rule base, the finding counts per
+// severity, and the titles of the most severe findings.
+func ExecutiveSummary(in SummaryInput) string {
+	device := "This device"
+	if in.DeviceType.IsValid() {
+		device = "This " + in.DeviceType.DisplayName() + " device"
+	}
+
+	sentences := []string{
+		fmt.Sprintf("%s has %s across %s.", device,
+			pluralize(in.Rules, "firewall rule", "firewall rules"),
+			pluralize(in.Interfaces, "interface", "interfaces")),
+		fmt.Sprintf("Analysis found %d critical, %d high, %d medium, and %d low findings.",
+			in.Critical, in.High, in.Medium, in.Low),
+	}
+
+	titles := in.TopIssues[:min(len(in.TopIssues), SummaryTopIssues)]
+	switch len(titles) {
+	case 0:
+		sentences = append(sentences, "No issues requiring attention were identified.")
+	case 1:
+		sentences = append(sentences, "The most critical issue is: "+titles[0]+".")
+	default:
+		sentences = append(sentences, "The most critical issues are: "+strings.Join(titles, "; ")+".")
+	}
+
+	if in.Critical > 0 || in.High > 0 {
+		sentences = append(sentences, "Immediate attention is recommended for the Critical and High findings.")
+	}
+
+	return strings.Join(sentences, " ")
+}
+
+// SummarizeAnalysis builds a [SummaryInput] from cfg and its analysis
+// result a, typically from [ComputeAnalysis]. Security, performance and
+// consistency issues are counted by severity; informational findings and the
+// severity-less dead, unused and shadowed rule findings are not. When a is
+// nil only the severity-rated detectors run, skipping the costly dead and
+// shadowed rule passes. A nil cfg yields an empty input.
+func SummarizeAnalysis(cfg *common.CommonDevice, a *common.Analysis) SummaryInput {
+	if cfg == nil {
+		return SummaryInput{}
+	}
+
+	in := SummaryInput{
+		DeviceType: cfg.DeviceType,
+		Rules:      len(cfg.FirewallRules),
+		Interfaces: len(cfg.Interfaces),
+	}
+	if a == nil {
+		a = &common.Analysis{
+			SecurityIssues:    DetectSecurityIssues(cfg),
+			PerformanceIssues: DetectPerformanceIssues(cfg),
+			ConsistencyIssues: detectConsistencyIssues(cfg),
+		}
+	}
+
+	// Titles are bucketed by severity so the most severe come first while
+	// detection order is kept within a severity.
+	bySeverity := map[common.Severity][]string{}
+	add := func(severity common.Severity, title string) {
+		bySeverity[severity] = append(bySeverity[severity], title)
+	}
+
+	for _, f := range a.SecurityIssues {
+		add(f.Severity, f.Issue)
+	}
+	for _, f := range a.PerformanceIssues {
+		add(f.Severity, f.Issue)
+	}
+	for _, f := range a.ConsistencyIssues {
+		add(f.Severity, f.Issue)
+	}
+
+	in.Critical = len(bySeverity[common.SeverityCritical])
+	in.High = len(bySeverity[common.SeverityHigh])
+	in.Medium = len(bySeverity[common.SeverityMedium])
+	in.Low = len(bySeverity[common.SeverityLow])
+
+	for _, severity := range []common.Severity{
+		common.SeverityCritical, common.SeverityHigh, common.SeverityMedium, common.SeverityLow,
+	} {
+		in.TopIssues = append(in.TopIssues, bySeverity[severity]...)
+	}
+
+	return in
+}
+
+// pluralize formats n followed by the singular or plural noun.
+func pluralize(n int, singular, plural string) string {
+	if n == 1 {
+		return "1 " + singular
+	}
+
+	return strconv.Itoa(n) + " " + plural
+}
@@ -0,0 +1,54 @@
+package analysis_test
+
+import (
+	"testing"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/analysis"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSummarizeAnalysis(t *testing.T) {
+	t.Parallel()
+
+	cfg := &common.CommonDevice{
+		DeviceType:    common.DeviceTypePfSense,
+		Interfaces:    []common.Interface{{Name: "wan"}},
+		FirewallRules: make([]common.FirewallRule, 3),
+	}
+	result := &common.Analysis{
+		SecurityIssues: []common.SecurityFinding{
+			{Issue: "Low Issue", Severity: common.SeverityLow},
+			{Issue: "Critical Issue", Severity: common.SeverityCritical},
+		},
+		PerformanceIssues: []common.PerformanceFinding{
+			{Issue: "Info Note", Severity: common.SeverityInfo},
+		},
+		ConsistencyIssues: []common.ConsistencyFinding{
+			{Issue: "Medium Issue", Severity: common.SeverityMedium},
+			{Issue: "High Issue", Severity: common.SeverityHigh},
+		},
+		DeadRules: []common.DeadRuleFinding{{Description: "unreachable"}},
+	}
+
+	in := analysis.SummarizeAnalysis(cfg, result)
+
+	assert.Equal(t, analysis.SummaryInput{
+		DeviceType: common.DeviceTypePfSense,
+		Rules:      3,
+		Interfaces: 1,
+		Critical:   1,
+		High:       1,
+		Medium:     1,
+		Low:        1,
+		TopIssues:  []string{"Critical Issue", "High Issue", "Medium Issue", "Low Issue"},
+	}, in)
+	assert.Equal(t,
+		"This pfSense device has 3 firewall rules across 1 interface. "+
+			"Analysis found 1 critical, 1 high, 1 medium, and 1 low findings. "+
+			"The most critical issues are: Critical Issue; High Issue; Medium Issue. "+
+			"Immediate attention is recommended for the Critical and High findings.",
+		analysis.ExecutiveSummary(in))
+
+	assert.Equal(t, analysis.SummaryInput{}, analysis.SummarizeAnalysis(nil, result))
+}
@@ -0,0 +1,41 @@
+package analysis
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/tunables"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+)
+
+// detectUnrecognizedTunables flags tunables that are neither in the built-in
+// tunable catalog nor described in the configuration, which catches typos
+// such as net.inet.ip.fowarding that the kernel silently ignores. A tunable
+// the configuration documents is assumed intentional and is not reported.
+func detectUnrecognizedTunables(items []common.SysctlItem) []common.ConsistencyFinding {
+	var findings []common.ConsistencyFinding
+
+	for i, item := range items {
+		name := strings.TrimSpace(item.Tunable)
+		if name == "" || strings.TrimSpace(item.Description) != "" {
+			continue
+		}
+
+		if _, ok := tunables.Lookup(name); ok {
+			continue
+		}
+
+		findings = append(findings, common.ConsistencyFinding{
+			Component: fmt.Sprintf("sysctl[%d]", i),
+			Issue:     "Unrecognized Tunable",
+			Severity:  common.SeverityInfo,
+			Description: fmt.Sprintf(
+				"Tunable %s is not a known tunable and has no description; it may be misspelled",
+				name,
+			),
+			Recommendation: "Check the name against sysctl -a on the firewall, then fix it or add a description",
+		})
+	}
+
+	return findings
+}
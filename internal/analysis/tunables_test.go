@@ -0,0 +1,34 @@
+package analysis_test
+
+import (
+	"testing"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/analysis"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectConsistency_UnrecognizedTunable(t *testing.T) {
+	t.Parallel()
+
+	device := &common.CommonDevice{
+		Sysctl: []common.SysctlItem{
+			{Tunable: "net.inet.ip.fowarding", Value: "1"},
+			{Tunable: "net.inet.tcp.syncookies", Value: "1"},
+			{Tunable: "dev.custom.knob", Value: "1", Description: "Vendor driver setting"},
+		},
+	}
+
+	var unrecognized []common.ConsistencyFinding
+	for _, f := range analysis.DetectConsistency(device) {
+		if f.Issue == "Unrecognized Tunable" {
+			unrecognized = append(unrecognized, f)
+		}
+	}
+
+	require.Len(t, unrecognized, 1)
+	assert.Equal(t, "sysctl[0]", unrecognized[0].Component)
+	assert.Equal(t, common.SeverityInfo, unrecognized[0].Severity)
+	assert.Contains(t, unrecognized[0].Description, "net.inet.ip.fowarding")
+}
@@ -0,0 +1,118 @@
+package analysis
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+)
+
+// Tunnel interface types recorded in [TunnelInterface.Type].
+const (
+	TunnelTypeGIF = "GIF"
+	TunnelTypeGRE = "GRE"
+)
+
+// TunnelInterface is a GIF or GRE tunnel normalized to the fields both
+// tunnel types share.
+type TunnelInterface struct {
+	// Type is one of the TunnelType* constants.
+	Type string `json:"type"`
+	// Interface is the tunnel interface name, e.g. "gif0".
+	Interface string `json:"interface,omitempty"`
+	// Parent is the interface that carries the encapsulated traffic, e.g. "wan".
+	Parent string `json:"parent,omitempty"`
+	// Remote is the outer address of the far tunnel endpoint.
+	Remote string `json:"remote,omitempty"`
+	// LocalAddress is the inner address of this end of the tunnel.
+	LocalAddress string `json:"localAddress,omitempty"`
+	// RemoteAddress is the inner address of the far end of the tunnel.
+	RemoteAddress string `json:"remoteAddress,omitempty"`
+	// Description is the tunnel description.
+	Description string `json:"description,omitempty"`
+}
+
+// TunnelInterfaces returns cfg's GIF tunnels followed by its GRE tunnels, in
+// configuration order. Empty placeholder entries, such as a bare <gif/>
+// element, are skipped. A nil cfg returns nil.
+func TunnelInterfaces(cfg *common.CommonDevice) []TunnelInterface {
+	if cfg == nil {
+		return nil
+	}
+
+	var tunnels []TunnelInterface
+	add := func(tunnel TunnelInterface) {
+		if tunnel != (TunnelInterface{Type: tunnel.Type}) {
+			tunnels = append(tunnels, tunnel)
+		}
+	}
+
+	for _, gif := range cfg.GIFs {
+		add(TunnelInterface{
+			Type:          TunnelTypeGIF,
+			Interface:     gif.Interface,
+			Parent:        gif.Local,
+			Remote:        gif.Remote,
+			LocalAddress:  gif.TunnelLocalAddress,
+			RemoteAddress: gif.TunnelRemoteAddress,
+			Description:   gif.Description,
+		})
+	}
+	for _, gre := range cfg.GREs {
+		add(TunnelInterface{
+			Type:          TunnelTypeGRE,
+			Interface:     gre.Interface,
+			Parent:        gre.Local,
+			Remote:        gre.Remote,
+			LocalAddress:  gre.TunnelLocalAddress,
+			RemoteAddress: gre.TunnelRemoteAddress,
+			Description:   gre.Description,
+		})
+	}
+
+	return tunnels
+}
+
+// DetectTunnelIssues reports, at Info severity, every tunnel whose remote
+// endpoint is empty or an unspecified address such as 0.0.0.0, since such a
+// tunnel cannot reach a peer. Returns nil when no issues are found.
+func DetectTunnelIssues(cfg *common.CommonDevice) []common.ConsistencyFinding {
+	var findings []common.ConsistencyFinding
+
+	for _, tunnel := range TunnelInterfaces(cfg) {
+		if !unreachableEndpoint(tunnel.Remote) {
+			continue
+		}
+
+		remote := tunnel.Remote
+		if remote == "" {
+			remote = "no address"
+		}
+
+		findings = append(findings, common.ConsistencyFinding{
+			Component: strings.ToLower(tunnel.Type) + "." + tunnel.Interface,
+			Issue:     "Tunnel Without Remote Endpoint",
+			Severity:  common.SeverityInfo,
+			Description: fmt.Sprintf(
+				"%s tunnel %s on interface %s has %s as its remote endpoint and cannot reach a peer",
+				tunnel.Type, tunnel.Interface, tunnel.Parent, remote,
+			),
+			Recommendation: "Set the remote endpoint address, or remove the tunnel if it is no longer needed",
+		})
+	}
+
+	return findings
+}
+
+// unreachableEndpoint reports whether addr is empty or an unspecified address.
+func unreachableEndpoint(addr string) bool {
+	addr = strings.TrimSpace(addr)
+	if addr == "" {
+		return true
+	}
+
+	ip, err := netip.ParseAddr(addr)
+
+	return err == nil && ip.IsUnspecified()
+}
@@ -0,0 +1,91 @@
+package analysis_test
+
+import (
+	"testing"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/analysis"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTunnelInterfaces(t *testing.T) {
+	t.Parallel()
+
+	cfg := &common.CommonDevice{
+		GIFs: []common.GIF{{
+			Interface:           "gif0",
+			Local:               "wan",
+			Remote:              "209.51.181.2",
+			TunnelLocalAddress:  "2001:db8:1::2",
+			TunnelRemoteAddress: "2001:db8:1::1",
+			Description:         "HE IPv6 Tunnel",
+		}},
+		GREs: []common.GRE{{}, {Interface: "gre0", Local: "opt1", Remote: "198.51.100.1"}},
+	}
+
+	assert.Equal(t, []analysis.TunnelInterface{
+		{
+			Type:          analysis.TunnelTypeGIF,
+			Interface:     "gif0",
+			Parent:        "wan",
+			Remote:        "209.51.181.2",
+			LocalAddress:  "2001:db8:1::2",
+			RemoteAddress: "2001:db8:1::1",
+			Description:   "HE IPv6 Tunnel",
+		},
+		{Type: analysis.TunnelTypeGRE, Interface: "gre0", Parent: "opt1", Remote: "198.51.100.1"},
+	}, analysis.TunnelInterfaces(cfg))
+
+	assert.Nil(t, analysis.TunnelInterfaces(nil))
+	assert.Nil(t, analysis.TunnelInterfaces(&common.CommonDevice{}))
+}
+
+func TestDetectTunnelIssues(t *testing.T) {
+	t.Parallel()
+
+	cfg := &common.CommonDevice{
+		GIFs: []common.GIF{
+			{Interface: "gif0", Local: "wan", Remote: "209.51.181.2"},
+			{Interface: "gif1", Local: "wan", Remote: "0.0.0.0"},
+		},
+		GREs: []common.GRE{
+			{Interface: "gre0", Local: "opt1"},
+			{Interface: "gre1", Local: "opt1", Remote: "::"},
+			{Interface: "gre2", Local: "opt1", Remote: "vpn.example.com"},
+		},
+	}
+
+	findings := analysis.DetectTunnelIssues(cfg)
+	require.Len(t, findings, 3)
+
+	components := make([]string, 0, len(findings))
+	for _, f := range findings {
+		components = append(components, f.Component)
+		assert.Equal(t, common.SeverityInfo, f.Severity)
+		assert.Equal(t, "Tunnel Without Remote Endpoint", f.Issue)
+	}
+	assert.Equal(t, []string{"gif.gif1", "gre.gre0", "gre.gre1"}, components)
+	assert.Contains(t, findings[0].Description, "GIF tunnel gif1 on interface wan has 0.0.0.0")
+	assert.Contains(t, findings[1].Description, "has no address as its remote endpoint")
+
+	assert.Nil(t, analysis.DetectTunnelIssues(nil))
+}
+
+func TestDetectUnusedInterfaces_TunnelParents(t *testing.T) {
+	t.Parallel()
+
+	cfg := &common.CommonDevice{
+		Interfaces: []common.Interface{
+			{Name: "wan", Enabled: true},
+			{Name: "opt1", Enabled: true},
+		},
+		GIFs: []common.GIF{{Interface: "gif0", Local: "wan", Remote: "209.51.181.2"}},
+	}
+
+	assert.Equal(t, []string{"GIF tunnel gif0"}, analysis.BuildInterfaceIndex(cfg)["wan"].Services)
+
+	unused := analysis.DetectUnusedInterfaces(cfg)
+	require.Len(t, unused, 1)
+	assert.Equal(t, "opt1", unused[0].InterfaceName)
+}
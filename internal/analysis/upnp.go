@@ -0,0 +1,123 @@
+package analysis
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+)
+
+// maxPort is the highest TCP/UDP port number.
+const maxPort = 65535
+
+// detectUPnPIssues checks the UPnP IGD / NAT-PMP service. Any client on its
+// listening interfaces can open port forwards on the WAN without an
+// administrator, so a running service is always reported, at the highest
+// severity that applies:
+//
+//   - without default-deny, requests no ACL entry matches are accepted, so
+//     every internal host may map every port: High;
+//   - with default-deny, an allow entry covering a whole subnet and the full
+//     external and internal port ranges leaves the ACL nearly as open:
+//     Medium;
+//   - otherwise the restricted service is reported as Info.
+func detectUPnPIssues(upnp *common.UPnPConfig) []common.SecurityFinding {
+	if upnp == nil || !upnp.Enabled {
+		return nil
+	}
+
+	const (
+		component      = "upnp"
+		recommendation = "Disable UPnP and NAT-PMP unless required; otherwise enable default deny and " +
+			"allow only the hosts and ports that need automatic port mappings"
+	)
+
+	if !upnp.DefaultDeny {
+		return []common.SecurityFinding{{
+			Component: component,
+			Issue:     "UPnP Enabled Without Default Deny",
+			Severity:  common.SeverityHigh,
+			Description: fmt.Sprintf(
+				"UPnP / NAT-PMP is enabled on %s without default deny, so any client can open "+
+					"port forwards on %s for any internal host and port that no rule denies",
+				upnpInterfaces(upnp), upnpExternalInterface(upnp),
+			),
+			Recommendation: recommendation,
+		}}
+	}
+
+	var broad []string
+	for _, rule := range upnp.Rules {
+		if isBroadUPnPRule(rule) {
+			broad = append(broad, fmt.Sprintf("%s %s %s %s",
+				rule.Action, rule.ExternalPorts, rule.InternalAddress, rule.InternalPorts))
+		}
+	}
+
+	if len(broad) > 0 {
+		return []common.SecurityFinding{{
+			Component: component,
+			Issue:     "Broad UPnP Permission Rules",
+			Severity:  common.SeverityMedium,
+			Description: fmt.Sprintf(
+				"UPnP / NAT-PMP is enabled with default deny, but %d rule(s) let a whole subnet map any port: %s",
+				len(broad), strings.Join(broad, "; "),
+			),
+			Recommendation: recommendation,
+		}}
+	}
+
+	return []common.SecurityFinding{{
+		Component: component,
+		Issue:     "UPnP Enabled",
+		Severity:  common.SeverityInfo,
+		Description: fmt.Sprintf(
+			"UPnP / NAT-PMP is enabled on %s with default deny; clients allowed by its rules can open port forwards on %s",
+			upnpInterfaces(upnp), upnpExternalInterface(upnp),
+		),
+		Recommendation: "Review the UPnP permission rules periodically",
+	}}
+}
+
+// isBroadUPnPRule reports whether rule allows a network larger than a single
+// host to map the full external and internal port ranges.
+func isBroadUPnPRule(rule common.UPnPPermissionRule) bool {
+	if rule.Action != "allow" {
+		return false
+	}
+
+	prefix, err := netip.ParsePrefix(strings.TrimSpace(rule.InternalAddress))
+	if err != nil || prefix.IsSingleIP() {
+		return false
+	}
+
+	return isFullPortRange(rule.ExternalPorts) && isFullPortRange(rule.InternalPorts)
+}
+
+// isFullPortRange reports whether ports, a single port or a "low-high"
+// range, covers every port from 1 to 65535.
+func isFullPortRange(ports string) bool {
+	r, ok := parsePortPart(strings.TrimSpace(ports))
+
+	return ok && r.lo <= 1 && r.hi >= maxPort
+}
+
+// upnpInterfaces describes the listening interfaces of upnp for a finding.
+func upnpInterfaces(upnp *common.UPnPConfig) string {
+	if len(upnp.Interfaces) == 0 {
+		return "no configured interface"
+	}
+
+	return strings.Join(upnp.Interfaces, ", ")
+}
+
+// upnpExternalInterface describes the external interface of upnp for a
+// finding.
+func upnpExternalInterface(upnp *common.UPnPConfig) string {
+	if upnp.ExternalInterface == "" {
+		return "the external interface"
+	}
+
+	return upnp.ExternalInterface
+}
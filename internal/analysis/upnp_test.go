@@ -0,0 +1,90 @@
+package analysis_test
+
+import (
+	"testing"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/analysis"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+// upnpFindings returns the findings DetectSecurityIssues reports for upnp, as
+// "severity: issue" strings.
+func upnpFindings(upnp *common.UPnPConfig) []string {
+	var got []string
+	for _, f := range analysis.DetectSecurityIssues(&common.CommonDevice{UPnP: upnp}) {
+		if f.Component == "upnp" {
+			got = append(got, string(f.Severity)+": "+f.Issue)
+		}
+	}
+	return got
+}
+
+//nolint:funlen // test table or data declaration; length is in data not logic
+func TestDetectSecurityIssues_UPnP(t *testing.T) {
+	t.Parallel()
+
+	rule := func(action, extPorts, addr, intPorts string) common.UPnPPermissionRule {
+		return common.UPnPPermissionRule{
+			Action:          action,
+			ExternalPorts:   extPorts,
+			InternalAddress: addr,
+			InternalPorts:   intPorts,
+		}
+	}
+	enabled := func(defaultDeny bool, rules ...common.UPnPPermissionRule) *common.UPnPConfig {
+		return &common.UPnPConfig{
+			Enabled:           true,
+			UPnPEnabled:       true,
+			ExternalInterface: "wan",
+			Interfaces:        []string{"lan"},
+			DefaultDeny:       defaultDeny,
+			Rules:             rules,
+		}
+	}
+
+	tests := []struct {
+		name string
+		upnp *common.UPnPConfig
+		want []string
+	}{
+		{
+			name: "not configured",
+		},
+		{
+			name: "configured but disabled",
+			upnp: &common.UPnPConfig{UPnPEnabled: true},
+		},
+		{
+			name: "enabled without default deny",
+			upnp: enabled(false, rule("allow", "1024-65535", "192.168.1.20", "1024-65535")),
+			want: []string{"high: UPnP Enabled Without Default Deny"},
+		},
+		{
+			name: "default deny with a whole subnet on full port ranges",
+			upnp: enabled(true,
+				rule("allow", "1024-65535", "192.168.1.20/32", "1024-65535"),
+				rule("allow", "0-65535", "192.168.1.0/24", "1-65535"),
+			),
+			want: []string{"medium: Broad UPnP Permission Rules"},
+		},
+		{
+			name: "default deny with restricted rules",
+			upnp: enabled(true,
+				rule("allow", "0-65535", "192.168.1.20/32", "0-65535"),
+				rule("allow", "1024-65535", "192.168.1.0/24", "1024-65535"),
+				rule("allow", "0-65535", "192.168.1.0/24", "3074"),
+				rule("deny", "0-65535", "0.0.0.0/0", "0-65535"),
+			),
+			want: []string{"info: UPnP Enabled"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, upnpFindings(tt.upnp))
+		})
+	}
+}
@@ -0,0 +1,339 @@
+package analysis
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+)
+
+// VPN binding kinds recorded in [VPNBinding.Kind].
+const (
+	VPNKindOpenVPNServer = "openvpn-server"
+	VPNKindOpenVPNClient = "openvpn-client"
+	VPNKindIPsec         = "ipsec"
+	VPNKindWireGuard     = "wireguard"
+	VPNKindPPTP          = "pptp"
+	VPNKindL2TP          = "l2tp"
+)
+
+// dnsResolverService is the Services entry for the DNS resolver, which is
+// assumed to bind to "lan".
+const dnsResolverService = "DNS resolver"
+
+// VPNBinding identifies a VPN instance bound to an interface.
+type VPNBinding struct {
+	// Kind is one of the VPNKind* constants.
+	Kind string `json:"kind"`
+	// Name is the instance description, falling back to its identifier.
+	Name string `json:"name,omitempty"`
+}
+
+// InterfaceReferences lists everything in a device configuration that refers
+// to one interface. Rule positions are 1-based indexes into the matching
+// CommonDevice slice, as shown in the report tables.
+type InterfaceReferences struct {
+	// FirewallRules are the positions of firewall rules attached to the
+	// interface, including disabled and floating rules.
+	FirewallRules []int `json:"firewallRules,omitempty"`
+	// OutboundNAT are the positions of outbound NAT rules on the interface.
+	OutboundNAT []int `json:"outboundNat,omitempty"`
+	// InboundNAT are the positions of inbound NAT (port forward) rules on the interface.
+	InboundNAT []int `json:"inboundNat,omitempty"`
+	// OneToOneNAT are the positions of 1:1 (binat) NAT rules on the interface.
+	OneToOneNAT []int `json:"oneToOneNat,omitempty"`
+	// DHCPScope is true when a DHCP scope is configured for the interface.
+	DHCPScope bool `json:"dhcpScope,omitempty"`
+	// DHCPEnabled is true when a configured DHCP scope is enabled.
+	DHCPEnabled bool `json:"dhcpEnabled,omitempty"`
+	// VPN lists the VPN instances bound to the interface.
+	VPN []VPNBinding `json:"vpn,omitempty"`
+	// Gateways lists the names of gateways reachable through the interface.
+	Gateways []string `json:"gateways,omitempty"`
+	// Services lists other services that listen on the interface, such as the
+	// DNS resolver, load balancer virtual servers, or GIF/GRE tunnels.
+	Services []string `json:"services,omitempty"`
+}
+
+// InUse reports whether anything puts the interface to use: a firewall rule,
+// an enabled DHCP scope, a VPN instance, or a service. NAT rules and gateways
+// alone do not count, since they do not admit traffic on their own.
+func (r InterfaceReferences) InUse() bool {
+	return len(r.FirewallRules) > 0 || r.DHCPEnabled || len(r.VPN) > 0 || len(r.Services) > 0
+}
+
+// InterfaceIndex maps interface names to the configuration items that
+// reference them.
+type InterfaceIndex map[string]InterfaceReferences
+
+// BuildInterfaceIndex cross-references cfg by interface. Every configured
+// interface is present, even with no references; names referenced without a
+// matching interface are present too. Disabled IPsec tunnels and legacy VPN
+// servers are skipped. The DNS resolver and WireGuard are assumed to bind to
+// "lan" when enabled, since the model does not record their interfaces. A nil
+// cfg returns an empty index.
+func BuildInterfaceIndex(cfg *common.CommonDevice) InterfaceIndex {
+	idx := make(InterfaceIndex)
+	if cfg == nil {
+		return idx
+	}
+
+	for _, iface := range cfg.Interfaces {
+		idx[iface.Name] = InterfaceReferences{}
+	}
+
+	for i, rule := range cfg.FirewallRules {
+		for _, iface := range rule.Interfaces {
+			idx.update(iface, func(r *InterfaceReferences) { r.FirewallRules = append(r.FirewallRules, i+1) })
+		}
+	}
+	for i, rule := range cfg.NAT.OutboundRules {
+		for _, iface := range rule.Interfaces {
+			idx.update(iface, func(r *InterfaceReferences) { r.OutboundNAT = append(r.OutboundNAT, i+1) })
+		}
+	}
+	for i, rule := range cfg.NAT.InboundRules {
+		for _, iface := range rule.Interfaces {
+			idx.update(iface, func(r *InterfaceReferences) { r.InboundNAT = append(r.InboundNAT, i+1) })
+		}
+	}
+	for i, rule := range cfg.NAT.OneToOneRules {
+		for _, iface := range rule.Interfaces {
+			idx.update(iface, func(r *InterfaceReferences) { r.OneToOneNAT = append(r.OneToOneNAT, i+1) })
+		}
+	}
+
+	for _, scope := range cfg.DHCP {
+		idx.update(scope.Interface, func(r *InterfaceReferences) {
+			r.DHCPScope = true
+			r.DHCPEnabled = r.DHCPEnabled || scope.Enabled
+		})
+	}
+
+	indexVPNBindings(cfg, idx)
+
+	for _, gw := range cfg.Routing.Gateways {
+		idx.update(gw.Interface, func(r *InterfaceReferences) {
+			r.Gateways = append(r.Gateways, cmp.Or(gw.Name, gw.Address))
+		})
+	}
+
+	if cfg.DNS.Unbound.Enabled || cfg.DNS.DNSMasq.Enabled {
+		idx.update("lan", func(r *InterfaceReferences) { r.Services = append(r.Services, dnsResolverService) })
+	}
+
+	for _, tunnel := range TunnelInterfaces(cfg) {
+		idx.update(tunnel.Parent, func(r *InterfaceReferences) {
+			r.Services = append(r.Services, tunnel.Type+" tunnel "+tunnel.Interface)
+		})
+	}
+
+	used := make(map[string]bool)
+	markLoadBalancerInterfaces(cfg, used)
+	for _, iface := range slices.Sorted(maps.Keys(used)) {
+		idx.update(iface, func(r *InterfaceReferences) { r.Services = append(r.Services, "load balancer") })
+	}
+
+	return idx
+}
+
+// indexVPNBindings adds every VPN instance with a known interface to idx.
+func indexVPNBindings(cfg *common.CommonDevice, idx InterfaceIndex) {
+	bind := func(iface, kind, name string) {
+		idx.update(iface, func(r *InterfaceReferences) {
+			r.VPN = append(r.VPN, VPNBinding{Kind: kind, Name: name})
+		})
+	}
+
+	for _, srv := range cfg.VPN.OpenVPN.Servers {
+		bind(srv.Interface, VPNKindOpenVPNServer, cmp.Or(srv.Description, srv.VPNID))
+	}
+	for _, cli := range cfg.VPN.OpenVPN.Clients {
+		bind(cli.Interface, VPNKindOpenVPNClient, cmp.Or(cli.Description, cli.VPNID))
+	}
+	for _, p1 := range cfg.VPN.IPsec.Phase1Tunnels {
+		if !p1.Disabled {
+			bind(p1.Interface, VPNKindIPsec, cmp.Or(p1.Description, p1.IKEID))
+		}
+	}
+	if cfg.VPN.WireGuard.Enabled {
+		bind("lan", VPNKindWireGuard, "")
+	}
+	if vpn := cfg.VPN.PPTP; vpn != nil && vpn.Enabled {
+		bind(vpn.Interface, VPNKindPPTP, "")
+	}
+	if vpn := cfg.VPN.L2TP; vpn != nil && vpn.Enabled {
+		bind(vpn.Interface, VPNKindL2TP, "")
+	}
+}
+
+// update applies fn to the references for iface. Empty names are ignored.
+func (idx InterfaceIndex) update(iface string, fn func(*InterfaceReferences)) {
+	if iface == "" {
+		return
+	}
+
+	refs := idx[iface]
+	fn(&refs)
+	idx[iface] = refs
+}
+
+// UnusedInterfaces returns a finding for every enabled interface in ifaces
+// whose references are not [InterfaceReferences.InUse]. Returns nil when all
+// enabled interfaces are in use.
+func (idx InterfaceIndex) UnusedInterfaces(ifaces []common.Interface) []common.UnusedInterfaceFinding {
+	var findings []common.UnusedInterfaceFinding
+	for _, iface := range ifaces {
+		if iface.Enabled && !idx[iface.Name].InUse() {
+			findings = append(findings, common.UnusedInterfaceFinding{
+				InterfaceName: iface.Name,
+				Description: fmt.Sprintf(
+					"Interface %s is enabled but not used in any rules or services",
+					strings.ToUpper(iface.Name),
+				),
+				Recommendation: "Consider disabling unused interface or add appropriate rules",
+			})
+		}
+	}
+
+	return findings
+}
+
+// builtinInterfaceNames are interface and group names that pf or the
+// platform provides without an <interfaces> entry: loopback, the IPsec
+// enc0 device, the VPN interface groups, and the "any"/"localhost" binding
+// choices of VPN servers.
+var builtinInterfaceNames = map[string]bool{ //nolint:gochecknoglobals // Read-only lookup table
+	"any":       true,
+	"localhost": true,
+	"lo0":       true,
+	"enc0":      true,
+	"ipsec":     true,
+	"openvpn":   true,
+	"wireguard": true,
+	"l2tp":      true,
+	"pppoe":     true,
+}
+
+// vipInterfacePrefix marks a binding to a virtual IP ("_vip5f1e..."), which
+// pfSense accepts wherever an interface is expected.
+const vipInterfacePrefix = "_vip"
+
+// DanglingInterfaceReference is one configuration item that refers to an
+// interface name with no matching interface or interface group.
+type DanglingInterfaceReference struct {
+	// Interface is the missing name, as referenced.
+	Interface string `json:"interface"`
+	// Referrer describes the referring item, e.g. firewall rule 3 or
+	// OpenVPN server "Remote Access".
+	Referrer string `json:"referrer"`
+}
+
+// DanglingReferences returns the references in idx, and the interface group
+// members in groups, to interface names that ifaces and groups do not define,
+// sorted by name. Names are compared case-insensitively; an interface group
+// name counts as defined, as do the names in builtinInterfaceNames and
+// virtual IP bindings. The DNS resolver and WireGuard bindings to "lan" are
+// assumed by [BuildInterfaceIndex] rather than configured, so they are never
+// reported.
+func (idx InterfaceIndex) DanglingReferences(
+	ifaces []common.Interface,
+	groups []common.InterfaceGroup,
+) []DanglingInterfaceReference {
+	defined := make(map[string]bool, len(ifaces)+len(groups))
+	for _, iface := range ifaces {
+		defined[strings.ToLower(iface.Name)] = true
+	}
+	for _, group := range groups {
+		defined[strings.ToLower(group.Name)] = true
+	}
+
+	isDefined := func(name string) bool {
+		lower := strings.ToLower(name)
+		return defined[lower] || builtinInterfaceNames[lower] || strings.HasPrefix(lower, vipInterfacePrefix)
+	}
+
+	var dangling []DanglingInterfaceReference
+
+	for _, name := range slices.Sorted(maps.Keys(idx)) {
+		if isDefined(name) {
+			continue
+		}
+
+		for _, referrer := range idx[name].referrers() {
+			dangling = append(dangling, DanglingInterfaceReference{Interface: name, Referrer: referrer})
+		}
+	}
+
+	for _, group := range groups {
+		for _, member := range group.Members {
+			if member != "" && !isDefined(member) {
+				dangling = append(dangling, DanglingInterfaceReference{
+					Interface: member,
+					Referrer:  "interface group " + group.Name,
+				})
+			}
+		}
+	}
+
+	slices.SortStableFunc(dangling, func(a, b DanglingInterfaceReference) int {
+		return cmp.Compare(a.Interface, b.Interface)
+	})
+
+	return dangling
+}
+
+// vpnKindLabels names each VPN binding kind for DanglingReferences.
+var vpnKindLabels = map[string]string{ //nolint:gochecknoglobals // Read-only lookup table
+	VPNKindOpenVPNServer: "OpenVPN server",
+	VPNKindOpenVPNClient: "OpenVPN client",
+	VPNKindIPsec:         "IPsec tunnel",
+	VPNKindPPTP:          "PPTP server",
+	VPNKindL2TP:          "L2TP server",
+}
+
+// referrers describes every configured item in r, skipping the assumed DNS
+// resolver and WireGuard bindings.
+func (r InterfaceReferences) referrers() []string {
+	var out []string
+
+	for _, pos := range r.FirewallRules {
+		out = append(out, fmt.Sprintf("firewall rule %d", pos))
+	}
+	for _, pos := range r.OutboundNAT {
+		out = append(out, fmt.Sprintf("outbound NAT rule %d", pos))
+	}
+	for _, pos := range r.InboundNAT {
+		out = append(out, fmt.Sprintf("inbound NAT rule %d", pos))
+	}
+	for _, pos := range r.OneToOneNAT {
+		out = append(out, fmt.Sprintf("1:1 NAT rule %d", pos))
+	}
+	if r.DHCPScope {
+		out = append(out, "DHCP scope")
+	}
+	for _, vpn := range r.VPN {
+		label, ok := vpnKindLabels[vpn.Kind]
+		if !ok {
+			continue
+		}
+		if vpn.Name == "" {
+			out = append(out, label)
+			continue
+		}
+		out = append(out, fmt.Sprintf("%s %q", label, vpn.Name))
+	}
+	for _, gw := range r.Gateways {
+		out = append(out, "gateway "+gw)
+	}
+	for _, service := range r.Services {
+		if service != dnsResolverService {
+			out = append(out, service)
+		}
+	}
+
+	return out
+}
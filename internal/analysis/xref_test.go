@@ -0,0 +1,228 @@
+package analysis_test
+
+import (
+	"testing"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/analysis"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildInterfaceIndex(t *testing.T) {
+	t.Parallel()
+
+	cfg := &common.CommonDevice{
+		Interfaces: []common.Interface{
+			{Name: "wan", Enabled: true},
+			{Name: "lan", Enabled: true},
+			{Name: "opt1", Enabled: true},
+			{Name: "opt2", Enabled: true},
+		},
+		FirewallRules: []common.FirewallRule{
+			{Type: common.RuleTypePass, Interfaces: []string{"lan"}},
+			{Type: common.RuleTypeBlock, Interfaces: []string{"wan"}},
+			{Type: common.RuleTypePass, Interfaces: []string{"wan", "lan"}, Floating: true},
+			{Type: common.RuleTypePass, Interfaces: []string{"opt9"}},
+		},
+		NAT: common.NATConfig{
+			OutboundRules: []common.NATRule{{Interfaces: []string{"wan"}}},
+			InboundRules: []common.InboundNATRule{
+				{Interfaces: []string{"opt2"}},
+				{Interfaces: []string{"wan"}},
+			},
+		},
+		DHCP: []common.DHCPScope{
+			{Interface: "lan", Enabled: true},
+			{Interface: "opt1"},
+		},
+		VPN: common.VPN{
+			OpenVPN: common.OpenVPNConfig{
+				Servers: []common.OpenVPNServer{{Interface: "wan", VPNID: "1"}},
+				Clients: []common.OpenVPNClient{{Interface: "opt1", Description: "Site B"}},
+			},
+			IPsec: common.IPsecConfig{Phase1Tunnels: []common.IPsecPhase1Tunnel{
+				{Interface: "wan", Description: "HQ"},
+				{Interface: "opt2", Description: "Old tunnel", Disabled: true},
+			}},
+			L2TP: &common.LegacyRemoteAccessVPN{Interface: "opt2"},
+		},
+		Routing: common.Routing{Gateways: []common.Gateway{
+			{Interface: "wan", Name: "WAN_GW"},
+			{Interface: "wan", Address: "203.0.113.1"},
+		}},
+	}
+
+	idx := analysis.BuildInterfaceIndex(cfg)
+
+	assert.Equal(t, analysis.InterfaceReferences{
+		FirewallRules: []int{2, 3},
+		OutboundNAT:   []int{1},
+		InboundNAT:    []int{2},
+		VPN: []analysis.VPNBinding{
+			{Kind: analysis.VPNKindOpenVPNServer, Name: "1"},
+			{Kind: analysis.VPNKindIPsec, Name: "HQ"},
+		},
+		Gateways: []string{"WAN_GW", "203.0.113.1"},
+	}, idx["wan"])
+
+	assert.Equal(t, analysis.InterfaceReferences{
+		FirewallRules: []int{1, 3},
+		DHCPScope:     true,
+		DHCPEnabled:   true,
+	}, idx["lan"])
+
+	assert.Equal(t, analysis.InterfaceReferences{
+		DHCPScope: true,
+		VPN:       []analysis.VPNBinding{{Kind: analysis.VPNKindOpenVPNClient, Name: "Site B"}},
+	}, idx["opt1"])
+
+	assert.Equal(t, analysis.InterfaceReferences{InboundNAT: []int{1}}, idx["opt2"],
+		"disabled IPsec tunnels and L2TP servers are not bindings")
+
+	assert.Equal(t, []int{4}, idx["opt9"].FirewallRules, "unconfigured interfaces referenced by rules are indexed")
+
+	assert.True(t, idx["wan"].InUse())
+	assert.True(t, idx["opt1"].InUse(), "a VPN binding puts an interface to use")
+	assert.False(t, idx["opt2"].InUse(), "NAT rules alone do not put an interface to use")
+
+	unused := idx.UnusedInterfaces(cfg.Interfaces)
+	require.Len(t, unused, 1)
+	assert.Equal(t, "opt2", unused[0].InterfaceName)
+}
+
+func TestBuildInterfaceIndex_AssumedLANServices(t *testing.T) {
+	t.Parallel()
+
+	cfg := &common.CommonDevice{
+		Interfaces: []common.Interface{{Name: "lan", Enabled: true}},
+		DNS:        common.DNSConfig{Unbound: common.UnboundConfig{Enabled: true}},
+		VPN:        common.VPN{WireGuard: common.WireGuardConfig{Enabled: true}},
+	}
+
+	refs := analysis.BuildInterfaceIndex(cfg)["lan"]
+	assert.Equal(t, []string{"DNS resolver"}, refs.Services)
+	assert.Equal(t, []analysis.VPNBinding{{Kind: analysis.VPNKindWireGuard}}, refs.VPN)
+	assert.True(t, refs.InUse())
+}
+
+func TestBuildInterfaceIndex_Nil(t *testing.T) {
+	t.Parallel()
+
+	assert.Empty(t, analysis.BuildInterfaceIndex(nil))
+}
+
+func TestDetectUnusedInterfaces_VPNBindings(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		vpn        common.VPN
+		wantUnused bool
+	}{
+		{
+			name: "enabled IPsec tunnel",
+			vpn: common.VPN{IPsec: common.IPsecConfig{Phase1Tunnels: []common.IPsecPhase1Tunnel{
+				{Interface: "opt1", Description: "HQ"},
+			}}},
+		},
+		{
+			name: "disabled IPsec tunnel",
+			vpn: common.VPN{IPsec: common.IPsecConfig{Phase1Tunnels: []common.IPsecPhase1Tunnel{
+				{Interface: "opt1", Description: "HQ", Disabled: true},
+			}}},
+			wantUnused: true,
+		},
+		{
+			name: "enabled PPTP server",
+			vpn:  common.VPN{PPTP: &common.LegacyRemoteAccessVPN{Enabled: true, Interface: "opt1"}},
+		},
+		{
+			name:       "disabled PPTP server",
+			vpn:        common.VPN{PPTP: &common.LegacyRemoteAccessVPN{Interface: "opt1"}},
+			wantUnused: true,
+		},
+		{
+			name: "enabled L2TP server",
+			vpn:  common.VPN{L2TP: &common.LegacyRemoteAccessVPN{Enabled: true, Interface: "opt1"}},
+		},
+		{
+			name:       "disabled L2TP server",
+			vpn:        common.VPN{L2TP: &common.LegacyRemoteAccessVPN{Interface: "opt1"}},
+			wantUnused: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := &common.CommonDevice{
+				Interfaces: []common.Interface{{Name: "opt1", Enabled: true}},
+				VPN:        tt.vpn,
+			}
+
+			unused := analysis.DetectUnusedInterfaces(cfg)
+			if tt.wantUnused {
+				require.Len(t, unused, 1)
+				assert.Equal(t, "opt1", unused[0].InterfaceName)
+			} else {
+				assert.Empty(t, unused, "a VPN server bound to the interface puts it to use")
+			}
+		})
+	}
+}
+
+func TestInterfaceIndex_DanglingReferences(t *testing.T) {
+	t.Parallel()
+
+	cfg := &common.CommonDevice{
+		Interfaces: []common.Interface{
+			{Name: "wan", Enabled: true},
+			{Name: "lan", Enabled: true},
+			{Name: "opt1", Enabled: true},
+		},
+		InterfaceGroups: []common.InterfaceGroup{
+			{Name: "DMZ", Members: []string{"opt1", "opt4"}},
+		},
+		FirewallRules: []common.FirewallRule{
+			{Type: common.RuleTypePass, Interfaces: []string{"LAN"}},
+			{Type: common.RuleTypePass, Interfaces: []string{"dmz"}},
+			{Type: common.RuleTypePass, Interfaces: []string{"openvpn"}},
+			{Type: common.RuleTypePass, Interfaces: []string{"opt7"}},
+		},
+		NAT: common.NATConfig{
+			OutboundRules: []common.NATRule{{Interfaces: []string{"_vip5f3a1"}}},
+		},
+		DHCP: []common.DHCPScope{{Interface: "opt10", Enabled: true}},
+		VPN: common.VPN{
+			OpenVPN: common.OpenVPNConfig{
+				Servers: []common.OpenVPNServer{{Interface: "opt3", VPNID: "2"}},
+			},
+			WireGuard: common.WireGuardConfig{Enabled: true},
+		},
+		DNS: common.DNSConfig{Unbound: common.UnboundConfig{Enabled: true}},
+	}
+
+	got := analysis.BuildInterfaceIndex(cfg).DanglingReferences(cfg.Interfaces, cfg.InterfaceGroups)
+
+	assert.Equal(t, []analysis.DanglingInterfaceReference{
+		{Interface: "opt10", Referrer: "DHCP scope"},
+		{Interface: "opt3", Referrer: `OpenVPN server "2"`},
+		{Interface: "opt4", Referrer: "interface group DMZ"},
+		{Interface: "opt7", Referrer: "firewall rule 4"},
+	}, got)
+}
+
+func TestInterfaceIndex_DanglingReferences_AssumedLANBindings(t *testing.T) {
+	t.Parallel()
+
+	cfg := &common.CommonDevice{
+		Interfaces: []common.Interface{{Name: "wan", Enabled: true}},
+		DNS:        common.DNSConfig{Unbound: common.UnboundConfig{Enabled: true}},
+		VPN:        common.VPN{WireGuard: common.WireGuardConfig{Enabled: true}},
+	}
+
+	assert.Empty(t, analysis.BuildInterfaceIndex(cfg).DanglingReferences(cfg.Interfaces, nil),
+		"bindings assumed rather than configured are not dangling")
+}
@@ -0,0 +1,131 @@
+package audit
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/compliance"
+)
+
+// ErrChecksErrored is returned by CheckErroredChecks when at least one
+// compliance check errored instead of reaching a result.
+var ErrChecksErrored = errors.New("compliance checks errored")
+
+// reasonNotEvaluable is the skip reason of a control that a plugin left out
+// of its evaluated list without explaining why.
+const reasonNotEvaluable = "configuration does not contain the data this check needs"
+
+// CheckExecution records how the check of one control ran during an audit.
+type CheckExecution struct {
+	ID       string                 `json:"id"`
+	Name     string                 `json:"name"`
+	Status   compliance.CheckStatus `json:"status"`
+	Findings int                    `json:"findings"`
+	Reason   string                 `json:"reason,omitempty"`
+}
+
+// buildCheckExecutions derives the execution status of every control from
+// one plugin run. An outcome the plugin reported as errored wins; otherwise a
+// control referenced by findings produced findings, an evaluated control
+// passed, and any other control was skipped, with the plugin's reason when it
+// gave one.
+func buildCheckExecutions(
+	controls []compliance.Control,
+	findings []compliance.Finding,
+	evaluated []string,
+	outcomes map[string]compliance.CheckOutcome,
+) []CheckExecution {
+	findingCounts := make(map[string]int, len(findings))
+	for _, f := range findings {
+		for _, ref := range f.References {
+			findingCounts[ref]++
+		}
+	}
+
+	checks := make([]CheckExecution, 0, len(controls))
+	for _, c := range controls {
+		check := CheckExecution{ID: c.ID, Name: c.Title, Findings: findingCounts[c.ID]}
+		outcome, reported := outcomes[c.ID]
+
+		switch {
+		case reported && outcome.Status == compliance.CheckStatusErrored:
+			check.Status, check.Reason = compliance.CheckStatusErrored, outcome.Reason
+		case check.Findings > 0:
+			check.Status = compliance.CheckStatusFindings
+		case slices.Contains(evaluated, c.ID):
+			check.Status = compliance.CheckStatusPassed
+		default:
+			check.Status, check.Reason = compliance.CheckStatusSkipped, reasonNotEvaluable
+			if reported && outcome.Reason != "" {
+				check.Reason = outcome.Reason
+			}
+		}
+
+		checks = append(checks, check)
+	}
+
+	return checks
+}
+
+// erroredCheckExecutions marks every control of a plugin that aborted as a
+// whole as errored with reason. A plugin without controls is recorded as a
+// single errored entry named after the plugin.
+func erroredCheckExecutions(pluginName string, controls []compliance.Control, reason string) []CheckExecution {
+	if len(controls) == 0 {
+		return []CheckExecution{{Name: pluginName, Status: compliance.CheckStatusErrored, Reason: reason}}
+	}
+
+	checks := make([]CheckExecution, len(controls))
+	for i, c := range controls {
+		checks[i] = CheckExecution{ID: c.ID, Name: c.Title, Status: compliance.CheckStatusErrored, Reason: reason}
+	}
+
+	return checks
+}
+
+// CountErroredChecks returns the number of errored checks across every
+// plugin of report.
+func CountErroredChecks(report *Report) int {
+	if report == nil {
+		return 0
+	}
+
+	errored := 0
+	for _, cr := range report.Compliance {
+		for _, checks := range cr.Checks {
+			for _, check := range checks {
+				if check.Status == compliance.CheckStatusErrored {
+					errored++
+				}
+			}
+		}
+	}
+
+	return errored
+}
+
+// CheckErroredChecks returns an error wrapping ErrChecksErrored when any
+// compliance check of report errored, naming the affected plugins.
+func CheckErroredChecks(report *Report) error {
+	errored := CountErroredChecks(report)
+	if errored == 0 {
+		return nil
+	}
+
+	var plugins []string
+	for _, pluginName := range slices.Sorted(maps.Keys(report.Compliance)) {
+		for _, checks := range report.Compliance[pluginName].Checks {
+			if slices.ContainsFunc(checks, func(c CheckExecution) bool {
+				return c.Status == compliance.CheckStatusErrored
+			}) {
+				plugins = append(plugins, pluginName)
+				break
+			}
+		}
+	}
+
+	return fmt.Errorf("%w: %d check(s) errored in plugin(s) %s", ErrChecksErrored, errored, strings.Join(plugins, ", "))
+}
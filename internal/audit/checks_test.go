@@ -0,0 +1,106 @@
+package audit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/compliance"
+	"github.com/EvilBit-Labs/opnDossier/internal/plugins/firewall"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildCheckExecutions(t *testing.T) {
+	t.Parallel()
+
+	controls := []compliance.Control{
+		{ID: "CTL-001", Title: "Passing"},
+		{ID: "CTL-002", Title: "Failing"},
+		{ID: "CTL-003", Title: "Unexplained"},
+		{ID: "CTL-004", Title: "Explained"},
+		{ID: "CTL-005", Title: "Panicking"},
+	}
+	findings := []compliance.Finding{
+		{Type: "compliance", References: []string{"CTL-002"}},
+		{Type: "compliance", References: []string{"CTL-002"}},
+	}
+	evaluated := []string{"CTL-001", "CTL-002"}
+	outcomes := map[string]compliance.CheckOutcome{
+		"CTL-004": {Status: compliance.CheckStatusSkipped, Reason: "feature not configured"},
+		"CTL-005": {Status: compliance.CheckStatusErrored, Reason: "check panicked: boom"},
+	}
+
+	assert.Equal(t, []CheckExecution{
+		{ID: "CTL-001", Name: "Passing", Status: compliance.CheckStatusPassed},
+		{ID: "CTL-002", Name: "Failing", Status: compliance.CheckStatusFindings, Findings: 2},
+		{ID: "CTL-003", Name: "Unexplained", Status: compliance.CheckStatusSkipped, Reason: reasonNotEvaluable},
+		{ID: "CTL-004", Name: "Explained", Status: compliance.CheckStatusSkipped, Reason: "feature not configured"},
+		{ID: "CTL-005", Name: "Panicking", Status: compliance.CheckStatusErrored, Reason: "check panicked: boom"},
+	}, buildCheckExecutions(controls, findings, evaluated, outcomes))
+}
+
+// TestRunComplianceChecks_PanickingPluginChecksErrored verifies that every
+// check of a panicking plugin is recorded as errored with the panic message
+// while the other plugins' checks complete.
+func TestRunComplianceChecks_PanickingPluginChecksErrored(t *testing.T) {
+	t.Parallel()
+
+	registry := registerPluginsOrFatal(t, []compliance.Plugin{
+		&mockPanickingPlugin{
+			mockCompliancePlugin: mockCompliancePlugin{name: "panicking-plugin", version: "0.0.1"},
+			controls:             []compliance.Control{{ID: "PANIC-001", Title: "Panic Control"}},
+		},
+		firewall.NewPlugin(),
+	})
+
+	device := &common.CommonDevice{System: common.System{Hostname: "test-host"}}
+	result, err := registry.RunComplianceChecks(device, []string{"panicking-plugin", "firewall"}, newTestLogger(t))
+	require.NoError(t, err)
+
+	assert.Equal(t, []CheckExecution{{
+		ID:     "PANIC-001",
+		Name:   "Panic Control",
+		Status: compliance.CheckStatusErrored,
+		Reason: "plugin panicked: test panic",
+	}}, result.Checks["panicking-plugin"])
+
+	firewallChecks := result.Checks["firewall"]
+	require.Len(t, firewallChecks, len(firewall.NewPlugin().GetControls()))
+	for _, check := range firewallChecks {
+		assert.NotEqual(t, compliance.CheckStatusErrored, check.Status, check.ID)
+	}
+}
+
+func TestCheckErroredChecks(t *testing.T) {
+	t.Parallel()
+
+	registry := registerPluginsOrFatal(t, []compliance.Plugin{
+		&mockPanickingPlugin{
+			mockCompliancePlugin: mockCompliancePlugin{name: "panicking-plugin", version: "0.0.1"},
+		},
+		firewall.NewPlugin(),
+	})
+	controller := NewModeController(registry, newTestLogger(t))
+	device := &common.CommonDevice{System: common.System{Hostname: "test-host"}}
+
+	report, err := controller.GenerateReport(context.Background(), device, &ModeConfig{
+		Mode:            ModeBlue,
+		SelectedPlugins: []string{"firewall"},
+	})
+	require.NoError(t, err)
+	require.NoError(t, CheckErroredChecks(report))
+	assert.NotEmpty(t, report.Compliance["firewall"].Checks["firewall"])
+
+	report, err = controller.GenerateReport(context.Background(), device, &ModeConfig{
+		Mode:            ModeBlue,
+		SelectedPlugins: []string{"panicking-plugin", "firewall"},
+	})
+	require.NoError(t, err, "a panicking plugin must not abort the audit")
+	assert.Equal(t, 1, CountErroredChecks(report), "a plugin without controls is recorded as one errored check")
+
+	err = CheckErroredChecks(report)
+	require.ErrorIs(t, err, ErrChecksErrored)
+	assert.Contains(t, err.Error(), "panicking-plugin")
+	assert.NotContains(t, err.Error(), "firewall")
+}
@@ -71,6 +71,24 @@ type ModeConfig struct {
 	// Tone only — it never changes which findings are reported and never
 	// introduces instructional content. Ignored outside red mode.
 	Blackhat bool
+	// DisableDedupe keeps every blue hygiene finding as emitted instead of
+	// merging findings that share a category and component (see
+	// analysis.MergeFindings). Ignored outside blue mode.
+	DisableDedupe bool
+	// DescriptionPolicy configures the rule description hygiene checks run
+	// in blue mode (see analysis.DetectDescriptionHygiene). The zero value
+	// applies the default minimum length and no required pattern. Ignored
+	// outside blue mode.
+	DescriptionPolicy analysis.DescriptionPolicy
+	// LogCoverageThreshold is the percentage of pass rules on a WAN
+	// interface expected to log their matches (see
+	// analysis.DetectLoggingCoverage). Zero selects
+	// analysis.DefaultLogCoverageThreshold. Ignored outside blue mode.
+	LogCoverageThreshold float64
+	// ReferenceTime is the time configuration freshness is measured against
+	// (see analysis.DetectConfigFreshness). The zero value selects the
+	// current time. Ignored outside blue mode.
+	ReferenceTime time.Time
 }
 
 // ValidateModeConfig validates the mode configuration.
@@ -147,6 +165,8 @@ func (mc *ModeController) GenerateReport(
 		Metadata:      make(map[string]any),
 	}
 
+	report.addCompatibilityFinding()
+
 	// Generate mode-specific content
 	switch config.Mode {
 	case ModeBlue:
@@ -211,8 +231,11 @@ func (mc *ModeController) generateBlueReport(_ context.Context, report *Report,
 				Compliance:     map[string]map[string]bool{pluginName: pluginComplianceMap},
 				Summary:        pluginSummary,
 				PluginInfo:     map[string]PluginInfo{pluginName: info},
+				Checks:         map[string][]CheckExecution{pluginName: complianceResult.Checks[pluginName]},
 			}
 		}
+		report.BenchmarkScore = computeBenchmarkScore(report.Compliance)
+
 		// Add metadata to report indicating successful compliance checks
 		report.Metadata["compliance_check_status"] = complianceCheckStatusCompleted
 		report.Metadata["compliance_check_time"] = time.Now().Format(time.RFC3339)
@@ -223,7 +246,29 @@ func (mc *ModeController) generateBlueReport(_ context.Context, report *Report,
 	// compliance findings just aggregated above.
 	observations := analysis.ScanObservations(report.Configuration)
 
-	report.addSecurityFindings(observations)
+	// Rule description hygiene is a change-management check, so it joins the
+	// blue hygiene findings only; red mode never sees it.
+	descObservations, descQuality := analysis.DetectDescriptionHygiene(report.Configuration, config.DescriptionPolicy)
+	observations = append(observations, descObservations...)
+	report.DescriptionQuality = &descQuality
+
+	// Logging coverage measures what the monitoring team can see, so like
+	// description hygiene it is a blue-only concern.
+	logObservations, logCoverage := analysis.DetectLoggingCoverage(report.Configuration, config.LogCoverageThreshold)
+	observations = append(observations, logObservations...)
+	report.LoggingCoverage = &logCoverage
+
+	// Configuration freshness is a change-management signal, so it is
+	// blue-only as well.
+	referenceTime := config.ReferenceTime
+	if referenceTime.IsZero() {
+		referenceTime = time.Now()
+	}
+	freshObservations, freshness := analysis.DetectConfigFreshness(report.Configuration, referenceTime)
+	observations = append(observations, freshObservations...)
+	report.ConfigFreshness = &freshness
+
+	report.addSecurityFindings(observations, !config.DisableDedupe)
 	report.addComplianceAnalysis()
 	report.addRecommendations()
 	report.addStructuredConfigurationTables()
@@ -271,6 +316,18 @@ type Report struct {
 	Findings      []Finding                   `json:"findings"`
 	Compliance    map[string]ComplianceResult `json:"compliance"`
 	Metadata      map[string]any              `json:"metadata"`
+	// DescriptionQuality summarizes rule description hygiene. Set in blue
+	// mode only.
+	DescriptionQuality *common.DescriptionQuality `json:"descriptionQuality,omitempty"`
+	// LoggingCoverage summarizes firewall rule logging per interface. Set in
+	// blue mode only.
+	LoggingCoverage *common.LoggingCoverage `json:"loggingCoverage,omitempty"`
+	// BenchmarkScore weighs passed compliance controls against applicable
+	// ones. Set in blue mode only, when at least one plugin ran.
+	BenchmarkScore *common.BenchmarkScore `json:"benchmarkScore,omitempty"`
+	// ConfigFreshness counts rules, NAT rules, and users by the age of their
+	// last change. Set in blue mode only.
+	ConfigFreshness *common.ConfigFreshness `json:"configFreshness,omitempty"`
 }
 
 // Finding represents a security finding or audit result.
@@ -390,11 +447,39 @@ func (r *Report) dedupeAgainstPluginFindings(observations []analysis.Observation
 	return deduped
 }
 
+// findingTypeCompatibility is the Finding.Type of the configuration format
+// compatibility finding.
+const findingTypeCompatibility = "compatibility"
+
+// addCompatibilityFinding records an informational finding when the
+// configuration format is newer than the parser was tested against, since
+// sections that format introduced are missing from the audit.
+func (r *Report) addCompatibilityFinding() {
+	if r.Configuration.Compatibility == "" {
+		return
+	}
+
+	r.Findings = append(r.Findings, Finding{
+		Finding: analysis.Finding{
+			Type:        findingTypeCompatibility,
+			Severity:    string(analysis.SeverityInfo),
+			Title:       "Configuration Format Newer Than Tested",
+			Description: r.Configuration.Compatibility,
+			Recommendation: "Upgrade opnDossier, and review sections added in newer firmware " +
+				"releases directly on the device",
+			Component: "version",
+		},
+	})
+}
+
 // addSecurityFindings renders the shared engine's observations as blue
 // hygiene findings appended to report.Findings (R7, R8), de-duplicated
 // against fired plugin controls (R9) and ordered by severity then
-// reachability (R12).
-func (r *Report) addSecurityFindings(observations []analysis.Observation) {
+// reachability (R12). When merge is true, findings several checks raised
+// against the same config element are collapsed into one via
+// analysis.MergeFindings; sorting first makes the most severe, most exposed
+// member lead each merged group.
+func (r *Report) addSecurityFindings(observations []analysis.Observation, merge bool) {
 	hygiene := r.dedupeAgainstPluginFindings(observations)
 
 	slices.SortStableFunc(hygiene, func(a, b analysis.Observation) int {
@@ -405,9 +490,18 @@ func (r *Report) addSecurityFindings(observations []analysis.Observation) {
 		return reachabilityOrder[a.Reachability] - reachabilityOrder[b.Reachability]
 	})
 
-	findings := make([]Finding, 0, len(hygiene))
+	rendered := make([]analysis.Finding, 0, len(hygiene))
 	for _, obs := range hygiene {
-		findings = append(findings, Finding{Finding: obs.ToFinding()})
+		rendered = append(rendered, obs.ToFinding())
+	}
+
+	if merge {
+		rendered = analysis.MergeFindings(rendered)
+	}
+
+	findings := make([]Finding, 0, len(rendered))
+	for _, f := range rendered {
+		findings = append(findings, Finding{Finding: f})
 	}
 
 	r.Findings = append(r.Findings, findings...)
@@ -392,6 +392,9 @@ func TestRedMode_RegressionBattery(t *testing.T) {
 						Type:        common.RuleTypePass,
 						Interfaces:  []string{"wan"},
 						Destination: common.RuleEndpoint{Port: "8000-9000"},
+						// Connection limits keep the any-protocol rule from
+						// surfacing as a state table weakness.
+						MaxSrcConn: "100",
 					},
 				},
 			},
@@ -487,6 +490,9 @@ func TestRedMode_RegressionBattery(t *testing.T) {
 						Type:        common.RuleTypePass,
 						Interfaces:  []string{"wan"},
 						Destination: common.RuleEndpoint{Port: "8080"},
+						// Connection limits keep the any-protocol rule from
+						// surfacing as a state table weakness.
+						MaxSrcConn: "100",
 					},
 				},
 			},
@@ -532,6 +538,9 @@ func TestRedMode_RegressionBattery(t *testing.T) {
 						Type:        common.RuleTypePass,
 						Interfaces:  []string{"wan"},
 						Destination: common.RuleEndpoint{Port: "443"},
+						// Connection limits keep the any-protocol rule from
+						// surfacing as a state table weakness.
+						MaxSrcConn: "100",
 					},
 				},
 			},
@@ -3,15 +3,18 @@ package audit
 import (
 	"context"
 	"path/filepath"
+	"regexp"
 	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/EvilBit-Labs/opnDossier/internal/analysis"
 	"github.com/EvilBit-Labs/opnDossier/internal/compliance"
 	"github.com/EvilBit-Labs/opnDossier/internal/plugins/firewall"
 	"github.com/EvilBit-Labs/opnDossier/internal/plugins/sans"
 	"github.com/EvilBit-Labs/opnDossier/internal/plugins/stig"
+	"github.com/EvilBit-Labs/opnDossier/internal/testutil"
 	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
 )
 
@@ -351,12 +354,7 @@ func TestModeController_GenerateReport(t *testing.T) {
 	controller := NewModeController(registry, logger)
 
 	// Create a minimal test configuration
-	testConfig := &common.CommonDevice{
-		System: common.System{
-			Hostname: "test-host",
-			Domain:   "test.local",
-		},
-	}
+	testConfig := testutil.NewDeviceBuilder().WithHostname("test-host").WithDomain("test.local").Build()
 
 	tests := []struct {
 		name    string
@@ -446,6 +444,58 @@ func TestModeController_GenerateReport(t *testing.T) {
 	}
 }
 
+func TestModeController_GenerateReport_CompatibilityFinding(t *testing.T) {
+	t.Parallel()
+
+	controller := NewModeController(NewPluginRegistry(), newTestLogger(t))
+
+	tests := []struct {
+		name          string
+		compatibility string
+		expectFinding bool
+	}{
+		{name: "tested format", expectFinding: false},
+		{
+			name:          "newer format",
+			compatibility: "config format 25.1 newer than tested 24.7 — some sections may be unreported",
+			expectFinding: true,
+		},
+	}
+
+	for _, tt := range tests {
+		for _, mode := range []ReportMode{ModeBlue, ModeRed} {
+			t.Run(tt.name+"/"+mode.String(), func(t *testing.T) {
+				t.Parallel()
+
+				device := &common.CommonDevice{Version: "25.1", Compatibility: tt.compatibility}
+				report, err := controller.GenerateReport(context.Background(), device, &ModeConfig{Mode: mode})
+				if err != nil {
+					t.Fatalf("GenerateReport() error = %v", err)
+				}
+
+				var found *Finding
+				for i := range report.Findings {
+					if report.Findings[i].Type == findingTypeCompatibility {
+						found = &report.Findings[i]
+					}
+				}
+
+				if (found != nil) != tt.expectFinding {
+					t.Fatalf("compatibility finding present = %v, want %v", found != nil, tt.expectFinding)
+				}
+				if found != nil {
+					if found.Severity != string(analysis.SeverityInfo) {
+						t.Errorf("Severity = %q, want info", found.Severity)
+					}
+					if found.Description != tt.compatibility {
+						t.Errorf("Description = %q, want %q", found.Description, tt.compatibility)
+					}
+				}
+			})
+		}
+	}
+}
+
 func TestReport_Structure(t *testing.T) {
 	t.Parallel()
 
@@ -1022,7 +1072,7 @@ func TestReport_AnalysisMethods(t *testing.T) {
 	// non-empty.
 	t.Run("addSecurityFindings", func(t *testing.T) {
 		observations := analysis.ScanObservations(report.Configuration)
-		report.addSecurityFindings(observations)
+		report.addSecurityFindings(observations, true)
 
 		if len(report.Findings) == 0 {
 			t.Fatal("addSecurityFindings() should append hygiene findings for an insecure WebGUI config")
@@ -1184,12 +1234,7 @@ func TestPluginRegistry_RunComplianceChecks(t *testing.T) {
 	}
 
 	// Create a test configuration
-	testConfig := &common.CommonDevice{
-		System: common.System{
-			Hostname: "test-host",
-			Domain:   "test.local",
-		},
-	}
+	testConfig := testutil.NewDeviceBuilder().WithHostname("test-host").WithDomain("test.local").Build()
 
 	// Test running compliance checks with no plugins selected
 	results, err := registry.RunComplianceChecks(testConfig, nil, newTestLogger(t))
@@ -1288,12 +1333,7 @@ func TestPluginManager_RunComplianceAudit(t *testing.T) {
 	manager := NewPluginManager()
 
 	// Create a test configuration
-	testConfig := &common.CommonDevice{
-		System: common.System{
-			Hostname: "test-host",
-			Domain:   "test.local",
-		},
-	}
+	testConfig := testutil.NewDeviceBuilder().WithHostname("test-host").WithDomain("test.local").Build()
 
 	// Test running compliance audit
 	results, err := manager.RunComplianceAudit(testConfig, nil)
@@ -1438,7 +1478,7 @@ func TestAddSecurityFindings_DedupeAgainstFiredPluginControls(t *testing.T) {
 		},
 	}
 
-	report.addSecurityFindings(observations)
+	report.addSecurityFindings(observations, true)
 
 	if len(report.Findings) != 1 {
 		t.Fatalf(
@@ -1476,7 +1516,7 @@ func TestAddSecurityFindings_OrderedBySeverityThenReachability(t *testing.T) {
 		{Severity: analysis.SeverityHigh, Reachability: analysis.WANReachable, Component: "d", Title: "high-wan"},
 	}
 
-	report.addSecurityFindings(observations)
+	report.addSecurityFindings(observations, true)
 
 	wantOrder := []string{"critical-local", "high-wan", "high-lan", "medium-wan"}
 	gotOrder := make([]string, len(report.Findings))
@@ -1602,3 +1642,281 @@ func TestGenerateBlueReport_SurfacesShadowedRuleFindings(t *testing.T) {
 		)
 	}
 }
+
+// TestGenerateBlueReport_MergesOverlappingFindings pins the finding merge pass:
+// a WAN any-to-any pass rule shadowed by an identical earlier rule trips three
+// checks (overly permissive WAN rule, any-to-any rule, shadowed rule) against
+// the same filter.rule[1] component. By default they collapse into one
+// finding with the maximum severity and all three check IDs; DisableDedupe
+// keeps the three findings separate.
+func TestGenerateBlueReport_MergesOverlappingFindings(t *testing.T) {
+	t.Parallel()
+
+	anyRule := common.FirewallRule{
+		Type:        common.RuleTypePass,
+		Description: "Temporary vendor access",
+		Interfaces:  []string{"wan"},
+		Direction:   common.DirectionIn,
+		Quick:       true,
+		Source:      common.RuleEndpoint{Address: "any"},
+		Destination: common.RuleEndpoint{Address: "any"},
+	}
+
+	device := &common.CommonDevice{
+		System:        common.System{Hostname: "fw", Domain: "example.com"},
+		Interfaces:    []common.Interface{{Name: "wan", Enabled: true}},
+		FirewallRules: []common.FirewallRule{anyRule, anyRule},
+	}
+
+	ruleFindings := func(t *testing.T, disableDedupe bool) []Finding {
+		t.Helper()
+
+		controller := NewModeController(NewPluginRegistry(), newTestLogger(t))
+
+		report, err := controller.GenerateReport(context.Background(), device, &ModeConfig{
+			Mode:          ModeBlue,
+			DisableDedupe: disableDedupe,
+		})
+		if err != nil {
+			t.Fatalf("GenerateReport() unexpected error: %v", err)
+		}
+
+		var matched []Finding
+		for _, f := range report.Findings {
+			if f.Component == "filter.rule[1]" {
+				matched = append(matched, f)
+			}
+		}
+
+		return matched
+	}
+
+	t.Run("merged by default", func(t *testing.T) {
+		t.Parallel()
+
+		findings := ruleFindings(t, false)
+		if len(findings) != 1 {
+			t.Fatalf("filter.rule[1] findings = %d, want 1 merged finding: %+v", len(findings), findings)
+		}
+
+		merged := findings[0]
+		if merged.Severity != string(analysis.SeverityHigh) {
+			t.Errorf("merged Severity = %q, want %q", merged.Severity, analysis.SeverityHigh)
+		}
+
+		if len(merged.References) != 4 {
+			t.Errorf("merged References = %v, want 4 check IDs", merged.References)
+		}
+
+		for _, id := range []string{
+			"any-to-any-pass-rule",
+			"overly-permissive-wan-rule",
+			"internet-facing-tcp-rule-without-rate-limiting",
+		} {
+			if !slices.Contains(merged.References, id) {
+				t.Errorf("merged References = %v, want it to contain %q", merged.References, id)
+			}
+		}
+
+		if !strings.Contains(merged.Description, "Merged from 4 checks") {
+			t.Errorf("merged Description = %q, want it to list the contributing checks", merged.Description)
+		}
+	})
+
+	t.Run("no dedupe keeps every finding", func(t *testing.T) {
+		t.Parallel()
+
+		findings := ruleFindings(t, true)
+		if len(findings) != 4 {
+			t.Fatalf("filter.rule[1] findings = %d, want 4 with DisableDedupe: %+v", len(findings), findings)
+		}
+	})
+}
+
+// TestGenerateReport_DescriptionHygiene pins the rule description checks to
+// blue mode: blue reports carry the description findings and compliance
+// rate, honoring the configured policy, while red reports carry neither.
+func TestGenerateReport_DescriptionHygiene(t *testing.T) {
+	t.Parallel()
+
+	device := &common.CommonDevice{
+		System:     common.System{Hostname: "fw", Domain: "example.com"},
+		Interfaces: []common.Interface{{Name: "lan", Enabled: true}},
+		FirewallRules: []common.FirewallRule{
+			{
+				Type:        common.RuleTypePass,
+				Interfaces:  []string{"lan"},
+				Source:      common.RuleEndpoint{Address: "lan"},
+				Destination: common.RuleEndpoint{Address: "10.0.1.53", Port: "53"},
+				Description: "Allow DNS to resolver",
+			},
+			{
+				Type:        common.RuleTypePass,
+				Interfaces:  []string{"lan"},
+				Source:      common.RuleEndpoint{Address: "lan"},
+				Destination: common.RuleEndpoint{Address: "10.0.1.25", Port: "25"},
+				Description: "CHG-1001 allow SMTP relay",
+			},
+		},
+	}
+
+	controller := NewModeController(NewPluginRegistry(), newTestLogger(t))
+
+	blue, err := controller.GenerateReport(context.Background(), device, &ModeConfig{
+		Mode:              ModeBlue,
+		DescriptionPolicy: analysis.DescriptionPolicy{Pattern: regexp.MustCompile(`CHG-\d+`)},
+	})
+	if err != nil {
+		t.Fatalf("GenerateReport(blue) unexpected error: %v", err)
+	}
+
+	if blue.DescriptionQuality == nil {
+		t.Fatal("blue report DescriptionQuality = nil, want description stats")
+	}
+
+	if got := *blue.DescriptionQuality; got.TotalRules != 2 || got.CompliantRules != 1 || got.CompliantPercent != 50 {
+		t.Errorf("blue report DescriptionQuality = %+v, want 1 of 2 rules compliant (50%%)", got)
+	}
+
+	idx := slices.IndexFunc(blue.Findings, func(f Finding) bool {
+		return f.Title == "Rule Description Missing Required Reference"
+	})
+	if idx < 0 || blue.Findings[idx].Component != "filter.rule[0]" {
+		t.Errorf("blue report missing pattern finding for filter.rule[0]; findings: %+v", blue.Findings)
+	}
+
+	red, err := controller.GenerateReport(context.Background(), device, &ModeConfig{Mode: ModeRed})
+	if err != nil {
+		t.Fatalf("GenerateReport(red) unexpected error: %v", err)
+	}
+
+	if red.DescriptionQuality != nil {
+		t.Errorf("red report DescriptionQuality = %+v, want nil", *red.DescriptionQuality)
+	}
+}
+
+// TestGenerateReport_LoggingCoverage pins the logging coverage analysis to
+// blue mode: blue reports carry the per-interface stats and the WAN coverage
+// finding, honoring the configured threshold, while red reports carry neither.
+func TestGenerateReport_LoggingCoverage(t *testing.T) {
+	t.Parallel()
+
+	passRule := func(port string, log bool) common.FirewallRule {
+		return common.FirewallRule{
+			Type:        common.RuleTypePass,
+			Interfaces:  []string{"wan"},
+			Source:      common.RuleEndpoint{Address: "any"},
+			Destination: common.RuleEndpoint{Address: "10.0.1.10", Port: port},
+			Description: "CHG-3001 allow published service " + port,
+			Log:         log,
+		}
+	}
+
+	device := &common.CommonDevice{
+		System:     common.System{Hostname: "fw", Domain: "example.com"},
+		Interfaces: []common.Interface{{Name: "wan", Enabled: true}},
+		FirewallRules: []common.FirewallRule{
+			passRule("443", true),
+			passRule("25", false),
+			passRule("22", false),
+		},
+	}
+
+	controller := NewModeController(NewPluginRegistry(), newTestLogger(t))
+
+	hasCoverageFinding := func(report *Report) bool {
+		return slices.ContainsFunc(report.Findings, func(f Finding) bool {
+			return f.Title == "Low Pass Rule Logging Coverage" && f.Component == "interfaces.wan"
+		})
+	}
+
+	blue, err := controller.GenerateReport(context.Background(), device, &ModeConfig{Mode: ModeBlue})
+	if err != nil {
+		t.Fatalf("GenerateReport(blue) unexpected error: %v", err)
+	}
+
+	if blue.LoggingCoverage == nil || len(blue.LoggingCoverage.Interfaces) != 1 {
+		t.Fatalf("blue report LoggingCoverage = %+v, want one interface entry", blue.LoggingCoverage)
+	}
+
+	if got := blue.LoggingCoverage.Interfaces[0]; got.PassRules != 3 || got.LoggedPassRules != 1 {
+		t.Errorf("blue report wan coverage = %+v, want 1 of 3 pass rules logged", got)
+	}
+
+	if !hasCoverageFinding(blue) {
+		t.Errorf("blue report missing logging coverage finding for interfaces.wan; findings: %+v", blue.Findings)
+	}
+
+	lenient, err := controller.GenerateReport(context.Background(), device, &ModeConfig{
+		Mode:                 ModeBlue,
+		LogCoverageThreshold: 30,
+	})
+	if err != nil {
+		t.Fatalf("GenerateReport(blue, threshold 30) unexpected error: %v", err)
+	}
+
+	if hasCoverageFinding(lenient) {
+		t.Error("blue report with a 30% threshold should not flag 33% logging coverage")
+	}
+
+	red, err := controller.GenerateReport(context.Background(), device, &ModeConfig{Mode: ModeRed})
+	if err != nil {
+		t.Fatalf("GenerateReport(red) unexpected error: %v", err)
+	}
+
+	if red.LoggingCoverage != nil {
+		t.Errorf("red report LoggingCoverage = %+v, want nil", *red.LoggingCoverage)
+	}
+}
+
+// TestGenerateReport_ConfigFreshness pins the configuration freshness
+// analysis to blue mode and checks that ModeConfig.ReferenceTime is the time
+// ages are measured against.
+func TestGenerateReport_ConfigFreshness(t *testing.T) {
+	t.Parallel()
+
+	device := &common.CommonDevice{
+		System: common.System{Hostname: "fw", Domain: "example.com"},
+		FirewallRules: []common.FirewallRule{{
+			Type:        common.RuleTypePass,
+			Interfaces:  []string{"lan"},
+			Source:      common.RuleEndpoint{Address: "lan"},
+			Destination: common.RuleEndpoint{Address: "any"},
+			Description: "CHG-3002 allow LAN outbound",
+			Created:     &common.ChangeRecord{Time: "1704067200"}, // 2024-01-01
+		}},
+	}
+
+	controller := NewModeController(NewPluginRegistry(), newTestLogger(t))
+
+	blue, err := controller.GenerateReport(context.Background(), device, &ModeConfig{
+		Mode:          ModeBlue,
+		ReferenceTime: time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("GenerateReport(blue) unexpected error: %v", err)
+	}
+
+	if blue.ConfigFreshness == nil {
+		t.Fatal("blue report ConfigFreshness = nil, want freshness stats")
+	}
+
+	if got := blue.ConfigFreshness.Rules; got != (common.FreshnessBuckets{Recent: 1}) {
+		t.Errorf("blue report rule freshness = %+v, want one recent rule", got)
+	}
+
+	if !slices.ContainsFunc(blue.Findings, func(f Finding) bool {
+		return f.Title == "Long-Unchanged Firewall Rule" && f.Component == "filter.rule[0]"
+	}) {
+		t.Errorf("blue report missing oldest rule finding for filter.rule[0]; findings: %+v", blue.Findings)
+	}
+
+	red, err := controller.GenerateReport(context.Background(), device, &ModeConfig{Mode: ModeRed})
+	if err != nil {
+		t.Fatalf("GenerateReport(red) unexpected error: %v", err)
+	}
+
+	if red.ConfigFreshness != nil {
+		t.Errorf("red report ConfigFreshness = %+v, want nil", *red.ConfigFreshness)
+	}
+}
@@ -28,4 +28,34 @@ type Options struct {
 	// meaningful in red mode; it adjusts tone only and never changes whether a
 	// finding is reported or introduces instructional content (R20).
 	Blackhat bool
+
+	// DisableDedupe keeps every blue-mode hygiene finding separate instead of
+	// merging findings that several checks raised against the same config
+	// element. Only meaningful in blue mode.
+	DisableDedupe bool
+
+	// DescriptionPattern is a regular expression every enabled firewall and
+	// NAT rule description must match (e.g. a change ticket reference). Empty
+	// disables the pattern check. Only meaningful in blue mode.
+	DescriptionPattern string
+
+	// MinDescriptionLength is the shortest acceptable rule description in
+	// characters. Zero selects analysis.DefaultMinDescriptionLength. Only
+	// meaningful in blue mode.
+	MinDescriptionLength int
+
+	// LogCoverageThreshold is the percentage of pass rules on a WAN interface
+	// expected to log their matches. Zero selects
+	// analysis.DefaultLogCoverageThreshold. Only meaningful in blue mode.
+	LogCoverageThreshold int
+
+	// MinScore is the lowest acceptable overall benchmark score, from 0 to 1.
+	// A report scoring below it fails with ErrScoreBelowMinimum after it is
+	// written. Zero disables the check. Only meaningful in blue mode.
+	MinScore float64
+
+	// StrictChecks fails a report in which any compliance check errored
+	// with ErrChecksErrored after it is written. Only meaningful in blue
+	// mode.
+	StrictChecks bool
 }
@@ -240,8 +240,9 @@ func (pr *PluginRegistry) LoadDynamicPlugins(
 // Each plugin's RunChecks call is wrapped in a panic recovery boundary so that
 // a misbehaving (especially dynamically-loaded) plugin cannot crash the entire
 // audit. Panicking plugins are logged and retained in the result with zero
-// findings, ensuring downstream consumers can see they were requested and
-// evaluated.
+// findings and errored checks, ensuring downstream consumers can see they
+// were requested and evaluated. Result.Checks records the execution status
+// of every control.
 func (pr *PluginRegistry) RunComplianceChecks(
 	device *common.CommonDevice,
 	pluginNames []string,
@@ -271,6 +272,7 @@ func (pr *PluginRegistry) RunComplianceChecks(
 		Compliance:     make(map[string]map[string]bool),
 		Summary:        &ComplianceSummary{},
 		PluginInfo:     make(map[string]PluginInfo),
+		Checks:         make(map[string][]CheckExecution),
 	}
 
 	for _, pluginName := range pluginNames {
@@ -295,10 +297,11 @@ func (pr *PluginRegistry) RunComplianceChecks(
 }
 
 // runPluginChecks executes one plugin against device, records the resulting
-// findings/PluginInfo/Compliance into result, and returns any non-recoverable
-// error. Panics inside the plugin's RunChecks are contained by
+// findings/PluginInfo/Compliance/Checks into result, and returns any
+// non-recoverable error. Panics inside the plugin's checks are contained by
 // invokePluginWithRecovery; on panic the plugin is retained with empty
-// findings so downstream consumers still see it was evaluated.
+// findings so downstream consumers still see it was evaluated, and each of
+// its checks is recorded as errored with the panic message.
 func (pr *PluginRegistry) runPluginChecks(
 	device *common.CommonDevice,
 	pluginName string,
@@ -310,14 +313,15 @@ func (pr *PluginRegistry) runPluginChecks(
 		return fmt.Errorf("failed to get plugin '%s': %w", pluginName, err)
 	}
 
-	findings, evaluated, panicked, runErr := invokePluginWithRecovery(p, device, pluginName, logger)
-	if panicked {
+	findings, evaluated, outcomes, panicErr, runErr := invokePluginWithRecovery(p, device, pluginName, logger)
+	if panicErr != nil {
 		result.PluginFindings[pluginName] = nil
 		result.PluginInfo[pluginName] = PluginInfo{
 			Name:    pluginName,
 			Version: "unknown (panicked)",
 		}
 		result.Compliance[pluginName] = make(map[string]bool)
+		result.Checks[pluginName] = erroredCheckExecutions(pluginName, controlsWithRecovery(p), panicErr.Error())
 		return nil
 	}
 	if runErr != nil {
@@ -341,11 +345,12 @@ func (pr *PluginRegistry) runPluginChecks(
 	// GetControls is contractually required to return a defensive deep copy
 	// (see compliance.Plugin) so we assign it directly without an additional
 	// CloneControls call — dropping the historical double-clone on every audit.
+	controls := p.GetControls()
 	result.PluginInfo[pluginName] = PluginInfo{
 		Name:        p.Name(),
 		Version:     p.Version(),
 		Description: p.Description(),
-		Controls:    p.GetControls(),
+		Controls:    controls,
 	}
 
 	// Initialize compliance tracking for this plugin. Only controls the plugin
@@ -357,15 +362,18 @@ func (pr *PluginRegistry) runPluginChecks(
 	}
 	applyFindingsToCompliance(complianceMap, findings)
 	result.Compliance[pluginName] = complianceMap
+	result.Checks[pluginName] = buildCheckExecutions(controls, findings, evaluated, outcomes)
 	return nil
 }
 
-// invokePluginWithRecovery runs p.RunChecks inside a panic boundary. A
-// panicking plugin (especially a dynamically-loaded one) must not crash the
-// entire audit process. Returned panicked=true means the plugin aborted; the
-// caller should record it with empty findings. Stack dumps are gated behind
-// verbose logging so plugin function names (which can leak internal paths
-// like "acmecorp-pci-plugin.RunChecks") do not end up in shared logs.
+// invokePluginWithRecovery runs the plugin's checks inside a panic boundary,
+// calling RunChecksWithOutcomes when p implements compliance.OutcomeReporter
+// and RunChecks otherwise. A panicking plugin (especially a
+// dynamically-loaded one) must not crash the entire audit process. A non-nil
+// panicErr means the plugin aborted; the caller should record it with empty
+// findings. Stack dumps are gated behind verbose logging so plugin function
+// names (which can leak internal paths like "acmecorp-pci-plugin.RunChecks")
+// do not end up in shared logs.
 //
 //nolint:nonamedreturns // panic recovery in the deferred func must overwrite the success value; a named return is the idiomatic way to do that.
 func invokePluginWithRecovery(
@@ -373,10 +381,15 @@ func invokePluginWithRecovery(
 	device *common.CommonDevice,
 	pluginName string,
 	logger *logging.Logger,
-) (findings []compliance.Finding, evaluated []string, panicked bool, runErr error) {
+) (
+	findings []compliance.Finding,
+	evaluated []string,
+	outcomes map[string]compliance.CheckOutcome,
+	panicErr, runErr error,
+) {
 	defer func() {
 		if r := recover(); r != nil {
-			panicked = true
+			panicErr = fmt.Errorf("plugin panicked: %v", r)
 			if logger.IsVerbose() {
 				logger.Error("plugin panicked during RunChecks",
 					"plugin", pluginName,
@@ -391,8 +404,29 @@ func invokePluginWithRecovery(
 			}
 		}
 	}()
+
+	if reporter, ok := p.(compliance.OutcomeReporter); ok {
+		findings, evaluated, outcomes, runErr = reporter.RunChecksWithOutcomes(device)
+		return findings, evaluated, outcomes, nil, runErr
+	}
+
 	findings, evaluated, runErr = p.RunChecks(device)
-	return findings, evaluated, false, runErr
+	return findings, evaluated, nil, nil, runErr
+}
+
+// controlsWithRecovery returns p's controls, or nil when GetControls panics
+// as well — a plugin that already panicked in RunChecks gets no benefit of
+// the doubt.
+//
+//nolint:nonamedreturns // panic recovery in the deferred func must overwrite the success value; a named return is the idiomatic way to do that.
+func controlsWithRecovery(p compliance.Plugin) (controls []compliance.Control) {
+	defer func() {
+		if recover() != nil {
+			controls = nil
+		}
+	}()
+
+	return p.GetControls()
 }
 
 // applyFindingsToCompliance flips evaluated controls referenced by non-inventory
@@ -625,6 +659,9 @@ type ComplianceResult struct {
 	Compliance     map[string]map[string]bool      `json:"compliance"`
 	Summary        *ComplianceSummary              `json:"summary"`
 	PluginInfo     map[string]PluginInfo           `json:"pluginInfo"`
+	// Checks records the execution status of every control, keyed by
+	// plugin name.
+	Checks map[string][]CheckExecution `json:"checks"`
 }
 
 // ComplianceSummary provides summary statistics.
@@ -0,0 +1,133 @@
+package audit
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/compliance"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+)
+
+// ErrScoreBelowMinimum is returned by CheckMinimumScore when a report's
+// benchmark score is below the required minimum.
+var ErrScoreBelowMinimum = errors.New("benchmark score below minimum")
+
+// gradeNotApplicable is the grade of a score with no applicable controls.
+const gradeNotApplicable = "N/A"
+
+// gradeThresholds maps the lowest score of each letter grade, best first.
+// Scores below the last threshold are graded F.
+var gradeThresholds = []struct {
+	minimum float64
+	grade   string
+}{
+	{0.9, "A"},
+	{0.8, "B"},
+	{0.7, "C"},
+	{0.6, "D"},
+}
+
+// ScoreGrade returns the letter grade A-F for a score from 0 to 1.
+func ScoreGrade(score float64) string {
+	for _, t := range gradeThresholds {
+		if score >= t.minimum {
+			return t.grade
+		}
+	}
+
+	return "F"
+}
+
+// computeBenchmarkScore scores the compliance results of a blue report. Every
+// control of every executed plugin is placed in its compliance.Control
+// ScoreSection with its ScoreWeight. A control in the plugin's compliance map
+// is applicable and passed when its value is true; a control missing from
+// the map could not be evaluated against the device and is not applicable,
+// so it is left out of both the passed and the applicable weight.
+//
+// Plugins and controls are visited in sorted order so the floating-point
+// sums, and therefore the score, are identical on every run.
+func computeBenchmarkScore(results map[string]ComplianceResult) *common.BenchmarkScore {
+	sections := make(map[string]*common.SectionScore)
+	for _, name := range compliance.ScoreSections() {
+		sections[name] = &common.SectionScore{Section: name}
+	}
+
+	for _, pluginName := range slices.Sorted(maps.Keys(results)) {
+		result := results[pluginName]
+		status := result.Compliance[pluginName]
+
+		controls := slices.Clone(result.PluginInfo[pluginName].Controls)
+		slices.SortFunc(controls, func(a, b compliance.Control) int {
+			return cmp.Compare(a.ID, b.ID)
+		})
+
+		for _, control := range controls {
+			section := sections[control.ScoreSection()]
+
+			passed, applicable := status[control.ID]
+			if !applicable {
+				section.NotApplicableChecks++
+				continue
+			}
+
+			weight := control.ScoreWeight()
+			section.ApplicableChecks++
+			section.ApplicableWeight += weight
+
+			if passed {
+				section.PassedChecks++
+				section.PassedWeight += weight
+			}
+		}
+	}
+
+	score := &common.BenchmarkScore{Grade: gradeNotApplicable}
+
+	var weightedSum, totalWeight float64
+	for _, name := range compliance.ScoreSections() {
+		section := sections[name]
+		section.Grade = gradeNotApplicable
+
+		if section.ApplicableWeight > 0 {
+			section.Score = section.PassedWeight / section.ApplicableWeight
+			section.Grade = ScoreGrade(section.Score)
+
+			weightedSum += section.Score * section.ApplicableWeight
+			totalWeight += section.ApplicableWeight
+		}
+
+		score.ApplicableChecks += section.ApplicableChecks
+		score.Sections = append(score.Sections, *section)
+	}
+
+	if totalWeight > 0 {
+		score.Score = weightedSum / totalWeight
+		score.Grade = ScoreGrade(score.Score)
+	}
+
+	return score
+}
+
+// CheckMinimumScore returns an error wrapping ErrScoreBelowMinimum when score
+// is below minimum, including when no control was applicable and the score
+// cannot be compared. A minimum of zero or less disables the check.
+func CheckMinimumScore(score *common.BenchmarkScore, minimum float64) error {
+	if minimum <= 0 {
+		return nil
+	}
+
+	if score == nil || score.ApplicableChecks == 0 {
+		return fmt.Errorf("%w: no applicable compliance checks to score, minimum is %.2f", ErrScoreBelowMinimum, minimum)
+	}
+
+	if score.Score < minimum {
+		return fmt.Errorf("%w: score %.2f (grade %s) is below the minimum of %.2f",
+			ErrScoreBelowMinimum, score.Score, score.Grade, minimum)
+	}
+
+	return nil
+}
@@ -0,0 +1,225 @@
+package audit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/compliance"
+	"github.com/EvilBit-Labs/opnDossier/internal/plugins/firewall"
+	"github.com/EvilBit-Labs/opnDossier/internal/plugins/sans"
+	"github.com/EvilBit-Labs/opnDossier/internal/plugins/stig"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// scoringFixturePlugin is a compliance plugin with one or two controls per
+// score section. It evaluates every control except the crypto ones, as a
+// plugin does for VPN checks on a device without a VPN, and fails the
+// controls listed in failing.
+type scoringFixturePlugin struct {
+	failing []string
+}
+
+func (p *scoringFixturePlugin) Name() string        { return "scoring" }
+func (p *scoringFixturePlugin) Version() string     { return "1.0.0" }
+func (p *scoringFixturePlugin) Description() string { return "benchmark scoring fixture" }
+
+//nolint:gocritic // nonamedreturns enforced project-wide
+func (p *scoringFixturePlugin) RunChecks(_ *common.CommonDevice) ([]compliance.Finding, []string, error) {
+	var evaluated []string
+	for _, c := range p.GetControls() {
+		if c.ScoreSection() != compliance.SectionCrypto {
+			evaluated = append(evaluated, c.ID)
+		}
+	}
+
+	findings := make([]compliance.Finding, 0, len(p.failing))
+	for _, id := range p.failing {
+		findings = append(findings, compliance.Finding{
+			Type:       "compliance",
+			Title:      id + " failed",
+			Severity:   "medium",
+			Component:  "fixture",
+			References: []string{id},
+		})
+	}
+
+	return findings, evaluated, nil
+}
+
+func (p *scoringFixturePlugin) GetControls() []compliance.Control {
+	return []compliance.Control{
+		{ID: "NET-1", Category: "Network Segmentation", Severity: "high"},
+		{ID: "NET-2", Category: "Port Filtering", Severity: "medium"},
+		{ID: "ACC-1", Category: "Authentication", Severity: "critical"},
+		{ID: "LOG-1", Category: "Logging", Severity: "low"},
+		{ID: "SVC-1", Category: "DNS Security", Severity: "medium"},
+		{ID: "VPN-1", Category: "VPN Configuration", Severity: "high"},
+		{ID: "VPN-2", Category: "Encryption", Severity: "medium"},
+	}
+}
+
+func (p *scoringFixturePlugin) GetControlByID(_ string) (*compliance.Control, error) {
+	return nil, compliance.ErrControlNotFound
+}
+
+func (p *scoringFixturePlugin) ValidateConfiguration() error { return nil }
+
+// generateScoredReport runs a blue audit with plugins against device.
+func generateScoredReport(t *testing.T, device *common.CommonDevice, plugins ...compliance.Plugin) *Report {
+	t.Helper()
+
+	registry := NewPluginRegistry()
+	for _, p := range plugins {
+		require.NoError(t, registry.RegisterPlugin(p))
+	}
+
+	report, err := NewModeController(registry, newTestLogger(t)).
+		GenerateReport(context.Background(), device, &ModeConfig{Mode: ModeBlue})
+	require.NoError(t, err)
+
+	return report
+}
+
+func TestComputeBenchmarkScore_ExcludesNotApplicableSection(t *testing.T) {
+	t.Parallel()
+
+	report := generateScoredReport(t, &common.CommonDevice{},
+		&scoringFixturePlugin{failing: []string{"NET-2", "LOG-1"}})
+	require.NotNil(t, report.BenchmarkScore)
+
+	score := report.BenchmarkScore
+	sections := make(map[string]common.SectionScore, len(score.Sections))
+	for _, s := range score.Sections {
+		sections[s.Section] = s
+	}
+
+	// Network: NET-1 (high, 3) passed, NET-2 (medium, 2) failed.
+	assert.InDelta(t, 0.6, sections[compliance.SectionNetwork].Score, 0.0001)
+	assert.Equal(t, "D", sections[compliance.SectionNetwork].Grade)
+	assert.Equal(t, 1, sections[compliance.SectionNetwork].PassedChecks)
+	assert.Equal(t, 2, sections[compliance.SectionNetwork].ApplicableChecks)
+
+	assert.Equal(t, "A", sections[compliance.SectionAccessControl].Grade)
+	assert.Equal(t, "F", sections[compliance.SectionLogging].Grade)
+	assert.Equal(t, "A", sections[compliance.SectionServices].Grade)
+
+	// Crypto is wholly not applicable: no grade and no weight.
+	crypto := sections[compliance.SectionCrypto]
+	assert.Equal(t, gradeNotApplicable, crypto.Grade)
+	assert.Equal(t, 0, crypto.ApplicableChecks)
+	assert.Equal(t, 2, crypto.NotApplicableChecks)
+	assert.Zero(t, crypto.ApplicableWeight)
+
+	// Overall: passed weight 3+4+2 over applicable weight 3+2+4+1+2. Counting
+	// the two crypto controls as failures would give 9/17 instead.
+	assert.InDelta(t, 0.75, score.Score, 0.0001)
+	assert.Equal(t, "C", score.Grade)
+	assert.Equal(t, 5, score.ApplicableChecks)
+}
+
+func TestComputeBenchmarkScore_SectionOrder(t *testing.T) {
+	t.Parallel()
+
+	score := computeBenchmarkScore(map[string]ComplianceResult{})
+
+	got := make([]string, 0, len(score.Sections))
+	for _, s := range score.Sections {
+		got = append(got, s.Section)
+		assert.Equal(t, gradeNotApplicable, s.Grade)
+	}
+
+	assert.Equal(t, compliance.ScoreSections(), got)
+	assert.Equal(t, gradeNotApplicable, score.Grade)
+	assert.Zero(t, score.ApplicableChecks)
+}
+
+func TestComputeBenchmarkScore_Deterministic(t *testing.T) {
+	t.Parallel()
+
+	device := &common.CommonDevice{
+		System: common.System{Hostname: "fw", Domain: "example.com"},
+		FirewallRules: []common.FirewallRule{
+			{Type: common.RuleTypePass, Interfaces: []string{"wan"}, Description: "allow any"},
+		},
+	}
+
+	first := generateScoredReport(t, device, stig.NewPlugin(), sans.NewPlugin(), firewall.NewPlugin())
+	require.NotNil(t, first.BenchmarkScore)
+	require.Positive(t, first.BenchmarkScore.ApplicableChecks)
+
+	for range 10 {
+		again := generateScoredReport(t, device, firewall.NewPlugin(), sans.NewPlugin(), stig.NewPlugin())
+		require.Equal(t, first.BenchmarkScore, again.BenchmarkScore)
+		require.Equal(t, first.BenchmarkScore, computeBenchmarkScore(first.Compliance))
+	}
+}
+
+func TestGenerateReport_BenchmarkScoreBlueOnly(t *testing.T) {
+	t.Parallel()
+
+	registry := NewPluginRegistry()
+	require.NoError(t, registry.RegisterPlugin(&scoringFixturePlugin{}))
+	controller := NewModeController(registry, newTestLogger(t))
+
+	red, err := controller.GenerateReport(context.Background(), &common.CommonDevice{}, &ModeConfig{Mode: ModeRed})
+	require.NoError(t, err)
+	assert.Nil(t, red.BenchmarkScore)
+}
+
+func TestScoreGrade(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		score float64
+		want  string
+	}{
+		{1, "A"},
+		{0.9, "A"},
+		{0.89, "B"},
+		{0.8, "B"},
+		{0.75, "C"},
+		{0.6, "D"},
+		{0.59, "F"},
+		{0, "F"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, ScoreGrade(tt.score), "ScoreGrade(%v)", tt.score)
+	}
+}
+
+func TestCheckMinimumScore(t *testing.T) {
+	t.Parallel()
+
+	scored := &common.BenchmarkScore{Score: 0.75, Grade: "C", ApplicableChecks: 5}
+
+	tests := []struct {
+		name    string
+		score   *common.BenchmarkScore
+		minimum float64
+		wantErr bool
+	}{
+		{"disabled", scored, 0, false},
+		{"at minimum", scored, 0.75, false},
+		{"above minimum", scored, 0.7, false},
+		{"below minimum", scored, 0.8, true},
+		{"no score", nil, 0.5, true},
+		{"no applicable checks", &common.BenchmarkScore{Grade: gradeNotApplicable}, 0.5, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := CheckMinimumScore(tt.score, tt.minimum)
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				return
+			}
+
+			require.ErrorIs(t, err, ErrScoreBelowMinimum)
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package cfgparser
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/EvilBit-Labs/opnDossier/pkg/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestXMLParser_RejectsDTD parses a config.xml whose DOCTYPE declares an
+// external entity and checks it is refused before the entity is resolved.
+func TestXMLParser_RejectsDTD(t *testing.T) {
+	content, err := os.ReadFile(filepath.Join("testdata", "doctype-xxe.xml"))
+	require.NoError(t, err)
+
+	doc, err := NewXMLParser().Parse(context.Background(), bytes.NewReader(content))
+	require.ErrorIs(t, err, parser.ErrDTDNotSupported)
+	assert.Nil(t, doc)
+	assert.False(t, IsParseError(err), "limit errors stay distinguishable from syntax errors")
+
+	var limitErr *parser.LimitError
+	require.ErrorAs(t, err, &limitErr)
+	assert.Equal(t, int64(strings.Index(string(content), "<!DOCTYPE")), limitErr.Offset)
+}
+
+func TestXMLParser_Limits(t *testing.T) {
+	deep := `<opnsense><system><hostname>deep</hostname></system><filter>` +
+		strings.Repeat("<a>", 20) + strings.Repeat("</a>", 20) + `</filter></opnsense>`
+
+	p := NewXMLParser()
+	_, err := p.Parse(context.Background(), strings.NewReader(deep))
+	require.NoError(t, err)
+
+	p.MaxElementDepth = 10
+	_, err = p.Parse(context.Background(), strings.NewReader(deep))
+	require.ErrorIs(t, err, parser.ErrElementTooDeep)
+
+	p = NewXMLParser()
+	p.MaxTokenSize = 16
+	_, err = p.Parse(context.Background(), strings.NewReader(
+		`<opnsense><system><hostname>a-very-long-hostname</hostname></system></opnsense>`))
+	require.ErrorIs(t, err, parser.ErrTokenTooLarge)
+}
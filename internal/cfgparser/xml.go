@@ -16,12 +16,21 @@ import (
 // Parser size limits to prevent XML bomb attacks.
 const (
 	// DefaultMaxInputSize is the default maximum size in bytes for XML input to prevent XML bombs.
-	DefaultMaxInputSize = 10 * 1024 * 1024 // 10MB
+	DefaultMaxInputSize = parser.DefaultMaxInputSize
+	// DefaultMaxElementDepth is the default maximum element nesting depth.
+	DefaultMaxElementDepth = parser.DefaultMaxElementDepth
+	// DefaultMaxTokenSize is the default maximum size in bytes of a single XML token.
+	DefaultMaxTokenSize = parser.DefaultMaxTokenSize
 )
 
 // ErrMissingOpnSenseDocumentRoot is returned when the XML document is missing the required opnsense root element.
 var ErrMissingOpnSenseDocumentRoot = errors.New("invalid XML: missing opnsense root element")
 
+// ErrMissingSystemSection is returned when the opnsense root element has no
+// system section. Such a document cannot be normalized; every other section
+// is optional and problems inside it are reported as conversion warnings.
+var ErrMissingSystemSection = errors.New("invalid configuration: missing system section")
+
 // Parser is the interface for parsing OPNsense configuration files.
 type Parser interface {
 	Parse(ctx context.Context, r io.Reader) (*schema.OpnSenseDocument, error)
@@ -33,27 +42,39 @@ type Parser interface {
 type XMLParser struct {
 	// MaxInputSize is the maximum size in bytes for XML input to prevent XML bombs
 	MaxInputSize int64
+	// MaxElementDepth is the maximum element nesting depth
+	MaxElementDepth int
+	// MaxTokenSize is the maximum size in bytes of a single XML token
+	MaxTokenSize int64
 }
 
-// NewXMLParser returns a new XMLParser instance with the default maximum input size for secure OPNsense XML configuration parsing.
+// NewXMLParser returns a new XMLParser instance with the default input limits for secure OPNsense XML configuration parsing.
 func NewXMLParser() *XMLParser {
 	return &XMLParser{
-		MaxInputSize: DefaultMaxInputSize,
+		MaxInputSize:    DefaultMaxInputSize,
+		MaxElementDepth: DefaultMaxElementDepth,
+		MaxTokenSize:    DefaultMaxTokenSize,
 	}
 }
 
 // Parse parses an OPNsense configuration file with security protections using streaming to minimize memory usage.
 // The streaming approach processes XML tokens individually rather than loading the entire document into memory,
 // providing better memory efficiency for large configuration files while maintaining security protections
-// against XML bombs, XXE attacks, and excessive entity expansion.
+// against XML bombs, XXE attacks, and excessive entity expansion. Input that breaks one of the
+// parser's limits or contains a DTD fails with a *parser.LimitError.
 // The context is checked periodically to support cancellation of long-running parse operations.
 func (p *XMLParser) Parse(ctx context.Context, r io.Reader) (*schema.OpnSenseDocument, error) {
-	dec := parser.NewSecureXMLDecoder(r, p.MaxInputSize)
+	dec, enc := parser.NewLimitedXMLDecoder(r, parser.Limits{
+		MaxInputSize:    p.MaxInputSize,
+		MaxElementDepth: p.MaxElementDepth,
+		MaxTokenSize:    p.MaxTokenSize,
+	})
 	// OPNsense-specific decoder settings for streaming token parsing.
 	dec.DefaultSpace = ""
 	dec.AutoClose = xml.HTMLAutoClose
 
 	var doc schema.OpnSenseDocument
+	sawSystem := false
 	for {
 		// Check for context cancellation to support timeouts and cancellation
 		select {
@@ -71,6 +92,7 @@ func (p *XMLParser) Parse(ctx context.Context, r io.Reader) (*schema.OpnSenseDoc
 		}
 
 		if startElem, ok := tok.(xml.StartElement); ok {
+			sawSystem = sawSystem || startElem.Name.Local == "system"
 			if err := handleStartElement(dec, &doc, startElem); err != nil {
 				return nil, err
 			}
@@ -87,11 +109,21 @@ func (p *XMLParser) Parse(ctx context.Context, r io.Reader) (*schema.OpnSenseDoc
 		return nil, ErrMissingOpnSenseDocumentRoot
 	}
 
+	if !sawSystem {
+		return nil, ErrMissingSystemSection
+	}
+
+	doc.Encoding = *enc
+
 	return &doc, nil
 }
 
-// handleXMLError processes XML syntax errors.
+// handleXMLError processes XML syntax errors. Limit violations are returned
+// as is so callers can explain them.
 func handleXMLError(err error, dec *xml.Decoder) error {
+	if _, ok := errors.AsType[*parser.LimitError](err); ok {
+		return fmt.Errorf("failed to decode XML: %w", err)
+	}
 	if wrappedErr := WrapXMLSyntaxErrorWithOffset(err, "opnsense", dec); wrappedErr != nil {
 		return fmt.Errorf("failed to decode XML: %w", wrappedErr)
 	}
@@ -145,6 +177,10 @@ func handleStartElement(dec *xml.Decoder, doc *schema.OpnSenseDocument, se xml.S
 		return decodeChild(dec, &doc.Ntpd, se)
 	case "widgets":
 		return decodeChild(dec, &doc.Widgets, se)
+	case "rrddata":
+		return decodeChild(dec, &doc.RRDData, se)
+	case "notifications":
+		return decodeChild(dec, &doc.Notifications, se)
 	case "revision":
 		return decodeChild(dec, &doc.Revision, se)
 	case "gateways":
@@ -189,10 +225,31 @@ func handleStartElement(dec *xml.Decoder, doc *schema.OpnSenseDocument, se xml.S
 		}
 		doc.Certs = append(doc.Certs, cert)
 		return nil
+	case "crl":
+		var crl schema.CRL
+		if err := decodeChild(dec, &crl, se); err != nil {
+			return err
+		}
+		doc.CRLs = append(doc.CRLs, crl)
+		return nil
 	case "dnsmasq":
 		return decodeChild(dec, &doc.DNSMasquerade, se)
 	case "syslog":
 		return decodeChild(dec, &doc.Syslog, se)
+	case "pf":
+		return decodeChild(dec, &doc.PF, se)
+	case "wol":
+		return decodeChild(dec, &doc.WOL, se)
+	case "pptpd":
+		return decodeChild(dec, &doc.PPTPD, se)
+	case "l2tp":
+		return decodeChild(dec, &doc.L2TP, se)
+	case "queuestats":
+		return decodeChild(dec, &doc.QueueStats, se)
+	case "schedules":
+		return decodeChild(dec, &doc.Schedules, se)
+	case "installedpackages":
+		return decodeChild(dec, &doc.InstalledPackages, se)
 	case "OPNsense":
 		return decodeChild(dec, &doc.OPNsense, se)
 	default:
@@ -86,6 +86,76 @@ func TestXMLParser_Parse(t *testing.T) {
 	}
 }
 
+func TestXMLParser_CosmeticSections(t *testing.T) {
+	input := `<opnsense><theme>opnsense</theme>` +
+		`<widgets><sequence>traffic_graphs-container:00000001-col2:show</sequence>` +
+		`<widget id="traffic_graphs"><interfaces>lan</interfaces></widget></widgets>` +
+		`<rrddata><rrddatafile><filename>wan-traffic.rrd</filename></rrddatafile></rrddata>` +
+		`<notifications><smtp><ipaddress>mail.example.com</ipaddress></smtp></notifications>` +
+		`<system><hostname>fw</hostname><domain>example.com</domain></system></opnsense>`
+
+	doc, err := NewXMLParser().Parse(context.Background(), strings.NewReader(input))
+	require.NoError(t, err)
+
+	assert.Equal(t, "opnsense", doc.Theme)
+	require.Len(t, doc.Widgets.Extra, 1)
+	assert.Equal(t, `<interfaces>lan</interfaces>`, doc.Widgets.Extra[0].InnerXML)
+	require.NotNil(t, doc.RRDData)
+	assert.Contains(t, doc.RRDData.InnerXML, "<filename>wan-traffic.rrd</filename>")
+	require.NotNil(t, doc.Notifications)
+	assert.Contains(t, doc.Notifications.InnerXML, "<ipaddress>mail.example.com</ipaddress>")
+	assert.Equal(t, "fw", doc.System.Hostname, "sections after the raw blobs must still be parsed")
+}
+
+func TestXMLParser_CRLs(t *testing.T) {
+	input := `<opnsense><system><hostname>fw</hostname><domain>example.com</domain></system>` +
+		`<crl><refid>crl-1</refid><descr>VPN CRL</descr><lifetime>365</lifetime>` +
+		`<cert><refid>cert-1</refid><reason>1</reason><revoke_time>1700000000</revoke_time></cert></crl>` +
+		`<crl><refid>crl-2</refid><method>existing</method></crl></opnsense>`
+
+	doc, err := NewXMLParser().Parse(context.Background(), strings.NewReader(input))
+	require.NoError(t, err)
+
+	require.Len(t, doc.CRLs, 2)
+	crl := doc.FindCRLByRef("crl-1")
+	require.NotNil(t, crl)
+	assert.Equal(t, "VPN CRL", crl.Descr)
+	assert.Equal(t, "365", crl.Lifetime)
+	require.Len(t, crl.Certs, 1)
+	assert.Equal(t, schema.RevocationReasonKeyCompromise, crl.Certs[0].Reason)
+	assert.Equal(t, "1700000000", crl.Certs[0].RevokeTime)
+	assert.Equal(t, "existing", doc.CRLs[1].Method)
+}
+
+func TestXMLParser_Schedules(t *testing.T) {
+	input := `<opnsense><system><hostname>fw</hostname><domain>example.com</domain></system>` +
+		`<filter><rule><type>pass</type><interface>lan</interface><sched>OfficeHours</sched></rule></filter>` +
+		`<schedules><schedule><name>OfficeHours</name><descr>Weekdays</descr>` +
+		`<timerange><position>1,2,3,4,5</position><hour>8:00-17:00</hour><rangedescr>Work</rangedescr></timerange>` +
+		`</schedule></schedules></opnsense>`
+
+	doc, err := NewXMLParser().Parse(context.Background(), strings.NewReader(input))
+	require.NoError(t, err)
+
+	require.Len(t, doc.Filter.Rule, 1)
+	assert.Equal(t, "OfficeHours", doc.Filter.Rule[0].Sched)
+	require.Len(t, doc.Schedules.Schedule, 1)
+	sched := doc.Schedules.Schedule[0]
+	assert.Equal(t, "OfficeHours", sched.Name)
+	assert.Equal(t, "Weekdays", sched.Descr)
+	assert.Equal(t, []schema.ScheduleTimeRange{
+		{Position: "1,2,3,4,5", Hour: "8:00-17:00", RangeDescr: "Work"},
+	}, sched.TimeRanges)
+}
+
+func TestXMLParser_MissingSystemSection(t *testing.T) {
+	input := `<opnsense><interfaces><lan><if>em1</if></lan></interfaces></opnsense>`
+
+	doc, err := NewXMLParser().Parse(context.Background(), strings.NewReader(input))
+	require.ErrorIs(t, err, ErrMissingSystemSection)
+	assert.Nil(t, doc)
+}
+
 func TestXMLParser_ISO8859_1Encoding(t *testing.T) {
 	parser := NewXMLParser()
 	fixturePath := filepath.Join("testdata", "iso8859-1-basic.xml")
@@ -96,6 +166,7 @@ func TestXMLParser_ISO8859_1Encoding(t *testing.T) {
 	require.NotNil(t, opnsense)
 	assert.Equal(t, "Configuración", opnsense.System.Hostname)
 	assert.Equal(t, "español.local", opnsense.System.Domain)
+	assert.Equal(t, schema.InputEncoding{Name: "ISO-8859-1"}, opnsense.Encoding)
 }
 
 func TestXMLParser_Windows1252Encoding(t *testing.T) {
@@ -108,6 +179,7 @@ func TestXMLParser_Windows1252Encoding(t *testing.T) {
 	require.NotNil(t, opnsense)
 	require.NotEmpty(t, opnsense.System.User)
 	assert.Equal(t, "Cost €100 – “quoted” ‘single’", opnsense.System.User[0].Descr)
+	assert.Equal(t, schema.InputEncoding{Name: "Windows-1252"}, opnsense.Encoding)
 }
 
 func TestXMLParser_MixedEncodings(t *testing.T) {
@@ -49,8 +49,52 @@ type Plugin interface {
 	ValidateConfiguration() error
 }
 
+// CheckStatus is the execution status of a control's check in an audit run.
+type CheckStatus string
+
+// Check execution statuses.
+const (
+	// CheckStatusPassed means the check ran and produced no findings.
+	CheckStatusPassed CheckStatus = "passed"
+	// CheckStatusFindings means the check ran and produced findings.
+	CheckStatusFindings CheckStatus = "findings"
+	// CheckStatusSkipped means the check did not run because the device
+	// lacks the data or feature it evaluates.
+	CheckStatusSkipped CheckStatus = "skipped"
+	// CheckStatusErrored means the check failed or panicked before it
+	// could reach a result.
+	CheckStatusErrored CheckStatus = "errored"
+)
+
+// CheckOutcome explains a control whose check did not reach a result.
+type CheckOutcome struct {
+	// Status is CheckStatusSkipped or CheckStatusErrored.
+	Status CheckStatus
+	// Reason says why the check was skipped, or carries the error or panic
+	// message of an errored check.
+	Reason string
+}
+
+// OutcomeReporter is an optional interface for plugins that can explain
+// skipped and errored checks. When a plugin implements it, the registry
+// calls RunChecksWithOutcomes instead of RunChecks.
+//
+// The findings, evaluated, and err results follow the RunChecks contract.
+// outcomes maps control IDs to the outcome of checks that did not reach a
+// result; controls missing from both evaluated and outcomes are reported as
+// skipped without a specific reason.
+type OutcomeReporter interface {
+	RunChecksWithOutcomes(device *common.CommonDevice) (
+		findings []Finding, evaluated []string, outcomes map[string]CheckOutcome, err error,
+	)
+}
+
 // Control represents a single compliance control.
 // This is a standardized structure that all plugins must use.
+//
+// Section and Weight place the control in the benchmark score. Both are
+// optional: when empty, ScoreSection derives the section from Category and
+// ScoreWeight derives the weight from Severity.
 type Control struct {
 	ID          string            `json:"id"`
 	Title       string            `json:"title"`
@@ -62,6 +106,8 @@ type Control struct {
 	References  []string          `json:"references,omitempty"`
 	Tags        []string          `json:"tags,omitempty"`
 	Metadata    map[string]string `json:"metadata,omitempty"`
+	Section     string            `json:"section,omitempty"`
+	Weight      float64           `json:"weight,omitempty"`
 }
 
 // CloneControl returns a deep copy of a Control, cloning nested reference
@@ -0,0 +1,101 @@
+package compliance
+
+import (
+	"slices"
+	"strings"
+)
+
+// Benchmark score sections. Every control is scored in exactly one section.
+const (
+	SectionNetwork       = "network"
+	SectionAccessControl = "access control"
+	SectionLogging       = "logging"
+	SectionServices      = "services"
+	SectionCrypto        = "crypto"
+)
+
+// Default control weights by severity, used when a control sets no Weight.
+const (
+	WeightCritical = 4.0
+	WeightHigh     = 3.0
+	WeightMedium   = 2.0
+	WeightLow      = 1.0
+)
+
+// ScoreSections returns the benchmark score sections in report order.
+func ScoreSections() []string {
+	return []string{SectionNetwork, SectionAccessControl, SectionLogging, SectionServices, SectionCrypto}
+}
+
+// categorySections maps the lower-cased control categories used by the
+// built-in plugins to their benchmark score section.
+var categorySections = map[string]string{
+	"anti-spoofing":           SectionNetwork,
+	"availability":            SectionNetwork,
+	"default deny policy":     SectionNetwork,
+	"dos prevention":          SectionNetwork,
+	"firewall rule hygiene":   SectionNetwork,
+	"high availability":       SectionNetwork,
+	"nat security":            SectionNetwork,
+	"network architecture":    SectionNetwork,
+	"network configuration":   SectionNetwork,
+	"network segmentation":    SectionNetwork,
+	"packet filtering":        SectionNetwork,
+	"port filtering":          SectionNetwork,
+	"rule management":         SectionNetwork,
+	"ruleset and filtering":   SectionNetwork,
+	"stateful inspection":     SectionNetwork,
+	"access control":          SectionAccessControl,
+	"authentication":          SectionAccessControl,
+	"management access":       SectionAccessControl,
+	"ssh security":            SectionAccessControl,
+	"change management":       SectionLogging,
+	"configuration inventory": SectionLogging,
+	"logging":                 SectionLogging,
+	"logging and monitoring":  SectionLogging,
+	"time synchronization":    SectionLogging,
+	"backup and recovery":     SectionServices,
+	"dns security":            SectionServices,
+	"maintenance":             SectionServices,
+	"server protection":       SectionServices,
+	"service hardening":       SectionServices,
+	"snmp security":           SectionServices,
+	"system configuration":    SectionServices,
+	"encryption":              SectionCrypto,
+	"vpn configuration":       SectionCrypto,
+}
+
+// ScoreSection returns the benchmark score section of the control: Section
+// when it names one of ScoreSections, otherwise the section of its Category.
+// Controls in a category no section claims are scored under services.
+func (c Control) ScoreSection() string {
+	if section := strings.ToLower(strings.TrimSpace(c.Section)); slices.Contains(ScoreSections(), section) {
+		return section
+	}
+
+	if section, ok := categorySections[strings.ToLower(strings.TrimSpace(c.Category))]; ok {
+		return section
+	}
+
+	return SectionServices
+}
+
+// ScoreWeight returns the weight of the control in the benchmark score:
+// Weight when positive, otherwise the default weight for its Severity.
+// Controls with an unrecognized severity weigh as much as low ones.
+func (c Control) ScoreWeight() float64 {
+	if c.Weight > 0 {
+		return c.Weight
+	}
+
+	switch strings.ToLower(c.Severity) {
+	case "critical":
+		return WeightCritical
+	case "high":
+		return WeightHigh
+	case "medium":
+		return WeightMedium
+	default:
+		return WeightLow
+	}
+}
@@ -0,0 +1,63 @@
+package compliance_test
+
+import (
+	"testing"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/compliance"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestControl_ScoreSection(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		control compliance.Control
+		want    string
+	}{
+		{"category maps to section", compliance.Control{Category: "VPN Configuration"}, compliance.SectionCrypto},
+		{"category match ignores case", compliance.Control{Category: "management access"}, compliance.SectionAccessControl},
+		{
+			"explicit section overrides category",
+			compliance.Control{Category: "Logging", Section: "Network"},
+			compliance.SectionNetwork,
+		},
+		{
+			"unknown explicit section falls back to category",
+			compliance.Control{Category: "Logging", Section: "physical"},
+			compliance.SectionLogging,
+		},
+		{"unknown category scores under services", compliance.Control{Category: "Custom"}, compliance.SectionServices},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.control.ScoreSection())
+		})
+	}
+}
+
+func TestControl_ScoreWeight(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		control compliance.Control
+		want    float64
+	}{
+		{"critical", compliance.Control{Severity: "critical"}, compliance.WeightCritical},
+		{"high", compliance.Control{Severity: "High"}, compliance.WeightHigh},
+		{"medium", compliance.Control{Severity: "medium"}, compliance.WeightMedium},
+		{"low", compliance.Control{Severity: "low"}, compliance.WeightLow},
+		{"unknown severity weighs as low", compliance.Control{Severity: "severe"}, compliance.WeightLow},
+		{"explicit weight overrides severity", compliance.Control{Severity: "low", Weight: 7.5}, 7.5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.InDelta(t, tt.want, tt.control.ScoreWeight(), 0.0001)
+		})
+	}
+}
@@ -111,9 +111,14 @@ var ValidOptimizationModes = map[string]struct{}{
 	"conservative": {},
 }
 
-// ValidPowerdModes defines the allowed powerd power modes.
+// ValidPowerdModes defines the allowed powerd power modes. Current firmware
+// writes the short spellings ("adp", "min", "max", "hadp"); the long
+// spellings are accepted as well.
 // Shared by processor and validator packages — single source of truth.
 var ValidPowerdModes = map[string]struct{}{
+	"adp":        {},
+	"min":        {},
+	"max":        {},
 	"hadp":       {},
 	"hiadp":      {},
 	"hadaptive":  {},
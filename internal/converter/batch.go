@@ -0,0 +1,256 @@
+package converter
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/cfgparser"
+	"github.com/EvilBit-Labs/opnDossier/internal/converter/builder"
+	"github.com/EvilBit-Labs/opnDossier/internal/logging"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/EvilBit-Labs/opnDossier/pkg/parser"
+)
+
+// reportFileMode is the permission mode of reports written by writeReportFile.
+const reportFileMode = 0o600
+
+// ConvertOptions configures BatchConvert.
+type ConvertOptions struct {
+	// Options controls how each report is rendered. Its Format also selects
+	// the report file extension.
+	Options
+
+	// DeviceType forces the parser used for every input. Empty or
+	// DeviceTypeUnknown detects each file's device type from its root element.
+	DeviceType common.DeviceType
+
+	// Parallelism is the number of files processed at once. Values below 1
+	// use runtime.NumCPU().
+	Parallelism int
+
+	// Force overwrites reports that already exist in the output directory.
+	Force bool
+
+	// Logger receives per-file conversion warnings. Nil uses a default
+	// logger writing to stderr.
+	Logger *logging.Logger
+
+	// Export writes a rendered report to path. Nil writes the file directly
+	// with mode 0600; the CLI passes its file exporter so batch reports are
+	// written atomically like single-file output.
+	Export func(ctx context.Context, content, path string) error
+}
+
+// BatchResult is the outcome of converting one BatchConvert input.
+type BatchResult struct {
+	// Input is the configuration file path as passed to BatchConvert.
+	Input string
+	// Output is the report path, empty when the input failed before a report
+	// name was chosen.
+	Output string
+	// Err is the error that stopped this input, nil on success. A
+	// *builder.PartialReportError means the report was written with
+	// placeholders for the sections that failed to render.
+	Err error
+}
+
+// BatchConvert converts each input configuration file into a report named
+// <hostname>-report.<ext> in outputDir, using a pool of opts.Parallelism
+// workers. Results are returned in input order; a failing input does not stop
+// the others.
+//
+// Inputs are parsed first so report names can be assigned in input order: a
+// device without a hostname is named after its input file, and a name already
+// taken by an earlier input gets a numeric suffix (fw-2-report.md). Existing
+// reports are refused with ErrOutputExists unless opts.Force is set.
+func BatchConvert(ctx context.Context, inputs []string, outputDir string, opts ConvertOptions) []BatchResult {
+	results := make([]BatchResult, len(inputs))
+	for i, input := range inputs {
+		results[i].Input = input
+	}
+
+	handler, err := DefaultRegistry.Get(string(opts.Format))
+	if err == nil {
+		opts.Logger, err = ensureLogger(opts.Logger)
+	}
+	if err != nil {
+		for i := range results {
+			results[i].Err = err
+		}
+		return results
+	}
+
+	workers := opts.Parallelism
+	if workers < 1 {
+		workers = runtime.NumCPU()
+	}
+
+	devices := make([]*common.CommonDevice, len(inputs))
+	runBatchWorkers(workers, len(inputs), func(i int) {
+		devices[i], results[i].Err = parseBatchInput(ctx, inputs[i], opts)
+	})
+
+	used := make(map[string]bool, len(inputs))
+	for i, device := range devices {
+		if device != nil {
+			results[i].Output = filepath.Join(outputDir,
+				batchReportName(device.System.Hostname, inputs[i], handler.FileExtension(), used))
+		}
+	}
+
+	if opts.Export == nil {
+		opts.Export = writeReportFile
+	}
+
+	runBatchWorkers(workers, len(inputs), func(i int) {
+		if devices[i] != nil {
+			results[i].Err = writeBatchReport(ctx, devices[i], results[i].Output, opts)
+		}
+	})
+
+	return results
+}
+
+// runBatchWorkers calls work once for every index below n from at most
+// workers goroutines and returns when all calls have finished.
+func runBatchWorkers(workers, n int, work func(i int)) {
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for range min(workers, n) {
+		wg.Go(func() {
+			for i := range jobs {
+				work(i)
+			}
+		})
+	}
+
+	for i := range n {
+		jobs <- i
+	}
+	close(jobs)
+
+	wg.Wait()
+}
+
+// parseBatchInput parses the configuration file at path into a CommonDevice,
+// logging each conversion warning unless opts.SuppressWarnings is set.
+func parseBatchInput(ctx context.Context, path string, opts ConvertOptions) (*common.CommonDevice, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	file, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file %s: %w", path, err)
+	}
+	defer func() {
+		if cerr := file.Close(); cerr != nil {
+			opts.Logger.Error("failed to close file", "input_file", path, "error", cerr)
+		}
+	}()
+
+	device, warnings, err := parser.NewFactory(cfgparser.NewXMLParser()).
+		CreateDevice(ctx, file, opts.DeviceType, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse configuration from %s: %w", path, err)
+	}
+
+	if !opts.SuppressWarnings {
+		for _, w := range warnings {
+			opts.Logger.Warn("conversion warning", "input_file", path,
+				"field", w.Field, "value", w.Value, "message", w.Message, "action", w.Action, "severity", w.Severity)
+		}
+	}
+
+	return device, nil
+}
+
+// writeBatchReport renders device with opts and writes it to path. A report
+// with sections that failed to render is written before the
+// *builder.PartialReportError is returned.
+func writeBatchReport(
+	ctx context.Context,
+	device *common.CommonDevice,
+	path string,
+	opts ConvertOptions,
+) error {
+	if !opts.Force {
+		if _, err := os.Lstat(path); err == nil {
+			return fmt.Errorf("%w: %s (use --force to overwrite)", ErrOutputExists, path)
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("failed to check output file %s: %w", path, err)
+		}
+	}
+
+	gen, err := NewHybridGenerator(builder.NewMarkdownBuilder(builder.WithLogger(opts.Logger)), opts.Logger)
+	if err != nil {
+		return err
+	}
+
+	// A partial report is still written; its section failures are returned
+	// afterwards.
+	output, renderErr := gen.Generate(ctx, device, opts.Options)
+	if renderErr != nil && !isPartialReport(renderErr) {
+		return fmt.Errorf("failed to convert %s: %w", path, renderErr)
+	}
+
+	if err := opts.Export(ctx, output, path); err != nil {
+		return fmt.Errorf("failed to export output to %s: %w", path, err)
+	}
+
+	if renderErr != nil {
+		return fmt.Errorf("%s: %w", path, renderErr)
+	}
+
+	return nil
+}
+
+// writeReportFile is the default ConvertOptions.Export: it writes content to
+// path with mode 0600.
+func writeReportFile(_ context.Context, content, path string) error {
+	return os.WriteFile(path, []byte(content), reportFileMode)
+}
+
+// batchReportName returns the report file name for a device with hostname
+// parsed from input, and marks it used. The hostname is reduced to
+// characters safe in a file name; when none remain the input file name
+// without its extension is used. A name already in used gets the first free
+// numeric suffix.
+func batchReportName(hostname, input, ext string, used map[string]bool) string {
+	stem := strings.TrimSuffix(filepath.Base(input), filepath.Ext(input))
+	base := cmp.Or(safeFileName(hostname), safeFileName(stem), "config")
+
+	name := base + "-report" + ext
+	for n := 2; used[name]; n++ {
+		name = base + "-" + strconv.Itoa(n) + "-report" + ext
+	}
+	used[name] = true
+
+	return name
+}
+
+// safeFileName replaces every character of s other than ASCII letters,
+// digits, '-', '_' and '.' with '_', and trims leading dots and underscores
+// so the result is neither hidden nor a path element like "..".
+func safeFileName(s string) string {
+	mapped := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+			return r
+		default:
+			return '_'
+		}
+	}, strings.TrimSpace(s))
+
+	return strings.TrimLeft(mapped, "._")
+}
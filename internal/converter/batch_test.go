@@ -0,0 +1,103 @@
+package converter
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	_ "github.com/EvilBit-Labs/opnDossier/pkg/parser/opnsense" // registers the OPNsense parser
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeBatchConfig writes a minimal OPNsense config with hostname to dir/name
+// and returns its path.
+func writeBatchConfig(t *testing.T, dir, name, hostname string) string {
+	t.Helper()
+
+	path := filepath.Join(dir, name)
+	content := `<?xml version="1.0"?><opnsense><system><hostname>` + hostname +
+		`</hostname><domain>example.com</domain></system></opnsense>`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	return path
+}
+
+func TestBatchConvert(t *testing.T) {
+	t.Parallel()
+
+	inDir, outDir := t.TempDir(), t.TempDir()
+	inputs := []string{
+		writeBatchConfig(t, inDir, "site-a.xml", "fw-a"),
+		writeBatchConfig(t, inDir, "site-b.xml", "fw-b"),
+		filepath.Join(inDir, "missing.xml"),
+		writeBatchConfig(t, inDir, "site-c.xml", "fw-a"),
+		writeBatchConfig(t, inDir, "unnamed.xml", ""),
+	}
+
+	opts := ConvertOptions{Options: DefaultOptions().WithFormat(FormatJSON), Parallelism: 2}
+	results := BatchConvert(t.Context(), inputs, outDir, opts)
+	require.Len(t, results, len(inputs))
+
+	wantOutputs := []string{"fw-a-report.json", "fw-b-report.json", "", "fw-a-2-report.json", "unnamed-report.json"}
+	for i, r := range results {
+		assert.Equal(t, inputs[i], r.Input)
+
+		if wantOutputs[i] == "" {
+			require.Error(t, r.Err)
+			assert.Contains(t, r.Err.Error(), "failed to open file")
+			assert.Empty(t, r.Output)
+			continue
+		}
+
+		require.NoError(t, r.Err, r.Input)
+		assert.Equal(t, filepath.Join(outDir, wantOutputs[i]), r.Output)
+		data, err := os.ReadFile(r.Output)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), `"domain": "example.com"`)
+	}
+}
+
+func TestBatchConvert_ExistingOutput(t *testing.T) {
+	t.Parallel()
+
+	inDir, outDir := t.TempDir(), t.TempDir()
+	inputs := []string{writeBatchConfig(t, inDir, "config.xml", "fw")}
+	existing := filepath.Join(outDir, "fw-report.md")
+	require.NoError(t, os.WriteFile(existing, []byte("old"), 0o600))
+
+	results := BatchConvert(t.Context(), inputs, outDir, ConvertOptions{Options: DefaultOptions()})
+	require.Len(t, results, 1)
+	require.ErrorIs(t, results[0].Err, ErrOutputExists)
+
+	results = BatchConvert(t.Context(), inputs, outDir, ConvertOptions{Options: DefaultOptions(), Force: true})
+	require.NoError(t, results[0].Err)
+	data, err := os.ReadFile(existing)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "fw")
+	assert.NotEqual(t, "old", string(data))
+}
+
+func TestBatchConvert_UnsupportedFormat(t *testing.T) {
+	t.Parallel()
+
+	results := BatchConvert(t.Context(), []string{"a.xml", "b.xml"}, t.TempDir(),
+		ConvertOptions{Options: DefaultOptions().WithFormat("docx")})
+	require.Len(t, results, 2)
+	for _, r := range results {
+		require.ErrorIs(t, r.Err, ErrUnsupportedFormat)
+		assert.Empty(t, r.Output)
+	}
+}
+
+func TestBatchReportName(t *testing.T) {
+	t.Parallel()
+
+	used := map[string]bool{}
+	assert.Equal(t, "fw01-report.md", batchReportName("fw01", "a.xml", ".md", used))
+	assert.Equal(t, "fw01-2-report.md", batchReportName("fw01", "b.xml", ".md", used))
+	assert.Equal(t, "fw01-3-report.md", batchReportName("fw01", "c.xml", ".md", used))
+	assert.Equal(t, "branch-report.md", batchReportName("", "/configs/branch.xml", ".md", used))
+	assert.Equal(t, "etc_passwd-report.md", batchReportName("../etc/passwd", "x.xml", ".md", used))
+	assert.Equal(t, "config-report.md", batchReportName(" ", ".xml", ".md", used))
+}
@@ -2,13 +2,19 @@
 package builder
 
 import (
-	"bytes"
+	"errors"
+	"fmt"
+	"runtime/debug"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/EvilBit-Labs/opnDossier/internal/analysis"
 	"github.com/EvilBit-Labs/opnDossier/internal/constants"
+	"github.com/EvilBit-Labs/opnDossier/internal/converter/document"
 	"github.com/EvilBit-Labs/opnDossier/internal/converter/formatters"
 	"github.com/EvilBit-Labs/opnDossier/internal/logging"
+	"github.com/EvilBit-Labs/opnDossier/internal/progress"
 	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
 	"github.com/nao1215/markdown"
 )
@@ -32,48 +38,85 @@ type SectionBuilder interface {
 	BuildSecuritySection(data *common.CommonDevice) string
 	// BuildServicesSection builds the services configuration section.
 	BuildServicesSection(data *common.CommonDevice) string
+	// BuildNTPSection builds the NTP time server section.
+	BuildNTPSection(data *common.CommonDevice) string
+	// BuildNetflowSection builds the NetFlow export section.
+	BuildNetflowSection(data *common.CommonDevice) string
+	// BuildWOLSection builds the Wake-on-LAN hosts section.
+	BuildWOLSection(data *common.CommonDevice) string
+	// BuildUPnPSection builds the UPnP / NAT-PMP section.
+	BuildUPnPSection(data *common.CommonDevice) string
+	// BuildMonitSection builds the Monit service monitoring section.
+	BuildMonitSection(data *common.CommonDevice) string
+	// BuildTrafficShaperPipeSection builds the traffic shaper pipes and queues section.
+	BuildTrafficShaperPipeSection(data *common.CommonDevice) string
+	// BuildQueueStatsSection builds the traffic shaper queue statistics section.
+	BuildQueueStatsSection(data *common.CommonDevice) string
 	// BuildIPsecSection builds the IPsec VPN configuration section.
 	BuildIPsecSection(data *common.CommonDevice) string
 	// BuildOpenVPNSection builds the OpenVPN configuration section.
 	BuildOpenVPNSection(data *common.CommonDevice) string
+	// BuildLegacyVPNSection builds the legacy PPTP/L2TP remote access VPN section.
+	BuildLegacyVPNSection(data *common.CommonDevice) string
+	// BuildZeroTierSection builds the ZeroTier overlay VPN section.
+	BuildZeroTierSection(data *common.CommonDevice) string
 	// BuildHASection builds the High Availability and CARP configuration section.
 	BuildHASection(data *common.CommonDevice) string
 	// BuildIDSSection builds the IDS/Suricata configuration section.
 	BuildIDSSection(data *common.CommonDevice) string
+	// BuildPFSettingsSection builds the pf state table limits and timeouts section.
+	BuildPFSettingsSection(data *common.CommonDevice) string
+	// BuildInterfaceHeatmapSection builds the per-interface firewall rule count table.
+	BuildInterfaceHeatmapSection(data *common.CommonDevice) string
+	// BuildAliasesSection builds the firewall alias table.
+	BuildAliasesSection(data *common.CommonDevice) string
+	// BuildSchedulesSection builds the firewall schedules table.
+	BuildSchedulesSection(data *common.CommonDevice) string
+	// BuildPortExposureSection builds the table of ports reachable from the WAN.
+	BuildPortExposureSection(data *common.CommonDevice) string
+	// BuildInterfaceXRefSection builds the interface cross-reference appendix.
+	BuildInterfaceXRefSection(data *common.CommonDevice) string
+	// BuildEffectiveRulesSection builds the effective ruleset of one interface in pf evaluation order.
+	BuildEffectiveRulesSection(data *common.CommonDevice, iface string) string
+	// BuildChangeLogSection builds the table of recently modified firewall and NAT rules.
+	BuildChangeLogSection(data *common.CommonDevice) string
 	// BuildAuditSection builds the compliance audit section from the device's ComplianceResults.
 	BuildAuditSection(data *common.CommonDevice) string
+	// BuildDataQualitySection builds the Data Quality appendix from normalization issues.
+	BuildDataQualitySection(issues []common.ConversionWarning) string
 }
 
-// TableWriter defines methods for writing data tables into a markdown instance.
-// Each method appends a formatted table and returns the markdown for chaining.
+// TableWriter defines methods for writing data tables into a report document.
+// Each method appends a formatted table and returns the document for chaining.
 type TableWriter interface {
-	// WriteFirewallRulesTable writes a firewall rules table and returns md for chaining.
-	WriteFirewallRulesTable(md *markdown.Markdown, rules []common.FirewallRule) *markdown.Markdown
-	// WriteInterfaceTable writes an interfaces table and returns md for chaining.
-	WriteInterfaceTable(md *markdown.Markdown, interfaces []common.Interface) *markdown.Markdown
-	// WriteUserTable writes a users table and returns md for chaining.
-	WriteUserTable(md *markdown.Markdown, users []common.User) *markdown.Markdown
-	// WriteGroupTable writes a groups table and returns md for chaining.
-	WriteGroupTable(md *markdown.Markdown, groups []common.Group) *markdown.Markdown
-	// WriteSysctlTable writes a sysctl tunables table and returns md for chaining.
-	WriteSysctlTable(md *markdown.Markdown, sysctl []common.SysctlItem) *markdown.Markdown
-	// WriteOutboundNATTable writes an outbound NAT rules table and returns md for chaining.
-	WriteOutboundNATTable(md *markdown.Markdown, rules []common.NATRule) *markdown.Markdown
-	// WriteInboundNATTable writes an inbound NAT/port forward rules table and returns md for chaining.
-	WriteInboundNATTable(md *markdown.Markdown, rules []common.InboundNATRule) *markdown.Markdown
-	// WriteVLANTable writes a VLAN configurations table and returns md for chaining.
-	WriteVLANTable(md *markdown.Markdown, vlans []common.VLAN) *markdown.Markdown
-	// WriteStaticRoutesTable writes a static routes table and returns md for chaining.
-	WriteStaticRoutesTable(md *markdown.Markdown, routes []common.StaticRoute) *markdown.Markdown
-	// WriteDHCPSummaryTable writes a DHCP summary table and returns md for chaining.
-	WriteDHCPSummaryTable(md *markdown.Markdown, scopes []common.DHCPScope) *markdown.Markdown
-	// WriteDHCPStaticLeasesTable writes a static leases table and returns md for chaining.
-	WriteDHCPStaticLeasesTable(md *markdown.Markdown, leases []common.DHCPStaticLease) *markdown.Markdown
+	// WriteFirewallRulesTable writes a firewall rules table and returns doc for chaining.
+	WriteFirewallRulesTable(doc *document.Document, rules []common.FirewallRule) *document.Document
+	// WriteInterfaceTable writes an interfaces table and returns doc for chaining.
+	WriteInterfaceTable(doc *document.Document, interfaces []common.Interface) *document.Document
+	// WriteUserTable writes a users table and returns doc for chaining.
+	WriteUserTable(doc *document.Document, users []common.User) *document.Document
+	// WriteGroupTable writes a groups table and returns doc for chaining.
+	WriteGroupTable(doc *document.Document, groups []common.Group) *document.Document
+	// WriteSysctlTable writes a sysctl tunables table and returns doc for chaining.
+	WriteSysctlTable(doc *document.Document, sysctl []common.SysctlItem) *document.Document
+	// WriteOutboundNATTable writes an outbound NAT rules table and returns doc for chaining.
+	WriteOutboundNATTable(doc *document.Document, rules []common.NATRule) *document.Document
+	// WriteInboundNATTable writes an inbound NAT/port forward rules table and returns doc for chaining.
+	WriteInboundNATTable(doc *document.Document, rules []common.InboundNATRule) *document.Document
+	// WriteVLANTable writes a VLAN configurations table and returns doc for chaining.
+	WriteVLANTable(doc *document.Document, vlans []common.VLAN) *document.Document
+	// WriteStaticRoutesTable writes a static routes table and returns doc for chaining.
+	WriteStaticRoutesTable(doc *document.Document, routes []common.StaticRoute) *document.Document
+	// WriteDHCPSummaryTable writes a DHCP summary table and returns doc for chaining.
+	WriteDHCPSummaryTable(doc *document.Document, scopes []common.DHCPScope) *document.Document
+	// WriteDHCPStaticLeasesTable writes a static leases table and returns doc for chaining.
+	WriteDHCPStaticLeasesTable(doc *document.Document, leases []common.DHCPStaticLease) *document.Document
 }
 
 // ReportComposer defines methods for composing full configuration reports.
 // Each method assembles multiple sections into a complete markdown document.
-// SetIncludeTunables and SetFailuresOnly configure rendering behavior before composition.
+// SetIncludeTunables, SetFailuresOnly, SetPortNames, SetRuleFilter, SetSectionOrder and SetStrictRender configure rendering
+// behavior before composition.
 type ReportComposer interface {
 	// SetIncludeTunables configures whether all system tunables are included in the report.
 	// When false, only tunables matching the security prefixes used by
@@ -81,12 +124,40 @@ type ReportComposer interface {
 	SetIncludeTunables(v bool)
 	// SetFailuresOnly configures whether only non-compliant controls are shown in audit reports.
 	SetFailuresOnly(v bool)
+	// SetPortNames configures whether well-known TCP/UDP ports in firewall and
+	// NAT rule tables are annotated with their service name.
+	SetPortNames(v bool)
+	// SetRuleFilter configures the filter that narrows the firewall and NAT
+	// rule tables.
+	SetRuleFilter(f analysis.RuleFilter)
+	// SetSectionOrder configures the order of the report sections and table
+	// of contents. Unlisted sections follow in default order.
+	SetSectionOrder(ids []string) error
+	// SetStrictRender configures whether a report section that fails to
+	// render is a hard error instead of a placeholder.
+	SetStrictRender(v bool)
 	// BuildStandardReport generates a standard configuration report.
 	BuildStandardReport(data *common.CommonDevice) (string, error)
 	// BuildComprehensiveReport generates a comprehensive configuration report.
 	BuildComprehensiveReport(data *common.CommonDevice) (string, error)
 }
 
+// DocumentComposer assembles reports as document trees for renderers that
+// write binary output rather than text, such as document.PDFRenderer. It is
+// optional; callers type-assert a ReportBuilder to it.
+type DocumentComposer interface {
+	// ComprehensiveReportDocument assembles the comprehensive configuration report.
+	ComprehensiveReportDocument(data *common.CommonDevice) (*document.Document, error)
+	// AuditSectionDocument assembles the compliance audit section, or returns
+	// nil when the device has no compliance results.
+	AuditSectionDocument(data *common.CommonDevice) *document.Document
+	// DataQualityDocument assembles the Data Quality appendix, or returns nil
+	// when there are no issues.
+	DataQualityDocument(issues []common.ConversionWarning) *document.Document
+	// GeneratedTime returns the timestamp shown as the report's generation time.
+	GeneratedTime() time.Time
+}
+
 // ReportBuilder defines the contract for programmatic report generation.
 // It composes SectionBuilder, TableWriter, and ReportComposer to provide
 // type-safe, compile-time guaranteed markdown generation.
@@ -98,22 +169,49 @@ type ReportBuilder interface {
 
 // Compile-time assertions that MarkdownBuilder satisfies all interfaces.
 var (
-	_ SectionBuilder = (*MarkdownBuilder)(nil)
-	_ TableWriter    = (*MarkdownBuilder)(nil)
-	_ ReportComposer = (*MarkdownBuilder)(nil)
-	_ ReportBuilder  = (*MarkdownBuilder)(nil)
+	_ SectionBuilder   = (*MarkdownBuilder)(nil)
+	_ TableWriter      = (*MarkdownBuilder)(nil)
+	_ ReportComposer   = (*MarkdownBuilder)(nil)
+	_ ReportBuilder    = (*MarkdownBuilder)(nil)
+	_ DocumentComposer = (*MarkdownBuilder)(nil)
 )
 
 // MarkdownBuilder implements the ReportBuilder interface with comprehensive
 // programmatic markdown generation capabilities.
-// MarkdownBuilder is not safe for concurrent use. Create a new instance per goroutine.
+// Reports are assembled as a document.Document and rendered with the
+// configured document.Renderer, which is markdown unless WithRenderer selects
+// another output format.
+//
+// MarkdownBuilder is safe for concurrent use. Its Build and Write methods
+// render only from their arguments and from state fixed at construction (the
+// generated time, tool version, renderer, and progress tracker), so any
+// number of goroutines may render distinct devices with one builder. The
+// rendering toggles changed by the Set methods are guarded by a mutex; a
+// report reads each toggle when it needs it, so configure the builder before
+// sharing it rather than while reports are rendering. The builder keeps no
+// caches; per-report data such as the interface index is derived from the
+// data argument on every call. A shared progress tracker receives the
+// sections of all concurrent reports.
 type MarkdownBuilder struct {
-	config          *common.CommonDevice
-	logger          *logging.Logger
-	generated       time.Time
-	toolVersion     string
+	config      *common.CommonDevice
+	logger      *logging.Logger
+	generated   time.Time
+	toolVersion string
+	progress    progress.Tracker
+	renderer    document.Renderer
+
+	mu       sync.RWMutex
+	settings renderSettings
+}
+
+// renderSettings holds the rendering toggles changed by the Set methods.
+type renderSettings struct {
 	includeTunables bool
 	failuresOnly    bool
+	noPortNames     bool
+	ruleFilter      analysis.RuleFilter
+	sectionOrder    []string
+	strictRender    bool
 }
 
 // Option configures a MarkdownBuilder at construction time.
@@ -149,6 +247,51 @@ func WithVersion(v string) Option {
 	}
 }
 
+// WithProgress reports each rendered report section to t.
+//
+// When nil is supplied the default no-op tracker is retained.
+func WithProgress(t progress.Tracker) Option {
+	return func(b *MarkdownBuilder) {
+		if t != nil {
+			b.progress = t
+		}
+	}
+}
+
+// WithLogger sets the logger that receives diagnostics, such as the stack of
+// a report section that failed to render.
+//
+// When nil is supplied the default info-level logger is retained.
+func WithLogger(l *logging.Logger) Option {
+	return func(b *MarkdownBuilder) {
+		if l != nil {
+			b.logger = l
+		}
+	}
+}
+
+// WithRenderer sets the renderer that turns assembled report documents into
+// output text. Every Build and Write method uses it.
+//
+// When nil is supplied the default document.MarkdownRenderer is retained.
+func WithRenderer(r document.Renderer) Option {
+	return func(b *MarkdownBuilder) {
+		if r != nil {
+			b.renderer = r
+		}
+	}
+}
+
+// WithPortNames sets whether well-known TCP/UDP ports in firewall and NAT
+// rule tables are annotated with their service name ("443 (https)").
+//
+// Annotation is enabled by default.
+func WithPortNames(enabled bool) Option {
+	return func(b *MarkdownBuilder) {
+		b.settings.noPortNames = !enabled
+	}
+}
+
 // NewMarkdownBuilder creates a new MarkdownBuilder instance.
 //
 // By default the generated timestamp is time.Now() and the tool version is
@@ -163,6 +306,8 @@ func NewMarkdownBuilder(opts ...Option) *MarkdownBuilder {
 		generated:   time.Now(),
 		toolVersion: constants.Version,
 		logger:      logger,
+		progress:    progress.NewNoOp(),
+		renderer:    document.MarkdownRenderer{},
 	}
 	for _, opt := range opts {
 		opt(b)
@@ -192,6 +337,8 @@ func NewMarkdownBuilderWithConfig(
 		logger:      logger,
 		generated:   time.Now(),
 		toolVersion: constants.Version,
+		progress:    progress.NewNoOp(),
+		renderer:    document.MarkdownRenderer{},
 	}
 	for _, opt := range opts {
 		opt(b)
@@ -201,78 +348,142 @@ func NewMarkdownBuilderWithConfig(
 
 // SetIncludeTunables configures whether all system tunables are included in the report.
 // When false, only security-relevant tunables are shown (filtered by formatters.FilterSystemTunables).
-// Not safe for concurrent use — call in the same goroutine as Build/Write methods.
 func (b *MarkdownBuilder) SetIncludeTunables(v bool) {
-	b.includeTunables = v
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.settings.includeTunables = v
 }
 
 // SetFailuresOnly configures whether only non-compliant controls are shown in audit reports.
 // When true, passing controls are filtered out of the plugin results table.
-// Not safe for concurrent use — call in the same goroutine as Build/Write methods.
 func (b *MarkdownBuilder) SetFailuresOnly(v bool) {
-	b.failuresOnly = v
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.settings.failuresOnly = v
 }
 
-// standardToCItems returns the table of contents items for a standard report.
-// The hasTunables parameter controls whether the "System Tunables" link is included.
-func (b *MarkdownBuilder) standardToCItems(hasTunables bool) []string {
-	items := []string{
-		markdown.Link("System Configuration", "#system-configuration"),
-		markdown.Link("Interfaces", "#interfaces"),
-		markdown.Link("Firewall Rules", "#firewall-rules"),
-		markdown.Link("NAT Configuration", "#nat-configuration"),
-		markdown.Link("DHCP Services", "#dhcp-services"),
-		markdown.Link("DNS Resolver", "#dns-resolver"),
-		markdown.Link("System Users", "#system-users"),
-		markdown.Link("Services & Daemons", "#service-configuration"),
-	}
-	if hasTunables {
-		items = append(items, markdown.Link("System Tunables", "#system-tunables"))
-	}
-	return items
+// SetPortNames configures whether well-known TCP/UDP ports in firewall and NAT
+// rule tables are annotated with their service name.
+func (b *MarkdownBuilder) SetPortNames(v bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.settings.noPortNames = !v
+}
+
+// SetRuleFilter configures the filter that narrows the firewall and NAT rule
+// tables to the matching rules. Each filtered table is preceded by the active
+// filters and the number of rules shown. The zero filter shows every rule.
+func (b *MarkdownBuilder) SetRuleFilter(f analysis.RuleFilter) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.settings.ruleFilter = f
+}
+
+// SetStrictRender configures how a report section that fails to render is
+// handled. By default the section is replaced by a placeholder, every other
+// section is rendered, and the report is returned with a *PartialReportError.
+// When strict, the first failure is returned as a *SectionRenderError and no
+// report is produced.
+func (b *MarkdownBuilder) SetStrictRender(v bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.settings.strictRender = v
+}
+
+// currentSettings returns a copy of the rendering toggles.
+func (b *MarkdownBuilder) currentSettings() renderSettings {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.settings
+}
+
+// GeneratedTime returns the "Generated On" timestamp of the builder's reports.
+func (b *MarkdownBuilder) GeneratedTime() time.Time {
+	return b.generated
 }
 
-// comprehensiveToCItems returns the table of contents items for a comprehensive report.
-// The hasTunables parameter controls whether the "System Tunables" link is included.
-func (b *MarkdownBuilder) comprehensiveToCItems(hasTunables bool) []string {
-	items := []string{
-		markdown.Link("System Configuration", "#system-configuration"),
-		markdown.Link("Interfaces", "#interfaces"),
-		markdown.Link("VLANs", "#vlan-configuration"),
-		markdown.Link("Static Routes", "#static-routes"),
-		markdown.Link("Firewall Rules", "#firewall-rules"),
-		markdown.Link("NAT Configuration", "#nat-configuration"),
-		markdown.Link("Intrusion Detection System", "#intrusion-detection-system-idssuricata"),
-		markdown.Link("IPsec VPN", "#ipsec-vpn-configuration"),
-		markdown.Link("OpenVPN", "#openvpn-configuration"),
-		markdown.Link("High Availability", "#high-availability--carp"),
-		markdown.Link("DHCP Services", "#dhcp-services"),
-		markdown.Link("DNS Resolver", "#dns-resolver"),
-		markdown.Link("System Users", "#system-users"),
-		markdown.Link("System Groups", "#system-groups"),
-		markdown.Link("Services & Daemons", "#service-configuration"),
+// render renders doc with the configured renderer.
+func (b *MarkdownBuilder) render(doc *document.Document) string {
+	if b.renderer == nil {
+		return document.MarkdownRenderer{}.Render(doc)
 	}
-	if hasTunables {
-		items = append(items, markdown.Link("System Tunables", "#system-tunables"))
+	return b.renderer.Render(doc)
+}
+
+// writeSections renders the report body parts into doc in order, reporting
+// each one to the configured progress tracker. A part that fails is replaced
+// by a placeholder; see renderPart. In strict mode the first failure is
+// returned at once, otherwise all failures are returned as a
+// *PartialReportError after every part has been written.
+func (b *MarkdownBuilder) writeSections(doc *document.Document, data *common.CommonDevice, parts []sectionPart) error {
+	b.progress.SetTotal(int64(len(parts)))
+	strict := b.currentSettings().strictRender
+
+	var failed []*SectionRenderError
+	for _, part := range parts {
+		section, renderErr := b.renderPart(part, data)
+		if renderErr != nil {
+			if strict {
+				return renderErr
+			}
+			failed = append(failed, renderErr)
+		}
+
+		doc.Append(section)
+		b.progress.Step(1, "Rendering report sections")
 	}
-	return items
+
+	return partialReport(failed)
+}
+
+// renderPart renders part into a new document. A writer that panics leaves a
+// document holding only a warning placeholder that names the part, so no
+// half-written tables reach the report, and the panic is returned as a
+// *SectionRenderError. The stack is logged only at debug level, since it
+// names internal functions.
+//
+//nolint:nonamedreturns // panic recovery in the deferred func must overwrite the results; a named return is the idiomatic way to do that.
+func (b *MarkdownBuilder) renderPart(
+	part sectionPart,
+	data *common.CommonDevice,
+) (doc *document.Document, renderErr *SectionRenderError) {
+	defer func() {
+		if r := recover(); r != nil {
+			if b.logger.IsVerbose() {
+				b.logger.Debug("report section panicked",
+					"section", part.title,
+					"panic", r,
+					"stack", string(debug.Stack()),
+				)
+			}
+			renderErr = &SectionRenderError{Section: part.title, Err: fmt.Errorf("panic: %v", r)}
+			doc = document.New().Warning(renderErr.placeholder())
+		}
+	}()
+
+	doc = document.New()
+	part.write(b, doc, data)
+	return doc, nil
 }
 
 // BuildStandardReport builds a standard markdown report.
+// A section that fails to render is replaced by a placeholder and the report
+// is returned with a *PartialReportError; see SetStrictRender.
 func (b *MarkdownBuilder) BuildStandardReport(data *common.CommonDevice) (string, error) {
 	if data == nil {
 		return "", ErrNilDevice
 	}
 
-	filteredSysctl := formatters.FilterSystemTunables(data.Sysctl, b.includeTunables)
-	tocItems := b.standardToCItems(len(filteredSysctl) > 0)
+	filteredSysctl := formatters.FilterSystemTunables(data.Sysctl, b.currentSettings().includeTunables)
+	tocItems := b.tocItems(false, len(filteredSysctl) > 0)
 
 	platformName := data.DeviceType.DisplayName()
 
-	var buf bytes.Buffer
-	md := markdown.NewMarkdown(&buf).
-		H1(platformName+" Configuration Summary").
-		H2("System Information").
+	doc := document.New().
+		Comment(b.metadataComment(data)).
+		H1(platformName + " Configuration Summary")
+	writeExecutiveSummarySection(doc, executiveSummary(data))
+	doc.H2("System Information").
 		BulletList(
 			markdown.Bold("Hostname")+": "+data.System.Hostname,
 			markdown.Bold("Domain")+": "+data.System.Domain,
@@ -283,33 +494,48 @@ func (b *MarkdownBuilder) BuildStandardReport(data *common.CommonDevice) (string
 		H2("Table of Contents").
 		BulletList(tocItems...)
 
-	b.writeSystemSection(md, data)
-	b.writeNetworkSection(md, data)
-	b.writeSecuritySection(md, data)
-	b.writeServicesSection(md, data)
-
-	if len(filteredSysctl) > 0 {
-		b.WriteSysctlTable(md.H2("System Tunables"), filteredSysctl)
+	if err := b.writeSections(doc, data, b.sectionParts(false)); err != nil {
+		var partial *PartialReportError
+		if !errors.As(err, &partial) {
+			return "", err
+		}
+		return b.render(doc), err
 	}
 
-	return md.String(), nil
+	return b.render(doc), nil
 }
 
 // BuildComprehensiveReport builds a comprehensive markdown report.
+// A section that fails to render is replaced by a placeholder and the report
+// is returned with a *PartialReportError; see SetStrictRender.
 func (b *MarkdownBuilder) BuildComprehensiveReport(data *common.CommonDevice) (string, error) {
+	doc, err := b.ComprehensiveReportDocument(data)
+	if doc == nil {
+		return "", err
+	}
+
+	return b.render(doc), err
+}
+
+// ComprehensiveReportDocument assembles the comprehensive report that
+// BuildComprehensiveReport renders. It returns nil and the error when the
+// report cannot be assembled; a report with sections replaced by
+// placeholders is returned together with a *PartialReportError.
+func (b *MarkdownBuilder) ComprehensiveReportDocument(data *common.CommonDevice) (*document.Document, error) {
 	if data == nil {
-		return "", ErrNilDevice
+		return nil, ErrNilDevice
 	}
 
-	filteredSysctl := formatters.FilterSystemTunables(data.Sysctl, b.includeTunables)
-	tocItems := b.comprehensiveToCItems(len(filteredSysctl) > 0)
+	filteredSysctl := formatters.FilterSystemTunables(data.Sysctl, b.currentSettings().includeTunables)
+	tocItems := b.tocItems(true, len(filteredSysctl) > 0)
 
 	platformName := data.DeviceType.DisplayName()
 
-	var buf bytes.Buffer
-	md := markdown.NewMarkdown(&buf).
-		H1(platformName+" Configuration Summary").
-		H2("System Information").
+	doc := document.New().
+		Comment(b.metadataComment(data)).
+		H1(platformName + " Configuration Summary")
+	writeExecutiveSummarySection(doc, executiveSummary(data))
+	doc.H2("System Information").
 		BulletList(
 			markdown.Bold("Hostname")+": "+data.System.Hostname,
 			markdown.Bold("Domain")+": "+data.System.Domain,
@@ -320,19 +546,13 @@ func (b *MarkdownBuilder) BuildComprehensiveReport(data *common.CommonDevice) (s
 		H2("Table of Contents").
 		BulletList(tocItems...)
 
-	b.writeSystemSection(md, data)
-	b.writeNetworkSection(md, data)
-	b.writeVLANSection(md, data)
-	b.writeStaticRoutesSection(md, data)
-	b.writeSecuritySection(md, data)
-	b.writeIPsecSection(md, data)
-	b.writeOpenVPNSection(md, data)
-	b.writeHASection(md, data)
-	b.writeServicesSection(md, data)
-
-	if len(filteredSysctl) > 0 {
-		b.WriteSysctlTable(md.H2("System Tunables"), filteredSysctl)
+	if err := b.writeSections(doc, data, b.sectionParts(true)); err != nil {
+		var partial *PartialReportError
+		if !errors.As(err, &partial) {
+			return nil, err
+		}
+		return doc, err
 	}
 
-	return md.String(), nil
+	return doc, nil
 }
@@ -1,13 +1,14 @@
 package builder
 
 import (
-	"bytes"
+	"cmp"
 	"fmt"
 	"maps"
 	"slices"
 	"strconv"
 	"strings"
 
+	"github.com/EvilBit-Labs/opnDossier/internal/converter/document"
 	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
 	"github.com/nao1215/markdown"
 )
@@ -16,51 +17,59 @@ import (
 // If ComplianceResults is nil, it returns an empty string.
 //
 // When Controls data is available for a plugin, a unified "Plugin Results" table is rendered
-// with a Status column (PASS/FAIL). When b.failuresOnly is true, only FAIL rows are included.
+// with a Status column (PASS/FAIL). When failures-only rendering is enabled, only FAIL rows are included.
 // When Controls is empty but Findings exist, the legacy findings table is rendered as a fallback.
 func (b *MarkdownBuilder) BuildAuditSection(data *common.CommonDevice) string {
-	if data == nil || data.ComplianceResults == nil {
+	doc := b.AuditSectionDocument(data)
+	if doc == nil {
 		return ""
 	}
 
-	cc := data.ComplianceResults
+	return b.render(doc)
+}
+
+// AuditSectionDocument assembles the compliance audit section that
+// BuildAuditSection renders. It returns nil when ComplianceResults is nil.
+func (b *MarkdownBuilder) AuditSectionDocument(data *common.CommonDevice) *document.Document {
+	if data == nil || data.ComplianceResults == nil {
+		return nil
+	}
 
-	var buf bytes.Buffer
-	md := markdown.NewMarkdown(&buf)
+	cc := data.ComplianceResults
 
-	md.HorizontalRule()
+	doc := document.New()
 
-	b.writeAuditPluginSections(md, cc)
-	writeAuditSecurityAndInventory(md, cc)
-	writeAuditSummary(md, cc)
-	writeAuditMetadata(md, cc)
+	doc.HorizontalRule()
 
-	//nolint:errcheck,gosec // Build writes to bytes.Buffer which cannot fail
-	md.Build()
+	b.writeAuditPluginSections(doc, cc)
+	writeAuditSecurityAndInventory(doc, cc)
+	writeAuditSummary(doc, cc)
+	writeAuditMetadata(doc, cc)
+	writeAuditChecksExecuted(doc, cc)
 
-	return buf.String()
+	return doc
 }
 
 // writeAuditPluginSections emits the per-plugin H3 blocks under "Compliance
 // Audit Results". When a plugin has Controls data, the unified controls table
 // is rendered; otherwise the legacy findings-only fallback is used.
-func (b *MarkdownBuilder) writeAuditPluginSections(md *markdown.Markdown, cc *common.ComplianceResults) {
+func (b *MarkdownBuilder) writeAuditPluginSections(doc *document.Document, cc *common.ComplianceResults) {
 	if len(cc.PluginResults) == 0 {
 		return
 	}
-	md.H2("Compliance Audit Results")
+	doc.H2("Compliance Audit Results")
 	for _, pluginName := range slices.Sorted(maps.Keys(cc.PluginResults)) {
 		result := cc.PluginResults[pluginName]
-		md.H3(pluginName)
+		doc.H3(pluginName)
 		switch {
 		case len(result.Controls) > 0:
-			b.writePluginControlsTable(md, pluginName, result)
+			b.writePluginControlsTable(doc, pluginName, result)
 		case len(result.Findings) > 0:
 			// Legacy fallback: render findings table when no Controls data.
 			// failuresOnly does not apply here — findings ARE failures by
 			// definition; the flag only filters the controls table which has
 			// both PASS and FAIL rows.
-			b.writePluginFindingsTable(md, pluginName, result)
+			b.writePluginFindingsTable(doc, pluginName, result)
 		}
 	}
 }
@@ -68,7 +77,7 @@ func (b *MarkdownBuilder) writeAuditPluginSections(md *markdown.Markdown, cc *co
 // writeAuditSecurityAndInventory partitions top-level findings into security
 // (compliance) and inventory, plus per-plugin inventory findings, and emits
 // the "Security Findings" and "Configuration Notes" tables.
-func writeAuditSecurityAndInventory(md *markdown.Markdown, cc *common.ComplianceResults) {
+func writeAuditSecurityAndInventory(doc *document.Document, cc *common.ComplianceResults) {
 	var securityFindings, inventoryFindings []common.ComplianceFinding
 	for _, f := range cc.Findings {
 		if f.Type == findingTypeInventory {
@@ -86,7 +95,7 @@ func writeAuditSecurityAndInventory(md *markdown.Markdown, cc *common.Compliance
 	}
 
 	if len(securityFindings) > 0 {
-		md.H3("Security Findings")
+		doc.H3("Security Findings")
 		findingsTable := markdown.TableSet{
 			Header: []string{colSeverity, "Component", colTitle, "Recommendation"},
 			Rows:   make([][]string, 0, len(securityFindings)),
@@ -99,11 +108,11 @@ func writeAuditSecurityAndInventory(md *markdown.Markdown, cc *common.Compliance
 				EscapePipeForMarkdown(f.Recommendation),
 			})
 		}
-		md.Table(findingsTable)
+		doc.Table(findingsTable)
 	}
 
 	if len(inventoryFindings) > 0 {
-		md.H3("Configuration Notes")
+		doc.H3("Configuration Notes")
 		notesTable := markdown.TableSet{
 			Header: []string{"Component", colTitle, "Details"},
 			Rows:   make([][]string, 0, len(inventoryFindings)),
@@ -115,32 +124,176 @@ func writeAuditSecurityAndInventory(md *markdown.Markdown, cc *common.Compliance
 				EscapePipeForMarkdown(f.Description),
 			})
 		}
-		md.Table(notesTable)
+		doc.Table(notesTable)
 	}
 }
 
-// writeAuditSummary emits the compliance totals table and per-plugin
-// summary statistics. Totals come from cc.Summary when present, otherwise
+// writeAuditSummary emits the compliance totals table, including the rule
+// description compliance rate when the audit checked descriptions, the
+// logging coverage table when the audit checked rule logging, the
+// configuration freshness table when the audit checked object ages, and
+// per-plugin summary statistics. Totals come from cc.Summary when present, otherwise
 // derived from PluginResults (inventory-only plugins with neither Summary
 // nor Findings contribute zero).
-func writeAuditSummary(md *markdown.Markdown, cc *common.ComplianceResults) {
+func writeAuditSummary(doc *document.Document, cc *common.ComplianceResults) {
 	totalFindings, totalCompliant, totalNonCompliant := computeAuditTotals(cc)
 
-	md.H2("Compliance Audit Summary")
-	md.Table(markdown.TableSet{
+	rows := [][]string{
+		{labelMode, cc.Mode},
+		{"Total Findings", strconv.Itoa(totalFindings)},
+		{"Compliant", strconv.Itoa(totalCompliant)},
+		{"Non-Compliant", strconv.Itoa(totalNonCompliant)},
+	}
+
+	if cc.Summary != nil && cc.Summary.DescriptionQuality != nil {
+		dq := cc.Summary.DescriptionQuality
+		rows = append(rows, []string{
+			"Rule Descriptions Compliant",
+			fmt.Sprintf("%d/%d (%.1f%%)", dq.CompliantRules, dq.TotalRules, dq.CompliantPercent),
+		})
+	}
+
+	if cc.Summary != nil && cc.Summary.StateTableMax > 0 {
+		rows = append(rows, []string{"State Table Maximum", strconv.Itoa(cc.Summary.StateTableMax)})
+	}
+
+	doc.H2("Compliance Audit Summary")
+	doc.Table(markdown.TableSet{
 		Header: []string{"Metric", colValue},
-		Rows: [][]string{
-			{labelMode, cc.Mode},
-			{"Total Findings", strconv.Itoa(totalFindings)},
-			{"Compliant", strconv.Itoa(totalCompliant)},
-			{"Non-Compliant", strconv.Itoa(totalNonCompliant)},
-		},
+		Rows:   rows,
 	})
 
+	if cc.Summary != nil && cc.Summary.BenchmarkScore != nil {
+		writeAuditBenchmarkScore(doc, cc.Summary.BenchmarkScore)
+	}
+
+	if cc.Summary != nil && cc.Summary.LoggingCoverage != nil {
+		writeAuditLoggingCoverage(doc, cc.Summary.LoggingCoverage)
+	}
+
+	if cc.Summary != nil && cc.Summary.ConfigFreshness != nil {
+		writeAuditConfigFreshness(doc, cc.Summary.ConfigFreshness)
+	}
+
 	for _, pluginName := range slices.Sorted(maps.Keys(cc.PluginResults)) {
-		md.H3(pluginName)
-		md.BulletList(pluginSummaryItems(cc.PluginResults[pluginName])...)
+		doc.H3(pluginName)
+		doc.BulletList(pluginSummaryItems(cc.PluginResults[pluginName])...)
+	}
+}
+
+// writeAuditBenchmarkScore emits the "Benchmark Score" table: the score and
+// letter grade of each section, followed by the overall score. Sections with
+// no applicable controls show "-" as their score.
+func writeAuditBenchmarkScore(doc *document.Document, bs *common.BenchmarkScore) {
+	score := func(value float64, applicable int) string {
+		if applicable == 0 {
+			return "-"
+		}
+
+		return fmt.Sprintf("%.2f", value)
+	}
+
+	rows := make([][]string, 0, len(bs.Sections)+1)
+	passed, notApplicable := 0, 0
+	for _, section := range bs.Sections {
+		rows = append(rows, []string{
+			titleWords(section.Section),
+			score(section.Score, section.ApplicableChecks),
+			section.Grade,
+			fmt.Sprintf("%d/%d", section.PassedChecks, section.ApplicableChecks),
+			strconv.Itoa(section.NotApplicableChecks),
+		})
+		passed += section.PassedChecks
+		notApplicable += section.NotApplicableChecks
 	}
+	rows = append(rows, []string{
+		markdown.Bold("Overall"),
+		markdown.Bold(score(bs.Score, bs.ApplicableChecks)),
+		markdown.Bold(bs.Grade),
+		fmt.Sprintf("%d/%d", passed, bs.ApplicableChecks),
+		strconv.Itoa(notApplicable),
+	})
+
+	doc.H3("Benchmark Score")
+	doc.Table(markdown.TableSet{
+		Header: []string{"Section", "Score", "Grade", "Checks Passed", "Not Applicable"},
+		Rows:   rows,
+	})
+}
+
+// titleWords upper-cases the first letter of each space-separated word in s.
+func titleWords(s string) string {
+	words := strings.Fields(s)
+	for i, w := range words {
+		words[i] = strings.ToUpper(w[:1]) + w[1:]
+	}
+
+	return strings.Join(words, " ")
+}
+
+// writeAuditLoggingCoverage emits the "Logging Coverage" table: the logged
+// share of enabled pass and block rules per interface, followed by the total
+// across the rule set.
+func writeAuditLoggingCoverage(doc *document.Document, lc *common.LoggingCoverage) {
+	rows := make([][]string, 0, len(lc.Interfaces)+1)
+	for _, entry := range lc.Interfaces {
+		rows = append(rows, loggingCoverageRow(EscapePipeForMarkdown(entry.Interface), entry))
+	}
+	rows = append(rows, loggingCoverageRow(markdown.Bold("Total"), lc.Total))
+
+	doc.H3("Logging Coverage")
+	doc.Table(markdown.TableSet{
+		Header: []string{colInterface, "Pass Rules Logged", "Block Rules Logged"},
+		Rows:   rows,
+	})
+}
+
+// loggingCoverageRow formats one Logging Coverage row as "logged/total
+// (percent)" per rule kind, or "-" when the interface has no rules of a kind.
+func loggingCoverageRow(label string, entry common.LoggingCoverageEntry) []string {
+	ratio := func(logged, total int, percent float64) string {
+		if total == 0 {
+			return "-"
+		}
+
+		return fmt.Sprintf("%d/%d (%.1f%%)", logged, total, percent)
+	}
+
+	return []string{
+		label,
+		ratio(entry.LoggedPassRules, entry.PassRules, entry.PassLoggedPercent),
+		ratio(entry.LoggedBlockRules, entry.BlockRules, entry.BlockLoggedPercent),
+	}
+}
+
+// writeAuditConfigFreshness emits the "Configuration Freshness" table: the
+// rules, NAT rules, and users in each age bucket, preceded by the time of
+// the configuration's last revision when it is known.
+func writeAuditConfigFreshness(doc *document.Document, cf *common.ConfigFreshness) {
+	row := func(label string, buckets common.FreshnessBuckets) []string {
+		return []string{
+			label,
+			strconv.Itoa(buckets.Recent),
+			strconv.Itoa(buckets.Aging),
+			strconv.Itoa(buckets.Stale),
+			strconv.Itoa(buckets.Unknown),
+		}
+	}
+
+	doc.H3("Configuration Freshness")
+	if cf.LastChanged != "" {
+		doc.Paragraphf("Last configuration change: %s (measured against %s).", cf.LastChanged, cf.ReferenceTime)
+	} else {
+		doc.Paragraphf("Ages measured against %s.", cf.ReferenceTime)
+	}
+	doc.Table(markdown.TableSet{
+		Header: []string{"Object", "< 90 Days", "90-365 Days", "> 365 Days", "Unknown"},
+		Rows: [][]string{
+			row("Firewall Rules", cf.Rules),
+			row("NAT Rules", cf.NATRules),
+			row("Users", cf.Users),
+		},
+	})
 }
 
 // computeAuditTotals returns (totalFindings, totalCompliant, totalNonCompliant),
@@ -178,11 +331,11 @@ func computeAuditTotals(cc *common.ComplianceResults) (total, compliant, nonComp
 
 // writeAuditMetadata emits the final "Audit Metadata" table when Metadata
 // is non-empty. Keys are sorted for determinism.
-func writeAuditMetadata(md *markdown.Markdown, cc *common.ComplianceResults) {
+func writeAuditMetadata(doc *document.Document, cc *common.ComplianceResults) {
 	if len(cc.Metadata) == 0 {
 		return
 	}
-	md.H2("Audit Metadata")
+	doc.H2("Audit Metadata")
 	metadataTable := markdown.TableSet{
 		Header: []string{"Key", colValue},
 		Rows:   make([][]string, 0, len(cc.Metadata)),
@@ -193,7 +346,42 @@ func writeAuditMetadata(md *markdown.Markdown, cc *common.ComplianceResults) {
 			EscapePipeForMarkdown(fmt.Sprintf("%v", cc.Metadata[key])),
 		})
 	}
-	md.Table(metadataTable)
+	doc.Table(metadataTable)
+}
+
+// writeAuditChecksExecuted emits the closing "Appendix: Checks Executed"
+// table listing the execution status of every plugin check, with the reason
+// a check was skipped or errored. Plugins are sorted by name and checks by
+// ID for determinism.
+func writeAuditChecksExecuted(doc *document.Document, cc *common.ComplianceResults) {
+	checksTable := markdown.TableSet{
+		Header: []string{"Plugin", "Check ID", "Name", colStatus, "Findings", "Reason"},
+	}
+
+	for _, pluginName := range slices.Sorted(maps.Keys(cc.PluginResults)) {
+		checks := slices.Clone(cc.PluginResults[pluginName].Checks)
+		slices.SortStableFunc(checks, func(a, c common.ComplianceCheck) int {
+			return strings.Compare(a.ID, c.ID)
+		})
+
+		for _, check := range checks {
+			checksTable.Rows = append(checksTable.Rows, []string{
+				EscapePipeForMarkdown(pluginName),
+				EscapePipeForMarkdown(cmp.Or(check.ID, "-")),
+				EscapePipeForMarkdown(TruncateString(check.Name, MaxDescriptionLength)),
+				EscapePipeForMarkdown(check.Status),
+				strconv.Itoa(check.Findings),
+				EscapePipeForMarkdown(cmp.Or(check.Reason, "-")),
+			})
+		}
+	}
+
+	if len(checksTable.Rows) == 0 {
+		return
+	}
+
+	doc.H2("Appendix: Checks Executed")
+	doc.Table(checksTable)
 }
 
 // pluginSummaryItems renders a per-plugin summary as bullet list items. When
@@ -229,13 +417,15 @@ func pluginSummaryItems(result common.PluginComplianceResult) []string {
 }
 
 // writePluginControlsTable renders a unified controls table for a plugin with a Status column.
-// Controls are sorted by ID for deterministic output. When b.failuresOnly is true, only
-// non-compliant controls are included.
+// Controls are sorted by ID for deterministic output. When failures-only rendering is
+// enabled, only non-compliant controls are included.
 func (b *MarkdownBuilder) writePluginControlsTable(
-	md *markdown.Markdown,
+	doc *document.Document,
 	pluginName string,
 	result common.PluginComplianceResult,
 ) {
+	failuresOnly := b.currentSettings().failuresOnly
+
 	// Sort controls by ID for deterministic output (GOTCHAS.md §3.1)
 	sortedControls := slices.Clone(result.Controls)
 	slices.SortFunc(sortedControls, func(a, c common.ComplianceControl) int {
@@ -256,7 +446,7 @@ func (b *MarkdownBuilder) writePluginControlsTable(
 			status = common.ControlStatusUnknown
 		}
 
-		if b.failuresOnly && status == common.ControlStatusPass {
+		if failuresOnly && status == common.ControlStatusPass {
 			continue
 		}
 
@@ -270,22 +460,22 @@ func (b *MarkdownBuilder) writePluginControlsTable(
 	}
 
 	if len(controlTable.Rows) > 0 {
-		md.H4(pluginName + " Plugin Results")
-		md.Table(controlTable)
-	} else if b.failuresOnly {
-		md.H4(pluginName + " Plugin Results")
-		md.PlainText("All controls compliant — no failures to display.")
+		doc.H4(pluginName + " Plugin Results")
+		doc.Table(controlTable)
+	} else if failuresOnly {
+		doc.H4(pluginName + " Plugin Results")
+		doc.Paragraph("All controls compliant — no failures to display.")
 	}
 }
 
 // writePluginFindingsTable renders the legacy per-plugin findings table.
 // Used as a fallback when plugin Controls data is not available.
 func (b *MarkdownBuilder) writePluginFindingsTable(
-	md *markdown.Markdown,
+	doc *document.Document,
 	pluginName string,
 	result common.PluginComplianceResult,
 ) {
-	md.H4(pluginName + " Plugin Findings")
+	doc.H4(pluginName + " Plugin Findings")
 	pluginTable := markdown.TableSet{
 		Header: []string{"Control", colSeverity, colTitle, colDescription},
 		Rows:   make([][]string, 0, len(result.Findings)),
@@ -314,5 +504,5 @@ func (b *MarkdownBuilder) writePluginFindingsTable(
 		})
 	}
 
-	md.Table(pluginTable)
+	doc.Table(pluginTable)
 }
@@ -0,0 +1,147 @@
+package builder
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/converter/document"
+	"github.com/EvilBit-Labs/opnDossier/internal/converter/formatters"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/EvilBit-Labs/opnDossier/pkg/schema/shared"
+	"github.com/nao1215/markdown"
+)
+
+// changeLogLimit is the maximum number of rules listed in the Recent Changes
+// table.
+const changeLogLimit = 20
+
+// Actions shown in the Recent Changes table for NAT rules, which have no
+// firewall rule type.
+const (
+	changeActionNAT   = "nat"
+	changeActionNoNAT = "no nat"
+	changeActionRDR   = "rdr"
+)
+
+// changeLogEntry is one firewall or NAT rule in the Recent Changes table.
+type changeLogEntry struct {
+	updated     *common.ChangeRecord
+	when        time.Time
+	hasTime     bool
+	description string
+	interfaces  []string
+	action      string
+}
+
+// writeChangeLogSection writes the Recent Changes table: the changeLogLimit
+// most recently modified firewall, outbound NAT, and inbound NAT rules,
+// newest first. Rules without a parseable <updated> time are of unknown age:
+// they sort after the rest in configuration order and show their raw time.
+func (b *MarkdownBuilder) writeChangeLogSection(doc *document.Document, data *common.CommonDevice) {
+	doc.H2("Recent Changes")
+
+	entries := collectChangeLogEntries(data)
+	if len(entries) == 0 {
+		doc.Paragraph(markdown.Italic("No firewall or NAT rules configured"))
+		return
+	}
+
+	slices.SortStableFunc(entries, func(a, c changeLogEntry) int {
+		switch {
+		case a.hasTime && c.hasTime:
+			return c.when.Compare(a.when)
+		case a.hasTime:
+			return -1
+		case c.hasTime:
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	if len(entries) > changeLogLimit {
+		entries = entries[:changeLogLimit]
+	}
+
+	rows := make([][]string, 0, len(entries))
+	for _, entry := range entries {
+		timestamp, user := "-", "-"
+		switch {
+		case entry.hasTime:
+			timestamp = entry.when.UTC().Format(time.RFC3339)
+		case entry.updated != nil && entry.updated.Time != "":
+			timestamp = formatters.EscapeTableContent(entry.updated.Time)
+		}
+		if entry.updated != nil && entry.updated.Username != "" {
+			user = formatters.EscapeTableContent(entry.updated.Username)
+		}
+
+		rows = append(rows, []string{
+			timestamp,
+			user,
+			formatters.EscapeTableContent(cmp.Or(entry.description, "-")),
+			formatters.EscapeTableContent(cmp.Or(strings.Join(entry.interfaces, ", "), "-")),
+			cmp.Or(entry.action, "-"),
+		})
+	}
+
+	doc.Table(markdown.TableSet{
+		Header: []string{"Timestamp", "User", "Rule Description", colInterface, "Action"},
+		Rows:   rows,
+	})
+}
+
+// BuildChangeLogSection builds the Recent Changes table of recently modified
+// firewall and NAT rules.
+func (b *MarkdownBuilder) BuildChangeLogSection(data *common.CommonDevice) string {
+	doc := document.New()
+	b.writeChangeLogSection(doc, data)
+	return b.render(doc)
+}
+
+// collectChangeLogEntries returns a change log entry for every firewall rule,
+// outbound NAT rule, and inbound NAT rule in data, in that order.
+func collectChangeLogEntries(data *common.CommonDevice) []changeLogEntry {
+	entries := make([]changeLogEntry, 0,
+		len(data.FirewallRules)+len(data.NAT.OutboundRules)+len(data.NAT.InboundRules))
+
+	add := func(updated *common.ChangeRecord, description string, interfaces []string, action string) {
+		entry := changeLogEntry{
+			updated:     updated,
+			description: description,
+			interfaces:  interfaces,
+			action:      action,
+		}
+		entry.when, entry.hasTime = parseChangeTime(updated)
+		entries = append(entries, entry)
+	}
+
+	for _, rule := range data.FirewallRules {
+		add(rule.Updated, rule.Description, rule.Interfaces, string(rule.Type))
+	}
+	for _, rule := range data.NAT.OutboundRules {
+		action := changeActionNAT
+		if rule.NoNat {
+			action = changeActionNoNAT
+		}
+		add(rule.Updated, rule.Description, rule.Interfaces, action)
+	}
+	for _, rule := range data.NAT.InboundRules {
+		add(rule.Updated, rule.Description, rule.Interfaces, changeActionRDR)
+	}
+
+	return entries
+}
+
+// parseChangeTime parses a change record time in any format
+// shared.ParseTimestamp accepts. It reports false when the record is nil or
+// the time is missing or malformed.
+func parseChangeTime(record *common.ChangeRecord) (time.Time, bool) {
+	if record == nil {
+		return time.Time{}, false
+	}
+
+	return shared.ParseTimestamp(record.Time)
+}
@@ -0,0 +1,169 @@
+package builder
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+)
+
+// changeLogRows returns the data rows of the Recent Changes table in section.
+func changeLogRows(t *testing.T, section string) []string {
+	t.Helper()
+
+	var rows []string
+	for line := range strings.SplitSeq(section, "\n") {
+		if !strings.HasPrefix(line, "|") || strings.Contains(line, "Timestamp") || strings.HasPrefix(line, "|-") {
+			continue
+		}
+		rows = append(rows, line)
+	}
+
+	return rows
+}
+
+func TestBuildChangeLogSection_SortedByUpdatedDescending(t *testing.T) {
+	t.Parallel()
+
+	data := &common.CommonDevice{
+		FirewallRules: []common.FirewallRule{
+			{
+				Type:        common.RuleTypePass,
+				Description: "Oldest rule",
+				Interfaces:  []string{"lan"},
+				Updated:     &common.ChangeRecord{Username: "alice@10.0.0.2", Time: "11/14/23 22:13:20"},
+			},
+			{
+				Type:        common.RuleTypeBlock,
+				Description: "Newest rule",
+				Interfaces:  []string{"wan"},
+				Updated:     &common.ChangeRecord{Username: "bob@10.0.0.3", Time: "1720000000.25"},
+			},
+		},
+		NAT: common.NATConfig{
+			InboundRules: []common.InboundNATRule{
+				{
+					Description: "Middle port forward",
+					Interfaces:  []string{"wan"},
+					Updated:     &common.ChangeRecord{Username: "root", Time: "2024-03-09T17:00:00+01:00"},
+				},
+			},
+		},
+	}
+
+	result := NewMarkdownBuilder().BuildChangeLogSection(data)
+
+	if !strings.Contains(result, "## Recent Changes") {
+		t.Fatalf("BuildChangeLogSection() missing heading:\n%s", result)
+	}
+
+	rows := changeLogRows(t, result)
+	if len(rows) != 3 {
+		t.Fatalf("BuildChangeLogSection() rows = %d, want 3:\n%s", len(rows), result)
+	}
+
+	want := []struct{ timestamp, user, description, action string }{
+		{"2024-07-03T09:46:40Z", "bob@10.0.0.3", "Newest rule", "block"},
+		{"2024-03-09T16:00:00Z", "root", "Middle port forward", changeActionRDR},
+		{"2023-11-14T22:13:20Z", "alice@10.0.0.2", "Oldest rule", "pass"},
+	}
+	for i, w := range want {
+		for _, field := range []string{w.timestamp, w.user, w.description, w.action} {
+			if !strings.Contains(rows[i], field) {
+				t.Errorf("row %d = %q, want it to contain %q", i, rows[i], field)
+			}
+		}
+	}
+}
+
+func TestBuildChangeLogSection_MissingUpdatedSortsLast(t *testing.T) {
+	t.Parallel()
+
+	data := &common.CommonDevice{
+		FirewallRules: []common.FirewallRule{
+			{Type: common.RuleTypePass, Description: "Never updated"},
+			{Type: common.RuleTypePass, Description: "Bad timestamp", Updated: &common.ChangeRecord{Time: "yesterday"}},
+			{Type: common.RuleTypePass, Description: "Updated", Updated: &common.ChangeRecord{Time: "1700000000"}},
+		},
+		NAT: common.NATConfig{
+			OutboundRules: []common.NATRule{
+				{Description: "Excluded traffic", NoNat: true},
+			},
+		},
+	}
+
+	rows := changeLogRows(t, NewMarkdownBuilder().BuildChangeLogSection(data))
+	if len(rows) != 4 {
+		t.Fatalf("BuildChangeLogSection() rows = %d, want 4", len(rows))
+	}
+
+	order := []string{"Updated", "Never updated", "Bad timestamp", "Excluded traffic"}
+	for i, description := range order {
+		if !strings.Contains(rows[i], "| "+description+" |") {
+			t.Errorf("row %d = %q, want description %q", i, rows[i], description)
+		}
+	}
+
+	// Rules of unknown age show their raw time, or "-" when there is none.
+	for i, timestamp := range map[int]string{1: "-", 2: "yesterday", 3: "-"} {
+		if !strings.HasPrefix(rows[i], "| "+timestamp+" ") {
+			t.Errorf("row %d = %q, want timestamp %q", i, rows[i], timestamp)
+		}
+	}
+
+	if !strings.Contains(rows[3], changeActionNoNAT) {
+		t.Errorf("row %q: want action %q", rows[3], changeActionNoNAT)
+	}
+}
+
+func TestBuildChangeLogSection_LimitsRows(t *testing.T) {
+	t.Parallel()
+
+	rules := make([]common.FirewallRule, 0, changeLogLimit+5)
+	for i := range changeLogLimit + 5 {
+		rules = append(rules, common.FirewallRule{
+			Type:        common.RuleTypePass,
+			Description: fmt.Sprintf("Rule %d", i),
+			Updated:     &common.ChangeRecord{Time: strconv.Itoa(1700000000 + i)},
+		})
+	}
+
+	rows := changeLogRows(t, NewMarkdownBuilder().BuildChangeLogSection(&common.CommonDevice{FirewallRules: rules}))
+	if len(rows) != changeLogLimit {
+		t.Fatalf("BuildChangeLogSection() rows = %d, want %d", len(rows), changeLogLimit)
+	}
+
+	if !strings.Contains(rows[0], fmt.Sprintf("| Rule %d |", changeLogLimit+4)) {
+		t.Errorf("first row = %q, want the most recently updated rule", rows[0])
+	}
+}
+
+func TestBuildChangeLogSection_NoRules(t *testing.T) {
+	t.Parallel()
+
+	result := NewMarkdownBuilder().BuildChangeLogSection(&common.CommonDevice{})
+	if !strings.Contains(result, "No firewall or NAT rules configured") {
+		t.Errorf("BuildChangeLogSection() = %q, want empty-state message", result)
+	}
+}
+
+func TestBuildComprehensiveReport_ChangeLogFollowsSystemInformation(t *testing.T) {
+	t.Parallel()
+
+	report, err := NewMarkdownBuilder().BuildComprehensiveReport(&common.CommonDevice{})
+	if err != nil {
+		t.Fatalf("BuildComprehensiveReport() error = %v", err)
+	}
+
+	changes := strings.Index(report, "## Recent Changes")
+	system := strings.Index(report, "## System Configuration")
+	if changes < 0 || system < 0 || changes > system {
+		t.Errorf("Recent Changes (%d) should precede System Configuration (%d)", changes, system)
+	}
+
+	if !strings.Contains(report, "[Recent Changes](#recent-changes)") {
+		t.Error("table of contents missing Recent Changes link")
+	}
+}
@@ -0,0 +1,155 @@
+package builder_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/cfgparser"
+	"github.com/EvilBit-Labs/opnDossier/internal/converter/builder"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/EvilBit-Labs/opnDossier/pkg/parser"
+	_ "github.com/EvilBit-Labs/opnDossier/pkg/parser/opnsense" // self-registers OPNsense parser via init()
+	_ "github.com/EvilBit-Labs/opnDossier/pkg/parser/pfsense"  // self-registers pfSense parser via init()
+)
+
+// concurrentRenderers is the number of goroutines sharing one builder in
+// TestMarkdownBuilder_ConcurrentReports.
+const concurrentRenderers = 16
+
+// renderedReports holds every report form rendered for one device.
+type renderedReports struct {
+	standard, comprehensive, streamed string
+}
+
+// loadConcurrencyDevices parses three fixtures covering both platforms.
+func loadConcurrencyDevices(t *testing.T) []*common.CommonDevice {
+	t.Helper()
+
+	paths := []string{
+		filepath.Join("..", "..", "..", "testdata", "sample.config.1.xml"),
+		filepath.Join("..", "..", "..", "testdata", "gif_tunnel_test.xml"),
+		filepath.Join("..", "..", "..", "testdata", "pfsense", "config-2.7.x.xml"),
+	}
+
+	devices := make([]*common.CommonDevice, 0, len(paths))
+	for _, path := range paths {
+		f, err := os.Open(path)
+		if err != nil {
+			t.Fatalf("open %s: %v", path, err)
+		}
+
+		device, _, err := parser.NewFactory(cfgparser.NewXMLParser()).
+			CreateDevice(context.Background(), f, common.DeviceTypeUnknown, false)
+		_ = f.Close()
+		if err != nil {
+			t.Fatalf("parse %s: %v", path, err)
+		}
+
+		devices = append(devices, device)
+	}
+
+	return devices
+}
+
+// renderAll renders the standard, comprehensive, and streamed comprehensive
+// reports for data.
+func renderAll(b *builder.MarkdownBuilder, data *common.CommonDevice) (renderedReports, error) {
+	standard, err := b.BuildStandardReport(data)
+	if err != nil {
+		return renderedReports{}, err
+	}
+
+	comprehensive, err := b.BuildComprehensiveReport(data)
+	if err != nil {
+		return renderedReports{}, err
+	}
+
+	var buf bytes.Buffer
+	if err := b.WriteComprehensiveReport(&buf, data); err != nil {
+		return renderedReports{}, err
+	}
+
+	return renderedReports{standard: standard, comprehensive: comprehensive, streamed: buf.String()}, nil
+}
+
+// TestMarkdownBuilder_ConcurrentReports renders reports for three devices
+// from many goroutines sharing one builder and checks every report matches
+// the serial output. Run with -race to verify the concurrency contract.
+func TestMarkdownBuilder_ConcurrentReports(t *testing.T) {
+	t.Parallel()
+
+	devices := loadConcurrencyDevices(t)
+
+	b := builder.NewMarkdownBuilder(
+		builder.WithGeneratedTime(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)),
+		builder.WithVersion("test"),
+	)
+	b.SetIncludeTunables(true)
+
+	want := make([]renderedReports, len(devices))
+	for i, device := range devices {
+		got, err := renderAll(b, device)
+		if err != nil {
+			t.Fatalf("serial render of device %d: %v", i, err)
+		}
+		want[i] = got
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan string, concurrentRenderers*len(devices))
+
+	for g := range concurrentRenderers {
+		wg.Go(func() {
+			// Stagger the starting device so goroutines render different
+			// devices at the same time.
+			for n := range devices {
+				i := (g + n) % len(devices)
+
+				got, err := renderAll(b, devices[i])
+				switch {
+				case err != nil:
+					errs <- err.Error()
+				case got != want[i]:
+					errs <- devices[i].System.Hostname + ": concurrent report differs from serial output"
+				}
+			}
+		})
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for msg := range errs {
+		t.Error(msg)
+	}
+}
+
+// TestMarkdownBuilder_ConcurrentSetters checks the Set methods may be called
+// while reports render without a data race.
+func TestMarkdownBuilder_ConcurrentSetters(t *testing.T) {
+	t.Parallel()
+
+	b := builder.NewMarkdownBuilder()
+	data := createTestDocument()
+
+	var wg sync.WaitGroup
+	for range concurrentRenderers / 2 {
+		wg.Go(func() {
+			b.SetIncludeTunables(true)
+			b.SetFailuresOnly(true)
+			b.SetPortNames(false)
+		})
+		wg.Go(func() {
+			if _, err := b.BuildComprehensiveReport(data); err != nil {
+				t.Errorf("BuildComprehensiveReport: %v", err)
+			}
+		})
+	}
+
+	wg.Wait()
+}
@@ -0,0 +1,53 @@
+package builder
+
+import (
+	"cmp"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/converter/document"
+	"github.com/EvilBit-Labs/opnDossier/internal/converter/formatters"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/nao1215/markdown"
+)
+
+// BuildDataQualitySection builds the Data Quality appendix listing the issues
+// found while normalizing the configuration: the offending field and value,
+// what was wrong, and whether the value was skipped or defaulted. It returns
+// an empty string when there are no issues.
+func (b *MarkdownBuilder) BuildDataQualitySection(issues []common.ConversionWarning) string {
+	doc := b.DataQualityDocument(issues)
+	if doc == nil {
+		return ""
+	}
+
+	return b.render(doc)
+}
+
+// DataQualityDocument assembles the Data Quality appendix that
+// BuildDataQualitySection renders. It returns nil when there are no issues.
+func (b *MarkdownBuilder) DataQualityDocument(issues []common.ConversionWarning) *document.Document {
+	if len(issues) == 0 {
+		return nil
+	}
+
+	rows := make([][]string, 0, len(issues))
+	for _, issue := range issues {
+		rows = append(rows, []string{
+			formatters.EscapeTableContent(issue.Field),
+			formatters.EscapeTableContent(cmp.Or(issue.Value, "-")),
+			formatters.EscapeTableContent(issue.Message),
+			formatters.EscapeTableContent(cmp.Or(issue.Action, "-")),
+			issue.Severity.String(),
+		})
+	}
+
+	doc := document.New()
+	doc.HorizontalRule()
+	doc.H2("Data Quality").
+		Paragraph("These values could not be normalized; the report shows them skipped or defaulted as noted.").
+		Table(markdown.TableSet{
+			Header: []string{"Field", "Value", "Issue", "Action", "Severity"},
+			Rows:   rows,
+		})
+
+	return doc
+}
@@ -2,16 +2,18 @@ package builder
 
 import (
 	"fmt"
+	"slices"
 	"strconv"
 
+	"github.com/EvilBit-Labs/opnDossier/internal/converter/document"
 	"github.com/EvilBit-Labs/opnDossier/internal/converter/formatters"
 	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
 	"github.com/nao1215/markdown"
 )
 
-// WriteOutboundNATTable writes an outbound NAT rules table and returns md for chaining.
-func (b *MarkdownBuilder) WriteOutboundNATTable(md *markdown.Markdown, rules []common.NATRule) *markdown.Markdown {
-	return md.Table(*BuildOutboundNATTableSet(rules))
+// WriteOutboundNATTable writes an outbound NAT rules table and returns doc for chaining.
+func (b *MarkdownBuilder) WriteOutboundNATTable(doc *document.Document, rules []common.NATRule) *document.Document {
+	return doc.Table(*BuildOutboundNATTableSet(rules))
 }
 
 // BuildOutboundNATTableSet builds the table data for outbound NAT rules.
@@ -66,7 +68,7 @@ func BuildOutboundNATTableSet(rules []common.NATRule) *markdown.TableSet {
 			interfaceLinks := formatters.FormatInterfacesAsLinks(rule.Interfaces)
 
 			rows = append(rows, []string{
-				strconv.Itoa(i + 1),
+				ruleNumberCell(ruleAnchorOutboundNAT, i+1),
 				"⬆️ Outbound",
 				interfaceLinks,
 				source,
@@ -85,16 +87,86 @@ func BuildOutboundNATTableSet(rules []common.NATRule) *markdown.TableSet {
 	}
 }
 
-// WriteInboundNATTable writes an inbound NAT rules table and returns md for chaining.
+// WriteOneToOneNATTable writes a 1:1 NAT rules table and returns doc for chaining.
+func (b *MarkdownBuilder) WriteOneToOneNATTable(
+	doc *document.Document,
+	rules []common.OneToOneNATRule,
+) *document.Document {
+	return doc.Table(*BuildOneToOneNATTableSet(rules))
+}
+
+// BuildOneToOneNATTableSet builds the table data for 1:1 (binat) NAT rules.
+// An external address without a mask is shown with the prefix length it
+// inherits from the internal subnet, so a subnet mapping is visible as one.
+func BuildOneToOneNATTableSet(rules []common.OneToOneNATRule) *markdown.TableSet {
+	headers := []string{
+		"#",
+		colInterface,
+		"External",
+		"Internal",
+		colDescription,
+		colStatus,
+	}
+
+	rows := make([][]string, 0, len(rules))
+
+	if len(rules) == 0 {
+		rows = append(rows, []string{
+			"-", "-", "-", "-",
+			"No 1:1 NAT rules configured",
+			"-",
+		})
+	} else {
+		for i, rule := range rules {
+			external := rule.External
+			if prefix, ok := rule.ExternalPrefix(); ok && rule.MapsSubnet() {
+				external = prefix.String()
+			}
+			if external != "" {
+				external = fmt.Sprintf("`%s`", external)
+			}
+
+			internal := rule.Internal
+			if internal != "" {
+				internal = fmt.Sprintf("`%s`", internal)
+			}
+
+			status := "**Active**"
+			if rule.Disabled {
+				status = "**Disabled**"
+			}
+
+			rows = append(rows, []string{
+				ruleNumberCell(ruleAnchorOneToOneNAT, i+1),
+				formatters.FormatInterfacesAsLinks(rule.Interfaces),
+				external,
+				internal,
+				formatters.EscapeTableContent(rule.Description),
+				status,
+			})
+		}
+	}
+
+	return &markdown.TableSet{
+		Header: headers,
+		Rows:   rows,
+	}
+}
+
+// WriteInboundNATTable writes an inbound NAT rules table and returns doc for chaining.
 func (b *MarkdownBuilder) WriteInboundNATTable(
-	md *markdown.Markdown,
+	doc *document.Document,
 	rules []common.InboundNATRule,
-) *markdown.Markdown {
-	return md.Table(*BuildInboundNATTableSet(rules))
+) *document.Document {
+	return doc.Table(*BuildInboundNATTableSet(rules, !b.currentSettings().noPortNames))
 }
 
-// BuildInboundNATTableSet builds the table data for inbound NAT rules.
-func BuildInboundNATTableSet(rules []common.InboundNATRule) *markdown.TableSet {
+// BuildInboundNATTableSet builds the table data for inbound NAT rules. A
+// "NAT Reflection" column with each rule's mode is added when any rule sets
+// one; rules without a mode show "default" (the system-wide setting). When
+// portNames is true, well-known TCP/UDP ports are annotated with their
+// service name.
+func BuildInboundNATTableSet(rules []common.InboundNATRule, portNames bool) *markdown.TableSet {
 	headers := []string{
 		"#",
 		"Direction",
@@ -136,12 +208,12 @@ func BuildInboundNATTableSet(rules []common.InboundNATRule) *markdown.TableSet {
 			interfaceLinks := formatters.FormatInterfacesAsLinks(rule.Interfaces)
 
 			rows = append(rows, []string{
-				strconv.Itoa(i + 1),
+				ruleNumberCell(ruleAnchorInboundNAT, i+1),
 				"⬇️ Inbound",
 				interfaceLinks,
-				rule.ExternalPort,
+				formatters.EscapeTableContent(formatters.FormatPortRange(rule.ExternalPort, rule.Protocol, portNames)),
 				targetIP,
-				rule.InternalPort,
+				formatters.EscapeTableContent(formatters.FormatPortRange(rule.InternalPort, rule.Protocol, portNames)),
 				protocol,
 				formatters.EscapeTableContent(rule.Description),
 				strconv.Itoa(rule.Priority),
@@ -150,6 +222,18 @@ func BuildInboundNATTableSet(rules []common.InboundNATRule) *markdown.TableSet {
 		}
 	}
 
+	if slices.ContainsFunc(rules, func(r common.InboundNATRule) bool { return r.NATReflection != "" }) {
+		headers = append(headers, "NAT Reflection")
+		for i, rule := range rules {
+			mode := "default"
+			if rule.NATReflection != "" {
+				mode = formatters.EscapeTableContent(rule.NATReflection)
+			}
+
+			rows[i] = append(rows[i], mode)
+		}
+	}
+
 	return &markdown.TableSet{
 		Header: headers,
 		Rows:   rows,
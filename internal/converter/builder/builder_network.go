@@ -1,19 +1,20 @@
 package builder
 
 import (
-	"bytes"
+	"cmp"
 	"fmt"
-	"strings"
 
+	"github.com/EvilBit-Labs/opnDossier/internal/analysis"
+	"github.com/EvilBit-Labs/opnDossier/internal/converter/document"
 	"github.com/EvilBit-Labs/opnDossier/internal/converter/formatters"
 	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
 	"github.com/nao1215/markdown"
 )
 
-// writeNetworkSection writes the network configuration section to the markdown instance.
-func (b *MarkdownBuilder) writeNetworkSection(md *markdown.Markdown, data *common.CommonDevice) {
+// writeNetworkSection writes the network configuration section to the report document.
+func (b *MarkdownBuilder) writeNetworkSection(doc *document.Document, data *common.CommonDevice) {
 	b.WriteInterfaceTable(
-		md.H2("Network Configuration").H3("Interfaces"),
+		doc.H2("Network Configuration").H3("Interfaces"),
 		data.Interfaces,
 	)
 
@@ -22,23 +23,29 @@ func (b *MarkdownBuilder) writeNetworkSection(md *markdown.Markdown, data *commo
 		if name == "" {
 			name = "unnamed"
 		}
-		sectionName := strings.ToUpper(name[:1]) + strings.ToLower(name[1:]) + " Interface"
-		md.H3(sectionName)
-		buildInterfaceDetails(md, iface)
+		sectionName := formatters.CapitalizeName(name) + " Interface"
+		doc.H3(sectionName)
+		buildInterfaceDetails(doc, iface)
+	}
+
+	if tunnels := analysis.TunnelInterfaces(data); len(tunnels) > 0 {
+		doc.H3("Tunnel Interfaces").Table(*BuildTunnelTableSet(tunnels))
 	}
 }
 
 // BuildNetworkSection builds the network configuration section.
 func (b *MarkdownBuilder) BuildNetworkSection(data *common.CommonDevice) string {
-	var buf bytes.Buffer
-	md := markdown.NewMarkdown(&buf)
-	b.writeNetworkSection(md, data)
-	return md.String()
+	doc := document.New()
+	b.writeNetworkSection(doc, data)
+	return b.render(doc)
 }
 
-// WriteInterfaceTable writes an interfaces table and returns md for chaining.
-func (b *MarkdownBuilder) WriteInterfaceTable(md *markdown.Markdown, interfaces []common.Interface) *markdown.Markdown {
-	return md.Table(*BuildInterfaceTableSet(interfaces))
+// WriteInterfaceTable writes an interfaces table and returns doc for chaining.
+func (b *MarkdownBuilder) WriteInterfaceTable(
+	doc *document.Document,
+	interfaces []common.Interface,
+) *document.Document {
+	return doc.Table(*BuildInterfaceTableSet(interfaces))
 }
 
 // BuildInterfaceTableSet builds the table data for network interfaces.
@@ -73,37 +80,37 @@ func BuildInterfaceTableSet(interfaces []common.Interface) *markdown.TableSet {
 }
 
 // buildInterfaceDetails renders the property details for a single network interface into the markdown builder.
-func buildInterfaceDetails(md *markdown.Markdown, iface common.Interface) {
+func buildInterfaceDetails(doc *document.Document, iface common.Interface) {
 	// Build a list of interface properties that are set
 	if iface.PhysicalIf != "" {
-		md.PlainTextf("%s: %s", markdown.Bold("Physical Interface"), iface.PhysicalIf).LF()
+		doc.Paragraphf("%s: %s", markdown.Bold("Physical Interface"), iface.PhysicalIf).Break()
 	}
-	md.PlainTextf("%s: %s", markdown.Bold(labelEnabled), formatters.FormatBool(iface.Enabled)).LF()
+	doc.Paragraphf("%s: %s", markdown.Bold(labelEnabled), formatters.FormatBool(iface.Enabled)).Break()
 	if iface.IPAddress != "" {
-		md.PlainTextf("%s: %s", markdown.Bold("IPv4 Address"), iface.IPAddress).LF()
+		doc.Paragraphf("%s: %s", markdown.Bold("IPv4 Address"), iface.IPAddress).Break()
 	}
 	if iface.Subnet != "" {
-		md.PlainTextf("%s: %s", markdown.Bold("IPv4 Subnet"), iface.Subnet).LF()
+		doc.Paragraphf("%s: %s", markdown.Bold("IPv4 Subnet"), iface.Subnet).Break()
 	}
 	if iface.IPv6Address != "" {
-		md.PlainTextf("%s: %s", markdown.Bold("IPv6 Address"), iface.IPv6Address).LF()
+		doc.Paragraphf("%s: %s", markdown.Bold("IPv6 Address"), iface.IPv6Address).Break()
 	}
 	if iface.SubnetV6 != "" {
-		md.PlainTextf("%s: %s", markdown.Bold("IPv6 Subnet"), iface.SubnetV6).LF()
+		doc.Paragraphf("%s: %s", markdown.Bold("IPv6 Subnet"), iface.SubnetV6).Break()
 	}
 	if iface.Gateway != "" {
-		md.PlainTextf("%s: %s", markdown.Bold("Gateway"), iface.Gateway).LF()
+		doc.Paragraphf("%s: %s", markdown.Bold("Gateway"), iface.Gateway).Break()
 	}
 	if iface.MTU != "" {
-		md.PlainTextf("%s: %s", markdown.Bold("MTU"), iface.MTU).LF()
+		doc.Paragraphf("%s: %s", markdown.Bold("MTU"), iface.MTU).Break()
 	}
-	md.PlainTextf("%s: %s", markdown.Bold("Block Private Networks"), formatters.FormatBool(iface.BlockPrivate)).LF()
-	md.PlainTextf("%s: %s", markdown.Bold("Block Bogon Networks"), formatters.FormatBool(iface.BlockBogons))
+	doc.Paragraphf("%s: %s", markdown.Bold("Block Private Networks"), formatters.FormatBool(iface.BlockPrivate)).Break()
+	doc.Paragraphf("%s: %s", markdown.Bold("Block Bogon Networks"), formatters.FormatBool(iface.BlockBogons))
 }
 
-// WriteVLANTable writes a VLAN configurations table and returns md for chaining.
-func (b *MarkdownBuilder) WriteVLANTable(md *markdown.Markdown, vlans []common.VLAN) *markdown.Markdown {
-	return md.Table(*BuildVLANTableSet(vlans))
+// WriteVLANTable writes a VLAN configurations table and returns doc for chaining.
+func (b *MarkdownBuilder) WriteVLANTable(doc *document.Document, vlans []common.VLAN) *document.Document {
+	return doc.Table(*BuildVLANTableSet(vlans))
 }
 
 // BuildVLANTableSet builds the table data for VLAN configurations.
@@ -130,8 +137,8 @@ func BuildVLANTableSet(vlans []common.VLAN) *markdown.TableSet {
 				formatters.EscapeTableContent(vlan.PhysicalIf),
 				vlan.Tag,
 				formatters.EscapeTableContent(vlan.Description),
-				vlan.Created,
-				vlan.Updated,
+				formatChangeRecord(vlan.Created),
+				formatChangeRecord(vlan.Updated),
 			})
 		}
 	}
@@ -142,12 +149,65 @@ func BuildVLANTableSet(vlans []common.VLAN) *markdown.TableSet {
 	}
 }
 
-// WriteStaticRoutesTable writes a static routes table and returns md for chaining.
+// BuildTunnelTableSet builds the table data for GIF and GRE tunnel interfaces.
+// Inner tunnel addresses are shown as "local → remote" when either is set.
+func BuildTunnelTableSet(tunnels []analysis.TunnelInterface) *markdown.TableSet {
+	headers := []string{
+		colType,
+		colInterface,
+		"Parent Interface",
+		"Remote Endpoint",
+		"Tunnel Addresses",
+		colDescription,
+	}
+
+	rows := make([][]string, 0, len(tunnels))
+	for _, tunnel := range tunnels {
+		addresses := "-"
+		if tunnel.LocalAddress != "" || tunnel.RemoteAddress != "" {
+			addresses = formatters.EscapeTableContent(
+				cmp.Or(tunnel.LocalAddress, "-") + " → " + cmp.Or(tunnel.RemoteAddress, "-"),
+			)
+		}
+
+		rows = append(rows, []string{
+			tunnel.Type,
+			formatters.EscapeTableContent(tunnel.Interface),
+			formatters.EscapeTableContent(cmp.Or(tunnel.Parent, "-")),
+			formatters.EscapeTableContent(cmp.Or(tunnel.Remote, "-")),
+			addresses,
+			formatters.EscapeTableContent(tunnel.Description),
+		})
+	}
+
+	return &markdown.TableSet{
+		Header: headers,
+		Rows:   rows,
+	}
+}
+
+// formatChangeRecord renders a created/updated record as its timestamp
+// followed by the user that made the change, e.g.
+// "2023-11-14T22:13:20Z (root@10.0.0.5)". Returns "-" for a nil record.
+func formatChangeRecord(record *common.ChangeRecord) string {
+	if record == nil {
+		return "-"
+	}
+
+	text := formatters.FormatTimestamp(record.Time)
+	if record.Username != "" {
+		text += " (" + record.Username + ")"
+	}
+
+	return formatters.EscapeTableContent(text)
+}
+
+// WriteStaticRoutesTable writes a static routes table and returns doc for chaining.
 func (b *MarkdownBuilder) WriteStaticRoutesTable(
-	md *markdown.Markdown,
+	doc *document.Document,
 	routes []common.StaticRoute,
-) *markdown.Markdown {
-	return md.Table(*BuildStaticRoutesTableSet(routes))
+) *document.Document {
+	return doc.Table(*BuildStaticRoutesTableSet(routes))
 }
 
 // BuildStaticRoutesTableSet builds the table data for static routes.
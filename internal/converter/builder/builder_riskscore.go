@@ -0,0 +1,48 @@
+package builder
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/converter/document"
+	"github.com/nao1215/markdown"
+)
+
+// riskScoreSeverities lists the severities of a risk score breakdown in
+// table order.
+var riskScoreSeverities = []string{"critical", "high", "medium", "low"}
+
+// BuildRiskScoreSection builds the Risk Score section: the score in bold
+// with its level, a blockquote hinting the level's colour (a tip for Low, a
+// note for Medium, a warning for High and Critical), and a table of the
+// points each severity added. Severities missing from breakdown are
+// omitted from the table.
+func (b *MarkdownBuilder) BuildRiskScoreSection(score int, level string, breakdown map[string]int) string {
+	doc := document.New()
+	doc.H2("Risk Score").
+		Paragraphf("%s (%s)", markdown.Bold(strconv.Itoa(score)), level)
+
+	switch hint := fmt.Sprintf("Risk level %s: ", level); strings.ToLower(level) {
+	case "low":
+		doc.Tip(hint + "no immediate action is required.")
+	case "medium":
+		doc.Note(hint + "schedule remediation of the high and medium findings.")
+	default:
+		doc.Warning(hint + "remediate the critical and high findings first.")
+	}
+	doc.Break()
+
+	var rows [][]string
+	for _, severity := range riskScoreSeverities {
+		if points, ok := breakdown[severity]; ok {
+			rows = append(rows, []string{severity, strconv.Itoa(points)})
+		}
+	}
+
+	if len(rows) > 0 {
+		doc.Table(markdown.TableSet{Header: []string{"Severity", "Points"}, Rows: rows})
+	}
+
+	return b.render(doc)
+}
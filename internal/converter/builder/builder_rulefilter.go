@@ -0,0 +1,40 @@
+package builder
+
+import (
+	"fmt"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/analysis"
+	"github.com/EvilBit-Labs/opnDossier/internal/converter/document"
+	"github.com/nao1215/markdown"
+)
+
+// writeFilteredRuleTable writes the table build makes from the rules accepted
+// by match, preceded by a note naming the active filter and how many of the
+// rules are shown, so a filtered table cannot be mistaken for the full rule
+// set. Rows keep their original rule numbers and anchors under anchorPrefix.
+// Without an active filter, or without rules, the table lists every rule.
+func writeFilteredRuleTable[T any](
+	doc *document.Document,
+	filter analysis.RuleFilter,
+	rules []T,
+	match func(T) bool,
+	anchorPrefix string,
+	build func([]T) *markdown.TableSet,
+) *document.Document {
+	if !filter.IsActive() || len(rules) == 0 {
+		return doc.Table(*build(rules))
+	}
+
+	kept, positions := analysis.FilterRules(rules, match)
+	doc.Note(fmt.Sprintf("Filtered by %s — showing %d of %d rules.", filter, len(kept), len(rules)))
+	if len(kept) == 0 {
+		return doc
+	}
+
+	set := build(kept)
+	for i, pos := range positions {
+		set.Rows[i][0] = ruleNumberCell(anchorPrefix, pos)
+	}
+
+	return doc.Table(*set)
+}
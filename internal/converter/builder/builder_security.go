@@ -1,82 +1,480 @@
 package builder
 
 import (
-	"bytes"
+	"cmp"
 	"fmt"
+	"maps"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
 
+	"github.com/EvilBit-Labs/opnDossier/internal/analysis"
+	"github.com/EvilBit-Labs/opnDossier/internal/converter/document"
 	"github.com/EvilBit-Labs/opnDossier/internal/converter/formatters"
 	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
 	"github.com/nao1215/markdown"
 )
 
-// writeSecuritySection writes the security configuration section to the markdown instance.
-func (b *MarkdownBuilder) writeSecuritySection(md *markdown.Markdown, data *common.CommonDevice) {
-	md.H2("Security Configuration").
-		H3("NAT Configuration")
+// heavyInterfaceRuleCount is the rule count above which an interface is
+// highlighted in the rules-per-interface table.
+const heavyInterfaceRuleCount = 50
+
+// writeSecuritySection writes the security configuration section to the report document.
+func (b *MarkdownBuilder) writeSecuritySection(doc *document.Document, data *common.CommonDevice) {
+	b.writeSecurityConfiguration(doc, data, false)
+}
+
+// writeComprehensiveSecuritySection writes the security section with a
+// section summary callout opening the NAT and firewall rule subsections.
+func (b *MarkdownBuilder) writeComprehensiveSecuritySection(doc *document.Document, data *common.CommonDevice) {
+	b.writeSecurityConfiguration(doc, data, true)
+}
 
+// writeSecurityConfiguration writes the security configuration section. When
+// summaries is set, the NAT and firewall rule subsections open with a callout
+// counting their rules and the scan findings attributed to them.
+func (b *MarkdownBuilder) writeSecurityConfiguration(
+	doc *document.Document,
+	data *common.CommonDevice,
+	summaries bool,
+) {
+	settings := b.currentSettings()
 	natSummary := data.NATSummary()
+
+	var observations []analysis.Observation
+	if summaries {
+		observations = analysis.ScanObservations(data)
+	}
+
+	doc.H2("Security Configuration").
+		H3("NAT Configuration")
+
+	if summaries {
+		counts := fmt.Sprintf("%s, %s",
+			pluralize(len(natSummary.OutboundRules), "outbound rule"),
+			pluralize(len(natSummary.InboundRules), "inbound rule"),
+		)
+		if len(natSummary.OneToOneRules) > 0 {
+			counts += ", " + pluralize(len(natSummary.OneToOneRules), "one-to-one rule")
+		}
+		writeSectionSummaryCallout(doc, counts, componentFindings(observations, "nat."))
+	}
+
 	if natSummary.Mode != "" || data.NAT.OutboundMode != "" {
-		md.H4("NAT Summary")
+		doc.H4("NAT Summary")
 		mode := natSummary.Mode
 		if mode == "" {
 			mode = data.NAT.OutboundMode
 		}
-		md.PlainTextf("%s: %s", markdown.Bold("NAT Mode"), mode).LF().
-			PlainTextf("%s: %s", markdown.Bold("NAT Reflection"), formatters.FormatBool(natSummary.ReflectionDisabled)).
-			LF().
-			PlainTextf(
+		doc.Paragraphf("%s: %s", markdown.Bold("NAT Mode"), mode).Break().
+			Paragraphf("%s: %s", markdown.Bold("NAT Reflection"), formatters.FormatBool(natSummary.ReflectionDisabled)).
+			Break().
+			Paragraphf(
 				"%s: %s",
 				markdown.Bold("Port Forward State Sharing"),
 				formatters.FormatBool(natSummary.PfShareForward),
-			).LF().
-			PlainTextf("%s: %d", markdown.Bold("Outbound Rules"), len(natSummary.OutboundRules)).LF().
-			PlainTextf("%s: %d", markdown.Bold("Inbound Rules"), len(natSummary.InboundRules))
+			).Break().
+			Paragraphf("%s: %d", markdown.Bold("Outbound Rules"), len(natSummary.OutboundRules)).Break().
+			Paragraphf("%s: %d", markdown.Bold("Inbound Rules"), len(natSummary.InboundRules))
+		if len(natSummary.OneToOneRules) > 0 {
+			doc.Break().Paragraphf("%s: %d", markdown.Bold("1:1 NAT Rules"), len(natSummary.OneToOneRules))
+		}
 
 		if natSummary.ReflectionDisabled {
-			md.Note(
+			doc.Note(
 				"NAT reflection is properly disabled, preventing potential security issues where internal clients can access internal services via external IP addresses.",
 			)
 		} else {
-			md.Warning(
+			doc.Warning(
 				"NAT reflection is enabled, which may allow internal clients to access internal services via external IP addresses. Consider disabling if not needed.",
 			)
 		}
 	}
 
-	b.WriteOutboundNATTable(md.H4("Outbound NAT (Source Translation)"), natSummary.OutboundRules)
-	b.WriteInboundNATTable(md.H4("Inbound NAT (Port Forwarding)"), natSummary.InboundRules)
+	writeFilteredRuleTable(doc.H4("Outbound NAT (Source Translation)"), settings.ruleFilter,
+		natSummary.OutboundRules, settings.ruleFilter.MatchOutboundNATRule, ruleAnchorOutboundNAT, BuildOutboundNATTableSet)
+	if len(natSummary.OneToOneRules) > 0 {
+		writeFilteredRuleTable(doc.H4("1:1 NAT"), settings.ruleFilter,
+			natSummary.OneToOneRules, settings.ruleFilter.MatchOneToOneNATRule, ruleAnchorOneToOneNAT,
+			BuildOneToOneNATTableSet)
+		doc.Warning(
+			"1:1 NAT rules expose every port of the mapped internal hosts that the firewall rules allow. Ensure each mapping is necessary and its interface rules are restrictive.",
+		)
+	}
+	writeFilteredRuleTable(doc.H4("Inbound NAT (Port Forwarding)"), settings.ruleFilter,
+		natSummary.InboundRules, settings.ruleFilter.MatchInboundNATRule, ruleAnchorInboundNAT,
+		func(rules []common.InboundNATRule) *markdown.TableSet {
+			return BuildInboundNATTableSet(rules, !settings.noPortNames)
+		})
 
 	if len(natSummary.InboundRules) > 0 {
-		md.Warning(
+		doc.Warning(
 			"Inbound NAT rules (port forwarding) increase the attack surface by exposing internal services to external networks. Ensure these rules are necessary and properly secured.",
 		)
 	}
 
 	if len(data.FirewallRules) > 0 {
-		b.WriteFirewallRulesTable(md.H3("Firewall Rules"), data.FirewallRules)
+		doc.H3("Firewall Rules")
+		if summaries {
+			writeSectionSummaryCallout(doc, fmt.Sprintf("%s (%d enabled)",
+				pluralize(len(data.FirewallRules), "rule"), enabledFirewallRules(data.FirewallRules),
+			), componentFindings(observations, "filter.rule"))
+		}
+		writeFilteredRuleTable(doc, settings.ruleFilter,
+			data.FirewallRules, settings.ruleFilter.MatchFirewallRule, ruleAnchorFirewall,
+			func(rules []common.FirewallRule) *markdown.TableSet {
+				return BuildFirewallRulesTableSet(rules, !settings.noPortNames)
+			})
+		b.writeInterfaceHeatmapSection(doc, data)
 	}
 
+	b.writeAliasesSection(doc, data)
+	b.writeSchedulesSection(doc, data)
+	b.writePortExposureSection(doc, data)
+
+	// pf state table limits and timeouts
+	b.writePFSettingsSection(doc, data)
+
 	// IDS/Suricata Configuration
-	b.writeIDSSection(md, data)
+	b.writeIDSSection(doc, data)
 }
 
 // BuildSecuritySection builds the security configuration section.
 func (b *MarkdownBuilder) BuildSecuritySection(data *common.CommonDevice) string {
-	var buf bytes.Buffer
-	md := markdown.NewMarkdown(&buf)
-	b.writeSecuritySection(md, data)
-	return md.String()
+	doc := document.New()
+	b.writeSecuritySection(doc, data)
+	return b.render(doc)
+}
+
+// BuildSectionSummaryCallout builds the section summary callout that opens a
+// comprehensive report section, counting the section's items and the
+// findings attributed to it.
+func (b *MarkdownBuilder) BuildSectionSummaryCallout(itemCount int, findings []analysis.Finding) string {
+	doc := document.New()
+	writeSectionSummaryCallout(doc, pluralize(itemCount, "item"), findings)
+	return b.render(doc)
+}
+
+// writeSectionSummaryCallout writes a note callout of the form
+// "Section Summary: <counts>, N findings".
+func writeSectionSummaryCallout(doc *document.Document, counts string, findings []analysis.Finding) {
+	doc.Note(fmt.Sprintf("%s: %s, %s",
+		markdown.Bold("Section Summary"), counts, pluralize(len(findings), "finding"))).
+		Break()
+}
+
+// componentFindings returns the findings for the observations whose
+// component starts with prefix.
+func componentFindings(observations []analysis.Observation, prefix string) []analysis.Finding {
+	var findings []analysis.Finding
+	for _, o := range observations {
+		if strings.HasPrefix(o.Component, prefix) {
+			findings = append(findings, o.ToFinding())
+		}
+	}
+	return findings
 }
 
-// writeIDSSection writes the IDS/Suricata configuration section to the markdown instance.
-func (b *MarkdownBuilder) writeIDSSection(md *markdown.Markdown, data *common.CommonDevice) {
+// enabledFirewallRules counts the rules in rules that are not disabled.
+func enabledFirewallRules(rules []common.FirewallRule) int {
+	enabled := 0
+	for _, rule := range rules {
+		if !rule.Disabled {
+			enabled++
+		}
+	}
+	return enabled
+}
+
+// writeInterfaceHeatmapSection writes the per-interface rule count table,
+// sorted by total rules descending. Interfaces above heavyInterfaceRuleCount
+// rules are bold and interfaces without rules are italic, so over- and
+// under-engineered segments stand out.
+func (b *MarkdownBuilder) writeInterfaceHeatmapSection(doc *document.Document, data *common.CommonDevice) {
+	counts := analysis.InterfaceRuleCounts(data)
+	if len(counts) == 0 {
+		return
+	}
+
+	names := slices.Collect(maps.Keys(counts))
+	slices.SortFunc(names, func(a, c string) int {
+		if d := cmp.Compare(counts[c].Total, counts[a].Total); d != 0 {
+			return d
+		}
+		return cmp.Compare(a, c)
+	})
+
+	rows := make([][]string, 0, len(names))
+	for _, name := range names {
+		count := counts[name]
+		label := name
+		if label == "" {
+			label = "unnamed"
+		}
+
+		row := []string{
+			formatters.EscapeTableContent(label),
+			strconv.Itoa(count.Pass),
+			strconv.Itoa(count.Block),
+			strconv.Itoa(count.Total),
+		}
+
+		switch {
+		case count.Total > heavyInterfaceRuleCount:
+			for i := range row {
+				row[i] = markdown.Bold(row[i])
+			}
+		case count.Total == 0:
+			for i := range row {
+				row[i] = markdown.Italic(row[i])
+			}
+		}
+
+		rows = append(rows, row)
+	}
+
+	doc.H4("Rules per Interface").
+		Table(markdown.TableSet{
+			Header: []string{colInterface, "Pass", "Block", "Total"},
+			Rows:   rows,
+		})
+}
+
+// BuildInterfaceHeatmapSection builds the per-interface firewall rule count
+// table.
+func (b *MarkdownBuilder) BuildInterfaceHeatmapSection(data *common.CommonDevice) string {
+	doc := document.New()
+	b.writeInterfaceHeatmapSection(doc, data)
+	return b.render(doc)
+}
+
+// writeAliasesSection writes the firewall alias table in name order. URL,
+// URL table, and GeoIP aliases list their sources instead of their entries,
+// which the firewall fetches at runtime, with a marker saying so. Nothing is
+// written when the device defines no aliases.
+func (b *MarkdownBuilder) writeAliasesSection(doc *document.Document, data *common.CommonDevice) {
+	if len(data.NamedObjects) == 0 {
+		return
+	}
+
+	rows := make([][]string, 0, len(data.NamedObjects))
+	for _, name := range slices.Sorted(maps.Keys(data.NamedObjects)) {
+		obj := data.NamedObjects[name]
+		rows = append(rows, []string{
+			formatters.EscapeTableContent(name),
+			formatters.EscapeTableContent(cmp.Or(string(obj.Type), "-")),
+			formatters.EscapeTableContent(formatAliasContents(obj)),
+			formatters.EscapeTableContent(obj.Description),
+		})
+	}
+
+	doc.H4("Firewall Aliases").
+		Table(markdown.TableSet{
+			Header: []string{"Name", "Type", "Contents", "Description"},
+			Rows:   rows,
+		})
+}
+
+// BuildAliasesSection builds the firewall alias table.
+func (b *MarkdownBuilder) BuildAliasesSection(data *common.CommonDevice) string {
+	doc := document.New()
+	b.writeAliasesSection(doc, data)
+	return b.render(doc)
+}
+
+// formatAliasContents renders an alias's members, or for an externally
+// resolved alias its country codes or source URLs followed by
+// "externally resolved (N entries unknown offline)", where N counts the
+// sources whose entries cannot be known from the configuration.
+func formatAliasContents(obj common.NamedObject) string {
+	if !obj.Type.IsExternallyResolved() {
+		return cmp.Or(strings.Join(obj.Members, ", "), "-")
+	}
+
+	sources := obj.URLs
+	if obj.Type == common.NamedObjectTypeGeoIP {
+		sources = obj.Countries
+	}
+
+	entries := "entries"
+	if len(sources) == 1 {
+		entries = "entry"
+	}
+
+	marker := fmt.Sprintf("externally resolved (%d %s unknown offline)", len(sources), entries)
+	if len(sources) == 0 {
+		return marker
+	}
+
+	return strings.Join(sources, ", ") + " - " + marker
+}
+
+// writeSchedulesSection writes the firewall schedules table with the time
+// ranges of each schedule and the number of rules that use it. Nothing is
+// written when the device defines no schedules.
+func (b *MarkdownBuilder) writeSchedulesSection(doc *document.Document, data *common.CommonDevice) {
+	if len(data.Schedules) == 0 {
+		return
+	}
+
+	ruleCounts := make(map[string]int)
+	for _, rule := range data.FirewallRules {
+		if rule.Schedule != "" {
+			ruleCounts[rule.Schedule]++
+		}
+	}
+
+	rows := make([][]string, 0, len(data.Schedules))
+	for _, sched := range data.Schedules {
+		ranges := make([]string, 0, len(sched.TimeRanges))
+		for _, tr := range sched.TimeRanges {
+			ranges = append(ranges, formatScheduleTimeRange(tr))
+		}
+
+		rows = append(rows, []string{
+			formatters.EscapeTableContent(sched.Name),
+			formatters.EscapeTableContent(sched.Description),
+			formatters.EscapeTableContent(cmp.Or(strings.Join(ranges, "; "), "-")),
+			strconv.Itoa(ruleCounts[sched.Name]),
+		})
+	}
+
+	doc.H4("Schedules").
+		Table(markdown.TableSet{
+			Header: []string{"Name", "Description", "Time Ranges", "Rules"},
+			Rows:   rows,
+		})
+}
+
+// BuildSchedulesSection builds the firewall schedules table.
+func (b *MarkdownBuilder) BuildSchedulesSection(data *common.CommonDevice) string {
+	doc := document.New()
+	b.writeSchedulesSection(doc, data)
+	return b.render(doc)
+}
+
+// formatScheduleTimeRange renders a schedule time range as its days followed
+// by its hours, e.g. "Mon, Tue 08:00-17:00" or "Mar 14, Mar 15 0:00-23:59".
+func formatScheduleTimeRange(tr common.ScheduleTimeRange) string {
+	days := make([]string, 0, len(tr.Weekdays)+len(tr.Dates))
+	for _, wd := range tr.Weekdays {
+		// ISO weekday 7 is Sunday, which time.Weekday numbers 0.
+		days = append(days, time.Weekday(wd % 7).String()[:3])
+	}
+
+	for _, d := range tr.Dates {
+		days = append(days, fmt.Sprintf("%s %d", time.Month(d.Month).String()[:3], d.Day))
+	}
+
+	return strings.TrimSpace(strings.Join(days, ", ") + " " + tr.Hours)
+}
+
+// writePortExposureSection writes the ports reachable from the WAN, one row
+// per rule that opens a port, so a port opened by both a pass rule and a port
+// forward appears twice. Nothing is written when no port is exposed.
+func (b *MarkdownBuilder) writePortExposureSection(doc *document.Document, data *common.CommonDevice) {
+	entries := analysis.WANPortExposure(data)
+	if len(entries) == 0 {
+		return
+	}
+
+	rows := make([][]string, 0, len(entries))
+	for _, entry := range entries {
+		rows = append(rows, []string{
+			formatters.EscapeTableContent(entry.Protocol),
+			formatters.EscapeTableContent(entry.Port),
+			formatters.EscapeTableContent(entry.Target),
+			formatters.EscapeTableContent(entry.SourceRule),
+		})
+	}
+
+	doc.H3("WAN Port Exposure").
+		Paragraph("Ports reachable from the internet through enabled WAN pass rules and inbound port forwards.").
+		Table(markdown.TableSet{
+			Header: []string{colProtocol, "Port", "Target", "Source Rule"},
+			Rows:   rows,
+		})
+}
+
+// BuildPortExposureSection builds the table of ports reachable from the WAN.
+func (b *MarkdownBuilder) BuildPortExposureSection(data *common.CommonDevice) string {
+	doc := document.New()
+	b.writePortExposureSection(doc, data)
+	return b.render(doc)
+}
+
+// writePFSettingsSection writes the pf state table limits and timeout
+// overrides to the report document. Nothing is written when the
+// configuration leaves every pf setting at its default.
+func (b *MarkdownBuilder) writePFSettingsSection(doc *document.Document, data *common.CommonDevice) {
+	pf := data.PF
+	if pf == nil {
+		return
+	}
+
+	doc.H3("Stateful Inspection")
+
+	maxStates := "Default"
+	if pf.MaxStates > 0 {
+		maxStates = strconv.Itoa(pf.MaxStates)
+	}
+
+	limitRows := [][]string{
+		{"**Maximum States**", maxStates},
+	}
+
+	for _, limit := range []struct{ label, value string }{
+		{"**State Scaling**", pf.StateScale},
+		{"**Maximum Table Entries**", pf.MaxTableEntries},
+		{"**Maximum Fragments**", pf.MaxFragments},
+		{"**Maximum Source Nodes**", pf.MaxSourceNodes},
+		{"**Adaptive Start**", pf.AdaptiveStart},
+		{"**Adaptive End**", pf.AdaptiveEnd},
+	} {
+		if limit.value != "" {
+			limitRows = append(limitRows, []string{limit.label, formatters.EscapeTableContent(limit.value)})
+		}
+	}
+
+	doc.H4("State Limits").
+		Table(markdown.TableSet{
+			Header: []string{colSetting, colValue},
+			Rows:   limitRows,
+		})
+
+	if len(pf.Timeouts) > 0 {
+		timeoutRows := make([][]string, 0, len(pf.Timeouts))
+		for _, t := range pf.Timeouts {
+			timeoutRows = append(timeoutRows, []string{
+				fmt.Sprintf("`%s`", t.Name),
+				formatters.EscapeTableContent(t.Seconds),
+			})
+		}
+
+		doc.H4("State Timeouts").
+			Table(markdown.TableSet{
+				Header: []string{"Timeout", "Seconds"},
+				Rows:   timeoutRows,
+			})
+	}
+}
+
+// BuildPFSettingsSection builds the pf state table limits and timeouts section.
+func (b *MarkdownBuilder) BuildPFSettingsSection(data *common.CommonDevice) string {
+	doc := document.New()
+	b.writePFSettingsSection(doc, data)
+	return b.render(doc)
+}
+
+// writeIDSSection writes the IDS/Suricata configuration section to the report document.
+func (b *MarkdownBuilder) writeIDSSection(doc *document.Document, data *common.CommonDevice) {
 	ids := data.IDS
 	if ids == nil || !ids.Enabled {
 		return
 	}
 
-	md.H3("Intrusion Detection System (IDS/Suricata)")
+	doc.H3("Intrusion Detection System (IDS/Suricata)")
 
 	// Detection mode
 	detectionMode := "IDS"
@@ -107,7 +505,7 @@ func (b *MarkdownBuilder) writeIDSSection(md *markdown.Markdown, data *common.Co
 		configRows = append(configRows, []string{"**Default Packet Size**", ids.DefaultPacketSize})
 	}
 
-	md.H4("Configuration Summary").
+	doc.H4("Configuration Summary").
 		Table(markdown.TableSet{
 			Header: []string{colSetting, colValue},
 			Rows:   configRows,
@@ -115,22 +513,26 @@ func (b *MarkdownBuilder) writeIDSSection(md *markdown.Markdown, data *common.Co
 
 	// Monitored interfaces
 	if len(ids.Interfaces) > 0 {
-		md.H4("Monitored Interfaces")
+		doc.H4("Monitored Interfaces")
 		interfaceItems := make([]string, 0, len(ids.Interfaces))
 		for _, iface := range ids.Interfaces {
 			interfaceItems = append(interfaceItems, fmt.Sprintf("`%s`", iface))
 		}
-		md.BulletList(interfaceItems...)
+		doc.BulletList(interfaceItems...)
 	}
 
 	// Home networks
 	if len(ids.HomeNetworks) > 0 {
-		md.H4("Home Networks")
+		doc.H4("Home Networks")
 		netItems := make([]string, 0, len(ids.HomeNetworks))
 		for _, net := range ids.HomeNetworks {
 			netItems = append(netItems, fmt.Sprintf("`%s`", net))
 		}
-		md.BulletList(netItems...)
+		doc.BulletList(netItems...)
+	}
+
+	if len(ids.Policies) > 0 {
+		doc.H4("IDS Policy Actions").Table(*BuildIDSPolicyTableSet(ids.Policies))
 	}
 
 	// Logging configuration
@@ -155,7 +557,7 @@ func (b *MarkdownBuilder) writeIDSSection(md *markdown.Markdown, data *common.Co
 		logRows = append(logRows, []string{"**Log Retention**", ids.AlertSaveLogs})
 	}
 
-	md.H4("Logging Configuration").
+	doc.H4("Logging Configuration").
 		Table(markdown.TableSet{
 			Header: []string{colSetting, colValue},
 			Rows:   logRows,
@@ -163,40 +565,72 @@ func (b *MarkdownBuilder) writeIDSSection(md *markdown.Markdown, data *common.Co
 
 	// Security notes
 	if !ids.IPSMode {
-		md.Tip(
+		doc.Tip(
 			"Consider enabling IPS mode for active threat prevention. IDS mode only detects threats without blocking them.",
 		)
 	} else {
-		md.Note(
+		doc.Note(
 			"IPS mode is active. Suricata will actively block detected threats based on configured rules.",
 		)
 	}
 
 	if ids.SyslogEveEnabled {
-		md.Note(
+		doc.Note(
 			"EVE JSON logging is enabled via syslog, which supports SIEM integration for centralized threat monitoring.",
 		)
 	}
 }
 
+// BuildIDSPolicyTableSet builds the table data for IDS policies. Empty
+// rulesets and match criteria render as "any".
+func BuildIDSPolicyTableSet(policies []common.IDSPolicy) *markdown.TableSet {
+	headers := []string{colDescription, "Priority", colStatus, "Rulesets", "Matches", "Action"}
+
+	orAny := func(values []string) string {
+		if len(values) == 0 {
+			return destinationAny
+		}
+		return strings.Join(values, ", ")
+	}
+
+	rows := make([][]string, 0, len(policies))
+	for _, p := range policies {
+		matches := orAny(append(slices.Clone(p.MatchActions), p.Filters...))
+		rows = append(rows, []string{
+			formatters.EscapeTableContent(p.Description),
+			formatters.EscapeTableContent(p.Priority),
+			formatters.FormatBoolStatus(p.Enabled),
+			formatters.EscapeTableContent(orAny(p.Rulesets)),
+			formatters.EscapeTableContent(matches),
+			formatters.EscapeTableContent(p.Action),
+		})
+	}
+
+	return &markdown.TableSet{
+		Header: headers,
+		Rows:   rows,
+	}
+}
+
 // BuildIDSSection builds the IDS/Suricata configuration section.
 func (b *MarkdownBuilder) BuildIDSSection(data *common.CommonDevice) string {
-	var buf bytes.Buffer
-	md := markdown.NewMarkdown(&buf)
-	b.writeIDSSection(md, data)
-	return md.String()
+	doc := document.New()
+	b.writeIDSSection(doc, data)
+	return b.render(doc)
 }
 
-// WriteFirewallRulesTable writes a firewall rules table and returns md for chaining.
+// WriteFirewallRulesTable writes a firewall rules table and returns doc for chaining.
 func (b *MarkdownBuilder) WriteFirewallRulesTable(
-	md *markdown.Markdown,
+	doc *document.Document,
 	rules []common.FirewallRule,
-) *markdown.Markdown {
-	return md.Table(*BuildFirewallRulesTableSet(rules))
+) *document.Document {
+	return doc.Table(*BuildFirewallRulesTableSet(rules, !b.currentSettings().noPortNames))
 }
 
-// BuildFirewallRulesTableSet builds the table data for firewall rules.
-func BuildFirewallRulesTableSet(rules []common.FirewallRule) *markdown.TableSet {
+// BuildFirewallRulesTableSet builds the table data for firewall rules. When
+// portNames is true, well-known TCP/UDP ports are annotated with their
+// service name.
+func BuildFirewallRulesTableSet(rules []common.FirewallRule, portNames bool) *markdown.TableSet {
 	headers := []string{
 		"#",
 		colInterface,
@@ -227,7 +661,7 @@ func BuildFirewallRulesTableSet(rules []common.FirewallRule) *markdown.TableSet
 		interfaceLinks := formatters.FormatInterfacesAsLinks(rule.Interfaces)
 
 		rows = append(rows, []string{
-			strconv.Itoa(i + 1),
+			ruleNumberCell(ruleAnchorFirewall, i+1),
 			interfaceLinks,
 			string(rule.Type),
 			string(rule.IPProtocol),
@@ -235,8 +669,8 @@ func BuildFirewallRulesTableSet(rules []common.FirewallRule) *markdown.TableSet
 			source,
 			dest,
 			rule.Target,
-			formatters.EscapeTableContent(rule.Source.Port),
-			formatters.EscapeTableContent(rule.Destination.Port),
+			formatters.EscapeTableContent(formatters.FormatPortRange(rule.Source.Port, rule.Protocol, portNames)),
+			formatters.EscapeTableContent(formatters.FormatPortRange(rule.Destination.Port, rule.Protocol, portNames)),
 			formatters.FormatBoolInverted(rule.Disabled),
 			formatters.EscapeTableContent(rule.Description),
 		})
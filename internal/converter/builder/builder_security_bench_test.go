@@ -57,7 +57,7 @@ func BenchmarkFirewallRulesTable(b *testing.B) {
 		b.Run(strconv.Itoa(size), func(b *testing.B) {
 			b.ReportAllocs()
 			for b.Loop() {
-				_ = BuildFirewallRulesTableSet(rules)
+				_ = BuildFirewallRulesTableSet(rules, true)
 			}
 		})
 	}
@@ -101,7 +101,7 @@ func BenchmarkFirewallRulesTable_InterfaceCount(b *testing.B) {
 		b.Run("ifaces="+strconv.Itoa(ifaceCount), func(b *testing.B) {
 			b.ReportAllocs()
 			for b.Loop() {
-				_ = BuildFirewallRulesTableSet(rules)
+				_ = BuildFirewallRulesTableSet(rules, true)
 			}
 		})
 	}
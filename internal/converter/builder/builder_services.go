@@ -1,21 +1,22 @@
 package builder
 
 import (
-	"bytes"
+	"strconv"
 	"strings"
 
+	"github.com/EvilBit-Labs/opnDossier/internal/converter/document"
 	"github.com/EvilBit-Labs/opnDossier/internal/converter/formatters"
 	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
 	"github.com/nao1215/markdown"
 )
 
-// writeServicesSection writes the service configuration section to the markdown instance.
-func (b *MarkdownBuilder) writeServicesSection(md *markdown.Markdown, data *common.CommonDevice) {
-	md.H2("Service Configuration").
+// writeServicesSection writes the service configuration section to the report document.
+func (b *MarkdownBuilder) writeServicesSection(doc *document.Document, data *common.CommonDevice) {
+	doc.H2("Service Configuration").
 		H3("DHCP Server")
 
 	// DHCP Summary Table
-	b.WriteDHCPSummaryTable(md, data.DHCP)
+	b.WriteDHCPSummaryTable(doc, data.DHCP)
 
 	// Per-scope detailed sections (only for scopes with additional config)
 	for _, dhcp := range data.DHCP {
@@ -31,19 +32,19 @@ func (b *MarkdownBuilder) writeServicesSection(md *markdown.Markdown, data *comm
 		// Capitalize interface name for header (defensive check for empty string)
 		headerName := dhcp.Interface
 		if dhcp.Interface != "" {
-			headerName = strings.ToUpper(dhcp.Interface[:1]) + strings.ToLower(dhcp.Interface[1:])
+			headerName = formatters.CapitalizeName(dhcp.Interface)
 		}
-		md.H4(headerName + " DHCP Details")
+		doc.H4(headerName + " DHCP Details")
 
 		// Static leases table
 		if hasStaticLeases {
-			md.PlainTextf("%s:", markdown.Bold("Static Leases")).LF()
-			b.WriteDHCPStaticLeasesTable(md, dhcp.StaticLeases)
+			doc.Paragraphf("%s:", markdown.Bold("Static Leases")).Break()
+			b.WriteDHCPStaticLeasesTable(doc, dhcp.StaticLeases)
 		}
 
 		// Number options table
 		if hasNumberOptions {
-			md.PlainTextf("%s:", markdown.Bold("DHCP Number Options")).LF()
+			doc.Paragraphf("%s:", markdown.Bold("DHCP Number Options")).Break()
 			numOptRows := make([][]string, 0, len(dhcp.NumberOptions))
 			for _, opt := range dhcp.NumberOptions {
 				numOptRows = append(numOptRows, []string{
@@ -52,7 +53,7 @@ func (b *MarkdownBuilder) writeServicesSection(md *markdown.Markdown, data *comm
 					formatters.EscapeTableContent(opt.Value),
 				})
 			}
-			md.Table(markdown.TableSet{
+			doc.Table(markdown.TableSet{
 				Header: []string{"Option Number", colType, colValue},
 				Rows:   numOptRows,
 			})
@@ -60,39 +61,37 @@ func (b *MarkdownBuilder) writeServicesSection(md *markdown.Markdown, data *comm
 
 		// Advanced options section
 		if hasAdvanced {
-			md.PlainTextf("%s:", markdown.Bold("Advanced DHCP Options")).LF()
+			doc.Paragraphf("%s:", markdown.Bold("Advanced DHCP Options")).Break()
 			advItems := buildAdvancedDHCPItems(dhcp)
-			md.BulletList(advItems...)
+			doc.BulletList(advItems...)
 		}
 
 		// DHCPv6 options section
 		if hasIPv6 {
-			md.PlainTextf("%s:", markdown.Bold("DHCPv6 Options")).LF()
+			doc.Paragraphf("%s:", markdown.Bold("DHCPv6 Options")).Break()
 			v6Items := buildDHCPv6Items(dhcp)
-			md.BulletList(v6Items...)
+			doc.BulletList(v6Items...)
 		}
 	}
 
-	md.H3("DNS Resolver (Unbound)")
+	doc.H3("DNS Resolver (Unbound)")
 	if data.DNS.Unbound.Enabled {
-		md.PlainTextf("%s: %s", markdown.Bold(labelEnabled), formatters.FormatBool(data.DNS.Unbound.Enabled)).LF()
+		doc.Paragraphf("%s: %s", markdown.Bold(labelEnabled), formatters.FormatBool(data.DNS.Unbound.Enabled)).Break()
 	}
 
-	md.H3("SNMP")
+	doc.H3("SNMP")
 	if data.SNMP.SysLocation != "" {
-		md.PlainTextf("%s: %s", markdown.Bold("System Location"), data.SNMP.SysLocation).LF()
+		doc.Paragraphf("%s: %s", markdown.Bold("System Location"), data.SNMP.SysLocation).Break()
 	}
 	if data.SNMP.SysContact != "" {
-		md.PlainTextf("%s: %s", markdown.Bold("System Contact"), data.SNMP.SysContact).LF()
+		doc.Paragraphf("%s: %s", markdown.Bold("System Contact"), data.SNMP.SysContact).Break()
 	}
 	if data.SNMP.ROCommunity != "" {
-		md.PlainTextf("%s: %s", markdown.Bold("Read-Only Community"), data.SNMP.ROCommunity).LF()
+		doc.Paragraphf("%s: %s", markdown.Bold("Read-Only Community"), data.SNMP.ROCommunity).Break()
 	}
 
-	md.H3("NTP")
-	if data.NTP.PreferredServer != "" {
-		md.PlainTextf("%s: %s", markdown.Bold("Preferred Server"), data.NTP.PreferredServer).LF()
-	}
+	b.writeNTPSection(doc, data)
+	b.writeNetflowSection(doc, data)
 
 	if len(data.LoadBalancer.MonitorTypes) > 0 {
 		rows := make([][]string, 0, len(data.LoadBalancer.MonitorTypes))
@@ -103,25 +102,569 @@ func (b *MarkdownBuilder) writeServicesSection(md *markdown.Markdown, data *comm
 				formatters.EscapeTableContent(monitor.Description),
 			})
 		}
-		md.H3("Load Balancer Monitors").
+		doc.H3("Load Balancer Monitors").
 			Table(markdown.TableSet{
 				Header: []string{colName, colType, colDescription},
 				Rows:   rows,
 			})
 	}
+
+	if len(data.LoadBalancer.Pools) > 0 {
+		doc.H3("Load Balancer Pools").Table(*BuildLBPoolTableSet(data.LoadBalancer))
+	}
+
+	if len(data.LoadBalancer.VirtualServers) > 0 {
+		doc.H3("Virtual Servers").Table(*BuildLBVirtualServerTableSet(data.LoadBalancer))
+	}
+
+	b.writeWOLSection(doc, data)
+	b.writeUPnPSection(doc, data)
+	b.writeMonitSection(doc, data)
+	b.writeTrafficShaperPipeSection(doc, data)
+	b.writeQueueStatsSection(doc, data)
+}
+
+// writeNTPSection writes the NTP daemon settings, the configured time servers
+// with their prefer and noselect flags, and the client access restrictions.
+func (b *MarkdownBuilder) writeNTPSection(doc *document.Document, data *common.CommonDevice) {
+	ntp := data.NTP
+
+	doc.H3("NTP")
+	if ntp.PreferredServer != "" {
+		doc.Paragraphf("%s: %s", markdown.Bold("Preferred Server"), ntp.PreferredServer).Break()
+	}
+	if ntp.MinPoll != "" {
+		doc.Paragraphf("%s: %s", markdown.Bold("Minimum Poll Interval"), ntp.MinPoll).Break()
+	}
+	if ntp.MaxPoll != "" {
+		doc.Paragraphf("%s: %s", markdown.Bold("Maximum Poll Interval"), ntp.MaxPoll).Break()
+	}
+	if ntp.Orphan != "" {
+		doc.Paragraphf("%s: %s", markdown.Bold("Orphan Mode Stratum"), ntp.Orphan).Break()
+	}
+	if ntp.PreferredServer != "" || len(ntp.Servers) > 0 {
+		doc.Paragraphf("%s: %s", markdown.Bold("Deny Status Queries (noquery)"), formatters.FormatBool(ntp.NoQuery)).
+			Break()
+	}
+
+	if len(ntp.Servers) > 0 {
+		rows := make([][]string, 0, len(ntp.Servers))
+		for _, server := range ntp.Servers {
+			rows = append(rows, []string{
+				formatters.EscapeTableContent(server.Address),
+				formatters.FormatBool(server.Prefer),
+				formatters.FormatBool(server.NoSelect),
+			})
+		}
+
+		doc.Table(markdown.TableSet{
+			Header: []string{"Time Server", "Prefer", "No Select"},
+			Rows:   rows,
+		})
+	}
+
+	if len(ntp.Restrictions) > 0 {
+		items := make([]string, 0, len(ntp.Restrictions))
+		for _, r := range ntp.Restrictions {
+			items = append(items, markdown.Code(r))
+		}
+
+		doc.Paragraphf("%s:", markdown.Bold("Access Restrictions")).Break()
+		doc.BulletList(items...)
+	}
+}
+
+// BuildNTPSection builds the NTP time server section.
+func (b *MarkdownBuilder) BuildNTPSection(data *common.CommonDevice) string {
+	doc := document.New()
+	b.writeNTPSection(doc, data)
+	return b.render(doc)
+}
+
+// writeNetflowSection writes the NetFlow/IPFIX export settings and the
+// collectors flows are sent to. Nothing is written when NetFlow is not
+// configured.
+func (b *MarkdownBuilder) writeNetflowSection(doc *document.Document, data *common.CommonDevice) {
+	nf := data.Netflow
+	if nf == nil || (nf.CaptureInterfaces == "" && len(nf.Collectors) == 0 && !nf.CollectEnabled) {
+		return
+	}
+
+	doc.H3("NetFlow").
+		Paragraphf("%s: %s", markdown.Bold("Export Enabled"), formatters.FormatBool(nf.Enabled)).Break()
+	if nf.CaptureVersion != "" {
+		doc.Paragraphf("%s: %s", markdown.Bold("Version"), nf.CaptureVersion).Break()
+	}
+	if nf.CaptureInterfaces != "" {
+		doc.Paragraphf("%s: %s", markdown.Bold("Capture Interfaces"), nf.CaptureInterfaces).Break()
+	}
+	doc.Paragraphf("%s: %s", markdown.Bold("Egress Only"), formatters.FormatBool(nf.EgressOnly)).Break().
+		Paragraphf("%s: %s", markdown.Bold("Local Collector"), formatters.FormatBool(nf.CollectEnabled)).Break()
+	if nf.ActiveTimeout != "" {
+		doc.Paragraphf("%s: %ss", markdown.Bold("Active Timeout"), nf.ActiveTimeout).Break()
+	}
+	if nf.InactiveTimeout != "" {
+		doc.Paragraphf("%s: %ss", markdown.Bold("Inactive Timeout"), nf.InactiveTimeout).Break()
+	}
+
+	if len(nf.Collectors) > 0 {
+		rows := make([][]string, 0, len(nf.Collectors))
+		for _, c := range nf.Collectors {
+			port := c.Port
+			if port == "" {
+				port = "-"
+			}
+			rows = append(rows, []string{formatters.EscapeTableContent(c.Address), formatters.EscapeTableContent(port)})
+		}
+
+		doc.Table(markdown.TableSet{
+			Header: []string{"Collector", "Port"},
+			Rows:   rows,
+		})
+	}
+}
+
+// BuildNetflowSection builds the NetFlow export section.
+func (b *MarkdownBuilder) BuildNetflowSection(data *common.CommonDevice) string {
+	doc := document.New()
+	b.writeNetflowSection(doc, data)
+	return b.render(doc)
+}
+
+// writeWOLSection writes the Wake-on-LAN hosts table to the markdown
+// instance. Nothing is written when no hosts are configured.
+func (b *MarkdownBuilder) writeWOLSection(doc *document.Document, data *common.CommonDevice) {
+	if len(data.WakeOnLAN) == 0 {
+		return
+	}
+
+	rows := make([][]string, 0, len(data.WakeOnLAN))
+	for _, entry := range data.WakeOnLAN {
+		rows = append(rows, []string{
+			formatters.EscapeTableContent(entry.Interface),
+			formatters.EscapeTableContent(entry.MAC),
+			formatters.EscapeTableContent(entry.Description),
+		})
+	}
+
+	doc.H3("Wake-on-LAN").
+		Table(markdown.TableSet{
+			Header: []string{colInterface, "MAC", colDescription},
+			Rows:   rows,
+		})
+}
+
+// BuildWOLSection builds the Wake-on-LAN hosts section.
+func (b *MarkdownBuilder) BuildWOLSection(data *common.CommonDevice) string {
+	doc := document.New()
+	b.writeWOLSection(doc, data)
+	return b.render(doc)
+}
+
+// writeUPnPSection writes the UPnP IGD / NAT-PMP status, interfaces, and ACL
+// table. Nothing is written when the configuration has no miniupnpd settings.
+func (b *MarkdownBuilder) writeUPnPSection(doc *document.Document, data *common.CommonDevice) {
+	upnp := data.UPnP
+	if upnp == nil {
+		return
+	}
+
+	doc.H3("UPnP / NAT-PMP").
+		Paragraphf("%s: %s", markdown.Bold(labelEnabled), formatters.FormatBool(upnp.Enabled)).Break().
+		Paragraphf("%s: %s", markdown.Bold("UPnP IGD"), formatters.FormatBool(upnp.UPnPEnabled)).Break().
+		Paragraphf("%s: %s", markdown.Bold("NAT-PMP"), formatters.FormatBool(upnp.NATPMPEnabled)).Break()
+	if upnp.ExternalInterface != "" {
+		doc.Paragraphf("%s: %s", markdown.Bold("External Interface"), upnp.ExternalInterface).Break()
+	}
+	if len(upnp.Interfaces) > 0 {
+		doc.Paragraphf("%s: %s", markdown.Bold("Listening Interfaces"), strings.Join(upnp.Interfaces, ", ")).Break()
+	}
+	doc.Paragraphf("%s: %s", markdown.Bold("Default Deny"), formatters.FormatBool(upnp.DefaultDeny)).Break()
+
+	if len(upnp.Rules) > 0 {
+		rows := make([][]string, 0, len(upnp.Rules))
+		for _, rule := range upnp.Rules {
+			rows = append(rows, []string{
+				formatters.EscapeTableContent(rule.Action),
+				formatters.EscapeTableContent(rule.ExternalPorts),
+				formatters.EscapeTableContent(rule.InternalAddress),
+				formatters.EscapeTableContent(rule.InternalPorts),
+				formatters.EscapeTableContent(rule.Description),
+			})
+		}
+
+		doc.Table(markdown.TableSet{
+			Header: []string{"Action", "External Ports", "Internal Address", "Internal Ports", colDescription},
+			Rows:   rows,
+		})
+	}
+
+	if upnp.Enabled && !upnp.DefaultDeny {
+		doc.Warning("Default deny is off: requests that match no rule are accepted, " +
+			"so any client can open port forwards to any internal host and port.")
+	}
+}
+
+// BuildUPnPSection builds the UPnP / NAT-PMP section.
+func (b *MarkdownBuilder) BuildUPnPSection(data *common.CommonDevice) string {
+	doc := document.New()
+	b.writeUPnPSection(doc, data)
+	return b.render(doc)
+}
+
+// writeMonitSection writes the Monit service monitor settings followed by
+// its alert recipients, monitored services, and tests. Each service lists
+// the names of the tests applied to it; a test reference that does not
+// resolve shows the raw UUID. Nothing is written when Monit is not
+// configured.
+func (b *MarkdownBuilder) writeMonitSection(doc *document.Document, data *common.CommonDevice) {
+	monit := data.Monit
+	if monit == nil {
+		return
+	}
+
+	doc.H3("Monit").
+		Paragraphf("%s: %s", markdown.Bold(labelEnabled), formatters.FormatBool(monit.Enabled)).Break()
+	if monit.Interval != "" {
+		doc.Paragraphf("%s: %s s", markdown.Bold("Polling Interval"), monit.Interval).Break()
+	}
+	if monit.MailServer != "" {
+		server := monit.MailServer
+		if monit.MailPort != "" {
+			server += ":" + monit.MailPort
+		}
+		doc.Paragraphf("%s: %s (TLS: %s)", markdown.Bold("Mail Server"), server, formatters.FormatBool(monit.SSLEnabled)).Break()
+	}
+	if monit.HTTPDEnabled {
+		doc.Paragraphf("%s: port %s", markdown.Bold("Web Interface"), monit.HTTPDPort).Break()
+	}
+	if monit.MMonitURL != "" {
+		doc.Paragraphf("%s: %s", markdown.Bold("M/Monit"), monit.MMonitURL).Break()
+	}
+
+	if len(monit.Alerts) > 0 {
+		rows := make([][]string, 0, len(monit.Alerts))
+		for _, alert := range monit.Alerts {
+			rows = append(rows, []string{
+				formatters.EscapeTableContent(alert.Recipient),
+				formatters.FormatBool(alert.Enabled),
+				formatters.EscapeTableContent(monitAlertEvents(alert)),
+				formatters.EscapeTableContent(alert.Description),
+			})
+		}
+
+		doc.H4("Monit Alerts").Table(markdown.TableSet{
+			Header: []string{"Recipient", colEnabled, "Events", colDescription},
+			Rows:   rows,
+		})
+	}
+
+	if len(monit.Services) > 0 {
+		testNames := make(map[string]string, len(monit.Tests))
+		for _, t := range monit.Tests {
+			testNames[t.UUID] = t.Name
+		}
+
+		rows := make([][]string, 0, len(monit.Services))
+		for _, svc := range monit.Services {
+			var tests []string
+			for _, ref := range strings.Split(svc.Tests, ",") {
+				ref = strings.TrimSpace(ref)
+				if ref == "" {
+					continue
+				}
+				if name, ok := testNames[ref]; ok {
+					ref = name
+				}
+				tests = append(tests, ref)
+			}
+
+			rows = append(rows, []string{
+				formatters.EscapeTableContent(svc.Name),
+				formatters.FormatBool(svc.Enabled),
+				formatters.EscapeTableContent(svc.Type),
+				formatters.EscapeTableContent(monitServiceTarget(svc)),
+				formatters.EscapeTableContent(joinOrDash(tests)),
+			})
+		}
+
+		doc.H4("Monitored Services").Table(markdown.TableSet{
+			Header: []string{colName, colEnabled, colType, "Target", "Tests"},
+			Rows:   rows,
+		})
+	}
+
+	if len(monit.Tests) > 0 {
+		rows := make([][]string, 0, len(monit.Tests))
+		for _, t := range monit.Tests {
+			rows = append(rows, []string{
+				formatters.EscapeTableContent(t.Name),
+				formatters.EscapeTableContent(t.Type),
+				formatters.EscapeTableContent(t.Condition),
+				formatters.EscapeTableContent(t.Action),
+			})
+		}
+
+		doc.H4("Monit Tests").Table(markdown.TableSet{
+			Header: []string{colName, colType, "Condition", "Action"},
+			Rows:   rows,
+		})
+	}
+}
+
+// monitAlertEvents describes the events an alert recipient is notified of.
+// An empty event list means every event; NotOn inverts the list.
+func monitAlertEvents(alert common.MonitAlert) string {
+	events := strings.ReplaceAll(alert.Events, ",", ", ")
+	switch {
+	case events == "":
+		return "all"
+	case alert.NotOn == "1":
+		return "all except " + events
+	default:
+		return events
+	}
+}
+
+// monitServiceTarget returns what a monitored service checks: its PID file,
+// process match pattern, path, or address, whichever is set first.
+func monitServiceTarget(svc common.MonitServiceEntry) string {
+	for _, target := range []string{svc.PIDFile, svc.Match, svc.Path, svc.Address} {
+		if target != "" {
+			return target
+		}
+	}
+
+	return "-"
+}
+
+// BuildMonitSection builds the Monit service monitoring section.
+func (b *MarkdownBuilder) BuildMonitSection(data *common.CommonDevice) string {
+	doc := document.New()
+	b.writeMonitSection(doc, data)
+	return b.render(doc)
+}
+
+// writeTrafficShaperPipeSection writes the traffic shaper pipes with the
+// queues attached to each, followed by the queues and the pipe whose
+// bandwidth they share. A queue whose pipe is not defined shows the raw
+// reference. Nothing is written when no pipes or queues are configured.
+func (b *MarkdownBuilder) writeTrafficShaperPipeSection(doc *document.Document, data *common.CommonDevice) {
+	ts := data.TrafficShaper
+	if ts == nil || (len(ts.PipeEntries) == 0 && len(ts.QueueEntries) == 0) {
+		return
+	}
+
+	pipeNames := make(map[string]string, len(ts.PipeEntries))
+	for _, p := range ts.PipeEntries {
+		pipeNames[p.UUID] = shaperName(p.Description, "Pipe", p.Number)
+	}
+
+	queuesByPipe := make(map[string][]string, len(ts.PipeEntries))
+	for _, q := range ts.QueueEntries {
+		queuesByPipe[q.Pipe] = append(queuesByPipe[q.Pipe], shaperName(q.Description, "Queue", q.Number))
+	}
+
+	if len(ts.PipeEntries) > 0 {
+		rows := make([][]string, 0, len(ts.PipeEntries))
+		for _, p := range ts.PipeEntries {
+			delay := "-"
+			if p.Delay != "" {
+				delay = p.Delay + " ms"
+			}
+
+			rows = append(rows, []string{
+				formatters.EscapeTableContent(pipeNames[p.UUID]),
+				formatters.FormatBool(p.Enabled),
+				formatters.EscapeTableContent(strings.TrimSpace(p.Bandwidth + " " + p.BandwidthMetric)),
+				delay,
+				formatters.EscapeTableContent(p.Mask),
+				formatters.EscapeTableContent(joinOrDash(queuesByPipe[p.UUID])),
+			})
+		}
+
+		doc.H3("Traffic Shaper Pipes").Table(markdown.TableSet{
+			Header: []string{"Pipe", colEnabled, "Bandwidth", "Delay", "Mask", "Queues"},
+			Rows:   rows,
+		})
+	}
+
+	if len(ts.QueueEntries) > 0 {
+		rows := make([][]string, 0, len(ts.QueueEntries))
+		for _, q := range ts.QueueEntries {
+			pipe, ok := pipeNames[q.Pipe]
+			if !ok {
+				pipe = q.Pipe
+			}
+
+			rows = append(rows, []string{
+				formatters.EscapeTableContent(shaperName(q.Description, "Queue", q.Number)),
+				formatters.FormatBool(q.Enabled),
+				formatters.EscapeTableContent(pipe),
+				formatters.EscapeTableContent(q.Weight),
+				formatters.EscapeTableContent(q.Mask),
+			})
+		}
+
+		doc.H3("Traffic Shaper Queues").Table(markdown.TableSet{
+			Header: []string{"Queue", colEnabled, "Pipe", "Weight", "Mask"},
+			Rows:   rows,
+		})
+	}
+}
+
+// shaperName labels a traffic shaper pipe or queue by its description, or
+// by kind and number when it has none.
+func shaperName(description, kind, number string) string {
+	if description != "" {
+		return description
+	}
+
+	return kind + " " + number
+}
+
+// BuildTrafficShaperPipeSection builds the traffic shaper pipes and queues
+// section.
+func (b *MarkdownBuilder) BuildTrafficShaperPipeSection(data *common.CommonDevice) string {
+	doc := document.New()
+	b.writeTrafficShaperPipeSection(doc, data)
+	return b.render(doc)
+}
+
+// writeQueueStatsSection writes the traffic shaper queue throughput table
+// captured in the backup export. Nothing is written when the export carries
+// no queue statistics.
+func (b *MarkdownBuilder) writeQueueStatsSection(doc *document.Document, data *common.CommonDevice) {
+	if data.QueueStats == nil || len(data.QueueStats.Queues) == 0 {
+		return
+	}
+
+	rows := make([][]string, 0, len(data.QueueStats.Queues))
+	for _, q := range data.QueueStats.Queues {
+		rows = append(rows, []string{
+			formatters.EscapeTableContent(q.Name),
+			formatters.EscapeTableContent(q.Interface),
+			strconv.FormatInt(q.Packets, 10),
+			formatters.FormatBytes(q.Bytes),
+			strconv.FormatInt(q.DroppedPackets, 10),
+			formatters.FormatBytes(q.DroppedBytes),
+			strconv.FormatInt(q.QueueLength, 10),
+		})
+	}
+
+	doc.H3("Queue Statistics")
+	if data.QueueStats.Timestamp != "" {
+		doc.Paragraph("Counters captured at " + formatters.EscapeMarkdownText(data.QueueStats.Timestamp) + ".")
+	}
+
+	doc.Table(markdown.TableSet{
+		Header: []string{
+			"Queue",
+			colInterface,
+			"Packets",
+			"Bytes",
+			"Dropped Packets",
+			"Dropped Bytes",
+			"Queue Length",
+		},
+		Rows: rows,
+	})
+}
+
+// BuildQueueStatsSection builds the traffic shaper queue statistics section.
+func (b *MarkdownBuilder) BuildQueueStatsSection(data *common.CommonDevice) string {
+	doc := document.New()
+	b.writeQueueStatsSection(doc, data)
+	return b.render(doc)
+}
+
+// BuildLBPoolTableSet builds the table data for load balancer pools. The
+// "Used By" column lists the virtual servers that reference each pool as
+// their primary or fallback pool.
+func BuildLBPoolTableSet(lb common.LoadBalancerConfig) *markdown.TableSet {
+	usedBy := make(map[string][]string, len(lb.Pools))
+	for _, vs := range lb.VirtualServers {
+		usedBy[vs.Pool] = append(usedBy[vs.Pool], vs.Name)
+		if vs.FallbackPool != "" && vs.FallbackPool != vs.Pool {
+			usedBy[vs.FallbackPool] = append(usedBy[vs.FallbackPool], vs.Name+" (fallback)")
+		}
+	}
+
+	rows := make([][]string, 0, len(lb.Pools))
+	for _, pool := range lb.Pools {
+		rows = append(rows, []string{
+			formatters.EscapeTableContent(pool.Name),
+			formatters.EscapeTableContent(pool.Mode),
+			formatters.EscapeTableContent(pool.Port),
+			formatters.EscapeTableContent(pool.Monitor),
+			formatters.EscapeTableContent(joinOrDash(pool.Servers)),
+			formatters.EscapeTableContent(joinOrDash(pool.DisabledServers)),
+			formatters.EscapeTableContent(joinOrDash(usedBy[pool.Name])),
+		})
+	}
+
+	return &markdown.TableSet{
+		Header: []string{colName, colMode, "Port", "Monitor", "Enabled Servers", "Disabled Servers", "Used By"},
+		Rows:   rows,
+	}
+}
+
+// BuildLBVirtualServerTableSet builds the table data for load balancer virtual
+// servers. The "Backend Servers" column resolves each virtual server's pool to
+// its enabled members so the report shows which internal hosts sit behind
+// each exposed address.
+func BuildLBVirtualServerTableSet(lb common.LoadBalancerConfig) *markdown.TableSet {
+	pools := make(map[string]common.LBPool, len(lb.Pools))
+	for _, pool := range lb.Pools {
+		pools[pool.Name] = pool
+	}
+
+	rows := make([][]string, 0, len(lb.VirtualServers))
+	for _, vs := range lb.VirtualServers {
+		backends := "pool not found"
+		if pool, ok := pools[vs.Pool]; ok {
+			backends = joinOrDash(pool.Servers)
+			if pool.Port != "" && len(pool.Servers) > 0 {
+				backends += " (port " + pool.Port + ")"
+			}
+		}
+
+		rows = append(rows, []string{
+			formatters.EscapeTableContent(vs.Name),
+			formatters.EscapeTableContent(vs.Address),
+			formatters.EscapeTableContent(vs.Port),
+			formatters.EscapeTableContent(vs.Pool),
+			formatters.EscapeTableContent(vs.FallbackPool),
+			formatters.EscapeTableContent(backends),
+			formatters.EscapeTableContent(vs.Description),
+		})
+	}
+
+	return &markdown.TableSet{
+		Header: []string{colName, "Address", "Port", "Pool", "Fallback Pool", "Backend Servers", colDescription},
+		Rows:   rows,
+	}
+}
+
+// joinOrDash joins values with ", ", or returns "-" when values is empty.
+func joinOrDash(values []string) string {
+	if len(values) == 0 {
+		return "-"
+	}
+
+	return strings.Join(values, ", ")
 }
 
 // BuildServicesSection builds the service configuration section.
 func (b *MarkdownBuilder) BuildServicesSection(data *common.CommonDevice) string {
-	var buf bytes.Buffer
-	md := markdown.NewMarkdown(&buf)
-	b.writeServicesSection(md, data)
-	return md.String()
+	doc := document.New()
+	b.writeServicesSection(doc, data)
+	return b.render(doc)
 }
 
-// WriteDHCPSummaryTable writes a DHCP scope summary table and returns md for chaining.
-func (b *MarkdownBuilder) WriteDHCPSummaryTable(md *markdown.Markdown, scopes []common.DHCPScope) *markdown.Markdown {
-	return md.Table(*BuildDHCPSummaryTableSet(scopes))
+// WriteDHCPSummaryTable writes a DHCP scope summary table and returns doc for chaining.
+func (b *MarkdownBuilder) WriteDHCPSummaryTable(doc *document.Document, scopes []common.DHCPScope) *document.Document {
+	return doc.Table(*BuildDHCPSummaryTableSet(scopes))
 }
 
 // BuildDHCPSummaryTableSet builds the table data for DHCP scope summary.
@@ -165,12 +708,12 @@ func BuildDHCPSummaryTableSet(scopes []common.DHCPScope) *markdown.TableSet {
 	}
 }
 
-// WriteDHCPStaticLeasesTable writes a static DHCP leases table and returns md for chaining.
+// WriteDHCPStaticLeasesTable writes a static DHCP leases table and returns doc for chaining.
 func (b *MarkdownBuilder) WriteDHCPStaticLeasesTable(
-	md *markdown.Markdown,
+	doc *document.Document,
 	leases []common.DHCPStaticLease,
-) *markdown.Markdown {
-	return md.Table(*BuildDHCPStaticLeasesTableSet(leases))
+) *document.Document {
+	return doc.Table(*BuildDHCPStaticLeasesTableSet(leases))
 }
 
 // BuildDHCPStaticLeasesTableSet builds the table data for static DHCP leases.
@@ -0,0 +1,39 @@
+package builder
+
+import (
+	"strings"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/analysis"
+	"github.com/EvilBit-Labs/opnDossier/internal/converter/document"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+)
+
+// executiveSummary returns the executive summary paragraph for a report on
+// data, reusing the Analysis populated by export enrichment when present.
+func executiveSummary(data *common.CommonDevice) string {
+	return analysis.ExecutiveSummary(analysis.SummarizeAnalysis(data, data.Analysis))
+}
+
+// writeExecutiveSummarySection writes the executive summary heading and
+// paragraph to the report document. An empty summary writes nothing.
+func writeExecutiveSummarySection(doc *document.Document, summary string) {
+	summary = strings.TrimSpace(summary)
+	if summary == "" {
+		return
+	}
+
+	doc.H2("Executive Summary").
+		Paragraph(summary).
+		Break()
+}
+
+// BuildExecutiveSummarySection builds the executive summary section placed at
+// the top of a report. summary is a plain-English paragraph such as the one
+// produced by the processor report's ExecutiveSummary method; the standard
+// and comprehensive reports derive theirs from the device's analysis.
+// Returns an empty string when summary is empty.
+func (b *MarkdownBuilder) BuildExecutiveSummarySection(summary string) string {
+	doc := document.New()
+	writeExecutiveSummarySection(doc, summary)
+	return b.render(doc)
+}
@@ -1,139 +1,187 @@
 package builder
 
 import (
-	"bytes"
+	"net"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/EvilBit-Labs/opnDossier/internal/converter/document"
 	"github.com/EvilBit-Labs/opnDossier/internal/converter/formatters"
+	"github.com/EvilBit-Labs/opnDossier/internal/tunables"
 	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
 	"github.com/nao1215/markdown"
 )
 
-// writeSystemSection writes the system configuration section to the markdown instance.
-func (b *MarkdownBuilder) writeSystemSection(md *markdown.Markdown, data *common.CommonDevice) {
+// writeSystemSection writes the system configuration section to the report document.
+func (b *MarkdownBuilder) writeSystemSection(doc *document.Document, data *common.CommonDevice) {
 	sys := data.System
-	md.H2("System Configuration")
+	doc.H2("System Configuration")
 
-	writeSystemBasics(md, sys)
-	writeSystemWebGUI(md, sys)
-	writeSystemSettings(md, sys)
-	writeSystemHardwareOffloading(md, sys)
-	writeSystemPowerManagement(md, sys)
-	writeSystemFeatures(md, sys)
-	writeSystemMisc(md, sys)
+	writeSystemBasics(doc, sys)
+	writeSystemWebGUI(doc, sys, data.WebGUICert())
+	writeSystemSettings(doc, sys)
+	writeSystemHardwareOffloading(doc, sys, data.NATSummary().ReflectionDisabled)
+	writeSystemPowerManagement(doc, sys)
+	writeSystemFeatures(doc, sys)
+	writeSystemMisc(doc, sys)
 
 	if len(data.Users) > 0 {
-		b.WriteUserTable(md.H3("System Users"), data.Users)
+		b.WriteUserTable(doc.H3("System Users"), data.Users)
 	}
 	if len(data.Groups) > 0 {
-		b.WriteGroupTable(md.H3("System Groups"), data.Groups)
+		b.WriteGroupTable(doc.H3("System Groups"), data.Groups)
+	}
+	if len(data.AuthServers) > 0 {
+		doc.H3("Authentication Servers").Table(*BuildAuthServerTableSet(data.AuthServers))
 	}
 }
 
-func writeSystemBasics(md *markdown.Markdown, sys common.System) {
-	md.H3("Basic Information").
-		PlainTextf("%s: %s", markdown.Bold("Hostname"), sys.Hostname).LF().
-		PlainTextf("%s: %s", markdown.Bold("Domain"), sys.Domain).LF()
+func writeSystemBasics(doc *document.Document, sys common.System) {
+	doc.H3("Basic Information").
+		Paragraphf("%s: %s", markdown.Bold("Hostname"), sys.Hostname).Break().
+		Paragraphf("%s: %s", markdown.Bold("Domain"), sys.Domain).Break()
 
 	if sys.Optimization != "" {
-		md.PlainTextf("%s: %s", markdown.Bold("Optimization"), sys.Optimization).LF()
+		doc.Paragraphf("%s: %s", markdown.Bold("Optimization"), sys.Optimization).Break()
 	}
 	if sys.Timezone != "" {
-		md.PlainTextf("%s: %s", markdown.Bold("Timezone"), sys.Timezone).LF()
+		doc.Paragraphf("%s: %s", markdown.Bold("Timezone"), sys.Timezone).Break()
 	}
 	if sys.Language != "" {
-		md.PlainTextf("%s: %s", markdown.Bold("Language"), sys.Language).LF()
+		doc.Paragraphf("%s: %s", markdown.Bold("Language"), sys.Language).Break()
 	}
 }
 
-func writeSystemWebGUI(md *markdown.Markdown, sys common.System) {
+// writeSystemWebGUI writes the web GUI settings. cert is the certificate
+// sys.WebGUI.SSLCertRef resolves to; the reference is shown as its
+// description, or as the raw refid when it does not resolve.
+func writeSystemWebGUI(doc *document.Document, sys common.System, cert *common.Certificate) {
 	if sys.WebGUI.Protocol == "" {
 		return
 	}
-	md.H3("Web GUI Configuration").
-		PlainTextf("%s: %s", markdown.Bold(colProtocol), sys.WebGUI.Protocol).LF()
+	doc.H3("Web GUI Configuration").
+		Paragraphf("%s: %s", markdown.Bold(colProtocol), sys.WebGUI.Protocol).Break()
+
+	if ref := sys.WebGUI.SSLCertRef; ref != "" {
+		switch {
+		case cert == nil:
+			doc.Paragraphf("%s: %s (not found)", markdown.Bold("SSL Certificate"), ref).Break()
+		case cert.Description == "":
+			doc.Paragraphf("%s: %s", markdown.Bold("SSL Certificate"), ref).Break()
+		default:
+			doc.Paragraphf("%s: %s", markdown.Bold("SSL Certificate"), cert.Description).Break()
+		}
+	}
 }
 
-func writeSystemSettings(md *markdown.Markdown, sys common.System) {
-	md.H3("System Settings").
-		PlainTextf("%s: %s", markdown.Bold("DNS Allow Override"), formatters.FormatBool(sys.DNSAllowOverride)).LF().
-		PlainTextf("%s: %d", markdown.Bold("Next UID"), sys.NextUID).LF().
-		PlainTextf("%s: %d", markdown.Bold("Next GID"), sys.NextGID).LF()
+func writeSystemSettings(doc *document.Document, sys common.System) {
+	doc.H3("System Settings").
+		Paragraphf("%s: %s", markdown.Bold("DNS Allow Override"), formatters.FormatBool(sys.DNSAllowOverride)).Break().
+		Paragraphf("%s: %d", markdown.Bold("Next UID"), sys.NextUID).Break().
+		Paragraphf("%s: %d", markdown.Bold("Next GID"), sys.NextGID).Break()
 
 	if len(sys.TimeServers) > 0 {
-		md.PlainTextf("%s: %s", markdown.Bold("Time Servers"), strings.Join(sys.TimeServers, ", ")).LF()
+		doc.Paragraphf("%s: %s", markdown.Bold("Time Servers"), strings.Join(sys.TimeServers, ", ")).Break()
 	}
 	if len(sys.DNSServers) > 0 {
-		md.PlainTextf("%s: %s", markdown.Bold("DNS Server"), strings.Join(sys.DNSServers, ", ")).LF()
-	}
-}
-
-func writeSystemHardwareOffloading(md *markdown.Markdown, sys common.System) {
-	md.H3("Hardware Offloading").
-		PlainTextf("%s: %s", markdown.Bold("Disable NAT Reflection"), formatters.FormatBool(sys.DisableNATReflection)).
-		LF().
-		PlainTextf("%s: %s", markdown.Bold("Use Virtual Terminal"), formatters.FormatBool(sys.UseVirtualTerminal)).LF().
-		PlainTextf("%s: %s", markdown.Bold("Disable Console Menu"), formatters.FormatBool(sys.DisableConsoleMenu)).LF().
-		PlainTextf("%s: %s", markdown.Bold("Disable VLAN HW Filter"), formatters.FormatBool(sys.DisableVLANHWFilter)).
-		LF().
-		PlainTextf("%s: %s", markdown.Bold("Disable Checksum Offloading"), formatters.FormatBool(sys.DisableChecksumOffloading)).
-		LF().
-		PlainTextf("%s: %s", markdown.Bold("Disable Segmentation Offloading"), formatters.FormatBool(sys.DisableSegmentationOffloading)).
-		LF().
-		PlainTextf("%s: %s", markdown.Bold("Disable Large Receive Offloading"), formatters.FormatBool(sys.DisableLargeReceiveOffloading)).
-		LF().
-		PlainTextf("%s: %s", markdown.Bold("IPv6 Allow"), formatters.FormatBool(sys.IPv6Allow)).LF()
-}
-
-func writeSystemPowerManagement(md *markdown.Markdown, sys common.System) {
-	md.H3("Power Management").
-		PlainTextf("%s: %s", markdown.Bold("Powerd AC Mode"), formatters.GetPowerModeDescriptionCompact(sys.PowerdACMode)).
-		LF().
-		PlainTextf("%s: %s", markdown.Bold("Powerd Battery Mode"), formatters.GetPowerModeDescriptionCompact(sys.PowerdBatteryMode)).
-		LF().
-		PlainTextf("%s: %s", markdown.Bold("Powerd Normal Mode"), formatters.GetPowerModeDescriptionCompact(sys.PowerdNormalMode)).
-		LF()
-}
-
-func writeSystemFeatures(md *markdown.Markdown, sys common.System) {
-	md.H3("System Features").
-		PlainTextf("%s: %s", markdown.Bold("PF Share Forward"), formatters.FormatBool(sys.PfShareForward)).LF().
-		PlainTextf("%s: %s", markdown.Bold("LB Use Sticky"), formatters.FormatBool(sys.LbUseSticky)).LF().
-		PlainTextf("%s: %s", markdown.Bold("RRD Backup"), formatters.FormatBool(sys.RrdBackup)).LF().
-		PlainTextf("%s: %s", markdown.Bold("Netflow Backup"), formatters.FormatBool(sys.NetflowBackup))
-}
-
-func writeSystemMisc(md *markdown.Markdown, sys common.System) {
+		doc.Paragraphf("%s: %s", markdown.Bold("DNS Server"), strings.Join(sys.DNSServers, ", ")).Break()
+	}
+}
+
+// writeSystemHardwareOffloading writes the hardware offloading settings. The
+// NAT reflection line takes reflectionDisabled from the NAT configuration so
+// it always agrees with the security section.
+func writeSystemHardwareOffloading(doc *document.Document, sys common.System, reflectionDisabled bool) {
+	doc.H3("Hardware Offloading").
+		Paragraphf("%s: %s", markdown.Bold("Disable NAT Reflection"), formatters.FormatBool(reflectionDisabled)).
+		Break().
+		Paragraphf("%s: %s", markdown.Bold("Use Virtual Terminal"), formatters.FormatBool(sys.UseVirtualTerminal)).Break().
+		Paragraphf("%s: %s", markdown.Bold("Disable Console Menu"), formatters.FormatBool(sys.DisableConsoleMenu)).Break().
+		Paragraphf("%s: %s", markdown.Bold("Disable VLAN HW Filter"), formatters.FormatBool(sys.DisableVLANHWFilter)).
+		Break().
+		Paragraphf("%s: %s", markdown.Bold("Disable Checksum Offloading"), formatters.FormatBool(sys.DisableChecksumOffloading)).
+		Break().
+		Paragraphf("%s: %s", markdown.Bold("Disable Segmentation Offloading"), formatters.FormatBool(sys.DisableSegmentationOffloading)).
+		Break().
+		Paragraphf("%s: %s", markdown.Bold("Disable Large Receive Offloading"), formatters.FormatBool(sys.DisableLargeReceiveOffloading)).
+		Break().
+		Paragraphf("%s: %s", markdown.Bold("IPv6 Allow"), formatters.FormatBool(sys.IPv6Allow)).Break()
+}
+
+func writeSystemPowerManagement(doc *document.Document, sys common.System) {
+	doc.H3("Power Management").
+		Paragraphf("%s: %s", markdown.Bold("Powerd AC Mode"), formatters.GetPowerModeDescriptionCompact(sys.PowerdACMode)).
+		Break().
+		Paragraphf("%s: %s", markdown.Bold("Powerd Battery Mode"), formatters.GetPowerModeDescriptionCompact(sys.PowerdBatteryMode)).
+		Break().
+		Paragraphf("%s: %s", markdown.Bold("Powerd Normal Mode"), formatters.GetPowerModeDescriptionCompact(sys.PowerdNormalMode)).
+		Break()
+}
+
+func writeSystemFeatures(doc *document.Document, sys common.System) {
+	doc.H3("System Features").
+		Paragraphf("%s: %s", markdown.Bold("PF Share Forward"), formatters.FormatBool(sys.PfShareForward)).Break().
+		Paragraphf("%s: %s", markdown.Bold("LB Use Sticky"), formatters.FormatBool(sys.LbUseSticky)).Break().
+		Paragraphf("%s: %s", markdown.Bold("RRD Backup"), formatters.FormatBool(sys.RrdBackup)).Break().
+		Paragraphf("%s: %s", markdown.Bold("Netflow Backup"), formatters.FormatBool(sys.NetflowBackup))
+}
+
+func writeSystemMisc(doc *document.Document, sys common.System) {
 	if sys.Bogons.Interval != "" {
-		md.H3("Bogons Configuration").
-			PlainTextf("%s: %s", markdown.Bold("Interval"), sys.Bogons.Interval).LF()
+		doc.H3("Bogons Configuration").
+			Paragraphf("%s: %s", markdown.Bold("Interval"), sys.Bogons.Interval).Break()
 	}
 	if sys.SSH.Group != "" {
-		md.H3("SSH Configuration").
-			PlainTextf("%s: %s", markdown.Bold("Group"), sys.SSH.Group).LF()
+		doc.H3("SSH Configuration").
+			Paragraphf("%s: %s", markdown.Bold("Group"), sys.SSH.Group).Break()
 	}
 	if sys.Firmware.Version != "" {
-		md.H3("Firmware Information").
-			PlainTextf("%s: %s", markdown.Bold("Version"), sys.Firmware.Version).LF()
+		doc.H3("Firmware Information").
+			Paragraphf("%s: %s", markdown.Bold("Version"), sys.Firmware.Version).Break()
+	}
+	if sys.Console.PrimaryConsole != "" || sys.Console.SerialSpeed != 0 {
+		doc.H3("Console Configuration")
+		if sys.Console.PrimaryConsole != "" {
+			doc.Paragraphf("%s: %s", markdown.Bold("Primary Console"), sys.Console.PrimaryConsole).Break()
+		}
+		if sys.Console.SerialSpeed != 0 {
+			doc.Paragraphf("%s: %d baud", markdown.Bold("Serial Speed"), sys.Console.SerialSpeed).Break()
+		}
+	}
+}
+
+// writeComprehensiveSystemSection writes the system section followed by the
+// comprehensive-only note naming the cosmetic and telemetry sections present
+// in the source configuration. Their content is never rendered.
+func (b *MarkdownBuilder) writeComprehensiveSystemSection(doc *document.Document, data *common.CommonDevice) {
+	b.writeSystemSection(doc, data)
+
+	if len(data.CosmeticSections) > 0 {
+		doc.Paragraphf("%s: %s",
+			markdown.Bold("Cosmetic/Telemetry sections present"),
+			strings.Join(data.CosmeticSections, ", "),
+		).Break()
 	}
 }
 
 // BuildSystemSection builds the system configuration section.
 func (b *MarkdownBuilder) BuildSystemSection(data *common.CommonDevice) string {
-	var buf bytes.Buffer
-	md := markdown.NewMarkdown(&buf)
-	b.writeSystemSection(md, data)
-	return md.String()
+	doc := document.New()
+	b.writeSystemSection(doc, data)
+	return b.render(doc)
 }
 
-// WriteUserTable writes a users table and returns md for chaining.
-func (b *MarkdownBuilder) WriteUserTable(md *markdown.Markdown, users []common.User) *markdown.Markdown {
-	return md.Table(*BuildUserTableSet(users))
+// WriteUserTable writes a users table and returns doc for chaining.
+func (b *MarkdownBuilder) WriteUserTable(doc *document.Document, users []common.User) *document.Document {
+	return doc.Table(*BuildUserTableSet(users, b.getGeneratedTime()))
 }
 
-// BuildUserTableSet builds the table data for system users.
-func BuildUserTableSet(users []common.User) *markdown.TableSet {
-	headers := []string{colName, colDescription, "Group", "Scope"}
+// BuildUserTableSet builds the table data for system users. The Expires
+// column shows the account expiry date, marked when it had passed at now.
+func BuildUserTableSet(users []common.User, now time.Time) *markdown.TableSet {
+	headers := []string{colName, colDescription, "Group", "Scope", "SSH Key", "Expires"}
 
 	rows := make([][]string, 0, len(users))
 	for _, user := range users {
@@ -142,6 +190,8 @@ func BuildUserTableSet(users []common.User) *markdown.TableSet {
 			formatters.EscapeTableContent(user.Description),
 			formatters.EscapeTableContent(user.GroupName),
 			formatters.EscapeTableContent(user.Scope),
+			formatters.FormatBool(user.HasSSHKey()),
+			formatUserExpiry(user, now),
 		})
 	}
 
@@ -151,14 +201,34 @@ func BuildUserTableSet(users []common.User) *markdown.TableSet {
 	}
 }
 
-// WriteGroupTable writes a groups table and returns md for chaining.
-func (b *MarkdownBuilder) WriteGroupTable(md *markdown.Markdown, groups []common.Group) *markdown.Markdown {
-	return md.Table(*BuildGroupTableSet(groups))
+// formatUserExpiry renders a user's expiry date, followed by "**Expired**"
+// when it had passed at now. Accounts that never expire render as "-".
+func formatUserExpiry(user common.User, now time.Time) string {
+	if strings.TrimSpace(user.Expires) == "" {
+		return "-"
+	}
+
+	expires := formatters.EscapeTableContent(user.Expires)
+	if user.IsExpiredAt(now) {
+		return expires + " **Expired**"
+	}
+
+	return expires
 }
 
-// BuildGroupTableSet builds the table data for system groups.
+// WriteGroupTable writes a groups table and returns doc for chaining.
+func (b *MarkdownBuilder) WriteGroupTable(doc *document.Document, groups []common.Group) *document.Document {
+	return doc.Table(*BuildGroupTableSet(groups))
+}
+
+// maxPrivilegePreviewLength is the maximum rune length of the privilege list
+// shown after the count in the groups table.
+const maxPrivilegePreviewLength = 40
+
+// BuildGroupTableSet builds the table data for system groups. The Privileges
+// column shows the number of privileges followed by a truncated list.
 func BuildGroupTableSet(groups []common.Group) *markdown.TableSet {
-	headers := []string{colName, colDescription, "Scope"}
+	headers := []string{colName, colDescription, "Scope", "Privileges"}
 
 	rows := make([][]string, 0, len(groups))
 	for _, group := range groups {
@@ -166,6 +236,7 @@ func BuildGroupTableSet(groups []common.Group) *markdown.TableSet {
 			formatters.EscapeTableContent(group.Name),
 			formatters.EscapeTableContent(group.Description),
 			formatters.EscapeTableContent(group.Scope),
+			formatters.EscapeTableContent(formatPrivilegeSummary(group.Privileges)),
 		})
 	}
 
@@ -175,21 +246,102 @@ func BuildGroupTableSet(groups []common.Group) *markdown.TableSet {
 	}
 }
 
-// WriteSysctlTable writes a sysctl tunables table and returns md for chaining.
-func (b *MarkdownBuilder) WriteSysctlTable(md *markdown.Markdown, sysctl []common.SysctlItem) *markdown.Markdown {
-	return md.Table(*BuildSysctlTableSet(sysctl))
+// formatPrivilegeSummary renders a privilege list as its count followed by
+// the privileges truncated to maxPrivilegePreviewLength, e.g.
+// "2 (page-all, user-shell-access)". An empty list renders as "0".
+func formatPrivilegeSummary(privileges []string) string {
+	if len(privileges) == 0 {
+		return "0"
+	}
+
+	preview := TruncateString(strings.Join(privileges, ", "), maxPrivilegePreviewLength)
+
+	return strconv.Itoa(len(privileges)) + " (" + preview + ")"
 }
 
-// BuildSysctlTableSet builds the table data for system tunables.
+// BuildAuthServerTableSet builds the table data for the external LDAP and
+// RADIUS authentication servers. Credentials are never rendered.
+func BuildAuthServerTableSet(servers []common.AuthServer) *markdown.TableSet {
+	headers := []string{colName, colType, "Host:Port", "Transport", "Base DN"}
+
+	rows := make([][]string, 0, len(servers))
+	for _, server := range servers {
+		endpoint := server.Host
+		if server.Port != "" {
+			endpoint = net.JoinHostPort(server.Host, server.Port)
+		}
+
+		rows = append(rows, []string{
+			formatters.EscapeTableContent(server.Name),
+			formatters.EscapeTableContent(strings.ToUpper(string(server.Type))),
+			formatters.EscapeTableContent(endpoint),
+			formatters.EscapeTableContent(string(server.Transport)),
+			formatters.EscapeTableContent(server.BaseDN),
+		})
+	}
+
+	return &markdown.TableSet{
+		Header: headers,
+		Rows:   rows,
+	}
+}
+
+// catalogDescriptionMarker is appended to tunable descriptions taken from the
+// built-in catalog rather than the configuration, and catalogDescriptionFootnote
+// explains it below the table.
+const (
+	catalogDescriptionMarker   = " *"
+	catalogDescriptionFootnote = `\* Description from the built-in tunable catalog, not from the configuration.`
+)
+
+// WriteSysctlTable writes a sysctl tunables table and returns doc for chaining.
+// When a description comes from the built-in tunable catalog, a footnote
+// explaining the marker follows the table.
+func (b *MarkdownBuilder) WriteSysctlTable(doc *document.Document, sysctl []common.SysctlItem) *document.Document {
+	doc.Table(*BuildSysctlTableSet(sysctl))
+
+	if slices.ContainsFunc(sysctl, func(item common.SysctlItem) bool {
+		_, ok := catalogDescription(item)
+		return ok
+	}) {
+		doc.Paragraph(catalogDescriptionFootnote)
+	}
+
+	return doc
+}
+
+// catalogDescription returns the catalog description of a tunable whose
+// configuration leaves its description empty, and whether there is one.
+func catalogDescription(item common.SysctlItem) (string, bool) {
+	if item.Description != "" {
+		return "", false
+	}
+
+	entry, ok := tunables.Lookup(item.Tunable)
+	if !ok {
+		return "", false
+	}
+
+	return entry.Description, true
+}
+
+// BuildSysctlTableSet builds the table data for system tunables. A tunable
+// without a description in the configuration is described from the built-in
+// tunable catalog, marked with an asterisk.
 func BuildSysctlTableSet(sysctl []common.SysctlItem) *markdown.TableSet {
 	headers := []string{"Tunable", colValue, colDescription}
 
 	rows := make([][]string, 0, len(sysctl))
 	for _, item := range sysctl {
+		description := item.Description
+		if text, ok := catalogDescription(item); ok {
+			description = text + catalogDescriptionMarker
+		}
+
 		rows = append(rows, []string{
 			formatters.EscapeTableContent(item.Tunable),
 			formatters.EscapeTableContent(item.Value),
-			formatters.EscapeTableContent(item.Description),
+			formatters.EscapeTableContent(description),
 		})
 	}
 
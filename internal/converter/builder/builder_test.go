@@ -4,9 +4,12 @@ import (
 	"errors"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/EvilBit-Labs/opnDossier/internal/analysis"
+	"github.com/EvilBit-Labs/opnDossier/internal/converter/document"
+	"github.com/EvilBit-Labs/opnDossier/internal/converter/formatters"
 	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
-	"github.com/nao1215/markdown"
 )
 
 func TestNewMarkdownBuilder(t *testing.T) {
@@ -201,10 +204,9 @@ func TestBuildInterfaceDetails(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			var buf strings.Builder
-			md := markdown.NewMarkdown(&buf)
+			md := document.New()
 			buildInterfaceDetails(md, tt.iface)
-			output := md.String()
+			output := document.MarkdownRenderer{}.Render(md)
 
 			for _, want := range tt.wantContains {
 				if !strings.Contains(output, want) {
@@ -248,7 +250,7 @@ func TestBuildFirewallRulesTableSet(t *testing.T) {
 			},
 			wantRows: 1,
 			wantContains: []string{
-				"pass", "inet", "tcp", "192.168.1.0/24", "any", "443", "Allow LAN traffic",
+				"pass", "inet", "tcp", "192.168.1.0/24", "any", "443 (https)", "Allow LAN traffic",
 			},
 		},
 		{
@@ -281,6 +283,19 @@ func TestBuildFirewallRulesTableSet(t *testing.T) {
 				"pass", "udp", "Multi-interface rule",
 			},
 		},
+		{
+			name: "port range is not annotated",
+			rules: []common.FirewallRule{
+				{
+					Type:        common.RuleTypePass,
+					Interfaces:  []string{"lan"},
+					Protocol:    "tcp",
+					Destination: common.RuleEndpoint{Address: "any", Port: "1024:65535"},
+				},
+			},
+			wantRows:     1,
+			wantContains: []string{"1024:65535"},
+		},
 	}
 
 	expectedHeaders := []string{
@@ -292,7 +307,7 @@ func TestBuildFirewallRulesTableSet(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			tableSet := BuildFirewallRulesTableSet(tt.rules)
+			tableSet := BuildFirewallRulesTableSet(tt.rules, true)
 			verifyTableSet(t, tableSet, expectedHeaders, tt.wantRows, tt.wantContains)
 		})
 	}
@@ -394,7 +409,7 @@ func TestBuildInboundNATTableSet(t *testing.T) {
 			},
 			wantRows: 1,
 			wantContains: []string{
-				"⬇️ Inbound", "80", "`192.168.1.100`", "80", "tcp", "HTTP forward", "1", "**Active**",
+				"⬇️ Inbound", "80 (http)", "`192.168.1.100`", "tcp", "HTTP forward", "1", "**Active**",
 			},
 		},
 		{
@@ -423,12 +438,165 @@ func TestBuildInboundNATTableSet(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			tableSet := BuildInboundNATTableSet(tt.rules)
+			tableSet := BuildInboundNATTableSet(tt.rules, true)
 			verifyTableSet(t, tableSet, expectedHeaders, tt.wantRows, tt.wantContains)
 		})
 	}
 }
 
+func TestBuildInboundNATTableSet_NATReflectionColumn(t *testing.T) {
+	t.Parallel()
+
+	rules := []common.InboundNATRule{
+		{Interfaces: []string{"wan"}, InternalIP: "192.168.1.10", NATReflection: "enable"},
+		{Interfaces: []string{"wan"}, InternalIP: "192.168.1.11"},
+	}
+
+	expectedHeaders := []string{
+		"#", "Direction", "Interface", "External Port", "Target IP", "Target Port",
+		"Protocol", "Description", "Priority", "Status", "NAT Reflection",
+	}
+
+	tableSet := BuildInboundNATTableSet(rules, true)
+	verifyTableSet(t, tableSet, expectedHeaders, 2, nil)
+
+	if got := tableSet.Rows[0][10]; got != "enable" {
+		t.Errorf("Rows[0] NAT Reflection = %q, want %q", got, "enable")
+	}
+	if got := tableSet.Rows[1][10]; got != "default" {
+		t.Errorf("Rows[1] NAT Reflection = %q, want %q", got, "default")
+	}
+}
+
+func TestBuildOneToOneNATTableSet(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		rules        []common.OneToOneNATRule
+		wantRows     int
+		wantContains []string
+	}{
+		{
+			name:         "empty rules returns placeholder",
+			rules:        nil,
+			wantRows:     1,
+			wantContains: []string{"No 1:1 NAT rules configured"},
+		},
+		{
+			name: "host mapping",
+			rules: []common.OneToOneNATRule{
+				{
+					Interfaces:  []string{"wan"},
+					External:    "203.0.113.10",
+					Internal:    "192.168.1.25",
+					Description: "Mail server",
+				},
+			},
+			wantRows:     1,
+			wantContains: []string{"`203.0.113.10`", "`192.168.1.25`", "Mail server", "**Active**"},
+		},
+		{
+			name: "subnet mapping shows the external range",
+			rules: []common.OneToOneNATRule{
+				{
+					Interfaces: []string{"wan"},
+					External:   "203.0.113.64",
+					Internal:   "10.20.0.0/27",
+					Disabled:   true,
+				},
+			},
+			wantRows:     1,
+			wantContains: []string{"`203.0.113.64/27`", "`10.20.0.0/27`", "**Disabled**"},
+		},
+	}
+
+	expectedHeaders := []string{"#", "Interface", "External", "Internal", "Description", "Status"}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tableSet := BuildOneToOneNATTableSet(tt.rules)
+			verifyTableSet(t, tableSet, expectedHeaders, tt.wantRows, tt.wantContains)
+		})
+	}
+}
+
+func TestBuildSecuritySection_OneToOneNAT(t *testing.T) {
+	t.Parallel()
+
+	b := NewMarkdownBuilder()
+	data := &common.CommonDevice{
+		NAT: common.NATConfig{
+			OutboundMode: common.OutboundAutomatic,
+			OneToOneRules: []common.OneToOneNATRule{
+				{Interfaces: []string{"wan"}, External: "203.0.113.10", Internal: "192.168.1.25"},
+				{Interfaces: []string{"wan"}, External: "203.0.113.64", Internal: "10.20.0.0/27"},
+			},
+		},
+	}
+
+	section := b.BuildSecuritySection(data)
+	if !strings.Contains(section, "**1:1 NAT Rules**: 2") {
+		t.Error("NAT summary should count the 1:1 NAT rules")
+	}
+
+	outbound := strings.Index(section, "#### Outbound NAT")
+	oneToOne := strings.Index(section, "#### 1:1 NAT")
+	inbound := strings.Index(section, "#### Inbound NAT")
+	if oneToOne < 0 || outbound > oneToOne || oneToOne > inbound {
+		t.Errorf("1:1 NAT table should sit between outbound (%d) and inbound (%d), got %d", outbound, inbound, oneToOne)
+	}
+	if !strings.Contains(section, "`203.0.113.64/27`") {
+		t.Error("1:1 NAT table should list the subnet mapping")
+	}
+
+	data.NAT.OneToOneRules = nil
+	section = b.BuildSecuritySection(data)
+	if strings.Contains(section, "1:1 NAT") {
+		t.Error("security section should omit 1:1 NAT when no rules are configured")
+	}
+}
+
+func TestBuildRuleTableSets_PortNames(t *testing.T) {
+	t.Parallel()
+
+	fwRules := []common.FirewallRule{{
+		Protocol:    "tcp",
+		Source:      common.RuleEndpoint{Port: "1024:65535"},
+		Destination: common.RuleEndpoint{Port: "443"},
+	}}
+	natRules := []common.InboundNATRule{{Protocol: "tcp", ExternalPort: "5432", InternalPort: "5432"}}
+
+	tests := []struct {
+		portNames bool
+		wantDest  string
+		wantNAT   string
+	}{
+		{portNames: true, wantDest: "443 (https)", wantNAT: "5432 (postgresql)"},
+		{portNames: false, wantDest: "443", wantNAT: "5432"},
+	}
+
+	for _, tt := range tests {
+		fw := BuildFirewallRulesTableSet(fwRules, tt.portNames)
+		if got := fw.Rows[0][8]; got != "1024:65535" {
+			t.Errorf("portNames=%v: source port = %q, want %q", tt.portNames, got, "1024:65535")
+		}
+		if got := fw.Rows[0][9]; got != tt.wantDest {
+			t.Errorf("portNames=%v: dest port = %q, want %q", tt.portNames, got, tt.wantDest)
+		}
+
+		nat := BuildInboundNATTableSet(natRules, tt.portNames)
+		if got := nat.Rows[0][3]; got != tt.wantNAT {
+			t.Errorf("portNames=%v: external port = %q, want %q", tt.portNames, got, tt.wantNAT)
+		}
+		if got := nat.Rows[0][5]; got != tt.wantNAT {
+			t.Errorf("portNames=%v: target port = %q, want %q", tt.portNames, got, tt.wantNAT)
+		}
+	}
+}
+
 func TestBuildInterfaceTableSet(t *testing.T) {
 	t.Parallel()
 
@@ -544,15 +712,41 @@ func TestBuildUserTableSet(t *testing.T) {
 				"user1", "Regular User", "users", "user",
 			},
 		},
+		{
+			name: "user with SSH key",
+			users: []common.User{
+				{Name: "ops", GroupName: "admins", Scope: "user", AuthorizedKeys: "c3NoLWVkMjU1MTkgQUFBQQ=="},
+			},
+			wantRows:     1,
+			wantContains: []string{"ops", "✓"},
+		},
+		{
+			name: "users with expiry dates",
+			users: []common.User{
+				{
+					Name: "former", Scope: "user", Expires: "01/31/2020",
+					ExpiryDate:    time.Date(2020, time.January, 31, 0, 0, 0, 0, time.UTC).Unix(),
+					HasExpiryDate: true,
+				},
+				{
+					Name: "contractor", Scope: "user", Expires: "03/31/2020",
+					ExpiryDate:    time.Date(2020, time.March, 31, 0, 0, 0, 0, time.UTC).Unix(),
+					HasExpiryDate: true,
+				},
+			},
+			wantRows:     2,
+			wantContains: []string{"01/31/2020 **Expired**", "03/31/2020"},
+		},
 	}
 
-	expectedHeaders := []string{colName, colDescription, "Group", "Scope"}
+	expectedHeaders := []string{colName, colDescription, "Group", "Scope", "SSH Key", "Expires"}
+	now := time.Date(2020, time.March, 1, 12, 0, 0, 0, time.UTC)
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			tableSet := BuildUserTableSet(tt.users)
+			tableSet := BuildUserTableSet(tt.users, now)
 			verifyTableSet(t, tableSet, expectedHeaders, tt.wantRows, tt.wantContains)
 		})
 	}
@@ -584,12 +778,37 @@ func TestBuildGroupTableSet(t *testing.T) {
 			},
 			wantRows: 1,
 			wantContains: []string{
-				"admins", "System Administrators", "system",
+				"admins", "System Administrators", "system", "0",
+			},
+		},
+		{
+			name: "group with privileges",
+			groups: []common.Group{
+				{
+					Name:       "operators",
+					Scope:      "local",
+					Privileges: []string{"page-all", "user-shell-access"},
+				},
+			},
+			wantRows:     1,
+			wantContains: []string{"2 (page-all, user-shell-access)"},
+		},
+		{
+			name: "long privilege list is truncated",
+			groups: []common.Group{
+				{
+					Name: "auditors",
+					Privileges: []string{
+						"page-dashboard-all", "page-diagnostics-logs", "page-status-systemlogs", "page-firewall-rules",
+					},
+				},
 			},
+			wantRows:     1,
+			wantContains: []string{"4 (page-dashboard-all, page-diagnostics-...)"},
 		},
 	}
 
-	expectedHeaders := []string{colName, colDescription, "Scope"}
+	expectedHeaders := []string{colName, colDescription, "Scope", "Privileges"}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -601,6 +820,40 @@ func TestBuildGroupTableSet(t *testing.T) {
 	}
 }
 
+func TestBuildAuthServerTableSet(t *testing.T) {
+	t.Parallel()
+
+	servers := []common.AuthServer{
+		{
+			Name:         "corp-ldap",
+			Type:         common.AuthServerLDAP,
+			Host:         "ldap.example.com",
+			Port:         "389",
+			Transport:    common.LDAPTransportTCP,
+			BaseDN:       "dc=example,dc=com",
+			BindPassword: "[REDACTED]",
+		},
+		{Name: "ldap6", Type: common.AuthServerLDAP, Host: "2001:db8::10", Port: "636", Transport: common.LDAPTransportSSL},
+		{Name: "wifi", Type: common.AuthServerRADIUS, Host: "10.0.0.5", SecretLength: 8},
+	}
+
+	tableSet := BuildAuthServerTableSet(servers)
+	verifyTableSet(t, tableSet, []string{colName, colType, "Host:Port", "Transport", "Base DN"}, 3, []string{
+		"corp-ldap", "LDAP", "ldap.example.com:389", "tcp", "dc=example,dc=com", `\[2001:db8::10\]:636`, "ssl", "RADIUS",
+	})
+
+	if got := tableSet.Rows[2][2]; got != "10.0.0.5" {
+		t.Errorf("host without port = %q, want %q", got, "10.0.0.5")
+	}
+	for _, row := range tableSet.Rows {
+		for _, cell := range row {
+			if strings.Contains(cell, "REDACTED") {
+				t.Errorf("auth server table renders credential placeholder in %q", cell)
+			}
+		}
+	}
+}
+
 func TestBuildSysctlTableSet(t *testing.T) {
 	t.Parallel()
 
@@ -630,6 +883,24 @@ func TestBuildSysctlTableSet(t *testing.T) {
 				"kern.ipc.maxsockbuf", "16777216", "Maximum socket buffer size",
 			},
 		},
+		{
+			name: "empty description falls back to the catalog with a marker",
+			sysctl: []common.SysctlItem{
+				{Tunable: "net.inet.tcp.syncookies", Value: "1"},
+			},
+			wantRows: 1,
+			wantContains: []string{
+				"net.inet.tcp.syncookies", `Use SYN cookies when the SYN cache overflows \*`,
+			},
+		},
+		{
+			name: "configured description wins over the catalog",
+			sysctl: []common.SysctlItem{
+				{Tunable: "net.inet.tcp.syncookies", Value: "1", Description: "SYN flood guard"},
+			},
+			wantRows:     1,
+			wantContains: []string{"SYN flood guard"},
+		},
 	}
 
 	expectedHeaders := []string{"Tunable", "Value", "Description"}
@@ -644,6 +915,27 @@ func TestBuildSysctlTableSet(t *testing.T) {
 	}
 }
 
+func TestWriteSysctlTable_CatalogFootnote(t *testing.T) {
+	t.Parallel()
+
+	b := NewMarkdownBuilder()
+
+	documented := document.New()
+	b.WriteSysctlTable(documented, []common.SysctlItem{
+		{Tunable: "net.inet.tcp.syncookies", Value: "1", Description: "SYN flood guard"},
+		{Tunable: "net.inet.ip.fowarding", Value: "1"},
+	})
+	if got := b.render(documented); strings.Contains(got, "built-in tunable catalog") {
+		t.Errorf("table without catalog descriptions has a footnote:\n%s", got)
+	}
+
+	fromCatalog := document.New()
+	b.WriteSysctlTable(fromCatalog, []common.SysctlItem{{Tunable: "net.inet.tcp.syncookies", Value: "1"}})
+	if got := b.render(fromCatalog); !strings.Contains(got, catalogDescriptionFootnote) {
+		t.Errorf("table with a catalog description has no footnote:\n%s", got)
+	}
+}
+
 func TestBuildVLANTableSet(t *testing.T) {
 	t.Parallel()
 
@@ -669,14 +961,24 @@ func TestBuildVLANTableSet(t *testing.T) {
 					PhysicalIf:  "em0",
 					Tag:         "10",
 					Description: "Management VLAN",
-					Created:     "2024-01-01",
-					Updated:     "2024-01-02",
+					Created:     &common.ChangeRecord{Username: "root@10.0.0.5", Time: "1700000000"},
+					Updated:     &common.ChangeRecord{Username: "admin@10.0.0.6", Time: "1700086400"},
 				},
 			},
 			wantRows: 1,
 			wantContains: []string{
-				"vlan10", "em0", "10", "Management VLAN", "2024-01-01", "2024-01-02",
+				"vlan10", "em0", "10", "Management VLAN",
+				formatters.FormatTimestamp("1700000000") + " (root@10.0.0.5)",
+				formatters.FormatTimestamp("1700086400") + " (admin@10.0.0.6)",
+			},
+		},
+		{
+			name: "vlan without change records",
+			vlans: []common.VLAN{
+				{VLANIf: "vlan20", PhysicalIf: "em0", Tag: "20"},
 			},
+			wantRows:     1,
+			wantContains: []string{"vlan20", "-"},
 		},
 	}
 
@@ -694,6 +996,57 @@ func TestBuildVLANTableSet(t *testing.T) {
 	}
 }
 
+func TestBuildTunnelTableSet(t *testing.T) {
+	t.Parallel()
+
+	tunnels := []analysis.TunnelInterface{
+		{
+			Type:          analysis.TunnelTypeGIF,
+			Interface:     "gif0",
+			Parent:        "wan",
+			Remote:        "209.51.181.2",
+			LocalAddress:  "2001:db8:1::2",
+			RemoteAddress: "2001:db8:1::1",
+			Description:   "HE IPv6 Tunnel",
+		},
+		{Type: analysis.TunnelTypeGRE, Interface: "gre0"},
+	}
+
+	expectedHeaders := []string{
+		"Type", "Interface", "Parent Interface", "Remote Endpoint", "Tunnel Addresses", "Description",
+	}
+
+	tableSet := BuildTunnelTableSet(tunnels)
+	verifyTableSet(t, tableSet, expectedHeaders, 2, []string{
+		"GIF", "gif0", "wan", "209.51.181.2", "2001:db8:1::2 → 2001:db8:1::1", "HE IPv6 Tunnel", "GRE", "gre0",
+	})
+
+	if got := tableSet.Rows[1][2]; got != "-" {
+		t.Errorf("GRE parent cell = %q, want %q", got, "-")
+	}
+	if got := tableSet.Rows[1][4]; got != "-" {
+		t.Errorf("GRE tunnel addresses cell = %q, want %q", got, "-")
+	}
+}
+
+func TestBuildNetworkSection_TunnelInterfaces(t *testing.T) {
+	t.Parallel()
+
+	b := NewMarkdownBuilder()
+	data := &common.CommonDevice{Interfaces: []common.Interface{{Name: "wan", Enabled: true}}}
+	if strings.Contains(b.BuildNetworkSection(data), "Tunnel Interfaces") {
+		t.Error("network section without tunnels should omit the Tunnel Interfaces table")
+	}
+
+	data.GIFs = []common.GIF{{Interface: "gif0", Local: "wan", Remote: "209.51.181.2"}}
+	section := b.BuildNetworkSection(data)
+	for _, want := range []string{"### Tunnel Interfaces", "| GIF | gif0 | wan | 209.51.181.2 |"} {
+		if !strings.Contains(section, want) {
+			t.Errorf("network section missing %q", want)
+		}
+	}
+}
+
 func TestBuildStaticRoutesTableSet(t *testing.T) {
 	t.Parallel()
 
@@ -776,16 +1129,15 @@ func TestWriteTableMethods(t *testing.T) {
 			test: func(t *testing.T) {
 				t.Helper()
 				t.Parallel()
-				var buf strings.Builder
-				md := markdown.NewMarkdown(&buf)
+				md := document.New()
 				rules := []common.FirewallRule{
 					{Type: common.RuleTypePass, Interfaces: []string{"lan"}, Description: "Test rule"},
 				}
 				result := builder.WriteFirewallRulesTable(md, rules)
 				if result != md {
-					t.Error("WriteFirewallRulesTable should return the markdown instance for chaining")
+					t.Error("WriteFirewallRulesTable should return the document for chaining")
 				}
-				output := md.String()
+				output := document.MarkdownRenderer{}.Render(md)
 				if !strings.Contains(output, "pass") || !strings.Contains(output, "Test rule") {
 					t.Error("WriteFirewallRulesTable output missing expected content")
 				}
@@ -796,16 +1148,15 @@ func TestWriteTableMethods(t *testing.T) {
 			test: func(t *testing.T) {
 				t.Helper()
 				t.Parallel()
-				var buf strings.Builder
-				md := markdown.NewMarkdown(&buf)
+				md := document.New()
 				interfaces := []common.Interface{
 					{Name: "lan", PhysicalIf: "em0", Enabled: true, IPAddress: "192.168.1.1"},
 				}
 				result := builder.WriteInterfaceTable(md, interfaces)
 				if result != md {
-					t.Error("WriteInterfaceTable should return the markdown instance for chaining")
+					t.Error("WriteInterfaceTable should return the document for chaining")
 				}
-				output := md.String()
+				output := document.MarkdownRenderer{}.Render(md)
 				if !strings.Contains(output, "lan") || !strings.Contains(output, "192.168.1.1") {
 					t.Error("WriteInterfaceTable output missing expected content")
 				}
@@ -816,14 +1167,13 @@ func TestWriteTableMethods(t *testing.T) {
 			test: func(t *testing.T) {
 				t.Helper()
 				t.Parallel()
-				var buf strings.Builder
-				md := markdown.NewMarkdown(&buf)
+				md := document.New()
 				rules := []common.NATRule{
 					{Interfaces: []string{"wan"}, Description: "Test NAT"},
 				}
 				result := builder.WriteOutboundNATTable(md, rules)
 				if result != md {
-					t.Error("WriteOutboundNATTable should return the markdown instance for chaining")
+					t.Error("WriteOutboundNATTable should return the document for chaining")
 				}
 			},
 		},
@@ -832,14 +1182,13 @@ func TestWriteTableMethods(t *testing.T) {
 			test: func(t *testing.T) {
 				t.Helper()
 				t.Parallel()
-				var buf strings.Builder
-				md := markdown.NewMarkdown(&buf)
+				md := document.New()
 				rules := []common.InboundNATRule{
 					{Interfaces: []string{"wan"}, Description: "Test forward"},
 				}
 				result := builder.WriteInboundNATTable(md, rules)
 				if result != md {
-					t.Error("WriteInboundNATTable should return the markdown instance for chaining")
+					t.Error("WriteInboundNATTable should return the document for chaining")
 				}
 			},
 		},
@@ -848,14 +1197,13 @@ func TestWriteTableMethods(t *testing.T) {
 			test: func(t *testing.T) {
 				t.Helper()
 				t.Parallel()
-				var buf strings.Builder
-				md := markdown.NewMarkdown(&buf)
+				md := document.New()
 				users := []common.User{
 					{Name: "admin", Description: "Administrator"},
 				}
 				result := builder.WriteUserTable(md, users)
 				if result != md {
-					t.Error("WriteUserTable should return the markdown instance for chaining")
+					t.Error("WriteUserTable should return the document for chaining")
 				}
 			},
 		},
@@ -864,14 +1212,13 @@ func TestWriteTableMethods(t *testing.T) {
 			test: func(t *testing.T) {
 				t.Helper()
 				t.Parallel()
-				var buf strings.Builder
-				md := markdown.NewMarkdown(&buf)
+				md := document.New()
 				groups := []common.Group{
 					{Name: "admins", Description: "Administrators"},
 				}
 				result := builder.WriteGroupTable(md, groups)
 				if result != md {
-					t.Error("WriteGroupTable should return the markdown instance for chaining")
+					t.Error("WriteGroupTable should return the document for chaining")
 				}
 			},
 		},
@@ -880,14 +1227,13 @@ func TestWriteTableMethods(t *testing.T) {
 			test: func(t *testing.T) {
 				t.Helper()
 				t.Parallel()
-				var buf strings.Builder
-				md := markdown.NewMarkdown(&buf)
+				md := document.New()
 				sysctl := []common.SysctlItem{
 					{Tunable: "net.inet.tcp.mssdflt", Value: "1460"},
 				}
 				result := builder.WriteSysctlTable(md, sysctl)
 				if result != md {
-					t.Error("WriteSysctlTable should return the markdown instance for chaining")
+					t.Error("WriteSysctlTable should return the document for chaining")
 				}
 			},
 		},
@@ -896,14 +1242,13 @@ func TestWriteTableMethods(t *testing.T) {
 			test: func(t *testing.T) {
 				t.Helper()
 				t.Parallel()
-				var buf strings.Builder
-				md := markdown.NewMarkdown(&buf)
+				md := document.New()
 				vlans := []common.VLAN{
 					{VLANIf: "vlan10", Tag: "10"},
 				}
 				result := builder.WriteVLANTable(md, vlans)
 				if result != md {
-					t.Error("WriteVLANTable should return the markdown instance for chaining")
+					t.Error("WriteVLANTable should return the document for chaining")
 				}
 			},
 		},
@@ -912,14 +1257,13 @@ func TestWriteTableMethods(t *testing.T) {
 			test: func(t *testing.T) {
 				t.Helper()
 				t.Parallel()
-				var buf strings.Builder
-				md := markdown.NewMarkdown(&buf)
+				md := document.New()
 				routes := []common.StaticRoute{
 					{Network: "10.0.0.0/8", Gateway: "192.168.1.1"},
 				}
 				result := builder.WriteStaticRoutesTable(md, routes)
 				if result != md {
-					t.Error("WriteStaticRoutesTable should return the markdown instance for chaining")
+					t.Error("WriteStaticRoutesTable should return the document for chaining")
 				}
 			},
 		},
@@ -928,14 +1272,13 @@ func TestWriteTableMethods(t *testing.T) {
 			test: func(t *testing.T) {
 				t.Helper()
 				t.Parallel()
-				var buf strings.Builder
-				md := markdown.NewMarkdown(&buf)
+				md := document.New()
 				scopes := []common.DHCPScope{
 					{Interface: "lan", Enabled: true, Gateway: "192.168.1.1"},
 				}
 				result := builder.WriteDHCPSummaryTable(md, scopes)
 				if result != md {
-					t.Error("WriteDHCPSummaryTable should return the markdown instance for chaining")
+					t.Error("WriteDHCPSummaryTable should return the document for chaining")
 				}
 			},
 		},
@@ -944,14 +1287,13 @@ func TestWriteTableMethods(t *testing.T) {
 			test: func(t *testing.T) {
 				t.Helper()
 				t.Parallel()
-				var buf strings.Builder
-				md := markdown.NewMarkdown(&buf)
+				md := document.New()
 				leases := []common.DHCPStaticLease{
 					{MAC: "00:11:22:33:44:55", IPAddress: "192.168.1.100"},
 				}
 				result := builder.WriteDHCPStaticLeasesTable(md, leases)
 				if result != md {
-					t.Error("WriteDHCPStaticLeasesTable should return the markdown instance for chaining")
+					t.Error("WriteDHCPStaticLeasesTable should return the document for chaining")
 				}
 			},
 		},
@@ -966,6 +1308,59 @@ func TestWriteTableMethods(t *testing.T) {
 // BuildAuditSection Tests
 // ─────────────────────────────────────────────────────────────────────────────
 
+func TestBuildExecutiveSummarySection(t *testing.T) {
+	t.Parallel()
+
+	b := NewMarkdownBuilder()
+
+	if result := b.BuildExecutiveSummarySection("  "); result != "" {
+		t.Errorf("BuildExecutiveSummarySection with empty summary should return empty string, got: %s", result)
+	}
+
+	summary := "This OPNsense device has 12 firewall rules across 3 interfaces."
+	result := b.BuildExecutiveSummarySection(summary)
+	if !strings.HasPrefix(result, "## Executive Summary\n") {
+		t.Errorf("Expected output to start with the Executive Summary heading, got: %s", result)
+	}
+	if !strings.Contains(result, summary) {
+		t.Errorf("Expected summary paragraph in output, got: %s", result)
+	}
+}
+
+func TestReports_StartWithExecutiveSummary(t *testing.T) {
+	t.Parallel()
+
+	data := &common.CommonDevice{
+		DeviceType: common.DeviceTypeOPNsense,
+		Analysis: &common.Analysis{
+			SecurityIssues: []common.SecurityFinding{{Issue: "Weak Admin Password", Severity: common.SeverityHigh}},
+		},
+	}
+	want := "This OPNsense device has 0 firewall rules across 0 interfaces. " +
+		"Analysis found 0 critical, 1 high, 0 medium, and 0 low findings. " +
+		"The most critical issue is: Weak Admin Password. " +
+		"Immediate attention is recommended for the Critical and High findings."
+
+	b := NewMarkdownBuilder()
+	for name, build := range map[string]func(*common.CommonDevice) (string, error){
+		"standard":      b.BuildStandardReport,
+		"comprehensive": b.BuildComprehensiveReport,
+	} {
+		report, err := build(data)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+
+		summaryAt := strings.Index(report, "## Executive Summary")
+		if summaryAt == -1 || summaryAt > strings.Index(report, "## System Information") {
+			t.Errorf("%s: expected Executive Summary before System Information, got: %s", name, report)
+		}
+		if !strings.Contains(report, want) {
+			t.Errorf("%s: expected summary %q in report", name, want)
+		}
+	}
+}
+
 func TestBuildAuditSection_NilData(t *testing.T) {
 	t.Parallel()
 
@@ -1050,6 +1445,178 @@ func TestBuildAuditSection_WithFindings(t *testing.T) {
 	}
 }
 
+func TestBuildAuditSection_DescriptionQuality(t *testing.T) {
+	t.Parallel()
+
+	b := NewMarkdownBuilder()
+	data := &common.CommonDevice{
+		ComplianceResults: &common.ComplianceResults{
+			Mode: "blue",
+			Summary: &common.ComplianceResultSummary{
+				DescriptionQuality: &common.DescriptionQuality{
+					TotalRules:       8,
+					CompliantRules:   6,
+					CompliantPercent: 75,
+				},
+			},
+		},
+	}
+
+	result := b.BuildAuditSection(data)
+	if !strings.Contains(result, "Rule Descriptions Compliant") || !strings.Contains(result, "6/8 (75.0%)") {
+		t.Errorf("Expected description compliance row '6/8 (75.0%%)' in output, got: %s", result)
+	}
+
+	data.ComplianceResults.Summary.DescriptionQuality = nil
+	if result := b.BuildAuditSection(data); strings.Contains(result, "Rule Descriptions Compliant") {
+		t.Error("Should not contain description compliance row when stats are absent")
+	}
+}
+
+func TestBuildAuditSection_StateTableMax(t *testing.T) {
+	t.Parallel()
+
+	b := NewMarkdownBuilder()
+	data := &common.CommonDevice{
+		ComplianceResults: &common.ComplianceResults{
+			Mode:    "blue",
+			Summary: &common.ComplianceResultSummary{StateTableMax: 500000},
+		},
+	}
+
+	result := b.BuildAuditSection(data)
+	if !strings.Contains(result, "State Table Maximum") || !strings.Contains(result, "500000") {
+		t.Errorf("Expected state table maximum row '500000' in output, got: %s", result)
+	}
+
+	data.ComplianceResults.Summary.StateTableMax = 0
+	if result := b.BuildAuditSection(data); strings.Contains(result, "State Table Maximum") {
+		t.Error("Should not contain state table maximum row when no limit is configured")
+	}
+}
+
+func TestBuildAuditSection_LoggingCoverage(t *testing.T) {
+	t.Parallel()
+
+	b := NewMarkdownBuilder()
+	data := &common.CommonDevice{
+		ComplianceResults: &common.ComplianceResults{
+			Mode: "blue",
+			Summary: &common.ComplianceResultSummary{
+				LoggingCoverage: &common.LoggingCoverage{
+					Interfaces: []common.LoggingCoverageEntry{
+						{
+							Interface: "wan", PassRules: 4, LoggedPassRules: 1, PassLoggedPercent: 25,
+							BlockRules: 2, LoggedBlockRules: 1, BlockLoggedPercent: 50,
+						},
+						{Interface: "lan", PassRules: 1, LoggedPassRules: 1, PassLoggedPercent: 100, BlockLoggedPercent: 100},
+					},
+					Total: common.LoggingCoverageEntry{
+						PassRules: 5, LoggedPassRules: 2, PassLoggedPercent: 40,
+						BlockRules: 2, LoggedBlockRules: 1, BlockLoggedPercent: 50,
+					},
+				},
+			},
+		},
+	}
+
+	result := b.BuildAuditSection(data)
+	for _, want := range []string{"### Logging Coverage", "Pass Rules Logged", "1/4 (25.0%)", "2/5 (40.0%)", "**Total**"} {
+		if !strings.Contains(result, want) {
+			t.Errorf("Expected %q in logging coverage output, got: %s", want, result)
+		}
+	}
+
+	data.ComplianceResults.Summary.LoggingCoverage = nil
+	if result := b.BuildAuditSection(data); strings.Contains(result, "Logging Coverage") {
+		t.Error("Should not contain the logging coverage table when stats are absent")
+	}
+}
+
+func TestBuildAuditSection_ConfigFreshness(t *testing.T) {
+	t.Parallel()
+
+	b := NewMarkdownBuilder()
+	data := &common.CommonDevice{
+		ComplianceResults: &common.ComplianceResults{
+			Mode: "blue",
+			Summary: &common.ComplianceResultSummary{
+				ConfigFreshness: &common.ConfigFreshness{
+					ReferenceTime: "2026-01-01T00:00:00Z",
+					LastChanged:   "2025-12-24T10:00:00Z",
+					Rules:         common.FreshnessBuckets{Recent: 3, Aging: 2, Stale: 1, Unknown: 4},
+					NATRules:      common.FreshnessBuckets{Stale: 5},
+					Users:         common.FreshnessBuckets{Unknown: 2},
+				},
+			},
+		},
+	}
+
+	result := b.BuildAuditSection(data)
+	for _, want := range []string{
+		"### Configuration Freshness",
+		"Last configuration change: 2025-12-24T10:00:00Z",
+		"| Firewall Rules | 3 | 2 | 1 | 4 |",
+		"| NAT Rules | 0 | 0 | 5 | 0 |",
+		"| Users | 0 | 0 | 0 | 2 |",
+	} {
+		if !strings.Contains(result, want) {
+			t.Errorf("Expected %q in config freshness output, got: %s", want, result)
+		}
+	}
+
+	data.ComplianceResults.Summary.ConfigFreshness.LastChanged = ""
+	if result := b.BuildAuditSection(data); strings.Contains(result, "Last configuration change") {
+		t.Error("Should not mention the last change when the revision time is unknown")
+	}
+
+	data.ComplianceResults.Summary.ConfigFreshness = nil
+	if result := b.BuildAuditSection(data); strings.Contains(result, "Configuration Freshness") {
+		t.Error("Should not contain the config freshness table when stats are absent")
+	}
+}
+
+func TestBuildAuditSection_BenchmarkScore(t *testing.T) {
+	t.Parallel()
+
+	b := NewMarkdownBuilder()
+	data := &common.CommonDevice{
+		ComplianceResults: &common.ComplianceResults{
+			Mode: "blue",
+			Summary: &common.ComplianceResultSummary{
+				BenchmarkScore: &common.BenchmarkScore{
+					Score:            0.75,
+					Grade:            "C",
+					ApplicableChecks: 5,
+					Sections: []common.SectionScore{
+						{Section: "network", Score: 0.6, Grade: "D", PassedChecks: 1, ApplicableChecks: 2},
+						{Section: "access control", Score: 1, Grade: "A", PassedChecks: 3, ApplicableChecks: 3},
+						{Section: "crypto", Grade: "N/A", NotApplicableChecks: 2},
+					},
+				},
+			},
+		},
+	}
+
+	result := b.BuildAuditSection(data)
+	for _, want := range []string{
+		"### Benchmark Score",
+		"| Network | 0.60 | D | 1/2 | 0 |",
+		"| Access Control | 1.00 | A | 3/3 | 0 |",
+		"| Crypto | - | N/A | 0/0 | 2 |",
+		"| **Overall** | **0.75** | **C** | 4/5 | 2 |",
+	} {
+		if !strings.Contains(result, want) {
+			t.Errorf("Expected %q in benchmark score output, got: %s", want, result)
+		}
+	}
+
+	data.ComplianceResults.Summary.BenchmarkScore = nil
+	if result := b.BuildAuditSection(data); strings.Contains(result, "Benchmark Score") {
+		t.Error("Should not contain the benchmark score table when no score was computed")
+	}
+}
+
 func TestBuildAuditSection_WithPluginResults(t *testing.T) {
 	t.Parallel()
 
@@ -1112,6 +1679,68 @@ func TestBuildAuditSection_WithPluginResults(t *testing.T) {
 	}
 }
 
+func TestBuildAuditSection_ChecksExecuted(t *testing.T) {
+	t.Parallel()
+
+	b := NewMarkdownBuilder()
+	data := &common.CommonDevice{
+		ComplianceResults: &common.ComplianceResults{
+			Mode: "blue",
+			PluginResults: map[string]common.PluginComplianceResult{
+				"stig": {
+					Checks: []common.ComplianceCheck{
+						{ID: "V-206694", Name: "Default deny", Status: common.CheckStatusPassed},
+					},
+				},
+				"firewall": {
+					Checks: []common.ComplianceCheck{
+						{
+							ID:     "FIREWALL-066",
+							Name:   "OpenVPN CRL Validity",
+							Status: common.CheckStatusSkipped,
+							Reason: "no OpenVPN servers configured",
+						},
+						{ID: "FIREWALL-004", Name: "Hostname Configuration", Status: common.CheckStatusFindings, Findings: 1},
+						{ID: "FIREWALL-014", Name: "Console | Menu", Status: common.CheckStatusErrored, Reason: "check panicked: boom"},
+					},
+				},
+			},
+			Metadata: map[string]any{"scan_time": "2024-01-15"},
+		},
+	}
+
+	result := b.BuildAuditSection(data)
+	rows := []string{
+		"| firewall | FIREWALL-004 | Hostname Configuration | findings | 1 | - |",
+		"| firewall | FIREWALL-014 | Console \\| Menu | errored | 0 | check panicked: boom |",
+		"| firewall | FIREWALL-066 | OpenVPN CRL Validity | skipped | 0 | no OpenVPN servers configured |",
+		"| stig | V-206694 | Default deny | passed | 0 | - |",
+	}
+	for _, want := range append([]string{"## Appendix: Checks Executed"}, rows...) {
+		if !strings.Contains(result, want) {
+			t.Errorf("Expected %q in checks appendix, got: %s", want, result)
+		}
+	}
+
+	// Checks are sorted by plugin, then ID, and the appendix closes the report.
+	last := -1
+	for _, row := range rows {
+		idx := strings.Index(result, row)
+		if idx < last {
+			t.Errorf("Expected %q after the previous row", row)
+		}
+		last = idx
+	}
+	if strings.Index(result, "## Appendix: Checks Executed") < strings.Index(result, "## Audit Metadata") {
+		t.Error("Expected the checks appendix after the audit metadata")
+	}
+
+	data.ComplianceResults.PluginResults = nil
+	if result := b.BuildAuditSection(data); strings.Contains(result, "Checks Executed") {
+		t.Error("Should not contain the checks appendix when no plugin ran")
+	}
+}
+
 func TestBuildAuditSection_WithMetadata(t *testing.T) {
 	t.Parallel()
 
@@ -1831,4 +2460,101 @@ func TestBuildAuditSection_InventoryExcludedFromSecurityFindings(t *testing.T) {
 	}
 }
 
+func TestBuildRiskScoreSection(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		level string
+		alert string
+	}{
+		{"Low", "> [!TIP]"},
+		{"Medium", "> [!NOTE]"},
+		{"High", "> [!WARNING]"},
+		{"Critical", "> [!WARNING]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.level, func(t *testing.T) {
+			t.Parallel()
+
+			result := NewMarkdownBuilder().BuildRiskScoreSection(21, tt.level, map[string]int{"critical": 20, "low": 1})
+
+			for _, want := range []string{"## Risk Score", "**21** (" + tt.level + ")", tt.alert, "| critical | 20 |", "| low | 1 |"} {
+				if !strings.Contains(result, want) {
+					t.Errorf("expected %q in risk score section:\n%s", want, result)
+				}
+			}
+			if strings.Contains(result, "| high |") {
+				t.Errorf("severities without points should be omitted:\n%s", result)
+			}
+		})
+	}
+}
+
 // Use helper functions from existing helpers_test.go
+
+func TestBuildSectionSummaryCallout(t *testing.T) {
+	t.Parallel()
+
+	b := NewMarkdownBuilder()
+	tests := []struct {
+		name     string
+		items    int
+		findings []analysis.Finding
+		want     string
+	}{
+		{"no findings", 3, nil, "> **Section Summary**: 3 items, 0 findings"},
+		{
+			"findings", 1,
+			[]analysis.Finding{{Title: "Any-to-any rule"}, {Title: "Logging disabled"}},
+			"> **Section Summary**: 1 item, 2 findings",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := b.BuildSectionSummaryCallout(tt.items, tt.findings)
+			if !strings.Contains(got, "[!NOTE]") || !strings.Contains(got, tt.want) {
+				t.Errorf("BuildSectionSummaryCallout() = %q, want a note containing %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteComprehensiveSecuritySection_SummaryCallouts(t *testing.T) {
+	t.Parallel()
+
+	b := NewMarkdownBuilder()
+	data := &common.CommonDevice{
+		FirewallRules: []common.FirewallRule{
+			{
+				Type:        "pass",
+				Interfaces:  []string{"wan"},
+				Source:      common.RuleEndpoint{Address: "any"},
+				Destination: common.RuleEndpoint{Address: "any"},
+			},
+			{Type: "block", Interfaces: []string{"lan"}, Disabled: true},
+		},
+	}
+
+	doc := document.New()
+	b.writeComprehensiveSecuritySection(doc, data)
+	section := b.render(doc)
+	for _, want := range []string{
+		"> **Section Summary**: 0 outbound rules, 0 inbound rules, 0 findings",
+		"> **Section Summary**: 2 rules (1 enabled), ",
+	} {
+		if !strings.Contains(section, want) {
+			t.Errorf("comprehensive security section missing %q", want)
+		}
+	}
+	if strings.Contains(section, "> **Section Summary**: 2 rules (1 enabled), 0 findings") {
+		t.Error("firewall callout should count the findings for the permissive WAN rule")
+	}
+
+	if strings.Contains(b.BuildSecuritySection(data), "Section Summary") {
+		t.Error("standard security section should not include section summary callouts")
+	}
+}
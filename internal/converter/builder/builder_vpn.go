@@ -1,24 +1,29 @@
 package builder
 
 import (
-	"bytes"
+	"cmp"
+	"strconv"
+	"strings"
 
+	"github.com/EvilBit-Labs/opnDossier/internal/converter/document"
 	"github.com/EvilBit-Labs/opnDossier/internal/converter/formatters"
 	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
 	"github.com/nao1215/markdown"
 )
 
-// writeIPsecSection writes the IPsec VPN configuration section to the markdown instance.
-func (b *MarkdownBuilder) writeIPsecSection(md *markdown.Markdown, data *common.CommonDevice) {
-	md.H3("IPsec VPN Configuration")
+// writeIPsecSection writes the IPsec VPN configuration section to the report document.
+// Legacy and connection-based (swanctl) tunnels are rendered from the unified
+// connection list, with their child SAs in a separate table.
+func (b *MarkdownBuilder) writeIPsecSection(doc *document.Document, data *common.CommonDevice) {
+	doc.H3("IPsec VPN Configuration")
 
 	ipsec := data.VPN.IPsec
-	if !ipsec.Enabled {
-		md.PlainText(markdown.Italic("No IPsec configuration present"))
+	if !ipsec.Enabled && len(ipsec.Connections) == 0 {
+		doc.Paragraph(markdown.Italic("No IPsec configuration present"))
 		return
 	}
 
-	md.H4("General Configuration").
+	doc.H4("General Configuration").
 		Table(markdown.TableSet{
 			Header: []string{colSetting, colValue},
 			Rows: [][]string{
@@ -26,27 +31,123 @@ func (b *MarkdownBuilder) writeIPsecSection(md *markdown.Markdown, data *common.
 			},
 		})
 
-	md.Note("Phase 1/Phase 2 tunnel configurations require additional parser implementation")
+	writeIPsecConnections(doc, ipsec.Connections)
+	writeIPsecPools(doc, ipsec.Pools)
+}
+
+// writeIPsecConnections writes the unified IPsec connection table and the
+// table of their child SAs.
+func writeIPsecConnections(doc *document.Document, conns []common.IPsecConnection) {
+	if len(conns) == 0 {
+		doc.H4("IPsec Connections").
+			Paragraph(markdown.Italic("No IPsec connections configured"))
+		return
+	}
+
+	connRows := make([][]string, 0, len(conns))
+	var childRows [][]string
+
+	for _, conn := range conns {
+		name := cmp.Or(conn.Description, conn.ID)
+
+		connRows = append(connRows, []string{
+			formatters.EscapeTableContent(name),
+			string(conn.Origin),
+			formatters.EscapeTableContent(conn.Version),
+			formatVPNList(conn.LocalAddresses),
+			formatVPNList(conn.RemoteAddresses),
+			formatVPNList(conn.LocalIDs),
+			formatVPNList(conn.RemoteIDs),
+			formatVPNList(conn.Proposals),
+			formatters.FormatBoolStatus(!conn.Disabled),
+		})
+
+		for _, child := range conn.Children {
+			childRows = append(childRows, []string{
+				formatters.EscapeTableContent(name),
+				formatters.EscapeTableContent(cmp.Or(child.Description, child.ID)),
+				formatters.EscapeTableContent(child.Mode),
+				formatVPNList(child.LocalTrafficSelectors),
+				formatVPNList(child.RemoteTrafficSelectors),
+				formatVPNList(child.Proposals),
+				formatters.FormatBoolStatus(!conn.Disabled && !child.Disabled),
+			})
+		}
+	}
+
+	doc.H4("IPsec Connections").
+		Table(markdown.TableSet{
+			Header: []string{
+				colName, "Origin", "Version", "Local Addresses", "Remote Addresses",
+				"Local ID", "Remote ID", "IKE Proposals", colStatus,
+			},
+			Rows: connRows,
+		})
+
+	doc.H4("Child SAs")
+	if len(childRows) == 0 {
+		doc.Paragraph(markdown.Italic("No child SAs configured"))
+	} else {
+		doc.Table(markdown.TableSet{
+			Header: []string{
+				"Connection", "Child SA", colMode, "Local Traffic Selectors", "Remote Traffic Selectors",
+				"ESP Proposals", colStatus,
+			},
+			Rows: childRows,
+		})
+	}
+}
+
+// writeIPsecPools writes the connection-based IPsec address pool table. It
+// writes nothing when no pools are configured.
+func writeIPsecPools(doc *document.Document, pools []common.IPsecPool) {
+	if len(pools) == 0 {
+		return
+	}
+
+	rows := make([][]string, 0, len(pools))
+	for _, pool := range pools {
+		rows = append(rows, []string{
+			formatters.EscapeTableContent(pool.Name),
+			formatters.EscapeTableContent(pool.Addresses),
+			formatVPNList(pool.DNSServers),
+		})
+	}
+
+	doc.H4("Address Pools").
+		Table(markdown.TableSet{
+			Header: []string{colName, "Addresses", "DNS Servers"},
+			Rows:   rows,
+		})
+}
+
+// formatVPNList joins VPN table values with commas, or returns "-" when
+// there are none.
+func formatVPNList(values []string) string {
+	if len(values) == 0 {
+		return "-"
+	}
+
+	return formatters.EscapeTableContent(strings.Join(values, ", "))
 }
 
 // BuildIPsecSection builds the IPsec VPN configuration section.
 func (b *MarkdownBuilder) BuildIPsecSection(data *common.CommonDevice) string {
-	var buf bytes.Buffer
-	md := markdown.NewMarkdown(&buf)
-	b.writeIPsecSection(md, data)
-	return md.String()
+	doc := document.New()
+	b.writeIPsecSection(doc, data)
+	return b.render(doc)
 }
 
-// writeOpenVPNSection writes the OpenVPN configuration section to the markdown instance.
-func (b *MarkdownBuilder) writeOpenVPNSection(md *markdown.Markdown, data *common.CommonDevice) {
-	md.H3("OpenVPN Configuration")
+// writeOpenVPNSection writes the OpenVPN configuration section to the report document.
+func (b *MarkdownBuilder) writeOpenVPNSection(doc *document.Document, data *common.CommonDevice) {
+	doc.H3("OpenVPN Configuration")
 
 	openvpn := data.VPN.OpenVPN
 
 	// OpenVPN Servers
 	if len(openvpn.Servers) == 0 {
-		md.H4("OpenVPN Servers").
-			PlainText(markdown.Italic("No OpenVPN servers configured"))
+		doc.H4("OpenVPN Servers").
+			Paragraph(markdown.Italic("No OpenVPN servers configured"))
 	} else {
 		serverRows := make([][]string, 0, len(openvpn.Servers))
 		for _, server := range openvpn.Servers {
@@ -54,23 +155,27 @@ func (b *MarkdownBuilder) writeOpenVPNSection(md *markdown.Markdown, data *commo
 				formatters.EscapeTableContent(server.Description),
 				formatters.EscapeTableContent(server.Mode),
 				formatters.EscapeTableContent(server.Protocol),
+				formatters.EscapeTableContent(server.DevMode),
 				formatters.EscapeTableContent(server.Interface),
 				formatters.EscapeTableContent(server.LocalPort),
 				formatters.EscapeTableContent(server.TunnelNetwork),
 				formatters.EscapeTableContent(server.RemoteNetwork),
+				formatVPNList(server.DataCiphers),
 				formatters.EscapeTableContent(server.CertRef),
 			})
 		}
-		md.H4("OpenVPN Servers").
+		doc.H4("OpenVPN Servers").
 			Table(markdown.TableSet{
 				Header: []string{
 					colDescription,
 					colMode,
 					colProtocol,
+					"Device",
 					colInterface,
 					"Port",
 					"Tunnel Network",
 					"Remote Network",
+					"Data Ciphers",
 					"Certificate",
 				},
 				Rows: serverRows,
@@ -79,8 +184,8 @@ func (b *MarkdownBuilder) writeOpenVPNSection(md *markdown.Markdown, data *commo
 
 	// OpenVPN Clients
 	if len(openvpn.Clients) == 0 {
-		md.H4("OpenVPN Clients").
-			PlainText(markdown.Italic("No OpenVPN clients configured"))
+		doc.H4("OpenVPN Clients").
+			Paragraph(markdown.Italic("No OpenVPN clients configured"))
 	} else {
 		clientRows := make([][]string, 0, len(openvpn.Clients))
 		for _, client := range openvpn.Clients {
@@ -90,10 +195,12 @@ func (b *MarkdownBuilder) writeOpenVPNSection(md *markdown.Markdown, data *commo
 				formatters.EscapeTableContent(client.ServerPort),
 				formatters.EscapeTableContent(client.Mode),
 				formatters.EscapeTableContent(client.Protocol),
+				formatters.EscapeTableContent(client.DevMode),
+				formatVPNList(client.DataCiphers),
 				formatters.EscapeTableContent(client.CertRef),
 			})
 		}
-		md.H4("OpenVPN Clients").
+		doc.H4("OpenVPN Clients").
 			Table(markdown.TableSet{
 				Header: []string{
 					colDescription,
@@ -101,6 +208,8 @@ func (b *MarkdownBuilder) writeOpenVPNSection(md *markdown.Markdown, data *commo
 					"Port",
 					colMode,
 					colProtocol,
+					"Device",
+					"Data Ciphers",
 					"Certificate",
 				},
 				Rows: clientRows,
@@ -110,46 +219,145 @@ func (b *MarkdownBuilder) writeOpenVPNSection(md *markdown.Markdown, data *commo
 
 // BuildOpenVPNSection builds the OpenVPN configuration section with servers and clients.
 func (b *MarkdownBuilder) BuildOpenVPNSection(data *common.CommonDevice) string {
-	var buf bytes.Buffer
-	md := markdown.NewMarkdown(&buf)
-	b.writeOpenVPNSection(md, data)
-	return md.String()
+	doc := document.New()
+	b.writeOpenVPNSection(doc, data)
+	return b.render(doc)
 }
 
-// writeVLANSection writes the VLAN configuration section to the markdown instance.
-func (b *MarkdownBuilder) writeVLANSection(md *markdown.Markdown, data *common.CommonDevice) {
-	b.WriteVLANTable(md.H3("VLAN Configuration"), data.VLANs)
+// writeLegacyVPNSection writes the legacy PPTP/L2TP remote access VPN
+// section to the report document. Nothing is written when the configuration
+// carries neither a <pptpd> nor an <l2tp> section.
+func (b *MarkdownBuilder) writeLegacyVPNSection(doc *document.Document, data *common.CommonDevice) {
+	servers := []struct {
+		protocol string
+		server   *common.LegacyRemoteAccessVPN
+	}{
+		{"PPTP", data.VPN.PPTP},
+		{"L2TP", data.VPN.L2TP},
+	}
+
+	rows := make([][]string, 0, len(servers))
+	for _, s := range servers {
+		if s.server == nil {
+			continue
+		}
+
+		remoteRange := s.server.RemoteAddressStart
+		if remoteRange != "" && s.server.RemoteAddressCount > 0 {
+			remoteRange += " (" + strconv.Itoa(s.server.RemoteAddressCount) + " addresses)"
+		}
+
+		users := make([]string, 0, len(s.server.Users))
+		for _, u := range s.server.Users {
+			users = append(users, u.Name)
+		}
+
+		rows = append(rows, []string{
+			s.protocol,
+			formatters.FormatBool(s.server.Enabled),
+			formatters.EscapeTableContent(s.server.Mode),
+			formatters.EscapeTableContent(s.server.Interface),
+			formatters.EscapeTableContent(s.server.LocalAddress),
+			formatters.EscapeTableContent(remoteRange),
+			formatters.EscapeTableContent(s.server.Authentication),
+			formatters.EscapeTableContent(strings.Join(users, ", ")),
+		})
+	}
+
+	if len(rows) == 0 {
+		return
+	}
+
+	doc.H3("Legacy Remote Access VPN").
+		Table(markdown.TableSet{
+			Header: []string{
+				colProtocol,
+				colEnabled,
+				colMode,
+				colInterface,
+				"Local Address",
+				"Remote Range",
+				"Authentication",
+				"Users",
+			},
+			Rows: rows,
+		})
+
+	doc.Note("PPTP and L2TP are deprecated; migrate remote access users to OpenVPN, WireGuard, or IPsec")
+}
+
+// BuildLegacyVPNSection builds the legacy PPTP/L2TP remote access VPN section.
+func (b *MarkdownBuilder) BuildLegacyVPNSection(data *common.CommonDevice) string {
+	doc := document.New()
+	b.writeLegacyVPNSection(doc, data)
+	return b.render(doc)
+}
+
+// writeZeroTierSection writes the ZeroTier overlay VPN settings and joined
+// networks to the report document. Nothing is written when the configuration
+// has no <zerotier> section.
+func (b *MarkdownBuilder) writeZeroTierSection(doc *document.Document, data *common.CommonDevice) {
+	zt := data.VPN.ZeroTier
+	if zt == nil {
+		return
+	}
+
+	port := zt.Port
+	if port == "" {
+		port = "9993 (default)"
+	}
+
+	doc.H3("ZeroTier").
+		Paragraphf("%s: %s", markdown.Bold(colEnabled), formatters.FormatBool(zt.Enabled)).Break().
+		Paragraphf("%s: %s", markdown.Bold("Port"), port).Break().
+		Paragraphf("%s: %s", markdown.Bold("Allow Default Route"), formatters.FormatBool(zt.AllowDefaultRoute)).Break()
+
+	if len(zt.Networks) > 0 {
+		rows := make([][]string, 0, len(zt.Networks))
+		for _, n := range zt.Networks {
+			rows = append(rows, []string{
+				formatters.EscapeTableContent(n.NetworkID),
+				formatters.FormatBool(n.Enabled),
+				formatters.EscapeTableContent(n.Description),
+			})
+		}
+
+		doc.Table(markdown.TableSet{
+			Header: []string{"Network ID", colEnabled, colDescription},
+			Rows:   rows,
+		})
+	}
+
+	if zt.Enabled {
+		doc.Note("ZeroTier traffic is only filtered by pf when rules are added to the assigned ZeroTier interface")
+	}
 }
 
-// buildVLANSection builds the VLAN configuration section wrapper.
-func (b *MarkdownBuilder) buildVLANSection(data *common.CommonDevice) string {
-	var buf bytes.Buffer
-	md := markdown.NewMarkdown(&buf)
-	b.writeVLANSection(md, data)
-	return md.String()
+// BuildZeroTierSection builds the ZeroTier overlay VPN section.
+func (b *MarkdownBuilder) BuildZeroTierSection(data *common.CommonDevice) string {
+	doc := document.New()
+	b.writeZeroTierSection(doc, data)
+	return b.render(doc)
 }
 
-// writeStaticRoutesSection writes the static routes section to the markdown instance.
-func (b *MarkdownBuilder) writeStaticRoutesSection(md *markdown.Markdown, data *common.CommonDevice) {
-	b.WriteStaticRoutesTable(md.H3("Static Routes"), data.Routing.StaticRoutes)
+// writeVLANSection writes the VLAN configuration section to the report document.
+func (b *MarkdownBuilder) writeVLANSection(doc *document.Document, data *common.CommonDevice) {
+	b.WriteVLANTable(doc.H3("VLAN Configuration"), data.VLANs)
 }
 
-// buildStaticRoutesSection builds the static routes section wrapper.
-func (b *MarkdownBuilder) buildStaticRoutesSection(data *common.CommonDevice) string {
-	var buf bytes.Buffer
-	md := markdown.NewMarkdown(&buf)
-	b.writeStaticRoutesSection(md, data)
-	return md.String()
+// writeStaticRoutesSection writes the static routes section to the report document.
+func (b *MarkdownBuilder) writeStaticRoutesSection(doc *document.Document, data *common.CommonDevice) {
+	b.WriteStaticRoutesTable(doc.H3("Static Routes"), data.Routing.StaticRoutes)
 }
 
-// writeHASection writes the High Availability and CARP configuration section to the markdown instance.
-func (b *MarkdownBuilder) writeHASection(md *markdown.Markdown, data *common.CommonDevice) {
-	md.H3("High Availability & CARP")
+// writeHASection writes the High Availability and CARP configuration section to the report document.
+func (b *MarkdownBuilder) writeHASection(doc *document.Document, data *common.CommonDevice) {
+	doc.H3("High Availability & CARP")
 
 	// Virtual IP Addresses
 	if len(data.VirtualIPs) == 0 {
-		md.H4("Virtual IP Addresses (CARP)").
-			PlainText(markdown.Italic("No virtual IPs configured"))
+		doc.H4("Virtual IP Addresses (CARP)").
+			Paragraph(markdown.Italic("No virtual IPs configured"))
 	} else {
 		vipRows := make([][]string, 0, len(data.VirtualIPs))
 		for _, vip := range data.VirtualIPs {
@@ -158,7 +366,7 @@ func (b *MarkdownBuilder) writeHASection(md *markdown.Markdown, data *common.Com
 				formatters.EscapeTableContent(vip.Mode),
 			})
 		}
-		md.H4("Virtual IP Addresses (CARP)").
+		doc.H4("Virtual IP Addresses (CARP)").
 			Table(markdown.TableSet{
 				Header: []string{"VIP Address", colType},
 				Rows:   vipRows,
@@ -173,10 +381,10 @@ func (b *MarkdownBuilder) writeHASection(md *markdown.Markdown, data *common.Com
 		hasync.PfsyncVersion != "" || hasync.DisablePreempt
 
 	if !haConfigured {
-		md.H4("HA Synchronization Settings").
-			PlainText(markdown.Italic("No HA synchronization configured"))
+		doc.H4("HA Synchronization Settings").
+			Paragraph(markdown.Italic("No HA synchronization configured"))
 	} else {
-		md.H4("HA Synchronization Settings").
+		doc.H4("HA Synchronization Settings").
 			Table(markdown.TableSet{
 				Header: []string{colSetting, colValue},
 				Rows: [][]string{
@@ -193,8 +401,7 @@ func (b *MarkdownBuilder) writeHASection(md *markdown.Markdown, data *common.Com
 
 // BuildHASection builds the High Availability and CARP configuration section.
 func (b *MarkdownBuilder) BuildHASection(data *common.CommonDevice) string {
-	var buf bytes.Buffer
-	md := markdown.NewMarkdown(&buf)
-	b.writeHASection(md, data)
-	return md.String()
+	doc := document.New()
+	b.writeHASection(doc, data)
+	return b.render(doc)
 }
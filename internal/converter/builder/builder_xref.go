@@ -0,0 +1,265 @@
+package builder
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/analysis"
+	"github.com/EvilBit-Labs/opnDossier/internal/converter/document"
+	"github.com/EvilBit-Labs/opnDossier/internal/converter/formatters"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/nao1215/markdown"
+)
+
+// Anchor ID prefixes of the rule table rows the interface cross-reference
+// links to; the 1-based rule number completes the ID.
+const (
+	ruleAnchorFirewall    = "firewall-rule-"
+	ruleAnchorOutboundNAT = "outbound-nat-rule-"
+	ruleAnchorInboundNAT  = "inbound-nat-rule-"
+	ruleAnchorOneToOneNAT = "one-to-one-nat-rule-"
+)
+
+// Anchors of the report headings the interface cross-reference links to.
+const (
+	anchorDHCPServer    = "#dhcp-server"
+	anchorOpenVPNServer = "#openvpn-servers"
+	anchorOpenVPNClient = "#openvpn-clients"
+	anchorIPsec         = "#ipsec-vpn-configuration"
+	anchorLegacyVPN     = "#legacy-remote-access-vpn"
+)
+
+// noReferences is shown for a reference category that is empty.
+const noReferences = "none"
+
+// vpnBindingLabels maps VPN binding kinds to their display label and the
+// anchor of the section that lists them. An empty anchor renders plain text.
+var vpnBindingLabels = map[string]struct{ label, anchor string }{
+	analysis.VPNKindOpenVPNServer: {"OpenVPN server", anchorOpenVPNServer},
+	analysis.VPNKindOpenVPNClient: {"OpenVPN client", anchorOpenVPNClient},
+	analysis.VPNKindIPsec:         {"IPsec tunnel", anchorIPsec},
+	analysis.VPNKindWireGuard:     {"WireGuard", ""},
+	analysis.VPNKindPPTP:          {"PPTP server", anchorLegacyVPN},
+	analysis.VPNKindL2TP:          {"L2TP server", anchorLegacyVPN},
+}
+
+// writeInterfaceXRefSection writes the interface cross-reference appendix:
+// for every configured interface, sorted by name, the firewall rules, NAT
+// rules, DHCP scope, VPN instances, and gateways that reference it, and its
+// effective ruleset in evaluation order. Rule numbers link to their rows in
+// the rule tables.
+func (b *MarkdownBuilder) writeInterfaceXRefSection(doc *document.Document, data *common.CommonDevice) {
+	doc.H2("Interface Cross-Reference")
+
+	names := make([]string, 0, len(data.Interfaces))
+	for _, iface := range data.Interfaces {
+		if iface.Name != "" {
+			names = append(names, iface.Name)
+		}
+	}
+
+	if len(names) == 0 {
+		doc.Paragraph(markdown.Italic("No interfaces configured"))
+		return
+	}
+
+	slices.Sort(names)
+	names = slices.Compact(names)
+
+	index := analysis.BuildInterfaceIndex(data)
+	for _, name := range names {
+		refs := index[name]
+
+		items := []string{
+			markdown.Bold("Details") + ": " + formatters.FormatInterfacesAsLinks([]string{name}),
+			xrefRuleItem("Firewall Rules", refs.FirewallRules, ruleAnchorFirewall),
+			xrefEffectiveRulesItem(analysis.EffectiveRules(data, name)),
+			xrefRuleItem("Outbound NAT Rules", refs.OutboundNAT, ruleAnchorOutboundNAT),
+			xrefRuleItem("Inbound NAT Rules", refs.InboundNAT, ruleAnchorInboundNAT),
+		}
+		// 1:1 NAT is rare, so the item is only listed for interfaces that have one.
+		if len(refs.OneToOneNAT) > 0 {
+			items = append(items, xrefRuleItem("1:1 NAT Rules", refs.OneToOneNAT, ruleAnchorOneToOneNAT))
+		}
+		items = append(items, xrefDHCPItem(refs), xrefVPNItem(refs.VPN), xrefGatewayItem(refs.Gateways))
+
+		doc.H3(formatters.CapitalizeName(name) + " References").BulletList(items...)
+	}
+}
+
+// BuildInterfaceXRefSection builds the interface cross-reference appendix.
+func (b *MarkdownBuilder) BuildInterfaceXRefSection(data *common.CommonDevice) string {
+	doc := document.New()
+	b.writeInterfaceXRefSection(doc, data)
+	return b.render(doc)
+}
+
+// xrefRuleItem formats a rule count and the 1-based rule numbers, each linked
+// to its row anchor under anchorPrefix.
+func xrefRuleItem(label string, positions []int, anchorPrefix string) string {
+	item := fmt.Sprintf("%s (%d): ", markdown.Bold(label), len(positions))
+	if len(positions) == 0 {
+		return item + noReferences
+	}
+
+	links := make([]string, 0, len(positions))
+	for _, pos := range positions {
+		n := strconv.Itoa(pos)
+		links = append(links, markdown.Link(n, "#"+anchorPrefix+n))
+	}
+
+	return item + strings.Join(links, ", ")
+}
+
+// xrefEffectiveRulesItem formats an interface's effective ruleset: the
+// 1-based rule numbers in pf evaluation order, each linked to its firewall
+// rule row and followed by its origin.
+func xrefEffectiveRulesItem(rules []analysis.EffectiveRule) string {
+	item := fmt.Sprintf("%s (%d): ", markdown.Bold("Effective Rules"), len(rules))
+	if len(rules) == 0 {
+		return item + noReferences
+	}
+
+	links := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		n := strconv.Itoa(rule.Index + 1)
+		links = append(links, markdown.Link(n, "#"+ruleAnchorFirewall+n)+" "+rule.OriginLabel())
+	}
+
+	return item + strings.Join(links, ", ")
+}
+
+// ruleNumberCell formats a rule table's "#" cell: the 1-based rule number
+// preceded by the row anchor under anchorPrefix that xrefRuleItem links to.
+func ruleNumberCell(anchorPrefix string, pos int) string {
+	n := strconv.Itoa(pos)
+	return document.Anchor(anchorPrefix+n) + n
+}
+
+// xrefDHCPItem formats whether a DHCP scope serves the interface.
+func xrefDHCPItem(refs analysis.InterfaceReferences) string {
+	item := markdown.Bold("DHCP Scope") + ": "
+
+	switch {
+	case refs.DHCPEnabled:
+		return item + markdown.Link("enabled", anchorDHCPServer)
+	case refs.DHCPScope:
+		return item + markdown.Link("disabled", anchorDHCPServer)
+	default:
+		return item + noReferences
+	}
+}
+
+// xrefVPNItem formats the VPN instances bound to the interface.
+func xrefVPNItem(bindings []analysis.VPNBinding) string {
+	item := fmt.Sprintf("%s (%d): ", markdown.Bold("VPN Bindings"), len(bindings))
+	if len(bindings) == 0 {
+		return item + noReferences
+	}
+
+	links := make([]string, 0, len(bindings))
+	for _, binding := range bindings {
+		kind := vpnBindingLabels[binding.Kind]
+		text := cmp.Or(kind.label, binding.Kind)
+		if binding.Name != "" {
+			text += ": " + binding.Name
+		}
+
+		if kind.anchor == "" {
+			links = append(links, text)
+			continue
+		}
+
+		links = append(links, markdown.Link(text, kind.anchor))
+	}
+
+	return item + strings.Join(links, ", ")
+}
+
+// xrefGatewayItem formats the gateways reachable through the interface.
+func xrefGatewayItem(gateways []string) string {
+	item := fmt.Sprintf("%s (%d): ", markdown.Bold("Gateways"), len(gateways))
+	if len(gateways) == 0 {
+		return item + noReferences
+	}
+
+	names := make([]string, 0, len(gateways))
+	for _, gw := range gateways {
+		names = append(names, markdown.Code(gw))
+	}
+
+	return item + strings.Join(names, ", ")
+}
+
+// writeEffectiveRulesSection writes the effective ruleset of the interface
+// named iface: every enabled firewall rule that applies to it, in pf
+// evaluation order, with the origin that makes it apply.
+func (b *MarkdownBuilder) writeEffectiveRulesSection(doc *document.Document, data *common.CommonDevice, iface string) {
+	doc.H2("Effective Rules: " + formatters.CapitalizeName(iface))
+
+	rules := analysis.EffectiveRules(data, iface)
+	if len(rules) == 0 {
+		doc.Paragraph(markdown.Italic("No enabled firewall rules apply to this interface"))
+		return
+	}
+
+	doc.Table(*BuildEffectiveRulesTableSet(rules, !b.currentSettings().noPortNames))
+	doc.Note("Floating rules are evaluated first, then interface group rules, then the interface's own rules. " +
+		"The first matching quick rule decides; a floating rule without quick lets evaluation continue.")
+}
+
+// BuildEffectiveRulesSection builds the effective ruleset of the interface
+// named iface.
+func (b *MarkdownBuilder) BuildEffectiveRulesSection(data *common.CommonDevice, iface string) string {
+	doc := document.New()
+	b.writeEffectiveRulesSection(doc, data, iface)
+	return b.render(doc)
+}
+
+// BuildEffectiveRulesTableSet builds the table data for an effective
+// ruleset. The Rule column holds the 1-based position in the firewall rule
+// table. When portNames is true, well-known TCP/UDP ports are annotated with
+// their service name.
+func BuildEffectiveRulesTableSet(rules []analysis.EffectiveRule, portNames bool) *markdown.TableSet {
+	headers := []string{
+		"Order",
+		"Rule",
+		"Origin",
+		"Action",
+		"Quick",
+		"Proto",
+		"Source",
+		"Destination",
+		"Dest Port",
+		colDescription,
+	}
+
+	rows := make([][]string, 0, len(rules))
+	for i, rule := range rules {
+		source := cmp.Or(rule.Rule.Source.Address, destinationAny)
+		dest := cmp.Or(rule.Rule.Destination.Address, destinationAny)
+
+		rows = append(rows, []string{
+			strconv.Itoa(i + 1),
+			strconv.Itoa(rule.Index + 1),
+			formatters.EscapeTableContent(rule.OriginLabel()),
+			string(rule.Rule.Type),
+			formatters.FormatBool(rule.Rule.Quick || !rule.Rule.Floating),
+			rule.Rule.Protocol,
+			source,
+			dest,
+			formatters.EscapeTableContent(
+				formatters.FormatPortRange(rule.Rule.Destination.Port, rule.Rule.Protocol, portNames),
+			),
+			formatters.EscapeTableContent(rule.Rule.Description),
+		})
+	}
+
+	return &markdown.TableSet{
+		Header: headers,
+		Rows:   rows,
+	}
+}
@@ -1,6 +1,80 @@
 package builder
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
 
 // ErrNilDevice is returned when the input device configuration is nil.
 var ErrNilDevice = errors.New("device configuration is nil")
+
+// ErrUnknownSection is returned by ResolveSectionOrder and SetSectionOrder
+// for a section ID that is not in the registry.
+var ErrUnknownSection = errors.New("unknown report section")
+
+// ErrDuplicateSection is returned by ResolveSectionOrder and SetSectionOrder
+// when a section is listed more than once.
+var ErrDuplicateSection = errors.New("duplicate report section")
+
+// SectionRenderError records a report section that failed to render.
+type SectionRenderError struct {
+	// Section is the title of the section, such as "OpenVPN Configuration".
+	Section string
+	// Err is the failure; a recovered panic is wrapped as an error.
+	Err error
+}
+
+// Error implements error.
+func (e *SectionRenderError) Error() string {
+	return fmt.Sprintf("section '%s' could not be rendered: %v", e.Section, e.Err)
+}
+
+// Unwrap returns the underlying failure.
+func (e *SectionRenderError) Unwrap() error {
+	return e.Err
+}
+
+// placeholder returns the text written to the report in place of the
+// section.
+func (e *SectionRenderError) placeholder() string {
+	return fmt.Sprintf("⚠️ Section '%s' could not be rendered: %v; please report this with your config",
+		e.Section, e.Err)
+}
+
+// PartialReportError is returned together with a complete report when one or
+// more sections failed to render. Each failed section is replaced in the
+// report by a placeholder and every other section is rendered normally, so
+// callers may still use the report. SetStrictRender turns section failures
+// into hard errors instead.
+type PartialReportError struct {
+	// Sections lists the failed sections in report order.
+	Sections []*SectionRenderError
+}
+
+// Error implements error.
+func (e *PartialReportError) Error() string {
+	msgs := make([]string, 0, len(e.Sections))
+	for _, s := range e.Sections {
+		msgs = append(msgs, s.Error())
+	}
+	return fmt.Sprintf("%d report section(s) could not be rendered: %s", len(e.Sections), strings.Join(msgs, "; "))
+}
+
+// Unwrap returns the section failures.
+func (e *PartialReportError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Sections))
+	for _, s := range e.Sections {
+		errs = append(errs, s)
+	}
+	return errs
+}
+
+// partialReport returns the failed sections as a *PartialReportError, or nil
+// when no section failed.
+func partialReport(failed []*SectionRenderError) error {
+	if len(failed) == 0 {
+		return nil
+	}
+	return &PartialReportError{Sections: failed}
+}
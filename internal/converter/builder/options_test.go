@@ -1,9 +1,13 @@
 package builder
 
 import (
+	"bytes"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/EvilBit-Labs/opnDossier/internal/converter/document"
+	"github.com/EvilBit-Labs/opnDossier/internal/progress"
 	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
 )
 
@@ -69,6 +73,76 @@ func TestWithVersion(t *testing.T) {
 	})
 }
 
+func TestWithProgress(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil tracker preserves no-op default", func(t *testing.T) {
+		t.Parallel()
+		b := NewMarkdownBuilder(WithProgress(nil))
+		if _, ok := b.progress.(*progress.NoOpProgress); !ok {
+			t.Errorf("progress = %T, want *progress.NoOpProgress", b.progress)
+		}
+	})
+
+	t.Run("sections are reported while rendering", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		b := NewMarkdownBuilder(WithProgress(progress.NewLineTracker(&buf)))
+
+		if _, err := b.BuildStandardReport(&common.CommonDevice{}); err != nil {
+			t.Fatalf("BuildStandardReport() error = %v", err)
+		}
+
+		if !strings.HasSuffix(buf.String(), "100% Rendering report sections") {
+			t.Errorf("progress output = %q, want final 100%% section step", buf.String())
+		}
+	})
+}
+
+func TestWithRenderer(t *testing.T) {
+	t.Parallel()
+
+	data := &common.CommonDevice{System: common.System{Hostname: "fw01"}}
+
+	t.Run("nil renderer preserves markdown default", func(t *testing.T) {
+		t.Parallel()
+		b := NewMarkdownBuilder(WithRenderer(nil))
+		if _, ok := b.renderer.(document.MarkdownRenderer); !ok {
+			t.Errorf("renderer = %T, want document.MarkdownRenderer", b.renderer)
+		}
+	})
+
+	t.Run("plain text renderer applies to reports", func(t *testing.T) {
+		t.Parallel()
+		b := NewMarkdownBuilder(WithRenderer(document.PlainTextRenderer{}))
+
+		report, err := b.BuildStandardReport(data)
+		if err != nil {
+			t.Fatalf("BuildStandardReport() error = %v", err)
+		}
+
+		for _, unwanted := range []string{"<!--", "## ", "**", "|---"} {
+			if strings.Contains(report, unwanted) {
+				t.Errorf("plain text report contains markdown %q", unwanted)
+			}
+		}
+		if !strings.Contains(report, "Hostname: fw01") {
+			t.Errorf("plain text report missing hostname line:\n%s", report)
+		}
+	})
+
+	t.Run("plain text renderer applies to sections", func(t *testing.T) {
+		t.Parallel()
+		b := NewMarkdownBuilder(WithRenderer(document.PlainTextRenderer{}))
+
+		section := b.BuildSystemSection(data)
+		if !strings.HasPrefix(section, "System Configuration\n--------------------\n") {
+			t.Errorf("section = %q, want underlined plain text heading", section)
+		}
+	})
+}
+
 func TestOptions_Composition(t *testing.T) {
 	t.Parallel()
 
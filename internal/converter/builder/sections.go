@@ -0,0 +1,349 @@
+package builder
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/converter/document"
+	"github.com/EvilBit-Labs/opnDossier/internal/converter/formatters"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/nao1215/markdown"
+)
+
+// Report section IDs, in default report order.
+const (
+	SectionChanges  = "changes"
+	SectionSystem   = "system"
+	SectionNetwork  = "network"
+	SectionSecurity = "security"
+	SectionServices = "services"
+	SectionTunables = "tunables"
+	SectionXRef     = "xref"
+)
+
+// ReportSection describes a top-level section of the markdown report body.
+// The executive summary, system information, and table of contents always
+// open the report; the sections below follow in the configured order.
+type ReportSection struct {
+	// ID is the name accepted by SetSectionOrder and the --section and
+	// --section-order flags.
+	ID string
+	// Description summarizes the section's content.
+	Description string
+	// Aliases are alternative names accepted for ID.
+	Aliases []string
+}
+
+// sectionMethod appends part of a report section to doc.
+type sectionMethod func(b *MarkdownBuilder, doc *document.Document, data *common.CommonDevice)
+
+// sectionPart is one writer of a section layout. The title names the part
+// in the placeholder written when the writer fails.
+type sectionPart struct {
+	title string
+	write sectionMethod
+}
+
+// tocEntry is a table of contents link to a heading of a section.
+type tocEntry struct {
+	title  string
+	anchor string
+}
+
+// sectionLayout is what a section renders in one report mode. A section with
+// no writers is omitted from that mode.
+type sectionLayout struct {
+	writers []sectionPart
+	toc     []tocEntry
+}
+
+// registeredSection is a report section with its standard and comprehensive
+// layouts.
+type registeredSection struct {
+	ReportSection
+	standard      sectionLayout
+	comprehensive sectionLayout
+	// tunables marks the section that renders only when the filtered
+	// tunables list is non-empty, so its ToC entry is conditional.
+	tunables bool
+}
+
+// layout returns the section's layout for the given report mode.
+func (s *registeredSection) layout(comprehensive bool) sectionLayout {
+	if comprehensive {
+		return s.comprehensive
+	}
+	return s.standard
+}
+
+// servicesToC links the service headings shared by both report modes.
+var servicesToC = []tocEntry{
+	{"DHCP Services", "#dhcp-server"},
+	{"DNS Resolver", "#dns-resolver-unbound"},
+	{"Services & Daemons", "#service-configuration"},
+}
+
+// sectionRegistry lists the report sections in default order.
+var sectionRegistry = []registeredSection{
+	{
+		ReportSection: ReportSection{
+			ID:          SectionChanges,
+			Description: "Recently modified firewall and NAT rules (comprehensive reports)",
+		},
+		comprehensive: sectionLayout{
+			writers: []sectionPart{{"Recent Changes", (*MarkdownBuilder).writeChangeLogSection}},
+			toc:     []tocEntry{{"Recent Changes", "#recent-changes"}},
+		},
+	},
+	{
+		ReportSection: ReportSection{
+			ID:          SectionSystem,
+			Description: "System configuration, users, and groups",
+		},
+		standard: sectionLayout{
+			writers: []sectionPart{{"System Configuration", (*MarkdownBuilder).writeSystemSection}},
+			toc: []tocEntry{
+				{"System Configuration", "#system-configuration"},
+				{"System Users", "#system-users"},
+			},
+		},
+		comprehensive: sectionLayout{
+			writers: []sectionPart{{"System Configuration", (*MarkdownBuilder).writeComprehensiveSystemSection}},
+			toc: []tocEntry{
+				{"System Configuration", "#system-configuration"},
+				{"System Users", "#system-users"},
+				{"System Groups", "#system-groups"},
+			},
+		},
+	},
+	{
+		ReportSection: ReportSection{
+			ID:          SectionNetwork,
+			Description: "Network interfaces, VLANs, and routing",
+		},
+		standard: sectionLayout{
+			writers: []sectionPart{{"Network Configuration", (*MarkdownBuilder).writeNetworkSection}},
+			toc:     []tocEntry{{"Interfaces", "#interfaces"}},
+		},
+		comprehensive: sectionLayout{
+			writers: []sectionPart{
+				{"Network Configuration", (*MarkdownBuilder).writeNetworkSection},
+				{"VLAN Configuration", (*MarkdownBuilder).writeVLANSection},
+				{"Static Routes", (*MarkdownBuilder).writeStaticRoutesSection},
+			},
+			toc: []tocEntry{
+				{"Interfaces", "#interfaces"},
+				{"VLANs", "#vlan-configuration"},
+				{"Static Routes", "#static-routes"},
+			},
+		},
+	},
+	{
+		ReportSection: ReportSection{
+			ID:          SectionSecurity,
+			Description: "Firewall and NAT rules, IDS, VPN, and high availability",
+			Aliases:     []string{"firewall"},
+		},
+		standard: sectionLayout{
+			writers: []sectionPart{{"Security Configuration", (*MarkdownBuilder).writeSecuritySection}},
+			toc: []tocEntry{
+				{"Firewall Rules", "#firewall-rules"},
+				{"NAT Configuration", "#nat-configuration"},
+			},
+		},
+		comprehensive: sectionLayout{
+			writers: []sectionPart{
+				{"Security Configuration", (*MarkdownBuilder).writeComprehensiveSecuritySection},
+				{"IPsec VPN Configuration", (*MarkdownBuilder).writeIPsecSection},
+				{"OpenVPN Configuration", (*MarkdownBuilder).writeOpenVPNSection},
+				{"Legacy Remote Access VPN", (*MarkdownBuilder).writeLegacyVPNSection},
+				{"ZeroTier", (*MarkdownBuilder).writeZeroTierSection},
+				{"High Availability & CARP", (*MarkdownBuilder).writeHASection},
+			},
+			toc: []tocEntry{
+				{"Firewall Rules", "#firewall-rules"},
+				{"NAT Configuration", "#nat-configuration"},
+				{"Intrusion Detection System", "#intrusion-detection-system-idssuricata"},
+				{"IPsec VPN", "#ipsec-vpn-configuration"},
+				{"OpenVPN", "#openvpn-configuration"},
+				{"High Availability", "#high-availability--carp"},
+			},
+		},
+	},
+	{
+		ReportSection: ReportSection{
+			ID:          SectionServices,
+			Description: "Configured services and daemons",
+		},
+		standard: sectionLayout{
+			writers: []sectionPart{{"Service Configuration", (*MarkdownBuilder).writeServicesSection}},
+			toc:     servicesToC,
+		},
+		comprehensive: sectionLayout{
+			writers: []sectionPart{{"Service Configuration", (*MarkdownBuilder).writeServicesSection}},
+			toc:     servicesToC,
+		},
+	},
+	{
+		ReportSection: ReportSection{
+			ID:          SectionTunables,
+			Description: "System tunables (sysctl)",
+		},
+		standard: sectionLayout{
+			writers: []sectionPart{{"System Tunables", (*MarkdownBuilder).writeTunablesSection}},
+			toc:     []tocEntry{{"System Tunables", "#system-tunables"}},
+		},
+		comprehensive: sectionLayout{
+			writers: []sectionPart{{"System Tunables", (*MarkdownBuilder).writeTunablesSection}},
+			toc:     []tocEntry{{"System Tunables", "#system-tunables"}},
+		},
+		tunables: true,
+	},
+	{
+		ReportSection: ReportSection{
+			ID:          SectionXRef,
+			Description: "Interface cross-reference (comprehensive reports)",
+		},
+		comprehensive: sectionLayout{
+			writers: []sectionPart{{"Interface Cross-Reference", (*MarkdownBuilder).writeInterfaceXRefSection}},
+			toc:     []tocEntry{{"Interface Cross-Reference", "#interface-cross-reference"}},
+		},
+	},
+}
+
+// ReportSections returns the report sections in default order.
+func ReportSections() []ReportSection {
+	sections := make([]ReportSection, 0, len(sectionRegistry))
+	for _, s := range sectionRegistry {
+		sections = append(sections, s.ReportSection)
+	}
+	return sections
+}
+
+// lookupSection returns the registry index of the section named id, which may
+// be an alias. IDs are case-insensitive.
+func lookupSection(id string) (int, bool) {
+	id = strings.ToLower(strings.TrimSpace(id))
+	for i, s := range sectionRegistry {
+		if s.ID == id {
+			return i, true
+		}
+		for _, alias := range s.Aliases {
+			if alias == id {
+				return i, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// ResolveSectionOrder validates a requested section order and returns the
+// complete order as canonical IDs: the requested sections first, then every
+// unlisted section in default order. Aliases resolve to their section and
+// empty entries are ignored. Unknown IDs return ErrUnknownSection listing the
+// valid names; a section listed twice returns ErrDuplicateSection.
+func ResolveSectionOrder(ids []string) ([]string, error) {
+	listed := make([]bool, len(sectionRegistry))
+	order := make([]string, 0, len(sectionRegistry))
+
+	for _, id := range ids {
+		if strings.TrimSpace(id) == "" {
+			continue
+		}
+
+		i, ok := lookupSection(id)
+		if !ok {
+			valid := make([]string, 0, len(sectionRegistry))
+			for _, s := range sectionRegistry {
+				valid = append(valid, s.ID)
+			}
+			return nil, fmt.Errorf("%w %q, must be one of: %s", ErrUnknownSection, id, strings.Join(valid, ", "))
+		}
+		if listed[i] {
+			return nil, fmt.Errorf("%w %q", ErrDuplicateSection, id)
+		}
+
+		listed[i] = true
+		order = append(order, sectionRegistry[i].ID)
+	}
+
+	for i, s := range sectionRegistry {
+		if !listed[i] {
+			order = append(order, s.ID)
+		}
+	}
+
+	return order, nil
+}
+
+// SetSectionOrder configures the order of the report body sections and of
+// the table of contents. ids lists section IDs from ReportSections; unlisted
+// sections follow in default order, and a nil or empty ids restores the
+// default order. Invalid orders return an error and leave the current order
+// unchanged.
+func (b *MarkdownBuilder) SetSectionOrder(ids []string) error {
+	order, err := ResolveSectionOrder(ids)
+	if err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.settings.sectionOrder = order
+	return nil
+}
+
+// orderedSections returns the registry sections in the configured order.
+func (b *MarkdownBuilder) orderedSections() []*registeredSection {
+	order := b.currentSettings().sectionOrder
+
+	sections := make([]*registeredSection, 0, len(sectionRegistry))
+	if len(order) == 0 {
+		for i := range sectionRegistry {
+			sections = append(sections, &sectionRegistry[i])
+		}
+		return sections
+	}
+
+	for _, id := range order {
+		if i, ok := lookupSection(id); ok {
+			sections = append(sections, &sectionRegistry[i])
+		}
+	}
+	return sections
+}
+
+// sectionParts returns the writers of the report body for the given mode, in
+// the configured order.
+func (b *MarkdownBuilder) sectionParts(comprehensive bool) []sectionPart {
+	var parts []sectionPart
+	for _, s := range b.orderedSections() {
+		parts = append(parts, s.layout(comprehensive).writers...)
+	}
+	return parts
+}
+
+// tocItems returns the table of contents links for the given mode, in the
+// configured section order. The hasTunables parameter controls whether the
+// "System Tunables" link is included.
+func (b *MarkdownBuilder) tocItems(comprehensive, hasTunables bool) []string {
+	var items []string
+	for _, s := range b.orderedSections() {
+		if s.tunables && !hasTunables {
+			continue
+		}
+		for _, entry := range s.layout(comprehensive).toc {
+			items = append(items, markdown.Link(entry.title, entry.anchor))
+		}
+	}
+	return items
+}
+
+// writeTunablesSection writes the System Tunables table, filtered according
+// to the include-tunables setting. Nothing is written when no tunable remains.
+func (b *MarkdownBuilder) writeTunablesSection(doc *document.Document, data *common.CommonDevice) {
+	filtered := formatters.FilterSystemTunables(data.Sysctl, b.currentSettings().includeTunables)
+	if len(filtered) > 0 {
+		b.WriteSysctlTable(doc.H2("System Tunables"), filtered)
+	}
+}
@@ -0,0 +1,172 @@
+package builder
+
+import (
+	"errors"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/converter/document"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+)
+
+func TestResolveSectionOrder(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		ids     []string
+		want    []string
+		wantErr error
+	}{
+		{
+			name: "default order",
+			want: []string{"changes", "system", "network", "security", "services", "tunables", "xref"},
+		},
+		{
+			name: "listed sections first, the rest in default order",
+			ids:  []string{"tunables", " Security ", "", "changes"},
+			want: []string{"tunables", "security", "changes", "system", "network", "services", "xref"},
+		},
+		{
+			name: "alias resolves to its section",
+			ids:  []string{"firewall"},
+			want: []string{"security", "changes", "system", "network", "services", "tunables", "xref"},
+		},
+		{
+			name:    "duplicate",
+			ids:     []string{"network", "network"},
+			wantErr: ErrDuplicateSection,
+		},
+		{
+			name:    "unknown",
+			ids:     []string{"interfaces"},
+			wantErr: ErrUnknownSection,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ResolveSectionOrder(tt.ids)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ResolveSectionOrder(%q) error = %v, want %v", tt.ids, err, tt.wantErr)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("ResolveSectionOrder(%q) = %q, want %q", tt.ids, got, tt.want)
+			}
+		})
+	}
+}
+
+// replaceSectionPart swaps the comprehensive writer titled title for write
+// until the test ends. Tests using it must not call t.Parallel: the registry
+// is shared, and parallel tests only start once every sequential test has
+// returned and restored it.
+func replaceSectionPart(t *testing.T, title string, write sectionMethod) {
+	t.Helper()
+
+	for i := range sectionRegistry {
+		writers := sectionRegistry[i].comprehensive.writers
+		for j := range writers {
+			if writers[j].title != title {
+				continue
+			}
+			original := writers[j].write
+			writers[j].write = write
+			t.Cleanup(func() { writers[j].write = original })
+			return
+		}
+	}
+	t.Fatalf("no comprehensive section part titled %q", title)
+}
+
+// panickingOpenVPNSection stands in for an OpenVPN writer with a bug: it
+// writes its heading, then indexes a server the device does not have.
+func panickingOpenVPNSection(_ *MarkdownBuilder, doc *document.Document, data *common.CommonDevice) {
+	doc.H3("OpenVPN Configuration")
+	doc.Paragraph(data.VPN.OpenVPN.Servers[0].Description)
+}
+
+func TestMarkdownBuilder_SectionPanicRendersPlaceholder(t *testing.T) {
+	replaceSectionPart(t, "OpenVPN Configuration", panickingOpenVPNSection)
+
+	data := &common.CommonDevice{
+		System:        common.System{Hostname: "fw01"},
+		FirewallRules: []common.FirewallRule{{Type: common.RuleTypePass, Interfaces: []string{"lan"}}},
+	}
+	placeholder := "⚠️ Section 'OpenVPN Configuration' could not be rendered: panic: runtime error: " +
+		"index out of range [0] with length 0; please report this with your config"
+
+	check := func(label, report string, err error) {
+		t.Helper()
+
+		var partial *PartialReportError
+		if !errors.As(err, &partial) {
+			t.Fatalf("%s: error = %v, want *PartialReportError", label, err)
+		}
+		if len(partial.Sections) != 1 || partial.Sections[0].Section != "OpenVPN Configuration" {
+			t.Errorf("%s: failed sections = %v, want only OpenVPN Configuration", label, partial.Sections)
+		}
+		if !strings.Contains(report, placeholder) {
+			t.Errorf("%s: report lacks placeholder %q", label, placeholder)
+		}
+		if strings.Contains(report, "### OpenVPN Configuration") {
+			t.Errorf("%s: report kept the partial output of the failed section", label)
+		}
+		for _, heading := range []string{
+			"## Security Configuration",
+			"### IPsec VPN Configuration",
+			"### High Availability & CARP",
+			"## Service Configuration",
+			"## Interface Cross-Reference",
+		} {
+			if !strings.Contains(report, heading) {
+				t.Errorf("%s: report lacks %q", label, heading)
+			}
+		}
+	}
+
+	b := NewMarkdownBuilder()
+	report, err := b.BuildComprehensiveReport(data)
+	check("BuildComprehensiveReport", report, err)
+
+	var buf strings.Builder
+	err = b.WriteComprehensiveReport(&buf, data)
+	check("WriteComprehensiveReport", buf.String(), err)
+
+	standard, err := b.BuildStandardReport(data)
+	if err != nil {
+		t.Errorf("BuildStandardReport error = %v, want nil: the standard report has no OpenVPN section", err)
+	}
+	if strings.Contains(standard, "could not be rendered") {
+		t.Error("standard report contains a placeholder")
+	}
+}
+
+func TestMarkdownBuilder_SectionPanicStrictRender(t *testing.T) {
+	replaceSectionPart(t, "OpenVPN Configuration", panickingOpenVPNSection)
+
+	b := NewMarkdownBuilder()
+	b.SetStrictRender(true)
+	data := &common.CommonDevice{System: common.System{Hostname: "fw01"}}
+
+	report, err := b.BuildComprehensiveReport(data)
+	var sectionErr *SectionRenderError
+	if !errors.As(err, &sectionErr) || sectionErr.Section != "OpenVPN Configuration" {
+		t.Fatalf("BuildComprehensiveReport error = %v, want *SectionRenderError for OpenVPN Configuration", err)
+	}
+	var partial *PartialReportError
+	if errors.As(err, &partial) {
+		t.Error("strict render returned a *PartialReportError")
+	}
+	if report != "" {
+		t.Error("strict render returned a report")
+	}
+
+	if err := b.WriteComprehensiveReport(io.Discard, data); !errors.As(err, &sectionErr) {
+		t.Errorf("WriteComprehensiveReport error = %v, want *SectionRenderError", err)
+	}
+}
@@ -2,13 +2,14 @@
 package builder
 
 import (
-	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
 	"strings"
 	"time"
 
 	"github.com/EvilBit-Labs/opnDossier/internal/constants"
+	"github.com/EvilBit-Labs/opnDossier/internal/converter/document"
 	"github.com/EvilBit-Labs/opnDossier/internal/converter/formatters"
 	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
 	"github.com/nao1215/markdown"
@@ -87,129 +88,70 @@ func (b *MarkdownBuilder) WriteServicesSection(w io.Writer, data *common.CommonD
 // Unlike BuildStandardReport which returns a string, this method streams output
 // section-by-section, reducing peak memory usage for large configurations.
 func (b *MarkdownBuilder) WriteStandardReport(w io.Writer, data *common.CommonDevice) error {
-	if data == nil {
-		return ErrNilDevice
-	}
-
-	filteredSysctl := formatters.FilterSystemTunables(data.Sysctl, b.includeTunables)
-
-	// Write header section
-	if err := b.writeReportHeader(w, data); err != nil {
-		return fmt.Errorf("failed to write report header: %w", err)
-	}
-
-	// Write table of contents
-	if err := b.writeTableOfContents(w, false, len(filteredSysctl) > 0); err != nil {
-		return fmt.Errorf("failed to write table of contents: %w", err)
-	}
-
-	// Write each section directly - no intermediate string accumulation
-	if err := b.WriteSystemSection(w, data); err != nil {
-		return fmt.Errorf("failed to write system section: %w", err)
-	}
-
-	if err := b.WriteNetworkSection(w, data); err != nil {
-		return fmt.Errorf("failed to write network section: %w", err)
-	}
-
-	if err := b.WriteSecuritySection(w, data); err != nil {
-		return fmt.Errorf("failed to write security section: %w", err)
-	}
-
-	if err := b.WriteServicesSection(w, data); err != nil {
-		return fmt.Errorf("failed to write services section: %w", err)
-	}
-
-	// Write tunables footer (users already rendered by WriteSystemSection)
-	if err := b.writeStandardReportFooter(w, filteredSysctl); err != nil {
-		return fmt.Errorf("failed to write report footer: %w", err)
-	}
-
-	return nil
+	return b.writeReport(w, data, false)
 }
 
 // WriteComprehensiveReport writes a complete comprehensive report directly to the writer.
 // This provides the same content as BuildComprehensiveReport but with streaming output.
 func (b *MarkdownBuilder) WriteComprehensiveReport(w io.Writer, data *common.CommonDevice) error {
+	return b.writeReport(w, data, true)
+}
+
+// writeReport streams the report header, the table of contents, and each
+// section of the given mode in the configured order. Every section is
+// rendered and written before the next one is assembled. Sections that fail
+// to render are handled as in BuildStandardReport: the complete report is
+// written and a *PartialReportError returned, or in strict mode the first
+// failure is returned after the sections before it have been written.
+func (b *MarkdownBuilder) writeReport(w io.Writer, data *common.CommonDevice, comprehensive bool) error {
 	if data == nil {
 		return ErrNilDevice
 	}
 
-	filteredSysctl := formatters.FilterSystemTunables(data.Sysctl, b.includeTunables)
+	filteredSysctl := formatters.FilterSystemTunables(data.Sysctl, b.currentSettings().includeTunables)
 
 	// Write header section
 	if err := b.writeReportHeader(w, data); err != nil {
 		return fmt.Errorf("failed to write report header: %w", err)
 	}
 
-	// Write comprehensive table of contents
-	if err := b.writeTableOfContents(w, true, len(filteredSysctl) > 0); err != nil {
+	// Write table of contents
+	if err := b.writeTableOfContents(w, comprehensive, len(filteredSysctl) > 0); err != nil {
 		return fmt.Errorf("failed to write table of contents: %w", err)
 	}
 
-	// Write each section directly
-	if err := b.WriteSystemSection(w, data); err != nil {
-		return fmt.Errorf("failed to write system section: %w", err)
-	}
-
-	if err := b.WriteNetworkSection(w, data); err != nil {
-		return fmt.Errorf("failed to write network section: %w", err)
-	}
-
-	// Write VLAN section
-	if _, err := io.WriteString(w, b.buildVLANSection(data)); err != nil {
-		return fmt.Errorf("failed to write VLAN section: %w", err)
-	}
-
-	// Write Static Routes section
-	if _, err := io.WriteString(w, b.buildStaticRoutesSection(data)); err != nil {
-		return fmt.Errorf("failed to write static routes section: %w", err)
-	}
-
-	if err := b.WriteSecuritySection(w, data); err != nil {
-		return fmt.Errorf("failed to write security section: %w", err)
-	}
-
-	// Write IPsec section
-	if _, err := io.WriteString(w, b.BuildIPsecSection(data)); err != nil {
-		return fmt.Errorf("failed to write IPsec section: %w", err)
-	}
-
-	// Write OpenVPN section
-	if _, err := io.WriteString(w, b.BuildOpenVPNSection(data)); err != nil {
-		return fmt.Errorf("failed to write OpenVPN section: %w", err)
-	}
-
-	// Write High Availability section
-	if _, err := io.WriteString(w, b.BuildHASection(data)); err != nil {
-		return fmt.Errorf("failed to write HA section: %w", err)
-	}
-
-	if err := b.WriteServicesSection(w, data); err != nil {
-		return fmt.Errorf("failed to write services section: %w", err)
-	}
-
-	// Write tunables section
-	if len(filteredSysctl) > 0 {
-		var buf bytes.Buffer
-		md := markdown.NewMarkdown(&buf)
-		b.WriteSysctlTable(md.H2("System Tunables"), filteredSysctl)
-		if _, err := io.WriteString(w, md.String()); err != nil {
-			return fmt.Errorf("failed to write tunables section: %w", err)
+	// Write each section directly - no intermediate string accumulation. A
+	// failed section is written as its placeholder; see writeSections.
+	strict := b.currentSettings().strictRender
+
+	var failed []*SectionRenderError
+	for _, section := range b.orderedSections() {
+		for _, part := range section.layout(comprehensive).writers {
+			doc, renderErr := b.renderPart(part, data)
+			if renderErr != nil {
+				if strict {
+					return renderErr
+				}
+				failed = append(failed, renderErr)
+			}
+			if _, err := io.WriteString(w, b.render(doc)); err != nil {
+				return fmt.Errorf("failed to write %s section: %w", section.ID, err)
+			}
 		}
 	}
 
-	return nil
+	return partialReport(failed)
 }
 
 // writeReportHeader writes the report header (title, system info) to the writer.
 func (b *MarkdownBuilder) writeReportHeader(w io.Writer, data *common.CommonDevice) error {
 	platformName := data.DeviceType.DisplayName()
 
-	var buf bytes.Buffer
-	md := markdown.NewMarkdown(&buf).
-		H1(platformName+" Configuration Summary").
-		H2("System Information").
+	doc := document.New().
+		Comment(b.metadataComment(data)).
+		H1(platformName + " Configuration Summary")
+	writeExecutiveSummarySection(doc, executiveSummary(data))
+	doc.H2("System Information").
 		BulletList(
 			markdown.Bold("Hostname")+": "+data.System.Hostname,
 			markdown.Bold("Domain")+": "+data.System.Domain,
@@ -218,46 +160,18 @@ func (b *MarkdownBuilder) writeReportHeader(w io.Writer, data *common.CommonDevi
 			markdown.Bold("Parsed By")+": opnDossier v"+b.getToolVersion(),
 		)
 
-	_, err := io.WriteString(w, md.String())
+	_, err := io.WriteString(w, b.render(doc))
 	return err
 }
 
 // writeTableOfContents writes the table of contents to the writer.
 // The hasTunables parameter controls whether the "System Tunables" link is included.
 func (b *MarkdownBuilder) writeTableOfContents(w io.Writer, comprehensive, hasTunables bool) error {
-	var buf bytes.Buffer
-
-	var tocItems []string
-	if comprehensive {
-		tocItems = b.comprehensiveToCItems(hasTunables)
-	} else {
-		tocItems = b.standardToCItems(hasTunables)
-	}
-
-	md := markdown.NewMarkdown(&buf).
+	doc := document.New().
 		H2("Table of Contents").
-		BulletList(tocItems...)
-
-	_, err := io.WriteString(w, md.String())
-	return err
-}
+		BulletList(b.tocItems(comprehensive, hasTunables)...)
 
-// writeStandardReportFooter writes system tunables for standard reports.
-// Users are already rendered by WriteSystemSection — this avoids duplication.
-// The filteredSysctl parameter is the pre-filtered tunables slice.
-func (b *MarkdownBuilder) writeStandardReportFooter(
-	w io.Writer,
-	filteredSysctl []common.SysctlItem,
-) error {
-	if len(filteredSysctl) == 0 {
-		return nil
-	}
-
-	var buf bytes.Buffer
-	md := markdown.NewMarkdown(&buf)
-	b.WriteSysctlTable(md.H2("System Tunables"), filteredSysctl)
-
-	_, err := io.WriteString(w, md.String())
+	_, err := io.WriteString(w, b.render(doc))
 	return err
 }
 
@@ -269,6 +183,20 @@ func (b *MarkdownBuilder) getGeneratedTime() time.Time {
 	return b.generated
 }
 
+// metadataComment returns the comment text carrying the export metadata that
+// opens every report; the markdown renderer emits it as an HTML comment. The
+// metadata is JSON-encoded with the same keys as the _meta object of JSON/YAML
+// exports. encoding/json escapes '<' and '>', so configuration values cannot
+// terminate the comment early.
+func (b *MarkdownBuilder) metadataComment(data *common.CommonDevice) string {
+	meta, err := json.Marshal(common.NewExportMeta(data, b.getToolVersion()))
+	if err != nil {
+		return ""
+	}
+
+	return "_meta: " + string(meta)
+}
+
 // getToolVersion returns the tool version string.
 func (b *MarkdownBuilder) getToolVersion() string {
 	if b.toolVersion == "" {
@@ -7,8 +7,9 @@ import (
 
 	"github.com/EvilBit-Labs/opnDossier/internal/converter"
 	"github.com/EvilBit-Labs/opnDossier/internal/converter/builder"
+	"github.com/EvilBit-Labs/opnDossier/internal/converter/document"
+	"github.com/EvilBit-Labs/opnDossier/internal/testutil"
 	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
-	"github.com/nao1215/markdown"
 )
 
 func TestMarkdownBuilder_WriteSystemSection(t *testing.T) {
@@ -272,10 +273,9 @@ func TestMarkdownBuilder_WriteVLANTable_Empty(t *testing.T) {
 	t.Parallel()
 
 	b := builder.NewMarkdownBuilder()
-	var buf bytes.Buffer
-	md := markdown.NewMarkdown(&buf)
+	md := document.New()
 	b.WriteVLANTable(md, nil)
-	output := md.String()
+	output := document.MarkdownRenderer{}.Render(md)
 
 	// Verify "No VLANs configured" message
 	if !strings.Contains(output, "No VLANs configured") {
@@ -293,14 +293,13 @@ func TestMarkdownBuilder_WriteVLANTable_SingleVLAN(t *testing.T) {
 			PhysicalIf:  "em0",
 			Tag:         "10",
 			Description: "Management VLAN",
-			Created:     "2024-01-01",
-			Updated:     "2024-01-02",
+			Created:     &common.ChangeRecord{Username: "root@10.0.0.5", Time: "1700000000"},
+			Updated:     &common.ChangeRecord{Username: "admin@10.0.0.6", Time: "1700086400"},
 		},
 	}
-	var buf bytes.Buffer
-	md := markdown.NewMarkdown(&buf)
+	md := document.New()
 	b.WriteVLANTable(md, vlans)
-	output := md.String()
+	output := document.MarkdownRenderer{}.Render(md)
 
 	if !strings.Contains(output, "vlan10") {
 		t.Error("Expected VLAN interface 'vlan10' in output")
@@ -325,10 +324,9 @@ func TestMarkdownBuilder_WriteVLANTable_MultipleVLANs(t *testing.T) {
 		{VLANIf: "vlan20", PhysicalIf: "em0", Tag: "20", Description: "DMZ"},
 		{VLANIf: "vlan30", PhysicalIf: "em1", Tag: "30", Description: "Guest"},
 	}
-	var buf bytes.Buffer
-	md := markdown.NewMarkdown(&buf)
+	md := document.New()
 	b.WriteVLANTable(md, vlans)
-	output := md.String()
+	output := document.MarkdownRenderer{}.Render(md)
 
 	// Verify all VLANs are present
 	if !strings.Contains(output, "vlan10") || !strings.Contains(output, "vlan20") ||
@@ -345,10 +343,9 @@ func TestMarkdownBuilder_WriteStaticRoutesTable_Empty(t *testing.T) {
 	t.Parallel()
 
 	b := builder.NewMarkdownBuilder()
-	var buf bytes.Buffer
-	md := markdown.NewMarkdown(&buf)
+	md := document.New()
 	b.WriteStaticRoutesTable(md, nil)
-	output := md.String()
+	output := document.MarkdownRenderer{}.Render(md)
 
 	if !strings.Contains(output, "No static routes configured") {
 		t.Error("Expected 'No static routes configured' message")
@@ -377,10 +374,9 @@ func TestMarkdownBuilder_WriteStaticRoutesTable_WithRoutes(t *testing.T) {
 			Updated:     "2024-01-02",
 		},
 	}
-	var buf bytes.Buffer
-	md := markdown.NewMarkdown(&buf)
+	md := document.New()
 	b.WriteStaticRoutesTable(md, routes)
-	output := md.String()
+	output := document.MarkdownRenderer{}.Render(md)
 
 	// Verify routes are present
 	if !strings.Contains(output, "10.0.0.0/8") {
@@ -441,6 +437,62 @@ func TestMarkdownBuilder_BuildIPsecSection_Configured(t *testing.T) {
 	}
 }
 
+func TestMarkdownBuilder_BuildIPsecSection_Connections(t *testing.T) {
+	t.Parallel()
+
+	b := builder.NewMarkdownBuilder()
+	data := createTestDocument()
+	data.VPN.IPsec.Connections = []common.IPsecConnection{
+		{
+			Origin:          common.IPsecOriginSwanctl,
+			Description:     "Branch Office",
+			Version:         "ikev2",
+			LocalAddresses:  []string{"203.0.113.1"},
+			RemoteAddresses: []string{"198.51.100.10"},
+			LocalIDs:        []string{"fw.example.com"},
+			RemoteIDs:       []string{"branch.example.com"},
+			Proposals:       []string{"aes256-sha256-modp2048"},
+			Children: []common.IPsecChildSA{
+				{
+					Description:            "LAN to branch",
+					Mode:                   "tunnel",
+					LocalTrafficSelectors:  []string{"10.0.1.0/24"},
+					RemoteTrafficSelectors: []string{"10.20.0.0/24", "10.21.0.0/24"},
+					Proposals:              []string{"aes256gcm16-modp2048"},
+				},
+			},
+		},
+		{Origin: common.IPsecOriginLegacy, Description: "HQ", Version: "ikev1", Disabled: true},
+	}
+	data.VPN.IPsec.Pools = []common.IPsecPool{{Name: "roadwarrior", Addresses: "10.99.0.0/24"}}
+
+	output := b.BuildIPsecSection(data)
+
+	expectedContent := []string{
+		"#### IPsec Connections",
+		"Branch Office",
+		"swanctl",
+		"legacy",
+		"fw.example.com",
+		"aes256-sha256-modp2048",
+		"#### Child SAs",
+		"LAN to branch",
+		"10.20.0.0/24, 10.21.0.0/24",
+		"aes256gcm16-modp2048",
+		"#### Address Pools",
+		"roadwarrior",
+	}
+
+	for _, content := range expectedContent {
+		if !strings.Contains(output, content) {
+			t.Errorf("Expected IPsec section to contain '%s'", content)
+		}
+	}
+	if strings.Contains(output, "No IPsec configuration present") {
+		t.Error("Connections without the general enable flag should still be rendered")
+	}
+}
+
 // ─────────────────────────────────────────────────────────────────────────────
 // OpenVPN Section Tests (Issue #67)
 // ─────────────────────────────────────────────────────────────────────────────
@@ -516,6 +568,292 @@ func TestMarkdownBuilder_BuildOpenVPNSection_WithClients(t *testing.T) {
 	}
 }
 
+func TestMarkdownBuilder_BuildLegacyVPNSection_Absent(t *testing.T) {
+	t.Parallel()
+
+	b := builder.NewMarkdownBuilder()
+
+	if output := b.BuildLegacyVPNSection(createTestDocument()); output != "" {
+		t.Errorf("Expected no legacy VPN section when PPTP and L2TP are absent, got %q", output)
+	}
+}
+
+func TestMarkdownBuilder_BuildLegacyVPNSection_WithServers(t *testing.T) {
+	t.Parallel()
+
+	b := builder.NewMarkdownBuilder()
+	data := createTestDocument()
+	data.VPN.PPTP = &common.LegacyRemoteAccessVPN{
+		Enabled:            true,
+		Mode:               "server",
+		LocalAddress:       "10.10.10.1",
+		RemoteAddressStart: "10.10.10.100",
+		RemoteAddressCount: 16,
+		Authentication:     "mschapv2",
+		Users:              []common.LegacyVPNUser{{Name: "alice"}, {Name: "bob"}},
+	}
+	data.VPN.L2TP = &common.LegacyRemoteAccessVPN{Mode: "off"}
+
+	output := b.BuildLegacyVPNSection(data)
+
+	expectedContent := []string{
+		"### Legacy Remote Access VPN",
+		"PPTP",
+		"L2TP",
+		"10.10.10.100 (16 addresses)",
+		"mschapv2",
+		"alice, bob",
+		"PPTP and L2TP are deprecated",
+	}
+
+	for _, content := range expectedContent {
+		if !strings.Contains(output, content) {
+			t.Errorf("Expected legacy VPN section to contain '%s'", content)
+		}
+	}
+}
+
+func TestMarkdownBuilder_BuildZeroTierSection_Absent(t *testing.T) {
+	t.Parallel()
+
+	b := builder.NewMarkdownBuilder()
+
+	if output := b.BuildZeroTierSection(createTestDocument()); output != "" {
+		t.Errorf("Expected no ZeroTier section when ZeroTier is absent, got %q", output)
+	}
+}
+
+func TestMarkdownBuilder_BuildZeroTierSection_WithNetworks(t *testing.T) {
+	t.Parallel()
+
+	b := builder.NewMarkdownBuilder()
+	data := createTestDocument()
+	data.VPN.ZeroTier = &common.ZeroTierConfig{
+		Enabled:           true,
+		AllowDefaultRoute: true,
+		Networks: []common.ZeroTierNetwork{
+			{Enabled: true, NetworkID: "8056c2e21c000001", Description: "Branch mesh"},
+			{NetworkID: "8056c2e21c000002", Description: "Lab"},
+		},
+	}
+
+	output := b.BuildZeroTierSection(data)
+
+	expectedContent := []string{
+		"### ZeroTier",
+		"9993 (default)",
+		"8056c2e21c000001",
+		"Branch mesh",
+		"8056c2e21c000002",
+		"only filtered by pf when rules are added",
+	}
+
+	for _, content := range expectedContent {
+		if !strings.Contains(output, content) {
+			t.Errorf("Expected ZeroTier section to contain '%s'", content)
+		}
+	}
+}
+
+func TestMarkdownBuilder_BuildAliasesSection_Absent(t *testing.T) {
+	t.Parallel()
+
+	b := builder.NewMarkdownBuilder()
+
+	if output := b.BuildAliasesSection(createTestDocument()); output != "" {
+		t.Errorf("Expected no alias section when no aliases are defined, got %q", output)
+	}
+}
+
+func TestMarkdownBuilder_BuildAliasesSection_ExternallyResolved(t *testing.T) {
+	t.Parallel()
+
+	b := builder.NewMarkdownBuilder()
+	data := createTestDocument()
+	data.NamedObjects = common.NamedObjects{
+		"threat_feed": {
+			Name:        "threat_feed",
+			Type:        common.NamedObjectTypeURLTable,
+			URLs:        []string{"https://feeds.example.com/drop.txt"},
+			Description: "Spamhaus DROP",
+		},
+		"blocked_countries": {
+			Name:      "blocked_countries",
+			Type:      common.NamedObjectTypeGeoIP,
+			Countries: []string{"KP", "RU"},
+		},
+		"web_servers": {
+			Name:    "web_servers",
+			Type:    common.NamedObjectTypeHost,
+			Members: []string{"10.0.0.10", "10.0.0.11"},
+		},
+	}
+
+	output := b.BuildAliasesSection(data)
+
+	expectedContent := []string{
+		"#### Firewall Aliases",
+		"https://feeds.example.com/drop.txt - externally resolved (1 entry unknown offline)",
+		"Spamhaus DROP",
+		"KP, RU - externally resolved (2 entries unknown offline)",
+		"10.0.0.10, 10.0.0.11",
+	}
+
+	for _, content := range expectedContent {
+		if !strings.Contains(output, content) {
+			t.Errorf("Expected alias section to contain '%s', got:\n%s", content, output)
+		}
+	}
+
+	if strings.Index(output, "blocked_countries") > strings.Index(output, "web_servers") {
+		t.Error("Expected aliases in name order")
+	}
+}
+
+func TestMarkdownBuilder_BuildQueueStatsSection_Absent(t *testing.T) {
+	t.Parallel()
+
+	b := builder.NewMarkdownBuilder()
+
+	if output := b.BuildQueueStatsSection(createTestDocument()); output != "" {
+		t.Errorf("Expected no queue statistics section when the export carries none, got %q", output)
+	}
+}
+
+func TestMarkdownBuilder_BuildQueueStatsSection_WithQueues(t *testing.T) {
+	t.Parallel()
+
+	b := builder.NewMarkdownBuilder()
+	data := createTestDocument()
+	data.QueueStats = &common.QueueStats{
+		Timestamp: "2024-05-01T12:00:00Z",
+		Queues: []common.QueueEntry{
+			{Name: "qVoIP", Interface: "wan", Packets: 182734, Bytes: 24876213, DroppedPackets: 12, DroppedBytes: 1836},
+		},
+	}
+
+	output := b.BuildQueueStatsSection(data)
+
+	expectedContent := []string{
+		"### Queue Statistics",
+		"Counters captured at 2024-05-01T12:00:00Z.",
+		"Dropped Packets",
+		"qVoIP",
+		"182734",
+		"23.7 MiB",
+		"1.8 KiB",
+	}
+
+	for _, content := range expectedContent {
+		if !strings.Contains(output, content) {
+			t.Errorf("Expected queue statistics section to contain '%s'", content)
+		}
+	}
+
+	if services := b.BuildServicesSection(data); !strings.Contains(services, "### Queue Statistics") {
+		t.Error("Expected the services section to include queue statistics")
+	}
+}
+
+func TestMarkdownBuilder_BuildMonitSection(t *testing.T) {
+	t.Parallel()
+
+	b := builder.NewMarkdownBuilder()
+	data := createTestDocument()
+
+	if output := b.BuildMonitSection(data); output != "" {
+		t.Errorf("Expected no Monit section without Monit configured, got %q", output)
+	}
+
+	data.Monit = &common.MonitConfig{
+		Enabled:    true,
+		Interval:   "120",
+		MailServer: "smtp.example.com",
+		MailPort:   "587",
+		SSLEnabled: true,
+		Alerts: []common.MonitAlert{
+			{Enabled: true, Recipient: "noc@example.com"},
+			{Recipient: "pager@example.net", NotOn: "1", Events: "checksum,nonexist", Description: "Pager"},
+		},
+		Services: []common.MonitServiceEntry{
+			{Enabled: true, Name: "sshd", Type: "process", PIDFile: "/var/run/sshd.pid", Tests: "test-1, test-9"},
+			{Name: "RootFs", Type: "filesystem", Path: "/"},
+		},
+		Tests: []common.MonitTest{
+			{UUID: "test-1", Name: "MemoryUsage", Type: "SystemResource", Condition: "memory usage is greater than 75%", Action: "restart"},
+		},
+	}
+
+	output := b.BuildMonitSection(data)
+
+	expectedContent := []string{
+		"### Monit",
+		"**Polling Interval**: 120 s",
+		"**Mail Server**: smtp.example.com:587 (TLS: ✓)",
+		"#### Monit Alerts",
+		"| noc@example.com | ✓ | all |  |",
+		"| pager@example.net | ✗ | all except checksum, nonexist | Pager |",
+		"#### Monitored Services",
+		"| sshd | ✓ | process | /var/run/sshd.pid | MemoryUsage, test-9 |",
+		"| RootFs | ✗ | filesystem | / | - |",
+		"#### Monit Tests",
+		"| MemoryUsage | SystemResource | memory usage is greater than 75% | restart |",
+	}
+
+	for _, content := range expectedContent {
+		if !strings.Contains(output, content) {
+			t.Errorf("Expected Monit section to contain %q, got:\n%s", content, output)
+		}
+	}
+
+	if services := b.BuildServicesSection(data); !strings.Contains(services, "### Monit") {
+		t.Error("Expected the services section to include Monit")
+	}
+}
+
+func TestMarkdownBuilder_BuildTrafficShaperPipeSection(t *testing.T) {
+	t.Parallel()
+
+	b := builder.NewMarkdownBuilder()
+	data := createTestDocument()
+
+	if output := b.BuildTrafficShaperPipeSection(data); output != "" {
+		t.Errorf("Expected no traffic shaper section without pipes or queues, got %q", output)
+	}
+
+	data.TrafficShaper = &common.TrafficShaperConfig{
+		PipeEntries: []common.TrafficShaperPipe{
+			{UUID: "pipe-1", Number: "10000", Enabled: true, Bandwidth: "100", BandwidthMetric: "Mbit", Delay: "20", Mask: "src-ip", Description: "Download"},
+			{UUID: "pipe-2", Number: "10001", Bandwidth: "10", BandwidthMetric: "Mbit", Mask: "none"},
+		},
+		QueueEntries: []common.TrafficShaperQueue{
+			{UUID: "queue-1", Number: "10000", Enabled: true, Pipe: "pipe-1", Weight: "75", Mask: "none", Description: "VoIP"},
+			{UUID: "queue-2", Number: "10001", Enabled: true, Pipe: "pipe-9", Weight: "25", Mask: "none"},
+		},
+	}
+
+	output := b.BuildTrafficShaperPipeSection(data)
+
+	expectedContent := []string{
+		"### Traffic Shaper Pipes",
+		"| Download | ✓ | 100 Mbit | 20 ms | src-ip | VoIP |",
+		"| Pipe 10001 | ✗ | 10 Mbit | - | none | - |",
+		"### Traffic Shaper Queues",
+		"| VoIP | ✓ | Download | 75 | none |",
+		"| Queue 10001 | ✓ | pipe-9 | 25 | none |",
+	}
+
+	for _, content := range expectedContent {
+		if !strings.Contains(output, content) {
+			t.Errorf("Expected traffic shaper section to contain %q, got:\n%s", content, output)
+		}
+	}
+
+	if services := b.BuildServicesSection(data); !strings.Contains(services, "### Traffic Shaper Pipes") {
+		t.Error("Expected the services section to include the traffic shaper pipes")
+	}
+}
+
 // ─────────────────────────────────────────────────────────────────────────────
 // High Availability Section Tests (Issue #67)
 // ─────────────────────────────────────────────────────────────────────────────
@@ -618,6 +956,50 @@ func TestMarkdownBuilder_WriteComprehensiveReport_NewSections(t *testing.T) {
 	}
 }
 
+func TestMarkdownBuilder_CosmeticSectionsLine(t *testing.T) {
+	t.Parallel()
+
+	const want = "**Cosmetic/Telemetry sections present**: widgets, theme, rrddata"
+
+	b := builder.NewMarkdownBuilder()
+	data := createTestDocument()
+	data.CosmeticSections = []string{"widgets", "theme", "rrddata"}
+
+	comprehensive, err := b.BuildComprehensiveReport(data)
+	if err != nil {
+		t.Fatalf("BuildComprehensiveReport returned error: %v", err)
+	}
+	if !strings.Contains(comprehensive, want) {
+		t.Errorf("Comprehensive report missing %q", want)
+	}
+
+	var buf bytes.Buffer
+	if err := b.WriteComprehensiveReport(&buf, data); err != nil {
+		t.Fatalf("WriteComprehensiveReport returned error: %v", err)
+	}
+	if !strings.Contains(buf.String(), want) {
+		t.Errorf("Streamed comprehensive report missing %q", want)
+	}
+
+	standard, err := b.BuildStandardReport(data)
+	if err != nil {
+		t.Fatalf("BuildStandardReport returned error: %v", err)
+	}
+	if strings.Contains(standard, "Cosmetic/Telemetry") {
+		t.Error("Standard report should not list cosmetic sections")
+	}
+
+	data.CosmeticSections = nil
+
+	comprehensive, err = b.BuildComprehensiveReport(data)
+	if err != nil {
+		t.Fatalf("BuildComprehensiveReport returned error: %v", err)
+	}
+	if strings.Contains(comprehensive, "Cosmetic/Telemetry") {
+		t.Error("Comprehensive report should omit the line when no cosmetic sections are present")
+	}
+}
+
 // ─────────────────────────────────────────────────────────────────────────────
 // Markdown Syntax Validation Tests (goldmark round-trip)
 // ─────────────────────────────────────────────────────────────────────────────
@@ -838,53 +1220,53 @@ func TestMarkdownBuilder_ValidateTableMethods(t *testing.T) {
 
 	tests := []struct {
 		name  string
-		write func(md *markdown.Markdown)
+		write func(md *document.Document)
 	}{
 		{
 			name: "WriteFirewallRulesTable",
-			write: func(md *markdown.Markdown) {
+			write: func(md *document.Document) {
 				b.WriteFirewallRulesTable(md, data.FirewallRules)
 			},
 		},
 		{
 			name: "WriteInterfaceTable",
-			write: func(md *markdown.Markdown) {
+			write: func(md *document.Document) {
 				b.WriteInterfaceTable(md, data.Interfaces)
 			},
 		},
 		{
 			name: "WriteUserTable",
-			write: func(md *markdown.Markdown) {
+			write: func(md *document.Document) {
 				b.WriteUserTable(md, data.Users)
 			},
 		},
 		{
 			name: "WriteGroupTable",
-			write: func(md *markdown.Markdown) {
+			write: func(md *document.Document) {
 				b.WriteGroupTable(md, data.Groups)
 			},
 		},
 		{
 			name: "WriteVLANTable",
-			write: func(md *markdown.Markdown) {
+			write: func(md *document.Document) {
 				b.WriteVLANTable(md, data.VLANs)
 			},
 		},
 		{
 			name: "WriteStaticRoutesTable",
-			write: func(md *markdown.Markdown) {
+			write: func(md *document.Document) {
 				b.WriteStaticRoutesTable(md, data.Routing.StaticRoutes)
 			},
 		},
 		{
 			name: "WriteOutboundNATTable_Empty",
-			write: func(md *markdown.Markdown) {
+			write: func(md *document.Document) {
 				b.WriteOutboundNATTable(md, nil)
 			},
 		},
 		{
 			name: "WriteInboundNATTable_Empty",
-			write: func(md *markdown.Markdown) {
+			write: func(md *document.Document) {
 				b.WriteInboundNATTable(md, nil)
 			},
 		},
@@ -894,10 +1276,9 @@ func TestMarkdownBuilder_ValidateTableMethods(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			var buf bytes.Buffer
-			md := markdown.NewMarkdown(&buf)
+			md := document.New()
 			tt.write(md)
-			output := md.String()
+			output := document.MarkdownRenderer{}.Render(md)
 
 			if output == "" {
 				t.Fatalf("%s produced empty output", tt.name)
@@ -1003,28 +1384,23 @@ func TestMarkdownBuilder_ValidateEmptyData(t *testing.T) {
 
 // createTestDocument creates a minimal test document for testing.
 func createTestDocument() *common.CommonDevice {
-	return &common.CommonDevice{
-		DeviceType: common.DeviceTypeOPNsense,
-		Version:    "1.0",
-		System: common.System{
-			Hostname: "test-firewall",
-			Domain:   "example.com",
-			Firmware: common.Firmware{Version: "24.1"},
-		},
-		Users:  []common.User{{Name: "admin", Scope: "system"}},
-		Groups: []common.Group{{Name: "admins", Scope: "system"}},
-		Interfaces: []common.Interface{
-			{Name: "lan", PhysicalIf: "em0", Enabled: true, IPAddress: "192.168.1.1", Subnet: "24"},
-			{Name: "wan", PhysicalIf: "em1", Enabled: true},
-		},
-		DHCP: []common.DHCPScope{
-			{Interface: "lan", Enabled: true, Range: common.DHCPRange{From: "192.168.1.100", To: "192.168.1.200"}},
-		},
-		FirewallRules: []common.FirewallRule{
-			{Type: common.RuleTypePass, Interfaces: []string{"lan"}, Protocol: "tcp", Description: "Allow LAN"},
-		},
-		NAT: common.NATConfig{OutboundMode: common.OutboundAutomatic},
-	}
+	return testutil.NewDeviceBuilder().
+		WithVersion("1.0").
+		WithFirmwareVersion("24.1").
+		WithUser(common.User{Name: "admin", Scope: "system"}).
+		WithGroup(common.Group{Name: "admins", Scope: "system"}).
+		WithInterfaceConfig(common.Interface{
+			Name: "lan", PhysicalIf: "em0", Enabled: true, IPAddress: "192.168.1.1", Subnet: "24",
+		}).
+		WithInterfaceConfig(common.Interface{Name: "wan", PhysicalIf: "em1", Enabled: true}).
+		WithDHCPScope(common.DHCPScope{
+			Interface: "lan", Enabled: true, Range: common.DHCPRange{From: "192.168.1.100", To: "192.168.1.200"},
+		}).
+		WithFirewallRule(common.FirewallRule{
+			Type: common.RuleTypePass, Interfaces: []string{"lan"}, Protocol: "tcp", Description: "Allow LAN",
+		}).
+		WithOutboundNATMode(common.OutboundAutomatic).
+		Build()
 }
 
 // createTestDocumentWithIPsec creates a test document with IPsec configuration.
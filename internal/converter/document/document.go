@@ -0,0 +1,254 @@
+// Package document provides a format-neutral report tree and the renderers
+// that turn it into output text.
+//
+// Report builders assemble a [Document] from sections, paragraphs, tables,
+// lists, admonitions, and code blocks without committing to an output
+// syntax; a [Renderer] then walks the tree. [MarkdownRenderer] reproduces the
+// markdown the builders have always emitted, and [PlainTextRenderer] renders
+// the same tree as unformatted text. [PDFRenderer] writes the tree as a PDF
+// file.
+//
+// Text fields may carry inline markdown produced by the markdown package
+// helpers (bold, italics, code spans, links) and the table-cell escapes of
+// formatters.EscapeTableContent. Renderers that cannot express inline
+// formatting strip it.
+package document
+
+import (
+	"fmt"
+
+	"github.com/nao1215/markdown"
+)
+
+// Node is an element of a report tree. The concrete node types are
+// [*Section], [Paragraph], [Table], [List], [Admonition], [CodeBlock],
+// [HorizontalRule], [Break], and [Comment].
+type Node interface {
+	node()
+}
+
+// Section is a heading and the nodes that follow it up to the next heading
+// of the same or a higher level. Level is 1 for a top-level title through 6.
+type Section struct {
+	Level    int
+	Title    string
+	Children []Node
+}
+
+// Paragraph is a block of text.
+type Paragraph struct {
+	Text string
+}
+
+// Table is a table with a header row. Rows whose length differs from the
+// header are invalid; renderers drop the whole table, as the markdown
+// package does.
+type Table struct {
+	markdown.TableSet
+}
+
+// List is a bullet list, or a numbered list when Ordered is set.
+type List struct {
+	Items   []string
+	Ordered bool
+}
+
+// AdmonitionKind classifies an [Admonition].
+type AdmonitionKind string
+
+// Admonition kinds.
+const (
+	AdmonitionNote    AdmonitionKind = "note"
+	AdmonitionWarning AdmonitionKind = "warning"
+	AdmonitionTip     AdmonitionKind = "tip"
+)
+
+// Admonition is a call-out block such as a note or warning.
+type Admonition struct {
+	Kind AdmonitionKind
+	Text string
+}
+
+// CodeBlock is preformatted text, with an optional language hint.
+type CodeBlock struct {
+	Language string
+	Code     string
+}
+
+// HorizontalRule is a thematic break between blocks.
+type HorizontalRule struct{}
+
+// Break is an explicit blank-line separator between blocks. Renderers that
+// manage block spacing themselves ignore it.
+type Break struct{}
+
+// Comment is text for machine consumers only, such as export metadata.
+// Renderers for human-readable output omit it.
+type Comment struct {
+	Text string
+}
+
+func (*Section) node()       {}
+func (Paragraph) node()      {}
+func (Table) node()          {}
+func (List) node()           {}
+func (Admonition) node()     {}
+func (CodeBlock) node()      {}
+func (HorizontalRule) node() {}
+func (Break) node()          {}
+func (Comment) node()        {}
+
+// Inline anchor markup produced by [Anchor].
+const (
+	anchorPrefix = `<a id="`
+	anchorSuffix = `"></a>`
+)
+
+// Anchor returns an inline link target named id for text that in-document
+// links ("#id") point at, such as a table row. Markdown carries it as an
+// empty HTML anchor; renderers without link targets drop it. id must not
+// contain '"'.
+func Anchor(id string) string {
+	return anchorPrefix + id + anchorSuffix
+}
+
+// Document is a report tree under construction. Nodes are appended in output
+// order: each heading opens a [Section] nested under the nearest open section
+// of a lower level, and every other node is added to the innermost open
+// section. The append methods return the Document for chaining.
+//
+// The zero value is an empty document ready for use. A Document is not safe
+// for concurrent use.
+type Document struct {
+	root Section
+	open []*Section
+}
+
+// New returns an empty Document.
+func New() *Document {
+	return &Document{}
+}
+
+// Nodes returns the top-level nodes of the document: sections and any nodes
+// appended before the first heading.
+func (d *Document) Nodes() []Node {
+	return d.root.Children
+}
+
+// current returns the innermost open section, or the document root.
+func (d *Document) current() *Section {
+	if len(d.open) == 0 {
+		return &d.root
+	}
+
+	return d.open[len(d.open)-1]
+}
+
+// add appends n to the innermost open section.
+func (d *Document) add(n Node) *Document {
+	cur := d.current()
+	cur.Children = append(cur.Children, n)
+
+	return d
+}
+
+// Heading opens a new section at level, closing any open sections at the
+// same or a deeper level.
+func (d *Document) Heading(level int, title string) *Document {
+	for len(d.open) > 0 && d.open[len(d.open)-1].Level >= level {
+		d.open = d.open[:len(d.open)-1]
+	}
+
+	section := &Section{Level: level, Title: title}
+	d.add(section)
+	d.open = append(d.open, section)
+
+	return d
+}
+
+// H1 opens a level 1 section.
+func (d *Document) H1(title string) *Document { return d.Heading(1, title) }
+
+// H2 opens a level 2 section.
+func (d *Document) H2(title string) *Document { return d.Heading(2, title) }
+
+// H3 opens a level 3 section.
+func (d *Document) H3(title string) *Document { return d.Heading(3, title) }
+
+// H4 opens a level 4 section.
+func (d *Document) H4(title string) *Document { return d.Heading(4, title) }
+
+// Paragraph appends a paragraph.
+func (d *Document) Paragraph(text string) *Document {
+	return d.add(Paragraph{Text: text})
+}
+
+// Paragraphf appends a paragraph formatted with fmt.Sprintf.
+func (d *Document) Paragraphf(format string, args ...any) *Document {
+	return d.Paragraph(fmt.Sprintf(format, args...))
+}
+
+// Table appends a table.
+func (d *Document) Table(set markdown.TableSet) *Document {
+	return d.add(Table{TableSet: set})
+}
+
+// BulletList appends a bullet list with one entry per item.
+func (d *Document) BulletList(items ...string) *Document {
+	return d.add(List{Items: items})
+}
+
+// OrderedList appends a numbered list with one entry per item.
+func (d *Document) OrderedList(items ...string) *Document {
+	return d.add(List{Items: items, Ordered: true})
+}
+
+// Note appends a note admonition.
+func (d *Document) Note(text string) *Document {
+	return d.add(Admonition{Kind: AdmonitionNote, Text: text})
+}
+
+// Warning appends a warning admonition.
+func (d *Document) Warning(text string) *Document {
+	return d.add(Admonition{Kind: AdmonitionWarning, Text: text})
+}
+
+// Tip appends a tip admonition.
+func (d *Document) Tip(text string) *Document {
+	return d.add(Admonition{Kind: AdmonitionTip, Text: text})
+}
+
+// CodeBlock appends a code block.
+func (d *Document) CodeBlock(language, code string) *Document {
+	return d.add(CodeBlock{Language: language, Code: code})
+}
+
+// HorizontalRule appends a horizontal rule.
+func (d *Document) HorizontalRule() *Document {
+	return d.add(HorizontalRule{})
+}
+
+// Break appends a blank-line separator.
+func (d *Document) Break() *Document {
+	return d.add(Break{})
+}
+
+// Comment appends a comment.
+func (d *Document) Comment(text string) *Document {
+	return d.add(Comment{Text: text})
+}
+
+// Append adds the top-level nodes of other to the innermost open section, in
+// order. The appended sections are not opened in d, so later headings nest as
+// if other had not been appended. other must not be modified afterwards.
+func (d *Document) Append(other *Document) *Document {
+	cur := d.current()
+	cur.Children = append(cur.Children, other.Nodes()...)
+
+	return d
+}
+
+// Renderer turns a Document into output text.
+type Renderer interface {
+	Render(doc *Document) string
+}
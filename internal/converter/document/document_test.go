@@ -0,0 +1,102 @@
+package document
+
+import (
+	"testing"
+
+	"github.com/nao1215/markdown"
+)
+
+func TestDocument_HeadingsNestSections(t *testing.T) {
+	t.Parallel()
+
+	doc := New().
+		Comment("meta").
+		H1("Report").
+		H2("System").
+		Paragraph("basics").
+		H3("Users").
+		Table(markdown.TableSet{Header: []string{"Name"}, Rows: [][]string{{"root"}}}).
+		H2("Network").
+		H4("Deep").
+		H3("Interfaces").
+		BulletList("lan", "wan")
+
+	nodes := doc.Nodes()
+	if len(nodes) != 2 {
+		t.Fatalf("top-level nodes = %d, want 2 (comment, H1)", len(nodes))
+	}
+	if _, ok := nodes[0].(Comment); !ok {
+		t.Errorf("nodes[0] = %T, want Comment", nodes[0])
+	}
+
+	report := sectionAt(t, nodes, 1, 1, "Report")
+	if len(report.Children) != 2 {
+		t.Fatalf("H1 children = %d, want 2 sections", len(report.Children))
+	}
+
+	system := sectionAt(t, report.Children, 0, 2, "System")
+	if _, ok := system.Children[0].(Paragraph); !ok {
+		t.Errorf("System children[0] = %T, want Paragraph", system.Children[0])
+	}
+	users := sectionAt(t, system.Children, 1, 3, "Users")
+	if _, ok := users.Children[0].(Table); !ok {
+		t.Errorf("Users children[0] = %T, want Table", users.Children[0])
+	}
+
+	network := sectionAt(t, report.Children, 1, 2, "Network")
+	if len(network.Children) != 2 {
+		t.Fatalf("Network children = %d, want 2: a level 3 heading closes the open level 4 section",
+			len(network.Children))
+	}
+	sectionAt(t, network.Children, 0, 4, "Deep")
+	interfaces := sectionAt(t, network.Children, 1, 3, "Interfaces")
+	if list, ok := interfaces.Children[0].(List); !ok || len(list.Items) != 2 || list.Ordered {
+		t.Errorf("Interfaces children[0] = %#v, want two-item bullet List", interfaces.Children[0])
+	}
+}
+
+func TestDocument_Append(t *testing.T) {
+	t.Parallel()
+
+	part := New().H2("System").Paragraph("basics").H3("Users")
+	doc := New().H1("Report").Append(part).Paragraph("after")
+
+	report := sectionAt(t, doc.Nodes(), 0, 1, "Report")
+	if len(report.Children) != 2 {
+		t.Fatalf("H1 children = %d, want 2 (appended section, paragraph)", len(report.Children))
+	}
+	system := sectionAt(t, report.Children, 0, 2, "System")
+	sectionAt(t, system.Children, 1, 3, "Users")
+	if _, ok := report.Children[1].(Paragraph); !ok {
+		t.Errorf("H1 children[1] = %T, want Paragraph outside the appended sections", report.Children[1])
+	}
+}
+
+func TestDocument_ZeroValue(t *testing.T) {
+	t.Parallel()
+
+	var doc Document
+	doc.Paragraph("text")
+
+	if got := len(doc.Nodes()); got != 1 {
+		t.Errorf("Nodes() length = %d, want 1", got)
+	}
+}
+
+// sectionAt returns nodes[i] as a *Section after checking its level and title.
+func sectionAt(t *testing.T, nodes []Node, i, level int, title string) *Section {
+	t.Helper()
+
+	if i >= len(nodes) {
+		t.Fatalf("no node at index %d (len %d)", i, len(nodes))
+	}
+	section, ok := nodes[i].(*Section)
+	if !ok {
+		t.Fatalf("nodes[%d] = %T, want *Section", i, nodes[i])
+	}
+	if section.Level != level || section.Title != title {
+		t.Fatalf("nodes[%d] = H%d %q, want H%d %q", i, section.Level, section.Title, level, title)
+	}
+
+	return section
+}
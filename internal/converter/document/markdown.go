@@ -0,0 +1,75 @@
+package document
+
+import (
+	"bytes"
+
+	"github.com/nao1215/markdown"
+)
+
+// MarkdownRenderer renders a Document as GitHub-flavored markdown using the
+// markdown package. Each node maps to one markdown package call, so the
+// output is identical to writing the same calls directly: blocks are joined
+// by single newlines, [Break] emits the package's line-feed spacer, and
+// admonitions become GitHub alert blockquotes.
+type MarkdownRenderer struct{}
+
+// Compile-time assertion that MarkdownRenderer satisfies Renderer.
+var _ Renderer = MarkdownRenderer{}
+
+// Render implements Renderer.
+func (MarkdownRenderer) Render(doc *Document) string {
+	var buf bytes.Buffer
+	md := markdown.NewMarkdown(&buf)
+	writeMarkdownNodes(md, doc.Nodes())
+
+	return md.String()
+}
+
+// writeMarkdownNodes appends nodes to md in order.
+func writeMarkdownNodes(md *markdown.Markdown, nodes []Node) {
+	for _, n := range nodes {
+		switch n := n.(type) {
+		case *Section:
+			writeMarkdownHeading(md, n.Level, n.Title)
+			writeMarkdownNodes(md, n.Children)
+		case Paragraph:
+			md.PlainText(n.Text)
+		case Table:
+			md.Table(n.TableSet)
+		case List:
+			if n.Ordered {
+				md.OrderedList(n.Items...)
+			} else {
+				md.BulletList(n.Items...)
+			}
+		case Admonition:
+			writeMarkdownAdmonition(md, n)
+		case CodeBlock:
+			md.CodeBlocks(markdown.SyntaxHighlight(n.Language), n.Code)
+		case HorizontalRule:
+			md.HorizontalRule()
+		case Break:
+			md.LF()
+		case Comment:
+			md.PlainText("<!-- " + n.Text + " -->")
+		}
+	}
+}
+
+// writeMarkdownHeading appends an ATX heading, clamping level to 1 through 6.
+func writeMarkdownHeading(md *markdown.Markdown, level int, title string) {
+	headings := []func(string) *markdown.Markdown{md.H1, md.H2, md.H3, md.H4, md.H5, md.H6}
+	headings[min(max(level, 1), len(headings))-1](title)
+}
+
+// writeMarkdownAdmonition appends a GitHub alert for a.
+func writeMarkdownAdmonition(md *markdown.Markdown, a Admonition) {
+	switch a.Kind {
+	case AdmonitionWarning:
+		md.Warning(a.Text)
+	case AdmonitionTip:
+		md.Tip(a.Text)
+	default:
+		md.Note(a.Text)
+	}
+}
@@ -0,0 +1,72 @@
+package document
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/nao1215/markdown"
+)
+
+func TestMarkdownRenderer_MatchesMarkdownPackage(t *testing.T) {
+	t.Parallel()
+
+	table := markdown.TableSet{
+		Header: []string{"Name", "Value"},
+		Rows:   [][]string{{"a", "1"}, {"b\\|c", "2"}},
+	}
+	invalid := markdown.TableSet{Header: []string{"A", "B"}, Rows: [][]string{{"only one"}}}
+
+	doc := New().
+		Comment("_meta: {}").
+		H1("Title").
+		H2("Section").
+		Paragraphf("%s: %s", markdown.Bold("Key"), "value").
+		Break().
+		Table(table).
+		Table(invalid).
+		H3("Lists").
+		BulletList("one", "two").
+		OrderedList("first", "second").
+		H4("Callouts").
+		Note("note text").
+		Warning("warning text").
+		Tip("tip text").
+		CodeBlock("sh", "echo hi").
+		HorizontalRule().
+		Heading(6, "Six").
+		Heading(9, "Clamped")
+
+	var buf bytes.Buffer
+	want := markdown.NewMarkdown(&buf).
+		PlainText("<!-- _meta: {} -->").
+		H1("Title").
+		H2("Section").
+		PlainTextf("%s: %s", markdown.Bold("Key"), "value").
+		LF().
+		Table(table).
+		Table(invalid).
+		H3("Lists").
+		BulletList("one", "two").
+		OrderedList("first", "second").
+		H4("Callouts").
+		Note("note text").
+		Warning("warning text").
+		Tip("tip text").
+		CodeBlocks("sh", "echo hi").
+		HorizontalRule().
+		H6("Six").
+		H6("Clamped").
+		String()
+
+	if got := (MarkdownRenderer{}).Render(doc); got != want {
+		t.Errorf("Render() mismatch\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestMarkdownRenderer_Empty(t *testing.T) {
+	t.Parallel()
+
+	if got := (MarkdownRenderer{}).Render(New()); got != "" {
+		t.Errorf("Render(empty) = %q, want empty string", got)
+	}
+}
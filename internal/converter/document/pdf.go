@@ -0,0 +1,743 @@
+package document
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/go-pdf/fpdf"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/gobolditalic"
+	"golang.org/x/image/font/gofont/goitalic"
+	"golang.org/x/image/font/gofont/gomono"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/sfnt"
+)
+
+// PDF page layout, in millimetres unless noted.
+const (
+	pdfMargin        = 15.0
+	pdfFooterHeight  = 12.0
+	pdfLineHeight    = 5.0
+	pdfBlockGap      = 2.5
+	pdfBodyFontSize  = 10.0 // points
+	pdfCodeFontSize  = 8.5  // points
+	pdfTableFontSize = 7.5  // points
+	pdfTableLine     = 3.6
+	pdfCellPadding   = 1.2
+	pdfBoxPadding    = 2.5
+	pdfListIndent    = 6.0
+
+	// pdfHeadingKeep is the space a heading needs below it on the current
+	// page; a heading closer to the page bottom starts a new page so it is
+	// never separated from its first block.
+	pdfHeadingKeep = 25.0
+
+	// pdfMinColumnWidth is the narrowest a table column is drawn, unless its
+	// content is narrower. A table whose columns cannot all get their minimum
+	// width is split into column groups.
+	pdfMinColumnWidth = 16.0
+
+	// pdfMaxColumnWidth caps the natural width of a column, so one long cell
+	// does not starve the others of space.
+	pdfMaxColumnWidth = 70.0
+
+	pdfPageNumberAlias = "{nb}"
+
+	// pdfFont and pdfMonoFont are the families the embedded Go fonts are
+	// registered under.
+	pdfFont     = "go"
+	pdfMonoFont = "gomono"
+
+	// pdfMissingGlyph replaces characters the embedded fonts cannot draw.
+	pdfMissingGlyph = '?'
+)
+
+// pdfHeadingSizes holds the font size in points of each heading level,
+// starting at level 1. Deeper levels use the last size.
+var pdfHeadingSizes = []float64{18, 15, 12.5, 11} //nolint:gochecknoglobals // static lookup table
+
+// pdfColor is an RGB fill or text color.
+type pdfColor struct{ r, g, b int }
+
+// Admonition box and table colors.
+var (
+	pdfNoteFill    = pdfColor{225, 236, 248} //nolint:gochecknoglobals // static color
+	pdfNoteBar     = pdfColor{59, 130, 196}  //nolint:gochecknoglobals // static color
+	pdfWarningFill = pdfColor{253, 240, 205} //nolint:gochecknoglobals // static color
+	pdfWarningBar  = pdfColor{217, 140, 20}  //nolint:gochecknoglobals // static color
+	pdfTipFill     = pdfColor{222, 244, 226} //nolint:gochecknoglobals // static color
+	pdfTipBar      = pdfColor{46, 150, 80}   //nolint:gochecknoglobals // static color
+	pdfCodeFill    = pdfColor{240, 240, 240} //nolint:gochecknoglobals // static color
+	pdfHeaderFill  = pdfColor{220, 224, 230} //nolint:gochecknoglobals // static color
+	pdfMutedText   = pdfColor{110, 110, 110} //nolint:gochecknoglobals // static color
+)
+
+// pdfSymbols replaces the symbols reports use that the embedded fonts have
+// no glyphs for.
+var pdfSymbols = strings.NewReplacer( //nolint:gochecknoglobals // static replacer
+	"️", "",
+	"✓", "Yes",
+	"✗", "No",
+	"✅", "Yes",
+	"❌", "No",
+	"⚠", "(!)",
+	"ℹ", "(i)",
+	"⬆", "^",
+	"⬇", "v",
+	"⇒", "=>",
+	"🔴 ", "",
+	"🟠 ", "",
+	"🟡 ", "",
+	"🟢 ", "",
+	"⚪ ", "",
+	"🔴", "",
+	"🟠", "",
+	"🟡", "",
+	"🟢", "",
+	"⚪", "",
+)
+
+// pdfGlyphs parses the regular Go font once, to look up which characters
+// the embedded fonts can draw. The other styles cover the same characters.
+var pdfGlyphs = sync.OnceValues(func() (*sfnt.Font, error) { //nolint:gochecknoglobals // parsed once
+	return sfnt.Parse(goregular.TTF)
+})
+
+// PDFRenderer renders a Document as a PDF file set in the Go fonts, which
+// are embedded in the binary, so no font files are needed at run time and
+// text in any script the fonts cover (Latin, Greek, and Cyrillic) is drawn
+// as written. Headings become entries in the PDF
+// outline (bookmarks), tables repeat their header row after a page break,
+// tables too wide for the page are split into column groups that each repeat
+// the first column, code spans and code blocks are set in a monospace font,
+// and admonitions are drawn as shaded boxes. Every page has a footer with the
+// generation time and "Page N of M".
+//
+// Inline markdown in text fields is rendered as bold, italic, and monospace
+// runs; links keep their text, and the target of links that leave the
+// document in parentheses. Symbols the fonts have no glyph for are replaced
+// with an ASCII equivalent where one is known, and with "?" otherwise.
+// [Break] and [Comment] nodes are omitted.
+//
+// PDFRenderer writes binary output, so it does not implement [Renderer].
+type PDFRenderer struct {
+	// Title is the PDF document title shown by viewers.
+	Title string
+	// Generated is the time printed in the page footer and recorded as the
+	// creation date; the zero value uses the time of rendering.
+	Generated time.Time
+}
+
+// Render writes doc to w as a PDF file.
+func (r PDFRenderer) Render(w io.Writer, doc *Document) error {
+	if err := r.draw(doc).Output(w); err != nil {
+		return fmt.Errorf("failed to render PDF: %w", err)
+	}
+
+	return nil
+}
+
+// draw lays out doc on a new PDF. Errors are recorded on the returned PDF
+// and reported by its Output method.
+func (r PDFRenderer) draw(doc *Document) *fpdf.Fpdf {
+	generated := r.Generated
+	if generated.IsZero() {
+		generated = time.Now()
+	}
+
+	pdf := fpdf.New("P", "mm", "A4", "")
+	// fpdf writes into the font data while subsetting it, so each PDF gets
+	// its own copy rather than the shared package variables.
+	pdf.AddUTF8FontFromBytes(pdfFont, "", bytes.Clone(goregular.TTF))
+	pdf.AddUTF8FontFromBytes(pdfFont, "B", bytes.Clone(gobold.TTF))
+	pdf.AddUTF8FontFromBytes(pdfFont, "I", bytes.Clone(goitalic.TTF))
+	pdf.AddUTF8FontFromBytes(pdfFont, "BI", bytes.Clone(gobolditalic.TTF))
+	pdf.AddUTF8FontFromBytes(pdfMonoFont, "", bytes.Clone(gomono.TTF))
+	pdf.SetFont(pdfFont, "", pdfBodyFontSize)
+	pdf.SetMargins(pdfMargin, pdfMargin, pdfMargin)
+	pdf.SetAutoPageBreak(true, pdfMargin+pdfFooterHeight)
+	pdf.SetCellMargin(0) // padding is applied explicitly where wanted
+	pdf.SetCreationDate(generated)
+	pdf.SetModificationDate(generated)
+	pdf.SetCatalogSort(true)
+	pdf.SetCreator("opnDossier", false)
+	pdf.AliasNbPages(pdfPageNumberAlias)
+
+	font, err := pdfGlyphs()
+	if err != nil {
+		pdf.SetError(fmt.Errorf("failed to load the PDF font: %w", err))
+	}
+
+	p := &pdfWriter{pdf: pdf, font: font, glyphs: make(map[rune]bool)}
+	if r.Title != "" {
+		pdf.SetTitle(p.text(r.Title), true)
+	}
+
+	footer := "Generated " + generated.Format(time.RFC3339)
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-(pdfMargin + pdfFooterHeight/2))
+		pdf.SetFont(pdfFont, "", pdfTableFontSize)
+		p.setTextColor(pdfMutedText)
+		pdf.CellFormat(0, pdfLineHeight, p.text(footer), "", 0, "L", false, 0, "")
+		pdf.SetX(pdfMargin)
+		pdf.CellFormat(0, pdfLineHeight,
+			"Page "+strconv.Itoa(pdf.PageNo())+" of "+pdfPageNumberAlias, "", 0, "R", false, 0, "")
+		pdf.SetTextColor(0, 0, 0)
+	})
+
+	pdf.AddPage()
+	p.writeNodes(doc.Nodes())
+
+	return pdf
+}
+
+// pdfWriter walks a Document and draws it on a PDF.
+type pdfWriter struct {
+	pdf *fpdf.Fpdf
+	// font is the regular Go font, and glyphs caches whether it can draw a
+	// character outside ASCII.
+	font   *sfnt.Font
+	glyphs map[rune]bool
+	// outline is the outline level of the last bookmark, or -1 before the
+	// first. Bookmarks may nest at most one level deeper than their
+	// predecessor.
+	outline   int
+	bookmarks bool
+}
+
+// text prepares s for drawing with the embedded fonts.
+func (p *pdfWriter) text(s string) string {
+	return strings.Map(p.glyph, pdfSymbols.Replace(s))
+}
+
+// glyph returns r if the embedded fonts can draw it, and pdfMissingGlyph
+// otherwise.
+func (p *pdfWriter) glyph(r rune) rune {
+	if r < utf8.RuneSelf {
+		return r
+	}
+
+	ok, seen := p.glyphs[r]
+	if !seen {
+		if p.font != nil {
+			index, err := p.font.GlyphIndex(nil, r)
+			ok = err == nil && index != 0
+		}
+		p.glyphs[r] = ok
+	}
+	if !ok {
+		return pdfMissingGlyph
+	}
+
+	return r
+}
+
+func (p *pdfWriter) writeNodes(nodes []Node) {
+	for _, n := range nodes {
+		switch n := n.(type) {
+		case *Section:
+			p.writeHeading(n.Level, n.Title)
+			p.writeNodes(n.Children)
+		case Paragraph:
+			p.writeParagraph(n.Text)
+		case Table:
+			p.writeTable(n)
+		case List:
+			p.writeList(n)
+		case Admonition:
+			p.writeAdmonition(n)
+		case CodeBlock:
+			p.writeCodeBlock(n)
+		case HorizontalRule:
+			p.writeRule()
+		case Break, Comment:
+		}
+	}
+}
+
+// writeHeading draws a heading and adds it to the PDF outline.
+func (p *pdfWriter) writeHeading(level int, title string) {
+	level = max(level, 1)
+	size := pdfHeadingSizes[min(level, len(pdfHeadingSizes))-1]
+	title = p.text(stripInline(title))
+
+	p.keep(pdfHeadingKeep)
+	if p.pdf.GetY() > pdfMargin {
+		p.pdf.Ln(pdfBlockGap)
+	}
+
+	outline := level - 1
+	if !p.bookmarks {
+		outline, p.bookmarks = 0, true
+	}
+	outline = min(outline, p.outline+1)
+	p.outline = outline
+	p.pdf.Bookmark(title, outline, -1)
+
+	p.pdf.SetFont(pdfFont, "B", size)
+	p.pdf.MultiCell(0, size*0.5, title, "", "L", false)
+	if level <= 2 { //nolint:mnd // underline the title and section headings
+		x, y := p.pdf.GetX(), p.pdf.GetY()+0.8
+		pageWidth, _ := p.pdf.GetPageSize()
+		p.pdf.SetDrawColor(180, 180, 180)
+		p.pdf.Line(x, y, pageWidth-pdfMargin, y)
+		p.pdf.SetDrawColor(0, 0, 0)
+		p.pdf.Ln(1.5)
+	}
+	p.pdf.Ln(pdfBlockGap)
+}
+
+// writeParagraph draws a block of text with its inline formatting.
+func (p *pdfWriter) writeParagraph(text string) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return
+	}
+
+	p.writeRuns(text)
+	p.pdf.Ln(pdfLineHeight + pdfBlockGap)
+}
+
+// writeRuns draws text as flowing runs starting at the current position.
+func (p *pdfWriter) writeRuns(text string) {
+	for _, run := range inlineRuns(text) {
+		p.setRunFont(run)
+		p.pdf.Write(pdfLineHeight, p.text(run.text))
+	}
+	p.pdf.SetFont(pdfFont, "", pdfBodyFontSize)
+}
+
+func (p *pdfWriter) setRunFont(run inlineRun) {
+	if run.code {
+		p.pdf.SetFont(pdfMonoFont, "", pdfCodeFontSize+0.5)
+		return
+	}
+
+	style := ""
+	if run.bold {
+		style += "B"
+	}
+	if run.italic {
+		style += "I"
+	}
+	p.pdf.SetFont(pdfFont, style, pdfBodyFontSize)
+}
+
+// writeList draws a bullet or numbered list with wrapped lines indented
+// under the item text.
+func (p *pdfWriter) writeList(l List) {
+	left, _, _, _ := p.pdf.GetMargins()
+	for i, item := range l.Items {
+		marker := "•"
+		if l.Ordered {
+			marker = strconv.Itoa(i+1) + "."
+		}
+
+		p.pdf.SetFont(pdfFont, "", pdfBodyFontSize)
+		p.pdf.SetX(left + pdfListIndent/2)
+		p.pdf.CellFormat(pdfListIndent, pdfLineHeight, marker, "", 0, "L", false, 0, "")
+		p.pdf.SetLeftMargin(left + pdfListIndent*1.5)
+		p.writeRuns(item)
+		p.pdf.SetLeftMargin(left)
+		p.pdf.Ln(pdfLineHeight)
+	}
+	p.pdf.Ln(pdfBlockGap)
+}
+
+// writeAdmonition draws a note, warning, or tip as a shaded box with a
+// colored bar on its left edge.
+func (p *pdfWriter) writeAdmonition(a Admonition) {
+	fill, bar := pdfNoteFill, pdfNoteBar
+	switch a.Kind {
+	case AdmonitionWarning:
+		fill, bar = pdfWarningFill, pdfWarningBar
+	case AdmonitionTip:
+		fill, bar = pdfTipFill, pdfTipBar
+	case AdmonitionNote:
+	}
+
+	label := strings.ToUpper(string(a.Kind)[:1]) + string(a.Kind)[1:]
+	body := p.text(strings.TrimSpace(stripInline(a.Text)))
+
+	width := p.contentWidth()
+	textWidth := width - 2*pdfBoxPadding
+	p.pdf.SetFont(pdfFont, "", pdfBodyFontSize)
+	lines := p.pdf.SplitText(body, textWidth)
+	height := float64(len(lines)+1)*pdfLineHeight + 2*pdfBoxPadding
+	p.keep(min(height, p.pageBodyHeight()))
+
+	x, y := p.pdf.GetX(), p.pdf.GetY()
+	p.setFillColor(fill)
+	p.pdf.Rect(x, y, width, height, "F")
+	p.setFillColor(bar)
+	p.pdf.Rect(x, y, 1, height, "F")
+
+	p.pdf.SetXY(x+pdfBoxPadding, y+pdfBoxPadding)
+	p.pdf.SetFont(pdfFont, "B", pdfBodyFontSize)
+	p.pdf.CellFormat(textWidth, pdfLineHeight, label, "", 2, "L", false, 0, "")
+	p.pdf.SetFont(pdfFont, "", pdfBodyFontSize)
+	p.pdf.MultiCell(textWidth, pdfLineHeight, body, "", "L", false)
+
+	p.pdf.SetY(max(p.pdf.GetY(), y+height))
+	p.pdf.Ln(pdfBlockGap)
+}
+
+// writeCodeBlock draws preformatted text in a monospace font on a shaded
+// background.
+func (p *pdfWriter) writeCodeBlock(c CodeBlock) {
+	code := p.text(strings.TrimRight(c.Code, "\n"))
+	if code == "" {
+		return
+	}
+
+	p.setFillColor(pdfCodeFill)
+	p.pdf.SetFont(pdfMonoFont, "", pdfCodeFontSize)
+	p.pdf.SetCellMargin(pdfBoxPadding)
+	p.pdf.MultiCell(0, pdfTableLine+0.4, code, "", "L", true)
+	p.pdf.SetCellMargin(0)
+	p.pdf.SetFont(pdfFont, "", pdfBodyFontSize)
+	p.pdf.Ln(pdfBlockGap)
+}
+
+func (p *pdfWriter) writeRule() {
+	x, y := p.pdf.GetX(), p.pdf.GetY()+pdfBlockGap
+	pageWidth, _ := p.pdf.GetPageSize()
+	p.pdf.SetDrawColor(150, 150, 150)
+	p.pdf.Line(x, y, pageWidth-pdfMargin, y)
+	p.pdf.SetDrawColor(0, 0, 0)
+	p.pdf.Ln(2 * pdfBlockGap)
+}
+
+// writeTable draws t, splitting it into column groups when its columns do
+// not fit the page at their minimum widths. Tables with rows that do not
+// match the header are dropped, mirroring the markdown package.
+func (p *pdfWriter) writeTable(t Table) {
+	if len(t.Header) == 0 {
+		return
+	}
+	for _, row := range t.Rows {
+		if len(row) != len(t.Header) {
+			return
+		}
+	}
+
+	header := make([]string, len(t.Header))
+	for j, cell := range t.Header {
+		header[j] = p.text(stripInline(cell))
+	}
+	rows := make([][]string, len(t.Rows))
+	for i, row := range t.Rows {
+		rows[i] = make([]string, len(row))
+		for j, cell := range row {
+			rows[i][j] = p.text(stripInline(cell))
+		}
+	}
+
+	columns := p.measureColumns(header, rows)
+	groups := splitColumns(columns.minimum, p.contentWidth())
+	for g, cols := range groups {
+		if len(groups) > 1 {
+			p.pdf.SetFont(pdfFont, "I", pdfTableFontSize)
+			p.pdf.CellFormat(0, pdfLineHeight,
+				fmt.Sprintf("Columns %s of %d (part %d of %d)", columnRange(cols), len(header), g+1, len(groups)),
+				"", 1, "L", false, 0, "")
+		}
+		p.writeTableGroup(header, rows, cols, columns)
+	}
+	p.pdf.SetFont(pdfFont, "", pdfBodyFontSize)
+	p.pdf.Ln(pdfBlockGap)
+}
+
+// tableColumns holds the widths of each column of a table.
+type tableColumns struct {
+	// natural is the width that shows the widest cell on one line, capped at
+	// pdfMaxColumnWidth.
+	natural []float64
+	// minimum is the narrowest the column is drawn: wide enough for its
+	// header on one line and at least pdfMinColumnWidth, but never more than
+	// natural.
+	minimum []float64
+}
+
+func (p *pdfWriter) measureColumns(header []string, rows [][]string) tableColumns {
+	pad := 2*pdfCellPadding + 0.5
+	columns := tableColumns{
+		natural: make([]float64, len(header)),
+		minimum: make([]float64, len(header)),
+	}
+
+	p.pdf.SetFont(pdfFont, "B", pdfTableFontSize)
+	for j, cell := range header {
+		columns.natural[j] = p.pdf.GetStringWidth(cell) + pad
+		columns.minimum[j] = max(columns.natural[j], pdfMinColumnWidth)
+	}
+	p.pdf.SetFont(pdfFont, "", pdfTableFontSize)
+	for _, row := range rows {
+		for j, cell := range row {
+			columns.natural[j] = max(columns.natural[j], p.pdf.GetStringWidth(cell)+pad)
+		}
+	}
+	for j := range header {
+		columns.natural[j] = min(columns.natural[j], pdfMaxColumnWidth)
+		columns.minimum[j] = min(columns.minimum[j], columns.natural[j])
+	}
+
+	return columns
+}
+
+// splitColumns groups the column indexes of a table so each group fits in
+// available width at the columns' minimum widths. Every group after the
+// first repeats column 0, the column that identifies each row. A table that
+// fits whole is a single group.
+func splitColumns(minimum []float64, available float64) [][]int {
+	var groups [][]int
+	current := []int{0}
+	used := minimum[0]
+	for j := 1; j < len(minimum); j++ {
+		if used+minimum[j] > available && len(current) > 1 {
+			groups = append(groups, current)
+			current = []int{0}
+			used = minimum[0]
+		}
+		current = append(current, j)
+		used += minimum[j]
+	}
+
+	return append(groups, current)
+}
+
+// columnRange describes the 1-based column numbers of cols, such as "1, 5-8".
+// cols is column 0 followed by ascending, consecutive column indexes.
+func columnRange(cols []int) string {
+	if len(cols) == 1 {
+		return "1"
+	}
+
+	first, last := cols[1]+1, cols[len(cols)-1]+1
+	span := strconv.Itoa(first)
+	if last > first {
+		span += "-" + strconv.Itoa(last)
+	}
+	if first == 2 { //nolint:mnd // column 2 directly follows the repeated column 1
+		return "1-" + strconv.Itoa(last)
+	}
+
+	return "1, " + span
+}
+
+// writeTableGroup draws the columns cols of a table, repeating the header
+// row at the top of each page the table continues on.
+func (p *pdfWriter) writeTableGroup(header []string, rows [][]string, cols []int, columns tableColumns) {
+	widths := fitWidths(cols, columns, p.contentWidth())
+
+	pick := func(row []string) []string {
+		cells := make([]string, len(cols))
+		for k, j := range cols {
+			cells[k] = row[j]
+		}
+		return cells
+	}
+
+	head := pick(header)
+	p.writeTableRow(head, widths, true)
+	for _, row := range rows {
+		cells := pick(row)
+		if p.needsPage(p.rowHeight(cells, widths, false)) {
+			p.pdf.AddPage()
+			p.writeTableRow(head, widths, true)
+		}
+		p.writeTableRow(cells, widths, false)
+	}
+}
+
+// fitWidths returns the drawn widths of cols: their natural widths stretched
+// to fill available, or, when they do not fit, their minimum widths plus a
+// share of the remaining space proportional to how much wider they want to be.
+func fitWidths(cols []int, columns tableColumns, available float64) []float64 {
+	widths := make([]float64, len(cols))
+	var total, minTotal, extra float64
+	for _, j := range cols {
+		total += columns.natural[j]
+		minTotal += columns.minimum[j]
+		extra += columns.natural[j] - columns.minimum[j]
+	}
+
+	if total <= available {
+		for k, j := range cols {
+			widths[k] = columns.natural[j] * available / total
+		}
+		return widths
+	}
+
+	spare := max(available-minTotal, 0)
+	for k, j := range cols {
+		widths[k] = columns.minimum[j]
+		if extra > 0 {
+			widths[k] += spare * (columns.natural[j] - columns.minimum[j]) / extra
+		}
+	}
+
+	return widths
+}
+
+// rowHeight returns the height of a table row whose cells wrap to fit widths.
+func (p *pdfWriter) rowHeight(cells []string, widths []float64, bold bool) float64 {
+	p.setTableFont(bold)
+	lines := 1
+	for k, cell := range cells {
+		lines = max(lines, len(p.pdf.SplitText(cell, widths[k]-2*pdfCellPadding)))
+	}
+
+	return float64(lines)*pdfTableLine + 2*pdfCellPadding
+}
+
+func (p *pdfWriter) writeTableRow(cells []string, widths []float64, header bool) {
+	height := p.rowHeight(cells, widths, header)
+	if p.needsPage(height) {
+		p.pdf.AddPage()
+	}
+
+	left, _, _, _ := p.pdf.GetMargins()
+	x, y := left, p.pdf.GetY()
+	p.setTableFont(header)
+	p.setFillColor(pdfHeaderFill)
+	p.pdf.SetDrawColor(190, 190, 190)
+	for k, cell := range cells {
+		style := "D"
+		if header {
+			style = "FD"
+		}
+		p.pdf.Rect(x, y, widths[k], height, style)
+		p.pdf.SetXY(x+pdfCellPadding, y+pdfCellPadding)
+		p.pdf.MultiCell(widths[k]-2*pdfCellPadding, pdfTableLine, cell, "", "L", false)
+		x += widths[k]
+	}
+	p.pdf.SetDrawColor(0, 0, 0)
+	p.pdf.SetXY(left, y+height)
+}
+
+func (p *pdfWriter) setTableFont(bold bool) {
+	style := ""
+	if bold {
+		style = "B"
+	}
+	p.pdf.SetFont(pdfFont, style, pdfTableFontSize)
+}
+
+// keep starts a new page unless height fits below the current position.
+func (p *pdfWriter) keep(height float64) {
+	if p.needsPage(height) {
+		p.pdf.AddPage()
+	}
+}
+
+// needsPage reports whether height does not fit below the current position.
+func (p *pdfWriter) needsPage(height float64) bool {
+	_, pageHeight := p.pdf.GetPageSize()
+	return p.pdf.GetY()+height > pageHeight-pdfMargin-pdfFooterHeight
+}
+
+// pageBodyHeight returns the height available for content on a page.
+func (p *pdfWriter) pageBodyHeight() float64 {
+	_, pageHeight := p.pdf.GetPageSize()
+	return pageHeight - 2*pdfMargin - pdfFooterHeight
+}
+
+// contentWidth returns the width between the page margins.
+func (p *pdfWriter) contentWidth() float64 {
+	pageWidth, _ := p.pdf.GetPageSize()
+	left, _, right, _ := p.pdf.GetMargins()
+	return pageWidth - left - right
+}
+
+func (p *pdfWriter) setFillColor(c pdfColor) {
+	p.pdf.SetFillColor(c.r, c.g, c.b)
+}
+
+func (p *pdfWriter) setTextColor(c pdfColor) {
+	p.pdf.SetTextColor(c.r, c.g, c.b)
+}
+
+// inlineRun is a span of text drawn in one font.
+type inlineRun struct {
+	text   string
+	bold   bool
+	italic bool
+	code   bool
+}
+
+// inlineRuns splits inline markdown into runs: "**" toggles bold, "*"
+// toggles italics, and "`" delimits a code span, whose content is literal.
+// Backslash escapes are resolved, [Anchor] targets are removed, and links
+// are reduced to their text as in stripInline.
+func inlineRuns(s string) []inlineRun {
+	var runs []inlineRun
+	var cur inlineRun
+	var sb strings.Builder
+
+	flush := func() {
+		if sb.Len() > 0 {
+			cur.text = sb.String()
+			runs = append(runs, cur)
+			sb.Reset()
+		}
+	}
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if cur.code {
+			if c == '`' {
+				flush()
+				cur.code = false
+			} else {
+				sb.WriteByte(c)
+			}
+			continue
+		}
+
+		switch c {
+		case '\\':
+			if i+1 < len(s) {
+				i++
+				sb.WriteByte(s[i])
+			} else {
+				sb.WriteByte(c)
+			}
+		case '`':
+			flush()
+			cur.code = true
+		case '*':
+			flush()
+			if i+1 < len(s) && s[i+1] == '*' {
+				i++
+				cur.bold = !cur.bold
+			} else {
+				cur.italic = !cur.italic
+			}
+		case '<':
+			if n := anchorLen(s[i:]); n > 0 {
+				i += n - 1
+				continue
+			}
+			sb.WriteByte(c)
+		case '[':
+			if _, _, n, ok := parseLink(s[i:]); ok {
+				sb.WriteString(stripInline(s[i : i+n]))
+				i += n - 1
+				continue
+			}
+			sb.WriteByte(c)
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	flush()
+
+	return runs
+}
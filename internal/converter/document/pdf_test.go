@@ -0,0 +1,328 @@
+package document
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf16"
+
+	"github.com/nao1215/markdown"
+)
+
+func pdfTestDocument() *Document {
+	return New().
+		H1("OPNsense Configuration Summary").
+		H2("System Configuration").
+		Paragraph("Hostname "+markdown.Bold("fw01")+" runs "+markdown.Code("25.1")+" ✓").
+		H3("Sysctl").
+		BulletList("one", "two").
+		H2("Network Configuration").
+		Note("**Section Summary**: 2 interfaces").
+		Warning("Default admin account enabled").
+		Tip("Enable 2FA").
+		CodeBlock("", "set interfaces\n").
+		HorizontalRule().
+		H2("Security Configuration").
+		OrderedList(markdown.Link("Rules", "#rules"), markdown.Link("Docs", "https://example.com"))
+}
+
+// pdfXrefOffsets parses the cross-reference table of a PDF and returns the
+// byte offset of each in-use object, keyed by object number.
+func pdfXrefOffsets(t *testing.T, data []byte) map[int]int {
+	t.Helper()
+
+	m := regexp.MustCompile(`startxref\s+(\d+)\s+%%EOF\s*$`).FindSubmatch(data)
+	if m == nil {
+		t.Fatal("PDF has no startxref trailer")
+	}
+	start, err := strconv.Atoi(string(m[1]))
+	if err != nil || start >= len(data) {
+		t.Fatalf("startxref offset %q is out of range", m[1])
+	}
+	if !bytes.HasPrefix(data[start:], []byte("xref")) {
+		t.Fatalf("startxref offset %d does not point at an xref table", start)
+	}
+
+	lines := strings.Split(string(data[start:]), "\n")
+	var first, count int
+	if _, err := fmt.Sscanf(lines[1], "%d %d", &first, &count); err != nil {
+		t.Fatalf("xref subsection header %q: %v", lines[1], err)
+	}
+	if len(lines) < count+2 {
+		t.Fatalf("xref table has fewer than %d entries", count)
+	}
+
+	offsets := make(map[int]int)
+	for i, line := range lines[2 : count+2] {
+		fields := strings.Fields(line)
+		if len(fields) != 3 {
+			t.Fatalf("xref entry %d is malformed: %q", first+i, line)
+		}
+		if fields[2] != "n" {
+			continue
+		}
+		offset, err := strconv.Atoi(fields[0])
+		if err != nil {
+			t.Fatalf("xref entry %d offset: %v", first+i, err)
+		}
+		offsets[first+i] = offset
+	}
+
+	return offsets
+}
+
+// pdfStrings returns the decoded literal strings stored under key in the
+// dictionaries of a PDF, such as the /Title of the info dictionary and of
+// each outline entry.
+func pdfStrings(data []byte, key string) []string {
+	var values []string
+	pattern := regexp.MustCompile(`(?s)/` + key + ` \(((?:\\.|[^\\)])*)\)`)
+	for _, m := range pattern.FindAllSubmatch(data, -1) {
+		values = append(values, pdfDecodeString(m[1]))
+	}
+	return values
+}
+
+// pdfDecodeString resolves the backslash escapes of a PDF literal string and
+// decodes it from UTF-16BE when it starts with a byte order mark.
+func pdfDecodeString(raw []byte) string {
+	var b []byte
+	for i := 0; i < len(raw); i++ {
+		if raw[i] == '\\' && i+1 < len(raw) {
+			i++
+			switch raw[i] {
+			case 'r':
+				b = append(b, '\r')
+			case 'n':
+				b = append(b, '\n')
+			default:
+				b = append(b, raw[i])
+			}
+			continue
+		}
+		b = append(b, raw[i])
+	}
+
+	if !bytes.HasPrefix(b, []byte{0xFE, 0xFF}) {
+		return string(b)
+	}
+	units := make([]uint16, 0, len(b)/2)
+	for i := 2; i+1 < len(b); i += 2 {
+		units = append(units, uint16(b[i])<<8|uint16(b[i+1]))
+	}
+	return string(utf16.Decode(units))
+}
+
+// pdfShowText returns the text-showing operation that draws s in an embedded
+// UTF-8 font: s encoded as escaped UTF-16BE in a literal string, followed by
+// the Tj operator.
+func pdfShowText(s string) []byte {
+	var b []byte
+	for _, u := range utf16.Encode([]rune(s)) {
+		b = append(b, byte(u>>8), byte(u))
+	}
+	escaped := strings.NewReplacer(`\`, `\\`, "(", `\(`, ")", `\)`, "\r", `\r`).Replace(string(b))
+	return []byte("(" + escaped + ")Tj")
+}
+
+func TestPDFRenderer_RenderValidXrefAndOutline(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	r := PDFRenderer{Title: "fw01 Report", Generated: time.Date(2026, 5, 21, 10, 0, 0, 0, time.UTC)}
+	if err := r.Render(&buf, pdfTestDocument()); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	data := buf.Bytes()
+
+	if !bytes.HasPrefix(data, []byte("%PDF-")) {
+		t.Fatalf("output does not start with a PDF header: %q", data[:min(len(data), 16)])
+	}
+
+	offsets := pdfXrefOffsets(t, data)
+	if len(offsets) == 0 {
+		t.Fatal("xref table lists no objects")
+	}
+	for obj, offset := range offsets {
+		want := fmt.Sprintf("%d 0 obj", obj)
+		if offset >= len(data) || !bytes.HasPrefix(data[offset:], []byte(want)) {
+			t.Errorf("xref offset %d of object %d does not point at %q", offset, obj, want)
+		}
+	}
+
+	titles := pdfStrings(data, "Title")
+	for _, want := range []string{
+		"OPNsense Configuration Summary",
+		"System Configuration",
+		"Sysctl",
+		"Network Configuration",
+		"Security Configuration",
+	} {
+		if !slices.Contains(titles, want) {
+			t.Errorf("outline titles %q do not include %q", titles, want)
+		}
+	}
+
+	if !slices.Contains(titles, "fw01 Report") {
+		t.Error("document title is missing from the PDF info dictionary")
+	}
+}
+
+func TestPDFRenderer_OutputIsReproducible(t *testing.T) {
+	t.Parallel()
+
+	r := PDFRenderer{Generated: time.Date(2026, 5, 21, 10, 0, 0, 0, time.UTC)}
+	render := func() []byte {
+		var buf bytes.Buffer
+		if err := r.Render(&buf, pdfTestDocument()); err != nil {
+			t.Fatalf("Render() error = %v", err)
+		}
+		return buf.Bytes()
+	}
+
+	if first, second := render(), render(); !bytes.Equal(first, second) {
+		t.Error("rendering the same document twice produced different PDFs")
+	}
+}
+
+func TestPDFRenderer_TableHeaderRepeatsAcrossPages(t *testing.T) {
+	t.Parallel()
+
+	rows := make([][]string, 150)
+	for i := range rows {
+		rows[i] = []string{fmt.Sprintf("row%03d", i), "value"}
+	}
+	doc := New().H2("Rules").Table(markdown.TableSet{Header: []string{"HeaderName", "HeaderValue"}, Rows: rows})
+
+	pdf := PDFRenderer{}.draw(doc)
+	pdf.SetCompression(false)
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		t.Fatalf("Output() error = %v", err)
+	}
+
+	pages := pdf.PageCount()
+	if pages < 2 {
+		t.Fatalf("table spans %d page(s), want at least 2", pages)
+	}
+	if got := bytes.Count(buf.Bytes(), pdfShowText("HeaderName")); got != pages {
+		t.Errorf("header row drawn %d times, want once per page (%d)", got, pages)
+	}
+}
+
+func TestPDFRenderer_SplitsWideTables(t *testing.T) {
+	t.Parallel()
+
+	header := make([]string, 16)
+	row := make([]string, 16)
+	for i := range header {
+		header[i] = fmt.Sprintf("Column%02d", i+1)
+		row[i] = strings.Repeat("x", 20)
+	}
+	doc := New().Table(markdown.TableSet{Header: header, Rows: [][]string{row}})
+
+	pdf := PDFRenderer{}.draw(doc)
+	pdf.SetCompression(false)
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		t.Fatalf("Output() error = %v", err)
+	}
+	data := buf.Bytes()
+
+	if got := bytes.Count(data, pdfShowText("Column01")); got < 2 {
+		t.Errorf("first column drawn %d times, want it repeated in every column group", got)
+	}
+	if got := bytes.Count(data, pdfShowText("Column16")); got != 1 {
+		t.Errorf("last column drawn %d times, want 1", got)
+	}
+	if caption := pdfShowText("Columns 1-"); !bytes.Contains(data, caption[:len(caption)-len(")Tj")]) {
+		t.Error("column group caption is missing")
+	}
+}
+
+func TestPDFRenderer_DrawsNonLatinText(t *testing.T) {
+	t.Parallel()
+
+	doc := New().
+		H1("Zürich Ελλάδα Москва").
+		Paragraph("Standort Köln, филиал Київ").
+		Table(markdown.TableSet{Header: []string{"Beschreibung"}, Rows: [][]string{{"Übergang → Δίκτυο"}}}).
+		Paragraph("Branch 中 office ✓")
+
+	pdf := PDFRenderer{Title: "Pare-feu « Genève »"}.draw(doc)
+	pdf.SetCompression(false)
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		t.Fatalf("Output() error = %v", err)
+	}
+	data := buf.Bytes()
+
+	titles := pdfStrings(data, "Title")
+	for _, want := range []string{"Zürich Ελλάδα Москва", "Pare-feu « Genève »"} {
+		if !slices.Contains(titles, want) {
+			t.Errorf("titles %q do not include %q", titles, want)
+		}
+	}
+
+	for _, want := range []string{
+		"Zürich Ελλάδα Москва",
+		"Standort Köln, филиал Київ",
+		"Übergang → Δίκτυο",
+		"Branch ? office Yes",
+	} {
+		if !bytes.Contains(data, pdfShowText(want)) {
+			t.Errorf("page content does not draw %q", want)
+		}
+	}
+
+	if !bytes.Contains(data, []byte("/FontFile2")) {
+		t.Error("the TrueType font is not embedded")
+	}
+}
+
+func TestSplitColumns(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		minimum   []float64
+		available float64
+		want      [][]int
+	}{
+		{"fits", []float64{20, 20, 20}, 100, [][]int{{0, 1, 2}}},
+		{"split repeats first column", []float64{20, 15, 15, 15, 15}, 50, [][]int{{0, 1, 2}, {0, 3, 4}}},
+		{"column wider than the page gets its own group", []float64{20, 200, 15}, 50, [][]int{{0, 1}, {0, 2}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := splitColumns(tt.minimum, tt.available)
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("splitColumns() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInlineRuns(t *testing.T) {
+	t.Parallel()
+
+	got := inlineRuns("a **b** *c* `d*e` " + markdown.Link("f", "#f") + ` \|`)
+	want := []inlineRun{
+		{text: "a "},
+		{text: "b", bold: true},
+		{text: " "},
+		{text: "c", italic: true},
+		{text: " "},
+		{text: "d*e", code: true},
+		{text: " f |"},
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("inlineRuns() = %+v, want %+v", got, want)
+	}
+}
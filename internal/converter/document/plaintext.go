@@ -0,0 +1,247 @@
+package document
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Plain text layout.
+const (
+	plainTextColumnGap = "  "
+	plainTextIndent    = "    "
+	plainTextRuleWidth = 72
+
+	// plainTextUnderlines holds the underline character of each heading
+	// level that is underlined, starting at level 1.
+	plainTextUnderlines = "=-"
+)
+
+// PlainTextRenderer renders a Document as unformatted text for terminals,
+// e-mail, and ticketing systems. Level 1 and 2 headings are underlined with
+// '=' and '-', deeper headings are printed as-is, tables become padded
+// columns, admonitions are prefixed with their kind ("WARNING: ..."), and
+// code blocks are indented. Blocks are separated by a blank line; [Break]
+// and [Comment] nodes are omitted.
+//
+// Inline markdown in text fields is stripped: emphasis and code markers are
+// dropped, backslash escapes are resolved, and links are written as their
+// text followed by the target in parentheses. Links to in-document anchors
+// keep only their text.
+type PlainTextRenderer struct{}
+
+// Compile-time assertion that PlainTextRenderer satisfies Renderer.
+var _ Renderer = PlainTextRenderer{}
+
+// Render implements Renderer.
+func (PlainTextRenderer) Render(doc *Document) string {
+	blocks := appendPlainTextBlocks(nil, doc.Nodes())
+	if len(blocks) == 0 {
+		return ""
+	}
+
+	return strings.Join(blocks, "\n\n") + "\n"
+}
+
+// appendPlainTextBlocks renders nodes and appends the non-empty blocks.
+func appendPlainTextBlocks(blocks []string, nodes []Node) []string {
+	for _, n := range nodes {
+		var block string
+
+		switch n := n.(type) {
+		case *Section:
+			blocks = append(blocks, plainTextHeading(n.Level, n.Title))
+			blocks = appendPlainTextBlocks(blocks, n.Children)
+
+			continue
+		case Paragraph:
+			block = strings.TrimSpace(stripInline(n.Text))
+		case Table:
+			block = plainTextTable(n)
+		case List:
+			block = plainTextList(n)
+		case Admonition:
+			block = strings.ToUpper(string(n.Kind)) + ": " + stripInline(n.Text)
+		case CodeBlock:
+			block = plainTextIndent + strings.ReplaceAll(strings.TrimRight(n.Code, "\n"), "\n", "\n"+plainTextIndent)
+		case HorizontalRule:
+			block = strings.Repeat("-", plainTextRuleWidth)
+		case Break, Comment:
+		}
+
+		if block != "" {
+			blocks = append(blocks, block)
+		}
+	}
+
+	return blocks
+}
+
+// plainTextHeading renders a heading, underlined at the levels that have a
+// character in plainTextUnderlines.
+func plainTextHeading(level int, title string) string {
+	title = stripInline(title)
+
+	level = max(level, 1)
+	if level > len(plainTextUnderlines) {
+		return title
+	}
+
+	return title + "\n" + strings.Repeat(plainTextUnderlines[level-1:level], max(utf8.RuneCountInString(title), 1))
+}
+
+// plainTextTable renders t as left-aligned, space-padded columns under a
+// dashed rule. It returns "" for tables with rows that do not match the
+// header, mirroring the markdown package.
+func plainTextTable(t Table) string {
+	if len(t.Header) == 0 {
+		return ""
+	}
+
+	rows := make([][]string, 0, len(t.Rows)+1)
+	rows = append(rows, t.Header)
+	for _, row := range t.Rows {
+		if len(row) != len(t.Header) {
+			return ""
+		}
+		rows = append(rows, row)
+	}
+
+	widths := make([]int, len(t.Header))
+	cells := make([][]string, len(rows))
+	for i, row := range rows {
+		cells[i] = make([]string, len(row))
+		for j, cell := range row {
+			cells[i][j] = stripInline(cell)
+			widths[j] = max(widths[j], utf8.RuneCountInString(cells[i][j]))
+		}
+	}
+
+	rule := make([]string, len(widths))
+	for j, w := range widths {
+		rule[j] = strings.Repeat("-", w)
+	}
+
+	lines := make([]string, 0, len(cells)+1)
+	lines = append(lines, plainTextRow(cells[0], widths), strings.Join(rule, plainTextColumnGap))
+	for _, row := range cells[1:] {
+		lines = append(lines, plainTextRow(row, widths))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// plainTextRow pads each cell to its column width, without trailing spaces.
+func plainTextRow(cells []string, widths []int) string {
+	var sb strings.Builder
+	for j, cell := range cells {
+		if j > 0 {
+			sb.WriteString(plainTextColumnGap)
+		}
+		sb.WriteString(cell)
+		sb.WriteString(strings.Repeat(" ", widths[j]-utf8.RuneCountInString(cell)))
+	}
+
+	return strings.TrimRight(sb.String(), " ")
+}
+
+// plainTextList renders l with one item per line.
+func plainTextList(l List) string {
+	lines := make([]string, len(l.Items))
+	for i, item := range l.Items {
+		marker := "- "
+		if l.Ordered {
+			marker = strconv.Itoa(i+1) + ". "
+		}
+		lines[i] = strings.TrimRight(marker+stripInline(item), " ")
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// stripInline removes inline markdown from s: '*' and '`' markers are
+// dropped, backslash escapes are replaced by the escaped character, and
+// links are replaced by their text followed by " (target)". Links whose
+// target is an in-document anchor ("#...") or equal to the text keep only
+// the text, and [Anchor] targets are removed.
+func stripInline(s string) string {
+	var sb strings.Builder
+	sb.Grow(len(s))
+
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '\\':
+			if i+1 < len(s) {
+				i++
+				sb.WriteByte(s[i])
+			} else {
+				sb.WriteByte(c)
+			}
+		case '*', '`':
+		case '<':
+			if n := anchorLen(s[i:]); n > 0 {
+				i += n - 1
+				continue
+			}
+			sb.WriteByte(c)
+		case '[':
+			text, target, n, ok := parseLink(s[i:])
+			if !ok {
+				sb.WriteByte(c)
+				continue
+			}
+			text = stripInline(text)
+			sb.WriteString(text)
+			if !strings.HasPrefix(target, "#") && target != text {
+				sb.WriteString(" (" + target + ")")
+			}
+			i += n - 1
+		default:
+			sb.WriteByte(c)
+		}
+	}
+
+	return sb.String()
+}
+
+// anchorLen returns the length of the [Anchor] markup at the start of s, or
+// 0 when s does not start with one.
+func anchorLen(s string) int {
+	if !strings.HasPrefix(s, anchorPrefix) {
+		return 0
+	}
+
+	end := strings.Index(s[len(anchorPrefix):], anchorSuffix)
+	if end < 0 {
+		return 0
+	}
+
+	return len(anchorPrefix) + end + len(anchorSuffix)
+}
+
+// parseLink parses a "[text](target)" link at the start of s and returns its
+// parts and length. Escaped brackets in the text are skipped.
+func parseLink(s string) (string, string, int, bool) {
+	closeText := -1
+	for i := 1; i < len(s); i++ {
+		if s[i] == '\\' {
+			i++
+			continue
+		}
+		if s[i] == ']' {
+			closeText = i
+			break
+		}
+	}
+	if closeText < 0 || closeText+1 >= len(s) || s[closeText+1] != '(' {
+		return "", "", 0, false
+	}
+
+	closeTarget := strings.IndexByte(s[closeText+2:], ')')
+	if closeTarget < 0 {
+		return "", "", 0, false
+	}
+	end := closeText + 2 + closeTarget
+
+	return s[1:closeText], s[closeText+2 : end], end + 1, true
+}
@@ -0,0 +1,115 @@
+package document
+
+import (
+	"testing"
+
+	"github.com/nao1215/markdown"
+)
+
+func TestPlainTextRenderer_Render(t *testing.T) {
+	t.Parallel()
+
+	doc := New().
+		Comment("_meta: {}").
+		H1("OPNsense Configuration Summary").
+		H2("System Information").
+		BulletList(
+			markdown.Bold("Hostname")+": fw01",
+			markdown.Bold("Domain")+": ",
+		).
+		Break().
+		H3("Table of Contents").
+		OrderedList(markdown.Link("Interfaces", "#interfaces")).
+		H2("Interfaces").
+		Table(markdown.TableSet{
+			Header: []string{"Name", "Description"},
+			Rows: [][]string{
+				{"`lan`", "Office \\| Lab"},
+				{"wan", "-"},
+			},
+		}).
+		Table(markdown.TableSet{Header: []string{"A", "B"}, Rows: [][]string{{"only one"}}}).
+		Warning("Default "+markdown.Italic("admin")+" account enabled").
+		Paragraph("").
+		CodeBlock("", "line one\nline two\n").
+		HorizontalRule()
+
+	want := `OPNsense Configuration Summary
+==============================
+
+System Information
+------------------
+
+- Hostname: fw01
+- Domain:
+
+Table of Contents
+
+1. Interfaces
+
+Interfaces
+----------
+
+Name  Description
+----  ------------
+lan   Office | Lab
+wan   -
+
+WARNING: Default admin account enabled
+
+    line one
+    line two
+
+------------------------------------------------------------------------
+`
+
+	if got := (PlainTextRenderer{}).Render(doc); got != want {
+		t.Errorf("Render() mismatch\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestPlainTextRenderer_Empty(t *testing.T) {
+	t.Parallel()
+
+	if got := (PlainTextRenderer{}).Render(New().Comment("hidden").Break()); got != "" {
+		t.Errorf("Render() = %q, want empty string", got)
+	}
+}
+
+func TestStripInline(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "plain", in: "no markup", want: "no markup"},
+		{name: "bold and italic", in: markdown.Bold("a") + " " + markdown.Italic("b"), want: "a b"},
+		{name: "code span", in: markdown.Code("x_y"), want: "x_y"},
+		{name: "escapes", in: `\*literal\* \[x\] a\\b \<tag\>`, want: `*literal* [x] a\b <tag>`},
+		{name: "trailing backslash", in: `end\`, want: `end\`},
+		{name: "anchor link", in: markdown.Link("Rules", "#firewall-rules"), want: "Rules"},
+		{
+			name: "external link",
+			in:   markdown.Link("docs", "https://example.com/x"),
+			want: "docs (https://example.com/x)",
+		},
+		{name: "link equal to target", in: markdown.Link("https://a.b", "https://a.b"), want: "https://a.b"},
+		{name: "bold link text", in: markdown.Link(markdown.Bold("go"), "#go"), want: "go"},
+		{name: "anchor target", in: Anchor("firewall-rule-3") + "3", want: "3"},
+		{name: "unterminated anchor", in: `<a id="x`, want: `<a id="x`},
+		{name: "unterminated bracket", in: "[not a link", want: "[not a link"},
+		{name: "bracket without target", in: "[x] done", want: "[x] done"},
+		{name: "underscores kept", in: "_meta snake_case", want: "_meta snake_case"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := stripInline(tt.in); got != tt.want {
+				t.Errorf("stripInline(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
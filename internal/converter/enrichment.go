@@ -4,6 +4,7 @@ import (
 	"slices"
 
 	"github.com/EvilBit-Labs/opnDossier/internal/analysis"
+	"github.com/EvilBit-Labs/opnDossier/internal/constants"
 	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
 )
 
@@ -153,6 +154,47 @@ func prepareForExport(data *common.CommonDevice, redact bool) *common.CommonDevi
 	return &cp
 }
 
+// exportDocument is the top-level shape of JSON and YAML exports: a leading
+// _meta object followed by the prepared device's fields, inlined.
+type exportDocument struct {
+	Meta                common.ExportMeta `json:"_meta" yaml:"_meta"`
+	common.CommonDevice `yaml:",inline"`
+}
+
+// newExportDocument wraps a device returned by prepareForExport with the
+// export metadata (model version, tool version, and source config versions).
+// A non-nil source is embedded under _meta.source.
+func newExportDocument(target *common.CommonDevice, source *common.EmbeddedSource) *exportDocument {
+	meta := common.NewExportMeta(target, constants.Version)
+	meta.Source = source
+
+	return &exportDocument{
+		Meta:         meta,
+		CommonDevice: *target,
+	}
+}
+
+// newFilteredExportDocument wraps target with the export metadata for opts:
+// the source embedded when opts.EmbeddedSource is set, and, when
+// opts.RuleFilter is active, only the matching rules with the filter
+// recorded under _meta.ruleFilter.
+func newFilteredExportDocument(target *common.CommonDevice, opts Options) *exportDocument {
+	doc := newExportDocument(target, opts.EmbeddedSource)
+
+	if opts.RuleFilter.IsActive() {
+		shown, total := analysis.ApplyRuleFilter(&doc.CommonDevice, opts.RuleFilter)
+		doc.Meta.RuleFilter = &common.ExportRuleFilter{
+			Interface: opts.RuleFilter.Interface,
+			Action:    opts.RuleFilter.Action,
+			Search:    opts.RuleFilter.Search,
+			Shown:     shown,
+			Total:     total,
+		}
+	}
+
+	return doc
+}
+
 // redactSensitiveFields replaces sensitive field values with a redaction marker.
 // This must be called on the shallow copy, not the original, to avoid mutating
 // the caller's data. Slice fields that contain sensitive data are deep-copied
@@ -9,4 +9,22 @@ var (
 
 	// ErrNilDevice is returned when the input device configuration is nil.
 	ErrNilDevice = errors.New("device configuration is nil")
+
+	// ErrInvalidTemplate is returned when a user-supplied report template cannot be read or compiled.
+	ErrInvalidTemplate = errors.New("invalid report template")
+
+	// ErrSourceTooLarge is returned when a source configuration exceeds the embedding size limit.
+	ErrSourceTooLarge = errors.New("source configuration too large to embed")
+
+	// ErrNoEmbeddedSource is returned when an export does not carry an embedded source configuration.
+	ErrNoEmbeddedSource = errors.New("export has no embedded source configuration")
+
+	// ErrInvalidEmbeddedSource is returned when an export's embedded source cannot be decoded.
+	ErrInvalidEmbeddedSource = errors.New("invalid embedded source configuration")
+
+	// ErrSourceChecksumMismatch is returned when extracted source bytes do not match the recorded SHA-256.
+	ErrSourceChecksumMismatch = errors.New("embedded source checksum mismatch")
+
+	// ErrOutputExists is returned by BatchConvert when a report file already exists and overwriting was not forced.
+	ErrOutputExists = errors.New("output file already exists")
 )
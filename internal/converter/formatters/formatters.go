@@ -6,6 +6,8 @@ import (
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/EvilBit-Labs/opnDossier/pkg/schema/shared"
 )
 
 // Display symbols and boolean string representations used for formatting report output.
@@ -124,11 +126,11 @@ func GetPowerModeDescription(mode string) string {
 		return "High Performance with Dynamic Power Management"
 	case "hiadp":
 		return "High Performance with Adaptive Dynamic Power Management"
-	case "adaptive":
+	case "adp", "adaptive":
 		return "Adaptive Power Management"
-	case "minimum":
+	case "min", "minimum":
 		return "Minimum Power Consumption"
-	case "maximum":
+	case "max", "maximum":
 		return "Maximum Performance"
 	default:
 		return mode
@@ -140,14 +142,14 @@ func GetPowerModeDescriptionCompact(mode string) string {
 	switch mode {
 	case "hadp":
 		return "Adaptive (hadp)"
-	case "maximum":
-		return "Maximum Performance (maximum)"
-	case "minimum":
-		return "Minimum Power (minimum)"
+	case "max", "maximum":
+		return "Maximum Performance (" + mode + ")"
+	case "min", "minimum":
+		return "Minimum Power (" + mode + ")"
 	case "hiadaptive":
 		return "High Adaptive (hiadaptive)"
-	case "adaptive":
-		return "Adaptive (adaptive)"
+	case "adp", "adaptive":
+		return "Adaptive (" + mode + ")"
 	default:
 		return mode
 	}
@@ -201,20 +203,20 @@ func FormatBooleanWithUnset(value any) string {
 	return checkboxUnchecked
 }
 
-// FormatUnixTimestamp converts a Unix timestamp string to an ISO 8601 formatted date.
-func FormatUnixTimestamp(timestamp string) string {
+// FormatTimestamp renders a configuration timestamp in any format
+// shared.ParseTimestamp accepts as an RFC 3339 UTC time. An empty timestamp
+// renders as "-", and one that cannot be parsed is returned unchanged.
+func FormatTimestamp(timestamp string) string {
 	if timestamp == "" {
 		return "-"
 	}
 
-	ts, err := strconv.ParseFloat(timestamp, 64)
-	if err != nil {
+	t, ok := shared.ParseTimestamp(timestamp)
+	if !ok {
 		return timestamp
 	}
 
-	timeValue := time.Unix(int64(ts), int64((ts-float64(int64(ts)))*float64(time.Second)))
-
-	return timeValue.Format("2006-01-02T15:04:05Z07:00")
+	return t.Format(time.RFC3339)
 }
 
 // FormatWithSuffix appends a suffix to a value, returning "N/A" if the value is empty.
@@ -303,7 +303,7 @@ func TestFormatBooleanWithUnset(t *testing.T) {
 	}
 }
 
-func TestFormatUnixTimestamp(t *testing.T) {
+func TestFormatTimestamp(t *testing.T) {
 	t.Parallel()
 
 	tests := []struct {
@@ -312,22 +312,18 @@ func TestFormatUnixTimestamp(t *testing.T) {
 		want      string
 	}{
 		{"empty timestamp", "", "-"},
-		{"valid timestamp", "1609459200", "2021-01-01T00:00:00Z"},
-		{"invalid timestamp", "not-a-number", "not-a-number"},
-		{"floating point timestamp", "1609459200.5", "2021-01-01T00:00:00Z"},
+		{"epoch seconds", "1609459200", "2021-01-01T00:00:00Z"},
+		{"epoch with fraction", "1609459200.5", "2021-01-01T00:00:00Z"},
+		{"pfSense format", "3/14/24 09:30:00", "2024-03-14T09:30:00Z"},
+		{"RFC 3339", "2024-03-14T09:30:00+02:00", "2024-03-14T07:30:00Z"},
+		{"unparseable timestamp", "not-a-number", "not-a-number"},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			got := FormatUnixTimestamp(tt.timestamp)
-			// For valid timestamps, just check format is correct (timezone may vary)
-			if tt.name == "valid timestamp" || tt.name == "floating point timestamp" {
-				if len(got) < 10 || got[4] != '-' || got[7] != '-' {
-					t.Errorf("FormatUnixTimestamp(%q) = %q, doesn't look like ISO 8601", tt.timestamp, got)
-				}
-			} else if got != tt.want {
-				t.Errorf("FormatUnixTimestamp(%q) = %q, want %q", tt.timestamp, got, tt.want)
+			if got := FormatTimestamp(tt.timestamp); got != tt.want {
+				t.Errorf("FormatTimestamp(%q) = %q, want %q", tt.timestamp, got, tt.want)
 			}
 		})
 	}
@@ -0,0 +1,99 @@
+package formatters
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+)
+
+//go:generate go run ../../../tools/portnames/main.go -input ports.csv -output ports_gen.go
+
+// maxPortListItems is the number of comma-separated ports FormatPortRange
+// shows before summarizing the remainder as "…+N more".
+const maxPortListItems = 5
+
+// maxPortNumber is the highest valid TCP/UDP port number.
+const maxPortNumber = 65535
+
+// FormatPortRange humanizes a rule port value for report tables. When names
+// is true and protocol is TCP or UDP ("tcp", "udp" or "tcp/udp"), a single
+// well-known port gains its IANA service name ("443" becomes "443 (https)").
+// Comma-separated lists are formatted item by item, and lists longer than
+// five items show the first five followed by "…+N more". Ranges
+// ("1024:65535"), aliases, and unknown port numbers are returned unchanged.
+func FormatPortRange(port, protocol string, names bool) string {
+	port = strings.TrimSpace(port)
+	if port == "" {
+		return ""
+	}
+
+	var protocols []string
+	if names {
+		protocols = portProtocols(protocol)
+	}
+
+	items := strings.Split(port, ",")
+	if len(items) == 1 {
+		return formatPortItem(port, protocols)
+	}
+
+	shown := min(len(items), maxPortListItems)
+	formatted := make([]string, 0, shown+1)
+	for _, item := range items[:shown] {
+		formatted = append(formatted, formatPortItem(strings.TrimSpace(item), protocols))
+	}
+
+	if extra := len(items) - shown; extra > 0 {
+		formatted = append(formatted, "…+"+strconv.Itoa(extra)+" more")
+	}
+
+	return strings.Join(formatted, ", ")
+}
+
+// portProtocols returns the transport protocols whose service names apply
+// to a rule with protocol, or nil when the protocol carries no ports.
+func portProtocols(protocol string) []string {
+	switch strings.ToLower(strings.TrimSpace(protocol)) {
+	case "tcp":
+		return []string{"tcp"}
+	case "udp":
+		return []string{"udp"}
+	case "tcp/udp":
+		return []string{"tcp", "udp"}
+	default:
+		return nil
+	}
+}
+
+// portServiceName returns the IANA service name registered for port under
+// any of protocols. Differing TCP and UDP names are joined with "/"
+// ("shell/syslog" for 514).
+func portServiceName(port int, protocols []string) (string, bool) {
+	names := make([]string, 0, len(protocols))
+	for _, proto := range protocols {
+		if name, ok := portServices[strconv.Itoa(port)+"/"+proto]; ok && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+
+	return strings.Join(names, "/"), len(names) > 0
+}
+
+// formatPortItem appends the service name registered for protocols to a
+// single port number and returns any other value unchanged.
+func formatPortItem(item string, protocols []string) string {
+	if len(protocols) == 0 {
+		return item
+	}
+
+	n, err := strconv.Atoi(item)
+	if err != nil || n < 1 || n > maxPortNumber || strconv.Itoa(n) != item {
+		return item
+	}
+
+	if service, ok := portServiceName(n, protocols); ok {
+		return item + " (" + service + ")"
+	}
+
+	return item
+}
@@ -0,0 +1,69 @@
+// Code generated by go run tools/portnames/main.go; DO NOT EDIT.
+
+package formatters
+
+// portServices maps "port/protocol" to the IANA service name registered
+// for it. Generated from ports.csv.
+var portServices = map[string]string{
+	"20/tcp":   "ftp-data",
+	"21/tcp":   "ftp",
+	"22/tcp":   "ssh",
+	"23/tcp":   "telnet",
+	"25/tcp":   "smtp",
+	"53/tcp":   "domain",
+	"53/udp":   "domain",
+	"67/udp":   "bootps",
+	"68/udp":   "bootpc",
+	"69/udp":   "tftp",
+	"80/tcp":   "http",
+	"88/tcp":   "kerberos",
+	"88/udp":   "kerberos",
+	"110/tcp":  "pop3",
+	"119/tcp":  "nntp",
+	"123/udp":  "ntp",
+	"135/tcp":  "epmap",
+	"137/udp":  "netbios-ns",
+	"138/udp":  "netbios-dgm",
+	"139/tcp":  "netbios-ssn",
+	"143/tcp":  "imap",
+	"161/udp":  "snmp",
+	"162/udp":  "snmptrap",
+	"179/tcp":  "bgp",
+	"389/tcp":  "ldap",
+	"389/udp":  "ldap",
+	"443/tcp":  "https",
+	"443/udp":  "https",
+	"445/tcp":  "microsoft-ds",
+	"465/tcp":  "submissions",
+	"500/udp":  "isakmp",
+	"514/tcp":  "shell",
+	"514/udp":  "syslog",
+	"515/tcp":  "printer",
+	"587/tcp":  "submission",
+	"636/tcp":  "ldaps",
+	"853/tcp":  "domain-s",
+	"853/udp":  "domain-s",
+	"873/tcp":  "rsync",
+	"993/tcp":  "imaps",
+	"995/tcp":  "pop3s",
+	"1194/tcp": "openvpn",
+	"1194/udp": "openvpn",
+	"1433/tcp": "ms-sql-s",
+	"1701/udp": "l2tp",
+	"1723/tcp": "pptp",
+	"1812/tcp": "radius",
+	"1812/udp": "radius",
+	"1813/udp": "radius-acct",
+	"2049/tcp": "nfs",
+	"2049/udp": "nfs",
+	"3306/tcp": "mysql",
+	"3389/tcp": "ms-wbt-server",
+	"3478/udp": "stun",
+	"4500/udp": "ipsec-nat-t",
+	"5060/tcp": "sip",
+	"5060/udp": "sip",
+	"5432/tcp": "postgresql",
+	"5900/tcp": "rfb",
+	"6379/tcp": "redis",
+	"8080/tcp": "http-alt",
+}
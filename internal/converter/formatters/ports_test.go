@@ -0,0 +1,76 @@
+package formatters
+
+import "testing"
+
+func TestFormatPortRange(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		port     string
+		protocol string
+		want     string
+	}{
+		{name: "empty", port: "", protocol: "tcp", want: ""},
+		{name: "whitespace only", port: "  ", protocol: "tcp", want: ""},
+		{name: "well-known port", port: "80", protocol: "tcp", want: "80 (http)"},
+		{name: "well-known HTTPS port", port: "443", protocol: "tcp", want: "443 (https)"},
+		{name: "well-known SSH port", port: "22", protocol: "TCP", want: "22 (ssh)"},
+		{name: "surrounding whitespace", port: " 22 ", protocol: "tcp", want: "22 (ssh)"},
+		{name: "udp only service", port: "1812", protocol: "udp", want: "1812 (radius)"},
+		{name: "service not registered for protocol", port: "636", protocol: "udp", want: "636"},
+		{name: "tcp/udp shared name", port: "53", protocol: "tcp/udp", want: "53 (domain)"},
+		{name: "tcp/udp differing names", port: "514", protocol: "tcp/udp", want: "514 (shell/syslog)"},
+		{name: "non-port protocol", port: "443", protocol: "icmp", want: "443"},
+		{name: "any protocol", port: "443", protocol: "", want: "443"},
+		{name: "unknown numeric port", port: "8443", protocol: "tcp", want: "8443"},
+		{name: "leading zero is not enriched", port: "080", protocol: "tcp", want: "080"},
+		{name: "out of range port", port: "70000", protocol: "tcp", want: "70000"},
+		{name: "dash range", port: "1024-65535", protocol: "tcp", want: "1024-65535"},
+		{name: "colon range", port: "1024:65535", protocol: "tcp", want: "1024:65535"},
+		{name: "colon service range", port: "smtp:smtps", protocol: "tcp", want: "smtp:smtps"},
+		{name: "alias name", port: "WebPorts", protocol: "tcp", want: "WebPorts"},
+		{name: "list", port: "80,443,8080", protocol: "tcp", want: "80 (http), 443 (https), 8080 (http-alt)"},
+		{name: "list with spaces and range", port: "22, 1000-2000", protocol: "tcp", want: "22 (ssh), 1000-2000"},
+		{
+			name:     "list of exactly five",
+			port:     "21,22,23,25,53",
+			protocol: "tcp",
+			want:     "21 (ftp), 22 (ssh), 23 (telnet), 25 (smtp), 53 (domain)",
+		},
+		{
+			name:     "list longer than five is truncated",
+			port:     "21,22,23,25,53,80,443",
+			protocol: "tcp",
+			want:     "21 (ftp), 22 (ssh), 23 (telnet), 25 (smtp), 53 (domain), …+2 more",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := FormatPortRange(tt.port, tt.protocol, true); got != tt.want {
+				t.Errorf("FormatPortRange(%q, %q, true) = %q, want %q", tt.port, tt.protocol, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatPortRange_NamesDisabled(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		port string
+		want string
+	}{
+		{port: "443", want: "443"},
+		{port: "22, 1000-2000", want: "22, 1000-2000"},
+		{port: "21,22,23,25,53,80,443", want: "21, 22, 23, 25, 53, …+2 more"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatPortRange(tt.port, "tcp", false); got != tt.want {
+			t.Errorf("FormatPortRange(%q, tcp, false) = %q, want %q", tt.port, got, tt.want)
+		}
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"reflect"
 	"regexp"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
 // stringEscape applies the table-content escape rules to a single string.
@@ -36,6 +38,31 @@ var escapeTableReplacer = strings.NewReplacer(
 	"\r", " ",
 )
 
+// escapeTextReplacer escapes the inline markdown characters of paragraph text
+// in a single pass.
+//
+//nolint:gochecknoglobals // Immutable replacer, avoids per-call allocation
+var escapeTextReplacer = strings.NewReplacer(
+	"\\", "\\\\",
+	"*", "\\*",
+	"_", "\\_",
+	"`", "\\`",
+	"[", "\\[",
+	"]", "\\]",
+	"<", "\\<",
+	">", "\\>",
+)
+
+// EscapeMarkdownText escapes inline markdown characters in s for safe display
+// within paragraph text, so config-derived values cannot introduce emphasis,
+// code spans, links, or HTML. Unlike EscapeTableContent, pipes and line
+// breaks are left alone since they have no special meaning inside a
+// paragraph. s is expected to appear mid-line, where block markers such as
+// '#' or '-' are inert.
+func EscapeMarkdownText(s string) string {
+	return escapeTextReplacer.Replace(s)
+}
+
 // EscapeTableContent escapes content for safe display in markdown tables.
 // This function ensures that special Markdown characters don't break table formatting or rendering.
 //
@@ -144,6 +171,23 @@ func ToLower(s string) string {
 	return strings.ToLower(s)
 }
 
+// CapitalizeName title-cases the first rune of name and lower-cases the rest,
+// so "LAN" becomes "Lan" and "ÖFFENTLICH" becomes "Öffentlich". Names that do
+// not start with a valid UTF-8 rune keep their first byte unchanged.
+func CapitalizeName(name string) string {
+	r, size := utf8.DecodeRuneInString(name)
+	if size == 0 {
+		return name
+	}
+
+	first := name[:size]
+	if r != utf8.RuneError {
+		first = string(unicode.ToTitle(r))
+	}
+
+	return first + strings.ToLower(name[size:])
+}
+
 // TrimSpace removes leading and trailing whitespace from a string.
 func TrimSpace(s string) string {
 	return strings.TrimSpace(s)
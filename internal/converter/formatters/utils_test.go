@@ -215,6 +215,33 @@ func TestIsEmpty(t *testing.T) {
 	}
 }
 
+func TestEscapeMarkdownText(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		s    string
+		want string
+	}{
+		{"empty string", "", ""},
+		{"plain", "2026-01-02 15:04:05", "2026-01-02 15:04:05"},
+		{"emphasis and code", "*a* _b_ `c`", "\\*a\\* \\_b\\_ \\`c\\`"},
+		{"link and HTML", "[x](y) <b>", "\\[x\\](y) \\<b\\>"},
+		{"backslash", `a\b`, `a\\b`},
+		{"pipes and newlines kept", "a|b\nc", "a|b\nc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := EscapeMarkdownText(tt.s)
+			if got != tt.want {
+				t.Errorf("EscapeMarkdownText(%q) = %q, want %q", tt.s, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestToUpper(t *testing.T) {
 	t.Parallel()
 
@@ -241,6 +268,34 @@ func TestToUpper(t *testing.T) {
 	}
 }
 
+func TestCapitalizeName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		s    string
+		want string
+	}{
+		{"empty string", "", ""},
+		{"lowercase", "wan", "Wan"},
+		{"uppercase", "LAN", "Lan"},
+		{"non-ASCII first rune", "ÖFFENTLICH", "Öffentlich"},
+		{"non-ASCII lowercase", "école", "École"},
+		{"digits", "opt1", "Opt1"},
+		{"invalid UTF-8", "\xffWAN", "\xffwan"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := CapitalizeName(tt.s)
+			if got != tt.want {
+				t.Errorf("CapitalizeName(%q) = %q, want %q", tt.s, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestToLower(t *testing.T) {
 	t.Parallel()
 
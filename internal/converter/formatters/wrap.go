@@ -0,0 +1,193 @@
+package formatters
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// indentedCodeColumns is the indentation at which CommonMark treats a line
+// outside a list as an indented code block.
+const indentedCodeColumns = 4
+
+// proseLeadPattern captures the leading indentation of a markdown line and an
+// optional list marker or blockquote prefix.
+var proseLeadPattern = regexp.MustCompile(`^([ \t]*)((?:[-*+]|\d{1,9}[.)])[ \t]+|(?:>[ \t]?)+)?`)
+
+// orderedMarkerPattern matches a word that would open an ordered list item if
+// it started a line.
+var orderedMarkerPattern = regexp.MustCompile(`^\d{1,9}[.)]$`)
+
+// WrapMarkdownProse soft-wraps paragraph, list item, and blockquote lines of a
+// markdown document at width runes. Table rows, headings, HTML lines, fenced
+// and indented code blocks are left untouched, and inline code spans are never
+// split. Continuation lines keep list and blockquote structure, so the wrapped
+// document renders identically. A width of 0 or less returns markdown as-is.
+func WrapMarkdownProse(markdown string, width int) string {
+	if width <= 0 {
+		return markdown
+	}
+
+	lines := strings.Split(markdown, "\n")
+	wrapped := make([]string, 0, len(lines))
+	inFence := false
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			wrapped = append(wrapped, line)
+			continue
+		}
+
+		if inFence || utf8.RuneCountInString(line) <= width {
+			wrapped = append(wrapped, line)
+			continue
+		}
+
+		wrapped = append(wrapped, wrapProseLine(line, width)...)
+	}
+
+	return strings.Join(wrapped, "\n")
+}
+
+// wrapProseLine breaks a single over-long markdown line at word boundaries.
+// Lines that are not prose are returned unchanged.
+func wrapProseLine(line string, width int) []string {
+	match := proseLeadPattern.FindStringSubmatch(line)
+	indent, marker := match[1], match[2]
+	lead := match[0]
+	body := line[len(lead):]
+
+	if marker == "" && strings.Count(strings.ReplaceAll(indent, "\t", "    "), " ") >= indentedCodeColumns {
+		return []string{line}
+	}
+
+	if body == "" || strings.HasPrefix(body, "|") || strings.HasPrefix(body, "#") || strings.HasPrefix(body, "<") {
+		return []string{line}
+	}
+
+	continuation := indent
+	switch {
+	case strings.HasPrefix(marker, ">"):
+		continuation = lead
+	case marker != "":
+		continuation = strings.Repeat(" ", utf8.RuneCountInString(lead))
+	}
+
+	// A line ending in two spaces is a hard break; keep it on the last piece.
+	hardBreak := ""
+	if strings.HasSuffix(body, "  ") {
+		hardBreak = "  "
+	}
+
+	words := splitProseWords(body)
+	if len(words) < 2 {
+		return []string{line}
+	}
+
+	var (
+		out     []string
+		current []string
+	)
+
+	prefix := lead
+	length := utf8.RuneCountInString(prefix)
+
+	for _, word := range words {
+		wordLen := utf8.RuneCountInString(word)
+		if len(current) > 0 && length+1+wordLen > width {
+			var carry []string
+			// Never start a continuation line with something markdown would
+			// read as a new block; move the previous word down with it.
+			if startsMarkdownBlock(word) && len(current) > 1 {
+				carry = []string{current[len(current)-1]}
+				current = current[:len(current)-1]
+			}
+
+			out = append(out, prefix+strings.Join(current, " "))
+			prefix = continuation
+			current = append(carry, word)
+			length = utf8.RuneCountInString(prefix + strings.Join(current, " "))
+
+			continue
+		}
+
+		if len(current) > 0 {
+			length++
+		}
+
+		current = append(current, word)
+		length += wordLen
+	}
+
+	return append(out, prefix+strings.Join(current, " ")+hardBreak)
+}
+
+// splitProseWords splits s at runs of whitespace, keeping each inline code
+// span (a backtick run and its matching closing run) inside a single word.
+func splitProseWords(s string) []string {
+	var (
+		words   []string
+		current strings.Builder
+	)
+
+	flush := func() {
+		if current.Len() > 0 {
+			words = append(words, current.String())
+			current.Reset()
+		}
+	}
+
+	for i := 0; i < len(s); {
+		switch c := s[i]; c {
+		case ' ', '\t':
+			flush()
+			i++
+		case '`':
+			run := len(s[i:]) - len(strings.TrimLeft(s[i:], "`"))
+			fence := s[i : i+run]
+
+			end := strings.Index(s[i+run:], fence)
+			if end < 0 {
+				current.WriteString(fence)
+				i += run
+
+				continue
+			}
+
+			spanEnd := i + run + end + run
+			current.WriteString(s[i:spanEnd])
+			i = spanEnd
+		default:
+			current.WriteByte(c)
+			i++
+		}
+	}
+
+	flush()
+
+	return words
+}
+
+// startsMarkdownBlock reports whether word, placed at the start of a line,
+// would open a new markdown block (heading, list item, blockquote, table row,
+// fence, HTML block, thematic break, or setext underline).
+func startsMarkdownBlock(word string) bool {
+	switch word {
+	case "-", "*", "+":
+		return true
+	}
+
+	if orderedMarkerPattern.MatchString(word) {
+		return true
+	}
+
+	for _, prefix := range []string{"#", ">", "|", "<", "```", "~~~"} {
+		if strings.HasPrefix(word, prefix) {
+			return true
+		}
+	}
+
+	return strings.Trim(word, "-=*_") == ""
+}
@@ -0,0 +1,93 @@
+package formatters
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWrapMarkdownProse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input string
+		width int
+		want  string
+	}{
+		{
+			name:  "zero width leaves input unchanged",
+			input: "one two three four five six",
+			width: 0,
+			want:  "one two three four five six",
+		},
+		{
+			name:  "paragraph wraps at word boundaries",
+			input: "one two three four five six",
+			width: 10,
+			want:  "one two\nthree four\nfive six",
+		},
+		{
+			name:  "list item continuation is indented under the marker",
+			input: "- alpha beta gamma delta",
+			width: 12,
+			want:  "- alpha beta\n  gamma\n  delta",
+		},
+		{
+			name:  "blockquote continuation keeps the quote prefix",
+			input: "> alpha beta gamma delta",
+			width: 12,
+			want:  "> alpha beta\n> gamma\n> delta",
+		},
+		{
+			name:  "code span is never split",
+			input: "run `go test ./...` now please",
+			width: 12,
+			want:  "run\n`go test ./...`\nnow please",
+		},
+		{
+			name:  "table rows are untouched",
+			input: "| a very long cell | another long cell |",
+			width: 10,
+			want:  "| a very long cell | another long cell |",
+		},
+		{
+			name:  "headings are untouched",
+			input: "## A heading that is too long",
+			width: 10,
+			want:  "## A heading that is too long",
+		},
+		{
+			name:  "fenced code is untouched",
+			input: "```\nsome code line that is long\n```",
+			width: 10,
+			want:  "```\nsome code line that is long\n```",
+		},
+		{
+			name:  "continuation never starts with a list marker",
+			input: "values are - and more",
+			width: 11,
+			want:  "values\nare - and\nmore",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := WrapMarkdownProse(tt.input, tt.width); got != tt.want {
+				t.Errorf("WrapMarkdownProse() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWrapMarkdownProse_ContinuationNeverOpensBlock(t *testing.T) {
+	t.Parallel()
+
+	got := WrapMarkdownProse("counted up to 12345 and then 1. more words", 24)
+	for line := range strings.SplitSeq(got, "\n") {
+		if strings.HasPrefix(line, "1. ") {
+			t.Errorf("continuation line %q would start an ordered list", line)
+		}
+	}
+}
@@ -110,7 +110,7 @@ func TestGolden_JSONRedacted(t *testing.T) {
 
 			target := prepareForExport(testData, true)
 
-			output, err := json.MarshalIndent(target, "", "  ")
+			output, err := json.MarshalIndent(newExportDocument(target, nil), "", "  ")
 			require.NoError(t, err, "JSON marshalling should not fail")
 			require.NotEmpty(t, output, "JSON output should not be empty")
 
@@ -135,7 +135,7 @@ func TestGolden_YAMLRedacted(t *testing.T) {
 
 			target := prepareForExport(testData, true)
 
-			output, err := yaml.Marshal(target)
+			output, err := yaml.Marshal(newExportDocument(target, nil))
 			require.NoError(t, err, "YAML marshalling should not fail")
 			require.NotEmpty(t, output, "YAML output should not be empty")
 
@@ -160,7 +160,7 @@ func TestGolden_JSONUnredacted(t *testing.T) {
 
 			target := prepareForExport(testData, false)
 
-			output, err := json.MarshalIndent(target, "", "  ")
+			output, err := json.MarshalIndent(newExportDocument(target, nil), "", "  ")
 			require.NoError(t, err, "JSON marshalling should not fail")
 			require.NotEmpty(t, output, "JSON output should not be empty")
 
@@ -185,7 +185,7 @@ func TestGolden_YAMLUnredacted(t *testing.T) {
 
 			target := prepareForExport(testData, false)
 
-			output, err := yaml.Marshal(target)
+			output, err := yaml.Marshal(newExportDocument(target, nil))
 			require.NoError(t, err, "YAML marshalling should not fail")
 			require.NotEmpty(t, output, "YAML output should not be empty")
 
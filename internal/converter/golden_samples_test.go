@@ -0,0 +1,83 @@
+package converter
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/cfgparser"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/EvilBit-Labs/opnDossier/pkg/parser"
+	_ "github.com/EvilBit-Labs/opnDossier/pkg/parser/pfsense" // self-registers pfSense parser via init()
+	"github.com/stretchr/testify/require"
+)
+
+// sampleConfigGlobs lists the repository config samples rendered by
+// TestGolden_TestdataSamples, relative to this package.
+var sampleConfigGlobs = []string{
+	filepath.Join("..", "..", "testdata", "*.xml"),
+	filepath.Join("..", "..", "testdata", "pfsense", "*.xml"),
+}
+
+// TestGolden_TestdataSamples renders the standard and comprehensive markdown
+// reports for every config sample under testdata/ and compares them with
+// testdata/golden/samples. It pins report output byte-for-byte across
+// builder refactors.
+//
+// To update golden files when output changes intentionally, run:
+//
+//	go test ./internal/converter -run TestGolden_TestdataSamples -update
+func TestGolden_TestdataSamples(t *testing.T) {
+	var samples []string
+	for _, pattern := range sampleConfigGlobs {
+		matches, err := filepath.Glob(pattern)
+		require.NoError(t, err)
+		samples = append(samples, matches...)
+	}
+	require.NotEmpty(t, samples, "no config samples found")
+
+	for _, path := range samples {
+		name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		device := loadSampleConfig(t, path)
+
+		for _, comprehensive := range []bool{false, true} {
+			kind := "standard"
+			if comprehensive {
+				kind = "comprehensive"
+			}
+
+			t.Run(name+"_"+kind, func(t *testing.T) {
+				mdBuilder := createDeterministicBuilder(t)
+
+				var output string
+				var err error
+				if comprehensive {
+					output, err = mdBuilder.BuildComprehensiveReport(device)
+				} else {
+					output, err = mdBuilder.BuildStandardReport(device)
+				}
+				require.NoError(t, err)
+
+				newGoldie(t).Assert(t, filepath.Join("samples", name+"_"+kind), []byte(output))
+			})
+		}
+	}
+}
+
+// loadSampleConfig parses the config sample at path into a CommonDevice,
+// auto-detecting the device type.
+func loadSampleConfig(t *testing.T, path string) *common.CommonDevice {
+	t.Helper()
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = file.Close() })
+
+	device, _, err := parser.NewFactory(cfgparser.NewXMLParser()).
+		CreateDevice(context.Background(), file, common.DeviceTypeUnknown, false)
+	require.NoError(t, err, "failed to parse %s", path)
+
+	return device
+}
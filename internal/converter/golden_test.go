@@ -2,10 +2,12 @@ package converter
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/EvilBit-Labs/opnDossier/internal/converter/builder"
+	"github.com/EvilBit-Labs/opnDossier/internal/converter/document"
 	"github.com/EvilBit-Labs/opnDossier/internal/logging"
 	"github.com/sebdah/goldie/v2"
 	"github.com/stretchr/testify/assert"
@@ -285,3 +287,39 @@ func TestGolden_ConsistencyAcrossRuns(t *testing.T) {
 			"Output from run %d should match run 1", i+1)
 	}
 }
+
+// TestGolden_PlainTextReport renders a complete report through
+// document.PlainTextRenderer and compares it with a golden file, so every
+// node type the builders emit is exercised by the plain text renderer.
+//
+// To update the golden file when output changes intentionally, run:
+//
+//	go test -v ./internal/converter -run TestGolden_PlainTextReport -update
+func TestGolden_PlainTextReport(t *testing.T) {
+	testData := loadTestDataFromFile(t, "complete.json")
+	require.NotNil(t, testData)
+
+	mdBuilder := builder.NewMarkdownBuilder(
+		builder.WithGeneratedTime(goldenGeneratedTime),
+		builder.WithVersion(goldenToolVersion),
+		builder.WithRenderer(document.PlainTextRenderer{}),
+	)
+
+	output, err := mdBuilder.BuildComprehensiveReport(testData)
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(output,
+		"OPNsense Configuration Summary\n==============================\n"),
+		"plain text report should open with an underlined title")
+	for _, markup := range []string{"<!--", "\n## ", "\n### ", "**", "|---", "](#"} {
+		assert.NotContains(t, output, markup, "plain text report should not contain markdown %q", markup)
+	}
+
+	g := goldie.New(
+		t,
+		goldie.WithFixtureDir("testdata/golden"),
+		goldie.WithNameSuffix(".golden.txt"),
+		goldie.WithDiffEngine(goldie.ColoredDiff),
+	)
+	g.Assert(t, "complete_comprehensive_plaintext", []byte(output))
+}
@@ -1,6 +1,7 @@
 package converter
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -9,7 +10,10 @@ import (
 	"os"
 	"strings"
 
+	"github.com/EvilBit-Labs/opnDossier/internal/analysis"
 	"github.com/EvilBit-Labs/opnDossier/internal/converter/builder"
+	"github.com/EvilBit-Labs/opnDossier/internal/converter/document"
+	"github.com/EvilBit-Labs/opnDossier/internal/converter/formatters"
 	"github.com/EvilBit-Labs/opnDossier/internal/logging"
 	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
 	"gopkg.in/yaml.v3"
@@ -42,20 +46,32 @@ type StreamingGenerator interface {
 // reportGenerator is the narrowest interface HybridGenerator requires from its
 // builder. It lists only the methods HybridGenerator directly calls:
 // report composition (BuildStandardReport, BuildComprehensiveReport),
-// audit section rendering (BuildAuditSection), and rendering toggles
-// (SetIncludeTunables, SetFailuresOnly). The remaining SectionBuilder and
+// appendix rendering (BuildAuditSection, BuildDataQualitySection), and rendering toggles
+// (SetIncludeTunables, SetFailuresOnly, SetPortNames, SetRuleFilter, SetSectionOrder, SetStrictRender). The remaining
+// SectionBuilder and
 // TableWriter methods are deliberately excluded — HybridGenerator delegates
-// full-report assembly to the builder and only renders the audit section individually.
+// full-report assembly to the builder and only renders the appendix sections individually.
 //
 // Note: HybridGenerator also type-asserts the builder to builder.SectionWriter
-// for streaming support — see generateMarkdownToWriter.
+// for streaming support — see generateMarkdownToWriter — and to
+// builder.DocumentComposer for PDF output — see generatePDFToWriter.
 type reportGenerator interface {
 	// SetIncludeTunables configures whether all system tunables are included in the report.
 	SetIncludeTunables(v bool)
 	// SetFailuresOnly configures whether only non-compliant controls are shown in audit reports.
 	SetFailuresOnly(v bool)
+	// SetPortNames configures whether well-known ports in rule tables are annotated with their service name.
+	SetPortNames(v bool)
+	// SetRuleFilter configures the filter that narrows the firewall and NAT rule tables.
+	SetRuleFilter(f analysis.RuleFilter)
+	// SetSectionOrder configures the order of the report sections and table of contents.
+	SetSectionOrder(ids []string) error
+	// SetStrictRender configures whether a section that fails to render is a hard error instead of a placeholder.
+	SetStrictRender(v bool)
 	// BuildAuditSection builds the compliance audit section from the device's ComplianceResults.
 	BuildAuditSection(data *common.CommonDevice) string
+	// BuildDataQualitySection builds the Data Quality appendix from normalization issues.
+	BuildDataQualitySection(issues []common.ConversionWarning) string
 	// BuildStandardReport generates a standard configuration report.
 	BuildStandardReport(data *common.CommonDevice) (string, error)
 	// BuildComprehensiveReport generates a comprehensive configuration report.
@@ -134,7 +150,7 @@ func handlerForFormat(format string) (FormatHandler, error) {
 }
 
 // Generate creates documentation in the specified format from the provided OPNsense configuration.
-// Supported formats: markdown (default), json, yaml, text, and html.
+// Supported formats: markdown (default), json, yaml, text, html, and pdf.
 //
 // Memory tradeoff (JSON/YAML): Generate pays roughly 2x peak memory for JSON and
 // YAML output because the marshaled byte slice and its string(...) conversion both
@@ -183,13 +199,14 @@ func (g *HybridGenerator) Generate(ctx context.Context, data *common.CommonDevic
 
 // GenerateToWriter writes documentation directly to the provided io.Writer.
 //
-// Supported formats: markdown (default), json, yaml, text, and html.
+// Supported formats: markdown (default), json, yaml, text, html, and pdf.
 // For markdown, sections are written incrementally as they are generated.
 // For JSON and YAML, an encoder writes directly to w — this avoids the 2x
 // peak-memory hit that Generate incurs (marshaled bytes plus their string(...)
 // conversion both resident at once). For text and HTML, the full output is
 // produced then written because those formats require complete document
-// serialization or post-processing.
+// serialization or post-processing; PDF is rendered in memory for the same
+// reason.
 //
 // Use GenerateToWriter when:
 //   - You are writing directly to a file, socket, or HTTP response.
@@ -236,7 +253,11 @@ func (g *HybridGenerator) GenerateToWriter(
 }
 
 // generateMarkdown generates markdown output using the programmatic builder.
-// Not safe for concurrent use — MarkdownBuilder is per-instance, not shared.
+// It applies opts to the shared builder's rendering toggles, so concurrent
+// calls are race-free but must use the same rendering options; share a
+// generator only between callers with identical options.
+// A positive opts.MaxWidth soft-wraps paragraph prose at that width; tables
+// and code are never wrapped.
 //
 // ctx is checked at the per-subsystem boundary between report body composition
 // and the compliance audit section append. The builder itself does not yet
@@ -252,8 +273,9 @@ func (g *HybridGenerator) generateMarkdown(
 		return "", errors.New("no report builder available for programmatic generation")
 	}
 
-	g.builder.SetIncludeTunables(opts.IncludeTunables)
-	g.builder.SetFailuresOnly(opts.FailuresOnly)
+	if err := g.applyRenderSettings(opts); err != nil {
+		return "", err
+	}
 	target := prepareForExport(data, opts.Redact)
 
 	var report string
@@ -266,31 +288,82 @@ func (g *HybridGenerator) generateMarkdown(
 		report, err = g.builder.BuildStandardReport(target)
 	}
 
-	if err != nil {
+	// A partial report is complete apart from its placeholders; finish it and
+	// return the section failures with it.
+	if err != nil && !isPartialReport(err) {
 		return "", err
 	}
+	renderErr := err
 
 	// Per-subsystem boundary: between report body and audit section.
 	if err := ctx.Err(); err != nil {
 		return "", err
 	}
 
-	// Append audit section when compliance data is present. Use strings.Builder
-	// with Grow() pre-sizing instead of += concatenation so we do not copy the
-	// (potentially 2MB+) report body once per append (PERF-M7).
-	if target.ComplianceResults != nil {
-		auditSection := g.builder.BuildAuditSection(target)
-		if auditSection != "" {
-			var b strings.Builder
-			b.Grow(len(report) + len(auditSectionSeparator) + len(auditSection))
-			b.WriteString(report)
+	// Append the audit and Data Quality sections when present. Use
+	// strings.Builder with Grow() pre-sizing instead of += concatenation so we
+	// do not copy the (potentially 2MB+) report body once per append (PERF-M7).
+	sections := g.appendixSections(target, opts)
+	if len(sections) > 0 {
+		size := len(report)
+		for _, section := range sections {
+			size += len(auditSectionSeparator) + len(section)
+		}
+
+		var b strings.Builder
+		b.Grow(size)
+		b.WriteString(report)
+		for _, section := range sections {
 			b.WriteString(auditSectionSeparator)
-			b.WriteString(auditSection)
-			return b.String(), nil
+			b.WriteString(section)
 		}
+		return formatters.WrapMarkdownProse(b.String(), opts.MaxWidth), renderErr
 	}
 
-	return report, nil
+	return formatters.WrapMarkdownProse(report, opts.MaxWidth), renderErr
+}
+
+// applyRenderSettings applies the rendering toggles in opts to the shared
+// builder.
+func (g *HybridGenerator) applyRenderSettings(opts Options) error {
+	g.builder.SetIncludeTunables(opts.IncludeTunables)
+	g.builder.SetFailuresOnly(opts.FailuresOnly)
+	g.builder.SetPortNames(!opts.NoPortNames)
+	g.builder.SetRuleFilter(opts.RuleFilter)
+	g.builder.SetStrictRender(opts.StrictRender)
+	if err := g.builder.SetSectionOrder(opts.SectionOrder); err != nil {
+		return fmt.Errorf("invalid section order: %w", err)
+	}
+
+	return nil
+}
+
+// isPartialReport reports whether err is a *builder.PartialReportError: the
+// report returned with it is complete except for the sections replaced by
+// placeholders, so callers should still emit it.
+func isPartialReport(err error) bool {
+	var partial *builder.PartialReportError
+	return errors.As(err, &partial)
+}
+
+// appendixSections returns the sections appended after the report body, in
+// order: the compliance audit section when compliance data is present, then
+// the Data Quality appendix when opts.DataQuality is non-empty. Empty
+// sections are omitted.
+func (g *HybridGenerator) appendixSections(target *common.CommonDevice, opts Options) []string {
+	var sections []string
+
+	if target.ComplianceResults != nil {
+		if auditSection := g.builder.BuildAuditSection(target); auditSection != "" {
+			sections = append(sections, auditSection)
+		}
+	}
+
+	if dataQuality := g.builder.BuildDataQualitySection(opts.DataQuality); dataQuality != "" {
+		sections = append(sections, dataQuality)
+	}
+
+	return sections
 }
 
 // generateMarkdownToWriter writes markdown output directly to the writer.
@@ -311,8 +384,24 @@ func (g *HybridGenerator) generateMarkdownToWriter(
 		return errors.New("no report builder available for programmatic generation")
 	}
 
-	g.builder.SetIncludeTunables(opts.IncludeTunables)
-	g.builder.SetFailuresOnly(opts.FailuresOnly)
+	// Prose wrapping needs the whole document, so a positive max width
+	// trades streaming for the string path.
+	if opts.MaxWidth > 0 {
+		report, err := g.generateMarkdown(ctx, data, opts)
+		if err != nil && !isPartialReport(err) {
+			return err
+		}
+
+		if _, writeErr := io.WriteString(w, report); writeErr != nil {
+			return fmt.Errorf("failed to write report body: %w", writeErr)
+		}
+
+		return err
+	}
+
+	if err := g.applyRenderSettings(opts); err != nil {
+		return err
+	}
 	target := prepareForExport(data, opts.Redact)
 
 	// Check if builder supports SectionWriter interface for streaming
@@ -331,27 +420,35 @@ func (g *HybridGenerator) generateMarkdownToWriter(
 		err = sectionWriter.WriteStandardReport(w, target)
 	}
 
-	if err != nil {
+	if err != nil && !isPartialReport(err) {
 		return err
 	}
+	renderErr := err
 
 	// Per-subsystem boundary: between report body and audit section (streaming path).
 	if err := ctx.Err(); err != nil {
 		return err
 	}
 
-	// Append audit section when compliance data is present. Write the
-	// separator and the audit body as two direct writes to w rather than
-	// concatenating a new string first (PERF-M7).
-	if target.ComplianceResults != nil {
-		auditSection := g.builder.BuildAuditSection(target)
-		if auditSection != "" {
-			if _, writeErr := io.WriteString(w, auditSectionSeparator); writeErr != nil {
-				return fmt.Errorf("failed to write audit section: %w", writeErr)
-			}
-			if _, writeErr := io.WriteString(w, auditSection); writeErr != nil {
-				return fmt.Errorf("failed to write audit section: %w", writeErr)
-			}
+	// Append the audit and Data Quality sections when present. Write each
+	// separator and section as direct writes to w rather than concatenating a
+	// new string first (PERF-M7).
+	if err := writeAppendixSections(w, g.appendixSections(target, opts)); err != nil {
+		return err
+	}
+
+	return renderErr
+}
+
+// writeAppendixSections writes each section to w preceded by the section
+// separator.
+func writeAppendixSections(w io.Writer, sections []string) error {
+	for _, section := range sections {
+		if _, writeErr := io.WriteString(w, auditSectionSeparator); writeErr != nil {
+			return fmt.Errorf("failed to write audit section separator: %w", writeErr)
+		}
+		if _, writeErr := io.WriteString(w, section); writeErr != nil {
+			return fmt.Errorf("failed to write audit section: %w", writeErr)
 		}
 	}
 
@@ -379,36 +476,26 @@ func (g *HybridGenerator) generateMarkdownFallback(
 	default:
 		output, err = g.builder.BuildStandardReport(target)
 	}
-	if err != nil {
+	if err != nil && !isPartialReport(err) {
 		return err
 	}
+	renderErr := err
 
 	// Per-subsystem boundary: between report body and audit section (fallback path).
 	if err := ctx.Err(); err != nil {
 		return err
 	}
 
-	// Append audit section when compliance data is present.
-	if target.ComplianceResults != nil {
-		auditSection := g.builder.BuildAuditSection(target)
-		if auditSection != "" {
-			if _, writeErr := io.WriteString(w, output); writeErr != nil {
-				return fmt.Errorf("failed to write report body: %w", writeErr)
-			}
-			if _, writeErr := io.WriteString(w, auditSectionSeparator); writeErr != nil {
-				return fmt.Errorf("failed to write audit section separator: %w", writeErr)
-			}
-			if _, writeErr := io.WriteString(w, auditSection); writeErr != nil {
-				return fmt.Errorf("failed to write audit section: %w", writeErr)
-			}
-			return nil
-		}
-	}
-
 	if _, writeErr := io.WriteString(w, output); writeErr != nil {
 		return fmt.Errorf("failed to write report body: %w", writeErr)
 	}
-	return nil
+
+	// Append the audit and Data Quality sections when present.
+	if err := writeAppendixSections(w, g.appendixSections(target, opts)); err != nil {
+		return err
+	}
+
+	return renderErr
 }
 
 // generateJSON generates JSON output by serializing the model.
@@ -426,11 +513,7 @@ func (g *HybridGenerator) generateJSON(ctx context.Context, data *common.CommonD
 		return "", err
 	}
 
-	jsonBytes, err := json.MarshalIndent(
-		target,
-		"",
-		"  ",
-	)
+	jsonBytes, err := json.MarshalIndent(newFilteredExportDocument(target, opts), "", "  ")
 	if err != nil {
 		return "", fmt.Errorf("failed to marshal to JSON: %w", err)
 	}
@@ -457,7 +540,7 @@ func (g *HybridGenerator) generateJSONToWriter(
 
 	encoder := json.NewEncoder(w)
 	encoder.SetIndent("", "  ")
-	if err := encoder.Encode(target); err != nil {
+	if err := encoder.Encode(newFilteredExportDocument(target, opts)); err != nil {
 		return fmt.Errorf("failed to encode JSON to writer: %w", err)
 	}
 	return nil
@@ -476,7 +559,7 @@ func (g *HybridGenerator) generateYAML(ctx context.Context, data *common.CommonD
 		return "", err
 	}
 
-	yamlData, err := yaml.Marshal(target)
+	yamlData, err := yaml.Marshal(newFilteredExportDocument(target, opts))
 	if err != nil {
 		return "", fmt.Errorf("failed to marshal to YAML: %w", err)
 	}
@@ -503,7 +586,7 @@ func (g *HybridGenerator) generateYAMLToWriter(
 
 	encoder := yaml.NewEncoder(w)
 	encoder.SetIndent(2) //nolint:mnd // Standard YAML indentation
-	if err := encoder.Encode(target); err != nil {
+	if err := encoder.Encode(newFilteredExportDocument(target, opts)); err != nil {
 		return fmt.Errorf("failed to encode YAML to writer: %w", err)
 	}
 	return encoder.Close()
@@ -520,9 +603,9 @@ func (g *HybridGenerator) generatePlainText(
 ) (string, error) {
 	g.logger.Debug("Generating plain text output")
 
-	markdown, err := g.generateMarkdown(ctx, data, opts)
-	if err != nil {
-		return "", fmt.Errorf("failed to generate markdown for plain text conversion: %w", err)
+	markdown, renderErr := g.generateMarkdown(ctx, data, opts)
+	if renderErr != nil && !isPartialReport(renderErr) {
+		return "", fmt.Errorf("failed to generate markdown for plain text conversion: %w", renderErr)
 	}
 
 	// Per-subsystem boundary: between markdown rendering and strip-formatting.
@@ -530,7 +613,12 @@ func (g *HybridGenerator) generatePlainText(
 		return "", err
 	}
 
-	return StripMarkdownFormatting(markdown)
+	output, err := StripMarkdownFormatting(markdown)
+	if err != nil {
+		return "", err
+	}
+
+	return output, renderErr
 }
 
 // generatePlainTextToWriter writes plain text output directly to the writer.
@@ -542,13 +630,16 @@ func (g *HybridGenerator) generatePlainTextToWriter(
 ) error {
 	g.logger.Debug("Generating plain text output to writer")
 
-	output, err := g.generatePlainText(ctx, data, opts)
-	if err != nil {
+	output, renderErr := g.generatePlainText(ctx, data, opts)
+	if renderErr != nil && !isPartialReport(renderErr) {
+		return renderErr
+	}
+
+	if _, err := io.WriteString(w, output); err != nil {
 		return err
 	}
 
-	_, err = io.WriteString(w, output)
-	return err
+	return renderErr
 }
 
 // generateHTML generates HTML output by rendering markdown first, then converting via goldmark.
@@ -558,9 +649,9 @@ func (g *HybridGenerator) generatePlainTextToWriter(
 func (g *HybridGenerator) generateHTML(ctx context.Context, data *common.CommonDevice, opts Options) (string, error) {
 	g.logger.Debug("Generating HTML output")
 
-	markdown, err := g.generateMarkdown(ctx, data, opts)
-	if err != nil {
-		return "", fmt.Errorf("failed to generate markdown for HTML conversion: %w", err)
+	markdown, renderErr := g.generateMarkdown(ctx, data, opts)
+	if renderErr != nil && !isPartialReport(renderErr) {
+		return "", fmt.Errorf("failed to generate markdown for HTML conversion: %w", renderErr)
 	}
 
 	// Per-subsystem boundary: between markdown rendering and HTML conversion.
@@ -568,7 +659,12 @@ func (g *HybridGenerator) generateHTML(ctx context.Context, data *common.CommonD
 		return "", err
 	}
 
-	return RenderMarkdownToHTML(markdown)
+	output, err := RenderMarkdownToHTML(markdown)
+	if err != nil {
+		return "", err
+	}
+
+	return output, renderErr
 }
 
 // generateHTMLToWriter writes HTML output directly to the writer.
@@ -580,13 +676,87 @@ func (g *HybridGenerator) generateHTMLToWriter(
 ) error {
 	g.logger.Debug("Generating HTML output to writer")
 
-	output, err := g.generateHTML(ctx, data, opts)
-	if err != nil {
+	output, renderErr := g.generateHTML(ctx, data, opts)
+	if renderErr != nil && !isPartialReport(renderErr) {
+		return renderErr
+	}
+
+	if _, err := io.WriteString(w, output); err != nil {
+		return err
+	}
+
+	return renderErr
+}
+
+// generatePDF renders the comprehensive report, followed by the audit and
+// Data Quality sections when present, as a PDF file. The report is always
+// comprehensive: a PDF is an archival document, and the standard report is
+// a subset of it. The returned string holds the binary PDF.
+//
+// ctx is checked at the boundary between document assembly and PDF
+// rendering.
+func (g *HybridGenerator) generatePDF(ctx context.Context, data *common.CommonDevice, opts Options) (string, error) {
+	var buf bytes.Buffer
+	err := g.generatePDFToWriter(ctx, &buf, data, opts)
+	if err != nil && !isPartialReport(err) {
+		return "", err
+	}
+
+	return buf.String(), err
+}
+
+// generatePDFToWriter writes PDF output to the writer. The PDF is rendered in
+// memory first, so nothing is written when rendering fails.
+func (g *HybridGenerator) generatePDFToWriter(
+	ctx context.Context,
+	w io.Writer,
+	data *common.CommonDevice,
+	opts Options,
+) error {
+	g.logger.Debug("Generating PDF output")
+
+	composer, ok := g.builder.(builder.DocumentComposer)
+	if !ok {
+		return errors.New("report builder does not support PDF generation")
+	}
+
+	if err := g.applyRenderSettings(opts); err != nil {
 		return err
 	}
+	target := prepareForExport(data, opts.Redact)
+
+	doc, renderErr := composer.ComprehensiveReportDocument(target)
+	if doc == nil {
+		return renderErr
+	}
+	if target.ComplianceResults != nil {
+		if audit := composer.AuditSectionDocument(target); audit != nil {
+			doc.Append(audit)
+		}
+	}
+	if dataQuality := composer.DataQualityDocument(opts.DataQuality); dataQuality != nil {
+		doc.Append(dataQuality)
+	}
+
+	// Per-subsystem boundary: between document assembly and PDF rendering.
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	renderer := document.PDFRenderer{
+		Title:     target.System.Hostname + " Configuration Report",
+		Generated: composer.GeneratedTime(),
+	}
+	var buf bytes.Buffer
+	if err := renderer.Render(&buf, doc); err != nil {
+		return err
+	}
+
+	if _, err := buf.WriteTo(w); err != nil {
+		return fmt.Errorf("failed to write PDF: %w", err)
+	}
 
-	_, err = io.WriteString(w, output)
-	return err
+	return renderErr
 }
 
 // SetBuilder sets the report builder for programmatic generation.
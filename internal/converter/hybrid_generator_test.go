@@ -3,13 +3,17 @@ package converter
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"io"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/EvilBit-Labs/opnDossier/internal/analysis"
 	"github.com/EvilBit-Labs/opnDossier/internal/converter/builder"
 	"github.com/EvilBit-Labs/opnDossier/internal/logging"
+	"github.com/EvilBit-Labs/opnDossier/internal/testutil"
 	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -134,6 +138,67 @@ func TestHybridGenerator_GenerateHTMLToWriter(t *testing.T) {
 	assert.Equal(t, direct, buf.String())
 }
 
+func TestHybridGenerator_GeneratePDF(t *testing.T) {
+	t.Parallel()
+
+	gen, err := NewHybridGenerator(builder.NewMarkdownBuilder(), nil)
+	require.NoError(t, err)
+
+	device := testutil.NewDeviceBuilder().
+		WithInterface("lan", "192.168.1.1/24").
+		WithFirewallRule(common.FirewallRule{Type: common.RuleTypePass, Interfaces: []string{"lan"}}).
+		Build()
+	opts := DefaultOptions().WithFormat(FormatPDF)
+	opts.DataQuality = []common.ConversionWarning{{Field: "system.hostname", Message: "defaulted"}}
+
+	output, err := gen.Generate(context.Background(), device, opts)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(output, "%PDF-"), "output should be a PDF file")
+
+	// Outline entries are written uncompressed, one per heading.
+	for _, title := range []string{
+		"System Configuration",
+		"Network Configuration",
+		"Security Configuration",
+		"Data Quality",
+	} {
+		assert.Contains(t, output, pdfTitle(title))
+	}
+	assert.Contains(t, output, pdfTitle(testutil.DefaultHostname+" Configuration Report"))
+}
+
+// pdfTitle returns the /Title entry a PDF stores for an ASCII title: UTF-16BE
+// text with a byte order mark.
+func pdfTitle(title string) string {
+	var sb strings.Builder
+	sb.WriteString("/Title (\xfe\xff")
+	for _, c := range []byte(title) {
+		sb.WriteByte(0)
+		sb.WriteByte(c)
+	}
+	sb.WriteString(")")
+	return sb.String()
+}
+
+func TestHybridGenerator_GeneratePDFToWriter(t *testing.T) {
+	t.Parallel()
+
+	gen, err := NewHybridGenerator(builder.NewMarkdownBuilder(), nil)
+	require.NoError(t, err)
+
+	device := testutil.NewDeviceBuilder().Build()
+	opts := DefaultOptions().WithFormat(FormatPDF)
+
+	var buf bytes.Buffer
+	err = gen.GenerateToWriter(context.Background(), &buf, device, opts)
+	require.NoError(t, err)
+
+	// The builder's generation time is fixed, so both paths render the same bytes.
+	direct, err := gen.Generate(context.Background(), device, opts)
+	require.NoError(t, err)
+	assert.Equal(t, direct, buf.String())
+}
+
 func TestHybridGenerator_GenerateJSON(t *testing.T) {
 	t.Parallel()
 
@@ -208,6 +273,64 @@ func TestHybridGenerator_GenerateMarkdownToWriter(t *testing.T) {
 	assert.Contains(t, buf.String(), "#")
 }
 
+// TestHybridGenerator_ExportMetadata verifies that every serialized format
+// leads with the export metadata, on both the string and streaming paths.
+func TestHybridGenerator_ExportMetadata(t *testing.T) {
+	t.Parallel()
+
+	doc := &common.CommonDevice{
+		DeviceType: common.DeviceTypePfSense,
+		Version:    "23.3",
+		System:     common.System{Firmware: common.Firmware{Version: "2.7.2"}},
+	}
+
+	tests := []struct {
+		format Format
+		prefix string
+		want   []string
+	}{
+		{
+			format: FormatJSON,
+			prefix: "{\n  \"_meta\": {",
+			want:   []string{`"modelVersion": "` + common.ModelVersion + `"`, `"configVersion": "23.3"`},
+		},
+		{
+			format: FormatYAML,
+			prefix: "_meta:\n",
+			want:   []string{"modelVersion: " + common.ModelVersion, "firmwareVersion: 2.7.2"},
+		},
+		{
+			format: FormatMarkdown,
+			prefix: "<!-- _meta: {",
+			want:   []string{`"modelVersion":"` + common.ModelVersion + `"`, `"deviceType":"pfsense"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			t.Parallel()
+
+			gen, err := NewHybridGenerator(builder.NewMarkdownBuilder(), nil)
+			require.NoError(t, err)
+
+			opts := DefaultOptions().WithFormat(tt.format)
+
+			output, err := gen.Generate(context.Background(), doc, opts)
+			require.NoError(t, err)
+
+			var buf bytes.Buffer
+			require.NoError(t, gen.GenerateToWriter(context.Background(), &buf, doc, opts))
+
+			for _, out := range []string{output, buf.String()} {
+				assert.True(t, strings.HasPrefix(out, tt.prefix), "output should start with metadata")
+				for _, w := range tt.want {
+					assert.Contains(t, out, w)
+				}
+			}
+		})
+	}
+}
+
 func TestHybridGenerator_Generate_NilData(t *testing.T) {
 	t.Parallel()
 
@@ -223,6 +346,7 @@ func TestHybridGenerator_Generate_NilData(t *testing.T) {
 		{name: "yaml", format: FormatYAML},
 		{name: "text", format: FormatText},
 		{name: "html", format: FormatHTML},
+		{name: "pdf", format: FormatPDF},
 	}
 
 	for _, tt := range tests {
@@ -251,6 +375,7 @@ func TestHybridGenerator_GenerateToWriter_NilData(t *testing.T) {
 		{name: "yaml", format: FormatYAML},
 		{name: "text", format: FormatText},
 		{name: "html", format: FormatHTML},
+		{name: "pdf", format: FormatPDF},
 	}
 
 	for _, tt := range tests {
@@ -302,6 +427,7 @@ func TestHybridGenerator_Generate_NilBuilder(t *testing.T) {
 		{name: "markdown", format: FormatMarkdown},
 		{name: "text", format: FormatText},
 		{name: "html", format: FormatHTML},
+		{name: "pdf", format: FormatPDF},
 	}
 
 	for _, tt := range formats {
@@ -330,6 +456,7 @@ func TestHybridGenerator_GenerateToWriter_NilBuilder(t *testing.T) {
 		{name: "markdown", format: FormatMarkdown},
 		{name: "text", format: FormatText},
 		{name: "html", format: FormatHTML},
+		{name: "pdf", format: FormatPDF},
 	}
 
 	for _, tt := range formats {
@@ -361,7 +488,14 @@ var _ reportGenerator = (*narrowOnlyBuilder)(nil)
 
 func (n *narrowOnlyBuilder) SetIncludeTunables(_ bool)                       {}
 func (n *narrowOnlyBuilder) SetFailuresOnly(_ bool)                          {}
+func (n *narrowOnlyBuilder) SetPortNames(_ bool)                             {}
+func (n *narrowOnlyBuilder) SetRuleFilter(_ analysis.RuleFilter)             {}
+func (n *narrowOnlyBuilder) SetSectionOrder(_ []string) error                { return nil }
+func (n *narrowOnlyBuilder) SetStrictRender(_ bool)                          {}
 func (n *narrowOnlyBuilder) BuildAuditSection(_ *common.CommonDevice) string { return "" }
+func (n *narrowOnlyBuilder) BuildDataQualitySection(_ []common.ConversionWarning) string {
+	return ""
+}
 func (n *narrowOnlyBuilder) BuildStandardReport(_ *common.CommonDevice) (string, error) {
 	return "", nil
 }
@@ -385,6 +519,50 @@ func TestHybridGenerator_GetBuilder_NarrowBuilder(t *testing.T) {
 	assert.Nil(t, result, "GetBuilder should return nil for a builder not satisfying ReportBuilder")
 }
 
+// partialReportBuilder is a narrowOnlyBuilder whose reports come back with a
+// section replaced by a placeholder.
+type partialReportBuilder struct {
+	narrowOnlyBuilder
+}
+
+func (p *partialReportBuilder) BuildStandardReport(_ *common.CommonDevice) (string, error) {
+	return "# Report\n\nBody text\n", &builder.PartialReportError{Sections: []*builder.SectionRenderError{{
+		Section: "Service Configuration",
+		Err:     errors.New("panic: boom"),
+	}}}
+}
+
+// TestHybridGenerator_PartialReport verifies that a report with a failed
+// section is still produced in every report format, alongside the
+// *builder.PartialReportError.
+func TestHybridGenerator_PartialReport(t *testing.T) {
+	t.Parallel()
+
+	device := &common.CommonDevice{System: common.System{Hostname: "fw01"}}
+
+	for _, format := range []Format{FormatMarkdown, FormatText, FormatHTML} {
+		t.Run(string(format), func(t *testing.T) {
+			t.Parallel()
+
+			gen, err := NewHybridGenerator(builder.NewMarkdownBuilder(), nil)
+			require.NoError(t, err)
+			gen.builder = &partialReportBuilder{}
+
+			opts := DefaultOptions().WithFormat(format)
+			var partial *builder.PartialReportError
+
+			output, err := gen.Generate(context.Background(), device, opts)
+			require.ErrorAs(t, err, &partial)
+			assert.Contains(t, output, "Body text")
+
+			var buf bytes.Buffer
+			err = gen.GenerateToWriter(context.Background(), &buf, device, opts)
+			require.ErrorAs(t, err, &partial)
+			assert.Contains(t, buf.String(), "Body text")
+		})
+	}
+}
+
 // TestHybridGenerator_GetBuilder_NilBuilder verifies that GetBuilder returns nil
 // when the builder field is nil.
 func TestHybridGenerator_GetBuilder_NilBuilder(t *testing.T) {
@@ -476,6 +654,7 @@ func TestHybridGenerator_GenerateToWriter_WriteError(t *testing.T) {
 		{name: "yaml", format: FormatYAML},
 		{name: "text", format: FormatText},
 		{name: "html", format: FormatHTML},
+		{name: "pdf", format: FormatPDF},
 	}
 
 	for _, tt := range formats {
@@ -803,3 +982,173 @@ func TestHybridGenerator_GenerateToWriter_RespectsCanceledContext(t *testing.T)
 		})
 	}
 }
+
+func TestHybridGenerator_GenerateMarkdown_MaxWidth(t *testing.T) {
+	t.Parallel()
+
+	const width = 60
+
+	gen, err := NewHybridGenerator(builder.NewMarkdownBuilder(), nil)
+	require.NoError(t, err)
+
+	device := loadTestDataFromFile(t, "complete.json")
+	opts := DefaultOptions().WithFormat(FormatMarkdown).WithMaxWidth(width)
+	opts.Comprehensive = true
+
+	unwrapped, err := gen.Generate(context.Background(), device, opts.WithMaxWidth(0))
+	require.NoError(t, err)
+
+	wrapped, err := gen.Generate(context.Background(), device, opts)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, gen.GenerateToWriter(context.Background(), &buf, device, opts))
+	assert.Equal(t, wrapped, buf.String(), "streaming and string paths must wrap identically")
+
+	tableRows := func(md string) []string {
+		var rows []string
+		for line := range strings.SplitSeq(md, "\n") {
+			if strings.HasPrefix(strings.TrimSpace(line), "|") {
+				rows = append(rows, line)
+			}
+		}
+		return rows
+	}
+	assert.Equal(t, tableRows(unwrapped), tableRows(wrapped), "table rows must stay single-line")
+
+	inFence := false
+	for line := range strings.SplitSeq(wrapped, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") {
+			inFence = !inFence
+			continue
+		}
+
+		// Only breakable prose is bounded: tables, headings, code, and
+		// single unbreakable words may exceed the width.
+		if inFence || strings.HasPrefix(trimmed, "|") || strings.HasPrefix(trimmed, "#") ||
+			strings.HasPrefix(trimmed, "<") || !strings.Contains(trimmed, " ") {
+			continue
+		}
+
+		assert.LessOrEqual(t, len([]rune(line)), width, "prose line exceeds width: %q", line)
+	}
+}
+
+func TestHybridGenerator_GenerateMarkdown_WrapWidthLeavesProseAlone(t *testing.T) {
+	t.Parallel()
+
+	gen, err := NewHybridGenerator(builder.NewMarkdownBuilder(), nil)
+	require.NoError(t, err)
+
+	device := loadTestDataFromFile(t, "complete.json")
+	opts := DefaultOptions().WithFormat(FormatMarkdown)
+
+	unwrapped, err := gen.Generate(context.Background(), device, opts)
+	require.NoError(t, err)
+
+	// --wrap (and `wrap:` in config files) only sizes terminal rendering;
+	// markdown output must not change unless MaxWidth opts in.
+	withWrap, err := gen.Generate(context.Background(), device, opts.WithWrapWidth(60))
+	require.NoError(t, err)
+	assert.Equal(t, unwrapped, withWrap)
+}
+
+// TestHybridGenerator_PortNames verifies that NoPortNames reaches the builder on
+// both the string and streaming paths.
+func TestHybridGenerator_PortNames(t *testing.T) {
+	t.Parallel()
+
+	device := &common.CommonDevice{
+		FirewallRules: []common.FirewallRule{{
+			Type:        common.RuleTypePass,
+			Interfaces:  []string{"lan"},
+			Protocol:    "tcp",
+			Destination: common.RuleEndpoint{Address: "any", Port: "443"},
+		}},
+	}
+
+	for _, noPortNames := range []bool{false, true} {
+		gen, err := NewHybridGenerator(builder.NewMarkdownBuilder(), nil)
+		require.NoError(t, err)
+
+		opts := DefaultOptions().WithPortNames(!noPortNames)
+		out, err := gen.Generate(context.Background(), device, opts)
+		require.NoError(t, err)
+
+		var buf bytes.Buffer
+		require.NoError(t, gen.GenerateToWriter(context.Background(), &buf, device, opts))
+
+		for _, report := range []string{out, buf.String()} {
+			if noPortNames {
+				assert.NotContains(t, report, "443 (https)")
+				assert.Contains(t, report, "| 443 |")
+			} else {
+				assert.Contains(t, report, "443 (https)")
+			}
+		}
+	}
+}
+
+// TestHybridGenerator_RuleFilter verifies that an interface and action filter
+// narrows the markdown rule tables under a banner with the shown count, and
+// that the JSON export lists the same rules and records the filter.
+func TestHybridGenerator_RuleFilter(t *testing.T) {
+	t.Parallel()
+
+	device := &common.CommonDevice{
+		FirewallRules: []common.FirewallRule{
+			{Type: common.RuleTypeBlock, Interfaces: []string{"wan"}, Description: "block bogons"},
+			{Type: common.RuleTypePass, Interfaces: []string{"wan"}, Description: "allow vpn"},
+			{Type: common.RuleTypeBlock, Interfaces: []string{"lan"}, Description: "block guests"},
+			{Type: common.RuleTypeBlock, Interfaces: []string{"WAN"}, Description: "block telnet"},
+		},
+		NAT: common.NATConfig{InboundRules: []common.InboundNATRule{
+			{Interfaces: []string{"wan"}, ExternalPort: "8443", Description: "forward web"},
+			{Interfaces: []string{"lan"}, ExternalPort: "53", Description: "redirect dns"},
+		}},
+	}
+	filter := analysis.RuleFilter{Interface: "wan", Action: "block"}
+
+	gen, err := NewHybridGenerator(builder.NewMarkdownBuilder(), nil)
+	require.NoError(t, err)
+
+	report, err := gen.Generate(context.Background(), device, DefaultOptions().WithRuleFilter(filter))
+	require.NoError(t, err)
+
+	assert.Contains(t, report, "Filtered by interface=wan, action=block — showing 2 of 4 rules.")
+	assert.Contains(t, report, "Filtered by interface=wan, action=block — showing 1 of 2 rules.")
+	// Rule table rows end with the description; summaries such as the WAN
+	// port exposure table still cover every rule.
+	for _, kept := range []string{"| ✓ | block bogons |", "| ✓ | block telnet |", "| forward web |"} {
+		assert.Contains(t, report, kept)
+	}
+	for _, dropped := range []string{"| ✓ | allow vpn |", "| ✓ | block guests |", "| redirect dns |"} {
+		assert.NotContains(t, report, dropped)
+	}
+	assert.Contains(t, report, `<a id="firewall-rule-4"></a>4`, "filtered rows keep their rule numbers")
+
+	output, err := gen.Generate(context.Background(), device, DefaultOptions().
+		WithFormat(FormatJSON).
+		WithRuleFilter(filter))
+	require.NoError(t, err)
+
+	var export struct {
+		Meta          common.ExportMeta     `json:"_meta"`
+		FirewallRules []common.FirewallRule `json:"firewallRules"`
+		NAT           common.NATConfig      `json:"nat"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(output), &export))
+
+	descriptions := make([]string, 0, len(export.FirewallRules))
+	for _, rule := range export.FirewallRules {
+		descriptions = append(descriptions, rule.Description)
+	}
+	assert.Equal(t, []string{"block bogons", "block telnet"}, descriptions)
+	require.Len(t, export.NAT.InboundRules, 1)
+	assert.Equal(t, "forward web", export.NAT.InboundRules[0].Description)
+	assert.Equal(t, &common.ExportRuleFilter{Interface: "wan", Action: "block", Shown: 3, Total: 6},
+		export.Meta.RuleFilter)
+
+	assert.Len(t, device.FirewallRules, 4, "filtering must not modify the source device")
+}
@@ -27,8 +27,8 @@ func (c *JSONConverter) ToJSON(_ context.Context, data *common.CommonDevice, red
 
 	target := prepareForExport(data, redact)
 
-	// Marshal the CommonDevice struct to JSON with indentation
-	jsonBytes, err := json.MarshalIndent(target, "", "  ")
+	// Marshal the export document (_meta followed by the device) with indentation
+	jsonBytes, err := json.MarshalIndent(newExportDocument(target, nil), "", "  ")
 	if err != nil {
 		return "", fmt.Errorf("failed to marshal to JSON: %w", err)
 	}
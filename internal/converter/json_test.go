@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -18,6 +19,15 @@ func TestJSONConverter_ToJSON(t *testing.T) {
 				var parsed map[string]any
 				err := json.Unmarshal([]byte(result), &parsed)
 				require.NoError(t, err, "Result should be valid JSON")
+
+				meta, ok := parsed["_meta"].(map[string]any)
+				require.True(t, ok, "JSON export should carry a _meta object")
+				assert.Equal(t, common.ModelVersion, meta["modelVersion"])
+				assert.NotEmpty(t, meta["toolVersion"])
+
+				system, ok := parsed["system"].(map[string]any)
+				require.True(t, ok, "device fields should stay at the top level")
+				assert.Equal(t, "test-host", system["hostname"])
 			}
 		}
 	}
@@ -28,3 +38,33 @@ func TestJSONConverter_ToJSON(t *testing.T) {
 	}
 	RunConverterTests(t, tests, convertFunc)
 }
+
+func TestJSONConverter_ToJSON_Compatibility(t *testing.T) {
+	note := "config format 25.1 newer than tested 24.7 — some sections may be unreported"
+
+	for _, tt := range []struct {
+		name          string
+		compatibility string
+	}{
+		{name: "tested format"},
+		{name: "newer format", compatibility: note},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			device := &common.CommonDevice{Version: "25.1", Compatibility: tt.compatibility}
+
+			result, err := NewJSONConverter().ToJSON(context.Background(), device, false)
+			require.NoError(t, err)
+
+			var parsed struct {
+				Meta map[string]any `json:"_meta"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(result), &parsed))
+
+			if tt.compatibility == "" {
+				assert.NotContains(t, parsed.Meta, "compatibility")
+				return
+			}
+			assert.Equal(t, note, parsed.Meta["compatibility"])
+		})
+	}
+}
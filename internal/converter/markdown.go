@@ -192,7 +192,7 @@ func (c *MarkdownConverter) buildNetworkSection(md *markdown.Markdown, data *com
 		if name == "" {
 			name = "unnamed"
 		}
-		sectionName := strings.ToUpper(name[:1]) + strings.ToLower(name[1:]) + " Interface"
+		sectionName := formatters.CapitalizeName(name) + " Interface"
 		md.H3(sectionName)
 		buildInterfaceDetails(md, iface)
 	}
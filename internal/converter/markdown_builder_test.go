@@ -2,8 +2,11 @@ package converter
 
 import (
 	"context"
+	"regexp"
 	"strings"
 	"testing"
+	"time"
+	"unicode"
 
 	builderPkg "github.com/EvilBit-Labs/opnDossier/internal/converter/builder"
 	"github.com/EvilBit-Labs/opnDossier/internal/converter/formatters"
@@ -54,6 +57,64 @@ func TestMarkdownBuilder_BuildSystemSection(t *testing.T) {
 	assert.Contains(t, result, "admin")
 }
 
+func TestMarkdownBuilder_BuildSystemSection_Console(t *testing.T) {
+	builder := builderPkg.NewMarkdownBuilder()
+
+	data := createComprehensiveTestData()
+	result := builder.BuildSystemSection(data)
+	assert.NotContains(t, result, "Console Configuration")
+
+	data.System.Console = common.Console{SerialSpeed: 115200, PrimaryConsole: common.PrimaryConsoleSerial}
+	result = builder.BuildSystemSection(data)
+
+	assert.Contains(t, result, "Console Configuration")
+	assert.Contains(t, result, "**Primary Console**: serial")
+	assert.Contains(t, result, "**Serial Speed**: 115200 baud")
+}
+
+func TestMarkdownBuilder_BuildSystemSection_AuthServers(t *testing.T) {
+	builder := builderPkg.NewMarkdownBuilder()
+
+	data := createComprehensiveTestData()
+	assert.NotContains(t, builder.BuildSystemSection(data), "Authentication Servers")
+
+	data.AuthServers = []common.AuthServer{
+		{
+			Name:         "corp-ldap",
+			Type:         common.AuthServerLDAP,
+			Host:         "dc1.example.com",
+			Port:         "389",
+			Transport:    common.LDAPTransportTCP,
+			BaseDN:       "dc=example,dc=com",
+			BindPassword: "[REDACTED]",
+		},
+		{Name: "corp-ldaps", Type: common.AuthServerLDAP, Host: "dc2.example.com", Port: "636", Transport: common.LDAPTransportSSL},
+	}
+
+	result := builder.BuildSystemSection(data)
+	assert.Contains(t, result, "### Authentication Servers")
+	assert.Contains(t, result, "| Name | Type | Host:Port | Transport | Base DN |")
+	assert.Contains(t, result, "| corp-ldap | LDAP | dc1.example.com:389 | tcp | dc=example,dc=com |")
+	assert.Contains(t, result, "| corp-ldaps | LDAP | dc2.example.com:636 | ssl |  |")
+	assert.NotContains(t, result, "REDACTED")
+}
+
+func TestMarkdownBuilder_BuildSystemSection_WebGUICert(t *testing.T) {
+	builder := builderPkg.NewMarkdownBuilder()
+
+	data := createComprehensiveTestData()
+	data.System.WebGUI.Protocol = "https"
+	data.Certificates = []common.Certificate{{RefID: "cert-1", Description: "Web GUI TLS certificate"}}
+
+	assert.NotContains(t, builder.BuildSystemSection(data), "SSL Certificate")
+
+	data.System.WebGUI.SSLCertRef = "cert-1"
+	assert.Contains(t, builder.BuildSystemSection(data), "**SSL Certificate**: Web GUI TLS certificate")
+
+	data.System.WebGUI.SSLCertRef = "missing"
+	assert.Contains(t, builder.BuildSystemSection(data), "**SSL Certificate**: missing (not found)")
+}
+
 func TestMarkdownBuilder_BuildNetworkSection(t *testing.T) {
 	builder := builderPkg.NewMarkdownBuilder()
 
@@ -104,6 +165,23 @@ func TestMarkdownBuilder_BuildNetworkSection(t *testing.T) {
 	assert.Contains(t, result, "LAN Interface")
 }
 
+func TestMarkdownBuilder_NATReflectionConsistent(t *testing.T) {
+	builder := builderPkg.NewMarkdownBuilder()
+
+	// System says disabled, NAT config does not: both sections must agree.
+	data := &common.CommonDevice{
+		System: common.System{Hostname: "fw", DisableNATReflection: true},
+		NAT:    common.NATConfig{OutboundMode: common.OutboundAutomatic},
+	}
+
+	system := builder.BuildSystemSection(data)
+	security := builder.BuildSecuritySection(data)
+
+	assert.Contains(t, system, "**Disable NAT Reflection**: ✓")
+	assert.Contains(t, security, "**NAT Reflection**: ✓")
+	assert.Contains(t, security, "NAT reflection is properly disabled")
+}
+
 func TestMarkdownBuilder_BuildSecuritySection(t *testing.T) {
 	builder := builderPkg.NewMarkdownBuilder()
 
@@ -249,7 +327,7 @@ func TestMarkdownBuilder_BuildFirewallRulesTable(t *testing.T) {
 		},
 	}
 
-	tableSet := builderPkg.BuildFirewallRulesTableSet(rules)
+	tableSet := builderPkg.BuildFirewallRulesTableSet(rules, true)
 
 	assert.NotNil(t, tableSet)
 	assert.Len(t, tableSet.Header, 12)
@@ -274,18 +352,18 @@ func TestMarkdownBuilder_BuildFirewallRulesTable(t *testing.T) {
 
 	// Verify first row
 	row := tableSet.Rows[0]
-	assert.Equal(t, "1", row[0])                           // #
-	assert.Contains(t, row[1], "lan")                      // Interface (with link)
-	assert.Equal(t, string(common.RuleTypePass), row[2])   // Action
-	assert.Equal(t, string(common.IPProtocolInet), row[3]) // IP Ver
-	assert.Equal(t, "tcp", row[4])                         // Proto
-	assert.Equal(t, "lan", row[5])                         // Source
-	assert.Equal(t, "any", row[6])                         // Destination
-	assert.Empty(t, row[7])                                // Target
-	assert.Equal(t, "80", row[8])                          // Source Port
-	assert.Empty(t, row[9])                                // Dest Port
-	assert.Equal(t, "✓", row[10])                          // Enabled
-	assert.Equal(t, "Allow LAN to WAN", row[11])           // Description
+	assert.Equal(t, `<a id="firewall-rule-1"></a>1`, row[0]) // #
+	assert.Contains(t, row[1], "lan")                        // Interface (with link)
+	assert.Equal(t, string(common.RuleTypePass), row[2])     // Action
+	assert.Equal(t, string(common.IPProtocolInet), row[3])   // IP Ver
+	assert.Equal(t, "tcp", row[4])                           // Proto
+	assert.Equal(t, "lan", row[5])                           // Source
+	assert.Equal(t, "any", row[6])                           // Destination
+	assert.Empty(t, row[7])                                  // Target
+	assert.Equal(t, "80 (http)", row[8])                     // Source Port
+	assert.Empty(t, row[9])                                  // Dest Port
+	assert.Equal(t, "✓", row[10])                            // Enabled
+	assert.Equal(t, "Allow LAN to WAN", row[11])             // Description
 }
 
 func TestMarkdownBuilder_BuildInterfaceTable(t *testing.T) {
@@ -357,14 +435,14 @@ func TestMarkdownBuilder_BuildUserTable(t *testing.T) {
 		},
 	}
 
-	tableSet := builderPkg.BuildUserTableSet(users)
+	tableSet := builderPkg.BuildUserTableSet(users, time.Now())
 
 	assert.NotNil(t, tableSet)
-	assert.Len(t, tableSet.Header, 4)
+	assert.Len(t, tableSet.Header, 6)
 	assert.Len(t, tableSet.Rows, 2)
 
 	// Verify headers
-	expectedHeaders := []string{"Name", "Description", "Group", "Scope"}
+	expectedHeaders := []string{"Name", "Description", "Group", "Scope", "SSH Key", "Expires"}
 	assert.Equal(t, expectedHeaders, tableSet.Header)
 
 	// Verify first row
@@ -392,11 +470,11 @@ func TestMarkdownBuilder_BuildGroupTable(t *testing.T) {
 	tableSet := builderPkg.BuildGroupTableSet(groups)
 
 	assert.NotNil(t, tableSet)
-	assert.Len(t, tableSet.Header, 3)
+	assert.Len(t, tableSet.Header, 4)
 	assert.Len(t, tableSet.Rows, 2)
 
 	// Verify headers
-	expectedHeaders := []string{"Name", "Description", "Scope"}
+	expectedHeaders := []string{"Name", "Description", "Scope", "Privileges"}
 	assert.Equal(t, expectedHeaders, tableSet.Header)
 
 	// Verify first row
@@ -404,6 +482,7 @@ func TestMarkdownBuilder_BuildGroupTable(t *testing.T) {
 	assert.Equal(t, "wheel", row[0])
 	assert.Equal(t, "Wheel group", row[1])
 	assert.Equal(t, "system", row[2])
+	assert.Equal(t, "0", row[3])
 }
 
 func TestMarkdownBuilder_BuildSysctlTable(t *testing.T) {
@@ -681,7 +760,7 @@ func TestBuildFirewallRulesTable_EdgeCases(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := builderPkg.BuildFirewallRulesTableSet(tt.rules)
+			result := builderPkg.BuildFirewallRulesTableSet(tt.rules, true)
 			assert.NotNil(t, result)
 			assert.Len(t, result.Header, 12) // Should have 12 headers
 		})
@@ -757,30 +836,32 @@ func TestBuildFirewallRulesTable_AnyFieldAndDestPort(t *testing.T) {
 			name: "destination_port_populated",
 			rule: common.FirewallRule{
 				Type:        common.RuleTypePass,
+				Protocol:    "tcp",
 				Source:      common.RuleEndpoint{Address: "any"},
 				Destination: common.RuleEndpoint{Address: "wan", Port: "443"},
 			},
 			wantSource: "any",
 			wantDest:   "wan",
-			wantDPort:  "443",
+			wantDPort:  "443 (https)",
 		},
 		{
 			name: "destination_any_with_port",
 			rule: common.FirewallRule{
 				Type:        common.RuleTypePass,
+				Protocol:    "tcp",
 				Source:      common.RuleEndpoint{Address: "any"},
 				Destination: common.RuleEndpoint{Address: "any", Port: "80,443"},
 			},
 			wantSource: "any",
 			wantDest:   "any",
-			wantDPort:  "80,443",
+			wantDPort:  "80 (http), 443 (https)",
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			result := builderPkg.BuildFirewallRulesTableSet([]common.FirewallRule{tt.rule})
+			result := builderPkg.BuildFirewallRulesTableSet([]common.FirewallRule{tt.rule}, true)
 			assert.Len(t, result.Header, 12)
 			assert.Len(t, result.Rows, 1)
 			row := result.Rows[0]
@@ -1232,6 +1313,47 @@ func TestMarkdownBuilder_BuildServicesSection_WithLoadBalancerMonitors(t *testin
 	assert.Contains(t, result, "TCP Health Check")
 }
 
+func TestMarkdownBuilder_BuildServicesSection_WithLoadBalancerPools(t *testing.T) {
+	builder := builderPkg.NewMarkdownBuilder()
+
+	data := &common.CommonDevice{
+		LoadBalancer: common.LoadBalancerConfig{
+			MonitorTypes: []common.MonitorType{{Name: "HTTP", Type: "http"}},
+			Pools: []common.LBPool{
+				{
+					Name:            "web-pool",
+					Mode:            "loadbalance",
+					Port:            "8080",
+					Monitor:         "HTTP",
+					Servers:         []string{"10.0.1.10", "10.0.1.11"},
+					DisabledServers: []string{"10.0.1.12"},
+				},
+				{Name: "sorry-pool", Mode: "failover", Servers: []string{"10.0.1.99"}},
+			},
+			VirtualServers: []common.LBVirtualServer{
+				{
+					Name:         "web-vip",
+					Address:      "203.0.113.10",
+					Port:         "443",
+					Pool:         "web-pool",
+					FallbackPool: "sorry-pool",
+					Description:  "Public web",
+				},
+				{Name: "stale-vip", Address: "203.0.113.11", Port: "80", Pool: "missing-pool"},
+			},
+		},
+	}
+
+	result := builder.BuildServicesSection(data)
+
+	assert.Contains(t, result, "Load Balancer Pools")
+	assert.Contains(t, result, "Virtual Servers")
+	assert.Contains(t, result, "10.0.1.10, 10.0.1.11 (port 8080)", "virtual server should list resolved backends")
+	assert.Contains(t, result, "web-vip (fallback)", "fallback pool should be cross-linked to its virtual server")
+	assert.Contains(t, result, "pool not found")
+	assert.Contains(t, result, "10.0.1.12")
+}
+
 func TestMarkdownBuilder_BuildStandardReport_WithUsersAndSysctl(t *testing.T) {
 	builder := builderPkg.NewMarkdownBuilder()
 	builder.SetIncludeTunables(true)
@@ -1355,7 +1477,7 @@ func TestMarkdownBuilder_BuildFirewallRulesTable_WithComplexRules(t *testing.T)
 		},
 	}
 
-	tableSet := builderPkg.BuildFirewallRulesTableSet(rules)
+	tableSet := builderPkg.BuildFirewallRulesTableSet(rules, true)
 
 	assert.NotNil(t, tableSet)
 	assert.Len(t, tableSet.Header, 12)
@@ -1363,34 +1485,34 @@ func TestMarkdownBuilder_BuildFirewallRulesTable_WithComplexRules(t *testing.T)
 
 	// Verify first row (disabled rule)
 	row1 := tableSet.Rows[0]
-	assert.Equal(t, "1", row1[0])                           // #
-	assert.Contains(t, row1[1], "wan")                      // Interface
-	assert.Equal(t, string(common.RuleTypePass), row1[2])   // Action
-	assert.Equal(t, string(common.IPProtocolInet), row1[3]) // IP Ver
-	assert.Equal(t, "tcp", row1[4])                         // Proto
-	assert.Equal(t, "any", row1[5])                         // Source
-	assert.Equal(t, "lan", row1[6])                         // Destination
-	assert.Equal(t, "lan", row1[7])                         // Target
-	assert.Equal(t, "443", row1[8])                         // Source Port
-	assert.Empty(t, row1[9])                                // Dest Port
-	assert.Equal(t, "✗", row1[10])                          // Enabled (disabled)
-	assert.Equal(t, "Allow HTTPS", row1[11])                // Description
+	assert.Equal(t, `<a id="firewall-rule-1"></a>1`, row1[0]) // #
+	assert.Contains(t, row1[1], "wan")                        // Interface
+	assert.Equal(t, string(common.RuleTypePass), row1[2])     // Action
+	assert.Equal(t, string(common.IPProtocolInet), row1[3])   // IP Ver
+	assert.Equal(t, "tcp", row1[4])                           // Proto
+	assert.Equal(t, "any", row1[5])                           // Source
+	assert.Equal(t, "lan", row1[6])                           // Destination
+	assert.Equal(t, "lan", row1[7])                           // Target
+	assert.Equal(t, "443 (https)", row1[8])                   // Source Port
+	assert.Empty(t, row1[9])                                  // Dest Port
+	assert.Equal(t, "✗", row1[10])                            // Enabled (disabled)
+	assert.Equal(t, "Allow HTTPS", row1[11])                  // Description
 
 	// Verify second row (enabled rule)
 	row2 := tableSet.Rows[1]
-	assert.Equal(t, "2", row2[0])                            // #
-	assert.Contains(t, row2[1], "wan")                       // Interface
-	assert.Contains(t, row2[1], "lan")                       // Interface
-	assert.Equal(t, string(common.RuleTypeBlock), row2[2])   // Action
-	assert.Equal(t, string(common.IPProtocolInet6), row2[3]) // IP Ver
-	assert.Equal(t, "tcp", row2[4])                          // Proto
-	assert.Equal(t, "lan", row2[5])                          // Source
-	assert.Equal(t, "wan", row2[6])                          // Destination
-	assert.Empty(t, row2[7])                                 // Target
-	assert.Equal(t, "22", row2[8])                           // Source Port
-	assert.Empty(t, row2[9])                                 // Dest Port
-	assert.Equal(t, "✓", row2[10])                           // Enabled
-	assert.Equal(t, "Block SSH", row2[11])                   // Description
+	assert.Equal(t, `<a id="firewall-rule-2"></a>2`, row2[0]) // #
+	assert.Contains(t, row2[1], "wan")                        // Interface
+	assert.Contains(t, row2[1], "lan")                        // Interface
+	assert.Equal(t, string(common.RuleTypeBlock), row2[2])    // Action
+	assert.Equal(t, string(common.IPProtocolInet6), row2[3])  // IP Ver
+	assert.Equal(t, "tcp", row2[4])                           // Proto
+	assert.Equal(t, "lan", row2[5])                           // Source
+	assert.Equal(t, "wan", row2[6])                           // Destination
+	assert.Empty(t, row2[7])                                  // Target
+	assert.Equal(t, "22 (ssh)", row2[8])                      // Source Port
+	assert.Empty(t, row2[9])                                  // Dest Port
+	assert.Equal(t, "✓", row2[10])                            // Enabled
+	assert.Equal(t, "Block SSH", row2[11])                    // Description
 }
 
 func TestMarkdownBuilder_BuildInterfaceTable_WithComplexInterfaces(t *testing.T) {
@@ -1730,24 +1852,24 @@ func TestMarkdownBuilder_BuildOutboundNATTable_WithRules(t *testing.T) {
 
 	// Verify first row (active rule)
 	row := tableSet.Rows[0]
-	assert.Equal(t, "1", row[0])              // #
-	assert.Equal(t, "⬆️ Outbound", row[1])    // Direction
-	assert.Contains(t, row[2], "wan")         // Interface (with link)
-	assert.Equal(t, "lan", row[3])            // Source
-	assert.Equal(t, "any", row[4])            // Destination
-	assert.Equal(t, "`wan_ip`", row[5])       // Target
-	assert.Equal(t, "tcp", row[6])            // Protocol
-	assert.Equal(t, "LAN to WAN NAT", row[7]) // Description
-	assert.Equal(t, "**Active**", row[8])     // Status
+	assert.Equal(t, `<a id="outbound-nat-rule-1"></a>1`, row[0]) // #
+	assert.Equal(t, "⬆️ Outbound", row[1])                       // Direction
+	assert.Contains(t, row[2], "wan")                            // Interface (with link)
+	assert.Equal(t, "lan", row[3])                               // Source
+	assert.Equal(t, "any", row[4])                               // Destination
+	assert.Equal(t, "`wan_ip`", row[5])                          // Target
+	assert.Equal(t, "tcp", row[6])                               // Protocol
+	assert.Equal(t, "LAN to WAN NAT", row[7])                    // Description
+	assert.Equal(t, "**Active**", row[8])                        // Status
 
 	// Verify second row (disabled rule)
 	row2 := tableSet.Rows[1]
-	assert.Equal(t, "2", row2[0])                  // #
-	assert.Equal(t, "⬆️ Outbound", row2[1])        // Direction
-	assert.Equal(t, "dmz", row2[3])                // Source
-	assert.Equal(t, "any", row2[6])                // Protocol (default when empty)
-	assert.Equal(t, "**Disabled**", row2[8])       // Status
-	assert.Equal(t, "DMZ NAT (disabled)", row2[7]) // Description
+	assert.Equal(t, `<a id="outbound-nat-rule-2"></a>2`, row2[0]) // #
+	assert.Equal(t, "⬆️ Outbound", row2[1])                       // Direction
+	assert.Equal(t, "dmz", row2[3])                               // Source
+	assert.Equal(t, "any", row2[6])                               // Protocol (default when empty)
+	assert.Equal(t, "**Disabled**", row2[8])                      // Status
+	assert.Equal(t, "DMZ NAT (disabled)", row2[7])                // Description
 }
 
 func TestMarkdownBuilder_BuildOutboundNATTable_EmptyRules(t *testing.T) {
@@ -1816,7 +1938,7 @@ func TestMarkdownBuilder_BuildInboundNATTable_WithRules(t *testing.T) {
 		},
 	}
 
-	tableSet := builderPkg.BuildInboundNATTableSet(rules)
+	tableSet := builderPkg.BuildInboundNATTableSet(rules, true)
 
 	assert.NotNil(t, tableSet)
 	assert.Len(
@@ -1843,31 +1965,31 @@ func TestMarkdownBuilder_BuildInboundNATTable_WithRules(t *testing.T) {
 
 	// Verify first row (active rule)
 	row := tableSet.Rows[0]
-	assert.Equal(t, "1", row[0])                     // #
-	assert.Equal(t, "⬇️ Inbound", row[1])            // Direction
-	assert.Contains(t, row[2], "wan")                // Interface (with link)
-	assert.Equal(t, "443", row[3])                   // External Port
-	assert.Equal(t, "`192.168.1.10`", row[4])        // Target IP
-	assert.Equal(t, "443", row[5])                   // Target Port
-	assert.Equal(t, "tcp", row[6])                   // Protocol
-	assert.Equal(t, "Web server forwarding", row[7]) // Description
-	assert.Equal(t, "10", row[8])                    // Priority
-	assert.Equal(t, "**Active**", row[9])            // Status
+	assert.Equal(t, `<a id="inbound-nat-rule-1"></a>1`, row[0]) // #
+	assert.Equal(t, "⬇️ Inbound", row[1])                       // Direction
+	assert.Contains(t, row[2], "wan")                           // Interface (with link)
+	assert.Equal(t, "443 (https)", row[3])                      // External Port
+	assert.Equal(t, "`192.168.1.10`", row[4])                   // Target IP
+	assert.Equal(t, "443 (https)", row[5])                      // Target Port
+	assert.Equal(t, "tcp", row[6])                              // Protocol
+	assert.Equal(t, "Web server forwarding", row[7])            // Description
+	assert.Equal(t, "10", row[8])                               // Priority
+	assert.Equal(t, "**Active**", row[9])                       // Status
 
 	// Verify second row (disabled rule)
 	row2 := tableSet.Rows[1]
-	assert.Equal(t, "2", row2[0])              // #
-	assert.Equal(t, "⬇️ Inbound", row2[1])     // Direction
-	assert.Equal(t, "8080", row2[3])           // External Port
-	assert.Equal(t, "`192.168.1.20`", row2[4]) // Target IP
-	assert.Equal(t, "80", row2[5])             // Target Port
-	assert.Equal(t, "**Disabled**", row2[9])   // Status
+	assert.Equal(t, `<a id="inbound-nat-rule-2"></a>2`, row2[0]) // #
+	assert.Equal(t, "⬇️ Inbound", row2[1])                       // Direction
+	assert.Equal(t, "8080 (http-alt)", row2[3])                  // External Port
+	assert.Equal(t, "`192.168.1.20`", row2[4])                   // Target IP
+	assert.Equal(t, "80 (http)", row2[5])                        // Target Port
+	assert.Equal(t, "**Disabled**", row2[9])                     // Status
 }
 
 func TestMarkdownBuilder_BuildInboundNATTable_EmptyRules(t *testing.T) {
 	rules := []common.InboundNATRule{}
 
-	tableSet := builderPkg.BuildInboundNATTableSet(rules)
+	tableSet := builderPkg.BuildInboundNATTableSet(rules, true)
 
 	assert.NotNil(t, tableSet)
 	assert.Len(t, tableSet.Header, 10)
@@ -1894,7 +2016,7 @@ func TestMarkdownBuilder_BuildInboundNATTable_SpecialCharacters(t *testing.T) {
 		},
 	}
 
-	tableSet := builderPkg.BuildInboundNATTableSet(rules)
+	tableSet := builderPkg.BuildInboundNATTableSet(rules, true)
 
 	assert.NotNil(t, tableSet)
 	// Description should be escaped for markdown tables
@@ -2053,7 +2175,7 @@ func TestMarkdownBuilder_NATRulesWithInterfaceLinks(t *testing.T) {
 		},
 	}
 
-	inboundTableSet := builderPkg.BuildInboundNATTableSet(inboundRules)
+	inboundTableSet := builderPkg.BuildInboundNATTableSet(inboundRules, true)
 
 	// Verify inbound rule interface links
 	inRow1 := inboundTableSet.Rows[0]
@@ -2137,6 +2259,34 @@ func TestMarkdownBuilder_BuildIDSSection_Enabled(t *testing.T) {
 	assert.Contains(t, result, "EVE JSON logging is enabled")
 }
 
+func TestMarkdownBuilder_BuildIDSSection_Policies(t *testing.T) {
+	builder := builderPkg.NewMarkdownBuilder()
+
+	data := &common.CommonDevice{
+		IDS: &common.IDSConfig{Enabled: true, IPSMode: true},
+	}
+	assert.NotContains(t, builder.BuildIDSSection(data), "IDS Policy Actions")
+
+	data.IDS.Policies = []common.IDSPolicy{
+		{
+			Description:  "Block major",
+			Enabled:      true,
+			Priority:     "10",
+			Rulesets:     []string{"rs-1"},
+			MatchActions: []string{"alert"},
+			Filters:      []string{"signature_severity.Major"},
+			Action:       "drop",
+		},
+		{Description: "Trial", Priority: "20", Action: "alert"},
+	}
+
+	result := builder.BuildIDSSection(data)
+	assert.Contains(t, result, "#### IDS Policy Actions")
+	assert.Contains(t, result, "| Description | Priority | Status | Rulesets | Matches | Action |")
+	assert.Contains(t, result, `| Block major | 10 | Enabled | rs-1 | alert, signature\_severity.Major | drop |`)
+	assert.Contains(t, result, "| Trial | 20 | Disabled | any | any | alert |")
+}
+
 func TestMarkdownBuilder_BuildIDSSection_IDSMode(t *testing.T) {
 	builder := builderPkg.NewMarkdownBuilder()
 
@@ -2194,3 +2344,537 @@ func TestMarkdownBuilder_BuildSecuritySection_IncludesIDS(t *testing.T) {
 	assert.Contains(t, result, "Security Configuration")
 	assert.Contains(t, result, "Intrusion Detection System (IDS/Suricata)")
 }
+
+func TestMarkdownBuilder_BuildPFSettingsSection(t *testing.T) {
+	builder := builderPkg.NewMarkdownBuilder()
+
+	data := &common.CommonDevice{
+		PF: &common.PFSettings{
+			StateScale:      "adaptive",
+			MaxStates:       250000,
+			MaxTableEntries: "400000",
+			Timeouts: []common.PFTimeout{
+				{Name: "tcp.established", Seconds: "86400"},
+				{Name: "udp.multiple", Seconds: "60"},
+			},
+		},
+	}
+
+	result := builder.BuildPFSettingsSection(data)
+
+	assert.Contains(t, result, "Stateful Inspection")
+	assert.Contains(t, result, "250000")
+	assert.Contains(t, result, "adaptive")
+	assert.Contains(t, result, "400000")
+	assert.Contains(t, result, "State Timeouts")
+	assert.Contains(t, result, "`tcp.established`")
+	assert.Contains(t, result, "86400")
+	assert.NotContains(t, result, "Maximum Fragments")
+}
+
+func TestMarkdownBuilder_BuildPFSettingsSection_NoLimitOrTimeouts(t *testing.T) {
+	builder := builderPkg.NewMarkdownBuilder()
+
+	result := builder.BuildPFSettingsSection(&common.CommonDevice{
+		PF: &common.PFSettings{MaxSourceNodes: "20000"},
+	})
+
+	assert.Contains(t, result, "Default")
+	assert.Contains(t, result, "20000")
+	assert.NotContains(t, result, "State Timeouts")
+}
+
+func TestMarkdownBuilder_BuildPFSettingsSection_NilPF(t *testing.T) {
+	builder := builderPkg.NewMarkdownBuilder()
+
+	assert.Empty(t, builder.BuildPFSettingsSection(&common.CommonDevice{}))
+}
+
+func TestMarkdownBuilder_BuildInterfaceHeatmapSection(t *testing.T) {
+	builder := builderPkg.NewMarkdownBuilder()
+
+	rules := make([]common.FirewallRule, 0, 52)
+	for range 51 {
+		rules = append(rules, common.FirewallRule{Type: common.RuleTypePass, Interfaces: []string{"lan"}})
+	}
+	rules = append(rules, common.FirewallRule{Type: common.RuleTypeBlock, Interfaces: []string{"wan"}})
+
+	result := builder.BuildInterfaceHeatmapSection(&common.CommonDevice{
+		Interfaces:    []common.Interface{{Name: "wan"}, {Name: "lan"}, {Name: "opt1"}},
+		FirewallRules: rules,
+	})
+
+	assert.Contains(t, result, "Rules per Interface")
+	assert.Contains(t, result, "| **lan** | **51** | **0** | **51** |")
+	assert.Contains(t, result, "| wan | 0 | 1 | 1 |")
+	assert.Contains(t, result, "| *opt1* | *0* | *0* | *0* |")
+
+	lan := strings.Index(result, "**lan**")
+	wan := strings.Index(result, "| wan |")
+	opt1 := strings.Index(result, "*opt1*")
+	assert.Less(t, lan, wan, "busiest interface should come first")
+	assert.Less(t, wan, opt1, "interfaces without rules should come last")
+}
+
+func TestMarkdownBuilder_BuildInterfaceHeatmapSection_Empty(t *testing.T) {
+	builder := builderPkg.NewMarkdownBuilder()
+
+	assert.Empty(t, builder.BuildInterfaceHeatmapSection(&common.CommonDevice{}))
+}
+
+func TestMarkdownBuilder_BuildSchedulesSection(t *testing.T) {
+	builder := builderPkg.NewMarkdownBuilder()
+
+	result := builder.BuildSchedulesSection(&common.CommonDevice{
+		FirewallRules: []common.FirewallRule{
+			{Type: common.RuleTypePass, Schedule: "OfficeHours"},
+			{Type: common.RuleTypeBlock, Schedule: "OfficeHours"},
+		},
+		Schedules: []common.Schedule{
+			{
+				Name:        "OfficeHours",
+				Description: "Weekdays",
+				TimeRanges: []common.ScheduleTimeRange{
+					{Weekdays: []int{1, 2, 7}, Hours: "8:00-17:00"},
+				},
+			},
+			{
+				Name: "Maintenance",
+				TimeRanges: []common.ScheduleTimeRange{
+					{Dates: []common.ScheduleDate{{Month: 3, Day: 14}, {Month: 3, Day: 15}}, Hours: "0:00-23:59"},
+					{Dates: []common.ScheduleDate{{Month: 12, Day: 25}}},
+				},
+			},
+		},
+	})
+
+	assert.Contains(t, result, "Schedules")
+	assert.Contains(t, result, "| OfficeHours | Weekdays | Mon, Tue, Sun 8:00-17:00 | 2 |")
+	assert.Contains(t, result, "| Maintenance |  | Mar 14, Mar 15 0:00-23:59; Dec 25 | 0 |")
+}
+
+func TestMarkdownBuilder_BuildSchedulesSection_Empty(t *testing.T) {
+	builder := builderPkg.NewMarkdownBuilder()
+
+	assert.Empty(t, builder.BuildSchedulesSection(&common.CommonDevice{}))
+}
+
+func TestMarkdownBuilder_BuildPortExposureSection(t *testing.T) {
+	builder := builderPkg.NewMarkdownBuilder()
+
+	result := builder.BuildPortExposureSection(&common.CommonDevice{
+		FirewallRules: []common.FirewallRule{
+			{
+				Type:        common.RuleTypePass,
+				Interfaces:  []string{"wan"},
+				Protocol:    "tcp",
+				Destination: common.RuleEndpoint{Address: "192.168.1.10", Port: "443"},
+				Description: "Allow HTTPS",
+			},
+		},
+		NAT: common.NATConfig{InboundRules: []common.InboundNATRule{
+			{
+				Interfaces:   []string{"wan"},
+				Protocol:     "tcp",
+				ExternalPort: "443",
+				InternalIP:   "192.168.1.10",
+				InternalPort: "8443",
+			},
+		}},
+	})
+
+	assert.Contains(t, result, "WAN Port Exposure")
+	assert.Contains(t, result, "| tcp | 443 | 192.168.1.10 | filter.rule\\[0\\]: Allow HTTPS |")
+	assert.Contains(t, result, "| tcp | 443 | 192.168.1.10:8443 | nat.inbound\\[0\\] |")
+}
+
+func TestMarkdownBuilder_BuildPortExposureSection_Empty(t *testing.T) {
+	builder := builderPkg.NewMarkdownBuilder()
+
+	assert.Empty(t, builder.BuildPortExposureSection(&common.CommonDevice{}))
+}
+
+func TestMarkdownBuilder_BuildDataQualitySection(t *testing.T) {
+	builder := builderPkg.NewMarkdownBuilder()
+
+	result := builder.BuildDataQualitySection([]common.ConversionWarning{
+		{
+			Field:    "NAT.InboundRules[0].Priority",
+			Value:    "abc",
+			Message:  "inbound NAT rule priority is not an integer",
+			Action:   "defaulted to 0",
+			Severity: common.SeverityLow,
+		},
+		{
+			Field:    "FirewallRules[2].Interface",
+			Message:  "firewall rule has no interface assigned",
+			Severity: common.SeverityMedium,
+		},
+	})
+
+	assert.Contains(t, result, "## Data Quality")
+	assert.Contains(t, result,
+		`| NAT.InboundRules\[0\].Priority | abc | inbound NAT rule priority is not an integer | defaulted to 0 | low |`)
+	assert.Contains(t, result, `| FirewallRules\[2\].Interface | - | firewall rule has no interface assigned | - | medium |`)
+}
+
+func TestMarkdownBuilder_BuildDataQualitySection_Empty(t *testing.T) {
+	builder := builderPkg.NewMarkdownBuilder()
+
+	assert.Empty(t, builder.BuildDataQualitySection(nil))
+}
+
+func TestMarkdownBuilder_BuildNTPSection(t *testing.T) {
+	builder := builderPkg.NewMarkdownBuilder()
+
+	data := &common.CommonDevice{
+		NTP: common.NTPConfig{
+			PreferredServer: "0.opnsense.pool.ntp.org",
+			Servers: []common.NTPServer{
+				{Address: "0.opnsense.pool.ntp.org", Prefer: true},
+				{Address: "192.0.2.10", NoSelect: true},
+			},
+			MinPoll:      "6",
+			MaxPoll:      "10",
+			Orphan:       "12",
+			Restrictions: []string{"10.0.0.0 mask 255.0.0.0 nomodify"},
+		},
+	}
+
+	result := builder.BuildNTPSection(data)
+
+	assert.Contains(t, result, "### NTP")
+	assert.Contains(t, result, "**Minimum Poll Interval**: 6")
+	assert.Contains(t, result, "**Maximum Poll Interval**: 10")
+	assert.Contains(t, result, "**Orphan Mode Stratum**: 12")
+	assert.Contains(t, result, "**Deny Status Queries (noquery)**: ✗")
+	assert.Contains(t, result, "| 0.opnsense.pool.ntp.org | ✓ | ✗ |")
+	assert.Contains(t, result, "| 192.0.2.10 | ✗ | ✓ |")
+	assert.Contains(t, result, "- `10.0.0.0 mask 255.0.0.0 nomodify`")
+	assert.Contains(t, builder.BuildServicesSection(data), "| 192.0.2.10 | ✗ | ✓ |")
+
+	empty := builder.BuildNTPSection(&common.CommonDevice{})
+	assert.Contains(t, empty, "### NTP")
+	assert.NotContains(t, empty, "noquery", "query restriction is only reported when NTP servers are configured")
+}
+
+func TestMarkdownBuilder_BuildNetflowSection(t *testing.T) {
+	builder := builderPkg.NewMarkdownBuilder()
+
+	data := &common.CommonDevice{
+		Netflow: &common.NetflowConfig{
+			Enabled:           true,
+			CaptureInterfaces: "lan,opt1",
+			CaptureVersion:    "v9",
+			CaptureTargets:    "10.0.0.5:2055,collector.example.com",
+			Collectors: []common.NetflowCollector{
+				{Address: "10.0.0.5", Port: "2055"},
+				{Address: "collector.example.com"},
+			},
+			ActiveTimeout: "1800",
+		},
+	}
+
+	result := builder.BuildNetflowSection(data)
+
+	assert.Contains(t, result, "### NetFlow")
+	assert.Contains(t, result, "**Export Enabled**: ✓")
+	assert.Contains(t, result, "**Capture Interfaces**: lan,opt1")
+	assert.Contains(t, result, "**Active Timeout**: 1800s")
+	assert.Contains(t, result, "| 10.0.0.5 | 2055 |")
+	assert.Contains(t, result, "| collector.example.com | - |")
+	assert.Contains(t, builder.BuildServicesSection(data), "### NetFlow")
+	assert.Empty(t, builder.BuildNetflowSection(&common.CommonDevice{}))
+	assert.Empty(t, builder.BuildNetflowSection(&common.CommonDevice{Netflow: &common.NetflowConfig{}}))
+}
+
+func TestMarkdownBuilder_BuildWOLSection(t *testing.T) {
+	builder := builderPkg.NewMarkdownBuilder()
+
+	data := &common.CommonDevice{
+		WakeOnLAN: []common.WakeOnLANEntry{
+			{Interface: "lan", MAC: "00:11:22:33:44:55", Description: "Build server"},
+		},
+	}
+
+	result := builder.BuildWOLSection(data)
+
+	assert.Contains(t, result, "Wake-on-LAN")
+	assert.Contains(t, result, "00:11:22:33:44:55")
+	assert.Contains(t, result, "Build server")
+	assert.Contains(t, builder.BuildServicesSection(data), "Wake-on-LAN")
+	assert.Empty(t, builder.BuildWOLSection(&common.CommonDevice{}))
+}
+
+func TestMarkdownBuilder_BuildUPnPSection(t *testing.T) {
+	builder := builderPkg.NewMarkdownBuilder()
+
+	data := &common.CommonDevice{
+		UPnP: &common.UPnPConfig{
+			Enabled:           true,
+			UPnPEnabled:       true,
+			ExternalInterface: "wan",
+			Interfaces:        []string{"lan", "opt1"},
+			Rules: []common.UPnPPermissionRule{
+				{
+					Action:          "allow",
+					ExternalPorts:   "0-65535",
+					InternalAddress: "192.168.50.0/24",
+					InternalPorts:   "0-65535",
+					Description:     "Guest network",
+				},
+			},
+		},
+	}
+
+	result := builder.BuildUPnPSection(data)
+
+	assert.Contains(t, result, "### UPnP / NAT-PMP")
+	assert.Contains(t, result, "**Listening Interfaces**: lan, opt1")
+	assert.Contains(t, result, "**Default Deny**: ✗")
+	assert.Contains(t, result, "| allow | 0-65535 | 192.168.50.0/24 | 0-65535 | Guest network |")
+	assert.Contains(t, result, "requests that match no rule are accepted")
+	assert.Contains(t, builder.BuildServicesSection(data), "### UPnP / NAT-PMP")
+	assert.Empty(t, builder.BuildUPnPSection(&common.CommonDevice{}))
+
+	data.UPnP.DefaultDeny = true
+	assert.NotContains(t, builder.BuildUPnPSection(data), "requests that match no rule are accepted")
+}
+
+func TestMarkdownBuilder_BuildSecuritySection_IncludesPFSettings(t *testing.T) {
+	builder := builderPkg.NewMarkdownBuilder()
+
+	result := builder.BuildSecuritySection(&common.CommonDevice{
+		PF: &common.PFSettings{MaxStates: 100000},
+	})
+
+	assert.Contains(t, result, "Security Configuration")
+	assert.Contains(t, result, "Stateful Inspection")
+}
+
+// headingAnchors returns the GitHub-style anchor of every ATX heading in doc:
+// lowercased, punctuation other than hyphens and underscores dropped, and
+// spaces replaced with hyphens. Inline HTML anchors (<a id="...">) are
+// included too.
+func headingAnchors(doc string) map[string]bool {
+	anchors := make(map[string]bool)
+	for _, m := range regexp.MustCompile(`<a id="([^"]+)"></a>`).FindAllStringSubmatch(doc, -1) {
+		anchors["#"+m[1]] = true
+	}
+
+	for line := range strings.SplitSeq(doc, "\n") {
+		text, ok := strings.CutPrefix(strings.TrimLeft(line, "#"), " ")
+		if !ok || !strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		var b strings.Builder
+		for _, r := range strings.ToLower(text) {
+			switch {
+			case r == ' ':
+				b.WriteRune('-')
+			case r == '-' || r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r):
+				b.WriteRune(r)
+			}
+		}
+
+		anchors["#"+b.String()] = true
+	}
+
+	return anchors
+}
+
+func TestMarkdownBuilder_InterfaceXRefAnchorsExist(t *testing.T) {
+	device := loadTestDataFromFile(t, "complete.json")
+	device.VPN.OpenVPN.Servers = append(device.VPN.OpenVPN.Servers,
+		common.OpenVPNServer{Interface: "wan", Description: "Road warriors"})
+	device.VPN.OpenVPN.Clients = append(device.VPN.OpenVPN.Clients,
+		common.OpenVPNClient{Interface: "wan", Description: "Site B"})
+	device.VPN.IPsec.Phase1Tunnels = append(device.VPN.IPsec.Phase1Tunnels,
+		common.IPsecPhase1Tunnel{Interface: "wan", IKEID: "1"})
+	device.VPN.PPTP = &common.LegacyRemoteAccessVPN{Enabled: true, Interface: "wan", Mode: "server"}
+	device.Routing.Gateways = append(device.Routing.Gateways, common.Gateway{Interface: "wan", Name: "WAN_GW"})
+
+	report, err := createDeterministicBuilder(t).BuildComprehensiveReport(device)
+	require.NoError(t, err)
+
+	_, appendix, found := strings.Cut(report, "## Interface Cross-Reference\n")
+	require.True(t, found, "comprehensive report should end with the cross-reference appendix")
+	assert.Contains(t, appendix, "**VPN Bindings** (4): [OpenVPN server: Road warriors](#openvpn-servers)")
+	assert.Contains(t, appendix, "[PPTP server](#legacy-remote-access-vpn)")
+	assert.Contains(t, appendix, "**Gateways** (1): `WAN_GW`")
+	assert.Contains(t, appendix, "**Firewall Rules** (2): [1](#firewall-rule-1), [2](#firewall-rule-2)")
+
+	anchors := headingAnchors(report)
+	assert.True(t, anchors["#interface-cross-reference"], "table of contents link target must exist")
+
+	links := regexp.MustCompile(`\]\((#[^)]+)\)`).FindAllStringSubmatch(appendix, -1)
+	require.NotEmpty(t, links)
+
+	for _, link := range links {
+		assert.True(t, anchors[link[1]], "appendix links to missing anchor %s", link[1])
+	}
+}
+
+func TestMarkdownBuilder_BuildInterfaceXRefSection_Empty(t *testing.T) {
+	builder := builderPkg.NewMarkdownBuilder()
+
+	result := builder.BuildInterfaceXRefSection(&common.CommonDevice{})
+	assert.Contains(t, result, "Interface Cross-Reference")
+	assert.Contains(t, result, "No interfaces configured")
+}
+
+func TestMarkdownBuilder_BuildEffectiveRulesSection(t *testing.T) {
+	builder := builderPkg.NewMarkdownBuilder()
+
+	data := &common.CommonDevice{
+		Interfaces:      []common.Interface{{Name: "wan"}, {Name: "lan"}},
+		InterfaceGroups: []common.InterfaceGroup{{Name: "uplinks", Members: []string{"wan"}}},
+		FirewallRules: []common.FirewallRule{
+			{Type: common.RuleTypePass, Interfaces: []string{"wan"}, Description: "Direct"},
+			{Type: common.RuleTypeBlock, Interfaces: []string{"uplinks"}, Description: "Group"},
+			{Type: common.RuleTypeBlock, Interfaces: []string{"wan"}, Floating: true, Description: "Floating"},
+		},
+	}
+
+	result := builder.BuildEffectiveRulesSection(data, "wan")
+	assert.Contains(t, result, "## Effective Rules: Wan")
+	assert.Contains(t, result, "| Order | Rule | Origin | Action | Quick |")
+
+	floating := strings.Index(result, "| 1 | 3 | floating | block | ✗ |")
+	group := strings.Index(result, "| 2 | 2 | group:uplinks | block | ✓ |")
+	direct := strings.Index(result, "| 3 | 1 | direct | pass | ✓ |")
+	require.NotEqual(t, -1, floating, result)
+	assert.Less(t, floating, group)
+	assert.Less(t, group, direct)
+
+	xref := builder.BuildInterfaceXRefSection(data)
+	assert.Contains(t, xref,
+		"**Effective Rules** (3): [3](#firewall-rule-3) floating, [2](#firewall-rule-2) group:uplinks, [1](#firewall-rule-1) direct")
+	assert.Contains(t, xref, "**Effective Rules** (0): none", "the floating rule names only wan")
+
+	assert.Contains(t, builder.BuildEffectiveRulesSection(&common.CommonDevice{}, "wan"),
+		"No enabled firewall rules apply to this interface")
+}
+
+// reportH2s returns the level-two headings of report that follow the table
+// of contents.
+func reportH2s(report string) []string {
+	_, body, _ := strings.Cut(report, "## Table of Contents\n")
+
+	var headings []string
+	for line := range strings.SplitSeq(body, "\n") {
+		if heading, ok := strings.CutPrefix(line, "## "); ok {
+			headings = append(headings, heading)
+		}
+	}
+	return headings
+}
+
+// tocLinks returns the link targets of the table of contents of report.
+func tocLinks(report string) []string {
+	_, toc, _ := strings.Cut(report, "## Table of Contents\n")
+	toc, _, _ = strings.Cut(toc, "\n## ")
+
+	var links []string
+	for _, m := range regexp.MustCompile(`\]\((#[^)]+)\)`).FindAllStringSubmatch(toc, -1) {
+		links = append(links, m[1])
+	}
+	return links
+}
+
+func TestMarkdownBuilder_SectionOrder(t *testing.T) {
+	device := loadTestDataFromFile(t, "complete.json")
+
+	builder := createDeterministicBuilder(t)
+	require.NoError(t, builder.SetSectionOrder([]string{"services", "Firewall", "system"}))
+
+	report, err := builder.BuildComprehensiveReport(device)
+	require.NoError(t, err)
+
+	wantH2s := []string{
+		"Service Configuration",
+		"Security Configuration",
+		"System Configuration",
+		"Recent Changes",
+		"Network Configuration",
+		"System Tunables",
+		"Interface Cross-Reference",
+	}
+	assert.Equal(t, wantH2s, reportH2s(report))
+
+	links := tocLinks(report)
+	assert.Equal(t, []string{
+		"#dhcp-server", "#dns-resolver-unbound", "#service-configuration",
+		"#firewall-rules", "#nat-configuration", "#intrusion-detection-system-idssuricata",
+		"#ipsec-vpn-configuration", "#openvpn-configuration", "#high-availability--carp",
+		"#system-configuration", "#system-users", "#system-groups",
+		"#recent-changes",
+		"#interfaces", "#vlan-configuration", "#static-routes",
+		"#system-tunables",
+		"#interface-cross-reference",
+	}, links)
+
+	anchors := headingAnchors(report)
+	for _, link := range links {
+		if link == "#intrusion-detection-system-idssuricata" {
+			continue // rendered only when IDS is configured
+		}
+		assert.True(t, anchors[link], "table of contents links to missing anchor %s", link)
+	}
+
+	standard, err := builder.BuildStandardReport(device)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"Service Configuration",
+		"Security Configuration",
+		"System Configuration",
+		"Network Configuration",
+		"System Tunables",
+	}, reportH2s(standard))
+
+	var streamed strings.Builder
+	require.NoError(t, builder.WriteComprehensiveReport(&streamed, device))
+
+	// The streamed report concatenates separately rendered sections, so
+	// compare the order in which the ToC links and headings first appear.
+	last := -1
+	for _, marker := range []string{
+		"(#dhcp-server)", "(#firewall-rules)", "(#system-configuration)", "(#recent-changes)",
+		"## Service Configuration", "## Security Configuration", "## System Configuration", "## Recent Changes",
+	} {
+		pos := strings.Index(streamed.String(), marker)
+		require.NotEqual(t, -1, pos, "streamed report is missing %s", marker)
+		assert.Greater(t, pos, last, "streamed report has %s out of order", marker)
+		last = pos
+	}
+}
+
+func TestMarkdownBuilder_SetSectionOrder_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		order   []string
+		wantErr error
+	}{
+		{"duplicate", []string{"system", "network", "System"}, builderPkg.ErrDuplicateSection},
+		{"alias of a listed section", []string{"security", "firewall"}, builderPkg.ErrDuplicateSection},
+		{"unknown", []string{"system", "rules"}, builderPkg.ErrUnknownSection},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := createDeterministicBuilder(t)
+			require.NoError(t, builder.SetSectionOrder([]string{"network"}))
+
+			err := builder.SetSectionOrder(tt.order)
+			require.ErrorIs(t, err, tt.wantErr)
+
+			report, err := builder.BuildStandardReport(loadTestDataFromFile(t, "complete.json"))
+			require.NoError(t, err)
+			assert.Equal(t, "Network Configuration", reportH2s(report)[0], "a rejected order must keep the previous one")
+		})
+	}
+
+	err := builderPkg.NewMarkdownBuilder().SetSectionOrder([]string{"rules"})
+	assert.ErrorContains(t, err, "changes, system, network, security, services, tunables, xref")
+}
@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"testing"
+	"time"
 
 	builderPkg "github.com/EvilBit-Labs/opnDossier/internal/converter/builder"
 	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
@@ -91,7 +92,7 @@ func BenchmarkMarkdownBuilder_FirewallRulesTable(b *testing.B) {
 
 	b.ResetTimer()
 	for b.Loop() {
-		_ = builderPkg.BuildFirewallRulesTableSet(testData.FirewallRules)
+		_ = builderPkg.BuildFirewallRulesTableSet(testData.FirewallRules, true)
 	}
 }
 
@@ -111,7 +112,7 @@ func BenchmarkMarkdownBuilder_UserTable(b *testing.B) {
 
 	b.ResetTimer()
 	for b.Loop() {
-		_ = builderPkg.BuildUserTableSet(testData.Users)
+		_ = builderPkg.BuildUserTableSet(testData.Users, time.Now())
 	}
 }
 
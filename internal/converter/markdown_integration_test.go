@@ -6,6 +6,7 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	builderPkg "github.com/EvilBit-Labs/opnDossier/internal/converter/builder"
 	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
@@ -192,8 +193,8 @@ func TestMarkdownBuilder_CrossMethodInteraction(t *testing.T) {
 
 	// Test that tables can be generated independently
 	interfaceTable := builderPkg.BuildInterfaceTableSet(testData.Interfaces)
-	rulesTable := builderPkg.BuildFirewallRulesTableSet(testData.FirewallRules)
-	userTable := builderPkg.BuildUserTableSet(testData.Users)
+	rulesTable := builderPkg.BuildFirewallRulesTableSet(testData.FirewallRules, true)
+	userTable := builderPkg.BuildUserTableSet(testData.Users, time.Now())
 	groupTable := builderPkg.BuildGroupTableSet(testData.Groups)
 	sysctlTable := builderPkg.BuildSysctlTableSet(testData.Sysctl)
 
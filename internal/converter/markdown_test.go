@@ -1,6 +1,7 @@
 package converter
 
 import (
+	"bytes"
 	"context"
 	"os"
 	"path/filepath"
@@ -8,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/EvilBit-Labs/opnDossier/internal/cfgparser"
+	"github.com/EvilBit-Labs/opnDossier/internal/converter/builder"
 	"github.com/EvilBit-Labs/opnDossier/internal/converter/formatters"
 	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
 	"github.com/EvilBit-Labs/opnDossier/pkg/parser"
@@ -177,6 +179,40 @@ func TestMarkdownConverter_ConvertFromTestdataFile(t *testing.T) {
 	assert.Contains(t, markdown, "HTTP")
 }
 
+// TestMarkdownConverter_Latin1Fixture verifies that a config declaring
+// ISO-8859-1 is transcoded to UTF-8 and that the report records the source
+// encoding.
+func TestMarkdownConverter_Latin1Fixture(t *testing.T) {
+	t.Setenv("TERM", "dumb")
+
+	xmlPath := filepath.Join("..", "cfgparser", "testdata", "iso8859-1-raw.xml")
+	latin1, err := os.ReadFile(xmlPath)
+	require.NoError(t, err, "Failed to read testdata XML file")
+	require.NotContains(t, string(latin1), "tráfico", "fixture bytes must be Latin-1, not UTF-8")
+
+	factory := parser.NewFactory(cfgparser.NewXMLParser())
+	device, warnings, err := factory.CreateDevice(
+		context.Background(),
+		bytes.NewReader(latin1),
+		common.DeviceTypeUnknown,
+		false,
+	)
+	require.NoError(t, err, "XML parsing should succeed")
+	assert.Empty(t, warnings)
+	assert.Equal(t, "ISO-8859-1", device.SourceEncoding)
+
+	markdown, err := NewMarkdownConverter().ToMarkdown(context.Background(), device)
+	require.NoError(t, err, "Markdown conversion should succeed")
+
+	assert.Contains(t, markdown, "München-fw")
+	assert.Contains(t, markdown, "Permitir tráfico estándar - café")
+	assert.NotContains(t, markdown, "\uFFFD")
+
+	report, err := builder.NewMarkdownBuilder().BuildStandardReport(device)
+	require.NoError(t, err)
+	assert.Contains(t, report, `"sourceEncoding":"ISO-8859-1"`)
+}
+
 // TestMarkdownConverter_EdgeCases tests edge cases and error conditions.
 //
 //nolint:funlen // test table or data declaration; length is in data not logic
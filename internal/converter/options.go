@@ -3,6 +3,10 @@ package converter
 import (
 	"errors"
 	"fmt"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/analysis"
+	"github.com/EvilBit-Labs/opnDossier/internal/converter/builder"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
 )
 
 // Format represents the output format type.
@@ -20,6 +24,8 @@ const (
 	FormatText Format = "text"
 	// FormatHTML represents self-contained HTML output format.
 	FormatHTML Format = "html"
+	// FormatPDF represents PDF output format, rendered from the comprehensive report.
+	FormatPDF Format = "pdf"
 )
 
 // String returns the string representation of the format.
@@ -34,6 +40,15 @@ func (f Format) Validate() error {
 	return err
 }
 
+// IsBinary reports whether reports in format f are binary files, such as a
+// PDF, which are written byte for byte and never displayed as text. Format
+// aliases are resolved through the DefaultRegistry.
+func (f Format) IsBinary() bool {
+	canonical, _ := DefaultRegistry.Canonical(string(f))
+
+	return Format(canonical) == FormatPDF
+}
+
 // Theme represents the rendering theme for terminal output.
 type Theme string
 
@@ -56,7 +71,7 @@ func (t Theme) String() string {
 
 // Options contains configuration options for report generation.
 type Options struct {
-	// Format specifies the output format (markdown, json, yaml, text, html).
+	// Format specifies the output format (markdown, json, yaml, text, html, pdf).
 	Format Format
 
 	// Comprehensive specifies whether to generate a comprehensive report.
@@ -65,12 +80,23 @@ type Options struct {
 	// Sections specifies which configuration sections to include.
 	Sections []string
 
+	// SectionOrder lists section IDs (see builder.ReportSections) in the
+	// order markdown, text, and HTML reports render them and their table of
+	// contents entries. Unlisted sections follow in default order; nil keeps
+	// the default order.
+	SectionOrder []string
+
 	// Theme specifies the terminal rendering theme for markdown output.
 	Theme Theme
 
 	// WrapWidth specifies the column width for text wrapping.
 	WrapWidth int
 
+	// MaxWidth opts in to soft-wrapping paragraph prose in markdown output
+	// at this many columns and caps the terminal wrap width. Tables and code
+	// are never wrapped. Zero or negative leaves output unchanged.
+	MaxWidth int
+
 	// EnableTables controls whether to render data as tables.
 	EnableTables bool
 
@@ -98,9 +124,35 @@ type Options struct {
 	// Only meaningful in blue mode audit reports where compliance checks are executed.
 	FailuresOnly bool
 
+	// NoPortNames disables annotating well-known TCP/UDP ports in firewall and
+	// NAT rule tables with their service name ("443 (https)"), leaving the
+	// raw port values.
+	NoPortNames bool
+
+	// RuleFilter narrows the firewall and NAT rule tables of markdown, text,
+	// and HTML reports, and the rule arrays of JSON and YAML exports, to the
+	// matching rules. The zero value keeps every rule.
+	RuleFilter analysis.RuleFilter
+
+	// StrictRender makes a markdown, text, or HTML report section that fails
+	// to render a hard error. By default the section is replaced by a
+	// placeholder and the report is returned with a
+	// *builder.PartialReportError.
+	StrictRender bool
+
 	// Redact controls whether sensitive fields (passwords, private keys, community strings, etc.)
 	// are replaced with [REDACTED] in the output. Defaults to false.
 	Redact bool
+
+	// EmbeddedSource, when set, is written under _meta.source of JSON and YAML
+	// exports so the export carries the exact source file. Other formats
+	// ignore it. Build it with NewEmbeddedSource.
+	EmbeddedSource *common.EmbeddedSource
+
+	// DataQuality holds the issues found while normalizing the configuration.
+	// When non-empty, markdown, text, and HTML reports end with a Data
+	// Quality appendix listing them. Other formats ignore it.
+	DataQuality []common.ConversionWarning
 }
 
 // DefaultOptions returns an Options initialized with the package's default settings for report generation.
@@ -138,6 +190,10 @@ func (o Options) Validate() error {
 		return fmt.Errorf("%w: %d", ErrInvalidWrapWidth, o.WrapWidth)
 	}
 
+	if _, err := builder.ResolveSectionOrder(o.SectionOrder); err != nil {
+		return fmt.Errorf("invalid section order: %w", err)
+	}
+
 	return nil
 }
 
@@ -153,6 +209,13 @@ func (o Options) WithSections(sections ...string) Options {
 	return o
 }
 
+// WithSectionOrder sets the order of the report sections. Order validity is
+// checked by Options.Validate().
+func (o Options) WithSectionOrder(ids ...string) Options {
+	o.SectionOrder = ids
+	return o
+}
+
 // WithTheme sets the terminal rendering theme.
 func (o Options) WithTheme(theme Theme) Options {
 	o.Theme = theme
@@ -165,6 +228,12 @@ func (o Options) WithWrapWidth(width int) Options {
 	return o
 }
 
+// WithMaxWidth sets the prose soft-wrap and terminal width cap.
+func (o Options) WithMaxWidth(width int) Options {
+	o.MaxWidth = width
+	return o
+}
+
 // WithTables enables or disables table rendering.
 func (o Options) WithTables(enabled bool) Options {
 	o.EnableTables = enabled
@@ -213,6 +282,18 @@ func (o Options) WithRedact(redact bool) Options {
 	return o
 }
 
+// WithEmbeddedSource sets the source configuration embedded in JSON and YAML exports.
+func (o Options) WithEmbeddedSource(src *common.EmbeddedSource) Options {
+	o.EmbeddedSource = src
+	return o
+}
+
+// WithDataQuality sets the normalization issues rendered in the Data Quality appendix.
+func (o Options) WithDataQuality(issues []common.ConversionWarning) Options {
+	o.DataQuality = issues
+	return o
+}
+
 // WithIncludeTunables enables or disables inclusion of all system tunables.
 // When false, only security-related tunables are shown in reports.
 func (o Options) WithIncludeTunables(enabled bool) Options {
@@ -220,6 +301,26 @@ func (o Options) WithIncludeTunables(enabled bool) Options {
 	return o
 }
 
+// WithPortNames enables or disables service name annotation of well-known
+// ports in rule tables.
+func (o Options) WithPortNames(enabled bool) Options {
+	o.NoPortNames = !enabled
+	return o
+}
+
+// WithRuleFilter sets the filter that narrows the rule tables and exports.
+func (o Options) WithRuleFilter(filter analysis.RuleFilter) Options {
+	o.RuleFilter = filter
+	return o
+}
+
+// WithStrictRender enables or disables failing the report when a section
+// fails to render.
+func (o Options) WithStrictRender(enabled bool) Options {
+	o.StrictRender = enabled
+	return o
+}
+
 // WithFailuresOnly enables or disables filtering plugin control results to show only failures.
 func (o Options) WithFailuresOnly(enabled bool) Options {
 	o.FailuresOnly = enabled
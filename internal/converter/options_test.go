@@ -39,6 +39,16 @@ func TestFormat_String(t *testing.T) {
 	}
 }
 
+func TestFormat_IsBinary(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, FormatPDF.IsBinary())
+	assert.True(t, Format("PDF").IsBinary(), "format names are case-insensitive")
+	for _, f := range []Format{FormatMarkdown, Format("md"), FormatJSON, FormatYAML, FormatText, FormatHTML} {
+		assert.False(t, f.IsBinary(), "%s is text", f)
+	}
+}
+
 func TestFormat_Validate(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -195,6 +205,16 @@ func TestOptions_Validate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name:    "valid section order",
+			options: DefaultOptions().WithSectionOrder("security", "system"),
+			wantErr: false,
+		},
+		{
+			name:    "unknown section in order",
+			options: DefaultOptions().WithSectionOrder("firewall-rules"),
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,104 @@
+package converter
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/cfgparser"
+	"github.com/EvilBit-Labs/opnDossier/internal/testutil"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/EvilBit-Labs/opnDossier/pkg/parser"
+)
+
+// pipelineParser returns an XML parser whose input limit admits xmlData,
+// however large the reference config grows.
+func pipelineParser(xmlData []byte) *cfgparser.XMLParser {
+	p := cfgparser.NewXMLParser()
+	p.MaxInputSize = max(p.MaxInputSize, int64(len(xmlData)))
+
+	return p
+}
+
+// parseNormalize parses xmlData and converts it to a CommonDevice, the work
+// every command does before rendering.
+func parseNormalize(ctx context.Context, xmlData []byte) (*common.CommonDevice, error) {
+	device, _, err := parser.NewFactory(pipelineParser(xmlData)).CreateDevice(
+		ctx,
+		bytes.NewReader(xmlData),
+		common.DeviceTypeUnknown,
+		false,
+	)
+
+	return device, err
+}
+
+// BenchmarkPipeline_Parse measures XML decoding into the OPNsense schema alone
+// for each reference config size.
+func BenchmarkPipeline_Parse(b *testing.B) {
+	for _, ref := range testutil.ReferenceConfigs() {
+		xmlData := testutil.SyntheticConfigXML(ref.Spec)
+
+		b.Run(ref.Name, func(b *testing.B) {
+			p := pipelineParser(xmlData)
+			ctx := context.Background()
+
+			b.SetBytes(int64(len(xmlData)))
+			b.ReportAllocs()
+
+			for b.Loop() {
+				if _, err := p.Parse(ctx, bytes.NewReader(xmlData)); err != nil {
+					b.Fatalf("Parse failed: %v", err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkPipeline_ParseNormalize measures parsing plus conversion to
+// CommonDevice for each reference config size.
+func BenchmarkPipeline_ParseNormalize(b *testing.B) {
+	for _, ref := range testutil.ReferenceConfigs() {
+		xmlData := testutil.SyntheticConfigXML(ref.Spec)
+
+		b.Run(ref.Name, func(b *testing.B) {
+			ctx := context.Background()
+
+			b.SetBytes(int64(len(xmlData)))
+			b.ReportAllocs()
+
+			for b.Loop() {
+				if _, err := parseNormalize(ctx, xmlData); err != nil {
+					b.Fatalf("parse+normalize failed: %v", err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkPipeline_ConvertMarkdown measures the full path from XML to a
+// rendered markdown report for each reference config size.
+func BenchmarkPipeline_ConvertMarkdown(b *testing.B) {
+	for _, ref := range testutil.ReferenceConfigs() {
+		xmlData := testutil.SyntheticConfigXML(ref.Spec)
+
+		b.Run(ref.Name, func(b *testing.B) {
+			ctx := context.Background()
+			converter := NewMarkdownConverter()
+
+			b.SetBytes(int64(len(xmlData)))
+			b.ReportAllocs()
+
+			for b.Loop() {
+				device, err := parseNormalize(ctx, xmlData)
+				if err != nil {
+					b.Fatalf("parse+normalize failed: %v", err)
+				}
+
+				if _, err := converter.ToMarkdown(ctx, device); err != nil {
+					b.Fatalf("ToMarkdown failed: %v", err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package converter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+// Budgets for parse+normalize of testutil.MediumConfig. Locally the run takes
+// about 90ms and 330k allocations; the wall-clock budget leaves room for -race
+// and loaded CI runners, while the allocation budget is tighter because
+// allocation counts do not vary with the machine. A failure here points to an
+// accidental quadratic or a per-element allocation added to the hot path —
+// compare BenchmarkPipeline_ParseNormalize before and after the change.
+const (
+	mediumParseNormalizeTimeBudget  = 3 * time.Second
+	mediumParseNormalizeAllocBudget = 1_000_000
+)
+
+func TestPipelineBudget_MediumParseNormalize(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping pipeline budget test in short mode")
+	}
+
+	xmlData := testutil.SyntheticConfigXML(testutil.MediumConfig)
+	ctx := context.Background()
+
+	var runErr error
+
+	start := time.Now()
+	allocs := testing.AllocsPerRun(1, func() {
+		_, runErr = parseNormalize(ctx, xmlData)
+	})
+	// AllocsPerRun makes one warm-up call before the measured run.
+	elapsed := time.Since(start) / 2
+
+	require.NoError(t, runErr)
+	t.Logf("parse+normalize medium config: %v, %.0f allocs", elapsed, allocs)
+
+	require.LessOrEqual(t, elapsed, mediumParseNormalizeTimeBudget,
+		"parse+normalize of the medium reference config exceeded its wall-clock budget")
+	require.LessOrEqual(t, allocs, float64(mediumParseNormalizeAllocBudget),
+		"parse+normalize of the medium reference config exceeded its allocation budget")
+}
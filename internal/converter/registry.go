@@ -200,6 +200,7 @@ func newDefaultRegistry() *FormatRegistry {
 	r.Register(string(FormatYAML), &yamlHandler{})
 	r.Register(string(FormatText), &textHandler{})
 	r.Register(string(FormatHTML), &htmlHandler{})
+	r.Register(string(FormatPDF), &pdfHandler{})
 
 	return r
 }
@@ -344,3 +345,30 @@ func (h *htmlHandler) GenerateToWriter(
 ) error {
 	return g.generateHTMLToWriter(ctx, w, data, opts)
 }
+
+// pdfHandler handles PDF output by rendering the report document tree with document.PDFRenderer.
+type pdfHandler struct{}
+
+func (h *pdfHandler) FileExtension() string { return ".pdf" }
+func (h *pdfHandler) Aliases() []string     { return nil }
+
+// Generate produces the PDF file as a string of bytes.
+func (h *pdfHandler) Generate(
+	ctx context.Context,
+	g *HybridGenerator,
+	data *common.CommonDevice,
+	opts Options,
+) (string, error) {
+	return g.generatePDF(ctx, data, opts)
+}
+
+// GenerateToWriter writes the rendered PDF file to the writer.
+func (h *pdfHandler) GenerateToWriter(
+	ctx context.Context,
+	g *HybridGenerator,
+	w io.Writer,
+	data *common.CommonDevice,
+	opts Options,
+) error {
+	return g.generatePDFToWriter(ctx, w, data, opts)
+}
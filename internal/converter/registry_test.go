@@ -403,11 +403,11 @@ func TestFormatRegistry_EmptyRegistry(t *testing.T) {
 
 // --- DefaultRegistry content verification ---
 
-func TestDefaultRegistry_ContainsFiveFormats(t *testing.T) {
+func TestDefaultRegistry_ContainsSixFormats(t *testing.T) {
 	t.Parallel()
 
 	formats := DefaultRegistry.ValidFormats()
-	assert.Equal(t, []string{"html", "json", "markdown", "text", "yaml"}, formats)
+	assert.Equal(t, []string{"html", "json", "markdown", "pdf", "text", "yaml"}, formats)
 }
 
 func TestDefaultRegistry_CorrectExtensions(t *testing.T) {
@@ -419,6 +419,7 @@ func TestDefaultRegistry_CorrectExtensions(t *testing.T) {
 		"yaml":     ".yaml",
 		"text":     ".txt",
 		"html":     ".html",
+		"pdf":      ".pdf",
 	}
 
 	exts := DefaultRegistry.Extensions()
@@ -469,7 +470,7 @@ func TestDefaultRegistry_ValidFormatsWithAliases(t *testing.T) {
 	t.Parallel()
 
 	all := DefaultRegistry.ValidFormatsWithAliases()
-	expected := []string{"htm", "html", "json", "markdown", "md", "text", "txt", "yaml", "yml"}
+	expected := []string{"htm", "html", "json", "markdown", "md", "pdf", "text", "txt", "yaml", "yml"}
 	assert.Equal(t, expected, all)
 }
 
@@ -0,0 +1,143 @@
+package converter
+
+import (
+	"bytes"
+	"compress/zlib"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultEmbedSourceLimit is the default maximum size in bytes of a source
+// configuration that may be embedded in, or extracted from, an export.
+const DefaultEmbedSourceLimit int64 = 64 << 20 // 64 MiB
+
+// sourceCompression is the EmbeddedSource.Compression value written by
+// NewEmbeddedSource and the only one ExtractEmbeddedSource accepts.
+const sourceCompression = "zlib"
+
+// NewEmbeddedSource compresses and encodes content, the exact bytes of the
+// source file at path, for embedding under _meta.source of a JSON or YAML
+// export. Only the base name of path is recorded. Content larger than limit
+// bytes is refused with ErrSourceTooLarge; a non-positive limit selects
+// DefaultEmbedSourceLimit.
+func NewEmbeddedSource(path string, content []byte, limit int64) (*common.EmbeddedSource, error) {
+	if limit <= 0 {
+		limit = DefaultEmbedSourceLimit
+	}
+
+	size := int64(len(content))
+	if size > limit {
+		return nil, fmt.Errorf("%w: %s is %d bytes, limit is %d bytes", ErrSourceTooLarge, path, size, limit)
+	}
+
+	var compressed bytes.Buffer
+	zw, err := zlib.NewWriterLevel(&compressed, zlib.BestCompression)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create zlib writer: %w", err)
+	}
+	if _, err := zw.Write(content); err != nil {
+		return nil, fmt.Errorf("failed to compress %s: %w", path, err)
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to compress %s: %w", path, err)
+	}
+
+	digest := sha256.Sum256(content)
+
+	return &common.EmbeddedSource{
+		Filename:    filepath.Base(path),
+		Size:        size,
+		SHA256:      hex.EncodeToString(digest[:]),
+		Compression: sourceCompression,
+		Data:        base64.StdEncoding.EncodeToString(compressed.Bytes()),
+	}, nil
+}
+
+// ExtractEmbeddedSource reads a JSON or YAML export produced with source
+// embedding and returns the embedded metadata together with the original
+// file bytes. The bytes are verified against the recorded size and SHA-256
+// digest; a mismatch returns ErrSourceChecksumMismatch. Exports without
+// _meta.source return ErrNoEmbeddedSource. Sources declaring more than limit
+// bytes are refused with ErrSourceTooLarge before decompression; a
+// non-positive limit selects DefaultEmbedSourceLimit.
+func ExtractEmbeddedSource(export []byte, limit int64) (*common.EmbeddedSource, []byte, error) {
+	if limit <= 0 {
+		limit = DefaultEmbedSourceLimit
+	}
+
+	src, err := decodeExportSource(export)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if src.Compression != sourceCompression {
+		return nil, nil, fmt.Errorf("%w: unsupported compression %q", ErrInvalidEmbeddedSource, src.Compression)
+	}
+	if src.Size < 0 || src.Size > limit {
+		return nil, nil, fmt.Errorf("%w: embedded %s declares %d bytes, limit is %d bytes",
+			ErrSourceTooLarge, src.Filename, src.Size, limit)
+	}
+
+	compressed, err := base64.StdEncoding.DecodeString(src.Data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: invalid base64 data: %w", ErrInvalidEmbeddedSource, err)
+	}
+
+	zr, err := zlib.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: invalid zlib data: %w", ErrInvalidEmbeddedSource, err)
+	}
+	defer zr.Close()
+
+	// Read one byte past the declared size so a stream that inflates beyond
+	// it is detected without decompressing the remainder.
+	content, err := io.ReadAll(io.LimitReader(zr, src.Size+1))
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: invalid zlib data: %w", ErrInvalidEmbeddedSource, err)
+	}
+
+	digest := sha256.Sum256(content)
+	if int64(len(content)) != src.Size || hex.EncodeToString(digest[:]) != src.SHA256 {
+		return nil, nil, fmt.Errorf("%w: %s does not match its recorded size and SHA-256",
+			ErrSourceChecksumMismatch, src.Filename)
+	}
+
+	return src, content, nil
+}
+
+// exportSourceEnvelope decodes only the _meta.source object of an export.
+type exportSourceEnvelope struct {
+	Meta struct {
+		Source *common.EmbeddedSource `json:"source" yaml:"source"`
+	} `json:"_meta" yaml:"_meta"`
+}
+
+// decodeExportSource parses export as JSON when it is valid JSON and as YAML
+// otherwise, and returns its embedded source.
+func decodeExportSource(export []byte) (*common.EmbeddedSource, error) {
+	var envelope exportSourceEnvelope
+
+	var err error
+	if json.Valid(export) {
+		err = json.Unmarshal(export, &envelope)
+	} else {
+		err = yaml.Unmarshal(export, &envelope)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to parse export: %w", ErrInvalidEmbeddedSource, err)
+	}
+
+	if envelope.Meta.Source == nil {
+		return nil, ErrNoEmbeddedSource
+	}
+
+	return envelope.Meta.Source, nil
+}
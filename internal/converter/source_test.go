@@ -0,0 +1,133 @@
+package converter
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/converter/builder"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmbeddedSource_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join("..", "..", "testdata", "sample.config.1.xml")
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	src, err := NewEmbeddedSource(path, content, 0)
+	require.NoError(t, err)
+
+	digest := sha256.Sum256(content)
+	assert.Equal(t, "sample.config.1.xml", src.Filename)
+	assert.Equal(t, int64(len(content)), src.Size)
+	assert.Equal(t, hex.EncodeToString(digest[:]), src.SHA256)
+	assert.Equal(t, "zlib", src.Compression)
+
+	gen, err := NewHybridGenerator(builder.NewMarkdownBuilder(), nil)
+	require.NoError(t, err)
+
+	device := &common.CommonDevice{System: common.System{Hostname: "fw01"}}
+
+	for _, format := range []Format{FormatJSON, FormatYAML} {
+		t.Run(string(format), func(t *testing.T) {
+			t.Parallel()
+
+			opts := DefaultOptions().WithFormat(format).WithEmbeddedSource(src)
+
+			output, err := gen.Generate(t.Context(), device, opts)
+			require.NoError(t, err)
+
+			var streamed bytes.Buffer
+			require.NoError(t, gen.GenerateToWriter(t.Context(), &streamed, device, opts))
+
+			for _, export := range []string{output, streamed.String()} {
+				got, extracted, err := ExtractEmbeddedSource([]byte(export), 0)
+				require.NoError(t, err)
+				assert.Equal(t, content, extracted, "extracted bytes must equal the source file")
+				assert.Equal(t, src.SHA256, got.SHA256)
+				assert.Equal(t, "sample.config.1.xml", got.Filename)
+			}
+		})
+	}
+}
+
+func TestNewEmbeddedSource_SizeLimit(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewEmbeddedSource("config.xml", []byte("<opnsense/>"), 4)
+	require.ErrorIs(t, err, ErrSourceTooLarge)
+	assert.Contains(t, err.Error(), "config.xml is 11 bytes, limit is 4 bytes")
+
+	_, err = NewEmbeddedSource("config.xml", []byte("<opnsense/>"), 11)
+	require.NoError(t, err)
+}
+
+func TestExtractEmbeddedSource_Errors(t *testing.T) {
+	t.Parallel()
+
+	src, err := NewEmbeddedSource("config.xml", []byte("<opnsense><system/></opnsense>"), 0)
+	require.NoError(t, err)
+
+	exportWith := func(t *testing.T, s common.EmbeddedSource) []byte {
+		t.Helper()
+
+		data, err := json.Marshal(map[string]any{"_meta": map[string]any{"source": s}})
+		require.NoError(t, err)
+
+		return data
+	}
+
+	tampered := *src
+	other, err := NewEmbeddedSource("config.xml", []byte("<opnsense><system/></opnsensf>"), 0)
+	require.NoError(t, err)
+	tampered.Data = other.Data
+
+	oversized := *src
+	oversized.Size = 1 << 30
+
+	corrupt := *src
+	corrupt.Data = "not base64!"
+
+	tests := []struct {
+		name    string
+		export  []byte
+		limit   int64
+		wantErr error
+	}{
+		{name: "no embedded source", export: []byte(`{"_meta":{"modelVersion":"1.0.0"}}`), wantErr: ErrNoEmbeddedSource},
+		{name: "YAML without source", export: []byte("_meta:\n  modelVersion: 1.0.0\n"), wantErr: ErrNoEmbeddedSource},
+		{name: "not an export", export: []byte("- [unbalanced"), wantErr: ErrInvalidEmbeddedSource},
+		{name: "content does not match hash", export: exportWith(t, tampered), wantErr: ErrSourceChecksumMismatch},
+		{name: "declared size above limit", export: exportWith(t, oversized), wantErr: ErrSourceTooLarge},
+		{name: "limit below source size", export: exportWith(t, *src), limit: 8, wantErr: ErrSourceTooLarge},
+		{name: "invalid base64", export: exportWith(t, corrupt), wantErr: ErrInvalidEmbeddedSource},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, _, err := ExtractEmbeddedSource(tt.export, tt.limit)
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestExportWithoutEmbeddedSource(t *testing.T) {
+	t.Parallel()
+
+	gen, err := NewHybridGenerator(builder.NewMarkdownBuilder(), nil)
+	require.NoError(t, err)
+
+	output, err := gen.Generate(t.Context(), &common.CommonDevice{}, DefaultOptions().WithFormat(FormatJSON))
+	require.NoError(t, err)
+	assert.NotContains(t, output, `"source"`, "exports omit _meta.source unless embedding is requested")
+}
@@ -0,0 +1,171 @@
+// Package converter provides functionality to convert device configurations to various formats.
+package converter
+
+import (
+	"context"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+)
+
+// templateExecutor is the subset of text/template and html/template used to
+// render a compiled report template.
+type templateExecutor interface {
+	Execute(w io.Writer, data any) error
+}
+
+// TemplateConverter renders a device configuration with a user-supplied Go
+// template. Templates whose file name ends in .html or .htm (optionally
+// followed by .tmpl) are compiled with html/template so interpolated values
+// are escaped; all other templates use text/template. The template is
+// executed with the *common.CommonDevice as its data root and has access to
+// the functions returned by TemplateFuncs.
+//
+// A TemplateConverter is safe for concurrent use.
+type TemplateConverter struct {
+	templatePath string
+	tmpl         templateExecutor
+}
+
+// NewTemplateConverter reads and compiles the template at templatePath.
+// Read failures and syntax errors are returned wrapped in ErrInvalidTemplate;
+// syntax errors carry the template name and line number of the problem.
+func NewTemplateConverter(templatePath string) (*TemplateConverter, error) {
+	content, err := os.ReadFile(filepath.Clean(templatePath))
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to read %s: %w", ErrInvalidTemplate, templatePath, err)
+	}
+
+	name := filepath.Base(templatePath)
+
+	var tmpl templateExecutor
+	if isHTMLTemplate(templatePath) {
+		tmpl, err = htmltemplate.New(name).Funcs(htmltemplate.FuncMap(TemplateFuncs())).Parse(string(content))
+	} else {
+		tmpl, err = template.New(name).Funcs(TemplateFuncs()).Parse(string(content))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidTemplate, err)
+	}
+
+	return &TemplateConverter{templatePath: templatePath, tmpl: tmpl}, nil
+}
+
+// Render executes the template against data.
+// When redact is true, sensitive fields (passwords, private keys, community strings)
+// are replaced with [REDACTED] before the template sees them.
+func (c *TemplateConverter) Render(_ context.Context, data *common.CommonDevice, redact bool) (string, error) {
+	if data == nil {
+		return "", ErrNilDevice
+	}
+
+	target := prepareForExport(data, redact)
+
+	var sb strings.Builder
+	if err := c.tmpl.Execute(&sb, target); err != nil {
+		return "", fmt.Errorf("failed to execute template %s: %w", c.templatePath, err)
+	}
+
+	return sb.String(), nil
+}
+
+// FileExtension returns the extension for files rendered from the template:
+// the template file's extension with a trailing .tmpl, .tpl, or .gotmpl
+// removed, so "report.md.tmpl" yields ".md". A template named only
+// "report.tmpl" yields ".txt".
+func (c *TemplateConverter) FileExtension() string {
+	ext := filepath.Ext(templateBaseName(c.templatePath))
+	if ext == "" {
+		return ".txt"
+	}
+
+	return ext
+}
+
+// templateBaseName returns the base name of path with any template suffix
+// (.tmpl, .tpl, or .gotmpl) removed.
+func templateBaseName(path string) string {
+	base := filepath.Base(path)
+	switch strings.ToLower(filepath.Ext(base)) {
+	case ".tmpl", ".tpl", ".gotmpl":
+		return strings.TrimSuffix(base, filepath.Ext(base))
+	default:
+		return base
+	}
+}
+
+// isHTMLTemplate reports whether the template at path renders HTML.
+func isHTMLTemplate(path string) bool {
+	switch strings.ToLower(filepath.Ext(templateBaseName(path))) {
+	case ".html", ".htm":
+		return true
+	default:
+		return false
+	}
+}
+
+// TemplateFuncs returns the functions available to report templates in
+// addition to the text/template builtins:
+//
+//   - countRules: the number of enabled rules in a []common.FirewallRule.
+//   - enabledInterfaces: the enabled entries of a []common.Interface.
+//   - formatIP: an address and prefix length joined as CIDR ("10.0.0.1/24");
+//     the address alone when the prefix is empty, or "-" when both are.
+//   - join: strings.Join with the separator as the second argument.
+//   - default: the second argument when it is non-empty, otherwise the first.
+func TemplateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"countRules":        countEnabledRules,
+		"enabledInterfaces": enabledInterfaces,
+		"formatIP":          formatIP,
+		"join":              strings.Join,
+		"default": func(fallback, value string) string {
+			if value == "" {
+				return fallback
+			}
+			return value
+		},
+	}
+}
+
+// countEnabledRules returns the number of rules that are not disabled.
+func countEnabledRules(rules []common.FirewallRule) int {
+	count := 0
+	for _, rule := range rules {
+		if !rule.Disabled {
+			count++
+		}
+	}
+
+	return count
+}
+
+// enabledInterfaces returns the enabled interfaces, preserving order.
+func enabledInterfaces(ifaces []common.Interface) []common.Interface {
+	result := make([]common.Interface, 0, len(ifaces))
+	for _, iface := range ifaces {
+		if iface.Enabled {
+			result = append(result, iface)
+		}
+	}
+
+	return result
+}
+
+// formatIP joins address and prefix in CIDR notation.
+func formatIP(address, prefix string) string {
+	switch {
+	case address == "":
+		return "-"
+	case prefix == "":
+		return address
+	default:
+		return address + "/" + prefix
+	}
+}
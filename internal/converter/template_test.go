@@ -0,0 +1,120 @@
+package converter
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeTemplate writes content to a file named name in a fresh temp dir and
+// returns its path.
+func writeTemplate(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	return path
+}
+
+func TestTemplateConverter_Render(t *testing.T) {
+	tmpl, err := NewTemplateConverter(writeTemplate(t, "report.tmpl", "Host: {{.System.Hostname}}"))
+	require.NoError(t, err)
+
+	tests := GetCommonTestCases()
+	for i := range tests {
+		if tests[i].Name == "valid device" {
+			tests[i].ValidateOut = func(t *testing.T, result string) {
+				t.Helper()
+				assert.Equal(t, "Host: test-host", result)
+			}
+		}
+	}
+
+	RunConverterTests(t, tests, func(ctx context.Context, data *common.CommonDevice) (string, error) {
+		return tmpl.Render(ctx, data, false)
+	})
+}
+
+func TestTemplateConverter_Funcs(t *testing.T) {
+	content := `{{countRules .FirewallRules}} enabled rules
+{{range enabledInterfaces .Interfaces}}{{.Name}}={{formatIP .IPAddress .Subnet}}
+{{end}}{{join .System.DNSServers ", "}} {{default "n/a" .System.Domain}}`
+
+	tmpl, err := NewTemplateConverter(writeTemplate(t, "funcs.txt.tmpl", content))
+	require.NoError(t, err)
+
+	device := &common.CommonDevice{
+		System: common.System{DNSServers: []string{"1.1.1.1", "9.9.9.9"}},
+		FirewallRules: []common.FirewallRule{
+			{Description: "allow"},
+			{Description: "off", Disabled: true},
+		},
+		Interfaces: []common.Interface{
+			{Name: "lan", Enabled: true, IPAddress: "10.0.0.1", Subnet: "24"},
+			{Name: "opt1", Enabled: false, IPAddress: "10.1.0.1", Subnet: "24"},
+			{Name: "wan", Enabled: true, IPAddress: "dhcp"},
+		},
+	}
+
+	got, err := tmpl.Render(context.Background(), device, false)
+	require.NoError(t, err)
+	assert.Equal(t, "1 enabled rules\nlan=10.0.0.1/24\nwan=dhcp\n1.1.1.1, 9.9.9.9 n/a", got)
+}
+
+func TestTemplateConverter_HTMLEscapes(t *testing.T) {
+	tmpl, err := NewTemplateConverter(writeTemplate(t, "report.html.tmpl", "<h1>{{.System.Hostname}}</h1>"))
+	require.NoError(t, err)
+	assert.Equal(t, ".html", tmpl.FileExtension())
+
+	device := &common.CommonDevice{System: common.System{Hostname: "<script>"}}
+
+	got, err := tmpl.Render(context.Background(), device, false)
+	require.NoError(t, err)
+	assert.Equal(t, "<h1>&lt;script&gt;</h1>", got)
+}
+
+func TestNewTemplateConverter_Errors(t *testing.T) {
+	t.Run("syntax error", func(t *testing.T) {
+		_, err := NewTemplateConverter(writeTemplate(t, "bad.tmpl", "line one\n{{.System.Hostname"))
+		require.ErrorIs(t, err, ErrInvalidTemplate)
+		assert.Contains(t, err.Error(), "bad.tmpl:2")
+	})
+
+	t.Run("unknown function", func(t *testing.T) {
+		_, err := NewTemplateConverter(writeTemplate(t, "fn.tmpl", "{{countAliases .}}"))
+		require.ErrorIs(t, err, ErrInvalidTemplate)
+		assert.Contains(t, err.Error(), "countAliases")
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := NewTemplateConverter(filepath.Join(t.TempDir(), "missing.tmpl"))
+		require.ErrorIs(t, err, ErrInvalidTemplate)
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
+
+func TestTemplateConverter_FileExtension(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"summary.md.tmpl", ".md"},
+		{"summary.csv.gotmpl", ".csv"},
+		{"summary.tpl", ".txt"},
+		{"summary.md", ".md"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl, err := NewTemplateConverter(writeTemplate(t, tt.name, "x"))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, tmpl.FileExtension())
+		})
+	}
+}
@@ -27,8 +27,8 @@ func (c *YAMLConverter) ToYAML(_ context.Context, data *common.CommonDevice, red
 
 	target := prepareForExport(data, redact)
 
-	// Marshal the CommonDevice struct to YAML
-	yamlBytes, err := yaml.Marshal(target)
+	// Marshal the export document (_meta followed by the device) to YAML
+	yamlBytes, err := yaml.Marshal(newExportDocument(target, nil))
 	if err != nil {
 		return "", fmt.Errorf("failed to marshal to YAML: %w", err)
 	}
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v3"
 )
@@ -18,6 +19,15 @@ func TestYAMLConverter_ToYAML(t *testing.T) {
 				var parsed map[string]any
 				err := yaml.Unmarshal([]byte(result), &parsed)
 				require.NoError(t, err, "Result should be valid YAML")
+
+				meta, ok := parsed["_meta"].(map[string]any)
+				require.True(t, ok, "YAML export should carry a _meta object")
+				assert.Equal(t, common.ModelVersion, meta["modelVersion"])
+				assert.NotEmpty(t, meta["toolVersion"])
+
+				system, ok := parsed["system"].(map[string]any)
+				require.True(t, ok, "device fields should stay at the top level")
+				assert.Equal(t, "test-host", system["hostname"])
 			}
 		}
 	}
@@ -72,6 +72,19 @@ func (a *Analyzer) CompareNAT(old, newCfg common.NATConfig) []Change {
 		})
 	}
 
+	// Compare 1:1 (binat) rule counts
+	if len(old.OneToOneRules) != len(newCfg.OneToOneRules) {
+		changes = append(changes, Change{
+			Type:           ChangeModified,
+			Section:        SectionNAT,
+			Path:           "nat.onetoone.rules",
+			Description:    "1:1 NAT rule count changed",
+			OldValue:       fmt.Sprintf("%d rules", len(old.OneToOneRules)),
+			NewValue:       fmt.Sprintf("%d rules", len(newCfg.OneToOneRules)),
+			SecurityImpact: "medium",
+		})
+	}
+
 	// Compare NAT boolean settings
 	if old.ReflectionDisabled != newCfg.ReflectionDisabled {
 		changes = append(changes, Change{
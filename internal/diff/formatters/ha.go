@@ -0,0 +1,143 @@
+package formatters
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/diff"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+)
+
+// haValueDisplayLimit is the longest node value shown in a markdown findings
+// table; longer values, such as whole rules, are truncated.
+const haValueDisplayLimit = 80
+
+// HAFormatter defines the interface for HA pair comparison output formatters.
+type HAFormatter interface {
+	Format(result *diff.HAResult) error
+}
+
+// NewHA creates an HAFormatter for the given format name and writer.
+// Supported formats: markdown, json.
+func NewHA(format string, w io.Writer) (HAFormatter, error) {
+	switch strings.ToLower(format) {
+	case FormatMarkdown, "":
+		return NewHAMarkdownFormatter(w), nil
+	case FormatJSON:
+		return NewHAJSONFormatter(w), nil
+	default:
+		return nil, fmt.Errorf("unsupported format: %s", format)
+	}
+}
+
+// HAJSONFormatter formats HA pair comparison results as JSON.
+type HAJSONFormatter struct {
+	writer io.Writer
+}
+
+// NewHAJSONFormatter creates a new HA JSON formatter.
+func NewHAJSONFormatter(writer io.Writer) *HAJSONFormatter {
+	return &HAJSONFormatter{writer: writer}
+}
+
+// Format formats the HA comparison result as indented JSON.
+func (f *HAJSONFormatter) Format(result *diff.HAResult) error {
+	encoder := json.NewEncoder(f.writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(result)
+}
+
+// HAMarkdownFormatter formats HA pair comparison results as markdown.
+type HAMarkdownFormatter struct {
+	writer io.Writer
+}
+
+// NewHAMarkdownFormatter creates a new HA markdown formatter.
+func NewHAMarkdownFormatter(writer io.Writer) *HAMarkdownFormatter {
+	return &HAMarkdownFormatter{writer: writer}
+}
+
+// Format formats the HA comparison result as markdown: the compared nodes, a
+// count of findings per severity, and a table of the findings.
+func (f *HAMarkdownFormatter) Format(result *diff.HAResult) error {
+	var b strings.Builder
+
+	b.WriteString("# HA Pair Comparison\n\n")
+
+	meta := result.Metadata
+	writeHANode(&b, "Primary", meta.PrimaryFile, meta.PrimaryHostname)
+	writeHANode(&b, "Secondary", meta.SecondaryFile, meta.SecondaryHostname)
+	if !meta.ComparedAt.IsZero() {
+		fmt.Fprintf(&b, "**Compared At:** %s\n", meta.ComparedAt.Format("2006-01-02 15:04:05"))
+	}
+	if meta.ToolVersion != "" {
+		fmt.Fprintf(&b, "**Tool Version:** %s\n", meta.ToolVersion)
+	}
+	b.WriteString("\n")
+
+	b.WriteString("## Summary\n\n")
+	b.WriteString("| Severity | Count |\n")
+	b.WriteString("|----------|-------|\n")
+	for _, severity := range []common.Severity{
+		common.SeverityHigh, common.SeverityMedium, common.SeverityLow, common.SeverityInfo,
+	} {
+		count := 0
+		for _, finding := range result.Findings {
+			if finding.Severity == severity {
+				count++
+			}
+		}
+		fmt.Fprintf(&b, "| %s | %d |\n", capitalizeFirst(severity.String()), count)
+	}
+	fmt.Fprintf(&b, "| **Total** | **%d** |\n\n", len(result.Findings))
+	fmt.Fprintf(&b, "%d expected differences matched the allowlist.\n\n", result.AllowedDifferences)
+
+	if !result.HasFindings() {
+		b.WriteString("*No unexpected divergences between the nodes.*\n")
+	} else {
+		b.WriteString("## Findings\n\n")
+		b.WriteString("| Severity | Path | Description | Primary | Secondary |\n")
+		b.WriteString("|----------|------|-------------|---------|-----------|\n")
+		for _, finding := range result.Findings {
+			fmt.Fprintf(&b, "| %s | `%s` | %s | %s | %s |\n",
+				capitalizeFirst(finding.Severity.String()),
+				escapeMarkdown(finding.Path),
+				escapeMarkdown(finding.Description),
+				haTableValue(finding.Primary),
+				haTableValue(finding.Secondary),
+			)
+		}
+	}
+
+	_, err := io.WriteString(f.writer, b.String())
+	return err
+}
+
+// writeHANode writes the metadata line for one node of the pair.
+func writeHANode(b *strings.Builder, label, file, hostname string) {
+	if file == "" && hostname == "" {
+		return
+	}
+
+	fmt.Fprintf(b, "**%s:** `%s`", label, file)
+	if hostname != "" {
+		fmt.Fprintf(b, " (%s)", hostname)
+	}
+	b.WriteString("\n")
+}
+
+// haTableValue renders a node value for a markdown table cell, truncated to
+// haValueDisplayLimit runes, or "-" when the node has no value.
+func haTableValue(value string) string {
+	if value == "" {
+		return "-"
+	}
+
+	if runes := []rune(value); len(runes) > haValueDisplayLimit {
+		value = string(runes[:haValueDisplayLimit]) + "…"
+	}
+
+	return "`" + escapeMarkdown(value) + "`"
+}
@@ -0,0 +1,99 @@
+package formatters
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/diff"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func haTestResult() *diff.HAResult {
+	return &diff.HAResult{
+		Metadata: diff.HAMetadata{
+			PrimaryFile:       "primary.xml",
+			SecondaryFile:     "secondary.xml",
+			PrimaryHostname:   "fw-a",
+			SecondaryHostname: "fw-b",
+			ComparedAt:        time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC),
+			ToolVersion:       "1.0.0",
+		},
+		AllowedDifferences: 4,
+		Findings: []diff.HAFinding{
+			{
+				Severity:    common.SeverityHigh,
+				Section:     "firewallRules",
+				Path:        "/firewallRules/2",
+				Description: "Entry uuid=abc present only on the secondary",
+				Secondary:   `{"description":"Temporary SSH | debug access","type":"pass"}` + strings.Repeat("x", 100),
+			},
+			{
+				Severity:    common.SeverityInfo,
+				Section:     "firewallRules",
+				Path:        "/firewallRules/0/description",
+				Description: "Value differs between nodes",
+				Primary:     "web",
+				Secondary:   "web server",
+			},
+		},
+	}
+}
+
+func TestNewHA(t *testing.T) {
+	var buf bytes.Buffer
+
+	for _, format := range []string{"markdown", "JSON", ""} {
+		formatter, err := NewHA(format, &buf)
+		require.NoError(t, err, format)
+		assert.NotNil(t, formatter)
+	}
+
+	_, err := NewHA("html", &buf)
+	require.Error(t, err)
+}
+
+func TestHAMarkdownFormatter_Format(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, NewHAMarkdownFormatter(&buf).Format(haTestResult()))
+
+	output := buf.String()
+	assert.Contains(t, output, "# HA Pair Comparison")
+	assert.Contains(t, output, "**Primary:** `primary.xml` (fw-a)")
+	assert.Contains(t, output, "| High | 1 |")
+	assert.Contains(t, output, "| Info | 1 |")
+	assert.Contains(t, output, "| **Total** | **2** |")
+	assert.Contains(t, output, "4 expected differences matched the allowlist.")
+	assert.Contains(t, output, "| High | `/firewallRules/2` | Entry uuid=abc present only on the secondary | - |")
+	assert.Contains(t, output, `Temporary SSH \| debug access`)
+	assert.Contains(t, output, "…`", "long values should be truncated")
+	assert.Contains(t, output, "| `web` | `web server` |")
+}
+
+func TestHAMarkdownFormatter_Format_NoFindings(t *testing.T) {
+	var buf bytes.Buffer
+
+	result := haTestResult()
+	result.Findings = []diff.HAFinding{}
+	require.NoError(t, NewHAMarkdownFormatter(&buf).Format(result))
+
+	output := buf.String()
+	assert.Contains(t, output, "*No unexpected divergences between the nodes.*")
+	assert.NotContains(t, output, "## Findings")
+}
+
+func TestHAJSONFormatter_Format(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, NewHAJSONFormatter(&buf).Format(haTestResult()))
+
+	var decoded diff.HAResult
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(t, 4, decoded.AllowedDifferences)
+	require.Len(t, decoded.Findings, 2)
+	assert.Equal(t, common.SeverityHigh, decoded.Findings[0].Severity)
+	assert.Equal(t, "fw-b", decoded.Metadata.SecondaryHostname)
+}
@@ -0,0 +1,419 @@
+package diff
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/constants"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+)
+
+// defaultHAAllowlist lists the JSON export paths expected to differ between
+// the two nodes of an HA pair: node identity, interface addresses, CARP skew,
+// the sync settings that point each node at its peer, and the revision
+// metadata written on every save.
+var defaultHAAllowlist = []string{
+	"/system/hostname",
+	"/interfaces/*/ipAddress",
+	"/interfaces/*/ipv6Address",
+	"/virtualIps/*/advSkew",
+	"/highAvailability/pfsyncPeerIp",
+	"/highAvailability/synchronizeToIp",
+	"/highAvailability/username",
+	"/highAvailability/password",
+	"/highAvailability/syncItems",
+	"/revision",
+}
+
+// haIdentityKeys are the object fields, in order of preference, that identify
+// an array element on both nodes. Unlike patchIdentityKeys they include names,
+// so users, interfaces, and tunables are matched regardless of position.
+var haIdentityKeys = []string{"uuid", "tracker", "name", "tunable"}
+
+// haOrderedIdentityKeys are the identity keys of arrays whose element order
+// is significant, such as firewall rules, so a reordering is a divergence.
+var haOrderedIdentityKeys = []string{"uuid", "tracker"}
+
+// haDerivedKeys are top-level fields computed from the configuration rather
+// than configured, so differences in them repeat other divergences.
+var haDerivedKeys = []string{
+	"statistics",
+	"analysis",
+	"securityAssessment",
+	"performanceMetrics",
+	"complianceResults",
+}
+
+// haSectionSeverities maps top-level JSON export fields to the severity of a
+// divergence within them. Fields not listed diverge with low severity.
+var haSectionSeverities = map[string]common.Severity{
+	"firewallRules":    common.SeverityHigh,
+	"nat":              common.SeverityHigh,
+	"namedObjects":     common.SeverityHigh,
+	"schedules":        common.SeverityHigh,
+	"users":            common.SeverityMedium,
+	"groups":           common.SeverityMedium,
+	"authServers":      common.SeverityMedium,
+	"system":           common.SeverityMedium,
+	"sysctl":           common.SeverityMedium,
+	"vpn":              common.SeverityMedium,
+	"certificates":     common.SeverityMedium,
+	"cas":              common.SeverityMedium,
+	"highAvailability": common.SeverityMedium,
+	"pf":               common.SeverityMedium,
+	"ids":              common.SeverityMedium,
+}
+
+// DefaultHAAllowlist returns the JSON export paths CompareHAPair expects to
+// differ between HA nodes unless told otherwise.
+func DefaultHAAllowlist() []string {
+	return slices.Clone(defaultHAAllowlist)
+}
+
+// HAFinding is a difference between the two nodes of an HA pair that the
+// allowlist does not expect.
+type HAFinding struct {
+	Severity    common.Severity `json:"severity"`
+	Section     string          `json:"section"`
+	Path        string          `json:"path"`
+	Description string          `json:"description"`
+	Primary     string          `json:"primary,omitempty"`
+	Secondary   string          `json:"secondary,omitempty"`
+}
+
+// HAMetadata describes the compared HA pair.
+type HAMetadata struct {
+	PrimaryFile       string    `json:"primary_file"`
+	SecondaryFile     string    `json:"secondary_file"`
+	PrimaryHostname   string    `json:"primary_hostname,omitempty"`
+	SecondaryHostname string    `json:"secondary_hostname,omitempty"`
+	Allowlist         []string  `json:"allowlist"`
+	ComparedAt        time.Time `json:"compared_at"`
+	ToolVersion       string    `json:"tool_version"`
+}
+
+// HAResult is the outcome of comparing the two nodes of an HA pair.
+type HAResult struct {
+	Metadata HAMetadata `json:"metadata"`
+	// AllowedDifferences counts the differences the allowlist matched.
+	AllowedDifferences int         `json:"allowed_differences"`
+	Findings           []HAFinding `json:"findings"`
+}
+
+// HasFindings returns true if the nodes diverge outside the allowlist.
+func (r *HAResult) HasFindings() bool {
+	return len(r.Findings) > 0
+}
+
+// CompareHAPair compares the JSON exports of the primary and secondary nodes
+// of an HA pair and reports every difference outside allowlist as a finding.
+// Allowlist entries are JSON Pointers into the export (e.g.
+// "/interfaces/*/ipAddress") in which "*" matches any single segment; an
+// entry also allows everything beneath it. Array elements are matched by
+// uuid, tracker, name, or tunable when every element has a distinct one, and
+// paths use the element's index on the primary.
+//
+// A divergence is graded by the section it falls in, firewall rules and NAT
+// being high, except that a differing description is cosmetic and graded
+// info. Findings are ordered by severity, and in configuration order within a
+// severity.
+func CompareHAPair(primary, secondary *common.CommonDevice, allowlist []string) (*HAResult, error) {
+	primaryDoc, err := toJSONObject(primary)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode primary config: %w", err)
+	}
+
+	secondaryDoc, err := toJSONObject(secondary)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode secondary config: %w", err)
+	}
+
+	for _, key := range haDerivedKeys {
+		delete(primaryDoc, key)
+		delete(secondaryDoc, key)
+	}
+
+	c := &haComparer{allowlist: parseHAAllowlist(allowlist)}
+	c.compareValues("", primaryDoc, secondaryDoc)
+
+	slices.SortStableFunc(c.findings, func(a, b HAFinding) int {
+		return cmp.Compare(haSeverityRank(a.Severity), haSeverityRank(b.Severity))
+	})
+
+	result := &HAResult{
+		Metadata: HAMetadata{
+			Allowlist:   slices.Clone(allowlist),
+			ComparedAt:  time.Now(),
+			ToolVersion: constants.Version,
+		},
+		AllowedDifferences: c.allowed,
+		Findings:           c.findings,
+	}
+	if result.Findings == nil {
+		result.Findings = []HAFinding{}
+	}
+
+	if primary != nil {
+		result.Metadata.PrimaryHostname = primary.System.Hostname
+	}
+	if secondary != nil {
+		result.Metadata.SecondaryHostname = secondary.System.Hostname
+	}
+
+	return result, nil
+}
+
+// haComparer accumulates the findings of one CompareHAPair call.
+type haComparer struct {
+	allowlist [][]string
+	allowed   int
+	findings  []HAFinding
+}
+
+// compareValues compares the primary and secondary values at path.
+func (c *haComparer) compareValues(path string, primary, secondary any) {
+	switch p := primary.(type) {
+	case map[string]any:
+		if s, ok := secondary.(map[string]any); ok {
+			c.compareObjects(path, p, s)
+			return
+		}
+	case []any:
+		if s, ok := secondary.([]any); ok {
+			c.compareArrays(path, p, s)
+			return
+		}
+	}
+
+	if reflect.DeepEqual(primary, secondary) {
+		return
+	}
+
+	c.report(path, "Value differs between nodes", primary, secondary)
+}
+
+// compareObjects compares two objects member by member; a member missing on
+// one node is compared as null.
+func (c *haComparer) compareObjects(path string, primary, secondary map[string]any) {
+	keys := slices.Sorted(maps.Keys(primary))
+	for key := range secondary {
+		if _, ok := primary[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		c.compareValues(path+"/"+escapePointerToken(key), primary[key], secondary[key])
+	}
+}
+
+// compareArrays compares two arrays by element identity when the elements
+// carry one, and by position otherwise.
+func (c *haComparer) compareArrays(path string, primary, secondary []any) {
+	key := haIdentityKey(primary, secondary)
+	if key == "" {
+		for i := range max(len(primary), len(secondary)) {
+			elemPath := path + "/" + strconv.Itoa(i)
+			switch {
+			case i >= len(primary):
+				c.report(elemPath, "Entry present only on the secondary", nil, secondary[i])
+			case i >= len(secondary):
+				c.report(elemPath, "Entry present only on the primary", primary[i], nil)
+			default:
+				c.compareValues(elemPath, primary[i], secondary[i])
+			}
+		}
+
+		return
+	}
+
+	secondaryByID := make(map[string]any, len(secondary))
+	for _, elem := range secondary {
+		secondaryByID[elementIdentity(elem, key)] = elem
+	}
+
+	var shared []string
+	for i, elem := range primary {
+		id := elementIdentity(elem, key)
+		elemPath := path + "/" + strconv.Itoa(i)
+
+		match, ok := secondaryByID[id]
+		if !ok {
+			c.report(elemPath, fmt.Sprintf("Entry %s=%s present only on the primary", key, id), elem, nil)
+			continue
+		}
+
+		shared = append(shared, id)
+		c.compareValues(elemPath, elem, match)
+	}
+
+	primaryIDs := make(map[string]bool, len(primary))
+	for _, elem := range primary {
+		primaryIDs[elementIdentity(elem, key)] = true
+	}
+
+	var sharedSecondary []string
+	for j, elem := range secondary {
+		id := elementIdentity(elem, key)
+		if primaryIDs[id] {
+			sharedSecondary = append(sharedSecondary, id)
+			continue
+		}
+
+		c.report(
+			path+"/"+strconv.Itoa(j),
+			fmt.Sprintf("Entry %s=%s present only on the secondary", key, id),
+			nil, elem,
+		)
+	}
+
+	if slices.Contains(haOrderedIdentityKeys, key) && !slices.Equal(shared, sharedSecondary) {
+		c.report(path, "Entries are in a different order on each node", nil, nil)
+	}
+}
+
+// report records a divergence at path, or counts it as allowed when the
+// allowlist matches the path.
+func (c *haComparer) report(path, description string, primary, secondary any) {
+	segments := splitPointer(path)
+	if c.allows(segments) {
+		c.allowed++
+		return
+	}
+
+	section := ""
+	if len(segments) > 0 {
+		section = segments[0]
+	}
+
+	severity, ok := haSectionSeverities[section]
+	if !ok {
+		severity = common.SeverityLow
+	}
+
+	if segments[len(segments)-1] == "description" {
+		severity = common.SeverityInfo
+	}
+
+	c.findings = append(c.findings, HAFinding{
+		Severity:    severity,
+		Section:     section,
+		Path:        path,
+		Description: description,
+		Primary:     haDisplayValue(primary),
+		Secondary:   haDisplayValue(secondary),
+	})
+}
+
+// allows reports whether an allowlist entry matches segments or one of its
+// ancestors.
+func (c *haComparer) allows(segments []string) bool {
+	return slices.ContainsFunc(c.allowlist, func(pattern []string) bool {
+		if len(pattern) > len(segments) {
+			return false
+		}
+
+		for i, want := range pattern {
+			if want != "*" && want != segments[i] {
+				return false
+			}
+		}
+
+		return true
+	})
+}
+
+// parseHAAllowlist splits allowlist entries into pointer segments, ignoring
+// blank entries. A missing leading "/" is tolerated.
+func parseHAAllowlist(allowlist []string) [][]string {
+	patterns := make([][]string, 0, len(allowlist))
+	for _, entry := range allowlist {
+		entry = strings.TrimSpace(entry)
+		if entry == "" || entry == "/" {
+			continue
+		}
+
+		if !strings.HasPrefix(entry, "/") {
+			entry = "/" + entry
+		}
+
+		patterns = append(patterns, splitPointer(entry))
+	}
+
+	return patterns
+}
+
+// splitPointer splits a JSON Pointer into its unescaped reference tokens.
+func splitPointer(pointer string) []string {
+	if pointer == "" {
+		return []string{""}
+	}
+
+	tokens := strings.Split(strings.TrimPrefix(pointer, "/"), "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+
+	return tokens
+}
+
+// haIdentityKey returns the first of haIdentityKeys that identifies every
+// element of both arrays, or "" when none does.
+func haIdentityKey(primary, secondary []any) string {
+	if len(primary) == 0 || len(secondary) == 0 {
+		return ""
+	}
+
+	for _, key := range haIdentityKeys {
+		if uniqueIdentities(primary, key) && uniqueIdentities(secondary, key) {
+			return key
+		}
+	}
+
+	return ""
+}
+
+// haDisplayValue renders a JSON value for a finding: strings as-is, other
+// values as compact JSON, and a missing value as "".
+func haDisplayValue(v any) string {
+	switch typed := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return typed
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+
+	return string(data)
+}
+
+// haSeverityOrder ranks severities from most to least severe for ordering
+// findings. An unrecognized severity sorts last.
+var haSeverityOrder = map[common.Severity]int{
+	common.SeverityCritical: 0,
+	common.SeverityHigh:     1,
+	common.SeverityMedium:   2,
+	common.SeverityLow:      3,
+	common.SeverityInfo:     4,
+}
+
+// haSeverityRank returns the sort rank of s from haSeverityOrder.
+func haSeverityRank(s common.Severity) int {
+	if r, ok := haSeverityOrder[s]; ok {
+		return r
+	}
+
+	return len(haSeverityOrder)
+}
@@ -0,0 +1,134 @@
+package diff
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/cfgparser"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/EvilBit-Labs/opnDossier/pkg/parser/opnsense"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// parseHAFixture parses an HA pair fixture from testdata/ha.
+func parseHAFixture(t *testing.T, name string) *common.CommonDevice {
+	t.Helper()
+
+	file, err := os.Open(filepath.Join("..", "..", "testdata", "ha", name))
+	require.NoError(t, err)
+	defer file.Close()
+
+	doc, err := cfgparser.NewXMLParser().Parse(context.Background(), file)
+	require.NoError(t, err)
+
+	device, _, err := opnsense.ConvertDocument(doc)
+	require.NoError(t, err)
+
+	return device
+}
+
+func TestCompareHAPair_AllowedDifferencesOnly(t *testing.T) {
+	t.Parallel()
+
+	primary := parseHAFixture(t, "primary.xml")
+	secondary := parseHAFixture(t, "secondary.xml")
+
+	result, err := CompareHAPair(primary, secondary, DefaultHAAllowlist())
+	require.NoError(t, err)
+
+	assert.Empty(t, result.Findings)
+	assert.False(t, result.HasFindings())
+	assert.Positive(t, result.AllowedDifferences)
+	assert.Equal(t, "fw-a", result.Metadata.PrimaryHostname)
+	assert.Equal(t, "fw-b", result.Metadata.SecondaryHostname)
+}
+
+func TestCompareHAPair_FirewallRuleDivergence(t *testing.T) {
+	t.Parallel()
+
+	primary := parseHAFixture(t, "primary.xml")
+	secondary := parseHAFixture(t, "secondary_rule_drift.xml")
+
+	result, err := CompareHAPair(primary, secondary, DefaultHAAllowlist())
+	require.NoError(t, err)
+
+	require.Len(t, result.Findings, 1)
+	finding := result.Findings[0]
+	assert.Equal(t, common.SeverityHigh, finding.Severity)
+	assert.Equal(t, "firewallRules", finding.Section)
+	assert.Equal(t, "/firewallRules/2", finding.Path)
+	assert.Contains(t, finding.Description, "present only on the secondary")
+	assert.Empty(t, finding.Primary)
+	assert.Contains(t, finding.Secondary, "Temporary SSH access")
+}
+
+func TestCompareHAPair_Grading(t *testing.T) {
+	t.Parallel()
+
+	primary := &common.CommonDevice{
+		System: common.System{Hostname: "fw-a", Timezone: "UTC"},
+		FirewallRules: []common.FirewallRule{
+			{UUID: "a", Type: common.RuleTypePass, Description: "web"},
+			{UUID: "b", Type: common.RuleTypeBlock, Description: "block"},
+		},
+		Users:  []common.User{{Name: "admin", Scope: "system"}, {Name: "ops", Scope: "user"}},
+		Sysctl: []common.SysctlItem{{Tunable: "net.inet.tcp.syncookies", Value: "1"}},
+	}
+
+	secondary := &common.CommonDevice{
+		System: common.System{Hostname: "fw-b", Timezone: "Europe/Berlin"},
+		FirewallRules: []common.FirewallRule{
+			{UUID: "b", Type: common.RuleTypeBlock, Description: "block"},
+			{UUID: "a", Type: common.RuleTypePass, Description: "web server"},
+		},
+		Users:  []common.User{{Name: "ops", Scope: "user"}},
+		Sysctl: []common.SysctlItem{{Tunable: "net.inet.tcp.syncookies", Value: "0"}},
+	}
+
+	result, err := CompareHAPair(primary, secondary, []string{"/system/hostname", "system/timezone"})
+	require.NoError(t, err)
+
+	got := make(map[string]common.Severity, len(result.Findings))
+	for _, f := range result.Findings {
+		got[f.Path] = f.Severity
+	}
+
+	assert.Equal(t, map[string]common.Severity{
+		"/firewallRules":               common.SeverityHigh,
+		"/firewallRules/0/description": common.SeverityInfo,
+		"/users/0":                     common.SeverityMedium,
+		"/sysctl/0/value":              common.SeverityMedium,
+	}, got)
+	assert.Equal(t, 2, result.AllowedDifferences)
+
+	assert.Equal(t, common.SeverityHigh, result.Findings[0].Severity, "findings should be ordered by severity")
+	assert.Equal(t, common.SeverityInfo, result.Findings[len(result.Findings)-1].Severity)
+}
+
+func TestCompareHAPair_WildcardAllowlist(t *testing.T) {
+	t.Parallel()
+
+	primary := &common.CommonDevice{Interfaces: []common.Interface{
+		{Name: "lan", IPAddress: "10.0.1.2", Description: "LAN"},
+		{Name: "wan", IPAddress: "192.0.2.2", Description: "WAN"},
+	}}
+	secondary := &common.CommonDevice{Interfaces: []common.Interface{
+		{Name: "wan", IPAddress: "192.0.2.3", Description: "Uplink"},
+		{Name: "lan", IPAddress: "10.0.1.3", Description: "LAN"},
+	}}
+
+	result, err := CompareHAPair(primary, secondary, DefaultHAAllowlist())
+	require.NoError(t, err)
+	require.Len(t, result.Findings, 1, "interfaces should be matched by name, not position")
+	assert.Equal(t, "/interfaces/1/description", result.Findings[0].Path)
+	assert.Equal(t, "WAN", result.Findings[0].Primary)
+	assert.Equal(t, "Uplink", result.Findings[0].Secondary)
+
+	result, err = CompareHAPair(primary, secondary, append(DefaultHAAllowlist(), "/interfaces/*/description"))
+	require.NoError(t, err)
+	assert.Empty(t, result.Findings)
+	assert.Equal(t, 3, result.AllowedDifferences)
+}
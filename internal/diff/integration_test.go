@@ -142,6 +142,45 @@ func TestIntegration_AllConfigPairs(t *testing.T) {
 	}
 }
 
+// TestIntegration_PatchesReproduceNewExport applies the JSON Patch and merge
+// patch between adjacent sample configs to the old JSON export and checks
+// each reproduces the new one.
+func TestIntegration_PatchesReproduceNewExport(t *testing.T) {
+	testdataDir := findTestdataDir(t)
+
+	configs := []string{}
+	for i := 1; i <= 7; i++ {
+		path := filepath.Join(testdataDir, fmt.Sprintf("sample.config.%d.xml", i))
+		if fileExists(path) {
+			configs = append(configs, path)
+		}
+	}
+
+	if len(configs) < 2 {
+		t.Skip("Not enough config files found for pair comparison")
+	}
+
+	for i := 0; i < len(configs)-1; i++ {
+		oldPath := configs[i]
+		newPath := configs[i+1]
+
+		t.Run(filepath.Base(oldPath)+"_vs_"+filepath.Base(newPath), func(t *testing.T) {
+			oldConfig, err := tryParseConfigFile(oldPath)
+			if err != nil {
+				t.Skipf("Skipping: could not parse %s: %v", filepath.Base(oldPath), err)
+			}
+
+			newConfig, err := tryParseConfigFile(newPath)
+			if err != nil {
+				t.Skipf("Skipping: could not parse %s: %v", filepath.Base(newPath), err)
+			}
+
+			ops := assertPatchesApply(t, oldConfig, newConfig, Options{})
+			t.Logf("JSON Patch operations: %d", len(ops))
+		})
+	}
+}
+
 // Helper functions
 
 func findTestdataDir(t *testing.T) string {
@@ -0,0 +1,360 @@
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+)
+
+// JSON Patch operation names (RFC 6902) emitted by BuildJSONPatch.
+const (
+	PatchOpAdd     = "add"
+	PatchOpRemove  = "remove"
+	PatchOpReplace = "replace"
+	PatchOpMove    = "move"
+)
+
+// patchIdentityKeys are the object fields, in order of preference, that
+// identify an array element across the two models. An array is diffed by
+// identity when every element on both sides is an object with a unique,
+// non-empty value for the key; otherwise it is diffed by index.
+var patchIdentityKeys = []string{"uuid", "tracker"}
+
+// sectionJSONKeys maps each implemented diff section to the top-level JSON
+// export fields it covers.
+var sectionJSONKeys = map[Section][]string{
+	SectionSystem:     {"system"},
+	SectionFirewall:   {"firewallRules"},
+	SectionNAT:        {"nat"},
+	SectionInterfaces: {"interfaces"},
+	SectionVLANs:      {"vlans"},
+	SectionDHCP:       {"dhcp"},
+	SectionUsers:      {"users"},
+	SectionRouting:    {"routing"},
+}
+
+// PatchOperation is one RFC 6902 JSON Patch operation. Path and From are
+// JSON Pointers (RFC 6901) into the JSON export of the model.
+type PatchOperation struct {
+	Op    string
+	From  string
+	Path  string
+	Value any
+}
+
+// MarshalJSON encodes the operation with the members RFC 6902 defines for
+// it: "from" for move, and "value" (including null) for add and replace.
+func (o PatchOperation) MarshalJSON() ([]byte, error) {
+	type withValue struct {
+		Op    string `json:"op"`
+		Path  string `json:"path"`
+		Value any    `json:"value"`
+	}
+
+	type withoutValue struct {
+		Op   string `json:"op"`
+		From string `json:"from,omitempty"`
+		Path string `json:"path"`
+	}
+
+	switch o.Op {
+	case PatchOpAdd, PatchOpReplace:
+		return json.Marshal(withValue{Op: o.Op, Path: o.Path, Value: o.Value})
+	default:
+		return json.Marshal(withoutValue{Op: o.Op, From: o.From, Path: o.Path})
+	}
+}
+
+// BuildJSONPatch returns the RFC 6902 JSON Patch that transforms the JSON
+// export of oldCfg into that of newCfg. Paths use the export's field names
+// (e.g. "/firewallRules/12/destination/port"). Arrays whose elements carry a
+// uuid or tracker are diffed by identity, so reordered rules become move
+// operations instead of a rewrite of every position; other arrays are diffed
+// by index. When opts selects sections, only their top-level fields are
+// compared.
+func BuildJSONPatch(oldCfg, newCfg *common.CommonDevice, opts Options) ([]PatchOperation, error) {
+	oldDoc, newDoc, err := patchDocuments(oldCfg, newCfg, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	ops := []PatchOperation{}
+	return diffPatchValues(ops, "", oldDoc, newDoc), nil
+}
+
+// BuildMergePatch returns the RFC 7386 JSON Merge Patch that transforms the
+// JSON export of oldCfg into that of newCfg, restricted to the sections
+// selected by opts. Merge patches replace arrays wholesale and cannot set a
+// field to null, since null removes the field.
+func BuildMergePatch(oldCfg, newCfg *common.CommonDevice, opts Options) (map[string]any, error) {
+	oldDoc, newDoc, err := patchDocuments(oldCfg, newCfg, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return mergePatchObjects(oldDoc, newDoc), nil
+}
+
+// patchDocuments returns the JSON exports of both models as generic objects,
+// keeping only the top-level fields of the sections selected by opts.
+func patchDocuments(oldCfg, newCfg *common.CommonDevice, opts Options) (map[string]any, map[string]any, error) {
+	oldDoc, err := toJSONObject(oldCfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode old config: %w", err)
+	}
+
+	newDoc, err := toJSONObject(newCfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode new config: %w", err)
+	}
+
+	if len(opts.Sections) == 0 {
+		return oldDoc, newDoc, nil
+	}
+
+	var keep []string
+	for _, section := range ImplementedSections() {
+		if opts.ShouldIncludeSection(section) {
+			keep = append(keep, sectionJSONKeys[section]...)
+		}
+	}
+
+	filter := func(doc map[string]any) map[string]any {
+		filtered := make(map[string]any, len(keep))
+		for _, key := range keep {
+			if v, ok := doc[key]; ok {
+				filtered[key] = v
+			}
+		}
+		return filtered
+	}
+
+	return filter(oldDoc), filter(newDoc), nil
+}
+
+// toJSONObject round-trips cfg through encoding/json into a generic object.
+func toJSONObject(cfg *common.CommonDevice) (map[string]any, error) {
+	if cfg == nil {
+		return map[string]any{}, nil
+	}
+
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc map[string]any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	return doc, nil
+}
+
+// diffPatchValues appends the operations that turn oldVal into newVal at path.
+func diffPatchValues(ops []PatchOperation, path string, oldVal, newVal any) []PatchOperation {
+	switch oldTyped := oldVal.(type) {
+	case map[string]any:
+		if newTyped, ok := newVal.(map[string]any); ok {
+			return diffPatchObjects(ops, path, oldTyped, newTyped)
+		}
+	case []any:
+		if newTyped, ok := newVal.([]any); ok {
+			if key := patchIdentityKey(oldTyped, newTyped); key != "" {
+				return diffPatchArrayByIdentity(ops, path, key, oldTyped, newTyped)
+			}
+			return diffPatchArrayByIndex(ops, path, oldTyped, newTyped)
+		}
+	}
+
+	if reflect.DeepEqual(oldVal, newVal) {
+		return ops
+	}
+
+	return append(ops, PatchOperation{Op: PatchOpReplace, Path: path, Value: newVal})
+}
+
+// diffPatchObjects diffs two objects member by member in sorted key order.
+func diffPatchObjects(ops []PatchOperation, path string, oldObj, newObj map[string]any) []PatchOperation {
+	for _, key := range slices.Sorted(maps.Keys(oldObj)) {
+		if _, ok := newObj[key]; !ok {
+			ops = append(ops, PatchOperation{Op: PatchOpRemove, Path: path + "/" + escapePointerToken(key)})
+		}
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(newObj)) {
+		child := path + "/" + escapePointerToken(key)
+
+		oldChild, ok := oldObj[key]
+		if !ok {
+			ops = append(ops, PatchOperation{Op: PatchOpAdd, Path: child, Value: newObj[key]})
+			continue
+		}
+
+		ops = diffPatchValues(ops, child, oldChild, newObj[key])
+	}
+
+	return ops
+}
+
+// diffPatchArrayByIndex diffs the common prefix position by position, then
+// appends new trailing elements or removes surplus ones from the end.
+func diffPatchArrayByIndex(ops []PatchOperation, path string, oldArr, newArr []any) []PatchOperation {
+	common := min(len(oldArr), len(newArr))
+	for i := range common {
+		ops = diffPatchValues(ops, path+"/"+strconv.Itoa(i), oldArr[i], newArr[i])
+	}
+
+	for i := common; i < len(newArr); i++ {
+		ops = append(ops, PatchOperation{Op: PatchOpAdd, Path: path + "/" + strconv.Itoa(i), Value: newArr[i]})
+	}
+
+	for i := len(oldArr) - 1; i >= common; i-- {
+		ops = append(ops, PatchOperation{Op: PatchOpRemove, Path: path + "/" + strconv.Itoa(i)})
+	}
+
+	return ops
+}
+
+// diffPatchArrayByIdentity diffs two arrays whose elements are identified by
+// key. Elements missing from newArr are removed (last first, so earlier
+// indices stay valid), then each position of newArr is filled in order by
+// moving the matching element into place or adding a new one, and matched
+// elements are diffed in place. Positions before the one being filled
+// already hold their final elements, so a move always comes from a later
+// index.
+func diffPatchArrayByIdentity(ops []PatchOperation, path, key string, oldArr, newArr []any) []PatchOperation {
+	newIDs := make(map[string]bool, len(newArr))
+	for _, elem := range newArr {
+		newIDs[elementIdentity(elem, key)] = true
+	}
+
+	oldByID := make(map[string]any, len(oldArr))
+	working := make([]string, 0, len(oldArr))
+	for _, elem := range oldArr {
+		id := elementIdentity(elem, key)
+		oldByID[id] = elem
+		working = append(working, id)
+	}
+
+	for i := len(working) - 1; i >= 0; i-- {
+		if !newIDs[working[i]] {
+			ops = append(ops, PatchOperation{Op: PatchOpRemove, Path: path + "/" + strconv.Itoa(i)})
+			working = slices.Delete(working, i, i+1)
+		}
+	}
+
+	for j, elem := range newArr {
+		id := elementIdentity(elem, key)
+		target := path + "/" + strconv.Itoa(j)
+
+		pos := slices.Index(working, id)
+		if pos < 0 {
+			ops = append(ops, PatchOperation{Op: PatchOpAdd, Path: target, Value: elem})
+			working = slices.Insert(working, j, id)
+
+			continue
+		}
+
+		if pos != j {
+			ops = append(ops, PatchOperation{Op: PatchOpMove, From: path + "/" + strconv.Itoa(pos), Path: target})
+			working = slices.Insert(slices.Delete(working, pos, pos+1), j, id)
+		}
+
+		ops = diffPatchValues(ops, target, oldByID[id], elem)
+	}
+
+	return ops
+}
+
+// patchIdentityKey returns the first of patchIdentityKeys that identifies
+// every element of both arrays, or "" when none does.
+func patchIdentityKey(oldArr, newArr []any) string {
+	if len(oldArr) == 0 || len(newArr) == 0 {
+		return ""
+	}
+
+	for _, key := range patchIdentityKeys {
+		if uniqueIdentities(oldArr, key) && uniqueIdentities(newArr, key) {
+			return key
+		}
+	}
+
+	return ""
+}
+
+// uniqueIdentities reports whether every element of arr is an object with a
+// distinct, non-empty string value for key.
+func uniqueIdentities(arr []any, key string) bool {
+	seen := make(map[string]bool, len(arr))
+	for _, elem := range arr {
+		id := elementIdentity(elem, key)
+		if id == "" || seen[id] {
+			return false
+		}
+		seen[id] = true
+	}
+
+	return true
+}
+
+// elementIdentity returns the string value of key in an object element, or
+// "" when elem is not an object or the value is missing or not a string.
+func elementIdentity(elem any, key string) string {
+	obj, ok := elem.(map[string]any)
+	if !ok {
+		return ""
+	}
+
+	id, _ := obj[key].(string)
+
+	return id
+}
+
+// mergePatchObjects returns the RFC 7386 merge patch from oldObj to newObj:
+// removed members map to null, nested objects are patched recursively, and
+// every other changed member is replaced by its new value.
+func mergePatchObjects(oldObj, newObj map[string]any) map[string]any {
+	patch := make(map[string]any)
+
+	for key := range oldObj {
+		if _, ok := newObj[key]; !ok {
+			patch[key] = nil
+		}
+	}
+
+	for key, newVal := range newObj {
+		oldVal, ok := oldObj[key]
+		if !ok {
+			patch[key] = newVal
+			continue
+		}
+
+		oldChild, oldIsObj := oldVal.(map[string]any)
+		newChild, newIsObj := newVal.(map[string]any)
+		if oldIsObj && newIsObj {
+			if nested := mergePatchObjects(oldChild, newChild); len(nested) > 0 {
+				patch[key] = nested
+			}
+
+			continue
+		}
+
+		if !reflect.DeepEqual(oldVal, newVal) {
+			patch[key] = newVal
+		}
+	}
+
+	return patch
+}
+
+// escapePointerToken escapes a JSON Pointer reference token (RFC 6901).
+func escapePointerToken(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
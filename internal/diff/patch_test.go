@@ -0,0 +1,240 @@
+package diff
+
+import (
+	"encoding/json"
+	"testing"
+
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// assertPatchesApply applies the JSON Patch and the merge patch built from
+// oldCfg to newCfg onto the old JSON export and asserts both reproduce the
+// new export. It returns the JSON Patch operations for further checks.
+func assertPatchesApply(t *testing.T, oldCfg, newCfg *common.CommonDevice, opts Options) []PatchOperation {
+	t.Helper()
+
+	oldDoc, newDoc, err := patchDocuments(oldCfg, newCfg, opts)
+	require.NoError(t, err)
+	oldJSON, err := json.Marshal(oldDoc)
+	require.NoError(t, err)
+	newJSON, err := json.Marshal(newDoc)
+	require.NoError(t, err)
+
+	ops, err := BuildJSONPatch(oldCfg, newCfg, opts)
+	require.NoError(t, err)
+	opsJSON, err := json.Marshal(ops)
+	require.NoError(t, err)
+
+	decoded, err := jsonpatch.DecodePatch(opsJSON)
+	require.NoError(t, err)
+	patched, err := decoded.Apply(oldJSON)
+	require.NoError(t, err, "patch: %s", opsJSON)
+	assert.JSONEq(t, string(newJSON), string(patched), "JSON Patch should reproduce the new export")
+
+	merge, err := BuildMergePatch(oldCfg, newCfg, opts)
+	require.NoError(t, err)
+	mergeJSON, err := json.Marshal(merge)
+	require.NoError(t, err)
+
+	merged, err := jsonpatch.MergePatch(oldJSON, mergeJSON)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(newJSON), string(merged), "merge patch should reproduce the new export")
+
+	return ops
+}
+
+func patchTestRule(uuid, descr, port string) common.FirewallRule {
+	return common.FirewallRule{
+		UUID:        uuid,
+		Type:        common.RuleTypePass,
+		Description: descr,
+		Interfaces:  []string{"lan"},
+		Source:      common.RuleEndpoint{Address: "any"},
+		Destination: common.RuleEndpoint{Address: "any", Port: port},
+	}
+}
+
+func TestBuildJSONPatch_IdenticalConfigs(t *testing.T) {
+	cfg := &common.CommonDevice{FirewallRules: []common.FirewallRule{patchTestRule("a", "web", "443")}}
+	cfg.System.Hostname = "fw"
+
+	ops, err := BuildJSONPatch(cfg, cfg, Options{})
+	require.NoError(t, err)
+	assert.Empty(t, ops)
+
+	merge, err := BuildMergePatch(cfg, cfg, Options{})
+	require.NoError(t, err)
+	assert.Empty(t, merge)
+
+	opsJSON, err := json.Marshal(ops)
+	require.NoError(t, err)
+	assert.JSONEq(t, "[]", string(opsJSON), "an empty patch should encode as an array")
+}
+
+func TestBuildJSONPatch_FieldChangeUsesExportPath(t *testing.T) {
+	oldCfg := &common.CommonDevice{FirewallRules: []common.FirewallRule{
+		patchTestRule("a", "web", "443"),
+		patchTestRule("b", "dns", "53"),
+	}}
+	newCfg := &common.CommonDevice{FirewallRules: []common.FirewallRule{
+		patchTestRule("a", "web", "443"),
+		patchTestRule("b", "dns", "853"),
+	}}
+
+	ops := assertPatchesApply(t, oldCfg, newCfg, Options{})
+	require.Len(t, ops, 1)
+	assert.Equal(t, PatchOperation{Op: PatchOpReplace, Path: "/firewallRules/1/destination/port", Value: "853"}, ops[0])
+}
+
+func TestBuildJSONPatch_ReorderedRulesMoveByUUID(t *testing.T) {
+	a, b, c := patchTestRule("a", "web", "443"), patchTestRule("b", "dns", "53"), patchTestRule("c", "ntp", "123")
+	oldCfg := &common.CommonDevice{FirewallRules: []common.FirewallRule{a, b, c}}
+	newCfg := &common.CommonDevice{FirewallRules: []common.FirewallRule{c, a, b}}
+
+	ops := assertPatchesApply(t, oldCfg, newCfg, Options{})
+	require.Len(t, ops, 1)
+	assert.Equal(t, PatchOperation{Op: PatchOpMove, From: "/firewallRules/2", Path: "/firewallRules/0"}, ops[0])
+}
+
+func TestBuildJSONPatch_AddRemoveAndModifyByUUID(t *testing.T) {
+	oldCfg := &common.CommonDevice{FirewallRules: []common.FirewallRule{
+		patchTestRule("a", "web", "443"),
+		patchTestRule("b", "dns", "53"),
+		patchTestRule("c", "ntp", "123"),
+	}}
+	newCfg := &common.CommonDevice{FirewallRules: []common.FirewallRule{
+		patchTestRule("d", "ssh", "22"),
+		patchTestRule("c", "ntp", "1123"),
+		patchTestRule("a", "web", "443"),
+	}}
+
+	ops := assertPatchesApply(t, oldCfg, newCfg, Options{})
+	for _, op := range ops {
+		assert.NotEqual(t, "/firewallRules", op.Path, "rules should not be replaced wholesale")
+	}
+	assert.Contains(t, ops, PatchOperation{Op: PatchOpRemove, Path: "/firewallRules/1"})
+}
+
+func TestBuildJSONPatch_TrackerIdentityFallback(t *testing.T) {
+	rule := func(tracker, descr string) common.FirewallRule {
+		r := patchTestRule("", descr, "")
+		r.Tracker = tracker
+
+		return r
+	}
+
+	oldCfg := &common.CommonDevice{FirewallRules: []common.FirewallRule{rule("100", "first"), rule("200", "second")}}
+	newCfg := &common.CommonDevice{FirewallRules: []common.FirewallRule{rule("200", "second"), rule("100", "first")}}
+
+	ops := assertPatchesApply(t, oldCfg, newCfg, Options{})
+	require.Len(t, ops, 1)
+	assert.Equal(t, PatchOpMove, ops[0].Op)
+}
+
+func TestBuildJSONPatch_IndexFallbackWithoutIdentity(t *testing.T) {
+	oldCfg := &common.CommonDevice{Interfaces: []common.Interface{
+		{Name: "wan", PhysicalIf: "em0"},
+		{Name: "lan", PhysicalIf: "em1"},
+		{Name: "opt1", PhysicalIf: "em2"},
+	}}
+	newCfg := &common.CommonDevice{Interfaces: []common.Interface{
+		{Name: "wan", PhysicalIf: "igb0"},
+		{Name: "lan", PhysicalIf: "em1"},
+	}}
+
+	ops := assertPatchesApply(t, oldCfg, newCfg, Options{})
+	assert.Equal(t, []PatchOperation{
+		{Op: PatchOpReplace, Path: "/interfaces/0/physicalIf", Value: "igb0"},
+		{Op: PatchOpRemove, Path: "/interfaces/2"},
+	}, ops)
+}
+
+func TestBuildJSONPatch_DuplicateUUIDsFallBackToIndex(t *testing.T) {
+	oldCfg := &common.CommonDevice{FirewallRules: []common.FirewallRule{
+		patchTestRule("a", "one", ""),
+		patchTestRule("a", "two", ""),
+	}}
+	newCfg := &common.CommonDevice{FirewallRules: []common.FirewallRule{
+		patchTestRule("a", "two", ""),
+		patchTestRule("a", "one", ""),
+	}}
+
+	ops := assertPatchesApply(t, oldCfg, newCfg, Options{})
+	for _, op := range ops {
+		assert.NotEqual(t, PatchOpMove, op.Op)
+	}
+}
+
+func TestBuildJSONPatch_SectionFilter(t *testing.T) {
+	oldCfg := &common.CommonDevice{FirewallRules: []common.FirewallRule{patchTestRule("a", "web", "443")}}
+	oldCfg.System.Hostname = "old"
+	newCfg := &common.CommonDevice{FirewallRules: []common.FirewallRule{patchTestRule("a", "web", "8443")}}
+	newCfg.System.Hostname = "new"
+
+	ops := assertPatchesApply(t, oldCfg, newCfg, Options{Sections: []string{"system"}})
+	assert.Equal(t, []PatchOperation{{Op: PatchOpReplace, Path: "/system/hostname", Value: "new"}}, ops)
+
+	merge, err := BuildMergePatch(oldCfg, newCfg, Options{Sections: []string{"firewall"}})
+	require.NoError(t, err)
+	assert.Contains(t, merge, "firewallRules")
+	assert.NotContains(t, merge, "system")
+}
+
+func TestBuildMergePatch_RemovedFieldIsNull(t *testing.T) {
+	oldCfg := &common.CommonDevice{}
+	oldCfg.System.Hostname = "fw"
+	oldCfg.System.Domain = "example.com"
+	newCfg := &common.CommonDevice{}
+	newCfg.System.Hostname = "fw"
+
+	assertPatchesApply(t, oldCfg, newCfg, Options{})
+
+	merge, err := BuildMergePatch(oldCfg, newCfg, Options{})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"system": map[string]any{"domain": nil}}, merge)
+}
+
+func TestPatchOperation_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		op   PatchOperation
+		want string
+	}{
+		{
+			name: "replace keeps a false value",
+			op:   PatchOperation{Op: PatchOpReplace, Path: "/system/ipv6Allow", Value: false},
+			want: `{"op":"replace","path":"/system/ipv6Allow","value":false}`,
+		},
+		{
+			name: "add keeps a null value",
+			op:   PatchOperation{Op: PatchOpAdd, Path: "/x"},
+			want: `{"op":"add","path":"/x","value":null}`,
+		},
+		{
+			name: "move carries from",
+			op:   PatchOperation{Op: PatchOpMove, From: "/firewallRules/2", Path: "/firewallRules/0"},
+			want: `{"op":"move","from":"/firewallRules/2","path":"/firewallRules/0"}`,
+		},
+		{
+			name: "remove has no value",
+			op:   PatchOperation{Op: PatchOpRemove, Path: "/firewallRules/1"},
+			want: `{"op":"remove","path":"/firewallRules/1"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(tt.op)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestEscapePointerToken(t *testing.T) {
+	assert.Equal(t, "a~1b~0c", escapePointerToken("a/b~c"))
+	assert.Equal(t, "plain", escapePointerToken("plain"))
+}
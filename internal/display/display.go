@@ -14,6 +14,7 @@ import (
 	"github.com/charmbracelet/bubbles/progress"
 	"github.com/charmbracelet/glamour"
 	"github.com/charmbracelet/lipgloss"
+	"golang.org/x/term"
 )
 
 // Theme and terminal color constants used throughout the display package.
@@ -144,12 +145,26 @@ func convertMarkdownOptions(mdOpts converter.Options) Options {
 
 	return Options{
 		Theme:        theme,
-		WrapWidth:    mdOpts.WrapWidth,
+		WrapWidth:    capWrapWidth(mdOpts.WrapWidth, mdOpts.MaxWidth),
 		EnableTables: mdOpts.EnableTables,
 		EnableColors: mdOpts.EnableColors,
 	}
 }
 
+// capWrapWidth applies a positive maxWidth to wrapWidth. Auto-detected widths
+// (negative) are resolved first; 0 (no wrapping) is preserved.
+func capWrapWidth(wrapWidth, maxWidth int) int {
+	if maxWidth <= 0 || wrapWidth == 0 {
+		return wrapWidth
+	}
+
+	if wrapWidth < 0 {
+		wrapWidth = getTerminalWidth()
+	}
+
+	return min(wrapWidth, maxWidth)
+}
+
 // DetermineGlamourStyle returns the Glamour style string to use for markdown rendering based on the provided options, considering color enablement, terminal color support, and the selected theme.
 func DetermineGlamourStyle(opts *Options) string {
 	// Check if colors are disabled first
@@ -306,16 +321,24 @@ func NewTerminalDisplayWithMarkdownOptions(mdOpts converter.Options) *TerminalDi
 	return NewTerminalDisplayWithOptions(convertMarkdownOptions(mdOpts))
 }
 
-// getTerminalWidth returns the terminal width in columns, using the COLUMNS environment variable if set, or a default wrap width otherwise.
+// getTerminalWidth returns the auto-detected wrap width: the COLUMNS
+// environment variable if set, otherwise the size of stdout when it is a
+// terminal, capped at DefaultWordWrapWidth. When stdout is not a terminal and
+// COLUMNS is unset, DefaultWordWrapWidth is returned.
 func getTerminalWidth() int {
-	columns := os.Getenv("COLUMNS")
-	if columns != "" {
-		if width, err := strconv.Atoi(columns); err == nil {
-			return width
+	width := DefaultWordWrapWidth
+
+	if columns := os.Getenv("COLUMNS"); columns != "" {
+		if parsed, err := strconv.Atoi(columns); err == nil {
+			width = parsed
+		}
+	} else if fd := int(os.Stdout.Fd()); term.IsTerminal(fd) {
+		if cols, _, err := term.GetSize(fd); err == nil && cols > 0 {
+			width = cols
 		}
 	}
 
-	return DefaultWordWrapWidth
+	return min(width, DefaultWordWrapWidth)
 }
 
 // ProgressEvent represents a progress update event.
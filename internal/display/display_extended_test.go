@@ -287,6 +287,7 @@ func TestGetTerminalWidthWithInvalidColumns(t *testing.T) {
 		{"negative number", "-100", -100}, // strconv.Atoi parses this successfully
 		{"zero", "0", 0},                  // strconv.Atoi parses this successfully
 		{"valid number", "100", 100},
+		{"wider than default is capped", "300", DefaultWordWrapWidth},
 	}
 
 	for _, tc := range testCases {
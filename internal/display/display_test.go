@@ -84,6 +84,30 @@ func TestConvertMarkdownOptions(t *testing.T) {
 	assert.False(t, opts.EnableColors)
 }
 
+func TestConvertMarkdownOptionsMaxWidth(t *testing.T) {
+	t.Setenv("COLUMNS", "100")
+
+	tests := []struct {
+		name      string
+		wrapWidth int
+		maxWidth  int
+		want      int
+	}{
+		{"no cap keeps wrap width", 100, 0, 100},
+		{"cap narrows explicit width", 100, 60, 60},
+		{"cap above explicit width", 80, 100, 80},
+		{"cap narrows auto-detected width", -1, 60, 60},
+		{"no wrapping is preserved", 0, 60, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := convertMarkdownOptions(converter.Options{WrapWidth: tt.wrapWidth, MaxWidth: tt.maxWidth})
+			assert.Equal(t, tt.want, opts.WrapWidth)
+		})
+	}
+}
+
 func TestNewTerminalDisplayWithOptions(t *testing.T) {
 	opts := Options{
 		Theme:        DarkTheme(),
@@ -107,14 +107,34 @@ type Exporter interface {
 // FileExporter is a file exporter for OPNsense configurations.
 type FileExporter struct {
 	logger *logging.Logger
+	binary bool
+}
+
+// Option configures a FileExporter at construction time.
+type Option func(*FileExporter)
+
+// WithBinaryContent sets whether exported content is a binary report, such as
+// a PDF, which is written byte for byte. Line endings of binary content are
+// never normalized, since a PDF records the byte offsets of its objects.
+//
+// Content is treated as text by default.
+func WithBinaryContent(binary bool) Option {
+	return func(e *FileExporter) {
+		e.binary = binary
+	}
 }
 
 // NewFileExporter creates and returns a new FileExporter for writing data to files.
 // If logger is nil, operations will continue without logging (graceful degradation).
-func NewFileExporter(logger *logging.Logger) *FileExporter {
-	return &FileExporter{
+func NewFileExporter(logger *logging.Logger, opts ...Option) *FileExporter {
+	e := &FileExporter{
 		logger: logger,
 	}
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	return e
 }
 
 // validateExportPath performs comprehensive validation of the export path.
@@ -419,11 +439,13 @@ func (e *FileExporter) Export(ctx context.Context, content, path string) error {
 		}
 	}
 
-	// Normalize line endings for the target platform before writing
-	normalizedContent := normalizeLineEndings(e.logger, content)
+	// Normalize line endings of text for the target platform before writing
+	if !e.binary {
+		content = normalizeLineEndings(e.logger, content)
+	}
 
 	// Write the file with atomic operation for better safety
-	if err := e.writeFileAtomic(path, []byte(normalizedContent)); err != nil {
+	if err := e.writeFileAtomic(path, []byte(content)); err != nil {
 		return &Error{
 			Operation: "write_file",
 			Path:      path,
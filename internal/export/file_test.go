@@ -348,6 +348,28 @@ func TestFileExporter_AtomicWrite(t *testing.T) {
 }
 
 // TestFileExporter_ExportErrorUnwrap tests that Error properly unwraps underlying errors.
+// TestFileExporter_BinaryContent tests that binary reports keep their exact
+// bytes, since a PDF cross-reference table records byte offsets, while text
+// exported with the same content has its line endings normalized.
+func TestFileExporter_BinaryContent(t *testing.T) {
+	t.Setenv("OPNDOSSIER_PLATFORM_LINE_ENDINGS", "1")
+
+	content := "%PDF-1.3\n1 0 obj\r\n<<>>\nendobj\n\x89\r"
+	dir := t.TempDir()
+
+	binaryPath := filepath.Join(dir, "report.pdf")
+	require.NoError(t, NewFileExporter(nil, WithBinaryContent(true)).Export(context.Background(), content, binaryPath))
+	written, err := os.ReadFile(binaryPath)
+	require.NoError(t, err)
+	assert.Equal(t, content, string(written))
+
+	textPath := filepath.Join(dir, "report.txt")
+	require.NoError(t, NewFileExporter(nil).Export(context.Background(), content, textPath))
+	written, err = os.ReadFile(textPath)
+	require.NoError(t, err)
+	assert.NotContains(t, string(written), "\x89\r", "text content is normalized")
+}
+
 func TestFileExporter_ExportErrorUnwrap(t *testing.T) {
 	e := NewFileExporter(nil)
 
@@ -0,0 +1,142 @@
+// Package exporter exposes opnDossier analysis results to monitoring systems.
+package exporter
+
+import (
+	"strconv"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/processor"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// metricNamespace prefixes every metric name.
+const metricNamespace = "opndossier"
+
+// floatingInterface is the interface label of a floating rule that applies
+// to every interface.
+const floatingInterface = "floating"
+
+// NAT rule directions used as the direction label.
+const (
+	natDirectionInbound  = "inbound"
+	natDirectionOutbound = "outbound"
+)
+
+// Metric descriptors. Every metric is a gauge: it describes the configuration
+// as parsed, not events counted since the exporter started.
+var (
+	findingsDesc = prometheus.NewDesc( //nolint:gochecknoglobals // Immutable descriptor
+		prometheus.BuildFQName(metricNamespace, "", "findings_total"),
+		"Number of processor findings by severity.",
+		[]string{"severity"}, nil,
+	)
+	firewallRulesDesc = prometheus.NewDesc( //nolint:gochecknoglobals // Immutable descriptor
+		prometheus.BuildFQName(metricNamespace, "", "firewall_rules_total"),
+		"Number of enabled firewall rules by interface and action. "+
+			"A rule on several interfaces is counted once per interface.",
+		[]string{"interface", "action"}, nil,
+	)
+	natRulesDesc = prometheus.NewDesc( //nolint:gochecknoglobals // Immutable descriptor
+		prometheus.BuildFQName(metricNamespace, "", "nat_rules_total"),
+		"Number of enabled NAT rules by direction.",
+		[]string{"direction"}, nil,
+	)
+	interfacesDesc = prometheus.NewDesc( //nolint:gochecknoglobals // Immutable descriptor
+		prometheus.BuildFQName(metricNamespace, "", "interfaces_total"),
+		"Number of configured interfaces by enabled state.",
+		[]string{"enabled"}, nil,
+	)
+)
+
+// PrometheusExporter is a prometheus.Collector exposing the findings and rule
+// and interface counts of one device configuration. Register it with a
+// prometheus.Registry and serve the registry to expose the metrics.
+type PrometheusExporter struct {
+	device   *common.CommonDevice
+	findings processor.Findings
+}
+
+// NewPrometheusExporter returns an exporter for device and the processor
+// findings reported for it.
+func NewPrometheusExporter(device *common.CommonDevice, findings processor.Findings) *PrometheusExporter {
+	return &PrometheusExporter{device: device, findings: findings}
+}
+
+// Describe implements prometheus.Collector.
+func (e *PrometheusExporter) Describe(ch chan<- *prometheus.Desc) {
+	ch <- findingsDesc
+	ch <- firewallRulesDesc
+	ch <- natRulesDesc
+	ch <- interfacesDesc
+}
+
+// Collect implements prometheus.Collector. Every severity, NAT direction, and
+// enabled state is reported, with a zero value when nothing matches, so
+// queries and alerts do not see series disappear.
+func (e *PrometheusExporter) Collect(ch chan<- prometheus.Metric) {
+	for _, s := range []struct {
+		severity processor.Severity
+		findings []processor.Finding
+	}{
+		{processor.SeverityCritical, e.findings.Critical},
+		{processor.SeverityHigh, e.findings.High},
+		{processor.SeverityMedium, e.findings.Medium},
+		{processor.SeverityLow, e.findings.Low},
+		{processor.SeverityInfo, e.findings.Info},
+	} {
+		ch <- gauge(findingsDesc, len(s.findings), string(s.severity))
+	}
+
+	if e.device == nil {
+		return
+	}
+
+	type ruleKey struct{ iface, action string }
+	rules := make(map[ruleKey]int)
+	for _, rule := range e.device.FirewallRules {
+		if rule.Disabled {
+			continue
+		}
+
+		interfaces := rule.Interfaces
+		if len(interfaces) == 0 {
+			interfaces = []string{floatingInterface}
+		}
+		for _, iface := range interfaces {
+			rules[ruleKey{iface: iface, action: string(rule.Type)}]++
+		}
+	}
+	for key, n := range rules {
+		ch <- gauge(firewallRulesDesc, n, key.iface, key.action)
+	}
+
+	var inbound, outbound int
+	for _, rule := range e.device.NAT.InboundRules {
+		if !rule.Disabled {
+			inbound++
+		}
+	}
+	for _, rule := range e.device.NAT.OutboundRules {
+		if !rule.Disabled {
+			outbound++
+		}
+	}
+	ch <- gauge(natRulesDesc, inbound, natDirectionInbound)
+	ch <- gauge(natRulesDesc, outbound, natDirectionOutbound)
+
+	var enabled, disabled int
+	for _, iface := range e.device.Interfaces {
+		if iface.Enabled {
+			enabled++
+		} else {
+			disabled++
+		}
+	}
+	ch <- gauge(interfacesDesc, enabled, strconv.FormatBool(true))
+	ch <- gauge(interfacesDesc, disabled, strconv.FormatBool(false))
+}
+
+// gauge returns a constant gauge sample of desc.
+func gauge(desc *prometheus.Desc, value int, labels ...string) prometheus.Metric {
+	return prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, float64(value), labels...)
+}
@@ -0,0 +1,75 @@
+package exporter_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/exporter"
+	"github.com/EvilBit-Labs/opnDossier/internal/processor"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrometheusExporter(t *testing.T) {
+	t.Parallel()
+
+	device := &common.CommonDevice{
+		Interfaces: []common.Interface{
+			{Name: "wan", Enabled: true},
+			{Name: "lan", Enabled: true},
+			{Name: "opt1"},
+		},
+		FirewallRules: []common.FirewallRule{
+			{Type: common.RuleTypePass, Interfaces: []string{"wan"}},
+			{Type: common.RuleTypeBlock, Interfaces: []string{"wan"}},
+			{Type: common.RuleTypeBlock, Interfaces: []string{"wan"}},
+			{Type: common.RuleTypePass, Interfaces: []string{"lan", "opt1"}},
+			{Type: common.RuleTypeReject, Floating: true},
+			{Type: common.RuleTypePass, Interfaces: []string{"wan"}, Disabled: true},
+		},
+		NAT: common.NATConfig{
+			InboundRules:  []common.InboundNATRule{{}, {Disabled: true}},
+			OutboundRules: []common.NATRule{{}, {}},
+		},
+	}
+	findings := processor.Findings{
+		High: []processor.Finding{{Title: "a"}, {Title: "b"}},
+		Info: []processor.Finding{{Title: "c"}},
+	}
+
+	const want = `
+# HELP opndossier_findings_total Number of processor findings by severity.
+# TYPE opndossier_findings_total gauge
+opndossier_findings_total{severity="critical"} 0
+opndossier_findings_total{severity="high"} 2
+opndossier_findings_total{severity="info"} 1
+opndossier_findings_total{severity="low"} 0
+opndossier_findings_total{severity="medium"} 0
+# HELP opndossier_firewall_rules_total Number of enabled firewall rules by interface and action. A rule on several interfaces is counted once per interface.
+# TYPE opndossier_firewall_rules_total gauge
+opndossier_firewall_rules_total{action="block",interface="wan"} 2
+opndossier_firewall_rules_total{action="pass",interface="lan"} 1
+opndossier_firewall_rules_total{action="pass",interface="opt1"} 1
+opndossier_firewall_rules_total{action="pass",interface="wan"} 1
+opndossier_firewall_rules_total{action="reject",interface="floating"} 1
+# HELP opndossier_interfaces_total Number of configured interfaces by enabled state.
+# TYPE opndossier_interfaces_total gauge
+opndossier_interfaces_total{enabled="false"} 1
+opndossier_interfaces_total{enabled="true"} 2
+# HELP opndossier_nat_rules_total Number of enabled NAT rules by direction.
+# TYPE opndossier_nat_rules_total gauge
+opndossier_nat_rules_total{direction="inbound"} 1
+opndossier_nat_rules_total{direction="outbound"} 2
+`
+
+	exp := exporter.NewPrometheusExporter(device, findings)
+	require.NoError(t, testutil.CollectAndCompare(exp, strings.NewReader(want)))
+}
+
+func TestPrometheusExporter_NoDevice(t *testing.T) {
+	t.Parallel()
+
+	exp := exporter.NewPrometheusExporter(nil, processor.Findings{})
+	require.Equal(t, 5, testutil.CollectAndCount(exp), "only the findings series")
+}
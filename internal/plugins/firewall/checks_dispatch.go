@@ -2,6 +2,8 @@
 package firewall
 
 import (
+	"fmt"
+
 	"github.com/EvilBit-Labs/opnDossier/internal/compliance"
 	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
 )
@@ -19,10 +21,11 @@ type newCheckEntry struct {
 	tags           []string
 }
 
-// newChecksTable returns the table of checks for FIREWALL-009 through -061.
+// newChecksTable returns the table of checks for FIREWALL-009 through -061
+// and FIREWALL-065 through -076.
 // Extracted as a method to keep RunChecks readable.
 //
-//nolint:funlen // 53 controls require a large dispatch table
+//nolint:funlen // 65 controls require a large dispatch table
 func (fp *Plugin) newChecksTable() []newCheckEntry {
 	return []newCheckEntry{
 		// Management Plane (009-021)
@@ -56,7 +59,7 @@ func (fp *Plugin) newChecksTable() []newCheckEntry {
 		},
 		{
 			controlID: "FIREWALL-018", checkFn: (*Plugin).checkLeastPrivilegeAccess,
-			title: "Overly Broad Privileges", description: "Groups are configured with page-all unrestricted access",
+			title: "Overly Broad Privileges", description: "Non-admin groups are configured with page-all unrestricted access",
 			recommendation: "Replace page-all privileges with specific page-level permissions",
 			component:      "user-privileges", tags: []string{"authentication", "least-privilege", "firewall-controls"},
 		},
@@ -177,6 +180,16 @@ func (fp *Plugin) newChecksTable() []newCheckEntry {
 			component:      "web-gui-cert",
 			tags:           []string{"encryption", "certificates", "firewall-controls"},
 		},
+		// Logging (072)
+		{
+			controlID:      "FIREWALL-072",
+			checkFn:        (*Plugin).checkMonitAlertDestination,
+			title:          "Monit Alerts Emailed Outside the Organization",
+			description:    "Monit sends alert email to a recipient outside the device's domain",
+			recommendation: "Address Monit alerts to an internal mailbox in Services > Monit > Settings > Alert Settings",
+			component:      "monit",
+			tags:           []string{"logging", "monit", "firewall-controls"},
+		},
 		// 037, 038: return unknown — not in table
 		{
 			controlID: "FIREWALL-039", checkFn: (*Plugin).checkRemoteSyslog,
@@ -296,7 +309,12 @@ func (fp *Plugin) newChecksTable() []newCheckEntry {
 			recommendation: "Disable NAT reflection in Firewall > Settings > Advanced",
 			component:      "nat-config", tags: []string{"nat-security", "nat-reflection", "firewall-controls"},
 		},
-		// 057: returns unknown — not in table
+		{
+			controlID: "FIREWALL-057", checkFn: (*Plugin).checkUPnPDisabled,
+			title: "UPnP/NAT-PMP Enabled", description: "UPnP or NAT-PMP automatic port mapping is enabled",
+			recommendation: "Disable UPnP and NAT-PMP in Services > UPnP/NAT-PMP",
+			component:      "upnp-config", tags: []string{"service-hardening", "upnp", "firewall-controls"},
+		},
 		{
 			controlID: "FIREWALL-058", checkFn: (*Plugin).checkDNSSECValidation,
 			title: "DNSSEC Not Enabled", description: "DNSSEC validation is not enabled on the DNS resolver",
@@ -313,11 +331,122 @@ func (fp *Plugin) newChecksTable() []newCheckEntry {
 			component:      "ha-config",
 			tags:           []string{"high-availability", "pfsync", "firewall-controls"},
 		},
+		// Service Hardening (065)
+		{
+			controlID:      "FIREWALL-065",
+			checkFn:        (*Plugin).checkWakeOnLANExposure,
+			title:          "Wake-on-LAN Host on WAN Interface",
+			description:    "A Wake-on-LAN host is configured on a WAN-facing interface",
+			recommendation: "Move Wake-on-LAN entries to internal interfaces in Services > Wake on LAN",
+			component:      "wol-config",
+			tags:           []string{"service-hardening", "wake-on-lan", "firewall-controls"},
+		},
+		// VPN Configuration (066)
+		{
+			controlID:      "FIREWALL-066",
+			checkFn:        (*Plugin).checkOpenVPNCRLValidity,
+			title:          "Expired OpenVPN CRL",
+			description:    "A certificate revocation list referenced by an OpenVPN server has expired",
+			recommendation: "Reissue the CRL in System > Trust > Revocation or raise its lifetime",
+			component:      "vpn-openvpn",
+			tags:           []string{"vpn-config", "crl", "firewall-controls"},
+		},
+		// VPN Configuration (073)
+		{
+			controlID:      "FIREWALL-073",
+			checkFn:        (*Plugin).checkZeroTierInterfaceRules,
+			title:          "ZeroTier Traffic Not Filtered",
+			description:    "ZeroTier is enabled but no firewall rules are defined on a ZeroTier interface, so overlay traffic bypasses pf",
+			recommendation: "Assign the ZeroTier network device as an interface and add explicit rules to it in Firewall > Rules",
+			component:      "vpn-zerotier",
+			tags:           []string{"vpn-config", "zerotier", "firewall-controls"},
+		},
+		// Management Access (074)
+		{
+			controlID:      "FIREWALL-074",
+			checkFn:        (*Plugin).checkSerialConsoleVirtualDeployment,
+			title:          "Serial Console on Virtual Deployment",
+			description:    "The serial port is the primary console on a cloud or virtual machine deployment",
+			recommendation: "Set the primary console to video in System > Settings > Administration, or restrict access to the virtual serial port in the hypervisor",
+			component:      "system-console",
+			tags:           []string{"management-access", "console", "firewall-controls"},
+		},
+		// Time Synchronization (067)
+		{
+			controlID:      "FIREWALL-067",
+			checkFn:        (*Plugin).checkNTPQueryRestriction,
+			title:          "NTP Status Queries Allowed",
+			description:    "ntpd answers status queries from clients, which can be abused for NTP amplification",
+			recommendation: "Enable the noquery restriction in Services > Network Time > General",
+			component:      "ntp-config",
+			tags:           []string{"time-sync", "ntp", "firewall-controls"},
+		},
+		// Management Access (068)
+		{
+			controlID:      "FIREWALL-068",
+			checkFn:        (*Plugin).checkWebGUIHTTPSOnly,
+			title:          "Web GUI Accepts HTTP",
+			description:    "The admin web GUI accepts plain HTTP connections because the HTTP redirect listener is enabled",
+			recommendation: "Use HTTPS and enable Disable web GUI redirect rule in System > Settings > Administration",
+			component:      "web-gui",
+			tags:           []string{"management-access", "tls", "firewall-controls"},
+		},
+		// Logging (069)
+		{
+			controlID:      "FIREWALL-069",
+			checkFn:        (*Plugin).checkNetFlowExportDestination,
+			title:          "NetFlow Exported Outside the Home Network",
+			description:    "NetFlow/IPFIX flow records are sent to a collector with an external IP address",
+			recommendation: "Point the capture targets at an internal collector in Reporting > NetFlow",
+			component:      "netflow-config",
+			tags:           []string{"logging", "netflow", "firewall-controls"},
+		},
+		// Authentication (070)
+		{
+			controlID:      "FIREWALL-070",
+			checkFn:        (*Plugin).checkSSHKeysWithoutSSH,
+			title:          "SSH Keys Configured With SSH Disabled",
+			description:    "One or more users have SSH authorized keys configured, but the SSH service is disabled",
+			recommendation: "Remove unused authorized keys in System > Access > Users, or enable SSH if key logins are intended",
+			component:      "users",
+			tags:           []string{"authentication", "ssh", "firewall-controls"},
+		},
+		// Encryption (071)
+		{
+			controlID:      "FIREWALL-071",
+			checkFn:        (*Plugin).checkTrustedWebGUICertificate,
+			title:          "Untrusted Web GUI Certificate",
+			description:    "The web GUI certificate is self-signed or expired",
+			recommendation: "Assign a current CA-issued certificate to the web GUI in System > Settings > Administration",
+			component:      "web-gui-cert",
+			tags:           []string{"encryption", "certificates", "firewall-controls"},
+		},
+		// Authentication (075-076)
+		{
+			controlID:      "FIREWALL-075",
+			checkFn:        (*Plugin).checkExpiredAccountsDisabled,
+			title:          "Expired Accounts Still Enabled",
+			description:    "One or more user accounts are past their expiry date but are still enabled",
+			recommendation: "Disable or remove expired accounts in System > Access > Users",
+			component:      "user-accounts",
+			tags:           []string{"authentication", "account-expiry", "firewall-controls"},
+		},
+		{
+			controlID:      "FIREWALL-076",
+			checkFn:        (*Plugin).checkAccountExpiryNotice,
+			title:          "Accounts Expiring Soon",
+			description:    "One or more enabled user accounts expire within the next 30 days",
+			recommendation: "Confirm whether access should lapse, and extend the expiry date in System > Access > Users where it should not",
+			component:      "user-accounts",
+			tags:           []string{"authentication", "account-expiry", "firewall-controls"},
+		},
 	}
 }
 
-// runNewChecks evaluates all checks from FIREWALL-009 through -061 in a single
-// pass. Returns (findings, evaluated):
+// runCheckTable evaluates the checks of table in a single pass; RunChecks
+// passes newChecksTable, covering FIREWALL-009 through -061 and FIREWALL-065
+// through -076. Each check runs through runCheck, which records skip reasons
+// and panics in outcomes. Returns (findings, evaluated):
 //   - findings: compliance findings for checks that failed.
 //   - evaluated: control IDs for checks that could be evaluated (Known=true),
 //     independent of pass/fail. Controls with Known=false (insufficient data
@@ -328,14 +457,16 @@ func (fp *Plugin) newChecksTable() []newCheckEntry {
 // table, and are intentionally absent from evaluated.
 //
 //nolint:gocritic // nonamedreturns enforced project-wide; docstring clarifies return shape.
-func (fp *Plugin) runNewChecks(device *common.CommonDevice) ([]compliance.Finding, []string) {
-	table := fp.newChecksTable()
-
+func (fp *Plugin) runCheckTable(
+	device *common.CommonDevice,
+	table []newCheckEntry,
+	outcomes map[string]compliance.CheckOutcome,
+) ([]compliance.Finding, []string) {
 	findings := make([]compliance.Finding, 0, len(table))
 	evaluated := make([]string, 0, len(table))
 
 	for _, entry := range table {
-		cr := entry.checkFn(fp, device)
+		cr := runCheck(entry.controlID, func() checkResult { return entry.checkFn(fp, device) }, outcomes)
 		if !cr.Known {
 			continue
 		}
@@ -369,3 +500,32 @@ func (fp *Plugin) runNewChecks(device *common.CommonDevice) ([]compliance.Findin
 
 	return findings, evaluated
 }
+
+// runCheck runs one check helper inside a panic boundary so a faulty check
+// cannot abort the rest of the audit. A panicking check is treated as unknown
+// and recorded in outcomes as errored with the panic message; an unknown
+// result with a reason is recorded as skipped.
+//
+//nolint:nonamedreturns // panic recovery in the deferred func must overwrite the result; a named return is the idiomatic way to do that.
+func runCheck(
+	controlID string,
+	check func() checkResult,
+	outcomes map[string]compliance.CheckOutcome,
+) (cr checkResult) {
+	defer func() {
+		if r := recover(); r != nil {
+			cr = unknown
+			outcomes[controlID] = compliance.CheckOutcome{
+				Status: compliance.CheckStatusErrored,
+				Reason: fmt.Sprintf("check panicked: %v", r),
+			}
+		}
+	}()
+
+	cr = check()
+	if !cr.Known && cr.Reason != "" {
+		outcomes[controlID] = compliance.CheckOutcome{Status: compliance.CheckStatusSkipped, Reason: cr.Reason}
+	}
+
+	return cr
+}
@@ -0,0 +1,51 @@
+package firewall
+
+import (
+	"testing"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/compliance"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRunCheckTable_PanickingCheck verifies that a check that panics is
+// recorded as errored with the panic message while the checks after it
+// still run.
+func TestRunCheckTable_PanickingCheck(t *testing.T) {
+	t.Parallel()
+
+	fp := NewPlugin()
+	table := []newCheckEntry{
+		{
+			controlID: "TEST-001",
+			checkFn: func(*Plugin, *common.CommonDevice) checkResult {
+				panic("deliberate test panic")
+			},
+		},
+		{
+			controlID: "TEST-002",
+			checkFn: func(*Plugin, *common.CommonDevice) checkResult {
+				return checkResult{Result: false, Known: true}
+			},
+			title: "Failing Check",
+		},
+		{
+			controlID: "TEST-003",
+			checkFn: func(*Plugin, *common.CommonDevice) checkResult {
+				return skipped("feature not configured")
+			},
+		},
+	}
+
+	outcomes := make(map[string]compliance.CheckOutcome)
+	findings, evaluated := fp.runCheckTable(&common.CommonDevice{}, table, outcomes)
+
+	assert.Equal(t, []string{"TEST-002"}, evaluated)
+	if assert.Len(t, findings, 1) {
+		assert.Equal(t, "Failing Check", findings[0].Title)
+	}
+	assert.Equal(t, map[string]compliance.CheckOutcome{
+		"TEST-001": {Status: compliance.CheckStatusErrored, Reason: "check panicked: deliberate test panic"},
+		"TEST-003": {Status: compliance.CheckStatusSkipped, Reason: "feature not configured"},
+	}, outcomes)
+}
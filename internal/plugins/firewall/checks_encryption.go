@@ -4,6 +4,7 @@ package firewall
 import (
 	"slices"
 	"strings"
+	"time"
 
 	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
 )
@@ -16,6 +17,13 @@ var weakVPNAlgorithms = []string{"des", "3des", "blowfish", "cast128", "null"}
 // IPsec VPN integrity verification.
 var weakHashAlgorithms = []string{"md5", "sha1"}
 
+// Reasons the IPsec checks are skipped.
+const (
+	reasonNoIPsecProposals = "IPsec is disabled or has no phase 2 tunnels or connection proposals"
+	reasonNoIPsecPhase1    = "IPsec is disabled or has no phase 1 tunnels"
+	reasonNoIPsecPhase2    = "IPsec is disabled or has no phase 2 tunnels"
+)
+
 // defaultSNMPCommunities contains SNMP community strings that ship as
 // factory defaults and should never be used in production.
 var defaultSNMPCommunities = []string{"public", "private"}
@@ -37,6 +45,25 @@ func (fp *Plugin) checkValidWebGUICertificate(device *common.CommonDevice) check
 	return checkResult{Result: device.System.WebGUI.SSLCertRef != "", Known: true}
 }
 
+// checkTrustedWebGUICertificate checks that the certificate the web GUI's
+// SSLCertRef resolves to is neither self-signed (see
+// [common.Certificate.SelfSigned]) nor past its NotAfter time. The result is
+// unknown when no certificate is assigned, the reference does not resolve,
+// or the certificate could not be decoded.
+func (fp *Plugin) checkTrustedWebGUICertificate(device *common.CommonDevice) checkResult {
+	cert := device.WebGUICert()
+	if cert == nil {
+		return unknown
+	}
+
+	expires, ok := cert.ExpiresAt()
+	if !ok {
+		return unknown
+	}
+
+	return checkResult{Result: !cert.SelfSigned() && !expires.Before(time.Now()), Known: true}
+}
+
 // FIREWALL-037 (checkCertificateExpiration) and FIREWALL-038
 // (checkStrongKeyLengths) were no-op helpers returning unknown — the
 // CommonDevice model does not expose certificate expiry dates or key lengths.
@@ -114,6 +141,23 @@ func (fp *Plugin) checkNTPConfiguration(device *common.CommonDevice) checkResult
 	}
 }
 
+// checkNTPQueryRestriction checks that ntpd denies status queries (noquery)
+// when NTP is in use. Without it, clients can issue mode 6 queries that are
+// abused for NTP amplification attacks. The check is unknown when no time
+// server is configured, and on pfSense, whose ntpd restrictions are not
+// parsed.
+func (fp *Plugin) checkNTPQueryRestriction(device *common.CommonDevice) checkResult {
+	if device == nil || device.DeviceType == common.DeviceTypePfSense {
+		return unknown
+	}
+
+	if device.NTP.PreferredServer == "" && len(device.NTP.Servers) == 0 && len(device.System.TimeServers) == 0 {
+		return unknown
+	}
+
+	return checkResult{Result: device.NTP.NoQuery, Known: true}
+}
+
 // checkTimezoneConfiguration checks that a timezone is explicitly configured.
 // An empty timezone may cause log timestamps to be ambiguous.
 func (fp *Plugin) checkTimezoneConfiguration(device *common.CommonDevice) checkResult {
@@ -156,37 +200,48 @@ func (fp *Plugin) checkNoDefaultCommunityStrings(device *common.CommonDevice) ch
 	return checkResult{Result: !isDefault, Known: true}
 }
 
-// checkStrongVPNEncryption checks that IPsec Phase 2 tunnels use strong
-// encryption algorithms (AES-GCM variants) and do not use weak algorithms
-// (DES, 3DES, Blowfish, CAST128, null).
+// checkStrongVPNEncryption checks that IPsec Phase 2 tunnels and
+// connection-based (swanctl) IKE and ESP proposals use strong encryption
+// algorithms (AES-GCM variants) and do not use weak algorithms (DES, 3DES,
+// Blowfish, CAST128, null).
 func (fp *Plugin) checkStrongVPNEncryption(device *common.CommonDevice) checkResult {
 	if device == nil {
 		return unknown
 	}
 
-	if !device.VPN.IPsec.Enabled || len(device.VPN.IPsec.Phase2Tunnels) == 0 {
-		return unknown
+	ipsec := device.VPN.IPsec
+	proposals := swanctlProposalAlgorithms(ipsec.Connections)
+
+	if !ipsec.Enabled || (len(ipsec.Phase2Tunnels) == 0 && len(proposals) == 0) {
+		return skipped(reasonNoIPsecProposals)
 	}
 
-	for _, p2 := range device.VPN.IPsec.Phase2Tunnels {
+	isWeak := func(algo string) bool {
+		algoLower := strings.ToLower(algo)
+		return slices.ContainsFunc(weakVPNAlgorithms, func(weak string) bool {
+			return strings.Contains(algoLower, weak)
+		})
+	}
+
+	for _, p2 := range ipsec.Phase2Tunnels {
 		if p2.Disabled {
 			continue
 		}
 
-		for _, algo := range p2.EncryptionAlgorithms {
-			algoLower := strings.ToLower(algo)
-			if slices.ContainsFunc(weakVPNAlgorithms, func(weak string) bool {
-				return strings.Contains(algoLower, weak)
-			}) {
-				return checkResult{Result: false, Known: true}
-			}
+		if slices.ContainsFunc(p2.EncryptionAlgorithms, isWeak) {
+			return checkResult{Result: false, Known: true}
 		}
 	}
 
+	if slices.ContainsFunc(proposals, isWeak) {
+		return checkResult{Result: false, Known: true}
+	}
+
 	return checkResult{Result: true, Known: true}
 }
 
-// checkStrongVPNIntegrity checks that IPsec Phase 2 tunnels use strong hash
+// checkStrongVPNIntegrity checks that IPsec Phase 2 tunnels and
+// connection-based (swanctl) IKE and ESP proposals use strong hash
 // algorithms for integrity verification and do not use weak algorithms
 // (MD5, SHA-1).
 func (fp *Plugin) checkStrongVPNIntegrity(device *common.CommonDevice) checkResult {
@@ -194,28 +249,67 @@ func (fp *Plugin) checkStrongVPNIntegrity(device *common.CommonDevice) checkResu
 		return unknown
 	}
 
-	if !device.VPN.IPsec.Enabled || len(device.VPN.IPsec.Phase2Tunnels) == 0 {
-		return unknown
+	ipsec := device.VPN.IPsec
+	proposals := swanctlProposalAlgorithms(ipsec.Connections)
+
+	if !ipsec.Enabled || (len(ipsec.Phase2Tunnels) == 0 && len(proposals) == 0) {
+		return skipped(reasonNoIPsecProposals)
 	}
 
-	for _, p2 := range device.VPN.IPsec.Phase2Tunnels {
+	isWeak := func(hash string) bool {
+		return slices.ContainsFunc(weakHashAlgorithms, func(weak string) bool {
+			return strings.EqualFold(hash, weak)
+		})
+	}
+
+	for _, p2 := range ipsec.Phase2Tunnels {
 		if p2.Disabled {
 			continue
 		}
 
-		for _, hash := range p2.HashAlgorithms {
-			hashLower := strings.ToLower(hash)
-			if slices.ContainsFunc(weakHashAlgorithms, func(weak string) bool {
-				return strings.EqualFold(hashLower, weak)
-			}) {
-				return checkResult{Result: false, Known: true}
-			}
+		if slices.ContainsFunc(p2.HashAlgorithms, isWeak) {
+			return checkResult{Result: false, Known: true}
 		}
 	}
 
+	if slices.ContainsFunc(proposals, isWeak) {
+		return checkResult{Result: false, Known: true}
+	}
+
 	return checkResult{Result: true, Known: true}
 }
 
+// swanctlProposalAlgorithms returns the individual algorithms of the IKE and
+// ESP proposals of every enabled swanctl connection and child SA. A proposal
+// such as "aes256-sha256-modp2048" yields "aes256", "sha256", and
+// "modp2048". Legacy connections are skipped: their algorithms are checked
+// through the Phase 2 tunnels.
+func swanctlProposalAlgorithms(conns []common.IPsecConnection) []string {
+	var algos []string
+
+	add := func(proposals []string) {
+		for _, proposal := range proposals {
+			algos = append(algos, strings.Split(proposal, "-")...)
+		}
+	}
+
+	for _, conn := range conns {
+		if conn.Origin != common.IPsecOriginSwanctl || conn.Disabled {
+			continue
+		}
+
+		add(conn.Proposals)
+
+		for _, child := range conn.Children {
+			if !child.Disabled {
+				add(child.Proposals)
+			}
+		}
+	}
+
+	return algos
+}
+
 // checkPerfectForwardSecrecy checks that IPsec Phase 2 tunnels have PFS
 // (Perfect Forward Secrecy) enabled with a configured DH group.
 func (fp *Plugin) checkPerfectForwardSecrecy(device *common.CommonDevice) checkResult {
@@ -224,7 +318,7 @@ func (fp *Plugin) checkPerfectForwardSecrecy(device *common.CommonDevice) checkR
 	}
 
 	if !device.VPN.IPsec.Enabled || len(device.VPN.IPsec.Phase2Tunnels) == 0 {
-		return unknown
+		return skipped(reasonNoIPsecPhase2)
 	}
 
 	for _, p2 := range device.VPN.IPsec.Phase2Tunnels {
@@ -249,7 +343,7 @@ func (fp *Plugin) checkVPNKeyLifetime(device *common.CommonDevice) checkResult {
 	}
 
 	if !device.VPN.IPsec.Enabled || len(device.VPN.IPsec.Phase2Tunnels) == 0 {
-		return unknown
+		return skipped(reasonNoIPsecPhase2)
 	}
 
 	for _, p2 := range device.VPN.IPsec.Phase2Tunnels {
@@ -274,7 +368,7 @@ func (fp *Plugin) checkNoIKEv1AggressiveMode(device *common.CommonDevice) checkR
 	}
 
 	if !device.VPN.IPsec.Enabled || len(device.VPN.IPsec.Phase1Tunnels) == 0 {
-		return unknown
+		return skipped(reasonNoIPsecPhase1)
 	}
 
 	for _, p1 := range device.VPN.IPsec.Phase1Tunnels {
@@ -299,7 +393,7 @@ func (fp *Plugin) checkIKEv2Preferred(device *common.CommonDevice) checkResult {
 	}
 
 	if !device.VPN.IPsec.Enabled || len(device.VPN.IPsec.Phase1Tunnels) == 0 {
-		return unknown
+		return skipped(reasonNoIPsecPhase1)
 	}
 
 	for _, p1 := range device.VPN.IPsec.Phase1Tunnels {
@@ -324,7 +418,7 @@ func (fp *Plugin) checkDeadPeerDetection(device *common.CommonDevice) checkResul
 	}
 
 	if !device.VPN.IPsec.Enabled || len(device.VPN.IPsec.Phase1Tunnels) == 0 {
-		return unknown
+		return skipped(reasonNoIPsecPhase1)
 	}
 
 	for _, p1 := range device.VPN.IPsec.Phase1Tunnels {
@@ -339,3 +433,50 @@ func (fp *Plugin) checkDeadPeerDetection(device *common.CommonDevice) checkResul
 
 	return checkResult{Result: true, Known: true}
 }
+
+// checkOpenVPNCRLValidity checks that no certificate revocation list
+// referenced by an OpenVPN server has expired. An expired CRL makes OpenVPN
+// reject every client, or, with some builds, stop enforcing revocations.
+// The result is unknown when no referenced CRL has a known expiry; see
+// [common.CRL.ExpiresAt].
+func (fp *Plugin) checkOpenVPNCRLValidity(device *common.CommonDevice) checkResult {
+	if device == nil {
+		return unknown
+	}
+
+	if len(device.VPN.OpenVPN.Servers) == 0 {
+		return skipped("no OpenVPN servers configured")
+	}
+
+	now := time.Now()
+	known := false
+
+	for _, srv := range device.VPN.OpenVPN.Servers {
+		if srv.CRLRef == "" {
+			continue
+		}
+
+		for _, crl := range device.CRLs {
+			if crl.RefID != srv.CRLRef {
+				continue
+			}
+
+			expires, ok := crl.ExpiresAt()
+			if !ok {
+				continue
+			}
+
+			if expires.Before(now) {
+				return checkResult{Result: false, Known: true}
+			}
+
+			known = true
+		}
+	}
+
+	if !known {
+		return skipped("no OpenVPN server uses a CRL with a known expiry date")
+	}
+
+	return checkResult{Result: true, Known: true}
+}
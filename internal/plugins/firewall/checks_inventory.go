@@ -44,6 +44,20 @@ func (fp *Plugin) runInventoryChecks(device *common.CommonDevice) []compliance.F
 		})
 	}
 
+	// FIREWALL-064: State Table Limit
+	if cr := fp.checkStateTableLimit(device); cr.Known && cr.Result {
+		findings = append(findings, compliance.Finding{
+			Type:        "inventory",
+			Severity:    fp.controlSeverity("FIREWALL-064"),
+			Title:       "Explicit State Table Limit",
+			Description: fmt.Sprintf("pf state table capped at %d entries", device.PF.MaxStates),
+			Component:   "pf-config",
+			Reference:   "FIREWALL-064",
+			References:  []string{"FIREWALL-064"},
+			Tags:        []string{"inventory", "stateful-inspection", "firewall-controls"},
+		})
+	}
+
 	return findings
 }
 
@@ -125,3 +139,13 @@ func (fp *Plugin) interfaceInventoryDescription(device *common.CommonDevice) str
 
 	return fmt.Sprintf("%d enabled interface(s)", enabled)
 }
+
+// checkStateTableLimit reports whether an explicit pf state table limit is
+// configured. Without one, pf sizes the table from available memory.
+func (fp *Plugin) checkStateTableLimit(device *common.CommonDevice) checkResult {
+	if device == nil || device.PF == nil {
+		return checkResult{Result: false, Known: true}
+	}
+
+	return checkResult{Result: device.PF.MaxStates > 0, Known: true}
+}
@@ -13,6 +13,7 @@ import (
 const (
 	controlDHCPInventory      = "FIREWALL-062"
 	controlInterfaceInventory = "FIREWALL-063"
+	controlStateTableLimit    = "FIREWALL-064"
 )
 
 // inventoryFindingByRef returns the first finding with the given Reference, or nil.
@@ -165,6 +166,40 @@ func TestFirewallPlugin_InventoryChecks_Interfaces(t *testing.T) {
 	})
 }
 
+func TestFirewallPlugin_InventoryChecks_StateTableLimit(t *testing.T) {
+	t.Parallel()
+
+	fp := firewall.NewPlugin()
+
+	t.Run("emits finding when an explicit limit is set", func(t *testing.T) {
+		t.Parallel()
+
+		device := &common.CommonDevice{PF: &common.PFSettings{MaxStates: 50000}}
+
+		findings, _, err := fp.RunChecks(device)
+		require.NoError(t, err)
+		f := inventoryFindingByRef(findings, controlStateTableLimit)
+		require.NotNil(t, f, "expected FIREWALL-064 inventory finding")
+		assert.Equal(t, "inventory", f.Type)
+		assert.Equal(t, "info", f.Severity)
+		assert.Contains(t, f.Description, "50000 entries")
+	})
+
+	t.Run("no finding when pf uses its default", func(t *testing.T) {
+		t.Parallel()
+
+		for _, device := range []*common.CommonDevice{
+			{},
+			{PF: &common.PFSettings{StateScale: "adaptive"}},
+		} {
+			findings, _, err := fp.RunChecks(device)
+			require.NoError(t, err)
+			assert.Nil(t, inventoryFindingByRef(findings, controlStateTableLimit),
+				"unexpected state table finding without an explicit limit")
+		}
+	})
+}
+
 func TestFirewallPlugin_InventoryFindings_DoNotAppearInEvaluated(t *testing.T) {
 	t.Parallel()
 
@@ -174,12 +209,13 @@ func TestFirewallPlugin_InventoryFindings_DoNotAppearInEvaluated(t *testing.T) {
 		Interfaces: []common.Interface{
 			{Name: "wan", Enabled: true},
 		},
+		PF: &common.PFSettings{MaxStates: 50000},
 	}
 
 	_, evaluated, err := fp.RunChecks(device)
 	require.NoError(t, err)
 
-	for _, id := range []string{controlDHCPInventory, controlInterfaceInventory} {
+	for _, id := range []string{controlDHCPInventory, controlInterfaceInventory, controlStateTableLimit} {
 		assert.NotContains(t, evaluated, id,
 			"inventory control %s must not appear in evaluated slice", id)
 	}
@@ -194,6 +230,7 @@ func TestFirewallPlugin_InventoryFindings_HaveCorrectType(t *testing.T) {
 		Interfaces: []common.Interface{
 			{Name: "wan", Enabled: true},
 		},
+		PF: &common.PFSettings{MaxStates: 50000},
 	}
 
 	findings, _, err := fp.RunChecks(device)
@@ -204,10 +241,11 @@ func TestFirewallPlugin_InventoryFindings_HaveCorrectType(t *testing.T) {
 	for _, f := range findings {
 		if f.Type == "inventory" {
 			inventoryCount++
-			require.Contains(t, []string{controlDHCPInventory, controlInterfaceInventory}, f.Reference,
+			require.Contains(t,
+				[]string{controlDHCPInventory, controlInterfaceInventory, controlStateTableLimit}, f.Reference,
 				"inventory finding should reference an inventory control")
 		}
 	}
 
-	assert.Equal(t, 2, inventoryCount, "expected exactly 2 inventory findings")
+	assert.Equal(t, 3, inventoryCount, "expected exactly 3 inventory findings")
 }
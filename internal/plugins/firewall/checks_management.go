@@ -4,6 +4,7 @@ package firewall
 import (
 	"slices"
 	"strings"
+	"time"
 
 	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
 )
@@ -15,6 +16,10 @@ var defaultUsernames = []string{"admin", "root"}
 // pageAllPrivilege is the OPNsense privilege granting full web GUI access.
 const pageAllPrivilege = "page-all"
 
+// adminGroupNames contains the administrative group names for which page-all
+// is the expected privilege.
+var adminGroupNames = []string{"admins", "admin"}
+
 // FIREWALL-009 through FIREWALL-013 (non-default webgui port, management
 // interface restriction, TLS version minimum, anti-lockout rule awareness,
 // session timeout) were no-op helpers returning unknown — the CommonDevice
@@ -33,6 +38,29 @@ func (fp *Plugin) checkConsoleMenuProtection(device *common.CommonDevice) checkR
 	return checkResult{Result: device.System.DisableConsoleMenu, Known: true}
 }
 
+// virtualPlatformMarker is the architecture tag in the firmware version
+// string that identifies a cloud or virtual machine image.
+const virtualPlatformMarker = "amd64"
+
+// checkSerialConsoleVirtualDeployment checks that a cloud or virtual machine
+// deployment does not use the serial port as its primary console. The
+// deployment is taken to be virtual when the firmware version names the
+// amd64 architecture; a serial console without a firmware version is
+// unknown.
+func (fp *Plugin) checkSerialConsoleVirtualDeployment(device *common.CommonDevice) checkResult {
+	if device == nil || !device.System.Console.IsSerialConsoleActive() {
+		return checkResult{Result: true, Known: true}
+	}
+
+	if device.System.Firmware.Version == "" {
+		return unknown
+	}
+
+	virtual := strings.Contains(strings.ToLower(device.System.Firmware.Version), virtualPlatformMarker)
+
+	return checkResult{Result: !virtual, Known: true}
+}
+
 // FIREWALL-015 (checkLoginProtection) was a no-op returning unknown — the
 // CommonDevice model does not expose login brute-force protection. Removed
 // with the EvaluatedControlIDs cleanup; control remains in controls.go so
@@ -73,15 +101,16 @@ func (fp *Plugin) checkUniqueAdministratorAccounts(device *common.CommonDevice)
 	return checkResult{Result: true, Known: true}
 }
 
-// checkLeastPrivilegeAccess checks that no user or group is granted the
-// page-all privilege, which provides unrestricted web GUI access.
+// checkLeastPrivilegeAccess checks that no non-administrative group is
+// granted the page-all privilege, which provides unrestricted web GUI access.
+// The admin groups are exempt because page-all is their intended grant.
 func (fp *Plugin) checkLeastPrivilegeAccess(device *common.CommonDevice) checkResult {
 	if device == nil {
 		return checkResult{Result: true, Known: true}
 	}
 
 	for _, group := range device.Groups {
-		if slices.Contains(strings.Split(group.Privileges, ","), pageAllPrivilege) {
+		if group.HasPrivilege(pageAllPrivilege) && !isAdminGroup(group.Name) {
 			return checkResult{Result: false, Known: true}
 		}
 	}
@@ -127,10 +156,97 @@ func (fp *Plugin) checkGroupBasedPrivileges(device *common.CommonDevice) checkRe
 	}
 
 	for _, group := range device.Groups {
-		if group.Privileges != "" {
+		if len(group.Privileges) > 0 {
 			return checkResult{Result: true, Known: true}
 		}
 	}
 
 	return checkResult{Result: false, Known: true}
 }
+
+// isAdminGroup reports whether name is one of the administrative groups.
+func isAdminGroup(name string) bool {
+	return slices.ContainsFunc(adminGroupNames, func(admin string) bool {
+		return strings.EqualFold(name, admin)
+	})
+}
+
+// checkWebGUIHTTPSOnly checks that the web GUI serves HTTPS and has its HTTP
+// redirect listener disabled. Without <disablehttpredirect/>, the GUI also
+// listens for plain HTTP on port 80.
+func (fp *Plugin) checkWebGUIHTTPSOnly(device *common.CommonDevice) checkResult {
+	if device == nil {
+		return checkResult{Result: false, Known: true}
+	}
+
+	webGUI := device.System.WebGUI
+
+	return checkResult{
+		Result: strings.EqualFold(webGUI.Protocol, "https") && webGUI.DisableHTTPRedirect,
+		Known:  true,
+	}
+}
+
+// checkSSHKeysWithoutSSH checks that users with SSH authorized keys are not
+// left over on a firewall whose SSH service is disabled. The check is not
+// applicable when no enabled user has SSH keys.
+func (fp *Plugin) checkSSHKeysWithoutSSH(device *common.CommonDevice) checkResult {
+	if device == nil {
+		return checkResult{Known: false}
+	}
+
+	hasKeys := slices.ContainsFunc(device.Users, func(user common.User) bool {
+		return !user.Disabled && user.HasSSHKey()
+	})
+	if !hasKeys {
+		return checkResult{Known: false}
+	}
+
+	return checkResult{Result: device.System.SSH.Enabled, Known: true}
+}
+
+// accountExpiryNoticeWindow is how far ahead FIREWALL-076 looks for enabled
+// accounts that are about to expire.
+const accountExpiryNoticeWindow = 30 * 24 * time.Hour
+
+// checkExpiredAccountsDisabled checks that no enabled user account is past
+// its expiry date.
+func (fp *Plugin) checkExpiredAccountsDisabled(device *common.CommonDevice) checkResult {
+	if device == nil {
+		return checkResult{Result: true, Known: true}
+	}
+
+	return checkResult{Result: !hasExpiredEnabledAccount(device.Users, time.Now()), Known: true}
+}
+
+// checkAccountExpiryNotice checks that no enabled user account expires within
+// accountExpiryNoticeWindow. Accounts that have already expired are reported
+// by FIREWALL-075 instead.
+func (fp *Plugin) checkAccountExpiryNotice(device *common.CommonDevice) checkResult {
+	if device == nil {
+		return checkResult{Result: true, Known: true}
+	}
+
+	return checkResult{Result: !hasAccountExpiringSoon(device.Users, time.Now()), Known: true}
+}
+
+// hasExpiredEnabledAccount reports whether any enabled account in users had
+// expired at now.
+func hasExpiredEnabledAccount(users []common.User, now time.Time) bool {
+	return slices.ContainsFunc(users, func(user common.User) bool {
+		return !user.Disabled && user.IsExpiredAt(now)
+	})
+}
+
+// hasAccountExpiringSoon reports whether any enabled account in users is
+// still valid at now but expires within accountExpiryNoticeWindow of it.
+func hasAccountExpiringSoon(users []common.User, now time.Time) bool {
+	return slices.ContainsFunc(users, func(user common.User) bool {
+		expires, ok := user.ExpiresAt()
+		if user.Disabled || !ok || !now.Before(expires) {
+			return false
+		}
+
+		return expires.Sub(now) <= accountExpiryNoticeWindow
+	})
+}
@@ -0,0 +1,88 @@
+package firewall
+
+import (
+	"testing"
+	"time"
+
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestAccountExpiryChecks_Boundaries pins FIREWALL-075 and FIREWALL-076 to a
+// fixed clock around an account that expires on 03/15/2025.
+func TestAccountExpiryChecks_Boundaries(t *testing.T) {
+	t.Parallel()
+
+	contractor := common.User{
+		Name:          "contractor",
+		Expires:       "03/15/2025",
+		ExpiryDate:    time.Date(2025, time.March, 15, 0, 0, 0, 0, time.UTC).Unix(),
+		HasExpiryDate: true,
+	}
+	disabled := contractor
+	disabled.Disabled = true
+
+	tests := []struct {
+		name         string
+		users        []common.User
+		now          time.Time
+		wantExpired  bool
+		wantExpiring bool
+	}{
+		{
+			name:  "more than 30 days before the account lapses",
+			users: []common.User{contractor},
+			now:   time.Date(2025, time.February, 13, 23, 59, 59, 0, time.UTC),
+		},
+		{
+			name:         "exactly 30 days before the account lapses",
+			users:        []common.User{contractor},
+			now:          time.Date(2025, time.February, 14, 0, 0, 0, 0, time.UTC),
+			wantExpiring: true,
+		},
+		{
+			name:         "last second of the expiry day",
+			users:        []common.User{contractor},
+			now:          time.Date(2025, time.March, 15, 23, 59, 59, 0, time.UTC),
+			wantExpiring: true,
+		},
+		{
+			name:        "first second after the expiry day",
+			users:       []common.User{contractor},
+			now:         time.Date(2025, time.March, 16, 0, 0, 0, 0, time.UTC),
+			wantExpired: true,
+		},
+		{
+			name:  "disabled account after the expiry day",
+			users: []common.User{disabled},
+			now:   time.Date(2025, time.March, 16, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "disabled account about to expire",
+			users: []common.User{disabled},
+			now:   time.Date(2025, time.March, 10, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "expiry date of 01/01/1970, stored as Unix zero",
+			users: []common.User{
+				{Name: "epoch", Expires: "01/01/1970", ExpiryDate: 0, HasExpiryDate: true},
+			},
+			now:         time.Date(2025, time.March, 16, 0, 0, 0, 0, time.UTC),
+			wantExpired: true,
+		},
+		{
+			name:  "expiry date that did not parse",
+			users: []common.User{{Name: "legacy", Expires: "someday"}},
+			now:   time.Date(2025, time.March, 16, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.wantExpired, hasExpiredEnabledAccount(tt.users, tt.now), "FIREWALL-075")
+			assert.Equal(t, tt.wantExpiring, hasAccountExpiringSoon(tt.users, tt.now), "FIREWALL-076")
+		})
+	}
+}
@@ -197,7 +197,7 @@ func (fp *Plugin) checkPrivateAddressFilteringOnWAN(device *common.CommonDevice)
 	}
 
 	if !foundWAN {
-		return unknown
+		return skipped("no WAN interface configured")
 	}
 
 	return checkResult{Result: true, Known: true}
@@ -221,7 +221,7 @@ func (fp *Plugin) checkBogonFilteringOnWAN(device *common.CommonDevice) checkRes
 	}
 
 	if !foundWAN {
-		return unknown
+		return skipped("no WAN interface configured")
 	}
 
 	return checkResult{Result: true, Known: true}
@@ -300,3 +300,38 @@ func (fp *Plugin) checkSYNFloodProtection(device *common.CommonDevice) checkResu
 // the CommonDevice model has no global max-states setting. Removed with the
 // EvaluatedControlIDs cleanup; control remains in controls.go so the report
 // labels it UNCONFIRMED.
+
+// zeroTierDevicePrefix is the device name prefix of ZeroTier network
+// interfaces (e.g., "ztc3q6qi7r").
+const zeroTierDevicePrefix = "zt"
+
+// checkZeroTierInterfaceRules checks that an enabled ZeroTier service has at
+// least one enabled firewall rule on an assigned ZeroTier interface. Without
+// an assigned interface and rules of its own, overlay traffic is not filtered
+// by pf. Returns unknown when ZeroTier is not configured or not enabled.
+func (fp *Plugin) checkZeroTierInterfaceRules(device *common.CommonDevice) checkResult {
+	if device == nil || device.VPN.ZeroTier == nil || !device.VPN.ZeroTier.Enabled {
+		return skipped("ZeroTier is not enabled")
+	}
+
+	ztInterfaces := make(map[string]bool)
+	for _, iface := range device.Interfaces {
+		if strings.HasPrefix(iface.PhysicalIf, zeroTierDevicePrefix) {
+			ztInterfaces[iface.Name] = true
+		}
+	}
+
+	for _, rule := range device.FirewallRules {
+		if rule.Disabled {
+			continue
+		}
+
+		for _, name := range rule.Interfaces {
+			if ztInterfaces[name] {
+				return checkResult{Result: true, Known: true}
+			}
+		}
+	}
+
+	return checkResult{Result: false, Known: true}
+}
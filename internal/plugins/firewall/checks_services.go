@@ -2,8 +2,10 @@
 package firewall
 
 import (
+	"net/netip"
 	"strings"
 
+	"github.com/EvilBit-Labs/opnDossier/internal/analysis"
 	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
 )
 
@@ -56,10 +58,16 @@ func (fp *Plugin) checkNATReflectionDisabled(device *common.CommonDevice) checkR
 	return checkResult{Result: device.NAT.ReflectionDisabled, Known: true}
 }
 
-// FIREWALL-057 (checkUPnPDisabled) was a no-op returning unknown — the
-// CommonDevice model does not expose UPnP configuration. Removed with the
-// EvaluatedControlIDs cleanup; control remains in controls.go so the report
-// labels it UNCONFIRMED.
+// checkUPnPDisabled checks that the UPnP IGD / NAT-PMP service is not
+// running. A configuration without miniupnpd settings has no UPnP service
+// installed and passes.
+func (fp *Plugin) checkUPnPDisabled(device *common.CommonDevice) checkResult {
+	if device == nil || device.UPnP == nil {
+		return checkResult{Result: true, Known: true}
+	}
+
+	return checkResult{Result: !device.UPnP.Enabled, Known: true}
+}
 
 // checkDNSSECValidation checks that DNSSEC validation is enabled on the
 // Unbound DNS resolver. DNSSEC prevents DNS spoofing by cryptographically
@@ -99,3 +107,113 @@ func (fp *Plugin) checkHAConfiguration(device *common.CommonDevice) checkResult
 	// HA is partially configured; check that pfsync peer is defined.
 	return checkResult{Result: ha.PfsyncPeerIP != "", Known: true}
 }
+
+// checkWakeOnLANExposure checks that no Wake-on-LAN host is configured on a
+// WAN-facing interface, where it becomes an unintended remote wake-up
+// vector. An entry whose interface is not in device.Interfaces is judged by
+// its name alone.
+func (fp *Plugin) checkWakeOnLANExposure(device *common.CommonDevice) checkResult {
+	if device == nil {
+		return unknown
+	}
+
+	for _, entry := range device.WakeOnLAN {
+		wanFacing := analysis.IsWANInterfaceName(entry.Interface)
+		for _, iface := range device.Interfaces {
+			if iface.Name == entry.Interface {
+				wanFacing = analysis.InterfaceReachability(iface) == analysis.WANReachable
+				break
+			}
+		}
+
+		if wanFacing {
+			return checkResult{Result: false, Known: true}
+		}
+	}
+
+	return checkResult{Result: true, Known: true}
+}
+
+// checkNetFlowExportDestination checks that NetFlow/IPFIX flows are only
+// exported to collectors inside the home network (see analysis.InHomeNetwork).
+// Returns unknown when flow export is not enabled or has no collectors.
+// Collectors named by hostname cannot be classified and are skipped.
+func (fp *Plugin) checkNetFlowExportDestination(device *common.CommonDevice) checkResult {
+	if device == nil || device.Netflow == nil || !device.Netflow.Enabled || len(device.Netflow.Collectors) == 0 {
+		return checkResult{Result: false, Known: false}
+	}
+
+	for _, collector := range device.Netflow.Collectors {
+		addr, err := netip.ParseAddr(collector.Address)
+		if err != nil {
+			continue
+		}
+
+		if !analysis.InHomeNetwork(device, addr) {
+			return checkResult{Result: false, Known: true}
+		}
+	}
+
+	return checkResult{Result: true, Known: true}
+}
+
+// localMailDomains are domain suffixes reserved for private or local use.
+// Mail addressed to them cannot leave the organization.
+var localMailDomains = []string{"localhost", "local", "localdomain", "internal", "home.arpa", "lan"}
+
+// checkMonitAlertDestination checks that Monit emails its alerts only to
+// internal recipients. Returns unknown when Monit is disabled or has no
+// enabled alert recipients.
+func (fp *Plugin) checkMonitAlertDestination(device *common.CommonDevice) checkResult {
+	if device == nil || device.Monit == nil || !device.Monit.Enabled {
+		return checkResult{Result: false, Known: false}
+	}
+
+	known := false
+
+	for _, alert := range device.Monit.Alerts {
+		if !alert.Enabled || alert.Recipient == "" {
+			continue
+		}
+
+		known = true
+
+		if isExternalMailRecipient(device, alert.Recipient) {
+			return checkResult{Result: false, Known: true}
+		}
+	}
+
+	if !known {
+		return checkResult{Result: false, Known: false}
+	}
+
+	return checkResult{Result: true, Known: true}
+}
+
+// isExternalMailRecipient reports whether mail to address leaves the
+// organization. A bare mailbox, a reserved local domain, the device's own
+// domain or a subdomain of it, and an address literal inside the home
+// network (see analysis.InHomeNetwork) are internal.
+func isExternalMailRecipient(device *common.CommonDevice, address string) bool {
+	at := strings.LastIndex(address, "@")
+	if at < 0 {
+		return false
+	}
+
+	domain := strings.TrimSuffix(strings.ToLower(strings.TrimSpace(address[at+1:])), ".")
+
+	if literal, ok := strings.CutPrefix(domain, "["); ok {
+		addr, err := netip.ParseAddr(strings.TrimPrefix(strings.TrimSuffix(literal, "]"), "ipv6:"))
+		return err != nil || !analysis.InHomeNetwork(device, addr)
+	}
+
+	for _, local := range localMailDomains {
+		if domain == local || strings.HasSuffix(domain, "."+local) {
+			return false
+		}
+	}
+
+	own := strings.ToLower(device.System.Domain)
+
+	return own == "" || (domain != own && !strings.HasSuffix(domain, "."+own))
+}
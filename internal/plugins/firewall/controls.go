@@ -3,10 +3,10 @@ package firewall
 
 import "github.com/EvilBit-Labs/opnDossier/internal/compliance"
 
-// newControlDefinitions returns the control definitions for FIREWALL-009 through -061.
+// newControlDefinitions returns the control definitions for FIREWALL-009 through -076.
 // These are appended to the original 8 controls in the NewPlugin constructor.
 //
-//nolint:funlen // 53 control definitions are inherently verbose
+//nolint:funlen // 59 control definitions are inherently verbose
 func newControlDefinitions() []compliance.Control {
 	return []compliance.Control{
 		// Management Plane controls (FIREWALL-009 through -021)
@@ -106,7 +106,7 @@ func newControlDefinitions() []compliance.Control {
 			Description: "Administrative access should follow the principle of least privilege",
 			Category:    "Authentication",
 			Severity:    "medium",
-			Rationale:   "Granting page-all privileges to groups provides unrestricted access beyond what most roles require",
+			Rationale:   "Granting page-all to non-admin groups provides unrestricted access beyond what most roles require",
 			Remediation: "Replace page-all privileges with specific page-level permissions matched to each role",
 			Tags:        []string{"authentication", "least-privilege", "firewall-controls"},
 		},
@@ -399,7 +399,7 @@ func newControlDefinitions() []compliance.Control {
 			Category:    "VPN Configuration",
 			Severity:    "high",
 			Rationale:   "Weak encryption (DES, 3DES, Blowfish) can be broken and exposes VPN traffic",
-			Remediation: "Configure AES-256-GCM or AES-128-GCM encryption for IPsec Phase 2 tunnels",
+			Remediation: "Configure AES-256-GCM or AES-128-GCM encryption for IPsec Phase 2 tunnels and connection proposals",
 			Tags:        []string{"vpn-config", "encryption", "firewall-controls"},
 		},
 		{
@@ -409,7 +409,7 @@ func newControlDefinitions() []compliance.Control {
 			Category:    "VPN Configuration",
 			Severity:    "high",
 			Rationale:   "Weak hash algorithms (MD5, SHA-1) are vulnerable to collision attacks",
-			Remediation: "Configure SHA-256 or stronger hash algorithms for IPsec Phase 2 tunnels",
+			Remediation: "Configure SHA-256 or stronger hash algorithms for IPsec Phase 2 tunnels and connection proposals",
 			Tags:        []string{"vpn-config", "integrity", "firewall-controls"},
 		},
 		{
@@ -544,7 +544,7 @@ func newControlDefinitions() []compliance.Control {
 			Tags:        []string{"high-availability", "pfsync", "firewall-controls"},
 		},
 
-		// Configuration Inventory controls (FIREWALL-062 through -063)
+		// Configuration Inventory controls (FIREWALL-062 through -064)
 		{
 			ID:          "FIREWALL-062",
 			Title:       "DHCP Scope Inventory",
@@ -565,5 +565,152 @@ func newControlDefinitions() []compliance.Control {
 			Remediation: "No action required — this is an informational observation",
 			Tags:        []string{"inventory", "interfaces", "firewall-controls"},
 		},
+		{
+			ID:          "FIREWALL-064",
+			Title:       "State Table Limit",
+			Description: "Reports an explicit pf state table limit when one is configured",
+			Category:    "Configuration Inventory",
+			Severity:    "info",
+			Rationale:   "A state table that fills up causes pf to drop new connections, so a fixed limit should be sized for the traffic the device carries",
+			Remediation: "Confirm the limit covers peak connection counts, or clear Firewall Maximum States in Firewall > Settings > Advanced to use the memory-based default",
+			Tags:        []string{"inventory", "stateful-inspection", "firewall-controls"},
+		},
+
+		// Service Hardening controls (FIREWALL-065)
+		{
+			ID:          "FIREWALL-065",
+			Title:       "Wake-on-LAN Exposure",
+			Description: "Wake-on-LAN hosts should only be configured on internal interfaces",
+			Category:    "Service Hardening",
+			Severity:    "info",
+			Rationale:   "A Wake-on-LAN entry on a WAN-facing interface is an unintended remote wake-up vector for internal hosts",
+			Remediation: "Remove or move Wake-on-LAN entries on WAN interfaces in Services > Wake on LAN",
+			Tags:        []string{"service-hardening", "wake-on-lan", "firewall-controls"},
+		},
+
+		// VPN Configuration controls (FIREWALL-066)
+		{
+			ID:          "FIREWALL-066",
+			Title:       "OpenVPN CRL Validity",
+			Description: "Certificate revocation lists used by OpenVPN servers should not be expired",
+			Category:    "VPN Configuration",
+			Severity:    "medium",
+			Rationale:   "OpenVPN rejects client connections once its CRL has expired, and a stale CRL may miss recent revocations",
+			Remediation: "Reissue the CRL in System > Trust > Revocation, or raise its lifetime so it is renewed before expiry",
+			Tags:        []string{"vpn-config", "crl", "firewall-controls"},
+		},
+
+		// Time Synchronization controls (FIREWALL-067)
+		{
+			ID:          "FIREWALL-067",
+			Title:       "NTP Query Restriction",
+			Description: "ntpd should deny status queries (noquery) from clients",
+			Category:    "Time Synchronization",
+			Severity:    "medium",
+			Rationale:   "Unrestricted mode 6 status queries let attackers use the firewall as an NTP amplification reflector",
+			Remediation: "Enable the noquery restriction in Services > Network Time > General",
+			Tags:        []string{"time-sync", "ntp", "firewall-controls"},
+		},
+
+		// Management Access controls (FIREWALL-068)
+		{
+			ID:          "FIREWALL-068",
+			Title:       "Web GUI HTTPS Only",
+			Description: "The web GUI should serve HTTPS only, with the HTTP redirect listener disabled",
+			Category:    "Management Access",
+			Severity:    "critical",
+			Rationale:   "While the HTTP redirect is enabled the admin GUI listens for plain HTTP on port 80, where the first request and any credentials posted to it travel unencrypted",
+			Remediation: "Set the protocol to HTTPS and enable Disable web GUI redirect rule in System > Settings > Administration",
+			Tags:        []string{"management-access", "tls", "firewall-controls"},
+		},
+
+		// Logging controls (FIREWALL-069)
+		{
+			ID:          "FIREWALL-069",
+			Title:       "NetFlow Export Destination",
+			Description: "NetFlow/IPFIX flow records should only be exported to collectors inside the home network",
+			Category:    "Logging",
+			Severity:    "medium",
+			Rationale:   "Flow records describe every connection through the firewall, so exporting them to an external address discloses the network's traffic profile and is a data exfiltration channel",
+			Remediation: "Point the capture targets at an internal collector in Reporting > NetFlow, or carry the export over a VPN to an internal address",
+			Tags:        []string{"logging", "netflow", "firewall-controls"},
+		},
+
+		// Authentication controls (FIREWALL-070)
+		{
+			ID:          "FIREWALL-070",
+			Title:       "SSH Keys Without SSH Service",
+			Description: "Users should not keep SSH authorized keys while the SSH service is disabled",
+			Category:    "Authentication",
+			Severity:    "medium",
+			Rationale:   "Keys left on accounts after SSH was turned off are unreviewed standing credentials that grant shell access the moment the service is re-enabled",
+			Remediation: "Remove the authorized keys from the accounts in System > Access > Users, or enable SSH if key logins are intended",
+			Tags:        []string{"authentication", "ssh", "firewall-controls"},
+		},
+		// Encryption controls (FIREWALL-071)
+		{
+			ID:          "FIREWALL-071",
+			Title:       "Trusted Web GUI Certificate",
+			Description: "The web GUI certificate should be issued by a CA and not be expired",
+			Category:    "Encryption",
+			Severity:    "critical",
+			Rationale:   "Administrators cannot tell a self-signed or expired management certificate from an attacker's, so they learn to click through the browser warning and management sessions become open to interception",
+			Remediation: "Issue a certificate from an internal or public CA in System > Trust > Certificates and select it as the web GUI SSL certificate in System > Settings > Administration",
+			Tags:        []string{"encryption", "certificates", "firewall-controls"},
+		},
+		// Logging controls (FIREWALL-072)
+		{
+			ID:          "FIREWALL-072",
+			Title:       "Monit Alert Destination",
+			Description: "Monit alert email should only be sent to recipients inside the organization",
+			Category:    "Logging",
+			Severity:    "info",
+			Rationale:   "Monit alerts name the firewall's services, resource usage, and failures, so mailing them to an outside address discloses operational detail and is a data exfiltration channel",
+			Remediation: "Address the Monit alerts to a mailbox in the device's domain in Services > Monit > Settings > Alert Settings, or relay them through an internal mail server",
+			Tags:        []string{"logging", "monit", "firewall-controls"},
+		},
+		// VPN Configuration controls (FIREWALL-073)
+		{
+			ID:          "FIREWALL-073",
+			Title:       "ZeroTier Interface Filtering",
+			Description: "An enabled ZeroTier overlay should be assigned as an interface with explicit firewall rules",
+			Category:    "VPN Configuration",
+			Severity:    "info",
+			Rationale:   "ZeroTier peers reach the firewall over the overlay network, and that traffic bypasses pf unless the ZeroTier device is assigned as an interface with rules of its own",
+			Remediation: "Assign the ZeroTier network device in Interfaces > Assignments and add explicit pass and block rules for it in Firewall > Rules",
+			Tags:        []string{"vpn-config", "zerotier", "firewall-controls"},
+		},
+		// Management Access controls (FIREWALL-074)
+		{
+			ID:          "FIREWALL-074",
+			Title:       "Serial Console on Virtual Deployment",
+			Description: "A cloud or virtual machine deployment should not use the serial port as its primary console",
+			Category:    "Management Access",
+			Severity:    "medium",
+			Rationale:   "On a hypervisor or cloud host the serial console is exposed through the provider's management plane, so anyone with access to the virtual serial port reaches the firewall console outside of the network access controls",
+			Remediation: "Set the primary console to video in System > Settings > Administration, or restrict access to the virtual serial port in the hypervisor or cloud console",
+			Tags:        []string{"management-access", "console", "firewall-controls"},
+		},
+		// Authentication controls (FIREWALL-075 through -076)
+		{
+			ID:          "FIREWALL-075",
+			Title:       "Expired Accounts Disabled",
+			Description: "User accounts past their expiry date should be disabled",
+			Category:    "Authentication",
+			Severity:    "medium",
+			Rationale:   "An expired account was meant to stop working on a known date; leaving it enabled keeps a credential in the configuration that nobody is expected to use and that outlives the access review it was created under",
+			Remediation: "Disable or remove expired accounts in System > Access > Users, or extend the expiry date if the access is still needed",
+			Tags:        []string{"authentication", "account-expiry", "firewall-controls"},
+		},
+		{
+			ID:          "FIREWALL-076",
+			Title:       "Account Expiry Notice",
+			Description: "User accounts expiring within 30 days should be reviewed",
+			Category:    "Authentication",
+			Severity:    "info",
+			Rationale:   "Reviewing accounts before they expire lets owners renew access that is still needed and confirms that access which is not will lapse on schedule",
+			Remediation: "Confirm with the account owners whether access should lapse, and extend the expiry date in System > Access > Users where it should not",
+			Tags:        []string{"authentication", "account-expiry", "firewall-controls"},
+		},
 	}
 }
@@ -10,10 +10,12 @@ import (
 
 // checkResult holds the outcome of a compliance check helper. When Known is
 // false the Result is meaningless — the check is skipped because config.xml
-// does not contain the data needed to determine compliance.
+// does not contain the data needed to determine compliance, and Reason may
+// say what is missing.
 type checkResult struct {
 	Result bool
 	Known  bool
+	Reason string
 }
 
 // initialFindingsCapacity is the starting capacity for the findings slice in
@@ -149,21 +151,38 @@ func (fp *Plugin) Description() string {
 }
 
 // RunChecks performs Firewall compliance checks against the device configuration
-// in a single traversal. Returns (findings, evaluated, err).
+// in a single traversal. Returns (findings, evaluated, err); see
+// RunChecksWithOutcomes.
+//
+//nolint:gocritic // nonamedreturns enforced project-wide
+func (fp *Plugin) RunChecks(
+	device *common.CommonDevice,
+) ([]compliance.Finding, []string, error) {
+	findings, evaluated, _, err := fp.RunChecksWithOutcomes(device)
+
+	return findings, evaluated, err
+}
+
+// RunChecksWithOutcomes performs Firewall compliance checks against the device
+// configuration in a single traversal. Returns (findings, evaluated, outcomes,
+// err).
 //
 // Each helper returns (result, known). When known is false the check is skipped
 // because the data needed to determine compliance is not available in config.xml,
-// and that control ID is excluded from the evaluated slice. When known is true
-// the control ID is appended to evaluated regardless of pass/fail.
+// and that control ID is excluded from the evaluated slice; a helper that
+// gives a reason has it recorded in outcomes. When known is true the control
+// ID is appended to evaluated regardless of pass/fail. A helper that panics is
+// recorded in outcomes as errored and the remaining checks still run.
 //
 // err is currently always nil — reserved for unrecoverable future conditions.
 //
 //nolint:gocritic,funlen // nonamedreturns enforced project-wide; length is dominated by the declarative baseEntries control table.
-func (fp *Plugin) RunChecks(
+func (fp *Plugin) RunChecksWithOutcomes(
 	device *common.CommonDevice,
-) ([]compliance.Finding, []string, error) {
+) ([]compliance.Finding, []string, map[string]compliance.CheckOutcome, error) {
 	findings := make([]compliance.Finding, 0, initialFindingsCapacity)
 	evaluated := make([]string, 0, len(fp.controls))
+	outcomes := make(map[string]compliance.CheckOutcome)
 
 	// Inline base-control dispatch. Each entry runs exactly once and contributes
 	// to both findings (on fail) and evaluated (on Known=true).
@@ -253,7 +272,7 @@ func (fp *Plugin) RunChecks(
 	}
 
 	for _, entry := range baseEntries {
-		cr := entry.checkFn(device)
+		cr := runCheck(entry.controlID, func() checkResult { return entry.checkFn(device) }, outcomes)
 		if !cr.Known {
 			continue
 		}
@@ -282,17 +301,17 @@ func (fp *Plugin) RunChecks(
 		})
 	}
 
-	// Run new checks (FIREWALL-009 through -061) via table-driven dispatch.
-	newFindings, newEvaluated := fp.runNewChecks(device)
+	// Run new checks (FIREWALL-009 through -061, -065 through -076) via table-driven dispatch.
+	newFindings, newEvaluated := fp.runCheckTable(device, fp.newChecksTable(), outcomes)
 	findings = append(findings, newFindings...)
 	evaluated = append(evaluated, newEvaluated...)
 
-	// Run inventory checks (FIREWALL-062+) — Type: "inventory", excluded from
+	// Run inventory checks (FIREWALL-062 through -064) — Type: "inventory", excluded from
 	// compliance map. Inventory controls are NOT appended to evaluated because
 	// they are informational and do not participate in compliance pass/fail.
 	findings = append(findings, fp.runInventoryChecks(device)...)
 
-	return findings, evaluated, nil
+	return findings, evaluated, outcomes, nil
 }
 
 // GetControls returns all Firewall controls. The returned slice is a deep copy to
@@ -348,11 +367,17 @@ const autoConfigBackupPackage = "os-acb"
 // unknown is a convenience value for checks that cannot be evaluated.
 var unknown = checkResult{Result: false, Known: false}
 
+// skipped returns an unknown result that explains why the check could not be
+// evaluated. The reason appears in the audit report's checks appendix.
+func skipped(reason string) checkResult {
+	return checkResult{Reason: reason}
+}
+
 // hasSSHBanner checks whether an SSH warning banner is configured.
 // SSH banners are OS-level configs (/etc/ssh/sshd_config) not present in
 // config.xml, so the state cannot be determined.
 func (fp *Plugin) hasSSHBanner(_ *common.CommonDevice) checkResult {
-	return unknown
+	return skipped("the SSH banner is set in sshd_config, which config.xml does not contain")
 }
 
 // hasAutoConfigBackup checks whether the os-acb automatic configuration backup
@@ -382,7 +407,7 @@ func (fp *Plugin) hasAutoConfigBackup(device *common.CommonDevice) checkResult {
 // MOTD is an OS-level file (/etc/motd) not present in config.xml, so the
 // state cannot be determined.
 func (fp *Plugin) hasCustomMOTD(_ *common.CommonDevice) checkResult {
-	return unknown
+	return skipped("the MOTD is stored in /etc/motd, which config.xml does not contain")
 }
 
 // hasCustomHostname checks whether the device hostname has been changed from
@@ -442,7 +467,7 @@ func (fp *Plugin) hasDNSRebindProtection(device *common.CommonDevice) checkResul
 	}
 
 	if !device.DNS.Unbound.Enabled {
-		return unknown
+		return skipped("Unbound DNS is not enabled")
 	}
 
 	if !device.DNS.Unbound.PrivateAddressConfigured {
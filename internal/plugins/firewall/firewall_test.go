@@ -1,18 +1,21 @@
 package firewall_test
 
 import (
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/EvilBit-Labs/opnDossier/internal/compliance"
 	"github.com/EvilBit-Labs/opnDossier/internal/constants"
 	"github.com/EvilBit-Labs/opnDossier/internal/plugins/firewall"
 	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/EvilBit-Labs/opnDossier/pkg/schema/shared"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
 // totalControls is the expected number of controls in the firewall plugin.
-const totalControls = 63
+const totalControls = 76
 
 func TestFirewallPlugin_RunChecks(t *testing.T) {
 	firewallPlugin := firewall.NewPlugin()
@@ -51,6 +54,7 @@ func TestFirewallPlugin_RunChecks(t *testing.T) {
 				"FIREWALL-044", // No timezone
 				"FIREWALL-056", // NAT reflection not disabled
 				"FIREWALL-058", // No DNSSEC
+				"FIREWALL-068", // Web GUI accepts HTTP
 			},
 			description: "Default OPNsense config triggers verifiable firewall compliance checks",
 		},
@@ -58,9 +62,13 @@ func TestFirewallPlugin_RunChecks(t *testing.T) {
 			name: "Custom secure configuration - minimal findings",
 			config: &common.CommonDevice{
 				System: common.System{
-					Hostname:           "secure-firewall",
-					Domain:             "company.local",
-					WebGUI:             common.WebGUI{Protocol: "https", SSLCertRef: "cert-123"},
+					Hostname: "secure-firewall",
+					Domain:   "company.local",
+					WebGUI: common.WebGUI{
+						Protocol:            "https",
+						SSLCertRef:          "cert-123",
+						DisableHTTPRedirect: true,
+					},
 					IPv6Allow:          false, // IPv6 disabled
 					DNSServers:         []string{"8.8.8.8"},
 					DisableConsoleMenu: true,
@@ -68,9 +76,10 @@ func TestFirewallPlugin_RunChecks(t *testing.T) {
 					TimeServers:        []string{"0.pool.ntp.org", "1.pool.ntp.org"},
 				},
 				Groups: []common.Group{
-					{Name: "admins", Privileges: "page-system-config"},
+					{Name: "admins", Privileges: []string{"page-system-config"}},
 				},
 				VLANs: []common.VLAN{{Tag: "100"}},
+				NTP:   common.NTPConfig{NoQuery: true},
 				Syslog: common.SyslogConfig{
 					Enabled:       true,
 					RemoteServer:  "10.0.0.1",
@@ -117,6 +126,7 @@ func TestFirewallPlugin_RunChecks(t *testing.T) {
 				"FIREWALL-044", // No timezone
 				"FIREWALL-056", // NAT reflection not disabled
 				"FIREWALL-058", // No DNSSEC
+				"FIREWALL-068", // Web GUI accepts HTTP
 			},
 			description: "Empty system config triggers verifiable checks (IPv6 defaults to false)",
 		},
@@ -177,6 +187,7 @@ func TestFirewallPlugin_RunChecks_NilDevice(t *testing.T) {
 		"FIREWALL-043", // No NTP servers
 		"FIREWALL-044", // No timezone
 		"FIREWALL-058", // No DNSSEC
+		"FIREWALL-068", // Web GUI accepts HTTP
 	}
 	assert.Len(t, findings, len(expectedIDs), "Nil device should produce %d findings, got %d: %v",
 		len(expectedIDs), len(findings), getFindings(findings))
@@ -454,6 +465,97 @@ func TestFirewallPlugin_Controls(t *testing.T) {
 			expectedSeverity: "medium",
 			expectedCategory: "High Availability",
 		},
+		{
+			name:             "State Table Limit control",
+			controlID:        "FIREWALL-064",
+			expectFound:      true,
+			expectedSeverity: "info",
+			expectedCategory: "Configuration Inventory",
+		},
+		{
+			name:             "Wake-on-LAN Exposure control",
+			controlID:        "FIREWALL-065",
+			expectFound:      true,
+			expectedSeverity: "info",
+			expectedCategory: "Service Hardening",
+		},
+		{
+			name:             "OpenVPN CRL Validity control",
+			controlID:        "FIREWALL-066",
+			expectFound:      true,
+			expectedSeverity: "medium",
+			expectedCategory: "VPN Configuration",
+		},
+		{
+			name:             "NTP Query Restriction control",
+			controlID:        "FIREWALL-067",
+			expectFound:      true,
+			expectedSeverity: "medium",
+			expectedCategory: "Time Synchronization",
+		},
+		{
+			name:             "Web GUI HTTPS Only control",
+			controlID:        "FIREWALL-068",
+			expectFound:      true,
+			expectedSeverity: "critical",
+			expectedCategory: "Management Access",
+		},
+		{
+			name:             "NetFlow Export Destination control",
+			controlID:        "FIREWALL-069",
+			expectFound:      true,
+			expectedSeverity: "medium",
+			expectedCategory: "Logging",
+		},
+		{
+			name:             "SSH Keys Without SSH Service control",
+			controlID:        "FIREWALL-070",
+			expectFound:      true,
+			expectedSeverity: "medium",
+			expectedCategory: "Authentication",
+		},
+		{
+			name:             "Trusted Web GUI Certificate control",
+			controlID:        "FIREWALL-071",
+			expectFound:      true,
+			expectedSeverity: "critical",
+			expectedCategory: "Encryption",
+		},
+		{
+			name:             "Monit Alert Destination control",
+			controlID:        "FIREWALL-072",
+			expectFound:      true,
+			expectedSeverity: "info",
+			expectedCategory: "Logging",
+		},
+		{
+			name:             "ZeroTier Interface Filtering control",
+			controlID:        "FIREWALL-073",
+			expectFound:      true,
+			expectedSeverity: "info",
+			expectedCategory: "VPN Configuration",
+		},
+		{
+			name:             "Serial Console on Virtual Deployment control",
+			controlID:        "FIREWALL-074",
+			expectFound:      true,
+			expectedSeverity: "medium",
+			expectedCategory: "Management Access",
+		},
+		{
+			name:             "Expired Accounts Disabled control",
+			controlID:        "FIREWALL-075",
+			expectFound:      true,
+			expectedSeverity: "medium",
+			expectedCategory: "Authentication",
+		},
+		{
+			name:             "Account Expiry Notice control",
+			controlID:        "FIREWALL-076",
+			expectFound:      true,
+			expectedSeverity: "info",
+			expectedCategory: "Authentication",
+		},
 		{
 			name:        "Non-existent control",
 			controlID:   "FIREWALL-999",
@@ -576,6 +678,301 @@ func TestFirewallPlugin_ConsoleMenuProtection(t *testing.T) {
 	}
 }
 
+func TestFirewallPlugin_WebGUIHTTPSOnly(t *testing.T) {
+	fp := firewall.NewPlugin()
+
+	tests := []struct {
+		name          string
+		webGUI        common.WebGUI
+		expectFinding bool
+	}{
+		{
+			name:          "HTTPS with redirect disabled - no finding",
+			webGUI:        common.WebGUI{Protocol: "https", DisableHTTPRedirect: true},
+			expectFinding: false,
+		},
+		{
+			name:          "HTTPS with redirect enabled - finding expected",
+			webGUI:        common.WebGUI{Protocol: "https"},
+			expectFinding: true,
+		},
+		{
+			name:          "HTTP protocol - finding expected",
+			webGUI:        common.WebGUI{Protocol: "http", DisableHTTPRedirect: true},
+			expectFinding: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &common.CommonDevice{System: common.System{WebGUI: tt.webGUI}}
+			assertFindingPresence(t, fp, config, "FIREWALL-068", tt.expectFinding)
+		})
+	}
+}
+
+func TestFirewallPlugin_NetFlowExportDestination(t *testing.T) {
+	fp := firewall.NewPlugin()
+
+	interfaces := []common.Interface{
+		{Name: "wan", Enabled: true, IPAddress: "198.51.100.2", Subnet: "24"},
+		{Name: "dmz", Enabled: true, IPAddress: "203.0.113.1", Subnet: "28"},
+	}
+
+	tests := []struct {
+		name          string
+		collectors    []common.NetflowCollector
+		expectFinding bool
+	}{
+		{
+			name:          "private collector - no finding",
+			collectors:    []common.NetflowCollector{{Address: "10.0.0.5", Port: "2055"}},
+			expectFinding: false,
+		},
+		{
+			name:          "collector in a public internal subnet - no finding",
+			collectors:    []common.NetflowCollector{{Address: "203.0.113.5", Port: "2055"}},
+			expectFinding: false,
+		},
+		{
+			name:          "collector in the WAN subnet - finding expected",
+			collectors:    []common.NetflowCollector{{Address: "198.51.100.9", Port: "2055"}},
+			expectFinding: true,
+		},
+		{
+			name: "one external collector among internal ones - finding expected",
+			collectors: []common.NetflowCollector{
+				{Address: "10.0.0.5", Port: "2055"},
+				{Address: "192.0.2.77", Port: "4739"},
+			},
+			expectFinding: true,
+		},
+		{
+			name:          "hostname collector - no finding",
+			collectors:    []common.NetflowCollector{{Address: "collector.example.com", Port: "2055"}},
+			expectFinding: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &common.CommonDevice{
+				Interfaces: interfaces,
+				Netflow:    &common.NetflowConfig{Enabled: true, Collectors: tt.collectors},
+			}
+			assertFindingPresence(t, fp, config, "FIREWALL-069", tt.expectFinding)
+		})
+	}
+
+	t.Run("export disabled - not evaluated", func(t *testing.T) {
+		config := &common.CommonDevice{Netflow: &common.NetflowConfig{
+			Collectors: []common.NetflowCollector{{Address: "192.0.2.77", Port: "2055"}},
+		}}
+
+		_, evaluated, err := fp.RunChecks(config)
+		require.NoError(t, err)
+		assert.NotContains(t, evaluated, "FIREWALL-069")
+	})
+}
+
+func TestFirewallPlugin_SSHKeysWithoutSSH(t *testing.T) {
+	fp := firewall.NewPlugin()
+
+	const key = "c3NoLWVkMjU1MTkgQUFBQUMzTnphQzFsWkRJMU5URTVBQUFBSU9wcyBvcHNAZXhhbXBsZS5jb20="
+
+	tests := []struct {
+		name          string
+		users         []common.User
+		sshEnabled    bool
+		expectFinding bool
+	}{
+		{
+			name:          "keys with SSH disabled - finding expected",
+			users:         []common.User{{Name: "ops", AuthorizedKeys: key}},
+			expectFinding: true,
+		},
+		{
+			name:          "keys with SSH enabled - no finding",
+			users:         []common.User{{Name: "ops", AuthorizedKeys: key}},
+			sshEnabled:    true,
+			expectFinding: false,
+		},
+		{
+			name:          "keys only on a disabled user - no finding",
+			users:         []common.User{{Name: "ops", Disabled: true, AuthorizedKeys: key}},
+			expectFinding: false,
+		},
+		{
+			name:          "no keys - no finding",
+			users:         []common.User{{Name: "ops"}},
+			expectFinding: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &common.CommonDevice{Users: tt.users}
+			config.System.SSH.Enabled = tt.sshEnabled
+			assertFindingPresence(t, fp, config, "FIREWALL-070", tt.expectFinding)
+		})
+	}
+
+	t.Run("no keys - not evaluated", func(t *testing.T) {
+		config := &common.CommonDevice{Users: []common.User{{Name: "ops"}}}
+
+		_, evaluated, err := fp.RunChecks(config)
+		require.NoError(t, err)
+		assert.NotContains(t, evaluated, "FIREWALL-070")
+	})
+}
+
+func TestFirewallPlugin_TrustedWebGUICertificate(t *testing.T) {
+	fp := firewall.NewPlugin()
+
+	const subject = "CN=fw.example.com"
+	future := time.Now().AddDate(1, 0, 0).Unix()
+	past := time.Now().AddDate(0, 0, -1).Unix()
+
+	tests := []struct {
+		name          string
+		cert          common.Certificate
+		expectFinding bool
+	}{
+		{
+			name:          "self-signed - finding expected",
+			cert:          common.Certificate{Subject: subject, Issuer: subject, NotAfter: future},
+			expectFinding: true,
+		},
+		{
+			name:          "expired CA-issued - finding expected",
+			cert:          common.Certificate{CARef: "ca-1", Subject: subject, Issuer: "CN=Internal CA", NotAfter: past},
+			expectFinding: true,
+		},
+		{
+			name:          "current CA-issued - no finding",
+			cert:          common.Certificate{CARef: "ca-1", Subject: subject, Issuer: "CN=Internal CA", NotAfter: future},
+			expectFinding: false,
+		},
+		{
+			name:          "current imported with external issuer - no finding",
+			cert:          common.Certificate{Subject: subject, Issuer: "CN=Public CA", NotAfter: future},
+			expectFinding: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.cert.RefID = "cert-1"
+			config := &common.CommonDevice{Certificates: []common.Certificate{tt.cert}}
+			config.System.WebGUI.SSLCertRef = "cert-1"
+			assertFindingPresence(t, fp, config, "FIREWALL-071", tt.expectFinding)
+		})
+	}
+
+	for name, config := range map[string]*common.CommonDevice{
+		"no certificate assigned": {
+			Certificates: []common.Certificate{{RefID: "cert-1", Subject: subject, Issuer: subject, NotAfter: past}},
+		},
+		"unresolved reference": {
+			System: common.System{WebGUI: common.WebGUI{SSLCertRef: "missing"}},
+		},
+		"undecoded certificate": {
+			System:       common.System{WebGUI: common.WebGUI{SSLCertRef: "cert-1"}},
+			Certificates: []common.Certificate{{RefID: "cert-1"}},
+		},
+	} {
+		t.Run(name+" - not evaluated", func(t *testing.T) {
+			_, evaluated, err := fp.RunChecks(config)
+			require.NoError(t, err)
+			assert.NotContains(t, evaluated, "FIREWALL-071")
+		})
+	}
+}
+
+func TestFirewallPlugin_MonitAlertDestination(t *testing.T) {
+	fp := firewall.NewPlugin()
+
+	interfaces := []common.Interface{{Name: "lan", Enabled: true, IPAddress: "10.0.0.1", Subnet: "24"}}
+
+	tests := []struct {
+		name          string
+		alerts        []common.MonitAlert
+		expectFinding bool
+	}{
+		{
+			name:          "recipient in the device domain - no finding",
+			alerts:        []common.MonitAlert{{Enabled: true, Recipient: "noc@example.com"}},
+			expectFinding: false,
+		},
+		{
+			name:          "recipient in a subdomain - no finding",
+			alerts:        []common.MonitAlert{{Enabled: true, Recipient: "noc@mail.corp.example.com"}},
+			expectFinding: false,
+		},
+		{
+			name:          "local recipients - no finding",
+			alerts:        []common.MonitAlert{{Enabled: true, Recipient: "root@localhost.local"}, {Enabled: true, Recipient: "root"}},
+			expectFinding: false,
+		},
+		{
+			name:          "home network address literal - no finding",
+			alerts:        []common.MonitAlert{{Enabled: true, Recipient: "ops@[10.0.0.25]"}},
+			expectFinding: false,
+		},
+		{
+			name:          "external recipient - finding expected",
+			alerts:        []common.MonitAlert{{Enabled: true, Recipient: "someone@gmail.com"}},
+			expectFinding: true,
+		},
+		{
+			name:          "lookalike domain - finding expected",
+			alerts:        []common.MonitAlert{{Enabled: true, Recipient: "noc@notexample.com"}},
+			expectFinding: true,
+		},
+		{
+			name: "one external recipient among internal ones - finding expected",
+			alerts: []common.MonitAlert{
+				{Enabled: true, Recipient: "noc@example.com"},
+				{Enabled: true, Recipient: "ops@[192.0.2.10]"},
+			},
+			expectFinding: true,
+		},
+		{
+			name: "external recipient disabled - no finding",
+			alerts: []common.MonitAlert{
+				{Enabled: true, Recipient: "noc@example.com"},
+				{Recipient: "someone@gmail.com"},
+			},
+			expectFinding: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &common.CommonDevice{
+				System:     common.System{Domain: "example.com"},
+				Interfaces: interfaces,
+				Monit:      &common.MonitConfig{Enabled: true, Alerts: tt.alerts},
+			}
+			assertFindingPresence(t, fp, config, "FIREWALL-072", tt.expectFinding)
+		})
+	}
+
+	for name, monit := range map[string]*common.MonitConfig{
+		"monit disabled": {Alerts: []common.MonitAlert{{Enabled: true, Recipient: "someone@gmail.com"}}},
+		"no enabled alerts": {
+			Enabled: true,
+			Alerts:  []common.MonitAlert{{Recipient: "someone@gmail.com"}},
+		},
+	} {
+		t.Run(name+" - not evaluated", func(t *testing.T) {
+			_, evaluated, err := fp.RunChecks(&common.CommonDevice{Monit: monit})
+			require.NoError(t, err)
+			assert.NotContains(t, evaluated, "FIREWALL-072")
+		})
+	}
+}
+
 func TestFirewallPlugin_DefaultCredentialReset(t *testing.T) {
 	fp := firewall.NewPlugin()
 
@@ -637,16 +1034,30 @@ func TestFirewallPlugin_LeastPrivilegeAccess(t *testing.T) {
 		expectFinding bool
 	}{
 		{
-			name: "Group with page-all - finding expected",
+			name: "Non-admin group with page-all - finding expected",
 			config: &common.CommonDevice{
-				Groups: []common.Group{{Name: "admins", Privileges: "page-all"}},
+				Groups: []common.Group{{Name: "operators", Privileges: []string{"page-dashboard-all", "page-all"}}},
 			},
 			expectFinding: true,
 		},
+		{
+			name: "Admin group with page-all - no finding",
+			config: &common.CommonDevice{
+				Groups: []common.Group{{Name: "admins", Privileges: []string{"page-all"}}},
+			},
+			expectFinding: false,
+		},
+		{
+			name: "Privilege names must match exactly - no finding",
+			config: &common.CommonDevice{
+				Groups: []common.Group{{Name: "operators", Privileges: []string{"page-all-dashboards"}}},
+			},
+			expectFinding: false,
+		},
 		{
 			name: "Group with specific privileges - no finding",
 			config: &common.CommonDevice{
-				Groups: []common.Group{{Name: "admins", Privileges: "page-system-config"}},
+				Groups: []common.Group{{Name: "admins", Privileges: []string{"page-system-config"}}},
 			},
 			expectFinding: false,
 		},
@@ -1105,33 +1516,86 @@ func TestFirewallPlugin_NTPConfiguration(t *testing.T) {
 	}
 }
 
-func TestFirewallPlugin_SNMPChecks(t *testing.T) {
+func TestFirewallPlugin_NTPQueryRestriction(t *testing.T) {
 	fp := firewall.NewPlugin()
 
+	servers := []common.NTPServer{{Address: "0.pool.ntp.org", Prefer: true}}
+
 	tests := []struct {
 		name          string
 		config        *common.CommonDevice
-		controlID     string
 		expectFinding bool
 	}{
 		{
-			name:          "No SNMP community - 045 no finding",
-			config:        &common.CommonDevice{SNMP: common.SNMPConfig{}},
-			controlID:     "FIREWALL-045",
+			name: "noquery set - no finding",
+			config: &common.CommonDevice{
+				NTP: common.NTPConfig{Servers: servers, NoQuery: true},
+			},
 			expectFinding: false,
 		},
 		{
-			name:          "SNMP community set - 045 finding expected",
-			config:        &common.CommonDevice{SNMP: common.SNMPConfig{ROCommunity: "mycommunity"}},
-			controlID:     "FIREWALL-045",
+			name: "noquery absent - finding expected",
+			config: &common.CommonDevice{
+				NTP: common.NTPConfig{Servers: servers},
+			},
 			expectFinding: true,
 		},
 		{
-			name:          "Default community public - 046 finding expected",
-			config:        &common.CommonDevice{SNMP: common.SNMPConfig{ROCommunity: "public"}},
-			controlID:     "FIREWALL-046",
-			expectFinding: true,
-		},
+			name: "noquery absent with system time servers - finding expected",
+			config: &common.CommonDevice{
+				System: common.System{TimeServers: []string{"0.pool.ntp.org"}},
+			},
+			expectFinding: true,
+		},
+		{
+			name:          "no NTP servers - no finding",
+			config:        &common.CommonDevice{},
+			expectFinding: false,
+		},
+		{
+			name: "pfSense device - no finding",
+			config: &common.CommonDevice{
+				DeviceType: common.DeviceTypePfSense,
+				NTP:        common.NTPConfig{Servers: servers},
+			},
+			expectFinding: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertFindingPresence(t, fp, tt.config, "FIREWALL-067", tt.expectFinding)
+		})
+	}
+}
+
+func TestFirewallPlugin_SNMPChecks(t *testing.T) {
+	fp := firewall.NewPlugin()
+
+	tests := []struct {
+		name          string
+		config        *common.CommonDevice
+		controlID     string
+		expectFinding bool
+	}{
+		{
+			name:          "No SNMP community - 045 no finding",
+			config:        &common.CommonDevice{SNMP: common.SNMPConfig{}},
+			controlID:     "FIREWALL-045",
+			expectFinding: false,
+		},
+		{
+			name:          "SNMP community set - 045 finding expected",
+			config:        &common.CommonDevice{SNMP: common.SNMPConfig{ROCommunity: "mycommunity"}},
+			controlID:     "FIREWALL-045",
+			expectFinding: true,
+		},
+		{
+			name:          "Default community public - 046 finding expected",
+			config:        &common.CommonDevice{SNMP: common.SNMPConfig{ROCommunity: "public"}},
+			controlID:     "FIREWALL-046",
+			expectFinding: true,
+		},
 		{
 			name:          "Default community private - 046 finding expected",
 			config:        &common.CommonDevice{SNMP: common.SNMPConfig{ROCommunity: "private"}},
@@ -1212,6 +1676,65 @@ func TestFirewallPlugin_StrongVPNEncryption(t *testing.T) {
 			},
 			expectFinding: false,
 		},
+		{
+			name: "Weak swanctl IKE proposal - finding expected",
+			config: &common.CommonDevice{
+				VPN: common.VPN{IPsec: common.IPsecConfig{
+					Enabled: true,
+					Connections: []common.IPsecConnection{
+						{
+							Origin:    common.IPsecOriginSwanctl,
+							Proposals: []string{"aes256-sha256-modp2048", "3des-sha1-modp1024"},
+						},
+					},
+				}},
+			},
+			expectFinding: true,
+		},
+		{
+			name: "Weak swanctl ESP proposal on child SA - finding expected",
+			config: &common.CommonDevice{
+				VPN: common.VPN{IPsec: common.IPsecConfig{
+					Enabled: true,
+					Connections: []common.IPsecConnection{
+						{
+							Origin:    common.IPsecOriginSwanctl,
+							Proposals: []string{"aes256gcm16-sha256-modp2048"},
+							Children:  []common.IPsecChildSA{{Proposals: []string{"blowfish128-sha256"}}},
+						},
+					},
+				}},
+			},
+			expectFinding: true,
+		},
+		{
+			name: "Strong swanctl proposals - no finding",
+			config: &common.CommonDevice{
+				VPN: common.VPN{IPsec: common.IPsecConfig{
+					Enabled: true,
+					Connections: []common.IPsecConnection{
+						{
+							Origin:    common.IPsecOriginSwanctl,
+							Proposals: []string{"aes256gcm16-sha256-modp2048"},
+							Children:  []common.IPsecChildSA{{Proposals: []string{"aes256gcm16-modp2048"}}},
+						},
+					},
+				}},
+			},
+			expectFinding: false,
+		},
+		{
+			name: "Disabled swanctl connection ignored - no finding",
+			config: &common.CommonDevice{
+				VPN: common.VPN{IPsec: common.IPsecConfig{
+					Enabled: true,
+					Connections: []common.IPsecConnection{
+						{Origin: common.IPsecOriginSwanctl, Disabled: true, Proposals: []string{"des-md5-modp768"}},
+					},
+				}},
+			},
+			expectFinding: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -1253,6 +1776,30 @@ func TestFirewallPlugin_StrongVPNIntegrity(t *testing.T) {
 			},
 			expectFinding: false,
 		},
+		{
+			name: "Weak swanctl proposal hash sha1 - finding expected",
+			config: &common.CommonDevice{
+				VPN: common.VPN{IPsec: common.IPsecConfig{
+					Enabled: true,
+					Connections: []common.IPsecConnection{
+						{Origin: common.IPsecOriginSwanctl, Proposals: []string{"aes256-sha1-modp2048"}},
+					},
+				}},
+			},
+			expectFinding: true,
+		},
+		{
+			name: "Strong swanctl proposal hash sha256 - no finding",
+			config: &common.CommonDevice{
+				VPN: common.VPN{IPsec: common.IPsecConfig{
+					Enabled: true,
+					Connections: []common.IPsecConnection{
+						{Origin: common.IPsecOriginSwanctl, Proposals: []string{"aes256-sha256-modp2048"}},
+					},
+				}},
+			},
+			expectFinding: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -1723,6 +2270,230 @@ func TestFirewallPlugin_HAConfiguration(t *testing.T) {
 	}
 }
 
+func TestFirewallPlugin_WakeOnLANExposure(t *testing.T) {
+	fp := firewall.NewPlugin()
+
+	interfaces := []common.Interface{
+		{Name: "wan", Enabled: true},
+		{Name: "lan", Enabled: true},
+		{Name: "wan2", Enabled: false},
+	}
+
+	tests := []struct {
+		name          string
+		config        *common.CommonDevice
+		expectFinding bool
+	}{
+		{
+			name: "WOL host on WAN - finding expected",
+			config: &common.CommonDevice{
+				Interfaces: interfaces,
+				WakeOnLAN: []common.WakeOnLANEntry{
+					{Interface: "lan", MAC: "00:11:22:33:44:55"},
+					{Interface: "wan", MAC: "66:77:88:99:aa:bb"},
+				},
+			},
+			expectFinding: true,
+		},
+		{
+			name: "WOL host on unlisted WAN-named interface - finding expected",
+			config: &common.CommonDevice{
+				WakeOnLAN: []common.WakeOnLANEntry{{Interface: "WAN", MAC: "66:77:88:99:aa:bb"}},
+			},
+			expectFinding: true,
+		},
+		{
+			name: "WOL hosts on LAN only - no finding",
+			config: &common.CommonDevice{
+				Interfaces: interfaces,
+				WakeOnLAN:  []common.WakeOnLANEntry{{Interface: "lan", MAC: "00:11:22:33:44:55"}},
+			},
+			expectFinding: false,
+		},
+		{
+			name: "WOL host on disabled WAN interface - no finding",
+			config: &common.CommonDevice{
+				Interfaces: interfaces,
+				WakeOnLAN:  []common.WakeOnLANEntry{{Interface: "wan2", MAC: "00:11:22:33:44:55"}},
+			},
+			expectFinding: false,
+		},
+		{
+			name:          "no WOL hosts - no finding",
+			config:        &common.CommonDevice{Interfaces: interfaces},
+			expectFinding: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertFindingPresence(t, fp, tt.config, "FIREWALL-065", tt.expectFinding)
+		})
+	}
+}
+
+func TestFirewallPlugin_UPnPDisabled(t *testing.T) {
+	fp := firewall.NewPlugin()
+
+	tests := []struct {
+		name          string
+		config        *common.CommonDevice
+		expectFinding bool
+	}{
+		{
+			name:          "UPnP enabled - finding expected",
+			config:        &common.CommonDevice{UPnP: &common.UPnPConfig{Enabled: true, UPnPEnabled: true}},
+			expectFinding: true,
+		},
+		{
+			name:          "UPnP configured but disabled - no finding",
+			config:        &common.CommonDevice{UPnP: &common.UPnPConfig{UPnPEnabled: true}},
+			expectFinding: false,
+		},
+		{
+			name:          "no UPnP settings - no finding",
+			config:        &common.CommonDevice{},
+			expectFinding: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertFindingPresence(t, fp, tt.config, "FIREWALL-057", tt.expectFinding)
+		})
+	}
+}
+
+func TestFirewallPlugin_OpenVPNCRLValidity(t *testing.T) {
+	fp := firewall.NewPlugin()
+
+	expired := common.CRL{RefID: "crl-old", IssuedAt: time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC).Unix(), Lifetime: 30}
+	current := common.CRL{RefID: "crl-new", IssuedAt: time.Now().Unix(), Lifetime: 365}
+	servers := func(refs ...string) common.VPN {
+		var vpn common.VPN
+		for _, ref := range refs {
+			vpn.OpenVPN.Servers = append(vpn.OpenVPN.Servers, common.OpenVPNServer{CRLRef: ref})
+		}
+		return vpn
+	}
+
+	tests := []struct {
+		name          string
+		config        *common.CommonDevice
+		expectFinding bool
+	}{
+		{
+			name:          "server references expired CRL - finding expected",
+			config:        &common.CommonDevice{CRLs: []common.CRL{expired, current}, VPN: servers("crl-new", "crl-old")},
+			expectFinding: true,
+		},
+		{
+			name:          "server references current CRL - no finding",
+			config:        &common.CommonDevice{CRLs: []common.CRL{expired, current}, VPN: servers("crl-new")},
+			expectFinding: false,
+		},
+		{
+			name:          "expired CRL not referenced - no finding",
+			config:        &common.CommonDevice{CRLs: []common.CRL{expired}, VPN: servers("")},
+			expectFinding: false,
+		},
+		{
+			name: "CRL without lifetime - no finding",
+			config: &common.CommonDevice{
+				CRLs: []common.CRL{{RefID: "crl-old", IssuedAt: expired.IssuedAt}},
+				VPN:  servers("crl-old"),
+			},
+			expectFinding: false,
+		},
+		{
+			name: "signed nextUpdate passed despite long lifetime - finding expected",
+			config: &common.CommonDevice{
+				CRLs: []common.CRL{{
+					RefID:      "crl-new",
+					IssuedAt:   current.IssuedAt,
+					Lifetime:   365,
+					NextUpdate: time.Now().AddDate(0, 0, -1).Unix(),
+				}},
+				VPN: servers("crl-new"),
+			},
+			expectFinding: true,
+		},
+		{
+			name: "undecodable CRL with revocations - no finding",
+			config: &common.CommonDevice{
+				CRLs: []common.CRL{{
+					RefID:    "crl-old",
+					Lifetime: 30,
+					Revoked:  []common.RevokedCertificate{{RefID: "cert-1", RevokedAt: expired.IssuedAt}},
+				}},
+				VPN: servers("crl-old"),
+			},
+			expectFinding: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertFindingPresence(t, fp, tt.config, "FIREWALL-066", tt.expectFinding)
+		})
+	}
+}
+
+// TestFirewallPlugin_SkipReasons verifies that checks which cannot run on a
+// device report why, and that evaluated checks report no outcome.
+func TestFirewallPlugin_SkipReasons(t *testing.T) {
+	t.Parallel()
+
+	fp := firewall.NewPlugin()
+
+	tests := []struct {
+		name   string
+		config *common.CommonDevice
+		want   compliance.CheckOutcome
+		known  bool
+	}{
+		{
+			name:   "no OpenVPN servers",
+			config: &common.CommonDevice{},
+			want:   compliance.CheckOutcome{Status: compliance.CheckStatusSkipped, Reason: "no OpenVPN servers configured"},
+		},
+		{
+			name: "OpenVPN server without a CRL",
+			config: &common.CommonDevice{VPN: common.VPN{OpenVPN: common.OpenVPNConfig{
+				Servers: []common.OpenVPNServer{{VPNID: "1"}},
+			}}},
+			want: compliance.CheckOutcome{
+				Status: compliance.CheckStatusSkipped,
+				Reason: "no OpenVPN server uses a CRL with a known expiry date",
+			},
+		},
+		{
+			name: "OpenVPN server with a current CRL",
+			config: &common.CommonDevice{
+				CRLs: []common.CRL{{RefID: "crl", IssuedAt: time.Now().Unix(), Lifetime: 365}},
+				VPN: common.VPN{OpenVPN: common.OpenVPNConfig{
+					Servers: []common.OpenVPNServer{{CRLRef: "crl"}},
+				}},
+			},
+			known: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, evaluated, outcomes, err := fp.RunChecksWithOutcomes(tt.config)
+			require.NoError(t, err)
+
+			outcome, reported := outcomes["FIREWALL-066"]
+			assert.Equal(t, tt.known, slices.Contains(evaluated, "FIREWALL-066"))
+			assert.Equal(t, !tt.known, reported)
+			assert.Equal(t, tt.want, outcome)
+		})
+	}
+}
+
 func TestFirewallPlugin_DisabledRuleCleanup(t *testing.T) {
 	fp := firewall.NewPlugin()
 
@@ -1795,6 +2566,7 @@ func TestFirewallPlugin_RunChecks_ReturnsEvaluatedControlIDs(t *testing.T) {
 		"FIREWALL-022", "FIREWALL-029", "FIREWALL-030",
 		"FIREWALL-033", "FIREWALL-034",
 		"FIREWALL-036", "FIREWALL-039",
+		"FIREWALL-057", "FIREWALL-065",
 	} {
 		assert.Contains(t, evaluated, id, "Expected %s to be evaluable", id)
 	}
@@ -1807,9 +2579,11 @@ func TestFirewallPlugin_RunChecks_ReturnsEvaluatedControlIDs(t *testing.T) {
 		"FIREWALL-012", "FIREWALL-013", "FIREWALL-015",
 		"FIREWALL-019", "FIREWALL-035",
 		"FIREWALL-037", "FIREWALL-038",
-		"FIREWALL-057", "FIREWALL-059", "FIREWALL-060",
+		"FIREWALL-059", "FIREWALL-060",
+		// No OpenVPN server references a CRL on the test device.
+		"FIREWALL-066",
 		// Inventory controls are intentionally excluded from compliance evaluation.
-		"FIREWALL-062", "FIREWALL-063",
+		"FIREWALL-062", "FIREWALL-063", "FIREWALL-064",
 	} {
 		assert.NotContains(t, evaluated, id, "Expected %s to be unknown/not evaluated", id)
 	}
@@ -1852,3 +2626,211 @@ func getFindings(findings []compliance.Finding) []string {
 
 	return ids
 }
+
+func TestFirewallPlugin_ZeroTierInterfaceRules(t *testing.T) {
+	fp := firewall.NewPlugin()
+
+	interfaces := []common.Interface{
+		{Name: "lan", PhysicalIf: "igb1", Enabled: true},
+		{Name: "opt2", PhysicalIf: "ztc3q6qi7r", Enabled: true},
+	}
+
+	tests := []struct {
+		name          string
+		rules         []common.FirewallRule
+		expectFinding bool
+	}{
+		{
+			name:          "rule on the ZeroTier interface - no finding",
+			rules:         []common.FirewallRule{{Type: common.RuleTypePass, Interfaces: []string{"opt2"}}},
+			expectFinding: false,
+		},
+		{
+			name:          "rules on other interfaces only - finding expected",
+			rules:         []common.FirewallRule{{Type: common.RuleTypePass, Interfaces: []string{"lan"}}},
+			expectFinding: true,
+		},
+		{
+			name: "disabled rule on the ZeroTier interface - finding expected",
+			rules: []common.FirewallRule{
+				{Type: common.RuleTypePass, Interfaces: []string{"opt2"}, Disabled: true},
+			},
+			expectFinding: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &common.CommonDevice{
+				Interfaces:    interfaces,
+				FirewallRules: tt.rules,
+				VPN:           common.VPN{ZeroTier: &common.ZeroTierConfig{Enabled: true}},
+			}
+			assertFindingPresence(t, fp, config, "FIREWALL-073", tt.expectFinding)
+		})
+	}
+
+	t.Run("no assigned ZeroTier interface - finding expected", func(t *testing.T) {
+		config := &common.CommonDevice{
+			Interfaces:    interfaces[:1],
+			FirewallRules: []common.FirewallRule{{Type: common.RuleTypePass, Interfaces: []string{"lan"}}},
+			VPN:           common.VPN{ZeroTier: &common.ZeroTierConfig{Enabled: true}},
+		}
+		assertFindingPresence(t, fp, config, "FIREWALL-073", true)
+	})
+
+	for name, zt := range map[string]*common.ZeroTierConfig{
+		"ZeroTier absent":   nil,
+		"ZeroTier disabled": {Networks: []common.ZeroTierNetwork{{Enabled: true, NetworkID: "8056c2e21c000001"}}},
+	} {
+		t.Run(name+" - not evaluated", func(t *testing.T) {
+			_, evaluated, err := fp.RunChecks(&common.CommonDevice{VPN: common.VPN{ZeroTier: zt}})
+			require.NoError(t, err)
+			assert.NotContains(t, evaluated, "FIREWALL-073")
+		})
+	}
+}
+
+func TestFirewallPlugin_SerialConsoleVirtualDeployment(t *testing.T) {
+	t.Parallel()
+
+	fp := firewall.NewPlugin()
+
+	tests := []struct {
+		name          string
+		firmware      string
+		console       common.Console
+		expectFinding bool
+	}{
+		{
+			name:          "serial console on amd64 image - finding expected",
+			firmware:      "25.1-amd64",
+			console:       common.Console{PrimaryConsole: common.PrimaryConsoleSerial, SerialSpeed: 115200},
+			expectFinding: true,
+		},
+		{
+			name:          "video console on amd64 image - no finding",
+			firmware:      "25.1-amd64",
+			console:       common.Console{PrimaryConsole: common.PrimaryConsoleVideo},
+			expectFinding: false,
+		},
+		{
+			name:          "serial console on arm64 appliance - no finding",
+			firmware:      "25.1-aarch64",
+			console:       common.Console{PrimaryConsole: common.PrimaryConsoleSerial},
+			expectFinding: false,
+		},
+		{
+			name:          "default console - no finding",
+			firmware:      "25.1-amd64",
+			expectFinding: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			config := &common.CommonDevice{
+				System: common.System{Firmware: common.Firmware{Version: tt.firmware}, Console: tt.console},
+			}
+			assertFindingPresence(t, fp, config, "FIREWALL-074", tt.expectFinding)
+		})
+	}
+
+	t.Run("serial console without firmware version - not evaluated", func(t *testing.T) {
+		t.Parallel()
+
+		config := &common.CommonDevice{
+			System: common.System{Console: common.Console{PrimaryConsole: common.PrimaryConsoleSerial}},
+		}
+		_, evaluated, err := fp.RunChecks(config)
+		require.NoError(t, err)
+		assert.NotContains(t, evaluated, "FIREWALL-074")
+	})
+}
+
+func TestFirewallPlugin_AccountExpiry(t *testing.T) {
+	t.Parallel()
+
+	fp := firewall.NewPlugin()
+
+	// expiring returns user with an expiry date offset days from today.
+	expiring := func(user common.User, offset int) common.User {
+		now := time.Now().UTC()
+		day := time.Date(now.Year(), now.Month(), now.Day()+offset, 0, 0, 0, 0, time.UTC)
+		user.Expires = day.Format(shared.ExpiryDateLayout)
+		user.ExpiryDate, user.HasExpiryDate = day.Unix(), true
+
+		return user
+	}
+
+	tests := []struct {
+		name           string
+		users          []common.User
+		expectExpired  bool
+		expectExpiring bool
+	}{
+		{
+			name:          "enabled account past its expiry date - medium finding",
+			users:         []common.User{expiring(common.User{Name: "contractor"}, -10)},
+			expectExpired: true,
+		},
+		{
+			name:  "disabled account past its expiry date - no finding",
+			users: []common.User{expiring(common.User{Name: "contractor", Disabled: true}, -10)},
+		},
+		{
+			name:           "enabled account expiring in two weeks - info finding",
+			users:          []common.User{expiring(common.User{Name: "contractor"}, 14)},
+			expectExpiring: true,
+		},
+		{
+			name:           "enabled account expiring today - info finding",
+			users:          []common.User{expiring(common.User{Name: "contractor"}, 0)},
+			expectExpiring: true,
+		},
+		{
+			name:  "disabled account expiring in two weeks - no finding",
+			users: []common.User{expiring(common.User{Name: "contractor", Disabled: true}, 14)},
+		},
+		{
+			name:  "account expiring in three months - no finding",
+			users: []common.User{expiring(common.User{Name: "contractor"}, 90)},
+		},
+		{
+			name:  "account without an expiry date - no finding",
+			users: []common.User{{Name: "ops"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			config := &common.CommonDevice{Users: tt.users}
+			assertFindingPresence(t, fp, config, "FIREWALL-075", tt.expectExpired)
+			assertFindingPresence(t, fp, config, "FIREWALL-076", tt.expectExpiring)
+		})
+	}
+
+	t.Run("findings carry the control severity", func(t *testing.T) {
+		t.Parallel()
+
+		config := &common.CommonDevice{Users: []common.User{
+			expiring(common.User{Name: "former"}, -1),
+			expiring(common.User{Name: "contractor"}, 7),
+		}}
+		findings, evaluated, err := fp.RunChecks(config)
+		require.NoError(t, err)
+		assert.Contains(t, evaluated, "FIREWALL-075")
+		assert.Contains(t, evaluated, "FIREWALL-076")
+
+		severities := make(map[string]string)
+		for _, f := range findings {
+			severities[f.Reference] = f.Severity
+		}
+		assert.Equal(t, "medium", severities["FIREWALL-075"])
+		assert.Equal(t, "info", severities["FIREWALL-076"])
+	})
+}
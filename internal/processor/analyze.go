@@ -1,46 +1,157 @@
 package processor
 
 import (
+	"cmp"
 	"context"
 	"fmt"
+	"log/slog"
+	"maps"
+	"net/netip"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/EvilBit-Labs/opnDossier/internal/analysis"
 	"github.com/EvilBit-Labs/opnDossier/internal/constants"
+	"github.com/EvilBit-Labs/opnDossier/internal/progress"
 	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
 )
 
-// analyze performs comprehensive analysis of the device configuration based on enabled options.
-func (p *CoreProcessor) analyze(_ context.Context, cfg *common.CommonDevice, config *Config, report *Report) {
+// analysisPass is a single named analysis step run by analyze.
+type analysisPass struct {
+	name string
+	run  func(cfg *common.CommonDevice, report *Report)
+}
+
+// passRunner runs analysis passes against cfg, adding their findings to
+// report in pass order and reporting each finished pass to tracker as one
+// step.
+type passRunner func(
+	ctx context.Context,
+	cfg *common.CommonDevice,
+	passes []analysisPass,
+	report *Report,
+	logger *slog.Logger,
+	tracker progress.Tracker,
+)
+
+// analyze performs comprehensive analysis of the device configuration based on
+// enabled options, running the passes with run. The number of enabled passes
+// is set as the total of config.Progress.
+func (p *CoreProcessor) analyze(
+	ctx context.Context,
+	cfg *common.CommonDevice,
+	config *Config,
+	report *Report,
+	logger *slog.Logger,
+	run passRunner,
+) {
+	passes := p.analysisPasses(config)
+	if len(passes) == 0 {
+		return
+	}
+
+	tracker := config.tracker()
+	tracker.SetTotal(int64(len(passes)))
+
+	run(ctx, cfg, passes, report, logger, tracker)
+}
+
+// analysisPasses returns the analysis passes enabled by config, in report
+// order. Passes only read cfg and only write to report through AddFinding, so
+// they are independent of one another.
+func (p *CoreProcessor) analysisPasses(config *Config) []analysisPass {
+	var passes []analysisPass
+
 	// Dead rule detection
 	if config.EnableDeadRuleCheck {
-		p.analyzeDeadRules(cfg, report)
+		passes = append(passes, analysisPass{name: "dead rules", run: p.analyzeDeadRules})
 	}
 
 	// Unused interfaces analysis
 	if config.EnableSecurityAnalysis || config.EnableComplianceCheck {
-		p.analyzeUnusedInterfaces(cfg, report)
+		passes = append(passes,
+			analysisPass{name: "unused interfaces", run: p.analyzeUnusedInterfaces},
+			analysisPass{name: "interface references", run: p.analyzeDanglingInterfaceReferences},
+		)
+	}
+
+	// Address plan collisions
+	if config.EnableSecurityAnalysis || config.EnableComplianceCheck {
+		passes = append(passes, analysisPass{name: "address plan", run: p.analyzeAddressPlan})
 	}
 
 	// Consistency checks
 	if config.EnableComplianceCheck {
-		p.analyzeConsistency(cfg, report)
+		passes = append(passes, analysisPass{name: "consistency", run: p.analyzeConsistency})
 	}
 
 	// Security analysis
 	if config.EnableSecurityAnalysis {
-		p.analyzeSecurityIssues(cfg, report)
+		passes = append(passes,
+			analysisPass{name: "security", run: p.analyzeSecurityIssues},
+			analysisPass{name: "ipsec crypto", run: func(cfg *common.CommonDevice, report *Report) {
+				checkIPsecPhase1Crypto(cfg, report, config.WeakIPsecAlgorithms)
+				checkIPsecPhase2Crypto(cfg, report, config.WeakIPsecAlgorithms)
+			}},
+			analysisPass{name: "wan filtering", run: func(cfg *common.CommonDevice, report *Report) {
+				checkBlockPrivateOnWAN(cfg, report, config.PerimeterInterfaces)
+			}},
+		)
 	}
 
 	// Performance analysis
 	if config.EnablePerformanceAnalysis {
-		p.analyzePerformanceIssues(cfg, report)
+		passes = append(passes, analysisPass{name: "performance", run: p.analyzePerformanceIssues})
 	}
+
+	return passes
+}
+
+// runPassesSequentially is the default passRunner. Each pass is reported to
+// tracker as one step and logged at Info with its name, the firewall rule
+// count, and the findings it added.
+func runPassesSequentially(
+	ctx context.Context,
+	cfg *common.CommonDevice,
+	passes []analysisPass,
+	report *Report,
+	logger *slog.Logger,
+	tracker progress.Tracker,
+) {
+	for _, pass := range passes {
+		before := report.TotalFindings()
+		pass.run(cfg, report)
+		tracker.Step(1, analysisStepMessage(pass))
+
+		logPassComplete(ctx, logger, pass, cfg, report.TotalFindings()-before)
+	}
+}
+
+// analysisStepMessage is the progress message reported when pass finishes.
+func analysisStepMessage(pass analysisPass) string {
+	return "Analyzing: " + pass.name
+}
+
+// logPassComplete logs the completion of pass, which added findings findings.
+func logPassComplete(
+	ctx context.Context,
+	logger *slog.Logger,
+	pass analysisPass,
+	cfg *common.CommonDevice,
+	findings int,
+) {
+	logger.InfoContext(ctx, "analysis pass complete",
+		"pass", pass.name,
+		"rules", len(cfg.FirewallRules),
+		"findings", findings,
+	)
 }
 
 // analyzeDeadRules detects firewall rules that are never hit or are effectively dead.
 // It delegates to analysis.DetectDeadRules for block-all and duplicate detection,
-// then checks for processor-specific overly broad pass rules.
+// then checks for processor-specific overly broad pass rules and rules made
+// redundant by an overlapping source network.
 func (p *CoreProcessor) analyzeDeadRules(cfg *common.CommonDevice, report *Report) {
 	deadRules := analysis.DetectDeadRules(cfg)
 	for _, f := range deadRules {
@@ -66,6 +177,9 @@ func (p *CoreProcessor) analyzeDeadRules(cfg *common.CommonDevice, report *Repor
 
 	// Processor-specific check: overly broad pass rules without description
 	checkBroadPassRules(cfg, report)
+
+	// Processor-specific check: same-type rules whose source networks overlap
+	checkOverlappingSourceCIDRs(cfg, report)
 }
 
 // checkBroadPassRules detects pass rules with any source and no description,
@@ -86,15 +200,17 @@ func checkBroadPassRules(cfg *common.CommonDevice, report *Report) {
 					),
 					Component:      fmt.Sprintf("filter.rule[%d]", i),
 					Recommendation: "Add description and consider restricting source or destination",
+					Metadata:       map[string]string{metadataInterface: iface},
 				})
 			}
 		}
 	}
 }
 
-// analyzeUnusedInterfaces detects interfaces that are defined but not used in rules or services.
+// analyzeUnusedInterfaces detects interfaces that are defined but not used in
+// rules or services, as recorded in the interface cross-reference index.
 func (p *CoreProcessor) analyzeUnusedInterfaces(cfg *common.CommonDevice, report *Report) {
-	unused := analysis.DetectUnusedInterfaces(cfg)
+	unused := BuildInterfaceIndex(cfg).UnusedInterfaces(cfg.Interfaces)
 	for _, f := range unused {
 		report.AddFinding(SeverityLow, Finding{
 			Type:           "unused-interface",
@@ -106,6 +222,43 @@ func (p *CoreProcessor) analyzeUnusedInterfaces(cfg *common.CommonDevice, report
 	}
 }
 
+// analyzeDanglingInterfaceReferences reports every firewall rule, NAT rule,
+// DHCP scope, VPN instance, gateway, service, and interface group member that
+// names an interface missing from the configuration, as recorded in the
+// interface cross-reference index. This is the reverse of the unused-interface
+// check: typically a typo or a binding left behind when an interface was
+// deleted.
+func (p *CoreProcessor) analyzeDanglingInterfaceReferences(cfg *common.CommonDevice, report *Report) {
+	dangling := BuildInterfaceIndex(cfg).DanglingReferences(cfg.Interfaces, cfg.InterfaceGroups)
+	for _, ref := range dangling {
+		report.AddFinding(SeverityMedium, Finding{
+			Type:  "dangling-interface-reference",
+			Title: "Reference to Undefined Interface",
+			Description: fmt.Sprintf(
+				"Interface %s is referenced by %s but is not defined in the configuration",
+				ref.Interface, ref.Referrer,
+			),
+			Component:      "interfaces." + ref.Interface,
+			Recommendation: "Correct the interface name or remove the stale reference",
+		})
+	}
+}
+
+// analyzeAddressPlan detects overlapping interface subnets, duplicate
+// interface addresses, and virtual IPs, gateways, and port forwards that
+// collide with the interface address plan.
+func (p *CoreProcessor) analyzeAddressPlan(cfg *common.CommonDevice, report *Report) {
+	for _, f := range analysis.DetectAddressPlanIssues(cfg) {
+		report.AddFinding(mapSeverity(f.Severity), Finding{
+			Type:           "address-plan",
+			Title:          f.Issue,
+			Description:    f.Description,
+			Component:      f.Component,
+			Recommendation: f.Recommendation,
+		})
+	}
+}
+
 // analyzeConsistency performs consistency checks across the configuration.
 func (p *CoreProcessor) analyzeConsistency(cfg *common.CommonDevice, report *Report) {
 	issues := analysis.DetectConsistency(cfg)
@@ -118,6 +271,323 @@ func (p *CoreProcessor) analyzeConsistency(cfg *common.CommonDevice, report *Rep
 			Recommendation: f.Recommendation,
 		})
 	}
+
+	// Processor-specific check: duplicate MAC or IP addresses in static leases
+	checkDHCPStaticLeaseUniqueness(cfg, report)
+
+	// Processor-specific check: DHCP ranges reaching outside the interface subnet
+	checkDHCPRangeSubnetBoundary(cfg, report)
+
+	// Processor-specific check: rule endpoints naming aliases that do not exist
+	checkUndefinedAliases(cfg, report)
+
+	// Processor-specific check: OpenVPN servers on the commonly filtered default port
+	checkOpenVPNPortReachability(cfg, report)
+
+	// Processor-specific check: exhausted or stale UID and GID counters
+	checkUIDAllocation(cfg, report)
+
+	// Processor-specific check: NAT rules translating to non-unicast addresses
+	checkNATTargetAddresses(cfg, report)
+}
+
+// openVPNDefaultPort is the IANA-assigned OpenVPN port, which OpenVPN also
+// listens on when no local port is configured.
+const openVPNDefaultPort = "1194"
+
+// checkOpenVPNPortReachability detects OpenVPN servers that clients may fail
+// to reach from restrictive networks, where outbound traffic to 1194/UDP is
+// commonly blocked. A server without a local port is Medium, since it
+// silently listens on 1194; a server explicitly set to 1194 over UDP is Info.
+// Servers on any other port, or on 1194 over TCP, are not reported.
+func checkOpenVPNPortReachability(cfg *common.CommonDevice, report *Report) {
+	for i, server := range cfg.VPN.OpenVPN.Servers {
+		name := cmp.Or(server.Description, "VPN ID "+server.VPNID)
+		component := fmt.Sprintf("openvpn.server[%d]", i)
+		recommendation := "Consider listening on 443/TCP, which restrictive networks rarely block, " +
+			"for clients that connect from hotel, guest, or corporate networks"
+
+		switch {
+		case server.LocalPort == "":
+			report.AddFinding(SeverityMedium, Finding{
+				Type:  "reachability",
+				Title: "OpenVPN Server Without Local Port",
+				Description: fmt.Sprintf(
+					"OpenVPN server %q has no local port set and listens on the default %s, "+
+						"which networks that filter outbound VPN traffic commonly block",
+					name, openVPNDefaultPort,
+				),
+				Component:      component,
+				Recommendation: "Set the local port explicitly. " + recommendation,
+			})
+		case server.LocalPort == openVPNDefaultPort && !strings.HasPrefix(strings.ToLower(server.Protocol), "tcp"):
+			report.AddFinding(SeverityInfo, Finding{
+				Type:  "reachability",
+				Title: "OpenVPN Server on Default Port",
+				Description: fmt.Sprintf(
+					"OpenVPN server %q listens on %s/UDP, which networks that filter outbound VPN traffic commonly block",
+					name, openVPNDefaultPort,
+				),
+				Component:      component,
+				Recommendation: recommendation,
+			})
+		}
+	}
+}
+
+// reservedNetworkNames are rule address keywords that OPNsense resolves itself
+// and that are therefore never alias references.
+var reservedNetworkNames = []string{constants.NetworkAny, "lan", "wan", "localhost", "loopback", "(self)"}
+
+// builtinInterfaceGroups are the interfaces and interface groups OPNsense
+// creates for its VPN services; rules may use them as network macros without
+// a matching entry in the interfaces or interface groups sections.
+var builtinInterfaceGroups = []string{"enc0", "ipsec", "openvpn", "wireguard"}
+
+// builtinAliasNames are the pf tables OPNsense defines implicitly; rules may
+// reference them without a matching entry in the alias section.
+var builtinAliasNames = []string{"bogons", "bogonsv6", "sshlockout", "virusprot"}
+
+// checkUndefinedAliases detects firewall rule source or destination addresses
+// that name an alias missing from the device's alias table. An address is
+// treated as an alias reference when it is not an IP address, CIDR, or IP
+// range, not a reserved keyword, and not a network macro for an interface or
+// interface group (e.g. "opt1", "lanip", or "openvpn"); see
+// looksLikeAliasName. Every alias in the table counts as defined, including
+// URL table and GeoIP aliases whose entries are only fetched at runtime. A
+// rule referencing an undefined table may fail to load, so the finding is
+// High severity.
+func checkUndefinedAliases(cfg *common.CommonDevice, report *Report) {
+	interfaceNames := networkMacroNames(cfg)
+
+	for i, rule := range cfg.FirewallRules {
+		for _, endpoint := range []struct {
+			label    string
+			endpoint common.RuleEndpoint
+		}{
+			{"source", rule.Source},
+			{"destination", rule.Destination},
+		} {
+			if endpoint.endpoint.AddressRef != nil {
+				continue
+			}
+
+			name := strings.TrimSpace(endpoint.endpoint.Address)
+			if !looksLikeAliasName(name, interfaceNames) {
+				continue
+			}
+
+			if _, ok := cfg.NamedObjects[name]; ok {
+				continue
+			}
+
+			report.AddFinding(SeverityHigh, Finding{
+				Type:  "undefined-alias",
+				Title: "Firewall Rule References Undefined Alias",
+				Description: fmt.Sprintf(
+					"Rule at position %d uses %s address %q, which is not a defined alias",
+					i+1, endpoint.label, name,
+				),
+				Component:      fmt.Sprintf("filter.rule[%d]", i),
+				Recommendation: "Create the missing alias or update the rule to reference an existing alias or address",
+			})
+		}
+	}
+}
+
+// networkMacroNames returns the names a rule address may use to refer to an
+// interface's network: the device's logical interfaces, its interface groups,
+// the reserved keywords, and the built-in VPN interface groups.
+func networkMacroNames(cfg *common.CommonDevice) map[string]struct{} {
+	names := make(map[string]struct{},
+		len(cfg.Interfaces)+len(cfg.InterfaceGroups)+len(reservedNetworkNames)+len(builtinInterfaceGroups))
+
+	for _, iface := range cfg.Interfaces {
+		names[iface.Name] = struct{}{}
+	}
+	for _, group := range cfg.InterfaceGroups {
+		names[group.Name] = struct{}{}
+	}
+	for _, name := range slices.Concat(reservedNetworkNames, builtinInterfaceGroups) {
+		names[name] = struct{}{}
+	}
+
+	return names
+}
+
+// looksLikeAliasName reports whether a rule address can only be interpreted as
+// an alias name. interfaceNames holds the network macro names from
+// networkMacroNames, so interface and group networks and their "ip" address
+// variants are excluded. IP addresses, CIDRs, and ranges always contain '.',
+// ':', '/', or '-', none of which OPNsense permits in an alias name, so the
+// character check rejects them.
+func looksLikeAliasName(address string, interfaceNames map[string]struct{}) bool {
+	if address == "" || isInterfaceMacro(address, interfaceNames) {
+		return false
+	}
+
+	if base, ok := strings.CutSuffix(address, "ip"); ok && isInterfaceMacro(base, interfaceNames) {
+		return false
+	}
+
+	// OPNsense-generated aliases (e.g. "__lan_network") and the implicit pf
+	// tables always exist.
+	if strings.HasPrefix(address, "__") || slices.Contains(builtinAliasNames, address) {
+		return false
+	}
+
+	for i, r := range address {
+		isLetter := (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || r == '_'
+		if !isLetter && (i == 0 || r < '0' || r > '9') {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isInterfaceMacro reports whether name is one of interfaceNames or an optN
+// interface that the configuration does not define.
+func isInterfaceMacro(name string, interfaceNames map[string]struct{}) bool {
+	if _, ok := interfaceNames[name]; ok {
+		return true
+	}
+
+	digits, ok := strings.CutPrefix(name, "opt")
+	if !ok || digits == "" {
+		return false
+	}
+
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// checkDHCPStaticLeaseUniqueness detects static leases within a single DHCP
+// scope that share a MAC address or an IP address. Duplicate MACs make the
+// address a client receives depend on which mapping the server matches first;
+// duplicate IPs hand the same address to two clients. MAC addresses are
+// compared case-insensitively. Findings are emitted in sorted address order so
+// reports are deterministic.
+func checkDHCPStaticLeaseUniqueness(cfg *common.CommonDevice, report *Report) {
+	for i, scope := range cfg.DHCP {
+		if len(scope.StaticLeases) < 2 {
+			continue
+		}
+
+		macCounts := make(map[string]int, len(scope.StaticLeases))
+		ipCounts := make(map[string]int, len(scope.StaticLeases))
+
+		for _, lease := range scope.StaticLeases {
+			if mac := strings.ToLower(strings.TrimSpace(lease.MAC)); mac != "" {
+				macCounts[mac]++
+			}
+
+			if ip := strings.TrimSpace(lease.IPAddress); ip != "" {
+				ipCounts[ip]++
+			}
+		}
+
+		component := fmt.Sprintf("dhcp[%d].staticLeases", i)
+
+		for _, mac := range slices.Sorted(maps.Keys(macCounts)) {
+			if macCounts[mac] < 2 {
+				continue
+			}
+
+			report.AddFinding(SeverityMedium, Finding{
+				Type:  "duplicate-dhcp-mac",
+				Title: "Duplicate DHCP Static Lease MAC Address",
+				Description: fmt.Sprintf(
+					"MAC address %s appears in %d static leases on DHCP scope %s",
+					mac, macCounts[mac], scope.Interface,
+				),
+				Component:      component,
+				Recommendation: "Remove or merge the duplicate static mappings so each MAC address maps to one IP address",
+			})
+		}
+
+		for _, ip := range slices.Sorted(maps.Keys(ipCounts)) {
+			if ipCounts[ip] < 2 {
+				continue
+			}
+
+			report.AddFinding(SeverityMedium, Finding{
+				Type:  "duplicate-dhcp-ip",
+				Title: "Duplicate DHCP Static Lease IP Address",
+				Description: fmt.Sprintf(
+					"IP address %s is assigned by %d static leases on DHCP scope %s",
+					ip, ipCounts[ip], scope.Interface,
+				),
+				Component:      component,
+				Recommendation: "Assign a unique IP address to each static mapping",
+			})
+		}
+	}
+}
+
+// interfaceIPv4Subnets returns the IPv4 subnet of every interface with a
+// static IPv4 address, keyed by interface name.
+func interfaceIPv4Subnets(ifaces []common.Interface) map[string]netip.Prefix {
+	subnets := make(map[string]netip.Prefix, len(ifaces))
+	for _, iface := range ifaces {
+		addr, err := netip.ParseAddr(strings.TrimSpace(iface.IPAddress))
+		if err != nil || !addr.Is4() {
+			continue
+		}
+
+		bits, err := strconv.Atoi(strings.TrimSpace(iface.Subnet))
+		if err != nil {
+			continue
+		}
+
+		if prefix, err := addr.Prefix(bits); err == nil {
+			subnets[iface.Name] = prefix
+		}
+	}
+
+	return subnets
+}
+
+// checkDHCPRangeSubnetBoundary detects DHCP scopes whose address range does
+// not fit in the IPv4 subnet of the interface the scope is bound to, e.g. a
+// range of 192.168.1.200 to 192.168.2.50 on a /24. Clients leased an address
+// outside the subnet cannot reach the gateway. Scopes without a complete
+// range, and interfaces without a static IPv4 address, are skipped.
+func checkDHCPRangeSubnetBoundary(cfg *common.CommonDevice, report *Report) {
+	subnets := interfaceIPv4Subnets(cfg.Interfaces)
+
+	for i, scope := range cfg.DHCP {
+		subnet, ok := subnets[scope.Interface]
+		if !ok {
+			continue
+		}
+
+		from, fromErr := netip.ParseAddr(strings.TrimSpace(scope.Range.From))
+		to, toErr := netip.ParseAddr(strings.TrimSpace(scope.Range.To))
+		if fromErr != nil || toErr != nil {
+			continue
+		}
+
+		if subnet.Contains(from) && subnet.Contains(to) {
+			continue
+		}
+
+		report.AddFinding(SeverityMedium, Finding{
+			Type:  "dhcp-range-spans-subnets",
+			Title: "DHCP Range Spans Subnets",
+			Description: fmt.Sprintf(
+				"DHCP range %s-%s on scope %s extends beyond the interface subnet %s",
+				from, to, scope.Interface, subnet,
+			),
+			Component:      fmt.Sprintf("dhcp[%d].range", i),
+			Recommendation: fmt.Sprintf("Keep both ends of the DHCP range within %s", subnet),
+		})
+	}
 }
 
 // analyzeSecurityIssues performs security-focused analysis.
@@ -128,6 +598,9 @@ func (p *CoreProcessor) analyzeSecurityIssues(cfg *common.CommonDevice, report *
 	referenceMap := map[string]string{
 		"system.webgui.protocol": "HTTPS provides encryption for administrative access",
 		"snmpd.rocommunity":      "Default community strings are well-known and pose security risks",
+		"vpn.pptp":               "PPTP authentication (MS-CHAPv2) can be cracked offline",
+		"vpn.l2tp":               "L2TP provides no encryption without IPsec",
+		"upnp":                   "UPnP and NAT-PMP let internal clients open inbound port forwards without review",
 	}
 
 	for _, f := range issues {
@@ -145,6 +618,291 @@ func (p *CoreProcessor) analyzeSecurityIssues(cfg *common.CommonDevice, report *
 			Reference:      ref,
 		})
 	}
+
+	// Processor-specific check: interfaces whose rule list has no trailing block-all
+	checkDefaultDenyMissing(cfg, report)
+
+	// Processor-specific check: WAN ICMP pass rules that allow every ICMP type
+	checkBroadICMPRules(cfg, report)
+
+	// Processor-specific check: IPS mode with only alert policies
+	checkIDSPoliciesAlertOnly(cfg, report)
+
+	// Processor-specific check: stateless outbound rules on stateful inbound flows
+	checkStateTrackingConsistency(cfg, report)
+
+	// Processor-specific check: pass rules decided by externally fetched aliases
+	checkExternalFeedDependencies(cfg, report)
+}
+
+// checkDefaultDenyMissing detects interfaces whose filter rules do not end in
+// an explicit default-deny for both address families: the trailing enabled
+// rules bound to the interface must block every protocol from any source to
+// any destination, without negation or port restrictions, and together cover
+// IPv4 and IPv6. Interfaces without rules are skipped, since the implicit
+// firewall policy already blocks their traffic. An enabled floating block-all
+// rule contributes its families to every interface it applies to, and to
+// every interface when it names none.
+func checkDefaultDenyMissing(cfg *common.CommonDevice, report *Report) {
+	ifaceRules := make(map[string][]int)
+	floatingDeny := make(map[string]addressFamilies)
+	var floatingDenyAll addressFamilies
+
+	for i, rule := range cfg.FirewallRules {
+		if rule.Disabled {
+			continue
+		}
+
+		if rule.Floating {
+			families := defaultDenyFamilies(rule)
+			if len(rule.Interfaces) == 0 {
+				floatingDenyAll |= families
+			}
+			for _, iface := range rule.Interfaces {
+				floatingDeny[iface] |= families
+			}
+
+			continue
+		}
+
+		for _, iface := range rule.Interfaces {
+			ifaceRules[iface] = append(ifaceRules[iface], i)
+		}
+	}
+
+	if floatingDenyAll == familiesBoth {
+		return
+	}
+
+	for _, iface := range slices.Sorted(maps.Keys(ifaceRules)) {
+		indices := ifaceRules[iface]
+		covered := floatingDenyAll | floatingDeny[iface]
+
+		for _, idx := range slices.Backward(indices) {
+			families := defaultDenyFamilies(cfg.FirewallRules[idx])
+			if families == 0 {
+				break
+			}
+			covered |= families
+		}
+
+		if covered == familiesBoth {
+			continue
+		}
+
+		report.AddFinding(SeverityHigh, Finding{
+			Type:  "no-default-deny",
+			Title: "Missing Default-Deny Rule",
+			Description: fmt.Sprintf(
+				"Interface %s does not end with a block rule for all protocols from any source to any destination "+
+					"covering both IPv4 and IPv6; the last enabled rule is at position %d",
+				iface,
+				indices[len(indices)-1]+1,
+			),
+			Component: "interfaces." + iface,
+			Recommendation: "Add a block rule for any protocol with any source and any destination, " +
+				"for both IPv4 and IPv6, as the last rule on the interface",
+		})
+	}
+}
+
+// checkExternalFeedDependencies reports enabled pass rules whose address or
+// port comes from an externally resolved alias (URL, URL table, or GeoIP).
+// What such a rule admits is decided by a feed the firewall fetches at
+// runtime, so whoever controls the feed controls the policy. One Info finding
+// is emitted per alias, listing the pass rules that use it; block and reject
+// rules only narrow the policy and are not reported.
+func checkExternalFeedDependencies(cfg *common.CommonDevice, report *Report) {
+	if len(cfg.NamedObjects) == 0 {
+		return
+	}
+
+	positions := make(map[string][]string)
+	for i, rule := range cfg.FirewallRules {
+		if rule.Disabled || rule.Type != common.RuleTypePass {
+			continue
+		}
+
+		var used []string
+		for _, name := range []string{
+			objectRefName(rule.Source.AddressRef, rule.Source.Address),
+			objectRefName(rule.Source.PortRef, rule.Source.Port),
+			objectRefName(rule.Destination.AddressRef, rule.Destination.Address),
+			objectRefName(rule.Destination.PortRef, rule.Destination.Port),
+		} {
+			obj, ok := cfg.NamedObjects[name]
+			if !ok || !obj.Type.IsExternallyResolved() || slices.Contains(used, name) {
+				continue
+			}
+
+			used = append(used, name)
+			positions[name] = append(positions[name], strconv.Itoa(i+1))
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(positions)) {
+		obj := cfg.NamedObjects[name]
+		rules := positions[name]
+
+		usage := fmt.Sprintf("Pass rule at position %s uses", rules[0])
+		if len(rules) > 1 {
+			usage = fmt.Sprintf("Pass rules at positions %s use", strings.Join(rules, ", "))
+		}
+
+		report.AddFinding(SeverityInfo, Finding{
+			Type:  "external-feed",
+			Title: "Firewall Policy Depends on External Feed",
+			Description: fmt.Sprintf(
+				"Policy depends on external feed: %s. %s %s alias %q, whose entries the firewall fetches at runtime",
+				externalFeedSource(obj), usage, obj.Type, name,
+			),
+			Component: "aliases." + name,
+			Recommendation: "Confirm the feed is a trusted source served over HTTPS, " +
+				"and review what the rules would admit if the feed were tampered with or unavailable",
+		})
+	}
+}
+
+// objectRefName returns the name of the alias an endpoint field refers to:
+// ref's name when set, otherwise the literal value, which the caller looks up
+// in the alias table.
+func objectRefName(ref *common.ObjectRef, literal string) string {
+	if ref != nil {
+		return ref.Name
+	}
+
+	return strings.TrimSpace(literal)
+}
+
+// externalFeedSource describes where an externally resolved alias gets its
+// entries: its source URLs, or for a GeoIP alias its countries and the GeoIP
+// database URL when one is configured.
+func externalFeedSource(obj common.NamedObject) string {
+	urls := strings.Join(obj.URLs, ", ")
+
+	if obj.Type == common.NamedObjectTypeGeoIP {
+		source := "GeoIP " + cmp.Or(strings.Join(obj.Countries, ", "), "(no countries)")
+		if urls != "" {
+			source += " from " + urls
+		}
+
+		return source
+	}
+
+	return cmp.Or(urls, "(no source URL)")
+}
+
+// checkBroadICMPRules detects enabled pass rules for ICMP that set no ICMP
+// type and therefore admit every type, including timestamp and address mask
+// requests that aid reconnaissance, on a perimeter interface. A rule is on
+// the perimeter when analysis.RuleReachability classifies it as WAN-reachable.
+func checkBroadICMPRules(cfg *common.CommonDevice, report *Report) {
+	for i, rule := range cfg.FirewallRules {
+		if rule.Disabled || rule.Type != common.RuleTypePass ||
+			!strings.EqualFold(rule.Protocol, "icmp") || rule.ICMPType != "" {
+			continue
+		}
+
+		if analysis.RuleReachability(rule, cfg.Interfaces) != analysis.WANReachable {
+			continue
+		}
+
+		scope := "all interfaces"
+		if len(rule.Interfaces) > 0 {
+			scope = strings.Join(rule.Interfaces, ", ")
+		}
+
+		report.AddFinding(SeverityLow, Finding{
+			Type:  "unrestricted-icmp",
+			Title: "Unrestricted ICMP Pass Rule",
+			Description: fmt.Sprintf(
+				"Rule at position %d passes all ICMP types on %s, which includes a perimeter interface",
+				i+1,
+				scope,
+			),
+			Component: fmt.Sprintf("filter.rule[%d]", i),
+			Recommendation: "Restrict the rule to the ICMP types the network needs, " +
+				"such as 0 (echo-reply) and 8 (echo-request)",
+		})
+	}
+}
+
+// checkIDSPoliciesAlertOnly detects an IDS running in IPS mode whose enabled
+// policies all set matched rules to alert. Policies then never make a rule
+// drop traffic, so inline prevention only blocks what the rulesets
+// themselves mark as drop. Disabled engines and configurations without
+// enabled policies are skipped.
+func checkIDSPoliciesAlertOnly(cfg *common.CommonDevice, report *Report) {
+	ids := cfg.IDS
+	if ids == nil || !ids.Enabled || !ids.IPSMode {
+		return
+	}
+
+	enabled := 0
+	for _, policy := range ids.Policies {
+		if !policy.Enabled {
+			continue
+		}
+		if !strings.EqualFold(policy.Action, "alert") {
+			return
+		}
+		enabled++
+	}
+
+	if enabled == 0 {
+		return
+	}
+
+	report.AddFinding(SeverityMedium, Finding{
+		Type:  "ids-alert-only",
+		Title: "IPS Mode With Alert-Only Policies",
+		Description: fmt.Sprintf(
+			"IPS mode is enabled but all %d enabled IDS policies set matched rules to alert, "+
+				"so the policies detect threats without blocking them",
+			enabled,
+		),
+		Component:      "ids.policies",
+		Recommendation: "Set the action of policies covering high-confidence rules to drop, or disable IPS mode if detection only is intended",
+	})
+}
+
+// addressFamilies is a set of IP address families.
+type addressFamilies uint8
+
+const (
+	familyIPv4 addressFamilies = 1 << iota
+	familyIPv6
+
+	familiesBoth = familyIPv4 | familyIPv6
+)
+
+// defaultDenyFamilies returns the address families rule blocks outright: a
+// block rule for any protocol, inbound or in either direction, from any
+// source to any destination with no negation or port restriction. Rules of
+// any other shape return the empty set. An unset IP protocol covers both
+// families, as does pfSense's inet46.
+func defaultDenyFamilies(rule common.FirewallRule) addressFamilies {
+	if rule.Type != common.RuleTypeBlock ||
+		rule.Direction == common.DirectionOut ||
+		(rule.Protocol != "" && !strings.EqualFold(rule.Protocol, constants.NetworkAny)) ||
+		!isUnrestrictedEndpoint(rule.Source) ||
+		!isUnrestrictedEndpoint(rule.Destination) {
+		return 0
+	}
+
+	switch rule.IPProtocol {
+	case common.IPProtocolInet:
+		return familyIPv4
+	case common.IPProtocolInet6:
+		return familyIPv6
+	default:
+		return familiesBoth
+	}
+}
+
+// isUnrestrictedEndpoint reports whether ep matches any address on any port.
+func isUnrestrictedEndpoint(ep common.RuleEndpoint) bool {
+	return ep.Address == constants.NetworkAny && !ep.Negated && ep.Port == ""
 }
 
 // analyzePerformanceIssues performs performance-focused analysis.
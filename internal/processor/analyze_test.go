@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/EvilBit-Labs/opnDossier/internal/analysis"
@@ -1042,6 +1043,517 @@ func TestCoreProcessor_DeadRuleDetection_IsAnyPath(t *testing.T) {
 	}
 }
 
+func TestCheckDHCPStaticLeaseUniqueness(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		scopes    []common.DHCPScope
+		wantTypes []string
+		wantDescs []string
+	}{
+		{
+			name: "unique leases produce no findings",
+			scopes: []common.DHCPScope{{
+				Interface: "lan",
+				StaticLeases: []common.DHCPStaticLease{
+					{MAC: "00:11:22:33:44:55", IPAddress: "192.168.1.10"},
+					{MAC: "00:11:22:33:44:66", IPAddress: "192.168.1.11"},
+				},
+			}},
+		},
+		{
+			name: "duplicate MAC differing only in case",
+			scopes: []common.DHCPScope{{
+				Interface: "lan",
+				StaticLeases: []common.DHCPStaticLease{
+					{MAC: "00:11:22:AA:BB:CC", IPAddress: "192.168.1.10"},
+					{MAC: "00:11:22:aa:bb:cc", IPAddress: "192.168.1.11"},
+				},
+			}},
+			wantTypes: []string{"duplicate-dhcp-mac"},
+			wantDescs: []string{"MAC address 00:11:22:aa:bb:cc appears in 2 static leases on DHCP scope lan"},
+		},
+		{
+			name: "duplicate IP",
+			scopes: []common.DHCPScope{{
+				Interface: "opt1",
+				StaticLeases: []common.DHCPStaticLease{
+					{MAC: "00:11:22:33:44:55", IPAddress: "10.0.0.5"},
+					{MAC: "00:11:22:33:44:66", IPAddress: "10.0.0.5"},
+					{MAC: "00:11:22:33:44:77", IPAddress: "10.0.0.6"},
+				},
+			}},
+			wantTypes: []string{"duplicate-dhcp-ip"},
+			wantDescs: []string{"IP address 10.0.0.5 is assigned by 2 static leases on DHCP scope opt1"},
+		},
+		{
+			name: "same MAC in different scopes is allowed",
+			scopes: []common.DHCPScope{
+				{
+					Interface:    "lan",
+					StaticLeases: []common.DHCPStaticLease{{MAC: "00:11:22:33:44:55", IPAddress: "192.168.1.10"}},
+				},
+				{
+					Interface:    "opt1",
+					StaticLeases: []common.DHCPStaticLease{{MAC: "00:11:22:33:44:55", IPAddress: "10.0.0.10"}},
+				},
+			},
+		},
+		{
+			name: "empty MAC and IP are not counted",
+			scopes: []common.DHCPScope{{
+				Interface: "lan",
+				StaticLeases: []common.DHCPStaticLease{
+					{CID: "client-a"},
+					{CID: "client-b"},
+				},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := &common.CommonDevice{DHCP: tt.scopes}
+			report := NewReport(cfg, Config{})
+
+			checkDHCPStaticLeaseUniqueness(cfg, report)
+
+			var gotTypes, gotDescs []string
+			for _, f := range report.Findings.Medium {
+				gotTypes = append(gotTypes, f.Type)
+				gotDescs = append(gotDescs, f.Description)
+			}
+
+			assert.Equal(t, tt.wantTypes, gotTypes)
+			assert.Equal(t, tt.wantDescs, gotDescs)
+			assert.Equal(t, len(tt.wantTypes), report.TotalFindings())
+		})
+	}
+}
+
+func TestCheckDHCPRangeSubnetBoundary(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		from, to string
+		wantDesc string
+	}{
+		{
+			name: "range within the subnet",
+			from: "192.168.1.100",
+			to:   "192.168.1.199",
+		},
+		{
+			name: "range exactly at the subnet boundary",
+			from: "192.168.1.0",
+			to:   "192.168.1.255",
+		},
+		{
+			name:     "range crossing the subnet boundary",
+			from:     "192.168.1.200",
+			to:       "192.168.2.50",
+			wantDesc: "DHCP range 192.168.1.200-192.168.2.50 on scope lan extends beyond the interface subnet 192.168.1.0/24",
+		},
+		{
+			name:     "range one address past the boundary",
+			from:     "192.168.1.100",
+			to:       "192.168.2.0",
+			wantDesc: "DHCP range 192.168.1.100-192.168.2.0 on scope lan extends beyond the interface subnet 192.168.1.0/24",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := &common.CommonDevice{
+				Interfaces: []common.Interface{
+					{Name: "wan", IPAddress: "dhcp"},
+					{Name: "lan", IPAddress: "192.168.1.1", Subnet: "24"},
+				},
+				DHCP: []common.DHCPScope{
+					{Interface: "wan", Range: common.DHCPRange{From: "10.0.0.1", To: "10.9.0.1"}},
+					{Interface: "lan", Range: common.DHCPRange{From: tt.from, To: tt.to}},
+				},
+			}
+			report := NewReport(cfg, Config{})
+
+			checkDHCPRangeSubnetBoundary(cfg, report)
+
+			if tt.wantDesc == "" {
+				assert.Zero(t, report.TotalFindings())
+				return
+			}
+
+			require.Len(t, report.Findings.Medium, 1)
+			f := report.Findings.Medium[0]
+			assert.Equal(t, "dhcp-range-spans-subnets", f.Type)
+			assert.Equal(t, "dhcp[1].range", f.Component)
+			assert.Equal(t, tt.wantDesc, f.Description)
+			assert.Equal(t, 1, report.TotalFindings())
+		})
+	}
+}
+
+func TestCheckUndefinedAliases(t *testing.T) {
+	t.Parallel()
+
+	aliases := common.NamedObjects{
+		"web_servers": {Name: "web_servers", Type: common.NamedObjectTypeHost, Members: []string{"10.0.0.10"}},
+	}
+	interfaces := []common.Interface{{Name: "wan"}, {Name: "lan"}, {Name: "opt1"}, {Name: "dmz"}}
+	groups := []common.InterfaceGroup{{Name: "GuestNets", Members: []string{"opt1"}}}
+
+	tests := []struct {
+		name      string
+		source    common.RuleEndpoint
+		dest      common.RuleEndpoint
+		wantDescs []string
+	}{
+		{
+			name:   "literal addresses and keywords are not aliases",
+			source: common.RuleEndpoint{Address: "10.0.0.0/24"},
+			dest:   common.RuleEndpoint{Address: "any"},
+		},
+		{
+			name:   "interface networks and addresses are not aliases",
+			source: common.RuleEndpoint{Address: "opt1"},
+			dest:   common.RuleEndpoint{Address: "lanip"},
+		},
+		{
+			name:   "custom interface network and address are not aliases",
+			source: common.RuleEndpoint{Address: "dmz"},
+			dest:   common.RuleEndpoint{Address: "dmzip"},
+		},
+		{
+			name:   "firewall self address is not an alias",
+			source: common.RuleEndpoint{Address: "any"},
+			dest:   common.RuleEndpoint{Address: "(self)"},
+		},
+		{
+			name:   "interface groups are not aliases",
+			source: common.RuleEndpoint{Address: "GuestNets"},
+			dest:   common.RuleEndpoint{Address: "wanip"},
+		},
+		{
+			name:   "built-in VPN interface groups are not aliases",
+			source: common.RuleEndpoint{Address: "openvpn"},
+			dest:   common.RuleEndpoint{Address: "wireguard"},
+		},
+		{
+			name:   "IP range and IPv6 address are not aliases",
+			source: common.RuleEndpoint{Address: "10.0.0.1-10.0.0.9"},
+			dest:   common.RuleEndpoint{Address: "2001:db8::1"},
+		},
+		{
+			name:   "defined alias is accepted",
+			source: common.RuleEndpoint{Address: "any"},
+			dest:   common.RuleEndpoint{Address: "web_servers"},
+		},
+		{
+			name:   "resolved alias reference is accepted",
+			source: common.RuleEndpoint{Address: "other", AddressRef: &common.ObjectRef{Name: "other"}},
+		},
+		{
+			name:   "built-in and generated aliases are accepted",
+			source: common.RuleEndpoint{Address: "bogons"},
+			dest:   common.RuleEndpoint{Address: "__lan_network"},
+		},
+		{
+			name:   "undefined aliases on both endpoints",
+			source: common.RuleEndpoint{Address: "blocked_hosts"},
+			dest:   common.RuleEndpoint{Address: "DMZServers"},
+			wantDescs: []string{
+				`Rule at position 1 uses source address "blocked_hosts", which is not a defined alias`,
+				`Rule at position 1 uses destination address "DMZServers", which is not a defined alias`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := &common.CommonDevice{
+				Interfaces:      interfaces,
+				InterfaceGroups: groups,
+				NamedObjects:    aliases,
+				FirewallRules: []common.FirewallRule{{
+					Type:        common.RuleTypePass,
+					Interfaces:  []string{"lan"},
+					Source:      tt.source,
+					Destination: tt.dest,
+				}},
+			}
+			report := NewReport(cfg, Config{})
+
+			checkUndefinedAliases(cfg, report)
+
+			var gotDescs []string
+			for _, f := range report.Findings.High {
+				assert.Equal(t, "undefined-alias", f.Type)
+				assert.Equal(t, "filter.rule[0]", f.Component)
+				gotDescs = append(gotDescs, f.Description)
+			}
+
+			assert.Equal(t, tt.wantDescs, gotDescs)
+			assert.Equal(t, len(tt.wantDescs), report.TotalFindings())
+		})
+	}
+}
+
+//nolint:funlen // test table or data declaration; length is in data not logic
+func TestCheckDefaultDenyMissing(t *testing.T) {
+	t.Parallel()
+
+	anyAddr := common.RuleEndpoint{Address: constants.NetworkAny}
+	lanNet := common.RuleEndpoint{Address: "lan"}
+	passLAN := common.FirewallRule{
+		Type: common.RuleTypePass, Interfaces: []string{"lan"}, Source: lanNet, Destination: anyAddr,
+	}
+	blockAll := func(ifaces ...string) common.FirewallRule {
+		return common.FirewallRule{Type: common.RuleTypeBlock, Interfaces: ifaces, Source: anyAddr, Destination: anyAddr}
+	}
+
+	tests := []struct {
+		name       string
+		rules      []common.FirewallRule
+		wantIfaces []string
+	}{
+		{
+			name:  "no rules",
+			rules: nil,
+		},
+		{
+			name:  "trailing block-all satisfies the interface",
+			rules: []common.FirewallRule{passLAN, blockAll("lan")},
+		},
+		{
+			name:       "missing trailing block-all",
+			rules:      []common.FirewallRule{passLAN},
+			wantIfaces: []string{"lan"},
+		},
+		{
+			name:       "block-all followed by a pass rule",
+			rules:      []common.FirewallRule{blockAll("lan"), passLAN},
+			wantIfaces: []string{"lan"},
+		},
+		{
+			name: "disabled trailing rules are ignored",
+			rules: []common.FirewallRule{
+				passLAN,
+				blockAll("lan"),
+				{Type: common.RuleTypePass, Interfaces: []string{"lan"}, Source: anyAddr, Destination: anyAddr, Disabled: true},
+			},
+		},
+		{
+			name: "disabled block-all does not count",
+			rules: []common.FirewallRule{passLAN, {
+				Type: common.RuleTypeBlock, Interfaces: []string{"lan"},
+				Source: anyAddr, Destination: anyAddr, Disabled: true,
+			}},
+			wantIfaces: []string{"lan"},
+		},
+		{
+			name: "block with port restriction is not a default deny",
+			rules: []common.FirewallRule{passLAN, {
+				Type: common.RuleTypeBlock, Interfaces: []string{"lan"},
+				Source: anyAddr, Destination: common.RuleEndpoint{Address: constants.NetworkAny, Port: "22"},
+			}},
+			wantIfaces: []string{"lan"},
+		},
+		{
+			name: "multi-interface block-all covers each interface",
+			rules: []common.FirewallRule{
+				passLAN,
+				{Type: common.RuleTypePass, Interfaces: []string{"opt1"}, Source: anyAddr, Destination: anyAddr},
+				blockAll("lan", "opt1"),
+			},
+		},
+		{
+			name: "interfaces are reported in name order",
+			rules: []common.FirewallRule{
+				{Type: common.RuleTypePass, Interfaces: []string{"wan"}, Source: anyAddr, Destination: lanNet},
+				passLAN,
+			},
+			wantIfaces: []string{"lan", "wan"},
+		},
+		{
+			name: "floating block-all on all interfaces satisfies every interface",
+			rules: []common.FirewallRule{
+				passLAN,
+				{Type: common.RuleTypeBlock, Floating: true, Source: anyAddr, Destination: anyAddr},
+			},
+		},
+		{
+			name: "floating block-all scoped to one interface",
+			rules: []common.FirewallRule{
+				passLAN,
+				{Type: common.RuleTypePass, Interfaces: []string{"wan"}, Source: anyAddr, Destination: lanNet},
+				{Type: common.RuleTypeBlock, Floating: true, Interfaces: []string{"wan"}, Source: anyAddr, Destination: anyAddr},
+			},
+			wantIfaces: []string{"lan"},
+		},
+		{
+			name: "protocol-restricted block is not a default deny",
+			rules: []common.FirewallRule{passLAN, {
+				Type: common.RuleTypeBlock, Interfaces: []string{"lan"}, Protocol: "tcp",
+				Source: anyAddr, Destination: anyAddr,
+			}},
+			wantIfaces: []string{"lan"},
+		},
+		{
+			name: "explicit any protocol counts",
+			rules: []common.FirewallRule{passLAN, {
+				Type: common.RuleTypeBlock, Interfaces: []string{"lan"}, Protocol: "any",
+				Source: anyAddr, Destination: anyAddr,
+			}},
+		},
+		{
+			name: "outbound-only block is not a default deny",
+			rules: []common.FirewallRule{passLAN, {
+				Type: common.RuleTypeBlock, Interfaces: []string{"lan"}, Direction: common.DirectionOut,
+				Source: anyAddr, Destination: anyAddr,
+			}},
+			wantIfaces: []string{"lan"},
+		},
+		{
+			name: "IPv4-only block leaves IPv6 open",
+			rules: []common.FirewallRule{passLAN, {
+				Type: common.RuleTypeBlock, Interfaces: []string{"lan"}, IPProtocol: common.IPProtocolInet,
+				Source: anyAddr, Destination: anyAddr,
+			}},
+			wantIfaces: []string{"lan"},
+		},
+		{
+			name: "trailing IPv4 and IPv6 blocks together cover both families",
+			rules: []common.FirewallRule{
+				passLAN,
+				{
+					Type: common.RuleTypeBlock, Interfaces: []string{"lan"}, IPProtocol: common.IPProtocolInet6,
+					Source: anyAddr, Destination: anyAddr,
+				},
+				{
+					Type: common.RuleTypeBlock, Interfaces: []string{"lan"}, IPProtocol: common.IPProtocolInet,
+					Source: anyAddr, Destination: anyAddr, Direction: common.DirectionIn,
+				},
+			},
+		},
+		{
+			name: "dual-stack block covers both families",
+			rules: []common.FirewallRule{passLAN, {
+				Type: common.RuleTypeBlock, Interfaces: []string{"lan"}, IPProtocol: common.IPProtocolInet46,
+				Source: anyAddr, Destination: anyAddr,
+			}},
+		},
+		{
+			name: "floating IPv6 block completes an interface IPv4 block",
+			rules: []common.FirewallRule{
+				passLAN,
+				{
+					Type: common.RuleTypeBlock, Interfaces: []string{"lan"}, IPProtocol: common.IPProtocolInet,
+					Source: anyAddr, Destination: anyAddr,
+				},
+				{
+					Type: common.RuleTypeBlock, Floating: true, IPProtocol: common.IPProtocolInet6,
+					Source: anyAddr, Destination: anyAddr,
+				},
+			},
+		},
+		{
+			name: "floating pass rule does not satisfy the interface",
+			rules: []common.FirewallRule{
+				passLAN,
+				{Type: common.RuleTypePass, Floating: true, Source: anyAddr, Destination: anyAddr},
+			},
+			wantIfaces: []string{"lan"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := &common.CommonDevice{FirewallRules: tt.rules}
+			report := NewReport(cfg, Config{})
+
+			checkDefaultDenyMissing(cfg, report)
+
+			var gotIfaces []string
+			for _, f := range report.Findings.High {
+				assert.Equal(t, "no-default-deny", f.Type)
+				assert.Equal(t, "Missing Default-Deny Rule", f.Title)
+				gotIfaces = append(gotIfaces, f.Component)
+			}
+
+			var wantComponents []string
+			for _, iface := range tt.wantIfaces {
+				wantComponents = append(wantComponents, "interfaces."+iface)
+			}
+
+			assert.Equal(t, wantComponents, gotIfaces)
+			assert.Equal(t, len(tt.wantIfaces), report.TotalFindings())
+		})
+	}
+}
+
+func TestAnalyzeAddressPlan_Fixture(t *testing.T) {
+	t.Parallel()
+
+	xmlData, err := os.ReadFile("testdata/address_plan_conflicts.xml")
+	require.NoError(t, err)
+
+	factory := parser.NewFactory(cfgparser.NewXMLParser())
+	cfg, _, err := factory.CreateDevice(
+		context.Background(),
+		strings.NewReader(string(xmlData)),
+		common.DeviceTypeUnknown,
+		false,
+	)
+	require.NoError(t, err)
+
+	p, err := NewCoreProcessor(nil)
+	require.NoError(t, err)
+
+	report, err := p.Process(context.Background(), cfg, WithSecurityAnalysis())
+	require.NoError(t, err)
+
+	addressPlan := func(findings []Finding) []string {
+		var descs []string
+		for _, f := range findings {
+			if f.Type == "address-plan" {
+				descs = append(descs, f.Component+": "+f.Description)
+			}
+		}
+		return descs
+	}
+
+	assert.Equal(t, []string{
+		"interfaces.lan: Interface lan subnet 192.168.1.0/24 overlaps interface opt1 subnet 192.168.1.128/25",
+		"dhcpd.lan.staticmap[0]: Static lease for printer in DHCP scope lan reserves 192.168.1.1, " +
+			"which is the address of interface lan",
+	}, addressPlan(report.Findings.High))
+	assert.Equal(t, []string{
+		"virtualip.vip[1]: CARP virtual IP 198.51.100.10 is not within interface wan subnet 203.0.113.0/29",
+	}, addressPlan(report.Findings.Medium))
+	assert.Empty(t, addressPlan(report.Findings.Critical))
+
+	// The same findings reach the JSON/YAML exports through ComputeAnalysis.
+	var exported []string
+	for _, f := range analysis.ComputeAnalysis(cfg).ConsistencyIssues {
+		exported = append(exported, f.Component)
+	}
+	assert.Subset(t, exported, []string{
+		"interfaces.lan",
+		"dhcpd.lan.staticmap[0]",
+		"virtualip.vip[1]",
+	})
+}
+
 func TestMapSeverity(t *testing.T) {
 	t.Parallel()
 
@@ -1067,3 +1579,356 @@ func TestMapSeverity(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckBroadICMPRules(t *testing.T) {
+	t.Parallel()
+
+	icmpRule := func(ifaces ...string) common.FirewallRule {
+		return common.FirewallRule{Type: common.RuleTypePass, Protocol: "icmp", Interfaces: ifaces}
+	}
+
+	tests := []struct {
+		name           string
+		rules          []common.FirewallRule
+		wantComponents []string
+	}{
+		{
+			name:  "no rules",
+			rules: nil,
+		},
+		{
+			name:           "WAN ICMP pass rule without a type",
+			rules:          []common.FirewallRule{icmpRule("wan")},
+			wantComponents: []string{"filter.rule[0]"},
+		},
+		{
+			name: "WAN ICMP pass rule restricted to echo-request",
+			rules: []common.FirewallRule{
+				{Type: common.RuleTypePass, Protocol: "icmp", ICMPType: "echoreq", Interfaces: []string{"wan"}},
+			},
+		},
+		{
+			name:  "LAN ICMP pass rule is not on the perimeter",
+			rules: []common.FirewallRule{icmpRule("lan")},
+		},
+		{
+			name: "disabled and block rules are ignored",
+			rules: []common.FirewallRule{
+				{Type: common.RuleTypePass, Protocol: "icmp", Interfaces: []string{"wan"}, Disabled: true},
+				{Type: common.RuleTypeBlock, Protocol: "icmp", Interfaces: []string{"wan"}},
+			},
+		},
+		{
+			name:  "non-ICMP pass rule is ignored",
+			rules: []common.FirewallRule{{Type: common.RuleTypePass, Protocol: "tcp", Interfaces: []string{"wan"}}},
+		},
+		{
+			name: "protocol match is case-insensitive and covers secondary WANs",
+			rules: []common.FirewallRule{
+				icmpRule("lan"),
+				{Type: common.RuleTypePass, Protocol: "ICMP", Interfaces: []string{"lan", "wan2"}},
+			},
+			wantComponents: []string{"filter.rule[1]"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := &common.CommonDevice{FirewallRules: tt.rules}
+			report := NewReport(cfg, Config{})
+
+			checkBroadICMPRules(cfg, report)
+
+			var gotComponents []string
+			for _, f := range report.Findings.Low {
+				assert.Equal(t, "unrestricted-icmp", f.Type)
+				assert.Equal(t, "Unrestricted ICMP Pass Rule", f.Title)
+				assert.Contains(t, f.Recommendation, "0 (echo-reply)")
+				assert.Contains(t, f.Recommendation, "8 (echo-request)")
+				gotComponents = append(gotComponents, f.Component)
+			}
+
+			assert.Equal(t, tt.wantComponents, gotComponents)
+			assert.Equal(t, len(tt.wantComponents), report.TotalFindings())
+		})
+	}
+}
+
+// TestCheckExternalFeedDependencies uses a URL table alias referenced by a
+// WAN block rule and by a LAN pass rule: only the pass usage is reported, and
+// neither reference counts as an undefined alias.
+func TestCheckExternalFeedDependencies(t *testing.T) {
+	t.Parallel()
+
+	feedRef := &common.ObjectRef{Name: "threat_feed"}
+	cfg := &common.CommonDevice{
+		Interfaces: []common.Interface{{Name: "wan"}, {Name: "lan"}},
+		NamedObjects: common.NamedObjects{
+			"threat_feed": {
+				Name: "threat_feed",
+				Type: common.NamedObjectTypeURLTable,
+				URLs: []string{"https://feeds.example.com/drop.txt"},
+			},
+			"partners": {Name: "partners", Type: common.NamedObjectTypeGeoIP, Countries: []string{"DE", "FR"}},
+			"servers":  {Name: "servers", Type: common.NamedObjectTypeHost, Members: []string{"10.0.0.10"}},
+		},
+		FirewallRules: []common.FirewallRule{
+			{
+				Type:       common.RuleTypeBlock,
+				Interfaces: []string{"wan"},
+				Source:     common.RuleEndpoint{Address: "threat_feed", AddressRef: feedRef},
+			},
+			{
+				Type:        common.RuleTypePass,
+				Interfaces:  []string{"lan"},
+				Source:      common.RuleEndpoint{Address: "lan"},
+				Destination: common.RuleEndpoint{Address: "threat_feed", AddressRef: feedRef},
+			},
+			{
+				Type:        common.RuleTypePass,
+				Interfaces:  []string{"lan"},
+				Destination: common.RuleEndpoint{Address: "servers"},
+			},
+			{
+				Type:       common.RuleTypePass,
+				Interfaces: []string{"wan"},
+				Source:     common.RuleEndpoint{Address: "partners"},
+				Disabled:   true,
+			},
+		},
+	}
+	report := NewReport(cfg, Config{})
+
+	checkUndefinedAliases(cfg, report)
+	checkExternalFeedDependencies(cfg, report)
+
+	require.Len(t, report.Findings.Info, 1)
+	assert.Equal(t, 1, report.TotalFindings())
+
+	f := report.Findings.Info[0]
+	assert.Equal(t, "external-feed", f.Type)
+	assert.Equal(t, "Firewall Policy Depends on External Feed", f.Title)
+	assert.Equal(t, "aliases.threat_feed", f.Component)
+	assert.Equal(t,
+		"Policy depends on external feed: https://feeds.example.com/drop.txt. "+
+			`Pass rule at position 2 uses urltable alias "threat_feed", whose entries the firewall fetches at runtime`,
+		f.Description,
+	)
+}
+
+func TestExternalFeedSource(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		obj  common.NamedObject
+		want string
+	}{
+		{
+			name: "URL table with two feeds",
+			obj:  common.NamedObject{Type: common.NamedObjectTypeURLTable, URLs: []string{"https://a", "https://b"}},
+			want: "https://a, https://b",
+		},
+		{
+			name: "URL table without a feed",
+			obj:  common.NamedObject{Type: common.NamedObjectTypeURLTable},
+			want: "(no source URL)",
+		},
+		{
+			name: "GeoIP with database URL",
+			obj: common.NamedObject{
+				Type: common.NamedObjectTypeGeoIP, Countries: []string{"NL"}, URLs: []string{"https://geo"},
+			},
+			want: "GeoIP NL from https://geo",
+		},
+		{
+			name: "GeoIP without database URL",
+			obj:  common.NamedObject{Type: common.NamedObjectTypeGeoIP, Countries: []string{"NL", "BE"}},
+			want: "GeoIP NL, BE",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, externalFeedSource(tt.obj))
+		})
+	}
+}
+
+func TestCheckIDSPoliciesAlertOnly(t *testing.T) {
+	t.Parallel()
+
+	policy := func(enabled bool, action string) common.IDSPolicy {
+		return common.IDSPolicy{Enabled: enabled, Action: action}
+	}
+
+	tests := []struct {
+		name string
+		ids  *common.IDSConfig
+		want bool
+	}{
+		{
+			name: "no IDS",
+		},
+		{
+			name: "IPS mode with only alert policies",
+			ids: &common.IDSConfig{Enabled: true, IPSMode: true, Policies: []common.IDSPolicy{
+				policy(true, "alert"), policy(true, "Alert"), policy(false, "drop"),
+			}},
+			want: true,
+		},
+		{
+			name: "IPS mode with a drop policy",
+			ids: &common.IDSConfig{Enabled: true, IPSMode: true, Policies: []common.IDSPolicy{
+				policy(true, "alert"), policy(true, "drop"),
+			}},
+		},
+		{
+			name: "IPS mode with a default policy",
+			ids: &common.IDSConfig{Enabled: true, IPSMode: true, Policies: []common.IDSPolicy{
+				policy(true, "alert"), policy(true, "default"),
+			}},
+		},
+		{
+			name: "IDS mode with only alert policies",
+			ids:  &common.IDSConfig{Enabled: true, Policies: []common.IDSPolicy{policy(true, "alert")}},
+		},
+		{
+			name: "IPS mode without enabled policies",
+			ids:  &common.IDSConfig{Enabled: true, IPSMode: true, Policies: []common.IDSPolicy{policy(false, "alert")}},
+		},
+		{
+			name: "disabled engine",
+			ids:  &common.IDSConfig{IPSMode: true, Policies: []common.IDSPolicy{policy(true, "alert")}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := &common.CommonDevice{IDS: tt.ids}
+			report := NewReport(cfg, Config{})
+
+			checkIDSPoliciesAlertOnly(cfg, report)
+
+			if !tt.want {
+				assert.Zero(t, report.TotalFindings())
+				return
+			}
+
+			require.Len(t, report.Findings.Medium, 1)
+			f := report.Findings.Medium[0]
+			assert.Equal(t, "ids-alert-only", f.Type)
+			assert.Equal(t, "ids.policies", f.Component)
+			assert.Contains(t, f.Description, "all 2 enabled IDS policies")
+		})
+	}
+}
+
+func TestCheckOpenVPNPortReachability(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		servers    []common.OpenVPNServer
+		wantMedium []string
+		wantInfo   []string
+	}{
+		{
+			name: "no servers",
+		},
+		{
+			name:     "explicit 1194 over UDP",
+			servers:  []common.OpenVPNServer{{VPNID: "1", Protocol: "UDP4", LocalPort: "1194"}},
+			wantInfo: []string{"openvpn.server[0]"},
+		},
+		{
+			name:     "explicit 1194 with no protocol defaults to UDP",
+			servers:  []common.OpenVPNServer{{VPNID: "1", LocalPort: "1194"}},
+			wantInfo: []string{"openvpn.server[0]"},
+		},
+		{
+			name:    "1194 over TCP is not reported",
+			servers: []common.OpenVPNServer{{VPNID: "1", Protocol: "TCP4", LocalPort: "1194"}},
+		},
+		{
+			name: "non-default ports are not reported",
+			servers: []common.OpenVPNServer{
+				{VPNID: "1", Protocol: "TCP4", LocalPort: "443"},
+				{VPNID: "2", Protocol: "UDP", LocalPort: "51194"},
+			},
+		},
+		{
+			name: "missing local port",
+			servers: []common.OpenVPNServer{
+				{VPNID: "1", Protocol: "TCP4", LocalPort: "443"},
+				{VPNID: "2", Protocol: "UDP4"},
+			},
+			wantMedium: []string{"openvpn.server[1]"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := &common.CommonDevice{VPN: common.VPN{OpenVPN: common.OpenVPNConfig{Servers: tt.servers}}}
+			report := NewReport(cfg, Config{})
+
+			checkOpenVPNPortReachability(cfg, report)
+
+			var gotMedium, gotInfo []string
+			for _, f := range report.Findings.Medium {
+				assert.Equal(t, "OpenVPN Server Without Local Port", f.Title)
+				gotMedium = append(gotMedium, f.Component)
+			}
+			for _, f := range report.Findings.Info {
+				assert.Equal(t, "OpenVPN Server on Default Port", f.Title)
+				assert.Contains(t, f.Recommendation, "443/TCP")
+				gotInfo = append(gotInfo, f.Component)
+			}
+
+			assert.Equal(t, tt.wantMedium, gotMedium)
+			assert.Equal(t, tt.wantInfo, gotInfo)
+			assert.Equal(t, len(tt.wantMedium)+len(tt.wantInfo), report.TotalFindings())
+		})
+	}
+}
+
+func TestAnalyzeDanglingInterfaceReferences_Fixture(t *testing.T) {
+	t.Parallel()
+
+	xmlData, err := os.ReadFile("testdata/dangling_interfaces.xml")
+	require.NoError(t, err)
+
+	factory := parser.NewFactory(cfgparser.NewXMLParser())
+	cfg, _, err := factory.CreateDevice(
+		context.Background(),
+		strings.NewReader(string(xmlData)),
+		common.DeviceTypeUnknown,
+		false,
+	)
+	require.NoError(t, err)
+
+	p, err := NewCoreProcessor(nil)
+	require.NoError(t, err)
+
+	report, err := p.Process(context.Background(), cfg, WithSecurityAnalysis())
+	require.NoError(t, err)
+
+	var dangling []string
+	for _, f := range report.Findings.Medium {
+		if f.Type == "dangling-interface-reference" {
+			dangling = append(dangling, f.Component+": "+f.Description)
+		}
+	}
+
+	assert.Equal(t, []string{
+		"interfaces.opt10: Interface opt10 is referenced by DHCP scope but is not defined in the configuration",
+		"interfaces.opt3: Interface opt3 is referenced by OpenVPN server \"Road warriors\" but is not defined in the configuration",
+	}, dangling)
+}
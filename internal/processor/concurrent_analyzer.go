@@ -0,0 +1,101 @@
+package processor
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/progress"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+)
+
+// ConcurrentAnalyzer is a Processor that runs the analysis passes of a
+// CoreProcessor in parallel, one goroutine per pass. Normalization,
+// validation, and transformation are those of the wrapped CoreProcessor.
+//
+// The report is identical to the one CoreProcessor.Process returns: each
+// pass collects its findings privately, and the results are merged into the
+// report in pass order once every pass has finished, so finding order and
+// the per-pass log records do not depend on scheduling. Like CoreProcessor,
+// a ConcurrentAnalyzer is safe to share across goroutines.
+type ConcurrentAnalyzer struct {
+	*CoreProcessor
+}
+
+// NewConcurrentAnalyzer returns a ConcurrentAnalyzer wrapping p.
+func NewConcurrentAnalyzer(p *CoreProcessor) *ConcurrentAnalyzer {
+	return &ConcurrentAnalyzer{CoreProcessor: p}
+}
+
+// Process analyzes the given device configuration like
+// CoreProcessor.Process, running the enabled analysis passes in parallel.
+func (a *ConcurrentAnalyzer) Process(ctx context.Context, cfg *common.CommonDevice, opts ...Option) (*Report, error) {
+	return a.process(ctx, cfg, runPassesConcurrently, opts...)
+}
+
+// recordedFinding is a finding added by a pass, in the order it was added.
+type recordedFinding struct {
+	severity Severity
+	finding  Finding
+}
+
+// passResult carries the findings of the pass at index back to
+// runPassesConcurrently.
+type passResult struct {
+	index    int
+	findings []recordedFinding
+}
+
+// runPassesConcurrently is the passRunner of ConcurrentAnalyzer. Each pass
+// runs in its own goroutine against a private Report, recording its findings
+// in the order it adds them; the records are sent over a channel and replayed
+// into report through AddFinding in pass order, so report.onFinding fires
+// exactly as it does for runPassesSequentially. Each pass is reported to
+// tracker as one step when its goroutine finishes, so steps arrive in
+// completion order.
+func runPassesConcurrently(
+	ctx context.Context,
+	cfg *common.CommonDevice,
+	passes []analysisPass,
+	report *Report,
+	logger *slog.Logger,
+	tracker progress.Tracker,
+) {
+	results := make(chan passResult, len(passes))
+
+	var wg sync.WaitGroup
+	for i, pass := range passes {
+		wg.Go(func() {
+			var mu sync.Mutex
+			var recorded []recordedFinding
+
+			scratch := &Report{onFinding: func(severity Severity, finding Finding) {
+				mu.Lock()
+				recorded = append(recorded, recordedFinding{severity: severity, finding: finding})
+				mu.Unlock()
+			}}
+			pass.run(cfg, scratch)
+			tracker.Step(1, analysisStepMessage(pass))
+
+			mu.Lock()
+			results <- passResult{index: i, findings: recorded}
+			mu.Unlock()
+		})
+	}
+
+	wg.Wait()
+	close(results)
+
+	byPass := make([][]recordedFinding, len(passes))
+	for result := range results {
+		byPass[result.index] = result.findings
+	}
+
+	for i, pass := range passes {
+		for _, r := range byPass[i] {
+			report.AddFinding(r.severity, r.finding)
+		}
+
+		logPassComplete(ctx, logger, pass, cfg, len(byPass[i]))
+	}
+}
@@ -0,0 +1,176 @@
+package processor
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/cfgparser"
+	"github.com/EvilBit-Labs/opnDossier/internal/progress"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/EvilBit-Labs/opnDossier/pkg/parser"
+	_ "github.com/EvilBit-Labs/opnDossier/pkg/parser/opnsense" // self-registers OPNsense parser via init()
+	_ "github.com/EvilBit-Labs/opnDossier/pkg/parser/pfsense"  // self-registers pfSense parser via init()
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConcurrentAnalyzer_MatchesSequential(t *testing.T) {
+	t.Parallel()
+
+	paths, err := filepath.Glob("../../testdata/*.xml")
+	require.NoError(t, err)
+	require.NotEmpty(t, paths)
+
+	core, err := NewCoreProcessor(nil)
+	require.NoError(t, err)
+	concurrent := NewConcurrentAnalyzer(core)
+
+	for _, path := range paths {
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			t.Parallel()
+
+			xmlData, err := os.ReadFile(path)
+			require.NoError(t, err)
+
+			cfg, _, err := parser.NewFactory(cfgparser.NewXMLParser()).CreateDevice(
+				context.Background(),
+				strings.NewReader(string(xmlData)),
+				common.DeviceTypeUnknown,
+				false,
+			)
+			require.NoError(t, err)
+
+			want, err := core.Process(context.Background(), cfg, WithAllFeatures())
+			require.NoError(t, err)
+			got, err := concurrent.Process(context.Background(), cfg, WithAllFeatures())
+			require.NoError(t, err)
+
+			assert.Equal(t, want.Findings, got.Findings)
+			assert.Equal(t, want.Rating, got.Rating)
+			assert.Equal(t, want.RiskScore, got.RiskScore)
+		})
+	}
+}
+
+func TestConcurrentAnalyzer_Errors(t *testing.T) {
+	t.Parallel()
+
+	core, err := NewCoreProcessor(nil)
+	require.NoError(t, err)
+	concurrent := NewConcurrentAnalyzer(core)
+
+	_, err = concurrent.Process(context.Background(), nil)
+	require.ErrorIs(t, err, ErrConfigurationNil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = concurrent.Process(ctx, &common.CommonDevice{})
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestRunPassesConcurrently_PassOrder(t *testing.T) {
+	t.Parallel()
+
+	// Later passes finish first; findings still land in pass order.
+	release := make(chan struct{})
+	passes := []analysisPass{
+		{name: "first", run: func(_ *common.CommonDevice, report *Report) {
+			<-release
+			report.AddFinding(SeverityLow, Finding{Title: "first-a"})
+			report.AddFinding(SeverityHigh, Finding{Title: "first-b"})
+		}},
+		{name: "second", run: func(_ *common.CommonDevice, report *Report) {
+			report.AddFinding(SeverityLow, Finding{Title: "second"})
+			close(release)
+		}},
+	}
+
+	report := NewReport(&common.CommonDevice{}, Config{})
+	var order []string
+	report.onFinding = func(_ Severity, f Finding) { order = append(order, f.Title) }
+
+	runPassesConcurrently(context.Background(), report.NormalizedConfig, passes, report, slog.New(slog.DiscardHandler), progress.NewNoOp())
+
+	assert.Equal(t, []string{"first-a", "first-b", "second"}, order)
+	assert.Equal(t, []Finding{{Title: "first-a"}, {Title: "second"}}, report.Findings.Low)
+	assert.Equal(t, []Finding{{Title: "first-b"}}, report.Findings.High)
+}
+
+// benchmarkPassConfig returns a configuration with ruleCount firewall rules
+// spread over a handful of interfaces and source networks, so the rule
+// passes have real work to do.
+func benchmarkPassConfig(ruleCount int) *common.CommonDevice {
+	ifaces := []string{"lan", "opt1", "opt2", "opt3"}
+
+	rules := make([]common.FirewallRule, ruleCount)
+	for i := range rules {
+		rules[i] = common.FirewallRule{
+			Type:        common.RuleTypePass,
+			Protocol:    "tcp",
+			Interfaces:  []string{ifaces[i%len(ifaces)]},
+			Source:      common.RuleEndpoint{Address: fmt.Sprintf("10.%d.%d.0/24", i/256%256, i%256)},
+			Destination: common.RuleEndpoint{Address: "any", Port: strconv.Itoa(1024 + i)},
+			Description: fmt.Sprintf("rule %d", i),
+		}
+	}
+
+	cfg := &common.CommonDevice{
+		System:        common.System{Hostname: "bench", Domain: "example.com"},
+		FirewallRules: rules,
+	}
+	for i, name := range append([]string{"wan"}, ifaces...) {
+		cfg.Interfaces = append(cfg.Interfaces, common.Interface{
+			Name:      name,
+			Enabled:   true,
+			IPAddress: fmt.Sprintf("192.168.%d.1", i),
+			Subnet:    "24",
+		})
+	}
+
+	return cfg
+}
+
+// BenchmarkAnalysisPasses compares the sequential and concurrent pass runners
+// on 1000 rules and 20 passes, cycling through every built-in pass.
+func BenchmarkAnalysisPasses(b *testing.B) {
+	const (
+		ruleCount = 1000
+		passCount = 20
+	)
+
+	core, err := NewCoreProcessor(nil)
+	require.NoError(b, err)
+
+	config := DefaultConfig()
+	config.ApplyOptions(WithAllFeatures())
+	builtin := core.analysisPasses(config)
+
+	passes := make([]analysisPass, passCount)
+	for i := range passes {
+		passes[i] = builtin[i%len(builtin)]
+	}
+
+	cfg := core.normalize(benchmarkPassConfig(ruleCount))
+	logger := slog.New(slog.DiscardHandler)
+
+	for _, bench := range []struct {
+		name string
+		run  passRunner
+	}{
+		{"sequential", runPassesSequentially},
+		{"concurrent", runPassesConcurrently},
+	} {
+		b.Run(bench.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				bench.run(context.Background(), cfg, passes, NewReport(cfg, Config{}), logger, progress.NewNoOp())
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package processor
+
+import (
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+)
+
+// Metadata keys set on findings that concern a firewall rule.
+const (
+	metadataInterface = "interface"
+	metadataTracker   = "tracker"
+)
+
+// annotateRuleFindings records the interface and tracker of the firewall rule
+// each rule finding concerns in the finding's Metadata, so that
+// DeduplicateFindings can group the findings without the configuration. A
+// check that reports a multi-interface rule once per interface sets the
+// interface itself, and that value is kept. Caller must hold mu.
+func (r *Report) annotateRuleFindings(cfg *common.CommonDevice) {
+	for _, findings := range [][]Finding{
+		r.Findings.Critical, r.Findings.High, r.Findings.Medium, r.Findings.Low, r.Findings.Info,
+	} {
+		for i := range findings {
+			rule, ok := findingRule(cfg, findings[i].Component)
+			if !ok {
+				continue
+			}
+
+			metadata := maps.Clone(findings[i].Metadata)
+			if metadata == nil {
+				metadata = make(map[string]string, 2)
+			}
+			if _, ok := metadata[metadataInterface]; !ok {
+				metadata[metadataInterface] = strings.Join(rule.Interfaces, ",")
+			}
+			metadata[metadataTracker] = rule.Tracker
+			findings[i].Metadata = metadata
+		}
+	}
+}
+
+// findingRule returns the firewall rule a "filter.rule[<i>]" component
+// refers to.
+func findingRule(cfg *common.CommonDevice, component string) (common.FirewallRule, bool) {
+	index, ok := strings.CutPrefix(component, "filter.rule[")
+	if !ok || cfg == nil {
+		return common.FirewallRule{}, false
+	}
+
+	i, err := strconv.Atoi(strings.TrimSuffix(index, "]"))
+	if err != nil || i < 0 || i >= len(cfg.FirewallRules) {
+		return common.FirewallRule{}, false
+	}
+
+	return cfg.FirewallRules[i], true
+}
+
+// DeduplicateFindings merges repeated firewall rule findings so that an issue
+// shared by many rules is reported once. Rule findings of the same type and
+// title on the same interface collapse into the first occurrence, which keeps its
+// fields and gains the number of merged findings in Count and the affected
+// rule trackers in Trackers. Findings that concern no firewall rule, and rule
+// findings that are not repeated, are returned unchanged. Order follows the
+// first occurrence of each group; the input is not modified.
+func DeduplicateFindings(findings []Finding) []Finding {
+	type group struct {
+		index    int
+		count    int
+		trackers []string
+	}
+
+	result := make([]Finding, 0, len(findings))
+	groups := make(map[string]*group)
+
+	for _, f := range findings {
+		tracker, isRule := f.Metadata[metadataTracker]
+		if !isRule {
+			result = append(result, f)
+			continue
+		}
+
+		key := f.Type + "\x00" + f.Title + "\x00" + f.Metadata[metadataInterface]
+		g, seen := groups[key]
+		if !seen {
+			g = &group{index: len(result)}
+			groups[key] = g
+			result = append(result, f)
+		}
+
+		g.count++
+		if tracker != "" && !slices.Contains(g.trackers, tracker) {
+			g.trackers = append(g.trackers, tracker)
+		}
+	}
+
+	for _, g := range groups {
+		if g.count < 2 {
+			continue
+		}
+
+		result[g.index].Count = g.count
+		result[g.index].Trackers = strings.Join(g.trackers, ",")
+	}
+
+	return result
+}
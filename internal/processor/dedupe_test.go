@@ -0,0 +1,138 @@
+package processor
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/constants"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ruleFinding returns a finding of the given type annotated with the rule
+// interface and tracker the way annotateRuleFindings records them.
+func ruleFinding(findingType, iface, tracker string) Finding {
+	return Finding{
+		Type:        findingType,
+		Title:       findingType,
+		Description: "rule " + tracker,
+		Metadata:    map[string]string{metadataInterface: iface, metadataTracker: tracker},
+	}
+}
+
+func TestDeduplicateFindings_AggregatesCount(t *testing.T) {
+	t.Parallel()
+
+	findings := []Finding{
+		ruleFinding("unlogged-rule", "wan", "100"),
+		{Type: "unused-interface", Title: "Unused Network Interface", Component: "interfaces.opt1"},
+		ruleFinding("unlogged-rule", "wan", "101"),
+		ruleFinding("unlogged-rule", "wan", "102"),
+		ruleFinding("unlogged-rule", "wan", "101"),
+	}
+
+	got := DeduplicateFindings(findings)
+
+	require.Len(t, got, 2)
+	assert.Equal(t, "rule 100", got[0].Description, "the first occurrence is kept as-is")
+	assert.Equal(t, 4, got[0].Count)
+	assert.Equal(t, "100,101,102", got[0].Trackers)
+	assert.Equal(t, "unused-interface", got[1].Type)
+	assert.Zero(t, got[1].Count)
+	assert.Zero(t, findings[0].Count, "the input must not be modified")
+}
+
+func TestDeduplicateFindings_KeepsDistinctGroups(t *testing.T) {
+	t.Parallel()
+
+	findings := []Finding{
+		ruleFinding("unlogged-rule", "wan", "100"),
+		ruleFinding("dead-rule", "wan", "101"),
+		ruleFinding("unlogged-rule", "lan", "102"),
+		{Type: "unlogged-rule", Title: "not a rule finding"},
+		{Type: "unlogged-rule", Title: "not a rule finding"},
+	}
+
+	got := DeduplicateFindings(findings)
+
+	require.Len(t, got, 5, "findings of different types or interfaces must not be merged")
+	for _, f := range got {
+		assert.Zero(t, f.Count)
+		assert.Empty(t, f.Trackers)
+	}
+}
+
+func TestReport_ToMarkdown_DeduplicatesRuleFindings(t *testing.T) {
+	t.Parallel()
+
+	cfg := &common.CommonDevice{}
+	for i := range 3 {
+		cfg.FirewallRules = append(cfg.FirewallRules, common.FirewallRule{
+			Type:       common.RuleTypePass,
+			Interfaces: []string{"wan"},
+			Tracker:    fmt.Sprintf("17000000%d", i),
+		})
+	}
+
+	report := NewReport(cfg, Config{})
+	for i := range cfg.FirewallRules {
+		report.AddFinding(SeverityMedium, Finding{
+			Type:        "unlogged-rule",
+			Title:       "Unlogged Perimeter Rule",
+			Description: fmt.Sprintf("Rule at position %d is not logged", i+1),
+			Component:   fmt.Sprintf("filter.rule[%d]", i),
+		})
+	}
+	report.annotateRuleFindings(cfg)
+
+	md := report.ToMarkdown()
+
+	assert.Contains(t, md, "1. Unlogged Perimeter Rule (×3, trackers: 170000000, 170000001, 170000002)")
+	assert.Equal(t, 1, strings.Count(md, "#### "), "the three findings should render as one entry")
+	assert.Len(t, report.Findings.Medium, 3, "the report itself keeps every finding")
+}
+
+func TestDeduplicateFindings_KeepsDistinctTitles(t *testing.T) {
+	t.Parallel()
+
+	wanRule := ruleFinding(constants.FindingTypeSecurity, "wan", "100")
+	wanRule.Title = "Overly Permissive WAN Rule"
+	broadRule := ruleFinding(constants.FindingTypeSecurity, "wan", "100")
+	broadRule.Title = "Overly Broad Pass Rule"
+
+	got := DeduplicateFindings([]Finding{wanRule, broadRule})
+
+	require.Len(t, got, 2, "different checks of the same type on one interface must not be merged")
+	assert.Equal(t, "Overly Permissive WAN Rule", got[0].Title)
+	assert.Equal(t, "Overly Broad Pass Rule", got[1].Title)
+	assert.Zero(t, got[0].Count)
+	assert.Zero(t, got[1].Count)
+}
+
+func TestReport_ToMarkdown_KeepsPerInterfaceRuleFindings(t *testing.T) {
+	t.Parallel()
+
+	cfg := &common.CommonDevice{
+		FirewallRules: []common.FirewallRule{{
+			Type:       common.RuleTypePass,
+			Interfaces: []string{"lan", "opt1"},
+			Source:     common.RuleEndpoint{Address: constants.NetworkAny},
+			Tracker:    "170000000",
+		}},
+	}
+
+	report := NewReport(cfg, Config{})
+	checkBroadPassRules(cfg, report)
+	report.annotateRuleFindings(cfg)
+
+	require.Len(t, report.Findings.High, 2)
+	assert.Equal(t, "lan", report.Findings.High[0].Metadata[metadataInterface])
+	assert.Equal(t, "opt1", report.Findings.High[1].Metadata[metadataInterface])
+
+	md := report.ToMarkdown()
+
+	assert.NotContains(t, md, "×2", "one rule reported per interface is not a repeated finding")
+	assert.Equal(t, 2, strings.Count(md, "#### "), "each interface keeps its own entry")
+}
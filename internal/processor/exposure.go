@@ -0,0 +1,19 @@
+package processor
+
+import (
+	"github.com/EvilBit-Labs/opnDossier/internal/analysis"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+)
+
+// PortExposureEntry is a type alias for the canonical
+// analysis.PortExposureEntry type: a port reachable from the WAN and the rule
+// that opens it.
+type PortExposureEntry = analysis.PortExposureEntry
+
+// WanPortExposure lists every port reachable from the WAN, combining enabled
+// pass rules on WAN interfaces with enabled inbound NAT port forwards. See
+// analysis.WANPortExposure for the rules and ordering. The markdown report
+// renders the same list as the WAN Port Exposure table.
+func WanPortExposure(device *common.CommonDevice) []PortExposureEntry {
+	return analysis.WANPortExposure(device)
+}
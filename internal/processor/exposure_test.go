@@ -0,0 +1,43 @@
+package processor
+
+import (
+	"testing"
+
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWanPortExposure(t *testing.T) {
+	t.Parallel()
+
+	device := &common.CommonDevice{
+		FirewallRules: []common.FirewallRule{
+			{
+				Type:        common.RuleTypePass,
+				Interfaces:  []string{"wan"},
+				Protocol:    "tcp",
+				Destination: common.RuleEndpoint{Address: "10.0.0.5", Port: "22"},
+			},
+			{
+				Type:        common.RuleTypePass,
+				Interfaces:  []string{"lan"},
+				Protocol:    "tcp",
+				Destination: common.RuleEndpoint{Port: "3389"},
+			},
+		},
+		NAT: common.NATConfig{InboundRules: []common.InboundNATRule{
+			{
+				Interfaces:   []string{"wan"},
+				Protocol:     "tcp",
+				ExternalPort: "22",
+				InternalIP:   "10.0.0.5",
+				Description:  "SSH to bastion",
+			},
+		}},
+	}
+
+	assert.Equal(t, []PortExposureEntry{
+		{Protocol: "tcp", Port: "22", Target: "10.0.0.5", SourceRule: "filter.rule[0]"},
+		{Protocol: "tcp", Port: "22", Target: "10.0.0.5", SourceRule: "nat.inbound[0]: SSH to bastion"},
+	}, WanPortExposure(device))
+}
@@ -0,0 +1,127 @@
+package processor
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+)
+
+// weakIPsecHashes are the entries of the weak IPsec algorithm list that are
+// hash algorithms. A weak hash is Medium; every other listed algorithm is a
+// cipher and is Critical.
+var weakIPsecHashes = []string{"md5", "sha1"}
+
+// DefaultWeakIPsecAlgorithms returns the IPsec algorithms flagged by the
+// Phase 1 and Phase 2 crypto checks when Config.WeakIPsecAlgorithms is not
+// changed: the DES and 3DES ciphers and the MD5 and SHA-1 hashes.
+func DefaultWeakIPsecAlgorithms() []string {
+	return []string{"3des", "md5", "sha1", "des"}
+}
+
+// weakIPsecAlgorithms returns the entries of weak that appear in algorithms,
+// in order of first appearance, with the severity of the worst one. An
+// algorithm name such as "3des", "aes-256", or "hmac_sha1" is split into
+// alphanumeric tokens and matches an entry equal to one of them, so "des"
+// does not match "3des". Entries are case-insensitive.
+func weakIPsecAlgorithms(algorithms, weak []string) ([]string, Severity) {
+	var found []string
+	severity := Severity("")
+
+	for _, algo := range algorithms {
+		tokens := strings.FieldsFunc(strings.ToLower(algo), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})
+
+		for _, entry := range weak {
+			entry = strings.ToLower(strings.TrimSpace(entry))
+			if entry == "" || !slices.Contains(tokens, entry) || slices.Contains(found, entry) {
+				continue
+			}
+
+			found = append(found, entry)
+			if !slices.Contains(weakIPsecHashes, entry) {
+				severity = SeverityCritical
+			} else if severity == "" {
+				severity = SeverityMedium
+			}
+		}
+	}
+
+	return found, severity
+}
+
+// checkIPsecPhase1Crypto detects enabled IPsec Phase 1 tunnels whose
+// encryption algorithms include an entry of weak. The finding is Critical
+// for a weak cipher and Medium when only weak hashes are used. Nothing is
+// reported when IPsec is disabled.
+func checkIPsecPhase1Crypto(cfg *common.CommonDevice, report *Report, weak []string) {
+	if !cfg.VPN.IPsec.Enabled {
+		return
+	}
+
+	for i, p1 := range cfg.VPN.IPsec.Phase1Tunnels {
+		if p1.Disabled {
+			continue
+		}
+
+		found, severity := weakIPsecAlgorithms(p1.EncryptionAlgorithms, weak)
+		if len(found) == 0 {
+			continue
+		}
+
+		report.AddFinding(severity, Finding{
+			Type:  "weak-ipsec-phase1-crypto",
+			Title: "Weak IPsec Phase 1 Algorithms",
+			Description: fmt.Sprintf(
+				"Phase 1 tunnel %d (%s) to %s negotiates %s, which no longer protect the IKE exchange",
+				i+1, ipsecTunnelName(p1.Description, p1.IKEID), p1.RemoteGateway, strings.ToUpper(strings.Join(found, ", ")),
+			),
+			Component:      fmt.Sprintf("ipsec.phase1[%d]", i),
+			Recommendation: "Use AES-GCM, or AES-256 with SHA-256 or stronger, and remove the weak algorithms from the proposal",
+		})
+	}
+}
+
+// checkIPsecPhase2Crypto detects enabled IPsec Phase 2 entries whose
+// encryption or hash algorithms include an entry of weak, with the same
+// severities as checkIPsecPhase1Crypto.
+func checkIPsecPhase2Crypto(cfg *common.CommonDevice, report *Report, weak []string) {
+	if !cfg.VPN.IPsec.Enabled {
+		return
+	}
+
+	for i, p2 := range cfg.VPN.IPsec.Phase2Tunnels {
+		if p2.Disabled {
+			continue
+		}
+
+		found, severity := weakIPsecAlgorithms(slices.Concat(p2.EncryptionAlgorithms, p2.HashAlgorithms), weak)
+		if len(found) == 0 {
+			continue
+		}
+
+		report.AddFinding(severity, Finding{
+			Type:  "weak-ipsec-phase2-crypto",
+			Title: "Weak IPsec Phase 2 Algorithms",
+			Description: fmt.Sprintf(
+				"Phase 2 entry %d (%s) negotiates %s, which no longer protect the tunnel traffic",
+				i+1, ipsecTunnelName(p2.Description, p2.UniqID), strings.ToUpper(strings.Join(found, ", ")),
+			),
+			Component:      fmt.Sprintf("ipsec.phase2[%d]", i),
+			Recommendation: "Use AES-GCM, or AES-256 with SHA-256 or stronger, and remove the weak algorithms from the proposal",
+		})
+	}
+}
+
+// ipsecTunnelName returns the tunnel's description, or its ID when it has
+// none.
+func ipsecTunnelName(description, id string) string {
+	if description != "" {
+		return description
+	}
+
+	return "ID " + id
+}
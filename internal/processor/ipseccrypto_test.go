@@ -0,0 +1,164 @@
+package processor
+
+import (
+	"context"
+	"testing"
+
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ipsecCryptoFindings returns the IPsec crypto findings of report as
+// "severity: component" strings.
+func ipsecCryptoFindings(report *Report) []string {
+	var got []string
+	for severity, findings := range map[Severity][]Finding{
+		SeverityCritical: report.Findings.Critical,
+		SeverityMedium:   report.Findings.Medium,
+	} {
+		for _, f := range findings {
+			if f.Type == "weak-ipsec-phase1-crypto" || f.Type == "weak-ipsec-phase2-crypto" {
+				got = append(got, string(severity)+": "+f.Component)
+			}
+		}
+	}
+	return got
+}
+
+func TestCheckIPsecCrypto(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		ipsec common.IPsecConfig
+		weak  []string
+		want  []string
+	}{
+		{
+			name: "strong algorithms",
+			ipsec: common.IPsecConfig{
+				Enabled:       true,
+				Phase1Tunnels: []common.IPsecPhase1Tunnel{{EncryptionAlgorithms: []string{"aes-256", "aes256gcm16"}}},
+				Phase2Tunnels: []common.IPsecPhase2Tunnel{{
+					EncryptionAlgorithms: []string{"aes-256"},
+					HashAlgorithms:       []string{"hmac_sha256"},
+				}},
+			},
+			weak: DefaultWeakIPsecAlgorithms(),
+		},
+		{
+			name: "3des phase 1 is critical",
+			ipsec: common.IPsecConfig{
+				Enabled:       true,
+				Phase1Tunnels: []common.IPsecPhase1Tunnel{{EncryptionAlgorithms: []string{"aes-256", "3des"}}},
+			},
+			weak: DefaultWeakIPsecAlgorithms(),
+			want: []string{"critical: ipsec.phase1[0]"},
+		},
+		{
+			name: "sha1 phase 2 hash is medium",
+			ipsec: common.IPsecConfig{
+				Enabled: true,
+				Phase2Tunnels: []common.IPsecPhase2Tunnel{{
+					EncryptionAlgorithms: []string{"aes-128"},
+					HashAlgorithms:       []string{"hmac_sha1", "hmac_md5"},
+				}},
+			},
+			weak: DefaultWeakIPsecAlgorithms(),
+			want: []string{"medium: ipsec.phase2[0]"},
+		},
+		{
+			name: "weak cipher outranks weak hash",
+			ipsec: common.IPsecConfig{
+				Enabled: true,
+				Phase2Tunnels: []common.IPsecPhase2Tunnel{{
+					EncryptionAlgorithms: []string{"DES"},
+					HashAlgorithms:       []string{"hmac_sha1"},
+				}},
+			},
+			weak: DefaultWeakIPsecAlgorithms(),
+			want: []string{"critical: ipsec.phase2[0]"},
+		},
+		{
+			name: "disabled tunnels are skipped",
+			ipsec: common.IPsecConfig{
+				Enabled:       true,
+				Phase1Tunnels: []common.IPsecPhase1Tunnel{{Disabled: true, EncryptionAlgorithms: []string{"3des"}}},
+				Phase2Tunnels: []common.IPsecPhase2Tunnel{{Disabled: true, EncryptionAlgorithms: []string{"des"}}},
+			},
+			weak: DefaultWeakIPsecAlgorithms(),
+		},
+		{
+			name: "custom list",
+			ipsec: common.IPsecConfig{
+				Enabled:       true,
+				Phase1Tunnels: []common.IPsecPhase1Tunnel{{EncryptionAlgorithms: []string{"blowfish-128", "3des"}}},
+			},
+			weak: []string{"Blowfish"},
+			want: []string{"critical: ipsec.phase1[0]"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := &common.CommonDevice{VPN: common.VPN{IPsec: tt.ipsec}}
+			report := &Report{}
+			checkIPsecPhase1Crypto(cfg, report, tt.weak)
+			checkIPsecPhase2Crypto(cfg, report, tt.weak)
+
+			assert.ElementsMatch(t, tt.want, ipsecCryptoFindings(report))
+		})
+	}
+
+	t.Run("ipsec disabled", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := &common.CommonDevice{VPN: common.VPN{IPsec: common.IPsecConfig{
+			Phase1Tunnels: []common.IPsecPhase1Tunnel{{EncryptionAlgorithms: []string{"3des"}}},
+		}}}
+		report := &Report{}
+		checkIPsecPhase1Crypto(cfg, report, DefaultWeakIPsecAlgorithms())
+		assert.Empty(t, ipsecCryptoFindings(report))
+	})
+}
+
+func TestWeakIPsecAlgorithms(t *testing.T) {
+	t.Parallel()
+
+	found, severity := weakIPsecAlgorithms(
+		[]string{"3des", "hmac_sha1", "aes-256", "sha1"},
+		DefaultWeakIPsecAlgorithms(),
+	)
+	assert.Equal(t, []string{"3des", "sha1"}, found, "des does not match 3des and sha1 is listed once")
+	assert.Equal(t, SeverityCritical, severity)
+}
+
+func TestCoreProcessor_WeakIPsecAlgorithms(t *testing.T) {
+	t.Parallel()
+
+	processor, err := NewCoreProcessor(nil)
+	require.NoError(t, err)
+
+	cfg := &common.CommonDevice{
+		System: common.System{Hostname: "fw", Domain: "example.com"},
+		VPN: common.VPN{IPsec: common.IPsecConfig{
+			Enabled: true,
+			Phase1Tunnels: []common.IPsecPhase1Tunnel{{
+				IKEID:                "1",
+				RemoteGateway:        "203.0.113.1",
+				EncryptionAlgorithms: []string{"3des"},
+			}},
+		}},
+	}
+
+	report, err := processor.Process(context.Background(), cfg, WithSecurityAnalysis())
+	require.NoError(t, err)
+	assert.Contains(t, ipsecCryptoFindings(report), "critical: ipsec.phase1[0]")
+
+	report, err = processor.Process(context.Background(), cfg, WithSecurityAnalysis(), WithWeakIPsecAlgorithms("des"))
+	require.NoError(t, err)
+	assert.Empty(t, ipsecCryptoFindings(report), "the custom list replaces the default")
+}
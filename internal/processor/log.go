@@ -0,0 +1,43 @@
+package processor
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+)
+
+// findingLogger returns a Report.onFinding hook that logs every finding added
+// to the report at Debug with its type, severity, component, and the
+// interface it concerns. Nothing is built when Debug is disabled.
+func findingLogger(ctx context.Context, logger *slog.Logger, cfg *common.CommonDevice) func(Severity, Finding) {
+	if !logger.Enabled(ctx, slog.LevelDebug) {
+		return nil
+	}
+
+	return func(severity Severity, f Finding) {
+		logger.DebugContext(ctx, "finding emitted",
+			"type", f.Type,
+			"severity", string(severity),
+			"component", f.Component,
+			"interface", findingInterface(cfg, f.Component),
+		)
+	}
+}
+
+// findingInterface resolves the interface a finding's component refers to:
+// the name itself for "interfaces.<name>", or the comma-separated interfaces
+// of the rule for "filter.rule[<i>]". Returns "" for any other component.
+func findingInterface(cfg *common.CommonDevice, component string) string {
+	if name, ok := strings.CutPrefix(component, "interfaces."); ok {
+		return name
+	}
+
+	rule, ok := findingRule(cfg, component)
+	if !ok {
+		return ""
+	}
+
+	return strings.Join(rule.Interfaces, ",")
+}
@@ -0,0 +1,145 @@
+package processor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/testutil"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// decodeLogRecords parses the JSON lines written by slog.NewJSONHandler.
+func decodeLogRecords(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+
+	var records []map[string]any
+
+	dec := json.NewDecoder(buf)
+	for dec.More() {
+		var rec map[string]any
+		require.NoError(t, dec.Decode(&rec))
+		records = append(records, rec)
+	}
+
+	return records
+}
+
+// loggingTestDevice returns a device whose analysis produces findings in
+// several passes: an unused interface and an overly broad WAN pass rule.
+func loggingTestDevice() *common.CommonDevice {
+	return testutil.NewDeviceBuilder().
+		WithHostname("fw").
+		WithInterface("wan", "").
+		WithInterface("lan", "").
+		WithInterface("opt1", "").
+		WithFirewallRule(common.FirewallRule{
+			Type:        common.RuleTypePass,
+			Interfaces:  []string{"wan"},
+			Source:      common.RuleEndpoint{Address: "any"},
+			Destination: common.RuleEndpoint{Address: "any"},
+		}).
+		WithFirewallRule(common.FirewallRule{
+			Type:        common.RuleTypePass,
+			Interfaces:  []string{"lan"},
+			Source:      common.RuleEndpoint{Address: "lan"},
+			Destination: common.RuleEndpoint{Address: "any"},
+			Description: "Default allow LAN",
+		}).
+		Build()
+}
+
+func TestCoreProcessor_StructuredLogging(t *testing.T) {
+	t.Parallel()
+
+	p, err := NewCoreProcessor(nil)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	h := slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})
+	device := loggingTestDevice()
+
+	report, err := p.Process(context.Background(), device, WithAllFeatures(), WithLogHandler(h))
+	require.NoError(t, err)
+
+	var (
+		passes         []string
+		passFindings   int
+		findingCount   int
+		ruleInterfaces []string
+	)
+
+	for _, rec := range decodeLogRecords(t, &buf) {
+		switch rec[slog.MessageKey] {
+		case "analysis pass complete":
+			assert.Equal(t, "INFO", rec[slog.LevelKey])
+			assert.InDelta(t, len(device.FirewallRules), rec["rules"], 0)
+
+			passes = append(passes, rec["pass"].(string))
+			passFindings += int(rec["findings"].(float64))
+		case "finding emitted":
+			assert.Equal(t, "DEBUG", rec[slog.LevelKey])
+			assert.NotEmpty(t, rec["type"])
+			assert.NotEmpty(t, rec["severity"])
+			assert.Contains(t, rec, "interface")
+
+			findingCount++
+
+			if strings.HasPrefix(rec["component"].(string), "filter.rule[") {
+				ruleInterfaces = append(ruleInterfaces, rec["interface"].(string))
+			}
+		}
+	}
+
+	assert.Equal(t, []string{
+		"dead rules", "unused interfaces", "interface references", "address plan",
+		"consistency", "security", "ipsec crypto", "wan filtering", "performance",
+	}, passes)
+	assert.Equal(t, report.TotalFindings(), findingCount, "every finding should be logged once")
+	assert.LessOrEqual(t, passFindings, findingCount, "pass counts cover analysis findings only")
+	assert.Contains(t, ruleInterfaces, "wan", "the broad WAN pass rule finding should name its interface")
+}
+
+func TestCoreProcessor_TextLogHandlerOmitsDebugRecords(t *testing.T) {
+	t.Parallel()
+
+	p, err := NewCoreProcessor(nil)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+
+	_, err = p.Process(context.Background(), loggingTestDevice(),
+		WithSecurityAnalysis(), WithLogHandler(slog.NewTextHandler(&buf, nil)))
+	require.NoError(t, err)
+
+	assert.Contains(t, buf.String(), `msg="analysis pass complete" pass=security`)
+	assert.NotContains(t, buf.String(), "finding emitted")
+}
+
+func TestFindingInterface(t *testing.T) {
+	t.Parallel()
+
+	cfg := &common.CommonDevice{
+		FirewallRules: []common.FirewallRule{{Interfaces: []string{"wan", "opt1"}}},
+	}
+
+	tests := []struct {
+		component string
+		want      string
+	}{
+		{"interfaces.opt2", "opt2"},
+		{"filter.rule[0]", "wan,opt1"},
+		{"filter.rule[5]", ""},
+		{"filter.rule[x]", ""},
+		{"system.webgui.protocol", ""},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, findingInterface(cfg, tt.component), tt.component)
+	}
+}
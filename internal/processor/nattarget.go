@@ -0,0 +1,199 @@
+package processor
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+)
+
+// natTargetCategory describes why a NAT target address cannot receive
+// translated traffic.
+type natTargetCategory struct {
+	kind     string
+	severity Severity
+	// detail is appended to the finding description, e.g. to say how the
+	// category was inferred.
+	detail string
+}
+
+// Categories of invalid NAT target addresses.
+var (
+	natTargetBroadcast       = natTargetCategory{kind: "broadcast", severity: SeverityMedium}
+	natTargetLikelyBroadcast = natTargetCategory{
+		kind:     "likely broadcast",
+		severity: SeverityLow,
+		detail:   "; no interface subnet contains it, so this is inferred from the .255 ending",
+	}
+	natTargetMulticast = natTargetCategory{kind: "multicast", severity: SeverityMedium}
+	natTargetLoopback  = natTargetCategory{kind: "loopback", severity: SeverityHigh}
+	natTargetLinkLocal = natTargetCategory{kind: "link-local", severity: SeverityMedium}
+)
+
+// checkNATTargetAddresses detects enabled NAT rules whose translation target
+// is a broadcast, multicast, loopback, or link-local address, none of which
+// can stand in for a single routed host. The target is the translation
+// address of an outbound rule and the redirect address of a port forward;
+// targets that are not a literal IP address, such as aliases, networks, or
+// interface addresses, are skipped.
+//
+// An IPv4 target is a broadcast address when it is the last address of the
+// subnet of an interface that contains it. When no interface subnet contains
+// it, an address ending in .255 is reported as a likely broadcast address at
+// Low severity. A loopback translation address is High, as outbound traffic
+// would be delivered to the firewall itself; a port forward to loopback is
+// the usual way to redirect clients to a local service such as Unbound DNS,
+// so it is reported as Info. The other categories are Medium.
+func checkNATTargetAddresses(cfg *common.CommonDevice, report *Report) {
+	subnets := interfaceIPv4Subnets(cfg.Interfaces)
+
+	for i, rule := range cfg.NAT.OutboundRules {
+		if rule.Disabled {
+			continue
+		}
+
+		addr, ok := parseNATTarget(rule.Target)
+		if !ok {
+			continue
+		}
+
+		reportInvalidNATTarget(report, subnets, fmt.Sprintf("nat.outbound[%d]", i),
+			fmt.Sprintf("Outbound NAT rule at position %d translates to", i+1), addr)
+	}
+
+	for i, rule := range cfg.NAT.InboundRules {
+		if rule.Disabled {
+			continue
+		}
+
+		addr, ok := parseNATTarget(rule.InternalIP)
+		if !ok {
+			continue
+		}
+
+		component := fmt.Sprintf("nat.inbound[%d]", i)
+		if addr.IsLoopback() {
+			report.AddFinding(SeverityInfo, Finding{
+				Type:  "nat-redirect-to-firewall",
+				Title: "Port Forward to Firewall Service",
+				Description: fmt.Sprintf(
+					"Port forward at position %d redirects to %s, a service on the firewall itself",
+					i+1, addr,
+				),
+				Component: component,
+				Recommendation: "Confirm the redirect to a local service, such as forcing clients onto " +
+					"the local DNS resolver, is intended",
+			})
+
+			continue
+		}
+
+		reportInvalidNATTarget(report, subnets, component,
+			fmt.Sprintf("Port forward at position %d redirects to", i+1), addr)
+	}
+}
+
+// parseNATTarget parses target as a literal IP address. It reports false for
+// aliases, networks, interface names, and unset targets.
+func parseNATTarget(target string) (netip.Addr, bool) {
+	addr, err := netip.ParseAddr(strings.TrimSpace(target))
+	if err != nil {
+		return netip.Addr{}, false
+	}
+
+	return addr.Unmap(), true
+}
+
+// reportInvalidNATTarget adds an "invalid-nat-target" finding for addr
+// when it is an address that cannot receive translated traffic. prefix
+// describes the rule and its action.
+func reportInvalidNATTarget(
+	report *Report,
+	subnets map[string]netip.Prefix,
+	component, prefix string,
+	addr netip.Addr,
+) {
+	category, ok := classifyNATTarget(addr, subnets)
+	if !ok {
+		return
+	}
+
+	report.AddFinding(category.severity, Finding{
+		Type:  "invalid-nat-target",
+		Title: "Invalid NAT Target Address",
+		Description: fmt.Sprintf("%s %s, a %s address that cannot receive translated traffic%s",
+			prefix, addr, category.kind, category.detail),
+		Component:      component,
+		Recommendation: "Set the NAT target to the unicast address of the host that should receive the traffic",
+	})
+}
+
+// classifyNATTarget returns the category of addr when it is not a valid NAT
+// target. subnets are the interface IPv4 subnets used to recognize directed
+// broadcast addresses.
+func classifyNATTarget(addr netip.Addr, subnets map[string]netip.Prefix) (natTargetCategory, bool) {
+	switch {
+	case addr.IsLoopback():
+		return natTargetLoopback, true
+	case addr.IsMulticast():
+		return natTargetMulticast, true
+	case addr.IsLinkLocalUnicast():
+		return natTargetLinkLocal, true
+	case !addr.Is4():
+		return natTargetCategory{}, false
+	case addr == netip.AddrFrom4([4]byte{255, 255, 255, 255}):
+		return natTargetBroadcast, true
+	}
+
+	if subnet, ok := owningSubnet(addr, subnets); ok {
+		// /31 and /32 subnets have no broadcast address.
+		if subnet.Bits() < 31 && addr == subnetBroadcast(subnet) {
+			return natTargetBroadcast, true
+		}
+
+		return natTargetCategory{}, false
+	}
+
+	if addr.As4()[3] == 0xff {
+		return natTargetLikelyBroadcast, true
+	}
+
+	return natTargetCategory{}, false
+}
+
+// owningSubnet returns the most specific interface subnet that contains
+// addr.
+func owningSubnet(addr netip.Addr, subnets map[string]netip.Prefix) (netip.Prefix, bool) {
+	var (
+		best  netip.Prefix
+		found bool
+	)
+
+	for _, subnet := range subnets {
+		if !subnet.Contains(addr) {
+			continue
+		}
+
+		if !found || subnet.Bits() > best.Bits() {
+			best, found = subnet, true
+		}
+	}
+
+	return best, found
+}
+
+// subnetBroadcast returns the last address of the IPv4 subnet, its directed
+// broadcast address.
+func subnetBroadcast(subnet netip.Prefix) netip.Addr {
+	ip := subnet.Masked().Addr().As4()
+	hostBits := 32 - subnet.Bits()
+
+	for i := 3; i >= 0 && hostBits > 0; i-- {
+		n := min(hostBits, 8)
+		ip[i] |= byte(1<<n - 1)
+		hostBits -= n
+	}
+
+	return netip.AddrFrom4(ip)
+}
@@ -0,0 +1,125 @@
+package processor
+
+import (
+	"context"
+	"testing"
+
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// natTargetFindings returns the invalid-nat-target and
+// nat-redirect-to-firewall findings of report as "severity: component"
+// strings.
+func natTargetFindings(report *Report) []string {
+	var got []string
+	for _, bucket := range []struct {
+		severity string
+		findings []Finding
+	}{
+		{"high", report.Findings.High},
+		{"medium", report.Findings.Medium},
+		{"low", report.Findings.Low},
+		{"info", report.Findings.Info},
+	} {
+		for _, f := range bucket.findings {
+			if f.Type == "invalid-nat-target" || f.Type == "nat-redirect-to-firewall" {
+				got = append(got, bucket.severity+": "+f.Component)
+			}
+		}
+	}
+	return got
+}
+
+func TestCheckNATTargetAddresses(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		target string
+		want   []string
+	}{
+		{name: "unicast host is valid", target: "192.168.1.10"},
+		{name: "broadcast of an interface subnet", target: "10.0.1.255", want: []string{"medium: nat.outbound[0]"}},
+		{name: "host ending in .255 inside a /23", target: "10.0.0.255"},
+		{name: "broadcast of a /26 not ending in .255", target: "10.20.0.63", want: []string{"medium: nat.outbound[0]"}},
+		{name: "host in a /31 is valid", target: "10.30.0.255"},
+		{name: "unresolved .255 is a likely broadcast", target: "192.168.1.255", want: []string{"low: nat.outbound[0]"}},
+		{name: "limited broadcast", target: "255.255.255.255", want: []string{"medium: nat.outbound[0]"}},
+		{name: "IPv4 multicast", target: "239.1.2.3", want: []string{"medium: nat.outbound[0]"}},
+		{name: "IPv6 multicast", target: "ff02::1", want: []string{"medium: nat.outbound[0]"}},
+		{name: "loopback", target: "127.0.0.1", want: []string{"high: nat.outbound[0]"}},
+		{name: "loopback outside 127.0.0.1", target: "127.10.0.5", want: []string{"high: nat.outbound[0]"}},
+		{name: "IPv6 loopback", target: "::1", want: []string{"high: nat.outbound[0]"}},
+		{name: "IPv4 link-local", target: "169.254.10.20", want: []string{"medium: nat.outbound[0]"}},
+		{name: "IPv6 link-local", target: "fe80::1", want: []string{"medium: nat.outbound[0]"}},
+		{name: "IPv6 unicast is valid", target: "2001:db8::10"},
+		{name: "alias is skipped", target: "web_servers"},
+		{name: "network is skipped", target: "10.0.0.0/24"},
+		{name: "unset target is skipped"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := &common.CommonDevice{
+				Interfaces: []common.Interface{
+					{Name: "lan", IPAddress: "10.0.0.1", Subnet: "23"},
+					{Name: "opt1", IPAddress: "10.20.0.1", Subnet: "26"},
+					{Name: "opt2", IPAddress: "10.30.0.254", Subnet: "31"},
+				},
+				NAT: common.NATConfig{
+					OutboundRules: []common.NATRule{{Target: tt.target}},
+				},
+			}
+			report := NewReport(cfg, Config{})
+			checkNATTargetAddresses(cfg, report)
+			assert.Equal(t, tt.want, natTargetFindings(report))
+		})
+	}
+}
+
+func TestCheckNATTargetAddresses_PortForwards(t *testing.T) {
+	t.Parallel()
+
+	cfg := &common.CommonDevice{NAT: common.NATConfig{
+		InboundRules: []common.InboundNATRule{
+			{InternalIP: "10.0.0.5"},
+			{InternalIP: "127.0.0.1"},
+			{InternalIP: "10.0.0.255", Disabled: true},
+			{InternalIP: "224.0.0.251"},
+		},
+		OutboundRules: []common.NATRule{
+			{Target: "127.0.0.1", Disabled: true},
+		},
+	}}
+
+	report := NewReport(cfg, Config{})
+	checkNATTargetAddresses(cfg, report)
+	assert.Equal(t, []string{"medium: nat.inbound[3]", "info: nat.inbound[1]"}, natTargetFindings(report))
+	assert.Empty(t, report.Findings.High, "a port forward to loopback is a local service redirect, not a misconfiguration")
+	require.Len(t, report.Findings.Info, 1)
+	assert.Equal(t, "Port Forward to Firewall Service", report.Findings.Info[0].Title)
+	assert.Contains(t, report.Findings.Info[0].Description,
+		"Port forward at position 2 redirects to 127.0.0.1, a service on the firewall itself")
+}
+
+func TestCoreProcessor_NATTargetAddresses(t *testing.T) {
+	t.Parallel()
+
+	processor, err := NewCoreProcessor(nil)
+	require.NoError(t, err)
+
+	cfg := &common.CommonDevice{
+		System: common.System{Hostname: "fw", Domain: "example.com"},
+		NAT: common.NATConfig{
+			OutboundRules: []common.NATRule{{Target: "192.168.1.255"}},
+		},
+	}
+
+	report, err := processor.Process(context.Background(), cfg, WithComplianceCheck())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"low: nat.outbound[0]"}, natTargetFindings(report))
+}
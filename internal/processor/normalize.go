@@ -20,6 +20,8 @@ func (p *CoreProcessor) normalize(cfg *common.CommonDevice) *common.CommonDevice
 	normalized.Groups = slices.Clone(cfg.Groups)
 	normalized.Sysctl = slices.Clone(cfg.Sysctl)
 	normalized.LoadBalancer.MonitorTypes = slices.Clone(cfg.LoadBalancer.MonitorTypes)
+	normalized.LoadBalancer.Pools = slices.Clone(cfg.LoadBalancer.Pools)
+	normalized.LoadBalancer.VirtualServers = slices.Clone(cfg.LoadBalancer.VirtualServers)
 	// Defensive clones — not mutated by normalize phases, but contain sensitive
 	// fields that downstream code must not accidentally leak back to the caller.
 	normalized.Certificates = slices.Clone(cfg.Certificates)
@@ -127,6 +129,14 @@ func (p *CoreProcessor) sortSlices(cfg *common.CommonDevice) {
 	slices.SortFunc(cfg.LoadBalancer.MonitorTypes, func(a, b common.MonitorType) int {
 		return strings.Compare(a.Name, b.Name)
 	})
+
+	// Sort load balancer pools and virtual servers by name
+	slices.SortFunc(cfg.LoadBalancer.Pools, func(a, b common.LBPool) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	slices.SortFunc(cfg.LoadBalancer.VirtualServers, func(a, b common.LBVirtualServer) int {
+		return strings.Compare(a.Name, b.Name)
+	})
 }
 
 // canonicalizeIPField normalizes an IP/CIDR field in-place, converting bare IPs
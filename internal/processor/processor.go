@@ -7,11 +7,13 @@ package processor
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"runtime/debug"
 	"strings"
 
 	"github.com/EvilBit-Labs/opnDossier/internal/converter"
 	"github.com/EvilBit-Labs/opnDossier/internal/logging"
+	"github.com/EvilBit-Labs/opnDossier/internal/progress"
 	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
 )
 
@@ -38,19 +40,20 @@ type Processor interface {
 // not be mutated while a downstream consumer is still reading the resulting
 // Report.NormalizedConfig. normalize() shallow-copies the input and clones
 // the slices it sorts (FirewallRules, Users, Groups, Sysctl,
-// LoadBalancer.MonitorTypes) plus credential-bearing slices it never mutates
+// LoadBalancer.MonitorTypes/Pools/VirtualServers) plus credential-bearing slices it never mutates
 // but defensively isolates from the caller (Certificates, DHCP and its
 // AdvancedV4/V6 pointers, VPN.WireGuard.Clients). All other CommonDevice
 // slices (Interfaces, VLANs, Bridges, CAs, etc.) share their backing arrays
 // with the caller's struct. See GOTCHAS.md §21 for the full invariant.
 type CoreProcessor struct {
-	logger     *logging.Logger
+	logger     *slog.Logger
 	validateFn func(*common.CommonDevice) []ValidationError
 }
 
 // NewCoreProcessor returns a new CoreProcessor with logging and CommonDevice
 // semantic validation configured. If logger is nil, a default logger writing
-// to stderr is created.
+// to stderr is created. The processor emits its records through log/slog,
+// using logger as the handler unless a call supplies WithLogHandler.
 func NewCoreProcessor(logger *logging.Logger) (*CoreProcessor, error) {
 	if logger == nil {
 		var err error
@@ -62,13 +65,23 @@ func NewCoreProcessor(logger *logging.Logger) (*CoreProcessor, error) {
 	}
 
 	return &CoreProcessor{
-		logger:     logger,
+		logger:     slog.New(logger.Logger),
 		validateFn: ValidateCommonDevice,
 	}, nil
 }
 
 // Process analyzes the given device configuration and returns a comprehensive report.
 func (p *CoreProcessor) Process(ctx context.Context, cfg *common.CommonDevice, opts ...Option) (*Report, error) {
+	return p.process(ctx, cfg, runPassesSequentially, opts...)
+}
+
+// process implements Process, running the analysis passes with run.
+func (p *CoreProcessor) process(
+	ctx context.Context,
+	cfg *common.CommonDevice,
+	run passRunner,
+	opts ...Option,
+) (*Report, error) {
 	// Check for context cancellation before starting
 	select {
 	case <-ctx.Done():
@@ -94,7 +107,7 @@ func (p *CoreProcessor) Process(ctx context.Context, cfg *common.CommonDevice, o
 	}
 
 	// Phase 2: Validate the configuration
-	logger := p.logger
+	logger := config.logger(p.logger)
 
 	var validationErrors []ValidationError
 	func() {
@@ -103,7 +116,7 @@ func (p *CoreProcessor) Process(ctx context.Context, cfg *common.CommonDevice, o
 				// Gate stack dumps behind verbose logging — function names in
 				// stack traces can leak internal plugin/validator paths into
 				// centralized logs.
-				if logger.IsVerbose() {
+				if logger.Enabled(ctx, slog.LevelDebug) {
 					logger.Error("validation panic recovered", "panic", r, "stack", string(debug.Stack()))
 				} else {
 					logger.Error("validation panic recovered", "panic", r)
@@ -126,6 +139,7 @@ func (p *CoreProcessor) Process(ctx context.Context, cfg *common.CommonDevice, o
 
 	// Create the report
 	report := NewReport(normalizedCfg, *config)
+	report.onFinding = findingLogger(ctx, logger, normalizedCfg)
 
 	for _, validationErr := range validationErrors {
 		severity := SeverityHigh
@@ -149,7 +163,14 @@ func (p *CoreProcessor) Process(ctx context.Context, cfg *common.CommonDevice, o
 	}
 
 	// Phase 3: Analyze the configuration
-	p.analyze(ctx, normalizedCfg, config, report)
+	p.analyze(ctx, normalizedCfg, config, report, logger, run)
+
+	report.mu.Lock()
+	report.annotateRuleFindings(normalizedCfg)
+	report.Rating, report.RatingReason = config.RatingThresholds.Rate(report.Findings)
+	riskScore := report.riskScoreUnsafe()
+	report.RiskScore = &riskScore
+	report.mu.Unlock()
 
 	// Check for context cancellation
 	select {
@@ -208,6 +229,39 @@ type Config struct {
 	EnablePerformanceAnalysis bool
 	// EnableComplianceCheck controls whether to check compliance with best practices
 	EnableComplianceCheck bool
+	// RatingThresholds maps the report's finding counts to its posture rating
+	RatingThresholds RatingThresholds
+	// WeakIPsecAlgorithms lists the IPsec ciphers and hashes the security
+	// analysis flags in Phase 1 and Phase 2 tunnels
+	WeakIPsecAlgorithms []string
+	// PerimeterInterfaces lists the WAN-facing interface names the security
+	// analysis expects to block private and bogon networks
+	PerimeterInterfaces []string
+	// Progress receives one step per analysis pass; nil disables reporting
+	Progress progress.Tracker `json:"-" yaml:"-"`
+	// LogHandler receives the processor's structured log records (e.g. a
+	// slog.JSONHandler or slog.TextHandler); nil uses the logger passed to
+	// NewCoreProcessor
+	LogHandler slog.Handler `json:"-" yaml:"-"`
+}
+
+// tracker returns the configured progress tracker, or a no-op tracker when none is set.
+func (c *Config) tracker() progress.Tracker {
+	if c.Progress == nil {
+		return progress.NewNoOp()
+	}
+
+	return c.Progress
+}
+
+// logger returns a slog.Logger writing to the configured LogHandler, or
+// fallback when none is set.
+func (c *Config) logger(fallback *slog.Logger) *slog.Logger {
+	if c.LogHandler == nil {
+		return fallback
+	}
+
+	return slog.New(c.LogHandler)
 }
 
 // WithStats enables statistics generation in the processor.
@@ -245,6 +299,45 @@ func WithComplianceCheck() Option {
 	}
 }
 
+// WithProgress reports analysis passes to the given tracker.
+func WithProgress(t progress.Tracker) Option {
+	return func(config *Config) {
+		config.Progress = t
+	}
+}
+
+// WithLogHandler sends the processor's structured log records to h.
+func WithLogHandler(h slog.Handler) Option {
+	return func(config *Config) {
+		config.LogHandler = h
+	}
+}
+
+// WithRatingThresholds sets the thresholds used to compute the report's
+// posture rating in place of DefaultRatingThresholds.
+func WithRatingThresholds(t RatingThresholds) Option {
+	return func(config *Config) {
+		config.RatingThresholds = t
+	}
+}
+
+// WithWeakIPsecAlgorithms sets the IPsec algorithms the security analysis
+// flags in place of DefaultWeakIPsecAlgorithms.
+func WithWeakIPsecAlgorithms(algorithms ...string) Option {
+	return func(config *Config) {
+		config.WeakIPsecAlgorithms = algorithms
+	}
+}
+
+// WithPerimeterInterfaces sets the WAN-facing interface names the security
+// analysis checks for private and bogon network blocking in place of
+// DefaultPerimeterInterfaces.
+func WithPerimeterInterfaces(names ...string) Option {
+	return func(config *Config) {
+		config.PerimeterInterfaces = names
+	}
+}
+
 // WithAllFeatures enables all available analysis features.
 func WithAllFeatures() Option {
 	return func(config *Config) {
@@ -264,6 +357,9 @@ func DefaultConfig() *Config {
 		EnableSecurityAnalysis:    false,
 		EnablePerformanceAnalysis: false,
 		EnableComplianceCheck:     false,
+		RatingThresholds:          DefaultRatingThresholds(),
+		WeakIPsecAlgorithms:       DefaultWeakIPsecAlgorithms(),
+		PerimeterInterfaces:       DefaultPerimeterInterfaces(),
 	}
 }
 
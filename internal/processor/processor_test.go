@@ -10,6 +10,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/EvilBit-Labs/opnDossier/internal/testutil"
 	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -205,6 +206,74 @@ func TestReport_ToMarkdown(t *testing.T) {
 	assert.Contains(t, markdown, "High (1)")
 }
 
+func TestReport_ExecutiveSummary(t *testing.T) {
+	t.Parallel()
+
+	cfg := &common.CommonDevice{
+		DeviceType:    common.DeviceTypeOPNsense,
+		Interfaces:    []common.Interface{{Name: "wan"}, {Name: "lan"}},
+		FirewallRules: make([]common.FirewallRule, 7),
+	}
+
+	t.Run("no findings", func(t *testing.T) {
+		t.Parallel()
+
+		report := NewReport(cfg, *DefaultConfig())
+		report.AddFinding(SeverityInfo, Finding{Title: "Informational Note"})
+
+		assert.Equal(t,
+			"This OPNsense device has 7 firewall rules across 2 interfaces. "+
+				"Analysis found 0 critical, 0 high, 0 medium, and 0 low findings. "+
+				"No issues requiring attention were identified.",
+			report.ExecutiveSummary())
+	})
+
+	t.Run("counts and top three titles", func(t *testing.T) {
+		t.Parallel()
+
+		report := NewReport(cfg, *DefaultConfig())
+		report.AddFinding(SeverityLow, Finding{Title: "Low Issue"})
+		report.AddFinding(SeverityMedium, Finding{Title: "Medium Issue"})
+		report.AddFinding(SeverityMedium, Finding{Title: "Second Medium Issue"})
+		report.AddFinding(SeverityHigh, Finding{Title: "High Issue"})
+		report.AddFinding(SeverityCritical, Finding{Title: "Critical Issue"})
+		report.AddFinding(SeverityInfo, Finding{Title: "Informational Note"})
+
+		assert.Equal(t,
+			"This OPNsense device has 7 firewall rules across 2 interfaces. "+
+				"Analysis found 1 critical, 1 high, 2 medium, and 1 low findings. "+
+				"The most critical issues are: Critical Issue; High Issue; Medium Issue. "+
+				"Immediate attention is recommended for the Critical and High findings.",
+			report.ExecutiveSummary())
+	})
+
+	t.Run("single medium finding without normalized config", func(t *testing.T) {
+		t.Parallel()
+
+		report := NewReport(nil, *DefaultConfig())
+		report.AddFinding(SeverityMedium, Finding{Title: "Medium Issue"})
+
+		assert.Equal(t,
+			"This device has 0 firewall rules across 0 interfaces. "+
+				"Analysis found 0 critical, 0 high, 1 medium, and 0 low findings. "+
+				"The most critical issue is: Medium Issue.",
+			report.ExecutiveSummary())
+	})
+
+	t.Run("rendered at the top of the markdown report", func(t *testing.T) {
+		t.Parallel()
+
+		report := NewReport(cfg, *DefaultConfig())
+		report.AddFinding(SeverityHigh, Finding{Title: "High Issue"})
+
+		md := report.ToMarkdown()
+		summaryAt := strings.Index(md, "## Executive Summary")
+		require.NotEqual(t, -1, summaryAt)
+		assert.Less(t, summaryAt, strings.Index(md, "## Configuration Information"))
+		assert.Contains(t, md, report.ExecutiveSummary())
+	})
+}
+
 func TestReport_Summary(t *testing.T) {
 	cfg := &common.CommonDevice{
 		System: common.System{
@@ -238,6 +307,8 @@ func TestDefaultConfig(t *testing.T) {
 	assert.False(t, config.EnableSecurityAnalysis)
 	assert.False(t, config.EnablePerformanceAnalysis)
 	assert.False(t, config.EnableComplianceCheck)
+	assert.Equal(t, DefaultWeakIPsecAlgorithms(), config.WeakIPsecAlgorithms)
+	assert.Equal(t, DefaultPerimeterInterfaces(), config.PerimeterInterfaces)
 }
 
 func TestProcessorOptions(t *testing.T) {
@@ -311,9 +382,58 @@ func TestNewReport(t *testing.T) {
 	assert.Equal(t, 1, report.Statistics.TotalGroups)
 	assert.Equal(t, 2, report.Statistics.TotalFirewallRules)
 	assert.Equal(t, 1, report.Statistics.SysctlSettings)
+	assert.NotNil(t, report.InterfaceRuleCounts)
 	assert.WithinDuration(t, time.Now(), report.GeneratedAt, time.Second)
 }
 
+func TestInterfaceRuleCount(t *testing.T) {
+	t.Parallel()
+
+	cfg := &common.CommonDevice{
+		Interfaces: []common.Interface{{Name: "wan"}, {Name: "lan"}},
+		FirewallRules: []common.FirewallRule{
+			{Type: common.RuleTypePass, Interfaces: []string{"lan"}},
+			{Type: common.RuleTypeBlock, Interfaces: []string{"wan"}},
+			{Type: common.RuleTypeReject, Interfaces: []string{"wan"}},
+		},
+	}
+
+	assert.Equal(t, map[string]RuleCount{
+		"wan": {Block: 2, Total: 2},
+		"lan": {Pass: 1, Total: 1},
+	}, InterfaceRuleCount(cfg))
+
+	report := NewReport(cfg, Config{EnableStats: true})
+	assert.Equal(t, InterfaceRuleCount(cfg), report.InterfaceRuleCounts)
+	assert.Nil(t, NewReport(cfg, Config{}).InterfaceRuleCounts, "counts are only computed with statistics")
+}
+
+func TestBuildInterfaceIndex(t *testing.T) {
+	t.Parallel()
+
+	cfg := &common.CommonDevice{
+		Interfaces: []common.Interface{{Name: "wan", Enabled: true}, {Name: "opt1", Enabled: true}},
+		FirewallRules: []common.FirewallRule{
+			{Type: common.RuleTypeBlock, Interfaces: []string{"wan"}},
+		},
+		NAT: common.NATConfig{InboundRules: []common.InboundNATRule{{Interfaces: []string{"opt1"}}}},
+	}
+
+	index := BuildInterfaceIndex(cfg)
+	assert.Equal(t, InterfaceReferences{FirewallRules: []int{1}}, index["wan"])
+	assert.Equal(t, InterfaceReferences{InboundNAT: []int{1}}, index["opt1"])
+
+	report := NewReport(cfg, Config{})
+	processor := &CoreProcessor{}
+	processor.analyzeUnusedInterfaces(cfg, report)
+
+	var components []string
+	for _, f := range report.Findings.Low {
+		components = append(components, f.Component)
+	}
+	assert.Equal(t, []string{"interfaces.opt1"}, components, "unused-interface analysis reads the index")
+}
+
 // TestCoreProcessor_NormalizationIdempotence tests that normalization is idempotent
 // (applying it multiple times yields the same result).
 func TestCoreProcessor_NormalizationIdempotence(t *testing.T) {
@@ -698,44 +818,27 @@ func generateManyRules(count int) []common.FirewallRule {
 
 // generateSmallConfig creates a small configuration for benchmarking.
 func generateSmallConfig() *common.CommonDevice {
-	return &common.CommonDevice{
-		System: common.System{
-			Hostname: "small-config",
-			Domain:   "example.com",
-			WebGUI:   common.WebGUI{Protocol: "https"},
-			SSH:      common.SSH{Group: "admins"},
-			Bogons:   common.Bogons{Interval: "monthly"},
-		},
-		Users: []common.User{
-			{Name: "admin", UID: "1000", Scope: "local"},
-			{Name: "user1", UID: "1001", Scope: "local"},
-		},
-		Groups: []common.Group{
-			{Name: "admins", GID: "1000", Scope: "local"},
-		},
-		Interfaces: []common.Interface{
-			{
-				Name:      "wan",
-				Enabled:   true,
-				IPAddress: "203.0.113.1",
-				Subnet:    "24",
-			},
-			{
-				Name:      "lan",
-				Enabled:   true,
-				IPAddress: "192.168.1.1",
-				Subnet:    "24",
-			},
-		},
-		FirewallRules: []common.FirewallRule{
-			{Type: common.RuleTypePass, Interfaces: []string{"lan"}, Description: "Allow LAN"},
-			{Type: common.RuleTypeBlock, Interfaces: []string{"wan"}, Description: "Block WAN"},
-		},
-		Sysctl: []common.SysctlItem{
-			{Tunable: "net.inet.ip.forwarding", Value: "1"},
-			{Tunable: "kern.ipc.maxsockbuf", Value: "16777216"},
-		},
-	}
+	device := testutil.NewDeviceBuilder().
+		WithHostname("small-config").
+		WithUser(common.User{Name: "admin", UID: "1000", Scope: "local"}).
+		WithUser(common.User{Name: "user1", UID: "1001", Scope: "local"}).
+		WithGroup(common.Group{Name: "admins", GID: "1000", Scope: "local"}).
+		WithInterface("wan", "203.0.113.1/24").
+		WithInterface("lan", "192.168.1.1/24").
+		WithFirewallRule(common.FirewallRule{
+			Type: common.RuleTypePass, Interfaces: []string{"lan"}, Description: "Allow LAN",
+		}).
+		WithFirewallRule(common.FirewallRule{
+			Type: common.RuleTypeBlock, Interfaces: []string{"wan"}, Description: "Block WAN",
+		}).
+		WithSysctl("net.inet.ip.forwarding", "1").
+		WithSysctl("kern.ipc.maxsockbuf", "16777216").
+		Build()
+	device.System.WebGUI = common.WebGUI{Protocol: "https"}
+	device.System.SSH = common.SSH{Group: "admins"}
+	device.System.Bogons = common.Bogons{Interval: "monthly"}
+
+	return device
 }
 
 // generateLargeConfig creates a large configuration for benchmarking.
@@ -2285,3 +2388,64 @@ func TestReport_ThreadSafetyStress(t *testing.T) {
 	assert.NotEmpty(t, markdown)
 	assert.Contains(t, markdown, "stress-test")
 }
+
+// countingTracker records tracker calls for assertions.
+type countingTracker struct {
+	mu    sync.Mutex
+	total int64
+	steps int64
+}
+
+func (c *countingTracker) SetTotal(total int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.total = total
+	c.steps = 0
+}
+
+func (c *countingTracker) Step(n int64, _ string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.steps += n
+}
+
+func (c *countingTracker) Done(_ string) {}
+
+func TestCoreProcessor_ProgressReportsAnalysisPasses(t *testing.T) {
+	t.Parallel()
+
+	core, err := NewCoreProcessor(nil)
+	require.NoError(t, err)
+
+	config := DefaultConfig()
+	config.ApplyOptions(WithAllFeatures())
+	wantPasses := int64(len(core.analysisPasses(config)))
+
+	for _, tt := range []struct {
+		name      string
+		processor Processor
+	}{
+		{"sequential", core},
+		{"concurrent", NewConcurrentAnalyzer(core)},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := &common.CommonDevice{
+				System: common.System{Hostname: "fw", Domain: "example.com"},
+			}
+
+			tracker := &countingTracker{}
+			report, err := tt.processor.Process(context.Background(), cfg, WithAllFeatures(), WithProgress(tracker))
+			require.NoError(t, err)
+			require.NotNil(t, report)
+
+			assert.Equal(t, wantPasses, tracker.total)
+			assert.Equal(t, tracker.total, tracker.steps)
+
+			data, err := report.ToJSON()
+			require.NoError(t, err)
+			assert.NotContains(t, data, "Progress", "tracker must not leak into serialized config")
+		})
+	}
+}
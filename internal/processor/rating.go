@@ -0,0 +1,86 @@
+package processor
+
+import "fmt"
+
+// PostureRating is the traffic-light rating of a report's overall security
+// posture, derived from its finding counts per severity.
+type PostureRating string
+
+// Posture rating constants, from worst to best.
+const (
+	// RatingRed means the configuration has findings that need immediate attention.
+	RatingRed PostureRating = "Red"
+	// RatingAmber means the configuration has findings that should be scheduled for remediation.
+	RatingAmber PostureRating = "Amber"
+	// RatingGreen means no finding count reached an Amber or Red threshold.
+	RatingGreen PostureRating = "Green"
+)
+
+// Default posture rating thresholds. See RatingThresholds.
+const (
+	DefaultRedCriticalThreshold = 1
+	DefaultRedHighThreshold     = 3
+	DefaultAmberHighThreshold   = 1
+	DefaultAmberMediumThreshold = 5
+)
+
+// RatingThresholds configures how finding counts map to a PostureRating. A
+// report is Red when its critical or high finding count reaches the matching
+// Red threshold, otherwise Amber when its high or medium count reaches the
+// matching Amber threshold, otherwise Green. Low and informational findings
+// never affect the rating. A threshold of zero disables that criterion.
+type RatingThresholds struct {
+	// RedCritical is the critical finding count that rates Red (default 1)
+	RedCritical int `json:"redCritical" yaml:"redCritical"`
+	// RedHigh is the high finding count that rates Red (default 3)
+	RedHigh int `json:"redHigh" yaml:"redHigh"`
+	// AmberHigh is the high finding count that rates Amber (default 1)
+	AmberHigh int `json:"amberHigh" yaml:"amberHigh"`
+	// AmberMedium is the medium finding count that rates Amber (default 5)
+	AmberMedium int `json:"amberMedium" yaml:"amberMedium"`
+}
+
+// DefaultRatingThresholds returns the default posture rating thresholds.
+func DefaultRatingThresholds() RatingThresholds {
+	return RatingThresholds{
+		RedCritical: DefaultRedCriticalThreshold,
+		RedHigh:     DefaultRedHighThreshold,
+		AmberHigh:   DefaultAmberHighThreshold,
+		AmberMedium: DefaultAmberMediumThreshold,
+	}
+}
+
+// Rate returns the posture rating for findings and a one-sentence reason
+// naming the count and threshold that decided it.
+func (t RatingThresholds) Rate(findings Findings) (PostureRating, string) {
+	critical, high, medium := len(findings.Critical), len(findings.High), len(findings.Medium)
+
+	switch {
+	case reaches(critical, t.RedCritical):
+		return RatingRed, countReason(critical, "critical", t.RedCritical, RatingRed)
+	case reaches(high, t.RedHigh):
+		return RatingRed, countReason(high, "high", t.RedHigh, RatingRed)
+	case reaches(high, t.AmberHigh):
+		return RatingAmber, countReason(high, "high", t.AmberHigh, RatingAmber)
+	case reaches(medium, t.AmberMedium):
+		return RatingAmber, countReason(medium, "medium", t.AmberMedium, RatingAmber)
+	default:
+		return RatingGreen, "No critical, high, or medium finding count reached an Amber or Red threshold."
+	}
+}
+
+// reaches reports whether count meets an enabled (non-zero) threshold.
+func reaches(count, threshold int) bool {
+	return threshold > 0 && count >= threshold
+}
+
+// countReason explains a rating decided by count findings of severity
+// reaching threshold.
+func countReason(count int, severity string, threshold int, rating PostureRating) string {
+	noun := "findings"
+	if count == 1 {
+		noun = "finding"
+	}
+
+	return fmt.Sprintf("%d %s %s reached the %s threshold of %d.", count, severity, noun, rating, threshold)
+}
@@ -0,0 +1,88 @@
+package processor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRatingThresholds_Rate(t *testing.T) {
+	t.Parallel()
+
+	findings := func(critical, high, medium, low int) Findings {
+		return Findings{
+			Critical: make([]Finding, critical),
+			High:     make([]Finding, high),
+			Medium:   make([]Finding, medium),
+			Low:      make([]Finding, low),
+		}
+	}
+
+	tests := []struct {
+		name       string
+		thresholds RatingThresholds
+		findings   Findings
+		want       PostureRating
+		wantReason string
+	}{
+		{
+			name:       "no findings",
+			thresholds: DefaultRatingThresholds(),
+			want:       RatingGreen,
+			wantReason: "No critical, high, or medium finding count reached an Amber or Red threshold.",
+		},
+		{
+			name:       "low findings never affect the rating",
+			thresholds: DefaultRatingThresholds(),
+			findings:   findings(0, 0, 4, 50),
+			want:       RatingGreen,
+		},
+		{
+			name:       "two critical findings",
+			thresholds: DefaultRatingThresholds(),
+			findings:   findings(2, 0, 0, 0),
+			want:       RatingRed,
+			wantReason: "2 critical findings reached the Red threshold of 1.",
+		},
+		{
+			name:       "high findings reaching the Red threshold",
+			thresholds: DefaultRatingThresholds(),
+			findings:   findings(0, 3, 0, 0),
+			want:       RatingRed,
+			wantReason: "3 high findings reached the Red threshold of 3.",
+		},
+		{
+			name:       "one high finding",
+			thresholds: DefaultRatingThresholds(),
+			findings:   findings(0, 1, 0, 0),
+			want:       RatingAmber,
+			wantReason: "1 high finding reached the Amber threshold of 1.",
+		},
+		{
+			name:       "medium findings reaching the Amber threshold",
+			thresholds: DefaultRatingThresholds(),
+			findings:   findings(0, 0, 5, 0),
+			want:       RatingAmber,
+			wantReason: "5 medium findings reached the Amber threshold of 5.",
+		},
+		{
+			name:       "zero thresholds are disabled",
+			thresholds: RatingThresholds{AmberMedium: 1},
+			findings:   findings(2, 4, 1, 0),
+			want:       RatingAmber,
+			wantReason: "1 medium finding reached the Amber threshold of 1.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, reason := tt.thresholds.Rate(tt.findings)
+			assert.Equal(t, tt.want, got)
+			if tt.wantReason != "" {
+				assert.Equal(t, tt.wantReason, reason)
+			}
+		})
+	}
+}
@@ -19,6 +19,9 @@ const redactedValue = "[REDACTED]"
 type Report struct {
 	mu sync.RWMutex `json:"-" yaml:"-"` // protects Findings for concurrent access
 
+	// onFinding, when set, is called after each AddFinding outside the lock.
+	onFinding func(Severity, Finding)
+
 	// DeviceType identifies the platform at the top level for easy access
 	DeviceType common.DeviceType `json:"device_type" yaml:"device_type"`
 
@@ -34,9 +37,24 @@ type Report struct {
 	// Statistics contains various statistics about the configuration
 	Statistics *Statistics `json:"statistics,omitempty"`
 
+	// InterfaceRuleCounts holds per-interface pass/block rule counts for the
+	// interface heatmap
+	InterfaceRuleCounts map[string]RuleCount `json:"interfaceRuleCounts,omitempty"`
+
 	// Findings contains analysis findings categorized by type
 	Findings Findings `json:"findings"`
 
+	// Rating is the traffic-light posture rating computed from the finding
+	// counts once analysis completes
+	Rating PostureRating `json:"rating,omitempty" yaml:"rating,omitempty"`
+
+	// RatingReason names the finding count and threshold that decided Rating
+	RatingReason string `json:"ratingReason,omitempty" yaml:"ratingReason,omitempty"`
+
+	// RiskScore is the weighted finding score computed with
+	// CalculateRiskScore once analysis completes
+	RiskScore *RiskScore `json:"riskScore,omitempty" yaml:"riskScore,omitempty"`
+
 	// ProcessorConfig contains the configuration used during processing
 	ProcessorConfig Config `json:"processorConfig"`
 }
@@ -121,6 +139,7 @@ func NewReport(cfg *common.CommonDevice, processorConfig Config) *Report {
 
 		if processorConfig.EnableStats {
 			report.Statistics = generateStatistics(cfg)
+			report.InterfaceRuleCounts = InterfaceRuleCount(cfg)
 		}
 
 		// Store normalized config if requested (could be controlled by an option)
@@ -131,9 +150,9 @@ func NewReport(cfg *common.CommonDevice, processorConfig Config) *Report {
 }
 
 // AddFinding adds a finding to the report with the specified severity.
+// Findings with an unrecognized severity are dropped.
 func (r *Report) AddFinding(severity Severity, finding Finding) {
 	r.mu.Lock()
-	defer r.mu.Unlock()
 	switch severity {
 	case SeverityCritical:
 		r.Findings.Critical = append(r.Findings.Critical, finding)
@@ -146,6 +165,13 @@ func (r *Report) AddFinding(severity Severity, finding Finding) {
 	case SeverityInfo:
 		r.Findings.Info = append(r.Findings.Info, finding)
 	}
+
+	onFinding := r.onFinding
+	r.mu.Unlock()
+
+	if onFinding != nil {
+		onFinding(severity, finding)
+	}
 }
 
 // TotalFindings returns the total number of findings across all severities.
@@ -237,13 +263,17 @@ func (r *Report) ToYAML() (string, error) {
 // common.IPsecPhase1Tunnel, this function MUST be extended to redact them.
 func (r *Report) redactedCopyUnsafe() *Report {
 	cp := &Report{
-		DeviceType:       r.DeviceType,
-		GeneratedAt:      r.GeneratedAt,
-		ConfigInfo:       r.ConfigInfo,
-		NormalizedConfig: r.NormalizedConfig,
-		Statistics:       r.Statistics,
-		Findings:         r.Findings,
-		ProcessorConfig:  r.ProcessorConfig,
+		DeviceType:          r.DeviceType,
+		GeneratedAt:         r.GeneratedAt,
+		ConfigInfo:          r.ConfigInfo,
+		NormalizedConfig:    r.NormalizedConfig,
+		Statistics:          r.Statistics,
+		Findings:            r.Findings,
+		Rating:              r.Rating,
+		RatingReason:        r.RatingReason,
+		RiskScore:           r.RiskScore,
+		ProcessorConfig:     r.ProcessorConfig,
+		InterfaceRuleCounts: r.InterfaceRuleCounts,
 	}
 
 	if cp.NormalizedConfig != nil {
@@ -0,0 +1,178 @@
+package processor
+
+import (
+	"cmp"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/converter/builder"
+	"github.com/nao1215/markdown"
+)
+
+// executiveTopFindings is the number of findings listed in the executive report.
+const executiveTopFindings = 5
+
+// maxExecutiveDescriptionLength caps a finding description in the executive
+// report so each finding stays on one line.
+const maxExecutiveDescriptionLength = 120
+
+// severityFinding pairs a finding with the severity it was reported under.
+type severityFinding struct {
+	Severity Severity
+	Finding  Finding
+}
+
+// ToExecutiveMarkdown returns a one-page Markdown summary of the report for
+// executive stakeholders: the system identity, the posture rating, the risk
+// score, a key metrics table, and the most severe findings with one-line
+// descriptions.
+// Only H1 and H2 headings are used, and no table is wider than two columns.
+func (r *Report) ToExecutiveMarkdown() string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var buf strings.Builder
+	md := markdown.NewMarkdown(&buf)
+
+	md.H1("Executive Security Summary").
+		PlainTextf("Generated: %s", r.GeneratedAt.Format(time.RFC3339)).
+		LF()
+
+	md.H2("System Identity").
+		BulletList(r.identityItemsUnsafe()...).
+		LF()
+
+	rating := cmp.Or(string(r.Rating), "Not rated")
+	md.H2("Posture Rating").
+		PlainTextf("%s: %s", markdown.Bold(rating), r.RatingReason).
+		LF()
+
+	score := r.riskScoreUnsafe()
+	md.PlainText(strings.TrimSpace(builder.NewMarkdownBuilder().BuildRiskScoreSection(
+		score.Score, score.Level, score.Breakdown,
+	))).LF()
+
+	md.H2("Key Metrics").
+		Table(r.executiveMetricsUnsafe()).
+		LF()
+
+	md.H2("Top Findings")
+	if top := r.topFindingsUnsafe(executiveTopFindings); len(top) > 0 {
+		items := make([]string, 0, len(top))
+		for _, sf := range top {
+			items = append(items, fmt.Sprintf("%s %s: %s",
+				markdown.Bold(severityLabel(sf.Severity)), sf.Finding.Title, oneLine(sf.Finding.Description)))
+		}
+		md.OrderedList(items...)
+	} else {
+		md.PlainText("No critical, high, medium, or low findings.")
+	}
+	md.LF()
+
+	if err := md.Build(); err != nil {
+		return "# Executive Security Summary\n\nError generating report.\n"
+	}
+
+	return buf.String()
+}
+
+// identityItemsUnsafe returns the system identity bullet items: hostname,
+// platform, and firmware version. Caller must hold mu.
+func (r *Report) identityItemsUnsafe() []string {
+	hostname := r.ConfigInfo.Hostname
+	if r.ConfigInfo.Domain != "" {
+		hostname += "." + r.ConfigInfo.Domain
+	}
+
+	firmware := r.ConfigInfo.Version
+	if r.NormalizedConfig != nil {
+		firmware = cmp.Or(r.NormalizedConfig.System.Firmware.Version, firmware)
+	}
+
+	return []string{
+		fmt.Sprintf("%s: %s", markdown.Bold("Hostname"), cmp.Or(hostname, "unknown")),
+		fmt.Sprintf("%s: %s", markdown.Bold("Platform"), r.DeviceType.DisplayName()),
+		fmt.Sprintf("%s: %s", markdown.Bold("Firmware"), cmp.Or(firmware, "unknown")),
+	}
+}
+
+// executiveMetricsUnsafe returns the two-column key metrics table: the size
+// of the configuration followed by the finding counts that drive the rating.
+// Caller must hold mu.
+func (r *Report) executiveMetricsUnsafe() markdown.TableSet {
+	stats := r.Statistics
+	if stats == nil {
+		stats = &Statistics{}
+	}
+
+	metrics := []struct {
+		name  string
+		value int
+	}{
+		{"Interfaces", stats.TotalInterfaces},
+		{"Firewall Rules", stats.TotalFirewallRules},
+		{"NAT Entries", stats.NATEntries},
+		{"Users", stats.TotalUsers},
+		{"Enabled Services", len(stats.EnabledServices)},
+		{"Critical Findings", len(r.Findings.Critical)},
+		{"High Findings", len(r.Findings.High)},
+		{"Medium Findings", len(r.Findings.Medium)},
+	}
+
+	rows := make([][]string, 0, len(metrics))
+	for _, m := range metrics {
+		rows = append(rows, []string{m.name, strconv.Itoa(m.value)})
+	}
+
+	return markdown.TableSet{Header: []string{"Metric", "Value"}, Rows: rows}
+}
+
+// topFindingsUnsafe returns up to limit findings, most severe first, in the
+// order the findings were added. Informational findings are skipped. Caller
+// must hold mu.
+func (r *Report) topFindingsUnsafe(limit int) []severityFinding {
+	top := make([]severityFinding, 0, limit)
+
+	for _, group := range []struct {
+		severity Severity
+		findings []Finding
+	}{
+		{SeverityCritical, r.Findings.Critical},
+		{SeverityHigh, r.Findings.High},
+		{SeverityMedium, r.Findings.Medium},
+		{SeverityLow, r.Findings.Low},
+	} {
+		for _, finding := range group.findings {
+			if len(top) == limit {
+				return top
+			}
+			top = append(top, severityFinding{Severity: group.severity, Finding: finding})
+		}
+	}
+
+	return top
+}
+
+// oneLine reduces a finding description to its first line, truncated to
+// maxExecutiveDescriptionLength characters.
+func oneLine(s string) string {
+	s, _, _ = strings.Cut(strings.TrimSpace(s), "\n")
+
+	if runes := []rune(s); len(runes) > maxExecutiveDescriptionLength {
+		s = strings.TrimSpace(string(runes[:maxExecutiveDescriptionLength-3])) + "..."
+	}
+
+	return s
+}
+
+// severityLabel returns severity with its first letter upper-cased, e.g. "High".
+func severityLabel(severity Severity) string {
+	s := string(severity)
+	if s == "" {
+		return s
+	}
+
+	return strings.ToUpper(s[:1]) + s[1:]
+}
@@ -0,0 +1,140 @@
+package processor
+
+import (
+	"context"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/cfgparser"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/EvilBit-Labs/opnDossier/pkg/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	// headingPattern matches a Markdown ATX heading line.
+	headingPattern = regexp.MustCompile(`^#+ `)
+	// orderedItemPattern matches a Markdown ordered list item.
+	orderedItemPattern = regexp.MustCompile(`^\d+\. `)
+)
+
+// processFixture parses the processor fixture at path and processes it with
+// every analysis enabled.
+func processFixture(t *testing.T, path string) *Report {
+	t.Helper()
+
+	xmlData, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	cfg, _, err := parser.NewFactory(cfgparser.NewXMLParser()).
+		CreateDevice(context.Background(), strings.NewReader(string(xmlData)), common.DeviceTypeUnknown, false)
+	require.NoError(t, err)
+
+	p, err := NewCoreProcessor(nil)
+	require.NoError(t, err)
+
+	report, err := p.Process(context.Background(), cfg, WithAllFeatures())
+	require.NoError(t, err)
+
+	return report
+}
+
+// assertExecutiveStructure checks that md uses only the defined executive
+// headings, has no table wider than four columns, a six-to-eight row metrics
+// table, and at most five top findings.
+func assertExecutiveStructure(t *testing.T, md string) {
+	t.Helper()
+
+	var headings []string
+	tableRows, topFindings := 0, 0
+	for line := range strings.SplitSeq(md, "\n") {
+		if headingPattern.MatchString(line) {
+			headings = append(headings, line)
+		}
+		if strings.HasPrefix(line, "|") {
+			assert.LessOrEqual(t, strings.Count(line, "|")-1, 4, "table wider than 4 columns: %s", line)
+			if headings[len(headings)-1] == "## Key Metrics" {
+				tableRows++
+			}
+		}
+		if orderedItemPattern.MatchString(line) {
+			topFindings++
+		}
+	}
+
+	assert.Equal(t, []string{
+		"# Executive Security Summary",
+		"## System Identity",
+		"## Posture Rating",
+		"## Risk Score",
+		"## Key Metrics",
+		"## Top Findings",
+	}, headings)
+
+	// Header and separator rows plus the metric rows.
+	assert.GreaterOrEqual(t, tableRows-2, 6)
+	assert.LessOrEqual(t, tableRows-2, 8)
+	assert.LessOrEqual(t, topFindings, executiveTopFindings)
+}
+
+func TestReport_ToExecutiveMarkdown_TwoCriticalFindings(t *testing.T) {
+	t.Parallel()
+
+	report := processFixture(t, "testdata/executive_two_critical.xml")
+	assert.Equal(t, RatingRed, report.Rating)
+	assert.Equal(t, "2 critical findings reached the Red threshold of 1.", report.RatingReason)
+
+	md := report.ToExecutiveMarkdown()
+	assertExecutiveStructure(t, md)
+	assert.Contains(t, md, "**Hostname**: critical-fw.example.com")
+	assert.Contains(t, md, "**Platform**: OPNsense")
+	assert.Contains(t, md, "**Firmware**: 24.7")
+	assert.Contains(t, md, "**Red**: 2 critical findings")
+	assert.Contains(t, md, "| Critical Findings | 2 ")
+	assert.Contains(t, md, "1. **Critical** ")
+	assert.Contains(t, md, "2. **Critical** ")
+	assert.Contains(t, md, "| critical | 20 |")
+
+	exported, err := report.ToJSON()
+	require.NoError(t, err)
+	assert.Contains(t, exported, `"rating": "Red"`)
+	assert.Contains(t, exported, `"riskScore": {`)
+}
+
+func TestReport_ToExecutiveMarkdown_CleanFixture(t *testing.T) {
+	t.Parallel()
+
+	report := processFixture(t, "testdata/executive_clean.xml")
+	assert.Equal(t, RatingGreen, report.Rating)
+
+	md := report.ToExecutiveMarkdown()
+	assertExecutiveStructure(t, md)
+	assert.Contains(t, md, "**Green**: ")
+	assert.Contains(t, md, "| Critical Findings | 0 ")
+}
+
+func TestReport_ToExecutiveMarkdown_TopFindingsLimit(t *testing.T) {
+	t.Parallel()
+
+	report := NewReport(&common.CommonDevice{}, Config{EnableStats: true})
+	for range 4 {
+		report.AddFinding(SeverityLow, Finding{Title: "Low issue", Description: "Low"})
+		report.AddFinding(SeverityHigh, Finding{
+			Title:       "High issue",
+			Description: strings.Repeat("long ", 40) + "\nsecond line",
+		})
+	}
+	report.AddFinding(SeverityInfo, Finding{Title: "Info issue"})
+
+	md := report.ToExecutiveMarkdown()
+	assertExecutiveStructure(t, md)
+	assert.Contains(t, md, "**Not rated**")
+	assert.Equal(t, 4, strings.Count(md, "**High** High issue"))
+	assert.Equal(t, 1, strings.Count(md, "**Low** Low issue"))
+	assert.NotContains(t, md, "Info issue")
+	assert.NotContains(t, md, "second line")
+	assert.Contains(t, md, "...")
+}
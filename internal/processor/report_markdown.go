@@ -8,6 +8,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/EvilBit-Labs/opnDossier/internal/analysis"
 	"github.com/nao1215/markdown"
 )
 
@@ -20,6 +21,9 @@ func (r *Report) ToMarkdown() string {
 	md := markdown.NewMarkdown(&buf)
 
 	r.addHeader(md)
+	md.H2("Executive Summary").
+		PlainText(r.executiveSummaryUnsafe()).
+		LF()
 	r.addConfigInfo(md)
 
 	if r.Statistics != nil {
@@ -327,6 +331,50 @@ func (r *Report) Summary() string {
 	return buf.String()
 }
 
+// ExecutiveSummary returns a one-paragraph plain-English summary of the
+// report for executive stakeholders: the size of the rule base, the finding
+// counts per severity, and the titles of the most severe findings.
+// Informational findings are not counted.
+func (r *Report) ExecutiveSummary() string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.executiveSummaryUnsafe()
+}
+
+// executiveSummaryUnsafe builds the ExecutiveSummary paragraph. Caller must hold mu.
+func (r *Report) executiveSummaryUnsafe() string {
+	in := analysis.SummaryInput{
+		DeviceType: r.DeviceType,
+		Critical:   len(r.Findings.Critical),
+		High:       len(r.Findings.High),
+		Medium:     len(r.Findings.Medium),
+		Low:        len(r.Findings.Low),
+		TopIssues:  r.topFindingTitlesUnsafe(analysis.SummaryTopIssues),
+	}
+
+	if r.NormalizedConfig != nil {
+		in.Rules = len(r.NormalizedConfig.FirewallRules)
+		in.Interfaces = len(r.NormalizedConfig.Interfaces)
+	}
+
+	return analysis.ExecutiveSummary(in)
+}
+
+// topFindingTitlesUnsafe returns up to limit finding titles, most severe
+// first, in the order the findings were added. Informational findings are
+// skipped. Caller must hold mu.
+func (r *Report) topFindingTitlesUnsafe(limit int) []string {
+	top := r.topFindingsUnsafe(limit)
+
+	titles := make([]string, 0, len(top))
+	for _, sf := range top {
+		titles = append(titles, sf.Finding.Title)
+	}
+
+	return titles
+}
+
 // addFindingsSection adds a findings section using the markdown library.
 // Caller must hold mu.
 func (r *Report) addFindingsSection(md *markdown.Markdown, title string, findings []Finding) {
@@ -336,8 +384,8 @@ func (r *Report) addFindingsSection(md *markdown.Markdown, title string, finding
 
 	md.H3(fmt.Sprintf("%s (%d)", title, len(findings)))
 
-	for i, finding := range findings {
-		md.H4(fmt.Sprintf("%d. %s", i+1, finding.Title))
+	for i, finding := range DeduplicateFindings(findings) {
+		md.H4(fmt.Sprintf("%d. %s%s", i+1, finding.Title, findingRepeatSuffix(finding)))
 
 		findingItems := []string{
 			fmt.Sprintf("%s: %s", markdown.Bold("Type"), finding.Type),
@@ -366,3 +414,17 @@ func (r *Report) addFindingsSection(md *markdown.Markdown, title string, finding
 			LF()
 	}
 }
+
+// findingRepeatSuffix returns the " (×N, trackers: ...)" annotation of a
+// merged finding, or "" when the finding was not merged.
+func findingRepeatSuffix(finding Finding) string {
+	if finding.Count < 2 {
+		return ""
+	}
+
+	if finding.Trackers == "" {
+		return fmt.Sprintf(" (×%d)", finding.Count)
+	}
+
+	return fmt.Sprintf(" (×%d, trackers: %s)", finding.Count, strings.ReplaceAll(finding.Trackers, ",", ", "))
+}
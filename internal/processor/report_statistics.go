@@ -123,6 +123,16 @@ func generateStatistics(cfg *common.CommonDevice) *Statistics {
 	return stats
 }
 
+// RuleCount is a type alias for the canonical analysis.RuleCount type.
+type RuleCount = analysis.RuleCount
+
+// InterfaceRuleCount returns the pass, block, and total firewall rule counts
+// for every interface in device, keyed by interface name. Interfaces without
+// rules are included with zero counts.
+func InterfaceRuleCount(device *common.CommonDevice) map[string]RuleCount {
+	return analysis.InterfaceRuleCounts(device)
+}
+
 // translateCommonStats converts a common.Statistics into a processor.Statistics
 // by copying all matching fields. The processor's Statistics type mirrors the
 // common type but adds IDS-specific fields.
@@ -200,3 +210,17 @@ func translateCommonStats(cs *common.Statistics) *Statistics {
 
 	return stats
 }
+
+// InterfaceIndex is a type alias for the canonical analysis.InterfaceIndex type.
+type InterfaceIndex = analysis.InterfaceIndex
+
+// InterfaceReferences is a type alias for the canonical analysis.InterfaceReferences type.
+type InterfaceReferences = analysis.InterfaceReferences
+
+// BuildInterfaceIndex cross-references device by interface: the firewall
+// rules, NAT rules, DHCP scopes, VPN instances, gateways, and services that
+// refer to each one. The unused-interface analysis and the report's
+// interface cross-reference appendix both read this index.
+func BuildInterfaceIndex(device *common.CommonDevice) InterfaceIndex {
+	return analysis.BuildInterfaceIndex(device)
+}
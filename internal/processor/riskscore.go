@@ -0,0 +1,93 @@
+package processor
+
+// Risk score weights: the points each finding of a severity adds to the
+// score. Informational findings add nothing.
+const (
+	riskWeightCritical = 10
+	riskWeightHigh     = 5
+	riskWeightMedium   = 2
+	riskWeightLow      = 1
+)
+
+// Risk score level bounds: the highest score rated at each level. Scores
+// above riskLevelHighMax are Critical.
+const (
+	riskLevelLowMax    = 20
+	riskLevelMediumMax = 50
+	riskLevelHighMax   = 100
+)
+
+// Risk score levels, from best to worst.
+const (
+	RiskLevelLow      = "Low"
+	RiskLevelMedium   = "Medium"
+	RiskLevelHigh     = "High"
+	RiskLevelCritical = "Critical"
+)
+
+// RiskScore is a single-number summary of a report's findings for
+// compliance programs that track risk numerically.
+type RiskScore struct {
+	// Score is the weighted finding count: Critical×10 + High×5 + Medium×2 + Low×1
+	Score int `json:"score" yaml:"score"`
+	// Level is RiskLevelLow (0-20), RiskLevelMedium (21-50), RiskLevelHigh
+	// (51-100), or RiskLevelCritical (above 100)
+	Level string `json:"level" yaml:"level"`
+	// Breakdown maps each severity with findings to the points it added
+	Breakdown map[string]int `json:"breakdown,omitempty" yaml:"breakdown,omitempty"`
+}
+
+// CalculateRiskScore weights the report's findings by severity and sums them
+// into a RiskScore. A nil report scores 0 (Low).
+func CalculateRiskScore(report *Report) RiskScore {
+	if report == nil {
+		return RiskScore{Level: RiskLevelLow}
+	}
+
+	report.mu.RLock()
+	defer report.mu.RUnlock()
+
+	return report.riskScoreUnsafe()
+}
+
+// riskScoreUnsafe computes the report's RiskScore. Caller must hold mu.
+func (r *Report) riskScoreUnsafe() RiskScore {
+	score := RiskScore{Breakdown: map[string]int{}}
+
+	for _, s := range []struct {
+		severity Severity
+		count    int
+		weight   int
+	}{
+		{SeverityCritical, len(r.Findings.Critical), riskWeightCritical},
+		{SeverityHigh, len(r.Findings.High), riskWeightHigh},
+		{SeverityMedium, len(r.Findings.Medium), riskWeightMedium},
+		{SeverityLow, len(r.Findings.Low), riskWeightLow},
+	} {
+		if s.count == 0 {
+			continue
+		}
+
+		points := s.count * s.weight
+		score.Breakdown[string(s.severity)] = points
+		score.Score += points
+	}
+
+	score.Level = riskLevel(score.Score)
+
+	return score
+}
+
+// riskLevel returns the level of a risk score.
+func riskLevel(score int) string {
+	switch {
+	case score <= riskLevelLowMax:
+		return RiskLevelLow
+	case score <= riskLevelMediumMax:
+		return RiskLevelMedium
+	case score <= riskLevelHighMax:
+		return RiskLevelHigh
+	default:
+		return RiskLevelCritical
+	}
+}
@@ -0,0 +1,64 @@
+package processor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// reportWithFindings returns a report with the given number of findings per
+// severity.
+func reportWithFindings(critical, high, medium, low, info int) *Report {
+	report := &Report{}
+	for severity, n := range map[Severity]int{
+		SeverityCritical: critical,
+		SeverityHigh:     high,
+		SeverityMedium:   medium,
+		SeverityLow:      low,
+		SeverityInfo:     info,
+	} {
+		for range n {
+			report.AddFinding(severity, Finding{Type: "test", Title: "Finding"})
+		}
+	}
+	return report
+}
+
+func TestCalculateRiskScore(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		report    *Report
+		wantScore int
+		wantLevel string
+	}{
+		{name: "nil report", wantLevel: RiskLevelLow},
+		{name: "no findings", report: reportWithFindings(0, 0, 0, 0, 0), wantLevel: RiskLevelLow},
+		{name: "info findings add nothing", report: reportWithFindings(0, 0, 0, 0, 7), wantLevel: RiskLevelLow},
+		{name: "low upper bound", report: reportWithFindings(2, 0, 0, 0, 0), wantScore: 20, wantLevel: RiskLevelLow},
+		{name: "medium lower bound", report: reportWithFindings(2, 0, 0, 1, 0), wantScore: 21, wantLevel: RiskLevelMedium},
+		{name: "medium upper bound", report: reportWithFindings(0, 10, 0, 0, 0), wantScore: 50, wantLevel: RiskLevelMedium},
+		{name: "high lower bound", report: reportWithFindings(0, 10, 0, 1, 0), wantScore: 51, wantLevel: RiskLevelHigh},
+		{name: "high upper bound", report: reportWithFindings(5, 8, 5, 0, 0), wantScore: 100, wantLevel: RiskLevelHigh},
+		{name: "critical lower bound", report: reportWithFindings(5, 8, 5, 1, 0), wantScore: 101, wantLevel: RiskLevelCritical},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := CalculateRiskScore(tt.report)
+			assert.Equal(t, tt.wantScore, got.Score)
+			assert.Equal(t, tt.wantLevel, got.Level)
+		})
+	}
+}
+
+func TestCalculateRiskScore_Breakdown(t *testing.T) {
+	t.Parallel()
+
+	got := CalculateRiskScore(reportWithFindings(1, 2, 3, 4, 5))
+	assert.Equal(t, 30, got.Score)
+	assert.Equal(t, map[string]int{"critical": 10, "high": 10, "medium": 6, "low": 4}, got.Breakdown)
+}
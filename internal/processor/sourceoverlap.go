@@ -0,0 +1,131 @@
+package processor
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+)
+
+// sourceOverlapKey groups rules that can only be redundant with each other:
+// the same interface, rule type, and protocol.
+type sourceOverlapKey struct {
+	iface    string
+	ruleType common.FirewallRuleType
+	protocol string
+}
+
+// sourceOverlapRule is a rule whose source parsed as an IP network.
+type sourceOverlapRule struct {
+	index  int
+	source *net.IPNet
+}
+
+// checkOverlappingSourceCIDRs detects pairs of enabled rules on the same
+// interface, with the same type and protocol, whose source networks overlap:
+// one source is a subnet of, or identical to, the other. Such pairs often
+// mean one rule is redundant or was meant for a different network. Sources
+// that are "any", negated, or not an IP address or CIDR (aliases, interface
+// networks) are skipped. Each pair is reported once per shared interface, as
+// Info, against the later rule.
+func checkOverlappingSourceCIDRs(cfg *common.CommonDevice, report *Report) {
+	groups := make(map[sourceOverlapKey][]sourceOverlapRule)
+
+	var order []sourceOverlapKey
+
+	for i, rule := range cfg.FirewallRules {
+		if rule.Disabled || rule.Source.Negated || isAnyAddress(rule.Source.Address) {
+			continue
+		}
+
+		source, ok := parseSourceNetwork(rule.Source.Address)
+		if !ok {
+			continue
+		}
+
+		for _, iface := range rule.Interfaces {
+			key := sourceOverlapKey{
+				iface:    iface,
+				ruleType: rule.Type,
+				protocol: strings.ToLower(rule.Protocol),
+			}
+			if _, seen := groups[key]; !seen {
+				order = append(order, key)
+			}
+			groups[key] = append(groups[key], sourceOverlapRule{index: i, source: source})
+		}
+	}
+
+	for _, key := range order {
+		rules := groups[key]
+		for a := range rules {
+			for b := a + 1; b < len(rules); b++ {
+				first, second := rules[a], rules[b]
+				relation, ok := sourceRelation(first.source, second.source)
+				if !ok {
+					continue
+				}
+
+				report.AddFinding(SeverityInfo, Finding{
+					Type:  "overlapping-source",
+					Title: "Overlapping Rule Source Networks",
+					Description: fmt.Sprintf(
+						"Rules at positions %d and %d on interface %s are both %s rules with overlapping sources: %s %s %s",
+						first.index+1,
+						second.index+1,
+						key.iface,
+						key.ruleType,
+						cfg.FirewallRules[first.index].Source.Address,
+						relation,
+						cfg.FirewallRules[second.index].Source.Address,
+					),
+					Component: fmt.Sprintf("filter.rule[%d]", second.index),
+					Recommendation: "Confirm both rules are needed; remove the redundant rule or correct the source " +
+						"network if one was meant for a different range",
+				})
+			}
+		}
+	}
+}
+
+// sourceRelation describes how network a relates to network b, and reports
+// whether they overlap at all. Two CIDR networks overlap only when one
+// contains the other's network address.
+func sourceRelation(a, b *net.IPNet) (string, bool) {
+	aContainsB := a.Contains(b.IP)
+	bContainsA := b.Contains(a.IP)
+
+	aBits, _ := a.Mask.Size()
+	bBits, _ := b.Mask.Size()
+
+	switch {
+	case aContainsB && bContainsA && aBits == bBits:
+		return "is identical to", true
+	case aContainsB && aBits <= bBits:
+		return "contains", true
+	case bContainsA && bBits <= aBits:
+		return "is within", true
+	default:
+		return "", false
+	}
+}
+
+// parseSourceNetwork parses addr as a CIDR network or a single address, which
+// is treated as a host network.
+func parseSourceNetwork(addr string) (*net.IPNet, bool) {
+	if _, network, err := net.ParseCIDR(addr); err == nil {
+		return network, true
+	}
+
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return nil, false
+	}
+
+	if ip4 := ip.To4(); ip4 != nil {
+		return &net.IPNet{IP: ip4, Mask: net.CIDRMask(32, 32)}, true
+	}
+
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(128, 128)}, true
+}
@@ -0,0 +1,145 @@
+package processor
+
+import (
+	"testing"
+
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckOverlappingSourceCIDRs(t *testing.T) {
+	t.Parallel()
+
+	rule := func(source string, edits ...func(*common.FirewallRule)) common.FirewallRule {
+		r := common.FirewallRule{
+			Type:        common.RuleTypePass,
+			Protocol:    "tcp",
+			Interfaces:  []string{"lan"},
+			Source:      common.RuleEndpoint{Address: source},
+			Destination: common.RuleEndpoint{Address: "any"},
+		}
+		for _, edit := range edits {
+			edit(&r)
+		}
+		return r
+	}
+
+	tests := []struct {
+		name             string
+		rules            []common.FirewallRule
+		wantComponents   []string
+		wantDescriptions []string
+	}{
+		{
+			name:             "supernet then subnet",
+			rules:            []common.FirewallRule{rule("10.0.0.0/16"), rule("10.0.5.0/24")},
+			wantComponents:   []string{"filter.rule[1]"},
+			wantDescriptions: []string{"10.0.0.0/16 contains 10.0.5.0/24"},
+		},
+		{
+			name:             "subnet then supernet",
+			rules:            []common.FirewallRule{rule("10.0.5.0/24"), rule("10.0.0.0/16")},
+			wantComponents:   []string{"filter.rule[1]"},
+			wantDescriptions: []string{"10.0.5.0/24 is within 10.0.0.0/16"},
+		},
+		{
+			name:             "identical networks",
+			rules:            []common.FirewallRule{rule("192.0.2.0/24"), rule("192.0.2.0/24")},
+			wantComponents:   []string{"filter.rule[1]"},
+			wantDescriptions: []string{"192.0.2.0/24 is identical to 192.0.2.0/24"},
+		},
+		{
+			name:             "host address inside network",
+			rules:            []common.FirewallRule{rule("192.0.2.0/24"), rule("192.0.2.10")},
+			wantComponents:   []string{"filter.rule[1]"},
+			wantDescriptions: []string{"192.0.2.0/24 contains 192.0.2.10"},
+		},
+		{
+			name:             "IPv6 networks",
+			rules:            []common.FirewallRule{rule("2001:db8::/32"), rule("2001:db8:1::/48")},
+			wantComponents:   []string{"filter.rule[1]"},
+			wantDescriptions: []string{"2001:db8::/32 contains 2001:db8:1::/48"},
+		},
+		{
+			name:  "disjoint networks",
+			rules: []common.FirewallRule{rule("10.0.1.0/24"), rule("10.0.2.0/24")},
+		},
+		{
+			name:  "any source",
+			rules: []common.FirewallRule{rule("any"), rule("10.0.2.0/24")},
+		},
+		{
+			name:  "alias source",
+			rules: []common.FirewallRule{rule("Servers"), rule("Servers")},
+		},
+		{
+			name: "different interfaces",
+			rules: []common.FirewallRule{
+				rule("10.0.0.0/16"),
+				rule("10.0.5.0/24", func(r *common.FirewallRule) { r.Interfaces = []string{"opt1"} }),
+			},
+		},
+		{
+			name: "different rule types",
+			rules: []common.FirewallRule{
+				rule("10.0.0.0/16"),
+				rule("10.0.5.0/24", func(r *common.FirewallRule) { r.Type = common.RuleTypeBlock }),
+			},
+		},
+		{
+			name: "different protocols",
+			rules: []common.FirewallRule{
+				rule("10.0.0.0/16"),
+				rule("10.0.5.0/24", func(r *common.FirewallRule) { r.Protocol = "udp" }),
+			},
+		},
+		{
+			name: "disabled rule",
+			rules: []common.FirewallRule{
+				rule("10.0.0.0/16"),
+				rule("10.0.5.0/24", func(r *common.FirewallRule) { r.Disabled = true }),
+			},
+		},
+		{
+			name: "negated source",
+			rules: []common.FirewallRule{
+				rule("10.0.0.0/16"),
+				rule("10.0.5.0/24", func(r *common.FirewallRule) { r.Source.Negated = true }),
+			},
+		},
+		{
+			name: "reported once per shared interface",
+			rules: []common.FirewallRule{
+				rule("10.0.0.0/16", func(r *common.FirewallRule) { r.Interfaces = []string{"lan", "opt1"} }),
+				rule("10.0.5.0/24", func(r *common.FirewallRule) { r.Interfaces = []string{"lan", "opt1"} }),
+			},
+			wantComponents: []string{"filter.rule[1]", "filter.rule[1]"},
+			wantDescriptions: []string{
+				"on interface lan are both pass rules",
+				"on interface opt1 are both pass rules",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := &common.CommonDevice{FirewallRules: tt.rules}
+			report := NewReport(cfg, Config{})
+
+			checkOverlappingSourceCIDRs(cfg, report)
+
+			var gotComponents []string
+			for i, f := range report.Findings.Info {
+				assert.Equal(t, "overlapping-source", f.Type)
+				assert.Equal(t, "Overlapping Rule Source Networks", f.Title)
+				assert.Contains(t, f.Description, tt.wantDescriptions[i])
+				gotComponents = append(gotComponents, f.Component)
+			}
+
+			assert.Equal(t, tt.wantComponents, gotComponents)
+			assert.Equal(t, len(tt.wantComponents), report.TotalFindings())
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package processor
+
+import (
+	"fmt"
+	"net/netip"
+	"slices"
+	"strings"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/constants"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+)
+
+// stateTypeNone is the StateType of a rule that creates no pf state.
+const stateTypeNone = "none"
+
+// checkStateTrackingConsistency detects stateless outbound pass rules that
+// match the return path of a stateful inbound pass rule on the same
+// interface. pf tracks a TCP or UDP connection from its first packet, so an
+// inbound rule that keeps state expects the replies to be matched by that
+// state; an outbound rule with state type "none" covering the same traffic
+// shows the rule sets disagree on whether the flow is tracked.
+//
+// An outbound rule corresponds to an inbound rule when both are enabled pass
+// rules sharing an interface (an outbound floating rule without interfaces
+// applies to all of them), their protocols share TCP or UDP, and the
+// outbound source and destination overlap the inbound destination and
+// source. Each inconsistent pair is reported once, as Medium.
+func checkStateTrackingConsistency(cfg *common.CommonDevice, report *Report) {
+	for outIdx, out := range cfg.FirewallRules {
+		if out.Disabled || out.Type != common.RuleTypePass || out.Direction != common.DirectionOut ||
+			!strings.EqualFold(strings.TrimSpace(out.StateType), stateTypeNone) {
+			continue
+		}
+
+		for inIdx, in := range cfg.FirewallRules {
+			if in.Disabled || in.Type != common.RuleTypePass || !isInboundRule(in) ||
+				!keepsState(in) || !isTCPOrUDP(in.Protocol) {
+				continue
+			}
+
+			iface, ok := sharedInterface(in, out)
+			if !ok || !protocolsOverlap(in.Protocol, out.Protocol) ||
+				!endpointsOverlap(out.Source, in.Destination) ||
+				!endpointsOverlap(out.Destination, in.Source) {
+				continue
+			}
+
+			report.AddFinding(SeverityMedium, Finding{
+				Type:  "state-tracking-mismatch",
+				Title: "Inconsistent State Tracking",
+				Description: fmt.Sprintf(
+					"Inbound rule at position %d keeps state for %s traffic on interface %s, "+
+						"but outbound rule at position %d matches its return traffic with state type %q",
+					inIdx+1,
+					strings.ToUpper(in.Protocol),
+					iface,
+					outIdx+1,
+					out.StateType,
+				),
+				Component: fmt.Sprintf("filter.rule[%d]", outIdx),
+				Recommendation: "Use keep state on the outbound rule, or remove it and let the inbound rule's " +
+					"state match the return traffic",
+			})
+		}
+	}
+}
+
+// isInboundRule reports whether rule matches inbound traffic. Rules without a
+// direction apply inbound, as on a non-floating OPNsense rule.
+func isInboundRule(rule common.FirewallRule) bool {
+	return rule.Direction == "" || rule.Direction == common.DirectionIn || rule.Direction == common.DirectionAny
+}
+
+// keepsState reports whether rule creates pf state. An unset state type
+// defaults to keep state.
+func keepsState(rule common.FirewallRule) bool {
+	return !strings.EqualFold(strings.TrimSpace(rule.StateType), stateTypeNone)
+}
+
+// isTCPOrUDP reports whether protocol is TCP, UDP, or both.
+func isTCPOrUDP(protocol string) bool {
+	switch strings.ToLower(protocol) {
+	case "tcp", "udp", "tcp/udp":
+		return true
+	default:
+		return false
+	}
+}
+
+// protocolsOverlap reports whether an outbound rule for protocol out can
+// match traffic of the TCP/UDP protocol in. An unset or "any" protocol
+// matches everything.
+func protocolsOverlap(in, out string) bool {
+	out = strings.ToLower(out)
+	if out == "" || out == constants.NetworkAny {
+		return true
+	}
+	if !isTCPOrUDP(out) {
+		return false
+	}
+
+	in = strings.ToLower(in)
+
+	return in == out || in == "tcp/udp" || out == "tcp/udp"
+}
+
+// sharedInterface returns an interface that both in and out apply to. An
+// outbound rule without interfaces applies to every interface.
+func sharedInterface(in, out common.FirewallRule) (string, bool) {
+	for _, iface := range in.Interfaces {
+		if len(out.Interfaces) == 0 || slices.Contains(out.Interfaces, iface) {
+			return iface, true
+		}
+	}
+
+	return "", false
+}
+
+// endpointsOverlap reports whether two rule endpoints can match a common
+// address. "any" overlaps everything, and addresses and networks overlap when
+// their prefixes do. Other values, such as aliases or interface networks,
+// overlap only when equal. Negated endpoints match almost every address and
+// are treated as overlapping.
+func endpointsOverlap(a, b common.RuleEndpoint) bool {
+	if a.Negated || b.Negated || isAnyAddress(a.Address) || isAnyAddress(b.Address) {
+		return true
+	}
+
+	pa, errA := parseEndpointPrefix(a.Address)
+	pb, errB := parseEndpointPrefix(b.Address)
+	if errA == nil && errB == nil {
+		return pa.Overlaps(pb)
+	}
+
+	return strings.EqualFold(a.Address, b.Address)
+}
+
+// isAnyAddress reports whether addr is unset or "any".
+func isAnyAddress(addr string) bool {
+	return addr == "" || strings.EqualFold(addr, constants.NetworkAny)
+}
+
+// parseEndpointPrefix parses addr as a CIDR network or a single address.
+func parseEndpointPrefix(addr string) (netip.Prefix, error) {
+	if strings.Contains(addr, "/") {
+		return netip.ParsePrefix(addr)
+	}
+
+	ip, err := netip.ParseAddr(addr)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+
+	return netip.PrefixFrom(ip, ip.BitLen()), nil
+}
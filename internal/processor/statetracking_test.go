@@ -0,0 +1,174 @@
+package processor
+
+import (
+	"testing"
+
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckStateTrackingConsistency(t *testing.T) {
+	t.Parallel()
+
+	inbound := common.FirewallRule{
+		Type:        common.RuleTypePass,
+		Protocol:    "tcp",
+		Interfaces:  []string{"lan"},
+		Source:      common.RuleEndpoint{Address: "10.0.1.0/24"},
+		Destination: common.RuleEndpoint{Address: "any"},
+		StateType:   "keep state",
+	}
+	stateless := common.FirewallRule{
+		Type:        common.RuleTypePass,
+		Protocol:    "tcp",
+		Interfaces:  []string{"lan"},
+		Direction:   common.DirectionOut,
+		Floating:    true,
+		Source:      common.RuleEndpoint{Address: "any"},
+		Destination: common.RuleEndpoint{Address: "10.0.1.20"},
+		StateType:   "none",
+	}
+	with := func(rule common.FirewallRule, edit func(*common.FirewallRule)) common.FirewallRule {
+		edit(&rule)
+		return rule
+	}
+
+	tests := []struct {
+		name           string
+		rules          []common.FirewallRule
+		wantComponents []string
+	}{
+		{
+			name: "no rules",
+		},
+		{
+			name:           "stateless outbound rule on the return path",
+			rules:          []common.FirewallRule{inbound, stateless},
+			wantComponents: []string{"filter.rule[1]"},
+		},
+		{
+			name: "unset inbound state type defaults to keep state",
+			rules: []common.FirewallRule{
+				with(inbound, func(r *common.FirewallRule) { r.StateType = "" }),
+				stateless,
+			},
+			wantComponents: []string{"filter.rule[1]"},
+		},
+		{
+			name: "outbound floating rule without interfaces applies everywhere",
+			rules: []common.FirewallRule{
+				inbound,
+				with(stateless, func(r *common.FirewallRule) { r.Interfaces = nil; r.Protocol = "any" }),
+			},
+			wantComponents: []string{"filter.rule[1]"},
+		},
+		{
+			name: "tcp/udp inbound rule overlaps a udp outbound rule",
+			rules: []common.FirewallRule{
+				with(inbound, func(r *common.FirewallRule) { r.Protocol = "tcp/udp" }),
+				with(stateless, func(r *common.FirewallRule) { r.Protocol = "udp" }),
+			},
+			wantComponents: []string{"filter.rule[1]"},
+		},
+		{
+			name: "outbound rule that keeps state is consistent",
+			rules: []common.FirewallRule{
+				inbound,
+				with(stateless, func(r *common.FirewallRule) { r.StateType = "keep state" }),
+			},
+		},
+		{
+			name: "stateless inbound rule is not compared",
+			rules: []common.FirewallRule{
+				with(inbound, func(r *common.FirewallRule) { r.StateType = "none" }),
+				stateless,
+			},
+		},
+		{
+			name: "different interface",
+			rules: []common.FirewallRule{
+				inbound,
+				with(stateless, func(r *common.FirewallRule) { r.Interfaces = []string{"opt1"} }),
+			},
+		},
+		{
+			name: "different protocol",
+			rules: []common.FirewallRule{
+				inbound,
+				with(stateless, func(r *common.FirewallRule) { r.Protocol = "udp" }),
+			},
+		},
+		{
+			name: "non-TCP/UDP inbound rule",
+			rules: []common.FirewallRule{
+				with(inbound, func(r *common.FirewallRule) { r.Protocol = "icmp" }),
+				with(stateless, func(r *common.FirewallRule) { r.Protocol = "any" }),
+			},
+		},
+		{
+			name: "return path outside the inbound source network",
+			rules: []common.FirewallRule{
+				inbound,
+				with(stateless, func(r *common.FirewallRule) { r.Destination.Address = "10.0.2.20" }),
+			},
+		},
+		{
+			name: "disabled and block rules are ignored",
+			rules: []common.FirewallRule{
+				inbound,
+				with(stateless, func(r *common.FirewallRule) { r.Disabled = true }),
+				with(stateless, func(r *common.FirewallRule) { r.Type = common.RuleTypeBlock }),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := &common.CommonDevice{FirewallRules: tt.rules}
+			report := NewReport(cfg, Config{})
+
+			checkStateTrackingConsistency(cfg, report)
+
+			var gotComponents []string
+			for _, f := range report.Findings.Medium {
+				assert.Equal(t, "state-tracking-mismatch", f.Type)
+				assert.Equal(t, "Inconsistent State Tracking", f.Title)
+				assert.Contains(t, f.Description, "Inbound rule at position 1")
+				gotComponents = append(gotComponents, f.Component)
+			}
+
+			assert.Equal(t, tt.wantComponents, gotComponents)
+			assert.Equal(t, len(tt.wantComponents), report.TotalFindings())
+		})
+	}
+}
+
+func TestEndpointsOverlap(t *testing.T) {
+	t.Parallel()
+
+	ep := func(addr string) common.RuleEndpoint { return common.RuleEndpoint{Address: addr} }
+
+	tests := []struct {
+		name string
+		a, b common.RuleEndpoint
+		want bool
+	}{
+		{name: "any", a: ep("any"), b: ep("192.0.2.1"), want: true},
+		{name: "unset", a: ep(""), b: ep("lan"), want: true},
+		{name: "host inside network", a: ep("192.0.2.10"), b: ep("192.0.2.0/24"), want: true},
+		{name: "disjoint networks", a: ep("192.0.2.0/24"), b: ep("198.51.100.0/24"), want: false},
+		{name: "same alias", a: ep("Servers"), b: ep("servers"), want: true},
+		{name: "different aliases", a: ep("Servers"), b: ep("Printers"), want: false},
+		{name: "negated", a: common.RuleEndpoint{Address: "192.0.2.0/24", Negated: true}, b: ep("192.0.2.1"), want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, endpointsOverlap(tt.a, tt.b))
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package processor
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+)
+
+// UID and GID allocation thresholds. OPNsense and pfSense hand out IDs for
+// local accounts and groups from firstAllocatedID upwards and cannot create
+// them past maxAllocatableID.
+const (
+	// firstAllocatedID is the first UID and GID assigned to a local account
+	// or group.
+	firstAllocatedID = 2000
+	// maxAllocatableID is the highest UID or GID that can be assigned.
+	maxAllocatableID = 65535
+	// idExhaustionHighThreshold is the next ID above which account or group
+	// creation is about to fail.
+	idExhaustionHighThreshold = 65000
+	// idExhaustionInfoThreshold is the next ID above which the ID space is
+	// approaching its limit.
+	idExhaustionInfoThreshold = 60000
+	// staleUIDThreshold is the number of allocated but unused UIDs above
+	// which deleted accounts are worth investigating.
+	staleUIDThreshold = 1000
+)
+
+// CheckUIDExhaustion reports how close the system's next UID and GID
+// counters are to maxAllocatableID. Beyond it, creating a user or group
+// fails. A counter above idExhaustionHighThreshold is High and one above
+// idExhaustionInfoThreshold is Info. The returned finding carries its
+// severity in Finding.Severity. It is nil when both counters are within
+// limits or sys is nil.
+func CheckUIDExhaustion(sys *common.System) *Finding {
+	if sys == nil {
+		return nil
+	}
+
+	severity := Severity("")
+	var counters, components []string
+	for _, c := range []struct {
+		name string
+		next int
+	}{
+		{"UID", sys.NextUID},
+		{"GID", sys.NextGID},
+	} {
+		switch {
+		case c.next > idExhaustionHighThreshold:
+			severity = SeverityHigh
+		case c.next > idExhaustionInfoThreshold:
+			if severity == "" {
+				severity = SeverityInfo
+			}
+		default:
+			continue
+		}
+
+		counters = append(counters, fmt.Sprintf("next %s is %d", c.name, c.next))
+		components = append(components, "system.next"+strings.ToLower(c.name))
+	}
+
+	if severity == "" {
+		return nil
+	}
+
+	return &Finding{
+		Type:     "consistency",
+		Severity: string(severity),
+		Title:    "UID/GID Space Nearly Exhausted",
+		Description: fmt.Sprintf(
+			"The %s, close to the limit of %d; creating users or groups fails once it is reached",
+			strings.Join(counters, " and the "), maxAllocatableID,
+		),
+		Component: strings.Join(components, ", "),
+		Recommendation: "Plan a migration to external authentication (LDAP, RADIUS) or renumber " +
+			"local accounts before new users or groups can no longer be created",
+	}
+}
+
+// checkUIDAllocation reports CheckUIDExhaustion's finding, and detects a next
+// UID counter that has run more than staleUIDThreshold ahead of the local
+// accounts holding an allocated UID. OPNsense and pfSense never reuse the
+// UID of a deleted account, so a large gap shows many accounts were created
+// and deleted, for example by an automated provisioning loop. The gap is Low.
+func checkUIDAllocation(cfg *common.CommonDevice, report *Report) {
+	if f := CheckUIDExhaustion(&cfg.System); f != nil {
+		report.AddFinding(Severity(f.Severity), *f)
+	}
+
+	if cfg.System.NextUID <= firstAllocatedID {
+		return
+	}
+
+	accounts := 0
+	for _, user := range cfg.Users {
+		// A UID that does not parse is counted, so the gap is never
+		// overstated.
+		if uid, err := strconv.Atoi(strings.TrimSpace(user.UID)); err != nil || uid >= firstAllocatedID {
+			accounts++
+		}
+	}
+
+	allocated := cfg.System.NextUID - firstAllocatedID
+	if stale := allocated - accounts; stale > staleUIDThreshold {
+		report.AddFinding(SeverityLow, Finding{
+			Type:  "consistency",
+			Title: "Stale UID Allocations",
+			Description: fmt.Sprintf(
+				"The next UID is %d, so %d UIDs have been allocated since %d, but only %d local accounts hold one; "+
+					"%d UIDs belong to deleted accounts and are not reclaimed",
+				cfg.System.NextUID, allocated, firstAllocatedID, accounts, stale,
+			),
+			Component: "system.nextuid",
+			Recommendation: "Review how local accounts are created and deleted; " +
+				"repeated automated provisioning consumes the UID space",
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package processor
+
+import (
+	"testing"
+
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckUIDExhaustion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		nextUID       int
+		nextGID       int
+		wantSeverity  Severity
+		wantComponent string
+	}{
+		{name: "defaults", nextUID: 2000, nextGID: 2000},
+		{name: "uid at info threshold", nextUID: 60000, nextGID: 2000},
+		{
+			name: "uid above info threshold", nextUID: 60001, nextGID: 2000,
+			wantSeverity: SeverityInfo, wantComponent: "system.nextuid",
+		},
+		{
+			name: "uid at high threshold", nextUID: 65000, nextGID: 2000,
+			wantSeverity: SeverityInfo, wantComponent: "system.nextuid",
+		},
+		{
+			name: "uid above high threshold", nextUID: 65001, nextGID: 2000,
+			wantSeverity: SeverityHigh, wantComponent: "system.nextuid",
+		},
+		{
+			name: "gid above info threshold", nextUID: 2000, nextGID: 60001,
+			wantSeverity: SeverityInfo, wantComponent: "system.nextgid",
+		},
+		{
+			name: "gid high outranks uid info", nextUID: 60001, nextGID: 65001,
+			wantSeverity: SeverityHigh, wantComponent: "system.nextuid, system.nextgid",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := CheckUIDExhaustion(&common.System{NextUID: tt.nextUID, NextGID: tt.nextGID})
+			if tt.wantSeverity == "" {
+				assert.Nil(t, got)
+				return
+			}
+
+			require.NotNil(t, got)
+			assert.Equal(t, string(tt.wantSeverity), got.Severity)
+			assert.Equal(t, tt.wantComponent, got.Component)
+			assert.Contains(t, got.Description, "65535")
+		})
+	}
+
+	assert.Nil(t, CheckUIDExhaustion(nil))
+}
+
+func TestCheckUIDAllocation(t *testing.T) {
+	t.Parallel()
+
+	users := func(uids ...string) []common.User {
+		out := make([]common.User, 0, len(uids))
+		for _, uid := range uids {
+			out = append(out, common.User{Name: "user" + uid, UID: uid})
+		}
+		return out
+	}
+
+	tests := []struct {
+		name      string
+		nextUID   int
+		users     []common.User
+		wantStale bool
+	}{
+		{name: "no counter", users: users("0")},
+		{name: "fresh system", nextUID: 2000, users: users("0")},
+		{name: "gap at threshold", nextUID: 3002, users: users("0", "2000", "2001")},
+		{name: "gap above threshold", nextUID: 3003, users: users("0", "2000", "2001"), wantStale: true},
+		{name: "system accounts are not counted", nextUID: 3001, users: users("0", "1", "2", "3"), wantStale: true},
+		{name: "unparsable uid is counted", nextUID: 3002, users: users("0", "2000", "")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := &common.CommonDevice{System: common.System{NextUID: tt.nextUID}, Users: tt.users}
+			report := &Report{}
+			checkUIDAllocation(cfg, report)
+
+			if !tt.wantStale {
+				assert.Empty(t, report.Findings.Low)
+				return
+			}
+
+			require.Len(t, report.Findings.Low, 1)
+			assert.Equal(t, "Stale UID Allocations", report.Findings.Low[0].Title)
+			assert.Contains(t, report.Findings.Low[0].Description, "1001 UIDs belong to deleted accounts")
+		})
+	}
+}
+
+func TestCheckUIDAllocation_Exhaustion(t *testing.T) {
+	t.Parallel()
+
+	cfg := &common.CommonDevice{
+		System: common.System{NextUID: 65100, NextGID: 2000},
+		Users:  []common.User{{Name: "root", UID: "0"}},
+	}
+	report := &Report{}
+	checkUIDAllocation(cfg, report)
+
+	require.Len(t, report.Findings.High, 1)
+	assert.Equal(t, "UID/GID Space Nearly Exhausted", report.Findings.High[0].Title)
+	require.Len(t, report.Findings.Low, 1, "63100 UIDs allocated for no accounts is also stale")
+}
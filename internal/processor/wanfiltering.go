@@ -0,0 +1,62 @@
+package processor
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+)
+
+// DefaultPerimeterInterfaces returns the interface names checked by
+// checkBlockPrivateOnWAN when Config.PerimeterInterfaces is not changed.
+func DefaultPerimeterInterfaces() []string {
+	return []string{"wan"}
+}
+
+// checkBlockPrivateOnWAN detects enabled interfaces named in perimeter whose
+// Block Private Networks or Block Bogon Networks option is off. RFC 1918 and
+// bogon sources are never routed on the public internet, so receiving them
+// on a WAN-facing interface indicates a misconfiguration or spoofing. Each
+// missing option is a separate High finding. Names are matched
+// case-insensitively.
+func checkBlockPrivateOnWAN(cfg *common.CommonDevice, report *Report, perimeter []string) {
+	for _, iface := range cfg.Interfaces {
+		if !iface.Enabled || !isPerimeterInterface(iface.Name, perimeter) {
+			continue
+		}
+
+		if !iface.BlockPrivate {
+			report.AddFinding(SeverityHigh, Finding{
+				Type:  "wan-block-private-disabled",
+				Title: "Private Networks Not Blocked on WAN Interface",
+				Description: fmt.Sprintf(
+					"Interface %s accepts traffic from RFC 1918 private addresses, which are never routed on the public internet",
+					iface.Name,
+				),
+				Component:      "interfaces." + iface.Name,
+				Recommendation: "Enable Block private networks on the interface",
+			})
+		}
+
+		if !iface.BlockBogons {
+			report.AddFinding(SeverityHigh, Finding{
+				Type:  "wan-block-bogons-disabled",
+				Title: "Bogon Networks Not Blocked on WAN Interface",
+				Description: fmt.Sprintf(
+					"Interface %s accepts traffic from reserved and unassigned (bogon) addresses, a common source of spoofed traffic",
+					iface.Name,
+				),
+				Component:      "interfaces." + iface.Name,
+				Recommendation: "Enable Block bogon networks on the interface",
+			})
+		}
+	}
+}
+
+// isPerimeterInterface reports whether name is one of perimeter, ignoring case.
+func isPerimeterInterface(name string, perimeter []string) bool {
+	return slices.ContainsFunc(perimeter, func(entry string) bool {
+		return strings.EqualFold(strings.TrimSpace(entry), name)
+	})
+}
@@ -0,0 +1,143 @@
+package processor
+
+import (
+	"context"
+	"testing"
+
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// wanFilteringFindings returns the private and bogon blocking findings of
+// report as "type: component" strings.
+func wanFilteringFindings(report *Report) []string {
+	var got []string
+	for _, f := range report.Findings.High {
+		if f.Type == "wan-block-private-disabled" || f.Type == "wan-block-bogons-disabled" {
+			got = append(got, f.Type+": "+f.Component)
+		}
+	}
+	return got
+}
+
+func TestCheckBlockPrivateOnWAN(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		interfaces []common.Interface
+		perimeter  []string
+		want       []string
+	}{
+		{
+			name: "WAN blocking both is clean",
+			interfaces: []common.Interface{
+				{Name: "wan", Enabled: true, BlockPrivate: true, BlockBogons: true},
+			},
+			perimeter: DefaultPerimeterInterfaces(),
+		},
+		{
+			name: "WAN blocking neither",
+			interfaces: []common.Interface{
+				{Name: "wan", Enabled: true},
+			},
+			perimeter: DefaultPerimeterInterfaces(),
+			want: []string{
+				"wan-block-private-disabled: interfaces.wan",
+				"wan-block-bogons-disabled: interfaces.wan",
+			},
+		},
+		{
+			name: "WAN blocking only bogons",
+			interfaces: []common.Interface{
+				{Name: "wan", Enabled: true, BlockBogons: true},
+			},
+			perimeter: DefaultPerimeterInterfaces(),
+			want:      []string{"wan-block-private-disabled: interfaces.wan"},
+		},
+		{
+			name: "WAN blocking only private networks",
+			interfaces: []common.Interface{
+				{Name: "wan", Enabled: true, BlockPrivate: true},
+			},
+			perimeter: DefaultPerimeterInterfaces(),
+			want:      []string{"wan-block-bogons-disabled: interfaces.wan"},
+		},
+		{
+			name: "LAN blocking neither is not a perimeter interface",
+			interfaces: []common.Interface{
+				{Name: "wan", Enabled: true, BlockPrivate: true, BlockBogons: true},
+				{Name: "lan", Enabled: true},
+			},
+			perimeter: DefaultPerimeterInterfaces(),
+		},
+		{
+			name: "LAN blocking both does not excuse the WAN",
+			interfaces: []common.Interface{
+				{Name: "wan", Enabled: true, BlockBogons: true},
+				{Name: "lan", Enabled: true, BlockPrivate: true, BlockBogons: true},
+			},
+			perimeter: DefaultPerimeterInterfaces(),
+			want:      []string{"wan-block-private-disabled: interfaces.wan"},
+		},
+		{
+			name: "disabled WAN is skipped",
+			interfaces: []common.Interface{
+				{Name: "wan"},
+			},
+			perimeter: DefaultPerimeterInterfaces(),
+		},
+		{
+			name: "custom perimeter list matches case-insensitively",
+			interfaces: []common.Interface{
+				{Name: "wan", Enabled: true},
+				{Name: "OPT1", Enabled: true, BlockBogons: true},
+			},
+			perimeter: []string{"opt1"},
+			want:      []string{"wan-block-private-disabled: interfaces.OPT1"},
+		},
+		{
+			name: "empty perimeter list checks nothing",
+			interfaces: []common.Interface{
+				{Name: "wan", Enabled: true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			report := NewReport(&common.CommonDevice{}, Config{})
+			checkBlockPrivateOnWAN(&common.CommonDevice{Interfaces: tt.interfaces}, report, tt.perimeter)
+			assert.Equal(t, tt.want, wanFilteringFindings(report))
+		})
+	}
+}
+
+func TestCoreProcessor_PerimeterInterfaces(t *testing.T) {
+	t.Parallel()
+
+	processor, err := NewCoreProcessor(nil)
+	require.NoError(t, err)
+
+	cfg := &common.CommonDevice{
+		System: common.System{Hostname: "fw", Domain: "example.com"},
+		Interfaces: []common.Interface{
+			{Name: "wan", Enabled: true},
+			{Name: "opt1", Enabled: true, BlockPrivate: true, BlockBogons: true},
+		},
+	}
+
+	report, err := processor.Process(context.Background(), cfg, WithSecurityAnalysis())
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"wan-block-private-disabled: interfaces.wan",
+		"wan-block-bogons-disabled: interfaces.wan",
+	}, wanFilteringFindings(report))
+
+	report, err = processor.Process(context.Background(), cfg, WithSecurityAnalysis(), WithPerimeterInterfaces("opt1"))
+	require.NoError(t, err)
+	assert.Empty(t, wanFilteringFindings(report), "the custom list replaces the default")
+}
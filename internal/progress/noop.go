@@ -2,7 +2,7 @@
 package progress
 
 // NoOpProgress is a no-operation progress indicator.
-// It implements the Progress interface but does nothing.
+// It implements the Progress and Tracker interfaces but does nothing.
 // This is used in quiet mode or non-TTY environments.
 type NoOpProgress struct{}
 
@@ -22,3 +22,12 @@ func (n *NoOpProgress) Complete(_ string) {}
 
 // Fail does nothing for no-op progress.
 func (n *NoOpProgress) Fail(_ error) {}
+
+// SetTotal does nothing for no-op progress.
+func (n *NoOpProgress) SetTotal(_ int64) {}
+
+// Step does nothing for no-op progress.
+func (n *NoOpProgress) Step(_ int64, _ string) {}
+
+// Done does nothing for no-op progress.
+func (n *NoOpProgress) Done(_ string) {}
@@ -0,0 +1,194 @@
+// Package progress provides progress indication for CLI operations.
+package progress
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// dumbTerminal is the TERM value for terminals without cursor control.
+const dumbTerminal = "dumb"
+
+// Tracker reports incremental progress of a multi-phase operation such as
+// parsing a large config, running analysis passes, or rendering report
+// sections. Unlike Progress, which models a single start/complete lifecycle,
+// a Tracker is counter-based so each pipeline stage can report in its own
+// unit (bytes, passes, sections) without knowing about the others.
+//
+// Implementations must be safe for concurrent use and must tolerate an
+// unknown total (zero), which is the normal case for stream input.
+type Tracker interface {
+	// SetTotal starts a new phase with the given total and resets the
+	// current count to zero. A total <= 0 means the total is unknown.
+	SetTotal(total int64)
+	// Step advances the current count by n and updates the status message.
+	Step(n int64, message string)
+	// Done marks the whole operation as finished and releases the line.
+	// An empty message clears the line without reporting success.
+	Done(message string)
+}
+
+// Compile-time assertions that both implementations satisfy Tracker.
+var (
+	_ Tracker = (*NoOpProgress)(nil)
+	_ Tracker = (*LineTracker)(nil)
+)
+
+// NewTracker returns a Tracker suited to opts. It returns a no-op tracker
+// when progress is disabled, when Output is not a terminal, or when TERM is
+// "dumb"; otherwise it returns a LineTracker writing to Output.
+//
+// Callers that also need to suppress progress when report output is piped
+// should check IsTerminal on their stdout before enabling opts.
+func NewTracker(opts Options) Tracker {
+	if !opts.Enabled || !isTerminal(opts.Output) || os.Getenv("TERM") == dumbTerminal {
+		return NewNoOp()
+	}
+
+	return NewLineTracker(opts.Output)
+}
+
+// IsTerminal reports whether w is connected to a terminal.
+func IsTerminal(w io.Writer) bool {
+	return isTerminal(w)
+}
+
+// LineTracker renders a single, continuously rewritten status line. It
+// never writes a trailing newline until Done, so the line can share a
+// terminal with log output on a different stream.
+type LineTracker struct {
+	output  io.Writer
+	total   int64
+	current int64
+	percent int
+	message string
+	done    bool
+	mu      sync.Mutex
+}
+
+// NewLineTracker creates a LineTracker that writes to output. A nil output
+// defaults to os.Stderr; progress is never written to stdout by default.
+func NewLineTracker(output io.Writer) *LineTracker {
+	if output == nil {
+		output = os.Stderr
+	}
+
+	return &LineTracker{output: output, percent: -1}
+}
+
+// SetTotal starts a new phase with the given total and resets the count.
+func (l *LineTracker) SetTotal(total int64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.total = total
+	l.current = 0
+	l.percent = -1
+}
+
+// Step advances the count by n and re-renders the line when the visible
+// state changes. Rendering is skipped for steps that do not move the
+// displayed percentage, which keeps byte-level reporting cheap.
+func (l *LineTracker) Step(n int64, message string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.done {
+		return
+	}
+
+	l.current += n
+
+	percent := -1
+	if l.total > 0 {
+		percent = int(min(l.current, l.total) * percentMultiplier / l.total)
+	}
+
+	if percent == l.percent && message == l.message && l.total > 0 {
+		return
+	}
+
+	l.percent = percent
+	l.message = message
+	l.render()
+}
+
+// Done clears the status line and, when message is non-empty, writes it as
+// the final line. An empty message only clears the line, which lets callers
+// release the terminal on error paths without printing a success marker.
+// Subsequent calls to Step or Done are ignored.
+func (l *LineTracker) Done(message string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.done {
+		return
+	}
+
+	l.done = true
+	l.message = message
+
+	if message == "" {
+		fmt.Fprint(l.output, "\r\033[K")
+		return
+	}
+
+	fmt.Fprintf(l.output, "\r\033[K✓ %s\n", message)
+}
+
+// render draws the current state. Caller must hold mu.
+func (l *LineTracker) render() {
+	if l.total > 0 {
+		fmt.Fprintf(l.output, "\r\033[K%3d%% %s", l.percent, l.message)
+		return
+	}
+
+	fmt.Fprintf(l.output, "\r\033[K%d %s", l.current, l.message)
+}
+
+// trackingReader reports every successful Read as a Step of the bytes read.
+type trackingReader struct {
+	r       io.Reader
+	tracker Tracker
+	message string
+}
+
+// NewReader wraps r so that bytes consumed are reported to t as steps with
+// the given message. Pair it with t.SetTotal(fileSize) for a percentage, or
+// leave the total unset for stream input.
+func NewReader(r io.Reader, t Tracker, message string) io.Reader {
+	return &trackingReader{r: r, tracker: t, message: message}
+}
+
+// Read implements io.Reader.
+func (tr *trackingReader) Read(p []byte) (int, error) {
+	n, err := tr.r.Read(p)
+	if n > 0 {
+		tr.tracker.Step(int64(n), tr.message)
+	}
+
+	return n, err
+}
+
+// trackerContextKey is the context key under which a Tracker is stored.
+type trackerContextKey struct{}
+
+// NewContext returns a copy of ctx carrying t. Pipeline stages that are
+// constructed deep inside a call chain (e.g. the markdown builder created
+// per format) retrieve it with FromContext.
+func NewContext(ctx context.Context, t Tracker) context.Context {
+	return context.WithValue(ctx, trackerContextKey{}, t)
+}
+
+// FromContext returns the Tracker stored in ctx, or a no-op tracker when
+// none is present.
+func FromContext(ctx context.Context) Tracker {
+	if t, ok := ctx.Value(trackerContextKey{}).(Tracker); ok && t != nil {
+		return t
+	}
+
+	return NewNoOp()
+}
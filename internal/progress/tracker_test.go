@@ -0,0 +1,191 @@
+package progress
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestNewTrackerWithDisabledReturnsNoOp(t *testing.T) {
+	t.Parallel()
+
+	opts := DefaultOptions()
+	opts.Enabled = false
+
+	tr := NewTracker(opts)
+
+	if _, ok := tr.(*NoOpProgress); !ok {
+		t.Errorf("NewTracker() with Enabled=false should return *NoOpProgress, got %T", tr)
+	}
+}
+
+func TestNewTrackerWithNonTerminalReturnsNoOp(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	opts := DefaultOptions()
+	opts.Output = &buf // Non-terminal output
+
+	tr := NewTracker(opts)
+
+	if _, ok := tr.(*NoOpProgress); !ok {
+		t.Errorf("NewTracker() with non-terminal output should return *NoOpProgress, got %T", tr)
+	}
+
+	tr.SetTotal(10)
+	tr.Step(10, "parsing")
+	tr.Done("done")
+
+	if buf.Len() != 0 {
+		t.Errorf("no-op tracker wrote %q, want nothing", buf.String())
+	}
+}
+
+func TestLineTrackerKnownTotal(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	tr := NewLineTracker(&buf)
+
+	tr.SetTotal(200)
+	tr.Step(50, "Parsing configuration")
+	tr.Step(1, "Parsing configuration") // 25% -> 25%: no redraw
+	tr.Step(149, "Parsing configuration")
+	tr.Done("Report generated")
+
+	output := buf.String()
+
+	if !strings.Contains(output, " 25% Parsing configuration") {
+		t.Errorf("output should contain 25%% line, got %q", output)
+	}
+	if !strings.Contains(output, "100% Parsing configuration") {
+		t.Errorf("output should contain 100%% line, got %q", output)
+	}
+	if strings.Count(output, "25%") != 1 {
+		t.Errorf("unchanged percentage should not redraw, got %q", output)
+	}
+	if !strings.HasSuffix(output, "\r\033[K✓ Report generated\n") {
+		t.Errorf("output should end with done line, got %q", output)
+	}
+}
+
+func TestLineTrackerUnknownTotal(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	tr := NewLineTracker(&buf)
+
+	tr.Step(3, "Analyzing")
+	tr.Step(4, "Analyzing")
+
+	output := buf.String()
+
+	if !strings.Contains(output, "7 Analyzing") {
+		t.Errorf("unknown total should render the running count, got %q", output)
+	}
+	if strings.Contains(output, "%") {
+		t.Errorf("unknown total should not render a percentage, got %q", output)
+	}
+}
+
+func TestLineTrackerOvershootClampsAt100(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	tr := NewLineTracker(&buf)
+
+	tr.SetTotal(10)
+	tr.Step(25, "Rendering")
+
+	if !strings.Contains(buf.String(), "100% Rendering") {
+		t.Errorf("overshoot should clamp to 100%%, got %q", buf.String())
+	}
+}
+
+func TestLineTrackerSetTotalResetsPhase(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	tr := NewLineTracker(&buf)
+
+	tr.SetTotal(4)
+	tr.Step(4, "Analyzing")
+	tr.SetTotal(2)
+	tr.Step(1, "Rendering")
+
+	if !strings.HasSuffix(buf.String(), " 50% Rendering") {
+		t.Errorf("SetTotal should reset the count, got %q", buf.String())
+	}
+}
+
+func TestLineTrackerDoneWithEmptyMessageClearsLine(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	tr := NewLineTracker(&buf)
+
+	tr.Step(1, "Parsing")
+	tr.Done("")
+
+	if !strings.HasSuffix(buf.String(), "Parsing\r\033[K") {
+		t.Errorf("empty Done should only clear the line, got %q", buf.String())
+	}
+	if strings.Contains(buf.String(), "✓") {
+		t.Errorf("empty Done should not print a success marker, got %q", buf.String())
+	}
+}
+
+func TestLineTrackerIgnoresCallsAfterDone(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	tr := NewLineTracker(&buf)
+
+	tr.Done("finished")
+	before := buf.String()
+
+	tr.Step(1, "late")
+	tr.Done("again")
+
+	if buf.String() != before {
+		t.Errorf("calls after Done should be ignored, got %q", buf.String())
+	}
+}
+
+func TestNewReaderReportsBytes(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	tr := NewLineTracker(&buf)
+
+	content := strings.Repeat("x", 1000)
+	tr.SetTotal(int64(len(content)))
+
+	data, err := io.ReadAll(NewReader(strings.NewReader(content), tr, "Parsing"))
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+	if string(data) != content {
+		t.Error("NewReader() altered the data read")
+	}
+	if !strings.HasSuffix(buf.String(), "100% Parsing") {
+		t.Errorf("reader should report all bytes consumed, got %q", buf.String())
+	}
+}
+
+func TestTrackerContext(t *testing.T) {
+	t.Parallel()
+
+	if _, ok := FromContext(context.Background()).(*NoOpProgress); !ok {
+		t.Error("FromContext() without a tracker should return *NoOpProgress")
+	}
+
+	tr := NewLineTracker(io.Discard)
+	ctx := NewContext(context.Background(), tr)
+
+	if got := FromContext(ctx); got != tr {
+		t.Errorf("FromContext() = %v, want %v", got, tr)
+	}
+}
@@ -0,0 +1,74 @@
+package runstats
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// Appender appends records to an NDJSON file, one JSON object per line. It is
+// safe for concurrent use.
+//
+// Each record is encoded in full before the file is touched and then written
+// with a single write call while holding both the Appender's mutex and an
+// exclusive advisory lock on the file. Workers sharing an Appender and
+// separate opndossier processes appending to the same file therefore never
+// interleave partial lines. On platforms without file locking only the mutex
+// applies, so just the workers of a single process are kept apart.
+type Appender struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewAppender returns an Appender for the file at path. The file is created
+// with mode 0600 on the first Append if it does not exist.
+func NewAppender(path string) *Appender {
+	return &Appender{path: path}
+}
+
+// Path returns the file the Appender writes to.
+func (a *Appender) Path() string {
+	return a.path
+}
+
+// Append writes rec as one line at the end of the file.
+//
+//nolint:nonamedreturns // the deferred close must be able to report its error.
+func (a *Appender) Append(rec Record) (err error) {
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("encode stats record for %s: %w", rec.File, err)
+	}
+
+	line = append(line, '\n')
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	f, err := os.OpenFile(a.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("open stats file: %w", err)
+	}
+	defer func() {
+		if cerr := f.Close(); cerr != nil {
+			err = errors.Join(err, fmt.Errorf("close stats file: %w", cerr))
+		}
+	}()
+
+	if err := lockFile(f); err != nil {
+		return fmt.Errorf("lock stats file: %w", err)
+	}
+	defer func() {
+		if uerr := unlockFile(f); uerr != nil {
+			err = errors.Join(err, fmt.Errorf("unlock stats file: %w", uerr))
+		}
+	}()
+
+	if _, err := f.Write(line); err != nil {
+		return fmt.Errorf("write stats file: %w", err)
+	}
+
+	return nil
+}
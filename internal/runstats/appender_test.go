@@ -0,0 +1,132 @@
+package runstats
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppender_Append(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "stats.ndjson")
+	appender := NewAppender(path)
+	assert.Equal(t, path, appender.Path())
+
+	require.NoError(t, appender.Append(Record{SchemaVersion: SchemaVersion, File: "a.xml"}))
+	require.NoError(t, appender.Append(Record{SchemaVersion: SchemaVersion, File: "b.xml"}))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	records := readRecords(t, path)
+	require.Len(t, records, 2)
+	assert.Equal(t, "a.xml", records[0].File)
+	assert.Equal(t, "b.xml", records[1].File)
+}
+
+func TestAppender_AppendsToExistingFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "stats.ndjson")
+	require.NoError(t, NewAppender(path).Append(Record{File: "first.xml"}))
+	require.NoError(t, NewAppender(path).Append(Record{File: "second.xml"}))
+
+	records := readRecords(t, path)
+	require.Len(t, records, 2)
+	assert.Equal(t, "second.xml", records[1].File)
+}
+
+func TestAppender_OpenError(t *testing.T) {
+	t.Parallel()
+
+	err := NewAppender(filepath.Join(t.TempDir(), "missing", "stats.ndjson")).Append(Record{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "open stats file")
+}
+
+// TestAppender_ConcurrentWorkers appends large records from many goroutines
+// through a shared Appender and through separate Appenders, which open the
+// file independently as separate processes would. Every line must still
+// decode as one whole record.
+func TestAppender_ConcurrentWorkers(t *testing.T) {
+	t.Parallel()
+
+	const (
+		workers          = 16
+		recordsPerWorker = 25
+	)
+
+	path := filepath.Join(t.TempDir(), "stats.ndjson")
+	shared := NewAppender(path)
+
+	// A long hostname makes each line far larger than a pipe buffer, so a
+	// torn write would show up as a corrupt line.
+	padding := strings.Repeat("x", 64*1024)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, workers*recordsPerWorker)
+	for w := range workers {
+		appender := shared
+		if w%2 == 1 {
+			appender = NewAppender(path)
+		}
+
+		wg.Go(func() {
+			for i := range recordsPerWorker {
+				errs <- appender.Append(Record{
+					SchemaVersion: SchemaVersion,
+					File:          fmt.Sprintf("worker-%d/config-%d.xml", w, i),
+					Hostname:      padding,
+				})
+			}
+		})
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	records := readRecords(t, path)
+	require.Len(t, records, workers*recordsPerWorker)
+
+	seen := make(map[string]bool, len(records))
+	for _, rec := range records {
+		assert.Equal(t, padding, rec.Hostname)
+		seen[rec.File] = true
+	}
+	assert.Len(t, seen, workers*recordsPerWorker)
+}
+
+// readRecords decodes every line of the NDJSON file at path, failing the
+// test on any line that is not a complete record.
+func readRecords(t *testing.T, path string) []Record {
+	t.Helper()
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = f.Close() })
+
+	var records []Record
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 1024*1024)
+	for scanner.Scan() {
+		var rec Record
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &rec), "line %d", len(records)+1)
+		records = append(records, rec)
+	}
+	require.NoError(t, scanner.Err())
+
+	return records
+}
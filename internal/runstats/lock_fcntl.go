@@ -0,0 +1,28 @@
+//go:build aix || solaris
+
+package runstats
+
+import (
+	"io"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// lockFile takes an exclusive lock on the whole of f, blocking until it is
+// free. These platforms have no flock, so it uses an fcntl record lock.
+func lockFile(f *os.File) error {
+	return setLock(f, unix.F_WRLCK)
+}
+
+// unlockFile releases the lock taken by lockFile.
+func unlockFile(f *os.File) error {
+	return setLock(f, unix.F_UNLCK)
+}
+
+// setLock applies a record lock of type typ covering the whole of f.
+func setLock(f *os.File, typ int16) error {
+	lk := unix.Flock_t{Type: typ, Whence: io.SeekStart}
+
+	return unix.FcntlFlock(f.Fd(), unix.F_SETLKW, &lk)
+}
@@ -0,0 +1,16 @@
+//go:build !unix && !windows
+
+package runstats
+
+import "os"
+
+// lockFile is a no-op on platforms without file locking; appends from one
+// process are still serialized by the Appender's mutex.
+func lockFile(*os.File) error {
+	return nil
+}
+
+// unlockFile is a no-op counterpart to lockFile.
+func unlockFile(*os.File) error {
+	return nil
+}
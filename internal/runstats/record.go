@@ -0,0 +1,194 @@
+// Package runstats builds the per-configuration usage statistics that
+// `opndossier audit --stats-out` appends to a local NDJSON file for fleet
+// reporting. Records are computed from the parsed configuration and the audit
+// results only; nothing is sent anywhere.
+package runstats
+
+import (
+	"strings"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/analysis"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+)
+
+// SchemaVersion is the version of the Record schema. It changes only when a
+// key is removed or its meaning changes; new keys are added without a bump.
+const SchemaVersion = 1
+
+// Record is one line of the statistics file. Every key is always present
+// except score and grade, which are omitted when the run computed no
+// benchmark score.
+type Record struct {
+	// SchemaVersion is the Record schema version, see SchemaVersion.
+	SchemaVersion int `json:"schemaVersion"`
+	// File is the input path as given on the command line.
+	File string `json:"file"`
+	// DeviceType is the detected platform, e.g. "opnsense" or "pfsense".
+	DeviceType string `json:"deviceType"`
+	// Hostname is the system hostname.
+	Hostname string `json:"hostname"`
+	// Domain is the system domain.
+	Domain string `json:"domain"`
+	// FirmwareVersion is the firmware version recorded in the configuration.
+	FirmwareVersion string `json:"firmwareVersion"`
+	// Interfaces is the number of configured interfaces.
+	Interfaces int `json:"interfaces"`
+	// Rules counts the enabled firewall rules by action.
+	Rules RuleCounts `json:"rules"`
+	// NATRules is the number of inbound, outbound, and 1:1 NAT rules.
+	NATRules int `json:"natRules"`
+	// Users is the number of system user accounts.
+	Users int `json:"users"`
+	// VPNInstances is the number of OpenVPN servers and clients, WireGuard
+	// instances, IPsec connections, ZeroTier networks, and enabled legacy
+	// PPTP/L2TP servers.
+	VPNInstances int `json:"vpnInstances"`
+	// Services records which services are enabled.
+	Services Services `json:"services"`
+	// Findings counts the report's findings by severity.
+	Findings SeverityCounts `json:"findings"`
+	// Score is the overall benchmark score from 0 to 1.
+	Score *float64 `json:"score,omitempty"`
+	// Grade is the letter grade of Score.
+	Grade string `json:"grade,omitempty"`
+}
+
+// RuleCounts counts enabled firewall rules by action.
+type RuleCounts struct {
+	Pass   int `json:"pass"`
+	Block  int `json:"block"`
+	Reject int `json:"reject"`
+}
+
+// Services records which services are enabled.
+type Services struct {
+	DHCP         bool `json:"dhcp"`
+	DNSResolver  bool `json:"dnsResolver"`
+	DNSForwarder bool `json:"dnsForwarder"`
+	NTP          bool `json:"ntp"`
+	SNMP         bool `json:"snmp"`
+	SSH          bool `json:"ssh"`
+	IDS          bool `json:"ids"`
+	UPnP         bool `json:"upnp"`
+	Syslog       bool `json:"syslog"`
+	Monit        bool `json:"monit"`
+	Netflow      bool `json:"netflow"`
+}
+
+// SeverityCounts counts findings by severity.
+type SeverityCounts struct {
+	Critical int `json:"critical"`
+	High     int `json:"high"`
+	Medium   int `json:"medium"`
+	Low      int `json:"low"`
+	Info     int `json:"info"`
+}
+
+// Add counts one finding of the given severity. Severities are matched
+// case-insensitively; unknown severities are ignored.
+func (c *SeverityCounts) Add(severity string) {
+	switch analysis.Severity(strings.ToLower(severity)) {
+	case analysis.SeverityCritical:
+		c.Critical++
+	case analysis.SeverityHigh:
+		c.High++
+	case analysis.SeverityMedium:
+		c.Medium++
+	case analysis.SeverityLow:
+		c.Low++
+	case analysis.SeverityInfo:
+		c.Info++
+	}
+}
+
+// NewRecord returns the record for the configuration read from file, with
+// the configuration counts and service flags filled in. Findings, Score, and
+// Grade are left for the caller. A nil device yields a record with only File
+// and SchemaVersion set.
+func NewRecord(file string, device *common.CommonDevice) Record {
+	rec := Record{SchemaVersion: SchemaVersion, File: file}
+	if device == nil {
+		return rec
+	}
+
+	rec.DeviceType = device.DeviceType.String()
+	rec.Hostname = device.System.Hostname
+	rec.Domain = device.System.Domain
+	rec.FirmwareVersion = device.System.Firmware.Version
+	rec.Interfaces = len(device.Interfaces)
+	rec.NATRules = len(device.NAT.InboundRules) + len(device.NAT.OutboundRules) + len(device.NAT.OneToOneRules)
+	rec.Users = len(device.Users)
+	rec.VPNInstances = countVPNInstances(&device.VPN)
+
+	for _, rule := range device.FirewallRules {
+		if rule.Disabled {
+			continue
+		}
+
+		switch rule.Type {
+		case common.RuleTypePass:
+			rec.Rules.Pass++
+		case common.RuleTypeBlock:
+			rec.Rules.Block++
+		case common.RuleTypeReject:
+			rec.Rules.Reject++
+		}
+	}
+
+	rec.Services = enabledServices(device)
+
+	return rec
+}
+
+// SetScore records the overall benchmark score. A nil score leaves the
+// record without one.
+func (r *Record) SetScore(score *common.BenchmarkScore) {
+	if score == nil {
+		return
+	}
+
+	value := score.Score
+	r.Score = &value
+	r.Grade = score.Grade
+}
+
+func countVPNInstances(vpn *common.VPN) int {
+	count := len(vpn.OpenVPN.Servers) + len(vpn.OpenVPN.Clients) +
+		len(vpn.WireGuard.Servers) + len(vpn.IPsec.Connections)
+
+	if vpn.ZeroTier != nil {
+		count += len(vpn.ZeroTier.Networks)
+	}
+
+	for _, legacy := range []*common.LegacyRemoteAccessVPN{vpn.PPTP, vpn.L2TP} {
+		if legacy != nil && legacy.Enabled {
+			count++
+		}
+	}
+
+	return count
+}
+
+func enabledServices(device *common.CommonDevice) Services {
+	services := Services{
+		DNSResolver:  device.DNS.Unbound.Enabled,
+		DNSForwarder: device.DNS.DNSMasq.Enabled,
+		NTP:          device.NTP.PreferredServer != "" || len(device.NTP.Servers) > 0,
+		SNMP:         device.SNMP.ROCommunity != "",
+		SSH:          device.System.SSH.Enabled,
+		IDS:          device.IDS != nil && device.IDS.Enabled,
+		UPnP:         device.UPnP != nil && device.UPnP.Enabled,
+		Syslog:       device.Syslog.Enabled,
+		Monit:        device.Monit != nil && device.Monit.Enabled,
+		Netflow:      device.Netflow != nil && device.Netflow.Enabled,
+	}
+
+	services.DHCP = device.KeaDHCP != nil && device.KeaDHCP.Enabled
+	for _, scope := range device.DHCP {
+		if scope.Enabled {
+			services.DHCP = true
+		}
+	}
+
+	return services
+}
@@ -0,0 +1,138 @@
+package runstats
+
+import (
+	"encoding/json"
+	"maps"
+	"slices"
+	"testing"
+
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRecord(t *testing.T) {
+	t.Parallel()
+
+	device := &common.CommonDevice{
+		DeviceType: common.DeviceTypeOPNsense,
+		System: common.System{
+			Hostname: "fw01",
+			Domain:   "example.com",
+			Firmware: common.Firmware{Version: "25.1"},
+			SSH:      common.SSH{Enabled: true},
+		},
+		Interfaces: []common.Interface{{Name: "wan"}, {Name: "lan"}, {Name: "opt1"}},
+		FirewallRules: []common.FirewallRule{
+			{Type: common.RuleTypePass},
+			{Type: common.RuleTypePass},
+			{Type: common.RuleTypePass, Disabled: true},
+			{Type: common.RuleTypeBlock},
+			{Type: common.RuleTypeReject},
+		},
+		NAT: common.NATConfig{
+			InboundRules:  []common.InboundNATRule{{}},
+			OutboundRules: []common.NATRule{{}, {}},
+			OneToOneRules: []common.OneToOneNATRule{{}},
+		},
+		DHCP:  []common.DHCPScope{{Interface: "lan"}, {Interface: "opt1", Enabled: true}},
+		DNS:   common.DNSConfig{Unbound: common.UnboundConfig{Enabled: true}},
+		SNMP:  common.SNMPConfig{ROCommunity: "public"},
+		IDS:   &common.IDSConfig{},
+		Users: []common.User{{Name: "root"}, {Name: "alice"}},
+		VPN: common.VPN{
+			OpenVPN:   common.OpenVPNConfig{Servers: []common.OpenVPNServer{{}}, Clients: []common.OpenVPNClient{{}}},
+			WireGuard: common.WireGuardConfig{Servers: []common.WireGuardServer{{}}},
+			IPsec:     common.IPsecConfig{Connections: []common.IPsecConnection{{}, {}}},
+			ZeroTier:  &common.ZeroTierConfig{Networks: []common.ZeroTierNetwork{{}}},
+			PPTP:      &common.LegacyRemoteAccessVPN{Enabled: false},
+			L2TP:      &common.LegacyRemoteAccessVPN{Enabled: true},
+		},
+	}
+
+	got := NewRecord("configs/fw01.xml", device)
+
+	assert.Equal(t, Record{
+		SchemaVersion:   SchemaVersion,
+		File:            "configs/fw01.xml",
+		DeviceType:      "opnsense",
+		Hostname:        "fw01",
+		Domain:          "example.com",
+		FirmwareVersion: "25.1",
+		Interfaces:      3,
+		Rules:           RuleCounts{Pass: 2, Block: 1, Reject: 1},
+		NATRules:        4,
+		Users:           2,
+		VPNInstances:    7,
+		Services: Services{
+			DHCP:        true,
+			DNSResolver: true,
+			SNMP:        true,
+			SSH:         true,
+		},
+	}, got)
+}
+
+func TestNewRecord_NilDevice(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, Record{SchemaVersion: SchemaVersion, File: "x.xml"}, NewRecord("x.xml", nil))
+}
+
+func TestSeverityCounts_Add(t *testing.T) {
+	t.Parallel()
+
+	var counts SeverityCounts
+	for _, severity := range []string{"critical", "HIGH", "high", "Medium", "low", "info", "unknown", ""} {
+		counts.Add(severity)
+	}
+
+	assert.Equal(t, SeverityCounts{Critical: 1, High: 2, Medium: 1, Low: 1, Info: 1}, counts)
+}
+
+func TestRecord_SetScore(t *testing.T) {
+	t.Parallel()
+
+	var rec Record
+	rec.SetScore(nil)
+	assert.Nil(t, rec.Score)
+	assert.Empty(t, rec.Grade)
+
+	rec.SetScore(&common.BenchmarkScore{Score: 0.85, Grade: "B"})
+	require.NotNil(t, rec.Score)
+	assert.InDelta(t, 0.85, *rec.Score, 1e-9)
+	assert.Equal(t, "B", rec.Grade)
+}
+
+// TestRecord_JSONKeys pins the documented schema: renaming or dropping a key
+// is a breaking change for consumers of the statistics file.
+func TestRecord_JSONKeys(t *testing.T) {
+	t.Parallel()
+
+	rec := NewRecord("fw.xml", &common.CommonDevice{})
+	rec.SetScore(&common.BenchmarkScore{Score: 1, Grade: "A"})
+
+	data, err := json.Marshal(rec)
+	require.NoError(t, err)
+
+	var fields map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(data, &fields))
+
+	assert.Equal(t, []string{
+		"deviceType", "domain", "file", "findings", "firmwareVersion", "grade", "hostname",
+		"interfaces", "natRules", "rules", "schemaVersion", "score", "services", "users", "vpnInstances",
+	}, slices.Sorted(maps.Keys(fields)))
+
+	assert.JSONEq(t, `{"pass":0,"block":0,"reject":0}`, string(fields["rules"]))
+	assert.JSONEq(t, `{"critical":0,"high":0,"medium":0,"low":0,"info":0}`, string(fields["findings"]))
+	assert.JSONEq(t, `{
+		"dhcp":false,"dnsResolver":false,"dnsForwarder":false,"ntp":false,"snmp":false,"ssh":false,
+		"ids":false,"upnp":false,"syslog":false,"monit":false,"netflow":false
+	}`, string(fields["services"]))
+
+	// score and grade are omitted when no benchmark score was computed.
+	data, err = json.Marshal(NewRecord("fw.xml", nil))
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), `"score"`)
+	assert.NotContains(t, string(data), `"grade"`)
+}
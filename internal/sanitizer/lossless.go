@@ -0,0 +1,209 @@
+package sanitizer
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// sanitizeXMLLossless redacts raw XML bytes in place. Every token is copied
+// from data byte for byte — comments, processing instructions, attribute
+// order and quoting, self-closing tags, entity references, and indentation
+// included — except where redaction changes a value: an element's text, an
+// attribute value, or a word of a comment. Changed text keeps its
+// surrounding whitespace and is re-escaped; a changed attribute value is
+// re-quoted with double quotes. DTD directives are stripped as in
+// sanitizeXMLContent, since they can declare entities.
+func (s *Sanitizer) sanitizeXMLLossless(data []byte) ([]byte, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.Strict = false
+	// Prevent XXE attacks by disabling entity expansion
+	decoder.Entity = map[string]string{}
+
+	var output bytes.Buffer
+	output.Grow(len(data))
+	var pathStack []string
+
+	for {
+		start := decoder.InputOffset()
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			output.Write(data[start:])
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parsing xml: %w", err)
+		}
+		// A self-closing tag is returned as a StartElement spanning the whole
+		// tag followed by an EndElement spanning nothing.
+		raw := data[start:decoder.InputOffset()]
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			pathStack = append(pathStack, t.Name.Local)
+			output.Write(s.sanitizeRawStartTag(raw, t))
+
+		case xml.EndElement:
+			if len(pathStack) > 0 {
+				pathStack = pathStack[:len(pathStack)-1]
+			}
+			output.Write(raw)
+
+		case xml.CharData:
+			output.Write(s.sanitizeRawCharData(raw, t, pathStack))
+
+		case xml.Comment:
+			comment := string(t)
+			sanitized := whitespaceTokenPattern.ReplaceAllStringFunc(comment, s.sanitizeCommentWord)
+			if sanitized == comment {
+				output.Write(raw)
+				continue
+			}
+			output.WriteString("<!--")
+			output.WriteString(sanitized)
+			output.WriteString("-->")
+
+		case xml.Directive:
+			output.WriteString("<!-- DTD directive stripped -->")
+
+		default:
+			output.Write(raw)
+		}
+	}
+
+	return output.Bytes(), nil
+}
+
+// sanitizeRawStartTag redacts the attribute values of the start tag raw, the
+// source bytes of t. Attributes whose value does not change keep their
+// original bytes. A tag whose attributes cannot be located in raw is
+// rebuilt the way sanitizeXMLContent writes it.
+func (s *Sanitizer) sanitizeRawStartTag(raw []byte, t xml.StartElement) []byte {
+	if len(t.Attr) == 0 {
+		return raw
+	}
+
+	sanitized := make([]string, len(t.Attr))
+	changed := false
+	for i, attr := range t.Attr {
+		s.stats.TotalFields++
+		sanitized[i] = s.sanitizeValue(t.Name.Local+"."+attr.Name.Local, attr.Value)
+		changed = changed || sanitized[i] != attr.Value
+	}
+
+	if !changed {
+		return raw
+	}
+
+	spans, ok := attrValueSpans(raw)
+	if !ok || len(spans) != len(t.Attr) {
+		var tag strings.Builder
+		tag.WriteString("<" + t.Name.Local)
+		for i, attr := range t.Attr {
+			tag.WriteString(" " + attr.Name.Local + "=\"" + escapeXMLAttr(sanitized[i]) + "\"")
+		}
+		if bytes.HasSuffix(raw, []byte("/>")) {
+			tag.WriteString("/")
+		}
+		tag.WriteString(">")
+		return []byte(tag.String())
+	}
+
+	var out bytes.Buffer
+	out.Grow(len(raw))
+	last := 0
+	for i, span := range spans {
+		if sanitized[i] == t.Attr[i].Value {
+			continue
+		}
+		out.Write(raw[last:span[0]])
+		out.WriteString("\"" + escapeXMLAttr(sanitized[i]) + "\"")
+		last = span[1]
+	}
+	out.Write(raw[last:])
+
+	return out.Bytes()
+}
+
+// attrValueSpans returns the byte range of each attribute value in the
+// start tag raw, quotes included. ok is false when raw is not a start tag or
+// has an attribute without a value, which the non-strict decoder accepts.
+func attrValueSpans(raw []byte) ([][2]int, bool) {
+	isSpace := func(c byte) bool { return c == ' ' || c == '\t' || c == '\n' || c == '\r' }
+	skipSpace := func(i int) int {
+		for i < len(raw) && isSpace(raw[i]) {
+			i++
+		}
+		return i
+	}
+	// nameEnd returns the end of the name starting at i.
+	nameEnd := func(i int) int {
+		for i < len(raw) && !isSpace(raw[i]) && raw[i] != '=' && raw[i] != '>' && raw[i] != '/' {
+			i++
+		}
+		return i
+	}
+
+	if len(raw) < 2 || raw[0] != '<' {
+		return nil, false
+	}
+
+	var spans [][2]int
+	i := nameEnd(1)
+	for {
+		i = skipSpace(i)
+		if i >= len(raw) {
+			return nil, false
+		}
+		if raw[i] == '>' || raw[i] == '/' {
+			return spans, true
+		}
+
+		i = skipSpace(nameEnd(i))
+		if i >= len(raw) || raw[i] != '=' {
+			return nil, false
+		}
+
+		i = skipSpace(i + 1)
+		if i >= len(raw) {
+			return nil, false
+		}
+
+		start := i
+		if quote := raw[i]; quote == '"' || quote == '\'' {
+			end := bytes.IndexByte(raw[i+1:], quote)
+			if end < 0 {
+				return nil, false
+			}
+			i += end + 2
+		} else {
+			i = nameEnd(i)
+		}
+		spans = append(spans, [2]int{start, i})
+	}
+}
+
+// sanitizeRawCharData redacts the element text raw, the source bytes of
+// text. Text that redaction leaves unchanged keeps its original bytes,
+// including entity references and CDATA sections; changed text keeps the
+// whitespace around it in raw.
+func (s *Sanitizer) sanitizeRawCharData(raw []byte, text xml.CharData, pathStack []string) []byte {
+	content := strings.TrimSpace(string(text))
+	if content == "" {
+		return raw
+	}
+
+	sanitized := s.sanitizeCharData(content, pathStack)
+	if sanitized == content {
+		return raw
+	}
+
+	trimmed := bytes.TrimSpace(raw)
+	lead := bytes.Index(raw, trimmed)
+
+	return slices.Concat(raw[:lead], []byte(escapeXMLText(sanitized)), raw[lead+len(trimmed):])
+}
@@ -0,0 +1,157 @@
+package sanitizer
+
+import (
+	"bytes"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// commentPattern matches an XML comment, including multi-line ones.
+var commentPattern = regexp.MustCompile(`(?s)<!--.*?-->`)
+
+func TestSanitizeXML_LosslessFixture(t *testing.T) {
+	input, err := os.ReadFile("testdata/commented.xml")
+	if err != nil {
+		t.Fatalf("read fixture: %v", err)
+	}
+
+	s := NewSanitizer(ModeMinimal)
+	var output bytes.Buffer
+	if err := s.SanitizeXML(bytes.NewReader(input), &output); err != nil {
+		t.Fatalf("SanitizeXML() error = %v", err)
+	}
+
+	inLines := strings.Split(string(input), "\n")
+	outLines := strings.Split(output.String(), "\n")
+	if len(outLines) != len(inLines) {
+		t.Fatalf("line count = %d, want %d:\n%s", len(outLines), len(inLines), output.String())
+	}
+
+	changed := 0
+	for i := range inLines {
+		if inLines[i] == outLines[i] {
+			continue
+		}
+		changed++
+
+		want := strings.Replace(inLines[i], "$2y$10$hunter2hunter2hunter2", "[REDACTED-PASSWORD]", 1)
+		if outLines[i] != want {
+			t.Errorf("line %d = %q, want %q", i+1, outLines[i], want)
+		}
+	}
+	if changed != 1 {
+		t.Errorf("%d lines changed, want only the password line", changed)
+	}
+
+	inComments := commentPattern.FindAllString(string(input), -1)
+	outComments := commentPattern.FindAllString(output.String(), -1)
+	if strings.Join(outComments, "\n") != strings.Join(inComments, "\n") {
+		t.Errorf("comments = %q, want %q", outComments, inComments)
+	}
+}
+
+func TestSanitizeXML_LosslessEdits(t *testing.T) {
+	tests := []struct {
+		name  string
+		mode  Mode
+		input string
+		want  string
+	}{
+		{
+			name:  "attribute keeps its neighbours' quoting",
+			mode:  ModeMinimal,
+			input: `<rule  name='allow'   password = 'secret123' tracker="1"/>`,
+			want:  `<rule  name='allow'   password = "[REDACTED-PASSWORD]" tracker="1"/>`,
+		},
+		{
+			name:  "comment keeps its layout",
+			mode:  ModeModerate,
+			input: "<a><!--\n  gateway  8.8.8.8\n--><b>x</b></a>",
+			want:  "<a><!--\n  gateway  [REDACTED-PUBLIC-IP-1]\n--><b>x</b></a>",
+		},
+		{
+			name:  "text keeps surrounding whitespace and line endings",
+			mode:  ModeMinimal,
+			input: "<user>\r\n<password>\r\n  secret\r\n</password>\r\n</user>",
+			want:  "<user>\r\n<password>\r\n  [REDACTED-PASSWORD]\r\n</password>\r\n</user>",
+		},
+		{
+			name:  "redacted text is escaped",
+			mode:  ModeModerate,
+			input: `<dns>8.8.8.8 &amp; 1.1.1.1</dns>`,
+			want:  `<dns>[REDACTED-PUBLIC-IP-1] &amp; [REDACTED-PUBLIC-IP-2]</dns>`,
+		},
+		{
+			name:  "DTD is stripped",
+			mode:  ModeMinimal,
+			input: `<!DOCTYPE x [<!ENTITY e "v">]><x/>`,
+			want:  `<!-- DTD directive stripped --><x/>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewSanitizer(tt.mode)
+			var output bytes.Buffer
+			if err := s.SanitizeXML(strings.NewReader(tt.input), &output); err != nil {
+				t.Fatalf("SanitizeXML() error = %v", err)
+			}
+
+			if got := output.String(); got != tt.want {
+				t.Errorf("SanitizeXML() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSanitizeXML_Canonical(t *testing.T) {
+	input := "<user>\n\t<!--  admin  -->\n\t<password> secret </password>\n\t<disabled/>\n</user>"
+
+	s := NewSanitizer(ModeMinimal)
+	s.SetCanonicalXML(true)
+	var output bytes.Buffer
+	if err := s.SanitizeXML(strings.NewReader(input), &output); err != nil {
+		t.Fatalf("SanitizeXML() error = %v", err)
+	}
+
+	want := "<user>\n\t<!--admin-->\n\t<password>[REDACTED-PASSWORD]</password>\n\t<disabled></disabled>\n</user>"
+	if got := output.String(); got != want {
+		t.Errorf("SanitizeXML() = %q, want %q", got, want)
+	}
+}
+
+func TestAttrValueSpans(t *testing.T) {
+	tests := []struct {
+		raw    string
+		values []string
+		ok     bool
+	}{
+		{raw: `<a>`, ok: true},
+		{raw: `<a/>`, ok: true},
+		{raw: `<a x="1" y='two'>`, values: []string{`"1"`, `'two'`}, ok: true},
+		{raw: "<a\n\tx = \"1\"\n/>", values: []string{`"1"`}, ok: true},
+		{raw: `<a x=1>`, values: []string{`1`}, ok: true},
+		{raw: `<a checked>`},
+		{raw: `<a x="1>`},
+		{raw: `a x="1"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			spans, ok := attrValueSpans([]byte(tt.raw))
+			if ok != tt.ok {
+				t.Fatalf("ok = %v, want %v", ok, tt.ok)
+			}
+
+			var values []string
+			for _, span := range spans {
+				values = append(values, tt.raw[span[0]:span[1]])
+			}
+			if strings.Join(values, ",") != strings.Join(tt.values, ",") {
+				t.Errorf("values = %q, want %q", values, tt.values)
+			}
+		})
+	}
+}
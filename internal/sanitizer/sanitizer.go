@@ -27,6 +27,10 @@ type Sanitizer struct {
 	// maps encountered by SanitizeStruct) are silently dropped. Callers that
 	// care about observability should inject a logger via SetLogger.
 	logger *logging.Logger
+	// canonicalXML selects the normalized token re-encoding of
+	// sanitizeXMLContent over the default byte-preserving
+	// sanitizeXMLLossless.
+	canonicalXML bool
 }
 
 // Stats tracks sanitization statistics.
@@ -76,6 +80,15 @@ func (s *Sanitizer) SetLogger(logger *logging.Logger) {
 	s.logger = logger
 }
 
+// SetCanonicalXML selects how SanitizeXML writes its output. By default only
+// redacted values change and every other byte of the input is kept; with
+// canonical set, every token is re-encoded, which trims element text,
+// normalizes comment whitespace, expands self-closing tags, and re-quotes
+// attributes.
+func (s *Sanitizer) SetCanonicalXML(canonical bool) {
+	s.canonicalXML = canonical
+}
+
 // maxSanitizeInputSize is the maximum allowed size in bytes for XML input
 // to the sanitizer, preventing denial-of-service via oversized payloads.
 const maxSanitizeInputSize = 100 * 1024 * 1024 // 100 MB
@@ -106,7 +119,12 @@ func (s *Sanitizer) SanitizeXML(r io.Reader, w io.Writer) error {
 	}
 
 	// Parse and sanitize
-	sanitized, err := s.sanitizeXMLContent(data)
+	sanitize := s.sanitizeXMLLossless
+	if s.canonicalXML {
+		sanitize = s.sanitizeXMLContent
+	}
+
+	sanitized, err := sanitize(data)
 	if err != nil {
 		return fmt.Errorf("sanitizing content: %w", err)
 	}
@@ -353,27 +371,37 @@ func (s *Sanitizer) sanitizeCommentContent(content string) string {
 	// Split comment into words and sanitize each potential sensitive value
 	words := strings.Fields(content)
 	for i, word := range words {
-		s.stats.TotalFields++
-		should, rule := s.engine.ShouldRedactValue("comment", word)
-		if should {
-			redacted := s.engine.RedactWithRule(rule, "comment", word)
-			if redacted != word {
-				s.stats.RedactedFields++
-				if rule.Name != "" {
-					s.stats.RedactionsByType[rule.Name]++
-				}
-				words[i] = redacted
-			} else {
-				s.stats.SkippedFields++
-			}
-		} else {
-			s.stats.SkippedFields++
-		}
+		words[i] = s.sanitizeCommentWord(word)
 	}
 
 	return strings.Join(words, " ")
 }
 
+// sanitizeCommentWord redacts a single word of an XML comment, tracking it in
+// statistics.
+func (s *Sanitizer) sanitizeCommentWord(word string) string {
+	s.stats.TotalFields++
+
+	should, rule := s.engine.ShouldRedactValue("comment", word)
+	if !should {
+		s.stats.SkippedFields++
+		return word
+	}
+
+	redacted := s.engine.RedactWithRule(rule, "comment", word)
+	if redacted == word {
+		s.stats.SkippedFields++
+		return word
+	}
+
+	s.stats.RedactedFields++
+	if rule.Name != "" {
+		s.stats.RedactionsByType[rule.Name]++
+	}
+
+	return redacted
+}
+
 // SanitizeStruct uses reflection to sanitize a struct in place.
 // This is useful for sanitizing parsed model structs before re-encoding.
 func (s *Sanitizer) SanitizeStruct(v any) error {
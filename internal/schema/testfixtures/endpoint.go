@@ -0,0 +1,52 @@
+package testfixtures
+
+// EndpointXMLBuilder builds a <source> or <destination> element.
+type EndpointXMLBuilder struct {
+	name string
+	fragment
+}
+
+// NewSource returns a builder for a <source> element.
+func NewSource() *EndpointXMLBuilder {
+	return &EndpointXMLBuilder{name: "source"}
+}
+
+// NewDestination returns a builder for a <destination> element.
+func NewDestination() *EndpointXMLBuilder {
+	return &EndpointXMLBuilder{name: "destination"}
+}
+
+// WithAny adds the <any/> wildcard.
+func (b *EndpointXMLBuilder) WithAny() *EndpointXMLBuilder {
+	b.flag("any")
+	return b
+}
+
+// WithNetwork sets <network>, an interface network macro such as "lan".
+func (b *EndpointXMLBuilder) WithNetwork(network string) *EndpointXMLBuilder {
+	b.text("network", network)
+	return b
+}
+
+// WithAddress sets <address>, an IP, CIDR, or alias name.
+func (b *EndpointXMLBuilder) WithAddress(address string) *EndpointXMLBuilder {
+	b.text("address", address)
+	return b
+}
+
+// WithPort sets <port>, a port, range, or port alias.
+func (b *EndpointXMLBuilder) WithPort(port string) *EndpointXMLBuilder {
+	b.text("port", port)
+	return b
+}
+
+// WithNot adds the <not/> negation flag.
+func (b *EndpointXMLBuilder) WithNot() *EndpointXMLBuilder {
+	b.flag("not")
+	return b
+}
+
+// Build returns the endpoint element.
+func (b *EndpointXMLBuilder) Build() string {
+	return b.render(b.name)
+}
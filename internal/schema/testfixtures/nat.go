@@ -0,0 +1,285 @@
+package testfixtures
+
+// NATRuleXMLBuilder builds a <rule> element for an outbound NAT rule
+// (opnsense.NATRule).
+type NATRuleXMLBuilder struct {
+	fragment
+}
+
+// NewNATRule returns an empty outbound NAT rule builder.
+func NewNATRule() *NATRuleXMLBuilder {
+	return &NATRuleXMLBuilder{}
+}
+
+// WithUUID sets the uuid attribute.
+func (b *NATRuleXMLBuilder) WithUUID(uuid string) *NATRuleXMLBuilder {
+	b.attr("uuid", uuid)
+	return b
+}
+
+// WithInterface sets <interface>.
+func (b *NATRuleXMLBuilder) WithInterface(value string) *NATRuleXMLBuilder {
+	b.text("interface", value)
+	return b
+}
+
+// WithIPProtocol sets <ipprotocol>.
+func (b *NATRuleXMLBuilder) WithIPProtocol(value string) *NATRuleXMLBuilder {
+	b.text("ipprotocol", value)
+	return b
+}
+
+// WithProtocol sets <protocol>.
+func (b *NATRuleXMLBuilder) WithProtocol(value string) *NATRuleXMLBuilder {
+	b.text("protocol", value)
+	return b
+}
+
+// WithSource adds the <source> element built by endpoint.
+func (b *NATRuleXMLBuilder) WithSource(endpoint *EndpointXMLBuilder) *NATRuleXMLBuilder {
+	b.raw(endpoint.Build())
+	return b
+}
+
+// WithDestination adds the <destination> element built by endpoint.
+func (b *NATRuleXMLBuilder) WithDestination(endpoint *EndpointXMLBuilder) *NATRuleXMLBuilder {
+	b.raw(endpoint.Build())
+	return b
+}
+
+// WithTarget sets <target>, the translation address.
+func (b *NATRuleXMLBuilder) WithTarget(value string) *NATRuleXMLBuilder {
+	b.text("target", value)
+	return b
+}
+
+// WithSourcePort sets <sourceport>.
+func (b *NATRuleXMLBuilder) WithSourcePort(value string) *NATRuleXMLBuilder {
+	b.text("sourceport", value)
+	return b
+}
+
+// WithNatPort sets <natport>.
+func (b *NATRuleXMLBuilder) WithNatPort(value string) *NATRuleXMLBuilder {
+	b.text("natport", value)
+	return b
+}
+
+// WithPoolOpts sets <poolopts>.
+func (b *NATRuleXMLBuilder) WithPoolOpts(value string) *NATRuleXMLBuilder {
+	b.text("poolopts", value)
+	return b
+}
+
+// WithPoolOptsSrcHashKey sets <poolopts_sourcehashkey>.
+func (b *NATRuleXMLBuilder) WithPoolOptsSrcHashKey(value string) *NATRuleXMLBuilder {
+	b.text("poolopts_sourcehashkey", value)
+	return b
+}
+
+// WithStaticNatPort adds the <staticnatport/> flag.
+func (b *NATRuleXMLBuilder) WithStaticNatPort() *NATRuleXMLBuilder {
+	b.flag("staticnatport")
+	return b
+}
+
+// WithNoNat adds the <nonat/> flag.
+func (b *NATRuleXMLBuilder) WithNoNat() *NATRuleXMLBuilder {
+	b.flag("nonat")
+	return b
+}
+
+// WithDisabled adds the <disabled/> flag.
+func (b *NATRuleXMLBuilder) WithDisabled() *NATRuleXMLBuilder {
+	b.flag("disabled")
+	return b
+}
+
+// WithLog adds the <log/> flag.
+func (b *NATRuleXMLBuilder) WithLog() *NATRuleXMLBuilder {
+	b.flag("log")
+	return b
+}
+
+// WithDescr sets <descr>.
+func (b *NATRuleXMLBuilder) WithDescr(value string) *NATRuleXMLBuilder {
+	b.text("descr", value)
+	return b
+}
+
+// WithCategory sets <category>.
+func (b *NATRuleXMLBuilder) WithCategory(value string) *NATRuleXMLBuilder {
+	b.text("category", value)
+	return b
+}
+
+// WithTag sets <tag>.
+func (b *NATRuleXMLBuilder) WithTag(value string) *NATRuleXMLBuilder {
+	b.text("tag", value)
+	return b
+}
+
+// WithTagged sets <tagged>.
+func (b *NATRuleXMLBuilder) WithTagged(value string) *NATRuleXMLBuilder {
+	b.text("tagged", value)
+	return b
+}
+
+// WithUpdated adds an <updated> change stamp.
+func (b *NATRuleXMLBuilder) WithUpdated(username, time, description string) *NATRuleXMLBuilder {
+	b.changeStamp("updated", username, time, description)
+	return b
+}
+
+// WithCreated adds an <created> change stamp.
+func (b *NATRuleXMLBuilder) WithCreated(username, time, description string) *NATRuleXMLBuilder {
+	b.changeStamp("created", username, time, description)
+	return b
+}
+
+// Build returns the <rule> element.
+func (b *NATRuleXMLBuilder) Build() string {
+	return b.render("rule")
+}
+
+// InboundRuleXMLBuilder builds a <rule> element for an inbound NAT (port
+// forward) rule (opnsense.InboundRule).
+type InboundRuleXMLBuilder struct {
+	fragment
+}
+
+// NewInboundRule returns an empty inbound NAT rule builder.
+func NewInboundRule() *InboundRuleXMLBuilder {
+	return &InboundRuleXMLBuilder{}
+}
+
+// WithUUID sets the uuid attribute.
+func (b *InboundRuleXMLBuilder) WithUUID(uuid string) *InboundRuleXMLBuilder {
+	b.attr("uuid", uuid)
+	return b
+}
+
+// WithInterface sets <interface>.
+func (b *InboundRuleXMLBuilder) WithInterface(value string) *InboundRuleXMLBuilder {
+	b.text("interface", value)
+	return b
+}
+
+// WithIPProtocol sets <ipprotocol>.
+func (b *InboundRuleXMLBuilder) WithIPProtocol(value string) *InboundRuleXMLBuilder {
+	b.text("ipprotocol", value)
+	return b
+}
+
+// WithProtocol sets <protocol>.
+func (b *InboundRuleXMLBuilder) WithProtocol(value string) *InboundRuleXMLBuilder {
+	b.text("protocol", value)
+	return b
+}
+
+// WithSource adds the <source> element built by endpoint.
+func (b *InboundRuleXMLBuilder) WithSource(endpoint *EndpointXMLBuilder) *InboundRuleXMLBuilder {
+	b.raw(endpoint.Build())
+	return b
+}
+
+// WithDestination adds the <destination> element built by endpoint.
+func (b *InboundRuleXMLBuilder) WithDestination(endpoint *EndpointXMLBuilder) *InboundRuleXMLBuilder {
+	b.raw(endpoint.Build())
+	return b
+}
+
+// WithExternalPort sets <externalport>.
+func (b *InboundRuleXMLBuilder) WithExternalPort(value string) *InboundRuleXMLBuilder {
+	b.text("externalport", value)
+	return b
+}
+
+// WithInternalIP sets <internalip>, the port-forward target.
+func (b *InboundRuleXMLBuilder) WithInternalIP(value string) *InboundRuleXMLBuilder {
+	b.text("internalip", value)
+	return b
+}
+
+// WithInternalPort sets <internalport>.
+func (b *InboundRuleXMLBuilder) WithInternalPort(value string) *InboundRuleXMLBuilder {
+	b.text("internalport", value)
+	return b
+}
+
+// WithLocalPort sets <local-port>.
+func (b *InboundRuleXMLBuilder) WithLocalPort(value string) *InboundRuleXMLBuilder {
+	b.text("local-port", value)
+	return b
+}
+
+// WithReflection sets <reflection>.
+func (b *InboundRuleXMLBuilder) WithReflection(value string) *InboundRuleXMLBuilder {
+	b.text("reflection", value)
+	return b
+}
+
+// WithNATReflection sets <natreflection>.
+func (b *InboundRuleXMLBuilder) WithNATReflection(value string) *InboundRuleXMLBuilder {
+	b.text("natreflection", value)
+	return b
+}
+
+// WithAssociatedRuleID sets <associated-rule-id>.
+func (b *InboundRuleXMLBuilder) WithAssociatedRuleID(value string) *InboundRuleXMLBuilder {
+	b.text("associated-rule-id", value)
+	return b
+}
+
+// WithPriority sets <priority>.
+func (b *InboundRuleXMLBuilder) WithPriority(value string) *InboundRuleXMLBuilder {
+	b.text("priority", value)
+	return b
+}
+
+// WithNoRDR adds the <nordr/> flag.
+func (b *InboundRuleXMLBuilder) WithNoRDR() *InboundRuleXMLBuilder {
+	b.flag("nordr")
+	return b
+}
+
+// WithNoSync adds the <nosync/> flag.
+func (b *InboundRuleXMLBuilder) WithNoSync() *InboundRuleXMLBuilder {
+	b.flag("nosync")
+	return b
+}
+
+// WithDisabled adds the <disabled/> flag.
+func (b *InboundRuleXMLBuilder) WithDisabled() *InboundRuleXMLBuilder {
+	b.flag("disabled")
+	return b
+}
+
+// WithLog adds the <log/> flag.
+func (b *InboundRuleXMLBuilder) WithLog() *InboundRuleXMLBuilder {
+	b.flag("log")
+	return b
+}
+
+// WithDescr sets <descr>.
+func (b *InboundRuleXMLBuilder) WithDescr(value string) *InboundRuleXMLBuilder {
+	b.text("descr", value)
+	return b
+}
+
+// WithUpdated adds an <updated> change stamp.
+func (b *InboundRuleXMLBuilder) WithUpdated(username, time, description string) *InboundRuleXMLBuilder {
+	b.changeStamp("updated", username, time, description)
+	return b
+}
+
+// WithCreated adds an <created> change stamp.
+func (b *InboundRuleXMLBuilder) WithCreated(username, time, description string) *InboundRuleXMLBuilder {
+	b.changeStamp("created", username, time, description)
+	return b
+}
+
+// Build returns the <rule> element.
+func (b *InboundRuleXMLBuilder) Build() string {
+	return b.render("rule")
+}
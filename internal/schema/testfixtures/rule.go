@@ -0,0 +1,227 @@
+package testfixtures
+
+// RuleXMLBuilder builds a <rule> element for a firewall filter rule
+// (opnsense.Rule).
+type RuleXMLBuilder struct {
+	fragment
+}
+
+// NewRule returns an empty filter rule builder.
+func NewRule() *RuleXMLBuilder {
+	return &RuleXMLBuilder{}
+}
+
+// WithUUID sets the uuid attribute.
+func (b *RuleXMLBuilder) WithUUID(uuid string) *RuleXMLBuilder {
+	b.attr("uuid", uuid)
+	return b
+}
+
+// WithType sets <type>, the action: pass, block, or reject.
+func (b *RuleXMLBuilder) WithType(value string) *RuleXMLBuilder {
+	b.text("type", value)
+	return b
+}
+
+// WithDescr sets <descr>.
+func (b *RuleXMLBuilder) WithDescr(value string) *RuleXMLBuilder {
+	b.text("descr", value)
+	return b
+}
+
+// WithInterface sets <interface>, a comma-separated interface list.
+func (b *RuleXMLBuilder) WithInterface(value string) *RuleXMLBuilder {
+	b.text("interface", value)
+	return b
+}
+
+// WithIPProtocol sets <ipprotocol>, such as inet or inet6.
+func (b *RuleXMLBuilder) WithIPProtocol(value string) *RuleXMLBuilder {
+	b.text("ipprotocol", value)
+	return b
+}
+
+// WithStateType sets <statetype>.
+func (b *RuleXMLBuilder) WithStateType(value string) *RuleXMLBuilder {
+	b.text("statetype", value)
+	return b
+}
+
+// WithDirection sets <direction>.
+func (b *RuleXMLBuilder) WithDirection(value string) *RuleXMLBuilder {
+	b.text("direction", value)
+	return b
+}
+
+// WithFloating sets <floating>, "yes" for a floating rule.
+func (b *RuleXMLBuilder) WithFloating(value string) *RuleXMLBuilder {
+	b.text("floating", value)
+	return b
+}
+
+// WithQuick adds the <quick/> flag.
+func (b *RuleXMLBuilder) WithQuick() *RuleXMLBuilder {
+	b.flag("quick")
+	return b
+}
+
+// WithProtocol sets <protocol>.
+func (b *RuleXMLBuilder) WithProtocol(value string) *RuleXMLBuilder {
+	b.text("protocol", value)
+	return b
+}
+
+// WithSource adds the <source> element built by endpoint.
+func (b *RuleXMLBuilder) WithSource(endpoint *EndpointXMLBuilder) *RuleXMLBuilder {
+	b.raw(endpoint.Build())
+	return b
+}
+
+// WithDestination adds the <destination> element built by endpoint.
+func (b *RuleXMLBuilder) WithDestination(endpoint *EndpointXMLBuilder) *RuleXMLBuilder {
+	b.raw(endpoint.Build())
+	return b
+}
+
+// WithTarget sets <target>.
+func (b *RuleXMLBuilder) WithTarget(value string) *RuleXMLBuilder {
+	b.text("target", value)
+	return b
+}
+
+// WithGateway sets <gateway>.
+func (b *RuleXMLBuilder) WithGateway(value string) *RuleXMLBuilder {
+	b.text("gateway", value)
+	return b
+}
+
+// WithSourcePort sets <sourceport>.
+func (b *RuleXMLBuilder) WithSourcePort(value string) *RuleXMLBuilder {
+	b.text("sourceport", value)
+	return b
+}
+
+// WithLog adds the <log/> flag.
+func (b *RuleXMLBuilder) WithLog() *RuleXMLBuilder {
+	b.flag("log")
+	return b
+}
+
+// WithDisabled adds the <disabled/> flag.
+func (b *RuleXMLBuilder) WithDisabled() *RuleXMLBuilder {
+	b.flag("disabled")
+	return b
+}
+
+// WithTracker sets <tracker>.
+func (b *RuleXMLBuilder) WithTracker(value string) *RuleXMLBuilder {
+	b.text("tracker", value)
+	return b
+}
+
+// WithSched sets <sched>, the name of a schedule.
+func (b *RuleXMLBuilder) WithSched(value string) *RuleXMLBuilder {
+	b.text("sched", value)
+	return b
+}
+
+// WithMaxSrcNodes sets <max-src-nodes>.
+func (b *RuleXMLBuilder) WithMaxSrcNodes(value string) *RuleXMLBuilder {
+	b.text("max-src-nodes", value)
+	return b
+}
+
+// WithMaxSrcConn sets <max-src-conn>.
+func (b *RuleXMLBuilder) WithMaxSrcConn(value string) *RuleXMLBuilder {
+	b.text("max-src-conn", value)
+	return b
+}
+
+// WithMaxSrcConnRate sets <max-src-conn-rate>.
+func (b *RuleXMLBuilder) WithMaxSrcConnRate(value string) *RuleXMLBuilder {
+	b.text("max-src-conn-rate", value)
+	return b
+}
+
+// WithMaxSrcConnRates sets <max-src-conn-rates>.
+func (b *RuleXMLBuilder) WithMaxSrcConnRates(value string) *RuleXMLBuilder {
+	b.text("max-src-conn-rates", value)
+	return b
+}
+
+// WithTCPFlags1 sets <tcpflags1>.
+func (b *RuleXMLBuilder) WithTCPFlags1(value string) *RuleXMLBuilder {
+	b.text("tcpflags1", value)
+	return b
+}
+
+// WithTCPFlags2 sets <tcpflags2>.
+func (b *RuleXMLBuilder) WithTCPFlags2(value string) *RuleXMLBuilder {
+	b.text("tcpflags2", value)
+	return b
+}
+
+// WithTCPFlagsAny adds the <tcpflags_any/> flag.
+func (b *RuleXMLBuilder) WithTCPFlagsAny() *RuleXMLBuilder {
+	b.flag("tcpflags_any")
+	return b
+}
+
+// WithICMPType sets <icmptype>.
+func (b *RuleXMLBuilder) WithICMPType(value string) *RuleXMLBuilder {
+	b.text("icmptype", value)
+	return b
+}
+
+// WithICMP6Type sets <icmp6-type>.
+func (b *RuleXMLBuilder) WithICMP6Type(value string) *RuleXMLBuilder {
+	b.text("icmp6-type", value)
+	return b
+}
+
+// WithStateTimeout sets <statetimeout>.
+func (b *RuleXMLBuilder) WithStateTimeout(value string) *RuleXMLBuilder {
+	b.text("statetimeout", value)
+	return b
+}
+
+// WithAllowOpts adds the <allowopts/> flag.
+func (b *RuleXMLBuilder) WithAllowOpts() *RuleXMLBuilder {
+	b.flag("allowopts")
+	return b
+}
+
+// WithDisableReplyTo adds the <disablereplyto/> flag.
+func (b *RuleXMLBuilder) WithDisableReplyTo() *RuleXMLBuilder {
+	b.flag("disablereplyto")
+	return b
+}
+
+// WithNoPfSync adds the <nopfsync/> flag.
+func (b *RuleXMLBuilder) WithNoPfSync() *RuleXMLBuilder {
+	b.flag("nopfsync")
+	return b
+}
+
+// WithNoSync adds the <nosync/> flag.
+func (b *RuleXMLBuilder) WithNoSync() *RuleXMLBuilder {
+	b.flag("nosync")
+	return b
+}
+
+// WithUpdated adds an <updated> change stamp.
+func (b *RuleXMLBuilder) WithUpdated(username, time, description string) *RuleXMLBuilder {
+	b.changeStamp("updated", username, time, description)
+	return b
+}
+
+// WithCreated adds an <created> change stamp.
+func (b *RuleXMLBuilder) WithCreated(username, time, description string) *RuleXMLBuilder {
+	b.changeStamp("created", username, time, description)
+	return b
+}
+
+// Build returns the <rule> element.
+func (b *RuleXMLBuilder) Build() string {
+	return b.render("rule")
+}
@@ -0,0 +1,174 @@
+package testfixtures_test
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/schema/testfixtures"
+	"github.com/EvilBit-Labs/opnDossier/pkg/schema/opnsense"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEndpointXMLBuilder_Build(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "<source><any/></source>", testfixtures.NewSource().WithAny().Build())
+	assert.Equal(t,
+		"<destination><not/><network>lan</network><port>22</port></destination>",
+		testfixtures.NewDestination().WithNot().WithNetwork("lan").WithPort("22").Build(),
+	)
+	assert.Equal(t, "<source></source>", testfixtures.NewSource().Build())
+}
+
+func TestRuleXMLBuilder_Build(t *testing.T) {
+	t.Parallel()
+
+	got := testfixtures.NewRule().
+		WithType("pass").
+		WithFloating("yes").
+		WithSource(testfixtures.NewSource().WithNetwork("lan")).
+		Build()
+
+	assert.Equal(t, "<rule><type>pass</type><floating>yes</floating><source><network>lan</network></source></rule>", got)
+}
+
+func TestRuleXMLBuilder_EscapesValues(t *testing.T) {
+	t.Parallel()
+
+	xmlData := testfixtures.NewRule().WithUUID(`a"b`).WithDescr("LAN <-> DMZ & VPN").Build()
+
+	var rule opnsense.Rule
+	require.NoError(t, xml.Unmarshal([]byte(xmlData), &rule))
+	assert.Equal(t, `a"b`, rule.UUID)
+	assert.Equal(t, "LAN <-> DMZ & VPN", rule.Descr)
+}
+
+func TestRuleXMLBuilder_Unmarshals(t *testing.T) {
+	t.Parallel()
+
+	xmlData := testfixtures.NewRule().
+		WithUUID("rule-1").
+		WithType("block").
+		WithDescr("Block SSH").
+		WithInterface("lan,wan").
+		WithIPProtocol("inet").
+		WithStateType("keep state").
+		WithDirection("in").
+		WithFloating("yes").
+		WithQuick().
+		WithProtocol("tcp").
+		WithSource(testfixtures.NewSource().WithNot().WithAddress("10.0.0.0/8")).
+		WithDestination(testfixtures.NewDestination().WithAny().WithPort("22")).
+		WithGateway("WAN_GW").
+		WithLog().
+		WithDisabled().
+		WithSched("office-hours").
+		WithMaxSrcConn("50").
+		WithTCPFlagsAny().
+		WithICMP6Type("128").
+		WithNoSync().
+		WithUpdated("admin@10.0.0.1", "1700000000", "edited").
+		Build()
+
+	var rule opnsense.Rule
+	require.NoError(t, xml.Unmarshal([]byte(xmlData), &rule))
+
+	assert.Equal(t, "rule-1", rule.UUID)
+	assert.Equal(t, "block", rule.Type)
+	assert.Equal(t, "Block SSH", rule.Descr)
+	assert.Equal(t, "lan,wan", rule.Interface.String())
+	assert.Equal(t, "inet", rule.IPProtocol)
+	assert.Equal(t, "keep state", rule.StateType)
+	assert.Equal(t, "in", rule.Direction)
+	assert.Equal(t, "yes", rule.Floating)
+	assert.True(t, bool(rule.Quick))
+	assert.Equal(t, "tcp", rule.Protocol)
+	assert.Equal(t, "10.0.0.0/8", rule.Source.Address)
+	assert.True(t, bool(rule.Source.Not))
+	assert.True(t, rule.Destination.IsAny())
+	assert.Equal(t, "22", rule.Destination.Port)
+	assert.Equal(t, "WAN_GW", rule.Gateway)
+	assert.True(t, bool(rule.Log))
+	assert.True(t, bool(rule.Disabled))
+	assert.Equal(t, "office-hours", rule.Sched)
+	assert.Equal(t, "50", rule.MaxSrcConn)
+	assert.True(t, bool(rule.TCPFlagsAny))
+	assert.Equal(t, "128", rule.ICMP6Type)
+	assert.True(t, bool(rule.NoSync))
+	require.NotNil(t, rule.Updated)
+	assert.Equal(t, "admin@10.0.0.1", rule.Updated.Username)
+	assert.Equal(t, "edited", rule.Updated.Description)
+}
+
+func TestNATRuleXMLBuilder_Unmarshals(t *testing.T) {
+	t.Parallel()
+
+	xmlData := testfixtures.NewNATRule().
+		WithUUID("nat-1").
+		WithInterface("wan").
+		WithSource(testfixtures.NewSource().WithNetwork("lan")).
+		WithDestination(testfixtures.NewDestination().WithAny()).
+		WithTarget("203.0.113.10").
+		WithNatPort("1024-65535").
+		WithPoolOptsSrcHashKey("key").
+		WithStaticNatPort().
+		WithNoNat().
+		WithTag("nat-tag").
+		WithCreated("root", "1699000000", "created").
+		Build()
+
+	var rule opnsense.NATRule
+	require.NoError(t, xml.Unmarshal([]byte(xmlData), &rule))
+
+	assert.Equal(t, "nat-1", rule.UUID)
+	assert.Equal(t, "wan", rule.Interface.String())
+	assert.Equal(t, "lan", rule.Source.Network)
+	assert.True(t, rule.Destination.IsAny())
+	assert.Equal(t, "203.0.113.10", rule.Target)
+	assert.Equal(t, "1024-65535", rule.NatPort)
+	assert.Equal(t, "key", rule.PoolOptsSrcHashKey)
+	assert.True(t, bool(rule.StaticNatPort))
+	assert.True(t, bool(rule.NoNat))
+	assert.Equal(t, "nat-tag", rule.Tag)
+	require.NotNil(t, rule.Created)
+	assert.Equal(t, "root", rule.Created.Username)
+}
+
+func TestInboundRuleXMLBuilder_Unmarshals(t *testing.T) {
+	t.Parallel()
+
+	xmlData := testfixtures.NewInboundRule().
+		WithUUID("rdr-1").
+		WithInterface("wan").
+		WithProtocol("tcp").
+		WithDestination(testfixtures.NewDestination().WithNetwork("wanip").WithPort("443")).
+		WithExternalPort("443").
+		WithInternalIP("192.168.1.50").
+		WithInternalPort("8443").
+		WithLocalPort("8443").
+		WithNATReflection("purenat").
+		WithAssociatedRuleID("pass-1").
+		WithPriority("10").
+		WithNoRDR().
+		WithDescr("HTTPS").
+		Build()
+
+	var rule opnsense.InboundRule
+	require.NoError(t, xml.Unmarshal([]byte(xmlData), &rule))
+
+	assert.Equal(t, "rdr-1", rule.UUID)
+	assert.Equal(t, "wan", rule.Interface.String())
+	assert.Equal(t, "tcp", rule.Protocol)
+	assert.Equal(t, "wanip", rule.Destination.Network)
+	assert.Equal(t, "443", rule.Destination.Port)
+	assert.Equal(t, "443", rule.ExternalPort)
+	assert.Equal(t, "192.168.1.50", rule.InternalIP)
+	assert.Equal(t, "8443", rule.InternalPort)
+	assert.Equal(t, "8443", rule.LocalPort)
+	assert.Equal(t, "purenat", rule.NATReflection)
+	assert.Equal(t, "pass-1", rule.AssociatedRuleID)
+	assert.Equal(t, "10", rule.Priority)
+	assert.True(t, bool(rule.NoRDR))
+	assert.Equal(t, "HTTPS", rule.Descr)
+}
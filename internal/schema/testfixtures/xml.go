@@ -0,0 +1,95 @@
+// Package testfixtures builds OPNsense config.xml fragments for schema and
+// parser tests. Tests describe a fixture through method chaining, so a
+// renamed XML element is fixed here once instead of in every literal string:
+//
+//	xmlData := testfixtures.NewRule().
+//		WithType("pass").
+//		WithFloating("yes").
+//		WithSource(testfixtures.NewSource().WithNetwork("lan")).
+//		Build()
+//
+// Elements are written in the order the With methods are called. Values are
+// XML-escaped; presence flags such as <quick/> are written self-closing, as
+// OPNsense does.
+package testfixtures
+
+import (
+	"encoding/xml"
+	"strings"
+)
+
+// element is one child element of a fixture.
+type element struct {
+	name  string
+	value string
+	// flag writes the element self-closing, as a presence flag.
+	flag bool
+	// raw holds pre-rendered child markup, used for nested fixtures.
+	raw bool
+}
+
+// fragment accumulates the attributes and child elements of one XML element.
+type fragment struct {
+	attrs    []xml.Attr
+	elements []element
+}
+
+func (f *fragment) text(name, value string) {
+	f.elements = append(f.elements, element{name: name, value: value})
+}
+
+func (f *fragment) flag(name string) {
+	f.elements = append(f.elements, element{name: name, flag: true})
+}
+
+func (f *fragment) raw(markup string) {
+	f.elements = append(f.elements, element{value: markup, raw: true})
+}
+
+func (f *fragment) attr(name, value string) {
+	f.attrs = append(f.attrs, xml.Attr{Name: xml.Name{Local: name}, Value: value})
+}
+
+// changeStamp renders an <updated> or <created> block.
+func (f *fragment) changeStamp(name, username, time, description string) {
+	var stamp fragment
+	stamp.text("username", username)
+	stamp.text("time", time)
+	stamp.text("description", description)
+	f.raw(stamp.render(name))
+}
+
+// render returns the fragment as an element named name.
+func (f *fragment) render(name string) string {
+	var b strings.Builder
+
+	b.WriteString("<" + name)
+	for _, a := range f.attrs {
+		b.WriteString(" " + a.Name.Local + `="`)
+		escape(&b, a.Value)
+		b.WriteString(`"`)
+	}
+	b.WriteString(">")
+
+	for _, e := range f.elements {
+		switch {
+		case e.raw:
+			b.WriteString(e.value)
+		case e.flag:
+			b.WriteString("<" + e.name + "/>")
+		default:
+			b.WriteString("<" + e.name + ">")
+			escape(&b, e.value)
+			b.WriteString("</" + e.name + ">")
+		}
+	}
+
+	b.WriteString("</" + name + ">")
+
+	return b.String()
+}
+
+func escape(b *strings.Builder, s string) {
+	// strings.Builder writes never fail.
+	_ = xml.EscapeText(b, []byte(s))
+}
@@ -0,0 +1,198 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/cfgparser"
+	"github.com/EvilBit-Labs/opnDossier/internal/converter"
+	"github.com/EvilBit-Labs/opnDossier/internal/converter/builder"
+	"github.com/EvilBit-Labs/opnDossier/internal/exporter"
+	"github.com/EvilBit-Labs/opnDossier/pkg/parser"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Response content types.
+const (
+	contentTypeJSON     = "application/json"
+	contentTypeMarkdown = "text/markdown; charset=utf-8"
+)
+
+// uploadField is the multipart form field POST /api/v1/analyze reads the
+// configuration from.
+const uploadField = "config"
+
+// errNoConfiguration is reported by the GET endpoints when the server was
+// started without a configuration file.
+var errNoConfiguration = errors.New(
+	"no configuration loaded; start the server with a config.xml or push one to /api/v1/webhook/config")
+
+// errorResponse is the JSON body of every error response.
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// handleReport renders the served configuration as Markdown (the default) or
+// JSON, selected by the format query parameter.
+func (s *Server) handleReport(w http.ResponseWriter, r *http.Request) {
+	device := s.served()
+	if device == nil {
+		writeError(w, http.StatusNotFound, errNoConfiguration)
+		return
+	}
+
+	requested := r.URL.Query().Get("format")
+	format := converter.FormatMarkdown
+	if requested != "" {
+		canonical, _ := converter.DefaultRegistry.Canonical(requested)
+		format = converter.Format(canonical)
+	}
+
+	var contentType string
+	switch format {
+	case converter.FormatMarkdown:
+		contentType = contentTypeMarkdown
+	case converter.FormatJSON:
+		contentType = contentTypeJSON
+	default:
+		writeError(w, http.StatusBadRequest,
+			fmt.Errorf("unsupported format %q (supported: markdown, json)", requested))
+		return
+	}
+
+	gen, err := converter.NewHybridGenerator(builder.NewMarkdownBuilder(), s.logger)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	opts := converter.DefaultOptions().WithFormat(format).WithColors(false).WithRedact(true)
+
+	output, err := gen.Generate(r.Context(), device, opts)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Errorf("generate report: %w", err))
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	_, _ = w.Write([]byte(output))
+}
+
+// handleFindings returns the processor findings for the served configuration.
+func (s *Server) handleFindings(w http.ResponseWriter, r *http.Request) {
+	device := s.served()
+	if device == nil {
+		writeError(w, http.StatusNotFound, errNoConfiguration)
+		return
+	}
+
+	findings, err := s.servedFindings(r.Context(), device)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, findings)
+}
+
+// handleStatistics returns the statistics for the served configuration.
+func (s *Server) handleStatistics(w http.ResponseWriter, _ *http.Request) {
+	device := s.served()
+	if device == nil {
+		writeError(w, http.StatusNotFound, errNoConfiguration)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, statistics(device))
+}
+
+// handleMetrics serves the Prometheus metrics of the served configuration in
+// the exposition format the scraper negotiates.
+func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	device := s.served()
+	if device == nil {
+		writeError(w, http.StatusNotFound, errNoConfiguration)
+		return
+	}
+
+	findings, err := s.servedFindings(r.Context(), device)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	registry := prometheus.NewRegistry()
+	if err := registry.Register(exporter.NewPrometheusExporter(device, findings.Findings)); err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Errorf("register metrics: %w", err))
+		return
+	}
+
+	promhttp.HandlerFor(registry, promhttp.HandlerOpts{}).ServeHTTP(w, r)
+}
+
+// handleAnalyze parses the config.xml uploaded in the "config" multipart
+// field and returns its findings and statistics. Unparseable uploads get 422.
+func (s *Server) handleAnalyze(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, s.opts.MaxUploadSize)
+
+	file, _, err := r.FormFile(uploadField)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeError(w, http.StatusRequestEntityTooLarge,
+				fmt.Errorf("upload exceeds %d bytes", tooLarge.Limit))
+			return
+		}
+
+		writeError(w, http.StatusBadRequest,
+			fmt.Errorf("expected a multipart/form-data upload in the %q field: %w", uploadField, err))
+		return
+	}
+	defer file.Close()
+
+	device, warnings, err := parser.NewFactory(cfgparser.NewXMLParser()).
+		CreateDevice(r.Context(), file, s.opts.DeviceType, false)
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, fmt.Errorf("parse configuration: %w", err))
+		return
+	}
+
+	findings, err := s.findings(r.Context(), device)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, AnalyzeResponse{
+		DeviceType: device.DeviceType,
+		Hostname:   device.System.Hostname,
+		Warnings:   warnings,
+		Findings:   findings,
+		Statistics: statistics(device),
+	})
+}
+
+// writeJSON writes v as an indented JSON response with the given status.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	body, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Errorf("encode response: %w", err))
+		return
+	}
+
+	w.Header().Set("Content-Type", contentTypeJSON)
+	w.WriteHeader(status)
+	_, _ = w.Write(append(body, '\n'))
+}
+
+// writeError writes err as a JSON error response with the given status.
+func writeError(w http.ResponseWriter, status int, err error) {
+	body, _ := json.Marshal(errorResponse{Error: err.Error()})
+
+	w.Header().Set("Content-Type", contentTypeJSON)
+	w.WriteHeader(status)
+	_, _ = w.Write(append(body, '\n'))
+}
@@ -0,0 +1,297 @@
+// Package server serves opnDossier reports, findings, and statistics over
+// HTTP so dashboards can integrate without shelling out to the CLI.
+//
+// The API is versioned under /api/v1:
+//
+//	GET  /api/v1/report?format=markdown|json  report for the served configuration
+//	GET  /api/v1/findings                     processor findings and posture rating
+//	GET  /api/v1/statistics                   configuration statistics
+//	POST /api/v1/analyze                      findings and statistics for an uploaded config.xml
+//	POST /api/v1/webhook/config               replace the served configuration with a pushed config.xml
+//
+// With Options.Prometheus set, GET /metrics also serves the served
+// configuration's findings and rule counts as Prometheus metrics.
+//
+// A configuration pushed to the webhook replaces the served one for every
+// GET endpoint. With Options.WebhookSecret set, pushes must carry an
+// HMAC-SHA256 signature of the body and need no API key.
+//
+// Sensitive fields are always redacted, since responses leave the host.
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/analysis"
+	"github.com/EvilBit-Labs/opnDossier/internal/logging"
+	"github.com/EvilBit-Labs/opnDossier/internal/processor"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+)
+
+// HTTP server timeouts. Report generation for large configurations can take
+// several seconds, so the write timeout is generous.
+const (
+	readHeaderTimeout = 10 * time.Second
+	readTimeout       = time.Minute
+	writeTimeout      = 2 * time.Minute
+	idleTimeout       = 2 * time.Minute
+	shutdownTimeout   = 10 * time.Second
+)
+
+// Options configures a Server.
+type Options struct {
+	// Device is the configuration served by the GET endpoints until one is
+	// pushed to the webhook. When nil they respond 404 until then.
+	Device *common.CommonDevice
+
+	// DeviceType forces the parser used for uploaded configurations.
+	// DeviceTypeUnknown detects it from the XML root element.
+	DeviceType common.DeviceType
+
+	// APIKey, when set, must be sent in the X-API-Key header or as a bearer
+	// token with every request.
+	APIKey string
+
+	// Prometheus adds GET /metrics, serving the served configuration's
+	// findings and rule counts in the Prometheus exposition format.
+	Prometheus bool
+
+	// WebhookSecret, when set, is the HMAC-SHA256 key POST
+	// /api/v1/webhook/config bodies must be signed with in the
+	// X-Opndossier-Signature-256 header. Signed pushes bypass APIKey.
+	WebhookSecret string
+
+	// MaxUploadSize bounds the body of POST /api/v1/analyze. Zero or
+	// negative allows a 10MB config.xml plus room for the multipart framing.
+	MaxUploadSize int64
+
+	// MaxWebhookSize bounds the body of POST /api/v1/webhook/config, which
+	// with WebhookSecret set is read before the push is authenticated. Zero
+	// or negative uses MaxUploadSize, so any config that can be analyzed can
+	// also be pushed.
+	MaxWebhookSize int64
+
+	// Logger receives one record per request. A default logger writing to
+	// stderr is created when nil.
+	Logger *logging.Logger
+}
+
+// Server handles the opnDossier REST API.
+type Server struct {
+	opts   Options
+	logger *logging.Logger
+
+	// mu guards the served configuration, which the webhook replaces while
+	// other requests read it.
+	mu     sync.RWMutex
+	device *common.CommonDevice
+	// cached holds device's findings once computed, so repeated GET
+	// requests do not rerun the analysis.
+	cached *FindingsResponse
+}
+
+// defaultMaxConfigSize is the largest config.xml the server accepts by
+// default. It is far below the parser's own input limit because uploads
+// come from the network rather than from the operator's disk.
+const defaultMaxConfigSize = 10 * 1024 * 1024 // 10MB
+
+// multipartOverhead is the room left above defaultMaxConfigSize for
+// multipart boundaries and part headers in the default upload limit.
+const multipartOverhead = 64 * 1024
+
+// New returns a Server for opts. It returns an error only if opts.Logger is
+// nil and creating the default logger fails.
+func New(opts Options) (*Server, error) {
+	logger := opts.Logger
+	if logger == nil {
+		var err error
+
+		logger, err = logging.New(logging.Config{})
+		if err != nil {
+			return nil, fmt.Errorf("create default logger: %w", err)
+		}
+	}
+
+	if opts.MaxUploadSize <= 0 {
+		opts.MaxUploadSize = defaultMaxConfigSize + multipartOverhead
+	}
+
+	if opts.MaxWebhookSize <= 0 {
+		opts.MaxWebhookSize = opts.MaxUploadSize
+	}
+
+	return &Server{opts: opts, logger: logger, device: opts.Device}, nil
+}
+
+// Handler returns the API routes wrapped in the request logging and API key
+// middleware. Requests with a method a route does not accept get 405.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/report", s.handleReport)
+	mux.HandleFunc("GET /api/v1/findings", s.handleFindings)
+	mux.HandleFunc("GET /api/v1/statistics", s.handleStatistics)
+	mux.HandleFunc("POST /api/v1/analyze", s.handleAnalyze)
+	mux.HandleFunc("POST "+webhookPath, s.handleWebhook)
+	if s.opts.Prometheus {
+		mux.HandleFunc("GET /metrics", s.handleMetrics)
+	}
+
+	handler := requireAPIKey(s.opts.APIKey, mux)
+	if s.opts.WebhookSecret != "" {
+		// The signature authenticates pushes, so the firewall needs no API key.
+		handler = bypassForPath(webhookPath, mux, handler)
+	}
+
+	return logRequests(s.logger, handler)
+}
+
+// ListenAndServe serves the API on addr until ctx is canceled, then shuts
+// down gracefully, letting in-flight requests finish. ready, when non-nil, is
+// called with the bound address once the listener is open, which lets
+// callers listen on port 0.
+func (s *Server) ListenAndServe(ctx context.Context, addr string, ready func(net.Addr)) error {
+	var lc net.ListenConfig
+
+	ln, err := lc.Listen(ctx, "tcp", addr)
+	if err != nil {
+		return fmt.Errorf("listen on %s: %w", addr, err)
+	}
+
+	srv := &http.Server{
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: readHeaderTimeout,
+		ReadTimeout:       readTimeout,
+		WriteTimeout:      writeTimeout,
+		IdleTimeout:       idleTimeout,
+		BaseContext:       func(net.Listener) context.Context { return ctx },
+	}
+
+	if ready != nil {
+		ready(ln.Addr())
+	}
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- srv.Serve(ln) }()
+
+	select {
+	case err := <-errCh:
+		return fmt.Errorf("serve: %w", err)
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), shutdownTimeout)
+	defer cancel()
+
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("shut down: %w", err)
+	}
+	if err := <-errCh; err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("serve: %w", err)
+	}
+
+	return nil
+}
+
+// FindingsResponse is the body of GET /api/v1/findings.
+type FindingsResponse struct {
+	// Total is the number of findings across all severities.
+	Total int `json:"total"`
+	// Rating is the traffic-light posture rating derived from the findings.
+	Rating processor.PostureRating `json:"rating"`
+	// Findings are the processor findings grouped by severity.
+	Findings processor.Findings `json:"findings"`
+}
+
+// AnalyzeResponse is the body of POST /api/v1/analyze.
+type AnalyzeResponse struct {
+	// DeviceType is the platform the uploaded configuration was parsed as.
+	DeviceType common.DeviceType `json:"deviceType"`
+	// Hostname is the hostname of the uploaded configuration.
+	Hostname string `json:"hostname,omitempty"`
+	// Warnings are the non-fatal issues found while parsing the upload.
+	Warnings []common.ConversionWarning `json:"warnings,omitempty"`
+	// Findings are the processor findings for the upload.
+	Findings FindingsResponse `json:"findings"`
+	// Statistics are the configuration statistics for the upload.
+	Statistics *common.Statistics `json:"statistics"`
+}
+
+// served returns the configuration the GET endpoints serve, or nil when none
+// has been loaded or pushed.
+func (s *Server) served() *common.CommonDevice {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.device
+}
+
+// servedFindings returns the findings of the served configuration, running
+// the analysis on the first request after the configuration was loaded or
+// replaced.
+func (s *Server) servedFindings(ctx context.Context, device *common.CommonDevice) (FindingsResponse, error) {
+	s.mu.RLock()
+	cached := s.cached
+	current := s.device
+	s.mu.RUnlock()
+
+	if cached != nil && current == device {
+		return *cached, nil
+	}
+
+	findings, err := s.findings(ctx, device)
+	if err != nil {
+		return FindingsResponse{}, err
+	}
+
+	s.mu.Lock()
+	if s.device == device {
+		s.cached = &findings
+	}
+	s.mu.Unlock()
+
+	return findings, nil
+}
+
+// replaceServed makes device, with its already computed findings, the
+// served configuration.
+func (s *Server) replaceServed(device *common.CommonDevice, findings FindingsResponse) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.device = device
+	s.cached = &findings
+}
+
+// findings runs every processor analysis on device.
+func (s *Server) findings(ctx context.Context, device *common.CommonDevice) (FindingsResponse, error) {
+	p, err := processor.NewCoreProcessor(s.logger)
+	if err != nil {
+		return FindingsResponse{}, fmt.Errorf("create processor: %w", err)
+	}
+
+	report, err := p.Process(ctx, device, processor.WithAllFeatures())
+	if err != nil {
+		return FindingsResponse{}, fmt.Errorf("process configuration: %w", err)
+	}
+
+	return FindingsResponse{
+		Total:    report.TotalFindings(),
+		Rating:   report.Rating,
+		Findings: report.Findings,
+	}, nil
+}
+
+// statistics computes device's statistics with sensitive service details
+// redacted.
+func statistics(device *common.CommonDevice) *common.Statistics {
+	stats := analysis.ComputeStatistics(device)
+	stats.ServiceDetails, _ = analysis.RedactServiceDetails(stats.ServiceDetails)
+
+	return stats
+}
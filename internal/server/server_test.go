@@ -0,0 +1,383 @@
+package server_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/cfgparser"
+	"github.com/EvilBit-Labs/opnDossier/internal/logging"
+	"github.com/EvilBit-Labs/opnDossier/internal/server"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/EvilBit-Labs/opnDossier/pkg/parser"
+	_ "github.com/EvilBit-Labs/opnDossier/pkg/parser/opnsense" // self-registers OPNsense parser via init()
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const fixturePath = "../../testdata/gif_tunnel_test.xml"
+
+// newTestServer starts an httptest server for opts, loading the fixture as
+// the served device when withDevice is set.
+func newTestServer(t *testing.T, opts server.Options, withDevice bool) *httptest.Server {
+	t.Helper()
+
+	if withDevice {
+		opts.Device = loadFixture(t)
+	}
+	if opts.Logger == nil {
+		logger, err := logging.New(logging.Config{Output: io.Discard})
+		require.NoError(t, err)
+		opts.Logger = logger
+	}
+
+	srv, err := server.New(opts)
+	require.NoError(t, err)
+
+	ts := httptest.NewServer(srv.Handler())
+	t.Cleanup(ts.Close)
+
+	return ts
+}
+
+func loadFixture(t *testing.T) *common.CommonDevice {
+	t.Helper()
+
+	f, err := os.Open(fixturePath)
+	require.NoError(t, err)
+	defer f.Close()
+
+	device, _, err := parser.NewFactory(cfgparser.NewXMLParser()).
+		CreateDevice(context.Background(), f, common.DeviceTypeUnknown, false)
+	require.NoError(t, err)
+
+	return device
+}
+
+func get(t *testing.T, url string, header http.Header) (*http.Response, []byte) {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, url, nil)
+	require.NoError(t, err)
+	for k, v := range header {
+		req.Header[k] = v
+	}
+
+	return do(t, req)
+}
+
+func do(t *testing.T, req *http.Request) (*http.Response, []byte) {
+	t.Helper()
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	return resp, body
+}
+
+// uploadRequest builds a multipart POST /api/v1/analyze request with content
+// in the given form field.
+func uploadRequest(t *testing.T, baseURL, field string, content []byte) *http.Request {
+	t.Helper()
+
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	part, err := mw.CreateFormFile(field, "config.xml")
+	require.NoError(t, err)
+	_, err = part.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, mw.Close())
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost,
+		baseURL+"/api/v1/analyze", &buf)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+
+	return req
+}
+
+func TestServer_Report(t *testing.T) {
+	t.Parallel()
+
+	ts := newTestServer(t, server.Options{}, true)
+
+	tests := []struct {
+		name            string
+		query           string
+		wantStatus      int
+		wantContentType string
+		wantContains    string
+	}{
+		{
+			name:            "default markdown",
+			wantStatus:      http.StatusOK,
+			wantContentType: "text/markdown; charset=utf-8",
+			wantContains:    "gif-tunnel",
+		},
+		{
+			name:            "markdown alias",
+			query:           "?format=md",
+			wantStatus:      http.StatusOK,
+			wantContentType: "text/markdown; charset=utf-8",
+			wantContains:    "HE IPv6 Tunnel",
+		},
+		{
+			name:            "json",
+			query:           "?format=JSON",
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/json",
+			wantContains:    `"hostname": "gif-tunnel"`,
+		},
+		{
+			name:            "unsupported format",
+			query:           "?format=html",
+			wantStatus:      http.StatusBadRequest,
+			wantContentType: "application/json",
+			wantContains:    `unsupported format \"html\"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			resp, body := get(t, ts.URL+"/api/v1/report"+tt.query, nil)
+
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+			assert.Equal(t, tt.wantContentType, resp.Header.Get("Content-Type"))
+			assert.Contains(t, string(body), tt.wantContains)
+		})
+	}
+}
+
+func TestServer_Findings(t *testing.T) {
+	t.Parallel()
+
+	ts := newTestServer(t, server.Options{}, true)
+
+	resp, body := get(t, ts.URL+"/api/v1/findings", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+
+	var got server.FindingsResponse
+	require.NoError(t, json.Unmarshal(body, &got))
+	assert.Positive(t, got.Total)
+	assert.NotEmpty(t, got.Rating)
+}
+
+func TestServer_Statistics(t *testing.T) {
+	t.Parallel()
+
+	ts := newTestServer(t, server.Options{}, true)
+
+	resp, body := get(t, ts.URL+"/api/v1/statistics", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+
+	var got common.Statistics
+	require.NoError(t, json.Unmarshal(body, &got))
+	assert.Equal(t, 2, got.TotalInterfaces)
+}
+
+func TestServer_Metrics(t *testing.T) {
+	t.Parallel()
+
+	resp, _ := get(t, newTestServer(t, server.Options{}, true).URL+"/metrics", nil)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode, "disabled by default")
+
+	ts := newTestServer(t, server.Options{Prometheus: true}, true)
+
+	resp, body := get(t, ts.URL+"/metrics", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, resp.Header.Get("Content-Type"), "text/plain")
+	for _, want := range []string{
+		`opndossier_findings_total{severity="low"} 1`,
+		`opndossier_nat_rules_total{direction="inbound"} 0`,
+		`opndossier_interfaces_total{enabled="true"} 2`,
+	} {
+		assert.Contains(t, string(body), want)
+	}
+
+	resp, _ = get(t, newTestServer(t, server.Options{Prometheus: true}, false).URL+"/metrics", nil)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestServer_NoConfiguration(t *testing.T) {
+	t.Parallel()
+
+	ts := newTestServer(t, server.Options{}, false)
+
+	for _, path := range []string{"/api/v1/report", "/api/v1/findings", "/api/v1/statistics"} {
+		resp, body := get(t, ts.URL+path, nil)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode, path)
+		assert.Contains(t, string(body), "no configuration loaded", path)
+	}
+}
+
+func TestServer_MethodNotAllowed(t *testing.T) {
+	t.Parallel()
+
+	ts := newTestServer(t, server.Options{}, true)
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost,
+		ts.URL+"/api/v1/report", http.NoBody)
+	require.NoError(t, err)
+	resp, _ := do(t, req)
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+
+	resp, _ = get(t, ts.URL+"/api/v1/analyze", nil)
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+}
+
+func TestServer_Analyze(t *testing.T) {
+	t.Parallel()
+
+	fixture, err := os.ReadFile(fixturePath)
+	require.NoError(t, err)
+
+	ts := newTestServer(t, server.Options{MaxUploadSize: 16 * 1024}, false)
+
+	t.Run("valid upload", func(t *testing.T) {
+		t.Parallel()
+
+		resp, body := do(t, uploadRequest(t, ts.URL, "config", fixture))
+		require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+		assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+
+		var got server.AnalyzeResponse
+		require.NoError(t, json.Unmarshal(body, &got))
+		assert.Equal(t, common.DeviceTypeOPNsense, got.DeviceType)
+		assert.Equal(t, "gif-tunnel", got.Hostname)
+		assert.Positive(t, got.Findings.Total)
+		require.NotNil(t, got.Statistics)
+		assert.Equal(t, 2, got.Statistics.TotalInterfaces)
+	})
+
+	t.Run("wrong field", func(t *testing.T) {
+		t.Parallel()
+
+		resp, body := do(t, uploadRequest(t, ts.URL, "file", fixture))
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		assert.Contains(t, string(body), `\"config\" field`)
+	})
+
+	t.Run("unparseable upload", func(t *testing.T) {
+		t.Parallel()
+
+		resp, body := do(t, uploadRequest(t, ts.URL, "config", []byte("<notaconfig/>")))
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+		assert.Contains(t, string(body), "parse configuration")
+	})
+
+	t.Run("oversized upload", func(t *testing.T) {
+		t.Parallel()
+
+		resp, body := do(t, uploadRequest(t, ts.URL, "config", bytes.Repeat([]byte("x"), 32*1024)))
+		assert.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode)
+		assert.Contains(t, string(body), "upload exceeds 16384 bytes")
+	})
+}
+
+func TestServer_APIKey(t *testing.T) {
+	t.Parallel()
+
+	ts := newTestServer(t, server.Options{APIKey: "s3cret"}, true)
+	url := ts.URL + "/api/v1/statistics"
+
+	tests := []struct {
+		name       string
+		header     http.Header
+		wantStatus int
+	}{
+		{name: "missing", wantStatus: http.StatusUnauthorized},
+		{name: "wrong key", header: http.Header{"X-Api-Key": {"nope"}}, wantStatus: http.StatusUnauthorized},
+		{name: "wrong bearer", header: http.Header{"Authorization": {"Bearer nope"}}, wantStatus: http.StatusUnauthorized},
+		{name: "basic auth", header: http.Header{"Authorization": {"Basic s3cret"}}, wantStatus: http.StatusUnauthorized},
+		{name: "header", header: http.Header{"X-Api-Key": {"s3cret"}}, wantStatus: http.StatusOK},
+		{name: "bearer", header: http.Header{"Authorization": {"Bearer s3cret"}}, wantStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			resp, body := get(t, url, tt.header)
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+			if tt.wantStatus == http.StatusUnauthorized {
+				assert.Contains(t, string(body), "missing or invalid API key")
+				assert.NotEmpty(t, resp.Header.Get("WWW-Authenticate"))
+			}
+		})
+	}
+}
+
+func TestServer_LogsRequests(t *testing.T) {
+	t.Parallel()
+
+	var logs bytes.Buffer
+	logger, err := logging.New(logging.Config{Output: &logs})
+	require.NoError(t, err)
+
+	ts := newTestServer(t, server.Options{Logger: logger}, false)
+
+	resp, _ := get(t, ts.URL+"/api/v1/findings", nil)
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	out := logs.String()
+	assert.Contains(t, out, "HTTP request")
+	assert.Contains(t, out, "path=/api/v1/findings")
+	assert.Contains(t, out, "status=404")
+}
+
+func TestServer_ListenAndServe(t *testing.T) {
+	t.Parallel()
+
+	logger, err := logging.New(logging.Config{Output: io.Discard})
+	require.NoError(t, err)
+
+	srv, err := server.New(server.Options{Device: loadFixture(t), Logger: logger})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	addrCh := make(chan net.Addr, 1)
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- srv.ListenAndServe(ctx, "127.0.0.1:0", func(addr net.Addr) { addrCh <- addr })
+	}()
+
+	var addr net.Addr
+	select {
+	case addr = <-addrCh:
+	case err := <-errCh:
+		t.Fatalf("ListenAndServe returned before listening: %v", err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the listener")
+	}
+
+	resp, body := get(t, "http://"+addr.String()+"/api/v1/report?format=json", nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, string(body), "gif-tunnel")
+
+	cancel()
+
+	select {
+	case err := <-errCh:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for shutdown")
+	}
+}
@@ -0,0 +1,182 @@
+package server
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/cfgparser"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/EvilBit-Labs/opnDossier/pkg/parser"
+)
+
+// webhookPath is the route firewalls push their config.xml to.
+const webhookPath = "/api/v1/webhook/config"
+
+// Webhook signature header and the prefix of its value, following the GitHub
+// webhook convention: "sha256=" and the hex HMAC-SHA256 of the request body.
+const (
+	// SignatureHeader carries the signature of a webhook push.
+	SignatureHeader = "X-Opndossier-Signature-256"
+	signaturePrefix = "sha256="
+)
+
+// errInvalidSignature is returned to webhook pushes whose signature is
+// missing or does not match the body.
+var errInvalidSignature = errors.New("missing or invalid webhook signature")
+
+// WebhookResponse is the body of POST /api/v1/webhook/config.
+type WebhookResponse struct {
+	// DeviceType is the platform the pushed configuration was parsed as.
+	DeviceType common.DeviceType `json:"deviceType"`
+	// Hostname is the hostname of the pushed configuration.
+	Hostname string `json:"hostname,omitempty"`
+	// ReceivedAt is when the configuration was received.
+	ReceivedAt time.Time `json:"receivedAt"`
+	// Warnings are the non-fatal issues found while parsing the push.
+	Warnings []common.ConversionWarning `json:"warnings,omitempty"`
+	// Findings are the processor findings now served by GET /api/v1/findings.
+	Findings FindingsResponse `json:"findings"`
+}
+
+// handleWebhook accepts a config.xml pushed as a multipart upload, such as a
+// firewall backup export, analyzes it, and makes it the served configuration.
+// The upload is read from the "config" field, or the first file part when
+// there is none. With a webhook secret configured the body must be signed;
+// unsigned or mis-signed pushes get 401 and leave the served configuration
+// unchanged, as do unparseable ones (422). A missing or malformed signature
+// is rejected before the body is read.
+func (s *Server) handleWebhook(w http.ResponseWriter, r *http.Request) {
+	var signature []byte
+	if s.opts.WebhookSecret != "" {
+		var ok bool
+		if signature, ok = parseSignature(r.Header.Get(SignatureHeader)); !ok {
+			writeError(w, http.StatusUnauthorized, errInvalidSignature)
+			return
+		}
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, s.opts.MaxWebhookSize)
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeError(w, http.StatusRequestEntityTooLarge,
+				fmt.Errorf("upload exceeds %d bytes", tooLarge.Limit))
+			return
+		}
+
+		writeError(w, http.StatusBadRequest, fmt.Errorf("read request body: %w", err))
+		return
+	}
+
+	if s.opts.WebhookSecret != "" && !validSignature(s.opts.WebhookSecret, body, signature) {
+		writeError(w, http.StatusUnauthorized, errInvalidSignature)
+		return
+	}
+
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	file, err := webhookUpload(r, s.opts.MaxWebhookSize)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	defer func() { _ = r.MultipartForm.RemoveAll() }()
+	defer file.Close()
+
+	device, warnings, err := parser.NewFactory(cfgparser.NewXMLParser()).
+		CreateDevice(r.Context(), file, s.opts.DeviceType, false)
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, fmt.Errorf("parse configuration: %w", err))
+		return
+	}
+
+	findings, err := s.findings(r.Context(), device)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	s.replaceServed(device, findings)
+	s.logger.Info("Configuration received via webhook",
+		"hostname", device.System.Hostname,
+		"findings", findings.Total)
+
+	writeJSON(w, http.StatusOK, WebhookResponse{
+		DeviceType: device.DeviceType,
+		Hostname:   device.System.Hostname,
+		ReceivedAt: time.Now().UTC(),
+		Warnings:   warnings,
+		Findings:   findings,
+	})
+}
+
+// webhookUpload returns the config.xml of a multipart webhook push: the
+// "config" field when present, otherwise the first file part by field name.
+// Up to maxMemory bytes of the form are held in memory.
+func webhookUpload(r *http.Request, maxMemory int64) (multipart.File, error) {
+	if err := r.ParseMultipartForm(maxMemory); err != nil {
+		return nil, fmt.Errorf("expected a multipart/form-data upload: %w", err)
+	}
+
+	files := r.MultipartForm.File
+	field := uploadField
+	if len(files[field]) == 0 {
+		names := make([]string, 0, len(files))
+		for name, headers := range files {
+			if len(headers) > 0 {
+				names = append(names, name)
+			}
+		}
+		if len(names) == 0 {
+			return nil, errors.New("expected a config.xml file part in the multipart upload")
+		}
+
+		slices.Sort(names)
+		field = names[0]
+	}
+
+	file, err := files[field][0].Open()
+	if err != nil {
+		return nil, fmt.Errorf("open uploaded file: %w", err)
+	}
+
+	return file, nil
+}
+
+// parseSignature returns the digest of a "sha256=<hex>" signature header. It
+// reports false unless the header holds exactly one hex-encoded SHA-256
+// digest.
+func parseSignature(header string) ([]byte, bool) {
+	encoded, ok := strings.CutPrefix(header, signaturePrefix)
+	if !ok || len(encoded) != hex.EncodedLen(sha256.Size) {
+		return nil, false
+	}
+
+	digest, err := hex.DecodeString(encoded)
+	if err != nil {
+		return nil, false
+	}
+
+	return digest, true
+}
+
+// validSignature reports whether signature is the HMAC-SHA256 of body keyed
+// with secret. Digests are compared in constant time.
+func validSignature(secret string, body, signature []byte) bool {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+
+	return hmac.Equal(signature, mac.Sum(nil))
+}
@@ -0,0 +1,256 @@
+package server_test
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/server"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// webhookRequest builds a multipart POST /api/v1/webhook/config request with
+// content in the given form field, signed with secret unless it is empty.
+func webhookRequest(t *testing.T, baseURL, field string, content []byte, secret string) *http.Request {
+	t.Helper()
+
+	req := uploadRequest(t, baseURL, field, content)
+	req.URL.Path = "/api/v1/webhook/config"
+
+	if secret != "" {
+		body, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		req.Header.Set(server.SignatureHeader, sign(secret, body))
+	}
+
+	return req
+}
+
+// sign returns the webhook signature header value for body.
+func sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// servedHostname returns the hostname of the configuration the server's
+// report endpoint currently serves, or "" when none is loaded.
+func servedHostname(t *testing.T, baseURL string, header http.Header) string {
+	t.Helper()
+
+	resp, body := get(t, baseURL+"/api/v1/report?format=json", header)
+	if resp.StatusCode == http.StatusNotFound {
+		return ""
+	}
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+
+	var report struct {
+		System struct {
+			Hostname string `json:"hostname"`
+		} `json:"system"`
+	}
+	require.NoError(t, json.Unmarshal(body, &report))
+
+	return report.System.Hostname
+}
+
+func TestServer_Webhook(t *testing.T) {
+	t.Parallel()
+
+	fixture, err := os.ReadFile(fixturePath)
+	require.NoError(t, err)
+
+	ts := newTestServer(t, server.Options{}, false)
+
+	resp, _ := get(t, ts.URL+"/api/v1/findings", nil)
+	require.Equal(t, http.StatusNotFound, resp.StatusCode, "nothing is served before the first push")
+
+	resp, body := do(t, webhookRequest(t, ts.URL, "config", fixture, ""))
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+
+	var pushed server.WebhookResponse
+	require.NoError(t, json.Unmarshal(body, &pushed))
+	assert.Equal(t, common.DeviceTypeOPNsense, pushed.DeviceType)
+	assert.Equal(t, "gif-tunnel", pushed.Hostname)
+	assert.False(t, pushed.ReceivedAt.IsZero())
+	assert.Positive(t, pushed.Findings.Total)
+
+	resp, body = get(t, ts.URL+"/api/v1/findings", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var findings server.FindingsResponse
+	require.NoError(t, json.Unmarshal(body, &findings))
+	assert.Equal(t, pushed.Findings.Total, findings.Total)
+	assert.Equal(t, "gif-tunnel", servedHostname(t, ts.URL, nil))
+}
+
+// TestServer_WebhookReanalyzes pushes a changed configuration over a served
+// one and checks every GET endpoint follows it.
+func TestServer_WebhookReanalyzes(t *testing.T) {
+	t.Parallel()
+
+	fixture, err := os.ReadFile(fixturePath)
+	require.NoError(t, err)
+	updated, err := os.ReadFile("../../testdata/effective_rules_test.xml")
+	require.NoError(t, err)
+
+	ts := newTestServer(t, server.Options{}, true)
+
+	resp, body := get(t, ts.URL+"/api/v1/findings", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var before server.FindingsResponse
+	require.NoError(t, json.Unmarshal(body, &before))
+	require.Equal(t, "gif-tunnel", servedHostname(t, ts.URL, nil))
+
+	// The firewall's backup export may use a different field name.
+	resp, body = do(t, webhookRequest(t, ts.URL, "backup", updated, ""))
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+
+	resp, body = get(t, ts.URL+"/api/v1/findings", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var after server.FindingsResponse
+	require.NoError(t, json.Unmarshal(body, &after))
+	assert.NotEqual(t, before, after, "findings must be recomputed for the pushed configuration")
+	assert.Equal(t, "effective-firewall", servedHostname(t, ts.URL, nil))
+
+	resp, body = get(t, ts.URL+"/api/v1/statistics", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var stats common.Statistics
+	require.NoError(t, json.Unmarshal(body, &stats))
+	assert.Equal(t, 4, stats.TotalFirewallRules)
+
+	// A push that does not parse leaves the served configuration alone.
+	resp, _ = do(t, webhookRequest(t, ts.URL, "config", []byte("<notaconfig/>"), ""))
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	assert.Equal(t, "effective-firewall", servedHostname(t, ts.URL, nil))
+
+	resp, _ = do(t, webhookRequest(t, ts.URL, "config", fixture, ""))
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "gif-tunnel", servedHostname(t, ts.URL, nil))
+}
+
+func TestServer_WebhookSignature(t *testing.T) {
+	t.Parallel()
+
+	fixture, err := os.ReadFile(fixturePath)
+	require.NoError(t, err)
+
+	const secret = "webhook-s3cret"
+	ts := newTestServer(t, server.Options{APIKey: "api-s3cret", WebhookSecret: secret}, false)
+	apiKey := http.Header{"X-Api-Key": {"api-s3cret"}}
+
+	tests := []struct {
+		name   string
+		header string
+	}{
+		{name: "missing"},
+		{name: "wrong secret", header: sign("guess", fixture)},
+		{name: "no prefix", header: sign(secret, fixture)[len("sha256="):]},
+		{name: "not hex", header: "sha256=zz"},
+		{name: "short digest", header: "sha256=abcd"},
+		{name: "long digest", header: sign(secret, fixture) + "00"},
+	}
+
+	// Checked in sequence: every rejection must happen before the signed push.
+	for _, tt := range tests {
+		req := webhookRequest(t, ts.URL, "config", fixture, "")
+		if tt.header != "" {
+			req.Header.Set(server.SignatureHeader, tt.header)
+		}
+
+		resp, body := do(t, req)
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode, tt.name)
+		assert.Contains(t, string(body), "missing or invalid webhook signature", tt.name)
+	}
+
+	assert.Empty(t, servedHostname(t, ts.URL, apiKey), "rejected pushes must not be served")
+
+	resp, body := do(t, webhookRequest(t, ts.URL, "config", fixture, secret))
+	require.Equal(t, http.StatusOK, resp.StatusCode, "a signed push needs no API key: %s", body)
+	assert.Equal(t, "gif-tunnel", servedHostname(t, ts.URL, apiKey))
+
+	resp, _ = get(t, ts.URL+"/api/v1/findings", nil)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode, "other routes still require the API key")
+}
+
+func TestServer_WebhookRequiresAPIKeyWithoutSecret(t *testing.T) {
+	t.Parallel()
+
+	fixture, err := os.ReadFile(fixturePath)
+	require.NoError(t, err)
+
+	ts := newTestServer(t, server.Options{APIKey: "api-s3cret"}, false)
+
+	resp, _ := do(t, webhookRequest(t, ts.URL, "config", fixture, ""))
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	req := webhookRequest(t, ts.URL, "config", fixture, "")
+	req.Header.Set("X-Api-Key", "api-s3cret")
+	resp, body := do(t, req)
+	assert.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+}
+
+// TestServer_WebhookSignatureCheckedBeforeBody verifies that a push with a
+// missing or malformed signature is rejected before its body is read, so an
+// oversized body gets 401 rather than 413, while a well-formed signature
+// still has the body size enforced.
+func TestServer_WebhookSignatureCheckedBeforeBody(t *testing.T) {
+	t.Parallel()
+
+	const secret = "webhook-s3cret"
+	ts := newTestServer(t, server.Options{WebhookSecret: secret, MaxWebhookSize: 1024}, false)
+	oversized := bytes.Repeat([]byte("x"), 4096)
+
+	req := webhookRequest(t, ts.URL, "config", oversized, "")
+	req.Header.Set(server.SignatureHeader, "sha256=zz")
+	resp, body := do(t, req)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode, string(body))
+
+	resp, body = do(t, webhookRequest(t, ts.URL, "config", oversized, "guess"))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode, string(body))
+}
+
+// TestServer_WebhookSizeLimit verifies that the webhook enforces its own
+// limit, which is independent of the analyze upload limit.
+func TestServer_WebhookSizeLimit(t *testing.T) {
+	t.Parallel()
+
+	fixture, err := os.ReadFile(fixturePath)
+	require.NoError(t, err)
+
+	ts := newTestServer(t, server.Options{MaxWebhookSize: 1024}, false)
+
+	resp, body := do(t, webhookRequest(t, ts.URL, "config", fixture, ""))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode, string(body))
+
+	resp, body = do(t, uploadRequest(t, ts.URL, "config", fixture))
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "the analyze limit is unaffected: %s", body)
+	assert.Empty(t, servedHostname(t, ts.URL, nil), "an oversized push must not be served")
+}
+
+func TestServer_WebhookSizeLimitDefaultsToUploadLimit(t *testing.T) {
+	t.Parallel()
+
+	fixture, err := os.ReadFile(fixturePath)
+	require.NoError(t, err)
+
+	ts := newTestServer(t, server.Options{MaxUploadSize: 1024}, false)
+
+	resp, body := do(t, webhookRequest(t, ts.URL, "config", fixture, ""))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode, string(body))
+
+	ts = newTestServer(t, server.Options{}, false)
+
+	resp, body = do(t, webhookRequest(t, ts.URL, "config", fixture, ""))
+	assert.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+}
@@ -0,0 +1,153 @@
+package testutil
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// ConfigSpec sizes a synthetic OPNsense config.xml built by SyntheticConfigXML.
+type ConfigSpec struct {
+	// Interfaces is the number of interfaces: wan, lan, and opt1 onwards.
+	// Values below 2 are raised to 2.
+	Interfaces int
+	// Rules is the number of filter rules, assigned to the interfaces in
+	// turn.
+	Rules int
+	// StaticLeases is the number of DHCP static mappings, assigned in turn
+	// to every interface except wan.
+	StaticLeases int
+}
+
+// Reference configuration sizes shared by the benchmarks and performance
+// budget tests, so results stay comparable across packages.
+var (
+	SmallConfig  = ConfigSpec{Interfaces: 4, Rules: 50, StaticLeases: 20}
+	MediumConfig = ConfigSpec{Interfaces: 200, Rules: 2_000, StaticLeases: 500}
+	LargeConfig  = ConfigSpec{Interfaces: 200, Rules: 20_000, StaticLeases: 5_000}
+)
+
+// ReferenceConfig names a reference configuration size.
+type ReferenceConfig struct {
+	Name string
+	Spec ConfigSpec
+}
+
+// ReferenceConfigs returns the reference sizes from smallest to largest,
+// for table-driven benchmarks.
+func ReferenceConfigs() []ReferenceConfig {
+	return []ReferenceConfig{
+		{Name: "Small", Spec: SmallConfig},
+		{Name: "Medium", Spec: MediumConfig},
+		{Name: "Large", Spec: LargeConfig},
+	}
+}
+
+// SyntheticConfigXML returns an OPNsense config.xml sized by spec. The output
+// is deterministic, so it can also seed fuzz corpora and be compared across
+// goroutines in concurrency tests.
+//
+// Interface N above lan is optN-1 with address 10.x.y.1/24, where x.y encodes
+// N; wan is 198.51.100.2/24. Every non-wan interface runs a DHCP server.
+// Rules rotate through pass, block, and reject, through tcp and udp, and
+// through network, address, and any sources, so analysis sees a realistic
+// mix of overlapping and disjoint rules. Specs producing documents above
+// cfgparser.DefaultMaxInputSize need a parser with a raised MaxInputSize.
+func SyntheticConfigXML(spec ConfigSpec) []byte {
+	ifaces := syntheticInterfaceNames(max(spec.Interfaces, 2))
+
+	var buf bytes.Buffer
+	buf.WriteString(`<?xml version="1.0"?>` + "\n<opnsense>\n")
+	buf.WriteString("  <version>24.7</version>\n")
+	fmt.Fprintf(&buf, "  <system>\n    <hostname>%s</hostname>\n    <domain>%s</domain>\n"+
+		"    <firmware>\n      <version>24.7.1</version>\n    </firmware>\n  </system>\n",
+		DefaultHostname, DefaultDomain)
+
+	buf.WriteString("  <interfaces>\n")
+	for i, name := range ifaces {
+		fmt.Fprintf(&buf, "    <%s>\n      <enable>1</enable>\n      <if>vtnet%d</if>\n"+
+			"      <descr>%s</descr>\n      <ipaddr>%s</ipaddr>\n      <subnet>24</subnet>\n    </%s>\n",
+			name, i, name, syntheticInterfaceAddress(i, 1), name)
+	}
+	buf.WriteString("  </interfaces>\n")
+
+	buf.WriteString("  <dhcpd>\n")
+	internal := ifaces[1:]
+	for i, name := range internal {
+		idx := i + 1
+		fmt.Fprintf(&buf, "    <%s>\n      <enable>1</enable>\n      <range>\n        <from>%s</from>\n"+
+			"        <to>%s</to>\n      </range>\n",
+			name, syntheticInterfaceAddress(idx, 100), syntheticInterfaceAddress(idx, 199))
+		for lease := i; lease < spec.StaticLeases; lease += len(internal) {
+			host := 10 + (lease/len(internal))%80
+			fmt.Fprintf(&buf, "      <staticmap>\n        <mac>02:00:%02x:%02x:%02x:%02x</mac>\n"+
+				"        <ipaddr>%s</ipaddr>\n        <hostname>host-%d</hostname>\n"+
+				"        <descr>Lease %d</descr>\n      </staticmap>\n",
+				lease>>24&0xff, lease>>16&0xff, lease>>8&0xff, lease&0xff,
+				syntheticInterfaceAddress(idx, host), lease, lease)
+		}
+		fmt.Fprintf(&buf, "    </%s>\n", name)
+	}
+	buf.WriteString("  </dhcpd>\n")
+
+	buf.WriteString("  <filter>\n")
+	for r := range spec.Rules {
+		writeSyntheticRule(&buf, r, ifaces)
+	}
+	buf.WriteString("  </filter>\n</opnsense>\n")
+
+	return buf.Bytes()
+}
+
+// syntheticRuleTypes and syntheticProtocols are rotated through by rule index.
+var (
+	syntheticRuleTypes = []string{"pass", "pass", "pass", "block", "reject"}
+	syntheticProtocols = []string{"tcp", "udp"}
+)
+
+// writeSyntheticRule writes filter rule r to buf.
+func writeSyntheticRule(buf *bytes.Buffer, r int, ifaces []string) {
+	ifaceIdx := r % len(ifaces)
+	iface := ifaces[ifaceIdx]
+
+	var source string
+	switch r % 3 {
+	case 0:
+		source = "<network>" + iface + "</network>"
+	case 1:
+		source = "<address>" + syntheticInterfaceAddress(ifaceIdx, 0) + "/25</address>"
+	default:
+		source = "<any/>"
+	}
+
+	target := (ifaceIdx + 1 + r/len(ifaces)) % len(ifaces)
+
+	fmt.Fprintf(buf, "    <rule>\n      <type>%s</type>\n      <interface>%s</interface>\n"+
+		"      <ipprotocol>inet</ipprotocol>\n      <direction>in</direction>\n      <quick>1</quick>\n"+
+		"      <protocol>%s</protocol>\n      <source>\n        %s\n      </source>\n"+
+		"      <destination>\n        <address>%s</address>\n        <port>%d</port>\n      </destination>\n"+
+		"      <descr>Rule %d</descr>\n    </rule>\n",
+		syntheticRuleTypes[r%len(syntheticRuleTypes)], iface,
+		syntheticProtocols[r%len(syntheticProtocols)], source,
+		syntheticInterfaceAddress(target, 10+r%200), 1024+r%5000, r)
+}
+
+// syntheticInterfaceNames returns OPNsense interface keys: wan, lan, then
+// opt1 onwards.
+func syntheticInterfaceNames(n int) []string {
+	names := []string{"wan", "lan"}
+	for i := 1; len(names) < n; i++ {
+		names = append(names, fmt.Sprintf("opt%d", i))
+	}
+
+	return names
+}
+
+// syntheticInterfaceAddress returns host address host in the /24 of the
+// interface at index i.
+func syntheticInterfaceAddress(i, host int) string {
+	if i == 0 {
+		return fmt.Sprintf("198.51.100.%d", host)
+	}
+
+	return fmt.Sprintf("10.%d.%d.%d", i/256, i%256, host)
+}
@@ -0,0 +1,55 @@
+package testutil_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/cfgparser"
+	"github.com/EvilBit-Labs/opnDossier/internal/testutil"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/EvilBit-Labs/opnDossier/pkg/parser"
+	_ "github.com/EvilBit-Labs/opnDossier/pkg/parser/opnsense" // self-registers OPNsense parser via init()
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyntheticConfigXML_ParsesToSpec(t *testing.T) {
+	t.Parallel()
+
+	spec := testutil.ConfigSpec{Interfaces: 5, Rules: 37, StaticLeases: 11}
+
+	factory := parser.NewFactory(cfgparser.NewXMLParser())
+	device, _, err := factory.CreateDevice(
+		context.Background(),
+		bytes.NewReader(testutil.SyntheticConfigXML(spec)),
+		common.DeviceTypeUnknown,
+		false,
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, testutil.DefaultHostname, device.System.Hostname)
+	assert.Len(t, device.Interfaces, spec.Interfaces)
+	assert.Len(t, device.FirewallRules, spec.Rules)
+
+	leases := 0
+	for _, scope := range device.DHCP {
+		leases += len(scope.StaticLeases)
+	}
+	assert.Equal(t, spec.StaticLeases, leases)
+}
+
+func TestSyntheticConfigXML_Deterministic(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, testutil.SyntheticConfigXML(testutil.SmallConfig), testutil.SyntheticConfigXML(testutil.SmallConfig))
+}
+
+func TestSyntheticConfigXML_MinimumInterfaces(t *testing.T) {
+	t.Parallel()
+
+	out := testutil.SyntheticConfigXML(testutil.ConfigSpec{Rules: 1})
+	assert.Contains(t, string(out), "<wan>")
+	assert.Contains(t, string(out), "<lan>")
+	assert.NotContains(t, string(out), "<opt1>")
+}
@@ -0,0 +1,172 @@
+// Package testutil provides helpers for constructing test fixtures shared by
+// the processor, converter, and audit test suites.
+package testutil
+
+import (
+	"fmt"
+	"net/netip"
+	"slices"
+	"strconv"
+
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+)
+
+// Default identity of a device built by NewDeviceBuilder. Both are required
+// by processor.ValidateCommonDevice.
+const (
+	DefaultHostname = "test-firewall"
+	DefaultDomain   = "example.com"
+)
+
+// DeviceBuilder builds a common.CommonDevice test fixture.
+//
+//	device := testutil.NewDeviceBuilder().
+//		WithHostname("fw").
+//		WithInterface("wan", "203.0.113.1/24").
+//		WithFirewallRule(common.FirewallRule{Type: common.RuleTypePass, Interfaces: []string{"wan"}}).
+//		Build()
+//
+// The zero value is not usable; create builders with NewDeviceBuilder.
+type DeviceBuilder struct {
+	device common.CommonDevice
+}
+
+// NewDeviceBuilder returns a builder for a minimal OPNsense device named
+// DefaultHostname.DefaultDomain. The device passes ValidateCommonDevice as
+// long as the added interfaces, rules, and other entries are themselves valid.
+func NewDeviceBuilder() *DeviceBuilder {
+	return &DeviceBuilder{device: common.CommonDevice{
+		DeviceType: common.DeviceTypeOPNsense,
+		System: common.System{
+			Hostname: DefaultHostname,
+			Domain:   DefaultDomain,
+		},
+	}}
+}
+
+// WithDeviceType sets the device type.
+func (b *DeviceBuilder) WithDeviceType(deviceType common.DeviceType) *DeviceBuilder {
+	b.device.DeviceType = deviceType
+	return b
+}
+
+// WithVersion sets the configuration version.
+func (b *DeviceBuilder) WithVersion(version string) *DeviceBuilder {
+	b.device.Version = version
+	return b
+}
+
+// WithHostname sets the system hostname.
+func (b *DeviceBuilder) WithHostname(hostname string) *DeviceBuilder {
+	b.device.System.Hostname = hostname
+	return b
+}
+
+// WithDomain sets the system domain.
+func (b *DeviceBuilder) WithDomain(domain string) *DeviceBuilder {
+	b.device.System.Domain = domain
+	return b
+}
+
+// WithFirmwareVersion sets the firmware version.
+func (b *DeviceBuilder) WithFirmwareVersion(version string) *DeviceBuilder {
+	b.device.System.Firmware.Version = version
+	return b
+}
+
+// WithInterface adds an enabled interface. cidr is an IPv4 address with its
+// prefix length, such as "192.168.1.1/24"; an empty cidr adds an interface
+// without an address. It panics when cidr is not a valid prefix, since a
+// malformed fixture is a bug in the test.
+func (b *DeviceBuilder) WithInterface(name, cidr string) *DeviceBuilder {
+	iface := common.Interface{Name: name, Enabled: true}
+	if cidr != "" {
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			panic(fmt.Sprintf("testutil: interface %s: %v", name, err))
+		}
+		iface.IPAddress = prefix.Addr().String()
+		iface.Subnet = strconv.Itoa(prefix.Bits())
+	}
+
+	return b.WithInterfaceConfig(iface)
+}
+
+// WithInterfaceConfig adds iface as given, for fixtures that need fields
+// WithInterface does not set.
+func (b *DeviceBuilder) WithInterfaceConfig(iface common.Interface) *DeviceBuilder {
+	b.device.Interfaces = append(b.device.Interfaces, iface)
+	return b
+}
+
+// WithFirewallRule adds a firewall rule.
+func (b *DeviceBuilder) WithFirewallRule(rule common.FirewallRule) *DeviceBuilder {
+	b.device.FirewallRules = append(b.device.FirewallRules, rule)
+	return b
+}
+
+// WithOutboundNATMode sets the outbound NAT mode.
+func (b *DeviceBuilder) WithOutboundNATMode(mode common.NATOutboundMode) *DeviceBuilder {
+	b.device.NAT.OutboundMode = mode
+	return b
+}
+
+// WithOutboundNATRule adds an outbound NAT rule.
+func (b *DeviceBuilder) WithOutboundNATRule(rule common.NATRule) *DeviceBuilder {
+	b.device.NAT.OutboundRules = append(b.device.NAT.OutboundRules, rule)
+	return b
+}
+
+// WithInboundNATRule adds an inbound (port forward) NAT rule.
+func (b *DeviceBuilder) WithInboundNATRule(rule common.InboundNATRule) *DeviceBuilder {
+	b.device.NAT.InboundRules = append(b.device.NAT.InboundRules, rule)
+	return b
+}
+
+// WithOneToOneNATRule adds a 1:1 (binat) NAT rule.
+func (b *DeviceBuilder) WithOneToOneNATRule(rule common.OneToOneNATRule) *DeviceBuilder {
+	b.device.NAT.OneToOneRules = append(b.device.NAT.OneToOneRules, rule)
+	return b
+}
+
+// WithDHCPScope adds a DHCP scope.
+func (b *DeviceBuilder) WithDHCPScope(scope common.DHCPScope) *DeviceBuilder {
+	b.device.DHCP = append(b.device.DHCP, scope)
+	return b
+}
+
+// WithUser adds a user.
+func (b *DeviceBuilder) WithUser(user common.User) *DeviceBuilder {
+	b.device.Users = append(b.device.Users, user)
+	return b
+}
+
+// WithGroup adds a group.
+func (b *DeviceBuilder) WithGroup(group common.Group) *DeviceBuilder {
+	b.device.Groups = append(b.device.Groups, group)
+	return b
+}
+
+// WithSysctl adds a system tunable.
+func (b *DeviceBuilder) WithSysctl(tunable, value string) *DeviceBuilder {
+	b.device.Sysctl = append(b.device.Sysctl, common.SysctlItem{Tunable: tunable, Value: value})
+	return b
+}
+
+// Build returns the device. Each call returns a new device whose top-level
+// slices are independent of the builder, so a builder can be extended after
+// Build to derive related fixtures.
+func (b *DeviceBuilder) Build() *common.CommonDevice {
+	device := b.device
+	device.Interfaces = slices.Clone(b.device.Interfaces)
+	device.FirewallRules = slices.Clone(b.device.FirewallRules)
+	device.NAT.OutboundRules = slices.Clone(b.device.NAT.OutboundRules)
+	device.NAT.InboundRules = slices.Clone(b.device.NAT.InboundRules)
+	device.NAT.OneToOneRules = slices.Clone(b.device.NAT.OneToOneRules)
+	device.DHCP = slices.Clone(b.device.DHCP)
+	device.Users = slices.Clone(b.device.Users)
+	device.Groups = slices.Clone(b.device.Groups)
+	device.Sysctl = slices.Clone(b.device.Sysctl)
+
+	return &device
+}
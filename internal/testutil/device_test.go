@@ -0,0 +1,97 @@
+package testutil_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/processor"
+	"github.com/EvilBit-Labs/opnDossier/internal/testutil"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDeviceBuilder_Defaults(t *testing.T) {
+	t.Parallel()
+
+	device := testutil.NewDeviceBuilder().Build()
+
+	assert.Equal(t, common.DeviceTypeOPNsense, device.DeviceType)
+	assert.Equal(t, testutil.DefaultHostname, device.System.Hostname)
+	assert.Equal(t, testutil.DefaultDomain, device.System.Domain)
+	assert.Empty(t, processor.ValidateCommonDevice(device))
+}
+
+func TestDeviceBuilder_WithInterface(t *testing.T) {
+	t.Parallel()
+
+	device := testutil.NewDeviceBuilder().
+		WithInterface("wan", "203.0.113.1/24").
+		WithInterface("opt1", "").
+		Build()
+
+	require.Len(t, device.Interfaces, 2)
+	assert.Equal(t, common.Interface{Name: "wan", Enabled: true, IPAddress: "203.0.113.1", Subnet: "24"},
+		device.Interfaces[0])
+	assert.Equal(t, common.Interface{Name: "opt1", Enabled: true}, device.Interfaces[1])
+
+	assert.Panics(t, func() { testutil.NewDeviceBuilder().WithInterface("lan", "192.168.1.1") })
+}
+
+func TestDeviceBuilder_BuildReturnsIndependentDevices(t *testing.T) {
+	t.Parallel()
+
+	b := testutil.NewDeviceBuilder().WithInterface("lan", "192.168.1.1/24")
+	first := b.Build()
+	second := b.WithInterface("wan", "").WithHostname("fw2").Build()
+
+	assert.Len(t, first.Interfaces, 1)
+	assert.Equal(t, testutil.DefaultHostname, first.System.Hostname)
+	assert.Len(t, second.Interfaces, 2)
+	assert.Equal(t, "fw2", second.System.Hostname)
+
+	first.Interfaces[0].Name = "changed"
+	assert.Equal(t, "lan", second.Interfaces[0].Name)
+}
+
+func TestDeviceBuilder_AcceptedByProcessor(t *testing.T) {
+	t.Parallel()
+
+	device := testutil.NewDeviceBuilder().
+		WithHostname("fw").
+		WithVersion("25.1").
+		WithFirmwareVersion("25.1.3").
+		WithInterface("wan", "203.0.113.1/24").
+		WithInterface("lan", "192.168.1.1/24").
+		WithFirewallRule(common.FirewallRule{
+			Type:        common.RuleTypePass,
+			Interfaces:  []string{"lan"},
+			Source:      common.RuleEndpoint{Address: "lan"},
+			Destination: common.RuleEndpoint{Address: "any"},
+		}).
+		WithOutboundNATMode(common.OutboundAutomatic).
+		WithInboundNATRule(common.InboundNATRule{
+			Interfaces:   []string{"wan"},
+			Protocol:     "tcp",
+			ExternalPort: "443",
+			InternalIP:   "192.168.1.10",
+			InternalPort: "443",
+		}).
+		WithDHCPScope(common.DHCPScope{
+			Interface: "lan",
+			Enabled:   true,
+			Range:     common.DHCPRange{From: "192.168.1.100", To: "192.168.1.200"},
+		}).
+		WithUser(common.User{Name: "admin", UID: "1000", Scope: "local"}).
+		WithGroup(common.Group{Name: "admins", GID: "1999", Scope: "local"}).
+		WithSysctl("net.inet.ip.forwarding", "1").
+		Build()
+
+	assert.Empty(t, processor.ValidateCommonDevice(device))
+
+	p, err := processor.NewCoreProcessor(nil)
+	require.NoError(t, err)
+	report, err := p.Process(context.Background(), device, processor.WithAllFeatures())
+	require.NoError(t, err)
+	assert.Equal(t, "fw", report.NormalizedConfig.System.Hostname)
+}
@@ -0,0 +1,43 @@
+// Package tunables provides a built-in catalog of well-known FreeBSD and
+// OPNsense sysctl tunables, used to describe tunables a configuration leaves
+// undocumented and to spot tunable names that may be misspelled.
+package tunables
+
+import (
+	_ "embed"
+	"fmt"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed catalog.yaml
+var catalogYAML []byte
+
+// Entry describes a tunable in the catalog.
+type Entry struct {
+	// Description is a short summary of what the tunable controls.
+	Description string `yaml:"description"`
+	// Security notes why the tunable matters for the firewall's security
+	// posture. Empty when it has no particular security relevance.
+	Security string `yaml:"security,omitempty"`
+}
+
+// catalog returns the embedded catalog keyed by tunable name, decoding it on
+// first use. The catalog is compiled into the binary, so a decoding failure
+// is a build defect and panics.
+var catalog = sync.OnceValue(func() map[string]Entry {
+	entries := make(map[string]Entry)
+	if err := yaml.Unmarshal(catalogYAML, &entries); err != nil {
+		panic(fmt.Sprintf("tunables: decode embedded catalog: %v", err))
+	}
+
+	return entries
+})
+
+// Lookup returns the catalog entry for the tunable name, and whether the
+// catalog knows it. Names are matched exactly, as sysctl does.
+func Lookup(name string) (Entry, bool) {
+	entry, ok := catalog()[name]
+	return entry, ok
+}
@@ -0,0 +1,37 @@
+package tunables
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// minimumCatalogEntries is the least number of tunables the catalog covers.
+const minimumCatalogEntries = 50
+
+func TestCatalog_Entries(t *testing.T) {
+	t.Parallel()
+
+	entries := catalog()
+	require.GreaterOrEqual(t, len(entries), minimumCatalogEntries)
+
+	for name, entry := range entries {
+		assert.NotEmpty(t, entry.Description, "tunable %s has no description", name)
+	}
+}
+
+func TestLookup(t *testing.T) {
+	t.Parallel()
+
+	entry, ok := Lookup("net.inet.tcp.syncookies")
+	require.True(t, ok)
+	assert.Equal(t, "Use SYN cookies when the SYN cache overflows", entry.Description)
+	assert.NotEmpty(t, entry.Security)
+
+	_, ok = Lookup("net.inet.ip.fowarding")
+	assert.False(t, ok, "misspelled tunable must not match")
+
+	_, ok = Lookup("NET.INET.TCP.SYNCOOKIES")
+	assert.False(t, ok, "lookup is case-sensitive")
+}
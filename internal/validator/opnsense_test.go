@@ -781,6 +781,28 @@ func TestValidateUsersAndGroups_Uniqueness(t *testing.T) {
 	assert.Len(t, errors, 5, "Expected 5 validation errors")
 }
 
+// TestValidateUsers_Expires tests user expiry date validation.
+func TestValidateUsers_Expires(t *testing.T) {
+	tests := []struct {
+		name           string
+		expires        string
+		expectedErrors int
+	}{
+		{name: "unset", expectedErrors: 0},
+		{name: "MM/DD/YYYY date", expires: "12/31/2025", expectedErrors: 0},
+		{name: "ISO date", expires: "2025-12-31", expectedErrors: 1},
+		{name: "out of range month", expires: "13/01/2025", expectedErrors: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users := []schema.User{{Name: "contractor", UID: "2002", Scope: "local", Expires: tt.expires}}
+			errors := validateUsers(users, map[string]bool{})
+			assert.Len(t, errors, tt.expectedErrors, "Expected number of errors")
+		})
+	}
+}
+
 // TestValidationError_Error is already tested in config_test.go
 // We don't duplicate it here to avoid redeclaration
 
@@ -918,6 +940,17 @@ func TestValidateSystem_PowerManagement(t *testing.T) {
 			},
 			expectedErrors: 0,
 		},
+		{
+			name: "short power modes written by current firmware",
+			system: schema.System{
+				Hostname:          "test",
+				Domain:            "test.local",
+				PowerdACMode:      "max",
+				PowerdBatteryMode: "min",
+				PowerdNormalMode:  "adp",
+			},
+			expectedErrors: 0,
+		},
 		{
 			name: "invalid AC power mode",
 			system: schema.System{
@@ -955,6 +988,37 @@ func TestValidateSystem_PowerManagement(t *testing.T) {
 	}
 }
 
+// TestValidateSystem_Console tests serial console speed and primary console validation.
+func TestValidateSystem_Console(t *testing.T) {
+	tests := []struct {
+		name           string
+		serialSpeed    string
+		primaryConsole string
+		expectedErrors int
+	}{
+		{name: "unset", expectedErrors: 0},
+		{name: "valid serial console", serialSpeed: "115200", primaryConsole: "serial", expectedErrors: 0},
+		{name: "valid video console", serialSpeed: "9600", primaryConsole: "video", expectedErrors: 0},
+		{name: "unsupported serial speed", serialSpeed: "14400", expectedErrors: 1},
+		{name: "non-numeric serial speed", serialSpeed: "fast", expectedErrors: 1},
+		{name: "invalid primary console", primaryConsole: "efi", expectedErrors: 1},
+		{name: "both invalid", serialSpeed: "0", primaryConsole: "vga", expectedErrors: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			system := schema.System{
+				Hostname:       "test",
+				Domain:         "test.local",
+				SerialSpeed:    tt.serialSpeed,
+				PrimaryConsole: tt.primaryConsole,
+			}
+			errors := validateSystem(&system)
+			assert.Len(t, errors, tt.expectedErrors, "Expected number of errors")
+		})
+	}
+}
+
 // TestValidateSystem_BogonsInterval tests bogons interval validation.
 func TestValidateSystem_BogonsInterval(t *testing.T) {
 	tests := []struct {
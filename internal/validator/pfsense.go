@@ -7,8 +7,10 @@ import (
 	"strconv"
 
 	"github.com/EvilBit-Labs/opnDossier/internal/constants"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
 	opnsense "github.com/EvilBit-Labs/opnDossier/pkg/schema/opnsense"
 	"github.com/EvilBit-Labs/opnDossier/pkg/schema/pfsense"
+	"github.com/EvilBit-Labs/opnDossier/pkg/schema/shared"
 )
 
 // ValidatePfSenseDocument validates an entire pfSense configuration document and
@@ -182,6 +184,26 @@ func validatePfSenseSystem(sys *pfsense.System) []ValidationError {
 		}
 	}
 
+	if sys.SerialSpeed != "" {
+		if speed, err := strconv.Atoi(sys.SerialSpeed); err != nil || !common.ValidSerialSpeed(speed) {
+			errors = append(errors, ValidationError{
+				Field:   "system.serialspeed",
+				Message: fmt.Sprintf("serial speed '%s' is not a supported baud rate", sys.SerialSpeed),
+			})
+		}
+	}
+
+	if sys.PrimaryConsole != "" && !common.ValidPrimaryConsole(sys.PrimaryConsole) {
+		errors = append(errors, ValidationError{
+			Field: "system.primaryconsole",
+			Message: fmt.Sprintf(
+				"primary console '%s' must be one of: %v",
+				sys.PrimaryConsole,
+				[]string{common.PrimaryConsoleSerial, common.PrimaryConsoleVideo, common.PrimaryConsoleNone},
+			),
+		})
+	}
+
 	validBogonsIntervals := []string{"monthly", "weekly", "daily", "never"}
 	if sys.Bogons.Interval != "" && !contains(validBogonsIntervals, sys.Bogons.Interval) {
 		errors = append(errors, ValidationError{
@@ -447,6 +469,7 @@ func validatePfSenseUsers(users []pfsense.User, groupNames map[string]bool) []Va
 		errors = append(errors, validatePfSenseUserUID(user, i, userUIDs)...)
 		errors = append(errors, validatePfSenseUserGroupMembership(user, i, groupNames)...)
 		errors = append(errors, validatePfSenseUserScope(user, i)...)
+		errors = append(errors, validatePfSenseUserExpires(user, i)...)
 	}
 
 	return errors
@@ -583,6 +606,19 @@ func validatePfSenseGroups(
 	return errors
 }
 
+// validatePfSenseUserExpires validates that a pfSense user expiry date is in
+// MM/DD/YYYY format.
+func validatePfSenseUserExpires(user pfsense.User, index int) []ValidationError {
+	if _, err := shared.ParseExpiryDate(user.Expires); err != nil {
+		return []ValidationError{{
+			Field:   fmt.Sprintf("system.user[%d].expires", index),
+			Message: fmt.Sprintf("user expiry date '%s' must be in MM/DD/YYYY format", user.Expires),
+		}}
+	}
+
+	return nil
+}
+
 // validatePfSenseUserScope validates pfSense user scope requirements.
 func validatePfSenseUserScope(user pfsense.User, index int) []ValidationError {
 	var errors []ValidationError
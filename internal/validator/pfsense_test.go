@@ -131,6 +131,18 @@ func TestValidatePfSenseSystem(t *testing.T) {
 			wantCount: 1,
 			wantMsg:   "must be one of",
 		},
+		{
+			name:      "invalid serial speed",
+			mutate:    func(sys *pfsense.System) { sys.SerialSpeed = "12345" },
+			wantCount: 1,
+			wantMsg:   "not a supported baud rate",
+		},
+		{
+			name:      "invalid primary console",
+			mutate:    func(sys *pfsense.System) { sys.PrimaryConsole = "efi" },
+			wantCount: 1,
+			wantMsg:   "must be one of",
+		},
 		{
 			name: "invalid bogons interval",
 			mutate: func(sys *pfsense.System) {
@@ -411,6 +423,17 @@ func TestValidatePfSenseUsersAndGroups(t *testing.T) {
 			wantCount: 1,
 			wantMsg:   "must be one of",
 		},
+		{
+			name: "invalid user expiry date",
+			sys: pfsense.System{
+				Group: []pfsense.Group{{Name: "admins", Gid: "1999", Scope: "system"}},
+				User: []pfsense.User{
+					{Name: "operator", UID: "1000", Scope: "local", Expires: "2025-12-31"},
+				},
+			},
+			wantCount: 1,
+			wantMsg:   "MM/DD/YYYY",
+		},
 		{
 			name: "unknown group reference",
 			sys: pfsense.System{
@@ -7,7 +7,9 @@ import (
 	"strconv"
 
 	"github.com/EvilBit-Labs/opnDossier/internal/constants"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
 	schema "github.com/EvilBit-Labs/opnDossier/pkg/schema/opnsense"
+	"github.com/EvilBit-Labs/opnDossier/pkg/schema/shared"
 )
 
 // validateSystem checks the system-level configuration fields for required values and valid formats.
@@ -99,6 +101,27 @@ func validateSystem(system *schema.System) []ValidationError {
 		}
 	}
 
+	// Validate serial console settings
+	if system.SerialSpeed != "" {
+		if speed, err := strconv.Atoi(system.SerialSpeed); err != nil || !common.ValidSerialSpeed(speed) {
+			errors = append(errors, ValidationError{
+				Field:   "system.serialspeed",
+				Message: fmt.Sprintf("serial speed '%s' is not a supported baud rate", system.SerialSpeed),
+			})
+		}
+	}
+
+	if system.PrimaryConsole != "" && !common.ValidPrimaryConsole(system.PrimaryConsole) {
+		errors = append(errors, ValidationError{
+			Field: "system.primaryconsole",
+			Message: fmt.Sprintf(
+				"primary console '%s' must be one of: %v",
+				system.PrimaryConsole,
+				[]string{common.PrimaryConsoleSerial, common.PrimaryConsoleVideo, common.PrimaryConsoleNone},
+			),
+		})
+	}
+
 	// Validate bogons interval
 	validBogonsIntervals := []string{"monthly", "weekly", "daily", "never"}
 	if system.Bogons.Interval != "" && !contains(validBogonsIntervals, system.Bogons.Interval) {
@@ -228,6 +251,7 @@ func validateUsers(users []schema.User, groupNames map[string]bool) []Validation
 		errors = append(errors, validateUserUID(user, i, userUIDs)...)
 		errors = append(errors, validateUserGroupMembership(user, i, groupNames)...)
 		errors = append(errors, validateUserScope(user, i)...)
+		errors = append(errors, validateUserExpires(user, i)...)
 	}
 
 	return errors
@@ -321,6 +345,18 @@ func validateUserScope(user schema.User, index int) []ValidationError {
 	return errors
 }
 
+// validateUserExpires validates that a user expiry date is in MM/DD/YYYY format.
+func validateUserExpires(user schema.User, index int) []ValidationError {
+	if _, err := shared.ParseExpiryDate(user.Expires); err != nil {
+		return []ValidationError{{
+			Field:   fmt.Sprintf("system.user[%d].expires", index),
+			Message: fmt.Sprintf("user expiry date '%s' must be in MM/DD/YYYY format", user.Expires),
+		}}
+	}
+
+	return nil
+}
+
 // validateSysctl checks sysctl tunable items for required fields, uniqueness, valid naming format, and presence of values.
 // It returns a slice of ValidationError for any missing, duplicate, or improperly formatted tunable names, or missing values.
 func validateSysctl(items []schema.SysctlItem) []ValidationError {
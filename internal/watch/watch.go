@@ -0,0 +1,161 @@
+// Package watch reports changes to a single file so long-running commands
+// such as `opndossier convert --watch` can react to them. Changes are
+// detected with filesystem notifications where the platform provides them
+// and by polling the file's size and modification time otherwise, for
+// example on network filesystems that deliver no notifications.
+package watch
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/logging"
+	"github.com/fsnotify/fsnotify"
+)
+
+const (
+	// DefaultDebounce is how long a file must stay unchanged before a burst
+	// of writes is reported.
+	DefaultDebounce = 500 * time.Millisecond
+	// DefaultPollInterval is how often a polled file is checked.
+	DefaultPollInterval = time.Second
+)
+
+// Options configures File.
+type Options struct {
+	// Debounce is how long the file must stay unchanged before a burst of
+	// writes is reported as one change. Zero means DefaultDebounce.
+	Debounce time.Duration
+	// Poll checks the file every PollInterval instead of relying on
+	// filesystem notifications.
+	Poll bool
+	// PollInterval is how often the file is checked when polling. Zero
+	// means DefaultPollInterval.
+	PollInterval time.Duration
+	// Logger receives a warning when File falls back to polling. It may be
+	// nil.
+	Logger *logging.Logger
+}
+
+// File watches the file at path until ctx is cancelled, calling onChange
+// once for each burst of changes after the file has been left alone for the
+// debounce period. onChange runs on the calling goroutine; changes made
+// while it runs are reported by a later call.
+//
+// The directory containing path is watched rather than the file itself, so
+// an editor or export tool that replaces the file by renaming a new one over
+// it is still noticed. When filesystem notifications cannot be set up, File
+// falls back to polling. File returns nil once ctx is cancelled.
+func File(ctx context.Context, path string, opts Options, onChange func()) error {
+	if opts.Debounce <= 0 {
+		opts.Debounce = DefaultDebounce
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = DefaultPollInterval
+	}
+
+	path = filepath.Clean(path)
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("failed to watch %s: %w", path, err)
+	}
+
+	var (
+		events <-chan fsnotify.Event
+		errs   <-chan error
+		tick   <-chan time.Time
+	)
+
+	if !opts.Poll {
+		watcher, err := newDirWatcher(filepath.Dir(path))
+		if err == nil {
+			defer func() { _ = watcher.Close() }()
+			events, errs = watcher.Events, watcher.Errors
+		} else {
+			if opts.Logger != nil {
+				opts.Logger.Warn("Filesystem notifications unavailable, polling for changes",
+					"path", path, "interval", opts.PollInterval, "error", err)
+			}
+			opts.Poll = true
+		}
+	}
+
+	last := statFile(path)
+	if opts.Poll {
+		ticker := time.NewTicker(opts.PollInterval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	debounce := time.NewTimer(opts.Debounce)
+	debounce.Stop()
+	defer debounce.Stop()
+
+	name := filepath.Base(path)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+			if filepath.Base(event.Name) == name && event.Has(fsnotify.Write|fsnotify.Create) {
+				debounce.Reset(opts.Debounce)
+			}
+		case err, ok := <-errs:
+			if !ok {
+				return nil
+			}
+			// A dropped notification, such as an inotify queue overflow,
+			// may have been for path; check it rather than miss a change.
+			if opts.Logger != nil {
+				opts.Logger.Debug("Filesystem notification error", "path", path, "error", err)
+			}
+			debounce.Reset(opts.Debounce)
+		case <-tick:
+			if current := statFile(path); current != last {
+				last = current
+				if current.exists {
+					debounce.Reset(opts.Debounce)
+				}
+			}
+		case <-debounce.C:
+			onChange()
+		}
+	}
+}
+
+// newDirWatcher returns a notification watcher for dir.
+func newDirWatcher(dir string) (*fsnotify.Watcher, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	if err := watcher.Add(dir); err != nil {
+		_ = watcher.Close()
+		return nil, err
+	}
+
+	return watcher, nil
+}
+
+// fileState is what polling compares between checks of a file.
+type fileState struct {
+	exists  bool
+	size    int64
+	modTime int64
+}
+
+// statFile returns the current state of the file at path. A file that
+// cannot be read is reported as missing.
+func statFile(path string) fileState {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileState{}
+	}
+
+	return fileState{exists: true, size: info.Size(), modTime: info.ModTime().UnixNano()}
+}
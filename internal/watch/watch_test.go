@@ -0,0 +1,151 @@
+package watch
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startWatch runs File on path in the background and returns a channel
+// receiving one value per reported change. The watch stops when the test
+// ends.
+func startWatch(t *testing.T, path string, opts Options) <-chan struct{} {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(t.Context())
+	changes := make(chan struct{}, 16)
+	done := make(chan error, 1)
+	go func() {
+		done <- File(ctx, path, opts, func() { changes <- struct{}{} })
+	}()
+	t.Cleanup(func() {
+		cancel()
+		assert.NoError(t, <-done)
+	})
+
+	// Give the watcher time to register before the test writes.
+	time.Sleep(50 * time.Millisecond)
+
+	return changes
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func TestFile_ReportsChange(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		name string
+		poll bool
+	}{
+		{"notify", false},
+		{"poll", true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), "config.xml")
+			writeFile(t, path, "v1")
+
+			changes := startWatch(t, path, Options{
+				Debounce:     20 * time.Millisecond,
+				Poll:         tt.poll,
+				PollInterval: 10 * time.Millisecond,
+			})
+
+			writeFile(t, path, "v2, longer")
+
+			select {
+			case <-changes:
+			case <-time.After(5 * time.Second):
+				t.Fatal("change was not reported")
+			}
+		})
+	}
+}
+
+func TestFile_ReportsReplacement(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.xml")
+	writeFile(t, path, "v1")
+
+	changes := startWatch(t, path, Options{Debounce: 20 * time.Millisecond})
+
+	// Export tools and editors often write a new file and rename it over
+	// the old one.
+	tmp := filepath.Join(dir, "config.xml.new")
+	writeFile(t, tmp, "v2")
+	require.NoError(t, os.Rename(tmp, path))
+
+	select {
+	case <-changes:
+	case <-time.After(5 * time.Second):
+		t.Fatal("replacement was not reported")
+	}
+}
+
+func TestFile_DebounceCoalescesWrites(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "config.xml")
+	writeFile(t, path, "v0")
+
+	var count atomic.Int32
+	ctx, cancel := context.WithCancel(t.Context())
+	done := make(chan error, 1)
+	go func() {
+		done <- File(ctx, path, Options{Debounce: 200 * time.Millisecond}, func() { count.Add(1) })
+	}()
+	time.Sleep(50 * time.Millisecond)
+
+	for i := range 5 {
+		writeFile(t, path, "v"+strconv.Itoa(i+1))
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	assert.Eventually(t, func() bool { return count.Load() > 0 }, 5*time.Second, 10*time.Millisecond)
+	// Wait well past another debounce period to catch a late second call.
+	time.Sleep(400 * time.Millisecond)
+
+	cancel()
+	require.NoError(t, <-done)
+	assert.Equal(t, int32(1), count.Load(), "rapid writes must be reported as one change")
+}
+
+func TestFile_IgnoresOtherFiles(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.xml")
+	writeFile(t, path, "v1")
+
+	changes := startWatch(t, path, Options{Debounce: 20 * time.Millisecond})
+
+	writeFile(t, filepath.Join(dir, "report.md"), "# Report")
+
+	select {
+	case <-changes:
+		t.Fatal("a write to another file in the directory was reported")
+	case <-time.After(300 * time.Millisecond):
+	}
+}
+
+func TestFile_MissingFile(t *testing.T) {
+	t.Parallel()
+
+	err := File(t.Context(), filepath.Join(t.TempDir(), "missing.xml"), Options{}, func() {})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to watch")
+}
@@ -1,5 +1,7 @@
 package model
 
+import "time"
+
 // Certificate represents a TLS/SSL certificate.
 type Certificate struct {
 	// RefID is the unique reference identifier for the certificate.
@@ -15,6 +17,35 @@ type Certificate struct {
 	// PrivateKey is the PEM-encoded private key data.
 
 	PrivateKey string `json:"privateKey,omitempty" yaml:"privateKey,omitempty"`
+	// Subject is the certificate's subject distinguished name; empty when the
+	// certificate data is missing or could not be decoded.
+	Subject string `json:"subject,omitempty" yaml:"subject,omitempty"`
+	// Issuer is the certificate's issuer distinguished name; empty when the
+	// certificate data is missing or could not be decoded.
+	Issuer string `json:"issuer,omitempty" yaml:"issuer,omitempty"`
+	// NotBefore is the start of the validity period, in Unix seconds; zero
+	// when unknown.
+	NotBefore int64 `json:"notBefore,omitempty" yaml:"notBefore,omitempty"`
+	// NotAfter is the end of the validity period, in Unix seconds; zero when
+	// unknown.
+	NotAfter int64 `json:"notAfter,omitempty" yaml:"notAfter,omitempty"`
+}
+
+// SelfSigned reports whether the certificate was not issued by a CA in the
+// trust store and names itself as issuer. It is false when the subject is
+// unknown.
+func (c Certificate) SelfSigned() bool {
+	return c.CARef == "" && c.Subject != "" && c.Subject == c.Issuer
+}
+
+// ExpiresAt returns the end of the certificate's validity period. The second
+// return value is false when it is unknown.
+func (c Certificate) ExpiresAt() (time.Time, bool) {
+	if c.NotAfter == 0 {
+		return time.Time{}, false
+	}
+
+	return time.Unix(c.NotAfter, 0).UTC(), true
 }
 
 // CertificateAuthority represents a certificate authority.
@@ -32,3 +63,55 @@ type CertificateAuthority struct {
 	// Serial is the next serial number to use when issuing certificates.
 	Serial string `json:"serial,omitempty" yaml:"serial,omitempty"`
 }
+
+// CRL represents a certificate revocation list.
+type CRL struct {
+	// RefID is the unique reference identifier for the CRL.
+	RefID string `json:"refId,omitempty" yaml:"refId,omitempty"`
+	// Description is a human-readable description of the CRL.
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	// CARef is the reference ID of the certificate authority the CRL belongs to.
+	CARef string `json:"caRef,omitempty" yaml:"caRef,omitempty"`
+	// Method is "internal" for lists the firewall maintains and signs, or
+	// "existing" for imported lists.
+	Method string `json:"method,omitempty" yaml:"method,omitempty"`
+	// Serial is the CRL number of the most recently issued list.
+	Serial string `json:"serial,omitempty" yaml:"serial,omitempty"`
+	// Lifetime is the number of days an issued list stays valid.
+	Lifetime int `json:"lifetime,omitempty" yaml:"lifetime,omitempty"`
+	// IssuedAt is when the list was last issued, in Unix seconds; zero when
+	// unknown.
+	IssuedAt int64 `json:"issuedAt,omitempty" yaml:"issuedAt,omitempty"`
+	// NextUpdate is the nextUpdate time of the signed list, in Unix seconds;
+	// zero when the list carries none or could not be decoded.
+	NextUpdate int64 `json:"nextUpdate,omitempty" yaml:"nextUpdate,omitempty"`
+	// Revoked lists the certificates revoked by this CRL.
+	Revoked []RevokedCertificate `json:"revoked,omitempty" yaml:"revoked,omitempty"`
+}
+
+// ExpiresAt returns when the most recently issued list stops being valid:
+// NextUpdate when the signed list carries one, otherwise IssuedAt plus
+// Lifetime days. The second return value is false when neither is known.
+func (c CRL) ExpiresAt() (time.Time, bool) {
+	if c.NextUpdate != 0 {
+		return time.Unix(c.NextUpdate, 0).UTC(), true
+	}
+
+	if c.IssuedAt == 0 || c.Lifetime <= 0 {
+		return time.Time{}, false
+	}
+
+	return time.Unix(c.IssuedAt, 0).UTC().AddDate(0, 0, c.Lifetime), true
+}
+
+// RevokedCertificate represents a certificate listed in a CRL.
+type RevokedCertificate struct {
+	// RefID is the reference identifier of the revoked certificate.
+	RefID string `json:"refId,omitempty" yaml:"refId,omitempty"`
+	// Description is a human-readable description of the revoked certificate.
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	// Reason is the revocation reason (e.g., "Key Compromise").
+	Reason string `json:"reason,omitempty" yaml:"reason,omitempty"`
+	// RevokedAt is the revocation time in Unix seconds; zero when unknown.
+	RevokedAt int64 `json:"revokedAt,omitempty" yaml:"revokedAt,omitempty"`
+}
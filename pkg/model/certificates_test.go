@@ -0,0 +1,116 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCRL_ExpiresAt(t *testing.T) {
+	t.Parallel()
+
+	issued := time.Date(2024, 1, 31, 8, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		crl    common.CRL
+		want   time.Time
+		wantOK bool
+	}{
+		{
+			name:   "issued with lifetime",
+			crl:    common.CRL{IssuedAt: issued.Unix(), Lifetime: 30},
+			want:   issued.AddDate(0, 0, 30),
+			wantOK: true,
+		},
+		{
+			name:   "nextUpdate wins over lifetime",
+			crl:    common.CRL{IssuedAt: issued.Unix(), Lifetime: 30, NextUpdate: issued.AddDate(0, 0, 7).Unix()},
+			want:   issued.AddDate(0, 0, 7),
+			wantOK: true,
+		},
+		{
+			name: "unknown issue time",
+			crl:  common.CRL{Lifetime: 30},
+		},
+		{
+			name: "no lifetime",
+			crl:  common.CRL{IssuedAt: issued.Unix()},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := tt.crl.ExpiresAt()
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCertificate_SelfSigned(t *testing.T) {
+	t.Parallel()
+
+	const name = "CN=fw.example.com"
+
+	tests := []struct {
+		name string
+		cert common.Certificate
+		want bool
+	}{
+		{"issuer is subject", common.Certificate{Subject: name, Issuer: name}, true},
+		{"issued by a trust store CA", common.Certificate{CARef: "ca-1", Subject: name, Issuer: name}, false},
+		{"external issuer", common.Certificate{Subject: name, Issuer: "CN=Example CA"}, false},
+		{"not decoded", common.Certificate{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, tt.cert.SelfSigned())
+		})
+	}
+}
+
+func TestCertificate_ExpiresAt(t *testing.T) {
+	t.Parallel()
+
+	notAfter := time.Date(2027, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	got, ok := common.Certificate{NotAfter: notAfter.Unix()}.ExpiresAt()
+	assert.True(t, ok)
+	assert.Equal(t, notAfter, got)
+
+	_, ok = common.Certificate{}.ExpiresAt()
+	assert.False(t, ok)
+}
+
+func TestCommonDevice_WebGUICert(t *testing.T) {
+	t.Parallel()
+
+	device := &common.CommonDevice{
+		Certificates: []common.Certificate{
+			{RefID: "cert-1", Description: "Other"},
+			{RefID: "cert-2", Description: "Web GUI"},
+		},
+	}
+	assert.Nil(t, device.WebGUICert(), "no ssl-certref")
+
+	device.System.WebGUI.SSLCertRef = "cert-2"
+	if cert := device.WebGUICert(); assert.NotNil(t, cert) {
+		assert.Equal(t, "Web GUI", cert.Description)
+	}
+
+	device.System.WebGUI.SSLCertRef = "missing"
+	assert.Nil(t, device.WebGUICert(), "dangling ssl-certref")
+	assert.Equal(t, "Other", device.FindCertByRef("cert-1").Description)
+
+	var nilDevice *common.CommonDevice
+	assert.Nil(t, nilDevice.FindCertByRef("cert-1"))
+	assert.Nil(t, nilDevice.WebGUICert())
+}
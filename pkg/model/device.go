@@ -98,6 +98,17 @@ type CommonDevice struct {
 	Version string `json:"version,omitempty" yaml:"version,omitempty"`
 	// Theme is the web GUI theme name.
 	Theme string `json:"theme,omitempty" yaml:"theme,omitempty"`
+	// SourceEncoding is the character encoding of the parsed configuration
+	// file (e.g. "UTF-8", "ISO-8859-1"). Input is always transcoded to UTF-8.
+	SourceEncoding string `json:"sourceEncoding,omitempty" yaml:"sourceEncoding,omitempty"`
+	// Compatibility notes that the configuration format is newer than the
+	// parser was tested against, so some sections may be unreported. Empty
+	// for tested formats.
+	Compatibility string `json:"compatibility,omitempty" yaml:"compatibility,omitempty"`
+	// CosmeticSections names the presentation and telemetry sections present
+	// in the source configuration (e.g. "widgets", "theme", "rrddata"). The
+	// parser preserves their content for round-tripping but does not convert it.
+	CosmeticSections []string `json:"cosmeticSections,omitempty" yaml:"cosmeticSections,omitempty"`
 
 	// System contains system-level settings such as hostname, DNS, and web GUI configuration.
 	System System `json:"system" yaml:"system,omitempty"`
@@ -121,6 +132,8 @@ type CommonDevice struct {
 	InterfaceGroups []InterfaceGroup `json:"interfaceGroups,omitempty" yaml:"interfaceGroups,omitempty"`
 	// FirewallRules contains normalized firewall filter rules.
 	FirewallRules []FirewallRule `json:"firewallRules,omitempty" yaml:"firewallRules,omitempty"`
+	// Schedules contains the firewall schedules referenced by rules.
+	Schedules []Schedule `json:"schedules,omitempty" yaml:"schedules,omitempty"`
 	// NAT contains all NAT-related configuration including inbound and outbound rules.
 	NAT NATConfig `json:"nat" yaml:"nat,omitempty"`
 	// DHCP contains DHCP server scopes, one per interface.
@@ -133,6 +146,11 @@ type CommonDevice struct {
 	SNMP SNMPConfig `json:"snmp" yaml:"snmp,omitempty"`
 	// LoadBalancer contains load balancer and health monitor configuration.
 	LoadBalancer LoadBalancerConfig `json:"loadBalancer" yaml:"loadBalancer,omitempty"`
+	// WakeOnLAN contains the hosts configured for Wake-on-LAN.
+	WakeOnLAN []WakeOnLANEntry `json:"wakeOnLan,omitempty" yaml:"wakeOnLan,omitempty"`
+	// UPnP contains the UPnP IGD / NAT-PMP (miniupnpd) configuration; nil when
+	// the configuration has no miniupnpd settings.
+	UPnP *UPnPConfig `json:"upnp,omitempty" yaml:"upnp,omitempty"`
 	// VPN contains all VPN subsystem configurations (OpenVPN, WireGuard, IPsec).
 	VPN VPN `json:"vpn" yaml:"vpn,omitempty"`
 	// Routing contains gateways, gateway groups, and static routes.
@@ -141,6 +159,8 @@ type CommonDevice struct {
 	Certificates []Certificate `json:"certificates,omitempty" yaml:"certificates,omitempty"`
 	// CAs contains certificate authorities.
 	CAs []CertificateAuthority `json:"cas,omitempty" yaml:"cas,omitempty"`
+	// CRLs contains certificate revocation lists.
+	CRLs []CRL `json:"crls,omitempty" yaml:"crls,omitempty"`
 	// HighAvailability contains CARP/pfsync high-availability settings.
 	HighAvailability HighAvailability `json:"highAvailability" yaml:"highAvailability,omitempty"`
 	// IDS contains intrusion detection/prevention (Suricata) configuration.
@@ -151,6 +171,11 @@ type CommonDevice struct {
 	Users []User `json:"users,omitempty" yaml:"users,omitempty"`
 	// Groups contains system groups.
 	Groups []Group `json:"groups,omitempty" yaml:"groups,omitempty"`
+	// AuthServers contains the external LDAP and RADIUS authentication servers.
+	AuthServers []AuthServer `json:"authServers,omitempty" yaml:"authServers,omitempty"`
+	// PF contains pf state table limits and timeout overrides. Nil when the
+	// configuration leaves every setting at its default.
+	PF *PFSettings `json:"pf,omitempty" yaml:"pf,omitempty"`
 	// Sysctl contains kernel tunable parameters.
 	Sysctl []SysctlItem `json:"sysctl,omitempty" yaml:"sysctl,omitempty"`
 	// Packages contains installed or available software packages.
@@ -161,6 +186,9 @@ type CommonDevice struct {
 	Netflow *NetflowConfig `json:"netflow,omitempty" yaml:"netflow,omitempty"`
 	// TrafficShaper contains QoS/traffic shaping configuration.
 	TrafficShaper *TrafficShaperConfig `json:"trafficShaper,omitempty" yaml:"trafficShaper,omitempty"`
+	// QueueStats contains traffic shaper queue counters captured in the backup
+	// export. Nil when the export carries no <queuestats> section.
+	QueueStats *QueueStats `json:"queueStats,omitempty" yaml:"queueStats,omitempty"`
 	// CaptivePortal contains captive portal configuration.
 	CaptivePortal *CaptivePortalConfig `json:"captivePortal,omitempty" yaml:"captivePortal,omitempty"`
 	// Cron contains scheduled task configuration.
@@ -242,9 +270,38 @@ func (d *CommonDevice) HasVLANs() bool {
 	return len(d.VLANs) > 0
 }
 
+// FindCertByRef returns the certificate with the given refid, or nil when
+// none matches or d is nil.
+func (d *CommonDevice) FindCertByRef(refid string) *Certificate {
+	if d == nil || refid == "" {
+		return nil
+	}
+
+	for i := range d.Certificates {
+		if d.Certificates[i].RefID == refid {
+			return &d.Certificates[i]
+		}
+	}
+
+	return nil
+}
+
+// WebGUICert returns the certificate referenced by System.WebGUI.SSLCertRef,
+// or nil when none is set or it does not resolve.
+func (d *CommonDevice) WebGUICert() *Certificate {
+	if d == nil {
+		return nil
+	}
+
+	return d.FindCertByRef(d.System.WebGUI.SSLCertRef)
+}
+
 // NATSummary returns a convenience view of the device's NAT configuration.
 // Slice fields are cloned to prevent callers from mutating the original device.
-// Returns a zero-value NATSummary if d is nil.
+// ReflectionDisabled is true when either NAT.ReflectionDisabled or the
+// mirrored System.DisableNATReflection is set, so a device whose two fields
+// disagree reports one consistent value. Returns a zero-value NATSummary if d
+// is nil.
 func (d *CommonDevice) NATSummary() NATSummary {
 	if d == nil {
 		return NATSummary{}
@@ -252,9 +309,10 @@ func (d *CommonDevice) NATSummary() NATSummary {
 
 	return NATSummary{
 		Mode:               d.NAT.OutboundMode,
-		ReflectionDisabled: d.NAT.ReflectionDisabled,
+		ReflectionDisabled: d.NAT.ReflectionDisabled || d.System.DisableNATReflection,
 		PfShareForward:     d.NAT.PfShareForward,
 		OutboundRules:      slices.Clone(d.NAT.OutboundRules),
 		InboundRules:       slices.Clone(d.NAT.InboundRules),
+		OneToOneRules:      slices.Clone(d.NAT.OneToOneRules),
 	}
 }
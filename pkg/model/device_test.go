@@ -145,6 +145,17 @@ func TestCommonDevice_NATSummary(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "System.DisableNATReflection alone disables reflection",
+			device: common.CommonDevice{
+				System: common.System{DisableNATReflection: true},
+				NAT:    common.NATConfig{OutboundMode: common.OutboundAutomatic},
+			},
+			want: common.NATSummary{
+				Mode:               "automatic",
+				ReflectionDisabled: true,
+			},
+		},
 		{
 			name: "BiNATEnabled not included in summary",
 			device: common.CommonDevice{
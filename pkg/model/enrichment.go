@@ -24,7 +24,7 @@ type Statistics struct {
 	RulesByInterface map[string]int `json:"rulesByInterface,omitempty" yaml:"rulesByInterface,omitempty"`
 	// RulesByType maps rule types (pass, block, reject) to their counts.
 	RulesByType map[string]int `json:"rulesByType,omitempty" yaml:"rulesByType,omitempty"`
-	// NATEntries is the total number of NAT rules (inbound and outbound).
+	// NATEntries is the total number of NAT rules (inbound, outbound, and 1:1).
 	NATEntries int `json:"natEntries,omitempty" yaml:"natEntries,omitempty"`
 	// NATMode is the outbound NAT mode.
 	NATMode NATOutboundMode `json:"natMode,omitempty" yaml:"natMode,omitempty"`
@@ -430,6 +430,8 @@ type PluginComplianceResult struct {
 	Controls []ComplianceControl `json:"controls,omitempty" yaml:"controls,omitempty"`
 	// Compliance maps control IDs to their compliant/non-compliant status.
 	Compliance map[string]bool `json:"compliance,omitempty" yaml:"compliance,omitempty"`
+	// Checks records how the check of each control ran.
+	Checks []ComplianceCheck `json:"checks,omitempty" yaml:"checks,omitempty"`
 }
 
 // CompliancePluginInfo contains metadata about an audit plugin.
@@ -480,6 +482,36 @@ type ComplianceControl struct {
 	Metadata map[string]string `json:"metadata,omitempty" yaml:"metadata,omitempty"`
 }
 
+// Check execution status values used in audit report output (the checks
+// appendix and JSON/YAML exports).
+const (
+	// CheckStatusPassed indicates a check ran and produced no findings.
+	CheckStatusPassed = "passed"
+	// CheckStatusFindings indicates a check ran and produced findings.
+	CheckStatusFindings = "findings"
+	// CheckStatusSkipped indicates a check did not run because the
+	// configuration lacks the data or feature it evaluates.
+	CheckStatusSkipped = "skipped"
+	// CheckStatusErrored indicates a check failed or panicked before it
+	// reached a result.
+	CheckStatusErrored = "errored"
+)
+
+// ComplianceCheck records how the check of one control ran during an audit.
+type ComplianceCheck struct {
+	// ID is the control identifier (e.g., "FIREWALL-068").
+	ID string `json:"id,omitempty" yaml:"id,omitempty"`
+	// Name is the control title.
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+	// Status is the execution status ("passed", "findings", "skipped", or "errored").
+	Status string `json:"status" yaml:"status"`
+	// Findings is the number of findings the check produced.
+	Findings int `json:"findings" yaml:"findings"`
+	// Reason explains a skipped check, or carries the error or panic
+	// message of an errored one.
+	Reason string `json:"reason,omitempty" yaml:"reason,omitempty"`
+}
+
 // ComplianceResultSummary contains aggregate counts for compliance audit results.
 type ComplianceResultSummary struct {
 	// TotalFindings is the total number of findings.
@@ -500,4 +532,136 @@ type ComplianceResultSummary struct {
 	Compliant int `json:"compliant" yaml:"compliant,omitempty"`
 	// NonCompliant is the number of controls that failed.
 	NonCompliant int `json:"nonCompliant" yaml:"nonCompliant,omitempty"`
+	// DescriptionQuality summarizes rule description hygiene; nil when the
+	// audit mode does not check rule descriptions.
+	DescriptionQuality *DescriptionQuality `json:"descriptionQuality,omitempty" yaml:"descriptionQuality,omitempty"`
+	// StateTableMax is the configured pf state table limit; zero when the
+	// device relies on pf's built-in default.
+	StateTableMax int `json:"stateTableMax,omitempty" yaml:"stateTableMax,omitempty"`
+	// LoggingCoverage summarizes how many enabled pass and block rules log
+	// their matches; nil when the audit mode does not check rule logging.
+	LoggingCoverage *LoggingCoverage `json:"loggingCoverage,omitempty" yaml:"loggingCoverage,omitempty"`
+	// BenchmarkScore weighs passed compliance controls against applicable
+	// ones per section; nil when the audit mode runs no compliance checks.
+	BenchmarkScore *BenchmarkScore `json:"benchmarkScore,omitempty" yaml:"benchmarkScore,omitempty"`
+	// ConfigFreshness counts rules, NAT rules, and users by the age of their
+	// last change; nil when the audit mode does not check freshness.
+	ConfigFreshness *ConfigFreshness `json:"configFreshness,omitempty" yaml:"configFreshness,omitempty"`
+}
+
+// DescriptionQuality summarizes how many enabled firewall and NAT rules carry
+// a description that satisfies the audit's change-management policy.
+type DescriptionQuality struct {
+	// TotalRules is the number of enabled firewall and NAT rules checked.
+	TotalRules int `json:"totalRules" yaml:"totalRules"`
+	// CompliantRules is the number of checked rules whose description is
+	// present, long enough, and matches the required pattern when one is set.
+	CompliantRules int `json:"compliantRules" yaml:"compliantRules"`
+	// CompliantPercent is CompliantRules as a percentage of TotalRules, or 100
+	// when there are no rules to check.
+	CompliantPercent float64 `json:"compliantPercent" yaml:"compliantPercent"`
+}
+
+// ConfigFreshness summarizes how long ago the configuration and the objects
+// in it were last changed, measured against a reference time.
+type ConfigFreshness struct {
+	// ReferenceTime is the time ages are measured against, in RFC 3339.
+	ReferenceTime string `json:"referenceTime" yaml:"referenceTime"`
+	// LastChanged is the time of the configuration's last revision, in
+	// RFC 3339; empty when the revision time is missing or unrecognized.
+	LastChanged string `json:"lastChanged,omitempty" yaml:"lastChanged,omitempty"`
+	// Rules counts the firewall filter rules by age.
+	Rules FreshnessBuckets `json:"rules" yaml:"rules"`
+	// NATRules counts the inbound and outbound NAT rules by age.
+	NATRules FreshnessBuckets `json:"natRules" yaml:"natRules"`
+	// Users counts the system user accounts by age.
+	Users FreshnessBuckets `json:"users" yaml:"users"`
+}
+
+// FreshnessBuckets counts objects by the age of their last change: the later
+// of their created and updated times.
+type FreshnessBuckets struct {
+	// Recent is the number of objects changed less than 90 days ago.
+	Recent int `json:"recent" yaml:"recent"`
+	// Aging is the number of objects changed 90 to 365 days ago.
+	Aging int `json:"aging" yaml:"aging"`
+	// Stale is the number of objects changed more than 365 days ago.
+	Stale int `json:"stale" yaml:"stale"`
+	// Unknown is the number of objects without a recognizable change time.
+	Unknown int `json:"unknown" yaml:"unknown"`
+}
+
+// LoggingCoverage summarizes how much firewall traffic is visible in the logs,
+// per interface and across the whole rule set.
+type LoggingCoverage struct {
+	// Interfaces contains one entry per interface with at least one enabled
+	// pass or block rule, in configuration order.
+	Interfaces []LoggingCoverageEntry `json:"interfaces,omitempty" yaml:"interfaces,omitempty"`
+	// Total counts every enabled rule once, including floating rules that
+	// are not bound to any interface.
+	Total LoggingCoverageEntry `json:"total" yaml:"total"`
+}
+
+// LoggingCoverageEntry counts the enabled pass and block rules of one
+// interface, or of the whole rule set, and how many of them log.
+type LoggingCoverageEntry struct {
+	// Interface is the interface name; empty for the rule set total.
+	Interface string `json:"interface,omitempty" yaml:"interface,omitempty"`
+	// PassRules is the number of enabled pass rules.
+	PassRules int `json:"passRules" yaml:"passRules"`
+	// LoggedPassRules is the number of enabled pass rules with logging enabled.
+	LoggedPassRules int `json:"loggedPassRules" yaml:"loggedPassRules"`
+	// PassLoggedPercent is LoggedPassRules as a percentage of PassRules, or
+	// 100 when there are no pass rules.
+	PassLoggedPercent float64 `json:"passLoggedPercent" yaml:"passLoggedPercent"`
+	// BlockRules is the number of enabled block and reject rules.
+	BlockRules int `json:"blockRules" yaml:"blockRules"`
+	// LoggedBlockRules is the number of enabled block and reject rules with
+	// logging enabled.
+	LoggedBlockRules int `json:"loggedBlockRules" yaml:"loggedBlockRules"`
+	// BlockLoggedPercent is LoggedBlockRules as a percentage of BlockRules,
+	// or 100 when there are no block rules.
+	BlockLoggedPercent float64 `json:"blockLoggedPercent" yaml:"blockLoggedPercent"`
+}
+
+// BenchmarkScore is a single comparable posture number for a device: the
+// weighted share of applicable compliance controls that passed, overall and
+// per section. Controls a plugin could not evaluate against the device are
+// not applicable and count toward neither side of the ratio.
+type BenchmarkScore struct {
+	// Score is the overall score from 0 to 1: the average of the section
+	// scores weighted by each section's applicable weight. Zero when no
+	// control was applicable.
+	Score float64 `json:"score" yaml:"score"`
+	// Grade is the letter grade A-F for Score, or "N/A" when no control was
+	// applicable.
+	Grade string `json:"grade" yaml:"grade"`
+	// ApplicableChecks is the number of controls that were evaluated.
+	ApplicableChecks int `json:"applicableChecks" yaml:"applicableChecks"`
+	// Sections contains one entry per score section, in report order.
+	Sections []SectionScore `json:"sections" yaml:"sections"`
+}
+
+// SectionScore is the benchmark score of one section, such as network or
+// crypto.
+type SectionScore struct {
+	// Section is the section name.
+	Section string `json:"section" yaml:"section"`
+	// Score is PassedWeight divided by ApplicableWeight, from 0 to 1. Zero
+	// when the section has no applicable controls.
+	Score float64 `json:"score" yaml:"score"`
+	// Grade is the letter grade A-F for Score, or "N/A" when the section has
+	// no applicable controls.
+	Grade string `json:"grade" yaml:"grade"`
+	// PassedChecks is the number of applicable controls that passed.
+	PassedChecks int `json:"passedChecks" yaml:"passedChecks"`
+	// ApplicableChecks is the number of controls that were evaluated.
+	ApplicableChecks int `json:"applicableChecks" yaml:"applicableChecks"`
+	// NotApplicableChecks is the number of controls that could not be
+	// evaluated against the device and are excluded from the score.
+	NotApplicableChecks int `json:"notApplicableChecks" yaml:"notApplicableChecks"`
+	// PassedWeight is the summed weight of the passed controls.
+	PassedWeight float64 `json:"passedWeight" yaml:"passedWeight"`
+	// ApplicableWeight is the summed weight of the applicable controls.
+	ApplicableWeight float64 `json:"applicableWeight" yaml:"applicableWeight"`
 }
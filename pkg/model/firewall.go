@@ -1,5 +1,10 @@
 package model
 
+import (
+	"net/netip"
+	"strings"
+)
+
 // FirewallRuleType represents the action taken by a firewall rule.
 type FirewallRuleType string
 
@@ -165,6 +170,9 @@ type FirewallRule struct {
 	Target string `json:"target,omitempty" yaml:"target,omitempty"`
 	// Gateway is the policy-based routing gateway for the rule.
 	Gateway string `json:"gateway,omitempty" yaml:"gateway,omitempty"`
+	// Schedule names the Schedule that limits when the rule is active;
+	// empty when the rule is always active.
+	Schedule string `json:"schedule,omitempty" yaml:"schedule,omitempty"`
 
 	// Log indicates whether matched packets are logged.
 	Log bool `json:"log,omitempty" yaml:"log,omitempty"`
@@ -203,13 +211,19 @@ type FirewallRule struct {
 	NoSync bool `json:"noSync,omitempty" yaml:"noSync,omitempty"`
 	// AssociatedRuleID links this rule to an automatically generated companion rule.
 	AssociatedRuleID string `json:"associatedRuleId,omitempty" yaml:"associatedRuleId,omitempty"`
+	// Created records who created the rule and when; nil when not recorded.
+	Created *ChangeRecord `json:"created,omitempty" yaml:"created,omitempty"`
+	// Updated records who last modified the rule and when; nil when not recorded.
+	Updated *ChangeRecord `json:"updated,omitempty" yaml:"updated,omitempty"`
 }
 
 // NATConfig contains all NAT-related configuration.
 type NATConfig struct {
 	// OutboundMode is the outbound NAT mode (automatic, hybrid, advanced, or disabled).
 	OutboundMode NATOutboundMode `json:"outboundMode,omitempty" yaml:"outboundMode,omitempty"`
-	// ReflectionDisabled indicates NAT reflection is turned off.
+	// ReflectionDisabled is the system-wide NAT reflection default and the
+	// single source of truth for it; System.DisableNATReflection mirrors it.
+	// Per-rule InboundNATRule.NATReflection values take precedence.
 	ReflectionDisabled bool `json:"reflectionDisabled,omitempty" yaml:"reflectionDisabled,omitempty"`
 	// PfShareForward enables pf share-forward for NAT.
 	PfShareForward bool `json:"pfShareForward,omitempty" yaml:"pfShareForward,omitempty"`
@@ -217,6 +231,8 @@ type NATConfig struct {
 	OutboundRules []NATRule `json:"outboundRules,omitempty" yaml:"outboundRules,omitempty"`
 	// InboundRules contains inbound (port-forward) NAT rules.
 	InboundRules []InboundNATRule `json:"inboundRules,omitempty" yaml:"inboundRules,omitempty"`
+	// OneToOneRules contains 1:1 (binat) NAT mappings.
+	OneToOneRules []OneToOneNATRule `json:"oneToOneRules,omitempty" yaml:"oneToOneRules,omitempty"`
 	// BiNATEnabled indicates bidirectional NAT is active.
 	BiNATEnabled bool `json:"biNatEnabled,omitempty" yaml:"biNatEnabled,omitempty"`
 }
@@ -259,6 +275,10 @@ type NATRule struct {
 	Tag string `json:"tag,omitempty" yaml:"tag,omitempty"`
 	// Tagged matches packets that already carry the specified pf tag.
 	Tagged string `json:"tagged,omitempty" yaml:"tagged,omitempty"`
+	// Created records who created the NAT rule and when; nil when not recorded.
+	Created *ChangeRecord `json:"created,omitempty" yaml:"created,omitempty"`
+	// Updated records who last modified the NAT rule and when; nil when not recorded.
+	Updated *ChangeRecord `json:"updated,omitempty" yaml:"updated,omitempty"`
 }
 
 // InboundNATRule represents an inbound (port-forward) NAT rule.
@@ -286,6 +306,8 @@ type InboundNATRule struct {
 	// Reflection is the NAT reflection setting for this rule.
 	Reflection string `json:"reflection,omitempty" yaml:"reflection,omitempty"`
 	// NATReflection is the NAT reflection mode (e.g., "enable", "disable", "purenat").
+	// A non-empty mode overrides NATConfig.ReflectionDisabled for this rule;
+	// see [InboundNATRule.ReflectionDisabled].
 	NATReflection string `json:"natReflection,omitempty" yaml:"natReflection,omitempty"`
 	// AssociatedRuleID links this rule to an automatically generated filter rule.
 	AssociatedRuleID string `json:"associatedRuleId,omitempty" yaml:"associatedRuleId,omitempty"`
@@ -301,6 +323,88 @@ type InboundNATRule struct {
 	Log bool `json:"log,omitempty" yaml:"log,omitempty"`
 	// Description is a human-readable description of the port-forward rule.
 	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	// Created records who created the port-forward rule and when; nil when not recorded.
+	Created *ChangeRecord `json:"created,omitempty" yaml:"created,omitempty"`
+	// Updated records who last modified the port-forward rule and when; nil when not recorded.
+	Updated *ChangeRecord `json:"updated,omitempty" yaml:"updated,omitempty"`
+}
+
+// One-to-one NAT types for OneToOneNATRule.Type.
+const (
+	// OneToOneTypeBINAT translates in both directions (the default).
+	OneToOneTypeBINAT = "binat"
+	// OneToOneTypeNAT translates inbound traffic only.
+	OneToOneTypeNAT = "nat"
+)
+
+// OneToOneNATRule represents a 1:1 (binat) NAT mapping between an external
+// address or range and an internal host or subnet. Unlike a port forward, it
+// exposes every port of the internal address that the filter rules allow.
+type OneToOneNATRule struct {
+	// UUID is the unique identifier for the 1:1 NAT rule.
+	UUID string `json:"uuid,omitempty" yaml:"uuid,omitempty"`
+	// Interfaces lists the interface names this rule applies to.
+	Interfaces []string `json:"interfaces,omitempty" yaml:"interfaces,omitempty"`
+	// IPProtocol is the IP address family (inet or inet6).
+	IPProtocol IPProtocol `json:"ipProtocol,omitempty" yaml:"ipProtocol,omitempty"`
+	// Type is the mapping type, OneToOneTypeBINAT or OneToOneTypeNAT; empty means binat.
+	Type string `json:"type,omitempty" yaml:"type,omitempty"`
+	// External is the external address, or the first address of the external
+	// range when Internal is a subnet.
+	External string `json:"external,omitempty" yaml:"external,omitempty"`
+	// Internal is the internal address, subnet, or alias being mapped.
+	Internal string `json:"internal,omitempty" yaml:"internal,omitempty"`
+	// Destination restricts the mapping to traffic for this endpoint.
+	Destination RuleEndpoint `json:"destination" yaml:"destination,omitempty"`
+	// NATReflection is the NAT reflection mode for this mapping.
+	NATReflection string `json:"natReflection,omitempty" yaml:"natReflection,omitempty"`
+	// Disabled indicates the 1:1 NAT rule is administratively disabled.
+	Disabled bool `json:"disabled,omitempty" yaml:"disabled,omitempty"`
+	// Log indicates whether matched packets are logged.
+	Log bool `json:"log,omitempty" yaml:"log,omitempty"`
+	// Description is a human-readable description of the 1:1 NAT rule.
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	// Category is the classification category for the 1:1 NAT rule.
+	Category string `json:"category,omitempty" yaml:"category,omitempty"`
+	// Created records who created the 1:1 NAT rule and when; nil when not recorded.
+	Created *ChangeRecord `json:"created,omitempty" yaml:"created,omitempty"`
+	// Updated records who last modified the 1:1 NAT rule and when; nil when not recorded.
+	Updated *ChangeRecord `json:"updated,omitempty" yaml:"updated,omitempty"`
+}
+
+// IsBidirectional reports whether the mapping also translates outbound
+// traffic. An empty Type is the binat default.
+func (r OneToOneNATRule) IsBidirectional() bool {
+	return r.Type == "" || strings.EqualFold(r.Type, OneToOneTypeBINAT)
+}
+
+// ExternalPrefix returns the external range the rule maps. An External value
+// without a mask takes the prefix length of Internal, as pf does for binat;
+// an Internal alias or bare address counts as a single host. ok is false when
+// External is not an IP address or prefix.
+func (r OneToOneNATRule) ExternalPrefix() (netip.Prefix, bool) {
+	if prefix, err := netip.ParsePrefix(r.External); err == nil {
+		return prefix.Masked(), true
+	}
+
+	addr, err := netip.ParseAddr(r.External)
+	if err != nil {
+		return netip.Prefix{}, false
+	}
+
+	bits := addr.BitLen()
+	if internal, err := netip.ParsePrefix(r.Internal); err == nil && internal.Addr().BitLen() == bits {
+		bits = internal.Bits()
+	}
+
+	return netip.PrefixFrom(addr, bits).Masked(), true
+}
+
+// MapsSubnet reports whether the rule maps more than a single external
+// address, exposing a whole internal subnet.
+func (r OneToOneNATRule) MapsSubnet() bool {
+	prefix, ok := r.ExternalPrefix()
+	return ok && !prefix.IsSingleIP()
 }
 
 // HasData reports whether the NATConfig contains any meaningful configuration
@@ -310,11 +414,49 @@ func (c NATConfig) HasData() bool {
 	return c.OutboundMode != "" ||
 		len(c.OutboundRules) > 0 ||
 		len(c.InboundRules) > 0 ||
+		len(c.OneToOneRules) > 0 ||
 		c.ReflectionDisabled ||
 		c.PfShareForward ||
 		c.BiNATEnabled
 }
 
+// NAT reflection modes for InboundNATRule.NATReflection.
+const (
+	natReflectionEnable  = "enable"
+	natReflectionPureNAT = "purenat"
+	natReflectionDisable = "disable"
+)
+
+// ReflectionDisabled resolves whether NAT reflection is off for this rule
+// given the system default. A NATReflection of "enable" or "purenat" turns
+// reflection on and "disable" turns it off, overriding systemDisabled; any
+// other value, including empty or "default", inherits the system default.
+func (r InboundNATRule) ReflectionDisabled(systemDisabled bool) bool {
+	switch strings.ToLower(r.NATReflection) {
+	case natReflectionEnable, natReflectionPureNAT:
+		return false
+	case natReflectionDisable:
+		return true
+	default:
+		return systemDisabled
+	}
+}
+
+// ReflectionOverrides returns the indices of the inbound rules whose
+// NATReflection mode contradicts the system-wide ReflectionDisabled default,
+// in rule order. The parsers report each one as a conversion warning.
+func (c NATConfig) ReflectionOverrides() []int {
+	var overrides []int
+
+	for i, rule := range c.InboundRules {
+		if rule.ReflectionDisabled(c.ReflectionDisabled) != c.ReflectionDisabled {
+			overrides = append(overrides, i)
+		}
+	}
+
+	return overrides
+}
+
 // NATSummary is a read-only convenience view of a device's NAT configuration,
 // returned by [CommonDevice.NATSummary]. Slice fields are cloned so callers
 // can iterate or filter without mutating the original device.
@@ -329,4 +471,72 @@ type NATSummary struct {
 	OutboundRules []NATRule `json:"outboundRules,omitempty" yaml:"outboundRules,omitempty"`
 	// InboundRules contains inbound (port-forward) NAT rules.
 	InboundRules []InboundNATRule `json:"inboundRules,omitempty" yaml:"inboundRules,omitempty"`
+	// OneToOneRules contains 1:1 (binat) NAT mappings.
+	OneToOneRules []OneToOneNATRule `json:"oneToOneRules,omitempty" yaml:"oneToOneRules,omitempty"`
+}
+
+// PFSettings contains the pf state table limits and state timeout overrides.
+// Limit and timeout values are kept as configured; an empty value means pf
+// uses its built-in default.
+type PFSettings struct {
+	// StateScale selects how state timeouts scale with table usage.
+	StateScale string `json:"stateScale,omitempty" yaml:"stateScale,omitempty"`
+	// MaxStates is the parsed state table limit, or 0 when no valid limit is set.
+	MaxStates int `json:"maxStates,omitempty" yaml:"maxStates,omitempty"`
+	// MaxTableEntries is the limit on addresses held across all pf tables.
+	MaxTableEntries string `json:"maxTableEntries,omitempty" yaml:"maxTableEntries,omitempty"`
+	// MaxFragments is the limit on fragment reassembly entries.
+	MaxFragments string `json:"maxFragments,omitempty" yaml:"maxFragments,omitempty"`
+	// MaxSourceNodes is the limit on source-tracking nodes.
+	MaxSourceNodes string `json:"maxSourceNodes,omitempty" yaml:"maxSourceNodes,omitempty"`
+	// AdaptiveStart is the state count at which adaptive timeout scaling begins.
+	AdaptiveStart string `json:"adaptiveStart,omitempty" yaml:"adaptiveStart,omitempty"`
+	// AdaptiveEnd is the state count at which all timeouts reach zero.
+	AdaptiveEnd string `json:"adaptiveEnd,omitempty" yaml:"adaptiveEnd,omitempty"`
+	// Timeouts lists the state timeout overrides that are set, in pf's
+	// canonical order (tcp, udp, icmp, other).
+	Timeouts []PFTimeout `json:"timeouts,omitempty" yaml:"timeouts,omitempty"`
+}
+
+// PFTimeout is a single pf state timeout override.
+type PFTimeout struct {
+	// Name is the pf timeout name (e.g. "tcp.established").
+	Name string `json:"name" yaml:"name"`
+	// Seconds is the configured timeout value in seconds.
+	Seconds string `json:"seconds" yaml:"seconds"`
+}
+
+// Schedule is a named set of time ranges that firewall rules reference to
+// restrict when they are active.
+type Schedule struct {
+	// Name is the schedule name referenced by FirewallRule.Schedule.
+	Name string `json:"name" yaml:"name"`
+	// Description is the user-provided description.
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	// TimeRanges lists the ranges during which the schedule is active.
+	TimeRanges []ScheduleTimeRange `json:"timeRanges,omitempty" yaml:"timeRanges,omitempty"`
+}
+
+// ScheduleTimeRange is one time range of a Schedule. It selects days either
+// by calendar date (Dates) or by weekday (Weekdays), and applies the Hours
+// window to each selected day.
+type ScheduleTimeRange struct {
+	// Dates lists the calendar dates the range covers.
+	Dates []ScheduleDate `json:"dates,omitempty" yaml:"dates,omitempty"`
+	// Weekdays lists the ISO weekdays (1 = Monday through 7 = Sunday) the
+	// range repeats on every week.
+	Weekdays []int `json:"weekdays,omitempty" yaml:"weekdays,omitempty"`
+	// Hours is the daily time window in "HH:MM-HH:MM" form; empty for all day.
+	Hours string `json:"hours,omitempty" yaml:"hours,omitempty"`
+	// Description is the user-provided description of the range.
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+// ScheduleDate is a calendar date of a ScheduleTimeRange. Configurations
+// record only the month and day.
+type ScheduleDate struct {
+	// Month is the month of the year, 1 through 12.
+	Month int `json:"month" yaml:"month"`
+	// Day is the day of the month, 1 through 31.
+	Day int `json:"day" yaml:"day"`
 }
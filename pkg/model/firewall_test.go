@@ -99,3 +99,104 @@ func TestNATOutboundMode_IsValid(t *testing.T) {
 		})
 	}
 }
+
+func TestInboundNATRule_ReflectionDisabled(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		mode           string
+		systemDisabled bool
+		want           bool
+	}{
+		{"empty inherits disabled default", "", true, true},
+		{"empty inherits enabled default", "", false, false},
+		{"default inherits system default", "default", true, true},
+		{"enable overrides disabled default", "enable", true, false},
+		{"purenat overrides disabled default", "purenat", true, false},
+		{"disable overrides enabled default", "disable", false, true},
+		{"mode is case-insensitive", "Enable", true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			rule := common.InboundNATRule{NATReflection: tt.mode}
+			assert.Equal(t, tt.want, rule.ReflectionDisabled(tt.systemDisabled))
+		})
+	}
+}
+
+func TestNATConfig_ReflectionOverrides(t *testing.T) {
+	t.Parallel()
+
+	rules := []common.InboundNATRule{
+		{NATReflection: ""},
+		{NATReflection: "enable"},
+		{NATReflection: "disable"},
+		{NATReflection: "purenat"},
+		{NATReflection: "default"},
+	}
+
+	tests := []struct {
+		name           string
+		systemDisabled bool
+		want           []int
+	}{
+		{"enabling modes override a disabled default", true, []int{1, 3}},
+		{"disable overrides an enabled default", false, []int{2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			nat := common.NATConfig{ReflectionDisabled: tt.systemDisabled, InboundRules: rules}
+			assert.Equal(t, tt.want, nat.ReflectionOverrides())
+		})
+	}
+
+	assert.Nil(t, common.NATConfig{}.ReflectionOverrides())
+}
+
+func TestOneToOneNATRule_ExternalPrefix(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		external   string
+		internal   string
+		want       string
+		wantSubnet bool
+	}{
+		{"host", "203.0.113.10", "192.168.1.25", "203.0.113.10/32", false},
+		{"inherits the internal mask", "203.0.113.70", "10.20.0.0/27", "203.0.113.64/27", true},
+		{"own mask wins", "203.0.113.0/28", "10.20.0.0/24", "203.0.113.0/28", true},
+		{"internal alias is a single host", "203.0.113.10", "MailHost", "203.0.113.10/32", false},
+		{"IPv6 ignores an IPv4 internal mask", "2001:db8::10", "10.20.0.0/27", "2001:db8::10/128", false},
+		{"not an address", "wanip", "10.20.0.0/27", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			rule := common.OneToOneNATRule{External: tt.external, Internal: tt.internal}
+			prefix, ok := rule.ExternalPrefix()
+			assert.Equal(t, tt.want != "", ok)
+			if ok {
+				assert.Equal(t, tt.want, prefix.String())
+			}
+			assert.Equal(t, tt.wantSubnet, rule.MapsSubnet())
+		})
+	}
+}
+
+func TestOneToOneNATRule_IsBidirectional(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, common.OneToOneNATRule{}.IsBidirectional())
+	assert.True(t, common.OneToOneNATRule{Type: "BINAT"}.IsBidirectional())
+	assert.False(t, common.OneToOneNATRule{Type: common.OneToOneTypeNAT}.IsBidirectional())
+}
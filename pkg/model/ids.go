@@ -32,6 +32,29 @@ type IDSConfig struct {
 	AlertSaveLogs string `json:"alertSaveLogs,omitempty" yaml:"alertSaveLogs,omitempty"`
 	// UpdateCron is the cron expression for automatic rule updates.
 	UpdateCron string `json:"updateCron,omitempty" yaml:"updateCron,omitempty"`
+	// Policies contains the policies that rewrite the action of matching rules.
+	Policies []IDSPolicy `json:"policies,omitempty" yaml:"policies,omitempty"`
+}
+
+// IDSPolicy is an IDS policy that sets the action of the rules it matches.
+type IDSPolicy struct {
+	// Description is the policy description.
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	// Enabled indicates the policy is applied.
+	Enabled bool `json:"enabled,omitempty" yaml:"enabled,omitempty"`
+	// Priority orders policies; lower values are applied first.
+	Priority string `json:"priority,omitempty" yaml:"priority,omitempty"`
+	// Rulesets lists the ruleset identifiers the policy applies to. Empty
+	// means every installed ruleset.
+	Rulesets []string `json:"rulesets,omitempty" yaml:"rulesets,omitempty"`
+	// MatchActions lists the current rule actions the policy matches (e.g., "alert", "drop").
+	MatchActions []string `json:"matchActions,omitempty" yaml:"matchActions,omitempty"`
+	// Filters lists the rule metadata criteria the policy matches
+	// (e.g., "signature_severity.Major").
+	Filters []string `json:"filters,omitempty" yaml:"filters,omitempty"`
+	// Action is the action applied to matched rules: "default" (keep the
+	// rule's own action), "alert", "drop", or "disable".
+	Action string `json:"action,omitempty" yaml:"action,omitempty"`
 }
 
 // IDSDetect contains IDS detection profile settings.
@@ -25,6 +25,12 @@ const (
 	// NamedObjectTypeURL is a dynamically-fetched URL table alias. Its
 	// members are opaque and are never expanded by Resolve.
 	NamedObjectTypeURL NamedObjectType = "url"
+	// NamedObjectTypeURLTable is a URL table alias the firewall refreshes
+	// from its source URLs on a schedule. Opaque, like URL.
+	NamedObjectTypeURLTable NamedObjectType = "urltable"
+	// NamedObjectTypeURLTablePorts is a URL table of ports, refreshed like
+	// URLTable. Opaque, like URL.
+	NamedObjectTypeURLTablePorts NamedObjectType = "urltable_ports"
 	// NamedObjectTypeGeoIP is a GeoIP country/region alias. Opaque, like URL.
 	NamedObjectTypeGeoIP NamedObjectType = "geoip"
 	// NamedObjectTypeExternal is an externally-managed table (e.g. a
@@ -36,7 +42,20 @@ const (
 func (t NamedObjectType) IsValid() bool {
 	switch t {
 	case NamedObjectTypeHost, NamedObjectTypeNetwork, NamedObjectTypePort,
-		NamedObjectTypeURL, NamedObjectTypeGeoIP, NamedObjectTypeExternal:
+		NamedObjectTypeURL, NamedObjectTypeURLTable, NamedObjectTypeURLTablePorts,
+		NamedObjectTypeGeoIP, NamedObjectTypeExternal:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsExternallyResolved reports whether the firewall fetches t's entries from
+// an external source at runtime: a URL, URL table, or GeoIP alias. The
+// entries of such an object cannot be known from the configuration alone.
+func (t NamedObjectType) IsExternallyResolved() bool {
+	switch t {
+	case NamedObjectTypeURL, NamedObjectTypeURLTable, NamedObjectTypeURLTablePorts, NamedObjectTypeGeoIP:
 		return true
 	default:
 		return false
@@ -45,9 +64,9 @@ func (t NamedObjectType) IsValid() bool {
 
 // staticNamedObjectTypes lists the ONLY NamedObjectType values whose members
 // are genuinely a static, resolvable list. Converters store raw vendor type
-// strings verbatim (e.g. OPNsense/pfSense "urltable", "urltable_ports",
-// "networkgroup", "mac", plus any future or unrecognized vendor spelling),
-// not just the three canonical dynamic constants. isDynamic used to be an
+// strings verbatim (e.g. OPNsense/pfSense "networkgroup", "mac", plus any
+// future or unrecognized vendor spelling), not just the canonical dynamic
+// constants. isDynamic used to be an
 // allowlist of known-opaque types and treated everything else — including
 // these vendor spellings — as statically resolvable, so a genuinely opaque,
 // externally-fetched "urltable" alias was silently expanded, and R8's
@@ -65,10 +84,10 @@ var staticNamedObjectTypes = map[NamedObjectType]struct{}{
 
 // isStatic reports whether t is one of the known-static, resolvable
 // NamedObjectType values (host, network, port). Every other type — the
-// canonical dynamic constants (url, geoip, external), a vendor-specific
-// dynamic spelling not modeled by a dedicated constant (e.g. "urltable",
-// "urltable_ports"), or any other unrecognized string — is treated as
-// opaque by isDynamic below.
+// canonical dynamic constants (url, urltable, urltable_ports, geoip,
+// external), a vendor-specific spelling not modeled by a dedicated constant
+// (e.g. "networkgroup", "mac"), or any other unrecognized string — is
+// treated as opaque by isDynamic below.
 func (t NamedObjectType) isStatic() bool {
 	_, ok := staticNamedObjectTypes[t]
 	return ok
@@ -85,16 +104,25 @@ func (t NamedObjectType) isDynamic() bool {
 
 // NamedObject represents a single named object (alias) as it appears in a
 // device's firewall configuration: a host, network, port, or dynamic
-// (url/geoip/external) alias with zero or more members.
+// (url/urltable/geoip/external) alias with zero or more members.
 type NamedObject struct {
 	// Name is the alias name, matching the registry key in NamedObjects.
 	Name string `json:"name,omitempty" yaml:"name,omitempty"`
-	// Type classifies the member semantics (host, network, port, url, geoip, external).
+	// Type classifies the member semantics (host, network, port, url,
+	// urltable, urltable_ports, geoip, external).
 	Type NamedObjectType `json:"type,omitempty" yaml:"type,omitempty"`
 	// Members lists the raw member values. For static types these are
 	// literal addresses/ports or the names of other NamedObjects (nested
 	// aliases); for dynamic types they are opaque and are not further resolved.
+	// Externally resolved types keep their sources in URLs and Countries
+	// instead, and carry Members only when the configuration stores a
+	// snapshot of the fetched entries (pfSense url aliases).
 	Members []string `json:"members,omitempty" yaml:"members,omitempty"`
+	// URLs lists the feeds a url or urltable alias fetches its entries from,
+	// and for a GeoIP alias the GeoIP database URL when one is configured.
+	URLs []string `json:"urls,omitempty" yaml:"urls,omitempty"`
+	// Countries lists the country or region codes a GeoIP alias matches.
+	Countries []string `json:"countries,omitempty" yaml:"countries,omitempty"`
 	// Description is the human-readable alias description, when present.
 	Description string `json:"description,omitempty" yaml:"description,omitempty"`
 }
@@ -224,6 +224,8 @@ func TestNamedObjectType_IsValid(t *testing.T) {
 		{"network is valid", common.NamedObjectTypeNetwork, true},
 		{"port is valid", common.NamedObjectTypePort, true},
 		{"url is valid", common.NamedObjectTypeURL, true},
+		{"urltable is valid", common.NamedObjectTypeURLTable, true},
+		{"urltable_ports is valid", common.NamedObjectTypeURLTablePorts, true},
 		{"geoip is valid", common.NamedObjectTypeGeoIP, true},
 		{"external is valid", common.NamedObjectTypeExternal, true},
 		{"empty is invalid", common.NamedObjectType(""), false},
@@ -238,6 +240,32 @@ func TestNamedObjectType_IsValid(t *testing.T) {
 	}
 }
 
+func TestNamedObjectType_IsExternallyResolved(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		val  common.NamedObjectType
+		want bool
+	}{
+		{common.NamedObjectTypeURL, true},
+		{common.NamedObjectTypeURLTable, true},
+		{common.NamedObjectTypeURLTablePorts, true},
+		{common.NamedObjectTypeGeoIP, true},
+		{common.NamedObjectTypeExternal, false},
+		{common.NamedObjectTypeHost, false},
+		{common.NamedObjectTypeNetwork, false},
+		{common.NamedObjectTypePort, false},
+		{common.NamedObjectType("networkgroup"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.val), func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.val.IsExternallyResolved())
+		})
+	}
+}
+
 func TestCommonDevice_NamedObjects_OmitEmpty(t *testing.T) {
 	t.Parallel()
 
@@ -54,10 +54,10 @@ type VLAN struct {
 	Tag string `json:"tag,omitempty" yaml:"tag,omitempty"`
 	// Description is a human-readable description of the VLAN.
 	Description string `json:"description,omitempty" yaml:"description,omitempty"`
-	// Created is the timestamp when the VLAN was created.
-	Created string `json:"created,omitempty" yaml:"created,omitempty"`
-	// Updated is the timestamp when the VLAN was last modified.
-	Updated string `json:"updated,omitempty" yaml:"updated,omitempty"`
+	// Created records who created the VLAN and when; nil when not recorded.
+	Created *ChangeRecord `json:"created,omitempty" yaml:"created,omitempty"`
+	// Updated records who last modified the VLAN and when; nil when not recorded.
+	Updated *ChangeRecord `json:"updated,omitempty" yaml:"updated,omitempty"`
 }
 
 // Bridge represents a network bridge configuration.
@@ -9,3 +9,25 @@ type Revision struct {
 	// Description is a human-readable description of the revision.
 	Description string `json:"description,omitempty" yaml:"description,omitempty"`
 }
+
+// ChangeRecord is the <created>/<updated> metadata OPNsense attaches to
+// individual configuration items such as rules and VLANs. It shares the
+// Revision shape: the user that made the change (often with the source
+// address appended, e.g. "root@192.168.1.10"), the change time as stored
+// (usually Unix epoch seconds), and the change note.
+type ChangeRecord = Revision
+
+// NewChangeRecord returns a ChangeRecord for the given change metadata, or nil
+// when username, time, and description are all empty so that absent
+// <created>/<updated> elements stay absent in exports.
+func NewChangeRecord(username, time, description string) *ChangeRecord {
+	if username == "" && time == "" && description == "" {
+		return nil
+	}
+
+	return &ChangeRecord{
+		Username:    username,
+		Time:        time,
+		Description: description,
+	}
+}
@@ -0,0 +1,22 @@
+package model_test
+
+import (
+	"testing"
+
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewChangeRecord(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, common.NewChangeRecord("", "", ""), "absent metadata should stay nil")
+	assert.Equal(t,
+		&common.ChangeRecord{Username: "root@10.0.0.5", Time: "1700000000"},
+		common.NewChangeRecord("root@10.0.0.5", "1700000000", ""),
+	)
+	assert.Equal(t,
+		&common.ChangeRecord{Description: "/firewall_rules_edit.php"},
+		common.NewChangeRecord("", "", "/firewall_rules_edit.php"),
+	)
+}
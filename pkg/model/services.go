@@ -325,6 +325,28 @@ type ForwarderGroup struct {
 type NTPConfig struct {
 	// PreferredServer is the preferred NTP server address.
 	PreferredServer string `json:"preferredServer,omitempty" yaml:"preferredServer,omitempty"`
+	// Servers lists the configured time servers with their selection flags.
+	Servers []NTPServer `json:"servers,omitempty" yaml:"servers,omitempty"`
+	// MinPoll is the minimum polling interval as a power of two seconds.
+	MinPoll string `json:"minPoll,omitempty" yaml:"minPoll,omitempty"`
+	// MaxPoll is the maximum polling interval as a power of two seconds.
+	MaxPoll string `json:"maxPoll,omitempty" yaml:"maxPoll,omitempty"`
+	// Orphan is the stratum served in orphan mode when no upstream server is reachable.
+	Orphan string `json:"orphan,omitempty" yaml:"orphan,omitempty"`
+	// NoQuery indicates clients are denied NTP status queries (ntpq/ntpdc).
+	NoQuery bool `json:"noQuery,omitempty" yaml:"noQuery,omitempty"`
+	// Restrictions lists the additional access restriction entries.
+	Restrictions []string `json:"restrictions,omitempty" yaml:"restrictions,omitempty"`
+}
+
+// NTPServer represents a configured NTP time server.
+type NTPServer struct {
+	// Address is the server hostname or IP address.
+	Address string `json:"address,omitempty" yaml:"address,omitempty"`
+	// Prefer marks the server as preferred during source selection.
+	Prefer bool `json:"prefer,omitempty" yaml:"prefer,omitempty"`
+	// NoSelect excludes the server from selection; it is polled for monitoring only.
+	NoSelect bool `json:"noSelect,omitempty" yaml:"noSelect,omitempty"`
 }
 
 // SNMPConfig contains SNMP service configuration.
@@ -341,6 +363,98 @@ type SNMPConfig struct {
 type LoadBalancerConfig struct {
 	// MonitorTypes contains health monitor configurations.
 	MonitorTypes []MonitorType `json:"monitorTypes,omitempty" yaml:"monitorTypes,omitempty"`
+	// Pools contains the backend server pools.
+	Pools []LBPool `json:"pools,omitempty" yaml:"pools,omitempty"`
+	// VirtualServers contains the virtual servers that expose pools on a listening address.
+	VirtualServers []LBVirtualServer `json:"virtualServers,omitempty" yaml:"virtualServers,omitempty"`
+}
+
+// LBPool represents a load balancer backend server pool.
+type LBPool struct {
+	// Name is the pool name referenced by virtual servers.
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+	// Mode is the pool balancing mode (e.g., "loadbalance", "failover").
+	Mode string `json:"mode,omitempty" yaml:"mode,omitempty"`
+	// Description is a human-readable description of the pool.
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	// Port is the backend port traffic is forwarded to.
+	Port string `json:"port,omitempty" yaml:"port,omitempty"`
+	// Retry is the number of health check retries before a member is marked down.
+	Retry string `json:"retry,omitempty" yaml:"retry,omitempty"`
+	// Monitor is the name of the MonitorType used to health-check members.
+	Monitor string `json:"monitor,omitempty" yaml:"monitor,omitempty"`
+	// Servers contains the enabled member addresses.
+	Servers []string `json:"servers,omitempty" yaml:"servers,omitempty"`
+	// DisabledServers contains member addresses that are configured but receive no traffic.
+	DisabledServers []string `json:"disabledServers,omitempty" yaml:"disabledServers,omitempty"`
+}
+
+// LBVirtualServer represents a load balancer virtual server (VIP) that
+// exposes a pool on a listening address and port.
+type LBVirtualServer struct {
+	// Name is the virtual server name.
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+	// Description is a human-readable description of the virtual server.
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	// Address is the listening IP address.
+	Address string `json:"address,omitempty" yaml:"address,omitempty"`
+	// Port is the listening port.
+	Port string `json:"port,omitempty" yaml:"port,omitempty"`
+	// Pool is the name of the LBPool that serves requests.
+	Pool string `json:"pool,omitempty" yaml:"pool,omitempty"`
+	// FallbackPool is the name of the LBPool used when every Pool member is down.
+	FallbackPool string `json:"fallbackPool,omitempty" yaml:"fallbackPool,omitempty"`
+	// Mode is the relay mode (e.g., "redirect_mode", "relay").
+	Mode string `json:"mode,omitempty" yaml:"mode,omitempty"`
+	// RelayProtocol is the relay protocol for relay mode (e.g., "tcp", "dns").
+	RelayProtocol string `json:"relayProtocol,omitempty" yaml:"relayProtocol,omitempty"`
+}
+
+// WakeOnLANEntry represents a host that can be woken with a Wake-on-LAN magic packet.
+type WakeOnLANEntry struct {
+	// Interface is the logical interface the magic packet is sent on (e.g., "lan").
+	Interface string `json:"interface,omitempty" yaml:"interface,omitempty"`
+	// MAC is the hardware address of the host to wake.
+	MAC string `json:"mac,omitempty" yaml:"mac,omitempty"`
+	// Description is a human-readable description of the host.
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+// UPnPConfig contains the UPnP IGD / NAT-PMP (miniupnpd) settings. When the
+// service runs, clients on the listening interfaces can open port forwards on
+// the external interface without an administrator, subject to Rules.
+type UPnPConfig struct {
+	// Enabled indicates whether the daemon runs: the service is enabled and
+	// at least one of UPnP IGD and NAT-PMP is switched on.
+	Enabled bool `json:"enabled" yaml:"enabled"`
+	// UPnPEnabled indicates whether the UPnP IGD protocol is switched on.
+	UPnPEnabled bool `json:"upnpEnabled" yaml:"upnpEnabled"`
+	// NATPMPEnabled indicates whether the NAT-PMP protocol is switched on.
+	NATPMPEnabled bool `json:"natPmpEnabled" yaml:"natPmpEnabled"`
+	// ExternalInterface is the interface mappings are opened on (e.g., "wan").
+	ExternalInterface string `json:"externalInterface,omitempty" yaml:"externalInterface,omitempty"`
+	// Interfaces are the internal interfaces the daemon listens on.
+	Interfaces []string `json:"interfaces,omitempty" yaml:"interfaces,omitempty"`
+	// DefaultDeny indicates that requests matching no rule are denied.
+	// Without it, miniupnpd accepts them.
+	DefaultDeny bool `json:"defaultDeny" yaml:"defaultDeny"`
+	// Rules are the ACL entries in evaluation order; the first match applies.
+	Rules []UPnPPermissionRule `json:"rules,omitempty" yaml:"rules,omitempty"`
+}
+
+// UPnPPermissionRule is a miniupnpd ACL entry deciding which internal
+// addresses and ports may be mapped to which external ports.
+type UPnPPermissionRule struct {
+	// Action is "allow" or "deny".
+	Action string `json:"action" yaml:"action"`
+	// ExternalPorts is the external port or port range (e.g., "1024-65535").
+	ExternalPorts string `json:"externalPorts" yaml:"externalPorts"`
+	// InternalAddress is the internal address or network (e.g., "192.168.1.0/24").
+	InternalAddress string `json:"internalAddress" yaml:"internalAddress"`
+	// InternalPorts is the internal port or port range.
+	InternalPorts string `json:"internalPorts" yaml:"internalPorts"`
+	// Description is the optional comment following the port range.
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
 }
 
 // MonitorType represents a load balancer health monitor.
@@ -433,8 +547,13 @@ type MonitConfig struct {
 	HTTPDPort string `json:"httpdPort,omitempty" yaml:"httpdPort,omitempty"`
 	// MMonitURL is the M/Monit aggregation server URL.
 	MMonitURL string `json:"mmonitUrl,omitempty" yaml:"mmonitUrl,omitempty"`
-	// Alert contains alert notification settings.
+	// Alert is the first entry of Alerts, kept for consumers of model
+	// versions before 2.18.0.
+	//
+	// Deprecated: Use Alerts, which lists every alert recipient.
 	Alert *MonitAlert `json:"alert,omitempty" yaml:"alert,omitempty"`
+	// Alerts contains the alert recipients and the events they are notified of.
+	Alerts []MonitAlert `json:"alerts,omitempty" yaml:"alerts,omitempty"`
 	// Services contains monitored service definitions.
 	Services []MonitServiceEntry `json:"services,omitempty" yaml:"services,omitempty"`
 	// Tests contains monitoring test definitions.
@@ -443,6 +562,8 @@ type MonitConfig struct {
 
 // MonitAlert contains Monit alert notification configuration.
 type MonitAlert struct {
+	// UUID is the unique identifier for this alert.
+	UUID string `json:"uuid,omitempty" yaml:"uuid,omitempty"`
 	// Enabled indicates whether this alert is active.
 	Enabled bool `json:"enabled,omitempty" yaml:"enabled,omitempty"`
 	// Recipient is the email address to receive alerts.
@@ -505,6 +626,9 @@ type MonitTest struct {
 
 // NetflowConfig contains NetFlow/IPFIX traffic accounting configuration.
 type NetflowConfig struct {
+	// Enabled indicates flows are captured on at least one interface and sent
+	// to a collector, either a configured target or the local collector.
+	Enabled bool `json:"enabled,omitempty" yaml:"enabled,omitempty"`
 	// CaptureInterfaces lists the interfaces to capture flow data from.
 	CaptureInterfaces string `json:"captureInterfaces,omitempty" yaml:"captureInterfaces,omitempty"`
 	// CaptureVersion is the NetFlow protocol version (e.g., "9", "10" for IPFIX).
@@ -513,6 +637,8 @@ type NetflowConfig struct {
 	EgressOnly bool `json:"egressOnly,omitempty" yaml:"egressOnly,omitempty"`
 	// CaptureTargets contains flow collector target addresses.
 	CaptureTargets string `json:"captureTargets,omitempty" yaml:"captureTargets,omitempty"`
+	// Collectors lists the flow export destinations parsed from CaptureTargets.
+	Collectors []NetflowCollector `json:"collectors,omitempty" yaml:"collectors,omitempty"`
 	// CollectEnabled enables the local flow collector.
 	CollectEnabled bool `json:"collectEnabled,omitempty" yaml:"collectEnabled,omitempty"`
 	// InactiveTimeout is the timeout for inactive flows in seconds.
@@ -521,6 +647,14 @@ type NetflowConfig struct {
 	ActiveTimeout string `json:"activeTimeout,omitempty" yaml:"activeTimeout,omitempty"`
 }
 
+// NetflowCollector is a NetFlow/IPFIX flow export destination.
+type NetflowCollector struct {
+	// Address is the collector's IP address or hostname.
+	Address string `json:"address,omitempty" yaml:"address,omitempty"`
+	// Port is the collector's UDP port, empty when the target names none.
+	Port string `json:"port,omitempty" yaml:"port,omitempty"`
+}
+
 // TrafficShaperConfig contains QoS/traffic shaping configuration.
 type TrafficShaperConfig struct {
 	// Pipes contains pipe (bandwidth limiter) identifiers.
@@ -529,6 +663,78 @@ type TrafficShaperConfig struct {
 	Queues string `json:"queues,omitempty" yaml:"queues,omitempty"`
 	// Rules contains traffic shaping rule identifiers.
 	Rules string `json:"rules,omitempty" yaml:"rules,omitempty"`
+	// PipeEntries contains the configured dummynet pipes.
+	PipeEntries []TrafficShaperPipe `json:"pipeEntries,omitempty" yaml:"pipeEntries,omitempty"`
+	// QueueEntries contains the configured dummynet queues.
+	QueueEntries []TrafficShaperQueue `json:"queueEntries,omitempty" yaml:"queueEntries,omitempty"`
+}
+
+// TrafficShaperPipe is a traffic shaper pipe: a bandwidth limiter that
+// queues and shaping rules send traffic through.
+type TrafficShaperPipe struct {
+	// UUID is the pipe's unique identifier, referenced by queues.
+	UUID string `json:"uuid,omitempty" yaml:"uuid,omitempty"`
+	// Number is the dummynet pipe number.
+	Number string `json:"number,omitempty" yaml:"number,omitempty"`
+	// Enabled indicates whether the pipe is active.
+	Enabled bool `json:"enabled,omitempty" yaml:"enabled,omitempty"`
+	// Bandwidth is the bandwidth limit, in units of BandwidthMetric.
+	Bandwidth string `json:"bandwidth,omitempty" yaml:"bandwidth,omitempty"`
+	// BandwidthMetric is the unit of Bandwidth (e.g., "Mbit").
+	BandwidthMetric string `json:"bandwidthMetric,omitempty" yaml:"bandwidthMetric,omitempty"`
+	// Delay is the added propagation delay in milliseconds.
+	Delay string `json:"delay,omitempty" yaml:"delay,omitempty"`
+	// Mask selects dynamic per-host pipes (e.g., "none", "src-ip", "dst-ip").
+	Mask string `json:"mask,omitempty" yaml:"mask,omitempty"`
+	// Description is a human-readable description of the pipe.
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+// TrafficShaperQueue is a traffic shaper queue: a weighted share of the
+// bandwidth of a pipe.
+type TrafficShaperQueue struct {
+	// UUID is the queue's unique identifier.
+	UUID string `json:"uuid,omitempty" yaml:"uuid,omitempty"`
+	// Number is the dummynet queue number.
+	Number string `json:"number,omitempty" yaml:"number,omitempty"`
+	// Enabled indicates whether the queue is active.
+	Enabled bool `json:"enabled,omitempty" yaml:"enabled,omitempty"`
+	// Pipe is the UUID of the pipe whose bandwidth the queue shares.
+	Pipe string `json:"pipe,omitempty" yaml:"pipe,omitempty"`
+	// Weight is the queue's share of the pipe bandwidth, 1 to 100.
+	Weight string `json:"weight,omitempty" yaml:"weight,omitempty"`
+	// Mask selects dynamic per-host queues (e.g., "none", "src-ip", "dst-ip").
+	Mask string `json:"mask,omitempty" yaml:"mask,omitempty"`
+	// Description is a human-readable description of the queue.
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+// QueueStats is a point-in-time snapshot of traffic shaper queue counters
+// taken from the <queuestats> section of a backup export. It reflects
+// activity at the time of the backup, not configuration.
+type QueueStats struct {
+	// Timestamp is when the counters were captured, as recorded in the export.
+	Timestamp string `json:"timestamp,omitempty" yaml:"timestamp,omitempty"`
+	// Queues contains the per-queue counters.
+	Queues []QueueEntry `json:"queues,omitempty" yaml:"queues,omitempty"`
+}
+
+// QueueEntry contains the counters of a single traffic shaper queue.
+type QueueEntry struct {
+	// Name is the queue name.
+	Name string `json:"name" yaml:"name"`
+	// Interface is the interface the queue is attached to.
+	Interface string `json:"interface,omitempty" yaml:"interface,omitempty"`
+	// Packets is the number of packets passed by the queue.
+	Packets int64 `json:"packets" yaml:"packets"`
+	// Bytes is the number of bytes passed by the queue.
+	Bytes int64 `json:"bytes" yaml:"bytes"`
+	// DroppedPackets is the number of packets dropped by the queue.
+	DroppedPackets int64 `json:"droppedPackets" yaml:"droppedPackets"`
+	// DroppedBytes is the number of bytes dropped by the queue.
+	DroppedBytes int64 `json:"droppedBytes" yaml:"droppedBytes"`
+	// QueueLength is the number of packets waiting in the queue.
+	QueueLength int64 `json:"queueLength" yaml:"queueLength"`
 }
 
 // CaptivePortalConfig contains captive portal configuration.
@@ -537,6 +743,9 @@ type CaptivePortalConfig struct {
 	Zones string `json:"zones,omitempty" yaml:"zones,omitempty"`
 	// Templates contains captive portal template identifiers.
 	Templates string `json:"templates,omitempty" yaml:"templates,omitempty"`
+	// AuthServers lists the authentication server names referenced by the
+	// captive portal zones, in first-seen order.
+	AuthServers []string `json:"authServers,omitempty" yaml:"authServers,omitempty"`
 }
 
 // CronConfig contains scheduled task (cron) configuration.
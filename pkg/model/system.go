@@ -1,5 +1,7 @@
 package model
 
+import "slices"
+
 // System contains system-level configuration settings.
 type System struct {
 	// Hostname is the device hostname.
@@ -26,6 +28,8 @@ type System struct {
 	SSH SSH `json:"ssh" yaml:"ssh,omitempty"`
 	// Firmware contains firmware version and update settings.
 	Firmware Firmware `json:"firmware" yaml:"firmware,omitempty"`
+	// Console contains the serial and video console settings.
+	Console Console `json:"console" yaml:"console,omitempty"`
 
 	// NextUID is the next available user ID for account creation.
 	NextUID int `json:"nextUid,omitempty" yaml:"nextUid,omitempty"`
@@ -89,6 +93,21 @@ type WebGUI struct {
 	LoginAutocomplete bool `json:"loginAutocomplete,omitempty" yaml:"loginAutocomplete,omitempty"`
 	// MaxProcesses is the maximum number of web server processes.
 	MaxProcesses string `json:"maxProcesses,omitempty" yaml:"maxProcesses,omitempty"`
+	// AuthMode is the comma-separated list of authentication server names
+	// the web GUI accepts logins from (e.g. "Local Database,corp-ldap").
+	AuthMode string `json:"authMode,omitempty" yaml:"authMode,omitempty"`
+	// Compression is the web server response compression level (OPNsense
+	// only). Empty means compression is off.
+	Compression string `json:"compression,omitempty" yaml:"compression,omitempty"`
+	// DisableHTTPRedirect turns off the port 80 listener that redirects
+	// browsers to HTTPS. When false, the GUI accepts plain HTTP connections.
+	DisableHTTPRedirect bool `json:"disableHttpRedirect,omitempty" yaml:"disableHttpRedirect,omitempty"`
+	// NoHTTPRefererCheck disables the HTTP_REFERER check that protects the
+	// GUI against cross-site request forgery.
+	NoHTTPRefererCheck bool `json:"noHttpRefererCheck,omitempty" yaml:"noHttpRefererCheck,omitempty"`
+	// SSLCiphers is the OpenSSL cipher list offered by the GUI (OPNsense
+	// only). Empty means the system default.
+	SSLCiphers string `json:"sslCiphers,omitempty" yaml:"sslCiphers,omitempty"`
 }
 
 // SSH contains SSH service configuration.
@@ -101,6 +120,52 @@ type SSH struct {
 	Group string `json:"group,omitempty" yaml:"group,omitempty"`
 }
 
+// Primary console values.
+const (
+	// PrimaryConsoleSerial selects the serial port as the primary console.
+	PrimaryConsoleSerial = "serial"
+	// PrimaryConsoleVideo selects the VGA/EFI video console.
+	PrimaryConsoleVideo = "video"
+	// PrimaryConsoleNone disables the primary console.
+	PrimaryConsoleNone = "none"
+)
+
+// serialSpeeds are the supported serial console baud rates.
+var serialSpeeds = []int{9600, 19200, 38400, 57600, 115200}
+
+// ValidSerialSpeed reports whether speed is a supported serial console baud
+// rate: 9600, 19200, 38400, 57600, or 115200.
+func ValidSerialSpeed(speed int) bool {
+	return slices.Contains(serialSpeeds, speed)
+}
+
+// ValidPrimaryConsole reports whether console is one of the primary console
+// values: "serial", "video", or "none".
+func ValidPrimaryConsole(console string) bool {
+	switch console {
+	case PrimaryConsoleSerial, PrimaryConsoleVideo, PrimaryConsoleNone:
+		return true
+	default:
+		return false
+	}
+}
+
+// Console contains the serial and video console settings.
+type Console struct {
+	// SerialSpeed is the serial console baud rate (see ValidSerialSpeed).
+	// Zero means the platform default.
+	SerialSpeed int `json:"serialSpeed,omitempty" yaml:"serialSpeed,omitempty"`
+	// PrimaryConsole is the console the boot loader and kernel use:
+	// "serial", "video", or "none". Empty means the platform default.
+	PrimaryConsole string `json:"primaryConsole,omitempty" yaml:"primaryConsole,omitempty"`
+}
+
+// IsSerialConsoleActive reports whether the serial port is the primary
+// console.
+func (c Console) IsSerialConsoleActive() bool {
+	return c.PrimaryConsole == PrimaryConsoleSerial
+}
+
 // TrustConfig contains system-wide TLS and certificate trust settings.
 type TrustConfig struct {
 	// StoreIntermediateCerts enables caching of intermediate CA certificates.
@@ -0,0 +1,51 @@
+package model_test
+
+import (
+	"testing"
+
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidSerialSpeed(t *testing.T) {
+	t.Parallel()
+
+	for _, speed := range []int{9600, 19200, 38400, 57600, 115200} {
+		assert.True(t, common.ValidSerialSpeed(speed), "speed %d", speed)
+	}
+	for _, speed := range []int{0, -1, 14400, 230400} {
+		assert.False(t, common.ValidSerialSpeed(speed), "speed %d", speed)
+	}
+}
+
+func TestValidPrimaryConsole(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		val  string
+		want bool
+	}{
+		{"serial is valid", common.PrimaryConsoleSerial, true},
+		{"video is valid", common.PrimaryConsoleVideo, true},
+		{"none is valid", common.PrimaryConsoleNone, true},
+		{"vga is invalid", "vga", false},
+		{"uppercase is invalid", "Serial", false},
+		{"empty is invalid", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, common.ValidPrimaryConsole(tt.val))
+		})
+	}
+}
+
+func TestConsole_IsSerialConsoleActive(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, common.Console{PrimaryConsole: common.PrimaryConsoleSerial}.IsSerialConsoleActive())
+	assert.False(t, common.Console{PrimaryConsole: common.PrimaryConsoleVideo, SerialSpeed: 115200}.IsSerialConsoleActive())
+	assert.False(t, common.Console{}.IsSerialConsoleActive())
+}
@@ -1,5 +1,12 @@
 package model
 
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
 // User represents a system user account.
 type User struct {
 	// Name is the login username.
@@ -16,6 +23,73 @@ type User struct {
 	UID string `json:"uid,omitempty" yaml:"uid,omitempty"`
 	// APIKeys contains API key credentials associated with the user.
 	APIKeys []APIKey `json:"apiKeys,omitempty" yaml:"apiKeys,omitempty"`
+	// CertRef is the refid of the user certificate used for certificate
+	// authentication.
+	CertRef string `json:"certRef,omitempty" yaml:"certRef,omitempty"`
+	// AuthorizedKeys is the base64-encoded SSH authorized_keys content.
+	AuthorizedKeys string `json:"authorizedKeys,omitempty" yaml:"authorizedKeys,omitempty"`
+	// Expires is the account expiry date in MM/DD/YYYY format; empty when
+	// the account never expires.
+	Expires string `json:"expires,omitempty" yaml:"expires,omitempty"`
+	// ExpiryDate is the start of the expiry day, midnight UTC, in Unix
+	// seconds. It is meaningful only when HasExpiryDate is set: 01/01/1970
+	// is stored as zero.
+	ExpiryDate int64 `json:"expiryDate,omitempty" yaml:"expiryDate,omitempty"`
+	// HasExpiryDate reports whether Expires was read as a valid date into
+	// ExpiryDate. It is false when the account never expires or Expires is
+	// not a valid MM/DD/YYYY date.
+	HasExpiryDate bool `json:"hasExpiryDate,omitempty" yaml:"hasExpiryDate,omitempty"`
+}
+
+// HasSSHKey reports whether the user has SSH authorized keys configured.
+func (u User) HasSSHKey() bool {
+	return strings.TrimSpace(u.AuthorizedKeys) != ""
+}
+
+// HasCert reports whether the user has a certificate assigned.
+func (u User) HasCert() bool {
+	return strings.TrimSpace(u.CertRef) != ""
+}
+
+// ExpiresTime returns the expiry day, midnight UTC. It returns the zero time
+// and a nil error when the account never expires, and an error when Expires
+// is set but is not a valid MM/DD/YYYY date.
+func (u User) ExpiresTime() (time.Time, error) {
+	if u.HasExpiryDate {
+		return time.Unix(u.ExpiryDate, 0).UTC(), nil
+	}
+	if strings.TrimSpace(u.Expires) == "" {
+		return time.Time{}, nil
+	}
+
+	return time.Time{}, fmt.Errorf("user expiry date %q is not in MM/DD/YYYY format", u.Expires)
+}
+
+// ExpiresAt returns when the account stops accepting logins. The firewall
+// accepts logins through the whole expiry day, so this is the end of that
+// day. The second return value is false when the account never expires or
+// the expiry date is unknown.
+func (u User) ExpiresAt() (time.Time, bool) {
+	if !u.HasExpiryDate {
+		return time.Time{}, false
+	}
+
+	return time.Unix(u.ExpiryDate, 0).UTC().AddDate(0, 0, 1), true
+}
+
+// IsExpiredAt reports whether the account had expired at now. An account
+// without a known expiry date never expires.
+func (u User) IsExpiredAt(now time.Time) bool {
+	expires, ok := u.ExpiresAt()
+
+	return ok && !now.Before(expires)
+}
+
+// IsExpired reports whether the account has expired, judged at the current
+// time. Use IsExpiredAt to judge it at a fixed time, such as a report's
+// generation time.
+func (u User) IsExpired() bool {
+	return u.IsExpiredAt(time.Now())
 }
 
 // Group represents a system group.
@@ -30,8 +104,14 @@ type Group struct {
 	GID string `json:"gid,omitempty" yaml:"gid,omitempty"`
 	// Member is a comma-separated list of user UIDs belonging to this group.
 	Member string `json:"member,omitempty" yaml:"member,omitempty"`
-	// Privileges is a comma-separated list of privileges assigned to the group.
-	Privileges string `json:"privileges,omitempty" yaml:"privileges,omitempty"`
+	// Privileges lists the privileges assigned to the group (e.g., "page-all").
+	// Model versions before 2.0.0 exported this as a comma-separated string.
+	Privileges []string `json:"privileges,omitempty" yaml:"privileges,omitempty"`
+}
+
+// HasPrivilege reports whether the group is granted priv, e.g. "page-all".
+func (g Group) HasPrivilege(priv string) bool {
+	return slices.Contains(g.Privileges, priv)
 }
 
 // APIKey represents an API key credential.
@@ -52,3 +132,57 @@ type APIKey struct {
 	// Description is a human-readable description of the API key.
 	Description string `json:"description,omitempty" yaml:"description,omitempty"`
 }
+
+// AuthServerType identifies the protocol of an external authentication server.
+type AuthServerType string
+
+const (
+	// AuthServerLDAP is an LDAP directory server.
+	AuthServerLDAP AuthServerType = "ldap"
+	// AuthServerRADIUS is a RADIUS server.
+	AuthServerRADIUS AuthServerType = "radius"
+)
+
+// LDAPTransport identifies how an LDAP server connection is protected.
+type LDAPTransport string
+
+const (
+	// LDAPTransportTCP is plain, unencrypted LDAP.
+	LDAPTransportTCP LDAPTransport = "tcp"
+	// LDAPTransportStartTLS upgrades a plain LDAP connection with StartTLS.
+	LDAPTransportStartTLS LDAPTransport = "starttls"
+	// LDAPTransportSSL is LDAP over TLS (LDAPS).
+	LDAPTransportSSL LDAPTransport = "ssl"
+)
+
+// AuthServer represents an external LDAP or RADIUS authentication backend.
+type AuthServer struct {
+	// RefID is the unique reference identifier of the server.
+	RefID string `json:"refId,omitempty" yaml:"refId,omitempty"`
+	// Name is the server name the web GUI, VPN servers, and captive portal
+	// zones reference it by.
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+	// Type is the server protocol. Unrecognized types are kept verbatim.
+	Type AuthServerType `json:"type,omitempty" yaml:"type,omitempty"`
+	// Host is the server hostname or IP address.
+	Host string `json:"host,omitempty" yaml:"host,omitempty"`
+	// Port is the LDAP port, or the RADIUS authentication port.
+	Port string `json:"port,omitempty" yaml:"port,omitempty"`
+	// Transport is how LDAP connections are protected. Empty for RADIUS.
+	Transport LDAPTransport `json:"transport,omitempty" yaml:"transport,omitempty"`
+	// Scope is the LDAP search scope ("one" or "subtree").
+	Scope string `json:"scope,omitempty" yaml:"scope,omitempty"`
+	// BaseDN is the LDAP search base.
+	BaseDN string `json:"baseDn,omitempty" yaml:"baseDn,omitempty"`
+	// AuthContainers is the semicolon-separated list of LDAP containers
+	// users are authenticated in.
+	AuthContainers string `json:"authContainers,omitempty" yaml:"authContainers,omitempty"`
+	// BindDN is the DN the firewall binds as to search the directory.
+	BindDN string `json:"bindDn,omitempty" yaml:"bindDn,omitempty"`
+	// BindPassword is "[REDACTED]" when a bind password is configured. The
+	// password itself is never exported.
+	BindPassword string `json:"bindPassword,omitempty" yaml:"bindPassword,omitempty"`
+	// SecretLength is the length of the RADIUS shared secret. The secret
+	// itself is never exported.
+	SecretLength int `json:"secretLength,omitempty" yaml:"secretLength,omitempty"`
+}
@@ -0,0 +1,83 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// expiringUser returns a user whose expiry date parsed as day.
+func expiringUser(expires string, day time.Time) common.User {
+	return common.User{Expires: expires, ExpiryDate: day.Unix(), HasExpiryDate: true}
+}
+
+func TestUser_ExpiresTime(t *testing.T) {
+	t.Parallel()
+
+	expiryDay := time.Date(2025, time.March, 15, 0, 0, 0, 0, time.UTC)
+
+	got, err := expiringUser("03/15/2025", expiryDay).ExpiresTime()
+	require.NoError(t, err)
+	assert.Equal(t, expiryDay, got)
+
+	got, err = common.User{}.ExpiresTime()
+	require.NoError(t, err)
+	assert.True(t, got.IsZero(), "an account without an expiry date never expires")
+
+	_, err = common.User{Expires: "someday"}.ExpiresTime()
+	assert.Error(t, err)
+}
+
+func TestUser_ExpiresAt(t *testing.T) {
+	t.Parallel()
+
+	expiryDay := time.Date(2025, time.March, 15, 0, 0, 0, 0, time.UTC)
+
+	got, ok := expiringUser("03/15/2025", expiryDay).ExpiresAt()
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2025, time.March, 16, 0, 0, 0, 0, time.UTC), got,
+		"logins are accepted through the whole expiry day")
+
+	_, ok = common.User{}.ExpiresAt()
+	assert.False(t, ok, "an account without an expiry date never expires")
+}
+
+func TestUser_IsExpiredAt(t *testing.T) {
+	t.Parallel()
+
+	user := expiringUser("03/15/2025", time.Date(2025, time.March, 15, 0, 0, 0, 0, time.UTC))
+	epoch := expiringUser("01/01/1970", time.Unix(0, 0).UTC())
+
+	tests := []struct {
+		name string
+		user common.User
+		now  time.Time
+		want bool
+	}{
+		{"day before expiry", user, time.Date(2025, time.March, 14, 12, 0, 0, 0, time.UTC), false},
+		{"start of expiry day", user, time.Date(2025, time.March, 15, 0, 0, 0, 0, time.UTC), false},
+		{"last second of expiry day", user, time.Date(2025, time.March, 15, 23, 59, 59, 0, time.UTC), false},
+		{"day after expiry", user, time.Date(2025, time.March, 16, 0, 0, 0, 0, time.UTC), true},
+		{"expiry date stored as Unix zero", epoch, time.Date(2025, time.March, 16, 0, 0, 0, 0, time.UTC), true},
+		{"no expiry date", common.User{}, time.Date(2099, time.January, 1, 0, 0, 0, 0, time.UTC), false},
+		{"unparsed expiry date", common.User{Expires: "someday"}, time.Date(2099, time.January, 1, 0, 0, 0, 0, time.UTC), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.user.IsExpiredAt(tt.now))
+		})
+	}
+}
+
+func TestUser_IsExpired(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, expiringUser("01/01/2020", time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)).IsExpired())
+	assert.False(t, expiringUser("12/31/2099", time.Date(2099, time.December, 31, 0, 0, 0, 0, time.UTC)).IsExpired())
+	assert.False(t, common.User{}.IsExpired())
+}
@@ -0,0 +1,156 @@
+package model
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ModelVersion is the semantic version of the CommonDevice export model. It is
+// written to the _meta object of every JSON/YAML export and to the metadata
+// comment of markdown reports so consumers can tell which model shape produced
+// a document.
+//
+// The constant lives in pkg/model rather than pkg/schema because it versions
+// the CommonDevice export shape, not the XML input schemas: a schema field
+// only affects exports once a converter maps it into this package.
+//
+// Bump rules:
+//   - MAJOR: a field is removed or renamed, or its type or meaning changes.
+//   - MINOR: a field or collection is added. Older consumers simply see an
+//     unknown key; newer consumers reading an older export see it missing.
+//   - PATCH: documentation or behavior fixes that do not change the shape.
+//
+// The pkg/model API snapshot test fails when the exported surface changes
+// without a bump; see docs/data-model/index.md for the version history and
+// migration notes.
+const ModelVersion = "3.2.0"
+
+// ExportMeta describes the provenance of an exported document. It is emitted
+// as the _meta object at the top of JSON/YAML exports.
+type ExportMeta struct {
+	// ModelVersion is the CommonDevice model version that produced the export.
+	ModelVersion string `json:"modelVersion" yaml:"modelVersion"`
+	// ToolVersion is the opnDossier version that produced the export.
+	ToolVersion string `json:"toolVersion,omitempty" yaml:"toolVersion,omitempty"`
+	// DeviceType is the platform of the source configuration.
+	DeviceType DeviceType `json:"deviceType,omitempty" yaml:"deviceType,omitempty"`
+	// ConfigVersion is the <version> element of the source configuration.
+	ConfigVersion string `json:"configVersion,omitempty" yaml:"configVersion,omitempty"`
+	// FirmwareVersion is the firmware version recorded in the source configuration.
+	FirmwareVersion string `json:"firmwareVersion,omitempty" yaml:"firmwareVersion,omitempty"`
+	// SourceEncoding is the character encoding of the source configuration.
+	SourceEncoding string `json:"sourceEncoding,omitempty" yaml:"sourceEncoding,omitempty"`
+	// Compatibility warns that the source configuration format is newer than
+	// the parser was tested against. Empty for tested formats.
+	Compatibility string `json:"compatibility,omitempty" yaml:"compatibility,omitempty"`
+	// RuleFilter records the rule filter the export was narrowed by; nil
+	// when the export lists every rule.
+	RuleFilter *ExportRuleFilter `json:"ruleFilter,omitempty" yaml:"ruleFilter,omitempty"`
+	// Source is the original configuration file, present only when the export
+	// was produced with source embedding enabled.
+	Source *EmbeddedSource `json:"source,omitempty" yaml:"source,omitempty"`
+}
+
+// ExportRuleFilter describes a rule filter applied to an export. Criteria
+// that were not set are empty. Shown and Total count the firewall, outbound
+// NAT, and inbound NAT rules kept by the filter and present before it.
+type ExportRuleFilter struct {
+	// Interface is the interface name rules were filtered by.
+	Interface string `json:"interface,omitempty" yaml:"interface,omitempty"`
+	// Action is the firewall rule action rules were filtered by.
+	Action string `json:"action,omitempty" yaml:"action,omitempty"`
+	// Search is the text rules were filtered by.
+	Search string `json:"search,omitempty" yaml:"search,omitempty"`
+	// Shown is the number of rules in the export.
+	Shown int `json:"shown" yaml:"shown"`
+	// Total is the number of rules in the source configuration.
+	Total int `json:"total" yaml:"total"`
+}
+
+// EmbeddedSource is an exact copy of the source configuration file carried
+// inside an export for archival, so a single artifact holds both the
+// normalized model and the bytes it was produced from.
+type EmbeddedSource struct {
+	// Filename is the base name of the source file.
+	Filename string `json:"filename" yaml:"filename"`
+	// Size is the length of the original file in bytes.
+	Size int64 `json:"size" yaml:"size"`
+	// SHA256 is the hex-encoded SHA-256 digest of the original file.
+	SHA256 string `json:"sha256" yaml:"sha256"`
+	// Compression names the algorithm applied before encoding; always "zlib".
+	Compression string `json:"compression" yaml:"compression"`
+	// Data is the compressed file content, base64-encoded (standard alphabet).
+	Data string `json:"data" yaml:"data"`
+}
+
+// NewExportMeta returns the ExportMeta for device as produced by toolVersion.
+// A nil device yields metadata carrying only the model and tool versions.
+func NewExportMeta(device *CommonDevice, toolVersion string) ExportMeta {
+	meta := ExportMeta{
+		ModelVersion: ModelVersion,
+		ToolVersion:  toolVersion,
+	}
+
+	if device != nil {
+		meta.DeviceType = device.DeviceType
+		meta.ConfigVersion = device.Version
+		meta.FirmwareVersion = device.System.Firmware.Version
+		meta.SourceEncoding = device.SourceEncoding
+		meta.Compatibility = device.Compatibility
+	}
+
+	return meta
+}
+
+// CompatibleWith reports whether an export declaring exportVersion as its
+// model version can be decoded into this package's types without losing
+// meaning. Versions are compatible when their MAJOR components match; a
+// differing MINOR only adds or omits keys. A leading "v" and any pre-release
+// or build suffix are accepted. Malformed versions are never compatible.
+func CompatibleWith(exportVersion string) bool {
+	exportMajor, ok := semverMajor(exportVersion)
+	if !ok {
+		return false
+	}
+
+	currentMajor, ok := semverMajor(ModelVersion)
+	if !ok {
+		return false
+	}
+
+	return exportMajor == currentMajor
+}
+
+// semverMajor parses a MAJOR.MINOR.PATCH version and returns its MAJOR
+// component. It reports false when v is not a well-formed semantic version.
+func semverMajor(v string) (int, bool) {
+	v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+
+	// Drop build metadata, then the pre-release suffix.
+	if i := strings.IndexByte(v, '+'); i >= 0 {
+		v = v[:i]
+	}
+
+	if i := strings.IndexByte(v, '-'); i >= 0 {
+		v = v[:i]
+	}
+
+	parts := strings.Split(v, ".")
+	if len(parts) != semverParts {
+		return 0, false
+	}
+
+	for _, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 || (len(p) > 1 && p[0] == '0') {
+			return 0, false
+		}
+	}
+
+	major, _ := strconv.Atoi(parts[0])
+
+	return major, true
+}
+
+// semverParts is the number of dot-separated components in a semantic version.
+const semverParts = 3
@@ -0,0 +1,69 @@
+package model_test
+
+import (
+	"testing"
+
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompatibleWith(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		exportVersion string
+		want          bool
+	}{
+		{"same version", common.ModelVersion, true},
+		{"older minor", "3.0.0", true},
+		{"newer minor", "3.7.2", true},
+		{"newer patch with v prefix", "v3.0.9", true},
+		{"pre-release and build metadata", "3.2.0-rc.1+build.5", true},
+		{"older major", "2.24.0", false},
+		{"newer major", "4.0.0", false},
+		{"missing patch", "1.0", false},
+		{"leading zero", "01.0.0", false},
+		{"not a number", "one.two.three", false},
+		{"empty", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, common.CompatibleWith(tt.exportVersion))
+		})
+	}
+}
+
+func TestNewExportMeta(t *testing.T) {
+	t.Parallel()
+
+	device := &common.CommonDevice{
+		DeviceType:     common.DeviceTypeOPNsense,
+		Version:        "24.1",
+		SourceEncoding: "ISO-8859-1",
+		System: common.System{
+			Firmware: common.Firmware{Version: "24.1.3"},
+		},
+	}
+
+	assert.Equal(t, common.ExportMeta{
+		ModelVersion:    common.ModelVersion,
+		ToolVersion:     "1.4.0",
+		DeviceType:      common.DeviceTypeOPNsense,
+		ConfigVersion:   "24.1",
+		FirmwareVersion: "24.1.3",
+		SourceEncoding:  "ISO-8859-1",
+	}, common.NewExportMeta(device, "1.4.0"))
+
+	assert.Equal(t, common.ExportMeta{
+		ModelVersion: common.ModelVersion,
+		ToolVersion:  "dev",
+	}, common.NewExportMeta(nil, "dev"))
+
+	device.Version = "25.1"
+	device.Compatibility = "config format 25.1 newer than tested 24.7 — some sections may be unreported"
+	assert.Equal(t, device.Compatibility, common.NewExportMeta(device, "dev").Compatibility)
+}
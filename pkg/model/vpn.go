@@ -8,6 +8,45 @@ type VPN struct {
 	WireGuard WireGuardConfig `json:"wireGuard" yaml:"wireGuard,omitempty"`
 	// IPsec contains IPsec VPN configuration.
 	IPsec IPsecConfig `json:"ipsec" yaml:"ipsec,omitempty"`
+	// PPTP is the legacy PPTP remote access server; nil when the configuration
+	// has no <pptpd> section.
+	PPTP *LegacyRemoteAccessVPN `json:"pptp,omitempty" yaml:"pptp,omitempty"`
+	// L2TP is the legacy L2TP remote access server; nil when the configuration
+	// has no <l2tp> section.
+	L2TP *LegacyRemoteAccessVPN `json:"l2tp,omitempty" yaml:"l2tp,omitempty"`
+	// ZeroTier is the ZeroTier overlay VPN plugin configuration; nil when the
+	// configuration has no <zerotier> section.
+	ZeroTier *ZeroTierConfig `json:"zeroTier,omitempty" yaml:"zeroTier,omitempty"`
+}
+
+// LegacyRemoteAccessVPN represents a legacy PPTP or L2TP remote access server
+// carried forward from an older configuration. Its presence is reported even
+// when disabled so stale sections can be cleaned up.
+type LegacyRemoteAccessVPN struct {
+	// Enabled indicates whether the server is active.
+	Enabled bool `json:"enabled" yaml:"enabled"`
+	// Mode is the configured server mode (e.g., "server", "redir", "off").
+	Mode string `json:"mode,omitempty" yaml:"mode,omitempty"`
+	// Interface is the interface the server listens on, when configured.
+	Interface string `json:"interface,omitempty" yaml:"interface,omitempty"`
+	// LocalAddress is the server-side tunnel address.
+	LocalAddress string `json:"localAddress,omitempty" yaml:"localAddress,omitempty"`
+	// RemoteAddressStart is the first address handed out to clients.
+	RemoteAddressStart string `json:"remoteAddressStart,omitempty" yaml:"remoteAddressStart,omitempty"`
+	// RemoteAddressCount is the number of client addresses in the remote range.
+	RemoteAddressCount int `json:"remoteAddressCount,omitempty" yaml:"remoteAddressCount,omitempty"`
+	// Authentication is the authentication protocol (e.g., "chap", "pap"), when configured.
+	Authentication string `json:"authentication,omitempty" yaml:"authentication,omitempty"`
+	// Users contains the locally defined VPN users.
+	Users []LegacyVPNUser `json:"users,omitempty" yaml:"users,omitempty"`
+}
+
+// LegacyVPNUser represents a local user of a legacy PPTP or L2TP server.
+type LegacyVPNUser struct {
+	// Name is the login name.
+	Name string `json:"name" yaml:"name"`
+	// IPAddress is the fixed address assigned to the user, if any.
+	IPAddress string `json:"ipAddress,omitempty" yaml:"ipAddress,omitempty"`
 }
 
 // OpenVPNConfig contains OpenVPN server and client configurations.
@@ -76,6 +115,15 @@ type OpenVPNServer struct {
 	Topology string `json:"topology,omitempty" yaml:"topology,omitempty"`
 	// StrictUserCN enforces matching of certificate CN to username.
 	StrictUserCN bool `json:"strictUserCn,omitempty" yaml:"strictUserCn,omitempty"`
+	// AuthMode is the comma-separated list of authentication servers used for
+	// user authentication (e.g., "Local Database,corp-ldap").
+	AuthMode string `json:"authMode,omitempty" yaml:"authMode,omitempty"`
+	// DataCiphers contains the negotiable data channel ciphers, in preference order.
+	DataCiphers []string `json:"dataCiphers,omitempty" yaml:"dataCiphers,omitempty"`
+	// DataCiphersFallback is the cipher used with peers that cannot negotiate one.
+	DataCiphersFallback string `json:"dataCiphersFallback,omitempty" yaml:"dataCiphersFallback,omitempty"`
+	// Digest is the HMAC digest algorithm for packet authentication (e.g., "SHA256").
+	Digest string `json:"digest,omitempty" yaml:"digest,omitempty"`
 	// GWRedir redirects all client traffic through the VPN gateway.
 	GWRedir bool `json:"gwRedir,omitempty" yaml:"gwRedir,omitempty"`
 	// DynamicIP allows clients with dynamic IP addresses.
@@ -114,6 +162,12 @@ type OpenVPNClient struct {
 	CertRef string `json:"certRef,omitempty" yaml:"certRef,omitempty"`
 	// CARef is the reference ID of the certificate authority.
 	CARef string `json:"caRef,omitempty" yaml:"caRef,omitempty"`
+	// DataCiphers contains the negotiable data channel ciphers, in preference order.
+	DataCiphers []string `json:"dataCiphers,omitempty" yaml:"dataCiphers,omitempty"`
+	// DataCiphersFallback is the cipher used with servers that cannot negotiate one.
+	DataCiphersFallback string `json:"dataCiphersFallback,omitempty" yaml:"dataCiphersFallback,omitempty"`
+	// Digest is the HMAC digest algorithm for packet authentication (e.g., "SHA256").
+	Digest string `json:"digest,omitempty" yaml:"digest,omitempty"`
 	// Compression is the compression algorithm.
 	Compression string `json:"compression,omitempty" yaml:"compression,omitempty"`
 	// VerbosityLevel is the logging verbosity level.
@@ -174,6 +228,30 @@ type WireGuardClient struct {
 	Keepalive string `json:"keepalive,omitempty" yaml:"keepalive,omitempty"`
 }
 
+// ZeroTierConfig contains the ZeroTier overlay VPN plugin configuration.
+type ZeroTierConfig struct {
+	// Enabled indicates whether the ZeroTier service is running.
+	Enabled bool `json:"enabled" yaml:"enabled"`
+	// Port is the UDP port the node listens on; empty means the default, 9993.
+	Port string `json:"port,omitempty" yaml:"port,omitempty"`
+	// AllowDefaultRoute indicates network controllers may push a default route.
+	AllowDefaultRoute bool `json:"allowDefaultRoute,omitempty" yaml:"allowDefaultRoute,omitempty"`
+	// Networks contains the ZeroTier networks the node is configured to join.
+	Networks []ZeroTierNetwork `json:"networks,omitempty" yaml:"networks,omitempty"`
+}
+
+// ZeroTierNetwork represents a ZeroTier network the node joins.
+type ZeroTierNetwork struct {
+	// UUID is the unique identifier of the network entry.
+	UUID string `json:"uuid,omitempty" yaml:"uuid,omitempty"`
+	// Enabled indicates whether the node joins this network.
+	Enabled bool `json:"enabled" yaml:"enabled"`
+	// NetworkID is the 16-digit hexadecimal ZeroTier network ID.
+	NetworkID string `json:"networkId,omitempty" yaml:"networkId,omitempty"`
+	// Description is a human-readable description of the network.
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
 // IPsecConfig contains IPsec VPN configuration.
 type IPsecConfig struct {
 	// Enabled indicates whether the IPsec subsystem is active.
@@ -196,12 +274,100 @@ type IPsecConfig struct {
 	Phase2Tunnels []IPsecPhase2Tunnel `json:"phase2Tunnels,omitempty" yaml:"phase2Tunnels,omitempty"`
 	// MobileClient contains mobile/road-warrior IPsec client pool configuration.
 	MobileClient IPsecMobileClient `json:"mobileClient" yaml:"mobileClient,omitempty"`
+	// Connections is the unified list of IPsec connections from both the legacy
+	// Phase 1/Phase 2 entries and the connection-based (swanctl) configuration,
+	// each tagged with its Origin.
+	Connections []IPsecConnection `json:"connections,omitempty" yaml:"connections,omitempty"`
+	// Pools contains the virtual address pools of connection-based IPsec.
+	Pools []IPsecPool `json:"pools,omitempty" yaml:"pools,omitempty"`
 }
 
 // HasData reports whether the IPsecConfig contains meaningful data.
 func (c IPsecConfig) HasData() bool {
 	return c.Enabled || len(c.Phase1Tunnels) > 0 || len(c.Phase2Tunnels) > 0 || c.MobileClient.Enabled ||
-		c.PreferredOldSA || c.DisableVPNRules || c.PassthroughNetworks != "" || c.Charon.Threads != ""
+		c.PreferredOldSA || c.DisableVPNRules || c.PassthroughNetworks != "" || c.Charon.Threads != "" ||
+		len(c.Connections) > 0
+}
+
+// IPsecOrigin identifies the configuration flavor an IPsec connection was read from.
+type IPsecOrigin string
+
+const (
+	// IPsecOriginLegacy marks a tunnel read from the legacy <ipsec> Phase 1/Phase 2 entries.
+	IPsecOriginLegacy IPsecOrigin = "legacy"
+	// IPsecOriginSwanctl marks a connection read from the OPNsense connection-based
+	// <OPNsense><Swanctl> configuration.
+	IPsecOriginSwanctl IPsecOrigin = "swanctl"
+)
+
+// IPsecConnection is an IKE connection in the unified IPsec connection list.
+// Legacy tunnels map a Phase 1 entry to the connection and its Phase 2 entries
+// to Children; swanctl connections map directly.
+type IPsecConnection struct {
+	// Origin is the configuration flavor the connection was read from.
+	Origin IPsecOrigin `json:"origin" yaml:"origin"`
+	// ID is the legacy IKE ID or the swanctl connection UUID.
+	ID string `json:"id,omitempty" yaml:"id,omitempty"`
+	// Description is a human-readable description of the connection.
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	// Disabled indicates whether the connection is administratively disabled.
+	Disabled bool `json:"disabled,omitempty" yaml:"disabled,omitempty"`
+	// Version is the IKE version ("ikev1", "ikev2", or "auto").
+	Version string `json:"version,omitempty" yaml:"version,omitempty"`
+	// Aggressive indicates IKEv1 aggressive mode.
+	Aggressive bool `json:"aggressive,omitempty" yaml:"aggressive,omitempty"`
+	// LocalAddresses lists the local addresses or interface the connection binds to.
+	LocalAddresses []string `json:"localAddresses,omitempty" yaml:"localAddresses,omitempty"`
+	// RemoteAddresses lists the remote peer addresses or hostnames.
+	RemoteAddresses []string `json:"remoteAddresses,omitempty" yaml:"remoteAddresses,omitempty"`
+	// LocalIDs lists the local IKE identities, one per authentication round.
+	LocalIDs []string `json:"localIds,omitempty" yaml:"localIds,omitempty"`
+	// RemoteIDs lists the remote IKE identities, one per authentication round.
+	RemoteIDs []string `json:"remoteIds,omitempty" yaml:"remoteIds,omitempty"`
+	// LocalAuth lists the local authentication methods, one per authentication round.
+	LocalAuth []string `json:"localAuth,omitempty" yaml:"localAuth,omitempty"`
+	// RemoteAuth lists the remote authentication methods, one per authentication round.
+	RemoteAuth []string `json:"remoteAuth,omitempty" yaml:"remoteAuth,omitempty"`
+	// Proposals lists the IKE proposals (e.g., "aes256-sha256-modp2048"). For
+	// legacy tunnels these are the Phase 1 encryption algorithms.
+	Proposals []string `json:"proposals,omitempty" yaml:"proposals,omitempty"`
+	// DPDDelay is the dead peer detection interval in seconds.
+	DPDDelay string `json:"dpdDelay,omitempty" yaml:"dpdDelay,omitempty"`
+	// Pools lists the names of the virtual address pools assigned to remote peers.
+	Pools []string `json:"pools,omitempty" yaml:"pools,omitempty"`
+	// Children contains the child SAs negotiated under this connection.
+	Children []IPsecChildSA `json:"children,omitempty" yaml:"children,omitempty"`
+}
+
+// IPsecChildSA is a child SA (legacy Phase 2 entry) of an [IPsecConnection].
+type IPsecChildSA struct {
+	// ID is the legacy Phase 2 unique ID or the swanctl child UUID.
+	ID string `json:"id,omitempty" yaml:"id,omitempty"`
+	// Description is a human-readable description of the child SA.
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	// Disabled indicates whether the child SA is administratively disabled.
+	Disabled bool `json:"disabled,omitempty" yaml:"disabled,omitempty"`
+	// Mode is the IPsec mode (e.g., "tunnel", "transport").
+	Mode string `json:"mode,omitempty" yaml:"mode,omitempty"`
+	// LocalTrafficSelectors lists the local networks protected by the child SA.
+	LocalTrafficSelectors []string `json:"localTrafficSelectors,omitempty" yaml:"localTrafficSelectors,omitempty"`
+	// RemoteTrafficSelectors lists the remote networks protected by the child SA.
+	RemoteTrafficSelectors []string `json:"remoteTrafficSelectors,omitempty" yaml:"remoteTrafficSelectors,omitempty"`
+	// Proposals lists the ESP proposals (e.g., "aes256gcm16-modp2048"). For
+	// legacy tunnels these are the Phase 2 encryption and hash algorithms.
+	Proposals []string `json:"proposals,omitempty" yaml:"proposals,omitempty"`
+	// RekeyTime is the child SA rekey time (legacy: lifetime) in seconds.
+	RekeyTime string `json:"rekeyTime,omitempty" yaml:"rekeyTime,omitempty"`
+}
+
+// IPsecPool is a virtual IP address pool handed out to connection-based IPsec peers.
+type IPsecPool struct {
+	// Name is the pool name.
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+	// Addresses is the pool address range in CIDR notation.
+	Addresses string `json:"addresses,omitempty" yaml:"addresses,omitempty"`
+	// DNSServers lists the DNS servers pushed to peers using the pool.
+	DNSServers []string `json:"dnsServers,omitempty" yaml:"dnsServers,omitempty"`
 }
 
 // IPsecPhase1Tunnel represents a platform-agnostic IKE Phase 1 tunnel configuration.
@@ -1,6 +1,9 @@
 package model
 
-import "slices"
+import (
+	"slices"
+	"strconv"
+)
 
 // Severity represents the severity level of a conversion warning. Severity is
 // a triage signal, not a compliance verdict: consumers use it to decide
@@ -77,7 +80,10 @@ func IsValidSeverity(s Severity) bool {
 // platform-agnostic [CommonDevice] model. Warnings never prevent conversion
 // from completing; they signal data-quality issues (unrecognized enum values,
 // truncated collections, orphan references, missing optional fields) that
-// consumers may want to surface in reports, logs, or UI.
+// consumers may want to surface in reports, logs, or UI. Malformed values are
+// skipped or replaced by a default rather than failing the conversion, and
+// the warning's Action records which, so every problem in a document is
+// reported in a single run.
 //
 // Consumers receive warnings as a slice alongside the converted device:
 //
@@ -93,19 +99,43 @@ func IsValidSeverity(s Severity) bool {
 // them and is stable across runs for a given input, but is otherwise
 // unspecified. Consumers should not rely on warnings being grouped by field
 // or severity.
+//
+// The JSON and YAML keys are the ones the encoders derived from the field
+// names before the struct carried tags (Field, Value, ... in JSON; field,
+// value, ... in YAML), so existing consumers keep decoding the same shape.
 type ConversionWarning struct {
 	// Field is the dot-path of the problematic field (e.g., "FirewallRules[0].Type").
-	Field string
+	Field string `json:"Field" yaml:"field"`
 	// Value provides context to identify the affected config element (e.g., rule UUID,
 	// gateway name, or certificate description). When the warning is about a missing or
 	// empty field, this contains a sibling identifier rather than the empty field itself.
 	// A few warnings instead store the raw input that triggered them (for example, the
 	// multi-pool Kea warning stores the full newline-separated pool string), so
 	// consumers should not assume Value is always a short identifier.
-	Value string
+	Value string `json:"Value" yaml:"value"`
 	// Message is a human-readable description of the issue.
-	Message string
+	Message string `json:"Message" yaml:"message"`
+	// Action describes what the converter did in place of the unusable value
+	// (e.g., "lease skipped" or "defaulted to 0"). Empty when the value was
+	// carried over as-is.
+	Action string `json:"Action,omitempty" yaml:"action,omitempty"`
 	// Severity indicates the importance of the warning. See the Severity
 	// constants for guidance on when to use each level.
-	Severity Severity
+	Severity Severity `json:"Severity" yaml:"severity"`
+}
+
+// String formats the warning as a single line, e.g.
+// "DHCP[lan].StaticLeases[4].MAC: \"xx:yy\" static lease MAC address is invalid — lease skipped".
+func (w ConversionWarning) String() string {
+	s := w.Field + ": "
+	if w.Value != "" {
+		s += strconv.Quote(w.Value) + " "
+	}
+
+	s += w.Message
+	if w.Action != "" {
+		s += " — " + w.Action
+	}
+
+	return s
 }
@@ -1,11 +1,13 @@
 package model_test
 
 import (
+	"encoding/json"
 	"fmt"
 	"slices"
 	"testing"
 
 	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"gopkg.in/yaml.v3"
 )
 
 func TestSeverity_String(t *testing.T) {
@@ -150,3 +152,75 @@ func ExampleConversionWarning() {
 	// [low] FirewallRules[0].Type: unrecognized firewall rule type
 	// [info] kea.dhcp4.subnets.subnet4.pools: Kea subnet sub-1 has 2 pools; only the first is represented in the unified scope
 }
+
+func TestConversionWarning_String(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		warning  common.ConversionWarning
+		expected string
+	}{
+		{
+			name: "value and action",
+			warning: common.ConversionWarning{
+				Field:   "DHCP[lan].StaticLeases[1].MAC",
+				Value:   "xx:yy",
+				Message: "static lease MAC address is invalid",
+				Action:  "lease skipped",
+			},
+			expected: `DHCP[lan].StaticLeases[1].MAC: "xx:yy" static lease MAC address is invalid — lease skipped`,
+		},
+		{
+			name: "message only",
+			warning: common.ConversionWarning{
+				Field:   "FirewallRules[0].Interface",
+				Message: "firewall rule has no interface assigned",
+			},
+			expected: "FirewallRules[0].Interface: firewall rule has no interface assigned",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.warning.String(); got != tt.expected {
+				t.Errorf("ConversionWarning.String() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestConversionWarning_SerializedKeys(t *testing.T) {
+	t.Parallel()
+
+	w := common.ConversionWarning{
+		Field:    "FirewallRules[0].Interface",
+		Message:  "firewall rule has no interface assigned",
+		Severity: common.SeverityMedium,
+	}
+
+	gotJSON, err := json.Marshal(w)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+
+	wantJSON := `{"Field":"FirewallRules[0].Interface","Value":"",` +
+		`"Message":"firewall rule has no interface assigned","Severity":"medium"}`
+	if string(gotJSON) != wantJSON {
+		t.Errorf("json.Marshal() = %s, want %s", gotJSON, wantJSON)
+	}
+
+	gotYAML, err := yaml.Marshal(w)
+	if err != nil {
+		t.Fatalf("yaml.Marshal() error = %v", err)
+	}
+
+	wantYAML := "field: FirewallRules[0].Interface\n" +
+		"value: \"\"\n" +
+		"message: firewall rule has no interface assigned\n" +
+		"severity: medium\n"
+	if string(gotYAML) != wantYAML {
+		t.Errorf("yaml.Marshal() = %q, want %q", gotYAML, wantYAML)
+	}
+}
@@ -0,0 +1,75 @@
+package parser_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+// modelVersionRecordFile pins the pkg/model API snapshot to the ModelVersion
+// it was released under. It lives next to pkg-model.golden.
+const modelVersionRecordFile = "pkg-model.version.json"
+
+// modelVersionRecord is the on-disk shape of modelVersionRecordFile.
+type modelVersionRecord struct {
+	ModelVersion   string `json:"modelVersion"`
+	SnapshotSHA256 string `json:"snapshotSha256"`
+}
+
+// TestPublicAPISnapshot_pkg_model_version fails when the exported pkg/model
+// surface changes while model.ModelVersion stays the same. Every change to
+// the model shape (or its documentation) must come with a bump following the
+// rules on model.ModelVersion.
+//
+// After bumping ModelVersion, refresh the record together with the snapshot:
+//
+//	go test ./pkg/parser/... -run TestPublicAPISnapshot -update
+//
+// The record is only rewritten when the version differs from the recorded
+// one, so -update cannot be used to skip the bump.
+func TestPublicAPISnapshot_pkg_model_version(t *testing.T) {
+	t.Parallel()
+
+	sum := sha256.Sum256(captureGoDoc(t, "github.com/EvilBit-Labs/opnDossier/pkg/model"))
+	current := modelVersionRecord{
+		ModelVersion:   common.ModelVersion,
+		SnapshotSHA256: hex.EncodeToString(sum[:]),
+	}
+
+	path := filepath.Join(apiSnapshotFixtureDir, modelVersionRecordFile)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	var recorded modelVersionRecord
+	require.NoError(t, json.Unmarshal(data, &recorded))
+
+	if current == recorded {
+		return
+	}
+
+	require.NotEqualf(t, recorded.ModelVersion, current.ModelVersion,
+		"the pkg/model API changed but ModelVersion is still %s; bump it per the rules documented on "+
+			"model.ModelVersion, then rerun with -update", current.ModelVersion)
+
+	if !updateRequested() {
+		t.Fatalf("ModelVersion changed from %s to %s; rerun with -update to record the new snapshot",
+			recorded.ModelVersion, current.ModelVersion)
+	}
+
+	out, err := json.MarshalIndent(current, "", "  ")
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, append(out, '\n'), 0o600))
+}
+
+// updateRequested reports whether the goldie -update flag was passed.
+func updateRequested() bool {
+	f := flag.Lookup("update")
+	return f != nil && f.Value.String() == "true"
+}
@@ -0,0 +1,70 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+)
+
+// DetectPeekSize is the maximum number of bytes [DetectDeviceType] reads
+// while looking for the XML root element.
+const DetectPeekSize = 4 * 1024
+
+// DetectDeviceType reads at most [DetectPeekSize] bytes of r and maps the XML
+// root element to a registered device type: <opnsense> yields
+// [common.DeviceTypeOPNsense] and <pfsense> yields [common.DeviceTypePfSense].
+// Roots registered by third-party parsers are returned as their lowercase
+// registry key.
+//
+// The returned bytes are everything consumed from r, so the full document can
+// be parsed from io.MultiReader(bytes.NewReader(peeked), r). The bytes are
+// returned even when detection fails.
+//
+// An error is returned when no start element appears within the peek window
+// or when the root element is not registered in [DefaultRegistry].
+// [Factory.CreateDevice] uses the same detection against its own registry.
+func DetectDeviceType(r io.Reader) (common.DeviceType, []byte, error) {
+	return detectDeviceType(r, DefaultRegistry())
+}
+
+// detectDeviceType implements [DetectDeviceType] against reg.
+func detectDeviceType(r io.Reader, reg *DeviceParserRegistry) (common.DeviceType, []byte, error) {
+	var buf bytes.Buffer
+
+	input, _ := transcodeInput(io.TeeReader(io.LimitReader(r, DetectPeekSize), &buf))
+	dec := xml.NewDecoder(input)
+	dec.CharsetReader = transcodedCharsetReader
+
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return common.DeviceTypeUnknown, buf.Bytes(), fmt.Errorf(
+				"unsupported device type: no root XML element found in the first %d bytes: %w",
+				DetectPeekSize, err,
+			)
+		}
+
+		se, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		root := strings.ToLower(se.Name.Local)
+		if _, registered := reg.Get(root); !registered {
+			return common.DeviceTypeUnknown, buf.Bytes(), fmt.Errorf(
+				"unsupported device type: root element <%s> is not recognized; supported: %s",
+				se.Name.Local, reg.SupportedDevices(),
+			)
+		}
+
+		if dt := common.ParseDeviceType(root); dt != common.DeviceTypeUnknown {
+			return dt, buf.Bytes(), nil
+		}
+
+		return common.DeviceType(root), buf.Bytes(), nil
+	}
+}
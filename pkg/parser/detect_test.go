@@ -0,0 +1,84 @@
+package parser_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/cfgparser"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/EvilBit-Labs/opnDossier/pkg/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectDeviceType(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		input     string
+		want      common.DeviceType
+		errSubstr string
+	}{
+		{
+			name:  "opnsense root",
+			input: validOPNsenseXML,
+			want:  common.DeviceTypeOPNsense,
+		},
+		{
+			name:  "pfsense root",
+			input: `<?xml version="1.0"?><!-- exported --><pfsense><version>23.3</version></pfsense>`,
+			want:  common.DeviceTypePfSense,
+		},
+		{
+			name:      "unknown root",
+			input:     `<?xml version="1.0"?><fortigate></fortigate>`,
+			want:      common.DeviceTypeUnknown,
+			errSubstr: "root element <fortigate> is not recognized",
+		},
+		{
+			name:      "no root within peek window",
+			input:     "<!--" + strings.Repeat("x", parser.DetectPeekSize) + "--><opnsense/>",
+			want:      common.DeviceTypeUnknown,
+			errSubstr: "no root XML element found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, peeked, err := parser.DetectDeviceType(strings.NewReader(tt.input))
+			assert.Equal(t, tt.want, got)
+			assert.LessOrEqual(t, len(peeked), parser.DetectPeekSize)
+			assert.True(t, strings.HasPrefix(tt.input, string(peeked)), "peeked bytes must be a prefix of the input")
+
+			if tt.errSubstr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errSubstr)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestDetectDeviceType_PeekedBytesReplay(t *testing.T) {
+	t.Parallel()
+
+	r := strings.NewReader(validOPNsenseXML)
+	deviceType, peeked, err := parser.DetectDeviceType(r)
+	require.NoError(t, err)
+
+	full := io.MultiReader(bytes.NewReader(peeked), r)
+	factory := parser.NewFactory(cfgparser.NewXMLParser())
+
+	device, _, err := factory.CreateDevice(context.Background(), full, deviceType, false)
+	require.NoError(t, err)
+	assert.Equal(t, common.DeviceTypeOPNsense, device.DeviceType)
+	assert.Equal(t, "test", device.System.Hostname)
+}
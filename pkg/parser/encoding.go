@@ -0,0 +1,186 @@
+package parser
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	schema "github.com/EvilBit-Labs/opnDossier/pkg/schema/opnsense"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/transform"
+)
+
+// Canonical encoding names recorded in [schema.InputEncoding.Name].
+const (
+	encodingUTF8        = "UTF-8"
+	encodingUSASCII     = "US-ASCII"
+	encodingISO88591    = "ISO-8859-1"
+	encodingWindows1252 = "Windows-1252"
+	encodingUTF16       = "UTF-16"
+	encodingUTF16LE     = "UTF-16LE"
+	encodingUTF16BE     = "UTF-16BE"
+)
+
+// encodingSniffLen is the number of leading bytes inspected for a byte order
+// mark and the XML declaration.
+const encodingSniffLen = 1024
+
+// xmlDeclEncoding matches the encoding pseudo-attribute of an XML declaration.
+var xmlDeclEncoding = regexp.MustCompile(`^\s*<\?xml[^>]*?\sencoding\s*=\s*["']([A-Za-z0-9._:-]+)["']`)
+
+// Byte order marks recognized by [transcodeInput].
+var (
+	bomUTF8    = []byte{0xEF, 0xBB, 0xBF}
+	bomUTF16LE = []byte{0xFF, 0xFE}
+	bomUTF16BE = []byte{0xFE, 0xFF}
+)
+
+// canonicalCharset maps an XML charset label to one of the encoding*
+// constants. It returns "" for charsets that are not supported.
+func canonicalCharset(charset string) string {
+	normalized := strings.ToLower(strings.TrimSpace(charset))
+	normalized = strings.ReplaceAll(normalized, "_", "-")
+	normalized = strings.TrimSuffix(normalized, ":1987")
+
+	switch normalized {
+	case "us-ascii", "ascii":
+		return encodingUSASCII
+	case "utf-8", "utf8":
+		return encodingUTF8
+	case "iso-8859-1", "iso8859-1", "latin1", "latin-1":
+		return encodingISO88591
+	case "windows-1252", "windows1252", "cp1252":
+		return encodingWindows1252
+	case "utf-16", "utf16":
+		return encodingUTF16
+	case "utf-16le":
+		return encodingUTF16LE
+	case "utf-16be":
+		return encodingUTF16BE
+	default:
+		return ""
+	}
+}
+
+// transcodeInput returns a reader that yields r converted to UTF-8, and the
+// encoding record it updates as it is read. The source encoding is taken
+// from a byte order mark, then from the XML declaration, and defaults to
+// UTF-8. Invalid UTF-8 sequences are replaced with U+FFFD and counted in
+// Replacements; the single-byte and UTF-16 decoders never fail. Input that
+// declares an unsupported charset passes through unchanged so the decoder's
+// CharsetReader can reject it.
+func transcodeInput(r io.Reader) (io.Reader, *schema.InputEncoding) {
+	br := bufio.NewReaderSize(r, encodingSniffLen)
+	// A short read is fine here: any error resurfaces on the next Read.
+	head, _ := br.Peek(encodingSniffLen)
+
+	enc := &schema.InputEncoding{Name: encodingUTF8}
+
+	switch {
+	case bytes.HasPrefix(head, bomUTF8):
+		_, _ = br.Discard(len(bomUTF8))
+		return transform.NewReader(br, &utf8Sanitizer{enc: enc}), enc
+	case bytes.HasPrefix(head, bomUTF16LE):
+		enc.Name = encodingUTF16LE
+		return transform.NewReader(br, unicode.UTF16(unicode.LittleEndian, unicode.ExpectBOM).NewDecoder()), enc
+	case bytes.HasPrefix(head, bomUTF16BE):
+		enc.Name = encodingUTF16BE
+		return transform.NewReader(br, unicode.UTF16(unicode.BigEndian, unicode.ExpectBOM).NewDecoder()), enc
+	}
+
+	m := xmlDeclEncoding.FindSubmatch(head)
+	if m == nil {
+		return transform.NewReader(br, &utf8Sanitizer{enc: enc}), enc
+	}
+
+	declared := string(m[1])
+
+	switch canonicalCharset(declared) {
+	case encodingISO88591:
+		enc.Name = encodingISO88591
+		return transform.NewReader(br, charmap.ISO8859_1.NewDecoder()), enc
+	case encodingWindows1252:
+		enc.Name = encodingWindows1252
+		return transform.NewReader(br, charmap.Windows1252.NewDecoder()), enc
+	case encodingUSASCII:
+		enc.Name = encodingUSASCII
+		return transform.NewReader(br, &utf8Sanitizer{enc: enc}), enc
+	case "":
+		enc.Name = declared
+		return br, enc
+	default:
+		// UTF-8, and UTF-16 declared without a byte order mark: an ASCII
+		// declaration was readable, so the bytes are not UTF-16.
+		return transform.NewReader(br, &utf8Sanitizer{enc: enc}), enc
+	}
+}
+
+// transcodedCharsetReader is the CharsetReader for decoders reading from
+// [transcodeInput]. The input is already UTF-8, so every supported charset
+// passes through unchanged.
+func transcodedCharsetReader(charset string, input io.Reader) (io.Reader, error) {
+	if canonicalCharset(charset) == "" {
+		return nil, fmt.Errorf("unsupported charset: %s", charset)
+	}
+
+	return input, nil
+}
+
+// utf8Sanitizer is a [transform.Transformer] that copies UTF-8 input and
+// replaces each invalid byte with U+FFFD, counting replacements in enc.
+type utf8Sanitizer struct {
+	enc *schema.InputEncoding
+}
+
+// Transform implements [transform.Transformer].
+func (s *utf8Sanitizer) Transform(dst, src []byte, atEOF bool) (int, int, error) {
+	var nDst, nSrc int
+
+	for nSrc < len(src) {
+		if c := src[nSrc]; c < utf8.RuneSelf {
+			if nDst >= len(dst) {
+				return nDst, nSrc, transform.ErrShortDst
+			}
+
+			dst[nDst] = c
+			nDst++
+			nSrc++
+
+			continue
+		}
+
+		r, size := utf8.DecodeRune(src[nSrc:])
+		if r == utf8.RuneError && size == 1 {
+			if !atEOF && !utf8.FullRune(src[nSrc:]) {
+				return nDst, nSrc, transform.ErrShortSrc
+			}
+
+			if nDst+utf8.RuneLen(utf8.RuneError) > len(dst) {
+				return nDst, nSrc, transform.ErrShortDst
+			}
+
+			nDst += utf8.EncodeRune(dst[nDst:], utf8.RuneError)
+			nSrc++
+			s.enc.Replacements++
+
+			continue
+		}
+
+		if nDst+size > len(dst) {
+			return nDst, nSrc, transform.ErrShortDst
+		}
+
+		nDst += copy(dst[nDst:], src[nSrc:nSrc+size])
+		nSrc += size
+	}
+
+	return nDst, nSrc, nil
+}
+
+// Reset implements [transform.Transformer]. The replacement count is kept.
+func (s *utf8Sanitizer) Reset() {}
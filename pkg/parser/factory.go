@@ -3,11 +3,9 @@ package parser
 import (
 	"bytes"
 	"context"
-	"encoding/xml"
 	"errors"
 	"fmt"
 	"io"
-	"strings"
 
 	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
 	schema "github.com/EvilBit-Labs/opnDossier/pkg/schema/opnsense"
@@ -15,8 +13,9 @@ import (
 
 // DefaultMaxInputSize is the default maximum size in bytes for XML input.
 // This prevents XML bomb attacks by limiting how much data is read during
-// root-element detection and parsing.
-const DefaultMaxInputSize = 10 * 1024 * 1024 // 10MB
+// parsing; larger input is rejected with [ErrInputTooLarge] rather than
+// truncated. Root-element detection reads at most [DetectPeekSize] bytes.
+const DefaultMaxInputSize = 512 * 1024 * 1024 // 512MB
 
 // OPNsenseXMLDecoder parses raw XML input into an OPNsense
 // [schema.OpnSenseDocument]. The name is OPNsense-specific because the return
@@ -149,26 +148,65 @@ func (f *Factory) createWithOverride(
 	return parseDevice(ctx, fn(f.xmlDecoder), r, validateMode)
 }
 
-// createWithAutoDetect peeks the XML root element using a bounded, context-aware
-// reader and delegates to the matching parser.
+// detectResult holds the outcome of the root-element detection goroutine.
+type detectResult struct {
+	deviceType common.DeviceType
+	peeked     []byte
+	err        error
+}
+
+// createWithAutoDetect detects the device type from the XML root element via
+// [DetectDeviceType]'s bounded peek and delegates to the matching parser.
+// Detection runs in a goroutine so ctx cancellation returns promptly.
+//
+// CANCELLATION CONTRACT: Callers must ensure the supplied ctx is eventually
+// cancelled. On ctx.Done(), this function returns ctx.Err() immediately, but
+// the detection goroutine only exits when the current read unblocks. The
+// ctx-wrapped reader (see [newCtxReader]) returns ctx.Err() on a subsequent
+// Read call after the underlying blocked Read has returned; it does not
+// interrupt an already-blocked Read. If the supplied reader never yields
+// (e.g., a hung network stream) AND the ctx is never cancelled, the goroutine
+// leaks and retains up to [DetectPeekSize] bytes until the process exits.
+//
+// The function deliberately does NOT close the reader on cancellation: it
+// receives an [io.Reader] it did not create and therefore does not own, and
+// not every reader is an [io.Closer]. CLI callers wrap *os.File readers which
+// return io.EOF promptly, so the goroutine exits naturally in that path.
+// Library consumers supplying readers that can block indefinitely (sockets,
+// fifos, long-polling HTTP bodies) MUST cancel the context to release it.
 func (f *Factory) createWithAutoDetect(
 	ctx context.Context,
 	r io.Reader,
 	validateMode bool,
 ) (*common.CommonDevice, []common.ConversionWarning, error) {
-	rootElem, fullReader, err := peekRootElementBounded(ctx, r)
-	if err != nil {
-		return nil, nil, err
+	ch := make(chan detectResult, 1)
+
+	go func() {
+		dt, peeked, err := detectDeviceType(newCtxReader(ctx, r), f.registry)
+		ch <- detectResult{deviceType: dt, peeked: peeked, err: err}
+	}()
+
+	var res detectResult
+	select {
+	case <-ctx.Done():
+		return nil, nil, ctx.Err()
+	case res = <-ch:
+	}
+
+	if res.err != nil {
+		return nil, nil, res.err
 	}
 
-	fn, ok := f.registry.Get(rootElem)
+	fn, ok := f.registry.Get(res.deviceType.String())
 	if !ok {
 		return nil, nil, fmt.Errorf(
-			"unsupported device type: root element <%s> is not recognized; supported: %s",
-			rootElem, f.registry.SupportedDevices(),
+			"unsupported device type: %q; supported: %s",
+			res.deviceType, f.registry.SupportedDevices(),
 		)
 	}
 
+	fullReader := io.MultiReader(bytes.NewReader(res.peeked), r)
+
 	return parseDevice(ctx, fn(f.xmlDecoder), fullReader, validateMode)
 }
 
@@ -187,78 +225,6 @@ func parseDevice(
 	return p.Parse(ctx, r)
 }
 
-// peekResult holds the outcome of the root-element detection goroutine.
-type peekResult struct {
-	name string
-	err  error
-}
-
-// peekRootElementBounded reads up to [DefaultMaxInputSize] bytes of r to find
-// the first XML start element. It runs the token-scanning loop in a goroutine
-// so ctx cancellation can return promptly, and uses a TeeReader to buffer the
-// consumed bytes. It returns the root element name, a reader that replays the
-// buffered bytes followed by the remainder of r, and any error.
-//
-// CANCELLATION CONTRACT: Callers must ensure the supplied ctx is eventually
-// cancelled. On ctx.Done(), this function returns ctx.Err() immediately, but
-// the inner goroutine itself only exits when the current token read unblocks.
-// The ctx-wrapped reader (see [newCtxReader]) returns ctx.Err() on a
-// subsequent Read call after the underlying blocked Read has returned; it
-// does not interrupt an already-blocked Read. If the supplied reader never
-// yields (e.g., a hung network stream) AND the ctx is never cancelled, the
-// goroutine leaks and retains up to DefaultMaxInputSize bytes in its
-// internal buffer until the process exits.
-//
-// The function deliberately does NOT install a watchdog timer that closes the
-// reader on timeout: peekRootElementBounded receives an [io.Reader] it did
-// not create and therefore does not own. Closing a caller-owned reader from
-// within this helper would corrupt caller state (the caller may legitimately
-// reuse the reader after cancellation, and not every reader is an
-// [io.Closer]). The cancellation contract is the single mechanism callers
-// use to bound goroutine lifetime.
-//
-// CLI callers wrap *os.File readers which return io.EOF promptly on EOF, so
-// the goroutine exits naturally in that path. Library consumers supplying
-// readers that can block indefinitely (sockets, fifos, long-polling HTTP
-// bodies) MUST cancel the context to release the goroutine.
-func peekRootElementBounded(ctx context.Context, r io.Reader) (string, io.Reader, error) {
-	var buf bytes.Buffer
-
-	limited := io.LimitReader(newCtxReader(ctx, r), DefaultMaxInputSize)
-	tee := io.TeeReader(limited, &buf)
-	dec := xml.NewDecoder(tee)
-	dec.CharsetReader = simpleCharsetReader
-
-	ch := make(chan peekResult, 1)
-
-	go func() {
-		for {
-			tok, err := dec.Token()
-			if err != nil {
-				ch <- peekResult{err: fmt.Errorf("unsupported device type: no root XML element found: %w", err)}
-				return
-			}
-
-			if se, ok := tok.(xml.StartElement); ok {
-				ch <- peekResult{name: se.Name.Local}
-				return
-			}
-		}
-	}()
-
-	select {
-	case <-ctx.Done():
-		return "", nil, ctx.Err()
-	case res := <-ch:
-		if res.err != nil {
-			return "", nil, res.err
-		}
-
-		fullReader := io.MultiReader(bytes.NewReader(buf.Bytes()), r)
-		return res.name, fullReader, nil
-	}
-}
-
 // readerFunc adapts a function to the io.Reader interface.
 type readerFunc func(p []byte) (int, error)
 
@@ -277,15 +243,3 @@ func newCtxReader(ctx context.Context, r io.Reader) io.Reader {
 		return r.Read(p)
 	})
 }
-
-// simpleCharsetReader handles common XML charset declarations for root-element
-// detection. Only charsets whose ASCII subset matches UTF-8 are accepted, which
-// is sufficient because XML element names use only ASCII-range characters.
-func simpleCharsetReader(charset string, input io.Reader) (io.Reader, error) {
-	switch strings.ToLower(charset) {
-	case "us-ascii", "iso-8859-1", "latin-1", "utf-8":
-		return input, nil
-	default:
-		return nil, fmt.Errorf("unsupported XML charset: %s", charset)
-	}
-}
@@ -1,11 +1,14 @@
 package parser_test
 
 import (
+	"bytes"
 	"context"
+	"encoding/binary"
 	"io"
 	"strings"
 	"testing"
 	"time"
+	"unicode/utf16"
 
 	"github.com/EvilBit-Labs/opnDossier/internal/cfgparser"
 	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
@@ -161,7 +164,7 @@ func TestFactory_ContextCancelled_BlockingReader(t *testing.T) {
 }
 
 // TestFactory_ContextCancelled_HungReader_FastReturn documents the
-// cancellation contract on peekRootElementBounded: even when the supplied
+// cancellation contract on auto-detection: even when the supplied
 // reader is hung (io.Pipe with no writer), CreateDevice must return
 // context.Canceled promptly after ctx is cancelled. The 100ms budget is the
 // regression threshold — this asserts the outer select{} picks up ctx.Done()
@@ -283,13 +286,13 @@ func TestFactory_UnsupportedCharset(t *testing.T) {
 		false,
 	)
 	require.Error(t, err)
-	assert.Contains(t, err.Error(), "unsupported XML charset")
+	assert.Contains(t, err.Error(), "unsupported charset: EBCDIC")
 }
 
 func TestFactory_AcceptedCharsets(t *testing.T) {
 	t.Parallel()
 
-	charsets := []string{"US-ASCII", "ISO-8859-1", "Latin-1", "UTF-8"}
+	charsets := []string{"US-ASCII", "ISO-8859-1", "Latin-1", "UTF-8", "Windows-1252"}
 	for _, charset := range charsets {
 		t.Run(charset, func(t *testing.T) {
 			t.Parallel()
@@ -309,12 +312,80 @@ func TestFactory_AcceptedCharsets(t *testing.T) {
 	}
 }
 
+// currentSystemDefaults carries the <system> settings the OPNsense migration
+// would otherwise populate, so tests that expect no warnings are not handed
+// its Info notes.
+const currentSystemDefaults = "<optimization>normal</optimization>" +
+	"<webgui><protocol>https</protocol></webgui><ssh><group>admins</group></ssh>"
+
+func TestFactory_RecordsSourceEncoding(t *testing.T) {
+	t.Parallel()
+
+	xmlData := "<?xml version=\"1.0\" encoding=\"ISO-8859-1\"?>\n" +
+		"<opnsense><system><hostname>fw</hostname><domain>caf\xe9.local</domain>" + currentSystemDefaults +
+		"</system></opnsense>"
+	device, warnings, err := parser.NewFactory(cfgparser.NewXMLParser()).CreateDevice(
+		context.Background(),
+		strings.NewReader(xmlData),
+		common.DeviceTypeUnknown,
+		false,
+	)
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+	assert.Equal(t, "ISO-8859-1", device.SourceEncoding)
+	assert.Equal(t, "café.local", device.System.Domain)
+}
+
+func TestFactory_UTF16WithBOM(t *testing.T) {
+	t.Parallel()
+
+	xmlData := `<?xml version="1.0" encoding="UTF-16"?>` +
+		`<pfsense><system><hostname>fw</hostname><domain>test.local</domain></system></pfsense>`
+	encoded := []byte{0xFF, 0xFE}
+	for _, u := range utf16.Encode([]rune(xmlData)) {
+		encoded = binary.LittleEndian.AppendUint16(encoded, u)
+	}
+
+	device, _, err := parser.NewFactory(cfgparser.NewXMLParser()).CreateDevice(
+		context.Background(),
+		bytes.NewReader(encoded),
+		common.DeviceTypeUnknown,
+		false,
+	)
+	require.NoError(t, err)
+	assert.Equal(t, common.DeviceTypePfSense, device.DeviceType)
+	assert.Equal(t, "UTF-16LE", device.SourceEncoding)
+	assert.Equal(t, "fw", device.System.Hostname)
+}
+
+func TestFactory_InvalidBytesReplacedWithWarning(t *testing.T) {
+	t.Parallel()
+
+	xmlData := "<opnsense><system><hostname>fw</hostname><domain>test.local</domain>" + currentSystemDefaults +
+		"<user><name>ops</name><uid>2000</uid><descr>Caf\xe9</descr></user></system></opnsense>"
+	device, warnings, err := parser.NewFactory(cfgparser.NewXMLParser()).CreateDevice(
+		context.Background(),
+		strings.NewReader(xmlData),
+		common.DeviceTypeUnknown,
+		false,
+	)
+	require.NoError(t, err)
+	require.Len(t, device.Users, 1)
+	assert.Equal(t, "Caf\uFFFD", device.Users[0].Description)
+	assert.Equal(t, "UTF-8", device.SourceEncoding)
+
+	require.Len(t, warnings, 1)
+	assert.Equal(t, "SourceEncoding", warnings[0].Field)
+	assert.Equal(t, common.SeverityMedium, warnings[0].Severity)
+	assert.Contains(t, warnings[0].Message, "1 invalid byte sequence(s)")
+}
+
 func TestFactory_LargeInput_BoundedRead(t *testing.T) {
 	t.Parallel()
 
-	// Build input that exceeds the default max input size without a root element.
-	// The decoder should stop at the limit and return an error.
-	bigInput := strings.Repeat("<!-- padding -->", int(cfgparser.DefaultMaxInputSize)/15+1)
+	// Build input that exceeds the detection window without a root element.
+	// Detection should stop at DetectPeekSize and return an error.
+	bigInput := strings.Repeat("<!-- padding -->", 4*parser.DetectPeekSize/15+1)
 	_, _, err := parser.NewFactory(cfgparser.NewXMLParser()).CreateDevice(
 		context.Background(),
 		strings.NewReader(bigInput),
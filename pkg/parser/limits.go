@@ -0,0 +1,373 @@
+package parser
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Default structural limits applied by [NewLimitedXMLDecoder]. Real
+// OPNsense and pfSense configurations nest a dozen or so elements deep; the
+// largest single tokens are base64 blobs such as RRD data and captive portal
+// templates, which stay well below the token limit.
+const (
+	// DefaultMaxElementDepth is the default maximum element nesting depth,
+	// counting the root element as depth 1.
+	DefaultMaxElementDepth = 256
+
+	// DefaultMaxTokenSize is the default maximum size in bytes of a single
+	// token: one run of text, one comment or CDATA section, or one tag with
+	// its attributes.
+	DefaultMaxTokenSize = 64 * 1024 * 1024 // 64MB
+)
+
+// Reasons a secure decoder rejects its input. The decoder returns them
+// wrapped in a [*LimitError]; match them with [errors.Is].
+var (
+	// ErrDTDNotSupported rejects a DOCTYPE declaration or any other DTD
+	// markup, including an internal subset.
+	ErrDTDNotSupported = errors.New("DTDs are not supported in config.xml inputs")
+
+	// ErrInputTooLarge rejects input longer than [Limits.MaxInputSize].
+	ErrInputTooLarge = errors.New("input exceeds the maximum size")
+
+	// ErrElementTooDeep rejects elements nested deeper than
+	// [Limits.MaxElementDepth].
+	ErrElementTooDeep = errors.New("element nesting exceeds the maximum depth")
+
+	// ErrTokenTooLarge rejects a token longer than [Limits.MaxTokenSize].
+	ErrTokenTooLarge = errors.New("XML token exceeds the maximum size")
+)
+
+// Limits bounds the input a secure decoder accepts. Zero or negative fields
+// use the corresponding default.
+type Limits struct {
+	// MaxInputSize is the maximum input size in bytes before transcoding.
+	// Defaults to [DefaultMaxInputSize].
+	MaxInputSize int64
+
+	// MaxElementDepth is the maximum element nesting depth. Defaults to
+	// [DefaultMaxElementDepth].
+	MaxElementDepth int
+
+	// MaxTokenSize is the maximum size in bytes of a single token. Defaults
+	// to [DefaultMaxTokenSize].
+	MaxTokenSize int64
+}
+
+// withDefaults returns l with unset fields replaced by their defaults.
+func (l Limits) withDefaults() Limits {
+	if l.MaxInputSize <= 0 {
+		l.MaxInputSize = DefaultMaxInputSize
+	}
+
+	if l.MaxElementDepth <= 0 {
+		l.MaxElementDepth = DefaultMaxElementDepth
+	}
+
+	if l.MaxTokenSize <= 0 {
+		l.MaxTokenSize = DefaultMaxTokenSize
+	}
+
+	return l
+}
+
+// LimitError reports input a secure decoder refused to parse. Err is one of
+// [ErrDTDNotSupported], [ErrInputTooLarge], [ErrElementTooDeep], or
+// [ErrTokenTooLarge].
+type LimitError struct {
+	Err    error // Reason the input was rejected
+	Limit  int64 // Limit that was exceeded; zero for ErrDTDNotSupported
+	Offset int64 // Byte offset at which the input was rejected
+}
+
+// Error implements the error interface for LimitError.
+func (e *LimitError) Error() string {
+	switch {
+	case errors.Is(e.Err, ErrElementTooDeep):
+		return fmt.Sprintf("%v of %d (at byte offset %d)", e.Err, e.Limit, e.Offset)
+	case e.Limit > 0:
+		return fmt.Sprintf("%v of %d bytes (at byte offset %d)", e.Err, e.Limit, e.Offset)
+	default:
+		return fmt.Sprintf("%v (at byte offset %d)", e.Err, e.Offset)
+	}
+}
+
+// Unwrap returns the rejection reason.
+func (e *LimitError) Unwrap() error {
+	return e.Err
+}
+
+// Rationale explains, for operators, why input like this is refused.
+func (e *LimitError) Rationale() string {
+	switch {
+	case errors.Is(e.Err, ErrDTDNotSupported):
+		return "A DTD can declare external entities (XXE) that read local files or reach the network, " +
+			"and nested entities that expand exponentially (billion laughs). OPNsense and pfSense never " +
+			"write one, so its presence means the file was crafted or altered; remove the DOCTYPE only " +
+			"if you trust the file."
+	case errors.Is(e.Err, ErrInputTooLarge):
+		return "Input size is capped so a hostile or corrupted file cannot exhaust memory. Real " +
+			"configuration exports are far smaller; check that this is the right file."
+	case errors.Is(e.Err, ErrElementTooDeep):
+		return "Element nesting is capped so a crafted file cannot exhaust memory or the stack while " +
+			"it is decoded. Real configurations nest only a dozen or so levels deep."
+	case errors.Is(e.Err, ErrTokenTooLarge):
+		return "Single text, comment, and tag tokens are capped so a crafted file cannot force the " +
+			"parser to buffer one unbounded value."
+	default:
+		return ""
+	}
+}
+
+// sizeLimitedReader reads at most max bytes from r and then fails with
+// [ErrInputTooLarge], instead of truncating the input as [io.LimitReader]
+// does. It reads at most one byte past max from r.
+type sizeLimitedReader struct {
+	r   io.Reader
+	max int64
+	n   int64 // bytes read from r so far
+}
+
+// Read implements io.Reader.
+func (l *sizeLimitedReader) Read(p []byte) (int, error) {
+	if l.n > l.max {
+		return 0, &LimitError{Err: ErrInputTooLarge, Limit: l.max, Offset: l.max}
+	}
+
+	if remaining := l.max + 1 - l.n; int64(len(p)) > remaining {
+		p = p[:remaining]
+	}
+
+	n, err := l.r.Read(p)
+	l.n += int64(n)
+
+	if l.n > l.max {
+		return n - int(l.n-l.max), &LimitError{Err: ErrInputTooLarge, Limit: l.max, Offset: l.max}
+	}
+
+	return n, err
+}
+
+// scanState is the markup construct a structureGuard is inside.
+type scanState int
+
+const (
+	scanText     scanState = iota // character data between markup
+	scanMarkup                    // just after '<'
+	scanBang                      // after "<!", deciding what follows
+	scanComment                   // inside <!-- -->
+	scanCDATA                     // inside <![CDATA[ ]]>
+	scanProcInst                  // inside <? ?>
+	scanStartTag                  // inside <name ...> or <name .../>
+	scanEndTag                    // inside </name>
+)
+
+// Openers of the only "<!" constructs allowed; every other one is DTD
+// markup.
+const (
+	commentOpener = "--"
+	cdataOpener   = "[CDATA["
+)
+
+// structureGuard scans the byte stream ahead of encoding/xml and fails the
+// read that would hand it DTD markup, an element nested too deep, or an
+// oversized token, so the decoder never buffers or interprets them. It
+// tracks just enough XML lexical structure to do so; well-formedness is left
+// to the decoder.
+type structureGuard struct {
+	r      io.Reader
+	limits Limits
+
+	state    scanState
+	offset   int64 // bytes scanned so far
+	start    int64 // offset of the '<' opening the current markup
+	depth    int   // currently open elements
+	tokenLen int64 // bytes of the current token
+	bang     []byte
+	quote    byte // open attribute quote in a start tag, or 0
+	last     byte // previous byte in a start tag outside quotes
+	run      int  // trailing '-', ']' or '?' bytes before a closing '>'
+	err      error
+}
+
+// newStructureGuard returns a structureGuard reading from r.
+func newStructureGuard(r io.Reader, limits Limits) *structureGuard {
+	return &structureGuard{r: r, limits: limits, bang: make([]byte, 0, len(cdataOpener))}
+}
+
+// Read implements io.Reader. It returns the bytes before a violation
+// together with its error, and the same error on every later call.
+func (g *structureGuard) Read(p []byte) (int, error) {
+	if g.err != nil {
+		return 0, g.err
+	}
+
+	n, err := g.r.Read(p)
+
+	for i := 0; i < n; {
+		if skip := g.inert(p[i:n]); skip > 0 {
+			g.tokenLen += int64(skip)
+			g.offset += int64(skip)
+			if g.tokenLen > g.limits.MaxTokenSize {
+				g.err = g.tokenTooLarge()
+				return i + skip, g.err
+			}
+
+			if g.state == scanStartTag && g.quote == 0 {
+				g.last = p[i+skip-1]
+			}
+			g.run = 0
+			i += skip
+
+			continue
+		}
+
+		if g.err = g.scan(p[i]); g.err != nil {
+			return i, g.err
+		}
+		g.offset++
+		i++
+	}
+
+	return n, err
+}
+
+// inert returns how many leading bytes of b cannot change the guard's state
+// other than by lengthening the current token, so Read can skip them in one
+// step instead of scanning byte by byte.
+func (g *structureGuard) inert(b []byte) int {
+	var i int
+
+	switch g.state {
+	case scanText:
+		i = bytes.IndexByte(b, '<')
+	case scanStartTag:
+		if g.quote != 0 {
+			i = bytes.IndexByte(b, g.quote)
+		} else {
+			i = bytes.IndexAny(b, `"'>`)
+		}
+	case scanEndTag:
+		i = bytes.IndexByte(b, '>')
+	case scanComment:
+		i = bytes.IndexAny(b, "->")
+	case scanCDATA:
+		i = bytes.IndexAny(b, "]>")
+	case scanProcInst:
+		i = bytes.IndexAny(b, "?>")
+	case scanMarkup, scanBang:
+		return 0
+	}
+
+	if i < 0 {
+		return len(b)
+	}
+
+	return i
+}
+
+// scan advances the guard over one byte of markup.
+//
+//nolint:gocognit,cyclop // one lexer state per case; splitting it would scatter the state machine
+func (g *structureGuard) scan(b byte) error {
+	if g.state == scanText {
+		// Only reached on the '<' that ends the text.
+		g.state = scanMarkup
+		g.start = g.offset
+		g.tokenLen = 1
+
+		return nil
+	}
+
+	g.tokenLen++
+	if g.tokenLen > g.limits.MaxTokenSize {
+		return g.tokenTooLarge()
+	}
+
+	switch g.state {
+	case scanText:
+		// Handled above.
+	case scanMarkup:
+		switch b {
+		case '/':
+			g.state = scanEndTag
+		case '?':
+			g.state, g.run = scanProcInst, 0
+		case '!':
+			g.state, g.bang = scanBang, g.bang[:0]
+		default:
+			g.depth++
+			if g.depth > g.limits.MaxElementDepth {
+				return &LimitError{Err: ErrElementTooDeep, Limit: int64(g.limits.MaxElementDepth), Offset: g.start}
+			}
+			g.state, g.quote, g.last = scanStartTag, 0, b
+		}
+	case scanBang:
+		g.bang = append(g.bang, b)
+		switch opened := string(g.bang); {
+		case opened == commentOpener:
+			g.state, g.run = scanComment, 0
+		case opened == cdataOpener:
+			g.state, g.run = scanCDATA, 0
+		case !strings.HasPrefix(commentOpener, opened) && !strings.HasPrefix(cdataOpener, opened):
+			return &LimitError{Err: ErrDTDNotSupported, Offset: g.start}
+		}
+	case scanComment:
+		g.closeAfterRun(b, '-', 2)
+	case scanCDATA:
+		g.closeAfterRun(b, ']', 2)
+	case scanProcInst:
+		g.closeAfterRun(b, '?', 1)
+	case scanStartTag:
+		switch {
+		case g.quote != 0:
+			if b == g.quote {
+				g.quote = 0
+			}
+		case b == '"' || b == '\'':
+			g.quote = b
+		case b == '>':
+			if g.last == '/' {
+				g.depth--
+			}
+			g.endToken()
+		default:
+			g.last = b
+		}
+	case scanEndTag:
+		if b == '>' {
+			g.depth = max(g.depth-1, 0)
+			g.endToken()
+		}
+	}
+
+	return nil
+}
+
+// closeAfterRun ends the current comment, CDATA section, or processing
+// instruction on a '>' preceded by at least n consecutive want bytes.
+func (g *structureGuard) closeAfterRun(b, want byte, n int) {
+	switch {
+	case b == want:
+		g.run++
+	case b == '>' && g.run >= n:
+		g.endToken()
+	default:
+		g.run = 0
+	}
+}
+
+// endToken returns the guard to character data after a '>'.
+func (g *structureGuard) endToken() {
+	g.state = scanText
+	g.tokenLen = 0
+}
+
+// tokenTooLarge returns the error for a token that reached past
+// MaxTokenSize at the current offset.
+func (g *structureGuard) tokenTooLarge() *LimitError {
+	return &LimitError{Err: ErrTokenTooLarge, Limit: g.limits.MaxTokenSize, Offset: g.offset}
+}
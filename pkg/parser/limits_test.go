@@ -0,0 +1,206 @@
+package parser_test
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/EvilBit-Labs/opnDossier/pkg/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// decodeAll streams every token of the decoder's input, as the OPNsense
+// parser does, and returns the first error other than io.EOF.
+func decodeAll(dec *xml.Decoder) error {
+	for {
+		if _, err := dec.Token(); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+// countingReader counts the bytes read from r.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// endlessText never ends: an open root element followed by text.
+type endlessText struct{ started bool }
+
+func (e *endlessText) Read(p []byte) (int, error) {
+	if !e.started {
+		e.started = true
+		return copy(p, "<opnsense>"), nil
+	}
+	for i := range p {
+		p[i] = 'x'
+	}
+	return len(p), nil
+}
+
+func TestNewLimitedXMLDecoder_RejectsDTD(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{
+			name:  "internal subset",
+			input: `<?xml version="1.0"?><!DOCTYPE r [<!ENTITY x "pwned">]><r>&x;</r>`,
+		},
+		{
+			name:  "external entity",
+			input: `<!DOCTYPE r [<!ENTITY x SYSTEM "file:///etc/passwd">]><r>&x;</r>`,
+		},
+		{name: "bare DOCTYPE", input: `<!DOCTYPE r SYSTEM "config.dtd"><r/>`},
+		{name: "stray entity declaration", input: `<r><!ENTITY x "y"></r>`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dec := parser.NewSecureXMLDecoder(strings.NewReader(tt.input), parser.DefaultMaxInputSize)
+			err := dec.Decode(new(struct {
+				XMLName xml.Name `xml:"r"`
+			}))
+			require.ErrorIs(t, err, parser.ErrDTDNotSupported)
+
+			var limitErr *parser.LimitError
+			require.ErrorAs(t, err, &limitErr)
+			assert.Zero(t, limitErr.Limit)
+			assert.Contains(t, limitErr.Rationale(), "XXE")
+
+			dec = parser.NewSecureXMLDecoder(strings.NewReader(tt.input), parser.DefaultMaxInputSize)
+			require.ErrorIs(t, decodeAll(dec), parser.ErrDTDNotSupported)
+		})
+	}
+}
+
+func TestNewLimitedXMLDecoder_AllowsNonDTDMarkup(t *testing.T) {
+	t.Parallel()
+
+	input := `<?xml version="1.0"?>` +
+		`<!-- <!DOCTYPE r> in a comment - with a dash -->` +
+		`<r a="x>y" b='/'><br/><c><![CDATA[<!DOCTYPE r> ]] ]]]></c><?pi data?></r>`
+
+	dec, _ := parser.NewLimitedXMLDecoder(strings.NewReader(input), parser.Limits{MaxElementDepth: 2})
+	require.NoError(t, decodeAll(dec))
+}
+
+func TestNewLimitedXMLDecoder_InputSize(t *testing.T) {
+	t.Parallel()
+
+	t.Run("rejects oversized input before reading it all", func(t *testing.T) {
+		t.Parallel()
+
+		const limit = 4096
+		src := &countingReader{r: &endlessText{}}
+		dec, _ := parser.NewLimitedXMLDecoder(src, parser.Limits{MaxInputSize: limit})
+
+		err := decodeAll(dec)
+		require.ErrorIs(t, err, parser.ErrInputTooLarge)
+		assert.LessOrEqual(t, src.n, int64(limit+1))
+
+		var limitErr *parser.LimitError
+		require.ErrorAs(t, err, &limitErr)
+		assert.Equal(t, int64(limit), limitErr.Limit)
+		assert.Equal(t, "input exceeds the maximum size of 4096 bytes (at byte offset 4096)", err.Error())
+	})
+
+	t.Run("accepts input exactly at the limit", func(t *testing.T) {
+		t.Parallel()
+
+		input := "<r>" + strings.Repeat("x", 100) + "</r>"
+		dec, _ := parser.NewLimitedXMLDecoder(strings.NewReader(input), parser.Limits{MaxInputSize: int64(len(input))})
+		require.NoError(t, decodeAll(dec))
+	})
+}
+
+func TestNewLimitedXMLDecoder_ElementDepth(t *testing.T) {
+	t.Parallel()
+
+	nested := func(depth int) string {
+		return strings.Repeat("<a>", depth) + strings.Repeat("</a>", depth)
+	}
+
+	t.Run("default limit", func(t *testing.T) {
+		t.Parallel()
+
+		dec, _ := parser.NewLimitedXMLDecoder(strings.NewReader(nested(100_000)), parser.Limits{})
+		err := decodeAll(dec)
+		require.ErrorIs(t, err, parser.ErrElementTooDeep)
+
+		var limitErr *parser.LimitError
+		require.ErrorAs(t, err, &limitErr)
+		assert.Equal(t, int64(parser.DefaultMaxElementDepth), limitErr.Limit)
+		assert.Equal(t, "element nesting exceeds the maximum depth of 256 (at byte offset 768)", err.Error())
+	})
+
+	t.Run("siblings and self-closing elements do not accumulate", func(t *testing.T) {
+		t.Parallel()
+
+		input := "<r>" + strings.Repeat(nested(2)+"<e/>", 1000) + "</r>"
+		dec, _ := parser.NewLimitedXMLDecoder(strings.NewReader(input), parser.Limits{MaxElementDepth: 3})
+		require.NoError(t, decodeAll(dec))
+	})
+
+	t.Run("full decode", func(t *testing.T) {
+		t.Parallel()
+
+		dec, _ := parser.NewLimitedXMLDecoder(strings.NewReader(nested(4)), parser.Limits{MaxElementDepth: 3})
+		err := dec.Decode(new(struct {
+			XMLName xml.Name `xml:"a"`
+		}))
+		require.ErrorIs(t, err, parser.ErrElementTooDeep)
+	})
+}
+
+func TestNewLimitedXMLDecoder_TokenSize(t *testing.T) {
+	t.Parallel()
+
+	const limit = 64
+	big := strings.Repeat("x", limit+1)
+
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		{name: "text at the limit", input: "<r>" + big[1:] + "</r>"},
+		{name: "text", input: "<r>" + big + "</r>", wantErr: true},
+		{name: "attribute", input: `<r a="` + big + `"/>`, wantErr: true},
+		{name: "comment", input: "<r><!--" + big + "--></r>", wantErr: true},
+		{name: "CDATA", input: "<r><![CDATA[" + big + "]]></r>", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dec, _ := parser.NewLimitedXMLDecoder(strings.NewReader(tt.input), parser.Limits{MaxTokenSize: limit})
+			err := decodeAll(dec)
+			if !tt.wantErr {
+				require.NoError(t, err)
+				return
+			}
+
+			require.ErrorIs(t, err, parser.ErrTokenTooLarge)
+			assert.Contains(t, err.Error(), "XML token exceeds the maximum size of 64 bytes")
+		})
+	}
+}
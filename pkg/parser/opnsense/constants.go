@@ -12,4 +12,7 @@ const (
 
 	// packageTypePlugin identifies firmware plugin packages parsed from config.xml.
 	packageTypePlugin = "plugin"
+
+	// redactedValue replaces credentials that must not reach the export.
+	redactedValue = "[REDACTED]"
 )
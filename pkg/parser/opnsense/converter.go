@@ -1,9 +1,11 @@
 package opnsense
 
 import (
+	"cmp"
 	"errors"
 	"fmt"
 	"slices"
+	"strconv"
 	"strings"
 
 	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
@@ -28,7 +30,9 @@ func newConverter() *converter {
 
 // ConvertDocument transforms a parsed OpnSenseDocument into a platform-agnostic
 // CommonDevice along with any non-fatal conversion warnings. This is a
-// convenience function that creates a fresh converter internally.
+// convenience function that creates a fresh converter internally. Unlike
+// [Parser.Parse], it converts doc as given; run Migrate from
+// pkg/schema/opnsense/migration first for documents written by older releases.
 func ConvertDocument(doc *schema.OpnSenseDocument) (*common.CommonDevice, []common.ConversionWarning, error) {
 	return newConverter().ToCommonDevice(doc)
 }
@@ -43,6 +47,18 @@ func (c *converter) addWarning(field, value, message string, severity common.Sev
 	})
 }
 
+// addIssue records a conversion warning for a value the converter could not
+// use, together with the action it took instead (e.g. "lease skipped").
+func (c *converter) addIssue(field, value, message, action string, severity common.Severity) {
+	c.warnings = append(c.warnings, common.ConversionWarning{
+		Field:    field,
+		Value:    value,
+		Message:  message,
+		Action:   action,
+		Severity: severity,
+	})
+}
+
 // ToCommonDevice converts an OPNsense schema document into a platform-agnostic CommonDevice.
 // Returns ErrNilDocument if doc is nil.
 func (c *converter) ToCommonDevice(
@@ -59,7 +75,10 @@ func (c *converter) ToCommonDevice(
 	device := &common.CommonDevice{
 		DeviceType:       common.DeviceTypeOPNsense,
 		Version:          doc.Version,
-		Theme:            doc.Theme,
+		Theme:            cmp.Or(doc.System.Theme, doc.Theme),
+		SourceEncoding:   c.convertEncoding(doc.Encoding),
+		Compatibility:    schema.ConfigVersionNote(doc.Version),
+		CosmeticSections: convertCosmeticSections(doc),
 		System:           c.convertSystem(doc),
 		Interfaces:       c.convertInterfaces(doc),
 		VLANs:            c.convertVLANs(doc),
@@ -72,12 +91,15 @@ func (c *converter) ToCommonDevice(
 		InterfaceGroups:  c.convertInterfaceGroups(doc),
 		NamedObjects:     namedObjects,
 		FirewallRules:    c.convertFirewallRules(doc, namedObjects),
+		Schedules:        c.convertSchedules(doc),
 		NAT:              c.convertNAT(doc),
 		DHCP:             append(c.convertDHCP(doc), c.convertKeaDHCPScopes(doc)...),
 		DNS:              c.convertDNS(doc),
 		NTP:              c.convertNTP(doc),
 		SNMP:             c.convertSNMP(doc),
 		LoadBalancer:     c.convertLoadBalancer(doc),
+		WakeOnLAN:        c.convertWakeOnLAN(doc),
+		UPnP:             c.convertUPnP(doc),
 		VPN:              c.convertVPN(doc),
 		Routing:          c.convertRouting(doc),
 		HighAvailability: c.convertHA(doc),
@@ -85,14 +107,18 @@ func (c *converter) ToCommonDevice(
 		Syslog:           c.convertSyslog(doc),
 		Users:            c.convertUsers(doc),
 		Groups:           c.convertGroups(doc),
+		AuthServers:      c.convertAuthServers(doc),
+		PF:               c.convertPF(doc),
 		Sysctl:           c.convertSysctl(doc),
 		Revision:         c.convertRevision(doc),
 		Certificates:     c.convertCertificates(doc),
 		CAs:              c.convertCAs(doc),
+		CRLs:             c.convertCRLs(doc),
 		Packages:         c.convertPackages(doc),
 		Monit:            c.convertMonit(doc),
 		Netflow:          c.convertNetflow(doc),
 		TrafficShaper:    c.convertTrafficShaper(doc),
+		QueueStats:       c.convertQueueStats(doc.QueueStats),
 		CaptivePortal:    c.convertCaptivePortal(doc),
 		Cron:             c.convertCron(doc),
 		Trust:            c.convertTrust(doc),
@@ -102,6 +128,44 @@ func (c *converter) ToCommonDevice(
 	return device, c.warnings, nil
 }
 
+// convertEncoding returns the name of the input encoding recorded by the
+// parser, warning when invalid byte sequences were replaced while decoding.
+func (c *converter) convertEncoding(enc schema.InputEncoding) string {
+	if enc.Replacements > 0 {
+		c.addWarning("SourceEncoding", enc.Name,
+			fmt.Sprintf("%d invalid byte sequence(s) replaced with U+FFFD while decoding", enc.Replacements),
+			common.SeverityMedium)
+	}
+
+	return enc.Name
+}
+
+// convertCosmeticSections lists the presentation and telemetry sections
+// present in doc, in this order: the dashboard widgets, the web GUI theme,
+// the RRD graph data, and the legacy notification settings. Returns nil when
+// none are present.
+func convertCosmeticSections(doc *schema.OpnSenseDocument) []string {
+	var sections []string
+
+	if doc.Widgets.Sequence != "" || doc.Widgets.ColumnCount != "" || len(doc.Widgets.Extra) > 0 {
+		sections = append(sections, "widgets")
+	}
+
+	if doc.Theme != "" || doc.System.Theme != "" {
+		sections = append(sections, "theme")
+	}
+
+	if doc.RRDData != nil {
+		sections = append(sections, "rrddata")
+	}
+
+	if doc.Notifications != nil {
+		sections = append(sections, "notifications")
+	}
+
+	return sections
+}
+
 // convertSystem maps doc.System to common.System.
 // NOTE: SSH and WebGUI sub-structs are partially mapped; some fields
 // (SSH.Enabled, SSH.Port, WebGUI.LoginAutocomplete, etc.) are not yet populated.
@@ -137,11 +201,16 @@ func (c *converter) convertSystem(doc *schema.OpnSenseDocument) common.System {
 		Bogons:                        common.Bogons{Interval: sys.Bogons.Interval},
 		Notes:                         sys.Notes,
 		WebGUI: common.WebGUI{
-			Protocol:          sys.WebGUI.Protocol,
-			Port:              sys.WebGUI.Port,
-			SSLCertRef:        sys.WebGUI.SSLCertRef,
-			LoginAutocomplete: bool(sys.WebGUI.LoginAutocomplete),
-			MaxProcesses:      sys.WebGUI.MaxProcesses,
+			Protocol:            sys.WebGUI.Protocol,
+			Port:                sys.WebGUI.Port,
+			SSLCertRef:          sys.WebGUI.SSLCertRef,
+			LoginAutocomplete:   bool(sys.WebGUI.LoginAutocomplete),
+			MaxProcesses:        sys.WebGUI.MaxProcesses,
+			AuthMode:            sys.WebGUI.AuthMode,
+			Compression:         sys.WebGUI.Compression,
+			DisableHTTPRedirect: bool(sys.WebGUI.DisableHTTPRedirect),
+			NoHTTPRefererCheck:  bool(sys.WebGUI.NoHTTPRefererCheck),
+			SSLCiphers:          sys.WebGUI.SSLCiphers,
 		},
 		SSH: common.SSH{
 			Enabled: bool(sys.SSH.Enabled),
@@ -154,9 +223,31 @@ func (c *converter) convertSystem(doc *schema.OpnSenseDocument) common.System {
 			Flavour: sys.Firmware.Flavour,
 			Plugins: sys.Firmware.Plugins,
 		},
+		Console: c.convertConsole(sys.SerialSpeed, sys.PrimaryConsole),
 	}
 }
 
+// convertConsole maps the <serialspeed> and <primaryconsole> settings to
+// common.Console. A speed that is not a supported baud rate is dropped with a
+// warning; an unrecognized console type is kept and warned about.
+func (c *converter) convertConsole(serialSpeed, primaryConsole string) common.Console {
+	speed, err := parseOptionalInt(serialSpeed)
+	if err != nil || (speed != 0 && !common.ValidSerialSpeed(speed)) {
+		c.addIssue("System.Console.SerialSpeed", serialSpeed,
+			"serial console speed is not a supported baud rate", "ignored", common.SeverityLow)
+
+		speed = 0
+	}
+
+	console := strings.ToLower(strings.TrimSpace(primaryConsole))
+	if console != "" && !common.ValidPrimaryConsole(console) {
+		c.addWarning("System.Console.PrimaryConsole", primaryConsole,
+			"unrecognized primary console", common.SeverityLow)
+	}
+
+	return common.Console{SerialSpeed: speed, PrimaryConsole: console}
+}
+
 // convertInterfaces maps doc.Interfaces.Items to []common.Interface.
 func (c *converter) convertInterfaces(doc *schema.OpnSenseDocument) []common.Interface {
 	items := doc.Interfaces.Items
@@ -216,8 +307,8 @@ func (c *converter) convertVLANs(doc *schema.OpnSenseDocument) []common.VLAN {
 			Tag:         v.Tag,
 			Description: v.Descr,
 			VLANIf:      v.Vlanif,
-			Created:     v.Created,
-			Updated:     v.Updated,
+			Created:     common.NewChangeRecord(v.Created.Fields()),
+			Updated:     common.NewChangeRecord(v.Updated.Fields()),
 		})
 	}
 
@@ -330,6 +421,7 @@ func (c *converter) convertFirewallRules(
 			},
 			Target:          rule.Target,
 			Gateway:         rule.Gateway,
+			Schedule:        rule.Sched,
 			Log:             bool(rule.Log),
 			Disabled:        bool(rule.Disabled),
 			Tracker:         rule.Tracker,
@@ -347,12 +439,103 @@ func (c *converter) convertFirewallRules(
 			DisableReplyTo:  bool(rule.DisableReplyTo),
 			NoPfSync:        bool(rule.NoPfSync),
 			NoSync:          bool(rule.NoSync),
+			Created:         common.NewChangeRecord(rule.Created.Fields()),
+			Updated:         common.NewChangeRecord(rule.Updated.Fields()),
 		})
 	}
 
 	return result
 }
 
+// convertSchedules maps doc.Schedules to []common.Schedule. Time ranges whose
+// date or weekday lists cannot be parsed keep their hours and description but
+// drop the unparseable selection, and record a conversion warning.
+func (c *converter) convertSchedules(doc *schema.OpnSenseDocument) []common.Schedule {
+	if len(doc.Schedules.Schedule) == 0 {
+		return nil
+	}
+
+	result := make([]common.Schedule, 0, len(doc.Schedules.Schedule))
+	for i, sched := range doc.Schedules.Schedule {
+		ranges := make([]common.ScheduleTimeRange, 0, len(sched.TimeRanges))
+		for j, tr := range sched.TimeRanges {
+			field := fmt.Sprintf("Schedules[%d].TimeRanges[%d]", i, j)
+			ranges = append(ranges, common.ScheduleTimeRange{
+				Dates:       c.scheduleDates(field, tr),
+				Weekdays:    c.scheduleInts(field+".Position", tr.Position, 1, 7),
+				Hours:       strings.TrimSpace(tr.Hour),
+				Description: tr.RangeDescr,
+			})
+		}
+
+		result = append(result, common.Schedule{
+			Name:        sched.Name,
+			Description: sched.Descr,
+			TimeRanges:  ranges,
+		})
+	}
+
+	return result
+}
+
+// scheduleDates pairs the comma-separated month and day lists of a time range
+// into dates. Missing, unparseable or mismatched lists yield
+// nil; mismatched lengths record a conversion warning against field.
+func (c *converter) scheduleDates(field string, tr schema.ScheduleTimeRange) []common.ScheduleDate {
+	months := c.scheduleInts(field+".Month", tr.Month, 1, 12)
+	days := c.scheduleInts(field+".Day", tr.Day, 1, 31)
+	if months == nil || days == nil {
+		return nil
+	}
+
+	if len(months) != len(days) {
+		c.addWarning(field, tr.Month+" / "+tr.Day, "schedule month and day lists differ in length", common.SeverityLow)
+		return nil
+	}
+
+	dates := make([]common.ScheduleDate, len(months))
+	for k := range months {
+		dates[k] = common.ScheduleDate{Month: months[k], Day: days[k]}
+	}
+
+	return dates
+}
+
+// scheduleInts parses a comma-separated list of integers between lo and hi.
+// An empty value yields nil; a value with an invalid entry yields nil and
+// records a conversion warning against field.
+func (c *converter) scheduleInts(field, value string, lo, hi int) []int {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+
+	parts := strings.Split(value, ",")
+	result := make([]int, 0, len(parts))
+	for _, part := range parts {
+		n, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || n < lo || n > hi {
+			c.addIssue(field, value, fmt.Sprintf("schedule value is not a list of integers from %d to %d", lo, hi),
+				"value ignored", common.SeverityLow)
+			return nil
+		}
+		result = append(result, n)
+	}
+
+	return result
+}
+
+// parseOptionalInt parses an integer element value, ignoring surrounding
+// whitespace. An empty value yields 0 and no error.
+func parseOptionalInt(value string) (int, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
+	}
+
+	return strconv.Atoi(value)
+}
+
 // convertNAT maps doc.Nat and system fields to common.NATConfig.
 func (c *converter) convertNAT(doc *schema.OpnSenseDocument) common.NATConfig {
 	outboundMode := common.NATOutboundMode(doc.Nat.Outbound.Mode)
@@ -371,11 +554,40 @@ func (c *converter) convertNAT(doc *schema.OpnSenseDocument) common.NATConfig {
 		PfShareForward:     bool(doc.System.PfShareForward),
 		OutboundRules:      c.convertOutboundNATRules(doc.Nat.Outbound.Rule),
 		InboundRules:       c.convertInboundNATRules(doc.Nat.Inbound),
+		OneToOneRules:      c.convertOneToOneNATRules(doc.Nat.OneToOne),
+	}
+
+	for _, rule := range nat.OneToOneRules {
+		if !rule.Disabled && rule.IsBidirectional() {
+			nat.BiNATEnabled = true
+			break
+		}
 	}
 
+	c.warnNATReflectionOverrides(nat)
+
 	return nat
 }
 
+// warnNATReflectionOverrides records an info warning for every inbound NAT
+// rule returned by [common.NATConfig.ReflectionOverrides]. The rule-level
+// mode wins; the warning makes the disagreement visible.
+func (c *converter) warnNATReflectionOverrides(nat common.NATConfig) {
+	system := "enabled"
+	if nat.ReflectionDisabled {
+		system = "disabled"
+	}
+
+	for _, i := range nat.ReflectionOverrides() {
+		c.addWarning(
+			fmt.Sprintf("NAT.InboundRules[%d].NATReflection", i),
+			nat.InboundRules[i].NATReflection,
+			fmt.Sprintf("rule overrides the system NAT reflection default (%s system-wide)", system),
+			common.SeverityInfo,
+		)
+	}
+}
+
 // convertOutboundNATRules maps []schema.NATRule to []common.NATRule.
 func (c *converter) convertOutboundNATRules(rules []schema.NATRule) []common.NATRule {
 	if len(rules) == 0 {
@@ -428,6 +640,8 @@ func (c *converter) convertOutboundNATRules(rules []schema.NATRule) []common.NAT
 			Category:      r.Category,
 			Tag:           r.Tag,
 			Tagged:        r.Tagged,
+			Created:       common.NewChangeRecord(r.Created.Fields()),
+			Updated:       common.NewChangeRecord(r.Updated.Fields()),
 		})
 	}
 
@@ -469,6 +683,17 @@ func (c *converter) convertInboundNATRules(rules []schema.InboundRule) []common.
 			)
 		}
 
+		priority, err := parseOptionalInt(r.Priority)
+		if err != nil {
+			c.addIssue(
+				fmt.Sprintf("NAT.InboundRules[%d].Priority", i),
+				r.Priority,
+				"inbound NAT rule priority is not an integer",
+				"defaulted to 0",
+				common.SeverityLow,
+			)
+		}
+
 		result = append(result, common.InboundNATRule{
 			UUID:       r.UUID,
 			Interfaces: []string(r.Interface),
@@ -489,12 +714,74 @@ func (c *converter) convertInboundNATRules(rules []schema.InboundRule) []common.
 			Reflection:       r.Reflection,
 			NATReflection:    r.NATReflection,
 			AssociatedRuleID: r.AssociatedRuleID,
-			Priority:         r.Priority,
+			Priority:         priority,
 			NoRDR:            bool(r.NoRDR),
 			NoSync:           bool(r.NoSync),
 			Disabled:         bool(r.Disabled),
 			Log:              bool(r.Log),
 			Description:      r.Descr,
+			Created:          common.NewChangeRecord(r.Created.Fields()),
+			Updated:          common.NewChangeRecord(r.Updated.Fields()),
+		})
+	}
+
+	return result
+}
+
+// convertOneToOneNATRules maps []schema.OneToOneRule to []common.OneToOneNATRule.
+// The internal address is taken from the rule's source endpoint.
+func (c *converter) convertOneToOneNATRules(rules []schema.OneToOneRule) []common.OneToOneNATRule {
+	if len(rules) == 0 {
+		return nil
+	}
+
+	result := make([]common.OneToOneNATRule, 0, len(rules))
+	for i, r := range rules {
+		if r.External == "" {
+			c.addWarning(
+				fmt.Sprintf("NAT.OneToOneRules[%d].External", i),
+				r.UUID,
+				"1:1 NAT rule has no external address",
+				common.SeverityHigh,
+			)
+		}
+		if r.Interface.IsEmpty() {
+			c.addWarning(
+				fmt.Sprintf("NAT.OneToOneRules[%d].Interface", i),
+				r.UUID,
+				"1:1 NAT rule has no interface assigned",
+				common.SeverityMedium,
+			)
+		}
+
+		ipProto := common.IPProtocol(r.IPProtocol)
+		if r.IPProtocol != "" && !ipProto.IsValid() {
+			c.addWarning(
+				fmt.Sprintf("NAT.OneToOneRules[%d].IPProtocol", i),
+				r.IPProtocol,
+				"unrecognized IP protocol family",
+				common.SeverityLow,
+			)
+		}
+
+		result = append(result, common.OneToOneNATRule{
+			UUID:       r.UUID,
+			Interfaces: []string(r.Interface),
+			IPProtocol: ipProto,
+			Type:       r.Type,
+			External:   r.External,
+			Internal:   r.Source.EffectiveAddress(),
+			Destination: common.RuleEndpoint{
+				Address: r.Destination.EffectiveAddress(),
+				Port:    r.Destination.Port,
+			},
+			NATReflection: r.NATReflection,
+			Disabled:      bool(r.Disabled),
+			Log:           bool(r.Log),
+			Description:   r.Descr,
+			Category:      r.Category,
+			Created:       common.NewChangeRecord(r.Created.Fields()),
+			Updated:       common.NewChangeRecord(r.Updated.Fields()),
 		})
 	}
 
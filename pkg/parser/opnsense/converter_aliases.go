@@ -22,10 +22,14 @@ import (
 // map-key assignment overwrites — this is not a documented invariant,
 // just deterministic tie-breaking for a case that should never occur.
 func (c *converter) convertNamedObjects(doc *schema.OpnSenseDocument) common.NamedObjects {
-	var entries []schema.Alias
+	var (
+		entries  []schema.Alias
+		geoipURL string
+	)
 
 	if doc.OPNsense.Firewall != nil {
 		entries = append(entries, doc.OPNsense.Firewall.Alias.Aliases.Alias...)
+		geoipURL = strings.TrimSpace(doc.OPNsense.Firewall.Alias.Geoip.URL)
 	}
 	entries = append(entries, doc.Aliases.Alias...)
 
@@ -55,12 +59,28 @@ func (c *converter) convertNamedObjects(doc *schema.OpnSenseDocument) common.Nam
 			)
 		}
 
-		result[a.Name] = common.NamedObject{
+		obj := common.NamedObject{
 			Name:        a.Name,
 			Type:        objType,
-			Members:     splitAliasMembers(a.Content, a.Address),
 			Description: a.Description,
 		}
+
+		// External aliases list their sources, not their entries, in
+		// <content>: feed URLs for url/urltable and country codes for geoip.
+		members := splitAliasMembers(a.Content, a.Address)
+		switch objType {
+		case common.NamedObjectTypeURL, common.NamedObjectTypeURLTable, common.NamedObjectTypeURLTablePorts:
+			obj.URLs = members
+		case common.NamedObjectTypeGeoIP:
+			obj.Countries = members
+			if geoipURL != "" {
+				obj.URLs = []string{geoipURL}
+			}
+		default:
+			obj.Members = members
+		}
+
+		result[a.Name] = obj
 	}
 
 	if len(result) == 0 {
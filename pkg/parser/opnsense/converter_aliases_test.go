@@ -122,20 +122,54 @@ func TestConverter_NamedObjects_UnknownType_Warns(t *testing.T) {
 	t.Parallel()
 
 	doc := withMVCAliases(schema.NewOpnSenseDocument(),
-		schema.Alias{Name: "WEIRD_ALIAS", Type: "urltable", Content: "http://example.com/list.txt"},
+		schema.Alias{Name: "WEIRD_ALIAS", Type: "dynipv6host", Content: "::1000"},
 	)
 
 	device, warnings, err := opnsense.ConvertDocument(doc)
 	require.NoError(t, err)
 	require.Len(t, warnings, 1)
 	assert.Equal(t, "NamedObjects[WEIRD_ALIAS].Type", warnings[0].Field)
-	assert.Equal(t, "urltable", warnings[0].Value)
+	assert.Equal(t, "dynipv6host", warnings[0].Value)
 	assert.Equal(t, common.SeverityLow, warnings[0].Severity)
 
 	// The alias is still captured (fail-open), just with an unvalidated Type.
 	obj, ok := device.NamedObjects["WEIRD_ALIAS"]
 	require.True(t, ok)
-	assert.Equal(t, common.NamedObjectType("urltable"), obj.Type)
+	assert.Equal(t, common.NamedObjectType("dynipv6host"), obj.Type)
+	assert.Equal(t, []string{"::1000"}, obj.Members)
+}
+
+func TestConverter_NamedObjects_ExternalSources(t *testing.T) {
+	t.Parallel()
+
+	doc := withMVCAliases(schema.NewOpnSenseDocument(),
+		schema.Alias{
+			Name:    "FEED",
+			Type:    "urltable",
+			Content: "https://example.com/drop.txt\nhttps://example.com/edrop.txt",
+		},
+		schema.Alias{Name: "BAD_COUNTRIES", Type: "geoip", Content: "RU\nKP"},
+		schema.Alias{Name: "FETCHED", Type: "url", Content: "https://example.com/hosts.txt"},
+	)
+	doc.OPNsense.Firewall.Alias.Geoip.URL = "https://example.com/geoip.zip"
+
+	device, warnings, err := opnsense.ConvertDocument(doc)
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+
+	assert.Equal(t, common.NamedObject{
+		Name: "FEED",
+		Type: common.NamedObjectTypeURLTable,
+		URLs: []string{"https://example.com/drop.txt", "https://example.com/edrop.txt"},
+	}, device.NamedObjects["FEED"])
+	assert.Equal(t, common.NamedObject{
+		Name:      "BAD_COUNTRIES",
+		Type:      common.NamedObjectTypeGeoIP,
+		URLs:      []string{"https://example.com/geoip.zip"},
+		Countries: []string{"RU", "KP"},
+	}, device.NamedObjects["BAD_COUNTRIES"])
+	assert.Equal(t, []string{"https://example.com/hosts.txt"}, device.NamedObjects["FETCHED"].URLs)
+	assert.Empty(t, device.NamedObjects["FETCHED"].Members)
 }
 
 func TestConverter_NamedObjects_EmptyName_Warns(t *testing.T) {
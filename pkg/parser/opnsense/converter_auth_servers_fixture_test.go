@@ -0,0 +1,71 @@
+package opnsense_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/analysis"
+	"github.com/EvilBit-Labs/opnDossier/internal/cfgparser"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/EvilBit-Labs/opnDossier/pkg/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParser_OPNsenseAuthServersFixture parses testdata/auth_servers_test.xml
+// end-to-end and proves both LDAP servers reach the CommonDevice with their
+// transports normalized and the bind password redacted, and that the plain
+// LDAP server and the unused LDAPS server produce the expected findings.
+func TestParser_OPNsenseAuthServersFixture(t *testing.T) {
+	t.Parallel()
+
+	fpath := filepath.Join("..", "..", "..", "testdata", "auth_servers_test.xml")
+	f, err := os.Open(fpath)
+	require.NoError(t, err)
+	defer f.Close()
+
+	factory := parser.NewFactory(cfgparser.NewXMLParser())
+	device, _, err := factory.CreateDevice(context.Background(), f, common.DeviceTypeUnknown, false)
+	require.NoError(t, err)
+	require.NotNil(t, device)
+
+	assert.Equal(t, "Local Database,corp-ldap", device.System.WebGUI.AuthMode)
+	assert.Equal(t, []common.AuthServer{
+		{
+			RefID:          "64b7c1f2a9e01",
+			Name:           "corp-ldap",
+			Type:           common.AuthServerLDAP,
+			Host:           "dc1.example.com",
+			Port:           "389",
+			Transport:      common.LDAPTransportTCP,
+			Scope:          "subtree",
+			BaseDN:         "dc=example,dc=com",
+			AuthContainers: "ou=staff,dc=example,dc=com",
+			BindDN:         "cn=opnsense,ou=service,dc=example,dc=com",
+			BindPassword:   "[REDACTED]",
+		},
+		{
+			RefID:          "64b7c1f2a9e02",
+			Name:           "corp-ldaps",
+			Type:           common.AuthServerLDAP,
+			Host:           "dc2.example.com",
+			Port:           "636",
+			Transport:      common.LDAPTransportSSL,
+			Scope:          "one",
+			BaseDN:         "dc=example,dc=com",
+			AuthContainers: "ou=staff,dc=example,dc=com",
+		},
+	}, device.AuthServers)
+
+	findings := make(map[string]common.Severity)
+	for _, f := range analysis.DetectSecurityIssues(device) {
+		findings[f.Component+": "+f.Issue] = f.Severity
+	}
+
+	assert.Equal(t, common.SeverityHigh, findings["system.authserver[0]: LDAP Authentication Over Plain TCP"])
+	assert.Equal(t, common.SeverityInfo, findings["system.authserver[1]: Unused Authentication Server"])
+	assert.NotContains(t, findings, "system.authserver[0]: Unused Authentication Server")
+	assert.NotContains(t, findings, "system.authserver[1]: LDAP Authentication Over Plain TCP")
+}
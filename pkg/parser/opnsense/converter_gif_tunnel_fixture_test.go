@@ -0,0 +1,49 @@
+package opnsense_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/analysis"
+	"github.com/EvilBit-Labs/opnDossier/internal/cfgparser"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/EvilBit-Labs/opnDossier/pkg/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParser_OPNsenseGIFTunnelFixture parses testdata/gif_tunnel_test.xml
+// end-to-end and proves the GIF tunnel reaches the CommonDevice with its
+// endpoints, and that it keeps WAN out of the unused-interface findings.
+func TestParser_OPNsenseGIFTunnelFixture(t *testing.T) {
+	t.Parallel()
+
+	fpath := filepath.Join("..", "..", "..", "testdata", "gif_tunnel_test.xml")
+	f, err := os.Open(fpath)
+	require.NoError(t, err)
+	defer f.Close()
+
+	factory := parser.NewFactory(cfgparser.NewXMLParser())
+	device, _, err := factory.CreateDevice(context.Background(), f, common.DeviceTypeUnknown, false)
+	require.NoError(t, err)
+	require.NotNil(t, device)
+
+	assert.Equal(t, []common.GIF{{
+		Interface:           "gif0",
+		Local:               "wan",
+		Remote:              "209.51.181.2",
+		TunnelLocalAddress:  "2001:db8:1::2",
+		TunnelRemoteAddress: "2001:db8:1::1",
+		TunnelSubnetBits:    "64",
+		Description:         "HE IPv6 Tunnel",
+	}}, device.GIFs)
+
+	unused := make([]string, 0)
+	for _, finding := range analysis.DetectUnusedInterfaces(device) {
+		unused = append(unused, finding.InterfaceName)
+	}
+	assert.Equal(t, []string{"lan"}, unused)
+	assert.Empty(t, analysis.DetectTunnelIssues(device))
+}
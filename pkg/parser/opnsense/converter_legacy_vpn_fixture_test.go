@@ -0,0 +1,71 @@
+package opnsense_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/analysis"
+	"github.com/EvilBit-Labs/opnDossier/internal/cfgparser"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/EvilBit-Labs/opnDossier/pkg/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParser_OPNsenseLegacyVPNFixture parses testdata/legacy_vpn_test.xml
+// end-to-end and proves the enabled PPTP server and the disabled L2TP section
+// reach the CommonDevice without their passwords, that the malformed L2TP
+// address count surfaces as a conversion warning, and that both sections
+// produce the expected security findings.
+func TestParser_OPNsenseLegacyVPNFixture(t *testing.T) {
+	t.Parallel()
+
+	fpath := filepath.Join("..", "..", "..", "testdata", "legacy_vpn_test.xml")
+	f, err := os.Open(fpath)
+	require.NoError(t, err)
+	defer f.Close()
+
+	factory := parser.NewFactory(cfgparser.NewXMLParser())
+	device, warnings, err := factory.CreateDevice(context.Background(), f, common.DeviceTypeUnknown, false)
+	require.NoError(t, err)
+	require.NotNil(t, device)
+
+	require.NotNil(t, device.VPN.PPTP)
+	assert.Equal(t, common.LegacyRemoteAccessVPN{
+		Enabled:            true,
+		Mode:               "server",
+		LocalAddress:       "10.10.10.1",
+		RemoteAddressStart: "10.10.10.100",
+		RemoteAddressCount: 16,
+		Authentication:     "mschapv2",
+		Users: []common.LegacyVPNUser{
+			{Name: "alice", IPAddress: "10.10.10.150"},
+			{Name: "bob"},
+		},
+	}, *device.VPN.PPTP)
+
+	require.NotNil(t, device.VPN.L2TP)
+	assert.False(t, device.VPN.L2TP.Enabled)
+	assert.Equal(t, "wan", device.VPN.L2TP.Interface)
+	assert.Equal(t, "chap", device.VPN.L2TP.Authentication)
+	assert.Zero(t, device.VPN.L2TP.RemoteAddressCount)
+
+	var warned bool
+	for _, w := range warnings {
+		if w.Field == "VPN.L2TP.RemoteAddressCount" {
+			warned = true
+			assert.Equal(t, "ten", w.Value)
+		}
+	}
+	assert.True(t, warned, "malformed n_l2tp_units must produce a conversion warning")
+
+	severities := make(map[string]common.Severity)
+	for _, f := range analysis.DetectSecurityIssues(device) {
+		severities[f.Component] = f.Severity
+	}
+
+	assert.Equal(t, common.SeverityCritical, severities["vpn.pptp"])
+	assert.Equal(t, common.SeverityInfo, severities["vpn.l2tp"])
+}
@@ -0,0 +1,70 @@
+package opnsense_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/analysis"
+	"github.com/EvilBit-Labs/opnDossier/internal/cfgparser"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/EvilBit-Labs/opnDossier/pkg/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParser_OPNsenseLoadBalancerFixture parses testdata/load_balancer_test.xml
+// end-to-end and proves pools and virtual servers reach the CommonDevice, the
+// VIP marks the DMZ interface carrying its address as used, and the pool's
+// dangling monitor reference surfaces as a consistency finding.
+func TestParser_OPNsenseLoadBalancerFixture(t *testing.T) {
+	t.Parallel()
+
+	fpath := filepath.Join("..", "..", "..", "testdata", "load_balancer_test.xml")
+	f, err := os.Open(fpath)
+	require.NoError(t, err)
+	defer f.Close()
+
+	factory := parser.NewFactory(cfgparser.NewXMLParser())
+	device, _, err := factory.CreateDevice(context.Background(), f, common.DeviceTypeUnknown, false)
+	require.NoError(t, err)
+	require.NotNil(t, device)
+
+	lb := device.LoadBalancer
+	require.Len(t, lb.MonitorTypes, 1)
+	require.Len(t, lb.Pools, 1)
+	require.Len(t, lb.VirtualServers, 1)
+
+	assert.Equal(t, common.LBPool{
+		Name:        "web-pool",
+		Mode:        "loadbalance",
+		Description: "Internal web servers",
+		Port:        "8080",
+		Retry:       "3",
+		Monitor:     "HTTPS-custom",
+		Servers:     []string{"10.0.1.10", "10.0.1.11"},
+	}, lb.Pools[0])
+
+	assert.Equal(t, common.LBVirtualServer{
+		Name:          "web-vip",
+		Description:   "Public web front end",
+		Address:       "172.16.10.100",
+		Port:          "443",
+		Pool:          "web-pool",
+		Mode:          "redirect_mode",
+		RelayProtocol: "tcp",
+	}, lb.VirtualServers[0])
+
+	for _, f := range analysis.DetectUnusedInterfaces(device) {
+		assert.NotEqual(t, "opt1", f.InterfaceName, "interface carrying the VIP must be marked used")
+	}
+
+	var issues []string
+	for _, f := range analysis.DetectConsistency(device) {
+		issues = append(issues, f.Issue)
+	}
+
+	assert.Contains(t, issues, "Load Balancer Pool References Non-existent Monitor")
+	assert.NotContains(t, issues, "Load Balancer Virtual Server Has No Backends")
+}
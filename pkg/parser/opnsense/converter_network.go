@@ -1,6 +1,7 @@
 package opnsense
 
 import (
+	"cmp"
 	"fmt"
 
 	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
@@ -52,7 +53,7 @@ func (c *converter) convertPPPs(doc *schema.OpnSenseDocument) []common.PPP {
 // convertGIFs maps doc.GIFInterfaces.Gif to []common.GIF.
 // GIF (Generic Tunnel Interface) entries encapsulate IPv4-in-IPv4 or IPv6-in-IPv4
 // tunnels. The Gifif field is the tunnel interface name (e.g., "gif0"), while If
-// is the parent physical interface.
+// (local-addr on MVC-based configurations) is the parent physical interface.
 func (c *converter) convertGIFs(doc *schema.OpnSenseDocument) []common.GIF {
 	if len(doc.GIFInterfaces.Gif) == 0 {
 		return nil
@@ -61,12 +62,15 @@ func (c *converter) convertGIFs(doc *schema.OpnSenseDocument) []common.GIF {
 	result := make([]common.GIF, 0, len(doc.GIFInterfaces.Gif))
 	for _, g := range doc.GIFInterfaces.Gif {
 		result = append(result, common.GIF{
-			Interface:   g.Gifif,
-			Local:       g.If,
-			Remote:      g.Remote,
-			Description: g.Descr,
-			Created:     g.Created,
-			Updated:     g.Updated,
+			Interface:           g.Gifif,
+			Local:               cmp.Or(g.If, g.LocalAddr),
+			Remote:              cmp.Or(g.RemoteAddr, g.Remote),
+			TunnelLocalAddress:  g.TunnelLocalAddr,
+			TunnelRemoteAddress: g.TunnelRemoteAddr,
+			TunnelSubnetBits:    g.TunnelRemoteNet,
+			Description:         g.Descr,
+			Created:             g.Created,
+			Updated:             g.Updated,
 		})
 	}
 
@@ -76,7 +80,7 @@ func (c *converter) convertGIFs(doc *schema.OpnSenseDocument) []common.GIF {
 // convertGREs maps doc.GREInterfaces.Gre to []common.GRE.
 // GRE (Generic Routing Encapsulation) entries define point-to-point tunnel
 // interfaces. The Greif field is the tunnel interface name (e.g., "gre0"), while
-// If is the parent physical interface.
+// If (local-addr on MVC-based configurations) is the parent physical interface.
 func (c *converter) convertGREs(doc *schema.OpnSenseDocument) []common.GRE {
 	if len(doc.GREInterfaces.Gre) == 0 {
 		return nil
@@ -85,12 +89,15 @@ func (c *converter) convertGREs(doc *schema.OpnSenseDocument) []common.GRE {
 	result := make([]common.GRE, 0, len(doc.GREInterfaces.Gre))
 	for _, g := range doc.GREInterfaces.Gre {
 		result = append(result, common.GRE{
-			Interface:   g.Greif,
-			Local:       g.If,
-			Remote:      g.Remote,
-			Description: g.Descr,
-			Created:     g.Created,
-			Updated:     g.Updated,
+			Interface:           g.Greif,
+			Local:               cmp.Or(g.If, g.LocalAddr),
+			Remote:              cmp.Or(g.RemoteAddr, g.Remote),
+			TunnelLocalAddress:  g.TunnelLocalAddr,
+			TunnelRemoteAddress: g.TunnelRemoteAddr,
+			TunnelSubnetBits:    g.TunnelRemoteNet,
+			Description:         g.Descr,
+			Created:             g.Created,
+			Updated:             g.Updated,
 		})
 	}
 
@@ -347,6 +347,45 @@ func TestConverter_GREs_FieldMapping(t *testing.T) {
 	assert.Equal(t, "2024-02-10", g.Updated)
 }
 
+func TestConverter_Tunnels_MVCFields(t *testing.T) {
+	t.Parallel()
+
+	doc := schema.NewOpnSenseDocument()
+	doc.GIFInterfaces.Gif = []schema.GIF{{
+		Gifif:            "gif0",
+		LocalAddr:        "wan",
+		RemoteAddr:       "209.51.181.2",
+		TunnelLocalAddr:  "2001:db8:1::2",
+		TunnelRemoteAddr: "2001:db8:1::1",
+		TunnelRemoteNet:  "64",
+	}}
+	doc.GREInterfaces.Gre = []schema.GRE{{
+		Greif:      "gre0",
+		If:         "opt1",
+		LocalAddr:  "wan",
+		RemoteAddr: "198.51.100.1",
+		Remote:     "198.51.100.9",
+	}}
+
+	device, _, err := opnsense.ConvertDocument(doc)
+	require.NoError(t, err)
+	require.Len(t, device.GIFs, 1)
+	require.Len(t, device.GREs, 1)
+
+	assert.Equal(t, common.GIF{
+		Interface:           "gif0",
+		Local:               "wan",
+		Remote:              "209.51.181.2",
+		TunnelLocalAddress:  "2001:db8:1::2",
+		TunnelRemoteAddress: "2001:db8:1::1",
+		TunnelSubnetBits:    "64",
+	}, device.GIFs[0])
+
+	// The legacy <if> and <remote-addr> elements take precedence.
+	assert.Equal(t, "opt1", device.GREs[0].Local)
+	assert.Equal(t, "198.51.100.1", device.GREs[0].Remote)
+}
+
 func TestConverter_LAGGs(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,71 @@
+package opnsense_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/analysis"
+	"github.com/EvilBit-Labs/opnDossier/internal/cfgparser"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/EvilBit-Labs/opnDossier/pkg/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParser_OPNsenseOneToOneNATFixture parses testdata/onetoone_nat_test.xml
+// end-to-end and proves both <onetoone> entries reach the CommonDevice, that
+// the NAT summary counts them, and that only the subnet mapping is reported
+// as a Medium finding.
+func TestParser_OPNsenseOneToOneNATFixture(t *testing.T) {
+	t.Parallel()
+
+	fpath := filepath.Join("..", "..", "..", "testdata", "onetoone_nat_test.xml")
+	f, err := os.Open(fpath)
+	require.NoError(t, err)
+	defer f.Close()
+
+	factory := parser.NewFactory(cfgparser.NewXMLParser())
+	device, warnings, err := factory.CreateDevice(context.Background(), f, common.DeviceTypeUnknown, false)
+	require.NoError(t, err)
+	require.NotNil(t, device)
+	assert.Empty(t, warnings)
+
+	assert.Equal(t, []common.OneToOneNATRule{
+		{
+			UUID:        "6f1c2a3e-0001-4b7a-9c1d-000000000001",
+			Interfaces:  []string{"wan"},
+			IPProtocol:  common.IPProtocolInet,
+			Type:        common.OneToOneTypeBINAT,
+			External:    "203.0.113.10",
+			Internal:    "192.168.1.25",
+			Destination: common.RuleEndpoint{Address: "any"},
+			Description: "Mail server",
+		},
+		{
+			UUID:        "6f1c2a3e-0002-4b7a-9c1d-000000000002",
+			Interfaces:  []string{"wan"},
+			IPProtocol:  common.IPProtocolInet,
+			Type:        common.OneToOneTypeBINAT,
+			External:    "203.0.113.64",
+			Internal:    "10.20.0.0/27",
+			Destination: common.RuleEndpoint{Address: "any"},
+			Description: "DMZ block",
+		},
+	}, device.NAT.OneToOneRules)
+	assert.True(t, device.NAT.BiNATEnabled)
+	assert.Len(t, device.NATSummary().OneToOneRules, 2)
+
+	var natFindings []common.SecurityFinding
+	for _, f := range analysis.DetectSecurityIssues(device) {
+		if f.Issue == "1:1 NAT of an Entire Subnet" {
+			natFindings = append(natFindings, f)
+		}
+	}
+
+	require.Len(t, natFindings, 1)
+	assert.Equal(t, common.SeverityMedium, natFindings[0].Severity)
+	assert.Equal(t, "nat.onetoone[1]", natFindings[0].Component)
+	assert.Contains(t, natFindings[0].Description, "203.0.113.64/27")
+}
@@ -1,7 +1,12 @@
 package opnsense
 
 import (
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
 	"fmt"
+	"strconv"
+	"strings"
 
 	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
 	schema "github.com/EvilBit-Labs/opnDossier/pkg/schema/opnsense"
@@ -10,6 +15,8 @@ import (
 // convertCertificates maps doc.Certs to []common.Certificate.
 // Certificate data includes the PEM-encoded certificate and private key, which
 // are stored as base64-encoded strings in the OPNsense XML configuration.
+// Subject, Issuer, NotBefore and NotAfter are read from the decoded
+// certificate and stay empty when it is missing or cannot be decoded.
 func (c *converter) convertCertificates(doc *schema.OpnSenseDocument) []common.Certificate {
 	if len(doc.Certs) == 0 {
 		return nil
@@ -26,12 +33,22 @@ func (c *converter) convertCertificates(doc *schema.OpnSenseDocument) []common.C
 			)
 		}
 
-		result = append(result, common.Certificate{
+		converted := common.Certificate{
 			RefID:       cert.Refid,
 			Description: cert.Descr,
+			CARef:       cert.Caref,
 			Certificate: cert.Crt,
 			PrivateKey:  cert.Prv,
-		})
+		}
+
+		if parsed, err := cert.ParseCertificate(); err == nil {
+			converted.Subject = parsed.Subject.String()
+			converted.Issuer = parsed.Issuer.String()
+			converted.NotBefore = parsed.NotBefore.Unix()
+			converted.NotAfter = parsed.NotAfter.Unix()
+		}
+
+		result = append(result, converted)
 	}
 
 	return result
@@ -60,6 +77,110 @@ func (c *converter) convertCAs(doc *schema.OpnSenseDocument) []common.Certificat
 	return result
 }
 
+// convertCRLs maps doc.CRLs to []common.CRL. IssuedAt and NextUpdate are
+// taken from the signed list in <text>; when it is missing or cannot be
+// decoded both stay zero, so the list's expiry is unknown, and an
+// undecodable list records a conversion warning. Revoked certificate bodies
+// and private keys are not carried over.
+func (c *converter) convertCRLs(doc *schema.OpnSenseDocument) []common.CRL {
+	if len(doc.CRLs) == 0 {
+		return nil
+	}
+
+	result := make([]common.CRL, 0, len(doc.CRLs))
+	for i, crl := range doc.CRLs {
+		converted := common.CRL{
+			RefID:       crl.Refid,
+			Description: crl.Descr,
+			CARef:       crl.Caref,
+			Method:      crl.Method,
+			Serial:      crl.Serial,
+			Lifetime:    c.crlLifetime(fmt.Sprintf("CRLs[%d].Lifetime", i), crl.Lifetime),
+		}
+
+		for _, entry := range crl.Certs {
+			converted.Revoked = append(converted.Revoked, common.RevokedCertificate{
+				RefID:       entry.Refid,
+				Description: entry.Descr,
+				Reason:      entry.Reason.String(),
+				RevokedAt:   parseUnixSeconds(entry.RevokeTime),
+			})
+		}
+
+		if strings.TrimSpace(crl.Text) != "" {
+			list, err := parseSignedCRL(crl.Text)
+			if err != nil {
+				c.addWarning(
+					fmt.Sprintf("CRLs[%d].Text", i),
+					crl.Refid,
+					"signed CRL could not be decoded; its issue and expiry times are unknown",
+					common.SeverityLow,
+				)
+			} else {
+				converted.IssuedAt = list.ThisUpdate.Unix()
+				if !list.NextUpdate.IsZero() {
+					converted.NextUpdate = list.NextUpdate.Unix()
+				}
+			}
+		}
+
+		result = append(result, converted)
+	}
+
+	return result
+}
+
+// crlLifetime parses a CRL lifetime in days. An empty value yields 0; a
+// non-numeric or negative value yields 0 and records a conversion warning
+// against field.
+func (c *converter) crlLifetime(field, value string) int {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		c.addWarning(field, value, "CRL lifetime is not a non-negative number of days", common.SeverityLow)
+		return 0
+	}
+
+	return n
+}
+
+// parseSignedCRL decodes a signed CRL stored as base64-encoded PEM or DER.
+func parseSignedCRL(text string) (*x509.RevocationList, error) {
+	der, err := base64.StdEncoding.DecodeString(strings.TrimSpace(text))
+	if err != nil {
+		return nil, fmt.Errorf("decode CRL text: %w", err)
+	}
+
+	if block, _ := pem.Decode(der); block != nil {
+		der = block.Bytes
+	}
+
+	list, err := x509.ParseRevocationList(der)
+	if err != nil {
+		return nil, fmt.Errorf("parse CRL: %w", err)
+	}
+
+	return list, nil
+}
+
+// parseUnixSeconds parses a Unix timestamp such as "1700000000" or
+// "1700000000.1234", dropping any fractional part. It returns 0 when value
+// is empty or malformed.
+func parseUnixSeconds(value string) int64 {
+	whole, _, _ := strings.Cut(strings.TrimSpace(value), ".")
+
+	n, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil || n < 0 {
+		return 0
+	}
+
+	return n
+}
+
 // convertPackages extracts installed firmware plugin names from
 // doc.System.Firmware.Plugins. OPNsense stores plugin names as a
 // comma-separated string in the XML configuration. Full package metadata
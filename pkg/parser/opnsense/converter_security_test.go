@@ -1,7 +1,16 @@
 package opnsense_test
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
 	"testing"
+	"time"
 
 	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
 	"github.com/EvilBit-Labs/opnDossier/pkg/parser/opnsense"
@@ -85,6 +94,48 @@ func TestConverter_Certificates_FieldMapping(t *testing.T) {
 	assert.Equal(t, "LS0tLS1CRUdJTk...", cert.PrivateKey)
 }
 
+func TestConverter_Certificates_DecodedFields(t *testing.T) {
+	t.Parallel()
+
+	notAfter := time.Date(2027, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "fw.example.com"},
+		NotBefore:    notAfter.AddDate(-1, 0, 0),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	crt := base64.StdEncoding.EncodeToString(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+
+	doc := schema.NewOpnSenseDocument()
+	doc.Certs = []schema.Cert{
+		{Refid: "cert-001", Descr: "Web GUI", Crt: crt},
+		{Refid: "cert-002", Descr: "Issued", Caref: "ca-001", Crt: "MIIB..."},
+	}
+
+	device, warnings, err := opnsense.ConvertDocument(doc)
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+	require.Len(t, device.Certificates, 2)
+
+	cert := device.Certificates[0]
+	assert.Equal(t, "CN=fw.example.com", cert.Subject)
+	assert.Equal(t, "CN=fw.example.com", cert.Issuer)
+	assert.Equal(t, notAfter.AddDate(-1, 0, 0).Unix(), cert.NotBefore)
+	assert.Equal(t, notAfter.Unix(), cert.NotAfter)
+	assert.True(t, cert.SelfSigned())
+
+	// Undecodable data keeps the entry but leaves the decoded fields unknown.
+	issued := device.Certificates[1]
+	assert.Equal(t, "ca-001", issued.CARef)
+	assert.Empty(t, issued.Subject)
+	assert.Zero(t, issued.NotAfter)
+}
+
 func TestConverter_CAs(t *testing.T) {
 	t.Parallel()
 
@@ -162,6 +213,140 @@ func TestConverter_CAs_FieldMapping(t *testing.T) {
 	assert.Equal(t, "3", ca.Serial)
 }
 
+func TestConverter_CRLs_FieldMapping(t *testing.T) {
+	t.Parallel()
+
+	doc := schema.NewOpnSenseDocument()
+	doc.CRLs = []schema.CRL{
+		{
+			Refid:    "5f1a2b3c4d5e6",
+			Descr:    "VPN CRL",
+			Caref:    "ca-1",
+			Method:   "internal",
+			Serial:   "2",
+			Lifetime: "365",
+			Certs: []schema.CRLEntry{
+				{Refid: "cert-1", Descr: "alice", Crt: "MIIB...", Prv: "MIIE...", Reason: "1", RevokeTime: "1700000000"},
+				{Refid: "cert-2", Descr: "bob", Reason: "-1", RevokeTime: "1700000500.25"},
+			},
+		},
+	}
+
+	device, warnings, err := opnsense.ConvertDocument(doc)
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+	require.Len(t, device.CRLs, 1)
+
+	crl := device.CRLs[0]
+	assert.Equal(t, "5f1a2b3c4d5e6", crl.RefID)
+	assert.Equal(t, "VPN CRL", crl.Description)
+	assert.Equal(t, "ca-1", crl.CARef)
+	assert.Equal(t, "internal", crl.Method)
+	assert.Equal(t, "2", crl.Serial)
+	assert.Equal(t, 365, crl.Lifetime)
+	// Without a signed list in <text>, the issue time and expiry are unknown.
+	assert.Zero(t, crl.IssuedAt)
+	_, ok := crl.ExpiresAt()
+	assert.False(t, ok)
+	assert.Equal(t, []common.RevokedCertificate{
+		{RefID: "cert-1", Description: "alice", Reason: "Key Compromise", RevokedAt: 1700000000},
+		{RefID: "cert-2", Description: "bob", Reason: "No Status (default)", RevokedAt: 1700000500},
+	}, crl.Revoked)
+}
+
+func TestConverter_CRLs_IssuedAtFromSignedList(t *testing.T) {
+	t.Parallel()
+
+	thisUpdate := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	doc := schema.NewOpnSenseDocument()
+	doc.CRLs = []schema.CRL{
+		{
+			Refid:    "crl-1",
+			Lifetime: "30",
+			Text:     signedTestCRL(t, thisUpdate),
+			Certs:    []schema.CRLEntry{{Refid: "cert-1", RevokeTime: "1700000000"}},
+		},
+	}
+
+	device, _, err := opnsense.ConvertDocument(doc)
+	require.NoError(t, err)
+	require.Len(t, device.CRLs, 1)
+	assert.Equal(t, thisUpdate.Unix(), device.CRLs[0].IssuedAt)
+	assert.Equal(t, thisUpdate.AddDate(0, 0, 7).Unix(), device.CRLs[0].NextUpdate)
+
+	// The signed nextUpdate takes precedence over the configured lifetime.
+	expires, ok := device.CRLs[0].ExpiresAt()
+	require.True(t, ok)
+	assert.Equal(t, thisUpdate.AddDate(0, 0, 7), expires)
+}
+
+func TestConverter_CRLs_Warnings(t *testing.T) {
+	t.Parallel()
+
+	doc := schema.NewOpnSenseDocument()
+	doc.CRLs = []schema.CRL{
+		{Refid: "crl-1", Lifetime: "365"},
+		{Refid: "crl-2", Lifetime: "forever"},
+		{
+			Refid:    "crl-3",
+			Lifetime: "30",
+			Text:     base64.StdEncoding.EncodeToString([]byte("not a CRL")),
+			Certs:    []schema.CRLEntry{{Refid: "cert-1", RevokeTime: "1700000000"}},
+		},
+	}
+
+	device, warnings, err := opnsense.ConvertDocument(doc)
+	require.NoError(t, err)
+	require.Len(t, warnings, 2)
+	assert.Equal(t, "CRLs[1].Lifetime", warnings[0].Field)
+	assert.Equal(t, "forever", warnings[0].Value)
+	assert.Equal(t, common.SeverityLow, warnings[0].Severity)
+	assert.Equal(t, "CRLs[2].Text", warnings[1].Field)
+	assert.Equal(t, "crl-3", warnings[1].Value)
+	assert.Equal(t, common.SeverityLow, warnings[1].Severity)
+	require.Len(t, device.CRLs, 3)
+	assert.Zero(t, device.CRLs[1].Lifetime)
+
+	// An undecodable list leaves the expiry unknown rather than guessing it
+	// from revocation times.
+	_, ok := device.CRLs[2].ExpiresAt()
+	assert.False(t, ok)
+}
+
+// signedTestCRL returns a base64-encoded PEM CRL, as stored in <text>,
+// signed by a throwaway CA with the given thisUpdate time.
+func signedTestCRL(t *testing.T, thisUpdate time.Time) string {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test CA"},
+		NotBefore:             thisUpdate.AddDate(-1, 0, 0),
+		NotAfter:              thisUpdate.AddDate(1, 0, 0),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &key.PublicKey, key)
+	require.NoError(t, err)
+	ca, err := x509.ParseCertificate(caDER)
+	require.NoError(t, err)
+
+	crlDER, err := x509.CreateRevocationList(rand.Reader, &x509.RevocationList{
+		Number:     big.NewInt(1),
+		ThisUpdate: thisUpdate,
+		NextUpdate: thisUpdate.AddDate(0, 0, 7),
+	}, ca, key)
+	require.NoError(t, err)
+
+	crlPEM := pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: crlDER})
+	return base64.StdEncoding.EncodeToString(crlPEM)
+}
+
 func TestConverter_Packages(t *testing.T) {
 	t.Parallel()
 
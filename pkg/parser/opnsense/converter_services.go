@@ -2,13 +2,16 @@ package opnsense
 
 import (
 	"fmt"
+	"net"
 	"net/netip"
 	"slices"
+	"strconv"
 	"strings"
 	"unicode"
 
 	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
 	schema "github.com/EvilBit-Labs/opnDossier/pkg/schema/opnsense"
+	"github.com/EvilBit-Labs/opnDossier/pkg/schema/shared"
 )
 
 // knownUnboundPlusVersions enumerates the OPNsense <unboundplus version="...">
@@ -53,7 +56,7 @@ func (c *converter) convertDHCP(doc *schema.OpnSenseDocument) []common.DHCPScope
 		scope.AdvancedV4 = c.buildDHCPAdvancedV4(d)
 		scope.AdvancedV6 = c.buildDHCPAdvancedV6(d)
 
-		scope.StaticLeases = c.convertStaticLeases(d.Staticmap)
+		scope.StaticLeases = c.convertStaticLeases(key, d.Staticmap)
 		scope.NumberOptions = c.convertNumberOptions(d.NumberOptions)
 
 		result = append(result, scope)
@@ -62,14 +65,30 @@ func (c *converter) convertDHCP(doc *schema.OpnSenseDocument) []common.DHCPScope
 	return result
 }
 
-// convertStaticLeases maps []schema.DHCPStaticLease to []common.DHCPStaticLease.
-func (c *converter) convertStaticLeases(leases []schema.DHCPStaticLease) []common.DHCPStaticLease {
+// convertStaticLeases maps the static leases of the DHCP scope on iface to
+// []common.DHCPStaticLease. A lease whose MAC address cannot be parsed is
+// skipped with a conversion warning, since the address it reserves could not
+// be bound to any client.
+func (c *converter) convertStaticLeases(iface string, leases []schema.DHCPStaticLease) []common.DHCPStaticLease {
 	if len(leases) == 0 {
 		return nil
 	}
 
 	result := make([]common.DHCPStaticLease, 0, len(leases))
-	for _, l := range leases {
+	for i, l := range leases {
+		if l.Mac != "" {
+			if _, err := net.ParseMAC(l.Mac); err != nil {
+				c.addIssue(
+					fmt.Sprintf("DHCP[%s].StaticLeases[%d].MAC", iface, i),
+					l.Mac,
+					"static lease MAC address is invalid",
+					"lease skipped",
+					common.SeverityMedium,
+				)
+				continue
+			}
+		}
+
 		result = append(result, common.DHCPStaticLease{
 			MAC:              l.Mac,
 			CID:              l.Cid,
@@ -321,12 +340,15 @@ func (c *converter) convertForwarders(fwds []schema.ForwarderGroup) []common.For
 	return result
 }
 
-// convertVPN maps OpenVPN, WireGuard, and IPsec sections to common.VPN.
+// convertVPN maps OpenVPN, WireGuard, IPsec, ZeroTier, and the legacy
+// PPTP/L2TP sections to common.VPN.
 //
 // Schema pointer-vs-value inconsistency (landmine for future refactorers):
 //   - doc.OpenVPN is a value type (schema.OpenVPN, not a pointer) — it always exists.
 //   - doc.OPNsense.Wireguard is a pointer — it may be nil when absent.
 //   - doc.OPNsense.IPsec is a pointer — it may be nil when absent.
+//   - doc.OPNsense.Swanctl is a pointer — it may be nil when absent.
+//   - doc.OPNsense.ZeroTier is a pointer — it may be nil when absent.
 //
 // The OpenVPN sub-converters (convertOpenVPNServers/Clients/CSCs) all handle
 // empty slices gracefully, so no explicit nil-guard on doc.OpenVPN is needed
@@ -351,13 +373,96 @@ func (c *converter) convertVPN(doc *schema.OpnSenseDocument) common.VPN {
 		vpn.WireGuard = c.convertWireGuard(doc.OPNsense.Wireguard)
 	}
 
+	if doc.OPNsense.ZeroTier != nil {
+		vpn.ZeroTier = c.convertZeroTier(doc.OPNsense.ZeroTier)
+	}
+
 	if doc.OPNsense.IPsec != nil {
 		vpn.IPsec = c.convertIPsec(doc.OPNsense.IPsec)
 	}
 
+	if doc.OPNsense.Swanctl != nil {
+		vpn.IPsec.Connections = c.convertSwanctlConnections(doc.OPNsense.Swanctl)
+		vpn.IPsec.Pools = convertSwanctlPools(doc.OPNsense.Swanctl.Pools.Pool)
+	}
+
+	if doc.PPTPD != nil {
+		vpn.PPTP = c.convertPPTP(doc.PPTPD)
+	}
+
+	if doc.L2TP != nil {
+		vpn.L2TP = c.convertL2TP(doc.L2TP)
+	}
+
 	return vpn
 }
 
+// convertPPTP maps the legacy <pptpd> section to a common.LegacyRemoteAccessVPN.
+// PPTP has no interface selector or configurable authentication protocol; it
+// always uses MS-CHAPv2.
+func (c *converter) convertPPTP(pptp *schema.PPTPServer) *common.LegacyRemoteAccessVPN {
+	return &common.LegacyRemoteAccessVPN{
+		Enabled:            pptp.IsEnabled(),
+		Mode:               pptp.Mode,
+		LocalAddress:       pptp.LocalIP,
+		RemoteAddressStart: pptp.RemoteIP,
+		RemoteAddressCount: c.legacyVPNUnits("VPN.PPTP.RemoteAddressCount", pptp.NPPTPUnits),
+		Authentication:     "mschapv2",
+		Users:              convertLegacyVPNUsers(pptp.Users),
+	}
+}
+
+// convertL2TP maps the legacy <l2tp> section to a common.LegacyRemoteAccessVPN.
+// The shared secret and user passwords are intentionally not carried over.
+func (c *converter) convertL2TP(l2tp *schema.L2TPServer) *common.LegacyRemoteAccessVPN {
+	return &common.LegacyRemoteAccessVPN{
+		Enabled:            l2tp.IsEnabled(),
+		Mode:               l2tp.Mode,
+		Interface:          l2tp.Interface,
+		LocalAddress:       l2tp.LocalIP,
+		RemoteAddressStart: l2tp.RemoteIP,
+		RemoteAddressCount: c.legacyVPNUnits("VPN.L2TP.RemoteAddressCount", l2tp.NL2TPUnits),
+		Authentication:     l2tp.PAPOrCHAP,
+		Users:              convertLegacyVPNUsers(l2tp.Users),
+	}
+}
+
+// legacyVPNUnits parses the number of client addresses in a legacy VPN remote
+// range. An empty value yields 0; a non-numeric or negative value yields 0 and
+// records a conversion warning against field.
+func (c *converter) legacyVPNUnits(field, value string) int {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		c.addWarning(field, value, "legacy VPN remote address count is not a non-negative integer", common.SeverityLow)
+		return 0
+	}
+
+	return n
+}
+
+// convertLegacyVPNUsers maps legacy PPTP/L2TP users to common.LegacyVPNUser,
+// dropping their passwords.
+func convertLegacyVPNUsers(users []schema.LegacyVPNUser) []common.LegacyVPNUser {
+	if len(users) == 0 {
+		return nil
+	}
+
+	result := make([]common.LegacyVPNUser, 0, len(users))
+	for _, u := range users {
+		result = append(result, common.LegacyVPNUser{
+			Name:      u.Name,
+			IPAddress: u.IP,
+		})
+	}
+
+	return result
+}
+
 // convertIPsec maps *schema.IPsec to common.IPsecConfig.
 func (c *converter) convertIPsec(ipsec *schema.IPsec) common.IPsecConfig {
 	return common.IPsecConfig{
@@ -393,20 +498,20 @@ func (c *converter) convertOpenVPNCSCs(cscs []schema.OpenVPNCSC) []common.OpenVP
 	result := make([]common.OpenVPNCSC, 0, len(cscs))
 	for _, csc := range cscs {
 		result = append(result, common.OpenVPNCSC{
-			CommonName:      csc.Common_name,
+			CommonName:      csc.CommonName,
 			Block:           bool(csc.Block),
-			TunnelNetwork:   csc.Tunnel_network,
-			TunnelNetworkV6: csc.Tunnel_networkv6,
-			LocalNetwork:    csc.Local_network,
-			LocalNetworkV6:  csc.Local_networkv6,
-			RemoteNetwork:   csc.Remote_network,
-			RemoteNetworkV6: csc.Remote_networkv6,
-			GWRedir:         bool(csc.Gwredir),
-			PushReset:       bool(csc.Push_reset),
-			RemoveRoute:     bool(csc.Remove_route),
-			DNSDomain:       csc.DNS_domain,
-			DNSServers:      collectNonEmpty(csc.DNS_server1, csc.DNS_server2, csc.DNS_server3, csc.DNS_server4),
-			NTPServers:      collectNonEmpty(csc.NTP_server1, csc.NTP_server2),
+			TunnelNetwork:   csc.TunnelNetwork,
+			TunnelNetworkV6: csc.TunnelNetworkV6,
+			LocalNetwork:    csc.LocalNetwork,
+			LocalNetworkV6:  csc.LocalNetworkV6,
+			RemoteNetwork:   csc.RemoteNetwork,
+			RemoteNetworkV6: csc.RemoteNetworkV6,
+			GWRedir:         bool(csc.GWRedir),
+			PushReset:       bool(csc.PushReset),
+			RemoveRoute:     bool(csc.RemoveRoute),
+			DNSDomain:       csc.DNSDomain,
+			DNSServers:      collectNonEmpty(csc.DNSServer1, csc.DNSServer2, csc.DNSServer3, csc.DNSServer4),
+			NTPServers:      collectNonEmpty(csc.NTPServer1, csc.NTPServer2),
 		})
 	}
 
@@ -422,40 +527,44 @@ func (c *converter) convertOpenVPNServers(servers []schema.OpenVPNServer) []comm
 	result := make([]common.OpenVPNServer, 0, len(servers))
 	for _, s := range servers {
 		result = append(result, common.OpenVPNServer{
-			VPNID:            s.VPN_ID,
-			Mode:             s.Mode,
-			Protocol:         s.Protocol,
-			DevMode:          s.Dev_mode,
-			Interface:        s.Interface,
-			LocalPort:        s.Local_port,
-			Description:      s.Description,
-			TunnelNetwork:    s.Tunnel_network,
-			TunnelNetworkV6:  s.Tunnel_networkv6,
-			RemoteNetwork:    s.Remote_network,
-			RemoteNetworkV6:  s.Remote_networkv6,
-			LocalNetwork:     s.Local_network,
-			LocalNetworkV6:   s.Local_networkv6,
-			MaxClients:       s.Maxclients,
-			Compression:      s.Compression,
-			DNSServers:       collectNonEmpty(s.DNS_server1, s.DNS_server2, s.DNS_server3, s.DNS_server4),
-			NTPServers:       collectNonEmpty(s.NTP_server1, s.NTP_server2),
-			CertRef:          s.Cert_ref,
-			CARef:            s.CA_ref,
-			CRLRef:           s.CRL_ref,
-			DHLength:         s.DH_length,
-			ECDHCurve:        s.Ecdh_curve,
-			CertDepth:        s.Cert_depth,
-			TLSType:          s.TLS_type,
-			VerbosityLevel:   s.Verbosity_level,
-			Topology:         s.Topology,
-			StrictUserCN:     bool(s.Strictusercn),
-			GWRedir:          bool(s.Gwredir),
-			DynamicIP:        bool(s.Dynamic_ip),
-			ServerBridgeDHCP: bool(s.Serverbridge_dhcp),
-			DNSDomain:        s.DNS_domain,
-			NetBIOSEnable:    bool(s.Netbios_enable),
-			NetBIOSNType:     s.Netbios_ntype,
-			NetBIOSScope:     s.Netbios_scope,
+			VPNID:               s.VPNID,
+			Mode:                s.Mode,
+			Protocol:            s.Protocol,
+			DevMode:             s.DevMode,
+			Interface:           s.Interface,
+			LocalPort:           s.LocalPort,
+			Description:         s.Description,
+			TunnelNetwork:       s.TunnelNetwork,
+			TunnelNetworkV6:     s.TunnelNetworkV6,
+			RemoteNetwork:       s.RemoteNetwork,
+			RemoteNetworkV6:     s.RemoteNetworkV6,
+			LocalNetwork:        s.LocalNetwork,
+			LocalNetworkV6:      s.LocalNetworkV6,
+			MaxClients:          s.MaxClients,
+			Compression:         s.Compression,
+			DNSServers:          collectNonEmpty(s.DNSServer1, s.DNSServer2, s.DNSServer3, s.DNSServer4),
+			NTPServers:          collectNonEmpty(s.NTPServer1, s.NTPServer2),
+			CertRef:             s.CertRef,
+			CARef:               s.CARef,
+			CRLRef:              s.CRLRef,
+			DHLength:            s.DHLength,
+			ECDHCurve:           s.ECDHCurve,
+			CertDepth:           s.CertDepth,
+			TLSType:             s.TLSType,
+			VerbosityLevel:      s.VerbosityLevel,
+			Topology:            s.Topology,
+			StrictUserCN:        bool(s.StrictUserCN),
+			AuthMode:            s.AuthMode,
+			DataCiphers:         splitNonEmpty(s.EffectiveDataCiphers(), ","),
+			DataCiphersFallback: s.EffectiveDataCiphersFallback(),
+			Digest:              s.Digest,
+			GWRedir:             bool(s.GWRedir),
+			DynamicIP:           bool(s.DynamicIP),
+			ServerBridgeDHCP:    bool(s.ServerBridgeDHCP),
+			DNSDomain:           s.DNSDomain,
+			NetBIOSEnable:       bool(s.NetBIOSEnable),
+			NetBIOSNType:        s.NetBIOSNType,
+			NetBIOSScope:        s.NetBIOSScope,
 		})
 	}
 
@@ -471,18 +580,21 @@ func (c *converter) convertOpenVPNClients(clients []schema.OpenVPNClient) []comm
 	result := make([]common.OpenVPNClient, 0, len(clients))
 	for _, cl := range clients {
 		result = append(result, common.OpenVPNClient{
-			VPNID:          cl.VPN_ID,
-			Mode:           cl.Mode,
-			Protocol:       cl.Protocol,
-			DevMode:        cl.Dev_mode,
-			Interface:      cl.Interface,
-			ServerAddr:     cl.Server_addr,
-			ServerPort:     cl.Server_port,
-			Description:    cl.Description,
-			CertRef:        cl.Cert_ref,
-			CARef:          cl.CA_ref,
-			Compression:    cl.Compression,
-			VerbosityLevel: cl.Verbosity_level,
+			VPNID:               cl.VPNID,
+			Mode:                cl.Mode,
+			Protocol:            cl.Protocol,
+			DevMode:             cl.DevMode,
+			Interface:           cl.Interface,
+			ServerAddr:          cl.ServerAddr,
+			ServerPort:          cl.ServerPort,
+			Description:         cl.Description,
+			CertRef:             cl.CertRef,
+			CARef:               cl.CARef,
+			DataCiphers:         splitNonEmpty(cl.EffectiveDataCiphers(), ","),
+			DataCiphersFallback: cl.EffectiveDataCiphersFallback(),
+			Digest:              cl.Digest,
+			Compression:         cl.Compression,
+			VerbosityLevel:      cl.VerbosityLevel,
 		})
 	}
 
@@ -526,6 +638,32 @@ func (c *converter) convertWireGuard(wg *schema.WireGuard) common.WireGuardConfi
 	return cfg
 }
 
+// convertZeroTier maps the <OPNsense><zerotier> plugin section to
+// *common.ZeroTierConfig. A configuration that lists no <networks> but sets
+// <network_id> joins that single network.
+func (c *converter) convertZeroTier(zt *schema.ZeroTierConfig) *common.ZeroTierConfig {
+	cfg := &common.ZeroTierConfig{
+		Enabled:           zt.Enabled == xmlBoolTrue,
+		Port:              zt.LocalConf.Port,
+		AllowDefaultRoute: zt.LocalConf.AllowDefaultRoute == xmlBoolTrue,
+	}
+
+	for _, n := range zt.Networks.Network {
+		cfg.Networks = append(cfg.Networks, common.ZeroTierNetwork{
+			UUID:        n.UUID,
+			Enabled:     n.Enabled == xmlBoolTrue,
+			NetworkID:   n.NetworkID,
+			Description: n.Description,
+		})
+	}
+
+	if len(cfg.Networks) == 0 && zt.NetworkID != "" {
+		cfg.Networks = []common.ZeroTierNetwork{{Enabled: true, NetworkID: zt.NetworkID}}
+	}
+
+	return cfg
+}
+
 // convertRouting maps doc.Gateways and doc.StaticRoutes to common.Routing.
 func (c *converter) convertRouting(doc *schema.OpnSenseDocument) common.Routing {
 	return common.Routing{
@@ -673,9 +811,32 @@ func (c *converter) convertIDs(doc *schema.OpnSenseDocument) *common.IDSConfig {
 			ToclientGroups: ids.General.Detect.ToclientGroups,
 			ToserverGroups: ids.General.Detect.ToserverGroups,
 		},
+		Policies: convertIDSPolicies(ids.Policies.Items),
 	}
 }
 
+// convertIDSPolicies maps IDS policy items to []common.IDSPolicy.
+func convertIDSPolicies(items []schema.IDSPolicyItem) []common.IDSPolicy {
+	if len(items) == 0 {
+		return nil
+	}
+
+	result := make([]common.IDSPolicy, 0, len(items))
+	for _, p := range items {
+		result = append(result, common.IDSPolicy{
+			Description:  p.Description,
+			Enabled:      p.Enabled == xmlBoolTrue,
+			Priority:     p.Priority,
+			Rulesets:     splitNonEmpty(p.Rulesets, ","),
+			MatchActions: splitNonEmpty(p.Action, ","),
+			Filters:      splitNonEmpty(p.Content, ","),
+			Action:       p.NewAction,
+		})
+	}
+
+	return result
+}
+
 // convertSyslog maps doc.Syslog to common.SyslogConfig.
 func (c *converter) convertSyslog(doc *schema.OpnSenseDocument) common.SyslogConfig {
 	sl := doc.Syslog
@@ -718,14 +879,25 @@ func (c *converter) convertUsers(doc *schema.OpnSenseDocument) []common.User {
 		if u.UID == "" {
 			c.addWarning(fmt.Sprintf("Users[%d].UID", i), u.Name, "user has no UID", common.SeverityHigh)
 		}
+		expiryDate, err := shared.ParseExpiryDate(u.Expires)
+		if err != nil {
+			c.addWarning(fmt.Sprintf("Users[%d].Expires", i), u.Expires,
+				"user expiry date is not in MM/DD/YYYY format", common.SeverityLow)
+		}
 
 		user := common.User{
-			Name:        u.Name,
-			Disabled:    bool(u.Disabled),
-			Description: u.Descr,
-			Scope:       u.Scope,
-			GroupName:   u.Groupname,
-			UID:         u.UID,
+			Name:           u.Name,
+			Disabled:       bool(u.Disabled),
+			Description:    u.Descr,
+			Scope:          u.Scope,
+			GroupName:      u.Groupname,
+			UID:            u.UID,
+			CertRef:        u.CertRef,
+			AuthorizedKeys: u.AuthorizedKeys,
+			Expires:        u.Expires,
+		}
+		if !expiryDate.IsZero() {
+			user.ExpiryDate, user.HasExpiryDate = expiryDate.Unix(), true
 		}
 
 		if len(u.APIKeys) > 0 {
@@ -763,13 +935,73 @@ func (c *converter) convertGroups(doc *schema.OpnSenseDocument) []common.Group {
 			Scope:       g.Scope,
 			GID:         g.Gid,
 			Member:      g.Member,
-			Privileges:  g.Priv,
+			Privileges:  slices.Clone(g.Privileges),
 		})
 	}
 
 	return result
 }
 
+// convertAuthServers maps doc.System.AuthServer to []common.AuthServer. The
+// LDAP bind password is replaced with redactedValue and the RADIUS shared
+// secret is reduced to its length, so neither reaches the export.
+func (c *converter) convertAuthServers(doc *schema.OpnSenseDocument) []common.AuthServer {
+	if len(doc.System.AuthServer) == 0 {
+		return nil
+	}
+
+	result := make([]common.AuthServer, 0, len(doc.System.AuthServer))
+	for i, a := range doc.System.AuthServer {
+		if a.Name == "" {
+			c.addWarning(fmt.Sprintf("AuthServers[%d].Name", i), a.Host, "authentication server has empty name", common.SeverityMedium)
+		}
+
+		server := common.AuthServer{
+			RefID: a.RefID,
+			Name:  a.Name,
+			Type:  common.AuthServerType(strings.ToLower(a.Type)),
+			Host:  a.Host,
+		}
+
+		switch server.Type {
+		case common.AuthServerLDAP:
+			server.Port = a.LDAPPort
+			server.Transport = normalizeLDAPTransport(a.LDAPURLType)
+			server.Scope = a.LDAPScope
+			server.BaseDN = a.LDAPBaseDN
+			server.AuthContainers = a.LDAPAuthCN
+			server.BindDN = a.LDAPBindDN
+			if a.LDAPBindPW != "" {
+				server.BindPassword = redactedValue
+			}
+		case common.AuthServerRADIUS:
+			server.Port = a.RADIUSAuthPort
+			server.SecretLength = len(a.RADIUSSecret)
+		}
+
+		result = append(result, server)
+	}
+
+	return result
+}
+
+// normalizeLDAPTransport maps an ldap_urltype value ("TCP - Standard",
+// "StartTLS", "TCP - STARTTLS", "SSL - Encrypted") to a common.LDAPTransport.
+// Unrecognized values are kept verbatim.
+func normalizeLDAPTransport(urlType string) common.LDAPTransport {
+	lower := strings.ToLower(strings.TrimSpace(urlType))
+	switch {
+	case strings.Contains(lower, "starttls"):
+		return common.LDAPTransportStartTLS
+	case strings.HasPrefix(lower, "ssl"):
+		return common.LDAPTransportSSL
+	case strings.HasPrefix(lower, "tcp"):
+		return common.LDAPTransportTCP
+	default:
+		return common.LDAPTransport(urlType)
+	}
+}
+
 // convertSysctl maps doc.Sysctl to []common.SysctlItem.
 func (c *converter) convertSysctl(doc *schema.OpnSenseDocument) []common.SysctlItem {
 	if len(doc.Sysctl) == 0 {
@@ -799,8 +1031,23 @@ func (c *converter) convertRevision(doc *schema.OpnSenseDocument) common.Revisio
 
 // convertNTP maps doc.Ntpd to common.NTPConfig.
 func (c *converter) convertNTP(doc *schema.OpnSenseDocument) common.NTPConfig {
+	var servers []common.NTPServer
+	for _, ts := range doc.Ntpd.TimeServers {
+		servers = append(servers, common.NTPServer{
+			Address:  ts.Address,
+			Prefer:   bool(ts.Prefer),
+			NoSelect: bool(ts.NoSelect),
+		})
+	}
+
 	return common.NTPConfig{
 		PreferredServer: doc.Ntpd.Prefer,
+		Servers:         servers,
+		MinPoll:         doc.Ntpd.MinPoll,
+		MaxPoll:         doc.Ntpd.MaxPoll,
+		Orphan:          doc.Ntpd.Orphan,
+		NoQuery:         bool(doc.Ntpd.NoQuery),
+		Restrictions:    slices.Clone(doc.Ntpd.Restrict),
 	}
 }
 
@@ -813,30 +1060,127 @@ func (c *converter) convertSNMP(doc *schema.OpnSenseDocument) common.SNMPConfig
 	}
 }
 
-// convertLoadBalancer maps doc.LoadBalancer.MonitorType to common.LoadBalancerConfig.
+// convertLoadBalancer maps doc.LoadBalancer monitor types, pools, and virtual
+// servers to common.LoadBalancerConfig.
 func (c *converter) convertLoadBalancer(doc *schema.OpnSenseDocument) common.LoadBalancerConfig {
-	monitors := doc.LoadBalancer.MonitorType
-	if len(monitors) == 0 {
+	lb := doc.LoadBalancer
+	if len(lb.MonitorType) == 0 && len(lb.Pool) == 0 && len(lb.VirtualServer) == 0 {
 		return common.LoadBalancerConfig{}
 	}
 
-	result := make([]common.MonitorType, 0, len(monitors))
-	for _, m := range monitors {
-		result = append(result, common.MonitorType{
-			Name:        m.Name,
-			Type:        m.Type,
-			Description: m.Descr,
-			Options: common.MonitorOptions{
-				Path:   m.Options.Path,
-				Host:   m.Options.Host,
-				Code:   m.Options.Code,
-				Send:   m.Options.Send,
-				Expect: m.Options.Expect,
-			},
+	var result common.LoadBalancerConfig
+
+	if len(lb.MonitorType) > 0 {
+		result.MonitorTypes = make([]common.MonitorType, 0, len(lb.MonitorType))
+		for _, m := range lb.MonitorType {
+			result.MonitorTypes = append(result.MonitorTypes, common.MonitorType{
+				Name:        m.Name,
+				Type:        m.Type,
+				Description: m.Descr,
+				Options: common.MonitorOptions{
+					Path:   m.Options.Path,
+					Host:   m.Options.Host,
+					Code:   m.Options.Code,
+					Send:   m.Options.Send,
+					Expect: m.Options.Expect,
+				},
+			})
+		}
+	}
+
+	if len(lb.Pool) > 0 {
+		result.Pools = make([]common.LBPool, 0, len(lb.Pool))
+		for _, p := range lb.Pool {
+			result.Pools = append(result.Pools, common.LBPool{
+				Name:            p.Name,
+				Mode:            p.Mode,
+				Description:     p.Descr,
+				Port:            p.Port,
+				Retry:           p.Retry,
+				Monitor:         p.Monitor,
+				Servers:         collectNonEmpty(p.Servers...),
+				DisabledServers: collectNonEmpty(p.ServersDisabled...),
+			})
+		}
+	}
+
+	if len(lb.VirtualServer) > 0 {
+		result.VirtualServers = make([]common.LBVirtualServer, 0, len(lb.VirtualServer))
+		for _, v := range lb.VirtualServer {
+			result.VirtualServers = append(result.VirtualServers, common.LBVirtualServer{
+				Name:          v.Name,
+				Description:   v.Descr,
+				Address:       v.IPAddr,
+				Port:          v.Port,
+				Pool:          v.PoolName,
+				FallbackPool:  v.SiteDown,
+				Mode:          v.Mode,
+				RelayProtocol: v.RelayProtocol,
+			})
+		}
+	}
+
+	return result
+}
+
+// convertWakeOnLAN maps doc.WOL.Entries to []common.WakeOnLANEntry. Returns
+// nil when no hosts are configured.
+func (c *converter) convertWakeOnLAN(doc *schema.OpnSenseDocument) []common.WakeOnLANEntry {
+	if len(doc.WOL.Entries) == 0 {
+		return nil
+	}
+
+	result := make([]common.WakeOnLANEntry, 0, len(doc.WOL.Entries))
+	for _, e := range doc.WOL.Entries {
+		result = append(result, common.WakeOnLANEntry{
+			Interface:   e.Interface,
+			MAC:         e.MAC,
+			Description: e.Descr,
+		})
+	}
+
+	return result
+}
+
+// convertUPnP maps <installedpackages><miniupnpd> to *common.UPnPConfig.
+// Returns nil when the configuration has no miniupnpd settings. An ACL entry
+// that does not parse is dropped with a conversion warning.
+func (c *converter) convertUPnP(doc *schema.OpnSenseDocument) *common.UPnPConfig {
+	if doc.InstalledPackages == nil {
+		return nil
+	}
+
+	cfg := doc.InstalledPackages.MiniUPnPd.Settings()
+	if cfg == nil {
+		return nil
+	}
+
+	upnp := &common.UPnPConfig{
+		Enabled:           cfg.IsEnabled(),
+		UPnPEnabled:       bool(cfg.EnableUPnP),
+		NATPMPEnabled:     bool(cfg.EnableNATPMP),
+		ExternalInterface: cfg.ExtIface,
+		Interfaces:        cfg.Interfaces(),
+		DefaultDeny:       bool(cfg.PermDefault),
+	}
+
+	for _, rule := range cfg.PermissionRules() {
+		perm, err := schema.ParseMiniUPnPdPermission(rule)
+		if err != nil {
+			c.addWarning("UPnP.Rules", rule, err.Error(), common.SeverityMedium)
+			continue
+		}
+
+		upnp.Rules = append(upnp.Rules, common.UPnPPermissionRule{
+			Action:          perm.Action,
+			ExternalPorts:   perm.ExternalPorts,
+			InternalAddress: perm.InternalAddress,
+			InternalPorts:   perm.InternalPorts,
+			Description:     perm.Description,
 		})
 	}
 
-	return common.LoadBalancerConfig{MonitorTypes: result}
+	return upnp
 }
 
 // splitNonEmpty splits s by sep and returns only non-empty, trimmed parts.
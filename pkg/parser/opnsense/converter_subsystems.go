@@ -3,6 +3,7 @@ package opnsense
 import (
 	"fmt"
 	"slices"
+	"strconv"
 	"strings"
 
 	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
@@ -29,14 +30,11 @@ func (c *converter) convertMonit(doc *schema.OpnSenseDocument) *common.MonitConf
 		MMonitURL:    monit.General.MmonitURL,
 	}
 
-	if monit.Alert.Enabled == xmlBoolTrue || monit.Alert.Recipient != "" {
-		cfg.Alert = &common.MonitAlert{
-			Enabled:     monit.Alert.Enabled == xmlBoolTrue,
-			Recipient:   monit.Alert.Recipient,
-			NotOn:       monit.Alert.Noton,
-			Events:      monit.Alert.Events,
-			Description: monit.Alert.Description,
-		}
+	cfg.Alerts = c.convertMonitAlerts(monit.Alert)
+	if len(cfg.Alerts) > 0 {
+		first := cfg.Alerts[0]
+		//nolint:staticcheck // SA1019: deprecated Alert stays populated for pre-2.18.0 consumers.
+		cfg.Alert = &first
 	}
 
 	cfg.Services = c.convertMonitServices(monit.Service)
@@ -45,6 +43,29 @@ func (c *converter) convertMonit(doc *schema.OpnSenseDocument) *common.MonitConf
 	return cfg
 }
 
+// convertMonitAlerts maps []schema.MonitAlert to []common.MonitAlert,
+// skipping the disabled, recipient-less placeholder entry OPNsense writes
+// for a fresh installation.
+func (c *converter) convertMonitAlerts(alerts []schema.MonitAlert) []common.MonitAlert {
+	var result []common.MonitAlert
+	for _, a := range alerts {
+		if a.Enabled != xmlBoolTrue && a.Recipient == "" {
+			continue
+		}
+
+		result = append(result, common.MonitAlert{
+			UUID:        a.UUID,
+			Enabled:     a.Enabled == xmlBoolTrue,
+			Recipient:   a.Recipient,
+			NotOn:       a.Noton,
+			Events:      a.Events,
+			Description: a.Description,
+		})
+	}
+
+	return result
+}
+
 // convertMonitServices maps []schema.MonitService to []common.MonitServiceEntry.
 func (c *converter) convertMonitServices(services []schema.MonitService) []common.MonitServiceEntry {
 	if len(services) == 0 {
@@ -95,10 +116,11 @@ func (c *converter) convertMonitTests(tests []schema.MonitTest) []common.MonitTe
 	return result
 }
 
-// convertNetflow maps doc.OPNsense.Netflow to *common.NetflowConfig.
+// convertNetflow maps the NetFlow settings (see schema.OPNsense.NetFlowSettings)
+// to *common.NetflowConfig.
 // Returns nil if Netflow has no meaningful configuration.
 func (c *converter) convertNetflow(doc *schema.OpnSenseDocument) *common.NetflowConfig {
-	nf := doc.OPNsense.Netflow
+	nf := doc.OPNsense.NetFlowSettings()
 	hasCapture := nf.Capture.Interfaces != "" || nf.Capture.Version != ""
 	hasCollect := nf.Collect.Enable == xmlBoolTrue
 
@@ -106,11 +128,18 @@ func (c *converter) convertNetflow(doc *schema.OpnSenseDocument) *common.Netflow
 		return nil
 	}
 
+	var collectors []common.NetflowCollector
+	for _, collector := range nf.Collectors() {
+		collectors = append(collectors, common.NetflowCollector{Address: collector.Address, Port: collector.Port})
+	}
+
 	return &common.NetflowConfig{
+		Enabled:           nf.IsNetFlowEnabled(),
 		CaptureInterfaces: nf.Capture.Interfaces,
 		CaptureVersion:    nf.Capture.Version,
 		EgressOnly:        nf.Capture.EgressOnly == xmlBoolTrue,
 		CaptureTargets:    nf.Capture.Targets,
+		Collectors:        collectors,
 		CollectEnabled:    hasCollect,
 		InactiveTimeout:   nf.InactiveTimeout,
 		ActiveTimeout:     nf.ActiveTimeout,
@@ -121,28 +150,114 @@ func (c *converter) convertNetflow(doc *schema.OpnSenseDocument) *common.Netflow
 // Returns nil if no traffic shaping is configured.
 func (c *converter) convertTrafficShaper(doc *schema.OpnSenseDocument) *common.TrafficShaperConfig {
 	ts := doc.OPNsense.TrafficShaper
-	if ts.Pipes == "" && ts.Queues == "" && ts.Rules == "" {
+	pipesText := strings.TrimSpace(ts.Pipes.Text)
+	queuesText := strings.TrimSpace(ts.Queues.Text)
+	if pipesText == "" && queuesText == "" && ts.Rules == "" &&
+		len(ts.Pipes.Pipe) == 0 && len(ts.Queues.Queue) == 0 {
 		return nil
 	}
 
-	return &common.TrafficShaperConfig{
-		Pipes:  ts.Pipes,
-		Queues: ts.Queues,
+	result := &common.TrafficShaperConfig{
+		Pipes:  pipesText,
+		Queues: queuesText,
 		Rules:  ts.Rules,
 	}
+
+	for _, p := range ts.Pipes.Pipe {
+		result.PipeEntries = append(result.PipeEntries, common.TrafficShaperPipe{
+			UUID:            p.UUID,
+			Number:          p.Number,
+			Enabled:         p.Enabled == xmlBoolTrue,
+			Bandwidth:       p.Bandwidth,
+			BandwidthMetric: p.BandwidthMetric,
+			Delay:           p.Delay,
+			Mask:            p.Mask,
+			Description:     p.Description,
+		})
+	}
+
+	for _, q := range ts.Queues.Queue {
+		result.QueueEntries = append(result.QueueEntries, common.TrafficShaperQueue{
+			UUID:        q.UUID,
+			Number:      q.Number,
+			Enabled:     q.Enabled == xmlBoolTrue,
+			Pipe:        q.Pipe,
+			Weight:      q.Weight,
+			Mask:        q.Mask,
+			Description: q.Description,
+		})
+	}
+
+	return result
+}
+
+// convertQueueStats maps the <queuestats> snapshot to *common.QueueStats.
+// Returns nil when the export carries no queue statistics.
+func (c *converter) convertQueueStats(qs *schema.QueueStats) *common.QueueStats {
+	if qs == nil || (qs.Timestamp == "" && len(qs.Queues) == 0) {
+		return nil
+	}
+
+	queues := make([]common.QueueEntry, 0, len(qs.Queues))
+	for i, q := range qs.Queues {
+		field := fmt.Sprintf("QueueStats.Queues[%d]", i)
+		queues = append(queues, common.QueueEntry{
+			Name:           q.Name,
+			Interface:      q.Interface,
+			Packets:        c.queueCounter(field+".Packets", q.Packets),
+			Bytes:          c.queueCounter(field+".Bytes", q.Bytes),
+			DroppedPackets: c.queueCounter(field+".DroppedPackets", q.DroppedPackets),
+			DroppedBytes:   c.queueCounter(field+".DroppedBytes", q.DroppedBytes),
+			QueueLength:    c.queueCounter(field+".QueueLength", q.QueueLength),
+		})
+	}
+
+	return &common.QueueStats{
+		Timestamp: qs.Timestamp,
+		Queues:    queues,
+	}
+}
+
+// queueCounter parses a <queuestats> counter. An empty value yields 0; a
+// value that is not a non-negative integer yields 0 and records a conversion
+// warning against field.
+func (c *converter) queueCounter(field, value string) int64 {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || n < 0 {
+		c.addWarning(field, value, "queue counter is not a non-negative integer", common.SeverityLow)
+		return 0
+	}
+
+	return n
 }
 
 // convertCaptivePortal maps doc.OPNsense.Captiveportal to *common.CaptivePortalConfig.
 // Returns nil if no captive portal zones are configured.
 func (c *converter) convertCaptivePortal(doc *schema.OpnSenseDocument) *common.CaptivePortalConfig {
 	cp := doc.OPNsense.Captiveportal
-	if cp.Zones == "" && cp.Templates == "" {
+	zonesText := strings.TrimSpace(cp.Zones.Text)
+	if zonesText == "" && len(cp.Zones.Zone) == 0 && cp.Templates == "" {
 		return nil
 	}
 
+	var authServers []string
+	for _, zone := range cp.Zones.Zone {
+		for _, name := range splitNonEmpty(zone.AuthServers, ",") {
+			if !slices.Contains(authServers, name) {
+				authServers = append(authServers, name)
+			}
+		}
+	}
+
 	return &common.CaptivePortalConfig{
-		Zones:     cp.Zones,
-		Templates: cp.Templates,
+		Zones:       zonesText,
+		Templates:   cp.Templates,
+		AuthServers: authServers,
 	}
 }
 
@@ -346,3 +461,48 @@ func firstCSV(s string) string {
 	before, _, _ := strings.Cut(s, ",")
 	return strings.TrimSpace(before)
 }
+
+// convertPF maps doc.PF to *common.PFSettings. Timeout overrides are emitted
+// in pf's canonical order, skipping unset ones. Returns nil if no pf limit or
+// timeout is configured.
+func (c *converter) convertPF(doc *schema.OpnSenseDocument) *common.PFSettings {
+	pf := doc.PF
+	if pf == (schema.PFSettings{}) {
+		return nil
+	}
+
+	timeouts := []common.PFTimeout{
+		{Name: "tcp.first", Seconds: pf.TCPFirstTimeout},
+		{Name: "tcp.opening", Seconds: pf.TCPOpeningTimeout},
+		{Name: "tcp.established", Seconds: pf.TCPEstablishedTimeout},
+		{Name: "tcp.closing", Seconds: pf.TCPClosingTimeout},
+		{Name: "tcp.finwait", Seconds: pf.TCPFinWaitTimeout},
+		{Name: "tcp.closed", Seconds: pf.TCPClosedTimeout},
+		{Name: "udp.first", Seconds: pf.UDPFirstTimeout},
+		{Name: "udp.single", Seconds: pf.UDPSingleTimeout},
+		{Name: "udp.multiple", Seconds: pf.UDPMultipleTimeout},
+		{Name: "icmp.first", Seconds: pf.ICMPFirstTimeout},
+		{Name: "icmp.error", Seconds: pf.ICMPErrorTimeout},
+		{Name: "other.first", Seconds: pf.OtherFirstTimeout},
+		{Name: "other.single", Seconds: pf.OtherSingleTimeout},
+		{Name: "other.multiple", Seconds: pf.OtherMultipleTimeout},
+	}
+	timeouts = slices.DeleteFunc(timeouts, func(t common.PFTimeout) bool {
+		return t.Seconds == ""
+	})
+
+	cfg := &common.PFSettings{
+		StateScale:      pf.StateScale,
+		MaxStates:       pf.MaxStateTableSize(),
+		MaxTableEntries: pf.MaximumTableEntries,
+		MaxFragments:    pf.Frags,
+		MaxSourceNodes:  pf.SrcNodes,
+		AdaptiveStart:   pf.AdaptiveStart,
+		AdaptiveEnd:     pf.AdaptiveEnd,
+	}
+	if len(timeouts) > 0 {
+		cfg.Timeouts = timeouts
+	}
+
+	return cfg
+}
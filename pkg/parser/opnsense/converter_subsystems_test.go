@@ -74,6 +74,73 @@ func TestConverter_IPsec_NilReturnsZeroValue(t *testing.T) {
 	assert.False(t, device.VPN.IPsec.Enabled)
 }
 
+func TestConverter_Swanctl(t *testing.T) {
+	t.Parallel()
+
+	doc := schema.NewOpnSenseDocument()
+	doc.OPNsense.Swanctl = &schema.Swanctl{
+		Connections: schema.SwanctlConnections{Connection: []schema.SwanctlConnection{
+			{UUID: "conn-1", Enabled: "1", Version: "1", Aggressive: "1", Proposals: "aes128-sha1-modp1024"},
+			{UUID: "conn-2", Version: "7", Description: "odd version"},
+		}},
+		Locals: schema.SwanctlLocals{Local: []schema.SwanctlAuth{
+			{Enabled: "1", Connection: "conn-1", Round: "2", Auth: "eap-mschapv2"},
+			{Enabled: "1", Connection: "conn-1", Round: "1", Auth: "pubkey", ID: "fw.example.com"},
+			{Enabled: "0", Connection: "conn-1", Round: "3", Auth: "psk"},
+		}},
+		Children: schema.SwanctlChildren{Child: []schema.SwanctlChild{
+			{UUID: "child-1", Enabled: "1", Connection: "conn-1", Mode: "tunnel", LocalTS: "10.0.0.0/24"},
+			{UUID: "child-2", Enabled: "1", Connection: "missing"},
+		}},
+	}
+
+	device, warnings, err := opnsense.ConvertDocument(doc)
+	require.NoError(t, err)
+
+	conns := device.VPN.IPsec.Connections
+	require.Len(t, conns, 2)
+
+	assert.Equal(t, common.IPsecOriginSwanctl, conns[0].Origin)
+	assert.Equal(t, "ikev1", conns[0].Version)
+	assert.True(t, conns[0].Aggressive)
+	assert.False(t, conns[0].Disabled)
+	assert.Equal(t, []string{"fw.example.com"}, conns[0].LocalIDs)
+	assert.Equal(t, []string{"pubkey", "eap-mschapv2"}, conns[0].LocalAuth)
+	require.Len(t, conns[0].Children, 1)
+	assert.Equal(t, "child-1", conns[0].Children[0].ID)
+	assert.Equal(t, []string{"10.0.0.0/24"}, conns[0].Children[0].LocalTrafficSelectors)
+
+	assert.Equal(t, "7", conns[1].Version)
+	assert.True(t, conns[1].Disabled)
+	assert.Empty(t, conns[1].Children)
+
+	fields := make([]string, 0, len(warnings))
+	for _, w := range warnings {
+		fields = append(fields, w.Field)
+	}
+	assert.ElementsMatch(t, []string{
+		"VPN.IPsec.Swanctl.Connections[1].Version",
+		"VPN.IPsec.Swanctl.Children[1].Connection",
+	}, fields)
+}
+
+func TestConverter_Swanctl_OrphansWithoutConnections(t *testing.T) {
+	t.Parallel()
+
+	doc := schema.NewOpnSenseDocument()
+	doc.OPNsense.Swanctl = &schema.Swanctl{
+		Remotes: schema.SwanctlRemotes{Remote: []schema.SwanctlAuth{{Connection: "gone"}}},
+	}
+
+	device, warnings, err := opnsense.ConvertDocument(doc)
+	require.NoError(t, err)
+
+	assert.Empty(t, device.VPN.IPsec.Connections)
+	require.Len(t, warnings, 1)
+	assert.Equal(t, "VPN.IPsec.Swanctl.Remotes[0].Connection", warnings[0].Field)
+	assert.Equal(t, "gone", warnings[0].Value)
+}
+
 func TestConverter_OpenVPNCSC(t *testing.T) {
 	t.Parallel()
 
@@ -90,15 +157,15 @@ func TestConverter_OpenVPNCSC(t *testing.T) {
 		{
 			name: "single CSC",
 			cscs: []schema.OpenVPNCSC{
-				{Common_name: "user1"},
+				{CommonName: "user1"},
 			},
 			wantLen: 1,
 		},
 		{
 			name: "multiple CSCs",
 			cscs: []schema.OpenVPNCSC{
-				{Common_name: "user1"},
-				{Common_name: "user2"},
+				{CommonName: "user1"},
+				{CommonName: "user2"},
 			},
 			wantLen: 2,
 		},
@@ -130,21 +197,21 @@ func TestConverter_OpenVPNCSC_FieldMapping(t *testing.T) {
 	doc := schema.NewOpnSenseDocument()
 	doc.OpenVPN.CSC = []schema.OpenVPNCSC{
 		{
-			Common_name:      "admin-cert",
-			Block:            schema.BoolFlag(true),
-			Tunnel_network:   "10.8.1.0/24",
-			Tunnel_networkv6: "fd00::1/64",
-			Local_network:    "192.168.1.0/24",
-			Local_networkv6:  "fd01::0/64",
-			Remote_network:   "172.16.0.0/12",
-			Remote_networkv6: "fd02::0/64",
-			Gwredir:          schema.BoolFlag(true),
-			Push_reset:       schema.BoolFlag(true),
-			Remove_route:     schema.BoolFlag(true),
-			DNS_domain:       "vpn.example.com",
-			DNS_server1:      "10.8.0.1",
-			DNS_server2:      "10.8.0.2",
-			NTP_server1:      "10.8.0.3",
+			CommonName:      "admin-cert",
+			Block:           schema.BoolFlag(true),
+			TunnelNetwork:   "10.8.1.0/24",
+			TunnelNetworkV6: "fd00::1/64",
+			LocalNetwork:    "192.168.1.0/24",
+			LocalNetworkV6:  "fd01::0/64",
+			RemoteNetwork:   "172.16.0.0/12",
+			RemoteNetworkV6: "fd02::0/64",
+			GWRedir:         schema.BoolFlag(true),
+			PushReset:       schema.BoolFlag(true),
+			RemoveRoute:     schema.BoolFlag(true),
+			DNSDomain:       "vpn.example.com",
+			DNSServer1:      "10.8.0.1",
+			DNSServer2:      "10.8.0.2",
+			NTPServer1:      "10.8.0.3",
 		},
 	}
 
@@ -198,8 +265,11 @@ func TestConverter_Monit(t *testing.T) {
 		doc.OPNsense.Monit.General.HttpdEnabled = "1"
 		doc.OPNsense.Monit.General.HttpdPort = "2812"
 		doc.OPNsense.Monit.General.MmonitURL = "https://mmonit.example.com"
-		doc.OPNsense.Monit.Alert.Enabled = "1"
-		doc.OPNsense.Monit.Alert.Recipient = "admin@example.com"
+		doc.OPNsense.Monit.Alert = []schema.MonitAlert{
+			{UUID: "alert-1", Enabled: "1", Recipient: "admin@example.com", Events: "timeout"},
+			{UUID: "alert-2", Enabled: "0"},
+			{UUID: "alert-3", Enabled: "0", Recipient: "oncall@example.com", Description: "Paused"},
+		}
 		doc.OPNsense.Monit.Service = []schema.MonitService{
 			{UUID: "svc-1", Enabled: "1", Name: "sshd", Type: "3"},
 		}
@@ -223,9 +293,18 @@ func TestConverter_Monit(t *testing.T) {
 		assert.Equal(t, "2812", m.HTTPDPort)
 		assert.Equal(t, "https://mmonit.example.com", m.MMonitURL)
 
+		require.Len(t, m.Alerts, 2, "the placeholder alert without a recipient is skipped")
+		assert.Equal(t, "alert-1", m.Alerts[0].UUID)
+		assert.True(t, m.Alerts[0].Enabled)
+		assert.Equal(t, "admin@example.com", m.Alerts[0].Recipient)
+		assert.Equal(t, "timeout", m.Alerts[0].Events)
+		assert.Equal(t, "alert-3", m.Alerts[1].UUID)
+		assert.False(t, m.Alerts[1].Enabled)
+		assert.Equal(t, "Paused", m.Alerts[1].Description)
+
+		//nolint:staticcheck // SA1019: exercising the deprecated Alert field for backward-compat coverage.
 		require.NotNil(t, m.Alert)
-		assert.True(t, m.Alert.Enabled)
-		assert.Equal(t, "admin@example.com", m.Alert.Recipient)
+		assert.Equal(t, m.Alerts[0], *m.Alert) //nolint:staticcheck // SA1019: same as above.
 
 		require.Len(t, m.Services, 1)
 		assert.Equal(t, "svc-1", m.Services[0].UUID)
@@ -276,10 +355,86 @@ func TestConverter_Netflow(t *testing.T) {
 		assert.Equal(t, "9", nf.CaptureVersion)
 		assert.True(t, nf.EgressOnly)
 		assert.Equal(t, "10.0.0.1:2055", nf.CaptureTargets)
+		assert.Equal(t, []common.NetflowCollector{{Address: "10.0.0.1", Port: "2055"}}, nf.Collectors)
+		assert.True(t, nf.Enabled)
 		assert.True(t, nf.CollectEnabled)
 		assert.Equal(t, "15", nf.InactiveTimeout)
 		assert.Equal(t, "1800", nf.ActiveTimeout)
 	})
+
+	t.Run("diagnostics block takes precedence", func(t *testing.T) {
+		t.Parallel()
+
+		doc := schema.NewOpnSenseDocument()
+		doc.OPNsense.Netflow.Capture.Interfaces = "lan"
+		doc.OPNsense.Diagnostics = &schema.Diagnostics{Netflow: &schema.NetFlowConfig{
+			Capture: schema.NetFlowCapture{Interfaces: "wan", Version: "v9", Targets: "203.0.113.9:2055"},
+		}}
+
+		device, _, err := opnsense.ConvertDocument(doc)
+		require.NoError(t, err)
+		require.NotNil(t, device.Netflow)
+		assert.Equal(t, "wan", device.Netflow.CaptureInterfaces)
+		assert.Equal(t, []common.NetflowCollector{{Address: "203.0.113.9", Port: "2055"}}, device.Netflow.Collectors)
+		assert.True(t, device.Netflow.Enabled)
+	})
+}
+
+func TestConverter_PF(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty pf returns nil", func(t *testing.T) {
+		t.Parallel()
+
+		doc := schema.NewOpnSenseDocument()
+
+		device, warnings, err := opnsense.ConvertDocument(doc)
+		require.NoError(t, err)
+		assert.Empty(t, warnings)
+		assert.Nil(t, device.PF)
+	})
+
+	t.Run("populated pf", func(t *testing.T) {
+		t.Parallel()
+
+		doc := schema.NewOpnSenseDocument()
+		doc.PF.StateScale = "adaptive"
+		doc.PF.MaximumStates = "250000"
+		doc.PF.MaximumTableEntries = "400000"
+		doc.PF.Frags = "5000"
+		doc.PF.SrcNodes = "20000"
+		doc.PF.UDPMultipleTimeout = "60"
+		doc.PF.TCPEstablishedTimeout = "86400"
+
+		device, warnings, err := opnsense.ConvertDocument(doc)
+		require.NoError(t, err)
+		assert.Empty(t, warnings)
+		require.NotNil(t, device.PF)
+
+		pf := device.PF
+		assert.Equal(t, "adaptive", pf.StateScale)
+		assert.Equal(t, 250000, pf.MaxStates)
+		assert.Equal(t, "400000", pf.MaxTableEntries)
+		assert.Equal(t, "5000", pf.MaxFragments)
+		assert.Equal(t, "20000", pf.MaxSourceNodes)
+		assert.Equal(t, []common.PFTimeout{
+			{Name: "tcp.established", Seconds: "86400"},
+			{Name: "udp.multiple", Seconds: "60"},
+		}, pf.Timeouts)
+	})
+
+	t.Run("invalid state limit maps to zero", func(t *testing.T) {
+		t.Parallel()
+
+		doc := schema.NewOpnSenseDocument()
+		doc.PF.MaximumStates = "lots"
+
+		device, _, err := opnsense.ConvertDocument(doc)
+		require.NoError(t, err)
+		require.NotNil(t, device.PF)
+		assert.Zero(t, device.PF.MaxStates)
+		assert.Nil(t, device.PF.Timeouts)
+	})
 }
 
 func TestConverter_TrafficShaper(t *testing.T) {
@@ -300,8 +455,8 @@ func TestConverter_TrafficShaper(t *testing.T) {
 		t.Parallel()
 
 		doc := schema.NewOpnSenseDocument()
-		doc.OPNsense.TrafficShaper.Pipes = "pipe-uuid-1"
-		doc.OPNsense.TrafficShaper.Queues = "queue-uuid-1"
+		doc.OPNsense.TrafficShaper.Pipes.Text = "pipe-uuid-1"
+		doc.OPNsense.TrafficShaper.Queues.Text = "queue-uuid-1"
 		doc.OPNsense.TrafficShaper.Rules = "rule-uuid-1"
 
 		device, warnings, err := opnsense.ConvertDocument(doc)
@@ -313,6 +468,127 @@ func TestConverter_TrafficShaper(t *testing.T) {
 		assert.Equal(t, "pipe-uuid-1", ts.Pipes)
 		assert.Equal(t, "queue-uuid-1", ts.Queues)
 		assert.Equal(t, "rule-uuid-1", ts.Rules)
+		assert.Empty(t, ts.PipeEntries)
+		assert.Empty(t, ts.QueueEntries)
+	})
+
+	t.Run("pipes and queues", func(t *testing.T) {
+		t.Parallel()
+
+		doc := schema.NewOpnSenseDocument()
+		doc.OPNsense.TrafficShaper.Pipes.Pipe = []schema.TrafficShaperPipe{{
+			UUID:            "pipe-1",
+			Number:          "10000",
+			Enabled:         "1",
+			Bandwidth:       "100",
+			BandwidthMetric: "Mbit",
+			Delay:           "20",
+			Mask:            "src-ip",
+			Description:     "Download",
+		}}
+		doc.OPNsense.TrafficShaper.Queues.Queue = []schema.TrafficShaperQueue{{
+			UUID:        "queue-1",
+			Number:      "10000",
+			Enabled:     "0",
+			Pipe:        "pipe-1",
+			Weight:      "75",
+			Mask:        "none",
+			Description: "VoIP",
+		}}
+
+		device, warnings, err := opnsense.ConvertDocument(doc)
+		require.NoError(t, err)
+		assert.Empty(t, warnings)
+		require.NotNil(t, device.TrafficShaper)
+
+		assert.Equal(t, []common.TrafficShaperPipe{{
+			UUID:            "pipe-1",
+			Number:          "10000",
+			Enabled:         true,
+			Bandwidth:       "100",
+			BandwidthMetric: "Mbit",
+			Delay:           "20",
+			Mask:            "src-ip",
+			Description:     "Download",
+		}}, device.TrafficShaper.PipeEntries)
+		assert.Equal(t, []common.TrafficShaperQueue{{
+			UUID:        "queue-1",
+			Number:      "10000",
+			Pipe:        "pipe-1",
+			Weight:      "75",
+			Mask:        "none",
+			Description: "VoIP",
+		}}, device.TrafficShaper.QueueEntries)
+	})
+}
+
+func TestConverter_QueueStats(t *testing.T) {
+	t.Parallel()
+
+	t.Run("absent section returns nil", func(t *testing.T) {
+		t.Parallel()
+
+		device, warnings, err := opnsense.ConvertDocument(schema.NewOpnSenseDocument())
+		require.NoError(t, err)
+		assert.Empty(t, warnings)
+		assert.Nil(t, device.QueueStats)
+	})
+
+	t.Run("counters are parsed", func(t *testing.T) {
+		t.Parallel()
+
+		doc := schema.NewOpnSenseDocument()
+		doc.QueueStats = &schema.QueueStats{
+			Timestamp: "2024-05-01T12:00:00Z",
+			Queues: []schema.QueueEntry{{
+				Name:           "qVoIP",
+				Interface:      "wan",
+				Packets:        "182734",
+				Bytes:          "24876213",
+				DroppedPackets: "12",
+				DroppedBytes:   "1836",
+				QueueLength:    " 3 ",
+			}},
+		}
+
+		device, warnings, err := opnsense.ConvertDocument(doc)
+		require.NoError(t, err)
+		assert.Empty(t, warnings)
+		require.NotNil(t, device.QueueStats)
+		assert.Equal(t, &common.QueueStats{
+			Timestamp: "2024-05-01T12:00:00Z",
+			Queues: []common.QueueEntry{{
+				Name:           "qVoIP",
+				Interface:      "wan",
+				Packets:        182734,
+				Bytes:          24876213,
+				DroppedPackets: 12,
+				DroppedBytes:   1836,
+				QueueLength:    3,
+			}},
+		}, device.QueueStats)
+	})
+
+	t.Run("malformed counters warn", func(t *testing.T) {
+		t.Parallel()
+
+		doc := schema.NewOpnSenseDocument()
+		doc.QueueStats = &schema.QueueStats{
+			Queues: []schema.QueueEntry{{Name: "qBulk", Packets: "-1", Bytes: "n/a"}},
+		}
+
+		device, warnings, err := opnsense.ConvertDocument(doc)
+		require.NoError(t, err)
+		require.NotNil(t, device.QueueStats)
+		assert.Zero(t, device.QueueStats.Queues[0].Packets)
+		assert.Zero(t, device.QueueStats.Queues[0].Bytes)
+
+		fields := make([]string, 0, len(warnings))
+		for _, w := range warnings {
+			fields = append(fields, w.Field)
+			assert.Equal(t, common.SeverityLow, w.Severity)
+		}
+		assert.ElementsMatch(t, []string{"QueueStats.Queues[0].Packets", "QueueStats.Queues[0].Bytes"}, fields)
 	})
 }
 
@@ -334,7 +610,7 @@ func TestConverter_CaptivePortal(t *testing.T) {
 		t.Parallel()
 
 		doc := schema.NewOpnSenseDocument()
-		doc.OPNsense.Captiveportal.Zones = "zone-uuid-1"
+		doc.OPNsense.Captiveportal.Zones.Text = "zone-uuid-1"
 		doc.OPNsense.Captiveportal.Templates = "tmpl-uuid-1"
 
 		device, warnings, err := opnsense.ConvertDocument(doc)
@@ -345,6 +621,22 @@ func TestConverter_CaptivePortal(t *testing.T) {
 		cp := device.CaptivePortal
 		assert.Equal(t, "zone-uuid-1", cp.Zones)
 		assert.Equal(t, "tmpl-uuid-1", cp.Templates)
+		assert.Empty(t, cp.AuthServers)
+	})
+
+	t.Run("zone authentication servers", func(t *testing.T) {
+		t.Parallel()
+
+		doc := schema.NewOpnSenseDocument()
+		doc.OPNsense.Captiveportal.Zones.Zone = []schema.CaptivePortalZone{
+			{UUID: "zone-1", AuthServers: "corp-ldap,Local Database"},
+			{UUID: "zone-2", AuthServers: "corp-ldap, guest-radius"},
+		}
+
+		device, _, err := opnsense.ConvertDocument(doc)
+		require.NoError(t, err)
+		require.NotNil(t, device.CaptivePortal)
+		assert.Equal(t, []string{"corp-ldap", "Local Database", "guest-radius"}, device.CaptivePortal.AuthServers)
 	})
 }
 
@@ -796,6 +1088,11 @@ func TestConverter_WebGUI_Expansion(t *testing.T) {
 	doc.System.WebGUI.LoginAutocomplete = schema.BoolFlag(true)
 	doc.System.WebGUI.MaxProcesses = "4"
 	doc.System.WebGUI.Port = "8443"
+	doc.System.WebGUI.Compression = "6"
+	doc.System.WebGUI.DisableHTTPRedirect = schema.BoolFlag(true)
+	doc.System.WebGUI.NoHTTPRefererCheck = schema.BoolFlag(true)
+	doc.System.WebGUI.SSLCiphers = "ECDHE-RSA-AES256-GCM-SHA384"
+	doc.System.Theme = "opnsense-dark"
 
 	device, warnings, err := opnsense.ConvertDocument(doc)
 	require.NoError(t, err)
@@ -804,4 +1101,10 @@ func TestConverter_WebGUI_Expansion(t *testing.T) {
 	assert.True(t, device.System.WebGUI.LoginAutocomplete)
 	assert.Equal(t, "4", device.System.WebGUI.MaxProcesses)
 	assert.Equal(t, "8443", device.System.WebGUI.Port)
+	assert.Equal(t, "6", device.System.WebGUI.Compression)
+	assert.True(t, device.System.WebGUI.DisableHTTPRedirect)
+	assert.True(t, device.System.WebGUI.NoHTTPRefererCheck)
+	assert.Equal(t, "ECDHE-RSA-AES256-GCM-SHA384", device.System.WebGUI.SSLCiphers)
+	assert.Equal(t, "opnsense-dark", device.Theme)
+	assert.Contains(t, device.CosmeticSections, "theme")
 }
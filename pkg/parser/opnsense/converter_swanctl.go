@@ -0,0 +1,185 @@
+package opnsense
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	schema "github.com/EvilBit-Labs/opnDossier/pkg/schema/opnsense"
+)
+
+// swanctlVersions maps the Swanctl <version> option values to the IKE version
+// names used by legacy Phase 1 entries.
+var swanctlVersions = map[string]string{
+	"0": "auto",
+	"1": "ikev1",
+	"2": "ikev2",
+}
+
+// convertSwanctlConnections maps the connection-based IPsec configuration to
+// []common.IPsecConnection tagged with common.IPsecOriginSwanctl. Local and
+// remote authentication rounds and child SAs are attached to their
+// connection by UUID; entries that reference an unknown connection are
+// dropped with a conversion warning.
+func (c *converter) convertSwanctlConnections(sw *schema.Swanctl) []common.IPsecConnection {
+	conns := sw.Connections.Connection
+	if len(conns) == 0 {
+		c.warnOrphanSwanctlEntries(sw, nil)
+		return nil
+	}
+
+	poolNames := make(map[string]string, len(sw.Pools.Pool))
+	for _, pool := range sw.Pools.Pool {
+		poolNames[pool.UUID] = cmp.Or(pool.Name, pool.UUID)
+	}
+
+	result := make([]common.IPsecConnection, 0, len(conns))
+	index := make(map[string]int, len(conns))
+
+	for i, conn := range conns {
+		version, ok := swanctlVersions[conn.Version]
+		if !ok && conn.Version != "" {
+			c.addWarning(
+				fmt.Sprintf("VPN.IPsec.Swanctl.Connections[%d].Version", i),
+				conn.Version,
+				"unrecognized IKE version",
+				common.SeverityMedium,
+			)
+
+			version = conn.Version
+		}
+
+		var pools []string
+		for _, ref := range splitNonEmpty(conn.Pools, ",") {
+			pools = append(pools, cmp.Or(poolNames[ref], ref))
+		}
+
+		if conn.UUID != "" {
+			index[conn.UUID] = len(result)
+		}
+
+		result = append(result, common.IPsecConnection{
+			Origin:          common.IPsecOriginSwanctl,
+			ID:              conn.UUID,
+			Description:     conn.Description,
+			Disabled:        conn.Enabled != xmlBoolTrue,
+			Version:         version,
+			Aggressive:      conn.Aggressive == xmlBoolTrue,
+			LocalAddresses:  splitNonEmpty(conn.LocalAddrs, ","),
+			RemoteAddresses: splitNonEmpty(conn.RemoteAddrs, ","),
+			Proposals:       splitNonEmpty(conn.Proposals, ","),
+			DPDDelay:        conn.DPDDelay,
+			Pools:           pools,
+		})
+	}
+
+	c.warnOrphanSwanctlEntries(sw, index)
+
+	for _, local := range sortedSwanctlRounds(sw.Locals.Local) {
+		if i, ok := index[local.Connection]; ok && local.Enabled == xmlBoolTrue {
+			result[i].LocalIDs = appendNonEmpty(result[i].LocalIDs, local.ID)
+			result[i].LocalAuth = appendNonEmpty(result[i].LocalAuth, local.Auth)
+		}
+	}
+
+	for _, remote := range sortedSwanctlRounds(sw.Remotes.Remote) {
+		if i, ok := index[remote.Connection]; ok && remote.Enabled == xmlBoolTrue {
+			result[i].RemoteIDs = appendNonEmpty(result[i].RemoteIDs, remote.ID)
+			result[i].RemoteAuth = appendNonEmpty(result[i].RemoteAuth, remote.Auth)
+		}
+	}
+
+	for _, child := range sw.Children.Child {
+		i, ok := index[child.Connection]
+		if !ok {
+			continue
+		}
+
+		result[i].Children = append(result[i].Children, common.IPsecChildSA{
+			ID:                     child.UUID,
+			Description:            child.Description,
+			Disabled:               child.Enabled != xmlBoolTrue,
+			Mode:                   child.Mode,
+			LocalTrafficSelectors:  splitNonEmpty(child.LocalTS, ","),
+			RemoteTrafficSelectors: splitNonEmpty(child.RemoteTS, ","),
+			Proposals:              splitNonEmpty(child.ESPProposals, ","),
+			RekeyTime:              child.RekeyTime,
+		})
+	}
+
+	return result
+}
+
+// warnOrphanSwanctlEntries emits a conversion warning for every local,
+// remote, and child entry whose connection UUID is not in index.
+func (c *converter) warnOrphanSwanctlEntries(sw *schema.Swanctl, index map[string]int) {
+	for i, local := range sw.Locals.Local {
+		c.warnUnknownSwanctlConnection(fmt.Sprintf("Locals[%d]", i), local.Connection, index)
+	}
+
+	for i, remote := range sw.Remotes.Remote {
+		c.warnUnknownSwanctlConnection(fmt.Sprintf("Remotes[%d]", i), remote.Connection, index)
+	}
+
+	for i, child := range sw.Children.Child {
+		c.warnUnknownSwanctlConnection(fmt.Sprintf("Children[%d]", i), child.Connection, index)
+	}
+}
+
+// warnUnknownSwanctlConnection warns when the swanctl entry at path
+// references a connection UUID that is not in index.
+func (c *converter) warnUnknownSwanctlConnection(path, conn string, index map[string]int) {
+	if _, ok := index[conn]; ok {
+		return
+	}
+
+	c.addWarning(
+		"VPN.IPsec.Swanctl."+path+".Connection",
+		conn,
+		"swanctl entry references an unknown connection",
+		common.SeverityMedium,
+	)
+}
+
+// sortedSwanctlRounds returns auth rounds stably sorted by their numeric
+// <round>; rounds that are not numbers sort as round 0.
+func sortedSwanctlRounds(rounds []schema.SwanctlAuth) []schema.SwanctlAuth {
+	sorted := slices.Clone(rounds)
+	slices.SortStableFunc(sorted, func(a, b schema.SwanctlAuth) int {
+		ra, _ := strconv.Atoi(a.Round)
+		rb, _ := strconv.Atoi(b.Round)
+
+		return cmp.Compare(ra, rb)
+	})
+
+	return sorted
+}
+
+// appendNonEmpty appends v to s unless v is empty.
+func appendNonEmpty(s []string, v string) []string {
+	if v == "" {
+		return s
+	}
+
+	return append(s, v)
+}
+
+// convertSwanctlPools maps []schema.SwanctlPool to []common.IPsecPool.
+func convertSwanctlPools(pools []schema.SwanctlPool) []common.IPsecPool {
+	if len(pools) == 0 {
+		return nil
+	}
+
+	result := make([]common.IPsecPool, 0, len(pools))
+	for _, pool := range pools {
+		result = append(result, common.IPsecPool{
+			Name:       pool.Name,
+			Addresses:  pool.Addrs,
+			DNSServers: splitNonEmpty(pool.DNS, ","),
+		})
+	}
+
+	return result
+}
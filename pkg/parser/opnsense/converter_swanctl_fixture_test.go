@@ -0,0 +1,71 @@
+package opnsense_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/cfgparser"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/EvilBit-Labs/opnDossier/pkg/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParser_OPNsenseSwanctlFixture parses testdata/swanctl_test.xml
+// end-to-end and proves the swanctl connection reaches the unified IPsec
+// connection list with its authentication rounds, child SA, and address pool
+// resolved by UUID.
+func TestParser_OPNsenseSwanctlFixture(t *testing.T) {
+	t.Parallel()
+
+	fpath := filepath.Join("..", "..", "..", "testdata", "swanctl_test.xml")
+	f, err := os.Open(fpath)
+	require.NoError(t, err)
+	defer f.Close()
+
+	factory := parser.NewFactory(cfgparser.NewXMLParser())
+	device, warnings, err := factory.CreateDevice(context.Background(), f, common.DeviceTypeUnknown, false)
+	require.NoError(t, err)
+	require.NotNil(t, device)
+
+	for _, w := range warnings {
+		assert.NotContains(t, w.Field, "Swanctl", "fixture must convert without swanctl warnings")
+	}
+
+	ipsec := device.VPN.IPsec
+	assert.True(t, ipsec.Enabled)
+	require.Len(t, ipsec.Connections, 1)
+
+	assert.Equal(t, common.IPsecConnection{
+		Origin:          common.IPsecOriginSwanctl,
+		ID:              "5c1c5b3e-0d8f-4e43-9a43-6f1a7c0d2b11",
+		Description:     "Branch Office",
+		Version:         "ikev2",
+		LocalAddresses:  []string{"203.0.113.1"},
+		RemoteAddresses: []string{"198.51.100.10"},
+		LocalIDs:        []string{"fw.example.com"},
+		RemoteIDs:       []string{"branch.example.com"},
+		LocalAuth:       []string{"psk"},
+		RemoteAuth:      []string{"psk"},
+		Proposals:       []string{"aes256-sha256-modp2048", "3des-sha1-modp1024"},
+		DPDDelay:        "30",
+		Pools:           []string{"roadwarrior"},
+		Children: []common.IPsecChildSA{
+			{
+				ID:                     "9c3b4d5e-6f70-4b81-8c9d-2e3f4a5b6c7d",
+				Description:            "LAN to branch",
+				Mode:                   "tunnel",
+				LocalTrafficSelectors:  []string{"10.0.1.0/24"},
+				RemoteTrafficSelectors: []string{"10.20.0.0/24", "10.21.0.0/24"},
+				Proposals:              []string{"aes256gcm16-modp2048"},
+				RekeyTime:              "3600",
+			},
+		},
+	}, ipsec.Connections[0])
+
+	assert.Equal(t, []common.IPsecPool{
+		{Name: "roadwarrior", Addresses: "10.99.0.0/24", DNSServers: []string{"10.0.1.1"}},
+	}, ipsec.Pools)
+}
@@ -2,6 +2,7 @@ package opnsense_test
 
 import (
 	"testing"
+	"time"
 
 	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
 	"github.com/EvilBit-Labs/opnDossier/pkg/parser/opnsense"
@@ -49,6 +50,8 @@ func TestConverter_System(t *testing.T) {
 	doc.System.SSH.Group = "admins"
 	doc.System.Firmware.Version = "24.7"
 	doc.System.Firmware.Mirror = "https://mirror.example.com"
+	doc.System.SerialSpeed = "115200"
+	doc.System.PrimaryConsole = "Serial"
 	doc.System.Notes = []string{"test note"}
 
 	device, warnings, err := opnsense.ConvertDocument(doc)
@@ -82,9 +85,30 @@ func TestConverter_System(t *testing.T) {
 	assert.Equal(t, "admins", sys.SSH.Group)
 	assert.Equal(t, "24.7", sys.Firmware.Version)
 	assert.Equal(t, "https://mirror.example.com", sys.Firmware.Mirror)
+	assert.Equal(t, common.Console{SerialSpeed: 115200, PrimaryConsole: "serial"}, sys.Console)
 	assert.Equal(t, []string{"test note"}, sys.Notes)
 }
 
+func TestConverter_System_InvalidConsole(t *testing.T) {
+	t.Parallel()
+
+	doc := schema.NewOpnSenseDocument()
+	doc.System.Hostname = "fw01"
+	doc.System.SerialSpeed = "14400"
+	doc.System.PrimaryConsole = "vga"
+
+	device, warnings, err := opnsense.ConvertDocument(doc)
+	require.NoError(t, err)
+
+	assert.Equal(t, common.Console{PrimaryConsole: "vga"}, device.System.Console)
+	require.Len(t, warnings, 2)
+	assert.Equal(t, "System.Console.SerialSpeed", warnings[0].Field)
+	assert.Equal(t, "14400", warnings[0].Value)
+	assert.Equal(t, "ignored", warnings[0].Action)
+	assert.Equal(t, "System.Console.PrimaryConsole", warnings[1].Field)
+	assert.Equal(t, "vga", warnings[1].Value)
+}
+
 func TestConverter_Interfaces(t *testing.T) {
 	t.Parallel()
 
@@ -241,6 +265,31 @@ func TestConverter_NAT(t *testing.T) {
 	assert.True(t, device.NAT.InboundRules[0].NoRDR)
 }
 
+func TestConverter_NATReflectionOverride(t *testing.T) {
+	t.Parallel()
+
+	doc := schema.NewOpnSenseDocument()
+	doc.System.DisableNATReflection = "yes"
+	doc.Nat.Inbound = []schema.InboundRule{
+		{Interface: schema.InterfaceList{"wan"}, InternalIP: "192.168.1.10", NATReflection: "enable"},
+		{Interface: schema.InterfaceList{"wan"}, InternalIP: "192.168.1.11", NATReflection: "disable"},
+		{Interface: schema.InterfaceList{"wan"}, InternalIP: "192.168.1.12"},
+	}
+
+	device, warnings, err := opnsense.ConvertDocument(doc)
+	require.NoError(t, err)
+
+	assert.True(t, device.NAT.ReflectionDisabled)
+	assert.True(t, device.System.DisableNATReflection)
+	assert.Equal(t, "enable", device.NAT.InboundRules[0].NATReflection)
+
+	require.Len(t, warnings, 1, "only the rule that contradicts the system default should warn")
+	assert.Equal(t, "NAT.InboundRules[0].NATReflection", warnings[0].Field)
+	assert.Equal(t, "enable", warnings[0].Value)
+	assert.Contains(t, warnings[0].Message, "disabled system-wide")
+	assert.Equal(t, common.SeverityInfo, warnings[0].Severity)
+}
+
 func TestConverter_DHCP(t *testing.T) {
 	t.Parallel()
 
@@ -298,25 +347,34 @@ func TestConverter_VPN_OpenVPN(t *testing.T) {
 	doc := schema.NewOpnSenseDocument()
 	doc.OpenVPN.Servers = []schema.OpenVPNServer{
 		{
-			VPN_ID:            "1",
-			Description:       "Main VPN",
-			DNS_server1:       "8.8.8.8",
-			DNS_server2:       "8.8.4.4",
-			DNS_server3:       "",
-			DNS_server4:       "",
-			NTP_server1:       "pool.ntp.org",
-			NTP_server2:       "",
-			Strictusercn:      true,
-			Gwredir:           true,
-			Dynamic_ip:        true,
-			Serverbridge_dhcp: true,
-			Netbios_enable:    true,
+			VPNID:            "1",
+			Description:      "Main VPN",
+			DNSServer1:       "8.8.8.8",
+			DNSServer2:       "8.8.4.4",
+			DNSServer3:       "",
+			DNSServer4:       "",
+			NTPServer1:       "pool.ntp.org",
+			NTPServer2:       "",
+			StrictUserCN:     true,
+			GWRedir:          true,
+			DynamicIP:        true,
+			ServerBridgeDHCP: true,
+			NetBIOSEnable:    true,
+			DevMode:          "tun",
+			Topology:         "subnet",
+			AuthMode:         "Local Database",
+			OpenVPNCrypto: schema.OpenVPNCrypto{
+				DataCiphers:         "AES-256-GCM, CHACHA20-POLY1305",
+				DataCiphersFallback: "AES-256-CBC",
+				Digest:              "SHA512",
+			},
 		},
 	}
 	doc.OpenVPN.Clients = []schema.OpenVPNClient{
 		{
-			VPN_ID:      "2",
-			Description: "Remote client",
+			VPNID:         "2",
+			Description:   "Remote client",
+			OpenVPNCrypto: schema.OpenVPNCrypto{NCPCiphers: "AES-128-GCM", Crypto: "BF-CBC"},
 		},
 	}
 
@@ -335,9 +393,18 @@ func TestConverter_VPN_OpenVPN(t *testing.T) {
 	assert.True(t, srv.DynamicIP)
 	assert.True(t, srv.ServerBridgeDHCP)
 	assert.True(t, srv.NetBIOSEnable)
+	assert.Equal(t, "tun", srv.DevMode)
+	assert.Equal(t, "subnet", srv.Topology)
+	assert.Equal(t, "Local Database", srv.AuthMode)
+	assert.Equal(t, []string{"AES-256-GCM", "CHACHA20-POLY1305"}, srv.DataCiphers)
+	assert.Equal(t, "AES-256-CBC", srv.DataCiphersFallback)
+	assert.Equal(t, "SHA512", srv.Digest)
 
 	require.Len(t, device.VPN.OpenVPN.Clients, 1)
-	assert.Equal(t, "2", device.VPN.OpenVPN.Clients[0].VPNID)
+	cl := device.VPN.OpenVPN.Clients[0]
+	assert.Equal(t, "2", cl.VPNID)
+	assert.Equal(t, []string{"AES-128-GCM"}, cl.DataCiphers, "legacy ncp-ciphers fill DataCiphers")
+	assert.Equal(t, "BF-CBC", cl.DataCiphersFallback, "legacy crypto fills DataCiphersFallback")
 }
 
 func TestConverter_VPN_WireGuard(t *testing.T) {
@@ -376,6 +443,60 @@ func TestConverter_VPN_WireGuard(t *testing.T) {
 	assert.Equal(t, "peer1", device.VPN.WireGuard.Clients[0].Name)
 }
 
+func TestConverter_VPN_ZeroTier(t *testing.T) {
+	t.Parallel()
+
+	t.Run("networks", func(t *testing.T) {
+		t.Parallel()
+
+		doc := schema.NewOpnSenseDocument()
+		doc.OPNsense.ZeroTier = &schema.ZeroTierConfig{
+			Enabled:   "1",
+			NetworkID: "ignored-when-networks-listed",
+			LocalConf: schema.ZeroTierLocalConf{Port: "9994", AllowDefaultRoute: "1"},
+			Networks: schema.ZeroTierNetworks{Network: []schema.ZeroTierNetwork{
+				{UUID: "zt-1", Enabled: "1", NetworkID: "8056c2e21c000001", Description: "Branch mesh"},
+				{UUID: "zt-2", Enabled: "0", NetworkID: "8056c2e21c000002"},
+			}},
+		}
+
+		device, _, err := opnsense.ConvertDocument(doc)
+		require.NoError(t, err)
+		require.NotNil(t, device.VPN.ZeroTier)
+
+		assert.Equal(t, &common.ZeroTierConfig{
+			Enabled:           true,
+			Port:              "9994",
+			AllowDefaultRoute: true,
+			Networks: []common.ZeroTierNetwork{
+				{UUID: "zt-1", Enabled: true, NetworkID: "8056c2e21c000001", Description: "Branch mesh"},
+				{UUID: "zt-2", NetworkID: "8056c2e21c000002"},
+			},
+		}, device.VPN.ZeroTier)
+	})
+
+	t.Run("top-level network ID", func(t *testing.T) {
+		t.Parallel()
+
+		doc := schema.NewOpnSenseDocument()
+		doc.OPNsense.ZeroTier = &schema.ZeroTierConfig{Enabled: "1", NetworkID: "8056c2e21c000001"}
+
+		device, _, err := opnsense.ConvertDocument(doc)
+		require.NoError(t, err)
+		require.NotNil(t, device.VPN.ZeroTier)
+		assert.Equal(t, []common.ZeroTierNetwork{{Enabled: true, NetworkID: "8056c2e21c000001"}},
+			device.VPN.ZeroTier.Networks)
+	})
+
+	t.Run("absent", func(t *testing.T) {
+		t.Parallel()
+
+		device, _, err := opnsense.ConvertDocument(schema.NewOpnSenseDocument())
+		require.NoError(t, err)
+		assert.Nil(t, device.VPN.ZeroTier)
+	})
+}
+
 func TestConverter_VPN_IPsec(t *testing.T) {
 	t.Parallel()
 
@@ -486,6 +607,41 @@ func TestConverter_IDS_Enabled(t *testing.T) {
 	assert.True(t, device.IDS.SyslogEveEnabled)
 }
 
+func TestConverter_IDS_Policies(t *testing.T) {
+	t.Parallel()
+
+	doc := schema.NewOpnSenseDocument()
+	doc.OPNsense.IntrusionDetectionSystem = &schema.IDS{}
+	doc.OPNsense.IntrusionDetectionSystem.Policies.Items = []schema.IDSPolicyItem{
+		{
+			Enabled:     "1",
+			Priority:    "10",
+			Action:      "alert,drop",
+			Rulesets:    "rs-1, rs-2",
+			Content:     "signature_severity.Major",
+			NewAction:   "drop",
+			Description: "Block major",
+		},
+		{Enabled: "0", Priority: "20", NewAction: "alert"},
+	}
+
+	device, _, err := opnsense.ConvertDocument(doc)
+	require.NoError(t, err)
+	require.NotNil(t, device.IDS)
+	assert.Equal(t, []common.IDSPolicy{
+		{
+			Description:  "Block major",
+			Enabled:      true,
+			Priority:     "10",
+			Rulesets:     []string{"rs-1", "rs-2"},
+			MatchActions: []string{"alert", "drop"},
+			Filters:      []string{"signature_severity.Major"},
+			Action:       "drop",
+		},
+		{Priority: "20", Action: "alert"},
+	}, device.IDS.Policies)
+}
+
 func TestConverter_Syslog(t *testing.T) {
 	t.Parallel()
 
@@ -532,6 +688,7 @@ func TestConverter_Users(t *testing.T) {
 			Disabled: true,
 			Scope:    "local",
 			UID:      "2001",
+			Expires:  "06/30/2025",
 		},
 	}
 
@@ -549,6 +706,10 @@ func TestConverter_Users(t *testing.T) {
 
 	op := device.Users[1]
 	assert.True(t, op.Disabled)
+	assert.Equal(t, "06/30/2025", op.Expires)
+	assert.Equal(t, time.Date(2025, time.June, 30, 0, 0, 0, 0, time.UTC).Unix(), op.ExpiryDate)
+	assert.True(t, op.HasExpiryDate)
+	assert.False(t, admin.HasExpiryDate, "an account without an expiry date never expires")
 }
 
 func TestConverter_Sysctl(t *testing.T) {
@@ -585,6 +746,38 @@ func TestConverter_Revision(t *testing.T) {
 	assert.Equal(t, "config backup", device.Revision.Description)
 }
 
+func TestConverter_Compatibility(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		version string
+		want    string
+	}{
+		{schema.MaxTestedConfigVersion, ""},
+		{"25.1", "config format 25.1 newer than tested " + schema.MaxTestedConfigVersion},
+		{"27.1", "config format 27.1 newer than tested " + schema.MaxTestedConfigVersion},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			t.Parallel()
+
+			doc := schema.NewOpnSenseDocument()
+			doc.Version = tt.version
+
+			device, _, err := opnsense.ConvertDocument(doc)
+			require.NoError(t, err)
+
+			if tt.want == "" {
+				assert.Empty(t, device.Compatibility)
+				return
+			}
+			assert.Contains(t, device.Compatibility, tt.want)
+			assert.Equal(t, device.Compatibility, common.NewExportMeta(device, "dev").Compatibility)
+		})
+	}
+}
+
 func TestConverter_ComputedFieldsNil(t *testing.T) {
 	t.Parallel()
 
@@ -871,7 +1064,10 @@ func TestConverter_VLANs(t *testing.T) {
 
 	doc := schema.NewOpnSenseDocument()
 	doc.VLANs.VLAN = []schema.VLAN{
-		{If: "igb0", Tag: "100", Descr: "Guest VLAN", Vlanif: "igb0_vlan100"},
+		{
+			If: "igb0", Tag: "100", Descr: "Guest VLAN", Vlanif: "igb0_vlan100",
+			Created: &schema.Created{Username: "root@10.0.0.5", Time: "1700000000.1234", Description: "/interfaces_vlan_edit.php"},
+		},
 	}
 
 	device, warnings, err := opnsense.ConvertDocument(doc)
@@ -882,6 +1078,138 @@ func TestConverter_VLANs(t *testing.T) {
 	assert.Equal(t, "100", device.VLANs[0].Tag)
 	assert.Equal(t, "Guest VLAN", device.VLANs[0].Description)
 	assert.Equal(t, "igb0_vlan100", device.VLANs[0].VLANIf)
+	assert.Equal(t, &common.ChangeRecord{
+		Username:    "root@10.0.0.5",
+		Time:        "1700000000.1234",
+		Description: "/interfaces_vlan_edit.php",
+	}, device.VLANs[0].Created)
+	assert.Nil(t, device.VLANs[0].Updated)
+}
+
+func TestConverter_RuleChangeRecords(t *testing.T) {
+	t.Parallel()
+
+	updated := &schema.Updated{Username: "admin@10.0.0.7", Time: "1710000000.5", Description: "/firewall_rules_edit.php"}
+
+	doc := schema.NewOpnSenseDocument()
+	doc.Filter.Rule = []schema.Rule{
+		{Type: "pass", Interface: schema.InterfaceList{"lan"}, Updated: updated},
+	}
+	doc.Nat.Outbound.Rule = []schema.NATRule{
+		{Interface: schema.InterfaceList{"wan"}, Created: &schema.Created{Username: "root", Time: "1700000000"}},
+	}
+	doc.Nat.Inbound = []schema.InboundRule{
+		{Interface: schema.InterfaceList{"wan"}, InternalIP: "10.0.0.10", Updated: updated},
+	}
+
+	device, _, err := opnsense.ConvertDocument(doc)
+	require.NoError(t, err)
+
+	want := &common.ChangeRecord{
+		Username:    "admin@10.0.0.7",
+		Time:        "1710000000.5",
+		Description: "/firewall_rules_edit.php",
+	}
+
+	require.Len(t, device.FirewallRules, 1)
+	assert.Equal(t, want, device.FirewallRules[0].Updated)
+	assert.Nil(t, device.FirewallRules[0].Created)
+
+	require.Len(t, device.NAT.OutboundRules, 1)
+	assert.Equal(t, &common.ChangeRecord{Username: "root", Time: "1700000000"}, device.NAT.OutboundRules[0].Created)
+	assert.Nil(t, device.NAT.OutboundRules[0].Updated)
+
+	require.Len(t, device.NAT.InboundRules, 1)
+	assert.Equal(t, want, device.NAT.InboundRules[0].Updated)
+}
+
+func TestConverter_Schedules(t *testing.T) {
+	t.Parallel()
+
+	t.Run("absent section returns nil", func(t *testing.T) {
+		t.Parallel()
+
+		device, warnings, err := opnsense.ConvertDocument(schema.NewOpnSenseDocument())
+		require.NoError(t, err)
+		assert.Empty(t, warnings)
+		assert.Nil(t, device.Schedules)
+	})
+
+	t.Run("dates and weekdays are parsed", func(t *testing.T) {
+		t.Parallel()
+
+		doc := schema.NewOpnSenseDocument()
+		anyStr := ""
+		doc.Filter.Rule = []schema.Rule{{
+			Type:        "pass",
+			Interface:   schema.InterfaceList{"lan"},
+			Source:      schema.Source{Any: &anyStr},
+			Destination: schema.Destination{Any: &anyStr},
+			Sched:       "OfficeHours",
+		}}
+		doc.Schedules.Schedule = []schema.Schedule{
+			{
+				Name:  "Maintenance",
+				Descr: "Spring window",
+				TimeRanges: []schema.ScheduleTimeRange{
+					{Month: "3,3", Day: "14,15", Hour: "0:00-23:59"},
+					{Month: "12", Day: "25", RangeDescr: "Christmas"},
+				},
+			},
+			{
+				Name:       "OfficeHours",
+				TimeRanges: []schema.ScheduleTimeRange{{Position: "1, 2,3,4,5", Hour: " 8:00-17:00 "}},
+			},
+		}
+
+		device, warnings, err := opnsense.ConvertDocument(doc)
+		require.NoError(t, err)
+		assert.Empty(t, warnings)
+		require.Len(t, device.FirewallRules, 1)
+		assert.Equal(t, "OfficeHours", device.FirewallRules[0].Schedule)
+		assert.Equal(t, []common.Schedule{
+			{
+				Name:        "Maintenance",
+				Description: "Spring window",
+				TimeRanges: []common.ScheduleTimeRange{
+					{
+						Dates: []common.ScheduleDate{{Month: 3, Day: 14}, {Month: 3, Day: 15}},
+						Hours: "0:00-23:59",
+					},
+					{Dates: []common.ScheduleDate{{Month: 12, Day: 25}}, Description: "Christmas"},
+				},
+			},
+			{
+				Name: "OfficeHours",
+				TimeRanges: []common.ScheduleTimeRange{
+					{Weekdays: []int{1, 2, 3, 4, 5}, Hours: "8:00-17:00"},
+				},
+			},
+		}, device.Schedules)
+	})
+
+	t.Run("invalid lists are dropped with a warning", func(t *testing.T) {
+		t.Parallel()
+
+		doc := schema.NewOpnSenseDocument()
+		doc.Schedules.Schedule = []schema.Schedule{{
+			Name: "Broken",
+			TimeRanges: []schema.ScheduleTimeRange{
+				{Month: "3,4", Day: "14"},
+				{Position: "1,9", Hour: "8:00-17:00"},
+				{Month: "3,13", Day: "14,15"},
+			},
+		}}
+
+		device, warnings, err := opnsense.ConvertDocument(doc)
+		require.NoError(t, err)
+		require.Len(t, warnings, 3)
+		assert.Equal(t, "Schedules[0].TimeRanges[0]", warnings[0].Field)
+		assert.Equal(t, "Schedules[0].TimeRanges[1].Position", warnings[1].Field)
+		assert.Equal(t, "Schedules[0].TimeRanges[2].Month", warnings[2].Field)
+		require.Len(t, device.Schedules, 1)
+		assert.Equal(t, []common.ScheduleTimeRange{{}, {Hours: "8:00-17:00"}, {}}, device.Schedules[0].TimeRanges)
+	})
 }
 
 func TestConverter_Groups(t *testing.T) {
@@ -895,7 +1223,7 @@ func TestConverter_Groups(t *testing.T) {
 			Scope:       "local",
 			Gid:         "1999",
 			Member:      "0",
-			Priv:        "page-all",
+			Privileges:  []string{"page-all", "page-dashboard-all"},
 		},
 	}
 
@@ -905,7 +1233,63 @@ func TestConverter_Groups(t *testing.T) {
 	require.Len(t, device.Groups, 1)
 	assert.Equal(t, "admins", device.Groups[0].Name)
 	assert.Equal(t, "1999", device.Groups[0].GID)
-	assert.Equal(t, "page-all", device.Groups[0].Privileges)
+	assert.Equal(t, []string{"page-all", "page-dashboard-all"}, device.Groups[0].Privileges)
+	assert.True(t, device.Groups[0].HasPrivilege("page-all"))
+}
+
+func TestConverter_AuthServers(t *testing.T) {
+	t.Parallel()
+
+	doc := schema.NewOpnSenseDocument()
+	doc.System.WebGUI.AuthMode = "Local Database,corp-ldap"
+	doc.System.AuthServer = []schema.AuthServer{
+		{
+			RefID:       "5f1a",
+			Type:        "ldap",
+			Name:        "corp-ldap",
+			Host:        "ldap.example.com",
+			LDAPURLType: "TCP - Standard",
+			LDAPPort:    "389",
+			LDAPScope:   "subtree",
+			LDAPBaseDN:  "dc=example,dc=com",
+			LDAPAuthCN:  "ou=users,dc=example,dc=com",
+			LDAPBindDN:  "cn=firewall,dc=example,dc=com",
+			LDAPBindPW:  "hunter2",
+		},
+		{Type: "ldap", Name: "corp-ldaps", Host: "dc1", LDAPURLType: "SSL - Encrypted", LDAPPort: "636"},
+		{Type: "ldap", Name: "corp-starttls", Host: "dc2", LDAPURLType: "TCP - STARTTLS"},
+		{
+			Type:           "radius",
+			Name:           "guest-radius",
+			Host:           "10.0.0.5",
+			RADIUSSecret:   "short",
+			RADIUSAuthPort: "1812",
+		},
+	}
+
+	device, warnings, err := opnsense.ConvertDocument(doc)
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+	assert.Equal(t, "Local Database,corp-ldap", device.System.WebGUI.AuthMode)
+	require.Len(t, device.AuthServers, 4)
+
+	ldap := device.AuthServers[0]
+	assert.Equal(t, common.AuthServerLDAP, ldap.Type)
+	assert.Equal(t, "389", ldap.Port)
+	assert.Equal(t, common.LDAPTransportTCP, ldap.Transport)
+	assert.Equal(t, "dc=example,dc=com", ldap.BaseDN)
+	assert.Equal(t, "ou=users,dc=example,dc=com", ldap.AuthContainers)
+	assert.Equal(t, "[REDACTED]", ldap.BindPassword)
+
+	assert.Equal(t, common.LDAPTransportSSL, device.AuthServers[1].Transport)
+	assert.Empty(t, device.AuthServers[1].BindPassword)
+	assert.Equal(t, common.LDAPTransportStartTLS, device.AuthServers[2].Transport)
+
+	radius := device.AuthServers[3]
+	assert.Equal(t, common.AuthServerRADIUS, radius.Type)
+	assert.Equal(t, "1812", radius.Port)
+	assert.Equal(t, 5, radius.SecretLength)
+	assert.Empty(t, radius.Transport)
 }
 
 func TestConverter_LoadBalancer(t *testing.T) {
@@ -933,16 +1317,77 @@ func TestConverter_LoadBalancer(t *testing.T) {
 	assert.Equal(t, "200", device.LoadBalancer.MonitorTypes[0].Options.Code)
 }
 
+func TestConverter_WakeOnLAN(t *testing.T) {
+	t.Parallel()
+
+	doc := schema.NewOpnSenseDocument()
+
+	device, _, err := opnsense.ConvertDocument(doc)
+	require.NoError(t, err)
+	assert.Nil(t, device.WakeOnLAN)
+
+	doc.WOL.Entries = []schema.WOLEntry{
+		{Interface: "lan", MAC: "00:11:22:33:44:55", Descr: "Build server"},
+		{Interface: "opt1", MAC: "66:77:88:99:aa:bb"},
+	}
+
+	device, warnings, err := opnsense.ConvertDocument(doc)
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+	assert.Equal(t, []common.WakeOnLANEntry{
+		{Interface: "lan", MAC: "00:11:22:33:44:55", Description: "Build server"},
+		{Interface: "opt1", MAC: "66:77:88:99:aa:bb"},
+	}, device.WakeOnLAN)
+}
+
+func TestConverter_CosmeticSections(t *testing.T) {
+	t.Parallel()
+
+	doc := schema.NewOpnSenseDocument()
+
+	device, _, err := opnsense.ConvertDocument(doc)
+	require.NoError(t, err)
+	assert.Nil(t, device.CosmeticSections)
+
+	doc.Theme = "opnsense"
+	doc.Widgets.Sequence = "system_information-container:00000000-col1:show"
+	doc.RRDData = &schema.RawSection{InnerXML: "<rrddatafile/>"}
+
+	device, _, err = opnsense.ConvertDocument(doc)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"widgets", "theme", "rrddata"}, device.CosmeticSections)
+}
+
 func TestConverter_NTP(t *testing.T) {
 	t.Parallel()
 
 	doc := schema.NewOpnSenseDocument()
 	doc.Ntpd.Prefer = "0.opnsense.pool.ntp.org"
+	doc.Ntpd.MinPoll = "6"
+	doc.Ntpd.MaxPoll = "10"
+	doc.Ntpd.Orphan = "12"
+	doc.Ntpd.NoQuery = true
+	doc.Ntpd.Restrict = []string{"10.0.0.0 mask 255.0.0.0 nomodify"}
+	doc.Ntpd.TimeServers = []schema.NTPServer{
+		{Address: "0.opnsense.pool.ntp.org", Prefer: true},
+		{Address: "192.0.2.10", NoSelect: true},
+	}
 
 	device, warnings, err := opnsense.ConvertDocument(doc)
 	require.NoError(t, err)
 	assert.Empty(t, warnings)
-	assert.Equal(t, "0.opnsense.pool.ntp.org", device.NTP.PreferredServer)
+	assert.Equal(t, common.NTPConfig{
+		PreferredServer: "0.opnsense.pool.ntp.org",
+		Servers: []common.NTPServer{
+			{Address: "0.opnsense.pool.ntp.org", Prefer: true},
+			{Address: "192.0.2.10", NoSelect: true},
+		},
+		MinPoll:      "6",
+		MaxPoll:      "10",
+		Orphan:       "12",
+		NoQuery:      true,
+		Restrictions: []string{"10.0.0.0 mask 255.0.0.0 nomodify"},
+	}, device.NTP)
 }
 
 func TestConverter_SNMP(t *testing.T) {
@@ -1159,14 +1604,23 @@ func TestConverter_Users_Warnings(t *testing.T) {
 			Name: "",
 			UID:  "",
 		},
+		{
+			Name:    "contractor",
+			UID:     "2002",
+			Expires: "2025-06-30",
+		},
 	}
 
-	_, warnings, err := opnsense.ConvertDocument(doc)
+	device, warnings, err := opnsense.ConvertDocument(doc)
 	require.NoError(t, err)
-	require.Len(t, warnings, 2)
+	require.Len(t, warnings, 3)
+	assert.False(t, device.Users[1].HasExpiryDate, "an unparseable expiry date is left unset")
 
 	assert.Equal(t, "Users[0].Name", warnings[0].Field)
 	assert.Equal(t, common.SeverityHigh, warnings[0].Severity)
 	assert.Equal(t, "Users[0].UID", warnings[1].Field)
 	assert.Equal(t, common.SeverityHigh, warnings[1].Severity)
+	assert.Equal(t, "Users[1].Expires", warnings[2].Field)
+	assert.Equal(t, "2025-06-30", warnings[2].Value)
+	assert.Equal(t, common.SeverityLow, warnings[2].Severity)
 }
@@ -0,0 +1,85 @@
+package opnsense_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/analysis"
+	"github.com/EvilBit-Labs/opnDossier/internal/cfgparser"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/EvilBit-Labs/opnDossier/pkg/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParser_OPNsenseUPnPFixture parses testdata/upnp_test.xml end-to-end and
+// proves the miniupnpd settings and ACL reach the CommonDevice in evaluation
+// order, that the malformed ACL entry surfaces as a conversion warning, and
+// that the default-allow service is reported as High.
+func TestParser_OPNsenseUPnPFixture(t *testing.T) {
+	t.Parallel()
+
+	fpath := filepath.Join("..", "..", "..", "testdata", "upnp_test.xml")
+	f, err := os.Open(fpath)
+	require.NoError(t, err)
+	defer f.Close()
+
+	factory := parser.NewFactory(cfgparser.NewXMLParser())
+	device, warnings, err := factory.CreateDevice(context.Background(), f, common.DeviceTypeUnknown, false)
+	require.NoError(t, err)
+	require.NotNil(t, device)
+
+	require.NotNil(t, device.UPnP)
+	assert.Equal(t, common.UPnPConfig{
+		Enabled:           true,
+		UPnPEnabled:       true,
+		NATPMPEnabled:     true,
+		ExternalInterface: "wan",
+		Interfaces:        []string{"lan", "opt1"},
+		DefaultDeny:       false,
+		Rules: []common.UPnPPermissionRule{
+			{
+				Action:          "allow",
+				ExternalPorts:   "1024-65535",
+				InternalAddress: "192.168.1.20/32",
+				InternalPorts:   "1024-65535",
+				Description:     "Game console",
+			},
+			{
+				Action:          "allow",
+				ExternalPorts:   "0-65535",
+				InternalAddress: "192.168.50.0/24",
+				InternalPorts:   "0-65535",
+				Description:     "Guest network",
+			},
+			{
+				Action:          "deny",
+				ExternalPorts:   "0-65535",
+				InternalAddress: "0.0.0.0/0",
+				InternalPorts:   "0-65535",
+			},
+		},
+	}, *device.UPnP)
+
+	var warned bool
+	for _, w := range warnings {
+		if w.Field == "UPnP.Rules" {
+			warned = true
+			assert.Equal(t, "allow 8080", w.Value)
+		}
+	}
+	assert.True(t, warned, "malformed permuser entry must produce a conversion warning")
+
+	var upnpFindings []common.SecurityFinding
+	for _, f := range analysis.DetectSecurityIssues(device) {
+		if f.Component == "upnp" {
+			upnpFindings = append(upnpFindings, f)
+		}
+	}
+
+	require.Len(t, upnpFindings, 1)
+	assert.Equal(t, common.SeverityHigh, upnpFindings[0].Severity)
+	assert.Equal(t, "UPnP Enabled Without Default Deny", upnpFindings[0].Issue)
+}
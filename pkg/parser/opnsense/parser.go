@@ -31,6 +31,7 @@ import (
 	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
 	"github.com/EvilBit-Labs/opnDossier/pkg/parser"
 	schema "github.com/EvilBit-Labs/opnDossier/pkg/schema/opnsense"
+	"github.com/EvilBit-Labs/opnDossier/pkg/schema/opnsense/migration"
 )
 
 // Parser implements the DeviceParser interface for OPNsense configuration
@@ -74,14 +75,45 @@ func (p *Parser) ParseAndValidate(
 	return toCommonDevice(doc)
 }
 
-// toCommonDevice converts a parsed OPNsense document into a CommonDevice.
+// toCommonDevice migrates a parsed OPNsense document written by an older
+// release to the current encodings and converts it into a CommonDevice. Each
+// rewrite made by the migration is reported as an Info warning ahead of the
+// conversion warnings.
 func toCommonDevice(doc *schema.OpnSenseDocument) (*common.CommonDevice, []common.ConversionWarning, error) {
-	device, warnings, err := newConverter().ToCommonDevice(doc)
+	migrated, notes := migration.Migrate(doc, "")
+
+	device, warnings, err := newConverter().ToCommonDevice(migrated)
 	if err != nil {
 		return nil, nil, fmt.Errorf("opnsense parser: %w", err)
 	}
 
-	return device, warnings, nil
+	if len(notes) == 0 {
+		return device, warnings, nil
+	}
+
+	return device, append(migrationWarnings(notes), warnings...), nil
+}
+
+// migrationWarnings reports each migration note as an Info conversion
+// warning whose Action records the value written in place of the old one.
+func migrationWarnings(notes []migration.MigrationNote) []common.ConversionWarning {
+	warnings := make([]common.ConversionWarning, 0, len(notes))
+	for _, n := range notes {
+		action := fmt.Sprintf("migrated to %q", n.NewValue)
+		if n.NewValue == "" {
+			action = "cleared"
+		}
+
+		warnings = append(warnings, common.ConversionWarning{
+			Field:    n.Field,
+			Value:    n.OldValue,
+			Message:  n.Reason,
+			Action:   action,
+			Severity: common.SeverityInfo,
+		})
+	}
+
+	return warnings
 }
 
 // NewParserFactory returns a new DeviceParser configured for OPNsense devices.
@@ -0,0 +1,68 @@
+package opnsense_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/cfgparser"
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/EvilBit-Labs/opnDossier/pkg/parser/opnsense"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParser_MigratesLegacyEncodings parses a config written with 21.x
+// encodings and checks the converted device reads them as current values,
+// with every rewrite reported as an Info warning.
+func TestParser_MigratesLegacyEncodings(t *testing.T) {
+	t.Parallel()
+
+	const legacyXML = `<?xml version="1.0"?>
+<opnsense>
+  <system>
+    <hostname>legacy-fw</hostname>
+    <domain>example.com</domain>
+    <optimization>normal</optimization>
+    <powerd_ac_mode>maximum</powerd_ac_mode>
+    <webgui>
+      <protocol>https</protocol>
+    </webgui>
+    <ssh>
+      <group>admins</group>
+    </ssh>
+  </system>
+  <interfaces>
+    <wan>
+      <if>em0</if>
+      <enable>yes</enable>
+      <blockpriv>on</blockpriv>
+    </wan>
+  </interfaces>
+</opnsense>`
+
+	device, warnings, err := opnsense.NewParser(cfgparser.NewXMLParser()).
+		Parse(context.Background(), strings.NewReader(legacyXML))
+	require.NoError(t, err)
+
+	require.Len(t, device.Interfaces, 1)
+	wan := device.Interfaces[0]
+	assert.True(t, wan.Enabled, "a legacy \"yes\" enable flag must read as enabled")
+	assert.True(t, wan.BlockPrivate)
+	assert.Equal(t, "max", device.System.PowerdACMode)
+
+	byField := make(map[string]common.ConversionWarning, len(warnings))
+	for _, w := range warnings {
+		byField[w.Field] = w
+	}
+
+	require.Contains(t, byField, "interfaces.wan.enable")
+	enable := byField["interfaces.wan.enable"]
+	assert.Equal(t, common.SeverityInfo, enable.Severity)
+	assert.Equal(t, "yes", enable.Value)
+	assert.Equal(t, `migrated to "1"`, enable.Action)
+
+	require.Contains(t, byField, "system.powerd_ac_mode")
+	assert.Equal(t, `migrated to "max"`, byField["system.powerd_ac_mode"].Action)
+	assert.Contains(t, byField, "interfaces.wan.blockpriv")
+}
@@ -12,4 +12,7 @@ const (
 	// are decoded directly into string fields — use this constant for
 	// direct equality checks.
 	xmlBoolYes = "yes"
+
+	// redactedValue replaces credentials that must not reach the export.
+	redactedValue = "[REDACTED]"
 )
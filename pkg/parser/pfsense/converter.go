@@ -4,10 +4,13 @@ import (
 	"errors"
 	"fmt"
 	"slices"
+	"strconv"
 	"strings"
 
 	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/EvilBit-Labs/opnDossier/pkg/schema/opnsense"
 	"github.com/EvilBit-Labs/opnDossier/pkg/schema/pfsense"
+	"github.com/EvilBit-Labs/opnDossier/pkg/schema/shared"
 )
 
 // ErrNilDocument is returned when ToCommonDevice receives a nil document.
@@ -135,33 +138,60 @@ func (c *converter) ToCommonDevice(
 	namedObjects := c.convertNamedObjects(doc)
 
 	device := &common.CommonDevice{
-		DeviceType:    common.DeviceTypePfSense,
-		Version:       doc.Version,
-		System:        c.convertSystem(doc),
-		Interfaces:    c.convertInterfaces(doc),
-		VLANs:         c.convertVLANs(doc),
-		PPPs:          c.convertPPPs(doc),
-		NamedObjects:  namedObjects,
-		FirewallRules: c.convertFirewallRules(doc, namedObjects),
-		NAT:           c.convertNAT(doc),
-		DHCP:          c.convertDHCP(doc),
-		DNS:           c.convertDNS(doc),
-		SNMP:          c.convertSNMP(doc),
-		LoadBalancer:  c.convertLoadBalancer(doc),
-		VPN:           c.convertVPN(doc),
-		Routing:       c.convertRouting(doc),
-		Syslog:        c.convertSyslog(doc),
-		Users:         c.convertUsers(doc),
-		Groups:        c.convertGroups(doc),
-		Revision:      c.convertRevision(doc),
-		Certificates:  c.convertCertificates(doc),
-		CAs:           c.convertCAs(doc),
-		Cron:          c.convertCron(doc),
+		DeviceType:       common.DeviceTypePfSense,
+		Version:          doc.Version,
+		SourceEncoding:   c.convertEncoding(doc.Encoding),
+		CosmeticSections: convertCosmeticSections(doc),
+		System:           c.convertSystem(doc),
+		Interfaces:       c.convertInterfaces(doc),
+		VLANs:            c.convertVLANs(doc),
+		PPPs:             c.convertPPPs(doc),
+		NamedObjects:     namedObjects,
+		FirewallRules:    c.convertFirewallRules(doc, namedObjects),
+		NAT:              c.convertNAT(doc),
+		DHCP:             c.convertDHCP(doc),
+		DNS:              c.convertDNS(doc),
+		SNMP:             c.convertSNMP(doc),
+		LoadBalancer:     c.convertLoadBalancer(doc),
+		UPnP:             c.convertUPnP(doc),
+		VPN:              c.convertVPN(doc),
+		Routing:          c.convertRouting(doc),
+		Syslog:           c.convertSyslog(doc),
+		Users:            c.convertUsers(doc),
+		Groups:           c.convertGroups(doc),
+		AuthServers:      c.convertAuthServers(doc),
+		Revision:         c.convertRevision(doc),
+		Certificates:     c.convertCertificates(doc),
+		CAs:              c.convertCAs(doc),
+		Cron:             c.convertCron(doc),
 	}
 
 	return device, c.warnings, nil
 }
 
+// convertEncoding returns the name of the input encoding recorded by the
+// parser, warning when invalid byte sequences were replaced while decoding.
+func (c *converter) convertEncoding(enc opnsense.InputEncoding) string {
+	if enc.Replacements > 0 {
+		c.addWarning("SourceEncoding", enc.Name,
+			fmt.Sprintf("%d invalid byte sequence(s) replaced with U+FFFD while decoding", enc.Replacements),
+			common.SeverityMedium)
+	}
+
+	return enc.Name
+}
+
+// convertCosmeticSections lists the presentation sections present in doc.
+// Of these, the pfSense schema models only the dashboard widgets, so the
+// result is either ["widgets"] or nil.
+func convertCosmeticSections(doc *pfsense.Document) []string {
+	if doc.Widgets == (pfsense.Widgets{}) {
+		return nil
+	}
+
+	return []string{"widgets"}
+}
+
 // convertSystem maps doc.System to common.System.
 func (c *converter) convertSystem(doc *pfsense.Document) common.System {
 	sys := doc.System
@@ -187,20 +217,48 @@ func (c *converter) convertSystem(doc *pfsense.Document) common.System {
 		PowerdNormalMode:              sys.PowerdNormalMode,
 		Bogons:                        common.Bogons{Interval: sys.Bogons.Interval},
 		WebGUI: common.WebGUI{
-			Protocol:          sys.WebGUI.Protocol,
-			Port:              sys.WebGUI.Port,
-			SSLCertRef:        sys.WebGUI.SSLCertRef,
-			LoginAutocomplete: bool(sys.WebGUI.LoginAutocomplete),
-			MaxProcesses:      sys.WebGUI.MaxProcesses,
+			Protocol:            sys.WebGUI.Protocol,
+			Port:                sys.WebGUI.Port,
+			SSLCertRef:          sys.WebGUI.SSLCertRef,
+			LoginAutocomplete:   bool(sys.WebGUI.LoginAutocomplete),
+			MaxProcesses:        sys.WebGUI.MaxProcesses,
+			AuthMode:            sys.WebGUI.AuthMode,
+			DisableHTTPRedirect: bool(sys.WebGUI.DisableHTTPRedirect),
+			NoHTTPRefererCheck:  bool(sys.WebGUI.NoHTTPRefererCheck),
 		},
 		SSH: common.SSH{
 			Enabled: bool(sys.SSH.Enabled),
 			Port:    sys.SSH.Port,
 			Group:   sys.SSH.Group,
 		},
+		Console: c.convertConsole(sys.SerialSpeed, sys.PrimaryConsole),
 	}
 }
 
+// convertConsole maps the <serialspeed> and <primaryconsole> settings to
+// common.Console. A speed that is not a supported baud rate is dropped with a
+// warning; an unrecognized console type is kept and warned about.
+func (c *converter) convertConsole(serialSpeed, primaryConsole string) common.Console {
+	var speed int
+	if value := strings.TrimSpace(serialSpeed); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || !common.ValidSerialSpeed(parsed) {
+			c.addWarning("System.Console.SerialSpeed", serialSpeed,
+				"serial console speed is not a supported baud rate; ignored", common.SeverityLow)
+		} else {
+			speed = parsed
+		}
+	}
+
+	console := strings.ToLower(strings.TrimSpace(primaryConsole))
+	if console != "" && !common.ValidPrimaryConsole(console) {
+		c.addWarning("System.Console.PrimaryConsole", primaryConsole,
+			"unrecognized primary console", common.SeverityLow)
+	}
+
+	return common.Console{SerialSpeed: speed, PrimaryConsole: console}
+}
+
 // convertUsers maps doc.System.User to []common.User.
 func (c *converter) convertUsers(doc *pfsense.Document) []common.User {
 	if len(doc.System.User) == 0 {
@@ -216,14 +274,25 @@ func (c *converter) convertUsers(doc *pfsense.Document) []common.User {
 			c.addWarning(fmt.Sprintf("Users[%d].UID", i), u.Name, "user has no UID", common.SeverityHigh)
 		}
 
-		result = append(result, common.User{
-			Name:        u.Name,
-			Disabled:    bool(u.Disabled),
-			Description: u.Descr,
-			Scope:       u.Scope,
-			GroupName:   u.Groupname,
-			UID:         u.UID,
-		})
+		user := common.User{
+			Name:           u.Name,
+			Disabled:       bool(u.Disabled),
+			Description:    u.Descr,
+			Scope:          u.Scope,
+			GroupName:      u.Groupname,
+			UID:            u.UID,
+			AuthorizedKeys: u.AuthorizedKeys,
+			Expires:        u.Expires,
+		}
+		expiryDate, err := shared.ParseExpiryDate(u.Expires)
+		if err != nil {
+			c.addWarning(fmt.Sprintf("Users[%d].Expires", i), u.Expires,
+				"user expiry date is not in MM/DD/YYYY format", common.SeverityLow)
+		} else if !expiryDate.IsZero() {
+			user.ExpiryDate, user.HasExpiryDate = expiryDate.Unix(), true
+		}
+
+		result = append(result, user)
 	}
 
 	return result
@@ -243,9 +312,52 @@ func (c *converter) convertGroups(doc *pfsense.Document) []common.Group {
 			Scope:       g.Scope,
 			GID:         g.Gid,
 			Member:      strings.Join(g.Member, ", "),
-			Privileges:  strings.Join(g.Priv, ", "),
+			Privileges:  slices.Clone(g.Priv),
 		})
 	}
 
 	return result
 }
+
+// convertAuthServers maps doc.System.AuthServer to []common.AuthServer. The
+// LDAP bind password is replaced with redactedValue and the RADIUS shared
+// secret is reduced to its length, so neither reaches the export.
+func (c *converter) convertAuthServers(doc *pfsense.Document) []common.AuthServer {
+	if len(doc.System.AuthServer) == 0 {
+		return nil
+	}
+
+	result := make([]common.AuthServer, 0, len(doc.System.AuthServer))
+	for i, a := range doc.System.AuthServer {
+		if a.Name == "" {
+			c.addWarning(fmt.Sprintf("AuthServers[%d].Name", i), a.Host, "authentication server has empty name", common.SeverityMedium)
+		}
+
+		server := common.AuthServer{
+			RefID: a.RefID,
+			Name:  a.Name,
+			Type:  common.AuthServerType(strings.ToLower(a.Type)),
+			Host:  a.Host,
+		}
+
+		switch server.Type {
+		case common.AuthServerLDAP:
+			server.Port = a.LDAPPort
+			server.Transport = normalizeLDAPTransport(a.LDAPURLType)
+			server.Scope = a.LDAPScope
+			server.BaseDN = a.LDAPBaseDN
+			server.AuthContainers = a.LDAPAuthCN
+			server.BindDN = a.LDAPBindDN
+			if a.LDAPBindPW != "" {
+				server.BindPassword = redactedValue
+			}
+		case common.AuthServerRADIUS:
+			server.Port = a.RADIUSAuthPort
+			server.SecretLength = len(a.RADIUSSecret)
+		}
+
+		result = append(result, server)
+	}
+
+	return result
+}
@@ -40,12 +40,28 @@ func (c *converter) convertNamedObjects(doc *pfsense.Document) common.NamedObjec
 			)
 		}
 
-		result[a.Name] = common.NamedObject{
+		obj := common.NamedObject{
 			Name:        a.Name,
 			Type:        objType,
 			Members:     splitAliasMembers(a.Address),
 			Description: a.Descr,
 		}
+
+		// A url alias keeps the entries fetched when it was saved in
+		// <address> and its sources in <aliasurl>; a urltable alias names
+		// its feed in <url> and has no entries in the configuration.
+		switch objType {
+		case common.NamedObjectTypeURL:
+			obj.URLs = nonEmptyFields(a.AliasURL)
+		case common.NamedObjectTypeURLTable, common.NamedObjectTypeURLTablePorts:
+			obj.URLs = nonEmptyList(strings.TrimSpace(a.URL))
+			obj.Members = nil
+		case common.NamedObjectTypeGeoIP:
+			obj.Countries = obj.Members
+			obj.Members = nil
+		}
+
+		result[a.Name] = obj
 	}
 
 	if len(result) == 0 {
@@ -66,3 +82,16 @@ func splitAliasMembers(address string) []string {
 
 	return strings.Fields(address)
 }
+
+// nonEmptyFields returns the trimmed, non-empty values, or nil when there
+// are none.
+func nonEmptyFields(values []string) []string {
+	var result []string
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			result = append(result, v)
+		}
+	}
+
+	return result
+}
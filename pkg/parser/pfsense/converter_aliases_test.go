@@ -108,7 +108,7 @@ func TestConverter_NamedObjects_UnknownType_Warns(t *testing.T) {
 
 	doc := schema.NewDocument()
 	doc.Aliases.Alias = []schema.Alias{
-		{Name: "WEIRD_ALIAS", Type: "urltable", Address: "http://example.com/list.txt"},
+		{Name: "WEIRD_ALIAS", Type: "url_ports", Address: "80 443"},
 	}
 
 	device, warnings, err := pfsense.ConvertDocument(doc)
@@ -116,13 +116,48 @@ func TestConverter_NamedObjects_UnknownType_Warns(t *testing.T) {
 	warnings = nonGapWarnings(warnings)
 	require.Len(t, warnings, 1)
 	assert.Equal(t, "NamedObjects[WEIRD_ALIAS].Type", warnings[0].Field)
-	assert.Equal(t, "urltable", warnings[0].Value)
+	assert.Equal(t, "url_ports", warnings[0].Value)
 	assert.Equal(t, common.SeverityLow, warnings[0].Severity)
 
 	// The alias is still captured (fail-open), just with an unvalidated Type.
 	obj, ok := device.NamedObjects["WEIRD_ALIAS"]
 	require.True(t, ok)
-	assert.Equal(t, common.NamedObjectType("urltable"), obj.Type)
+	assert.Equal(t, common.NamedObjectType("url_ports"), obj.Type)
+}
+
+func TestConverter_NamedObjects_ExternalSources(t *testing.T) {
+	t.Parallel()
+
+	doc := schema.NewDocument()
+	doc.Aliases.Alias = []schema.Alias{
+		{Name: "FEED", Type: "urltable", URL: " https://example.com/drop.txt "},
+		{Name: "FEED_PORTS", Type: "urltable_ports", URL: "https://example.com/ports.txt"},
+		{
+			Name:     "FETCHED",
+			Type:     "url",
+			Address:  "192.0.2.1 192.0.2.2",
+			AliasURL: []string{"https://example.com/hosts.txt", ""},
+		},
+	}
+
+	device, warnings, err := pfsense.ConvertDocument(doc)
+	require.NoError(t, err)
+	assert.Empty(t, nonGapWarnings(warnings))
+
+	assert.Equal(t, common.NamedObject{
+		Name: "FEED",
+		Type: common.NamedObjectTypeURLTable,
+		URLs: []string{"https://example.com/drop.txt"},
+	}, device.NamedObjects["FEED"])
+	assert.Equal(t, []string{"https://example.com/ports.txt"}, device.NamedObjects["FEED_PORTS"].URLs)
+
+	// A url alias keeps the entries fetched when it was saved.
+	assert.Equal(t, common.NamedObject{
+		Name:    "FETCHED",
+		Type:    common.NamedObjectTypeURL,
+		Members: []string{"192.0.2.1", "192.0.2.2"},
+		URLs:    []string{"https://example.com/hosts.txt"},
+	}, device.NamedObjects["FETCHED"])
 }
 
 func TestConverter_NamedObjects_EmptyName_Warns(t *testing.T) {
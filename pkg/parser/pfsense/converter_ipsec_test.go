@@ -287,3 +287,82 @@ func TestConverter_IPsecPhase1_PreSharedKeyExclusion(t *testing.T) {
 	assert.NotContains(t, string(tunnelJSON), "super-secret-psk-42",
 		"raw PSK must not appear in JSON-exported Phase1Tunnel")
 }
+
+// TestConverter_IPsecLegacyConnections verifies that Phase 1 tunnels and their
+// Phase 2 entries are folded into the unified connection list, tagged with
+// the legacy origin, with Phase 2 entries attached by IKE ID.
+func TestConverter_IPsecLegacyConnections(t *testing.T) {
+	t.Parallel()
+
+	doc := &pfsenseSchema.Document{
+		IPsec: pfsenseSchema.IPsec{
+			Phase1: []pfsenseSchema.IPsecPhase1{
+				{
+					IKEId:      "1",
+					IKEType:    "ikev2",
+					Interface:  "wan",
+					RemoteGW:   "198.51.100.1",
+					AuthMethod: "pre_shared_key",
+					MyIDType:   "myaddress",
+					PeerIDType: "fqdn",
+					PeerIDData: "peer.example.com",
+					DPDDelay:   "10",
+					Descr:      "HQ",
+					Encryption: pfsenseSchema.IPsecPhase1Encryption{
+						Algorithms: []pfsenseSchema.IPsecEncryptionAlgorithm{{Name: "aes", KeyLen: "256"}},
+					},
+				},
+				{IKEId: "2", Descr: "No children"},
+			},
+			Phase2: []pfsenseSchema.IPsecPhase2{
+				{
+					IKEId:    "1",
+					UniqID:   "abc123",
+					Mode:     "tunnel",
+					Lifetime: "3600",
+					LocalID:  pfsenseSchema.IPsecID{Type: "lan"},
+					RemoteID: pfsenseSchema.IPsecID{Type: "network", Address: "10.2.0.0", Netbits: "16"},
+					EncryptionAlgorithms: []pfsenseSchema.IPsecEncryptionAlgorithm{
+						{Name: "aes256gcm", KeyLen: "128"},
+					},
+					HashAlgorithms: []pfsenseSchema.IPsecHashAlgorithm{{Name: "hmac_sha256"}},
+				},
+				{IKEId: "9", UniqID: "orphan"},
+			},
+		},
+	}
+
+	device, _, err := pfsense.ConvertDocument(doc)
+	require.NoError(t, err)
+
+	conns := device.VPN.IPsec.Connections
+	require.Len(t, conns, 2)
+
+	assert.Equal(t, common.IPsecConnection{
+		Origin:          common.IPsecOriginLegacy,
+		ID:              "1",
+		Description:     "HQ",
+		Version:         "ikev2",
+		LocalAddresses:  []string{"wan"},
+		RemoteAddresses: []string{"198.51.100.1"},
+		LocalIDs:        []string{"myaddress"},
+		RemoteIDs:       []string{"peer.example.com"},
+		LocalAuth:       []string{"pre_shared_key"},
+		RemoteAuth:      []string{"pre_shared_key"},
+		Proposals:       []string{"aes-256"},
+		DPDDelay:        "10",
+		Children: []common.IPsecChildSA{
+			{
+				ID:                     "abc123",
+				Mode:                   "tunnel",
+				LocalTrafficSelectors:  []string{"lan"},
+				RemoteTrafficSelectors: []string{"10.2.0.0/16"},
+				Proposals:              []string{"aes256gcm-128", "hmac_sha256"},
+				RekeyTime:              "3600",
+			},
+		},
+	}, conns[0])
+
+	assert.Equal(t, common.IPsecOriginLegacy, conns[1].Origin)
+	assert.Empty(t, conns[1].Children)
+}
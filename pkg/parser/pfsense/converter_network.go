@@ -66,8 +66,8 @@ func (c *converter) convertVLANs(doc *pfsense.Document) []common.VLAN {
 			Tag:         v.Tag,
 			Description: v.Descr,
 			VLANIf:      v.Vlanif,
-			Created:     v.Created,
-			Updated:     v.Updated,
+			Created:     common.NewChangeRecord(v.Created.Fields()),
+			Updated:     common.NewChangeRecord(v.Updated.Fields()),
 		})
 	}
 
@@ -132,6 +132,8 @@ func (c *converter) convertFirewallRules(
 			DisableReplyTo:  bool(rule.DisableReplyTo),
 			NoPfSync:        bool(rule.NoPfSync),
 			NoSync:          bool(rule.NoSync),
+			Created:         common.NewChangeRecord(rule.Created.Fields()),
+			Updated:         common.NewChangeRecord(rule.Updated.Fields()),
 		})
 	}
 
@@ -150,11 +152,42 @@ func (c *converter) convertNAT(doc *pfsense.Document) common.NATConfig {
 		)
 	}
 
-	return common.NATConfig{
+	nat := common.NATConfig{
 		OutboundMode:       outboundMode,
 		ReflectionDisabled: strings.EqualFold(doc.System.DisableNATReflection, xmlBoolYes),
 		OutboundRules:      c.convertOutboundNATRules(doc.Nat.Outbound.Rule),
 		InboundRules:       c.convertInboundNATRules(doc.Nat.Inbound),
+		OneToOneRules:      c.convertOneToOneNATRules(doc.Nat.OneToOne),
+	}
+
+	for _, rule := range nat.OneToOneRules {
+		if !rule.Disabled && rule.IsBidirectional() {
+			nat.BiNATEnabled = true
+			break
+		}
+	}
+
+	c.warnNATReflectionOverrides(nat)
+
+	return nat
+}
+
+// warnNATReflectionOverrides records an info warning for every inbound NAT
+// rule returned by [common.NATConfig.ReflectionOverrides]. The rule-level
+// mode wins; the warning makes the disagreement visible.
+func (c *converter) warnNATReflectionOverrides(nat common.NATConfig) {
+	system := "enabled"
+	if nat.ReflectionDisabled {
+		system = "disabled"
+	}
+
+	for _, i := range nat.ReflectionOverrides() {
+		c.addWarning(
+			fmt.Sprintf("NAT.InboundRules[%d].NATReflection", i),
+			nat.InboundRules[i].NATReflection,
+			fmt.Sprintf("rule overrides the system NAT reflection default (%s system-wide)", system),
+			common.SeverityInfo,
+		)
 	}
 }
 
@@ -210,6 +243,8 @@ func (c *converter) convertOutboundNATRules(rules []opnsense.NATRule) []common.N
 			Category:      r.Category,
 			Tag:           r.Tag,
 			Tagged:        r.Tagged,
+			Created:       common.NewChangeRecord(r.Created.Fields()),
+			Updated:       common.NewChangeRecord(r.Updated.Fields()),
 		})
 	}
 
@@ -283,13 +318,77 @@ func (c *converter) convertInboundNATRules(rules []pfsense.InboundRule) []common
 			Disabled:         bool(r.Disabled),
 			Log:              bool(r.Log),
 			Description:      r.Descr,
+			Created:          common.NewChangeRecord(r.Created.Fields()),
+			Updated:          common.NewChangeRecord(r.Updated.Fields()),
+		})
+	}
+
+	return result
+}
+
+// convertOneToOneNATRules maps []opnsense.OneToOneRule to []common.OneToOneNATRule.
+// The internal address is taken from the rule's source endpoint.
+func (c *converter) convertOneToOneNATRules(rules []opnsense.OneToOneRule) []common.OneToOneNATRule {
+	if len(rules) == 0 {
+		return nil
+	}
+
+	result := make([]common.OneToOneNATRule, 0, len(rules))
+	for i, r := range rules {
+		if r.External == "" {
+			c.addWarning(
+				fmt.Sprintf("NAT.OneToOneRules[%d].External", i),
+				r.UUID,
+				"1:1 NAT rule has no external address",
+				common.SeverityHigh,
+			)
+		}
+		if r.Interface.IsEmpty() {
+			c.addWarning(
+				fmt.Sprintf("NAT.OneToOneRules[%d].Interface", i),
+				r.UUID,
+				"1:1 NAT rule has no interface assigned",
+				common.SeverityMedium,
+			)
+		}
+
+		ipProto := common.IPProtocol(r.IPProtocol)
+		if r.IPProtocol != "" && !ipProto.IsValid() {
+			c.addWarning(
+				fmt.Sprintf("NAT.OneToOneRules[%d].IPProtocol", i),
+				r.IPProtocol,
+				"unrecognized IP protocol family",
+				common.SeverityLow,
+			)
+		}
+
+		result = append(result, common.OneToOneNATRule{
+			UUID:       r.UUID,
+			Interfaces: []string(r.Interface),
+			IPProtocol: ipProto,
+			Type:       r.Type,
+			External:   r.External,
+			Internal:   r.Source.EffectiveAddress(),
+			Destination: common.RuleEndpoint{
+				Address: r.Destination.EffectiveAddress(),
+				Port:    r.Destination.Port,
+			},
+			NATReflection: r.NATReflection,
+			Disabled:      bool(r.Disabled),
+			Log:           bool(r.Log),
+			Description:   r.Descr,
+			Category:      r.Category,
+			Created:       common.NewChangeRecord(r.Created.Fields()),
+			Updated:       common.NewChangeRecord(r.Updated.Fields()),
 		})
 	}
 
 	return result
 }
 
-// convertCertificates maps doc.Certs to []common.Certificate.
+// convertCertificates maps doc.Certs to []common.Certificate. Subject,
+// Issuer, NotBefore and NotAfter are read from the decoded certificate and
+// stay empty when it is missing or cannot be decoded.
 func (c *converter) convertCertificates(doc *pfsense.Document) []common.Certificate {
 	if len(doc.Certs) == 0 {
 		return nil
@@ -306,12 +405,22 @@ func (c *converter) convertCertificates(doc *pfsense.Document) []common.Certific
 			)
 		}
 
-		result = append(result, common.Certificate{
+		converted := common.Certificate{
 			RefID:       cert.Refid,
 			Description: cert.Descr,
+			CARef:       cert.Caref,
 			Certificate: cert.Crt,
 			PrivateKey:  cert.Prv,
-		})
+		}
+
+		if parsed, err := cert.ParseCertificate(); err == nil {
+			converted.Subject = parsed.Subject.String()
+			converted.Issuer = parsed.Issuer.String()
+			converted.NotBefore = parsed.NotBefore.Unix()
+			converted.NotAfter = parsed.NotAfter.Unix()
+		}
+
+		result = append(result, converted)
 	}
 
 	return result
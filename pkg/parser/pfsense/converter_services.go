@@ -1,6 +1,7 @@
 package pfsense
 
 import (
+	"cmp"
 	"fmt"
 	"slices"
 	"strings"
@@ -110,30 +111,110 @@ func (c *converter) convertSNMP(doc *pfsense.Document) common.SNMPConfig {
 	}
 }
 
-// convertLoadBalancer maps doc.LoadBalancer.MonitorType to common.LoadBalancerConfig.
+// convertUPnP maps <installedpackages><miniupnpd> to *common.UPnPConfig.
+// pfSense stores the package settings in the same layout as OPNsense's
+// os-upnp plugin. Returns nil when the configuration has no miniupnpd
+// settings. An ACL entry that does not parse is dropped with a conversion
+// warning.
+func (c *converter) convertUPnP(doc *pfsense.Document) *common.UPnPConfig {
+	if doc.InstalledPackages == nil {
+		return nil
+	}
+
+	cfg := doc.InstalledPackages.MiniUPnPd.Settings()
+	if cfg == nil {
+		return nil
+	}
+
+	upnp := &common.UPnPConfig{
+		Enabled:           cfg.IsEnabled(),
+		UPnPEnabled:       bool(cfg.EnableUPnP),
+		NATPMPEnabled:     bool(cfg.EnableNATPMP),
+		ExternalInterface: cfg.ExtIface,
+		Interfaces:        cfg.Interfaces(),
+		DefaultDeny:       bool(cfg.PermDefault),
+	}
+
+	for _, rule := range cfg.PermissionRules() {
+		perm, err := opnsense.ParseMiniUPnPdPermission(rule)
+		if err != nil {
+			c.addWarning("UPnP.Rules", rule, err.Error(), common.SeverityMedium)
+			continue
+		}
+
+		upnp.Rules = append(upnp.Rules, common.UPnPPermissionRule{
+			Action:          perm.Action,
+			ExternalPorts:   perm.ExternalPorts,
+			InternalAddress: perm.InternalAddress,
+			InternalPorts:   perm.InternalPorts,
+			Description:     perm.Description,
+		})
+	}
+
+	return upnp
+}
+
+// convertLoadBalancer maps doc.LoadBalancer monitor types, pools, and virtual
+// servers to common.LoadBalancerConfig.
 func (c *converter) convertLoadBalancer(doc *pfsense.Document) common.LoadBalancerConfig {
-	monitors := doc.LoadBalancer.MonitorType
-	if len(monitors) == 0 {
+	lb := doc.LoadBalancer
+	if len(lb.MonitorType) == 0 && len(lb.Pool) == 0 && len(lb.VirtualServer) == 0 {
 		return common.LoadBalancerConfig{}
 	}
 
-	result := make([]common.MonitorType, 0, len(monitors))
-	for _, m := range monitors {
-		result = append(result, common.MonitorType{
-			Name:        m.Name,
-			Type:        m.Type,
-			Description: m.Descr,
-			Options: common.MonitorOptions{
-				Path:   m.Options.Path,
-				Host:   m.Options.Host,
-				Code:   m.Options.Code,
-				Send:   m.Options.Send,
-				Expect: m.Options.Expect,
-			},
-		})
+	var result common.LoadBalancerConfig
+
+	if len(lb.MonitorType) > 0 {
+		result.MonitorTypes = make([]common.MonitorType, 0, len(lb.MonitorType))
+		for _, m := range lb.MonitorType {
+			result.MonitorTypes = append(result.MonitorTypes, common.MonitorType{
+				Name:        m.Name,
+				Type:        m.Type,
+				Description: m.Descr,
+				Options: common.MonitorOptions{
+					Path:   m.Options.Path,
+					Host:   m.Options.Host,
+					Code:   m.Options.Code,
+					Send:   m.Options.Send,
+					Expect: m.Options.Expect,
+				},
+			})
+		}
+	}
+
+	if len(lb.Pool) > 0 {
+		result.Pools = make([]common.LBPool, 0, len(lb.Pool))
+		for _, p := range lb.Pool {
+			result.Pools = append(result.Pools, common.LBPool{
+				Name:            p.Name,
+				Mode:            p.Mode,
+				Description:     p.Descr,
+				Port:            p.Port,
+				Retry:           p.Retry,
+				Monitor:         p.Monitor,
+				Servers:         collectNonEmpty(p.Servers...),
+				DisabledServers: collectNonEmpty(p.ServersDisabled...),
+			})
+		}
+	}
+
+	if len(lb.VirtualServer) > 0 {
+		result.VirtualServers = make([]common.LBVirtualServer, 0, len(lb.VirtualServer))
+		for _, v := range lb.VirtualServer {
+			result.VirtualServers = append(result.VirtualServers, common.LBVirtualServer{
+				Name:          v.Name,
+				Description:   v.Descr,
+				Address:       v.IPAddr,
+				Port:          v.Port,
+				Pool:          v.PoolName,
+				FallbackPool:  v.SiteDown,
+				Mode:          v.Mode,
+				RelayProtocol: v.RelayProtocol,
+			})
+		}
 	}
 
-	return common.LoadBalancerConfig{MonitorTypes: result}
+	return result
 }
 
 // convertVPN maps OpenVPN and IPsec sections to common.VPN.
@@ -161,12 +242,96 @@ func (c *converter) convertIPsec(doc *pfsense.Document) common.IPsecConfig {
 		return common.IPsecConfig{}
 	}
 
+	phase1 := c.convertIPsecPhase1Tunnels(ipsec.Phase1)
+	phase2 := c.convertIPsecPhase2Tunnels(ipsec.Phase2)
+
 	return common.IPsecConfig{
 		Enabled:       true,
-		Phase1Tunnels: c.convertIPsecPhase1Tunnels(ipsec.Phase1),
-		Phase2Tunnels: c.convertIPsecPhase2Tunnels(ipsec.Phase2),
+		Phase1Tunnels: phase1,
+		Phase2Tunnels: phase2,
 		MobileClient:  c.convertIPsecMobileClient(ipsec.Client),
+		Connections:   legacyIPsecConnections(phase1, phase2),
+	}
+}
+
+// legacyIPsecConnections folds Phase 1 tunnels and their Phase 2 entries into
+// the unified connection list, tagged with common.IPsecOriginLegacy. Phase 2
+// entries are attached to the Phase 1 tunnel with the same IKE ID; entries
+// without a matching tunnel are left out.
+func legacyIPsecConnections(
+	phase1 []common.IPsecPhase1Tunnel,
+	phase2 []common.IPsecPhase2Tunnel,
+) []common.IPsecConnection {
+	if len(phase1) == 0 {
+		return nil
 	}
+
+	result := make([]common.IPsecConnection, 0, len(phase1))
+	for _, p1 := range phase1 {
+		conn := common.IPsecConnection{
+			Origin:          common.IPsecOriginLegacy,
+			ID:              p1.IKEID,
+			Description:     p1.Description,
+			Disabled:        p1.Disabled,
+			Version:         p1.IKEType,
+			Aggressive:      p1.Mode == "aggressive",
+			LocalAddresses:  nonEmptyList(p1.Interface),
+			RemoteAddresses: nonEmptyList(p1.RemoteGateway),
+			LocalIDs:        nonEmptyList(cmp.Or(p1.MyIDData, p1.MyIDType)),
+			RemoteIDs:       nonEmptyList(cmp.Or(p1.PeerIDData, p1.PeerIDType)),
+			LocalAuth:       nonEmptyList(p1.AuthMethod),
+			RemoteAuth:      nonEmptyList(p1.AuthMethod),
+			Proposals:       slices.Clone(p1.EncryptionAlgorithms),
+			DPDDelay:        p1.DPDDelay,
+		}
+
+		for _, p2 := range phase2 {
+			if p2.IKEID != p1.IKEID {
+				continue
+			}
+
+			local := legacyIPsecSelector(p2.LocalIDType, p2.LocalIDAddress, p2.LocalIDNetbits)
+			remote := legacyIPsecSelector(p2.RemoteIDType, p2.RemoteIDAddress, p2.RemoteIDNetbits)
+
+			conn.Children = append(conn.Children, common.IPsecChildSA{
+				ID:                     p2.UniqID,
+				Description:            p2.Description,
+				Disabled:               p2.Disabled,
+				Mode:                   p2.Mode,
+				LocalTrafficSelectors:  nonEmptyList(local),
+				RemoteTrafficSelectors: nonEmptyList(remote),
+				Proposals:              slices.Concat(p2.EncryptionAlgorithms, p2.HashAlgorithms),
+				RekeyTime:              p2.Lifetime,
+			})
+		}
+
+		result = append(result, conn)
+	}
+
+	return result
+}
+
+// legacyIPsecSelector formats a Phase 2 identity as a traffic selector:
+// "address/netbits" for address identities, or the identity type (such as
+// "lan") for interface-network identities.
+func legacyIPsecSelector(idType, address, netbits string) string {
+	switch {
+	case address != "" && netbits != "":
+		return address + "/" + netbits
+	case address != "":
+		return address
+	default:
+		return idType
+	}
+}
+
+// nonEmptyList returns a one-element slice holding v, or nil when v is empty.
+func nonEmptyList(v string) []string {
+	if v == "" {
+		return nil
+	}
+
+	return []string{v}
 }
 
 // warnOrphanIPsecData emits conversion warnings for Phase 2 tunnels or mobile client
@@ -440,40 +605,44 @@ func (c *converter) convertOpenVPNServers(servers []opnsense.OpenVPNServer) []co
 	result := make([]common.OpenVPNServer, 0, len(servers))
 	for _, s := range servers {
 		result = append(result, common.OpenVPNServer{
-			VPNID:            s.VPN_ID,
-			Mode:             s.Mode,
-			Protocol:         s.Protocol,
-			DevMode:          s.Dev_mode,
-			Interface:        s.Interface,
-			LocalPort:        s.Local_port,
-			Description:      s.Description,
-			TunnelNetwork:    s.Tunnel_network,
-			TunnelNetworkV6:  s.Tunnel_networkv6,
-			RemoteNetwork:    s.Remote_network,
-			RemoteNetworkV6:  s.Remote_networkv6,
-			LocalNetwork:     s.Local_network,
-			LocalNetworkV6:   s.Local_networkv6,
-			MaxClients:       s.Maxclients,
-			Compression:      s.Compression,
-			DNSServers:       collectNonEmpty(s.DNS_server1, s.DNS_server2, s.DNS_server3, s.DNS_server4),
-			NTPServers:       collectNonEmpty(s.NTP_server1, s.NTP_server2),
-			CertRef:          s.Cert_ref,
-			CARef:            s.CA_ref,
-			CRLRef:           s.CRL_ref,
-			DHLength:         s.DH_length,
-			ECDHCurve:        s.Ecdh_curve,
-			CertDepth:        s.Cert_depth,
-			TLSType:          s.TLS_type,
-			VerbosityLevel:   s.Verbosity_level,
-			Topology:         s.Topology,
-			StrictUserCN:     bool(s.Strictusercn),
-			GWRedir:          bool(s.Gwredir),
-			DynamicIP:        bool(s.Dynamic_ip),
-			ServerBridgeDHCP: bool(s.Serverbridge_dhcp),
-			DNSDomain:        s.DNS_domain,
-			NetBIOSEnable:    bool(s.Netbios_enable),
-			NetBIOSNType:     s.Netbios_ntype,
-			NetBIOSScope:     s.Netbios_scope,
+			VPNID:               s.VPNID,
+			Mode:                s.Mode,
+			Protocol:            s.Protocol,
+			DevMode:             s.DevMode,
+			Interface:           s.Interface,
+			LocalPort:           s.LocalPort,
+			Description:         s.Description,
+			TunnelNetwork:       s.TunnelNetwork,
+			TunnelNetworkV6:     s.TunnelNetworkV6,
+			RemoteNetwork:       s.RemoteNetwork,
+			RemoteNetworkV6:     s.RemoteNetworkV6,
+			LocalNetwork:        s.LocalNetwork,
+			LocalNetworkV6:      s.LocalNetworkV6,
+			MaxClients:          s.MaxClients,
+			Compression:         s.Compression,
+			DNSServers:          collectNonEmpty(s.DNSServer1, s.DNSServer2, s.DNSServer3, s.DNSServer4),
+			NTPServers:          collectNonEmpty(s.NTPServer1, s.NTPServer2),
+			CertRef:             s.CertRef,
+			CARef:               s.CARef,
+			CRLRef:              s.CRLRef,
+			DHLength:            s.DHLength,
+			ECDHCurve:           s.ECDHCurve,
+			CertDepth:           s.CertDepth,
+			TLSType:             s.TLSType,
+			VerbosityLevel:      s.VerbosityLevel,
+			Topology:            s.Topology,
+			StrictUserCN:        bool(s.StrictUserCN),
+			AuthMode:            s.AuthMode,
+			DataCiphers:         splitNonEmpty(s.EffectiveDataCiphers(), ","),
+			DataCiphersFallback: s.EffectiveDataCiphersFallback(),
+			Digest:              s.Digest,
+			GWRedir:             bool(s.GWRedir),
+			DynamicIP:           bool(s.DynamicIP),
+			ServerBridgeDHCP:    bool(s.ServerBridgeDHCP),
+			DNSDomain:           s.DNSDomain,
+			NetBIOSEnable:       bool(s.NetBIOSEnable),
+			NetBIOSNType:        s.NetBIOSNType,
+			NetBIOSScope:        s.NetBIOSScope,
 		})
 	}
 
@@ -489,18 +658,21 @@ func (c *converter) convertOpenVPNClients(clients []opnsense.OpenVPNClient) []co
 	result := make([]common.OpenVPNClient, 0, len(clients))
 	for _, cl := range clients {
 		result = append(result, common.OpenVPNClient{
-			VPNID:          cl.VPN_ID,
-			Mode:           cl.Mode,
-			Protocol:       cl.Protocol,
-			DevMode:        cl.Dev_mode,
-			Interface:      cl.Interface,
-			ServerAddr:     cl.Server_addr,
-			ServerPort:     cl.Server_port,
-			Description:    cl.Description,
-			CertRef:        cl.Cert_ref,
-			CARef:          cl.CA_ref,
-			Compression:    cl.Compression,
-			VerbosityLevel: cl.Verbosity_level,
+			VPNID:               cl.VPNID,
+			Mode:                cl.Mode,
+			Protocol:            cl.Protocol,
+			DevMode:             cl.DevMode,
+			Interface:           cl.Interface,
+			ServerAddr:          cl.ServerAddr,
+			ServerPort:          cl.ServerPort,
+			Description:         cl.Description,
+			CertRef:             cl.CertRef,
+			CARef:               cl.CARef,
+			DataCiphers:         splitNonEmpty(cl.EffectiveDataCiphers(), ","),
+			DataCiphersFallback: cl.EffectiveDataCiphersFallback(),
+			Digest:              cl.Digest,
+			Compression:         cl.Compression,
+			VerbosityLevel:      cl.VerbosityLevel,
 		})
 	}
 
@@ -516,20 +688,20 @@ func (c *converter) convertOpenVPNCSCs(cscs []opnsense.OpenVPNCSC) []common.Open
 	result := make([]common.OpenVPNCSC, 0, len(cscs))
 	for _, csc := range cscs {
 		result = append(result, common.OpenVPNCSC{
-			CommonName:      csc.Common_name,
+			CommonName:      csc.CommonName,
 			Block:           bool(csc.Block),
-			TunnelNetwork:   csc.Tunnel_network,
-			TunnelNetworkV6: csc.Tunnel_networkv6,
-			LocalNetwork:    csc.Local_network,
-			LocalNetworkV6:  csc.Local_networkv6,
-			RemoteNetwork:   csc.Remote_network,
-			RemoteNetworkV6: csc.Remote_networkv6,
-			GWRedir:         bool(csc.Gwredir),
-			PushReset:       bool(csc.Push_reset),
-			RemoveRoute:     bool(csc.Remove_route),
-			DNSDomain:       csc.DNS_domain,
-			DNSServers:      collectNonEmpty(csc.DNS_server1, csc.DNS_server2, csc.DNS_server3, csc.DNS_server4),
-			NTPServers:      collectNonEmpty(csc.NTP_server1, csc.NTP_server2),
+			TunnelNetwork:   csc.TunnelNetwork,
+			TunnelNetworkV6: csc.TunnelNetworkV6,
+			LocalNetwork:    csc.LocalNetwork,
+			LocalNetworkV6:  csc.LocalNetworkV6,
+			RemoteNetwork:   csc.RemoteNetwork,
+			RemoteNetworkV6: csc.RemoteNetworkV6,
+			GWRedir:         bool(csc.GWRedir),
+			PushReset:       bool(csc.PushReset),
+			RemoveRoute:     bool(csc.RemoveRoute),
+			DNSDomain:       csc.DNSDomain,
+			DNSServers:      collectNonEmpty(csc.DNSServer1, csc.DNSServer2, csc.DNSServer3, csc.DNSServer4),
+			NTPServers:      collectNonEmpty(csc.NTPServer1, csc.NTPServer2),
 		})
 	}
 
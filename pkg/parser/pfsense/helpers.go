@@ -1,5 +1,11 @@
 package pfsense
 
+import (
+	"strings"
+
+	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+)
+
 // collectNonEmpty returns a slice containing only non-empty strings from the input.
 // Duplicated from the opnsense package since the function is unexported.
 func collectNonEmpty(values ...string) []string {
@@ -16,3 +22,45 @@ func collectNonEmpty(values ...string) []string {
 
 	return result
 }
+
+// splitNonEmpty splits s by sep and returns only non-empty, trimmed parts.
+// Returns nil when s is empty or contains no non-empty parts.
+// Duplicated from the opnsense package since the function is unexported.
+func splitNonEmpty(s, sep string) []string {
+	if s == "" {
+		return nil
+	}
+
+	parts := strings.Split(s, sep)
+	result := make([]string, 0, len(parts))
+
+	for _, p := range parts {
+		if trimmed := strings.TrimSpace(p); trimmed != "" {
+			result = append(result, trimmed)
+		}
+	}
+
+	if len(result) == 0 {
+		return nil
+	}
+
+	return result
+}
+
+// normalizeLDAPTransport maps an ldap_urltype value ("TCP - Standard",
+// "TCP - STARTTLS", "SSL - Encrypted") to a common.LDAPTransport.
+// Unrecognized values are kept verbatim.
+// Duplicated from the opnsense package since the function is unexported.
+func normalizeLDAPTransport(urlType string) common.LDAPTransport {
+	lower := strings.ToLower(strings.TrimSpace(urlType))
+	switch {
+	case strings.Contains(lower, "starttls"):
+		return common.LDAPTransportStartTLS
+	case strings.HasPrefix(lower, "ssl"):
+		return common.LDAPTransportSSL
+	case strings.HasPrefix(lower, "tcp"):
+		return common.LDAPTransportTCP
+	default:
+		return common.LDAPTransport(urlType)
+	}
+}
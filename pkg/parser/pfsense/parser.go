@@ -175,8 +175,8 @@ func (p *Parser) ParseAndValidate(
 }
 
 // decode reads XML from r into a pfsense.Document with security hardening
-// (input size limit, XXE protection, charset handling) via the shared
-// parser.NewSecureXMLDecoder helper. Presence-based <enable/> elements are
+// (input size, depth and token limits, DTD rejection, charset handling) via
+// the shared parser.NewSecureXMLDecoder helper. Presence-based <enable/> elements are
 // decoded directly into BoolFlag fields on pfsense.Interface and pfsense.DhcpdInterface.
 func (p *Parser) decode(ctx context.Context, r io.Reader) (*pfsense.Document, error) {
 	select {
@@ -185,7 +185,7 @@ func (p *Parser) decode(ctx context.Context, r io.Reader) (*pfsense.Document, er
 	default:
 	}
 
-	dec := parser.NewSecureXMLDecoder(r, p.maxInputSize)
+	dec, enc := parser.NewSecureXMLDecoderWithEncoding(r, p.maxInputSize)
 
 	var doc pfsense.Document
 	if err := parser.WrapDecodeError(dec.Decode(&doc), "/pfsense"); err != nil {
@@ -202,6 +202,8 @@ func (p *Parser) decode(ctx context.Context, r io.Reader) (*pfsense.Document, er
 		return nil, errMissingRoot
 	}
 
+	doc.Encoding = *enc
+
 	return &doc, nil
 }
 
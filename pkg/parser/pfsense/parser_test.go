@@ -3,14 +3,17 @@ package pfsense_test
 import (
 	"context"
 	"encoding/json"
+	"encoding/xml"
 	"errors"
 	"os"
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/EvilBit-Labs/opnDossier/internal/converter"
 	common "github.com/EvilBit-Labs/opnDossier/pkg/model"
+	"github.com/EvilBit-Labs/opnDossier/pkg/parser"
 	"github.com/EvilBit-Labs/opnDossier/pkg/parser/pfsense"
 	opnsense "github.com/EvilBit-Labs/opnDossier/pkg/schema/opnsense"
 	pfsenseSchema "github.com/EvilBit-Labs/opnDossier/pkg/schema/pfsense"
@@ -140,6 +143,21 @@ func TestParser_Parse(t *testing.T) {
 			wantErr:   true,
 			errSubstr: "unsupported charset",
 		},
+		{
+			name: "DOCTYPE",
+			input: `<?xml version="1.0"?><!DOCTYPE pfsense [<!ENTITY xxe SYSTEM "file:///etc/passwd">]>` +
+				`<pfsense><system><hostname>&xxe;</hostname></system></pfsense>`,
+			ctxFn:     context.Background,
+			wantErr:   true,
+			errSubstr: "DTDs are not supported in config.xml inputs",
+		},
+		{
+			name:      "nested too deep",
+			input:     `<pfsense>` + strings.Repeat("<a>", parser.DefaultMaxElementDepth) + `</pfsense>`,
+			ctxFn:     context.Background,
+			wantErr:   true,
+			errSubstr: "element nesting exceeds the maximum depth",
+		},
 	}
 
 	for _, tc := range tests {
@@ -252,6 +270,8 @@ func TestConverter_System(t *testing.T) {
 		Port:    "2222",
 		Group:   "admins",
 	}
+	doc.System.SerialSpeed = "9600"
+	doc.System.PrimaryConsole = "video"
 
 	device, warnings, err := pfsense.ConvertDocument(doc)
 	require.NoError(t, err)
@@ -285,6 +305,27 @@ func TestConverter_System(t *testing.T) {
 	assert.True(t, sys.SSH.Enabled)
 	assert.Equal(t, "2222", sys.SSH.Port)
 	assert.Equal(t, "admins", sys.SSH.Group)
+	assert.Equal(t, common.Console{SerialSpeed: 9600, PrimaryConsole: "video"}, sys.Console)
+}
+
+func TestConverter_System_InvalidConsole(t *testing.T) {
+	t.Parallel()
+
+	doc := pfsenseSchema.NewDocument()
+	doc.System.Hostname = "fw-test"
+	doc.System.SerialSpeed = "fast"
+	doc.System.PrimaryConsole = "efi"
+
+	device, warnings, err := pfsense.ConvertDocument(doc)
+	require.NoError(t, err)
+
+	assert.Equal(t, common.Console{PrimaryConsole: "efi"}, device.System.Console)
+	warnings = nonGapWarnings(warnings)
+	require.Len(t, warnings, 2)
+	assert.Equal(t, "System.Console.SerialSpeed", warnings[0].Field)
+	assert.Equal(t, "fast", warnings[0].Value)
+	assert.Equal(t, "System.Console.PrimaryConsole", warnings[1].Field)
+	assert.Equal(t, "efi", warnings[1].Value)
 }
 
 func TestConverter_Interfaces(t *testing.T) {
@@ -420,6 +461,32 @@ func TestConverter_NAT(t *testing.T) {
 	assert.Equal(t, "Port forward", device.NAT.InboundRules[0].Description)
 }
 
+func TestConverter_NATReflectionOverride(t *testing.T) {
+	t.Parallel()
+
+	doc := pfsenseSchema.NewDocument()
+	doc.Nat.Inbound = []pfsenseSchema.InboundRule{
+		{Interface: opnsense.InterfaceList{"wan"}, Target: "192.168.1.50", NATReflection: "purenat"},
+		{Interface: opnsense.InterfaceList{"wan"}, Target: "192.168.1.51", NATReflection: "disable"},
+	}
+
+	device, warnings, err := pfsense.ConvertDocument(doc)
+	require.NoError(t, err)
+
+	assert.False(t, device.NAT.ReflectionDisabled)
+
+	var fields []string
+	for _, w := range warnings {
+		if strings.HasSuffix(w.Field, ".NATReflection") {
+			fields = append(fields, w.Field)
+			assert.Contains(t, w.Message, "enabled system-wide")
+			assert.Equal(t, common.SeverityInfo, w.Severity)
+		}
+	}
+
+	assert.Equal(t, []string{"NAT.InboundRules[1].NATReflection"}, fields)
+}
+
 func TestConverter_NAT_TargetFallback(t *testing.T) {
 	t.Parallel()
 
@@ -508,21 +575,27 @@ func TestConverter_VPN_OpenVPN(t *testing.T) {
 	doc.OpenVPN = opnsense.OpenVPN{
 		Servers: []opnsense.OpenVPNServer{
 			{
-				VPN_ID:      "1",
+				VPNID:       "1",
 				Description: "Site VPN",
 				Mode:        "server_tls",
 				Protocol:    "UDP4",
-				DNS_server1: "10.0.0.1",
-				DNS_server2: "10.0.0.2",
-				DNS_server3: "",
-				DNS_server4: "",
+				DNSServer1:  "10.0.0.1",
+				DNSServer2:  "10.0.0.2",
+				DNSServer3:  "",
+				DNSServer4:  "",
+				AuthMode:    "Local Database",
+				OpenVPNCrypto: opnsense.OpenVPNCrypto{
+					DataCiphers: "AES-256-GCM,AES-128-GCM",
+					Digest:      "SHA256",
+				},
 			},
 		},
 		Clients: []opnsense.OpenVPNClient{
 			{
-				VPN_ID:      "2",
-				Description: "Client VPN",
-				Mode:        "p2p_tls",
+				VPNID:         "2",
+				Description:   "Client VPN",
+				Mode:          "p2p_tls",
+				OpenVPNCrypto: opnsense.OpenVPNCrypto{NCPCiphers: "AES-256-GCM", Crypto: "AES-256-CBC"},
 			},
 		},
 	}
@@ -534,9 +607,14 @@ func TestConverter_VPN_OpenVPN(t *testing.T) {
 	assert.Equal(t, "1", device.VPN.OpenVPN.Servers[0].VPNID)
 	assert.Equal(t, "Site VPN", device.VPN.OpenVPN.Servers[0].Description)
 	assert.Equal(t, []string{"10.0.0.1", "10.0.0.2"}, device.VPN.OpenVPN.Servers[0].DNSServers)
+	assert.Equal(t, "Local Database", device.VPN.OpenVPN.Servers[0].AuthMode)
+	assert.Equal(t, []string{"AES-256-GCM", "AES-128-GCM"}, device.VPN.OpenVPN.Servers[0].DataCiphers)
+	assert.Equal(t, "SHA256", device.VPN.OpenVPN.Servers[0].Digest)
 
 	require.Len(t, device.VPN.OpenVPN.Clients, 1)
 	assert.Equal(t, "2", device.VPN.OpenVPN.Clients[0].VPNID)
+	assert.Equal(t, []string{"AES-256-GCM"}, device.VPN.OpenVPN.Clients[0].DataCiphers)
+	assert.Equal(t, "AES-256-CBC", device.VPN.OpenVPN.Clients[0].DataCiphersFallback)
 }
 
 func TestConverter_Routing(t *testing.T) {
@@ -588,6 +666,7 @@ func TestConverter_Users(t *testing.T) {
 			Scope:     "system",
 			Groupname: "admins",
 			Descr:     "Admin user",
+			Expires:   "06/30/2025",
 		},
 		{
 			Name:  "",
@@ -604,6 +683,9 @@ func TestConverter_Users(t *testing.T) {
 	assert.Equal(t, "0", device.Users[0].UID)
 	assert.Equal(t, "system", device.Users[0].Scope)
 	assert.Equal(t, "admins", device.Users[0].GroupName)
+	assert.Equal(t, "06/30/2025", device.Users[0].Expires)
+	assert.Equal(t, time.Date(2025, time.June, 30, 0, 0, 0, 0, time.UTC).Unix(), device.Users[0].ExpiryDate)
+	assert.True(t, device.Users[0].HasExpiryDate)
 
 	// Empty name user should generate a warning.
 	filtered := nonGapWarnings(warnings)
@@ -631,7 +713,7 @@ func TestConverter_Groups(t *testing.T) {
 	require.Len(t, device.Groups, 1)
 	assert.Equal(t, "admins", device.Groups[0].Name)
 	assert.Equal(t, "1999", device.Groups[0].GID)
-	assert.Equal(t, "page-all", device.Groups[0].Privileges)
+	assert.Equal(t, []string{"page-all"}, device.Groups[0].Privileges)
 }
 
 func TestConverter_Groups_MultiplePrivileges(t *testing.T) {
@@ -650,7 +732,77 @@ func TestConverter_Groups_MultiplePrivileges(t *testing.T) {
 	device, _, err := pfsense.ConvertDocument(doc)
 	require.NoError(t, err)
 	require.Len(t, device.Groups, 1)
-	assert.Equal(t, "page-all, user-shell-access, page-system-groupmanager", device.Groups[0].Privileges)
+	assert.Equal(t,
+		[]string{"page-all", "user-shell-access", "page-system-groupmanager"},
+		device.Groups[0].Privileges,
+	)
+}
+
+func TestConverter_AuthServers(t *testing.T) {
+	t.Parallel()
+
+	doc := pfsenseSchema.NewDocument()
+	doc.System.WebGUI.AuthMode = "corp-ldap"
+	doc.System.AuthServer = []opnsense.AuthServer{
+		{
+			Type:        "ldap",
+			Name:        "corp-ldap",
+			Host:        "ldap.example.com",
+			LDAPURLType: "TCP - STARTTLS",
+			LDAPPort:    "389",
+			LDAPBaseDN:  "dc=example,dc=com",
+			LDAPBindPW:  "hunter2",
+		},
+		{Type: "radius", Name: "wifi-radius", Host: "10.0.0.5", RADIUSSecret: "0123456789abcdef", RADIUSAuthPort: "1812"},
+	}
+
+	device, warnings, err := pfsense.ConvertDocument(doc)
+	require.NoError(t, err)
+	assert.Empty(t, nonGapWarnings(warnings))
+	assert.Equal(t, "corp-ldap", device.System.WebGUI.AuthMode)
+	require.Len(t, device.AuthServers, 2)
+	assert.Equal(t, common.LDAPTransportStartTLS, device.AuthServers[0].Transport)
+	assert.Equal(t, "[REDACTED]", device.AuthServers[0].BindPassword)
+	assert.Equal(t, "1812", device.AuthServers[1].Port)
+	assert.Equal(t, 16, device.AuthServers[1].SecretLength)
+}
+
+func TestConverter_UPnP(t *testing.T) {
+	t.Parallel()
+
+	doc := pfsenseSchema.NewDocument()
+	require.NoError(t, xml.Unmarshal([]byte(`<pfsense>
+		<installedpackages>
+			<miniupnpd>
+				<config>
+					<enable>on</enable>
+					<enable_natpmp>on</enable_natpmp>
+					<ext_iface>wan</ext_iface>
+					<iface_array>lan</iface_array>
+					<permdefault>on</permdefault>
+					<permuser2>deny 0-65535 192.168.1.0/24 22</permuser2>
+					<permuser1>allow 1024-65535 192.168.1.0/24 1024-65535</permuser1>
+				</config>
+			</miniupnpd>
+		</installedpackages>
+	</pfsense>`), doc))
+
+	device, warnings, err := pfsense.ConvertDocument(doc)
+	require.NoError(t, err)
+	assert.Empty(t, nonGapWarnings(warnings))
+
+	require.NotNil(t, device.UPnP)
+	assert.True(t, device.UPnP.Enabled, "NAT-PMP alone runs the daemon")
+	assert.False(t, device.UPnP.UPnPEnabled)
+	assert.True(t, device.UPnP.DefaultDeny)
+	assert.Equal(t, []string{ifaceLAN}, device.UPnP.Interfaces)
+	require.Len(t, device.UPnP.Rules, 2)
+	assert.Equal(t, "allow", device.UPnP.Rules[0].Action, "rules are ordered by permuser number")
+	assert.Equal(t, "22", device.UPnP.Rules[1].InternalPorts)
+
+	empty, _, err := pfsense.ConvertDocument(pfsenseSchema.NewDocument())
+	require.NoError(t, err)
+	assert.Nil(t, empty.UPnP)
 }
 
 func TestConverter_Certificates_Warnings(t *testing.T) {
@@ -675,7 +827,7 @@ func TestConverter_Certificates(t *testing.T) {
 
 	doc := pfsenseSchema.NewDocument()
 	doc.Certs = []opnsense.Cert{
-		{Refid: "cert-001", Descr: "WebGUI Cert", Crt: "CERTDATA", Prv: "KEYDATA"},
+		{Refid: "cert-001", Descr: "WebGUI Cert", Caref: "ca-001", Crt: "CERTDATA", Prv: "KEYDATA"},
 	}
 	doc.CAs = []opnsense.CertificateAuthority{
 		{Refid: "ca-001", Descr: "Internal CA", Crt: "CADATA", Serial: "42"},
@@ -686,6 +838,7 @@ func TestConverter_Certificates(t *testing.T) {
 
 	require.Len(t, device.Certificates, 1)
 	assert.Equal(t, "cert-001", device.Certificates[0].RefID)
+	assert.Equal(t, "ca-001", device.Certificates[0].CARef)
 	assert.Equal(t, "CERTDATA", device.Certificates[0].Certificate)
 	assert.Equal(t, "KEYDATA", device.Certificates[0].PrivateKey)
 
@@ -978,11 +1131,12 @@ func TestConverter_Users_Warnings(t *testing.T) {
 	doc := pfsenseSchema.NewDocument()
 	doc.System.User = []pfsenseSchema.User{
 		{Name: "", UID: ""},
+		{Name: "contractor", UID: "2002", Expires: "2025-06-30"},
 	}
 
 	_, warnings, err := pfsense.ConvertDocument(doc)
 	require.NoError(t, err)
-	assert.Len(t, nonGapWarnings(warnings), 2)
+	assert.Len(t, nonGapWarnings(warnings), 3)
 
 	fields := make([]string, len(warnings))
 	for i, w := range warnings {
@@ -990,6 +1144,7 @@ func TestConverter_Users_Warnings(t *testing.T) {
 	}
 	assert.Contains(t, fields, "Users[0].Name")
 	assert.Contains(t, fields, "Users[0].UID")
+	assert.Contains(t, fields, "Users[1].Expires")
 }
 
 // --- File-based Parse tests ---
@@ -1538,10 +1693,10 @@ func TestConverter_OpenVPNCSCs(t *testing.T) {
 	doc.OpenVPN = opnsense.OpenVPN{
 		CSC: []opnsense.OpenVPNCSC{
 			{
-				Common_name:    "client1",
-				Tunnel_network: "10.8.1.0/24",
-				DNS_domain:     "vpn.local",
-				DNS_server1:    "10.8.0.1",
+				CommonName:    "client1",
+				TunnelNetwork: "10.8.1.0/24",
+				DNSDomain:     "vpn.local",
+				DNSServer1:    "10.8.0.1",
 			},
 		},
 	}
@@ -4,25 +4,49 @@ import (
 	"encoding/xml"
 	"fmt"
 	"io"
-	"strings"
 
+	schema "github.com/EvilBit-Labs/opnDossier/pkg/schema/opnsense"
 	"golang.org/x/text/encoding/charmap"
 	"golang.org/x/text/transform"
 )
 
 // NewSecureXMLDecoder returns an *xml.Decoder configured with security hardening:
 //   - Input size limited to maxSize bytes (prevents XML bomb attacks)
-//   - Entity expansion disabled (prevents XXE attacks)
-//   - Charset reader for UTF-8, US-ASCII, ISO-8859-1, and Windows-1252
+//   - DTDs rejected and entity expansion disabled (prevents XXE attacks)
+//   - Element depth and token size bounded by the defaults of [Limits]
+//   - Input transcoded to UTF-8 from UTF-8, US-ASCII, ISO-8859-1,
+//     Windows-1252, or UTF-16 with a byte order mark
 //
 // Both the OPNsense and pfSense parsers delegate to this function to avoid
 // duplicating security hardening logic.
 func NewSecureXMLDecoder(r io.Reader, maxSize int64) *xml.Decoder {
-	dec := xml.NewDecoder(io.LimitReader(r, maxSize))
+	dec, _ := NewSecureXMLDecoderWithEncoding(r, maxSize)
+	return dec
+}
+
+// NewSecureXMLDecoderWithEncoding is like [NewSecureXMLDecoder] but also
+// returns a record of the input's character encoding. The encoding name is
+// known immediately; Replacements counts invalid UTF-8 sequences replaced
+// with U+FFFD and is final only once decoding has finished.
+func NewSecureXMLDecoderWithEncoding(r io.Reader, maxSize int64) (*xml.Decoder, *schema.InputEncoding) {
+	return NewLimitedXMLDecoder(r, Limits{MaxInputSize: maxSize})
+}
+
+// NewLimitedXMLDecoder is like [NewSecureXMLDecoderWithEncoding] with every
+// limit configurable. Input that breaks a limit or contains DTD markup fails
+// with a [*LimitError] from the read that reaches it, whether the caller
+// streams tokens or decodes the whole document, and before the offending
+// bytes reach the decoder.
+func NewLimitedXMLDecoder(r io.Reader, limits Limits) (*xml.Decoder, *schema.InputEncoding) {
+	limits = limits.withDefaults()
+
+	input, enc := transcodeInput(&sizeLimitedReader{r: r, max: limits.MaxInputSize})
+
+	dec := xml.NewDecoder(newStructureGuard(input, limits))
 	dec.Entity = map[string]string{}
-	dec.CharsetReader = CharsetReader
+	dec.CharsetReader = transcodedCharsetReader
 
-	return dec
+	return dec, enc
 }
 
 // WrapDecodeError annotates an encoding/xml decode error with the element
@@ -51,18 +75,12 @@ func WrapDecodeError(err error, elementPath string) error {
 // Only charsets whose ASCII subset matches UTF-8 are accepted, which is
 // sufficient because XML element names use only ASCII-range characters.
 func CharsetReader(charset string, input io.Reader) (io.Reader, error) {
-	normalized := strings.ToLower(strings.TrimSpace(charset))
-	normalized = strings.ReplaceAll(normalized, "_", "-")
-	normalized = strings.TrimSuffix(normalized, ":1987")
-
-	switch normalized {
-	case "us-ascii", "ascii":
-		return input, nil
-	case "utf-8", "utf8":
+	switch canonicalCharset(charset) {
+	case encodingUSASCII, encodingUTF8:
 		return input, nil
-	case "iso-8859-1", "iso8859-1", "latin1", "latin-1":
+	case encodingISO88591:
 		return transform.NewReader(input, charmap.ISO8859_1.NewDecoder()), nil
-	case "windows-1252", "windows1252", "cp1252":
+	case encodingWindows1252:
 		return transform.NewReader(input, charmap.Windows1252.NewDecoder()), nil
 	default:
 		return nil, fmt.Errorf("unsupported charset: %s", charset)
@@ -2,10 +2,12 @@ package parser_test
 
 import (
 	"bytes"
+	"encoding/binary"
 	"encoding/xml"
 	"io"
 	"strings"
 	"testing"
+	"unicode/utf16"
 
 	"github.com/EvilBit-Labs/opnDossier/pkg/parser"
 	"github.com/stretchr/testify/assert"
@@ -114,3 +116,103 @@ func TestNewSecureXMLDecoder(t *testing.T) {
 		require.Error(t, err)
 	})
 }
+
+func TestNewSecureXMLDecoderWithEncoding(t *testing.T) {
+	t.Parallel()
+
+	utf16 := func(order binary.AppendByteOrder, bom []byte, s string) []byte {
+		out := bytes.Clone(bom)
+		for _, u := range utf16.Encode([]rune(s)) {
+			out = order.AppendUint16(out, u)
+		}
+		return out
+	}
+
+	const body = `<root><name>Café</name></root>`
+
+	tests := []struct {
+		name             string
+		input            []byte
+		wantName         string
+		wantEncoding     string
+		wantReplacements int
+	}{
+		{
+			name:         "UTF-8 without declaration",
+			input:        []byte(body),
+			wantName:     "Café",
+			wantEncoding: "UTF-8",
+		},
+		{
+			name:         "UTF-8 with byte order mark",
+			input:        append([]byte{0xEF, 0xBB, 0xBF}, body...),
+			wantName:     "Café",
+			wantEncoding: "UTF-8",
+		},
+		{
+			name:         "ISO-8859-1",
+			input:        []byte("<?xml version=\"1.0\" encoding=\"ISO-8859-1\"?><root><name>Caf\xe9</name></root>"),
+			wantName:     "Café",
+			wantEncoding: "ISO-8859-1",
+		},
+		{
+			name:         "Windows-1252",
+			input:        []byte("<?xml version='1.0' encoding='windows-1252'?><root><name>\x80 Caf\xe9</name></root>"),
+			wantName:     "€ Café",
+			wantEncoding: "Windows-1252",
+		},
+		{
+			name:         "UTF-16LE with byte order mark",
+			input:        utf16(binary.LittleEndian, []byte{0xFF, 0xFE}, `<?xml version="1.0" encoding="UTF-16"?>`+body),
+			wantName:     "Café",
+			wantEncoding: "UTF-16LE",
+		},
+		{
+			name:         "UTF-16BE with byte order mark",
+			input:        utf16(binary.BigEndian, []byte{0xFE, 0xFF}, body),
+			wantName:     "Café",
+			wantEncoding: "UTF-16BE",
+		},
+		{
+			name:             "invalid UTF-8 replaced",
+			input:            []byte("<?xml version=\"1.0\" encoding=\"UTF-8\"?><root><name>Caf\xe9 \xff</name></root>"),
+			wantName:         "Caf\uFFFD \uFFFD",
+			wantEncoding:     "UTF-8",
+			wantReplacements: 2,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			dec, enc := parser.NewSecureXMLDecoderWithEncoding(bytes.NewReader(tc.input), parser.DefaultMaxInputSize)
+
+			var result struct {
+				XMLName xml.Name `xml:"root"`
+				Name    string   `xml:"name"`
+			}
+			require.NoError(t, dec.Decode(&result))
+
+			assert.Equal(t, tc.wantName, result.Name)
+			assert.Equal(t, tc.wantEncoding, enc.Name)
+			assert.Equal(t, tc.wantReplacements, enc.Replacements)
+		})
+	}
+
+	t.Run("rejects unsupported charset", func(t *testing.T) {
+		t.Parallel()
+
+		xmlData := `<?xml version="1.0" encoding="Shift_JIS"?><root/>`
+		dec, enc := parser.NewSecureXMLDecoderWithEncoding(strings.NewReader(xmlData), parser.DefaultMaxInputSize)
+
+		var result struct {
+			XMLName xml.Name `xml:"root"`
+		}
+		err := dec.Decode(&result)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unsupported charset")
+		assert.Equal(t, "Shift_JIS", enc.Name)
+	})
+}
@@ -2,9 +2,19 @@
 package opnsense
 
 import (
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
 	"encoding/xml"
+	"errors"
+	"fmt"
+	"strings"
 )
 
+// ErrNoCertificateData is returned by [Cert.ParseCertificate] when the entry
+// has no certificate body.
+var ErrNoCertificateData = errors.New("certificate has no data")
+
 // CertificateAuthority represents a certificate authority entry in the OPNsense trust store,
 // containing the CA certificate (Crt), private key (Prv), reference ID, serial number, and description.
 type CertificateAuthority struct {
@@ -21,3 +31,105 @@ type CertificateAuthority struct {
 type DHCPv6Server struct {
 	XMLName xml.Name `xml:"dhcpdv6" json:"-" yaml:"-"`
 }
+
+// RevocationReason is the RFC 5280 reason code stored in a revoked
+// certificate's <reason> element. OPNsense writes "-1" when no reason was
+// given.
+type RevocationReason string
+
+// Revocation reason codes as stored in config.xml.
+const (
+	RevocationReasonNone                 RevocationReason = "-1"
+	RevocationReasonUnspecified          RevocationReason = "0"
+	RevocationReasonKeyCompromise        RevocationReason = "1"
+	RevocationReasonCACompromise         RevocationReason = "2"
+	RevocationReasonAffiliationChanged   RevocationReason = "3"
+	RevocationReasonSuperseded           RevocationReason = "4"
+	RevocationReasonCessationOfOperation RevocationReason = "5"
+	RevocationReasonCertificateHold      RevocationReason = "6"
+)
+
+// revocationReasonNames maps reason codes to the labels shown in the OPNsense UI.
+var revocationReasonNames = map[RevocationReason]string{
+	RevocationReasonNone:                 "No Status (default)",
+	RevocationReasonUnspecified:          "Unspecified",
+	RevocationReasonKeyCompromise:        "Key Compromise",
+	RevocationReasonCACompromise:         "CA Compromise",
+	RevocationReasonAffiliationChanged:   "Affiliation Changed",
+	RevocationReasonSuperseded:           "Superseded",
+	RevocationReasonCessationOfOperation: "Cessation of Operation",
+	RevocationReasonCertificateHold:      "Certificate Hold",
+}
+
+// String returns the UI label for the reason code, or the raw code when it
+// is not recognized. An empty code is reported as [RevocationReasonNone].
+func (r RevocationReason) String() string {
+	if r == "" {
+		r = RevocationReasonNone
+	}
+
+	if name, ok := revocationReasonNames[r]; ok {
+		return name
+	}
+
+	return string(r)
+}
+
+// ParseCertificate decodes the certificate body in Crt. OPNsense and pfSense
+// store it as base64-encoded PEM; plain PEM and base64-encoded DER are also
+// accepted.
+func (c *Cert) ParseCertificate() (*x509.Certificate, error) {
+	text := strings.TrimSpace(c.Crt)
+	if text == "" {
+		return nil, ErrNoCertificateData
+	}
+
+	der := []byte(text)
+	if !strings.HasPrefix(text, "-----BEGIN") {
+		decoded, err := base64.StdEncoding.DecodeString(text)
+		if err != nil {
+			return nil, fmt.Errorf("decode certificate: %w", err)
+		}
+		der = decoded
+	}
+
+	if block, _ := pem.Decode(der); block != nil {
+		der = block.Bytes
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, fmt.Errorf("parse certificate: %w", err)
+	}
+
+	return cert, nil
+}
+
+// CRLEntry represents a revoked certificate inside a <crl> element. OPNsense
+// copies the certificate into the list and adds the revocation reason and
+// time.
+type CRLEntry struct {
+	Refid      string           `xml:"refid,omitempty"       json:"refid,omitempty"      yaml:"refid,omitempty"`
+	Descr      string           `xml:"descr,omitempty"       json:"descr,omitempty"      yaml:"descr,omitempty"`
+	Caref      string           `xml:"caref,omitempty"       json:"caref,omitempty"      yaml:"caref,omitempty"`
+	Crt        string           `xml:"crt,omitempty"         json:"crt,omitempty"        yaml:"crt,omitempty"`
+	Prv        string           `xml:"prv,omitempty"         json:"prv,omitempty"        yaml:"prv,omitempty"`
+	Reason     RevocationReason `xml:"reason,omitempty"      json:"reason,omitempty"     yaml:"reason,omitempty"`
+	RevokeTime string           `xml:"revoke_time,omitempty" json:"revokeTime,omitempty" yaml:"revokeTime,omitempty"`
+}
+
+// CRL represents a certificate revocation list entry in the OPNsense trust
+// store. Method is "internal" for lists OPNsense maintains and signs itself
+// or "existing" for imported lists. Lifetime is the validity period in days
+// and Text is the base64-encoded signed list.
+type CRL struct {
+	XMLName  xml.Name   `xml:"crl"                json:"-"                  yaml:"-"`
+	Refid    string     `xml:"refid,omitempty"    json:"refid,omitempty"    yaml:"refid,omitempty"`
+	Descr    string     `xml:"descr,omitempty"    json:"descr,omitempty"    yaml:"descr,omitempty"`
+	Caref    string     `xml:"caref,omitempty"    json:"caref,omitempty"    yaml:"caref,omitempty"`
+	Method   string     `xml:"method,omitempty"   json:"method,omitempty"   yaml:"method,omitempty"`
+	Serial   string     `xml:"serial,omitempty"   json:"serial,omitempty"   yaml:"serial,omitempty"`
+	Lifetime string     `xml:"lifetime,omitempty" json:"lifetime,omitempty" yaml:"lifetime,omitempty"`
+	Text     string     `xml:"text,omitempty"     json:"text,omitempty"     yaml:"text,omitempty"`
+	Certs    []CRLEntry `xml:"cert,omitempty"     json:"cert,omitempty"     yaml:"cert,omitempty"`
+}
@@ -0,0 +1,225 @@
+package opnsense
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"encoding/xml"
+	"errors"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestCRL_MarshalUnmarshal tests XML round-trip for a <crl> element.
+func TestCRL_MarshalUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	xmlData := `<crl>
+  <refid>5f1a2b3c4d5e6</refid>
+  <descr>VPN CRL</descr>
+  <caref>ca-1</caref>
+  <method>internal</method>
+  <serial>3</serial>
+  <lifetime>365</lifetime>
+  <text>TUlJQg==</text>
+  <cert>
+    <refid>cert-1</refid>
+    <descr>alice</descr>
+    <caref>ca-1</caref>
+    <crt>Y2VydA==</crt>
+    <reason>1</reason>
+    <revoke_time>1700000000</revoke_time>
+  </cert>
+  <cert>
+    <refid>cert-2</refid>
+    <descr>bob</descr>
+    <reason>-1</reason>
+    <revoke_time>1700000100</revoke_time>
+  </cert>
+</crl>`
+
+	var crl CRL
+	if err := xml.Unmarshal([]byte(xmlData), &crl); err != nil {
+		t.Fatalf("xml.Unmarshal() failed: %v", err)
+	}
+
+	want := CRL{
+		XMLName:  xml.Name{Local: "crl"},
+		Refid:    "5f1a2b3c4d5e6",
+		Descr:    "VPN CRL",
+		Caref:    "ca-1",
+		Method:   "internal",
+		Serial:   "3",
+		Lifetime: "365",
+		Text:     "TUlJQg==",
+		Certs: []CRLEntry{
+			{
+				Refid:      "cert-1",
+				Descr:      "alice",
+				Caref:      "ca-1",
+				Crt:        "Y2VydA==",
+				Reason:     RevocationReasonKeyCompromise,
+				RevokeTime: "1700000000",
+			},
+			{Refid: "cert-2", Descr: "bob", Reason: RevocationReasonNone, RevokeTime: "1700000100"},
+		},
+	}
+	if !reflect.DeepEqual(crl, want) {
+		t.Fatalf("unmarshalled CRL = %+v, want %+v", crl, want)
+	}
+
+	data, err := xml.Marshal(crl)
+	if err != nil {
+		t.Fatalf("xml.Marshal() failed: %v", err)
+	}
+
+	var result CRL
+	if err := xml.Unmarshal(data, &result); err != nil {
+		t.Fatalf("xml.Unmarshal() of marshalled data failed: %v", err)
+	}
+
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("round-trip CRL = %+v, want %+v", result, want)
+	}
+}
+
+// TestOpnSenseDocument_CRLRoundTrip verifies that <crl> elements survive a
+// full document round-trip and can be looked up with FindCRLByRef.
+func TestOpnSenseDocument_CRLRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	doc := NewOpnSenseDocument()
+	doc.CRLs = []CRL{
+		{Refid: "crl-1", Descr: "First", Method: "internal", Lifetime: "9999"},
+		{Refid: "crl-2", Descr: "Second", Method: "existing", Certs: []CRLEntry{{Refid: "cert-1", Reason: "4"}}},
+	}
+
+	data, err := xml.Marshal(doc)
+	if err != nil {
+		t.Fatalf("xml.Marshal() failed: %v", err)
+	}
+
+	var result OpnSenseDocument
+	if err := xml.Unmarshal(data, &result); err != nil {
+		t.Fatalf("xml.Unmarshal() failed: %v", err)
+	}
+
+	if len(result.CRLs) != len(doc.CRLs) {
+		t.Fatalf("round-trip CRLs = %d, want %d", len(result.CRLs), len(doc.CRLs))
+	}
+
+	crl := result.FindCRLByRef("crl-2")
+	if crl == nil {
+		t.Fatal("FindCRLByRef(\"crl-2\") = nil, want CRL")
+	}
+	if crl.Descr != "Second" || len(crl.Certs) != 1 || crl.Certs[0].Reason != RevocationReasonSuperseded {
+		t.Errorf("FindCRLByRef(\"crl-2\") = %+v, want Second with one superseded entry", crl)
+	}
+
+	if got := result.FindCRLByRef("missing"); got != nil {
+		t.Errorf("FindCRLByRef(\"missing\") = %+v, want nil", got)
+	}
+}
+
+// TestOpnSenseDocument_WebGUICert verifies that the web GUI certificate
+// reference resolves through FindCertByRef.
+func TestOpnSenseDocument_WebGUICert(t *testing.T) {
+	t.Parallel()
+
+	doc := NewOpnSenseDocument()
+	doc.Certs = []Cert{{Refid: "cert-1", Descr: "Other"}, {Refid: "cert-2", Descr: "Web GUI"}}
+
+	if got := doc.WebGUICert(); got != nil {
+		t.Errorf("WebGUICert() without ssl-certref = %+v, want nil", got)
+	}
+
+	doc.System.WebGUI.SSLCertRef = "cert-2"
+	if got := doc.WebGUICert(); got == nil || got.Descr != "Web GUI" {
+		t.Errorf("WebGUICert() = %+v, want the Web GUI certificate", got)
+	}
+
+	doc.System.WebGUI.SSLCertRef = "missing"
+	if got := doc.WebGUICert(); got != nil {
+		t.Errorf("WebGUICert() with dangling ssl-certref = %+v, want nil", got)
+	}
+	if got := doc.FindCertByRef("cert-1"); got == nil || got.Descr != "Other" {
+		t.Errorf("FindCertByRef(\"cert-1\") = %+v, want the Other certificate", got)
+	}
+}
+
+// TestCert_ParseCertificate verifies that certificate bodies decode from
+// base64-encoded PEM, as stored in config.xml, and from plain PEM.
+func TestCert_ParseCertificate(t *testing.T) {
+	t.Parallel()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "fw.example.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("CreateCertificate() error = %v", err)
+	}
+	pemData := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+
+	for name, crt := range map[string]string{
+		"base64 PEM": base64.StdEncoding.EncodeToString(pemData),
+		"plain PEM":  string(pemData),
+		"base64 DER": base64.StdEncoding.EncodeToString(der),
+	} {
+		cert := Cert{Crt: crt}
+		got, err := cert.ParseCertificate()
+		if err != nil {
+			t.Errorf("ParseCertificate(%s) error = %v", name, err)
+			continue
+		}
+		if got.Subject.CommonName != "fw.example.com" {
+			t.Errorf("ParseCertificate(%s) subject = %q, want fw.example.com", name, got.Subject.CommonName)
+		}
+	}
+
+	if _, err := (&Cert{}).ParseCertificate(); !errors.Is(err, ErrNoCertificateData) {
+		t.Errorf("ParseCertificate() without data error = %v, want ErrNoCertificateData", err)
+	}
+	if _, err := (&Cert{Crt: "bm90IGEgY2VydA=="}).ParseCertificate(); err == nil {
+		t.Error("ParseCertificate() of garbage, want an error")
+	}
+}
+
+func TestRevocationReason_String(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		reason RevocationReason
+		want   string
+	}{
+		{"", "No Status (default)"},
+		{RevocationReasonNone, "No Status (default)"},
+		{RevocationReasonUnspecified, "Unspecified"},
+		{RevocationReasonKeyCompromise, "Key Compromise"},
+		{RevocationReasonCertificateHold, "Certificate Hold"},
+		{"8", "8"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.reason), func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.reason.String(); got != tt.want {
+				t.Errorf("RevocationReason(%q).String() = %q, want %q", tt.reason, got, tt.want)
+			}
+		})
+	}
+}
@@ -11,6 +11,18 @@ import (
 	"github.com/EvilBit-Labs/opnDossier/pkg/schema/shared"
 )
 
+// InputEncoding records the character encoding of the XML input a document
+// was parsed from. Parsers fill it in after transcoding the input to UTF-8;
+// it is never read from or written to XML.
+type InputEncoding struct {
+	// Name is the canonical name of the input encoding, e.g. "UTF-8",
+	// "ISO-8859-1", "Windows-1252", or "UTF-16LE".
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+	// Replacements counts invalid byte sequences that were replaced with
+	// U+FFFD during transcoding.
+	Replacements int `json:"replacements,omitempty" yaml:"replacements,omitempty"`
+}
+
 // BoolFlag represents a presence-based boolean used throughout OPNsense XML configurations.
 // Absent element means false; <tag/> (empty body) means true; <tag>value</tag> delegates
 // to the liberal value-parser [shared.IsValueTrue] so "on", "yes", "1", "true", "enable",
@@ -0,0 +1,82 @@
+package opnsense
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// MaxTestedConfigVersion is the newest configuration format version, as
+// recorded in <opnsense><version>, that this schema has been verified
+// against. Newer documents still parse, but sections introduced after this
+// version are not modeled and go unreported.
+const MaxTestedConfigVersion = "24.7"
+
+// ConfigVersionStatus classifies a document's <version> against
+// MaxTestedConfigVersion.
+type ConfigVersionStatus int
+
+const (
+	// ConfigVersionTested indicates the version is not newer than
+	// MaxTestedConfigVersion, or is not a decimal version at all.
+	ConfigVersionTested ConfigVersionStatus = iota
+	// ConfigVersionNewer indicates the version is newer than
+	// MaxTestedConfigVersion by at most one major version.
+	ConfigVersionNewer
+	// ConfigVersionUnsupported indicates the major version is more than one
+	// above that of MaxTestedConfigVersion.
+	ConfigVersionUnsupported
+)
+
+// CheckConfigVersion compares a document's <version>, a decimal such as
+// "24.1" or "24.1.3", with MaxTestedConfigVersion. Only the major and minor
+// components are compared.
+func CheckConfigVersion(version string) ConfigVersionStatus {
+	major, minor, ok := parseConfigVersion(version)
+	if !ok {
+		return ConfigVersionTested
+	}
+
+	testedMajor, testedMinor, _ := parseConfigVersion(MaxTestedConfigVersion)
+
+	switch {
+	case major > testedMajor+1:
+		return ConfigVersionUnsupported
+	case major > testedMajor || (major == testedMajor && minor > testedMinor):
+		return ConfigVersionNewer
+	default:
+		return ConfigVersionTested
+	}
+}
+
+// ConfigVersionNote describes a document version that is newer than
+// MaxTestedConfigVersion, e.g. "config format 25.1 newer than tested 24.7 —
+// some sections may be unreported". It returns "" for tested versions.
+func ConfigVersionNote(version string) string {
+	if CheckConfigVersion(version) == ConfigVersionTested {
+		return ""
+	}
+
+	return fmt.Sprintf("config format %s newer than tested %s — some sections may be unreported",
+		strings.TrimSpace(version), MaxTestedConfigVersion)
+}
+
+// parseConfigVersion splits a decimal version into its major and minor
+// components. A version without a minor component has minor 0.
+func parseConfigVersion(version string) (major, minor int, ok bool) {
+	parts := strings.Split(strings.TrimSpace(version), ".")
+
+	major, err := strconv.Atoi(parts[0])
+	if err != nil || major < 0 {
+		return 0, 0, false
+	}
+
+	if len(parts) > 1 {
+		minor, err = strconv.Atoi(parts[1])
+		if err != nil || minor < 0 {
+			return 0, 0, false
+		}
+	}
+
+	return major, minor, true
+}
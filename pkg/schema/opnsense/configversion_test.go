@@ -0,0 +1,46 @@
+package opnsense
+
+import "testing"
+
+func TestCheckConfigVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		version string
+		want    ConfigVersionStatus
+	}{
+		{MaxTestedConfigVersion, ConfigVersionTested},
+		{"24.1.3", ConfigVersionTested},
+		{"21.02", ConfigVersionTested},
+		{"", ConfigVersionTested},
+		{"v9", ConfigVersionTested},
+		{"24.10", ConfigVersionNewer},
+		{"25.1", ConfigVersionNewer},
+		{"25.7.2", ConfigVersionNewer},
+		{"26.1", ConfigVersionUnsupported},
+		{"30", ConfigVersionUnsupported},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			t.Parallel()
+
+			if got := CheckConfigVersion(tt.version); got != tt.want {
+				t.Errorf("CheckConfigVersion(%q) = %v, want %v", tt.version, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfigVersionNote(t *testing.T) {
+	t.Parallel()
+
+	if got := ConfigVersionNote(MaxTestedConfigVersion); got != "" {
+		t.Errorf("ConfigVersionNote(tested) = %q, want empty", got)
+	}
+
+	want := "config format 25.1 newer than tested " + MaxTestedConfigVersion + " — some sections may be unreported"
+	if got := ConfigVersionNote("25.1"); got != want {
+		t.Errorf("ConfigVersionNote(25.1) = %q, want %q", got, want)
+	}
+}
@@ -282,12 +282,12 @@ func TestNewClientExport(t *testing.T) {
 		t.Fatal("NewClientExport() returned nil")
 	}
 
-	// Check that Server_list slice is initialized and empty
-	if export.Server_list == nil {
+	// Check that ServerList slice is initialized and empty
+	if export.ServerList == nil {
 		t.Error("Server_list slice should be initialized")
 	}
-	if len(export.Server_list) != 0 {
-		t.Errorf("Server_list slice should be empty, got %d items", len(export.Server_list))
+	if len(export.ServerList) != 0 {
+		t.Errorf("Server_list slice should be empty, got %d items", len(export.ServerList))
 	}
 }
 
@@ -418,6 +418,14 @@ func TestNewMonit(t *testing.T) {
 		t.Fatal("NewMonit() returned nil")
 	}
 
+	// Check that Alert slice is initialized and empty
+	if monit.Alert == nil {
+		t.Error("Alert slice should be initialized")
+	}
+	if len(monit.Alert) != 0 {
+		t.Errorf("Alert slice should be empty, got %d items", len(monit.Alert))
+	}
+
 	// Check that Service slice is initialized and empty
 	if monit.Service == nil {
 		t.Error("Service slice should be initialized")
@@ -507,8 +515,8 @@ func TestNewIDS(t *testing.T) {
 	if ids.Rules != "" {
 		t.Errorf("Rules should be empty, got %q", ids.Rules)
 	}
-	if ids.Policies != "" {
-		t.Errorf("Policies should be empty, got %q", ids.Policies)
+	if len(ids.Policies.Items) != 0 {
+		t.Errorf("Policies should be empty, got %+v", ids.Policies.Items)
 	}
 	if ids.UserDefinedRules != "" {
 		t.Errorf("UserDefinedRules should be empty, got %q", ids.UserDefinedRules)
@@ -0,0 +1,127 @@
+package opnsense
+
+import (
+	"encoding/xml"
+	"reflect"
+	"strings"
+)
+
+// maxFieldPathDepth caps how many struct levels FieldPaths descends, so a
+// self-referential type cannot recurse forever.
+const maxFieldPathDepth = 10
+
+// xmlUnmarshalerType is the reflect.Type of xml.Unmarshaler. A struct that
+// decodes itself is reported as a leaf, since its Go fields need not match its
+// XML children.
+var xmlUnmarshalerType = reflect.TypeFor[xml.Unmarshaler]()
+
+// FieldPaths returns every field of the OPNsense schema as a map from its
+// dot-separated XML element path to its Go type name, for tools that need to
+// discover the available fields programmatically. Path segments are XML
+// element or attribute names; a repeated element's children are listed under
+// its name followed by "[]". For example "system.hostname" maps to "string",
+// "filter.rule" to "[]Rule", and "filter.rule[].type" to "string".
+//
+// Type names are unqualified. Fields without an element name (character data,
+// inner XML, and catch-all fields) and fields excluded from XML are omitted,
+// and paths deeper than ten elements are not listed.
+func (o *OpnSenseDocument) FieldPaths() map[string]string {
+	paths := make(map[string]string)
+	collectFieldPaths(reflect.TypeFor[OpnSenseDocument](), "", 1, paths)
+
+	return paths
+}
+
+// collectFieldPaths adds the fields of struct type t, at depth levels below the
+// document root, to paths under prefix.
+func collectFieldPaths(t reflect.Type, prefix string, depth int, paths map[string]string) {
+	if depth > maxFieldPathDepth {
+		return
+	}
+
+	for field := range t.Fields() {
+		if !field.IsExported() || field.Name == "XMLName" {
+			continue
+		}
+
+		tag := field.Tag.Get("xml")
+		if tag == "-" {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" {
+			if embedded := derefType(field.Type); embedded.Kind() == reflect.Struct {
+				collectFieldPaths(embedded, prefix, depth, paths)
+			}
+			continue
+		}
+
+		if hasNoElementName(opts) {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		path := prefix + strings.ReplaceAll(name, ">", ".")
+		paths[path] = typeName(field.Type)
+
+		elem := field.Type
+		if elem.Kind() == reflect.Slice {
+			elem = elem.Elem()
+			path += "[]"
+		}
+
+		if elem = derefType(elem); elem.Kind() == reflect.Struct && !decodesItself(elem) {
+			collectFieldPaths(elem, path+".", depth+1, paths)
+		}
+	}
+}
+
+// hasNoElementName reports whether the xml tag options opts mark a field
+// that maps to no named element or attribute: character data, inner XML,
+// comments, or unmatched elements.
+func hasNoElementName(opts string) bool {
+	for opt := range strings.SplitSeq(opts, ",") {
+		switch opt {
+		case "chardata", "cdata", "innerxml", "comment", "any":
+			return true
+		}
+	}
+
+	return false
+}
+
+// derefType returns the element type of pointer type t, or t itself.
+func derefType(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Pointer {
+		return t.Elem()
+	}
+
+	return t
+}
+
+// decodesItself reports whether t or *t implements xml.Unmarshaler.
+func decodesItself(t reflect.Type) bool {
+	return t.Implements(xmlUnmarshalerType) || reflect.PointerTo(t).Implements(xmlUnmarshalerType)
+}
+
+// typeName returns the Go name of t without package qualifiers, e.g.
+// "[]Rule" or "*PPTPServer". Unnamed struct types are reported as "struct".
+func typeName(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Pointer:
+		return "*" + typeName(t.Elem())
+	case reflect.Slice:
+		return "[]" + typeName(t.Elem())
+	case reflect.Map:
+		return "map[" + typeName(t.Key()) + "]" + typeName(t.Elem())
+	case reflect.Struct:
+		if t.Name() == "" {
+			return "struct"
+		}
+	}
+
+	return t.Name()
+}
@@ -0,0 +1,67 @@
+package opnsense
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestOpnSenseDocument_FieldPaths(t *testing.T) {
+	paths := NewOpnSenseDocument().FieldPaths()
+
+	want := map[string]string{
+		"system.hostname":         "string",
+		"system.webgui.protocol":  "string",
+		"filter.rule":             "[]Rule",
+		"filter.rule[].type":      "string",
+		"filter.rule[].disabled":  "BoolFlag",
+		"filter.rule[].created":   "*Created",
+		"filter.rule[].interface": "[]string",
+		"sysctl":                  "[]SysctlItem",
+		"pptpd":                   "*PPTPServer",
+		"OPNsense.IDS.general":    "struct",
+	}
+	for path, typ := range want {
+		if got, ok := paths[path]; !ok {
+			t.Errorf("FieldPaths() missing %q", path)
+		} else if got != typ {
+			t.Errorf("FieldPaths()[%q] = %q, want %q", path, got, typ)
+		}
+	}
+
+	for path := range paths {
+		if strings.Contains(path, "XMLName") || strings.HasSuffix(path, ".") || strings.Contains(path, "..") {
+			t.Errorf("FieldPaths() has malformed path %q", path)
+		}
+		if depth := strings.Count(path, ".") + 1; depth > maxFieldPathDepth {
+			t.Errorf("FieldPaths() path %q is %d levels deep, want at most %d", path, depth, maxFieldPathDepth)
+		}
+	}
+}
+
+// fieldPathNode is a self-referential type used to check the depth cap.
+type fieldPathNode struct {
+	Name  string         `xml:"name"`
+	Child *fieldPathNode `xml:"child"`
+	Text  string         `xml:",chardata"`
+	Skip  string         `xml:"-"`
+}
+
+func TestCollectFieldPaths_SelfReferential(t *testing.T) {
+	paths := make(map[string]string)
+	collectFieldPaths(reflect.TypeFor[fieldPathNode](), "", 1, paths)
+
+	deepest := "child" + strings.Repeat(".child", maxFieldPathDepth-2) + ".name"
+	if paths[deepest] != "string" {
+		t.Errorf("paths[%q] = %q, want %q", deepest, paths[deepest], "string")
+	}
+	if got := len(paths); got != 2*maxFieldPathDepth {
+		t.Errorf("len(paths) = %d, want %d", got, 2*maxFieldPathDepth)
+	}
+	if _, ok := paths["Text"]; ok {
+		t.Error("character data field should be omitted")
+	}
+	if _, ok := paths["Skip"]; ok {
+		t.Error(`field tagged xml:"-" should be omitted`)
+	}
+}
@@ -219,8 +219,8 @@ type VLAN struct {
 	Tag     string   `xml:"tag,omitempty"`
 	Descr   string   `xml:"descr,omitempty"`
 	Vlanif  string   `xml:"vlanif,omitempty"`
-	Created string   `xml:"created,omitempty"`
-	Updated string   `xml:"updated,omitempty"`
+	Created *Created `xml:"created,omitempty"`
+	Updated *Updated `xml:"updated,omitempty"`
 }
 
 // Bridge represents a network bridge configuration, combining multiple interfaces
@@ -242,25 +242,39 @@ type Bridges struct {
 }
 
 // GIF represents a GIF (Generic Tunnel Interface) configuration entry for IPv4/IPv6-in-IPv4/IPv6 tunneling.
+// The parent interface is in If on legacy configurations and LocalAddr on
+// MVC-based ones; the outer remote endpoint is in RemoteAddr, or Remote on
+// older exports.
 type GIF struct {
-	XMLName xml.Name `xml:"gif"`
-	Gifif   string   `xml:"gifif,omitempty"`
-	If      string   `xml:"if,omitempty"`
-	Remote  string   `xml:"remote,omitempty"`
-	Descr   string   `xml:"descr,omitempty"`
-	Created string   `xml:"created,omitempty"`
-	Updated string   `xml:"updated,omitempty"`
+	XMLName          xml.Name `xml:"gif"`
+	Gifif            string   `xml:"gifif,omitempty"`
+	If               string   `xml:"if,omitempty"`
+	LocalAddr        string   `xml:"local-addr,omitempty"`
+	Remote           string   `xml:"remote,omitempty"`
+	RemoteAddr       string   `xml:"remote-addr,omitempty"`
+	TunnelLocalAddr  string   `xml:"tunnel-local-addr,omitempty"`
+	TunnelRemoteAddr string   `xml:"tunnel-remote-addr,omitempty"`
+	TunnelRemoteNet  string   `xml:"tunnel-remote-net,omitempty"`
+	Descr            string   `xml:"descr,omitempty"`
+	Created          string   `xml:"created,omitempty"`
+	Updated          string   `xml:"updated,omitempty"`
 }
 
 // GRE represents a GRE (Generic Routing Encapsulation) tunnel configuration entry for point-to-point encapsulation.
+// Its parent interface and remote endpoint fields vary as for [GIF].
 type GRE struct {
-	XMLName xml.Name `xml:"gre"`
-	Greif   string   `xml:"greif,omitempty"`
-	If      string   `xml:"if,omitempty"`
-	Remote  string   `xml:"remote,omitempty"`
-	Descr   string   `xml:"descr,omitempty"`
-	Created string   `xml:"created,omitempty"`
-	Updated string   `xml:"updated,omitempty"`
+	XMLName          xml.Name `xml:"gre"`
+	Greif            string   `xml:"greif,omitempty"`
+	If               string   `xml:"if,omitempty"`
+	LocalAddr        string   `xml:"local-addr,omitempty"`
+	Remote           string   `xml:"remote,omitempty"`
+	RemoteAddr       string   `xml:"remote-addr,omitempty"`
+	TunnelLocalAddr  string   `xml:"tunnel-local-addr,omitempty"`
+	TunnelRemoteAddr string   `xml:"tunnel-remote-addr,omitempty"`
+	TunnelRemoteNet  string   `xml:"tunnel-remote-net,omitempty"`
+	Descr            string   `xml:"descr,omitempty"`
+	Created          string   `xml:"created,omitempty"`
+	Updated          string   `xml:"updated,omitempty"`
 }
 
 // LAGG represents a LAGG (Link Aggregation) interface configuration entry for bonding
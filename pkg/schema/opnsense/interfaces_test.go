@@ -44,3 +44,57 @@ func TestInterfaces_MarshalUnmarshal_Simple(t *testing.T) {
 		t.Errorf("wan.Enable = %q, want %q", wan.Enable, "1")
 	}
 }
+
+// TestVLAN_ChangeRecords verifies that a VLAN's <created>/<updated> elements
+// decode into their username, time, and description children and survive a
+// round-trip.
+func TestVLAN_ChangeRecords(t *testing.T) {
+	t.Parallel()
+
+	xmlData := `<vlan>
+  <if>igb0</if>
+  <tag>100</tag>
+  <descr>Guest</descr>
+  <vlanif>igb0_vlan100</vlanif>
+  <created>
+    <username>root@10.0.0.5</username>
+    <time>1700000000.1234</time>
+    <description>/interfaces_vlan_edit.php made changes</description>
+  </created>
+  <updated>
+    <username>admin@10.0.0.6</username>
+    <time>1700086400.5678</time>
+    <description>/interfaces_vlan_edit.php made changes</description>
+  </updated>
+</vlan>`
+
+	var vlan VLAN
+	if err := xml.Unmarshal([]byte(xmlData), &vlan); err != nil {
+		t.Fatalf("xml.Unmarshal() failed: %v", err)
+	}
+
+	wantCreated := Created{
+		Username:    "root@10.0.0.5",
+		Time:        "1700000000.1234",
+		Description: "/interfaces_vlan_edit.php made changes",
+	}
+	if vlan.Created == nil || *vlan.Created != wantCreated {
+		t.Fatalf("Created = %+v, want %+v", vlan.Created, wantCreated)
+	}
+	if vlan.Updated == nil || vlan.Updated.Username != "admin@10.0.0.6" {
+		t.Fatalf("Updated = %+v, want username admin@10.0.0.6", vlan.Updated)
+	}
+
+	data, err := xml.Marshal(vlan)
+	if err != nil {
+		t.Fatalf("xml.Marshal() failed: %v", err)
+	}
+
+	var result VLAN
+	if err := xml.Unmarshal(data, &result); err != nil {
+		t.Fatalf("xml.Unmarshal() of marshalled data failed: %v", err)
+	}
+	if result.Created == nil || *result.Created != wantCreated {
+		t.Errorf("round-trip Created = %+v, want %+v", result.Created, wantCreated)
+	}
+}
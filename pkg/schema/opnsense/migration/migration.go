@@ -0,0 +1,330 @@
+// Package migration upgrades OPNsense configuration documents written by older
+// firmware releases to the shape the current schema and converter expect.
+//
+// OPNsense has changed the encoding of a handful of config.xml values over
+// time without bumping a formal schema version: value-based booleans that
+// older releases wrote as "yes"/"on"/"true" are now "1", presence flags that
+// were stored as "1"/"0" strings are now "yes"/absent, and the powerd modes
+// are now written with their short names. The converter in pkg/parser/opnsense
+// matches the current encodings literally, so it runs Migrate on every parsed
+// document; without it a 21.x config would silently report those settings as
+// disabled. Migrate rewrites the legacy encodings and records every change as
+// a MigrationNote so callers can surface what was touched.
+package migration
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	schema "github.com/EvilBit-Labs/opnDossier/pkg/schema/opnsense"
+	"github.com/EvilBit-Labs/opnDossier/pkg/schema/shared"
+)
+
+// Canonical encodings the current converter matches against.
+const (
+	// valueBoolTrue is the current encoding for value-based booleans (<enable>1</enable>).
+	valueBoolTrue = "1"
+	// presenceBoolTrue is the current encoding for "yes"-style flags (<floating>yes</floating>).
+	presenceBoolTrue = "yes"
+)
+
+// Defaults applied to required fields that older releases could leave empty.
+// These mirror the processor's fillDefaults so a migrated document and a
+// normalized CommonDevice agree on what an unset value means.
+const (
+	defaultWebGUIProtocol = "https"
+	defaultOptimization   = "normal"
+	defaultSSHGroup       = "admins"
+)
+
+// MigrationNote records a single field rewrite applied by Migrate.
+//
+//nolint:revive // MigrationNote is the name used by the public Migrate contract
+type MigrationNote struct {
+	// Field is the dotted config path of the rewritten element
+	// (e.g. "interfaces.wan.enable", "filter.rule[3].floating").
+	Field string `json:"field"`
+	// OldValue is the value before migration; empty when the field was unset.
+	OldValue string `json:"oldValue"`
+	// NewValue is the value after migration; empty when the field was cleared.
+	NewValue string `json:"newValue"`
+	// Reason explains why the rewrite was applied.
+	Reason string `json:"reason"`
+}
+
+// String returns a single-line human-readable description of the note.
+func (n MigrationNote) String() string {
+	return fmt.Sprintf("%s: %q -> %q (%s)", n.Field, n.OldValue, n.NewValue, n.Reason)
+}
+
+// step is one migration transformation. introducedIn is the first firmware
+// release (major.minor) that writes the current encoding; configs from that
+// release onward are left untouched by the step.
+type step struct {
+	introducedIn version
+	apply        func(doc *schema.OpnSenseDocument) []MigrationNote
+}
+
+// steps lists the known transformations in the order they are applied.
+// Every step is idempotent, so applying one to an already-current document
+// produces no notes.
+var steps = []step{ //nolint:gochecknoglobals // static migration table
+	{introducedIn: version{major: 22, minor: 1}, apply: migrateInterfaceBooleans},
+	{introducedIn: version{major: 22, minor: 1}, apply: migrateFloatingFlags},
+	{introducedIn: version{major: 22, minor: 1}, apply: migrateNATReflection},
+	{introducedIn: version{major: 23, minor: 1}, apply: migratePowerdModes},
+	{introducedIn: version{major: 23, minor: 1}, apply: migrateRequiredDefaults},
+}
+
+// Migrate returns a copy of doc with legacy encodings rewritten to the
+// current schema conventions, together with one MigrationNote per change.
+//
+// fromVersion is the OPNsense release that wrote the config (e.g. "21.7" or
+// "21.7.8"). When empty, the firmware version recorded in
+// <system><firmware version="..."> is used. When neither parses, every step
+// is applied — the steps are idempotent normalizations, so running them on a
+// current config is a no-op.
+//
+// doc is never mutated: the returned document shares only the substructures
+// no step rewrites. A nil doc returns (nil, nil).
+func Migrate(doc *schema.OpnSenseDocument, fromVersion string) (*schema.OpnSenseDocument, []MigrationNote) {
+	if doc == nil {
+		return nil, nil
+	}
+
+	if fromVersion == "" {
+		fromVersion = doc.System.Firmware.Version
+	}
+
+	from, known := parseVersion(fromVersion)
+
+	migrated := cloneForMigration(doc)
+
+	var notes []MigrationNote
+	for _, s := range steps {
+		if known && !from.less(s.introducedIn) {
+			continue
+		}
+
+		notes = append(notes, s.apply(migrated)...)
+	}
+
+	return migrated, notes
+}
+
+// cloneForMigration returns a shallow copy of doc with every collection a
+// migration step rewrites (interfaces map, filter rules) cloned so the
+// caller's document is never mutated.
+func cloneForMigration(doc *schema.OpnSenseDocument) *schema.OpnSenseDocument {
+	cp := *doc
+
+	if doc.Interfaces.Items != nil {
+		cp.Interfaces.Items = maps.Clone(doc.Interfaces.Items)
+	}
+
+	cp.Filter.Rule = slices.Clone(doc.Filter.Rule)
+
+	return &cp
+}
+
+// migrateInterfaceBooleans rewrites interface <enable>, <blockpriv>, and
+// <blockbogons> values written with the liberal boolean vocabulary ("yes",
+// "on", "true") to the canonical "1", and clears explicit falsy values ("0",
+// "no", "off") so absence carries the false meaning as it does today.
+func migrateInterfaceBooleans(doc *schema.OpnSenseDocument) []MigrationNote {
+	var notes []MigrationNote
+
+	for _, name := range doc.Interfaces.Names() {
+		iface := doc.Interfaces.Items[name]
+		prefix := "interfaces." + name
+
+		iface.Enable, notes = normalizeValueBool(iface.Enable, prefix+".enable", notes)
+		iface.BlockPriv, notes = normalizeValueBool(iface.BlockPriv, prefix+".blockpriv", notes)
+		iface.BlockBogons, notes = normalizeValueBool(iface.BlockBogons, prefix+".blockbogons", notes)
+
+		doc.Interfaces.Items[name] = iface
+	}
+
+	return notes
+}
+
+// normalizeValueBool canonicalizes a value-based boolean and appends a note
+// when the value changes. Unknown values are left as-is.
+func normalizeValueBool(value, field string, notes []MigrationNote) (string, []MigrationNote) {
+	switch {
+	case value == "" || value == valueBoolTrue:
+		return value, notes
+	case shared.IsValueTrue(value):
+		return valueBoolTrue, append(notes, MigrationNote{
+			Field:    field,
+			OldValue: value,
+			NewValue: valueBoolTrue,
+			Reason:   "legacy boolean encoding; current releases store enabled value flags as \"1\"",
+		})
+	case shared.IsValueFalse(value):
+		return "", append(notes, MigrationNote{
+			Field:    field,
+			OldValue: value,
+			NewValue: "",
+			Reason:   "legacy boolean encoding; current releases omit disabled value flags",
+		})
+	default:
+		return value, notes
+	}
+}
+
+// migrateFloatingFlags rewrites filter rule <floating> values stored as
+// "1"/"true"/"on" to the canonical "yes", and clears explicit falsy values.
+func migrateFloatingFlags(doc *schema.OpnSenseDocument) []MigrationNote {
+	var notes []MigrationNote
+
+	for i := range doc.Filter.Rule {
+		field := fmt.Sprintf("filter.rule[%d].floating", i)
+		doc.Filter.Rule[i].Floating, notes = normalizePresenceBool(doc.Filter.Rule[i].Floating, field, notes)
+	}
+
+	return notes
+}
+
+// migrateNATReflection rewrites <system><disablenatreflection> stored as
+// "1"/"true"/"on" to the canonical "yes", and clears explicit falsy values.
+func migrateNATReflection(doc *schema.OpnSenseDocument) []MigrationNote {
+	var notes []MigrationNote
+
+	doc.System.DisableNATReflection, notes = normalizePresenceBool(
+		doc.System.DisableNATReflection,
+		"system.disablenatreflection",
+		notes,
+	)
+
+	return notes
+}
+
+// normalizePresenceBool canonicalizes a "yes"-style flag and appends a note
+// when the value changes. Unknown values are left as-is.
+func normalizePresenceBool(value, field string, notes []MigrationNote) (string, []MigrationNote) {
+	switch {
+	case value == "" || value == presenceBoolTrue:
+		return value, notes
+	case shared.IsValueTrue(value):
+		return presenceBoolTrue, append(notes, MigrationNote{
+			Field:    field,
+			OldValue: value,
+			NewValue: presenceBoolTrue,
+			Reason:   "legacy boolean encoding; current releases store this flag as \"yes\"",
+		})
+	case shared.IsValueFalse(value):
+		return "", append(notes, MigrationNote{
+			Field:    field,
+			OldValue: value,
+			NewValue: "",
+			Reason:   "legacy boolean encoding; current releases omit this flag when disabled",
+		})
+	default:
+		return value, notes
+	}
+}
+
+// legacyPowerdModes maps the long powerd(8) mode names older releases wrote
+// to the short names current releases write. "hiadaptive" has no entry: it
+// is still written as is.
+var legacyPowerdModes = map[string]string{ //nolint:gochecknoglobals // static lookup table
+	"adaptive": "adp",
+	"minimum":  "min",
+	"maximum":  "max",
+}
+
+// migratePowerdModes renames deprecated powerd mode values.
+func migratePowerdModes(doc *schema.OpnSenseDocument) []MigrationNote {
+	var notes []MigrationNote
+
+	rename := func(value *string, field string) {
+		if replacement, ok := legacyPowerdModes[*value]; ok {
+			notes = append(notes, MigrationNote{
+				Field:    field,
+				OldValue: *value,
+				NewValue: replacement,
+				Reason:   "deprecated powerd mode name; current releases store the short name",
+			})
+			*value = replacement
+		}
+	}
+
+	rename(&doc.System.PowerdACMode, "system.powerd_ac_mode")
+	rename(&doc.System.PowerdBatteryMode, "system.powerd_battery_mode")
+	rename(&doc.System.PowerdNormalMode, "system.powerd_normal_mode")
+
+	return notes
+}
+
+// migrateRequiredDefaults populates fields the current schema requires but
+// older releases could omit entirely.
+func migrateRequiredDefaults(doc *schema.OpnSenseDocument) []MigrationNote {
+	var notes []MigrationNote
+
+	fill := func(value *string, field, def string) {
+		if *value != "" {
+			return
+		}
+
+		notes = append(notes, MigrationNote{
+			Field:    field,
+			OldValue: "",
+			NewValue: def,
+			Reason:   "required by the current schema; populated with the release default",
+		})
+		*value = def
+	}
+
+	fill(&doc.System.WebGUI.Protocol, "system.webgui.protocol", defaultWebGUIProtocol)
+	fill(&doc.System.Optimization, "system.optimization", defaultOptimization)
+	fill(&doc.System.SSH.Group, "system.ssh.group", defaultSSHGroup)
+
+	return notes
+}
+
+// version is a parsed OPNsense release number. Only major and minor are
+// compared; OPNsense ships schema changes on major.minor boundaries.
+type version struct {
+	major int
+	minor int
+}
+
+// less reports whether v is an earlier release than other.
+func (v version) less(other version) bool {
+	if v.major != other.major {
+		return v.major < other.major
+	}
+
+	return v.minor < other.minor
+}
+
+// parseVersion parses "major.minor[.patch][_suffix]" release strings such as
+// "21.7", "24.1.3", or "23.7_4". The second return value is false when s does
+// not begin with a numeric major.minor pair.
+func parseVersion(s string) (version, bool) {
+	s = strings.TrimSpace(s)
+	if cut, _, found := strings.Cut(s, "_"); found {
+		s = cut
+	}
+
+	parts := strings.Split(s, ".")
+	if len(parts) < 2 { //nolint:mnd // major.minor
+		return version{}, false
+	}
+
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return version{}, false
+	}
+
+	minor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return version{}, false
+	}
+
+	return version{major: major, minor: minor}, true
+}
@@ -0,0 +1,243 @@
+package migration
+
+import (
+	"testing"
+
+	schema "github.com/EvilBit-Labs/opnDossier/pkg/schema/opnsense"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// legacyDocument returns a document carrying the encodings a 21.x release
+// wrote for the fields the migration steps cover.
+func legacyDocument() *schema.OpnSenseDocument {
+	doc := schema.NewOpnSenseDocument()
+	doc.System.Hostname = "fw01"
+	doc.System.WebGUI.Protocol = "https"
+	doc.System.Optimization = "normal"
+	doc.System.SSH.Group = "admins"
+	doc.Interfaces.Items["wan"] = schema.Interface{If: "em0", Enable: "yes", BlockPriv: "on", BlockBogons: "0"}
+	doc.Interfaces.Items["lan"] = schema.Interface{If: "em1", Enable: "1"}
+	doc.Filter.Rule = append(doc.Filter.Rule,
+		schema.Rule{Type: "pass", Floating: "1"},
+		schema.Rule{Type: "block", Floating: "yes"},
+	)
+
+	return doc
+}
+
+func TestMigrate_InterfaceValueBooleans(t *testing.T) {
+	t.Parallel()
+
+	migrated, notes := Migrate(legacyDocument(), "21.7")
+
+	wan := migrated.Interfaces.Items["wan"]
+	assert.Equal(t, "1", wan.Enable)
+	assert.Equal(t, "1", wan.BlockPriv)
+	assert.Empty(t, wan.BlockBogons)
+	assert.Equal(t, "1", migrated.Interfaces.Items["lan"].Enable, "canonical value untouched")
+
+	assert.Contains(t, notes, MigrationNote{
+		Field:    "interfaces.wan.enable",
+		OldValue: "yes",
+		NewValue: "1",
+		Reason:   "legacy boolean encoding; current releases store enabled value flags as \"1\"",
+	})
+	assert.Contains(t, notes, MigrationNote{
+		Field:    "interfaces.wan.blockbogons",
+		OldValue: "0",
+		NewValue: "",
+		Reason:   "legacy boolean encoding; current releases omit disabled value flags",
+	})
+}
+
+func TestMigrate_PresenceFlags(t *testing.T) {
+	t.Parallel()
+
+	doc := legacyDocument()
+	doc.System.DisableNATReflection = "1"
+
+	migrated, notes := Migrate(doc, "21.1")
+
+	assert.Equal(t, "yes", migrated.Filter.Rule[0].Floating)
+	assert.Equal(t, "yes", migrated.Filter.Rule[1].Floating)
+	assert.Equal(t, "yes", migrated.System.DisableNATReflection)
+
+	var fields []string
+	for _, n := range notes {
+		fields = append(fields, n.Field)
+	}
+
+	assert.Contains(t, fields, "filter.rule[0].floating")
+	assert.NotContains(t, fields, "filter.rule[1].floating", "already canonical")
+	assert.Contains(t, fields, "system.disablenatreflection")
+}
+
+func TestMigrate_DeprecatedValuesAndDefaults(t *testing.T) {
+	t.Parallel()
+
+	doc := legacyDocument()
+	doc.System.PowerdACMode = "maximum"
+	doc.System.PowerdBatteryMode = "minimum"
+	doc.System.PowerdNormalMode = "adaptive"
+	doc.System.WebGUI.Protocol = ""
+	doc.System.SSH.Group = ""
+
+	migrated, notes := Migrate(doc, "22.7")
+
+	assert.Equal(t, "max", migrated.System.PowerdACMode)
+	assert.Equal(t, "min", migrated.System.PowerdBatteryMode)
+	assert.Equal(t, "adp", migrated.System.PowerdNormalMode)
+	assert.Equal(t, "https", migrated.System.WebGUI.Protocol)
+	assert.Equal(t, "admins", migrated.System.SSH.Group)
+	assert.Equal(t, "normal", migrated.System.Optimization, "populated value untouched")
+
+	// 22.7 postdates the boolean re-encoding, so only the 23.1 steps apply.
+	for _, n := range notes {
+		assert.NotContains(t, n.Field, "interfaces.", "boolean steps must not run for 22.7")
+	}
+
+	assert.Len(t, notes, 5)
+}
+
+func TestMigrate_CurrentPowerdModesUnchanged(t *testing.T) {
+	t.Parallel()
+
+	doc := legacyDocument()
+	doc.System.PowerdACMode = "max"
+	doc.System.PowerdBatteryMode = "min"
+	doc.System.PowerdNormalMode = "adp"
+
+	migrated, notes := Migrate(doc, "21.7")
+	require.NotEmpty(t, notes, "the other migration steps still apply")
+
+	assert.Equal(t, "max", migrated.System.PowerdACMode)
+	assert.Equal(t, "min", migrated.System.PowerdBatteryMode)
+	assert.Equal(t, "adp", migrated.System.PowerdNormalMode)
+
+	for _, n := range notes {
+		assert.NotContains(t, n.Field, "powerd", "short powerd modes are already current")
+	}
+}
+
+func TestMigrate_ValidPowerdAliasUnchanged(t *testing.T) {
+	t.Parallel()
+
+	doc := legacyDocument()
+	doc.System.PowerdACMode = "hiadaptive"
+	doc.System.PowerdBatteryMode = "hiadp"
+	doc.System.PowerdNormalMode = "hadaptive"
+
+	// A missing version applies every step.
+	migrated, notes := Migrate(doc, "")
+	require.NotEmpty(t, notes, "the other migration steps still apply")
+
+	assert.Equal(t, "hiadaptive", migrated.System.PowerdACMode)
+	assert.Equal(t, "hiadp", migrated.System.PowerdBatteryMode)
+	assert.Equal(t, "hadaptive", migrated.System.PowerdNormalMode)
+
+	for _, n := range notes {
+		assert.NotContains(t, n.Field, "powerd", "valid powerd modes must not be renamed")
+	}
+}
+
+func TestMigrate_CurrentVersionIsNoOp(t *testing.T) {
+	t.Parallel()
+
+	doc := legacyDocument()
+	doc.System.PowerdACMode = "hiadaptive"
+
+	migrated, notes := Migrate(doc, "24.7.3")
+
+	assert.Empty(t, notes)
+	assert.Equal(t, "hiadaptive", migrated.System.PowerdACMode)
+	assert.Equal(t, "yes", migrated.Interfaces.Items["wan"].Enable)
+}
+
+func TestMigrate_VersionFallback(t *testing.T) {
+	t.Parallel()
+
+	t.Run("firmware version used when fromVersion empty", func(t *testing.T) {
+		t.Parallel()
+
+		doc := legacyDocument()
+		doc.System.Firmware.Version = "24.1"
+
+		_, notes := Migrate(doc, "")
+		assert.Empty(t, notes)
+	})
+
+	t.Run("unparseable version applies every step", func(t *testing.T) {
+		t.Parallel()
+
+		_, notes := Migrate(legacyDocument(), "unknown")
+		assert.NotEmpty(t, notes)
+	})
+}
+
+func TestMigrate_DoesNotMutateInput(t *testing.T) {
+	t.Parallel()
+
+	doc := legacyDocument()
+	doc.System.WebGUI.Protocol = ""
+
+	migrated, notes := Migrate(doc, "21.7")
+	require.NotEmpty(t, notes)
+
+	assert.Equal(t, "yes", doc.Interfaces.Items["wan"].Enable)
+	assert.Equal(t, "1", doc.Filter.Rule[0].Floating)
+	assert.Empty(t, doc.System.WebGUI.Protocol)
+	assert.NotSame(t, doc, migrated)
+}
+
+func TestMigrate_Idempotent(t *testing.T) {
+	t.Parallel()
+
+	once, _ := Migrate(legacyDocument(), "21.7")
+	_, notes := Migrate(once, "21.7")
+
+	assert.Empty(t, notes)
+}
+
+func TestMigrate_NilDocument(t *testing.T) {
+	t.Parallel()
+
+	migrated, notes := Migrate(nil, "21.7")
+	assert.Nil(t, migrated)
+	assert.Nil(t, notes)
+}
+
+func TestParseVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input  string
+		want   version
+		wantOK bool
+	}{
+		{input: "21.7", want: version{major: 21, minor: 7}, wantOK: true},
+		{input: "24.1.3", want: version{major: 24, minor: 1}, wantOK: true},
+		{input: "23.7_4", want: version{major: 23, minor: 7}, wantOK: true},
+		{input: " 22.1 ", want: version{major: 22, minor: 1}, wantOK: true},
+		{input: "", wantOK: false},
+		{input: "24", wantOK: false},
+		{input: "x.y", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := parseVersion(tt.input)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMigrationNote_String(t *testing.T) {
+	t.Parallel()
+
+	n := MigrationNote{Field: "system.optimization", OldValue: "", NewValue: "normal", Reason: "default"}
+	assert.Equal(t, `system.optimization: "" -> "normal" (default)`, n.String())
+}
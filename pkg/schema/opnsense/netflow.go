@@ -0,0 +1,96 @@
+package opnsense
+
+import (
+	"net"
+	"strings"
+)
+
+// NetFlowConfig represents the NetFlow/IPFIX export settings of the OPNsense
+// Diagnostics\Netflow model. OPNsense mounts the model at <OPNsense><Netflow>;
+// some exports nest it as <OPNsense><Diagnostics><netflow> instead, and both
+// locations decode into this type (see OPNsense.NetFlowSettings).
+type NetFlowConfig struct {
+	Text            string         `xml:",chardata"    json:"text,omitempty"`
+	Version         string         `xml:"version,attr" json:"version,omitempty"`
+	Capture         NetFlowCapture `xml:"capture"      json:"capture"`
+	Collect         NetFlowCollect `xml:"collect"      json:"collect"`
+	InactiveTimeout string         `xml:"inactiveTimeout"`
+	ActiveTimeout   string         `xml:"activeTimeout"`
+}
+
+// NetFlowCapture holds the flow capture settings: the captured interfaces,
+// the export protocol version, and the collectors flows are sent to.
+type NetFlowCapture struct {
+	Text string `xml:",chardata" json:"text,omitempty"`
+	// Interfaces is a comma-separated list of captured interfaces.
+	Interfaces string `xml:"interfaces"`
+	// Version is the export protocol: "v5", "v9", or IPFIX.
+	Version    string `xml:"version"`
+	EgressOnly string `xml:"egress_only"`
+	// Targets is a comma-separated list of collectors as ip:port.
+	Targets string `xml:"targets"`
+}
+
+// NetFlowCollect holds the settings of the local flow collector.
+type NetFlowCollect struct {
+	Text   string `xml:",chardata" json:"text,omitempty"`
+	Enable string `xml:"enable"`
+}
+
+// NetFlowCollector is one flow export destination parsed from
+// NetFlowCapture.Targets.
+type NetFlowCollector struct {
+	Address string
+	Port    string
+}
+
+// Diagnostics represents the <OPNsense><Diagnostics> container.
+type Diagnostics struct {
+	Text    string         `xml:",chardata"         json:"text,omitempty"`
+	Netflow *NetFlowConfig `xml:"netflow,omitempty" json:"netflow,omitempty"`
+}
+
+// Collectors returns the flow export destinations listed in Capture.Targets,
+// in configuration order. A target without a port is returned with an empty
+// Port.
+func (n *NetFlowConfig) Collectors() []NetFlowCollector {
+	var collectors []NetFlowCollector
+
+	for target := range strings.SplitSeq(n.Capture.Targets, ",") {
+		target = strings.TrimSpace(target)
+		if target == "" {
+			continue
+		}
+
+		host, port, err := net.SplitHostPort(target)
+		if err != nil {
+			collectors = append(collectors, NetFlowCollector{Address: target})
+			continue
+		}
+
+		collectors = append(collectors, NetFlowCollector{Address: host, Port: port})
+	}
+
+	return collectors
+}
+
+// IsNetFlowEnabled reports whether flows are captured and sent somewhere: at
+// least one capture interface is set, and flows go to a configured collector
+// or to the local collector.
+func (n *NetFlowConfig) IsNetFlowEnabled() bool {
+	if strings.TrimSpace(n.Capture.Interfaces) == "" {
+		return false
+	}
+
+	return len(n.Collectors()) > 0 || n.Collect.Enable == "1"
+}
+
+// NetFlowSettings returns the NetFlow configuration, preferring the
+// <Diagnostics><netflow> block when present over the <Netflow> mount point.
+func (o *OPNsense) NetFlowSettings() *NetFlowConfig {
+	if o.Diagnostics != nil && o.Diagnostics.Netflow != nil {
+		return o.Diagnostics.Netflow
+	}
+
+	return &o.Netflow
+}
@@ -0,0 +1,159 @@
+package opnsense
+
+import (
+	"encoding/xml"
+	"reflect"
+	"testing"
+)
+
+func TestNetFlowConfig_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	xmlData := `<OPNsense>
+		<Netflow version="1.0.1">
+			<capture>
+				<interfaces>lan,opt1</interfaces>
+				<version>v9</version>
+				<egress_only>lan</egress_only>
+				<targets>10.0.0.5:2055,198.51.100.7:4739</targets>
+			</capture>
+			<collect>
+				<enable>1</enable>
+			</collect>
+			<inactiveTimeout>15</inactiveTimeout>
+			<activeTimeout>1800</activeTimeout>
+		</Netflow>
+		<Diagnostics>
+			<netflow>
+				<capture>
+					<interfaces>wan</interfaces>
+					<version>ipfix</version>
+					<targets>203.0.113.9:4739</targets>
+				</capture>
+			</netflow>
+		</Diagnostics>
+	</OPNsense>`
+
+	var first OPNsense
+	if err := xml.Unmarshal([]byte(xmlData), &first); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	wantMount := NetFlowConfig{
+		Version: "1.0.1",
+		Capture: NetFlowCapture{
+			Interfaces: "lan,opt1",
+			Version:    "v9",
+			EgressOnly: "lan",
+			Targets:    "10.0.0.5:2055,198.51.100.7:4739",
+		},
+		Collect:         NetFlowCollect{Enable: "1"},
+		InactiveTimeout: "15",
+		ActiveTimeout:   "1800",
+	}
+	wantDiagnostics := NetFlowConfig{
+		Capture: NetFlowCapture{Interfaces: "wan", Version: "ipfix", Targets: "203.0.113.9:4739"},
+	}
+
+	check := func(label string, got OPNsense) {
+		t.Helper()
+
+		if !reflect.DeepEqual(stripNetFlowText(got.Netflow), wantMount) {
+			t.Errorf("%s: Netflow = %+v, want %+v", label, got.Netflow, wantMount)
+		}
+		if got.Diagnostics == nil || got.Diagnostics.Netflow == nil {
+			t.Fatalf("%s: Diagnostics.Netflow = nil", label)
+		}
+		if !reflect.DeepEqual(stripNetFlowText(*got.Diagnostics.Netflow), wantDiagnostics) {
+			t.Errorf("%s: Diagnostics.Netflow = %+v, want %+v", label, *got.Diagnostics.Netflow, wantDiagnostics)
+		}
+	}
+	check("unmarshal", first)
+
+	encoded, err := xml.Marshal(&first)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	var second OPNsense
+	if err := xml.Unmarshal(encoded, &second); err != nil {
+		t.Fatalf("Unmarshal() of marshaled output error = %v", err)
+	}
+	check("round trip", second)
+}
+
+func TestOPNsense_NetFlowSettings(t *testing.T) {
+	t.Parallel()
+
+	var o OPNsense
+	o.Netflow.Capture.Interfaces = "lan"
+
+	if got := o.NetFlowSettings(); got != &o.Netflow {
+		t.Errorf("NetFlowSettings() without Diagnostics = %p, want the <Netflow> mount %p", got, &o.Netflow)
+	}
+
+	nested := &NetFlowConfig{Capture: NetFlowCapture{Interfaces: "wan"}}
+	o.Diagnostics = &Diagnostics{Netflow: nested}
+	if got := o.NetFlowSettings(); got != nested {
+		t.Errorf("NetFlowSettings() = %+v, want the <Diagnostics><netflow> block", got)
+	}
+}
+
+func TestNetFlowConfig_Collectors(t *testing.T) {
+	t.Parallel()
+
+	n := NetFlowConfig{Capture: NetFlowCapture{Targets: "10.0.0.5:2055, [2001:db8::5]:4739,,collector.example.com"}}
+
+	want := []NetFlowCollector{
+		{Address: "10.0.0.5", Port: "2055"},
+		{Address: "2001:db8::5", Port: "4739"},
+		{Address: "collector.example.com"},
+	}
+	if got := n.Collectors(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Collectors() = %+v, want %+v", got, want)
+	}
+}
+
+func TestNetFlowConfig_IsNetFlowEnabled(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		cfg  NetFlowConfig
+		want bool
+	}{
+		{"empty", NetFlowConfig{}, false},
+		{"targets without interfaces", NetFlowConfig{Capture: NetFlowCapture{Targets: "10.0.0.5:2055"}}, false},
+		{"interfaces without destination", NetFlowConfig{Capture: NetFlowCapture{Interfaces: "lan"}}, false},
+		{
+			"interfaces with target",
+			NetFlowConfig{Capture: NetFlowCapture{Interfaces: "lan", Targets: "10.0.0.5:2055"}},
+			true,
+		},
+		{
+			"interfaces with local collector",
+			NetFlowConfig{Capture: NetFlowCapture{Interfaces: "lan"}, Collect: NetFlowCollect{Enable: "1"}},
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.cfg.IsNetFlowEnabled(); got != tt.want {
+				t.Errorf("IsNetFlowEnabled() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// stripNetFlowText clears the whitespace chardata the decoder keeps, so
+// decoded configurations can be compared to literals.
+func stripNetFlowText(n NetFlowConfig) NetFlowConfig {
+	n.Text = ""
+	n.Capture.Text = ""
+	n.Collect.Text = ""
+
+	return n
+}
@@ -27,6 +27,8 @@ type OpnSenseDocument struct {
 	LoadBalancer         LoadBalancer           `xml:"load_balancer,omitempty"          json:"loadBalancer"         yaml:"loadBalancer,omitempty"`
 	Ntpd                 Ntpd                   `xml:"ntpd,omitempty"                   json:"ntpd"                 yaml:"ntpd,omitempty"`
 	Widgets              Widgets                `xml:"widgets,omitempty"                json:"widgets"              yaml:"widgets,omitempty"`
+	RRDData              *RawSection            `xml:"rrddata,omitempty"                json:"-"                    yaml:"-"`
+	Notifications        *RawSection            `xml:"notifications,omitempty"          json:"-"                    yaml:"-"`
 	Revision             Revision               `xml:"revision,omitempty"               json:"revision"             yaml:"revision,omitempty"`
 	Gateways             Gateways               `xml:"gateways,omitempty"               json:"gateways"             yaml:"gateways,omitempty"`
 	HighAvailabilitySync HighAvailabilitySync   `xml:"hasync,omitempty"                 json:"hasync"               yaml:"hasync,omitempty"`
@@ -44,8 +46,15 @@ type OpnSenseDocument struct {
 	CAs                  []CertificateAuthority `xml:"ca,omitempty"                     json:"ca,omitempty"         yaml:"ca,omitempty"`
 	DHCPv6Server         DHCPv6Server           `xml:"dhcpdv6,omitempty"                json:"dhcpdv6"              yaml:"dhcpdv6,omitempty"`
 	Certs                []Cert                 `xml:"cert,omitempty"                   json:"cert,omitempty"       yaml:"cert,omitempty"`
+	CRLs                 []CRL                  `xml:"crl,omitempty"                    json:"crl,omitempty"        yaml:"crl,omitempty"`
 	DNSMasquerade        DNSMasq                `xml:"dnsmasq,omitempty"                json:"dnsmasq"              yaml:"dnsmasq,omitempty"`
 	Syslog               Syslog                 `xml:"syslog,omitempty"                 json:"syslog"               yaml:"syslog,omitempty"`
+	PF                   PFSettings             `xml:"pf,omitempty"                     json:"pf"                   yaml:"pf,omitempty"`
+	WOL                  WOL                    `xml:"wol,omitempty"                    json:"wol"                  yaml:"wol,omitempty"`
+	PPTPD                *PPTPServer            `xml:"pptpd,omitempty"                  json:"pptpd,omitempty"      yaml:"pptpd,omitempty"`
+	L2TP                 *L2TPServer            `xml:"l2tp,omitempty"                   json:"l2tp,omitempty"       yaml:"l2tp,omitempty"`
+	QueueStats           *QueueStats            `xml:"queuestats,omitempty"             json:"queuestats,omitempty" yaml:"queuestats,omitempty"`
+	Schedules            Schedules              `xml:"schedules,omitempty"              json:"schedules"            yaml:"schedules,omitempty"`
 	// Aliases is the legacy top-level <aliases> element used by older
 	// OPNsense configs that predate the MVC Firewall/Alias subsystem
 	// (modern configs store aliases at OPNsense.Firewall.Alias.Aliases
@@ -54,6 +63,13 @@ type OpnSenseDocument struct {
 	// single common.NamedObjects registry.
 	Aliases  AliasList `xml:"aliases,omitempty"  json:"aliases"  yaml:"aliases,omitempty"`
 	OPNsense OPNsense  `xml:"OPNsense,omitempty" json:"opnsense" yaml:"opnsense,omitempty"`
+	// InstalledPackages is the legacy <installedpackages> element holding
+	// the settings of packages that predate the MVC framework, such as
+	// miniupnpd. Nil when the configuration has no such element.
+	InstalledPackages *InstalledPackages `xml:"installedpackages,omitempty" json:"installedpackages,omitempty" yaml:"installedpackages,omitempty"`
+	// Encoding records the character encoding of the parsed input. It is set
+	// by the parser and is not part of the XML.
+	Encoding InputEncoding `xml:"-" json:"-" yaml:"-"`
 }
 
 // OPNsense represents the <OPNsense> sub-element within the configuration, containing
@@ -64,10 +80,10 @@ type OPNsense struct {
 	Text    string   `xml:",chardata" json:"text,omitempty"`
 
 	Captiveportal struct {
-		Text      string `xml:",chardata" json:"text,omitempty"`
-		Version   string `xml:"version,attr" json:"version,omitempty"`
-		Zones     string `xml:"zones"`
-		Templates string `xml:"templates"`
+		Text      string             `xml:",chardata"    json:"text,omitempty"`
+		Version   string             `xml:"version,attr" json:"version,omitempty"`
+		Zones     CaptivePortalZones `xml:"zones"`
+		Templates string             `xml:"templates"`
 	} `xml:"captiveportal" json:"captiveportal"`
 	Cron struct {
 		Text    string `xml:",chardata" json:"text,omitempty"`
@@ -87,9 +103,10 @@ type OPNsense struct {
 	Swanctl                  *Swanctl  `xml:"Swanctl,omitempty"  json:"swanctl,omitempty"`
 
 	// VPN components - now using references
-	OpenVPNExport *OpenVPNExport `xml:"OpenVPNExport,omitempty" json:"openvpnexport,omitempty"`
-	OpenVPN       *OpenVPNSystem `xml:"OpenVPN,omitempty"       json:"openvpn_system,omitempty"`
-	Wireguard     *WireGuard     `xml:"wireguard,omitempty"     json:"wireguard,omitempty"`
+	OpenVPNExport *OpenVPNExport  `xml:"OpenVPNExport,omitempty" json:"openvpnexport,omitempty"`
+	OpenVPN       *OpenVPNSystem  `xml:"OpenVPN,omitempty"       json:"openvpn_system,omitempty"`
+	Wireguard     *WireGuard      `xml:"wireguard,omitempty"     json:"wireguard,omitempty"`
+	ZeroTier      *ZeroTierConfig `xml:"zerotier,omitempty"      json:"zerotier,omitempty"`
 
 	// Monitoring components - now using references
 	Monit *Monit `xml:"monit,omitempty" json:"monit,omitempty"`
@@ -134,23 +151,8 @@ type OPNsense struct {
 		Version string `xml:"version,attr" json:"version,omitempty"`
 	} `xml:"Gateways" json:"gateways_internal"`
 
-	Netflow struct {
-		Text    string `xml:",chardata" json:"text,omitempty"`
-		Version string `xml:"version,attr" json:"version,omitempty"`
-		Capture struct {
-			Text       string `xml:",chardata" json:"text,omitempty"`
-			Interfaces string `xml:"interfaces"`
-			Version    string `xml:"version"`
-			EgressOnly string `xml:"egress_only"`
-			Targets    string `xml:"targets"`
-		} `xml:"capture" json:"capture"`
-		Collect struct {
-			Text   string `xml:",chardata" json:"text,omitempty"`
-			Enable string `xml:"enable"`
-		} `xml:"collect" json:"collect"`
-		InactiveTimeout string `xml:"inactiveTimeout"`
-		ActiveTimeout   string `xml:"activeTimeout"`
-	} `xml:"Netflow" json:"netflow"`
+	Netflow     NetFlowConfig `xml:"Netflow"               json:"netflow"`
+	Diagnostics *Diagnostics  `xml:"Diagnostics,omitempty" json:"diagnostics,omitempty"`
 
 	SyslogInternal struct {
 		Text    string `xml:",chardata" json:"text,omitempty"`
@@ -166,11 +168,11 @@ type OPNsense struct {
 	} `xml:"Syslog" json:"syslog_internal"`
 
 	TrafficShaper struct {
-		Text    string `xml:",chardata" json:"text,omitempty"`
-		Version string `xml:"version,attr" json:"version,omitempty"`
-		Pipes   string `xml:"pipes"`
-		Queues  string `xml:"queues"`
-		Rules   string `xml:"rules"`
+		Text    string              `xml:",chardata"    json:"text,omitempty"`
+		Version string              `xml:"version,attr" json:"version,omitempty"`
+		Pipes   TrafficShaperPipes  `xml:"pipes"`
+		Queues  TrafficShaperQueues `xml:"queues"`
+		Rules   string              `xml:"rules"`
 	} `xml:"TrafficShaper" json:"trafficshaper"`
 
 	Trust struct {
@@ -207,12 +209,35 @@ type OPNsense struct {
 	Updated string `xml:"updated,omitempty"`
 }
 
+// CaptivePortalZones represents the <OPNsense><captiveportal><zones>
+// container.
+type CaptivePortalZones struct {
+	Text string              `xml:",chardata" json:"text,omitempty"`
+	Zone []CaptivePortalZone `xml:"zone"      json:"zone,omitempty"`
+}
+
+// CaptivePortalZone represents one captive portal zone. Only the fields
+// opnDossier reports on are modeled; every other child element is kept
+// verbatim in Extra for XML round-tripping.
+type CaptivePortalZone struct {
+	UUID        string `xml:"uuid,attr,omitempty" json:"uuid,omitempty"`
+	Enabled     string `xml:"enabled"             json:"enabled,omitempty"`
+	Description string `xml:"description"         json:"description,omitempty"`
+	// AuthServers is the comma-separated list of authentication server
+	// names users of the zone log in with.
+	AuthServers string       `xml:"authservers" json:"authServers,omitempty"`
+	Extra       []RawSection `xml:",any"        json:"-"`
+}
+
 // Cert represents an X.509 certificate entry in the OPNsense configuration,
 // containing the certificate body (Crt), private key (Prv), reference ID, and description.
+// Caref is the refid of the issuing CA in the trust store; it is empty for
+// self-signed and imported certificates.
 type Cert struct {
 	Text  string `xml:",chardata" json:"text,omitempty"`
 	Refid string `xml:"refid"`
 	Descr string `xml:"descr"`
+	Caref string `xml:"caref,omitempty"`
 	Crt   string `xml:"crt"`
 	Prv   string `xml:"prv"`
 }
@@ -247,6 +272,40 @@ func (o *OpnSenseDocument) Hostname() string {
 	return o.System.Hostname
 }
 
+// FindCRLByRef returns the certificate revocation list with the given refid,
+// or nil when none matches.
+func (o *OpnSenseDocument) FindCRLByRef(refid string) *CRL {
+	for i := range o.CRLs {
+		if o.CRLs[i].Refid == refid {
+			return &o.CRLs[i]
+		}
+	}
+
+	return nil
+}
+
+// FindCertByRef returns the certificate with the given refid, or nil when
+// none matches.
+func (o *OpnSenseDocument) FindCertByRef(refid string) *Cert {
+	for i := range o.Certs {
+		if o.Certs[i].Refid == refid {
+			return &o.Certs[i]
+		}
+	}
+
+	return nil
+}
+
+// WebGUICert returns the certificate referenced by the web GUI's
+// ssl-certref, or nil when none is set or it does not resolve.
+func (o *OpnSenseDocument) WebGUICert() *Cert {
+	if o.System.WebGUI.SSLCertRef == "" {
+		return nil
+	}
+
+	return o.FindCertByRef(o.System.WebGUI.SSLCertRef)
+}
+
 // InterfaceByName returns a network interface by its interface name (e.g., "em0", "igb0").
 func (o *OpnSenseDocument) InterfaceByName(name string) *Interface {
 	for _, iface := range o.Interfaces.Items {
@@ -295,6 +354,7 @@ func (o *OpnSenseDocument) ServiceConfig() ServiceConfig {
 		Rrd:          o.Rrd,
 		LoadBalancer: o.LoadBalancer,
 		Ntpd:         o.Ntpd,
+		WOL:          o.WOL,
 	}
 }
 
@@ -308,6 +368,7 @@ func (o *OpnSenseDocument) NATSummary() NATSummary {
 		PfShareForward:     false,
 		OutboundRules:      nil,
 		InboundRules:       nil,
+		OneToOneRules:      nil,
 	}
 
 	// Safely access System fields
@@ -328,6 +389,9 @@ func (o *OpnSenseDocument) NATSummary() NATSummary {
 	if o.Nat.Inbound != nil {
 		summary.InboundRules = o.Nat.Inbound
 	}
+	if o.Nat.OneToOne != nil {
+		summary.OneToOneRules = o.Nat.OneToOne
+	}
 
 	return summary
 }
@@ -0,0 +1,61 @@
+// Package opnsense defines the data structures for OPNsense configurations.
+package opnsense
+
+import "strconv"
+
+// PFSettings represents the top-level <pf> element holding the advanced
+// stateful inspection limits and state timeout overrides applied to pf. Every
+// field is optional; an empty value means pf uses its built-in default.
+type PFSettings struct {
+	// StateScale selects how state timeouts scale with table usage
+	// (e.g. "adaptive" or "conservative").
+	StateScale string `xml:"statescale,omitempty"          json:"stateScale,omitempty"          yaml:"stateScale,omitempty"`
+	// MaximumStates is the hard limit on the number of pf state table entries.
+	MaximumStates string `xml:"maximumstates,omitempty"       json:"maximumStates,omitempty"       yaml:"maximumStates,omitempty"`
+	// MaximumTableEntries is the limit on addresses held across all pf tables.
+	MaximumTableEntries string `xml:"maximumtableentries,omitempty" json:"maximumTableEntries,omitempty" yaml:"maximumTableEntries,omitempty"`
+	// Frags is the limit on fragment reassembly entries.
+	Frags string `xml:"frags,omitempty"               json:"frags,omitempty"               yaml:"frags,omitempty"`
+	// SrcNodes is the limit on source-tracking nodes.
+	SrcNodes string `xml:"src_nodes,omitempty"           json:"srcNodes,omitempty"            yaml:"srcNodes,omitempty"`
+	// AdaptiveStart is the state count at which adaptive timeout scaling begins.
+	AdaptiveStart string `xml:"adaptivestart,omitempty"       json:"adaptiveStart,omitempty"       yaml:"adaptiveStart,omitempty"`
+	// AdaptiveEnd is the state count at which all timeouts reach zero.
+	AdaptiveEnd string `xml:"adaptiveend,omitempty"         json:"adaptiveEnd,omitempty"         yaml:"adaptiveEnd,omitempty"`
+
+	// State timeout overrides, in seconds.
+	TCPFirstTimeout       string `xml:"tcpfirsttimeout,omitempty"       json:"tcpFirstTimeout,omitempty"       yaml:"tcpFirstTimeout,omitempty"`
+	TCPOpeningTimeout     string `xml:"tcpopeningtimeout,omitempty"     json:"tcpOpeningTimeout,omitempty"     yaml:"tcpOpeningTimeout,omitempty"`
+	TCPEstablishedTimeout string `xml:"tcpestablishedtimeout,omitempty" json:"tcpEstablishedTimeout,omitempty" yaml:"tcpEstablishedTimeout,omitempty"`
+	TCPClosingTimeout     string `xml:"tcpclosingtimeout,omitempty"     json:"tcpClosingTimeout,omitempty"     yaml:"tcpClosingTimeout,omitempty"`
+	TCPFinWaitTimeout     string `xml:"tcpfinwaittimeout,omitempty"     json:"tcpFinWaitTimeout,omitempty"     yaml:"tcpFinWaitTimeout,omitempty"`
+	TCPClosedTimeout      string `xml:"tcpclosedtimeout,omitempty"      json:"tcpClosedTimeout,omitempty"      yaml:"tcpClosedTimeout,omitempty"`
+	UDPFirstTimeout       string `xml:"udpfirsttimeout,omitempty"       json:"udpFirstTimeout,omitempty"       yaml:"udpFirstTimeout,omitempty"`
+	UDPSingleTimeout      string `xml:"udpsingletimeout,omitempty"      json:"udpSingleTimeout,omitempty"      yaml:"udpSingleTimeout,omitempty"`
+	UDPMultipleTimeout    string `xml:"udpmultipletimeout,omitempty"    json:"udpMultipleTimeout,omitempty"    yaml:"udpMultipleTimeout,omitempty"`
+	ICMPFirstTimeout      string `xml:"icmpfirsttimeout,omitempty"      json:"icmpFirstTimeout,omitempty"      yaml:"icmpFirstTimeout,omitempty"`
+	ICMPErrorTimeout      string `xml:"icmperrortimeout,omitempty"      json:"icmpErrorTimeout,omitempty"      yaml:"icmpErrorTimeout,omitempty"`
+	OtherFirstTimeout     string `xml:"otherfirsttimeout,omitempty"     json:"otherFirstTimeout,omitempty"     yaml:"otherFirstTimeout,omitempty"`
+	OtherSingleTimeout    string `xml:"othersingletimeout,omitempty"    json:"otherSingleTimeout,omitempty"    yaml:"otherSingleTimeout,omitempty"`
+	OtherMultipleTimeout  string `xml:"othermultipletimeout,omitempty"  json:"otherMultipleTimeout,omitempty"  yaml:"otherMultipleTimeout,omitempty"`
+}
+
+// MaxStateTableSize returns the configured pf state table limit. It returns 0
+// when no limit is set, and also when the value is malformed or negative,
+// since neither is a usable limit. Callers cannot tell those cases apart from
+// an unset limit; read MaximumStates directly to see the raw value.
+func (p PFSettings) MaxStateTableSize() int {
+	n, err := strconv.Atoi(p.MaximumStates)
+	if err != nil || n < 0 {
+		return 0
+	}
+
+	return n
+}
+
+// IsStateLimitSet reports whether a usable state table limit is configured.
+// It is false for an unset, zero, negative or malformed value; pf then sizes
+// the table from its built-in default.
+func (p PFSettings) IsStateLimitSet() bool {
+	return p.MaxStateTableSize() > 0
+}
@@ -0,0 +1,123 @@
+package opnsense
+
+import (
+	"encoding/xml"
+	"testing"
+)
+
+func TestPFSettings_MaxStateTableSize(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		value   string
+		want    int
+		wantSet bool
+	}{
+		{"unset", "", 0, false},
+		{"explicit limit", "500000", 500000, true},
+		{"zero", "0", 0, false},
+		{"negative", "-1", 0, false},
+		{"not a number", "unlimited", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			pf := PFSettings{MaximumStates: tt.value}
+
+			if got := pf.MaxStateTableSize(); got != tt.want {
+				t.Errorf("MaxStateTableSize() = %d, want %d", got, tt.want)
+			}
+
+			if got := pf.IsStateLimitSet(); got != tt.wantSet {
+				t.Errorf("IsStateLimitSet() = %v, want %v", got, tt.wantSet)
+			}
+		})
+	}
+}
+
+// TestPFSettings_MarshalUnmarshal tests XML round-trip for PFSettings.
+func TestPFSettings_MarshalUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	xmlData := `<pf>
+  <statescale>adaptive</statescale>
+  <maximumstates>250000</maximumstates>
+  <maximumtableentries>400000</maximumtableentries>
+  <frags>5000</frags>
+  <src_nodes>20000</src_nodes>
+  <adaptivestart>150000</adaptivestart>
+  <adaptiveend>300000</adaptiveend>
+  <tcpestablishedtimeout>86400</tcpestablishedtimeout>
+  <udpmultipletimeout>60</udpmultipletimeout>
+  <icmperrortimeout>10</icmperrortimeout>
+</pf>`
+
+	var pf PFSettings
+	if err := xml.Unmarshal([]byte(xmlData), &pf); err != nil {
+		t.Fatalf("xml.Unmarshal() failed: %v", err)
+	}
+
+	want := PFSettings{
+		StateScale:            "adaptive",
+		MaximumStates:         "250000",
+		MaximumTableEntries:   "400000",
+		Frags:                 "5000",
+		SrcNodes:              "20000",
+		AdaptiveStart:         "150000",
+		AdaptiveEnd:           "300000",
+		TCPEstablishedTimeout: "86400",
+		UDPMultipleTimeout:    "60",
+		ICMPErrorTimeout:      "10",
+	}
+	if pf != want {
+		t.Fatalf("unmarshalled PFSettings = %+v, want %+v", pf, want)
+	}
+
+	data, err := xml.Marshal(struct {
+		XMLName xml.Name `xml:"pf"`
+		PFSettings
+	}{PFSettings: pf})
+	if err != nil {
+		t.Fatalf("xml.Marshal() failed: %v", err)
+	}
+
+	var result PFSettings
+	if err := xml.Unmarshal(data, &result); err != nil {
+		t.Fatalf("xml.Unmarshal() of marshalled data failed: %v", err)
+	}
+
+	if result != want {
+		t.Errorf("round-trip PFSettings = %+v, want %+v", result, want)
+	}
+}
+
+// TestOpnSenseDocument_PFRoundTrip verifies that <pf> survives a full
+// document round-trip with its state limit intact.
+func TestOpnSenseDocument_PFRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	doc := NewOpnSenseDocument()
+	doc.PF.MaximumStates = "100000"
+	doc.PF.OtherSingleTimeout = "30"
+
+	data, err := xml.Marshal(doc)
+	if err != nil {
+		t.Fatalf("xml.Marshal() failed: %v", err)
+	}
+
+	var result OpnSenseDocument
+	if err := xml.Unmarshal(data, &result); err != nil {
+		t.Fatalf("xml.Unmarshal() failed: %v", err)
+	}
+
+	if result.PF != doc.PF {
+		t.Errorf("round-trip PF = %+v, want %+v", result.PF, doc.PF)
+	}
+
+	if !result.PF.IsStateLimitSet() || result.PF.MaxStateTableSize() != 100000 {
+		t.Errorf("MaxStateTableSize() = %d after round-trip, want 100000", result.PF.MaxStateTableSize())
+	}
+}
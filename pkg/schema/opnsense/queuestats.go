@@ -0,0 +1,25 @@
+package opnsense
+
+import "encoding/xml"
+
+// QueueStats represents the top-level <queuestats> element that some OPNsense
+// backup exports carry alongside the configuration. It is a point-in-time
+// snapshot of traffic shaper queue counters taken when the backup was made,
+// not configuration, so its values are only meaningful relative to Timestamp.
+type QueueStats struct {
+	XMLName   xml.Name     `xml:"queuestats"`
+	Timestamp string       `xml:"timestamp,omitempty"`
+	Queues    []QueueEntry `xml:"queue,omitempty"`
+}
+
+// QueueEntry represents a <queue> entry of <queuestats>. Counters are kept as
+// the raw element text and parsed by the converter.
+type QueueEntry struct {
+	Name           string `xml:"name,omitempty"`
+	Interface      string `xml:"interface,omitempty"`
+	Packets        string `xml:"pkts,omitempty"`
+	Bytes          string `xml:"bytes,omitempty"`
+	DroppedPackets string `xml:"droppedpkts,omitempty"`
+	DroppedBytes   string `xml:"droppedbytes,omitempty"`
+	QueueLength    string `xml:"qlength,omitempty"`
+}
@@ -0,0 +1,105 @@
+package opnsense
+
+import (
+	"encoding/xml"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestQueueStats_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	xmlData := `<queuestats>
+  <timestamp>2024-05-01T12:00:00Z</timestamp>
+  <queue>
+    <name>qVoIP</name>
+    <interface>wan</interface>
+    <pkts>182734</pkts>
+    <bytes>24876213</bytes>
+    <droppedpkts>12</droppedpkts>
+    <droppedbytes>1836</droppedbytes>
+    <qlength>0</qlength>
+  </queue>
+  <queue>
+    <name>qDefault</name>
+    <interface>wan</interface>
+    <pkts>9912</pkts>
+    <bytes>8311021</bytes>
+  </queue>
+</queuestats>`
+
+	var first QueueStats
+	if err := xml.Unmarshal([]byte(xmlData), &first); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	want := QueueStats{
+		Timestamp: "2024-05-01T12:00:00Z",
+		Queues: []QueueEntry{
+			{
+				Name:           "qVoIP",
+				Interface:      "wan",
+				Packets:        "182734",
+				Bytes:          "24876213",
+				DroppedPackets: "12",
+				DroppedBytes:   "1836",
+				QueueLength:    "0",
+			},
+			{Name: "qDefault", Interface: "wan", Packets: "9912", Bytes: "8311021"},
+		},
+	}
+	first.XMLName = xml.Name{}
+	if !reflect.DeepEqual(first, want) {
+		t.Fatalf("Unmarshal() = %+v, want %+v", first, want)
+	}
+
+	encoded, err := xml.Marshal(&first)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	var second QueueStats
+	if err := xml.Unmarshal(encoded, &second); err != nil {
+		t.Fatalf("Unmarshal() of marshaled output error = %v", err)
+	}
+	second.XMLName = xml.Name{}
+	if !reflect.DeepEqual(second, want) {
+		t.Errorf("round trip = %+v, want %+v", second, want)
+	}
+}
+
+func TestOpnSenseDocument_QueueStatsRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	doc := OpnSenseDocument{
+		QueueStats: &QueueStats{
+			Queues: []QueueEntry{{Name: "qBulk", Interface: "lan", Packets: "7", Bytes: "4200"}},
+		},
+	}
+
+	encoded, err := xml.Marshal(&doc)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	var decoded OpnSenseDocument
+	if err := xml.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if decoded.QueueStats == nil {
+		t.Fatal("QueueStats = nil after round trip, want the <queuestats> section")
+	}
+	if got := decoded.QueueStats.Queues; !reflect.DeepEqual(got, doc.QueueStats.Queues) {
+		t.Errorf("Queues = %+v, want %+v", got, doc.QueueStats.Queues)
+	}
+
+	empty, err := xml.Marshal(&OpnSenseDocument{})
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if got := string(empty); strings.Contains(got, "<queuestats") {
+		t.Errorf("Marshal() of a document without queue statistics emitted <queuestats>: %s", got)
+	}
+}
@@ -0,0 +1,29 @@
+package opnsense
+
+// Schedules represents the top-level <schedules> element holding the
+// firewall schedules that rules reference by name through <sched>.
+type Schedules struct {
+	Schedule []Schedule `xml:"schedule,omitempty" json:"schedule,omitempty" yaml:"schedule,omitempty"`
+}
+
+// Schedule represents a named firewall schedule. A rule referencing it is
+// only active while one of its time ranges matches the current time.
+type Schedule struct {
+	Name       string              `xml:"name"                json:"name"                 yaml:"name"`
+	Descr      string              `xml:"descr,omitempty"     json:"description,omitempty" yaml:"description,omitempty"`
+	TimeRanges []ScheduleTimeRange `xml:"timerange,omitempty" json:"timeRanges,omitempty" yaml:"timeRanges,omitempty"`
+}
+
+// ScheduleTimeRange represents a <timerange> of a schedule. A range is either
+// date-based or weekly: date-based ranges list comma-separated Month and Day
+// values paired element-wise (Month "3,3" with Day "14,15" is March 14 and
+// March 15), while weekly ranges list ISO weekdays (1 = Monday through
+// 7 = Sunday) in Position. Dates carry no year. Hour is a "HH:MM-HH:MM"
+// window applied to every selected day.
+type ScheduleTimeRange struct {
+	Month      string `xml:"month,omitempty"      json:"month,omitempty"       yaml:"month,omitempty"`
+	Day        string `xml:"day,omitempty"        json:"day,omitempty"         yaml:"day,omitempty"`
+	Position   string `xml:"position,omitempty"   json:"position,omitempty"    yaml:"position,omitempty"`
+	Hour       string `xml:"hour,omitempty"       json:"hour,omitempty"        yaml:"hour,omitempty"`
+	RangeDescr string `xml:"rangedescr,omitempty" json:"description,omitempty" yaml:"description,omitempty"`
+}
@@ -0,0 +1,96 @@
+package opnsense
+
+import (
+	"encoding/xml"
+	"reflect"
+	"testing"
+)
+
+func TestSchedules_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	xmlData := `<schedules>
+  <schedule>
+    <name>Maintenance</name>
+    <descr>Spring window</descr>
+    <timerange>
+      <month>3,3</month>
+      <day>14,15</day>
+      <hour>0:00-23:59</hour>
+      <rangedescr>Weekend</rangedescr>
+    </timerange>
+  </schedule>
+  <schedule>
+    <name>OfficeHours</name>
+    <timerange>
+      <position>1,2,3,4,5</position>
+      <hour>8:00-17:00</hour>
+    </timerange>
+  </schedule>
+</schedules>`
+
+	var first Schedules
+	if err := xml.Unmarshal([]byte(xmlData), &first); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	want := Schedules{
+		Schedule: []Schedule{
+			{
+				Name:  "Maintenance",
+				Descr: "Spring window",
+				TimeRanges: []ScheduleTimeRange{{
+					Month:      "3,3",
+					Day:        "14,15",
+					Hour:       "0:00-23:59",
+					RangeDescr: "Weekend",
+				}},
+			},
+			{
+				Name:       "OfficeHours",
+				TimeRanges: []ScheduleTimeRange{{Position: "1,2,3,4,5", Hour: "8:00-17:00"}},
+			},
+		},
+	}
+	if !reflect.DeepEqual(first, want) {
+		t.Fatalf("Unmarshal() = %+v, want %+v", first, want)
+	}
+
+	encoded, err := xml.Marshal(&first)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	var second Schedules
+	if err := xml.Unmarshal(encoded, &second); err != nil {
+		t.Fatalf("Unmarshal() of marshaled output error = %v", err)
+	}
+	if !reflect.DeepEqual(second, want) {
+		t.Errorf("round trip = %+v, want %+v", second, want)
+	}
+}
+
+func TestRule_SchedRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	var rule Rule
+	if err := xml.Unmarshal([]byte(`<rule><type>pass</type><sched>OfficeHours</sched></rule>`), &rule); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if rule.Sched != "OfficeHours" {
+		t.Fatalf("Sched = %q, want %q", rule.Sched, "OfficeHours")
+	}
+
+	encoded, err := xml.Marshal(&rule)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	var decoded Rule
+	if err := xml.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("Unmarshal() of marshaled output error = %v", err)
+	}
+	if decoded.Sched != rule.Sched {
+		t.Errorf("round trip Sched = %q, want %q", decoded.Sched, rule.Sched)
+	}
+}
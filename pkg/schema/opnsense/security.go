@@ -5,6 +5,9 @@ import (
 	"encoding/xml"
 	"slices"
 	"strings"
+	"time"
+
+	"github.com/EvilBit-Labs/opnDossier/pkg/schema/shared"
 )
 
 // InterfaceList represents a comma-separated list of interfaces that can be unmarshaled from XML.
@@ -71,17 +74,20 @@ type SecurityConfig struct {
 // NATSummary provides a flattened view of NAT configuration for security analysis,
 // combining outbound mode, reflection settings, and both inbound and outbound rule sets.
 type NATSummary struct {
-	Mode               string        `json:"mode"                    yaml:"mode"`
-	ReflectionDisabled bool          `json:"reflectionDisabled"      yaml:"reflectionDisabled"`
-	PfShareForward     bool          `json:"pfShareForward"          yaml:"pfShareForward"`
-	OutboundRules      []NATRule     `json:"outboundRules,omitempty" yaml:"outboundRules,omitempty"`
-	InboundRules       []InboundRule `json:"inboundRules,omitempty"  yaml:"inboundRules,omitempty"`
+	Mode               string         `json:"mode"                    yaml:"mode"`
+	ReflectionDisabled bool           `json:"reflectionDisabled"      yaml:"reflectionDisabled"`
+	PfShareForward     bool           `json:"pfShareForward"          yaml:"pfShareForward"`
+	OutboundRules      []NATRule      `json:"outboundRules,omitempty" yaml:"outboundRules,omitempty"`
+	InboundRules       []InboundRule  `json:"inboundRules,omitempty"  yaml:"inboundRules,omitempty"`
+	OneToOneRules      []OneToOneRule `json:"oneToOneRules,omitempty" yaml:"oneToOneRules,omitempty"`
 }
 
-// Nat represents the complete NAT configuration, including outbound NAT rules and inbound port-forwarding rules.
+// Nat represents the complete NAT configuration, including outbound NAT rules, inbound
+// port-forwarding rules, and 1:1 (binat) mappings.
 type Nat struct {
-	Outbound Outbound      `xml:"outbound"     json:"outbound"          yaml:"outbound"`
-	Inbound  []InboundRule `xml:"inbound>rule" json:"inbound,omitempty" yaml:"inbound,omitempty"`
+	Outbound Outbound       `xml:"outbound"     json:"outbound"           yaml:"outbound"`
+	Inbound  []InboundRule  `xml:"inbound>rule" json:"inbound,omitempty"  yaml:"inbound,omitempty"`
+	OneToOne []OneToOneRule `xml:"onetoone"     json:"oneToOne,omitempty" yaml:"oneToOne,omitempty"`
 }
 
 // Outbound represents outbound NAT configuration, including the NAT mode
@@ -125,6 +131,8 @@ type NATRule struct {
 
 // InboundRule represents an inbound NAT rule (port forwarding). The InternalIP field specifies
 // the port-forward destination address; there is no Target field on InboundRule (unlike [NATRule]).
+// Priority is kept as the raw element text and parsed by the converter, so a malformed value
+// does not abort parsing of the whole document.
 type InboundRule struct {
 	XMLName          xml.Name      `xml:"rule"`
 	Interface        InterfaceList `xml:"interface,omitempty"          json:"interface,omitempty"        yaml:"interface,omitempty"`
@@ -139,7 +147,7 @@ type InboundRule struct {
 	Reflection       string        `xml:"reflection,omitempty"         json:"reflection,omitempty"       yaml:"reflection,omitempty"`
 	NATReflection    string        `xml:"natreflection,omitempty"      json:"natReflection,omitempty"    yaml:"natReflection,omitempty"`
 	AssociatedRuleID string        `xml:"associated-rule-id,omitempty" json:"associatedRuleID,omitempty" yaml:"associatedRuleID,omitempty"`
-	Priority         int           `xml:"priority,omitempty"           json:"priority,omitempty"         yaml:"priority,omitempty"`
+	Priority         string        `xml:"priority,omitempty"           json:"priority,omitempty"         yaml:"priority,omitempty"`
 	NoRDR            BoolFlag      `xml:"nordr,omitempty"              json:"noRDR,omitempty"            yaml:"noRDR,omitempty"`
 	NoSync           BoolFlag      `xml:"nosync,omitempty"             json:"noSync,omitempty"           yaml:"noSync,omitempty"`
 	Disabled         BoolFlag      `xml:"disabled,omitempty"           json:"disabled,omitempty"         yaml:"disabled,omitempty"`
@@ -150,6 +158,28 @@ type InboundRule struct {
 	UUID             string        `xml:"uuid,attr,omitempty"          json:"uuid,omitempty"             yaml:"uuid,omitempty"`
 }
 
+// OneToOneRule represents a 1:1 NAT (binat) entry from <nat><onetoone>. External is the
+// public address; the internal address or subnet is carried in Source. When Source is a
+// subnet, External is the first address of an equally sized external range. Type is
+// "binat" (bidirectional, the default) or "nat" (inbound translation only).
+type OneToOneRule struct {
+	XMLName       xml.Name      `xml:"onetoone"`
+	Interface     InterfaceList `xml:"interface,omitempty"     json:"interface,omitempty"     yaml:"interface,omitempty"`
+	IPProtocol    string        `xml:"ipprotocol,omitempty"    json:"ipProtocol,omitempty"    yaml:"ipProtocol,omitempty"`
+	Type          string        `xml:"type,omitempty"          json:"type,omitempty"          yaml:"type,omitempty"`
+	External      string        `xml:"external,omitempty"      json:"external,omitempty"      yaml:"external,omitempty"`
+	Source        Source        `xml:"source"                  json:"source"                  yaml:"source"`
+	Destination   Destination   `xml:"destination"             json:"destination"             yaml:"destination"`
+	NATReflection string        `xml:"natreflection,omitempty" json:"natReflection,omitempty" yaml:"natReflection,omitempty"`
+	Disabled      BoolFlag      `xml:"disabled,omitempty"      json:"disabled,omitempty"      yaml:"disabled,omitempty"`
+	Log           BoolFlag      `xml:"log,omitempty"           json:"log,omitempty"           yaml:"log,omitempty"`
+	Descr         string        `xml:"descr,omitempty"         json:"description,omitempty"   yaml:"description,omitempty"`
+	Category      string        `xml:"category,omitempty"      json:"category,omitempty"      yaml:"category,omitempty"`
+	Updated       *Updated      `xml:"updated,omitempty"       json:"updated,omitempty"       yaml:"updated,omitempty"`
+	Created       *Created      `xml:"created,omitempty"       json:"created,omitempty"       yaml:"created,omitempty"`
+	UUID          string        `xml:"uuid,attr,omitempty"     json:"uuid,omitempty"          yaml:"uuid,omitempty"`
+}
+
 // Rule represents a firewall filter rule with full source/destination specification,
 // protocol matching, rate limiting, TCP flag filtering, and state tracking options.
 type Rule struct {
@@ -171,6 +201,8 @@ type Rule struct {
 	Log         BoolFlag      `xml:"log,omitempty"`
 	Disabled    BoolFlag      `xml:"disabled,omitempty"`
 	Tracker     string        `xml:"tracker,omitempty"`
+	// Sched names the <schedules> entry that limits when the rule is active.
+	Sched string `xml:"sched,omitempty"`
 	// Rate-limiting fields (DoS protection)
 	MaxSrcNodes     string `xml:"max-src-nodes,omitempty"`
 	MaxSrcConn      string `xml:"max-src-conn,omitempty"`
@@ -333,6 +365,27 @@ type Updated struct {
 	Description string `xml:"description"`
 }
 
+// Fields returns the username, time, and description of the modification,
+// or empty strings when u is nil.
+func (u *Updated) Fields() (username, time, description string) {
+	if u == nil {
+		return "", "", ""
+	}
+
+	return u.Username, u.Time, u.Description
+}
+
+// ParsedTime returns the modification time parsed with
+// [shared.ParseTimestamp]. It reports false when u is nil or its time is
+// empty or in an unrecognized format.
+func (u *Updated) ParsedTime() (time.Time, bool) {
+	if u == nil {
+		return time.Time{}, false
+	}
+
+	return shared.ParseTimestamp(u.Time)
+}
+
 // Created records the user, timestamp, and description from when a rule or configuration item was first created.
 type Created struct {
 	Username    string `xml:"username"`
@@ -340,15 +393,38 @@ type Created struct {
 	Description string `xml:"description"`
 }
 
+// Fields returns the username, time, and description of the creation, or
+// empty strings when c is nil.
+func (c *Created) Fields() (username, time, description string) {
+	if c == nil {
+		return "", "", ""
+	}
+
+	return c.Username, c.Time, c.Description
+}
+
+// ParsedTime returns the creation time parsed with [shared.ParseTimestamp].
+// It reports false when c is nil or its time is empty or in an unrecognized
+// format.
+func (c *Created) ParsedTime() (time.Time, bool) {
+	if c == nil {
+		return time.Time{}, false
+	}
+
+	return shared.ParseTimestamp(c.Time)
+}
+
 // Alias represents a single OPNsense firewall alias definition (a "named
 // object" in ADR-0002 terms), as it appears both under the MVC-model path
 // (<Firewall><Alias><aliases><alias>) and the legacy top-level path
 // (<aliases><alias>, see OpnSenseDocument.Aliases).
 //
-// Type is one of host|network|port|url|geoip|external per
-// common.NamedObjectType, plus vendor variants (e.g. urltable, networkgroup,
-// mac, dynipv6host, authgroup) that the converter treats as unrecognized and
-// warns on rather than silently dropping (GOTCHAS §5.2).
+// Type is one of host|network|port|url|urltable|geoip|external per
+// common.NamedObjectType, plus vendor variants (e.g. networkgroup, mac,
+// dynipv6host, authgroup) that the converter treats as unrecognized and
+// warns on rather than silently dropping (GOTCHAS §5.2). For url and
+// urltable aliases Content holds the source URLs; for geoip aliases it holds
+// the country codes, looked up in the database at Firewall.Alias.Geoip.URL.
 //
 // Content holds members newline-separated — the modern OPNsense MVC
 // convention (mirrors KeaSubnet.Pools, see GOTCHAS §18.2). Legacy configs
@@ -410,14 +486,14 @@ type Firewall struct {
 // IDS represents the complete Intrusion Detection System configuration,
 // including Suricata general settings, detection profiles, EVE logging, and syslog output.
 type IDS struct {
-	XMLName          xml.Name `xml:"IDS"`
-	Text             string   `xml:",chardata"        json:"text,omitempty"`
-	Version          string   `xml:"version,attr"     json:"version,omitempty"`
-	Rules            string   `xml:"rules"`
-	Policies         string   `xml:"policies"`
-	UserDefinedRules string   `xml:"userDefinedRules"`
-	Files            string   `xml:"files"`
-	FileTags         string   `xml:"fileTags"`
+	XMLName          xml.Name  `xml:"IDS"`
+	Text             string    `xml:",chardata"        json:"text,omitempty"`
+	Version          string    `xml:"version,attr"     json:"version,omitempty"`
+	Rules            string    `xml:"rules"`
+	Policies         IDSPolicy `xml:"policies"`
+	UserDefinedRules string    `xml:"userDefinedRules"`
+	Files            string    `xml:"files"`
+	FileTags         string    `xml:"fileTags"`
 	General          struct {
 		Text              string `xml:",chardata" json:"text,omitempty"`
 		Enabled           string `xml:"enabled"`
@@ -459,6 +535,34 @@ type IDS struct {
 	} `xml:"general"          json:"general"`
 }
 
+// IDSPolicy represents the <policies> container of the IDS configuration.
+// Each policy rewrites the action of the rules it matches.
+type IDSPolicy struct {
+	Text  string          `xml:",chardata" json:"text,omitempty"`
+	Items []IDSPolicyItem `xml:"policy"    json:"policies,omitempty"`
+}
+
+// IDSPolicyItem represents one <policy> entry. Rules in Rulesets whose current
+// action is one of Action and whose metadata matches Content get NewAction.
+type IDSPolicyItem struct {
+	UUID     string `xml:"uuid,attr,omitempty" json:"uuid,omitempty"`
+	Enabled  string `xml:"enabled"             json:"enabled,omitempty"`
+	Priority string `xml:"prio"                json:"priority,omitempty"`
+	// Action is the comma-separated list of current rule actions the policy
+	// matches (alert, drop).
+	Action string `xml:"action" json:"action,omitempty"`
+	// Rulesets is the comma-separated list of ruleset UUIDs the policy applies
+	// to. Empty means every installed ruleset.
+	Rulesets string `xml:"rulesets" json:"rulesets,omitempty"`
+	// Content is the comma-separated list of rule metadata filters, e.g.
+	// "signature_severity.Major,affected_product.Any".
+	Content string `xml:"content" json:"content,omitempty"`
+	// NewAction is the action applied to matched rules: default (keep the
+	// rule's action), alert, drop, or disable.
+	NewAction   string `xml:"new_action"  json:"newAction,omitempty"`
+	Description string `xml:"description" json:"description,omitempty"`
+}
+
 // IPsec represents the OPNsense MVC-based IPsec VPN configuration, including
 // general settings, strongSwan charon daemon tuning, key pairs, and pre-shared keys.
 type IPsec struct {
@@ -517,21 +621,6 @@ type IPsec struct {
 	PreSharedKeys string `xml:"preSharedKeys"`
 }
 
-// Swanctl represents the StrongSwan swanctl configuration, including connections,
-// local/remote authentication, child SAs, address pools, VTIs, and SPD entries.
-type Swanctl struct {
-	XMLName     xml.Name `xml:"Swanctl"`
-	Text        string   `xml:",chardata"    json:"text,omitempty"`
-	Version     string   `xml:"version,attr" json:"version,omitempty"`
-	Connections string   `xml:"Connections"`
-	Locals      string   `xml:"locals"`
-	Remotes     string   `xml:"remotes"`
-	Children    string   `xml:"children"`
-	Pools       string   `xml:"Pools"`
-	VTIs        string   `xml:"VTIs"`
-	SPDs        string   `xml:"SPDs"`
-}
-
 // NewIDS returns a pointer to a new [IDS] configuration with zero-value defaults.
 func NewIDS() *IDS {
 	return &IDS{}
@@ -549,6 +638,22 @@ func (ids *IDS) IsIPSMode() bool {
 	return ids != nil && ids.General.Ips == "1"
 }
 
+// EnabledPolicies returns the enabled IDS policies in configuration order.
+func (ids *IDS) EnabledPolicies() []IDSPolicyItem {
+	if ids == nil {
+		return nil
+	}
+
+	var enabled []IDSPolicyItem
+	for _, policy := range ids.Policies.Items {
+		if policy.Enabled == "1" {
+			enabled = append(enabled, policy)
+		}
+	}
+
+	return enabled
+}
+
 // GetMonitoredInterfaces parses the comma-separated interfaces string and returns a slice.
 func (ids *IDS) GetMonitoredInterfaces() []string {
 	if ids == nil {
@@ -4,6 +4,9 @@ import (
 	"encoding/xml"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/EvilBit-Labs/opnDossier/internal/schema/testfixtures"
 )
 
 // Test constants for commonly repeated string literals.
@@ -638,6 +641,72 @@ func TestIDS_IsPromiscuousMode(t *testing.T) {
 	}
 }
 
+func TestIDS_EnabledPolicies(t *testing.T) {
+	t.Parallel()
+
+	var nilIDS *IDS
+	if got := nilIDS.EnabledPolicies(); got != nil {
+		t.Errorf("nil IDS EnabledPolicies() = %v, want nil", got)
+	}
+
+	ids := newTestIDs(func(ids *IDS) {
+		ids.Policies.Items = []IDSPolicyItem{
+			{UUID: "a", Enabled: "1", NewAction: "drop"},
+			{UUID: "b", Enabled: "0", NewAction: "alert"},
+			{UUID: "c", Enabled: "1", NewAction: "alert"},
+		}
+	})
+
+	got := ids.EnabledPolicies()
+	if len(got) != 2 || got[0].UUID != "a" || got[1].UUID != "c" {
+		t.Errorf("EnabledPolicies() = %+v, want policies a and c", got)
+	}
+}
+
+// TestIDS_PoliciesXMLRoundTrip verifies that <policies><policy> entries are
+// parsed and written back with every field.
+func TestIDS_PoliciesXMLRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	xmlData := `<IDS version="1.0.9"><policies><policy uuid="p-1"><enabled>1</enabled><prio>10</prio>` +
+		`<action>alert,drop</action><rulesets>rs-1,rs-2</rulesets><content>signature_severity.Major</content>` +
+		`<new_action>drop</new_action><description>Block major</description></policy></policies></IDS>`
+	want := IDSPolicyItem{
+		UUID:        "p-1",
+		Enabled:     "1",
+		Priority:    "10",
+		Action:      "alert,drop",
+		Rulesets:    "rs-1,rs-2",
+		Content:     "signature_severity.Major",
+		NewAction:   "drop",
+		Description: "Block major",
+	}
+
+	var ids IDS
+	if err := xml.Unmarshal([]byte(xmlData), &ids); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if len(ids.Policies.Items) != 1 || ids.Policies.Items[0] != want {
+		t.Fatalf("Policies.Items = %+v, want [%+v]", ids.Policies.Items, want)
+	}
+
+	data, err := xml.Marshal(&ids)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	var out IDS
+	if err := xml.Unmarshal(data, &out); err != nil {
+		t.Fatalf("unmarshal round-trip: %v", err)
+	}
+	if len(out.Policies.Items) != 1 || out.Policies.Items[0] != want {
+		t.Errorf("round-tripped Policies.Items = %+v, want [%+v]", out.Policies.Items, want)
+	}
+	if !strings.Contains(string(data), `<policy uuid="p-1">`) {
+		t.Errorf("marshaled XML missing policy uuid attribute: %s", data)
+	}
+}
+
 //nolint:dupl // Source/Destination XMLRoundTrip tests are structurally similar by design
 func TestSource_XMLRoundTrip(t *testing.T) {
 	t.Parallel()
@@ -650,43 +719,43 @@ func TestSource_XMLRoundTrip(t *testing.T) {
 	}{
 		{
 			name:         "any self-closing",
-			xml:          `<source><any/></source>`,
+			xml:          testfixtures.NewSource().WithAny().Build(),
 			want:         Source{Any: new("")},
 			wantElements: []string{"<any>"},
 		},
 		{
 			name:         "network only",
-			xml:          `<source><network>lan</network></source>`,
+			xml:          testfixtures.NewSource().WithNetwork("lan").Build(),
 			want:         Source{Network: "lan"},
 			wantElements: []string{"<network>lan</network>"},
 		},
 		{
 			name:         "address IP/CIDR",
-			xml:          `<source><address>192.168.1.0/24</address></source>`,
+			xml:          testfixtures.NewSource().WithAddress("192.168.1.0/24").Build(),
 			want:         Source{Address: "192.168.1.0/24"},
 			wantElements: []string{"<address>192.168.1.0/24</address>"},
 		},
 		{
 			name:         "address alias",
-			xml:          `<source><address>MyAlias</address></source>`,
+			xml:          testfixtures.NewSource().WithAddress("MyAlias").Build(),
 			want:         Source{Address: "MyAlias"},
 			wantElements: []string{"<address>MyAlias</address>"},
 		},
 		{
 			name:         "negated network",
-			xml:          `<source><not/><network>lan</network></source>`,
+			xml:          testfixtures.NewSource().WithNot().WithNetwork("lan").Build(),
 			want:         Source{Network: "lan", Not: BoolFlag(true)},
 			wantElements: []string{"<not>", "<network>lan</network>"},
 		},
 		{
 			name:         "network with port",
-			xml:          `<source><network>lan</network><port>8080</port></source>`,
+			xml:          testfixtures.NewSource().WithNetwork("lan").WithPort("8080").Build(),
 			want:         Source{Network: "lan", Port: "8080"},
 			wantElements: []string{"<network>lan</network>", "<port>8080</port>"},
 		},
 		{
 			name:         "negated address with port",
-			xml:          `<source><not/><address>10.0.0.0/8</address><port>22</port></source>`,
+			xml:          testfixtures.NewSource().WithNot().WithAddress("10.0.0.0/8").WithPort("22").Build(),
 			want:         Source{Address: "10.0.0.0/8", Port: "22", Not: BoolFlag(true)},
 			wantElements: []string{"<not>", "<address>10.0.0.0/8</address>", "<port>22</port>"},
 		},
@@ -743,37 +812,37 @@ func TestDestination_XMLRoundTrip(t *testing.T) {
 	}{
 		{
 			name:         "any self-closing",
-			xml:          `<destination><any/></destination>`,
+			xml:          testfixtures.NewDestination().WithAny().Build(),
 			want:         Destination{Any: new("")},
 			wantElements: []string{"<any>"},
 		},
 		{
 			name:         "network with port",
-			xml:          `<destination><network>wan</network><port>443</port></destination>`,
+			xml:          testfixtures.NewDestination().WithNetwork("wan").WithPort("443").Build(),
 			want:         Destination{Network: "wan", Port: "443"},
 			wantElements: []string{"<network>wan</network>", "<port>443</port>"},
 		},
 		{
 			name:         "address IP/CIDR",
-			xml:          `<destination><address>10.0.0.1</address></destination>`,
+			xml:          testfixtures.NewDestination().WithAddress("10.0.0.1").Build(),
 			want:         Destination{Address: "10.0.0.1"},
 			wantElements: []string{"<address>10.0.0.1</address>"},
 		},
 		{
 			name:         "any with port range",
-			xml:          `<destination><any/><port>8000-9000</port></destination>`,
+			xml:          testfixtures.NewDestination().WithAny().WithPort("8000-9000").Build(),
 			want:         Destination{Any: new(""), Port: "8000-9000"},
 			wantElements: []string{"<any>", "<port>8000-9000</port>"},
 		},
 		{
 			name:         "negated network with port",
-			xml:          `<destination><not/><network>lan</network><port>22</port></destination>`,
+			xml:          testfixtures.NewDestination().WithNot().WithNetwork("lan").WithPort("22").Build(),
 			want:         Destination{Network: "lan", Port: "22", Not: BoolFlag(true)},
 			wantElements: []string{"<not>", "<network>lan</network>", "<port>22</port>"},
 		},
 		{
 			name:         "address alias",
-			xml:          `<destination><address>WebServers</address><port>80</port></destination>`,
+			xml:          testfixtures.NewDestination().WithAddress("WebServers").WithPort("80").Build(),
 			want:         Destination{Address: "WebServers", Port: "80"},
 			wantElements: []string{"<address>WebServers</address>", "<port>80</port>"},
 		},
@@ -830,28 +899,28 @@ func TestRule_BoolFlagFields_XMLRoundTrip(t *testing.T) {
 	}{
 		{
 			name:         "all presence flags set",
-			xml:          `<rule><disabled/><quick/><log/></rule>`,
+			xml:          testfixtures.NewRule().WithDisabled().WithQuick().WithLog().Build(),
 			wantDisabled: true,
 			wantQuick:    true,
 			wantLog:      true,
 		},
 		{
 			name:         "no presence flags",
-			xml:          `<rule></rule>`,
+			xml:          testfixtures.NewRule().Build(),
 			wantDisabled: false,
 			wantQuick:    false,
 			wantLog:      false,
 		},
 		{
 			name:         "only disabled",
-			xml:          `<rule><disabled/></rule>`,
+			xml:          testfixtures.NewRule().WithDisabled().Build(),
 			wantDisabled: true,
 			wantQuick:    false,
 			wantLog:      false,
 		},
 		{
 			name:         "only log",
-			xml:          `<rule><log/></rule>`,
+			xml:          testfixtures.NewRule().WithLog().Build(),
 			wantDisabled: false,
 			wantQuick:    false,
 			wantLog:      true,
@@ -889,22 +958,26 @@ func TestRule_NewStringFields_XMLRoundTrip(t *testing.T) {
 		wantTracker  string
 	}{
 		{
-			name:         "all string fields set",
-			xml:          `<rule><floating>yes</floating><gateway>WAN_GW</gateway><tracker>12345</tracker></rule>`,
+			name: "all string fields set",
+			xml: testfixtures.NewRule().
+				WithFloating("yes").
+				WithGateway("WAN_GW").
+				WithTracker("12345").
+				Build(),
 			wantFloating: floatingYes,
 			wantGateway:  "WAN_GW",
 			wantTracker:  "12345",
 		},
 		{
 			name:         "no string fields",
-			xml:          `<rule></rule>`,
+			xml:          testfixtures.NewRule().Build(),
 			wantFloating: "",
 			wantGateway:  "",
 			wantTracker:  "",
 		},
 		{
 			name:         "only gateway",
-			xml:          `<rule><gateway>LAN_GW</gateway></rule>`,
+			xml:          testfixtures.NewRule().WithGateway("LAN_GW").Build(),
 			wantFloating: "",
 			wantGateway:  "LAN_GW",
 			wantTracker:  "",
@@ -943,79 +1016,79 @@ func TestRule_StateTypeAndDirection_XMLRoundTrip(t *testing.T) {
 	}{
 		{
 			name:          "keep state",
-			xml:           `<rule><statetype>keep state</statetype></rule>`,
+			xml:           testfixtures.NewRule().WithStateType("keep state").Build(),
 			wantStateType: "keep state",
 			wantDirection: "",
 		},
 		{
 			name:          "sloppy state",
-			xml:           `<rule><statetype>sloppy state</statetype></rule>`,
+			xml:           testfixtures.NewRule().WithStateType("sloppy state").Build(),
 			wantStateType: "sloppy state",
 			wantDirection: "",
 		},
 		{
 			name:          "synproxy state",
-			xml:           `<rule><statetype>synproxy state</statetype></rule>`,
+			xml:           testfixtures.NewRule().WithStateType("synproxy state").Build(),
 			wantStateType: "synproxy state",
 			wantDirection: "",
 		},
 		{
 			name:          "none state",
-			xml:           `<rule><statetype>none</statetype></rule>`,
+			xml:           testfixtures.NewRule().WithStateType("none").Build(),
 			wantStateType: "none",
 			wantDirection: "",
 		},
 		{
 			name:          "empty statetype",
-			xml:           `<rule><statetype></statetype></rule>`,
+			xml:           testfixtures.NewRule().WithStateType("").Build(),
 			wantStateType: "",
 			wantDirection: "",
 		},
 		{
 			name:          "direction in",
-			xml:           `<rule><direction>in</direction></rule>`,
+			xml:           testfixtures.NewRule().WithDirection("in").Build(),
 			wantStateType: "",
 			wantDirection: "in",
 		},
 		{
 			name:          "direction out",
-			xml:           `<rule><direction>out</direction></rule>`,
+			xml:           testfixtures.NewRule().WithDirection("out").Build(),
 			wantStateType: "",
 			wantDirection: "out",
 		},
 		{
 			name:          "direction any",
-			xml:           `<rule><direction>any</direction></rule>`,
+			xml:           testfixtures.NewRule().WithDirection("any").Build(),
 			wantStateType: "",
 			wantDirection: "any",
 		},
 		{
 			name:          "empty direction",
-			xml:           `<rule><direction></direction></rule>`,
+			xml:           testfixtures.NewRule().WithDirection("").Build(),
 			wantStateType: "",
 			wantDirection: "",
 		},
 		{
 			name:          "both fields present",
-			xml:           `<rule><statetype>keep state</statetype><direction>in</direction></rule>`,
+			xml:           testfixtures.NewRule().WithStateType("keep state").WithDirection("in").Build(),
 			wantStateType: "keep state",
 			wantDirection: "in",
 		},
 		{
 			name:          "statetype without direction",
-			xml:           `<rule><statetype>sloppy state</statetype></rule>`,
+			xml:           testfixtures.NewRule().WithStateType("sloppy state").Build(),
 			wantStateType: "sloppy state",
 			wantDirection: "",
 		},
 		{
 			name:          "direction without statetype",
-			xml:           `<rule><direction>out</direction></rule>`,
+			xml:           testfixtures.NewRule().WithDirection("out").Build(),
 			wantStateType: "",
 			wantDirection: "out",
 		},
 		{
 			name:          "neither field present",
-			xml:           `<rule></rule>`,
+			xml:           testfixtures.NewRule().Build(),
 			wantStateType: "",
 			wantDirection: "",
 		},
@@ -1071,8 +1144,13 @@ func TestRule_FloatingRules_XMLRoundTrip(t *testing.T) {
 		wantState    string
 	}{
 		{
-			name:         "floating in with gateway and quick",
-			xml:          `<rule><floating>yes</floating><direction>in</direction><gateway>WAN_GW</gateway><quick/></rule>`,
+			name: "floating in with gateway and quick",
+			xml: testfixtures.NewRule().
+				WithFloating("yes").
+				WithDirection("in").
+				WithGateway("WAN_GW").
+				WithQuick().
+				Build(),
 			wantFloating: floatingYes,
 			wantDir:      "in",
 			wantGateway:  "WAN_GW",
@@ -1080,29 +1158,39 @@ func TestRule_FloatingRules_XMLRoundTrip(t *testing.T) {
 		},
 		{
 			name:         "floating out no gateway",
-			xml:          `<rule><floating>yes</floating><direction>out</direction></rule>`,
+			xml:          testfixtures.NewRule().WithFloating("yes").WithDirection("out").Build(),
 			wantFloating: floatingYes,
 			wantDir:      "out",
 		},
 		{
-			name:         "floating any with gateway",
-			xml:          `<rule><floating>yes</floating><direction>any</direction><gateway>LAN_GW</gateway></rule>`,
+			name: "floating any with gateway",
+			xml: testfixtures.NewRule().
+				WithFloating("yes").
+				WithDirection("any").
+				WithGateway("LAN_GW").
+				Build(),
 			wantFloating: floatingYes,
 			wantDir:      "any",
 			wantGateway:  "LAN_GW",
 		},
 		{
 			name:         "non-floating with direction and gateway",
-			xml:          `<rule><direction>in</direction><gateway>WAN_GW</gateway></rule>`,
+			xml:          testfixtures.NewRule().WithDirection("in").WithGateway("WAN_GW").Build(),
 			wantFloating: "",
 			wantDir:      "in",
 			wantGateway:  "WAN_GW",
 		},
 		{
 			name: "floating with all optional fields",
-			xml: `<rule><floating>yes</floating><direction>in</direction>` +
-				`<gateway>WAN_GW</gateway><tracker>98765</tracker>` +
-				`<statetype>keep state</statetype><quick/><log/></rule>`,
+			xml: testfixtures.NewRule().
+				WithFloating("yes").
+				WithDirection("in").
+				WithGateway("WAN_GW").
+				WithTracker("98765").
+				WithStateType("keep state").
+				WithQuick().
+				WithLog().
+				Build(),
 			wantFloating: floatingYes,
 			wantDir:      "in",
 			wantGateway:  "WAN_GW",
@@ -1189,12 +1277,12 @@ func TestRule_RateLimitingFields_XMLRoundTrip(t *testing.T) {
 	}{
 		{
 			name: "all rate-limiting fields set",
-			xml: `<rule>` +
-				`<max-src-nodes>100</max-src-nodes>` +
-				`<max-src-conn>50</max-src-conn>` +
-				`<max-src-conn-rate>10/second</max-src-conn-rate>` +
-				`<max-src-conn-rates>15</max-src-conn-rates>` +
-				`</rule>`,
+			xml: testfixtures.NewRule().
+				WithMaxSrcNodes("100").
+				WithMaxSrcConn("50").
+				WithMaxSrcConnRate("10/second").
+				WithMaxSrcConnRates("15").
+				Build(),
 			wantMaxSrcNodes:     "100",
 			wantMaxSrcConn:      "50",
 			wantMaxSrcConnRate:  "10/second",
@@ -1202,7 +1290,7 @@ func TestRule_RateLimitingFields_XMLRoundTrip(t *testing.T) {
 		},
 		{
 			name:                "no rate-limiting fields",
-			xml:                 `<rule></rule>`,
+			xml:                 testfixtures.NewRule().Build(),
 			wantMaxSrcNodes:     "",
 			wantMaxSrcConn:      "",
 			wantMaxSrcConnRate:  "",
@@ -1210,7 +1298,7 @@ func TestRule_RateLimitingFields_XMLRoundTrip(t *testing.T) {
 		},
 		{
 			name:                "only max-src-nodes",
-			xml:                 `<rule><max-src-nodes>200</max-src-nodes></rule>`,
+			xml:                 testfixtures.NewRule().WithMaxSrcNodes("200").Build(),
 			wantMaxSrcNodes:     "200",
 			wantMaxSrcConn:      "",
 			wantMaxSrcConnRate:  "",
@@ -1218,7 +1306,7 @@ func TestRule_RateLimitingFields_XMLRoundTrip(t *testing.T) {
 		},
 		{
 			name:                "only max-src-conn",
-			xml:                 `<rule><max-src-conn>25</max-src-conn></rule>`,
+			xml:                 testfixtures.NewRule().WithMaxSrcConn("25").Build(),
 			wantMaxSrcNodes:     "",
 			wantMaxSrcConn:      "25",
 			wantMaxSrcConnRate:  "",
@@ -1226,7 +1314,7 @@ func TestRule_RateLimitingFields_XMLRoundTrip(t *testing.T) {
 		},
 		{
 			name:                "only max-src-conn-rate",
-			xml:                 `<rule><max-src-conn-rate>5/second</max-src-conn-rate></rule>`,
+			xml:                 testfixtures.NewRule().WithMaxSrcConnRate("5/second").Build(),
 			wantMaxSrcNodes:     "",
 			wantMaxSrcConn:      "",
 			wantMaxSrcConnRate:  "5/second",
@@ -1296,35 +1384,39 @@ func TestRule_TCPFlags_XMLRoundTrip(t *testing.T) {
 	}{
 		{
 			name:            "flags1 and flags2 set",
-			xml:             `<rule><tcpflags1>S/SA</tcpflags1><tcpflags2>FSRPAUEW</tcpflags2></rule>`,
+			xml:             testfixtures.NewRule().WithTCPFlags1("S/SA").WithTCPFlags2("FSRPAUEW").Build(),
 			wantTCPFlags1:   "S/SA",
 			wantTCPFlags2:   "FSRPAUEW",
 			wantTCPFlagsAny: false,
 		},
 		{
 			name:            "tcpflags_any set",
-			xml:             `<rule><tcpflags_any/></rule>`,
+			xml:             testfixtures.NewRule().WithTCPFlagsAny().Build(),
 			wantTCPFlags1:   "",
 			wantTCPFlags2:   "",
 			wantTCPFlagsAny: true,
 		},
 		{
-			name:            "all TCP flag fields set",
-			xml:             `<rule><tcpflags1>SA</tcpflags1><tcpflags2>SA</tcpflags2><tcpflags_any/></rule>`,
+			name: "all TCP flag fields set",
+			xml: testfixtures.NewRule().
+				WithTCPFlags1("SA").
+				WithTCPFlags2("SA").
+				WithTCPFlagsAny().
+				Build(),
 			wantTCPFlags1:   "SA",
 			wantTCPFlags2:   "SA",
 			wantTCPFlagsAny: true,
 		},
 		{
 			name:            "no TCP flag fields",
-			xml:             `<rule></rule>`,
+			xml:             testfixtures.NewRule().Build(),
 			wantTCPFlags1:   "",
 			wantTCPFlags2:   "",
 			wantTCPFlagsAny: false,
 		},
 		{
 			name:            "only tcpflags1",
-			xml:             `<rule><tcpflags1>S</tcpflags1></rule>`,
+			xml:             testfixtures.NewRule().WithTCPFlags1("S").Build(),
 			wantTCPFlags1:   "S",
 			wantTCPFlags2:   "",
 			wantTCPFlagsAny: false,
@@ -1383,37 +1475,37 @@ func TestRule_ICMPTypes_XMLRoundTrip(t *testing.T) {
 	}{
 		{
 			name:          "single ICMP type",
-			xml:           `<rule><icmptype>8</icmptype></rule>`,
+			xml:           testfixtures.NewRule().WithICMPType("8").Build(),
 			wantICMPType:  "8",
 			wantICMP6Type: "",
 		},
 		{
 			name:          "comma-separated ICMP types",
-			xml:           `<rule><icmptype>3,11,0</icmptype></rule>`,
+			xml:           testfixtures.NewRule().WithICMPType("3,11,0").Build(),
 			wantICMPType:  "3,11,0",
 			wantICMP6Type: "",
 		},
 		{
 			name:          "ICMPv6 type",
-			xml:           `<rule><icmp6-type>128</icmp6-type></rule>`,
+			xml:           testfixtures.NewRule().WithICMP6Type("128").Build(),
 			wantICMPType:  "",
 			wantICMP6Type: "128",
 		},
 		{
 			name:          "both ICMP and ICMPv6 types",
-			xml:           `<rule><icmptype>8</icmptype><icmp6-type>128</icmp6-type></rule>`,
+			xml:           testfixtures.NewRule().WithICMPType("8").WithICMP6Type("128").Build(),
 			wantICMPType:  "8",
 			wantICMP6Type: "128",
 		},
 		{
 			name:          "no ICMP fields",
-			xml:           `<rule></rule>`,
+			xml:           testfixtures.NewRule().Build(),
 			wantICMPType:  "",
 			wantICMP6Type: "",
 		},
 		{
 			name:          "multiple ICMPv6 types",
-			xml:           `<rule><icmp6-type>128,129,1</icmp6-type></rule>`,
+			xml:           testfixtures.NewRule().WithICMP6Type("128,129,1").Build(),
 			wantICMPType:  "",
 			wantICMP6Type: "128,129,1",
 		},
@@ -1468,32 +1560,37 @@ func TestRule_StateAndAdvancedFields_XMLRoundTrip(t *testing.T) {
 	}{
 		{
 			name:             "state timeout only",
-			xml:              `<rule><statetimeout>3600</statetimeout></rule>`,
+			xml:              testfixtures.NewRule().WithStateTimeout("3600").Build(),
 			wantStateTimeout: "3600",
 		},
 		{
 			name:          "allowopts only",
-			xml:           `<rule><allowopts/></rule>`,
+			xml:           testfixtures.NewRule().WithAllowOpts().Build(),
 			wantAllowOpts: true,
 		},
 		{
 			name:               "disablereplyto only",
-			xml:                `<rule><disablereplyto/></rule>`,
+			xml:                testfixtures.NewRule().WithDisableReplyTo().Build(),
 			wantDisableReplyTo: true,
 		},
 		{
 			name:         "nopfsync only",
-			xml:          `<rule><nopfsync/></rule>`,
+			xml:          testfixtures.NewRule().WithNoPfSync().Build(),
 			wantNoPfSync: true,
 		},
 		{
 			name:       "nosync only",
-			xml:        `<rule><nosync/></rule>`,
+			xml:        testfixtures.NewRule().WithNoSync().Build(),
 			wantNoSync: true,
 		},
 		{
-			name:               "all BoolFlag fields set",
-			xml:                `<rule><allowopts/><disablereplyto/><nopfsync/><nosync/></rule>`,
+			name: "all BoolFlag fields set",
+			xml: testfixtures.NewRule().
+				WithAllowOpts().
+				WithDisableReplyTo().
+				WithNoPfSync().
+				WithNoSync().
+				Build(),
 			wantAllowOpts:      true,
 			wantDisableReplyTo: true,
 			wantNoPfSync:       true,
@@ -1501,13 +1598,13 @@ func TestRule_StateAndAdvancedFields_XMLRoundTrip(t *testing.T) {
 		},
 		{
 			name: "all state and advanced fields set",
-			xml: `<rule>` +
-				`<statetimeout>86400</statetimeout>` +
-				`<allowopts/>` +
-				`<disablereplyto/>` +
-				`<nopfsync/>` +
-				`<nosync/>` +
-				`</rule>`,
+			xml: testfixtures.NewRule().
+				WithStateTimeout("86400").
+				WithAllowOpts().
+				WithDisableReplyTo().
+				WithNoPfSync().
+				WithNoSync().
+				Build(),
 			wantStateTimeout:   "86400",
 			wantAllowOpts:      true,
 			wantDisableReplyTo: true,
@@ -1516,7 +1613,7 @@ func TestRule_StateAndAdvancedFields_XMLRoundTrip(t *testing.T) {
 		},
 		{
 			name: "no state or advanced fields",
-			xml:  `<rule></rule>`,
+			xml:  testfixtures.NewRule().Build(),
 		},
 	}
 
@@ -1581,18 +1678,26 @@ func TestRule_BackwardCompatibility_MissingFields(t *testing.T) {
 	}{
 		{
 			name: "minimal rule with any source and destination",
-			xml:  `<rule><type>pass</type><source><any/></source><destination><any/></destination></rule>`,
+			xml: testfixtures.NewRule().
+				WithType("pass").
+				WithSource(testfixtures.NewSource().WithAny()).
+				WithDestination(testfixtures.NewDestination().WithAny()).
+				Build(),
 		},
 		{
 			name: "legacy rule without new fields",
-			xml: `<rule><type>pass</type><interface>wan</interface>` +
-				`<ipprotocol>inet</ipprotocol><protocol>tcp</protocol>` +
-				`<source><network>lan</network></source>` +
-				`<destination><network>wan</network><port>443</port></destination></rule>`,
+			xml: testfixtures.NewRule().
+				WithType("pass").
+				WithInterface("wan").
+				WithIPProtocol("inet").
+				WithProtocol("tcp").
+				WithSource(testfixtures.NewSource().WithNetwork("lan")).
+				WithDestination(testfixtures.NewDestination().WithNetwork("wan").WithPort("443")).
+				Build(),
 		},
 		{
 			name: "rule with only type",
-			xml:  `<rule><type>block</type></rule>`,
+			xml:  testfixtures.NewRule().WithType("block").Build(),
 		},
 	}
 
@@ -1672,30 +1777,42 @@ func TestRule_BackwardCompatibility_EffectiveAddress(t *testing.T) {
 		wantDstEA string
 	}{
 		{
-			name:      "any source and destination",
-			xml:       `<rule><type>pass</type><source><any/></source><destination><any/></destination></rule>`,
+			name: "any source and destination",
+			xml: testfixtures.NewRule().
+				WithType("pass").
+				WithSource(testfixtures.NewSource().WithAny()).
+				WithDestination(testfixtures.NewDestination().WithAny()).
+				Build(),
 			wantSrcEA: "any",
 			wantDstEA: "any",
 		},
 		{
 			name: "network source and destination with port",
-			xml: `<rule><type>pass</type>` +
-				`<source><network>lan</network></source>` +
-				`<destination><network>wan</network><port>443</port></destination></rule>`,
+			xml: testfixtures.NewRule().
+				WithType("pass").
+				WithSource(testfixtures.NewSource().WithNetwork("lan")).
+				WithDestination(testfixtures.NewDestination().WithNetwork("wan").WithPort("443")).
+				Build(),
 			wantSrcEA: "lan",
 			wantDstEA: "wan",
 		},
 		{
 			name: "address source and destination",
-			xml: `<rule><type>pass</type>` +
-				`<source><address>192.168.1.0/24</address></source>` +
-				`<destination><address>10.0.0.1</address></destination></rule>`,
+			xml: testfixtures.NewRule().
+				WithType("pass").
+				WithSource(testfixtures.NewSource().WithAddress("192.168.1.0/24")).
+				WithDestination(testfixtures.NewDestination().WithAddress("10.0.0.1")).
+				Build(),
 			wantSrcEA: "192.168.1.0/24",
 			wantDstEA: "10.0.0.1",
 		},
 		{
-			name:      "empty source and destination",
-			xml:       `<rule><type>block</type><source></source><destination></destination></rule>`,
+			name: "empty source and destination",
+			xml: testfixtures.NewRule().
+				WithType("block").
+				WithSource(testfixtures.NewSource()).
+				WithDestination(testfixtures.NewDestination()).
+				Build(),
 			wantSrcEA: "",
 			wantDstEA: "",
 		},
@@ -1853,42 +1970,41 @@ func verifyCompleteRuleFields(t *testing.T, got Rule, prefix string) {
 func TestRule_CompleteRule_XMLRoundTrip(t *testing.T) {
 	t.Parallel()
 
-	inputXML := `<rule uuid="a1b2c3d4-e5f6-7890-abcd-ef1234567890">` +
-		`<type>pass</type>` +
-		`<descr>Allow HTTPS from LAN to WAN</descr>` +
-		`<interface>lan,wan</interface>` +
-		`<ipprotocol>inet</ipprotocol>` +
-		`<statetype>keep state</statetype>` +
-		`<direction>in</direction>` +
-		`<floating>yes</floating>` +
-		`<quick/>` +
-		`<protocol>tcp</protocol>` +
-		`<source><network>lan</network><port>1024-65535</port></source>` +
-		`<destination><any/><port>443</port></destination>` +
-		`<target>10.0.0.1</target>` +
-		`<gateway>WAN_GW</gateway>` +
-		`<sourceport>1024-65535</sourceport>` +
-		`<log/>` +
-		`<tracker>1234567890</tracker>` +
-		`<max-src-nodes>100</max-src-nodes>` +
-		`<max-src-conn>50</max-src-conn>` +
-		`<max-src-conn-rate>10/second</max-src-conn-rate>` +
-		`<max-src-conn-rates>15</max-src-conn-rates>` +
-		`<tcpflags1>S/SA</tcpflags1>` +
-		`<tcpflags2>FSRPAUEW</tcpflags2>` +
-		`<tcpflags_any/>` +
-		`<icmptype>3,11,0</icmptype>` +
-		`<icmp6-type>128</icmp6-type>` +
-		`<statetimeout>3600</statetimeout>` +
-		`<allowopts/>` +
-		`<disablereplyto/>` +
-		`<nopfsync/>` +
-		`<nosync/>` +
-		`<updated><username>admin@192.168.1.1</username><time>1700000000</time>` +
-		`<description>/firewall_rules_edit.php made changes</description></updated>` +
-		`<created><username>admin@192.168.1.1</username><time>1699000000</time>` +
-		`<description>/firewall_rules_edit.php made changes</description></created>` +
-		`</rule>`
+	inputXML := testfixtures.NewRule().
+		WithUUID("a1b2c3d4-e5f6-7890-abcd-ef1234567890").
+		WithType("pass").
+		WithDescr("Allow HTTPS from LAN to WAN").
+		WithInterface("lan,wan").
+		WithIPProtocol("inet").
+		WithStateType("keep state").
+		WithDirection("in").
+		WithFloating("yes").
+		WithQuick().
+		WithProtocol("tcp").
+		WithSource(testfixtures.NewSource().WithNetwork("lan").WithPort("1024-65535")).
+		WithDestination(testfixtures.NewDestination().WithAny().WithPort("443")).
+		WithTarget("10.0.0.1").
+		WithGateway("WAN_GW").
+		WithSourcePort("1024-65535").
+		WithLog().
+		WithTracker("1234567890").
+		WithMaxSrcNodes("100").
+		WithMaxSrcConn("50").
+		WithMaxSrcConnRate("10/second").
+		WithMaxSrcConnRates("15").
+		WithTCPFlags1("S/SA").
+		WithTCPFlags2("FSRPAUEW").
+		WithTCPFlagsAny().
+		WithICMPType("3,11,0").
+		WithICMP6Type("128").
+		WithStateTimeout("3600").
+		WithAllowOpts().
+		WithDisableReplyTo().
+		WithNoPfSync().
+		WithNoSync().
+		WithUpdated("admin@192.168.1.1", "1700000000", "/firewall_rules_edit.php made changes").
+		WithCreated("admin@192.168.1.1", "1699000000", "/firewall_rules_edit.php made changes").
+		Build()
 
 	var got Rule
 	if err := xml.Unmarshal([]byte(inputXML), &got); err != nil {
@@ -1927,13 +2043,13 @@ func TestNATRule_BoolFlagFields_XMLRoundTrip(t *testing.T) {
 	}{
 		{
 			name:         "both flags set",
-			xml:          `<rule><disabled/><log/></rule>`,
+			xml:          testfixtures.NewNATRule().WithDisabled().WithLog().Build(),
 			wantDisabled: true,
 			wantLog:      true,
 		},
 		{
 			name:         "no flags",
-			xml:          `<rule></rule>`,
+			xml:          testfixtures.NewNATRule().Build(),
 			wantDisabled: false,
 			wantLog:      false,
 		},
@@ -1970,47 +2086,54 @@ func TestNATRule_NewFields_XMLRoundTrip(t *testing.T) {
 	}{
 		{
 			name:              "all BoolFlag fields present",
-			xml:               `<rule><staticnatport/><nonat/></rule>`,
+			xml:               testfixtures.NewNATRule().WithStaticNatPort().WithNoNat().Build(),
 			wantStaticNatPort: true,
 			wantNoNat:         true,
 		},
 		{
 			name:              "no BoolFlag fields",
-			xml:               `<rule></rule>`,
+			xml:               testfixtures.NewNATRule().Build(),
 			wantStaticNatPort: false,
 			wantNoNat:         false,
 		},
 		{
-			name:               "string fields with values",
-			xml:                `<rule><natport>8080-8090</natport><poolopts_sourcehashkey>key123</poolopts_sourcehashkey></rule>`,
+			name: "string fields with values",
+			xml: testfixtures.NewNATRule().
+				WithNatPort("8080-8090").
+				WithPoolOptsSrcHashKey("key123").
+				Build(),
 			wantNatPort:        "8080-8090",
 			wantPoolOptsHshKey: "key123",
 		},
 		{
-			name:               "mixed BoolFlag and string fields",
-			xml:                `<rule><staticnatport/><natport>443</natport><poolopts_sourcehashkey>abc</poolopts_sourcehashkey></rule>`,
+			name: "mixed BoolFlag and string fields",
+			xml: testfixtures.NewNATRule().
+				WithStaticNatPort().
+				WithNatPort("443").
+				WithPoolOptsSrcHashKey("abc").
+				Build(),
 			wantStaticNatPort:  true,
 			wantNatPort:        "443",
 			wantPoolOptsHshKey: "abc",
 		},
 		{
 			name:              "only staticnatport",
-			xml:               `<rule><staticnatport/></rule>`,
+			xml:               testfixtures.NewNATRule().WithStaticNatPort().Build(),
 			wantStaticNatPort: true,
 		},
 		{
 			name:      "only nonat",
-			xml:       `<rule><nonat/></rule>`,
+			xml:       testfixtures.NewNATRule().WithNoNat().Build(),
 			wantNoNat: true,
 		},
 		{
 			name:        "only natport",
-			xml:         `<rule><natport>5000</natport></rule>`,
+			xml:         testfixtures.NewNATRule().WithNatPort("5000").Build(),
 			wantNatPort: "5000",
 		},
 		{
 			name:               "self-closing poolopts_sourcehashkey",
-			xml:                `<rule><poolopts_sourcehashkey/></rule>`,
+			xml:                `<rule><poolopts_sourcehashkey/></rule>`, // literal: builders close text elements
 			wantPoolOptsHshKey: "",
 		},
 	}
@@ -2069,30 +2192,29 @@ func TestNATRule_NewFields_XMLRoundTrip(t *testing.T) {
 func TestNATRule_CompleteWithNewFields_XMLRoundTrip(t *testing.T) {
 	t.Parallel()
 
-	inputXML := `<rule uuid="nat-uuid-1234">` +
-		`<interface>wan</interface>` +
-		`<ipprotocol>inet</ipprotocol>` +
-		`<protocol>tcp</protocol>` +
-		`<source><any/></source>` +
-		`<destination><network>wan</network><port>443</port></destination>` +
-		`<target>192.168.1.100</target>` +
-		`<sourceport>1024-65535</sourceport>` +
-		`<natport>8443</natport>` +
-		`<poolopts>round-robin</poolopts>` +
-		`<poolopts_sourcehashkey>hashkey99</poolopts_sourcehashkey>` +
-		`<staticnatport/>` +
-		`<nonat/>` +
-		`<disabled/>` +
-		`<log/>` +
-		`<descr>Complete NAT rule test</descr>` +
-		`<category>test</category>` +
-		`<tag>mytag</tag>` +
-		`<tagged>mytagged</tagged>` +
-		`<updated><username>admin@10.0.0.1</username><time>1700000000</time>` +
-		`<description>test update</description></updated>` +
-		`<created><username>admin@10.0.0.1</username><time>1699000000</time>` +
-		`<description>test create</description></created>` +
-		`</rule>`
+	inputXML := testfixtures.NewNATRule().
+		WithUUID("nat-uuid-1234").
+		WithInterface("wan").
+		WithIPProtocol("inet").
+		WithProtocol("tcp").
+		WithSource(testfixtures.NewSource().WithAny()).
+		WithDestination(testfixtures.NewDestination().WithNetwork("wan").WithPort("443")).
+		WithTarget("192.168.1.100").
+		WithSourcePort("1024-65535").
+		WithNatPort("8443").
+		WithPoolOpts("round-robin").
+		WithPoolOptsSrcHashKey("hashkey99").
+		WithStaticNatPort().
+		WithNoNat().
+		WithDisabled().
+		WithLog().
+		WithDescr("Complete NAT rule test").
+		WithCategory("test").
+		WithTag("mytag").
+		WithTagged("mytagged").
+		WithUpdated("admin@10.0.0.1", "1700000000", "test update").
+		WithCreated("admin@10.0.0.1", "1699000000", "test create").
+		Build()
 
 	var got NATRule
 	if err := xml.Unmarshal([]byte(inputXML), &got); err != nil {
@@ -2202,16 +2324,22 @@ func TestNATRule_BackwardCompatibility(t *testing.T) {
 	}{
 		{
 			name: "minimal NAT rule",
-			xml:  `<rule><source><any/></source><destination><any/></destination></rule>`,
+			xml: testfixtures.NewNATRule().
+				WithSource(testfixtures.NewSource().WithAny()).
+				WithDestination(testfixtures.NewDestination().WithAny()).
+				Build(),
 		},
 		{
 			name: "legacy NAT rule without new fields",
-			xml: `<rule><interface>wan</interface>` +
-				`<ipprotocol>inet</ipprotocol><protocol>tcp</protocol>` +
-				`<source><network>lan</network></source>` +
-				`<destination><network>wan</network><port>443</port></destination>` +
-				`<target>192.168.1.10</target>` +
-				`<poolopts>round-robin</poolopts></rule>`,
+			xml: testfixtures.NewNATRule().
+				WithInterface("wan").
+				WithIPProtocol("inet").
+				WithProtocol("tcp").
+				WithSource(testfixtures.NewSource().WithNetwork("lan")).
+				WithDestination(testfixtures.NewDestination().WithNetwork("wan").WithPort("443")).
+				WithTarget("192.168.1.10").
+				WithPoolOpts("round-robin").
+				Build(),
 		},
 	}
 
@@ -2258,36 +2386,36 @@ func TestInboundRule_NewFields_XMLRoundTrip(t *testing.T) {
 	}{
 		{
 			name:       "all BoolFlag fields present",
-			xml:        `<rule><nordr/><nosync/></rule>`,
+			xml:        testfixtures.NewInboundRule().WithNoRDR().WithNoSync().Build(),
 			wantNoRDR:  true,
 			wantNoSync: true,
 		},
 		{
 			name:       "no BoolFlag fields",
-			xml:        `<rule></rule>`,
+			xml:        testfixtures.NewInboundRule().Build(),
 			wantNoRDR:  false,
 			wantNoSync: false,
 		},
 		{
 			name: "string fields with values",
-			xml: `<rule>` +
-				`<natreflection>enable</natreflection>` +
-				`<associated-rule-id>rule-123</associated-rule-id>` +
-				`<local-port>443</local-port>` +
-				`</rule>`,
+			xml: testfixtures.NewInboundRule().
+				WithNATReflection("enable").
+				WithAssociatedRuleID("rule-123").
+				WithLocalPort("443").
+				Build(),
 			wantNATReflection:    "enable",
 			wantAssociatedRuleID: "rule-123",
 			wantLocalPort:        "443",
 		},
 		{
 			name: "mixed BoolFlag and string fields",
-			xml: `<rule>` +
-				`<natreflection>purenat</natreflection>` +
-				`<associated-rule-id>pass-456</associated-rule-id>` +
-				`<local-port>8080</local-port>` +
-				`<nordr/>` +
-				`<nosync/>` +
-				`</rule>`,
+			xml: testfixtures.NewInboundRule().
+				WithNATReflection("purenat").
+				WithAssociatedRuleID("pass-456").
+				WithLocalPort("8080").
+				WithNoRDR().
+				WithNoSync().
+				Build(),
 			wantNATReflection:    "purenat",
 			wantAssociatedRuleID: "pass-456",
 			wantLocalPort:        "8080",
@@ -2296,22 +2424,25 @@ func TestInboundRule_NewFields_XMLRoundTrip(t *testing.T) {
 		},
 		{
 			name:      "only nordr",
-			xml:       `<rule><nordr/></rule>`,
+			xml:       testfixtures.NewInboundRule().WithNoRDR().Build(),
 			wantNoRDR: true,
 		},
 		{
 			name:       "only nosync",
-			xml:        `<rule><nosync/></rule>`,
+			xml:        testfixtures.NewInboundRule().WithNoSync().Build(),
 			wantNoSync: true,
 		},
 		{
 			name:              "only natreflection",
-			xml:               `<rule><natreflection>disable</natreflection></rule>`,
+			xml:               testfixtures.NewInboundRule().WithNATReflection("disable").Build(),
 			wantNATReflection: "disable",
 		},
 		{
-			name:              "reflection and natreflection both present",
-			xml:               `<rule><reflection>enable</reflection><natreflection>purenat</natreflection></rule>`,
+			name: "reflection and natreflection both present",
+			xml: testfixtures.NewInboundRule().
+				WithReflection("enable").
+				WithNATReflection("purenat").
+				Build(),
 			wantNATReflection: "purenat",
 		},
 	}
@@ -2376,30 +2507,29 @@ func TestInboundRule_NewFields_XMLRoundTrip(t *testing.T) {
 func TestInboundRule_CompleteWithNewFields_XMLRoundTrip(t *testing.T) {
 	t.Parallel()
 
-	inputXML := `<rule uuid="inbound-uuid-5678">` +
-		`<interface>wan</interface>` +
-		`<ipprotocol>inet</ipprotocol>` +
-		`<protocol>tcp</protocol>` +
-		`<source><not/><address>10.0.0.0/8</address><port>1024-65535</port></source>` +
-		`<destination><network>wan</network><port>443</port></destination>` +
-		`<externalport>443</externalport>` +
-		`<internalip>192.168.1.50</internalip>` +
-		`<internalport>8443</internalport>` +
-		`<local-port>8443</local-port>` +
-		`<reflection>enable</reflection>` +
-		`<natreflection>purenat</natreflection>` +
-		`<associated-rule-id>pass-rule-99</associated-rule-id>` +
-		`<priority>100</priority>` +
-		`<nordr/>` +
-		`<nosync/>` +
-		`<disabled/>` +
-		`<log/>` +
-		`<descr>Complete inbound rule test</descr>` +
-		`<updated><username>admin@10.0.0.1</username><time>1700000000</time>` +
-		`<description>test update</description></updated>` +
-		`<created><username>admin@10.0.0.1</username><time>1699000000</time>` +
-		`<description>test create</description></created>` +
-		`</rule>`
+	inputXML := testfixtures.NewInboundRule().
+		WithUUID("inbound-uuid-5678").
+		WithInterface("wan").
+		WithIPProtocol("inet").
+		WithProtocol("tcp").
+		WithSource(testfixtures.NewSource().WithNot().WithAddress("10.0.0.0/8").WithPort("1024-65535")).
+		WithDestination(testfixtures.NewDestination().WithNetwork("wan").WithPort("443")).
+		WithExternalPort("443").
+		WithInternalIP("192.168.1.50").
+		WithInternalPort("8443").
+		WithLocalPort("8443").
+		WithReflection("enable").
+		WithNATReflection("purenat").
+		WithAssociatedRuleID("pass-rule-99").
+		WithPriority("100").
+		WithNoRDR().
+		WithNoSync().
+		WithDisabled().
+		WithLog().
+		WithDescr("Complete inbound rule test").
+		WithUpdated("admin@10.0.0.1", "1700000000", "test update").
+		WithCreated("admin@10.0.0.1", "1699000000", "test create").
+		Build()
 
 	var got InboundRule
 	if err := xml.Unmarshal([]byte(inputXML), &got); err != nil {
@@ -2455,8 +2585,8 @@ func TestInboundRule_CompleteWithNewFields_XMLRoundTrip(t *testing.T) {
 	if got.AssociatedRuleID != "pass-rule-99" {
 		t.Errorf("AssociatedRuleID = %q, want %q", got.AssociatedRuleID, "pass-rule-99")
 	}
-	if got.Priority != 100 {
-		t.Errorf("Priority = %d, want %d", got.Priority, 100)
+	if got.Priority != "100" {
+		t.Errorf("Priority = %q, want %q", got.Priority, "100")
 	}
 	if !got.NoRDR {
 		t.Error("NoRDR = false, want true")
@@ -2518,18 +2648,24 @@ func TestInboundRule_BackwardCompatibility(t *testing.T) {
 	}{
 		{
 			name: "minimal inbound rule",
-			xml:  `<rule><source><any/></source><destination><any/></destination></rule>`,
+			xml: testfixtures.NewInboundRule().
+				WithSource(testfixtures.NewSource().WithAny()).
+				WithDestination(testfixtures.NewDestination().WithAny()).
+				Build(),
 		},
 		{
 			name: "legacy inbound rule without new fields",
-			xml: `<rule><interface>wan</interface>` +
-				`<ipprotocol>inet</ipprotocol><protocol>tcp</protocol>` +
-				`<source><any/></source>` +
-				`<destination><network>wan</network><port>443</port></destination>` +
-				`<externalport>443</externalport>` +
-				`<internalip>192.168.1.10</internalip>` +
-				`<internalport>443</internalport>` +
-				`<reflection>enable</reflection></rule>`,
+			xml: testfixtures.NewInboundRule().
+				WithInterface("wan").
+				WithIPProtocol("inet").
+				WithProtocol("tcp").
+				WithSource(testfixtures.NewSource().WithAny()).
+				WithDestination(testfixtures.NewDestination().WithNetwork("wan").WithPort("443")).
+				WithExternalPort("443").
+				WithInternalIP("192.168.1.10").
+				WithInternalPort("443").
+				WithReflection("enable").
+				Build(),
 		},
 	}
 
@@ -2729,3 +2865,28 @@ func TestFirewallAlias_EmptyAliases_RoundTrip(t *testing.T) {
 		t.Errorf("round-trip Alias.Aliases.Alias = %+v, want empty", roundTripped.Alias.Aliases.Alias)
 	}
 }
+
+func TestCreatedUpdated_ParsedTime(t *testing.T) {
+	want := time.Date(2021, 2, 18, 14, 55, 3, 0, time.UTC)
+
+	if got, ok := (&Created{Time: "02/18/21 14:55:03"}).ParsedTime(); !ok || !got.Equal(want) {
+		t.Errorf("Created.ParsedTime() = %v, %v; want %v, true", got, ok, want)
+	}
+	if got, ok := (&Updated{Time: "1613660103"}).ParsedTime(); !ok || !got.Equal(want) {
+		t.Errorf("Updated.ParsedTime() = %v, %v; want %v, true", got, ok, want)
+	}
+
+	for _, tc := range []struct {
+		name string
+		fn   func() (time.Time, bool)
+	}{
+		{"nil created", (*Created)(nil).ParsedTime},
+		{"nil updated", (*Updated)(nil).ParsedTime},
+		{"empty created", (&Created{}).ParsedTime},
+		{"garbage updated", (&Updated{Time: "last tuesday"}).ParsedTime},
+	} {
+		if got, ok := tc.fn(); ok || !got.IsZero() {
+			t.Errorf("%s: ParsedTime() = %v, %v; want zero time, false", tc.name, got, ok)
+		}
+	}
+}
@@ -4,7 +4,7 @@ package opnsense
 import "encoding/xml"
 
 // ServiceConfig groups service-related configuration including DHCP, DNS, SNMP, RRD,
-// load balancing, and NTP subsystems.
+// load balancing, NTP, and Wake-on-LAN subsystems.
 type ServiceConfig struct {
 	Dhcpd        Dhcpd        `json:"dhcpd"        yaml:"dhcpd,omitempty"`
 	Unbound      Unbound      `json:"unbound"      yaml:"unbound,omitempty"`
@@ -12,6 +12,7 @@ type ServiceConfig struct {
 	Rrd          Rrd          `json:"rrd"          yaml:"rrd,omitempty"`
 	LoadBalancer LoadBalancer `json:"loadBalancer" yaml:"loadBalancer,omitempty"`
 	Ntpd         Ntpd         `json:"ntpd"         yaml:"ntpd,omitempty"`
+	WOL          WOL          `json:"wol"          yaml:"wol,omitempty"`
 }
 
 // Unbound represents the Unbound DNS resolver configuration.
@@ -34,9 +35,40 @@ type Rrd struct {
 	Enable BoolFlag `xml:"enable"`
 }
 
-// LoadBalancer contains the load balancer configuration with its associated health monitor types.
+// LoadBalancer contains the relayd load balancer configuration: health monitor
+// types, server pools, and the virtual servers that expose those pools.
 type LoadBalancer struct {
-	MonitorType []MonitorType `xml:"monitor_type"`
+	MonitorType   []MonitorType     `xml:"monitor_type"`
+	Pool          []LBPool          `xml:"lbpool,omitempty"`
+	VirtualServer []LBVirtualServer `xml:"virtual_server,omitempty"`
+}
+
+// LBPool represents a load balancer server pool (<lbpool>). Each <servers>
+// element holds one enabled member address; members moved to
+// <serversdisabled> are kept in the config but receive no traffic.
+type LBPool struct {
+	Name            string   `xml:"name"`
+	Mode            string   `xml:"mode,omitempty"`
+	Descr           string   `xml:"descr,omitempty"`
+	Port            string   `xml:"port,omitempty"`
+	Retry           string   `xml:"retry,omitempty"`
+	Monitor         string   `xml:"monitor,omitempty"`
+	Servers         []string `xml:"servers,omitempty"`
+	ServersDisabled []string `xml:"serversdisabled,omitempty"`
+}
+
+// LBVirtualServer represents a load balancer virtual server (<virtual_server>):
+// the listening address and port, the pool that serves it, and the optional
+// fallback pool (<sitedown>) used when every primary member is down.
+type LBVirtualServer struct {
+	Name          string `xml:"name"`
+	Descr         string `xml:"descr,omitempty"`
+	IPAddr        string `xml:"ipaddr"`
+	Port          string `xml:"port,omitempty"`
+	PoolName      string `xml:"poolname"`
+	SiteDown      string `xml:"sitedown,omitempty"`
+	Mode          string `xml:"mode,omitempty"`
+	RelayProtocol string `xml:"relay_protocol,omitempty"`
 }
 
 // MonitorType represents a load balancer health monitor type with its name, check type,
@@ -58,9 +90,39 @@ type Options struct {
 	Expect string `xml:"expect,omitempty"`
 }
 
-// Ntpd contains the NTP daemon configuration with the preferred time server setting.
+// WOL contains the Wake-on-LAN configuration (<wol>): the hosts that can be
+// woken from Services > Wake on LAN.
+type WOL struct {
+	Entries []WOLEntry `xml:"wolentry,omitempty"`
+}
+
+// WOLEntry represents a single Wake-on-LAN host (<wolentry>): the interface
+// the magic packet is sent on and the MAC address of the host to wake.
+type WOLEntry struct {
+	Interface string `xml:"interface"`
+	MAC       string `xml:"mac"`
+	Descr     string `xml:"descr,omitempty"`
+}
+
+// Ntpd contains the NTP daemon configuration: the preferred time server,
+// polling bounds, orphan mode stratum, configured time servers, and the
+// access restrictions applied to clients.
 type Ntpd struct {
-	Prefer string `xml:"prefer"`
+	Prefer      string      `xml:"prefer"`
+	MinPoll     string      `xml:"minpoll,omitempty"`
+	MaxPoll     string      `xml:"maxpoll,omitempty"`
+	Orphan      string      `xml:"orphan,omitempty"`
+	TimeServers []NTPServer `xml:"timeserver,omitempty"`
+	NoQuery     BoolFlag    `xml:"noquery,omitempty"`
+	Restrict    []string    `xml:"restrict,omitempty"`
+}
+
+// NTPServer represents a single <timeserver> entry: the server address and
+// its prefer and noselect flags.
+type NTPServer struct {
+	Address  string   `xml:"address"`
+	Prefer   BoolFlag `xml:"prefer,omitempty"`
+	NoSelect BoolFlag `xml:"noselect,omitempty"`
 }
 
 // DNSMasq represents the dnsmasq DNS forwarder configuration, including host overrides,
@@ -171,21 +233,25 @@ type Monit struct {
 		MmonitTimeout             string `xml:"mmonitTimeout"`
 		MmonitRegisterCredentials string `xml:"mmonitRegisterCredentials"`
 	} `xml:"general"      json:"general"`
-	Alert struct {
-		Text        string `xml:",chardata" json:"text,omitempty"`
-		UUID        string `xml:"uuid,attr" json:"uuid,omitempty"`
-		Enabled     string `xml:"enabled"`
-		Recipient   string `xml:"recipient"`
-		Noton       string `xml:"noton"`
-		Events      string `xml:"events"`
-		Format      string `xml:"format"`
-		Reminder    string `xml:"reminder"`
-		Description string `xml:"description"`
-	} `xml:"alert"        json:"alert"`
+	Alert   []MonitAlert   `xml:"alert"        json:"alert,omitempty"`
 	Service []MonitService `xml:"service"      json:"service,omitempty"`
 	Test    []MonitTest    `xml:"test"         json:"test,omitempty"`
 }
 
+// MonitAlert represents a Monit alert recipient: an email address notified
+// of the selected events. A configuration may list several recipients.
+type MonitAlert struct {
+	Text        string `xml:",chardata" json:"text,omitempty"`
+	UUID        string `xml:"uuid,attr" json:"uuid,omitempty"`
+	Enabled     string `xml:"enabled"`
+	Recipient   string `xml:"recipient"`
+	Noton       string `xml:"noton"`
+	Events      string `xml:"events"`
+	Format      string `xml:"format"`
+	Reminder    string `xml:"reminder"`
+	Description string `xml:"description"`
+}
+
 // MonitService represents a single monitored service entry with its type (process, host, system, etc.),
 // start/stop commands, health tests, polling interval, and dependencies.
 type MonitService struct {
@@ -246,9 +312,10 @@ func NewSyslog() *Syslog {
 	}
 }
 
-// NewMonit returns a pointer to a new Monit configuration with initialized empty slices for services and tests.
+// NewMonit returns a pointer to a new Monit configuration with initialized empty slices for alerts, services, and tests.
 func NewMonit() *Monit {
 	return &Monit{
+		Alert:   make([]MonitAlert, 0),
 		Service: make([]MonitService, 0),
 		Test:    make([]MonitTest, 0),
 	}
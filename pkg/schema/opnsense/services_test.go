@@ -0,0 +1,281 @@
+package opnsense
+
+import (
+	"encoding/xml"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// TestWOL_MarshalUnmarshal tests XML round-trip for the <wol> section.
+func TestWOL_MarshalUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	xmlData := `<wol>
+  <wolentry>
+    <interface>lan</interface>
+    <mac>00:11:22:33:44:55</mac>
+    <descr>Build server</descr>
+  </wolentry>
+  <wolentry>
+    <interface>wan</interface>
+    <mac>66:77:88:99:aa:bb</mac>
+  </wolentry>
+</wol>`
+
+	var wol WOL
+	if err := xml.Unmarshal([]byte(xmlData), &wol); err != nil {
+		t.Fatalf("xml.Unmarshal() failed: %v", err)
+	}
+
+	want := []WOLEntry{
+		{Interface: "lan", MAC: "00:11:22:33:44:55", Descr: "Build server"},
+		{Interface: "wan", MAC: "66:77:88:99:aa:bb"},
+	}
+	if !slices.Equal(wol.Entries, want) {
+		t.Fatalf("unmarshalled WOL entries = %+v, want %+v", wol.Entries, want)
+	}
+
+	data, err := xml.Marshal(struct {
+		XMLName xml.Name `xml:"wol"`
+		WOL
+	}{WOL: wol})
+	if err != nil {
+		t.Fatalf("xml.Marshal() failed: %v", err)
+	}
+
+	var result WOL
+	if err := xml.Unmarshal(data, &result); err != nil {
+		t.Fatalf("xml.Unmarshal() of marshalled data failed: %v", err)
+	}
+
+	if !slices.Equal(result.Entries, want) {
+		t.Errorf("round-trip WOL entries = %+v, want %+v", result.Entries, want)
+	}
+}
+
+// TestOpnSenseDocument_WOLRoundTrip verifies that <wol> survives a full
+// document round-trip and is exposed through ServiceConfig.
+func TestOpnSenseDocument_WOLRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	doc := NewOpnSenseDocument()
+	doc.WOL.Entries = []WOLEntry{{Interface: "opt1", MAC: "aa:bb:cc:dd:ee:ff", Descr: "NAS"}}
+
+	data, err := xml.Marshal(doc)
+	if err != nil {
+		t.Fatalf("xml.Marshal() failed: %v", err)
+	}
+
+	var result OpnSenseDocument
+	if err := xml.Unmarshal(data, &result); err != nil {
+		t.Fatalf("xml.Unmarshal() failed: %v", err)
+	}
+
+	if !slices.Equal(result.WOL.Entries, doc.WOL.Entries) {
+		t.Errorf("round-trip WOL entries = %+v, want %+v", result.WOL.Entries, doc.WOL.Entries)
+	}
+
+	if got := result.ServiceConfig().WOL.Entries; !slices.Equal(got, doc.WOL.Entries) {
+		t.Errorf("ServiceConfig().WOL.Entries = %+v, want %+v", got, doc.WOL.Entries)
+	}
+}
+
+// TestNtpd_MarshalUnmarshal tests XML round-trip for the <ntpd> section.
+func TestNtpd_MarshalUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	xmlData := `<ntpd>
+  <prefer>0.opnsense.pool.ntp.org</prefer>
+  <minpoll>6</minpoll>
+  <maxpoll>10</maxpoll>
+  <orphan>12</orphan>
+  <timeserver>
+    <address>0.opnsense.pool.ntp.org</address>
+    <prefer/>
+  </timeserver>
+  <timeserver>
+    <address>192.0.2.10</address>
+    <noselect>1</noselect>
+  </timeserver>
+  <noquery/>
+  <restrict>10.0.0.0 mask 255.0.0.0 nomodify</restrict>
+  <restrict>192.168.1.0 mask 255.255.255.0 notrap</restrict>
+</ntpd>`
+
+	want := Ntpd{
+		Prefer:  "0.opnsense.pool.ntp.org",
+		MinPoll: "6",
+		MaxPoll: "10",
+		Orphan:  "12",
+		TimeServers: []NTPServer{
+			{Address: "0.opnsense.pool.ntp.org", Prefer: true},
+			{Address: "192.0.2.10", NoSelect: true},
+		},
+		NoQuery: true,
+		Restrict: []string{
+			"10.0.0.0 mask 255.0.0.0 nomodify",
+			"192.168.1.0 mask 255.255.255.0 notrap",
+		},
+	}
+
+	var ntpd Ntpd
+	if err := xml.Unmarshal([]byte(xmlData), &ntpd); err != nil {
+		t.Fatalf("xml.Unmarshal() failed: %v", err)
+	}
+
+	if !reflect.DeepEqual(ntpd, want) {
+		t.Fatalf("unmarshalled Ntpd = %+v, want %+v", ntpd, want)
+	}
+
+	data, err := xml.Marshal(struct {
+		XMLName xml.Name `xml:"ntpd"`
+		Ntpd
+	}{Ntpd: ntpd})
+	if err != nil {
+		t.Fatalf("xml.Marshal() failed: %v", err)
+	}
+
+	var result Ntpd
+	if err := xml.Unmarshal(data, &result); err != nil {
+		t.Fatalf("xml.Unmarshal() of marshalled data failed: %v", err)
+	}
+
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("round-trip Ntpd = %+v, want %+v", result, want)
+	}
+}
+
+// TestNtpd_MarshalOmitsUnsetFlags verifies that false flags are not written,
+// since element presence means enabled.
+func TestNtpd_MarshalOmitsUnsetFlags(t *testing.T) {
+	t.Parallel()
+
+	data, err := xml.Marshal(struct {
+		XMLName xml.Name `xml:"ntpd"`
+		Ntpd
+	}{Ntpd: Ntpd{TimeServers: []NTPServer{{Address: "192.0.2.10"}}}})
+	if err != nil {
+		t.Fatalf("xml.Marshal() failed: %v", err)
+	}
+
+	for _, tag := range []string{"<noquery", "<noselect", "<timeserver><address>192.0.2.10</address><prefer"} {
+		if strings.Contains(string(data), tag) {
+			t.Errorf("marshalled XML %s should not contain %s", data, tag)
+		}
+	}
+}
+
+// TestMonit_MarshalUnmarshal tests XML round-trip for the <monit> section,
+// including several <alert> recipients.
+func TestMonit_MarshalUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	xmlData := `<monit version="1.0.13">
+  <general>
+    <enabled>1</enabled>
+    <mailserver>smtp.example.com</mailserver>
+    <port>587</port>
+  </general>
+  <alert uuid="alert-1">
+    <enabled>1</enabled>
+    <recipient>noc@example.com</recipient>
+    <noton>0</noton>
+    <events>timeout,resource</events>
+  </alert>
+  <alert uuid="alert-2">
+    <enabled>0</enabled>
+    <recipient>pager@alerts.example.net</recipient>
+    <description>Out-of-hours pager</description>
+  </alert>
+  <service uuid="svc-1">
+    <enabled>1</enabled>
+    <name>sshd</name>
+    <type>process</type>
+    <pidfile>/var/run/sshd.pid</pidfile>
+    <tests>test-1</tests>
+  </service>
+  <test uuid="test-1">
+    <name>MemoryUsage</name>
+    <type>SystemResource</type>
+    <condition>memory usage is greater than 75%</condition>
+    <action>restart</action>
+  </test>
+</monit>`
+
+	wantAlerts := []MonitAlert{
+		{UUID: "alert-1", Enabled: "1", Recipient: "noc@example.com", Noton: "0", Events: "timeout,resource"},
+		{UUID: "alert-2", Enabled: "0", Recipient: "pager@alerts.example.net", Description: "Out-of-hours pager"},
+	}
+	wantServices := []MonitService{
+		{UUID: "svc-1", Enabled: "1", Name: "sshd", Type: "process", Pidfile: "/var/run/sshd.pid", Tests: "test-1"},
+	}
+	wantTests := []MonitTest{
+		{UUID: "test-1", Name: "MemoryUsage", Type: "SystemResource", Condition: "memory usage is greater than 75%", Action: "restart"},
+	}
+
+	check := func(label string, got *Monit) {
+		t.Helper()
+
+		if got.Version != "1.0.13" {
+			t.Errorf("%s: Version = %q, want %q", label, got.Version, "1.0.13")
+		}
+		if got.General.Mailserver != "smtp.example.com" || got.General.Port != "587" {
+			t.Errorf("%s: mail server = %q:%q, want smtp.example.com:587", label, got.General.Mailserver, got.General.Port)
+		}
+
+		alerts := make([]MonitAlert, 0, len(got.Alert))
+		for _, a := range got.Alert {
+			a.Text = ""
+			alerts = append(alerts, a)
+		}
+		if !slices.Equal(alerts, wantAlerts) {
+			t.Errorf("%s: alerts = %+v, want %+v", label, alerts, wantAlerts)
+		}
+
+		services := make([]MonitService, 0, len(got.Service))
+		for _, s := range got.Service {
+			s.Text = ""
+			services = append(services, s)
+		}
+		if !slices.Equal(services, wantServices) {
+			t.Errorf("%s: services = %+v, want %+v", label, services, wantServices)
+		}
+
+		tests := make([]MonitTest, 0, len(got.Test))
+		for _, mt := range got.Test {
+			mt.Text = ""
+			tests = append(tests, mt)
+		}
+		if !slices.Equal(tests, wantTests) {
+			t.Errorf("%s: tests = %+v, want %+v", label, tests, wantTests)
+		}
+	}
+
+	var monit Monit
+	if err := xml.Unmarshal([]byte(xmlData), &monit); err != nil {
+		t.Fatalf("xml.Unmarshal() failed: %v", err)
+	}
+	check("unmarshal", &monit)
+
+	doc := NewOpnSenseDocument()
+	doc.OPNsense.Monit = &monit
+
+	data, err := xml.Marshal(doc)
+	if err != nil {
+		t.Fatalf("xml.Marshal() failed: %v", err)
+	}
+	if !strings.Contains(string(data), `<alert uuid="alert-2">`) {
+		t.Errorf("marshalled document is missing the second <alert>:\n%s", data)
+	}
+
+	var result OpnSenseDocument
+	if err := xml.Unmarshal(data, &result); err != nil {
+		t.Fatalf("xml.Unmarshal() of marshalled data failed: %v", err)
+	}
+	if result.OPNsense.Monit == nil {
+		t.Fatal("round-trip document lost the <monit> section")
+	}
+	check("round trip", result.OPNsense.Monit)
+}
@@ -0,0 +1,63 @@
+// Package opnsense defines the data structures for OPNsense configurations.
+package opnsense
+
+// ConfigStatistics is a count summary of an OPNsense configuration. It is
+// computed directly from the parsed document, without conversion or analysis,
+// so it is cheap enough for dashboards and quick inventory checks.
+type ConfigStatistics struct {
+	FirewallRules        int `json:"firewallRules"        yaml:"firewallRules"`
+	EnabledFirewallRules int `json:"enabledFirewallRules" yaml:"enabledFirewallRules"`
+	NATOutboundRules     int `json:"natOutboundRules"     yaml:"natOutboundRules"`
+	NATInboundRules      int `json:"natInboundRules"      yaml:"natInboundRules"`
+	Interfaces           int `json:"interfaces"           yaml:"interfaces"`
+	EnabledInterfaces    int `json:"enabledInterfaces"    yaml:"enabledInterfaces"`
+	DHCPScopes           int `json:"dhcpScopes"           yaml:"dhcpScopes"`
+	StaticLeases         int `json:"staticLeases"         yaml:"staticLeases"`
+	OpenVPNServers       int `json:"openVpnServers"       yaml:"openVpnServers"`
+	OpenVPNClients       int `json:"openVpnClients"       yaml:"openVpnClients"`
+	Users                int `json:"users"                yaml:"users"`
+	Groups               int `json:"groups"               yaml:"groups"`
+	Aliases              int `json:"aliases"              yaml:"aliases"`
+	Certificates         int `json:"certificates"         yaml:"certificates"`
+}
+
+// Statistics returns a [ConfigStatistics] summary of the document. Aliases
+// count both the legacy top-level <aliases> element and the MVC
+// OPNsense/Firewall/Alias store, matching how the converter merges them.
+func (o *OpnSenseDocument) Statistics() ConfigStatistics {
+	stats := ConfigStatistics{
+		FirewallRules:    len(o.Filter.Rule),
+		NATOutboundRules: len(o.Nat.Outbound.Rule),
+		NATInboundRules:  len(o.Nat.Inbound),
+		Interfaces:       len(o.Interfaces.Items),
+		DHCPScopes:       len(o.Dhcpd.Items),
+		OpenVPNServers:   len(o.OpenVPN.Servers),
+		OpenVPNClients:   len(o.OpenVPN.Clients),
+		Users:            len(o.System.User),
+		Groups:           len(o.System.Group),
+		Aliases:          len(o.Aliases.Alias),
+		Certificates:     len(o.Certs),
+	}
+
+	for _, rule := range o.Filter.Rule {
+		if !bool(rule.Disabled) {
+			stats.EnabledFirewallRules++
+		}
+	}
+
+	for _, iface := range o.Interfaces.Items {
+		if iface.Enable == "1" {
+			stats.EnabledInterfaces++
+		}
+	}
+
+	for _, scope := range o.Dhcpd.Items {
+		stats.StaticLeases += len(scope.Staticmap)
+	}
+
+	if o.OPNsense.Firewall != nil {
+		stats.Aliases += len(o.OPNsense.Firewall.Alias.Aliases.Alias)
+	}
+
+	return stats
+}
@@ -0,0 +1,113 @@
+package opnsense
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpnSenseDocument_Statistics(t *testing.T) {
+	t.Parallel()
+
+	doc := NewOpnSenseDocument()
+	doc.Filter.Rule = []Rule{{Type: "pass"}, {Type: "block", Disabled: true}, {Type: "pass"}}
+	doc.Nat.Outbound.Rule = []NATRule{{}}
+	doc.Nat.Inbound = []InboundRule{{}, {}}
+	doc.Interfaces.Items["wan"] = Interface{Enable: "1", If: "em0"}
+	doc.Interfaces.Items["lan"] = Interface{Enable: "1", If: "em1"}
+	doc.Interfaces.Items["opt1"] = Interface{If: "em2"}
+	doc.Dhcpd.Items["lan"] = DhcpdInterface{Staticmap: []DHCPStaticLease{{}, {}}}
+	doc.Dhcpd.Items["opt1"] = DhcpdInterface{Staticmap: []DHCPStaticLease{{}}}
+	doc.OpenVPN.Servers = []OpenVPNServer{{}}
+	doc.OpenVPN.Clients = []OpenVPNClient{{}, {}}
+	doc.System.User = []User{{Name: "root"}, {Name: "ops"}}
+	doc.System.Group = []Group{{Name: "admins"}}
+	doc.Aliases.Alias = []Alias{{Name: "legacy"}}
+	doc.OPNsense.Firewall = &Firewall{}
+	doc.OPNsense.Firewall.Alias.Aliases.Alias = []Alias{{Name: "web"}, {Name: "db"}}
+	doc.Certs = []Cert{{Descr: "webgui"}}
+
+	assert.Equal(t, ConfigStatistics{
+		FirewallRules:        3,
+		EnabledFirewallRules: 2,
+		NATOutboundRules:     1,
+		NATInboundRules:      2,
+		Interfaces:           3,
+		EnabledInterfaces:    2,
+		DHCPScopes:           2,
+		StaticLeases:         3,
+		OpenVPNServers:       1,
+		OpenVPNClients:       2,
+		Users:                2,
+		Groups:               1,
+		Aliases:              3,
+		Certificates:         1,
+	}, doc.Statistics())
+}
+
+func TestOpnSenseDocument_Statistics_Empty(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, ConfigStatistics{}, NewOpnSenseDocument().Statistics())
+	assert.Equal(t, ConfigStatistics{}, (&OpnSenseDocument{}).Statistics())
+}
+
+func TestOpnSenseDocument_Statistics_SampleConfigs(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		file string
+		want ConfigStatistics
+	}{
+		{
+			file: "sample.config.1.xml",
+			want: ConfigStatistics{
+				FirewallRules: 2, EnabledFirewallRules: 2,
+				Interfaces: 2, EnabledInterfaces: 2,
+				DHCPScopes: 1, Users: 1, Groups: 1,
+			},
+		},
+		{
+			file: "sample.config.5.xml",
+			want: ConfigStatistics{
+				FirewallRules: 3, EnabledFirewallRules: 3,
+				Interfaces: 3, EnabledInterfaces: 3,
+				DHCPScopes: 1, Users: 1, Groups: 1, Certificates: 1,
+			},
+		},
+		{
+			file: "sample.config.6.xml",
+			want: ConfigStatistics{
+				FirewallRules: 51, EnabledFirewallRules: 50, NATOutboundRules: 51,
+				Interfaces: 54, EnabledInterfaces: 54,
+				DHCPScopes: 52, StaticLeases: 1, Users: 1, Groups: 1,
+			},
+		},
+		{
+			file: "opnsense-aliases.xml",
+			want: ConfigStatistics{
+				FirewallRules: 2, EnabledFirewallRules: 2,
+				Interfaces: 2, EnabledInterfaces: 2,
+				DHCPScopes: 1, Users: 1, Groups: 1, Aliases: 6,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			t.Parallel()
+
+			data, err := os.ReadFile(filepath.Join(testdataDir(), tt.file))
+			require.NoError(t, err)
+
+			var doc OpnSenseDocument
+			require.NoError(t, xml.Unmarshal(data, &doc))
+
+			assert.Equal(t, tt.want, doc.Statistics())
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package opnsense
+
+import "encoding/xml"
+
+// Swanctl represents the connection-based IPsec configuration introduced in
+// OPNsense 23.1 (VPN > IPsec > Connections), stored under
+// <OPNsense><Swanctl>. Connections, their local and remote authentication
+// rounds, and their child SAs are stored as separate lists linked by the
+// connection UUID. Legacy tunnels remain in the top-level <ipsec> section.
+type Swanctl struct {
+	XMLName     xml.Name           `xml:"Swanctl"`
+	Text        string             `xml:",chardata"    json:"text,omitempty"`
+	Version     string             `xml:"version,attr" json:"version,omitempty"`
+	Connections SwanctlConnections `xml:"Connections"  json:"connections"`
+	Locals      SwanctlLocals      `xml:"locals"       json:"locals"`
+	Remotes     SwanctlRemotes     `xml:"remotes"      json:"remotes"`
+	Children    SwanctlChildren    `xml:"children"     json:"children"`
+	Pools       SwanctlPools       `xml:"Pools"        json:"pools"`
+	VTIs        string             `xml:"VTIs"`
+	SPDs        string             `xml:"SPDs"`
+}
+
+// SwanctlConnections wraps the <Connection> entries of a Swanctl section.
+type SwanctlConnections struct {
+	Connection []SwanctlConnection `xml:"Connection" json:"connection,omitempty"`
+}
+
+// SwanctlLocals wraps the <local> authentication rounds of a Swanctl section.
+type SwanctlLocals struct {
+	Local []SwanctlAuth `xml:"local" json:"local,omitempty"`
+}
+
+// SwanctlRemotes wraps the <remote> authentication rounds of a Swanctl section.
+type SwanctlRemotes struct {
+	Remote []SwanctlAuth `xml:"remote" json:"remote,omitempty"`
+}
+
+// SwanctlChildren wraps the <child> SA entries of a Swanctl section.
+type SwanctlChildren struct {
+	Child []SwanctlChild `xml:"child" json:"child,omitempty"`
+}
+
+// SwanctlPools wraps the <Pool> virtual address pools of a Swanctl section.
+type SwanctlPools struct {
+	Pool []SwanctlPool `xml:"Pool" json:"pool,omitempty"`
+}
+
+// SwanctlConnection is an IKE connection. Proposals is a comma-separated list
+// of IKE proposals such as "aes256-sha256-modp2048". Version is "0" for
+// IKEv1 and IKEv2, "1" for IKEv1, or "2" for IKEv2. Pools lists the UUIDs of
+// the [SwanctlPool] entries assigned to the connection.
+type SwanctlConnection struct {
+	UUID        string `xml:"uuid,attr,omitempty"    json:"uuid,omitempty"`
+	Enabled     string `xml:"enabled,omitempty"      json:"enabled,omitempty"`
+	Proposals   string `xml:"proposals,omitempty"    json:"proposals,omitempty"`
+	Unique      string `xml:"unique,omitempty"       json:"unique,omitempty"`
+	Aggressive  string `xml:"aggressive,omitempty"   json:"aggressive,omitempty"`
+	Version     string `xml:"version,omitempty"      json:"version,omitempty"`
+	Mobike      string `xml:"mobike,omitempty"       json:"mobike,omitempty"`
+	LocalAddrs  string `xml:"local_addrs,omitempty"  json:"localAddrs,omitempty"`
+	LocalPort   string `xml:"local_port,omitempty"   json:"localPort,omitempty"`
+	RemoteAddrs string `xml:"remote_addrs,omitempty" json:"remoteAddrs,omitempty"`
+	RemotePort  string `xml:"remote_port,omitempty"  json:"remotePort,omitempty"`
+	Encap       string `xml:"encap,omitempty"        json:"encap,omitempty"`
+	ReauthTime  string `xml:"reauth_time,omitempty"  json:"reauthTime,omitempty"`
+	RekeyTime   string `xml:"rekey_time,omitempty"   json:"rekeyTime,omitempty"`
+	OverTime    string `xml:"over_time,omitempty"    json:"overTime,omitempty"`
+	DPDDelay    string `xml:"dpd_delay,omitempty"    json:"dpdDelay,omitempty"`
+	DPDTimeout  string `xml:"dpd_timeout,omitempty"  json:"dpdTimeout,omitempty"`
+	Pools       string `xml:"pools,omitempty"        json:"pools,omitempty"`
+	SendCertReq string `xml:"send_certreq,omitempty" json:"sendCertReq,omitempty"`
+	SendCert    string `xml:"send_cert,omitempty"    json:"sendCert,omitempty"`
+	KeyingTries string `xml:"keyingtries,omitempty"  json:"keyingTries,omitempty"`
+	Description string `xml:"description,omitempty"  json:"description,omitempty"`
+}
+
+// SwanctlAuth is one local or remote authentication round of a connection.
+// Connection holds the UUID of the owning [SwanctlConnection]; rounds are
+// applied in ascending Round order.
+type SwanctlAuth struct {
+	UUID        string `xml:"uuid,attr,omitempty"   json:"uuid,omitempty"`
+	Enabled     string `xml:"enabled,omitempty"     json:"enabled,omitempty"`
+	Connection  string `xml:"connection,omitempty"  json:"connection,omitempty"`
+	Round       string `xml:"round,omitempty"       json:"round,omitempty"`
+	Auth        string `xml:"auth,omitempty"        json:"auth,omitempty"`
+	ID          string `xml:"id,omitempty"          json:"id,omitempty"`
+	EAPID       string `xml:"eap_id,omitempty"      json:"eapId,omitempty"`
+	Certs       string `xml:"certs,omitempty"       json:"certs,omitempty"`
+	PubKeys     string `xml:"pubkeys,omitempty"     json:"pubkeys,omitempty"`
+	Groups      string `xml:"groups,omitempty"      json:"groups,omitempty"`
+	CACerts     string `xml:"cacerts,omitempty"     json:"cacerts,omitempty"`
+	Description string `xml:"description,omitempty" json:"description,omitempty"`
+}
+
+// SwanctlChild is a child SA of a connection. LocalTS and RemoteTS are
+// comma-separated traffic selectors, and ESPProposals is a comma-separated
+// list of ESP proposals such as "aes256gcm16-modp2048".
+type SwanctlChild struct {
+	UUID         string `xml:"uuid,attr,omitempty"     json:"uuid,omitempty"`
+	Enabled      string `xml:"enabled,omitempty"       json:"enabled,omitempty"`
+	Connection   string `xml:"connection,omitempty"    json:"connection,omitempty"`
+	ReqID        string `xml:"reqid,omitempty"         json:"reqid,omitempty"`
+	ESPProposals string `xml:"esp_proposals,omitempty" json:"espProposals,omitempty"`
+	SHA256Trunc  string `xml:"sha256_96,omitempty"     json:"sha256_96,omitempty"`
+	StartAction  string `xml:"start_action,omitempty"  json:"startAction,omitempty"`
+	CloseAction  string `xml:"close_action,omitempty"  json:"closeAction,omitempty"`
+	DPDAction    string `xml:"dpd_action,omitempty"    json:"dpdAction,omitempty"`
+	Mode         string `xml:"mode,omitempty"          json:"mode,omitempty"`
+	Policies     string `xml:"policies,omitempty"      json:"policies,omitempty"`
+	LocalTS      string `xml:"local_ts,omitempty"      json:"localTs,omitempty"`
+	RemoteTS     string `xml:"remote_ts,omitempty"     json:"remoteTs,omitempty"`
+	RekeyTime    string `xml:"rekey_time,omitempty"    json:"rekeyTime,omitempty"`
+	Description  string `xml:"description,omitempty"   json:"description,omitempty"`
+}
+
+// SwanctlPool is a virtual IP address pool handed out to remote peers.
+type SwanctlPool struct {
+	UUID  string `xml:"uuid,attr,omitempty" json:"uuid,omitempty"`
+	Name  string `xml:"name,omitempty"      json:"name,omitempty"`
+	Addrs string `xml:"addrs,omitempty"     json:"addrs,omitempty"`
+	DNS   string `xml:"dns,omitempty"       json:"dns,omitempty"`
+}
@@ -0,0 +1,115 @@
+package opnsense
+
+import (
+	"encoding/xml"
+	"reflect"
+	"testing"
+)
+
+// TestSwanctl_Unmarshal verifies that connections, auth rounds, child SAs,
+// and pools are decoded from their separate <Swanctl> lists.
+func TestSwanctl_Unmarshal(t *testing.T) {
+	t.Parallel()
+
+	xmlData := `<Swanctl version="1.0.0">
+  <Connections>
+    <Connection uuid="conn-1">
+      <enabled>1</enabled>
+      <proposals>aes256-sha256-modp2048</proposals>
+      <version>2</version>
+      <local_addrs>203.0.113.1</local_addrs>
+      <remote_addrs>198.51.100.10</remote_addrs>
+      <pools>pool-1</pools>
+      <description>Branch</description>
+    </Connection>
+  </Connections>
+  <locals>
+    <local uuid="local-1">
+      <enabled>1</enabled>
+      <connection>conn-1</connection>
+      <round>0</round>
+      <auth>psk</auth>
+      <id>fw.example.com</id>
+    </local>
+  </locals>
+  <remotes>
+    <remote uuid="remote-1">
+      <enabled>1</enabled>
+      <connection>conn-1</connection>
+      <auth>psk</auth>
+    </remote>
+  </remotes>
+  <children>
+    <child uuid="child-1">
+      <enabled>1</enabled>
+      <connection>conn-1</connection>
+      <esp_proposals>aes256gcm16-modp2048</esp_proposals>
+      <sha256_96>0</sha256_96>
+      <mode>tunnel</mode>
+      <local_ts>10.0.1.0/24</local_ts>
+      <remote_ts>10.20.0.0/24</remote_ts>
+    </child>
+  </children>
+  <Pools>
+    <Pool uuid="pool-1">
+      <name>roadwarrior</name>
+      <addrs>10.99.0.0/24</addrs>
+    </Pool>
+  </Pools>
+  <VTIs/>
+  <SPDs/>
+</Swanctl>`
+
+	var sw Swanctl
+	if err := xml.Unmarshal([]byte(xmlData), &sw); err != nil {
+		t.Fatalf("xml.Unmarshal() failed: %v", err)
+	}
+
+	if sw.Version != "1.0.0" {
+		t.Errorf("Version = %q, want %q", sw.Version, "1.0.0")
+	}
+
+	wantConn := SwanctlConnection{
+		UUID:        "conn-1",
+		Enabled:     "1",
+		Proposals:   "aes256-sha256-modp2048",
+		Version:     "2",
+		LocalAddrs:  "203.0.113.1",
+		RemoteAddrs: "198.51.100.10",
+		Pools:       "pool-1",
+		Description: "Branch",
+	}
+	if len(sw.Connections.Connection) != 1 || !reflect.DeepEqual(sw.Connections.Connection[0], wantConn) {
+		t.Errorf("Connections = %+v, want [%+v]", sw.Connections.Connection, wantConn)
+	}
+
+	wantLocal := SwanctlAuth{
+		UUID: "local-1", Enabled: "1", Connection: "conn-1", Round: "0", Auth: "psk", ID: "fw.example.com",
+	}
+	if len(sw.Locals.Local) != 1 || !reflect.DeepEqual(sw.Locals.Local[0], wantLocal) {
+		t.Errorf("Locals = %+v, want [%+v]", sw.Locals.Local, wantLocal)
+	}
+
+	if len(sw.Remotes.Remote) != 1 || sw.Remotes.Remote[0].Connection != "conn-1" {
+		t.Errorf("Remotes = %+v, want one remote for conn-1", sw.Remotes.Remote)
+	}
+
+	wantChild := SwanctlChild{
+		UUID:         "child-1",
+		Enabled:      "1",
+		Connection:   "conn-1",
+		ESPProposals: "aes256gcm16-modp2048",
+		SHA256Trunc:  "0",
+		Mode:         "tunnel",
+		LocalTS:      "10.0.1.0/24",
+		RemoteTS:     "10.20.0.0/24",
+	}
+	if len(sw.Children.Child) != 1 || !reflect.DeepEqual(sw.Children.Child[0], wantChild) {
+		t.Errorf("Children = %+v, want [%+v]", sw.Children.Child, wantChild)
+	}
+
+	wantPool := SwanctlPool{UUID: "pool-1", Name: "roadwarrior", Addrs: "10.99.0.0/24"}
+	if len(sw.Pools.Pool) != 1 || !reflect.DeepEqual(sw.Pools.Pool[0], wantPool) {
+		t.Errorf("Pools = %+v, want [%+v]", sw.Pools.Pool, wantPool)
+	}
+}
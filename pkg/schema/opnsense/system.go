@@ -1,8 +1,18 @@
 // Package opnsense defines the data structures for OPNsense configurations.
 package opnsense
 
+import (
+	"encoding/xml"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/EvilBit-Labs/opnDossier/pkg/schema/shared"
+)
+
 // WebGUIConfig represents the web management interface configuration, including
-// protocol (HTTP/HTTPS), SSL certificate reference, login autocomplete, and process limits.
+// protocol (HTTP/HTTPS), SSL certificate and ciphers, the HTTP redirect listener, login
+// autocomplete, and process limits.
 type WebGUIConfig struct {
 	Protocol string `xml:"protocol" json:"protocol" yaml:"protocol" validate:"required,oneof=http https"`
 	// Port is the custom WebGUI listening port, if configured. Empty means the
@@ -11,6 +21,32 @@ type WebGUIConfig struct {
 	SSLCertRef        string   `xml:"ssl-certref,omitempty"       json:"sslCertRef,omitempty"   yaml:"sslCertRef,omitempty"`
 	LoginAutocomplete BoolFlag `xml:"loginautocomplete,omitempty" json:"loginAutocomplete"      yaml:"loginAutocomplete,omitempty"`
 	MaxProcesses      string   `xml:"max_procs,omitempty"         json:"maxProcesses,omitempty" yaml:"maxProcesses,omitempty"`
+	// AuthMode is the comma-separated list of authentication server names
+	// the web GUI accepts logins from, e.g. "Local Database,corp-ldap".
+	AuthMode string `xml:"authmode,omitempty" json:"authMode,omitempty" yaml:"authMode,omitempty"`
+	// Compression is the lighttpd response compression level, empty when off.
+	Compression string `xml:"compression,omitempty" json:"compression,omitempty" yaml:"compression,omitempty"`
+	// DisableHTTPRedirect stops the web GUI from listening on port 80 to
+	// redirect browsers to HTTPS. When absent, the GUI accepts plain HTTP.
+	DisableHTTPRedirect BoolFlag `xml:"disablehttpredirect,omitempty" json:"disableHttpRedirect" yaml:"disableHttpRedirect,omitempty"`
+	// NoHTTPRefererCheck disables the HTTP_REFERER check that guards the GUI
+	// against cross-site request forgery from other hosts.
+	NoHTTPRefererCheck BoolFlag `xml:"nohttpreferercheck,omitempty" json:"noHttpRefererCheck" yaml:"noHttpRefererCheck,omitempty"`
+	// SSLCiphers is the colon-separated OpenSSL cipher list offered by the
+	// GUI, empty for the system default.
+	SSLCiphers string `xml:"ssl-ciphers,omitempty" json:"sslCiphers,omitempty" yaml:"sslCiphers,omitempty"`
+}
+
+// IsHTTPRedirectDisabled reports whether the web GUI's HTTP-to-HTTPS redirect
+// listener on port 80 is turned off.
+func (w WebGUIConfig) IsHTTPRedirectDisabled() bool {
+	return bool(w.DisableHTTPRedirect)
+}
+
+// IsHTTPSOnly reports whether the web GUI serves HTTPS and accepts no plain
+// HTTP connections, not even to redirect them.
+func (w WebGUIConfig) IsHTTPSOnly() bool {
+	return strings.EqualFold(w.Protocol, "https") && w.IsHTTPRedirectDisabled()
 }
 
 // SSHConfig represents the SSH daemon configuration, including whether it is enabled,
@@ -52,7 +88,9 @@ type System struct {
 	Firmware                      Firmware     `xml:"firmware"                      json:"firmware"                                yaml:"firmware,omitempty"`
 	Group                         []Group      `xml:"group"                         json:"groups,omitempty"                        yaml:"groups,omitempty"                        validate:"dive"`
 	User                          []User       `xml:"user"                          json:"users,omitempty"                         yaml:"users,omitempty"                         validate:"dive"`
+	AuthServer                    []AuthServer `xml:"authserver"                    json:"authServers,omitempty"                   yaml:"authServers,omitempty"`
 	WebGUI                        WebGUIConfig `xml:"webgui"                        json:"webgui"                                  yaml:"webgui,omitempty"`
+	Theme                         string       `xml:"theme,omitempty"               json:"theme,omitempty"                         yaml:"theme,omitempty"`
 	SSH                           SSHConfig    `xml:"ssh"                           json:"ssh"                                     yaml:"ssh,omitempty"`
 	Timezone                      string       `xml:"timezone"                      json:"timezone,omitempty"                      yaml:"timezone,omitempty"`
 	TimeServers                   string       `xml:"timeservers"                   json:"timeServers,omitempty"                   yaml:"timeServers,omitempty"`
@@ -64,6 +102,8 @@ type System struct {
 	IPv6Allow                     string       `xml:"ipv6allow"                     json:"ipv6Allow,omitempty"                     yaml:"ipv6Allow,omitempty"`
 	DisableNATReflection          string       `xml:"disablenatreflection"          json:"disableNatReflection,omitempty"          yaml:"disableNatReflection,omitempty"`
 	DisableConsoleMenu            BoolFlag     `xml:"disableconsolemenu"            json:"disableConsoleMenu"                      yaml:"disableConsoleMenu,omitempty"`
+	SerialSpeed                   string       `xml:"serialspeed,omitempty"         json:"serialSpeed,omitempty"                   yaml:"serialSpeed,omitempty"`
+	PrimaryConsole                string       `xml:"primaryconsole,omitempty"      json:"primaryConsole,omitempty"                yaml:"primaryConsole,omitempty"`
 	NextUID                       int          `xml:"nextuid"                       json:"nextUid,omitempty"                       yaml:"nextUid,omitempty"`
 	NextGID                       int          `xml:"nextgid"                       json:"nextGid,omitempty"                       yaml:"nextGid,omitempty"`
 	PowerdACMode                  string       `xml:"powerd_ac_mode"                json:"powerdAcMode,omitempty"                  yaml:"powerdAcMode,omitempty"                  validate:"omitempty,oneof=hadp hiadp adaptive minimum maximum"`
@@ -99,21 +139,39 @@ type System struct {
 }
 
 // Widgets represents the OPNsense dashboard widgets layout configuration,
-// including the widget display sequence and column count.
+// including the widget display sequence and column count. Per-widget
+// settings vary by release and plugin, so any other child element is kept
+// verbatim in Extra for XML round-tripping.
 type Widgets struct {
-	Sequence    string `xml:"sequence"     json:"sequence,omitempty"    yaml:"sequence,omitempty"`
-	ColumnCount string `xml:"column_count" json:"columnCount,omitempty" yaml:"columnCount,omitempty"`
+	Sequence    string       `xml:"sequence"     json:"sequence,omitempty"    yaml:"sequence,omitempty"`
+	ColumnCount string       `xml:"column_count" json:"columnCount,omitempty" yaml:"columnCount,omitempty"`
+	Extra       []RawSection `xml:",any"         json:"-"                     yaml:"-"`
+}
+
+// RawSection is an XML element that the parser recognizes but does not
+// model, such as the dashboard and RRD telemetry blobs. Its attributes and
+// inner markup are captured verbatim so the element survives an XML
+// round-trip unchanged; the content is never converted or reported.
+type RawSection struct {
+	XMLName  xml.Name
+	Attrs    []xml.Attr `xml:",any,attr"`
+	InnerXML string     `xml:",innerxml"`
 }
 
 // Group represents a user group with a name, GID, scope (system or local), member list,
-// and assigned privileges.
+// and assigned privileges. Each privilege is a separate <priv> element.
 type Group struct {
-	Name        string `xml:"name"        json:"name"                  yaml:"name"                  validate:"required,alphanum"`
-	Description string `xml:"description" json:"description,omitempty" yaml:"description,omitempty"`
-	Scope       string `xml:"scope"       json:"scope"                 yaml:"scope"                 validate:"required,oneof=system local"`
-	Gid         string `xml:"gid"         json:"gid"                   yaml:"gid"                   validate:"required,numeric"` //nolint:staticcheck // Field name matches OPNsense schema
-	Member      string `xml:"member"      json:"member,omitempty"      yaml:"member,omitempty"`
-	Priv        string `xml:"priv"        json:"privileges,omitempty"  yaml:"privileges,omitempty"`
+	Name        string   `xml:"name"        json:"name"                  yaml:"name"                  validate:"required,alphanum"`
+	Description string   `xml:"description" json:"description,omitempty" yaml:"description,omitempty"`
+	Scope       string   `xml:"scope"       json:"scope"                 yaml:"scope"                 validate:"required,oneof=system local"`
+	Gid         string   `xml:"gid"         json:"gid"                   yaml:"gid"                   validate:"required,numeric"` //nolint:staticcheck // Field name matches OPNsense schema
+	Member      string   `xml:"member"      json:"member,omitempty"      yaml:"member,omitempty"`
+	Privileges  []string `xml:"priv"        json:"privileges,omitempty"  yaml:"privileges,omitempty"`
+}
+
+// HasPrivilege reports whether the group is granted priv, e.g. "page-all".
+func (g Group) HasPrivilege(priv string) bool {
+	return slices.Contains(g.Privileges, priv)
 }
 
 // Firmware represents the OPNsense firmware configuration, including the update mirror,
@@ -129,7 +187,8 @@ type Firmware struct {
 }
 
 // User represents a local user account with authentication credentials, group membership,
-// UID, scope, API keys, and optional OTP/IPsec PSK/SSH authorized key flags.
+// UID, scope, API keys, the account expiry date, optional OTP/IPsec PSK flags, the user
+// certificate reference, and SSH authorized keys.
 type User struct {
 	Name      string   `xml:"name"      json:"name"                  yaml:"name"                  validate:"required,alphanum"`
 	Disabled  BoolFlag `xml:"disabled"  json:"disabled"              yaml:"disabled"`
@@ -137,13 +196,81 @@ type User struct {
 	Scope     string   `xml:"scope"     json:"scope"                 yaml:"scope"                 validate:"required,oneof=system local"`
 	Groupname string   `xml:"groupname" json:"groupname"             yaml:"groupname"             validate:"required"`
 
-	Password       string   `xml:"password"       json:"password"          yaml:"password"                 validate:"required"`
-	UID            string   `xml:"uid"            json:"uid"               yaml:"uid"                      validate:"required,numeric"`
-	APIKeys        []APIKey `xml:"apikeys>item"   json:"apiKeys,omitempty" yaml:"apiKeys,omitempty"`
-	Expires        BoolFlag `xml:"expires"        json:"expires"           yaml:"expires,omitempty"`
-	AuthorizedKeys BoolFlag `xml:"authorizedkeys" json:"authorizedKeys"    yaml:"authorizedKeys,omitempty"`
-	IPSecPSK       BoolFlag `xml:"ipsecpsk"       json:"ipsecPsk"          yaml:"ipsecPsk,omitempty"`
-	OTPSeed        BoolFlag `xml:"otp_seed"       json:"otpSeed"           yaml:"otpSeed,omitempty"`
+	Password       string   `xml:"password"                 json:"password"                 yaml:"password"                 validate:"required"`
+	UID            string   `xml:"uid"                      json:"uid"                      yaml:"uid"                      validate:"required,numeric"`
+	APIKeys        []APIKey `xml:"apikeys>item"             json:"apiKeys,omitempty"        yaml:"apiKeys,omitempty"`
+	Expires        string   `xml:"expires,omitempty"        json:"expires,omitempty"        yaml:"expires,omitempty"`
+	IPSecPSK       BoolFlag `xml:"ipsecpsk"                 json:"ipsecPsk"                 yaml:"ipsecPsk,omitempty"`
+	OTPSeed        BoolFlag `xml:"otp_seed"                 json:"otpSeed"                  yaml:"otpSeed,omitempty"`
+	CertRef        string   `xml:"cert,omitempty"           json:"certRef,omitempty"        yaml:"certRef,omitempty"`
+	AuthorizedKeys string   `xml:"authorizedkeys,omitempty" json:"authorizedKeys,omitempty" yaml:"authorizedKeys,omitempty"`
+}
+
+// HasSSHKey reports whether the user has SSH authorized keys configured.
+// AuthorizedKeys holds the base64-encoded authorized_keys content.
+func (u User) HasSSHKey() bool {
+	return strings.TrimSpace(u.AuthorizedKeys) != ""
+}
+
+// HasCert reports whether the user has a certificate assigned. CertRef is the
+// refid of the certificate used for certificate authentication.
+func (u User) HasCert() bool {
+	return strings.TrimSpace(u.CertRef) != ""
+}
+
+// ExpiresTime parses the account expiry date with [shared.ParseExpiryDate].
+// It returns the zero time and a nil error when the account never expires.
+func (u User) ExpiresTime() (time.Time, error) {
+	return shared.ParseExpiryDate(u.Expires)
+}
+
+// IsExpiredAt reports whether the account had expired at now. OPNsense
+// accepts logins through the whole expiry day, so an account expires at the
+// end of it. A missing or unparseable date is not expired.
+func (u User) IsExpiredAt(now time.Time) bool {
+	t, err := u.ExpiresTime()
+	if err != nil || t.IsZero() {
+		return false
+	}
+
+	return !now.Before(t.AddDate(0, 0, 1))
+}
+
+// IsExpired reports whether the account has expired, judged at the current
+// time.
+func (u User) IsExpired() bool {
+	return u.IsExpiredAt(time.Now())
+}
+
+// AuthServer represents an external LDAP or RADIUS authentication backend
+// (<system><authserver>). The web GUI, VPN servers, and captive portal zones
+// reference it by Name. LDAP servers use the ldap_* fields and RADIUS
+// servers the radius_* fields; the other set is empty.
+type AuthServer struct {
+	RefID string `xml:"refid" json:"refId,omitempty" yaml:"refId,omitempty"`
+	Type  string `xml:"type"  json:"type"            yaml:"type"`
+	Name  string `xml:"name"  json:"name"            yaml:"name"`
+	Host  string `xml:"host"  json:"host"            yaml:"host"`
+
+	// LDAPURLType is the connection transport: "TCP - Standard", "StartTLS"
+	// ("TCP - STARTTLS" on pfSense), or "SSL - Encrypted".
+	LDAPURLType       string `xml:"ldap_urltype,omitempty"        json:"ldapUrlType,omitempty"       yaml:"ldapUrlType,omitempty"`
+	LDAPPort          string `xml:"ldap_port,omitempty"           json:"ldapPort,omitempty"          yaml:"ldapPort,omitempty"`
+	LDAPProtVer       string `xml:"ldap_protver,omitempty"        json:"ldapProtVer,omitempty"       yaml:"ldapProtVer,omitempty"`
+	LDAPScope         string `xml:"ldap_scope,omitempty"          json:"ldapScope,omitempty"         yaml:"ldapScope,omitempty"`
+	LDAPBaseDN        string `xml:"ldap_basedn,omitempty"         json:"ldapBaseDn,omitempty"        yaml:"ldapBaseDn,omitempty"`
+	LDAPAuthCN        string `xml:"ldap_authcn,omitempty"         json:"ldapAuthCn,omitempty"        yaml:"ldapAuthCn,omitempty"`
+	LDAPExtendedQuery string `xml:"ldap_extended_query,omitempty" json:"ldapExtendedQuery,omitempty" yaml:"ldapExtendedQuery,omitempty"`
+	LDAPAttrUser      string `xml:"ldap_attr_user,omitempty"      json:"ldapAttrUser,omitempty"      yaml:"ldapAttrUser,omitempty"`
+	LDAPBindDN        string `xml:"ldap_binddn,omitempty"         json:"ldapBindDn,omitempty"        yaml:"ldapBindDn,omitempty"`
+	LDAPBindPW        string `xml:"ldap_bindpw,omitempty"         json:"ldapBindPw,omitempty"        yaml:"ldapBindPw,omitempty"`
+	LDAPTimeout       string `xml:"ldap_timeout,omitempty"        json:"ldapTimeout,omitempty"       yaml:"ldapTimeout,omitempty"`
+
+	RADIUSSecret   string `xml:"radius_secret,omitempty"    json:"radiusSecret,omitempty"   yaml:"radiusSecret,omitempty"`
+	RADIUSAuthPort string `xml:"radius_auth_port,omitempty" json:"radiusAuthPort,omitempty" yaml:"radiusAuthPort,omitempty"`
+	RADIUSAcctPort string `xml:"radius_acct_port,omitempty" json:"radiusAcctPort,omitempty" yaml:"radiusAcctPort,omitempty"`
+	RADIUSProtocol string `xml:"radius_protocol,omitempty"  json:"radiusProtocol,omitempty" yaml:"radiusProtocol,omitempty"`
+	RADIUSTimeout  string `xml:"radius_timeout,omitempty"   json:"radiusTimeout,omitempty"  yaml:"radiusTimeout,omitempty"`
 }
 
 // APIKey represents a user API key pair with its key, secret, associated privileges,
@@ -2,8 +2,10 @@ package opnsense
 
 import (
 	"encoding/xml"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 )
 
 // TestWebGUIConfig_PortRoundTrip pins the XML round-trip invariant for the
@@ -41,3 +43,430 @@ func TestWebGUIConfig_PortRoundTrip(t *testing.T) {
 		t.Errorf("empty Port must be omitted, got: %s", emptyData)
 	}
 }
+
+// TestWebGUIConfig_AdvancedRoundTrip verifies that the advanced web GUI
+// settings and the system theme survive an XML round-trip.
+func TestWebGUIConfig_AdvancedRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	const input = `<system>
+		<theme>opnsense-dark</theme>
+		<webgui>
+			<protocol>https</protocol>
+			<port>8443</port>
+			<max_procs>4</max_procs>
+			<compression>6</compression>
+			<disablehttpredirect/>
+			<nohttpreferercheck>1</nohttpreferercheck>
+			<loginautocomplete/>
+			<ssl-certref>cert-1</ssl-certref>
+			<ssl-ciphers>ECDHE-RSA-AES256-GCM-SHA384</ssl-ciphers>
+		</webgui>
+	</system>`
+
+	var first System
+	if err := xml.Unmarshal([]byte(input), &first); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	data, err := xml.Marshal(first)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	var got System
+	if err := xml.Unmarshal(data, &got); err != nil {
+		t.Fatalf("unmarshal round-trip: %v", err)
+	}
+
+	want := WebGUIConfig{
+		Protocol:            "https",
+		Port:                "8443",
+		SSLCertRef:          "cert-1",
+		LoginAutocomplete:   true,
+		MaxProcesses:        "4",
+		Compression:         "6",
+		DisableHTTPRedirect: true,
+		NoHTTPRefererCheck:  true,
+		SSLCiphers:          "ECDHE-RSA-AES256-GCM-SHA384",
+	}
+	if got.WebGUI != want {
+		t.Errorf("round-tripped WebGUI = %+v, want %+v", got.WebGUI, want)
+	}
+	if got.Theme != "opnsense-dark" {
+		t.Errorf("round-tripped Theme = %q, want %q", got.Theme, "opnsense-dark")
+	}
+
+	// Unset advanced settings must not be added to configs that lack them.
+	emptyData, err := xml.Marshal(WebGUIConfig{Protocol: "https"})
+	if err != nil {
+		t.Fatalf("marshal empty: %v", err)
+	}
+	for _, element := range []string{"<compression>", "<disablehttpredirect>", "<nohttpreferercheck>", "<ssl-ciphers>"} {
+		if strings.Contains(string(emptyData), element) {
+			t.Errorf("unset %s must be omitted, got: %s", element, emptyData)
+		}
+	}
+}
+
+func TestWebGUIConfig_HTTPSOnly(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name             string
+		webGUI           WebGUIConfig
+		redirectDisabled bool
+		httpsOnly        bool
+	}{
+		{"https with redirect", WebGUIConfig{Protocol: "https"}, false, false},
+		{"https without redirect", WebGUIConfig{Protocol: "https", DisableHTTPRedirect: true}, true, true},
+		{"protocol case ignored", WebGUIConfig{Protocol: "HTTPS", DisableHTTPRedirect: true}, true, true},
+		{"http", WebGUIConfig{Protocol: "http", DisableHTTPRedirect: true}, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.webGUI.IsHTTPRedirectDisabled(); got != tt.redirectDisabled {
+				t.Errorf("IsHTTPRedirectDisabled() = %v, want %v", got, tt.redirectDisabled)
+			}
+			if got := tt.webGUI.IsHTTPSOnly(); got != tt.httpsOnly {
+				t.Errorf("IsHTTPSOnly() = %v, want %v", got, tt.httpsOnly)
+			}
+		})
+	}
+}
+
+// TestWidgets_RawRoundTrip verifies that dashboard widget settings the schema
+// does not model are preserved verbatim across an XML round-trip.
+func TestWidgets_RawRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	const blob = `<widget id="traffic_graphs" refresh="5"><interfaces>lan,wan</interfaces></widget>`
+
+	xmlData := `<widgets><sequence>system_information-container:00000000-col1:show</sequence>` +
+		`<column_count>2</column_count>` + blob + `</widgets>`
+
+	var widgets Widgets
+	if err := xml.Unmarshal([]byte(xmlData), &widgets); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if widgets.ColumnCount != "2" {
+		t.Errorf("ColumnCount = %q, want %q", widgets.ColumnCount, "2")
+	}
+	if len(widgets.Extra) != 1 || widgets.Extra[0].XMLName.Local != "widget" {
+		t.Fatalf("Extra = %+v, want one <widget> element", widgets.Extra)
+	}
+
+	data, err := xml.Marshal(struct {
+		XMLName xml.Name `xml:"widgets"`
+		Widgets
+	}{Widgets: widgets})
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if !strings.Contains(string(data), blob) {
+		t.Errorf("marshaled XML lost the widget blob:\ngot:  %s\nwant: %s", data, blob)
+	}
+}
+
+// TestOpnSenseDocument_TelemetryRoundTrip verifies that <rrddata> and
+// <notifications> survive a full document round-trip without being modeled.
+func TestOpnSenseDocument_TelemetryRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	xmlData := `<opnsense><rrddata><rrddatafile><filename>wan-traffic.rrd</filename>` +
+		`<xmldata>eJzLSM3JyQcABiwCFQ==</xmldata></rrddatafile></rrddata>` +
+		`<notifications><smtp><ipaddress>mail.example.com</ipaddress></smtp></notifications></opnsense>`
+
+	var doc OpnSenseDocument
+	if err := xml.Unmarshal([]byte(xmlData), &doc); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if doc.RRDData == nil || doc.Notifications == nil {
+		t.Fatalf("RRDData = %v, Notifications = %v, want both captured", doc.RRDData, doc.Notifications)
+	}
+
+	data, err := xml.Marshal(&doc)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	for _, want := range []string{
+		"<rrddata>" + doc.RRDData.InnerXML + "</rrddata>",
+		"<notifications>" + doc.Notifications.InnerXML + "</notifications>",
+	} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("marshaled XML missing %s:\n%s", want, data)
+		}
+	}
+
+	// Absent sections stay absent.
+	empty, err := xml.Marshal(NewOpnSenseDocument())
+	if err != nil {
+		t.Fatalf("marshal empty: %v", err)
+	}
+	if strings.Contains(string(empty), "<rrddata") || strings.Contains(string(empty), "<notifications") {
+		t.Errorf("absent telemetry sections must be omitted, got: %s", empty)
+	}
+}
+
+// TestGroup_PrivilegesRoundTrip verifies that every repeated <priv> element
+// of a group is captured and written back in order, rather than only the
+// last one surviving.
+func TestGroup_PrivilegesRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	xmlData := `<group><name>operators</name><description>Operators</description><scope>local</scope>` +
+		`<gid>2000</gid><member>2001</member><priv>page-all</priv><priv>page-dashboard-all</priv>` +
+		`<priv>user-shell-access</priv></group>`
+	want := []string{"page-all", "page-dashboard-all", "user-shell-access"}
+
+	var group Group
+	if err := xml.Unmarshal([]byte(xmlData), &group); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if !slices.Equal(group.Privileges, want) {
+		t.Fatalf("Privileges = %v, want %v", group.Privileges, want)
+	}
+	if !group.HasPrivilege("user-shell-access") || group.HasPrivilege("page-firewall-rules-edit") {
+		t.Errorf("HasPrivilege mismatch for %v", group.Privileges)
+	}
+
+	data, err := xml.Marshal(struct {
+		XMLName xml.Name `xml:"group"`
+		Group
+	}{Group: group})
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if !strings.Contains(string(data), "<priv>page-all</priv><priv>page-dashboard-all</priv>") {
+		t.Errorf("marshaled XML missing repeated <priv> elements: %s", data)
+	}
+
+	var out Group
+	if err := xml.Unmarshal(data, &out); err != nil {
+		t.Fatalf("unmarshal round-trip: %v", err)
+	}
+	if !slices.Equal(out.Privileges, want) {
+		t.Errorf("round-tripped Privileges = %v, want %v", out.Privileges, want)
+	}
+
+	// A group without privileges emits no <priv> element.
+	emptyData, err := xml.Marshal(Group{Name: "empty", Scope: "local", Gid: "2002"})
+	if err != nil {
+		t.Fatalf("marshal empty: %v", err)
+	}
+	if strings.Contains(string(emptyData), "<priv>") {
+		t.Errorf("empty Privileges must be omitted, got: %s", emptyData)
+	}
+}
+
+// TestUser_CredentialsRoundTrip verifies that the <cert> reference and the
+// base64-encoded <authorizedkeys> of a <system><user> survive an XML
+// round-trip, and that empty values are omitted from the marshaled output.
+func TestUser_CredentialsRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	const keys = "c3NoLWVkMjU1MTkgQUFBQUMzTnphQzFsWkRJMU5URTVBQUFBSU9wcyBvcHNAZXhhbXBsZS5jb20="
+
+	xmlData := `<user><name>ops</name><scope>user</scope><uid>2001</uid>` +
+		`<cert>5f3e9a1c2b7d4</cert><authorizedkeys>` + keys + `</authorizedkeys></user>`
+
+	var user User
+	if err := xml.Unmarshal([]byte(xmlData), &user); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if user.CertRef != "5f3e9a1c2b7d4" || user.AuthorizedKeys != keys {
+		t.Fatalf("CertRef = %q, AuthorizedKeys = %q", user.CertRef, user.AuthorizedKeys)
+	}
+	if !user.HasCert() || !user.HasSSHKey() {
+		t.Errorf("HasCert() = %v, HasSSHKey() = %v, want true", user.HasCert(), user.HasSSHKey())
+	}
+
+	data, err := xml.Marshal(struct {
+		XMLName xml.Name `xml:"user"`
+		User
+	}{User: user})
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	var out User
+	if err := xml.Unmarshal(data, &out); err != nil {
+		t.Fatalf("unmarshal round-trip: %v", err)
+	}
+	if out.CertRef != user.CertRef || out.AuthorizedKeys != user.AuthorizedKeys {
+		t.Errorf("round-tripped CertRef = %q, AuthorizedKeys = %q", out.CertRef, out.AuthorizedKeys)
+	}
+
+	// A user without credentials emits neither element.
+	emptyData, err := xml.Marshal(User{Name: "plain"})
+	if err != nil {
+		t.Fatalf("marshal empty: %v", err)
+	}
+	if strings.Contains(string(emptyData), "<cert>") || strings.Contains(string(emptyData), "<authorizedkeys>") {
+		t.Errorf("empty credentials must be omitted, got: %s", emptyData)
+	}
+	if (User{AuthorizedKeys: " \n"}).HasSSHKey() {
+		t.Error("HasSSHKey() = true for whitespace-only authorized keys")
+	}
+}
+
+// TestUser_ExpiresRoundTrip verifies that the <expires> date of a
+// <system><user> survives an XML round-trip and is omitted when unset.
+func TestUser_ExpiresRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	xmlData := `<user><name>contractor</name><scope>user</scope><uid>2002</uid>` +
+		`<expires>12/31/2099</expires></user>`
+
+	var user User
+	if err := xml.Unmarshal([]byte(xmlData), &user); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if user.Expires != "12/31/2099" {
+		t.Fatalf("Expires = %q, want %q", user.Expires, "12/31/2099")
+	}
+	expires, err := user.ExpiresTime()
+	if err != nil {
+		t.Fatalf("ExpiresTime: %v", err)
+	}
+	if want := time.Date(2099, time.December, 31, 0, 0, 0, 0, time.UTC); !expires.Equal(want) {
+		t.Errorf("ExpiresTime() = %v, want %v", expires, want)
+	}
+	if user.IsExpired() {
+		t.Error("IsExpired() = true for a future expiry date")
+	}
+
+	data, err := xml.Marshal(struct {
+		XMLName xml.Name `xml:"user"`
+		User
+	}{User: user})
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	var out User
+	if err := xml.Unmarshal(data, &out); err != nil {
+		t.Fatalf("unmarshal round-trip: %v", err)
+	}
+	if out.Expires != user.Expires {
+		t.Errorf("round-tripped Expires = %q, want %q", out.Expires, user.Expires)
+	}
+
+	// An account without an expiry date emits no element.
+	emptyData, err := xml.Marshal(User{Name: "plain"})
+	if err != nil {
+		t.Fatalf("marshal empty: %v", err)
+	}
+	if strings.Contains(string(emptyData), "<expires>") {
+		t.Errorf("empty Expires must be omitted, got: %s", emptyData)
+	}
+	if (User{}).IsExpired() {
+		t.Error("IsExpired() = true for an account without an expiry date")
+	}
+
+	expiryDay := User{Expires: "03/15/2025"}
+	if expiryDay.IsExpiredAt(time.Date(2025, time.March, 15, 23, 59, 59, 0, time.UTC)) {
+		t.Error("IsExpiredAt() = true on the expiry day")
+	}
+	if !expiryDay.IsExpiredAt(time.Date(2025, time.March, 16, 0, 0, 0, 0, time.UTC)) {
+		t.Error("IsExpiredAt() = false after the expiry day")
+	}
+	if !(User{Expires: "01/01/1970"}).IsExpired() {
+		t.Error("IsExpired() = false for 01/01/1970")
+	}
+	if _, err := (User{Expires: "2020-01-01"}).ExpiresTime(); err == nil {
+		t.Error("ExpiresTime() accepted a date not in MM/DD/YYYY format")
+	}
+}
+
+// TestAuthServer_RoundTrip verifies that <system><authserver> entries and the
+// web GUI authmode survive an XML round-trip, and that the captive portal
+// zone authservers reference is captured alongside unmodeled zone settings.
+func TestAuthServer_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	xmlData := `<opnsense><system><webgui><authmode>Local Database,corp-ldap</authmode></webgui>` +
+		`<authserver><refid>5f1a</refid><type>ldap</type><name>corp-ldap</name><host>dc1.example.com</host>` +
+		`<ldap_port>636</ldap_port><ldap_urltype>SSL - Encrypted</ldap_urltype><ldap_bindpw>secret</ldap_bindpw></authserver>` +
+		`<authserver><type>radius</type><name>wifi</name><host>10.0.0.5</host>` +
+		`<radius_secret>abc</radius_secret><radius_auth_port>1812</radius_auth_port></authserver></system>` +
+		`<OPNsense><captiveportal version="1.0.1"><zones><zone uuid="z1"><enabled>1</enabled>` +
+		`<authservers>corp-ldap</authservers><idletimeout>30</idletimeout></zone></zones></captiveportal></OPNsense></opnsense>`
+
+	var doc OpnSenseDocument
+	if err := xml.Unmarshal([]byte(xmlData), &doc); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if doc.System.WebGUI.AuthMode != "Local Database,corp-ldap" {
+		t.Errorf("AuthMode = %q", doc.System.WebGUI.AuthMode)
+	}
+	if len(doc.System.AuthServer) != 2 {
+		t.Fatalf("AuthServer count = %d, want 2", len(doc.System.AuthServer))
+	}
+	if got := doc.System.AuthServer[0]; got.LDAPURLType != "SSL - Encrypted" || got.LDAPPort != "636" {
+		t.Errorf("LDAP server = %+v", got)
+	}
+	if got := doc.System.AuthServer[1]; got.RADIUSSecret != "abc" || got.RADIUSAuthPort != "1812" {
+		t.Errorf("RADIUS server = %+v", got)
+	}
+
+	zones := doc.OPNsense.Captiveportal.Zones.Zone
+	if len(zones) != 1 || zones[0].UUID != "z1" || zones[0].AuthServers != "corp-ldap" {
+		t.Fatalf("captive portal zones = %+v", zones)
+	}
+
+	data, err := xml.Marshal(&doc)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	for _, want := range []string{
+		"<authmode>Local Database,corp-ldap</authmode>",
+		"<ldap_urltype>SSL - Encrypted</ldap_urltype>",
+		"<radius_auth_port>1812</radius_auth_port>",
+		"<authservers>corp-ldap</authservers>",
+		"<idletimeout>30</idletimeout>",
+	} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("marshaled XML missing %s", want)
+		}
+	}
+}
+
+// TestSystem_ConsoleRoundTrip verifies that the serial console speed and
+// primary console survive an XML round-trip and are omitted when unset.
+func TestSystem_ConsoleRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	in := System{Hostname: "fw", SerialSpeed: "115200", PrimaryConsole: "serial"}
+
+	data, err := xml.Marshal(in)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	for _, want := range []string{"<serialspeed>115200</serialspeed>", "<primaryconsole>serial</primaryconsole>"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("marshaled XML missing %s: %s", want, data)
+		}
+	}
+
+	var out System
+	if err := xml.Unmarshal(data, &out); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if out.SerialSpeed != "115200" || out.PrimaryConsole != "serial" {
+		t.Errorf("round-tripped console = (%q, %q), want (%q, %q)",
+			out.SerialSpeed, out.PrimaryConsole, "115200", "serial")
+	}
+
+	emptyData, err := xml.Marshal(System{Hostname: "fw"})
+	if err != nil {
+		t.Fatalf("marshal empty: %v", err)
+	}
+	if strings.Contains(string(emptyData), "<serialspeed>") || strings.Contains(string(emptyData), "<primaryconsole>") {
+		t.Errorf("empty console settings must be omitted, got: %s", emptyData)
+	}
+}
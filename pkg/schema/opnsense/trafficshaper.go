@@ -0,0 +1,55 @@
+package opnsense
+
+// TrafficShaperPipes represents the <OPNsense><TrafficShaper><pipes>
+// container.
+type TrafficShaperPipes struct {
+	Text string              `xml:",chardata" json:"text,omitempty"`
+	Pipe []TrafficShaperPipe `xml:"pipe"      json:"pipe,omitempty"`
+}
+
+// TrafficShaperPipe represents one dummynet pipe of the traffic shaper: a
+// bandwidth limiter that queues and rules send traffic through. Pipes are
+// distinct from the HFSC queues of the legacy <shaper> section. Only the
+// fields opnDossier reports on are modeled; every other child element is
+// kept verbatim in Extra for XML round-tripping.
+type TrafficShaperPipe struct {
+	UUID    string `xml:"uuid,attr,omitempty" json:"uuid,omitempty"`
+	Number  string `xml:"number"              json:"number,omitempty"`
+	Enabled string `xml:"enabled"             json:"enabled,omitempty"`
+	// Bandwidth is the pipe's bandwidth limit, in units of BandwidthMetric.
+	Bandwidth string `xml:"bandwidth" json:"bandwidth,omitempty"`
+	// BandwidthMetric is the unit of Bandwidth: "bit", "Kbit", "Mbit", or
+	// "Gbit".
+	BandwidthMetric string `xml:"bandwidthMetric" json:"bandwidthMetric,omitempty"`
+	// Delay is the added propagation delay in milliseconds.
+	Delay string `xml:"delay" json:"delay,omitempty"`
+	// Mask selects dynamic per-host pipes: "none", "src-ip", or "dst-ip".
+	Mask        string       `xml:"mask"        json:"mask,omitempty"`
+	Description string       `xml:"description" json:"description,omitempty"`
+	Extra       []RawSection `xml:",any"        json:"-"`
+}
+
+// TrafficShaperQueues represents the <OPNsense><TrafficShaper><queues>
+// container.
+type TrafficShaperQueues struct {
+	Text  string               `xml:",chardata" json:"text,omitempty"`
+	Queue []TrafficShaperQueue `xml:"queue"     json:"queue,omitempty"`
+}
+
+// TrafficShaperQueue represents one dummynet queue of the traffic shaper: a
+// weighted share of the bandwidth of the pipe it references. Only the fields
+// opnDossier reports on are modeled; every other child element is kept
+// verbatim in Extra for XML round-tripping.
+type TrafficShaperQueue struct {
+	UUID    string `xml:"uuid,attr,omitempty" json:"uuid,omitempty"`
+	Number  string `xml:"number"              json:"number,omitempty"`
+	Enabled string `xml:"enabled"             json:"enabled,omitempty"`
+	// Pipe is the UUID of the pipe whose bandwidth the queue shares.
+	Pipe string `xml:"pipe" json:"pipe,omitempty"`
+	// Weight is the queue's share of the pipe bandwidth, 1 to 100.
+	Weight string `xml:"weight" json:"weight,omitempty"`
+	// Mask selects dynamic per-host queues: "none", "src-ip", or "dst-ip".
+	Mask        string       `xml:"mask"        json:"mask,omitempty"`
+	Description string       `xml:"description" json:"description,omitempty"`
+	Extra       []RawSection `xml:",any"        json:"-"`
+}
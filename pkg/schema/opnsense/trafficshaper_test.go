@@ -0,0 +1,191 @@
+package opnsense
+
+import (
+	"encoding/xml"
+	"reflect"
+	"testing"
+)
+
+func TestTrafficShaper_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		xmlData    string
+		wantPipes  []TrafficShaperPipe
+		wantQueues []TrafficShaperQueue
+	}{
+		{
+			// Rules send traffic straight to the pipe; no queues share it.
+			name: "pipe-based shaping",
+			xmlData: `<OPNsense>
+				<TrafficShaper version="1.0.3">
+					<pipes>
+						<pipe uuid="pipe-1">
+							<number>10000</number>
+							<enabled>1</enabled>
+							<bandwidth>50</bandwidth>
+							<bandwidthMetric>Mbit</bandwidthMetric>
+							<mask>src-ip</mask>
+							<delay>10</delay>
+							<codel_enable>0</codel_enable>
+							<description>Per-host upload cap</description>
+						</pipe>
+					</pipes>
+					<queues/>
+				</TrafficShaper>
+			</OPNsense>`,
+			wantPipes: []TrafficShaperPipe{{
+				UUID:            "pipe-1",
+				Number:          "10000",
+				Enabled:         "1",
+				Bandwidth:       "50",
+				BandwidthMetric: "Mbit",
+				Delay:           "10",
+				Mask:            "src-ip",
+				Description:     "Per-host upload cap",
+			}},
+		},
+		{
+			// Weighted queues split the bandwidth of one pipe.
+			name: "queue-based shaping",
+			xmlData: `<OPNsense>
+				<TrafficShaper version="1.0.3">
+					<pipes>
+						<pipe uuid="pipe-1">
+							<number>10000</number>
+							<enabled>1</enabled>
+							<bandwidth>100</bandwidth>
+							<bandwidthMetric>Mbit</bandwidthMetric>
+							<mask>none</mask>
+							<codel_enable>0</codel_enable>
+							<description>WAN download</description>
+						</pipe>
+					</pipes>
+					<queues>
+						<queue uuid="queue-1">
+							<number>10000</number>
+							<enabled>1</enabled>
+							<pipe>pipe-1</pipe>
+							<weight>80</weight>
+							<mask>none</mask>
+							<codel_enable>0</codel_enable>
+							<description>VoIP</description>
+						</queue>
+						<queue uuid="queue-2">
+							<number>10001</number>
+							<enabled>1</enabled>
+							<pipe>pipe-1</pipe>
+							<weight>20</weight>
+							<mask>dst-ip</mask>
+							<description>Bulk</description>
+						</queue>
+					</queues>
+				</TrafficShaper>
+			</OPNsense>`,
+			wantPipes: []TrafficShaperPipe{{
+				UUID:            "pipe-1",
+				Number:          "10000",
+				Enabled:         "1",
+				Bandwidth:       "100",
+				BandwidthMetric: "Mbit",
+				Mask:            "none",
+				Description:     "WAN download",
+			}},
+			wantQueues: []TrafficShaperQueue{
+				{
+					UUID:        "queue-1",
+					Number:      "10000",
+					Enabled:     "1",
+					Pipe:        "pipe-1",
+					Weight:      "80",
+					Mask:        "none",
+					Description: "VoIP",
+				},
+				{
+					UUID:        "queue-2",
+					Number:      "10001",
+					Enabled:     "1",
+					Pipe:        "pipe-1",
+					Weight:      "20",
+					Mask:        "dst-ip",
+					Description: "Bulk",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var first OPNsense
+			if err := xml.Unmarshal([]byte(tt.xmlData), &first); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+
+			check := func(label string, got OPNsense) {
+				t.Helper()
+
+				ts := got.TrafficShaper
+				if ts.Version != "1.0.3" {
+					t.Errorf("%s: Version = %q, want %q", label, ts.Version, "1.0.3")
+				}
+				if pipes := stripPipeExtra(ts.Pipes.Pipe); !reflect.DeepEqual(pipes, tt.wantPipes) {
+					t.Errorf("%s: pipes = %+v, want %+v", label, pipes, tt.wantPipes)
+				}
+				if queues := stripQueueExtra(ts.Queues.Queue); !reflect.DeepEqual(queues, tt.wantQueues) {
+					t.Errorf("%s: queues = %+v, want %+v", label, queues, tt.wantQueues)
+				}
+				if !hasRawSection(ts.Pipes.Pipe[0].Extra, "codel_enable") {
+					t.Errorf("%s: pipe Extra = %+v, want <codel_enable> kept", label, ts.Pipes.Pipe[0].Extra)
+				}
+				if len(ts.Queues.Queue) > 0 && !hasRawSection(ts.Queues.Queue[0].Extra, "codel_enable") {
+					t.Errorf("%s: queue Extra = %+v, want <codel_enable> kept", label, ts.Queues.Queue[0].Extra)
+				}
+			}
+			check("unmarshal", first)
+
+			encoded, err := xml.Marshal(&first)
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+
+			var second OPNsense
+			if err := xml.Unmarshal(encoded, &second); err != nil {
+				t.Fatalf("Unmarshal() of marshaled output error = %v", err)
+			}
+			check("round trip", second)
+		})
+	}
+}
+
+// stripPipeExtra returns pipes without their unmodeled child elements.
+func stripPipeExtra(pipes []TrafficShaperPipe) []TrafficShaperPipe {
+	var out []TrafficShaperPipe
+	for _, p := range pipes {
+		p.Extra = nil
+		out = append(out, p)
+	}
+	return out
+}
+
+// stripQueueExtra returns queues without their unmodeled child elements.
+func stripQueueExtra(queues []TrafficShaperQueue) []TrafficShaperQueue {
+	var out []TrafficShaperQueue
+	for _, q := range queues {
+		q.Extra = nil
+		out = append(out, q)
+	}
+	return out
+}
+
+// hasRawSection reports whether sections contains an element named name.
+func hasRawSection(sections []RawSection, name string) bool {
+	for _, s := range sections {
+		if s.XMLName.Local == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,157 @@
+package opnsense
+
+import (
+	"cmp"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// miniupnpdPermUserPrefix is the element name prefix of the numbered
+// miniupnpd ACL entries (<permuser1>, <permuser2>, ...).
+const miniupnpdPermUserPrefix = "permuser"
+
+// ErrMalformedUPnPPermission is returned by [ParseMiniUPnPdPermission] when
+// an ACL entry does not have the "allow|deny ext_ports int_addr int_ports"
+// form.
+var ErrMalformedUPnPPermission = errors.New("malformed UPnP permission rule")
+
+// InstalledPackages represents the legacy top-level <installedpackages>
+// element, where packages that predate the MVC framework store their
+// settings. Only the miniupnpd (UPnP IGD and NAT-PMP) package is modeled;
+// the settings of every other package are kept verbatim in Extra.
+type InstalledPackages struct {
+	MiniUPnPd *MiniUPnPd   `xml:"miniupnpd,omitempty" json:"miniupnpd,omitempty" yaml:"miniupnpd,omitempty"`
+	Extra     []RawSection `xml:",any"                json:"-"                   yaml:"-"`
+}
+
+// MiniUPnPd represents <installedpackages><miniupnpd>. Both OPNsense's
+// os-upnp plugin and pfSense keep the daemon settings in the first <config>
+// child.
+type MiniUPnPd struct {
+	Config []MiniUPnPdConfig `xml:"config,omitempty" json:"config,omitempty" yaml:"config,omitempty"`
+}
+
+// Settings returns the active miniupnpd settings, or nil when the section has
+// no <config> child.
+func (m *MiniUPnPd) Settings() *MiniUPnPdConfig {
+	if m == nil || len(m.Config) == 0 {
+		return nil
+	}
+
+	return &m.Config[0]
+}
+
+// MiniUPnPdConfig holds the miniupnpd settings. The ACL entries are numbered
+// elements (<permuser1> to <permuserN>) and are read from Extra with
+// [MiniUPnPdConfig.PermissionRules].
+type MiniUPnPdConfig struct {
+	Enable       BoolFlag `xml:"enable,omitempty"        json:"enable,omitempty"       yaml:"enable,omitempty"`
+	EnableUPnP   BoolFlag `xml:"enable_upnp,omitempty"   json:"enableUpnp,omitempty"   yaml:"enableUpnp,omitempty"`
+	EnableNATPMP BoolFlag `xml:"enable_natpmp,omitempty" json:"enableNatpmp,omitempty" yaml:"enableNatpmp,omitempty"`
+	// ExtIface is the external (WAN) interface mappings are opened on.
+	ExtIface string `xml:"ext_iface,omitempty" json:"extIface,omitempty" yaml:"extIface,omitempty"`
+	// IfaceArray is a comma-separated list of the internal interfaces the
+	// daemon listens on.
+	IfaceArray string `xml:"iface_array,omitempty" json:"ifaceArray,omitempty" yaml:"ifaceArray,omitempty"`
+	// PermDefault appends a rule denying every request no ACL entry allows.
+	// Without it, miniupnpd accepts requests that match no entry.
+	PermDefault BoolFlag     `xml:"permdefault,omitempty" json:"permdefault,omitempty" yaml:"permdefault,omitempty"`
+	Extra       []RawSection `xml:",any"                  json:"-"                     yaml:"-"`
+}
+
+// IsEnabled reports whether the daemon runs: the service is enabled and at
+// least one of UPnP IGD and NAT-PMP is switched on.
+func (c *MiniUPnPdConfig) IsEnabled() bool {
+	return bool(c.Enable) && (bool(c.EnableUPnP) || bool(c.EnableNATPMP))
+}
+
+// Interfaces returns the internal interfaces listed in IfaceArray.
+func (c *MiniUPnPdConfig) Interfaces() []string {
+	var interfaces []string
+	for iface := range strings.SplitSeq(c.IfaceArray, ",") {
+		if iface = strings.TrimSpace(iface); iface != "" {
+			interfaces = append(interfaces, iface)
+		}
+	}
+
+	return interfaces
+}
+
+// PermissionRules returns the non-empty <permuserN> ACL entries ordered by N,
+// which is the order miniupnpd evaluates them in.
+func (c *MiniUPnPdConfig) PermissionRules() []string {
+	type entry struct {
+		n    int
+		rule string
+	}
+
+	var entries []entry
+	for _, raw := range c.Extra {
+		suffix, ok := strings.CutPrefix(raw.XMLName.Local, miniupnpdPermUserPrefix)
+		if !ok {
+			continue
+		}
+
+		n, err := strconv.Atoi(suffix)
+		if err != nil {
+			continue
+		}
+
+		var text string
+		if err := xml.Unmarshal([]byte("<v>"+raw.InnerXML+"</v>"), &text); err != nil {
+			continue
+		}
+
+		if text = strings.TrimSpace(text); text != "" {
+			entries = append(entries, entry{n: n, rule: text})
+		}
+	}
+
+	slices.SortStableFunc(entries, func(a, b entry) int { return cmp.Compare(a.n, b.n) })
+
+	rules := make([]string, 0, len(entries))
+	for _, e := range entries {
+		rules = append(rules, e.rule)
+	}
+
+	return rules
+}
+
+// MiniUPnPdPermission is one parsed miniupnpd ACL entry of the form
+// "allow|deny ext_ports int_addr/mask int_ports [description]".
+type MiniUPnPdPermission struct {
+	Action          string
+	ExternalPorts   string
+	InternalAddress string
+	InternalPorts   string
+	Description     string
+}
+
+// miniupnpdPermissionFields is the number of mandatory fields of an ACL entry.
+const miniupnpdPermissionFields = 4
+
+// ParseMiniUPnPdPermission parses a miniupnpd ACL entry. The action is
+// lowercased; everything after the internal port range is the description.
+func ParseMiniUPnPdPermission(rule string) (MiniUPnPdPermission, error) {
+	fields := strings.Fields(rule)
+	if len(fields) < miniupnpdPermissionFields {
+		return MiniUPnPdPermission{}, fmt.Errorf("%w: %q", ErrMalformedUPnPPermission, rule)
+	}
+
+	action := strings.ToLower(fields[0])
+	if action != "allow" && action != "deny" {
+		return MiniUPnPdPermission{}, fmt.Errorf("%w: unknown action %q", ErrMalformedUPnPPermission, fields[0])
+	}
+
+	return MiniUPnPdPermission{
+		Action:          action,
+		ExternalPorts:   fields[1],
+		InternalAddress: fields[2],
+		InternalPorts:   fields[3],
+		Description:     strings.Join(fields[miniupnpdPermissionFields:], " "),
+	}, nil
+}
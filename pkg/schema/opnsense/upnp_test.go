@@ -0,0 +1,124 @@
+package opnsense
+
+import (
+	"encoding/xml"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestMiniUPnPdConfig_Unmarshal(t *testing.T) {
+	t.Parallel()
+
+	xmlData := `<installedpackages>
+		<miniupnpd>
+			<config>
+				<enable/>
+				<enable_upnp>on</enable_upnp>
+				<ext_iface>wan</ext_iface>
+				<iface_array>lan, opt1,</iface_array>
+				<permuser10>deny 0-65535 0.0.0.0/0 0-65535</permuser10>
+				<permuser2>allow 80 192.168.1.10 8080 Web &amp; API</permuser2>
+				<permuser1>  </permuser1>
+				<permuser_extra>ignored</permuser_extra>
+				<upload>20000</upload>
+			</config>
+		</miniupnpd>
+		<haproxy><config/></haproxy>
+	</installedpackages>`
+
+	var pkgs InstalledPackages
+	if err := xml.Unmarshal([]byte(xmlData), &pkgs); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if len(pkgs.Extra) != 1 || pkgs.Extra[0].XMLName.Local != "haproxy" {
+		t.Errorf("Extra = %+v, want the haproxy section only", pkgs.Extra)
+	}
+
+	cfg := pkgs.MiniUPnPd.Settings()
+	if cfg == nil {
+		t.Fatal("Settings() = nil, want the first <config>")
+	}
+
+	if !cfg.IsEnabled() {
+		t.Error("IsEnabled() = false, want true")
+	}
+	if cfg.PermDefault {
+		t.Error("PermDefault = true, want false when absent")
+	}
+	if got, want := cfg.Interfaces(), []string{"lan", "opt1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Interfaces() = %v, want %v", got, want)
+	}
+
+	wantRules := []string{
+		"allow 80 192.168.1.10 8080 Web & API",
+		"deny 0-65535 0.0.0.0/0 0-65535",
+	}
+	if got := cfg.PermissionRules(); !reflect.DeepEqual(got, wantRules) {
+		t.Errorf("PermissionRules() = %q, want %q", got, wantRules)
+	}
+}
+
+func TestMiniUPnPdConfig_IsEnabled(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		cfg  MiniUPnPdConfig
+		want bool
+	}{
+		{"service and UPnP", MiniUPnPdConfig{Enable: true, EnableUPnP: true}, true},
+		{"service and NAT-PMP", MiniUPnPdConfig{Enable: true, EnableNATPMP: true}, true},
+		{"service without protocols", MiniUPnPdConfig{Enable: true}, false},
+		{"protocols without service", MiniUPnPdConfig{EnableUPnP: true, EnableNATPMP: true}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.cfg.IsEnabled(); got != tt.want {
+				t.Errorf("IsEnabled() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMiniUPnPd_SettingsNil(t *testing.T) {
+	t.Parallel()
+
+	var missing *MiniUPnPd
+	if missing.Settings() != nil {
+		t.Error("Settings() on nil section, want nil")
+	}
+	if (&MiniUPnPd{}).Settings() != nil {
+		t.Error("Settings() without <config>, want nil")
+	}
+}
+
+func TestParseMiniUPnPdPermission(t *testing.T) {
+	t.Parallel()
+
+	got, err := ParseMiniUPnPdPermission("ALLOW 1024-65535 192.168.1.0/24 1024-65535 Game consoles")
+	if err != nil {
+		t.Fatalf("ParseMiniUPnPdPermission() error = %v", err)
+	}
+
+	want := MiniUPnPdPermission{
+		Action:          "allow",
+		ExternalPorts:   "1024-65535",
+		InternalAddress: "192.168.1.0/24",
+		InternalPorts:   "1024-65535",
+		Description:     "Game consoles",
+	}
+	if got != want {
+		t.Errorf("ParseMiniUPnPdPermission() = %+v, want %+v", got, want)
+	}
+
+	for _, rule := range []string{"allow 8080", "permit 0-65535 0.0.0.0/0 0-65535"} {
+		if _, err := ParseMiniUPnPdPermission(rule); !errors.Is(err, ErrMalformedUPnPPermission) {
+			t.Errorf("ParseMiniUPnPdPermission(%q) error = %v, want ErrMalformedUPnPPermission", rule, err)
+		}
+	}
+}
@@ -1,7 +1,10 @@
 // Package opnsense defines the data structures for OPNsense configurations.
 package opnsense
 
-import "encoding/xml"
+import (
+	"encoding/xml"
+	"strings"
+)
 
 // OpenVPN represents the legacy OpenVPN configuration container, holding server instances,
 // client instances, client-specific configurations (CSC), and client export settings.
@@ -18,111 +21,146 @@ type OpenVPN struct {
 // OpenVPNServer represents a single OpenVPN server instance with TLS settings, tunnel networks,
 // client routing, DNS push options, compression, and topology configuration.
 type OpenVPNServer struct {
-	XMLName           xml.Name `xml:"openvpn-server"`
-	VPN_ID            string   `xml:"vpnid,omitempty"` //nolint:revive,staticcheck // XML field name requires underscore
-	Mode              string   `xml:"mode,omitempty"`
-	Protocol          string   `xml:"protocol,omitempty"`
-	Dev_mode          string   `xml:"dev_mode,omitempty"` //nolint:revive,staticcheck // XML field name requires underscore
-	Interface         string   `xml:"interface,omitempty"`
-	Local_port        string   `xml:"local_port,omitempty"` //nolint:revive,staticcheck // XML field name requires underscore
-	Description       string   `xml:"description,omitempty"`
-	Custom_options    string   `xml:"custom_options,omitempty"` //nolint:revive,staticcheck // XML field name requires underscore
-	TLS               string   `xml:"tls,omitempty"`
-	TLS_type          string   `xml:"tls_type,omitempty"`   //nolint:revive,staticcheck // XML field name requires underscore
-	Cert_ref          string   `xml:"certref,omitempty"`    //nolint:revive,staticcheck // XML field name requires underscore
-	CA_ref            string   `xml:"caref,omitempty"`      //nolint:revive,staticcheck // XML field name requires underscore
-	CRL_ref           string   `xml:"crlref,omitempty"`     //nolint:revive,staticcheck // XML field name requires underscore
-	DH_length         string   `xml:"dh_length,omitempty"`  //nolint:revive,staticcheck // XML field name requires underscore
-	Ecdh_curve        string   `xml:"ecdh_curve,omitempty"` //nolint:revive,staticcheck // XML field name requires underscore
-	Cert_depth        string   `xml:"cert_depth,omitempty"` //nolint:revive,staticcheck // XML field name requires underscore
-	Strictusercn      BoolFlag `xml:"strictusercn,omitempty"`
-	Tunnel_network    string   `xml:"tunnel_network,omitempty"`   //nolint:revive,staticcheck // XML field name requires underscore
-	Tunnel_networkv6  string   `xml:"tunnel_networkv6,omitempty"` //nolint:revive,staticcheck // XML field name requires underscore
-	Remote_network    string   `xml:"remote_network,omitempty"`   //nolint:revive,staticcheck // XML field name requires underscore
-	Remote_networkv6  string   `xml:"remote_networkv6,omitempty"` //nolint:revive,staticcheck // XML field name requires underscore
-	Gwredir           BoolFlag `xml:"gwredir,omitempty"`
-	Local_network     string   `xml:"local_network,omitempty"`   //nolint:revive,staticcheck // XML field name requires underscore
-	Local_networkv6   string   `xml:"local_networkv6,omitempty"` //nolint:revive,staticcheck // XML field name requires underscore
-	Maxclients        string   `xml:"maxclients,omitempty"`
-	Compression       string   `xml:"compression,omitempty"`
-	Passtos           BoolFlag `xml:"passtos,omitempty"`
-	Client2client     BoolFlag `xml:"client2client,omitempty"`
-	Dynamic_ip        BoolFlag `xml:"dynamic_ip,omitempty"` //nolint:revive,staticcheck // XML field name requires underscore
-	Topology          string   `xml:"topology,omitempty"`
-	Serverbridge_dhcp BoolFlag `xml:"serverbridge_dhcp,omitempty"` //nolint:revive,staticcheck // XML field name requires underscore
-	DNS_domain        string   `xml:"dns_domain,omitempty"`        //nolint:revive,staticcheck // XML field name requires underscore
-	DNS_server1       string   `xml:"dns_server1,omitempty"`       //nolint:revive,staticcheck // XML field name requires underscore
-	DNS_server2       string   `xml:"dns_server2,omitempty"`       //nolint:revive,staticcheck // XML field name requires underscore
-	DNS_server3       string   `xml:"dns_server3,omitempty"`       //nolint:revive,staticcheck // XML field name requires underscore
-	DNS_server4       string   `xml:"dns_server4,omitempty"`       //nolint:revive,staticcheck // XML field name requires underscore
-	Push_register_dns BoolFlag `xml:"push_register_dns,omitempty"` //nolint:revive,staticcheck // XML field name requires underscore
-	NTP_server1       string   `xml:"ntp_server1,omitempty"`       //nolint:revive,staticcheck // XML field name requires underscore
-	NTP_server2       string   `xml:"ntp_server2,omitempty"`       //nolint:revive,staticcheck // XML field name requires underscore
-	Netbios_enable    BoolFlag `xml:"netbios_enable,omitempty"`    //nolint:revive,staticcheck // XML field name requires underscore
-	Netbios_ntype     string   `xml:"netbios_ntype,omitempty"`     //nolint:revive,staticcheck // XML field name requires underscore
-	Netbios_scope     string   `xml:"netbios_scope,omitempty"`     //nolint:revive,staticcheck // XML field name requires underscore
-	Verbosity_level   string   `xml:"verbosity_level,omitempty"`   //nolint:revive,staticcheck // XML field name requires underscore
-	Created           string   `xml:"created,omitempty"`
-	Updated           string   `xml:"updated,omitempty"`
+	XMLName xml.Name `xml:"openvpn-server"`
+	OpenVPNCrypto
+	VPNID            string   `xml:"vpnid,omitempty"`
+	Mode             string   `xml:"mode,omitempty"`
+	Protocol         string   `xml:"protocol,omitempty"`
+	DevMode          string   `xml:"dev_mode,omitempty"`
+	Interface        string   `xml:"interface,omitempty"`
+	LocalPort        string   `xml:"local_port,omitempty"`
+	Description      string   `xml:"description,omitempty"`
+	CustomOptions    string   `xml:"custom_options,omitempty"`
+	TLS              string   `xml:"tls,omitempty"`
+	TLSType          string   `xml:"tls_type,omitempty"`
+	CertRef          string   `xml:"certref,omitempty"`
+	CARef            string   `xml:"caref,omitempty"`
+	CRLRef           string   `xml:"crlref,omitempty"`
+	DHLength         string   `xml:"dh_length,omitempty"`
+	ECDHCurve        string   `xml:"ecdh_curve,omitempty"`
+	CertDepth        string   `xml:"cert_depth,omitempty"`
+	StrictUserCN     BoolFlag `xml:"strictusercn,omitempty"`
+	AuthMode         string   `xml:"authmode,omitempty"`
+	TunnelNetwork    string   `xml:"tunnel_network,omitempty"`
+	TunnelNetworkV6  string   `xml:"tunnel_networkv6,omitempty"`
+	RemoteNetwork    string   `xml:"remote_network,omitempty"`
+	RemoteNetworkV6  string   `xml:"remote_networkv6,omitempty"`
+	GWRedir          BoolFlag `xml:"gwredir,omitempty"`
+	LocalNetwork     string   `xml:"local_network,omitempty"`
+	LocalNetworkV6   string   `xml:"local_networkv6,omitempty"`
+	MaxClients       string   `xml:"maxclients,omitempty"`
+	Compression      string   `xml:"compression,omitempty"`
+	PassTOS          BoolFlag `xml:"passtos,omitempty"`
+	ClientToClient   BoolFlag `xml:"client2client,omitempty"`
+	DynamicIP        BoolFlag `xml:"dynamic_ip,omitempty"`
+	Topology         string   `xml:"topology,omitempty"`
+	ServerBridgeDHCP BoolFlag `xml:"serverbridge_dhcp,omitempty"`
+	DNSDomain        string   `xml:"dns_domain,omitempty"`
+	DNSServer1       string   `xml:"dns_server1,omitempty"`
+	DNSServer2       string   `xml:"dns_server2,omitempty"`
+	DNSServer3       string   `xml:"dns_server3,omitempty"`
+	DNSServer4       string   `xml:"dns_server4,omitempty"`
+	PushRegisterDNS  BoolFlag `xml:"push_register_dns,omitempty"`
+	NTPServer1       string   `xml:"ntp_server1,omitempty"`
+	NTPServer2       string   `xml:"ntp_server2,omitempty"`
+	NetBIOSEnable    BoolFlag `xml:"netbios_enable,omitempty"`
+	NetBIOSNType     string   `xml:"netbios_ntype,omitempty"`
+	NetBIOSScope     string   `xml:"netbios_scope,omitempty"`
+	VerbosityLevel   string   `xml:"verbosity_level,omitempty"`
+	Created          string   `xml:"created,omitempty"`
+	Updated          string   `xml:"updated,omitempty"`
 }
 
 // OpenVPNClient represents a single OpenVPN client instance with server address,
 // TLS settings, compression, and custom options.
 type OpenVPNClient struct {
-	XMLName         xml.Name `xml:"openvpn-client"`
-	VPN_ID          string   `xml:"vpnid,omitempty"` //nolint:revive,staticcheck // XML field name requires underscore
-	Mode            string   `xml:"mode,omitempty"`
-	Protocol        string   `xml:"protocol,omitempty"`
-	Dev_mode        string   `xml:"dev_mode,omitempty"` //nolint:revive,staticcheck // XML field name requires underscore
-	Interface       string   `xml:"interface,omitempty"`
-	Server_addr     string   `xml:"server_addr,omitempty"` //nolint:revive,staticcheck // XML field name requires underscore
-	Server_port     string   `xml:"server_port,omitempty"` //nolint:revive,staticcheck // XML field name requires underscore
-	Description     string   `xml:"description,omitempty"`
-	Custom_options  string   `xml:"custom_options,omitempty"` //nolint:revive,staticcheck // XML field name requires underscore
-	Cert_ref        string   `xml:"certref,omitempty"`        //nolint:revive,staticcheck // XML field name requires underscore
-	CA_ref          string   `xml:"caref,omitempty"`          //nolint:revive,staticcheck // XML field name requires underscore
-	Compression     string   `xml:"compression,omitempty"`
-	Verbosity_level string   `xml:"verbosity_level,omitempty"` //nolint:revive,staticcheck // XML field name requires underscore
-	Created         string   `xml:"created,omitempty"`
-	Updated         string   `xml:"updated,omitempty"`
+	XMLName xml.Name `xml:"openvpn-client"`
+	OpenVPNCrypto
+	VPNID          string `xml:"vpnid,omitempty"`
+	Mode           string `xml:"mode,omitempty"`
+	Protocol       string `xml:"protocol,omitempty"`
+	DevMode        string `xml:"dev_mode,omitempty"`
+	Interface      string `xml:"interface,omitempty"`
+	ServerAddr     string `xml:"server_addr,omitempty"`
+	ServerPort     string `xml:"server_port,omitempty"`
+	Description    string `xml:"description,omitempty"`
+	CustomOptions  string `xml:"custom_options,omitempty"`
+	CertRef        string `xml:"certref,omitempty"`
+	CARef          string `xml:"caref,omitempty"`
+	Compression    string `xml:"compression,omitempty"`
+	VerbosityLevel string `xml:"verbosity_level,omitempty"`
+	Created        string `xml:"created,omitempty"`
+	Updated        string `xml:"updated,omitempty"`
+}
+
+// OpenVPNCrypto holds the data channel cipher and HMAC settings shared by
+// OpenVPN servers and clients. Releases before OpenVPN 2.5 stored the
+// negotiable ciphers in <ncp-ciphers> and the fixed cipher in <crypto>; newer
+// releases use <data_ciphers> and <data_ciphers_fallback>.
+type OpenVPNCrypto struct {
+	DataCiphers         string `xml:"data_ciphers,omitempty"`
+	DataCiphersFallback string `xml:"data_ciphers_fallback,omitempty"`
+	NCPCiphers          string `xml:"ncp-ciphers,omitempty"`
+	Crypto              string `xml:"crypto,omitempty"`
+	Digest              string `xml:"digest,omitempty"`
+}
+
+// EffectiveDataCiphers returns the comma-separated negotiable data ciphers,
+// preferring <data_ciphers> over the legacy <ncp-ciphers>.
+func (c OpenVPNCrypto) EffectiveDataCiphers() string {
+	if c.DataCiphers != "" {
+		return c.DataCiphers
+	}
+
+	return c.NCPCiphers
+}
+
+// EffectiveDataCiphersFallback returns the cipher used when none can be
+// negotiated, preferring <data_ciphers_fallback> over the legacy <crypto>.
+func (c OpenVPNCrypto) EffectiveDataCiphersFallback() string {
+	if c.DataCiphersFallback != "" {
+		return c.DataCiphersFallback
+	}
+
+	return c.Crypto
 }
 
 // ClientExport represents client export options for OpenVPN, used to generate
 // downloadable client configuration packages.
 type ClientExport struct {
-	XMLName           xml.Name `xml:"openvpn-client-export"`
-	Server_list       []string `xml:"server_list,omitempty"` //nolint:revive,staticcheck // XML field name requires underscore
-	Hostname          string   `xml:"hostname,omitempty"`
-	Random_local_port BoolFlag `xml:"random_local_port,omitempty"` //nolint:revive,staticcheck // XML field name requires underscore
-	Silent_install    BoolFlag `xml:"silent_install,omitempty"`    //nolint:revive,staticcheck // XML field name requires underscore
-	Use_token         BoolFlag `xml:"use_token,omitempty"`         //nolint:revive,staticcheck // XML field name requires underscore
+	XMLName         xml.Name `xml:"openvpn-client-export"`
+	ServerList      []string `xml:"server_list,omitempty"`
+	Hostname        string   `xml:"hostname,omitempty"`
+	RandomLocalPort BoolFlag `xml:"random_local_port,omitempty"`
+	SilentInstall   BoolFlag `xml:"silent_install,omitempty"`
+	UseToken        BoolFlag `xml:"use_token,omitempty"`
 }
 
 // OpenVPNCSC represents a client-specific configuration (CSC) override for OpenVPN,
 // allowing per-client tunnel networks, DNS settings, and routing overrides.
 type OpenVPNCSC struct {
-	XMLName          xml.Name `xml:"openvpn-csc"`
-	Common_name      string   `xml:"common_name,omitempty"` //nolint:revive,staticcheck // XML field name requires underscore
-	Block            BoolFlag `xml:"block,omitempty"`
-	Tunnel_network   string   `xml:"tunnel_network,omitempty"`   //nolint:revive,staticcheck // XML field name requires underscore
-	Tunnel_networkv6 string   `xml:"tunnel_networkv6,omitempty"` //nolint:revive,staticcheck // XML field name requires underscore
-	Local_network    string   `xml:"local_network,omitempty"`    //nolint:revive,staticcheck // XML field name requires underscore
-	Local_networkv6  string   `xml:"local_networkv6,omitempty"`  //nolint:revive,staticcheck // XML field name requires underscore
-	Remote_network   string   `xml:"remote_network,omitempty"`   //nolint:revive,staticcheck // XML field name requires underscore
-	Remote_networkv6 string   `xml:"remote_networkv6,omitempty"` //nolint:revive,staticcheck // XML field name requires underscore
-	Gwredir          BoolFlag `xml:"gwredir,omitempty"`
-	Push_reset       BoolFlag `xml:"push_reset,omitempty"`     //nolint:revive,staticcheck // XML field name requires underscore
-	Remove_route     BoolFlag `xml:"remove_route,omitempty"`   //nolint:revive,staticcheck // XML field name requires underscore
-	DNS_domain       string   `xml:"dns_domain,omitempty"`     //nolint:revive,staticcheck // XML field name requires underscore
-	DNS_server1      string   `xml:"dns_server1,omitempty"`    //nolint:revive,staticcheck // XML field name requires underscore
-	DNS_server2      string   `xml:"dns_server2,omitempty"`    //nolint:revive,staticcheck // XML field name requires underscore
-	DNS_server3      string   `xml:"dns_server3,omitempty"`    //nolint:revive,staticcheck // XML field name requires underscore
-	DNS_server4      string   `xml:"dns_server4,omitempty"`    //nolint:revive,staticcheck // XML field name requires underscore
-	NTP_server1      string   `xml:"ntp_server1,omitempty"`    //nolint:revive,staticcheck // XML field name requires underscore
-	NTP_server2      string   `xml:"ntp_server2,omitempty"`    //nolint:revive,staticcheck // XML field name requires underscore
-	Custom_options   string   `xml:"custom_options,omitempty"` //nolint:revive,staticcheck // XML field name requires underscore
-	Created          string   `xml:"created,omitempty"`
-	Updated          string   `xml:"updated,omitempty"`
+	XMLName         xml.Name `xml:"openvpn-csc"`
+	CommonName      string   `xml:"common_name,omitempty"`
+	Block           BoolFlag `xml:"block,omitempty"`
+	TunnelNetwork   string   `xml:"tunnel_network,omitempty"`
+	TunnelNetworkV6 string   `xml:"tunnel_networkv6,omitempty"`
+	LocalNetwork    string   `xml:"local_network,omitempty"`
+	LocalNetworkV6  string   `xml:"local_networkv6,omitempty"`
+	RemoteNetwork   string   `xml:"remote_network,omitempty"`
+	RemoteNetworkV6 string   `xml:"remote_networkv6,omitempty"`
+	GWRedir         BoolFlag `xml:"gwredir,omitempty"`
+	PushReset       BoolFlag `xml:"push_reset,omitempty"`
+	RemoveRoute     BoolFlag `xml:"remove_route,omitempty"`
+	DNSDomain       string   `xml:"dns_domain,omitempty"`
+	DNSServer1      string   `xml:"dns_server1,omitempty"`
+	DNSServer2      string   `xml:"dns_server2,omitempty"`
+	DNSServer3      string   `xml:"dns_server3,omitempty"`
+	DNSServer4      string   `xml:"dns_server4,omitempty"`
+	NTPServer1      string   `xml:"ntp_server1,omitempty"`
+	NTPServer2      string   `xml:"ntp_server2,omitempty"`
+	CustomOptions   string   `xml:"custom_options,omitempty"`
+	Created         string   `xml:"created,omitempty"`
+	Updated         string   `xml:"updated,omitempty"`
 }
 
 // OpenVPNExport represents the MVC-based OpenVPN export configuration for client package generation.
@@ -208,6 +246,69 @@ type WireGuardClientItem struct {
 	Keepalive     string `xml:"keepalive"     json:"keepalive,omitempty"`
 }
 
+// LegacyVPNModeOff is the <mode> value that disables a legacy PPTP or L2TP server.
+const LegacyVPNModeOff = "off"
+
+// PPTPServer represents the legacy top-level <pptpd> element. PPTP was removed
+// from OPNsense, but configurations migrated forward from older releases (or
+// from pfSense) can still carry the section. PPTP relies on MS-CHAPv2, which
+// is cryptographically broken, so its presence matters to an audit.
+type PPTPServer struct {
+	XMLName    xml.Name        `xml:"pptpd"`
+	Mode       string          `xml:"mode,omitempty"`
+	Enable     BoolFlag        `xml:"enable,omitempty"`
+	Redir      string          `xml:"redir,omitempty"`
+	LocalIP    string          `xml:"localip,omitempty"`
+	RemoteIP   string          `xml:"remoteip,omitempty"`
+	NPPTPUnits string          `xml:"n_pptp_units,omitempty"`
+	Users      []LegacyVPNUser `xml:"user,omitempty"`
+}
+
+// IsEnabled reports whether the PPTP server is active. A <mode> of "off"
+// always disables it; otherwise any other mode ("server" or "redir") or an
+// explicit <enable> flag enables it.
+func (p *PPTPServer) IsEnabled() bool {
+	return legacyVPNEnabled(p.Mode, p.Enable)
+}
+
+// L2TPServer represents the legacy top-level <l2tp> element. L2TP provides no
+// encryption of its own and is only safe when carried inside IPsec.
+type L2TPServer struct {
+	XMLName    xml.Name        `xml:"l2tp"`
+	Mode       string          `xml:"mode,omitempty"`
+	Enable     BoolFlag        `xml:"enable,omitempty"`
+	Interface  string          `xml:"interface,omitempty"`
+	LocalIP    string          `xml:"localip,omitempty"`
+	RemoteIP   string          `xml:"remoteip,omitempty"`
+	NL2TPUnits string          `xml:"n_l2tp_units,omitempty"`
+	PAPOrCHAP  string          `xml:"paporchap,omitempty"`
+	Secret     string          `xml:"secret,omitempty"`
+	Users      []LegacyVPNUser `xml:"user,omitempty"`
+}
+
+// IsEnabled reports whether the L2TP server is active, using the same rules
+// as [PPTPServer.IsEnabled].
+func (l *L2TPServer) IsEnabled() bool {
+	return legacyVPNEnabled(l.Mode, l.Enable)
+}
+
+// legacyVPNEnabled applies the shared PPTP/L2TP enablement rules.
+func legacyVPNEnabled(mode string, enable BoolFlag) bool {
+	mode = strings.ToLower(strings.TrimSpace(mode))
+	if mode == LegacyVPNModeOff {
+		return false
+	}
+
+	return mode != "" || bool(enable)
+}
+
+// LegacyVPNUser represents a <user> entry of a legacy PPTP or L2TP server.
+type LegacyVPNUser struct {
+	Name     string `xml:"name,omitempty"`
+	Password string `xml:"password,omitempty"`
+	IP       string `xml:"ip,omitempty"`
+}
+
 // Constructor functions
 
 // NewOpenVPN returns a new OpenVPN configuration with empty server, client, and client-specific configuration lists.
@@ -222,7 +323,7 @@ func NewOpenVPN() *OpenVPN {
 // NewClientExport returns a new ClientExport instance with an empty server list.
 func NewClientExport() *ClientExport {
 	return &ClientExport{
-		Server_list: make([]string, 0),
+		ServerList: make([]string, 0),
 	}
 }
 
@@ -0,0 +1,208 @@
+package opnsense
+
+import (
+	"encoding/xml"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLegacyVPN_IsEnabled(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		mode   string
+		enable BoolFlag
+		want   bool
+	}{
+		{"absent mode and flag", "", false, false},
+		{"server mode", "server", false, true},
+		{"redirect mode", "redir", false, true},
+		{"enable flag only", "", true, true},
+		{"off mode", "off", false, false},
+		{"off mode overrides flag", " OFF ", true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			pptp := &PPTPServer{Mode: tt.mode, Enable: tt.enable}
+			if got := pptp.IsEnabled(); got != tt.want {
+				t.Errorf("PPTPServer.IsEnabled() = %v, want %v", got, tt.want)
+			}
+
+			l2tp := &L2TPServer{Mode: tt.mode, Enable: tt.enable}
+			if got := l2tp.IsEnabled(); got != tt.want {
+				t.Errorf("L2TPServer.IsEnabled() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestL2TPServer_Unmarshal tests decoding a legacy <l2tp> element.
+func TestL2TPServer_Unmarshal(t *testing.T) {
+	t.Parallel()
+
+	xmlData := `<l2tp>
+  <mode>server</mode>
+  <interface>wan</interface>
+  <localip>10.20.0.1</localip>
+  <remoteip>10.20.0.10</remoteip>
+  <n_l2tp_units>5</n_l2tp_units>
+  <paporchap>chap</paporchap>
+  <secret>s3cr3t</secret>
+  <user>
+    <name>carol</name>
+    <password>hunter2</password>
+    <ip>10.20.0.50</ip>
+  </user>
+</l2tp>`
+
+	var l2tp L2TPServer
+	if err := xml.Unmarshal([]byte(xmlData), &l2tp); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if !l2tp.IsEnabled() {
+		t.Error("IsEnabled() = false, want true")
+	}
+
+	if l2tp.Interface != "wan" || l2tp.NL2TPUnits != "5" || l2tp.PAPOrCHAP != "chap" {
+		t.Errorf("unexpected L2TP fields: %+v", l2tp)
+	}
+
+	if len(l2tp.Users) != 1 || l2tp.Users[0].Name != "carol" || l2tp.Users[0].IP != "10.20.0.50" {
+		t.Errorf("unexpected L2TP users: %+v", l2tp.Users)
+	}
+}
+
+// TestOpenVPNServer_RoundTrip tests that the OpenVPN server tunnel, TLS, and
+// crypto settings survive an unmarshal/marshal/unmarshal cycle.
+func TestOpenVPNServer_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	xmlData := `<openvpn-server>
+  <vpnid>1</vpnid>
+  <mode>server_tls_user</mode>
+  <dev_mode>tap</dev_mode>
+  <topology>subnet</topology>
+  <local_port>1194</local_port>
+  <dh_length>4096</dh_length>
+  <ecdh_curve>secp384r1</ecdh_curve>
+  <cert_depth>1</cert_depth>
+  <strictusercn>1</strictusercn>
+  <authmode>Local Database,corp-ldap</authmode>
+  <data_ciphers>AES-256-GCM,CHACHA20-POLY1305</data_ciphers>
+  <data_ciphers_fallback>AES-256-CBC</data_ciphers_fallback>
+  <digest>SHA256</digest>
+  <tunnel_network>10.8.0.0/24</tunnel_network>
+</openvpn-server>`
+
+	var first OpenVPNServer
+	if err := xml.Unmarshal([]byte(xmlData), &first); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	want := OpenVPNServer{
+		VPNID:         "1",
+		Mode:          "server_tls_user",
+		DevMode:       "tap",
+		Topology:      "subnet",
+		LocalPort:     "1194",
+		DHLength:      "4096",
+		ECDHCurve:     "secp384r1",
+		CertDepth:     "1",
+		StrictUserCN:  true,
+		AuthMode:      "Local Database,corp-ldap",
+		TunnelNetwork: "10.8.0.0/24",
+		OpenVPNCrypto: OpenVPNCrypto{
+			DataCiphers:         "AES-256-GCM,CHACHA20-POLY1305",
+			DataCiphersFallback: "AES-256-CBC",
+			Digest:              "SHA256",
+		},
+	}
+	first.XMLName = xml.Name{}
+	if !reflect.DeepEqual(first, want) {
+		t.Fatalf("Unmarshal() = %+v, want %+v", first, want)
+	}
+
+	encoded, err := xml.Marshal(&first)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	var second OpenVPNServer
+	if err := xml.Unmarshal(encoded, &second); err != nil {
+		t.Fatalf("Unmarshal() of marshaled output error = %v", err)
+	}
+	second.XMLName = xml.Name{}
+	if !reflect.DeepEqual(second, want) {
+		t.Errorf("round trip = %+v, want %+v", second, want)
+	}
+}
+
+func TestOpenVPNCrypto_Effective(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		crypto       OpenVPNCrypto
+		wantCiphers  string
+		wantFallback string
+	}{
+		{name: "empty", crypto: OpenVPNCrypto{}},
+		{
+			name:         "current elements",
+			crypto:       OpenVPNCrypto{DataCiphers: "AES-256-GCM", DataCiphersFallback: "AES-256-CBC"},
+			wantCiphers:  "AES-256-GCM",
+			wantFallback: "AES-256-CBC",
+		},
+		{
+			name:         "legacy elements",
+			crypto:       OpenVPNCrypto{NCPCiphers: "AES-128-GCM", Crypto: "BF-CBC"},
+			wantCiphers:  "AES-128-GCM",
+			wantFallback: "BF-CBC",
+		},
+		{
+			name: "current elements win",
+			crypto: OpenVPNCrypto{
+				DataCiphers: "AES-256-GCM", NCPCiphers: "AES-128-GCM",
+				DataCiphersFallback: "AES-256-CBC", Crypto: "BF-CBC",
+			},
+			wantCiphers:  "AES-256-GCM",
+			wantFallback: "AES-256-CBC",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.crypto.EffectiveDataCiphers(); got != tt.wantCiphers {
+				t.Errorf("EffectiveDataCiphers() = %q, want %q", got, tt.wantCiphers)
+			}
+			if got := tt.crypto.EffectiveDataCiphersFallback(); got != tt.wantFallback {
+				t.Errorf("EffectiveDataCiphersFallback() = %q, want %q", got, tt.wantFallback)
+			}
+		})
+	}
+}
+
+// TestOpenVPN_NoUnderscoreFieldNames guards against reintroducing
+// XML-derived field names such as Local_port in the OpenVPN structs.
+func TestOpenVPN_NoUnderscoreFieldNames(t *testing.T) {
+	t.Parallel()
+
+	for _, v := range []any{
+		OpenVPN{}, OpenVPNServer{}, OpenVPNClient{}, OpenVPNCrypto{}, ClientExport{}, OpenVPNCSC{},
+	} {
+		typ := reflect.TypeOf(v)
+		for i := range typ.NumField() {
+			if name := typ.Field(i).Name; strings.Contains(name, "_") {
+				t.Errorf("%s.%s: exported field names must not contain underscores", typ.Name(), name)
+			}
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package opnsense
+
+import "encoding/xml"
+
+// ZeroTierConfig represents the os-zerotier plugin configuration under
+// <OPNsense><zerotier>. ZeroTier is a peer-to-peer overlay VPN; each joined
+// network appears on the firewall as a zt* interface.
+type ZeroTierConfig struct {
+	XMLName xml.Name `xml:"zerotier"`
+	Text    string   `xml:",chardata"    json:"text,omitempty"`
+	Version string   `xml:"version,attr" json:"version,omitempty"`
+	Enabled string   `xml:"enabled"      json:"enabled,omitempty"`
+	// NetworkID is the network the node joins when no <networks> are listed.
+	NetworkID string            `xml:"network_id"  json:"networkId,omitempty"`
+	LocalConf ZeroTierLocalConf `xml:"local_conf"  json:"localConf"`
+	Networks  ZeroTierNetworks  `xml:"networks"    json:"networks"`
+}
+
+// ZeroTierLocalConf holds the node's local.conf settings.
+type ZeroTierLocalConf struct {
+	Text string `xml:",chardata" json:"text,omitempty"`
+	// Port is the UDP port the node listens on (ZeroTier defaults to 9993).
+	Port string `xml:"port" json:"port,omitempty"`
+	// AllowDefaultRoute lets network controllers push a default route.
+	AllowDefaultRoute string `xml:"allowdefaultroute" json:"allowDefaultRoute,omitempty"`
+}
+
+// ZeroTierNetworks is the <networks> container of joined networks.
+type ZeroTierNetworks struct {
+	Text    string            `xml:",chardata" json:"text,omitempty"`
+	Network []ZeroTierNetwork `xml:"network"   json:"network,omitempty"`
+}
+
+// ZeroTierNetwork is one ZeroTier network the node joins.
+type ZeroTierNetwork struct {
+	Text        string `xml:",chardata"   json:"text,omitempty"`
+	UUID        string `xml:"uuid,attr"   json:"uuid,omitempty"`
+	Enabled     string `xml:"enabled"     json:"enabled,omitempty"`
+	NetworkID   string `xml:"network_id"  json:"networkId,omitempty"`
+	Description string `xml:"description" json:"description,omitempty"`
+}
@@ -0,0 +1,104 @@
+package opnsense
+
+import (
+	"encoding/xml"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestZeroTierConfig_MarshalUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	xmlData := `<zerotier version="1.3.2">
+  <enabled>1</enabled>
+  <network_id>8056c2e21c000001</network_id>
+  <local_conf>
+    <port>9994</port>
+    <allowdefaultroute>1</allowdefaultroute>
+  </local_conf>
+  <networks>
+    <network uuid="zt-1">
+      <enabled>1</enabled>
+      <network_id>8056c2e21c000001</network_id>
+      <description>Branch mesh &amp; DR</description>
+    </network>
+    <network uuid="zt-2">
+      <enabled>0</enabled>
+      <network_id>8056c2e21c000002</network_id>
+    </network>
+  </networks>
+</zerotier>`
+
+	wantNetworks := []ZeroTierNetwork{
+		{UUID: "zt-1", Enabled: "1", NetworkID: "8056c2e21c000001", Description: "Branch mesh & DR"},
+		{UUID: "zt-2", Enabled: "0", NetworkID: "8056c2e21c000002"},
+	}
+
+	check := func(label string, got *ZeroTierConfig) {
+		t.Helper()
+
+		if got.Version != "1.3.2" || got.Enabled != "1" || got.NetworkID != "8056c2e21c000001" {
+			t.Errorf("%s: version/enabled/network_id = %q/%q/%q, want 1.3.2/1/8056c2e21c000001",
+				label, got.Version, got.Enabled, got.NetworkID)
+		}
+		if got.LocalConf.Port != "9994" || got.LocalConf.AllowDefaultRoute != "1" {
+			t.Errorf("%s: local_conf = %+v, want port 9994 with allowdefaultroute", label, got.LocalConf)
+		}
+
+		networks := make([]ZeroTierNetwork, 0, len(got.Networks.Network))
+		for _, n := range got.Networks.Network {
+			n.Text = ""
+			networks = append(networks, n)
+		}
+		if !slices.Equal(networks, wantNetworks) {
+			t.Errorf("%s: networks = %+v, want %+v", label, networks, wantNetworks)
+		}
+	}
+
+	var zt ZeroTierConfig
+	if err := xml.Unmarshal([]byte(xmlData), &zt); err != nil {
+		t.Fatalf("xml.Unmarshal() failed: %v", err)
+	}
+	check("unmarshal", &zt)
+
+	doc := NewOpnSenseDocument()
+	doc.OPNsense.ZeroTier = &zt
+
+	data, err := xml.Marshal(doc)
+	if err != nil {
+		t.Fatalf("xml.Marshal() failed: %v", err)
+	}
+	if !strings.Contains(string(data), `<zerotier version="1.3.2">`) {
+		t.Errorf("marshalled document is missing the <zerotier> section:\n%s", data)
+	}
+
+	var result OpnSenseDocument
+	if err := xml.Unmarshal(data, &result); err != nil {
+		t.Fatalf("xml.Unmarshal() of marshalled data failed: %v", err)
+	}
+	if result.OPNsense.ZeroTier == nil {
+		t.Fatal("round-trip document lost the <zerotier> section")
+	}
+	check("round trip", result.OPNsense.ZeroTier)
+}
+
+func TestOpnSenseDocument_ZeroTierAbsent(t *testing.T) {
+	t.Parallel()
+
+	data, err := xml.Marshal(NewOpnSenseDocument())
+	if err != nil {
+		t.Fatalf("xml.Marshal() failed: %v", err)
+	}
+	if strings.Contains(string(data), "<zerotier") {
+		t.Errorf("document without ZeroTier marshalled a <zerotier> element:\n%s", data)
+	}
+
+	var result OpnSenseDocument
+	if err := xml.Unmarshal(data, &result); err != nil {
+		t.Fatalf("xml.Unmarshal() failed: %v", err)
+	}
+	if result.OPNsense.ZeroTier != nil {
+		t.Errorf("ZeroTier = %+v, want nil", result.OPNsense.ZeroTier)
+	}
+}
@@ -19,18 +19,30 @@ package pfsense
 // no uuid attribute and stores members SPACE-separated in <address> rather
 // than newline-separated in <content>.
 //
-// Type is one of host|network|port|url per common.NamedObjectType. pfSense
-// also supports dynamic alias types (urltable, urltable_ports) that have no
-// direct common.NamedObjectType equivalent — the converter casts Type as-is
-// and emits an "unrecognized named-object type" warning for these per
+// Type is one of host|network|port|url|urltable|urltable_ports per
+// common.NamedObjectType. Any other type (e.g. url_ports) is cast as-is and
+// the converter emits an "unrecognized named-object type" warning per
 // GOTCHAS §5.2, the same fail-open pattern used for OPNsense's own dynamic
-// variants (e.g. urltable, networkgroup).
+// variants (e.g. networkgroup).
+//
+// External aliases name their sources in dedicated elements: a urltable or
+// urltable_ports alias stores its feed in <url>, and a url alias stores one
+// <aliasurl> per source URL next to the entries fetched when it was saved in
+// <address>:
+//
+//	<alias>
+//	  <name>BLOCKLIST</name>
+//	  <type>urltable</type>
+//	  <url>https://example.com/blocklist.txt</url>
+//	</alias>
 type Alias struct {
-	Name    string `xml:"name"              json:"name,omitempty"        yaml:"name,omitempty"`
-	Type    string `xml:"type"              json:"type,omitempty"        yaml:"type,omitempty"`
-	Address string `xml:"address,omitempty" json:"address,omitempty"     yaml:"address,omitempty"`
-	Descr   string `xml:"descr,omitempty"   json:"description,omitempty" yaml:"description,omitempty"`
-	Detail  string `xml:"detail,omitempty"  json:"detail,omitempty"      yaml:"detail,omitempty"`
+	Name     string   `xml:"name"               json:"name,omitempty"        yaml:"name,omitempty"`
+	Type     string   `xml:"type"               json:"type,omitempty"        yaml:"type,omitempty"`
+	Address  string   `xml:"address,omitempty"  json:"address,omitempty"     yaml:"address,omitempty"`
+	URL      string   `xml:"url,omitempty"      json:"url,omitempty"         yaml:"url,omitempty"`
+	AliasURL []string `xml:"aliasurl,omitempty" json:"aliasUrl,omitempty"    yaml:"aliasUrl,omitempty"`
+	Descr    string   `xml:"descr,omitempty"    json:"description,omitempty" yaml:"description,omitempty"`
+	Detail   string   `xml:"detail,omitempty"   json:"detail,omitempty"      yaml:"detail,omitempty"`
 }
 
 // AliasList is the container for a set of pfSense firewall alias
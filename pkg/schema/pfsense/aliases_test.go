@@ -84,3 +84,43 @@ func TestDocument_Aliases_XMLRoundTrip(t *testing.T) {
 	assert.Equal(t, "WEB_SERVERS", decoded.Aliases.Alias[0].Name)
 	assert.Equal(t, "10.20.30.40 10.20.30.41", decoded.Aliases.Alias[0].Address)
 }
+
+// TestAlias_ExternalSourcesRoundTrip proves the <url> feed of a urltable
+// alias and the repeated <aliasurl> sources of a url alias survive a
+// round trip, and that both are omitted when unset.
+func TestAlias_ExternalSourcesRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	xmlData := `<aliases>
+  <alias>
+    <name>FEED</name>
+    <type>urltable</type>
+    <url>https://example.com/drop.txt</url>
+  </alias>
+  <alias>
+    <name>FETCHED</name>
+    <type>url</type>
+    <address>192.0.2.1 192.0.2.2</address>
+    <aliasurl>https://example.com/a.txt</aliasurl>
+    <aliasurl>https://example.com/b.txt</aliasurl>
+  </alias>
+</aliases>`
+
+	var decoded AliasList
+	require.NoError(t, xml.Unmarshal([]byte(xmlData), &decoded))
+	require.Len(t, decoded.Alias, 2)
+	assert.Equal(t, "https://example.com/drop.txt", decoded.Alias[0].URL)
+	assert.Equal(t, []string{"https://example.com/a.txt", "https://example.com/b.txt"}, decoded.Alias[1].AliasURL)
+
+	out, err := xml.Marshal(&decoded)
+	require.NoError(t, err)
+
+	var again AliasList
+	require.NoError(t, xml.Unmarshal(out, &again))
+	assert.Equal(t, decoded, again)
+
+	empty, err := xml.Marshal(&Alias{Name: "HOSTS", Type: "host", Address: "10.0.0.1"})
+	require.NoError(t, err)
+	assert.NotContains(t, string(empty), "<url>")
+	assert.NotContains(t, string(empty), "<aliasurl>")
+}
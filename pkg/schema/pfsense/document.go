@@ -50,6 +50,13 @@ type Document struct {
 	Certs        []opnsense.Cert                 `xml:"cert,omitempty"          json:"cert,omitempty"       yaml:"cert,omitempty"`
 	VLANs        opnsense.VLANs                  `xml:"vlans,omitempty"         json:"vlans"                yaml:"vlans,omitempty"`
 	Aliases      AliasList                       `xml:"aliases,omitempty"       json:"aliases"              yaml:"aliases,omitempty"`
+	// InstalledPackages holds the settings of installed packages; only
+	// miniupnpd (UPnP IGD and NAT-PMP) is modeled. It shares the OPNsense
+	// layout. Nil when the configuration has no such element.
+	InstalledPackages *opnsense.InstalledPackages `xml:"installedpackages,omitempty" json:"installedpackages,omitempty" yaml:"installedpackages,omitempty"`
+	// Encoding records the character encoding of the parsed input. It is set
+	// by the parser and is not part of the XML.
+	Encoding opnsense.InputEncoding `xml:"-" json:"-" yaml:"-"`
 }
 
 // NewDocument returns a new Document with all slice and map fields initialized for safe use.
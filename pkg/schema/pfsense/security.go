@@ -9,11 +9,13 @@ import (
 
 // Nat represents the pfSense NAT configuration.
 // The key structural difference from OPNsense is that inbound (port-forward) rules
-// are direct children of <nat> rather than nested under <nat><inbound>.
+// are direct children of <nat> rather than nested under <nat><inbound>. 1:1 NAT
+// entries share the OPNsense <onetoone> layout.
 type Nat struct {
-	Outbound  opnsense.Outbound `xml:"outbound"            json:"outbound"            yaml:"outbound"`
-	Inbound   []InboundRule     `xml:"rule"                json:"inbound,omitempty"   yaml:"inbound,omitempty"`
-	Separator string            `xml:"separator,omitempty" json:"separator,omitempty" yaml:"separator,omitempty"`
+	Outbound  opnsense.Outbound       `xml:"outbound"            json:"outbound"            yaml:"outbound"`
+	Inbound   []InboundRule           `xml:"rule"                json:"inbound,omitempty"   yaml:"inbound,omitempty"`
+	OneToOne  []opnsense.OneToOneRule `xml:"onetoone"            json:"oneToOne,omitempty"  yaml:"oneToOne,omitempty"`
+	Separator string                  `xml:"separator,omitempty" json:"separator,omitempty" yaml:"separator,omitempty"`
 }
 
 // InboundRule represents a pfSense inbound NAT rule (port forwarding).
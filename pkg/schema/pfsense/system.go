@@ -12,33 +12,36 @@ import (
 //
 // Use [NewSystem] to create a System with all slice fields pre-initialized.
 type System struct {
-	Optimization                  string             `xml:"optimization"                            json:"optimization,omitempty"                  yaml:"optimization,omitempty"`
-	Hostname                      string             `xml:"hostname"                                json:"hostname"                                yaml:"hostname"`
-	Domain                        string             `xml:"domain"                                  json:"domain"                                  yaml:"domain"`
-	DNSAllowOverride              opnsense.BoolFlag  `xml:"dnsallowoverride,omitempty"              json:"dnsAllowOverride,omitempty"              yaml:"dnsAllowOverride,omitempty"`
-	DNSServers                    []string           `xml:"dnsserver"                               json:"dnsServers,omitempty"                    yaml:"dnsServers,omitempty"`
-	DNS1GW                        string             `xml:"dns1gw,omitempty"                        json:"dns1gw,omitempty"                        yaml:"dns1gw,omitempty"`
-	DNS2GW                        string             `xml:"dns2gw,omitempty"                        json:"dns2gw,omitempty"                        yaml:"dns2gw,omitempty"`
-	Language                      string             `xml:"language"                                json:"language,omitempty"                      yaml:"language,omitempty"`
-	Group                         []Group            `xml:"group"                                   json:"groups,omitempty"                        yaml:"groups,omitempty"`
-	User                          []User             `xml:"user"                                    json:"users,omitempty"                         yaml:"users,omitempty"`
-	WebGUI                        WebGUI             `xml:"webgui"                                  json:"webgui"                                  yaml:"webgui,omitempty"`
-	SSH                           opnsense.SSHConfig `xml:"ssh"                                     json:"ssh"                                     yaml:"ssh,omitempty"`
-	Timezone                      string             `xml:"timezone"                                json:"timezone,omitempty"                      yaml:"timezone,omitempty"`
-	TimeServers                   string             `xml:"timeservers"                             json:"timeServers,omitempty"                   yaml:"timeServers,omitempty"`
-	DisableNATReflection          string             `xml:"disablenatreflection"                    json:"disableNatReflection,omitempty"          yaml:"disableNatReflection,omitempty"`
-	DisableSegmentationOffloading opnsense.BoolFlag  `xml:"disablesegmentationoffloading,omitempty" json:"disableSegmentationOffloading,omitempty" yaml:"disableSegmentationOffloading,omitempty"`
-	DisableLargeReceiveOffloading opnsense.BoolFlag  `xml:"disablelargereceiveoffloading,omitempty" json:"disableLargeReceiveOffloading,omitempty" yaml:"disableLargeReceiveOffloading,omitempty"`
-	IPv6Allow                     string             `xml:"ipv6allow"                               json:"ipv6Allow,omitempty"                     yaml:"ipv6Allow,omitempty"`
-	MaximumTableEntries           string             `xml:"maximumtableentries,omitempty"           json:"maximumTableEntries,omitempty"           yaml:"maximumTableEntries,omitempty"`
-	CryptoHardware                string             `xml:"crypto_hardware,omitempty"               json:"cryptoHardware,omitempty"                yaml:"cryptoHardware,omitempty"`
-	EnableSerial                  opnsense.BoolFlag  `xml:"enableserial,omitempty"                  json:"enableSerial"                            yaml:"enableSerial,omitempty"`
-	AlreadyRunConfigUpgrade       opnsense.BoolFlag  `xml:"already_run_config_upgrade,omitempty"    json:"alreadyRunConfigUpgrade"                 yaml:"alreadyRunConfigUpgrade,omitempty"`
-	NextUID                       int                `xml:"nextuid"                                 json:"nextUid,omitempty"                       yaml:"nextUid,omitempty"`
-	NextGID                       int                `xml:"nextgid"                                 json:"nextGid,omitempty"                       yaml:"nextGid,omitempty"`
-	PowerdACMode                  string             `xml:"powerd_ac_mode"                          json:"powerdAcMode,omitempty"                  yaml:"powerdAcMode,omitempty"`
-	PowerdBatteryMode             string             `xml:"powerd_battery_mode"                     json:"powerdBatteryMode,omitempty"             yaml:"powerdBatteryMode,omitempty"`
-	PowerdNormalMode              string             `xml:"powerd_normal_mode"                      json:"powerdNormalMode,omitempty"              yaml:"powerdNormalMode,omitempty"`
+	Optimization                  string                `xml:"optimization"                            json:"optimization,omitempty"                  yaml:"optimization,omitempty"`
+	Hostname                      string                `xml:"hostname"                                json:"hostname"                                yaml:"hostname"`
+	Domain                        string                `xml:"domain"                                  json:"domain"                                  yaml:"domain"`
+	DNSAllowOverride              opnsense.BoolFlag     `xml:"dnsallowoverride,omitempty"              json:"dnsAllowOverride,omitempty"              yaml:"dnsAllowOverride,omitempty"`
+	DNSServers                    []string              `xml:"dnsserver"                               json:"dnsServers,omitempty"                    yaml:"dnsServers,omitempty"`
+	DNS1GW                        string                `xml:"dns1gw,omitempty"                        json:"dns1gw,omitempty"                        yaml:"dns1gw,omitempty"`
+	DNS2GW                        string                `xml:"dns2gw,omitempty"                        json:"dns2gw,omitempty"                        yaml:"dns2gw,omitempty"`
+	Language                      string                `xml:"language"                                json:"language,omitempty"                      yaml:"language,omitempty"`
+	Group                         []Group               `xml:"group"                                   json:"groups,omitempty"                        yaml:"groups,omitempty"`
+	User                          []User                `xml:"user"                                    json:"users,omitempty"                         yaml:"users,omitempty"`
+	AuthServer                    []opnsense.AuthServer `xml:"authserver"                              json:"authServers,omitempty"                   yaml:"authServers,omitempty"`
+	WebGUI                        WebGUI                `xml:"webgui"                                  json:"webgui"                                  yaml:"webgui,omitempty"`
+	SSH                           opnsense.SSHConfig    `xml:"ssh"                                     json:"ssh"                                     yaml:"ssh,omitempty"`
+	Timezone                      string                `xml:"timezone"                                json:"timezone,omitempty"                      yaml:"timezone,omitempty"`
+	TimeServers                   string                `xml:"timeservers"                             json:"timeServers,omitempty"                   yaml:"timeServers,omitempty"`
+	DisableNATReflection          string                `xml:"disablenatreflection"                    json:"disableNatReflection,omitempty"          yaml:"disableNatReflection,omitempty"`
+	DisableSegmentationOffloading opnsense.BoolFlag     `xml:"disablesegmentationoffloading,omitempty" json:"disableSegmentationOffloading,omitempty" yaml:"disableSegmentationOffloading,omitempty"`
+	DisableLargeReceiveOffloading opnsense.BoolFlag     `xml:"disablelargereceiveoffloading,omitempty" json:"disableLargeReceiveOffloading,omitempty" yaml:"disableLargeReceiveOffloading,omitempty"`
+	IPv6Allow                     string                `xml:"ipv6allow"                               json:"ipv6Allow,omitempty"                     yaml:"ipv6Allow,omitempty"`
+	MaximumTableEntries           string                `xml:"maximumtableentries,omitempty"           json:"maximumTableEntries,omitempty"           yaml:"maximumTableEntries,omitempty"`
+	CryptoHardware                string                `xml:"crypto_hardware,omitempty"               json:"cryptoHardware,omitempty"                yaml:"cryptoHardware,omitempty"`
+	EnableSerial                  opnsense.BoolFlag     `xml:"enableserial,omitempty"                  json:"enableSerial"                            yaml:"enableSerial,omitempty"`
+	SerialSpeed                   string                `xml:"serialspeed,omitempty"                   json:"serialSpeed,omitempty"                   yaml:"serialSpeed,omitempty"`
+	PrimaryConsole                string                `xml:"primaryconsole,omitempty"                json:"primaryConsole,omitempty"                yaml:"primaryConsole,omitempty"`
+	AlreadyRunConfigUpgrade       opnsense.BoolFlag     `xml:"already_run_config_upgrade,omitempty"    json:"alreadyRunConfigUpgrade"                 yaml:"alreadyRunConfigUpgrade,omitempty"`
+	NextUID                       int                   `xml:"nextuid"                                 json:"nextUid,omitempty"                       yaml:"nextUid,omitempty"`
+	NextGID                       int                   `xml:"nextgid"                                 json:"nextGid,omitempty"                       yaml:"nextGid,omitempty"`
+	PowerdACMode                  string                `xml:"powerd_ac_mode"                          json:"powerdAcMode,omitempty"                  yaml:"powerdAcMode,omitempty"`
+	PowerdBatteryMode             string                `xml:"powerd_battery_mode"                     json:"powerdBatteryMode,omitempty"             yaml:"powerdBatteryMode,omitempty"`
+	PowerdNormalMode              string                `xml:"powerd_normal_mode"                      json:"powerdNormalMode,omitempty"              yaml:"powerdNormalMode,omitempty"`
 	Bogons                        struct {
 		Interval string `xml:"interval" json:"interval,omitempty" yaml:"interval,omitempty"`
 	} `xml:"bogons"                                  json:"bogons"                                  yaml:"bogons,omitempty"`
@@ -97,4 +100,12 @@ type WebGUI struct {
 	WebGUICSS         string            `xml:"webguicss,omitempty"         json:"webguiCss,omitempty"        yaml:"webguiCss,omitempty"`
 	LoginCSS          string            `xml:"logincss,omitempty"          json:"loginCss,omitempty"         yaml:"loginCss,omitempty"`
 	AltHostnames      string            `xml:"althostnames,omitempty"      json:"altHostnames,omitempty"     yaml:"altHostnames,omitempty"`
+	// AuthMode is the name of the authentication server the web GUI accepts
+	// logins from, e.g. "Local Database" or "corp-ldap".
+	AuthMode string `xml:"authmode,omitempty" json:"authMode,omitempty" yaml:"authMode,omitempty"`
+	// DisableHTTPRedirect stops the web GUI from listening on port 80 to
+	// redirect browsers to HTTPS.
+	DisableHTTPRedirect opnsense.BoolFlag `xml:"disablehttpredirect,omitempty" json:"disableHttpRedirect" yaml:"disableHttpRedirect,omitempty"`
+	// NoHTTPRefererCheck disables the GUI's HTTP_REFERER check.
+	NoHTTPRefererCheck opnsense.BoolFlag `xml:"nohttpreferercheck,omitempty" json:"noHttpRefererCheck" yaml:"noHttpRefererCheck,omitempty"`
 }
@@ -40,3 +40,65 @@ func TestWebGUI_PortRoundTrip(t *testing.T) {
 		t.Errorf("empty Port must be omitted, got: %s", emptyData)
 	}
 }
+
+// TestSystem_ConsoleRoundTrip verifies that the pfSense serial console speed
+// and primary console survive an XML round-trip and are omitted when unset.
+func TestSystem_ConsoleRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	in := System{Hostname: "fw", SerialSpeed: "115200", PrimaryConsole: "serial"}
+
+	data, err := xml.Marshal(in)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	for _, want := range []string{"<serialspeed>115200</serialspeed>", "<primaryconsole>serial</primaryconsole>"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("marshaled XML missing %s: %s", want, data)
+		}
+	}
+
+	var out System
+	if err := xml.Unmarshal(data, &out); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if out.SerialSpeed != "115200" || out.PrimaryConsole != "serial" {
+		t.Errorf("round-tripped console = (%q, %q), want (%q, %q)",
+			out.SerialSpeed, out.PrimaryConsole, "115200", "serial")
+	}
+
+	emptyData, err := xml.Marshal(System{Hostname: "fw"})
+	if err != nil {
+		t.Fatalf("marshal empty: %v", err)
+	}
+	if strings.Contains(string(emptyData), "<serialspeed>") || strings.Contains(string(emptyData), "<primaryconsole>") {
+		t.Errorf("empty console settings must be omitted, got: %s", emptyData)
+	}
+}
+
+// TestUser_ExpiresRoundTrip verifies that the pfSense <expires> date of a
+// <system><user> survives an XML round-trip.
+func TestUser_ExpiresRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	in := User{Name: "contractor", UID: "2002", Expires: "12/31/2099"}
+
+	data, err := xml.Marshal(struct {
+		XMLName xml.Name `xml:"user"`
+		User
+	}{User: in})
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if !strings.Contains(string(data), "<expires>12/31/2099</expires>") {
+		t.Errorf("marshaled XML missing <expires>12/31/2099</expires>: %s", data)
+	}
+
+	var out User
+	if err := xml.Unmarshal(data, &out); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if out.Expires != in.Expires {
+		t.Errorf("round-tripped Expires = %q, want %q", out.Expires, in.Expires)
+	}
+}
@@ -0,0 +1,91 @@
+package shared
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// pfSenseTimestampLayout is the "m/d/y H:M:S" layout pfSense and some editing
+// tools write into <created>/<updated> <time> elements, e.g.
+// "02/18/21 14:55:03". Single-digit months, days, and hours are accepted.
+const pfSenseTimestampLayout = "1/2/06 15:04:05"
+
+// ParseTimestamp parses a configuration timestamp, as found in <time>
+// elements, in any of the formats seen in the wild:
+//
+//   - Unix epoch seconds ("1700000000");
+//   - Unix epoch seconds with a fractional part ("1753586994.3946");
+//   - the pfSense "m/d/y H:M:S" format ("02/18/21 14:55:03"), read as UTC;
+//   - RFC 3339 ("2024-01-02T15:04:05Z").
+//
+// The result is in UTC. It reports false for an empty or unrecognized value,
+// so callers can treat the time as unknown rather than as the zero time.
+func ParseTimestamp(s string) (time.Time, bool) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, false
+	}
+
+	if isEpoch(s) {
+		secs, err := strconv.ParseFloat(s, 64)
+		if err != nil || math.IsInf(secs, 0) {
+			return time.Time{}, false
+		}
+
+		whole, frac := math.Modf(secs)
+
+		return time.Unix(int64(whole), int64(frac*float64(time.Second))).UTC(), true
+	}
+
+	for _, layout := range []string{pfSenseTimestampLayout, time.RFC3339} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.UTC(), true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// ExpiryDateLayout is the MM/DD/YYYY [time] layout OPNsense and pfSense
+// write into user <expires> elements, e.g. "12/31/2025".
+const ExpiryDateLayout = "01/02/2006"
+
+// ParseExpiryDate parses a user account expiry date in [ExpiryDateLayout].
+// The result is midnight UTC at the start of that day. It returns the zero
+// time and a nil error for an empty value, an account that never expires.
+func ParseExpiryDate(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, nil
+	}
+
+	t, err := time.Parse(ExpiryDateLayout, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parse expiry date %q: %w", s, err)
+	}
+
+	return t, nil
+}
+
+// isEpoch reports whether s is a run of digits with at most one decimal
+// point, the shape of an epoch timestamp. strconv.ParseFloat alone would
+// also accept forms like "1e9", "Inf", and "0x1p-2".
+func isEpoch(s string) bool {
+	whole, frac, _ := strings.Cut(s, ".")
+	if whole == "" {
+		return false
+	}
+
+	for _, part := range []string{whole, frac} {
+		for _, r := range part {
+			if r < '0' || r > '9' {
+				return false
+			}
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,87 @@
+package shared_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/EvilBit-Labs/opnDossier/pkg/schema/shared"
+)
+
+func TestParseTimestamp(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		input  string
+		want   time.Time
+		wantOK bool
+	}{
+		{"epoch seconds", "1700000000", time.Date(2023, 11, 14, 22, 13, 20, 0, time.UTC), true},
+		{"epoch with fraction", "1700000000.25", time.Date(2023, 11, 14, 22, 13, 20, 250000000, time.UTC), true},
+		{"epoch with surrounding whitespace", " 1700000000\n", time.Date(2023, 11, 14, 22, 13, 20, 0, time.UTC), true},
+		{"pfSense format", "02/18/21 14:55:03", time.Date(2021, 2, 18, 14, 55, 3, 0, time.UTC), true},
+		{"pfSense format without leading zeros", "2/8/21 9:05:03", time.Date(2021, 2, 8, 9, 5, 3, 0, time.UTC), true},
+		{"RFC 3339", "2024-01-02T15:04:05Z", time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC), true},
+		{"RFC 3339 with offset", "2024-01-02T17:04:05+02:00", time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC), true},
+		{"empty", "", time.Time{}, false},
+		{"whitespace", "   ", time.Time{}, false},
+		{"garbage", "yesterday", time.Time{}, false},
+		{"exponent", "1e9", time.Time{}, false},
+		{"infinity", "Inf", time.Time{}, false},
+		{"negative", "-5", time.Time{}, false},
+		{"two decimal points", "1700000000.1.2", time.Time{}, false},
+		{"fraction only", ".5", time.Time{}, false},
+		{"day first", "18/02/21 14:55:03", time.Time{}, false},
+		{"date only", "2024-01-02", time.Time{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := shared.ParseTimestamp(tt.input)
+			if ok != tt.wantOK {
+				t.Fatalf("ParseTimestamp(%q) ok = %v, want %v", tt.input, ok, tt.wantOK)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("ParseTimestamp(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+			if ok && got.Location() != time.UTC {
+				t.Errorf("ParseTimestamp(%q) location = %v, want UTC", tt.input, got.Location())
+			}
+		})
+	}
+}
+
+func TestParseExpiryDate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		input   string
+		want    time.Time
+		wantErr bool
+	}{
+		{"date", "03/15/2025", time.Date(2025, 3, 15, 0, 0, 0, 0, time.UTC), false},
+		{"surrounding whitespace", " 12/31/2099\n", time.Date(2099, 12, 31, 0, 0, 0, 0, time.UTC), false},
+		{"empty", "", time.Time{}, false},
+		{"whitespace", "   ", time.Time{}, false},
+		{"ISO date", "2025-03-15", time.Time{}, true},
+		{"day first", "15/03/2025", time.Time{}, true},
+		{"garbage", "someday", time.Time{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := shared.ParseExpiryDate(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseExpiryDate(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("ParseExpiryDate(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
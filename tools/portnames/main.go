@@ -0,0 +1,134 @@
+// Package main generates the well-known port lookup table used to annotate
+// port numbers in report tables from a checked-in CSV.
+//
+//go:build ignore
+
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"flag"
+	"fmt"
+	"go/format"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultInputFile  = "internal/converter/formatters/ports.csv"
+	defaultOutputFile = "internal/converter/formatters/ports_gen.go"
+	maxPort           = 65535
+)
+
+// portService is one row of the CSV.
+type portService struct {
+	port     int
+	protocol string
+	service  string
+}
+
+func main() {
+	inputFile := flag.String("input", defaultInputFile, "CSV file with port,protocol,service rows")
+	outputFile := flag.String("output", defaultOutputFile, "Output Go file path")
+	flag.Parse()
+
+	in, err := os.Open(*inputFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error opening %s: %v\n", *inputFile, err)
+		os.Exit(1)
+	}
+	defer in.Close()
+
+	services, err := readServices(in)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading %s: %v\n", *inputFile, err)
+		os.Exit(1)
+	}
+
+	content, err := generate(services)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error generating code: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := os.WriteFile(*outputFile, content, 0o644); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing file %s: %v\n", *outputFile, err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Generated port names: %s (%d entries)\n", *outputFile, len(services))
+}
+
+// readServices parses and validates the CSV rows, skipping the header and
+// '#' comment lines, and returns them sorted by port then protocol.
+func readServices(r io.Reader) ([]portService, error) {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = 3
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 || !slices.Equal(records[0], []string{"port", "protocol", "service"}) {
+		return nil, errors.New(`missing "port,protocol,service" header`)
+	}
+
+	seen := make(map[string]bool, len(records))
+	services := make([]portService, 0, len(records)-1)
+	for _, rec := range records[1:] {
+		port, err := strconv.Atoi(rec[0])
+		if err != nil || port < 1 || port > maxPort {
+			return nil, fmt.Errorf("invalid port %q", rec[0])
+		}
+
+		protocol := rec[1]
+		if protocol != "tcp" && protocol != "udp" {
+			return nil, fmt.Errorf("port %d: protocol %q must be tcp or udp", port, protocol)
+		}
+
+		service := strings.TrimSpace(rec[2])
+		if service == "" {
+			return nil, fmt.Errorf("port %d/%s: empty service name", port, protocol)
+		}
+
+		key := rec[0] + "/" + protocol
+		if seen[key] {
+			return nil, fmt.Errorf("duplicate entry for %s", key)
+		}
+		seen[key] = true
+
+		services = append(services, portService{port: port, protocol: protocol, service: service})
+	}
+
+	slices.SortFunc(services, func(a, b portService) int {
+		if a.port != b.port {
+			return a.port - b.port
+		}
+		return strings.Compare(a.protocol, b.protocol)
+	})
+
+	return services, nil
+}
+
+// generate renders the gofmt-formatted Go source holding services.
+func generate(services []portService) ([]byte, error) {
+	var buf bytes.Buffer
+
+	buf.WriteString("// Code generated by go run tools/portnames/main.go; DO NOT EDIT.\n\n")
+	buf.WriteString("package formatters\n\n")
+	buf.WriteString("// portServices maps \"port/protocol\" to the IANA service name registered\n")
+	buf.WriteString("// for it. Generated from ports.csv.\n")
+	buf.WriteString("var portServices = map[string]string{\n")
+	for _, s := range services {
+		fmt.Fprintf(&buf, "\t%q: %q,\n", strconv.Itoa(s.port)+"/"+s.protocol, s.service)
+	}
+	buf.WriteString("}\n")
+
+	return format.Source(buf.Bytes())
+}